@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -247,6 +248,34 @@ func (c *Client) deleteRequest(relPath string, data interface{}, response interf
 	return nil
 }
 
+func (c *Client) putRequest(relPath string, data interface{}, response interface{}) error {
+	strData, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(
+		"PUT",
+		fmt.Sprintf("%s%s", c.BaseURL, relPath),
+		strings.NewReader(string(strData)),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if httpErr, err := c.sendRequest(req, response, true); httpErr != nil || err != nil {
+		if httpErr != nil {
+			return fmt.Errorf("%v", httpErr.Error)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 func (c *Client) sendRequest(req *http.Request, v interface{}, useCookie bool) (*types.ExternalError, error) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
@@ -274,6 +303,17 @@ func (c *Client) sendRequest(req *http.Request, v interface{}, useCookie bool) (
 		c.saveCookie(cookies[0])
 	}
 
+	// warnings are sent as "299 - <quoted message>", in the format used by the Kubernetes API
+	for _, warning := range res.Header.Values("Warning") {
+		if parts := strings.SplitN(warning, " ", 3); len(parts) == 3 {
+			if msg, err := strconv.Unquote(parts[2]); err == nil {
+				warning = msg
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
 		var errRes types.ExternalError
 		if err = json.NewDecoder(res.Body).Decode(&errRes); err == nil {
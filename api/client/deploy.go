@@ -108,6 +108,52 @@ func (c *Client) UpgradeRelease(
 	)
 }
 
+// TemplateUpgrade renders the values and manifest which an upgrade of a release would produce,
+// without upgrading the release
+func (c *Client) TemplateUpgrade(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.UpgradeReleaseRequest,
+) (*types.TemplateUpgradeResponse, error) {
+	resp := &types.TemplateUpgradeResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade/template",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DiffUpgrade returns the diff of the values and manifest of a release against the release which
+// an upgrade would produce, without upgrading the release
+func (c *Client) DiffUpgrade(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.UpgradeReleaseRequest,
+) (*types.DiffUpgradeResponse, error) {
+	resp := &types.DiffUpgradeResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade/diff",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // DeleteRelease deletes a Porter release
 func (c *Client) DeleteRelease(
 	ctx context.Context,
@@ -0,0 +1,16 @@
+/*
+Package client is the Go client for the Porter API. It has typed methods for projects, clusters,
+releases, env groups, preview environments, infrastructure and registries, and is used by the
+Porter CLI, so integrators can call the same endpoints without building HTTP requests by hand.
+
+A client authenticates with an API token, or with the cookie saved by "porter auth login":
+
+	c := client.NewClientWithToken("https://dashboard.getporter.dev/api", os.Getenv("PORTER_TOKEN"))
+
+	releases, err := c.ListReleases(ctx, projectID, clusterID, "default", &types.ListReleasesRequest{})
+
+Request and response types are defined in the api/types package. Methods which stream data, such
+as StreamLogs and StreamInfraOperationLogs, open a websocket to the API and call a function for each
+message they receive, until the stream ends or the context is cancelled.
+*/
+package client
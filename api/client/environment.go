@@ -22,6 +22,69 @@ func (c *Client) ListEnvironments(
 	return resp, err
 }
 
+// ListDeployments lists the preview deployments of a cluster, and the open pull requests which
+// can be deployed
+func (c *Client) ListDeployments(
+	ctx context.Context,
+	projID, clusterID uint,
+	req *types.ListDeploymentRequest,
+) (*types.ListDeploymentsResponse, error) {
+	resp := &types.ListDeploymentsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/deployments", projID, clusterID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// EnablePullRequest creates a preview deployment for an open pull request
+func (c *Client) EnablePullRequest(
+	ctx context.Context,
+	projID, clusterID uint,
+	req *types.PullRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/deployments/pull_request", projID, clusterID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// TriggerDeploymentWorkflow re-runs the GitHub workflow which deploys a preview deployment
+func (c *Client) TriggerDeploymentWorkflow(
+	ctx context.Context,
+	projID, clusterID, deploymentID uint,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/deployments/%d/trigger_workflow",
+			projID, clusterID, deploymentID,
+		),
+		nil, nil,
+	)
+}
+
+// ReenableDeployment re-deploys an inactive preview deployment
+func (c *Client) ReenableDeployment(
+	ctx context.Context,
+	projID, clusterID, deploymentID uint,
+) error {
+	return c.patchRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/deployments/%d/reenable",
+			projID, clusterID, deploymentID,
+		),
+		nil, nil,
+	)
+}
+
 func (c *Client) CreateDeployment(
 	ctx context.Context,
 	projID, gitInstallationID, clusterID uint,
@@ -138,6 +201,27 @@ func (c *Client) FinalizeDeploymentWithErrors(
 	return resp, err
 }
 
+// ReportCIStatus reports the status of a deployment's build from a CI system other than
+// GitHub Actions
+func (c *Client) ReportCIStatus(
+	ctx context.Context,
+	projID, clusterID, deploymentID uint,
+	req *types.ReportCIStatusRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/deployments/%d/ci_status",
+			projID, clusterID, deploymentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) DeleteDeployment(
 	ctx context.Context,
 	projID, clusterID, deploymentID uint,
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ExecStreams are the streams of a command run in a container
+type ExecStreams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// the sizes of the local terminal, which are sent to the command's TTY when it is resized
+	Resize <-chan types.ExecResize
+}
+
+// ExecPod runs a command in a container of a pod, and returns the command's status once it exits
+func (c *Client) ExecPod(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, podName string,
+	req *types.ExecRequest,
+	streams *ExecStreams,
+) (*types.ExecStatus, error) {
+	query := url.Values{}
+	query["command"] = req.Command
+
+	if req.Container != "" {
+		query.Set("container_name", req.Container)
+	}
+
+	if req.TTY {
+		query.Set("tty", "true")
+	}
+
+	conn, err := c.dialWebsocket(
+		ctx,
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/pod/%s/exec",
+			projectID, clusterID,
+			namespace, podName,
+		),
+		query,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+
+	write := func(channel byte, data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+
+		return conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, data...))
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	if streams.Stdin != nil {
+		go func() {
+			buf := make([]byte, 32*1024)
+
+			for {
+				n, err := streams.Stdin.Read(buf)
+
+				if n > 0 {
+					if write(types.ExecChannelStdin, buf[:n]) != nil {
+						return
+					}
+				}
+
+				if err != nil {
+					write(types.ExecChannelStdinClose, nil)
+					return
+				}
+			}
+		}()
+	}
+
+	if streams.Resize != nil {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case size, ok := <-streams.Resize:
+					if !ok {
+						return
+					}
+
+					if data, err := json.Marshal(size); err == nil && write(types.ExecChannelResize, data) != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			return nil, fmt.Errorf("the connection was closed before the command exited: %w", err)
+		}
+
+		if len(msg) == 0 {
+			continue
+		}
+
+		switch msg[0] {
+		case types.ExecChannelStdout:
+			streams.Stdout.Write(msg[1:])
+		case types.ExecChannelStderr:
+			if streams.Stderr != nil {
+				streams.Stderr.Write(msg[1:])
+			}
+		case types.ExecChannelStatus:
+			status := &types.ExecStatus{}
+
+			if err := json.Unmarshal(msg[1:], status); err != nil {
+				return nil, fmt.Errorf("invalid command status: %w", err)
+			}
+
+			return status, nil
+		default:
+			// errors which occur before the command is run are sent as JSON messages
+			errRes := &types.ExternalError{}
+
+			if err := json.Unmarshal(msg, errRes); err == nil && errRes.Error != "" {
+				return nil, fmt.Errorf("%s", errRes.Error)
+			}
+		}
+	}
+}
+
+// CreateDebugContainer injects an ephemeral debug container into a running pod
+func (c *Client) CreateDebugContainer(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, podName string,
+	req *types.CreateDebugContainerRequest,
+) (*types.DebugContainer, error) {
+	resp := &types.DebugContainer{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/pod/%s/debug_containers",
+			projectID, clusterID,
+			namespace, podName,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// AttachDebugContainer attaches to the TTY of a debug container until the session ends
+func (c *Client) AttachDebugContainer(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, podName, name string,
+	stdin io.Reader,
+	stdout io.Writer,
+) error {
+	conn, err := c.dialWebsocket(
+		ctx,
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/pod/%s/debug_containers/%s/attach",
+			projectID, clusterID,
+			namespace, podName, name,
+		),
+		nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	go func() {
+		buf := make([]byte, 32*1024)
+
+		for {
+			n, err := stdin.Read(buf)
+
+			if n > 0 {
+				if conn.WriteMessage(websocket.BinaryMessage, buf[:n]) != nil {
+					return
+				}
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+
+		// the server closes the connection once the session ends
+		if err != nil {
+			return nil
+		}
+
+		stdout.Write(msg)
+	}
+}
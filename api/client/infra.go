@@ -0,0 +1,314 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/api/types"
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+// ListInfra lists the infrastructure provisioned in a project
+func (c *Client) ListInfra(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListInfraRequest,
+) (*types.ListProjectInfraResponse, error) {
+	resp := &types.ListProjectInfraResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infra", projectID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetInfra gets an infrastructure, including the latest operation which was run against it
+func (c *Client) GetInfra(
+	ctx context.Context,
+	projectID, infraID uint,
+) (*types.Infra, error) {
+	resp := &types.Infra{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/%d", projectID, infraID),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateInfra starts provisioning an infrastructure, and returns the operation which provisions it
+func (c *Client) CreateInfra(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateInfraRequest,
+) (*types.Operation, error) {
+	resp := &types.Operation{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/infras", projectID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RetryCreateInfra retries provisioning an infrastructure which failed to be created
+func (c *Client) RetryCreateInfra(
+	ctx context.Context,
+	projectID, infraID uint,
+	req *types.RetryInfraRequest,
+) (*types.Operation, error) {
+	resp := &types.Operation{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/retry_create", projectID, infraID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateInfra applies new values to an infrastructure
+func (c *Client) UpdateInfra(
+	ctx context.Context,
+	projectID, infraID uint,
+	req *types.RetryInfraRequest,
+) (*types.Operation, error) {
+	resp := &types.Operation{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/update", projectID, infraID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteInfra starts destroying an infrastructure, and returns the operation which destroys it
+func (c *Client) DeleteInfra(
+	ctx context.Context,
+	projectID, infraID uint,
+	req *types.DeleteInfraRequest,
+) (*types.Operation, error) {
+	resp := &types.Operation{}
+
+	err := c.deleteRequest(
+		fmt.Sprintf("/projects/%d/infras/%d", projectID, infraID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RetryDeleteInfra retries destroying an infrastructure which failed to be deleted
+func (c *Client) RetryDeleteInfra(
+	ctx context.Context,
+	projectID, infraID uint,
+	req *types.DeleteInfraRequest,
+) (*types.Operation, error) {
+	resp := &types.Operation{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/retry_delete", projectID, infraID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetInfraState gets the last known state of the resources of an infrastructure
+func (c *Client) GetInfraState(
+	ctx context.Context,
+	projectID, infraID uint,
+) (*ptypes.TFState, error) {
+	resp := &ptypes.TFState{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/state", projectID, infraID),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListInfraTemplates lists the kinds of infrastructure which can be provisioned
+func (c *Client) ListInfraTemplates(
+	ctx context.Context,
+	projectID uint,
+) ([]types.InfraTemplateMeta, error) {
+	resp := make([]types.InfraTemplateMeta, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/templates", projectID),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// GetInfraTemplate gets the form which configures a kind of infrastructure
+func (c *Client) GetInfraTemplate(
+	ctx context.Context,
+	projectID uint,
+	name, version string,
+) (*types.InfraTemplate, error) {
+	resp := &types.InfraTemplate{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/templates/%s/%s", projectID, name, version),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListInfraOperations lists the operations which were run against an infrastructure
+func (c *Client) ListInfraOperations(
+	ctx context.Context,
+	projectID, infraID uint,
+) ([]*types.OperationMeta, error) {
+	resp := make([]*types.OperationMeta, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/operations", projectID, infraID),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// GetInfraOperation gets an operation which was run against an infrastructure
+func (c *Client) GetInfraOperation(
+	ctx context.Context,
+	projectID, infraID uint,
+	operationID string,
+) (*types.Operation, error) {
+	resp := &types.Operation{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/operations/%s", projectID, infraID, operationID),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetInfraOperationLogs gets the logs of an operation which has finished
+func (c *Client) GetInfraOperationLogs(
+	ctx context.Context,
+	projectID, infraID uint,
+	operationID string,
+) (*ptypes.GetLogsResponse, error) {
+	resp := &ptypes.GetLogsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/infras/%d/operations/%s/logs", projectID, infraID, operationID),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// StreamInfraOperationLogs streams the provisioning logs of an operation which is running, calling
+// onLog for each message until the operation finishes or the context is cancelled
+func (c *Client) StreamInfraOperationLogs(
+	ctx context.Context,
+	projectID, infraID uint,
+	operationID string,
+	onLog func(log string),
+) error {
+	conn, err := c.dialWebsocket(
+		ctx,
+		fmt.Sprintf("/projects/%d/infras/%d/operations/%s/log_stream", projectID, infraID, operationID),
+		nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+
+		if err != nil {
+			if ctx.Err() != nil || isStreamEnd(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		onLog(string(msg))
+	}
+}
+
+// StreamInfraOperationState streams the changes to the state of the resources managed by an
+// operation which is running, calling onUpdate for each change until the operation finishes or
+// the context is cancelled
+func (c *Client) StreamInfraOperationState(
+	ctx context.Context,
+	projectID, infraID uint,
+	operationID string,
+	onUpdate func(update *types.InfraStateUpdate),
+) error {
+	conn, err := c.dialWebsocket(
+		ctx,
+		fmt.Sprintf("/projects/%d/infras/%d/operations/%s/state", projectID, infraID, operationID),
+		nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for {
+		update := &types.InfraStateUpdate{}
+
+		if err := conn.ReadJSON(update); err != nil {
+			if ctx.Err() != nil || isStreamEnd(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		onUpdate(update)
+	}
+}
+
+// isStreamEnd returns whether a websocket read failed because the server closed the stream once
+// there was nothing left to send
+func isStreamEnd(err error) bool {
+	return websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure)
+}
@@ -27,6 +27,27 @@ func (c *Client) CreateAWSIntegration(
 	return resp, err
 }
 
+// CreateDopplerIntegration creates a Doppler integration, whose configs can be synced into
+// env groups
+func (c *Client) CreateDopplerIntegration(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateDopplerIntegrationRequest,
+) (*types.DopplerIntegration, error) {
+	resp := &types.DopplerIntegration{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/doppler_integrations",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // CreateGCPIntegration creates a GCP integration with the given request options
 func (c *Client) CreateGCPIntegration(
 	ctx context.Context,
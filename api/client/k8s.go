@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/gorilla/websocket"
 	"github.com/porter-dev/porter/api/types"
 
 	v1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // GetK8sNamespaces gets a namespaces list in a k8s cluster
@@ -164,6 +169,388 @@ func (c *Client) CloneEnvGroup(
 	return resp, err
 }
 
+// PreviewEnvGroup resolves the templates of a new version of an env group without creating
+// the version
+func (c *Client) PreviewEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.PreviewEnvGroupRequest,
+) (*types.PreviewEnvGroupResponse, error) {
+	resp := &types.PreviewEnvGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/preview",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListEnvGroups lists the env groups in a namespace
+func (c *Client) ListEnvGroups(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+) (*types.ListEnvGroupsResponse, error) {
+	resp := &types.ListEnvGroupsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/list",
+			projectID, clusterID,
+			namespace,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListEnvGroupVersions lists the versions of an env group
+func (c *Client) ListEnvGroupVersions(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetEnvGroupAllRequest,
+) (*types.ListEnvGroupsResponse, error) {
+	resp := &types.ListEnvGroupsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/all_versions",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RollbackEnvGroup creates a new version of an env group from a previous version, and optionally
+// redeploys the applications linked to the env group
+func (c *Client) RollbackEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.RollbackEnvGroupRequest,
+) (*types.EnvGroup, error) {
+	resp := &types.EnvGroup{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/rollback",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// SealEnvGroup encrypts the variables of an env group with the public key of the cluster's
+// sealed-secrets controller, and returns the SealedSecret manifest
+func (c *Client) SealEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.SealEnvGroupRequest,
+) (*types.SealEnvGroupResponse, error) {
+	resp := &types.SealEnvGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/seal",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetEnvGroupSource returns the external secret which an env group is synced from
+func (c *Client) GetEnvGroupSource(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetEnvGroupSourceRequest,
+) (*types.EnvGroupSource, error) {
+	resp := &types.EnvGroupSource{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/source",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateEnvGroupSource syncs an env group from an external secret, after which the env group
+// can't be updated through the API
+func (c *Client) CreateEnvGroupSource(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.CreateEnvGroupSourceRequest,
+) (*types.EnvGroupSource, error) {
+	resp := &types.EnvGroupSource{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/source",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// SyncEnvGroupSource syncs an env group from its external secret without waiting for the next
+// periodic sync
+func (c *Client) SyncEnvGroupSource(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.SyncEnvGroupSourceRequest,
+) (*types.EnvGroupSource, error) {
+	resp := &types.EnvGroupSource{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/source/sync",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteEnvGroupSource stops syncing an env group from its external secret
+func (c *Client) DeleteEnvGroupSource(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.DeleteEnvGroupSourceRequest,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/source",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		nil,
+	)
+}
+
+// ListEnvGroupRotationPolicies returns the rotation policies of the secret variables of an env group
+func (c *Client) ListEnvGroupRotationPolicies(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.ListEnvGroupRotationPoliciesRequest,
+) (types.ListEnvGroupRotationPoliciesResponse, error) {
+	resp := types.ListEnvGroupRotationPoliciesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/rotation_policies",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// CreateEnvGroupRotationPolicy creates or updates the rotation policy of a secret variable of an
+// env group
+func (c *Client) CreateEnvGroupRotationPolicy(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.CreateEnvGroupRotationPolicyRequest,
+) (*types.EnvGroupRotationPolicy, error) {
+	resp := &types.EnvGroupRotationPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/rotation_policies",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RotateEnvGroupSecret rotates a secret variable of an env group right away
+func (c *Client) RotateEnvGroupSecret(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.RotateEnvGroupSecretRequest,
+) (*types.EnvGroupRotationPolicy, error) {
+	resp := &types.EnvGroupRotationPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/rotation_policies/rotate",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteEnvGroupRotationPolicy stops rotating a secret variable of an env group
+func (c *Client) DeleteEnvGroupRotationPolicy(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.DeleteEnvGroupRotationPolicyRequest,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/rotation_policies",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		nil,
+	)
+}
+
+// GetEnvGroupSecrets returns the unmasked values of the secret variables of an env group. Each
+// read is recorded in the audit logs of the project.
+func (c *Client) GetEnvGroupSecrets(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetEnvGroupSecretsRequest,
+) (*types.GetEnvGroupSecretsResponse, error) {
+	resp := &types.GetEnvGroupSecretsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/secrets",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListEnvGroupSecretAccess lists the reads of the secret values of an env group
+func (c *Client) ListEnvGroupSecretAccess(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.ListEnvGroupSecretAccessRequest,
+) (*types.ListAuditLogsResponse, error) {
+	resp := &types.ListAuditLogsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/secret_access",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteEnvGroup deletes an env group
+func (c *Client) DeleteEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.DeleteEnvGroupRequest,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		nil,
+	)
+}
+
+// AddEnvGroupApplication syncs an env group to an application
+func (c *Client) AddEnvGroupApplication(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.AddEnvGroupApplicationRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/add_application",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		nil,
+	)
+}
+
+// RemoveEnvGroupApplication stops syncing an env group to an application
+func (c *Client) RemoveEnvGroupApplication(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.AddEnvGroupApplicationRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/remove_application",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		nil,
+	)
+}
+
 func (c *Client) GetRelease(
 	ctx context.Context,
 	projectID, clusterID uint,
@@ -206,6 +593,66 @@ func (c *Client) GetJobs(
 	return *resp, err
 }
 
+// RunJob runs a one-off job from the pod template of a release
+func (c *Client) RunJob(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.RunJobRequest,
+) (*types.RunJobResponse, error) {
+	resp := &types.RunJobResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/run_job",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetJobPods gets the pods of a job
+func (c *Client) GetJobPods(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]corev1.Pod, error) {
+	resp := make([]corev1.Pod, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/jobs/%s/pods",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// DeleteJob deletes a job and its pods
+func (c *Client) DeleteJob(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/jobs/%s",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		nil,
+	)
+}
+
 // GetK8sAllPods gets all pods for a given release
 func (c *Client) GetK8sAllPods(
 	ctx context.Context,
@@ -226,3 +673,79 @@ func (c *Client) GetK8sAllPods(
 
 	return resp, err
 }
+
+// StreamLogs streams the aggregated logs of the pods which match a selector, calling onLine for each
+// line until the stream ends or the context is cancelled
+func (c *Client) StreamLogs(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.StreamLogsRequest,
+	onLine func(line *types.PodLogLine),
+) error {
+	query := url.Values{}
+	query.Set("selector", req.Selector)
+
+	if req.Container != "" {
+		query.Set("container_name", req.Container)
+	}
+
+	if req.Follow {
+		query.Set("follow", "true")
+	}
+
+	if req.SinceTime != nil {
+		query.Set("since_time", req.SinceTime.Format(time.RFC3339Nano))
+	}
+
+	if req.SinceSeconds > 0 {
+		query.Set("since_seconds", strconv.FormatInt(req.SinceSeconds, 10))
+	}
+
+	if req.TailLines > 0 {
+		query.Set("tail_lines", strconv.FormatInt(req.TailLines, 10))
+	}
+
+	conn, err := c.dialWebsocket(
+		ctx,
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/logs/stream",
+			projectID, clusterID,
+			namespace,
+		),
+		query,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for {
+		// errors which occur after the connection is opened are sent as messages
+		msg := &struct {
+			types.PodLogLine
+			Error string `json:"error"`
+		}{}
+
+		if err := conn.ReadJSON(msg); err != nil {
+			if ctx.Err() != nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+
+			return err
+		}
+
+		if msg.Error != "" {
+			return fmt.Errorf("%s", msg.Error)
+		}
+
+		onLine(&msg.PodLogLine)
+	}
+}
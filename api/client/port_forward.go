@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// PortForwardPod forwards a single connection to a port of a pod, copying data between conn and
+// the port until either side closes the connection
+func (c *Client) PortForwardPod(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, podName string,
+	port uint16,
+	conn io.ReadWriter,
+) error {
+	query := url.Values{}
+	query.Set("port", strconv.Itoa(int(port)))
+
+	wsConn, err := c.dialWebsocket(
+		ctx,
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/pod/%s/portforward",
+			projectID, clusterID,
+			namespace, podName,
+		),
+		query,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer wsConn.Close()
+
+	go func() {
+		buf := make([]byte, 32*1024)
+
+		for {
+			n, err := conn.Read(buf)
+
+			if n > 0 {
+				if wsConn.WriteMessage(websocket.BinaryMessage, buf[:n]) != nil {
+					return
+				}
+			}
+
+			if err != nil {
+				// closing the websocket connection closes the connection to the pod's port
+				wsConn.WriteMessage(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				)
+
+				return
+			}
+		}
+	}()
+
+	for {
+		msgType, msg, err := wsConn.ReadMessage()
+
+		if err != nil {
+			// the server closes the connection once the pod closes the connection to its port
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) || ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		// data is sent as binary messages, and errors which occur before the connection to the
+		// port is made are sent as JSON messages
+		if msgType == websocket.TextMessage {
+			errRes := &types.ExternalError{}
+
+			if err := json.Unmarshal(msg, errRes); err == nil && errRes.Error != "" {
+				return fmt.Errorf("%s", errRes.Error)
+			}
+
+			continue
+		}
+
+		if _, err := conn.Write(msg); err != nil {
+			return err
+		}
+	}
+}
@@ -28,3 +28,42 @@ func (c *Client) ListReleases(
 
 	return resp, err
 }
+
+// GetReleaseHistory lists the revisions of a release
+func (c *Client) GetReleaseHistory(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]*release.Release, error) {
+	resp := make([]*release.Release, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/history",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// RollbackRelease rolls a release back to a previous revision
+func (c *Client) RollbackRelease(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.RollbackReleaseRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/rollback",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		nil,
+	)
+}
@@ -61,3 +61,46 @@ func (c *Client) RemoveEnvGroupFromStack(
 
 	return err
 }
+
+// CreateStack creates a stack and deploys its first revision
+func (c *Client) CreateStack(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.CreateStackRequest,
+) (*types.Stack, error) {
+	resp := &types.Stack{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/v1/projects/%d/clusters/%d/namespaces/%s/stacks",
+			projectID, clusterID, namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// PutStackSpec replaces the applications, source configs and env groups of a stack, and deploys
+// them as a new revision
+func (c *Client) PutStackSpec(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, stackID string,
+	req *types.PutStackSpecRequest,
+) (*types.Stack, error) {
+	resp := &types.Stack{}
+
+	err := c.putRequest(
+		fmt.Sprintf(
+			"/v1/projects/%d/clusters/%d/namespaces/%s/stacks/%s/spec",
+			projectID, clusterID, namespace, stackID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
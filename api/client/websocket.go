@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// dialWebsocket opens a websocket connection to an endpoint of the Porter API, authenticating the
+// same way as the other requests made by the client
+func (c *Client) dialWebsocket(ctx context.Context, relPath string, query url.Values) (*websocket.Conn, error) {
+	wsURL, err := url.Parse(fmt.Sprintf("%s%s", c.BaseURL, relPath))
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the server only accepts websocket connections which originate from the server URL
+	origin := &url.URL{
+		Scheme: wsURL.Scheme,
+		Host:   wsURL.Host,
+	}
+
+	switch wsURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+	default:
+		wsURL.Scheme = "ws"
+	}
+
+	if query != nil {
+		wsURL.RawQuery = query.Encode()
+	}
+
+	header := http.Header{}
+	header.Set("Origin", origin.String())
+
+	if c.Token != "" {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	} else if cookie, _ := c.getCookie(); cookie != nil {
+		c.Cookie = cookie
+		header.Set("Cookie", cookie.String())
+	}
+
+	if c.cfToken != "" {
+		header.Set("cf-access-token", c.cfToken)
+	}
+
+	conn, res, err := websocket.DefaultDialer.DialContext(ctx, wsURL.String(), header)
+
+	if err != nil {
+		if res != nil {
+			defer res.Body.Close()
+
+			var errRes types.ExternalError
+
+			if decodeErr := json.NewDecoder(res.Body).Decode(&errRes); decodeErr == nil && strings.TrimSpace(errRes.Error) != "" {
+				return nil, fmt.Errorf("%s", errRes.Error)
+			}
+
+			return nil, fmt.Errorf("could not open websocket, status code: %d", res.StatusCode)
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
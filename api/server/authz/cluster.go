@@ -115,7 +115,7 @@ func (d *OutOfClusterAgentGetter) GetAgent(r *http.Request, cluster *models.Clus
 	agent, err := kubernetes.GetAgentOutOfClusterConfig(ooc)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get agent: %s", err.Error())
+		return nil, fmt.Errorf("failed to get agent: %w", err)
 	}
 
 	newCtx := context.WithValue(r.Context(), KubernetesAgentCtxKey, agent)
@@ -152,6 +152,8 @@ func (d *OutOfClusterAgentGetter) GetHelmAgent(r *http.Request, cluster *models.
 		return nil, fmt.Errorf("failed to get Helm agent: %s", err.Error())
 	}
 
+	helmAgent = helmAgent.WithContext(r.Context())
+
 	newCtx := context.WithValue(r.Context(), HelmAgentCtxKey, helmAgent)
 
 	r = r.WithContext(newCtx)
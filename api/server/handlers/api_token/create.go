@@ -9,6 +9,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/auth/token"
@@ -99,6 +100,13 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	commonutils.RecordAccessChange(p.Config(), proj.ID, user.ID, types.AuditLogActionAPITokenCreate, "APIToken",
+		apiToken.Name, map[string]interface{}{
+			"token_id":   apiToken.UniqueID,
+			"policy_uid": apiToken.PolicyUID,
+			"expires_at": apiToken.Expiry,
+		})
+
 	// generate porter jwt token
 	jwt, err := token.GetStoredTokenForAPI(user.ID, proj.ID, apiToken.UniqueID, secretKey)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
@@ -30,6 +31,7 @@ func NewAPITokenRevokeHandler(
 }
 
 func (p *APITokenRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
 	if !proj.APITokensEnabled {
@@ -69,5 +71,10 @@ func (p *APITokenRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	commonutils.RecordAccessChange(p.Config(), proj.ID, user.ID, types.AuditLogActionAPITokenRevoke, "APIToken",
+		token.Name, map[string]interface{}{
+			"token_id": token.UniqueID,
+		})
+
 	p.WriteResult(w, r, token.ToAPITokenMetaType())
 }
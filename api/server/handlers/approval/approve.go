@@ -0,0 +1,72 @@
+package approval
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ApprovalApproveHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewApprovalApproveHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApprovalApproveHandler {
+	return &ApprovalApproveHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP approves a pending upgrade and executes it. The result of the upgrade is recorded
+// on the approval.
+func (p *ApprovalApproveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ReviewApprovalRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	approval, ok := readReviewableApproval(p, w, r, project.ID, user)
+
+	if !ok {
+		return
+	}
+
+	cluster, err := p.Repo().Cluster().ReadCluster(project.ID, approval.ClusterID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// an upgrade which is approved during a freeze window stays pending, unless the approver
+	// overrides the window
+	if apiErr := commonutils.CheckFreezeWindows(
+		p.Config(), cluster, approval.Namespace, approval.Name, user, request.FreezeOverride,
+	); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	approval, apiErr := release.ExecuteApproval(p.Config(), approval, user, request.Comment)
+
+	if apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	p.WriteResult(w, r, approval.ToApprovalType())
+}
@@ -0,0 +1,93 @@
+package approval
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type ApprovalPolicyCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewApprovalPolicyCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApprovalPolicyCreateHandler {
+	return &ApprovalPolicyCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ApprovalPolicyCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateApprovalPolicyRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if _, err := p.Repo().Cluster().ReadCluster(project.ID, request.ClusterID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("cluster %d not found", request.ClusterID),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// approvers must be members of the project
+	roles, err := p.Repo().Project().ListProjectRoles(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	members := make(map[uint]bool)
+
+	for _, role := range roles {
+		members[role.UserID] = true
+	}
+
+	for _, id := range request.ApproverIDs {
+		if !members[id] {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("user %d is not a member of the project", id),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	policy, err := p.Repo().Approval().CreateApprovalPolicy(&models.ApprovalPolicy{
+		ProjectID:   project.ID,
+		ClusterID:   request.ClusterID,
+		Namespaces:  strings.Join(request.Namespaces, ","),
+		ApproverIDs: models.FormatIDs(request.ApproverIDs),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, policy.ToApprovalPolicyType())
+}
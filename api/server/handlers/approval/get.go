@@ -0,0 +1,36 @@
+package approval
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ApprovalGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewApprovalGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ApprovalGetHandler {
+	return &ApprovalGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ApprovalGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	approval, ok := readApproval(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	p.WriteResult(w, r, approval.ToApprovalType())
+}
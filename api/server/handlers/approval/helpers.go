@@ -0,0 +1,79 @@
+package approval
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// readApproval reads the approval in the URL params of a request, and writes an error and
+// returns false if it cannot be read
+func readApproval(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+) (*models.Approval, bool) {
+	approvalID, reqErr := requestutils.GetURLParamUint(r, types.URLParamApprovalID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	approval, err := p.Repo().Approval().ReadApproval(projectID, approvalID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("approval not found")))
+			return nil, false
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return approval, true
+}
+
+// readReviewableApproval reads the approval in the URL params of a request, and writes an
+// error and returns false if the approval is not pending or the user cannot review it
+func readReviewableApproval(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+	user *models.User,
+) (*models.Approval, bool) {
+	approval, ok := readApproval(p, w, r, projectID)
+
+	if !ok {
+		return nil, false
+	}
+
+	if approval.Status != types.ApprovalStatusPending {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("approval %d has already been %s", approval.ID, approval.Status),
+			http.StatusConflict,
+		))
+
+		return nil, false
+	}
+
+	if !approval.CanReview(user.ID) {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not an approver of approval %d", user.ID, approval.ID),
+		))
+
+		return nil, false
+	}
+
+	return approval, true
+}
@@ -0,0 +1,44 @@
+package approval
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ApprovalPolicyListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewApprovalPolicyListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ApprovalPolicyListHandler {
+	return &ApprovalPolicyListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ApprovalPolicyListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	policies, err := p.Repo().Approval().ListApprovalPolicies(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListApprovalPoliciesResponse, 0, len(policies))
+
+	for _, policy := range policies {
+		res = append(res, policy.ToApprovalPolicyType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,60 @@
+package approval
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ApprovalRejectHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewApprovalRejectHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApprovalRejectHandler {
+	return &ApprovalRejectHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ApprovalRejectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ReviewApprovalRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	approval, ok := readReviewableApproval(p, w, r, project.ID, user)
+
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	approval.Status = types.ApprovalStatusRejected
+	approval.ReviewedByUserID = user.ID
+	approval.ReviewedAt = &now
+	approval.Comment = request.Comment
+
+	approval, err := p.Repo().Approval().UpdateApproval(approval)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, approval.ToApprovalType())
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/nodes"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CordonNodeHandler marks a node as unschedulable (cordon) or schedulable (uncordon)
+type CordonNodeHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+
+	unschedulable bool
+}
+
+func NewCordonNodeHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CordonNodeHandler {
+	return &CordonNodeHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+		unschedulable:         true,
+	}
+}
+
+func NewUncordonNodeHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CordonNodeHandler {
+	return &CordonNodeHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+		unschedulable:         false,
+	}
+}
+
+func (c *CordonNodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamNodeName)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	_, err = nodes.SetUnschedulable(agent.Clientset, name, c.unschedulable)
+
+	if errors.Is(err, nodes.ErrNodeNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("node %s was not found", name)))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, nodes.DescribeNode(agent.Clientset, name))
+}
@@ -62,6 +62,19 @@ func (c *CreateNamespaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// link the project's registries to the new namespace, so that images can be pulled without
+	// linking each registry to the namespace manually. Failures don't fail the request, since
+	// pull secrets are also created when releases are deployed.
+	if !c.Config().ServerConf.DisablePullSecretsInjection {
+		registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		} else if _, err := agent.PropagateImagePullSecrets(c.Repo(), namespace.Name, registries, c.Config().DOConf); err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
 	res := &types.NamespaceResponse{
 		Name:              namespace.Name,
 		CreationTimestamp: namespace.CreationTimestamp.Time.UTC().Format(time.RFC1123),
@@ -0,0 +1,78 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type CreateRelayHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCreateRelayHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CreateRelayHandler {
+	return &CreateRelayHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP creates the relay of a cluster and returns the token of its in-cluster agent. If
+// the cluster already has a relay, its token is rotated.
+func (c *CreateRelayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	secret, err := encryption.GenerateRandomBytes(32)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	tokenHash, err := bcrypt.GenerateFromPassword([]byte(secret), 8)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	relay, err := c.Repo().ClusterRelay().ReadClusterRelay(cluster.ID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err != nil {
+		relay, err = c.Repo().ClusterRelay().CreateClusterRelay(&models.ClusterRelay{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			TokenHash: string(tokenHash),
+		})
+	} else {
+		relay.TokenHash = string(tokenHash)
+		relay, err = c.Repo().ClusterRelay().UpdateClusterRelay(relay)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.CreateClusterRelayResponse{
+		Relay: relay.ToClusterRelayType(),
+		Token: fmt.Sprintf("%d.%s", cluster.ID, secret),
+	})
+}
@@ -0,0 +1,121 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/tunnel"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type CreateTunnelHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCreateTunnelHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CreateTunnelHandler {
+	return &CreateTunnelHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP enables the reverse tunnel of a cluster and returns the token of its tunnel agent.
+// If the cluster already has a tunnel, its token is rotated and the connected agent is
+// disconnected.
+func (c *CreateTunnelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agentTarget, err := getTunnelAgentTarget(cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	secret, err := encryption.GenerateRandomBytes(32)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	tokenHash, err := bcrypt.GenerateFromPassword([]byte(secret), 8)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	clusterTunnel, err := c.Repo().ClusterTunnel().ReadClusterTunnel(cluster.ID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err != nil {
+		clusterTunnel, err = c.Repo().ClusterTunnel().CreateClusterTunnel(&models.ClusterTunnel{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			TokenHash: string(tokenHash),
+		})
+	} else {
+		clusterTunnel.TokenHash = string(tokenHash)
+		clusterTunnel, err = c.Repo().ClusterTunnel().UpdateClusterTunnel(clusterTunnel)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the agent which is connected with the previous token must reconnect with the new token
+	tunnel.DefaultRegistry.Disconnect(cluster.ID)
+
+	if !cluster.TunnelEnabled {
+		cluster.TunnelEnabled = true
+
+		if _, err := c.Repo().Cluster().UpdateCluster(cluster); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	c.WriteResult(w, r, &types.CreateClusterTunnelResponse{
+		Tunnel:      clusterTunnel.ToClusterTunnelType(false),
+		Token:       fmt.Sprintf("%d.%s", cluster.ID, secret),
+		AgentTarget: agentTarget,
+	})
+}
+
+// getTunnelAgentTarget returns the address of the server endpoint of a cluster, which its
+// tunnel agent opens connections to
+func getTunnelAgentTarget(cluster *models.Cluster) (string, error) {
+	serverURL, err := url.Parse(cluster.Server)
+
+	if err != nil || serverURL.Host == "" {
+		return "", fmt.Errorf("the cluster does not have a valid server endpoint")
+	}
+
+	if serverURL.Scheme != "https" {
+		return "", fmt.Errorf("tunnels require a cluster with an https server endpoint")
+	}
+
+	if serverURL.Port() == "" {
+		return serverURL.Host + ":443", nil
+	}
+
+	return serverURL.Host, nil
+}
@@ -0,0 +1,178 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/certificates"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CreateWildcardCertificateHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateWildcardCertificateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateWildcardCertificateHandler {
+	return &CreateWildcardCertificateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP requests a wildcard certificate from cert-manager, which solves its DNS-01
+// challenges with a DNS provider integration of the project. The certificate is copied to the
+// namespaces of the cluster in the background once it's issued.
+func (c *CreateWildcardCertificateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.CreateWildcardCertificateRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	domain := strings.TrimPrefix(strings.ToLower(strings.TrimSuffix(request.Domain, ".")), "*.")
+
+	if _, err := c.Repo().WildcardCertificate().ReadWildcardCertificateByDomain(cluster.ID, domain); err == nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a wildcard certificate for %s already exists in this cluster", domain),
+			http.StatusConflict,
+		))
+
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	opts, apiErr := c.getOpts(cluster.ProjectID, request.DNSProviderIntegrationID)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	opts.Domain = domain
+	opts.Email = request.Email
+
+	if opts.Email == "" {
+		opts.Email = user.Email
+	}
+
+	cert, err := c.Repo().WildcardCertificate().CreateWildcardCertificate(&models.WildcardCertificate{
+		ProjectID:                cluster.ProjectID,
+		ClusterID:                cluster.ID,
+		Domain:                   domain,
+		DNSProviderIntegrationID: request.DNSProviderIntegrationID,
+		Namespaces:               strings.Join(request.Namespaces, ","),
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	opts.Name = cert.ResourceName()
+	opts.ID = strconv.FormatUint(uint64(cert.ID), 10)
+
+	if err := c.apply(r, cluster, opts); err != nil {
+		// the certificate is removed, so that it can be requested again
+		if delErr := c.Repo().WildcardCertificate().DeleteWildcardCertificate(cert); delErr != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(delErr))
+			return
+		}
+
+		if errors.Is(err, certificates.ErrCertManagerNotInstalled) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusPreconditionFailed))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.WriteResult(w, r, cert.ToWildcardCertificateType())
+}
+
+// getOpts returns the provider and the credentials of a wildcard certificate, which are read
+// from a DNS provider integration of the project
+func (c *CreateWildcardCertificateHandler) getOpts(
+	projectID, integrationID uint,
+) (*certificates.WildcardCertificateOpts, apierrors.RequestError) {
+	dnsInt, err := c.Repo().DNSProviderIntegration().ReadDNSProviderIntegration(projectID, integrationID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("dns provider integration %d not found", integrationID),
+				http.StatusNotFound,
+			)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	opts := &certificates.WildcardCertificateOpts{
+		Provider:           dnsInt.Provider,
+		CloudflareAPIToken: string(dnsInt.CloudflareAPIToken),
+	}
+
+	if dnsInt.Provider == types.DNSProviderRoute53 {
+		awsInt, err := c.Repo().AWSIntegration().ReadAWSIntegration(projectID, dnsInt.AWSIntegrationID)
+
+		if err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+
+		// cert-manager reads a static access key from a secret, so temporary credentials would
+		// expire before the certificate is renewed
+		if len(awsInt.AWSSessionToken) > 0 {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("the aws integration of a route53 wildcard certificate must use an access key without a session token"),
+				http.StatusBadRequest,
+			)
+		}
+
+		opts.AWSAccessKeyID = string(awsInt.AWSAccessKeyID)
+		opts.AWSSecretAccessKey = string(awsInt.AWSSecretAccessKey)
+	}
+
+	return opts, nil
+}
+
+func (c *CreateWildcardCertificateHandler) apply(
+	r *http.Request,
+	cluster *models.Cluster,
+	opts *certificates.WildcardCertificateOpts,
+) error {
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return err
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		return err
+	}
+
+	return certificates.ApplyWildcardCertificate(agent.Clientset, dynClient, opts)
+}
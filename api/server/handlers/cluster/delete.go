@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
 type ClusterDeleteHandler struct {
@@ -30,7 +31,11 @@ func NewClusterDeleteHandler(
 func (c *ClusterDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
-	err := c.Repo().Cluster().DeleteCluster(cluster)
+	// the cluster and its token cache are deleted in a transaction, so that the cached
+	// cluster is only invalidated once the delete is committed
+	err := c.Repo().Transaction(func(tx repository.Repository) error {
+		return tx.Cluster().DeleteCluster(cluster)
+	})
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/tunnel"
+	"gorm.io/gorm"
+)
+
+type DeleteTunnelHandler struct {
+	handlers.PorterHandler
+}
+
+func NewDeleteTunnelHandler(
+	config *config.Config,
+) *DeleteTunnelHandler {
+	return &DeleteTunnelHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+// ServeHTTP disables the reverse tunnel of a cluster, so that the cluster is connected through
+// its server endpoint again
+func (c *DeleteTunnelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	clusterTunnel, err := c.Repo().ClusterTunnel().ReadClusterTunnel(cluster.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("cluster %d does not have a tunnel", cluster.ID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	cluster.TunnelEnabled = false
+
+	if _, err := c.Repo().Cluster().UpdateCluster(cluster); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := c.Repo().ClusterTunnel().DeleteClusterTunnel(clusterTunnel); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	tunnel.DefaultRegistry.Disconnect(cluster.ID)
+
+	w.WriteHeader(http.StatusOK)
+}
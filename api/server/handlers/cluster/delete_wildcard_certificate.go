@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/certificates"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DeleteWildcardCertificateHandler struct {
+	handlers.PorterHandler
+	authz.KubernetesAgentGetter
+}
+
+func NewDeleteWildcardCertificateHandler(
+	config *config.Config,
+) *DeleteWildcardCertificateHandler {
+	return &DeleteWildcardCertificateHandler{
+		PorterHandler:         handlers.NewDefaultPorterHandler(config, nil, nil),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP deletes a wildcard certificate along with its issuer and the secrets which it was
+// copied to
+func (c *DeleteWildcardCertificateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	cert, reqErr := readWildcardCertificate(c.Repo(), r, cluster)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = certificates.DeleteWildcardCertificate(
+		agent.Clientset,
+		dynClient,
+		cert.ResourceName(),
+		strconv.FormatUint(uint64(cert.ID), 10),
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := c.Repo().WildcardCertificate().DeleteWildcardCertificate(cert); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
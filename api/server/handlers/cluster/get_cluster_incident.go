@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetClusterIncidentHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetClusterIncidentHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetClusterIncidentHandler {
+	return &GetClusterIncidentHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetClusterIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	incidentID, reqErr := requestutils.GetURLParamUint(r, types.URLParamClusterIncidentID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	incident, err := c.Repo().ClusterIncident().ReadClusterIncident(cluster.ProjectID, cluster.ID, incidentID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("incident %d not found", incidentID)))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, incident.ToClusterIncidentType())
+}
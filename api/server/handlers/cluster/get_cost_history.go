@@ -0,0 +1,70 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/cost"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// GetCostHistoryHandler returns the hourly costs of a cluster, or of a namespace or release in
+// the cluster, which defaults to the costs of the current billing period
+type GetCostHistoryHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGetCostHistoryHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetCostHistoryHandler {
+	return &GetCostHistoryHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *GetCostHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.GetCostHistoryRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.ReleaseName != "" && request.Namespace == "" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("namespace must be set to get the cost history of a release"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	from, to := cost.GetPeriod(request.From, request.To, time.Now())
+
+	if !from.Before(to) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("from must be before to"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	records, err := c.Repo().CostRecord().ListCostRecords(cluster.ID, from, to)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, cost.GetHistory(records, request.Namespace, request.ReleaseName))
+}
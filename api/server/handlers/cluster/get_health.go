@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/health"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// GetClusterHealthHandler checks the connection to a cluster: whether the API server is
+// reachable, whether the credentials are valid, and whether metrics and the porter agent
+// are available. Connection failures are reported in the response rather than as errors.
+type GetClusterHealthHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetClusterHealthHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetClusterHealthHandler {
+	return &GetClusterHealthHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetClusterHealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	now := time.Now().UTC()
+
+	var kubeInt *ints.KubeIntegration
+
+	if cluster.KubeIntegrationID != 0 {
+		// if the integration can't be read, the expiry of the credentials is simply unknown
+		kubeInt, _ = c.Repo().KubeIntegration().ReadKubeIntegration(cluster.ProjectID, cluster.KubeIntegrationID)
+	}
+
+	credsExpiry := health.GetCredentialsExpiry(cluster, kubeInt)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.WriteResult(w, r, health.ConnectionFailure(err, credsExpiry, now))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	c.WriteResult(w, r, health.CheckCluster(ctx, agent.Clientset, credsExpiry, now))
+}
@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -9,46 +11,79 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/access"
 	"github.com/porter-dev/porter/internal/models"
-	"k8s.io/client-go/tools/clientcmd"
+	"gorm.io/gorm"
 )
 
 type GetTemporaryKubeconfigHandler struct {
-	handlers.PorterHandlerWriter
+	handlers.PorterHandlerReadWriter
 	authz.KubernetesAgentGetter
 }
 
 func NewGetTemporaryKubeconfigHandler(
 	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
 	writer shared.ResultWriter,
 ) *GetTemporaryKubeconfigHandler {
 	return &GetTemporaryKubeconfigHandler{
-		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
-		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
 	}
 }
 
+// ServeHTTP issues a kubeconfig for a service account whose permissions are derived from the
+// user's Porter role, rather than sharing the credentials which Porter uses for the cluster
 func (c *GetTemporaryKubeconfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
-	outOfClusterConfig := c.GetOutOfClusterConfig(cluster)
+	request := &types.GetTemporaryKubeconfigRequest{}
 
-	kubeconfig, err := outOfClusterConfig.CreateRawConfigFromCluster()
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	role, err := c.Repo().Project().ReadProjectRole(cluster.ProjectID, user.ID)
 
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+				fmt.Errorf("user %d does not have a role in project %d", user.ID, cluster.ProjectID),
+			))
+
+			return
+		}
+
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	kubeconfigBytes, err := clientcmd.Write(*kubeconfig)
+	agent, err := c.GetAgent(r, cluster, "")
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	res := &types.GetTemporaryKubeconfigResponse{
-		Kubeconfig: kubeconfigBytes,
+	res, err := access.CreateScopedKubeconfig(agent.Clientset, cluster, &access.ScopedKubeconfigOpts{
+		UserID:        user.ID,
+		RoleKind:      role.Kind,
+		Namespace:     request.Namespace,
+		ExpirySeconds: request.ExpirySeconds,
+	})
+
+	if err != nil {
+		if errors.Is(err, access.ErrRoleNotSupported) {
+			c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+				fmt.Errorf("kubeconfigs cannot be issued for role %s", role.Kind),
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
 	}
 
 	c.WriteResult(w, r, res)
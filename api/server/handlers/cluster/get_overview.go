@@ -0,0 +1,168 @@
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/overview"
+	"github.com/porter-dev/porter/internal/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recentIncidentsLimit is the number of incidents which are returned in the overview
+const recentIncidentsLimit = 5
+
+// pendingStatusFilter are the statuses of the releases which are read for the overview. The
+// latest revision of each release is read among the revisions with these statuses, so
+// deployed and failed are included to avoid returning an older pending revision.
+var pendingStatusFilter = []string{
+	"deployed",
+	"failed",
+	"pending-install",
+	"pending-upgrade",
+	"pending-rollback",
+}
+
+type GetClusterOverviewHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetClusterOverviewHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetClusterOverviewHandler {
+	return &GetClusterOverviewHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP returns the nodes, namespaces, pods, pending upgrades and recent incidents of a
+// cluster in a single response. The cluster is queried concurrently.
+func (c *GetClusterOverviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.GetClusterOverviewResponse{}
+
+	var wg sync.WaitGroup
+	mu := &sync.Mutex{}
+	errors := make([]error, 0)
+
+	run := func(f func() error) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := f(); err != nil {
+				mu.Lock()
+				errors = append(errors, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// the nodes and pods are summarized together, since the requests of the pods are counted
+	// against the nodes
+	run(func() error {
+		nodeList, err := agent.Clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+
+		if err != nil {
+			return err
+		}
+
+		pods, err := agent.Clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+
+		if err != nil {
+			return err
+		}
+
+		res.Nodes = overview.SummarizeNodes(nodeList.Items, pods.Items)
+		res.PodsByPhase = overview.CountPodsByPhase(pods.Items)
+
+		return nil
+	})
+
+	run(func() error {
+		namespaces, err := agent.ListNamespaces()
+
+		if err != nil {
+			return err
+		}
+
+		res.Namespaces = overview.SummarizeNamespaces(namespaces.Items)
+
+		return nil
+	})
+
+	run(func() error {
+		releases, err := helmAgent.ListReleases("", &types.ReleaseListFilter{
+			StatusFilter: pendingStatusFilter,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		res.PendingUpgrades = overview.GetPendingUpgrades(releases)
+
+		return nil
+	})
+
+	run(func() error {
+		incidents, _, err := c.Repo().ClusterIncident().ListClusterIncidents(
+			cluster.ProjectID,
+			cluster.ID,
+			&types.ListClusterIncidentsRequest{Limit: recentIncidentsLimit},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		res.RecentIncidents = make([]*types.ClusterIncident, 0, len(incidents))
+
+		for _, incident := range incidents {
+			res.RecentIncidents = append(res.RecentIncidents, incident.ToClusterIncidentType())
+		}
+
+		_, res.ActiveIncidents, err = c.Repo().ClusterIncident().ListClusterIncidents(
+			cluster.ProjectID,
+			cluster.ID,
+			&types.ListClusterIncidentsRequest{Limit: 1, Status: types.IncidentStatusActive},
+		)
+
+		return err
+	})
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(errors[0]))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetRelayMetricsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGetRelayMetricsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetRelayMetricsHandler {
+	return &GetRelayMetricsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP returns the pod metrics of the last report of the relay of a cluster, which
+// doesn't require access to the Kubernetes API of the cluster
+func (c *GetRelayMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.GetClusterRelayMetricsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	relay, err := c.Repo().ClusterRelay().ReadClusterRelay(cluster.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("cluster %d does not have a relay", cluster.ID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	metrics, err := relay.GetPodMetrics()
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.GetClusterRelayMetricsResponse{
+		CollectedAt: relay.LastReportAt,
+		Pods:        make([]*types.RelayPodMetrics, 0),
+	}
+
+	for _, pod := range metrics {
+		if request.Namespace != "" && pod.Namespace != request.Namespace {
+			continue
+		}
+
+		if request.ReleaseName != "" && pod.ReleaseName != request.ReleaseName {
+			continue
+		}
+
+		res.Pods = append(res.Pods, pod)
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/tunnel"
+	"gorm.io/gorm"
+)
+
+type GetTunnelHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetTunnelHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetTunnelHandler {
+	return &GetTunnelHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetTunnelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	clusterTunnel, err := c.Repo().ClusterTunnel().ReadClusterTunnel(cluster.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("cluster %d does not have a tunnel", cluster.ID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, clusterTunnel.ToClusterTunnelType(tunnel.DefaultRegistry.IsConnected(cluster.ID)))
+}
@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ListArchivedKubeEventsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListArchivedKubeEventsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListArchivedKubeEventsHandler {
+	return &ListArchivedKubeEventsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListArchivedKubeEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListArchivedKubeEventsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	opts := commonutils.GetListOptions(
+		&request.ListOptions,
+		repository.Filter{Field: "namespace", Values: request.Namespace},
+		repository.Filter{Field: "resource_type", Values: request.ResourceType},
+		repository.Filter{Field: "name", Values: request.Name},
+	)
+
+	events, err := c.Repo().Archive().ListArchivedKubeEvents(cluster.ProjectID, cluster.ID, opts)
+
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidListOptions) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListArchivedKubeEventsResponse, 0)
+
+	for _, event := range events {
+		res = append(res, event.ToArchivedKubeEventType())
+	}
+
+	commonutils.SetNextCursor(w, opts)
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/velero"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListBackupsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewListBackupsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListBackupsHandler {
+	return &ListBackupsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ListBackupsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListBackupsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	backups, err := velero.ListBackups(dynClient, request.Namespace, request.ReleaseName)
+
+	if err != nil {
+		c.HandleAPIError(w, r, toVeleroAPIError(err))
+		return
+	}
+
+	c.WriteResult(w, r, types.ListBackupsResponse(backups))
+}
+
+// toVeleroAPIError returns the error of a request to Velero, which is passed to the client if
+// Velero isn't installed in the cluster
+func toVeleroAPIError(err error) apierrors.RequestError {
+	if errors.Is(err, velero.ErrNotInstalled) {
+		return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	return apierrors.NewErrInternal(err)
+}
@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListClusterIncidentsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListClusterIncidentsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListClusterIncidentsHandler {
+	return &ListClusterIncidentsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListClusterIncidentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListClusterIncidentsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	incidents, count, err := c.Repo().ClusterIncident().ListClusterIncidents(cluster.ProjectID, cluster.ID, request)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ListClusterIncidentsResponse{
+		Count:     count,
+		Limit:     request.Limit,
+		Skip:      request.Skip,
+		Incidents: make([]*types.ClusterIncident, 0),
+	}
+
+	for _, incident := range incidents {
+		res.Incidents = append(res.Incidents, incident.ToClusterIncidentType())
+	}
+
+	c.WriteResult(w, r, res)
+}
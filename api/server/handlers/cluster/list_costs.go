@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/cost"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListCostsHandler returns the recorded costs of a cluster for each namespace or release, which
+// defaults to the costs of the current billing period
+type ListCostsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListCostsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListCostsHandler {
+	return &ListCostsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListCostsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListCostsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	from, to := cost.GetPeriod(request.From, request.To, time.Now())
+
+	if !from.Before(to) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("from must be before to"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	records, err := c.Repo().CostRecord().ListCostRecords(cluster.ID, from, to)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	groupBy := request.GroupBy
+
+	if groupBy == "" {
+		groupBy = types.CostGroupByNamespace
+	}
+
+	c.WriteResult(w, r, cost.Summarize(records, groupBy))
+}
@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListDeletedClustersHandler lists the deleted clusters of a project which are within the
+// retention window, and can be restored
+type ListDeletedClustersHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListDeletedClustersHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListDeletedClustersHandler {
+	return &ListDeletedClustersHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListDeletedClustersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	retentionDays := c.Config().ServerConf.SoftDeleteRetentionDays
+
+	clusters, err := c.Repo().Cluster().ListDeletedClustersByProjectID(proj.ID, time.Now().AddDate(0, 0, -int(retentionDays)))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDeletedClustersResponse, 0)
+
+	for _, cluster := range clusters {
+		res = append(res, &types.DeletedCluster{
+			Cluster:      cluster.ToClusterType(),
+			DeletionMeta: types.NewDeletionMeta(cluster.DeletedAt.Time, retentionDays),
+		})
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,90 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/cost"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ListPreviewEnvironmentCostsHandler returns the most expensive preview environment deployments
+// of a cluster, based on the recorded costs of their namespaces
+type ListPreviewEnvironmentCostsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListPreviewEnvironmentCostsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListPreviewEnvironmentCostsHandler {
+	return &ListPreviewEnvironmentCostsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListPreviewEnvironmentCostsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListPreviewEnvironmentCostsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	from, to := cost.GetPeriod(request.From, request.To, time.Now())
+
+	if !from.Before(to) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("from must be before to"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	limit := request.Limit
+
+	if limit == 0 {
+		limit = 10
+	}
+
+	records, err := c.Repo().CostRecord().ListCostRecords(cluster.ID, from, to)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envs, err := c.Repo().Environment().ListEnvironments(cluster.ProjectID, cluster.ID, nil)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envsByID := make(map[uint]*models.Environment)
+
+	for _, env := range envs {
+		envsByID[env.ID] = env
+	}
+
+	depls, err := c.Repo().Environment().ListDeploymentsByCluster(cluster.ProjectID, cluster.ID, &repository.ListOptions{
+		ReadReplica: true,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, cost.RankPreviewEnvironments(records, depls, envsByID, limit))
+}
@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListPullThroughCacheRulesHandler lists the pull through cache rules which rewrite the
+// images deployed to a cluster
+type ListPullThroughCacheRulesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListPullThroughCacheRulesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListPullThroughCacheRulesHandler {
+	return &ListPullThroughCacheRulesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListPullThroughCacheRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	rules, err := c.Repo().PullThroughCacheRule().ListPullThroughCacheRulesByClusterID(cluster.ProjectID, cluster.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListPullThroughCacheRulesResponse, 0)
+
+	for _, rule := range rules {
+		res = append(res, rule.ToPullThroughCacheRuleType())
+	}
+
+	c.WriteResult(w, r, res)
+}
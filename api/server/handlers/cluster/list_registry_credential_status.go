@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListRegistryCredentialStatusHandler lists the status of the image pull secret refreshes of
+// a cluster, for each registry with expiring credentials
+type ListRegistryCredentialStatusHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListRegistryCredentialStatusHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListRegistryCredentialStatusHandler {
+	return &ListRegistryCredentialStatusHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListRegistryCredentialStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	refreshes, err := c.Repo().RegistryCredentialRefresh().ListRegistryCredentialRefreshesByClusterID(cluster.ProjectID, cluster.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	regNames := make(map[uint]string)
+
+	for _, reg := range registries {
+		regNames[reg.ID] = reg.Name
+	}
+
+	res := make(types.ListRegistryCredentialStatusResponse, 0)
+
+	for _, refresh := range refreshes {
+		// skip the status of registries which have been deleted
+		name, ok := regNames[refresh.RegistryID]
+
+		if !ok {
+			continue
+		}
+
+		res = append(res, refresh.ToRegistryCredentialStatusType(name))
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ListWildcardCertificatesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListWildcardCertificatesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListWildcardCertificatesHandler {
+	return &ListWildcardCertificatesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListWildcardCertificatesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	certs, err := c.Repo().WildcardCertificate().ListWildcardCertificatesByClusterID(cluster.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWildcardCertificatesResponse, 0, len(certs))
+
+	for _, cert := range certs {
+		res = append(res, cert.ToWildcardCertificateType())
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// readWildcardCertificate reads the wildcard certificate of the cluster which is referenced in
+// the URL of a request
+func readWildcardCertificate(
+	repo repository.Repository,
+	r *http.Request,
+	cluster *models.Cluster,
+) (*models.WildcardCertificate, apierrors.RequestError) {
+	certID, reqErr := requestutils.GetURLParamUint(r, types.URLParamWildcardCertificateID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	cert, err := repo.WildcardCertificate().ReadWildcardCertificate(cluster.ID, certID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrNotFound(fmt.Errorf("wildcard certificate not found"))
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return cert, nil
+}
@@ -14,8 +14,10 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/discord"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"gorm.io/gorm"
 )
 
@@ -44,7 +46,11 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 		return
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	slackInts, _ := slack.ListRoutedIntegrations(
+		c.Repo().SlackIntegration(), cluster.ProjectID, types.SlackEventIncident, cluster.ID, request.ReleaseNamespace,
+	)
+
+	discordInts, _ := discord.ListRoutedIntegrations(c.Repo(), cluster.ProjectID, cluster.ID, request.ReleaseNamespace)
 
 	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
 
@@ -66,13 +72,30 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 		notifConf = conf.ToNotificationConfigType()
 	}
 
+	notifPrefs, err := c.Repo().NotificationPreference().ListNotificationPreferencesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	notifiers := make([]notifier.IncidentNotifier, 0)
 
-	if c.Config().SlackConf != nil {
+	if c.Config().SlackConf != nil &&
+		notifier.ProjectAllows(notifPrefs, cluster.ProjectID, types.NotificationChannelSlack, types.NotificationEventIncident) {
 		notifiers = append(notifiers, slack.NewIncidentNotifier(slackInts...))
 	}
 
-	if sc := c.Config().ServerConf; sc.SendgridAPIKey != "" && sc.SendgridSenderEmail != "" && sc.SendgridIncidentAlertTemplateID != "" {
+	if len(discordInts) > 0 {
+		notifiers = append(notifiers, discord.NewIncidentNotifier(discordInts...))
+	}
+
+	if notifier.ProjectAllows(notifPrefs, cluster.ProjectID, types.NotificationChannelWebhook, types.NotificationEventIncident) {
+		notifiers = append(notifiers, webhook.NewIncidentNotifier(c.Repo(), cluster.ProjectID))
+	}
+
+	if sc := c.Config().ServerConf; sc.SendgridAPIKey != "" && sc.SendgridSenderEmail != "" && sc.SendgridIncidentAlertTemplateID != "" &&
+		notifier.ProjectAllows(notifPrefs, cluster.ProjectID, types.NotificationChannelEmail, types.NotificationEventIncident) {
 		users, err := getUsersByProjectID(c.Repo(), cluster.ProjectID)
 
 		if err != nil {
@@ -86,7 +109,9 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 				SenderEmail: c.Config().ServerConf.SendgridSenderEmail,
 			},
 			IncidentResolvedTemplateID: sc.SendgridIncidentResolvedTemplateID,
-			Users:                      users,
+			Users: notifier.FilterUsers(
+				notifPrefs, cluster.ProjectID, types.NotificationChannelEmail, types.NotificationEventIncident, users,
+			),
 		}))
 	}
 
@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/relay"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var errInvalidRelayToken = errors.New("invalid relay token")
+
+type RelayReportHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRelayReportHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RelayReportHandler {
+	return &RelayReportHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP stores a report of the in-cluster agent of a cluster, which is authenticated with
+// the token of the cluster's relay, and returns the log alert rules which the agent evaluates
+func (c *RelayReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	clusterRelay, err := c.authenticateAgent(r)
+
+	if err != nil {
+		if errors.Is(err, errInvalidRelayToken) {
+			c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	request := &types.RelayReport{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	cluster, err := c.Repo().Cluster().ReadCluster(clusterRelay.ProjectID, clusterRelay.ClusterID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := relay.Ingest(c.Repo(), cluster, clusterRelay, request, time.Now().UTC()); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rules, err := c.Repo().LogAlertRule().ListLogAlertRulesByCluster(cluster.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.RelayReportResponse{
+		LogAlertRules: make([]*types.LogAlertRule, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		res.LogAlertRules = append(res.LogAlertRules, rule.ToLogAlertRuleType())
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// authenticateAgent returns the relay of the token in the request
+func (c *RelayReportHandler) authenticateAgent(r *http.Request) (*models.ClusterRelay, error) {
+	clusterID, secret, ok := parseAgentToken(r)
+
+	if !ok {
+		return nil, errInvalidRelayToken
+	}
+
+	clusterRelay, err := c.Repo().ClusterRelay().ReadClusterRelay(clusterID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidRelayToken
+		}
+
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(clusterRelay.TokenHash), []byte(secret)); err != nil {
+		return nil, errInvalidRelayToken
+	}
+
+	return clusterRelay, nil
+}
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// ClusterRestoreHandler restores a deleted cluster of a project, along with its token cache
+type ClusterRestoreHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewClusterRestoreHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ClusterRestoreHandler {
+	return &ClusterRestoreHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ClusterRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	clusterID, reqErr := requestutils.GetURLParamUint(r, types.URLParamClusterID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	retentionDays := c.Config().ServerConf.SoftDeleteRetentionDays
+
+	cluster, err := c.Repo().Cluster().RestoreCluster(proj.ID, clusterID, time.Now().AddDate(0, 0, -int(retentionDays)))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(
+				errors.New("cluster not found, or deleted before the retention window"),
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, cluster.ToClusterType())
+}
@@ -0,0 +1,134 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/velero"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type RestoreBackupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRestoreBackupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RestoreBackupHandler {
+	return &RestoreBackupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP starts a Velero restore of a backup of the cluster, into the same cluster or into
+// another cluster of the project
+func (c *RestoreBackupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamBackupName)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.CreateRestoreRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	backup, err := velero.GetBackup(dynClient, name)
+
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("backup %s not found", name)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if backup.Phase != "Completed" && backup.Phase != "PartiallyFailed" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("backup %s cannot be restored in phase %s", name, backup.Phase),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if request.TargetClusterID != 0 && request.TargetClusterID != cluster.ID {
+		targetCluster, err := c.Repo().Cluster().ReadCluster(cluster.ProjectID, request.TargetClusterID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("target cluster %d not found in project", request.TargetClusterID),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		dynClient, err = kubernetes.GetDynamicClientOutOfClusterConfig(c.GetOutOfClusterConfig(targetCluster))
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		// Velero syncs the backups of a backup storage location into every cluster which uses
+		// the location, so the backup is only found if the clusters share a location
+		if _, err := velero.GetBackup(dynClient, name); err != nil {
+			if k8sErrors.IsNotFound(err) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("backup %s not found in target cluster %d: the Velero installations of both clusters "+
+						"must use the same backup storage location", name, request.TargetClusterID),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	restore, err := velero.CreateRestore(dynClient, backup, request.TargetNamespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, toVeleroAPIError(err))
+		return
+	}
+
+	c.WriteResult(w, r, restore)
+}
@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// clusterIncidentPollInterval is how often the database is checked for incident changes.
+// Incidents are written by the incident detector worker, so changes are picked up by polling.
+const clusterIncidentPollInterval = 5 * time.Second
+
+type StreamClusterIncidentsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewStreamClusterIncidentsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *StreamClusterIncidentsHandler {
+	return &StreamClusterIncidentsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *StreamClusterIncidentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	safeRW := r.Context().Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	closed := make(chan struct{})
+
+	// listens for websocket closing handshake
+	go func() {
+		defer close(closed)
+
+		for {
+			if _, _, err := safeRW.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	lastChecked := time.Now().UTC()
+	ticker := time.NewTicker(clusterIncidentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		currChecked := time.Now().UTC()
+
+		incidents, err := c.Repo().ClusterIncident().ListClusterIncidentsUpdatedSince(cluster.ProjectID, cluster.ID, lastChecked)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		lastChecked = currChecked
+
+		for _, incident := range incidents {
+			event := &types.ClusterIncidentEvent{
+				Type:     types.ClusterIncidentEventUpdated,
+				Incident: incident.ToClusterIncidentType(),
+			}
+
+			if incident.ResolvedAt != nil {
+				event.Type = types.ClusterIncidentEventResolved
+			} else if incident.CreatedAt.Equal(incident.UpdatedAt) {
+				event.Type = types.ClusterIncidentEventOpened
+			}
+
+			if err := safeRW.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
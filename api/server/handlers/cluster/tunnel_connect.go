@@ -0,0 +1,160 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/tunnel"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var errInvalidTunnelToken = errors.New("invalid tunnel token")
+
+type TunnelConnectHandler struct {
+	handlers.PorterHandler
+
+	// the default origin check of the upgrader rejects cross-origin requests from browsers,
+	// while agents don't send an origin
+	upgrader *websocket.Upgrader
+}
+
+func NewTunnelConnectHandler(
+	config *config.Config,
+) *TunnelConnectHandler {
+	return &TunnelConnectHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+		upgrader: &websocket.Upgrader{
+			ReadBufferSize:  32 * 1024,
+			WriteBufferSize: 32 * 1024,
+		},
+	}
+}
+
+// ServeHTTP accepts the connection of the tunnel agent of a cluster, which is authenticated
+// with the token of the cluster's tunnel, and routes the traffic to the cluster through the
+// connection until the agent disconnects
+func (c *TunnelConnectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	clusterTunnel, err := c.authenticateAgent(r)
+
+	if err != nil {
+		if errors.Is(err, errInvalidTunnelToken) {
+			c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	cluster, err := c.Repo().Cluster().ReadCluster(clusterTunnel.ProjectID, clusterTunnel.ClusterID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !cluster.TunnelEnabled {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("the tunnel of cluster %d is disabled", cluster.ID),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	conn, err := c.upgrader.Upgrade(w, r, nil)
+
+	if err != nil {
+		// the upgrader writes the error response
+		return
+	}
+
+	session := tunnel.NewSession(conn, nil)
+
+	tunnel.DefaultRegistry.Register(cluster.ID, session)
+	c.recordConnection(cluster.ID, true)
+
+	<-session.Done()
+
+	tunnel.DefaultRegistry.Unregister(cluster.ID, session)
+	c.recordConnection(cluster.ID, false)
+
+	c.Config().Logger.Info().Msgf("tunnel agent of cluster %d disconnected: %v", cluster.ID, session.Err())
+}
+
+// authenticateAgent returns the tunnel of the token in the request
+func (c *TunnelConnectHandler) authenticateAgent(r *http.Request) (*models.ClusterTunnel, error) {
+	clusterID, secret, ok := parseAgentToken(r)
+
+	if !ok {
+		return nil, errInvalidTunnelToken
+	}
+
+	clusterTunnel, err := c.Repo().ClusterTunnel().ReadClusterTunnel(clusterID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidTunnelToken
+		}
+
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(clusterTunnel.TokenHash), []byte(secret)); err != nil {
+		return nil, errInvalidTunnelToken
+	}
+
+	return clusterTunnel, nil
+}
+
+// recordConnection records when the agent of a tunnel connected or disconnected. The tunnel
+// is read again, since its token may have been rotated while the agent was connected.
+func (c *TunnelConnectHandler) recordConnection(clusterID uint, connected bool) {
+	clusterTunnel, err := c.Repo().ClusterTunnel().ReadClusterTunnel(clusterID)
+
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	if connected {
+		clusterTunnel.LastConnectedAt = &now
+	} else {
+		clusterTunnel.LastDisconnectedAt = &now
+	}
+
+	if _, err := c.Repo().ClusterTunnel().UpdateClusterTunnel(clusterTunnel); err != nil {
+		c.Config().Logger.Error().Err(err).Msgf("error recording tunnel connection of cluster %d", clusterID)
+	}
+}
+
+// parseAgentToken parses the bearer token of a request from an in-cluster agent, which has the
+// form <cluster id>.<secret>
+func parseAgentToken(r *http.Request) (uint, string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	idStr, secret, ok := strings.Cut(token, ".")
+
+	if !ok || secret == "" {
+		return 0, "", false
+	}
+
+	clusterID, err := strconv.ParseUint(idStr, 10, 64)
+
+	if err != nil {
+		return 0, "", false
+	}
+
+	return uint(clusterID), secret, true
+}
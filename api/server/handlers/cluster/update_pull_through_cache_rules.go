@@ -0,0 +1,86 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/docker/distribution/reference"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// UpdatePullThroughCacheRulesHandler replaces the pull through cache rules of a cluster. The
+// rules apply to releases as they are next installed or upgraded.
+type UpdatePullThroughCacheRulesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdatePullThroughCacheRulesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdatePullThroughCacheRulesHandler {
+	return &UpdatePullThroughCacheRulesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdatePullThroughCacheRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.UpdatePullThroughCacheRulesRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	rules := make([]*models.PullThroughCacheRule, 0)
+	upstreams := make(map[string]bool)
+
+	for _, reqRule := range request.Rules {
+		upstream := helm.NormalizeRegistryHost(reqRule.UpstreamRegistry)
+
+		if upstreams[upstream] {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("upstream registry %s has more than one rule", upstream), http.StatusBadRequest,
+			))
+			return
+		}
+
+		upstreams[upstream] = true
+
+		// the mirror prefix must itself be a valid repository name, so that rewritten images are valid
+		if _, err := reference.ParseNormalizedNamed(reqRule.MirrorPrefix + "/image"); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("invalid mirror prefix %s for upstream registry %s", reqRule.MirrorPrefix, upstream),
+				http.StatusBadRequest,
+			))
+			return
+		}
+
+		rules = append(rules, &models.PullThroughCacheRule{
+			UpstreamRegistry: upstream,
+			MirrorPrefix:     reqRule.MirrorPrefix,
+		})
+	}
+
+	rules, err := c.Repo().PullThroughCacheRule().ReplacePullThroughCacheRules(cluster.ProjectID, cluster.ID, rules)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListPullThroughCacheRulesResponse, 0)
+
+	for _, rule := range rules {
+		res = append(res, rule.ToPullThroughCacheRuleType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -59,6 +59,8 @@ func (c *UpgradeAgentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	// TODO: update values
 	// newValues["redis"] =
 
+	// the porter agent is a system release of the cluster rather than an application, so it is
+	// not upgraded through release.Deploy and is not gated by approvals or freeze windows
 	_, err = helmAgent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
 		Chart:      chart,
 		Name:       "porter-agent",
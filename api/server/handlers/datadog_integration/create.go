@@ -0,0 +1,67 @@
+package datadog_integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier/datadog"
+)
+
+type DatadogIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDatadogIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DatadogIntegrationCreateHandler {
+	return &DatadogIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *DatadogIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateDatadogIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// the API key is checked before it is stored, so that deployment events are not silently dropped
+	if err := datadog.ValidateAPIKey(request.Site, request.APIKey); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not validate Datadog API key: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	ddInt, err := p.Repo().DatadogIntegration().CreateDatadogIntegration(&integrations.DatadogIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Site:      request.Site,
+		Tags:      strings.Join(request.Tags, ","),
+		APIKey:    []byte(request.APIKey),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, ddInt.ToDatadogIntegrationType())
+}
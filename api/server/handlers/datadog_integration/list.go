@@ -0,0 +1,44 @@
+package datadog_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DatadogIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDatadogIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DatadogIntegrationListHandler {
+	return &DatadogIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *DatadogIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	ddInts, err := p.Repo().DatadogIntegration().ListDatadogIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDatadogIntegrationsResponse, 0)
+
+	for _, ddInt := range ddInts {
+		res = append(res, ddInt.ToDatadogIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,101 @@
+package discord_integration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+)
+
+type DiscordIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDiscordIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiscordIntegrationCreateHandler {
+	return &DiscordIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *DiscordIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateDiscordIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// only Discord webhooks are accepted, so that notifications cannot be sent to arbitrary URLs
+	if !isDiscordWebhook(request.Webhook) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("webhook must be a Discord webhook URL starting with https://discord.com/api/webhooks/"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if request.EnvironmentID != 0 {
+		_, err := p.Repo().Environment().ReadEnvironmentByID(project.ID, request.ClusterID, request.EnvironmentID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("environment %d not found in cluster %d", request.EnvironmentID, request.ClusterID),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	discordInt, err := p.Repo().DiscordIntegration().CreateDiscordIntegration(&integrations.DiscordIntegration{
+		UserID:        user.ID,
+		ProjectID:     project.ID,
+		Name:          request.Name,
+		EnvironmentID: request.EnvironmentID,
+		Webhook:       []byte(request.Webhook),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, discordInt.ToDiscordIntegrationType())
+}
+
+func isDiscordWebhook(webhook string) bool {
+	webhookURL, err := url.Parse(webhook)
+
+	if err != nil || webhookURL.Scheme != "https" {
+		return false
+	}
+
+	switch webhookURL.Host {
+	case "discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com":
+		return strings.HasPrefix(webhookURL.Path, "/api/webhooks/")
+	}
+
+	return false
+}
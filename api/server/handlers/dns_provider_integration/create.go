@@ -0,0 +1,108 @@
+package dns_provider_integration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+)
+
+type DNSProviderIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDNSProviderIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DNSProviderIntegrationCreateHandler {
+	return &DNSProviderIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *DNSProviderIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateDNSProviderIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	dnsInt := &integrations.DNSProviderIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Provider:  request.Provider,
+	}
+
+	switch request.Provider {
+	case types.DNSProviderRoute53:
+		if request.AWSIntegrationID == 0 {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("aws_integration_id is required for route53 integrations"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		_, err := p.Repo().AWSIntegration().ReadAWSIntegration(project.ID, request.AWSIntegrationID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("aws integration %d not found", request.AWSIntegrationID),
+					http.StatusNotFound,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		dnsInt.AWSIntegrationID = request.AWSIntegrationID
+	case types.DNSProviderCloudflare:
+		if request.CloudflareAPIToken == "" {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("cloudflare_api_token is required for cloudflare integrations"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		dnsInt.CloudflareAPIToken = []byte(request.CloudflareAPIToken)
+
+		if err := dns.NewCloudflareProvider(dnsInt).ValidateAPIToken(r.Context()); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("could not validate Cloudflare API token: %w", err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	dnsInt, err := p.Repo().DNSProviderIntegration().CreateDNSProviderIntegration(dnsInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, dnsInt.ToDNSProviderIntegrationType())
+}
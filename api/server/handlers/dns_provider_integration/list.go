@@ -0,0 +1,44 @@
+package dns_provider_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DNSProviderIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDNSProviderIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DNSProviderIntegrationListHandler {
+	return &DNSProviderIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *DNSProviderIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	dnsInts, err := p.Repo().DNSProviderIntegration().ListDNSProviderIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDNSProviderIntegrationsResponse, 0)
+
+	for _, dnsInt := range dnsInts {
+		res = append(res, dnsInt.ToDNSProviderIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
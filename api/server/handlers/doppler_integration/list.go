@@ -0,0 +1,44 @@
+package doppler_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DopplerIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDopplerIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DopplerIntegrationListHandler {
+	return &DopplerIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *DopplerIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	dopplerInts, err := p.Repo().DopplerIntegration().ListDopplerIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDopplerIntegrationsResponse, 0)
+
+	for _, dopplerInt := range dopplerInts {
+		res = append(res, dopplerInt.ToDopplerIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,349 @@
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// bulkDeploymentExecutor runs a bulk deployment operation one deployment at a time, and records
+// the result for each deployment. Items are marked as running before their action runs, so that
+// an item which was interrupted, for example by a restart, is resumed rather than skipped, and
+// items which already have a result are not run again.
+type bulkDeploymentExecutor struct {
+	config  *config.Config
+	cluster *models.Cluster
+	op      *models.BulkDeploymentOperation
+	prState string
+
+	// the user who created the operation, and whether re-enabled deployments override the
+	// freeze windows of their namespace
+	user     *models.User
+	override types.FreezeOverride
+
+	// getAgent and getClient are replaced in tests
+	getAgent  func() (*kubernetes.Agent, error)
+	getClient func(env *models.Environment) (*github.Client, error)
+
+	agent   *kubernetes.Agent
+	envs    map[uint]*models.Environment
+	clients map[uint]*github.Client
+}
+
+func newBulkDeploymentExecutor(
+	config *config.Config,
+	cluster *models.Cluster,
+	op *models.BulkDeploymentOperation,
+	prState string,
+	user *models.User,
+	override types.FreezeOverride,
+) *bulkDeploymentExecutor {
+	return &bulkDeploymentExecutor{
+		config:   config,
+		cluster:  cluster,
+		op:       op,
+		prState:  prState,
+		user:     user,
+		override: override,
+		getAgent: func() (*kubernetes.Agent, error) {
+			return kubernetes.GetAgentOutOfClusterConfig(
+				authz.NewOutOfClusterAgentGetter(config).GetOutOfClusterConfig(cluster),
+			)
+		},
+		getClient: func(env *models.Environment) (*github.Client, error) {
+			return getGithubClientFromEnvironment(config, env)
+		},
+		envs:    make(map[uint]*models.Environment),
+		clients: make(map[uint]*github.Client),
+	}
+}
+
+// run runs every item of the operation which does not have a result yet, in order
+func (e *bulkDeploymentExecutor) run() error {
+	for _, item := range e.op.Items {
+		if err := e.runItem(item.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *bulkDeploymentExecutor) getItem(itemID uint) (*models.BulkDeploymentOperationItem, error) {
+	for i := range e.op.Items {
+		if e.op.Items[i].ID == itemID {
+			return &e.op.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("item %d does not belong to bulk deployment operation %d", itemID, e.op.ID)
+}
+
+// runItem runs the action of the operation on the deployment of an item, and completes the
+// operation once every item has a result. The result of the action is stored on the item, so
+// an error is only returned if the item could not be stored, in which case the item can be
+// run again.
+func (e *bulkDeploymentExecutor) runItem(itemID uint) error {
+	item, err := e.getItem(itemID)
+
+	if err != nil {
+		return err
+	}
+
+	if item.Status != types.BulkDeploymentItemPending && item.Status != types.BulkDeploymentItemRunning {
+		return e.complete()
+	}
+
+	resumed := item.Status == types.BulkDeploymentItemRunning
+
+	if !resumed {
+		item.Status = types.BulkDeploymentItemRunning
+
+		if _, err := e.config.Repo.BulkDeploymentOperation().UpdateBulkDeploymentOperationItem(item); err != nil {
+			return fmt.Errorf("error updating item for deployment %d of bulk deployment operation %d: %w",
+				item.DeploymentID, e.op.ID, err)
+		}
+	}
+
+	status, err := e.runAction(item, resumed)
+
+	item.Status = status
+	item.Message = ""
+
+	if err != nil {
+		item.Message = err.Error()
+	}
+
+	if _, err := e.config.Repo.BulkDeploymentOperation().UpdateBulkDeploymentOperationItem(item); err != nil {
+		return fmt.Errorf("error updating item for deployment %d of bulk deployment operation %d: %w",
+			item.DeploymentID, e.op.ID, err)
+	}
+
+	return e.complete()
+}
+
+// failItem records an error which stopped an item from running
+func (e *bulkDeploymentExecutor) failItem(itemID uint, err error) error {
+	item, getErr := e.getItem(itemID)
+
+	if getErr != nil {
+		return getErr
+	}
+
+	item.Status = types.BulkDeploymentItemFailed
+	item.Message = err.Error()
+
+	if _, err := e.config.Repo.BulkDeploymentOperation().UpdateBulkDeploymentOperationItem(item); err != nil {
+		return fmt.Errorf("error updating item for deployment %d of bulk deployment operation %d: %w",
+			item.DeploymentID, e.op.ID, err)
+	}
+
+	return e.complete()
+}
+
+// complete marks the operation as completed if every item has a result. The operation is read
+// again, since its other items may be run by other workers.
+func (e *bulkDeploymentExecutor) complete() error {
+	op, err := e.config.Repo.BulkDeploymentOperation().ReadBulkDeploymentOperation(e.op.ProjectID, e.op.ClusterID, e.op.ID)
+
+	if err != nil {
+		return fmt.Errorf("error reading bulk deployment operation %d: %w", e.op.ID, err)
+	}
+
+	if op.Status == types.BulkDeploymentOperationCompleted {
+		return nil
+	}
+
+	for _, item := range op.Items {
+		if item.Status == types.BulkDeploymentItemPending || item.Status == types.BulkDeploymentItemRunning {
+			return nil
+		}
+	}
+
+	op.Status = types.BulkDeploymentOperationCompleted
+
+	if _, err := e.config.Repo.BulkDeploymentOperation().UpdateBulkDeploymentOperation(op); err != nil {
+		return fmt.Errorf("error completing bulk deployment operation %d: %w", e.op.ID, err)
+	}
+
+	return nil
+}
+
+// runAction runs the action of the operation on a single deployment. Deployments which are
+// skipped return the reason as an error. If the item was resumed, the action may already have
+// completed, in which case the item succeeds.
+func (e *bulkDeploymentExecutor) runAction(
+	item *models.BulkDeploymentOperationItem,
+	resumed bool,
+) (types.BulkDeploymentItemStatus, error) {
+	depl, err := e.config.Repo.Environment().ReadDeploymentByID(e.op.ProjectID, e.op.ClusterID, item.DeploymentID)
+
+	if err != nil {
+		if resumed && e.op.Action == types.BulkDeploymentActionDelete && errors.Is(err, gorm.ErrRecordNotFound) {
+			return types.BulkDeploymentItemSucceeded, nil
+		}
+
+		return types.BulkDeploymentItemFailed, fmt.Errorf("error reading deployment: %w", err)
+	}
+
+	env, client, err := e.getEnvironment(depl.EnvironmentID)
+
+	if err != nil {
+		return types.BulkDeploymentItemFailed, err
+	}
+
+	if e.prState != "" {
+		if depl.IsBranchDeploy() {
+			return types.BulkDeploymentItemSkipped, fmt.Errorf("branch deployments do not have a pull request")
+		}
+
+		prClosed, err := isGithubPRClosed(client, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID))
+
+		if err != nil {
+			return types.BulkDeploymentItemFailed, err
+		}
+
+		if prClosed != (e.prState == "closed") {
+			return types.BulkDeploymentItemSkipped, fmt.Errorf("pull request is not %s", e.prState)
+		}
+	}
+
+	switch e.op.Action {
+	case types.BulkDeploymentActionDisable:
+		if depl.Status == types.DeploymentStatusInactive {
+			// the deployment is only marked as inactive after it has been torn down
+			if resumed {
+				return types.BulkDeploymentItemSucceeded, nil
+			}
+
+			return types.BulkDeploymentItemSkipped, fmt.Errorf("deployment is already inactive")
+		}
+
+		if err := e.teardown(env, depl, client); err != nil {
+			return types.BulkDeploymentItemFailed, err
+		}
+
+		depl.Status = types.DeploymentStatusInactive
+
+		if _, err := e.config.Repo.Environment().UpdateDeployment(depl); err != nil {
+			return types.BulkDeploymentItemFailed, fmt.Errorf("error updating deployment: %w", err)
+		}
+	case types.BulkDeploymentActionReenable:
+		if depl.Status != types.DeploymentStatusInactive {
+			// the deployment is marked as creating before its workflow is dispatched, so the
+			// workflow of a resumed item may not have been dispatched yet
+			if !resumed || depl.Status != types.DeploymentStatusCreating {
+				return types.BulkDeploymentItemSkipped, fmt.Errorf("deployment is not inactive")
+			}
+		} else {
+			if !depl.IsBranchDeploy() && e.prState != "open" {
+				prClosed, err := isGithubPRClosed(client, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID))
+
+				if err != nil {
+					return types.BulkDeploymentItemFailed, err
+				}
+
+				if prClosed {
+					return types.BulkDeploymentItemSkipped, fmt.Errorf("pull request is closed")
+				}
+			}
+
+			if apiErr := commonutils.CheckFreezeWindows(
+				e.config, e.cluster, depl.Namespace, "", e.user, e.override,
+			); apiErr != nil {
+				return types.BulkDeploymentItemFailed, apiErr
+			}
+
+			depl.Status = types.DeploymentStatusCreating
+
+			if _, err := e.config.Repo.Environment().UpdateDeployment(depl); err != nil {
+				return types.BulkDeploymentItemFailed, fmt.Errorf("error updating deployment: %w", err)
+			}
+		}
+
+		if _, err := dispatchDeploymentWorkflow(context.Background(), client, env, depl); err != nil {
+			return types.BulkDeploymentItemFailed, fmt.Errorf("%v: %w", errGithubAPI, err)
+		}
+	case types.BulkDeploymentActionDelete:
+		if err := e.teardown(env, depl, client); err != nil {
+			return types.BulkDeploymentItemFailed, err
+		}
+
+		if _, err := e.config.Repo.Environment().DeleteDeployment(depl); err != nil {
+			return types.BulkDeploymentItemFailed, fmt.Errorf("error deleting deployment: %w", err)
+		}
+	}
+
+	return types.BulkDeploymentItemSucceeded, nil
+}
+
+// teardown deletes the namespace of a deployment and marks its GitHub deployment as inactive
+func (e *bulkDeploymentExecutor) teardown(env *models.Environment, depl *models.Deployment, client *github.Client) error {
+	// make sure we do not delete any kubernetes "system" namespaces
+	if !isSystemNamespace(depl.Namespace) {
+		if e.agent == nil {
+			agent, err := e.getAgent()
+
+			if err != nil {
+				return fmt.Errorf("failed to get agent: %w", err)
+			}
+
+			e.agent = agent
+		}
+
+		if err := e.agent.DeleteNamespace(depl.Namespace); err != nil {
+			return fmt.Errorf("error deleting preview deployment namespace: %w", err)
+		}
+	}
+
+	if depl.GHDeploymentID != 0 {
+		_, _, err := client.Repositories.CreateDeploymentStatus(
+			context.Background(),
+			env.GitRepoOwner,
+			env.GitRepoName,
+			depl.GHDeploymentID,
+			&github.DeploymentStatusRequest{
+				State: github.String("inactive"),
+			},
+		)
+
+		if err != nil {
+			return fmt.Errorf("%v: %w", errGithubAPI, err)
+		}
+	}
+
+	return nil
+}
+
+// getEnvironment returns an environment and its github client, which are cached across deployments
+func (e *bulkDeploymentExecutor) getEnvironment(envID uint) (*models.Environment, *github.Client, error) {
+	if env, ok := e.envs[envID]; ok {
+		return env, e.clients[envID], nil
+	}
+
+	env, err := e.config.Repo.Environment().ReadEnvironmentByID(e.op.ProjectID, e.op.ClusterID, envID)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading environment: %w", err)
+	}
+
+	client, err := e.getClient(env)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.envs[envID] = env
+	e.clients[envID] = client
+
+	return env, client, nil
+}
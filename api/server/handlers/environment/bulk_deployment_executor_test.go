@@ -0,0 +1,407 @@
+package environment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fakeGithub serves the GitHub endpoints which are called by the bulk deployment executor, and
+// records the workflows which are dispatched and the deployment statuses which are created
+type fakeGithub struct {
+	mu         sync.Mutex
+	closedPRs  map[int]bool
+	dispatches []string
+	statuses   []string
+}
+
+func newFakeGithubClient(t *testing.T, gh *fakeGithub) *github.Client {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/porter-dev/porter/pulls/", func(w http.ResponseWriter, r *http.Request) {
+		number, _ := strconv.Atoi(path.Base(r.URL.Path))
+
+		gh.mu.Lock()
+		state := "open"
+
+		if gh.closedPRs[number] {
+			state = "closed"
+		}
+		gh.mu.Unlock()
+
+		json.NewEncoder(w).Encode(&github.PullRequest{
+			Number: github.Int(number),
+			State:  github.String(state),
+		})
+	})
+
+	mux.HandleFunc("/repos/porter-dev/porter/actions/workflows/porter_preview_env.yml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		event := &github.CreateWorkflowDispatchEventRequest{}
+		json.NewDecoder(r.Body).Decode(event)
+
+		gh.mu.Lock()
+		gh.dispatches = append(gh.dispatches, event.Ref)
+		gh.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/repos/porter-dev/porter/deployments/", func(w http.ResponseWriter, r *http.Request) {
+		status := &github.DeploymentStatusRequest{}
+		json.NewDecoder(r.Body).Decode(status)
+
+		gh.mu.Lock()
+		gh.statuses = append(gh.statuses, fmt.Sprintf("%s:%s", path.Base(path.Dir(r.URL.Path)), status.GetState()))
+		gh.mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("{}"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client
+}
+
+type bulkDeploymentFixture struct {
+	*deploymentFixture
+
+	gh    *fakeGithub
+	agent *kubernetes.Agent
+}
+
+func newBulkDeploymentFixture(t *testing.T) *bulkDeploymentFixture {
+	f := newDeploymentFixture(t)
+
+	return &bulkDeploymentFixture{
+		deploymentFixture: f,
+		gh:                &fakeGithub{closedPRs: make(map[int]bool)},
+	}
+}
+
+// createDeployment creates a deployment of a pull request of the environment
+func (f *bulkDeploymentFixture) createDeployment(
+	t *testing.T,
+	prNumber uint,
+	status types.DeploymentStatus,
+) *models.Deployment {
+	depl, err := f.config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: f.env.ID,
+		Namespace:     fmt.Sprintf("pr-%d-porter", prNumber),
+		Status:        status,
+		PullRequestID: prNumber,
+		RepoOwner:     "porter-dev",
+		RepoName:      "porter",
+		PRBranchFrom:  fmt.Sprintf("branch-%d", prNumber),
+		PRBranchInto:  "main",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return depl
+}
+
+// newExecutor creates an operation on the deployments, and an executor which runs it with
+// the fake GitHub API and an agent which has the namespaces of the deployments
+func (f *bulkDeploymentFixture) newExecutor(
+	t *testing.T,
+	action types.BulkDeploymentAction,
+	deplIDs ...uint,
+) *bulkDeploymentExecutor {
+	op := &models.BulkDeploymentOperation{
+		ProjectID: f.project.ID,
+		ClusterID: f.cluster.ID,
+		Action:    action,
+		Status:    types.BulkDeploymentOperationRunning,
+	}
+
+	namespaces := make([]runtime.Object, 0)
+
+	for _, deplID := range deplIDs {
+		item := models.BulkDeploymentOperationItem{
+			DeploymentID: deplID,
+			Status:       types.BulkDeploymentItemPending,
+		}
+
+		if depl, err := f.config.Repo.Environment().ReadDeploymentByID(f.project.ID, f.cluster.ID, deplID); err == nil {
+			item.Namespace = depl.Namespace
+
+			namespaces = append(namespaces, &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: depl.Namespace},
+			})
+		}
+
+		op.Items = append(op.Items, item)
+	}
+
+	op, err := f.config.Repo.BulkDeploymentOperation().CreateBulkDeploymentOperation(op)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.agent = kubernetes.GetAgentTesting(namespaces...)
+	client := newFakeGithubClient(t, f.gh)
+
+	e := newBulkDeploymentExecutor(f.config, f.cluster, op, "", nil, types.FreezeOverride{})
+
+	e.getAgent = func() (*kubernetes.Agent, error) {
+		return f.agent, nil
+	}
+
+	e.getClient = func(env *models.Environment) (*github.Client, error) {
+		return client, nil
+	}
+
+	return e
+}
+
+// assertItems checks that the operation has completed with a status for each item
+func (f *bulkDeploymentFixture) assertItems(
+	t *testing.T,
+	e *bulkDeploymentExecutor,
+	statuses ...types.BulkDeploymentItemStatus,
+) []models.BulkDeploymentOperationItem {
+	t.Helper()
+
+	op, err := f.config.Repo.BulkDeploymentOperation().ReadBulkDeploymentOperation(f.project.ID, f.cluster.ID, e.op.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if op.Status != types.BulkDeploymentOperationCompleted {
+		t.Errorf("expected the operation to be completed, got %s", op.Status)
+	}
+
+	if len(op.Items) != len(statuses) {
+		t.Fatalf("expected %d items, got %d", len(statuses), len(op.Items))
+	}
+
+	for i, item := range op.Items {
+		if item.Status != statuses[i] {
+			t.Errorf("expected the item for deployment %d to be %s, got %s: %s",
+				item.DeploymentID, statuses[i], item.Status, item.Message)
+		}
+	}
+
+	return op.Items
+}
+
+// assertNamespaceDeleted checks that the namespace of a deployment was deleted
+func (f *bulkDeploymentFixture) assertNamespaceDeleted(t *testing.T, depl *models.Deployment) {
+	t.Helper()
+
+	_, err := f.agent.Clientset.CoreV1().Namespaces().Get(context.Background(), depl.Namespace, metav1.GetOptions{})
+
+	if !k8sErrors.IsNotFound(err) {
+		t.Errorf("expected namespace %s to be deleted, got %v", depl.Namespace, err)
+	}
+}
+
+func TestBulkDeploymentDisable(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+
+	active := f.createDeployment(t, 2, types.DeploymentStatusCreated)
+	active.GHDeploymentID = 20
+
+	if _, err := f.config.Repo.Environment().UpdateDeployment(active); err != nil {
+		t.Fatal(err)
+	}
+
+	inactive := f.createDeployment(t, 3, types.DeploymentStatusInactive)
+
+	e := f.newExecutor(t, types.BulkDeploymentActionDisable, active.ID, f.branchDepl.ID, inactive.ID)
+
+	if err := e.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.assertItems(t, e,
+		types.BulkDeploymentItemSucceeded,
+		types.BulkDeploymentItemSucceeded,
+		types.BulkDeploymentItemSkipped,
+	)
+
+	f.assertStoredDeploymentStatus(t, active, types.DeploymentStatusInactive)
+	f.assertStoredDeploymentStatus(t, f.branchDepl, types.DeploymentStatusInactive)
+	f.assertNamespaceDeleted(t, active)
+	f.assertNamespaceDeleted(t, f.branchDepl)
+
+	// only the deployment with a GitHub deployment is marked as inactive on GitHub
+	if len(f.gh.statuses) != 1 || f.gh.statuses[0] != "20:inactive" {
+		t.Errorf("expected GitHub deployment 20 to be marked as inactive, got %v", f.gh.statuses)
+	}
+}
+
+func TestBulkDeploymentReenable(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+
+	open := f.createDeployment(t, 2, types.DeploymentStatusInactive)
+	closed := f.createDeployment(t, 3, types.DeploymentStatusInactive)
+	f.gh.closedPRs[3] = true
+
+	e := f.newExecutor(t, types.BulkDeploymentActionReenable, open.ID, closed.ID, f.prDepl.ID)
+
+	if err := e.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.assertItems(t, e,
+		types.BulkDeploymentItemSucceeded,
+		types.BulkDeploymentItemSkipped,
+		types.BulkDeploymentItemSkipped,
+	)
+
+	f.assertStoredDeploymentStatus(t, open, types.DeploymentStatusCreating)
+	f.assertStoredDeploymentStatus(t, closed, types.DeploymentStatusInactive)
+
+	if len(f.gh.dispatches) != 1 || f.gh.dispatches[0] != open.PRBranchFrom {
+		t.Errorf("expected the workflow of branch %s to be dispatched, got %v", open.PRBranchFrom, f.gh.dispatches)
+	}
+}
+
+func TestBulkDeploymentReenableBlockedByFreezeWindow(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+	apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+	inactive := f.createDeployment(t, 2, types.DeploymentStatusInactive)
+
+	e := f.newExecutor(t, types.BulkDeploymentActionReenable, inactive.ID)
+
+	if err := e.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.assertItems(t, e, types.BulkDeploymentItemFailed)
+	f.assertStoredDeploymentStatus(t, inactive, types.DeploymentStatusInactive)
+
+	if len(f.gh.dispatches) != 0 {
+		t.Errorf("expected no workflows to be dispatched, got %v", f.gh.dispatches)
+	}
+}
+
+func TestBulkDeploymentDelete(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+
+	e := f.newExecutor(t, types.BulkDeploymentActionDelete, f.prDepl.ID, f.branchDepl.ID)
+
+	if err := e.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.assertItems(t, e, types.BulkDeploymentItemSucceeded, types.BulkDeploymentItemSucceeded)
+
+	for _, depl := range []*models.Deployment{f.prDepl, f.branchDepl} {
+		_, err := f.config.Repo.Environment().ReadDeploymentByID(f.project.ID, f.cluster.ID, depl.ID)
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("expected deployment %d to be deleted, got %v", depl.ID, err)
+		}
+
+		f.assertNamespaceDeleted(t, depl)
+	}
+}
+
+func TestBulkDeploymentPartialFailure(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+
+	// a failed item does not stop the operation
+	e := f.newExecutor(t, types.BulkDeploymentActionDelete, f.prDepl.ID, 99, f.branchDepl.ID)
+
+	if err := e.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	items := f.assertItems(t, e,
+		types.BulkDeploymentItemSucceeded,
+		types.BulkDeploymentItemFailed,
+		types.BulkDeploymentItemSucceeded,
+	)
+
+	if items[1].Message == "" {
+		t.Errorf("expected the failed item to have a message")
+	}
+}
+
+func TestBulkDeploymentResumesInterruptedItems(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+
+	e := f.newExecutor(t, types.BulkDeploymentActionDisable, f.prDepl.ID, f.branchDepl.ID)
+
+	// the first item was interrupted after its deployment was disabled, and the second item
+	// already has a result
+	e.op.Items[0].Status = types.BulkDeploymentItemRunning
+	e.op.Items[1].Status = types.BulkDeploymentItemFailed
+
+	for i := range e.op.Items {
+		if _, err := f.config.Repo.BulkDeploymentOperation().UpdateBulkDeploymentOperationItem(&e.op.Items[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f.prDepl.Status = types.DeploymentStatusInactive
+
+	if _, err := f.config.Repo.Environment().UpdateDeployment(f.prDepl); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.assertItems(t, e, types.BulkDeploymentItemSucceeded, types.BulkDeploymentItemFailed)
+
+	// the item which already had a result was not run again
+	f.assertStoredDeploymentStatus(t, f.branchDepl, types.DeploymentStatusCreating)
+}
+
+func TestBulkDeploymentItemJob(t *testing.T) {
+	f := newBulkDeploymentFixture(t)
+
+	inactive := f.createDeployment(t, 2, types.DeploymentStatusInactive)
+
+	e := f.newExecutor(t, types.BulkDeploymentActionDisable, inactive.ID)
+
+	jobs, err := newBulkDeploymentItemJobs(f.config, e.op, "", nil, types.FreezeOverride{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 1 {
+		t.Fatalf("expected a job for each item, got %d", len(jobs))
+	}
+
+	// the job reads the operation and its item from the repository
+	if err := runBulkDeploymentItemJob(f.config, jobs[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	f.assertItems(t, e, types.BulkDeploymentItemSkipped)
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/jira"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/tracing"
 	"gorm.io/gorm"
 )
 
@@ -33,7 +35,7 @@ func getGithubClientFromEnvironment(config *config.Config, env *models.Environme
 
 	// authenticate as github app installation
 	itr, err := ghinstallation.New(
-		http.DefaultTransport,
+		tracing.NewTransport(http.DefaultTransport),
 		int64(ghAppId),
 		int64(env.GitInstallationID),
 		config.ServerConf.GithubAppSecret,
@@ -70,6 +72,27 @@ func isGithubPRClosed(
 	return ghPR.GetState() == "closed", nil
 }
 
+// dispatchDeploymentWorkflow runs the preview environment workflow for a deployment
+func dispatchDeploymentWorkflow(
+	ctx context.Context,
+	client *github.Client,
+	env *models.Environment,
+	depl *models.Deployment,
+) (*github.Response, error) {
+	return client.Actions.CreateWorkflowDispatchEventByFileName(
+		ctx, env.GitRepoOwner, env.GitRepoName, fmt.Sprintf("porter_%s_env.yml", env.Name),
+		github.CreateWorkflowDispatchEventRequest{
+			Ref: depl.PRBranchFrom,
+			Inputs: map[string]interface{}{
+				"pr_number":      strconv.FormatUint(uint64(depl.PullRequestID), 10),
+				"pr_title":       depl.PRName,
+				"pr_branch_from": depl.PRBranchFrom,
+				"pr_branch_into": depl.PRBranchInto,
+			},
+		},
+	)
+}
+
 func validateGetDeploymentRequest(
 	projectID, clusterID, envID uint,
 	owner, name string,
@@ -124,3 +147,29 @@ func validateGetDeploymentRequest(
 
 	return depl, nil
 }
+
+// toDeploymentTypeWithJiraIssues returns a deployment along with the URLs of its linked Jira
+// issues
+func toDeploymentTypeWithJiraIssues(
+	repo repository.Repository,
+	projectID uint,
+	depl *models.Deployment,
+) (*types.Deployment, error) {
+	res := depl.ToDeploymentType()
+
+	keys := depl.GetJiraIssueKeys()
+
+	if len(keys) == 0 {
+		return res, nil
+	}
+
+	jiraInts, err := repo.JiraIntegration().ListJiraIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.JiraIssues = jira.GetIssues(jiraInts, keys)
+
+	return res, nil
+}
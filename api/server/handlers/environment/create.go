@@ -87,6 +87,13 @@ func (c *CreateEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		env.NamespaceLabels = []byte(strings.Join(labels, ","))
 	}
 
+	workflowTemplate, err := actions.GetWorkflowTemplateSpec(c.Repo(), project.ID, types.WorkflowTemplateKindPreview)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	// write Github actions files to the repo
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
@@ -191,6 +198,7 @@ func (c *CreateEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		GitInstallationID: uint(ga.InstallationID),
 		EnvironmentName:   request.Name,
 		InstanceName:      c.Config().ServerConf.InstanceName,
+		WorkflowTemplate:  workflowTemplate,
 	})
 
 	if err != nil {
@@ -0,0 +1,135 @@
+package environment
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type CreateBulkDeploymentOperationHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateBulkDeploymentOperationHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateBulkDeploymentOperationHandler {
+	return &CreateBulkDeploymentOperationHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreateBulkDeploymentOperationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.CreateBulkDeploymentOperationRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// an operation must select deployments explicitly, so that a request with no filters does
+	// not run on every deployment of the cluster
+	if len(request.EnvironmentIDs) == 0 && len(request.DeploymentIDs) == 0 &&
+		request.OlderThanHours == 0 && request.PRState == "" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("at least one of environment_ids, deployment_ids, older_than_hours or pr_state must be set"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	envIDs := make([]string, 0, len(request.EnvironmentIDs))
+
+	for _, envID := range request.EnvironmentIDs {
+		envIDs = append(envIDs, strconv.FormatUint(uint64(envID), 10))
+	}
+
+	depls, err := c.Repo().Environment().ListDeploymentsByCluster(project.ID, cluster.ID, &repository.ListOptions{
+		Filters: []repository.Filter{{Field: "environment_id", Values: envIDs}},
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	deplIDs := make(map[uint]bool)
+
+	for _, deplID := range request.DeploymentIDs {
+		deplIDs[deplID] = true
+	}
+
+	updatedBefore := time.Now().Add(-time.Duration(request.OlderThanHours) * time.Hour)
+
+	op := &models.BulkDeploymentOperation{
+		ProjectID: project.ID,
+		ClusterID: cluster.ID,
+		Action:    request.Action,
+		Status:    types.BulkDeploymentOperationRunning,
+	}
+
+	for _, depl := range depls {
+		if len(deplIDs) > 0 && !deplIDs[depl.ID] {
+			continue
+		}
+
+		if request.OlderThanHours > 0 && depl.UpdatedAt.After(updatedBefore) {
+			continue
+		}
+
+		op.Items = append(op.Items, models.BulkDeploymentOperationItem{
+			DeploymentID: depl.ID,
+			Namespace:    depl.Namespace,
+			Status:       types.BulkDeploymentItemPending,
+		})
+	}
+
+	if len(op.Items) == 0 {
+		op.Status = types.BulkDeploymentOperationCompleted
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	// the items run as jobs of the job queue, which are stored in the same transaction as the
+	// operation, so that the operation completes even if the server restarts
+	err = c.Repo().Transaction(func(tx repository.Repository) error {
+		op, err = tx.BulkDeploymentOperation().CreateBulkDeploymentOperation(op)
+
+		if err != nil {
+			return err
+		}
+
+		jobs, err := newBulkDeploymentItemJobs(c.Config(), op, request.PRState, user, request.FreezeOverride)
+
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			if _, err := tx.BackgroundJob().CreateBackgroundJob(job); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, op.ToBulkDeploymentOperationType())
+}
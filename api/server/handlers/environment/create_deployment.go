@@ -52,6 +52,15 @@ func (c *CreateDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if apiErr := commonutils.CheckFreezeWindows(
+		c.Config(), cluster, request.Namespace, "", user, request.FreezeOverride,
+	); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	// read the environment to get the environment id
 	env, err := c.Repo().Environment().ReadEnvironment(project.ID, cluster.ID, uint(ga.InstallationID), owner, name)
 
@@ -131,6 +140,8 @@ func (c *CreateDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, types.DeploymentStatusCreating, "")
+
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/porter-dev/porter/internal/integrations/ci/actions"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -68,7 +69,7 @@ func (c *DeleteEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	depls, err := c.Repo().Environment().ListDeployments(env.ID)
+	depls, err := c.Repo().Environment().ListDeployments(env.ID, nil)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -79,18 +80,24 @@ func (c *DeleteEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		if !isSystemNamespace(depl.Namespace) {
 			agent.DeleteNamespace(depl.Namespace)
 		}
-
-		if _, err := c.Repo().Environment().DeleteDeployment(depl); err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
 	}
 
 	ghWebhookID := env.GithubWebhookID
 	webhookUID := env.WebhookID
 
-	// delete the environment
-	env, err = c.Repo().Environment().DeleteEnvironment(env)
+	// delete the deployments and the environment together, so that deployments are not left
+	// behind without their environment
+	err = c.Repo().Transaction(func(tx repository.Repository) error {
+		for _, depl := range depls {
+			if _, err := tx.Environment().DeleteDeployment(depl); err != nil {
+				return err
+			}
+		}
+
+		env, err = tx.Environment().DeleteEnvironment(env)
+
+		return err
+	})
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -1,16 +1,13 @@
 package environment
 
 import (
-	"context"
 	"errors"
-	"fmt"
 	"net/http"
 
-	"github.com/google/go-github/v41/github"
-	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
@@ -20,7 +17,6 @@ import (
 
 type DeleteDeploymentHandler struct {
 	handlers.PorterHandlerReadWriter
-	authz.KubernetesAgentGetter
 }
 
 func NewDeleteDeploymentHandler(
@@ -30,7 +26,6 @@ func NewDeleteDeploymentHandler(
 ) *DeleteDeploymentHandler {
 	return &DeleteDeploymentHandler{
 		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
-		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
 	}
 }
 
@@ -58,25 +53,6 @@ func (c *DeleteDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// delete corresponding namespace
-	agent, err := c.GetAgent(r, cluster, "")
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	// make sure we do not delete any kubernetes "system" namespaces
-	if !isSystemNamespace(depl.Namespace) {
-		err = agent.DeleteNamespace(depl.Namespace)
-
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error deleting preview deployment namespace: %w",
-				err)))
-			return
-		}
-	}
-
 	// check that the environment belongs to the project and cluster IDs
 	env, err := c.Repo().Environment().ReadEnvironmentByID(project.ID, cluster.ID, depl.EnvironmentID)
 
@@ -102,32 +78,16 @@ func (c *DeleteDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	// the namespace and the GitHub deployment are torn down in the background, so that the
+	// teardown is retried if the cluster or GitHub are unavailable
+	err = enqueueDeploymentTeardown(c.Config(), env, depl)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	if depl.GHDeploymentID != 0 {
-		// set the GitHub deployment status to be inactive
-		_, _, err := client.Repositories.CreateDeploymentStatus(
-			context.Background(),
-			env.GitRepoOwner,
-			env.GitRepoName,
-			depl.GHDeploymentID,
-			&github.DeploymentStatusRequest{
-				State: github.String("inactive"),
-			},
-		)
-
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("%v: %w", errGithubAPI, err), http.StatusConflict,
-			))
-			return
-		}
-	}
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, types.DeploymentStatusInactive, "")
 
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
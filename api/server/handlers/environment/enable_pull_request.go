@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -37,7 +38,7 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
-	request := &types.PullRequest{}
+	request := &types.EnablePullRequestRequest{}
 
 	if ok := c.DecodeAndValidate(w, r, request); !ok {
 		return
@@ -93,6 +94,18 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	// the namespace of the deployment is only chosen by its workflow, so only the freeze windows
+	// of the whole cluster apply here. The windows of the namespace are checked when the workflow
+	// updates the deployment.
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if apiErr := commonutils.CheckFreezeWindows(
+		c.Config(), cluster, "", "", user, request.FreezeOverride,
+	); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -171,5 +184,7 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, types.DeploymentStatusCreating, "")
+
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
@@ -58,114 +58,73 @@ func (c *FinalizeDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	var err error
-
-	// read the environment to get the environment id
-	env, err := c.Repo().Environment().ReadEnvironment(project.ID, cluster.ID, uint(ga.InstallationID), owner, name)
+	// read the environment and the deployment in a single query
+	env, depl, err := c.Repo().Environment().ReadEnvironmentAndDeployment(
+		project.ID, cluster.ID, uint(ga.InstallationID), owner, name, request.PRNumber, request.Namespace,
+	)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
-			return
-		}
-
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	var depl *models.Deployment
-
-	// read the deployment
-	if request.PRNumber != 0 {
-		depl, err = c.Repo().Environment().ReadDeploymentByGitDetails(env.ID, owner, name, request.PRNumber)
-
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
-				return
-			}
-
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
-	} else if request.Namespace != "" {
-		depl, err = c.Repo().Environment().ReadDeployment(env.ID, request.Namespace)
-
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
+			if env == nil {
+				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
 				return
 			}
 
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
 			return
 		}
-	}
 
-	if depl == nil {
-		c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
 	depl.Subdomain = request.Subdomain
 	depl.Status = types.DeploymentStatusCreated
 
-	// update the deployment
-	depl, err = c.Repo().Environment().UpdateDeployment(depl)
+	jobs, err := getDeploymentSucceededJobs(c.Config(), env, depl)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	// update the deployment
+	depl, err = c.Repo().Environment().UpdateDeploymentWithJobs(depl, jobs...)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	// Create new deployment status to indicate deployment is ready
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, depl.Status, "")
 
-	state := "success"
-	env_url := depl.Subdomain
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
 
-	deploymentStatusRequest := github.DeploymentStatusRequest{
-		State:          &state,
-		EnvironmentURL: &env_url,
-	}
+// getDeploymentSucceededJobs returns the jobs which report a successful deployment to GitHub and
+// to the linked issue trackers
+func getDeploymentSucceededJobs(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+) ([]*models.BackgroundJob, error) {
+	// the GitHub deployment status and the PR comment are written to the job queue in the same
+	// transaction as the deployment, and are delivered in the background with retries
+	jobs := make([]*models.BackgroundJob, 0)
 
-	_, _, err = client.Repositories.CreateDeploymentStatus(
-		context.Background(),
-		env.GitRepoOwner,
-		env.GitRepoName,
-		depl.GHDeploymentID,
-		&deploymentStatusRequest,
-	)
+	// create new deployment status to indicate deployment is ready
+	statusJob, err := newDeploymentStatusJob(config, env, depl, &github.DeploymentStatusRequest{
+		State:          github.String("success"),
+		EnvironmentURL: github.String(depl.Subdomain),
+	})
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		return nil, err
 	}
 
-	if !depl.IsBranchDeploy() {
-		// add a check for the PR to be open before creating a comment
-		prClosed, err := isGithubPRClosed(client, owner, name, int(depl.PullRequestID))
-
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("error fetching details of github PR for deployment ID: %d. Error: %w",
-					depl.ID, err), http.StatusConflict,
-			))
-			return
-		}
-
-		if prClosed {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("Github PR has been closed"),
-				http.StatusConflict))
-			return
-		}
+	jobs = append(jobs, statusJob)
 
+	if !depl.IsBranchDeploy() {
 		commentBody := "## Porter Preview Environments\n"
 
 		if depl.Subdomain == "" {
@@ -180,15 +139,43 @@ func (c *FinalizeDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 			)
 		}
 
-		err = createOrUpdateComment(client, c.Repo(), env.NewCommentsDisabled, depl, github.String(commentBody))
+		// the comment is skipped if the PR has been closed by the time it is delivered
+		commentJob, err := newDeploymentCommentJob(config, env, depl, commentBody)
 
 		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
+			return nil, err
 		}
+
+		jobs = append(jobs, commentJob)
 	}
 
-	c.WriteResult(w, r, depl.ToDeploymentType())
+	jiraInts, err := config.Repo.JiraIntegration().ListJiraIntegrationsByProjectID(env.ProjectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jiraInts) > 0 {
+		jiraJob, err := newDeploymentJiraJob(config, env, depl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, jiraJob)
+	}
+
+	if env.LinearIntegrationID != 0 {
+		linearJob, err := newDeploymentLinearJob(config, env, depl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, linearJob)
+	}
+
+	return jobs, nil
 }
 
 func createOrUpdateComment(
@@ -270,7 +257,7 @@ func createGithubComment(
 
 	depl.GHPRCommentID = ghResp.GetID()
 
-	_, err = repo.Environment().UpdateDeployment(depl)
+	_, err = repo.Environment().UpdateDeploymentFields(depl, "GHPRCommentID")
 
 	if err != nil {
 		return fmt.Errorf("error updating deployment with ID: %d. Error: %w", depl.ID, err)
@@ -0,0 +1,101 @@
+package environment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func (f *deploymentFixture) finalizeDeploymentHandler() *FinalizeDeploymentHandler {
+	return NewFinalizeDeploymentHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+}
+
+func TestFinalizeDeploymentSuccessful(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentRequest{
+		PRNumber:  1,
+		Subdomain: "https://pr-1.porter.run",
+	})
+
+	f.finalizeDeploymentHandler().ServeHTTP(rr, req)
+
+	f.assertDeploymentStatus(t, rr, f.prDepl, types.DeploymentStatusCreated)
+}
+
+func TestFinalizeDeploymentByNamespace(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	// the owner and name of the repository are matched case-insensitively
+	req, rr := f.newRepoRequest(t, "Porter-Dev", "Porter", &types.FinalizeDeploymentRequest{
+		Namespace: "main-porter",
+	})
+
+	f.finalizeDeploymentHandler().ServeHTTP(rr, req)
+
+	f.assertDeploymentStatus(t, rr, f.branchDepl, types.DeploymentStatusCreated)
+}
+
+func TestFinalizeDeploymentMissingEnvironment(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "other-repo", &types.FinalizeDeploymentRequest{
+		PRNumber: 1,
+	})
+
+	f.finalizeDeploymentHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+
+	f.assertStoredDeploymentStatus(t, f.prDepl, types.DeploymentStatusCreating)
+}
+
+func TestFinalizeDeploymentMissingDeployment(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentRequest{
+		PRNumber: 2,
+	})
+
+	f.finalizeDeploymentHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+}
+
+func TestFinalizeDeploymentMissingNamespaceAndPRNumber(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentRequest{})
+
+	f.finalizeDeploymentHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusBadRequest, &types.ExternalError{
+		Error: "either namespace or pr_number must be present in request body",
+	})
+}
+
+func TestFinalizeDeploymentUpdateError(t *testing.T) {
+	f := newDeploymentFixture(t, test.UpdateDeploymentWithJobsMethod)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentRequest{
+		PRNumber: 1,
+	})
+
+	f.finalizeDeploymentHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseInternalServerError(t, rr)
+
+	f.assertStoredDeploymentStatus(t, f.prDepl, types.DeploymentStatusCreating)
+}
@@ -1,10 +1,11 @@
 package environment
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/google/go-github/v41/github"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -63,14 +64,19 @@ func (c *FinalizeDeploymentWithErrorsHandler) ServeHTTP(w http.ResponseWriter, r
 		return
 	}
 
-	var err error
-
-	// read the environment to get the environment id
-	env, err := c.Repo().Environment().ReadEnvironment(project.ID, cluster.ID, uint(ga.InstallationID), owner, name)
+	// read the environment and the deployment in a single query
+	env, depl, err := c.Repo().Environment().ReadEnvironmentAndDeployment(
+		project.ID, cluster.ID, uint(ga.InstallationID), owner, name, request.PRNumber, request.Namespace,
+	)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
+			if env == nil {
+				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
 			return
 		}
 
@@ -78,84 +84,76 @@ func (c *FinalizeDeploymentWithErrorsHandler) ServeHTTP(w http.ResponseWriter, r
 		return
 	}
 
-	var depl *models.Deployment
+	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
-	// read the deployment
-	if request.PRNumber != 0 {
-		depl, err = c.Repo().Environment().ReadDeploymentByGitDetails(env.ID, owner, name, request.PRNumber)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
 
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
-				return
-			}
+	depl.Status = types.DeploymentStatusFailed
 
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
-	} else if request.Namespace != "" {
-		depl, err = c.Repo().Environment().ReadDeployment(env.ID, request.Namespace)
+	var buildLogsURL string
 
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
-				return
-			}
+	if !depl.IsBranchDeploy() {
+		workflowRun, err := commonutils.GetLatestWorkflowRun(client, depl.RepoOwner, depl.RepoName,
+			fmt.Sprintf("porter_%s_env.yml", env.Name), depl.PRBranchFrom)
 
+		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
-	}
 
-	if depl == nil {
-		c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
-		return
+		buildLogsURL = workflowRun.GetHTMLURL()
 	}
 
-	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	jobs, err := getDeploymentFailedJobs(c.Config(), cluster, env, depl, buildLogsURL, request.SuccessfulResources, request.Errors)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	depl.Status = types.DeploymentStatusFailed
-
-	// we do not care of the error in this case because the list deployments endpoint
-	// talks to the github API to fetch the deployment status correctly
-	c.Repo().Environment().UpdateDeployment(depl)
+	depl, err = c.Repo().Environment().UpdateDeploymentWithJobs(depl, jobs...)
 
-	// FIXME: ignore the status of this API call for now
-	client.Repositories.CreateDeploymentStatus(
-		context.Background(), owner, name, depl.GHDeploymentID, &github.DeploymentStatusRequest{
-			State:       github.String("failure"),
-			Description: github.String("one or more resources failed to build"),
-		},
-	)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
 
-	if !depl.IsBranchDeploy() {
-		// add a check for the PR to be open before creating a comment
-		prClosed, err := isGithubPRClosed(client, owner, name, int(depl.PullRequestID))
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, depl.Status, getFailedResourcesSummary(request.Errors))
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
-			return
-		}
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
 
-		if prClosed {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("github PR has been closed"),
-				http.StatusConflict))
-			return
-		}
+// getDeploymentFailedJobs returns the jobs which report a failed deployment to GitHub and to the
+// linked issue trackers
+func getDeploymentFailedJobs(
+	config *config.Config,
+	cluster *models.Cluster,
+	env *models.Environment,
+	depl *models.Deployment,
+	buildLogsURL string,
+	successfulResources []*types.SuccessfullyDeployedResource,
+	errs map[string]string,
+) ([]*models.BackgroundJob, error) {
+	// the GitHub deployment status and the PR comment are written to the job queue in the same
+	// transaction as the deployment, and are delivered in the background with retries
+	jobs := make([]*models.BackgroundJob, 0)
+
+	statusJob, err := newDeploymentStatusJob(config, env, depl, &github.DeploymentStatusRequest{
+		State:       github.String("failure"),
+		Description: github.String("one or more resources failed to build"),
+		LogURL:      github.String(buildLogsURL),
+	})
 
-		workflowRun, err := commonutils.GetLatestWorkflowRun(client, depl.RepoOwner, depl.RepoName,
-			fmt.Sprintf("porter_%s_env.yml", env.Name), depl.PRBranchFrom)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
+	jobs = append(jobs, statusJob)
 
+	if !depl.IsBranchDeploy() {
 		commentBody := fmt.Sprintf(
 			"## Porter Preview Environments\n"+
 				"❌ Errors encountered while deploying the changes\n"+
@@ -163,38 +161,70 @@ func (c *FinalizeDeploymentWithErrorsHandler) ServeHTTP(w http.ResponseWriter, r
 				"|-|-|\n"+
 				"| Latest SHA | [`%s`](https://github.com/%s/%s/commit/%s) |\n"+
 				"| Build Logs | %s |\n",
-			depl.CommitSHA, depl.RepoOwner, depl.RepoName, depl.CommitSHA, workflowRun.GetHTMLURL(),
+			depl.CommitSHA, depl.RepoOwner, depl.RepoName, depl.CommitSHA, buildLogsURL,
 		)
 
-		if len(request.SuccessfulResources) > 0 {
+		if len(successfulResources) > 0 {
 			commentBody += "#### Successfully deployed resources\n"
 
-			for _, res := range request.SuccessfulResources {
+			for _, res := range successfulResources {
 				if res.ReleaseType == "job" {
 					commentBody += fmt.Sprintf("- [`%s`](%s/jobs/%s/%s/%s?project_id=%d)\n",
-						res.ReleaseName, c.Config().ServerConf.ServerURL, cluster.Name, depl.Namespace,
-						res.ReleaseName, project.ID)
+						res.ReleaseName, config.ServerConf.ServerURL, cluster.Name, depl.Namespace,
+						res.ReleaseName, env.ProjectID)
 				} else {
 					commentBody += fmt.Sprintf("- [`%s`](%s/applications/%s/%s/%s?project_id=%d)\n",
-						res.ReleaseName, c.Config().ServerConf.ServerURL, cluster.Name, depl.Namespace,
-						res.ReleaseName, project.ID)
+						res.ReleaseName, config.ServerConf.ServerURL, cluster.Name, depl.Namespace,
+						res.ReleaseName, env.ProjectID)
 				}
 			}
 		}
 
 		commentBody += "#### Failed resources\n"
 
-		for res, err := range request.Errors {
+		for res, err := range errs {
 			commentBody += fmt.Sprintf("<details>\n  <summary><code>%s</code></summary>\n\n  **Error:** %s\n</details>\n", res, err)
 		}
 
-		err = createOrUpdateComment(client, c.Repo(), env.NewCommentsDisabled, depl, github.String(commentBody))
+		// the comment is skipped if the PR has been closed by the time it is delivered
+		commentJob, err := newDeploymentCommentJob(config, env, depl, commentBody)
 
 		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
+			return nil, err
 		}
+
+		jobs = append(jobs, commentJob)
 	}
 
-	c.WriteResult(w, r, depl.ToDeploymentType())
+	if env.LinearIntegrationID != 0 {
+		linearJob, err := newDeploymentLinearJob(config, env, depl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, linearJob)
+	}
+
+	return jobs, nil
+}
+
+// getFailedResourcesSummary lists the failed resources of a deployment with their errors, sorted
+// by resource name
+func getFailedResourcesSummary(errs map[string]string) string {
+	resources := make([]string, 0, len(errs))
+
+	for res := range errs {
+		resources = append(resources, res)
+	}
+
+	sort.Strings(resources)
+
+	lines := make([]string, 0, len(resources))
+
+	for _, res := range resources {
+		lines = append(lines, fmt.Sprintf("%s: %s", res, errs[res]))
+	}
+
+	return strings.Join(lines, "\n")
 }
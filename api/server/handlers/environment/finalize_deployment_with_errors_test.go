@@ -0,0 +1,106 @@
+package environment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func (f *deploymentFixture) finalizeDeploymentWithErrorsHandler() *FinalizeDeploymentWithErrorsHandler {
+	return NewFinalizeDeploymentWithErrorsHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+}
+
+// the tests finalize the branch deployment, since the build logs of pull request deployments
+// are read from GitHub
+func TestFinalizeDeploymentWithErrorsSuccessful(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentWithErrorsRequest{
+		Namespace: "main-porter",
+		Errors: map[string]string{
+			"web": "image pull failed",
+		},
+	})
+
+	f.finalizeDeploymentWithErrorsHandler().ServeHTTP(rr, req)
+
+	f.assertDeploymentStatus(t, rr, f.branchDepl, types.DeploymentStatusFailed)
+}
+
+func TestFinalizeDeploymentWithErrorsMissingEnvironment(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "other-repo", &types.FinalizeDeploymentWithErrorsRequest{
+		Namespace: "main-porter",
+		Errors: map[string]string{
+			"web": "image pull failed",
+		},
+	})
+
+	f.finalizeDeploymentWithErrorsHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+
+	f.assertStoredDeploymentStatus(t, f.branchDepl, types.DeploymentStatusCreating)
+}
+
+func TestFinalizeDeploymentWithErrorsMissingDeployment(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentWithErrorsRequest{
+		Namespace: "other-namespace",
+		Errors: map[string]string{
+			"web": "image pull failed",
+		},
+	})
+
+	f.finalizeDeploymentWithErrorsHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+}
+
+func TestFinalizeDeploymentWithErrorsNoErrors(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentWithErrorsRequest{
+		Namespace: "main-porter",
+		Errors:    map[string]string{},
+	})
+
+	f.finalizeDeploymentWithErrorsHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusPreconditionFailed, &types.ExternalError{
+		Error: "at least one error is required to report",
+	})
+
+	f.assertStoredDeploymentStatus(t, f.branchDepl, types.DeploymentStatusCreating)
+}
+
+func TestFinalizeDeploymentWithErrorsUpdateError(t *testing.T) {
+	f := newDeploymentFixture(t, test.UpdateDeploymentWithJobsMethod)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", &types.FinalizeDeploymentWithErrorsRequest{
+		Namespace: "main-porter",
+		Errors: map[string]string{
+			"web": "image pull failed",
+		},
+	})
+
+	f.finalizeDeploymentWithErrorsHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseInternalServerError(t, rr)
+
+	f.assertStoredDeploymentStatus(t, f.branchDepl, types.DeploymentStatusCreating)
+}
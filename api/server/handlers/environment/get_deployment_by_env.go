@@ -66,5 +66,12 @@ func (c *GetDeploymentByEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *
 		return
 	}
 
-	c.WriteResult(w, r, depl.ToDeploymentType())
+	res, err := toDeploymentTypeWithJiraIssues(c.Repo(), project.ID, depl)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, res)
 }
@@ -0,0 +1,192 @@
+package environment
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/jobqueue"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type deploymentFixture struct {
+	config     *config.Config
+	project    *models.Project
+	cluster    *models.Cluster
+	ga         *integrations.GithubAppInstallation
+	env        *models.Environment
+	prDepl     *models.Deployment
+	branchDepl *models.Deployment
+}
+
+// newDeploymentFixture creates an environment with a deployment of a pull request and a
+// deployment of a branch. Jobs are only written to the job queue, so no requests are sent to
+// GitHub.
+func newDeploymentFixture(t *testing.T, failingRepoMethods ...string) *deploymentFixture {
+	config := apitest.LoadConfig(t, failingRepoMethods...)
+	config.JobQueue = jobqueue.NewQueue(config.Repo, config.Logger, &jobqueue.QueueOpts{MaxAttempts: 3})
+	config.ServerConf.GithubAppID = "1"
+	config.ServerConf.GithubAppSecret = newGithubAppSecret(t)
+
+	project, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := apitest.CreateTestCluster(t, config, project.ID)
+	cluster.NotificationsDisabled = true
+
+	ga := &integrations.GithubAppInstallation{InstallationID: 5}
+
+	env, err := config.Repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID:         project.ID,
+		ClusterID:         cluster.ID,
+		GitInstallationID: uint(ga.InstallationID),
+		GitRepoOwner:      "porter-dev",
+		GitRepoName:       "porter",
+		Name:              "preview",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prDepl, err := config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1-porter",
+		Status:        types.DeploymentStatusCreating,
+		PullRequestID: 1,
+		RepoOwner:     "porter-dev",
+		RepoName:      "porter",
+		CommitSHA:     "abc1234",
+		PRBranchFrom:  "feature",
+		PRBranchInto:  "main",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	branchDepl, err := config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "main-porter",
+		Status:        types.DeploymentStatusCreating,
+		RepoOwner:     "porter-dev",
+		RepoName:      "porter",
+		CommitSHA:     "abc1234",
+		PRBranchFrom:  "main",
+		PRBranchInto:  "main",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &deploymentFixture{config, project, cluster, ga, env, prDepl, branchDepl}
+}
+
+// newGithubAppSecret returns a private key for the GitHub app, which is only used to sign
+// tokens when a request is sent to GitHub
+func newGithubAppSecret(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+// newRepoRequest returns a request to an endpoint of a repository of the environment
+func (f *deploymentFixture) newRepoRequest(
+	t *testing.T,
+	owner, name string,
+	requestObj interface{},
+) (*http.Request, *httptest.ResponseRecorder) {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/gitrepos/5/porter-dev/porter/clusters/1/deployment", requestObj)
+	req = apitest.WithProject(t, req, f.project)
+	req = apitest.WithCluster(t, req, f.cluster)
+	req = apitest.WithGitInstallation(t, req, f.ga)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamGitRepoOwner): owner,
+		string(types.URLParamGitRepoName):  name,
+	})
+
+	return req, rr
+}
+
+// newDeploymentRequest returns a request to an endpoint of a deployment
+func (f *deploymentFixture) newDeploymentRequest(
+	t *testing.T,
+	deplID uint,
+	requestObj interface{},
+) (*http.Request, *httptest.ResponseRecorder) {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/1/deployments/1/ci_status", requestObj)
+	req = apitest.WithProject(t, req, f.project)
+	req = apitest.WithCluster(t, req, f.cluster)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		"deployment_id": fmt.Sprintf("%d", deplID),
+	})
+
+	return req, rr
+}
+
+// assertDeploymentStatus checks the status of a deployment in the response and in the
+// repository
+func (f *deploymentFixture) assertDeploymentStatus(
+	t *testing.T,
+	rr *httptest.ResponseRecorder,
+	depl *models.Deployment,
+	status types.DeploymentStatus,
+) {
+	t.Helper()
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	gotDepl := &types.Deployment{}
+
+	if err := json.NewDecoder(rr.Body).Decode(gotDepl); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotDepl.Status != status {
+		t.Errorf("expected deployment %d to be %s in the response, got %s", depl.ID, status, gotDepl.Status)
+	}
+
+	f.assertStoredDeploymentStatus(t, depl, status)
+}
+
+// assertStoredDeploymentStatus checks the status of a deployment in the repository
+func (f *deploymentFixture) assertStoredDeploymentStatus(
+	t *testing.T,
+	depl *models.Deployment,
+	status types.DeploymentStatus,
+) {
+	t.Helper()
+
+	storedDepl, err := f.config.Repo.Environment().ReadDeploymentByID(f.project.ID, f.cluster.ID, depl.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if storedDepl.Status != status {
+		t.Errorf("expected deployment %d to be %s, got %s", depl.ID, status, storedDepl.Status)
+	}
+}
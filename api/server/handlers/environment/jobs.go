@@ -0,0 +1,711 @@
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/jira"
+	"github.com/porter-dev/porter/internal/integrations/linear"
+	"github.com/porter-dev/porter/internal/jobqueue"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// jobKindDeploymentComment creates or updates the Porter comment on the pull request of
+	// a deployment
+	jobKindDeploymentComment = "preview-deployment-comment"
+
+	// jobKindDeploymentStatus creates a status for the GitHub deployment of a deployment
+	jobKindDeploymentStatus = "preview-deployment-status"
+
+	// jobKindDeploymentTeardown deletes the namespace of a deleted deployment and marks its
+	// GitHub deployment as inactive
+	jobKindDeploymentTeardown = "preview-deployment-teardown"
+
+	// jobKindDeploymentJira links a deployment to the Jira issues referenced by its pull
+	// request and commits, and comments on the linked issues
+	jobKindDeploymentJira = "preview-deployment-jira"
+
+	// jobKindDeploymentLinear attaches a deployment and its status to the Linear issues
+	// referenced by its branch
+	jobKindDeploymentLinear = "preview-deployment-linear"
+
+	// jobKindBulkDeploymentItem runs the action of a bulk deployment operation on a single
+	// deployment
+	jobKindBulkDeploymentItem = "preview-bulk-deployment-item"
+)
+
+type deploymentCommentPayload struct {
+	ProjectID    uint   `json:"project_id"`
+	ClusterID    uint   `json:"cluster_id"`
+	DeploymentID uint   `json:"deployment_id"`
+	Body         string `json:"body"`
+}
+
+type deploymentStatusPayload struct {
+	ProjectID      uint   `json:"project_id"`
+	ClusterID      uint   `json:"cluster_id"`
+	EnvironmentID  uint   `json:"environment_id"`
+	GHDeploymentID int64  `json:"gh_deployment_id"`
+	State          string `json:"state"`
+	EnvironmentURL string `json:"environment_url,omitempty"`
+	Description    string `json:"description,omitempty"`
+	LogURL         string `json:"log_url,omitempty"`
+}
+
+type deploymentJiraPayload struct {
+	ProjectID    uint `json:"project_id"`
+	ClusterID    uint `json:"cluster_id"`
+	DeploymentID uint `json:"deployment_id"`
+}
+
+type deploymentLinearPayload struct {
+	ProjectID    uint `json:"project_id"`
+	ClusterID    uint `json:"cluster_id"`
+	DeploymentID uint `json:"deployment_id"`
+}
+
+type deploymentTeardownPayload struct {
+	ProjectID      uint   `json:"project_id"`
+	ClusterID      uint   `json:"cluster_id"`
+	EnvironmentID  uint   `json:"environment_id"`
+	Namespace      string `json:"namespace"`
+	GHDeploymentID int64  `json:"gh_deployment_id"`
+}
+
+type bulkDeploymentItemPayload struct {
+	ProjectID   uint   `json:"project_id"`
+	ClusterID   uint   `json:"cluster_id"`
+	OperationID uint   `json:"operation_id"`
+	ItemID      uint   `json:"item_id"`
+	PRState     string `json:"pr_state,omitempty"`
+
+	// the user who created the operation, who may override freeze windows
+	UserID         uint   `json:"user_id,omitempty"`
+	OverrideFreeze bool   `json:"override_freeze,omitempty"`
+	OverrideReason string `json:"override_reason,omitempty"`
+}
+
+// RegisterJobHandlers registers the handlers of the background jobs which are enqueued by
+// the preview environment endpoints
+func RegisterJobHandlers(config *config.Config) {
+	config.JobQueue.Register(jobKindDeploymentComment, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeploymentCommentJob(config, job)
+	})
+
+	config.JobQueue.Register(jobKindDeploymentStatus, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeploymentStatusJob(ctx, config, job)
+	})
+
+	config.JobQueue.Register(jobKindDeploymentTeardown, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeploymentTeardownJob(ctx, config, job)
+	})
+
+	config.JobQueue.Register(jobKindDeploymentJira, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeploymentJiraJob(ctx, config, job)
+	})
+
+	config.JobQueue.Register(jobKindDeploymentLinear, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeploymentLinearJob(config, job)
+	})
+
+	config.JobQueue.Register(jobKindBulkDeploymentItem, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runBulkDeploymentItemJob(config, job)
+	})
+}
+
+// newDeploymentCommentJob returns a job which comments on the pull request of a deployment
+func newDeploymentCommentJob(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+	body string,
+) (*models.BackgroundJob, error) {
+	return config.JobQueue.NewJob(env.ProjectID, jobKindDeploymentComment, &deploymentCommentPayload{
+		ProjectID:    env.ProjectID,
+		ClusterID:    env.ClusterID,
+		DeploymentID: depl.ID,
+		Body:         body,
+	})
+}
+
+// newDeploymentStatusJob returns a job which creates a status for the GitHub deployment of a
+// deployment
+func newDeploymentStatusJob(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+	status *github.DeploymentStatusRequest,
+) (*models.BackgroundJob, error) {
+	return config.JobQueue.NewJob(env.ProjectID, jobKindDeploymentStatus, &deploymentStatusPayload{
+		ProjectID:      env.ProjectID,
+		ClusterID:      env.ClusterID,
+		EnvironmentID:  env.ID,
+		GHDeploymentID: depl.GHDeploymentID,
+		State:          status.GetState(),
+		EnvironmentURL: status.GetEnvironmentURL(),
+		Description:    status.GetDescription(),
+		LogURL:         status.GetLogURL(),
+	})
+}
+
+// newDeploymentJiraJob returns a job which comments on the Jira issues referenced by the pull
+// request and commits of a deployment
+func newDeploymentJiraJob(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+) (*models.BackgroundJob, error) {
+	return config.JobQueue.NewJob(env.ProjectID, jobKindDeploymentJira, &deploymentJiraPayload{
+		ProjectID:    env.ProjectID,
+		ClusterID:    env.ClusterID,
+		DeploymentID: depl.ID,
+	})
+}
+
+// newDeploymentLinearJob returns a job which attaches a deployment to the Linear issues
+// referenced by its branch
+func newDeploymentLinearJob(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+) (*models.BackgroundJob, error) {
+	return config.JobQueue.NewJob(env.ProjectID, jobKindDeploymentLinear, &deploymentLinearPayload{
+		ProjectID:    env.ProjectID,
+		ClusterID:    env.ClusterID,
+		DeploymentID: depl.ID,
+	})
+}
+
+// enqueueDeploymentTeardown enqueues a job which deletes the namespace of a deployment and marks
+// its GitHub deployment as inactive
+func enqueueDeploymentTeardown(config *config.Config, env *models.Environment, depl *models.Deployment) error {
+	_, err := config.JobQueue.Enqueue(env.ProjectID, jobKindDeploymentTeardown, &deploymentTeardownPayload{
+		ProjectID:      env.ProjectID,
+		ClusterID:      env.ClusterID,
+		EnvironmentID:  env.ID,
+		Namespace:      depl.Namespace,
+		GHDeploymentID: depl.GHDeploymentID,
+	})
+
+	return err
+}
+
+// newBulkDeploymentItemJobs returns a job for each item of a bulk deployment operation, which
+// are stored in the same transaction as the operation
+func newBulkDeploymentItemJobs(
+	config *config.Config,
+	op *models.BulkDeploymentOperation,
+	prState string,
+	user *models.User,
+	override types.FreezeOverride,
+) ([]*models.BackgroundJob, error) {
+	jobs := make([]*models.BackgroundJob, 0, len(op.Items))
+
+	for _, item := range op.Items {
+		payload := &bulkDeploymentItemPayload{
+			ProjectID:      op.ProjectID,
+			ClusterID:      op.ClusterID,
+			OperationID:    op.ID,
+			ItemID:         item.ID,
+			PRState:        prState,
+			OverrideFreeze: override.OverrideFreeze,
+			OverrideReason: override.OverrideReason,
+		}
+
+		if user != nil {
+			payload.UserID = user.ID
+		}
+
+		job, err := config.JobQueue.NewJob(op.ProjectID, jobKindBulkDeploymentItem, payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func runDeploymentCommentJob(config *config.Config, job *models.BackgroundJob) error {
+	payload := &deploymentCommentPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	depl, err := config.Repo.Environment().ReadDeploymentByID(payload.ProjectID, payload.ClusterID, payload.DeploymentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading deployment: %w", err)
+	}
+
+	env, err := config.Repo.Environment().ReadEnvironmentByID(payload.ProjectID, payload.ClusterID, depl.EnvironmentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment: %w", err)
+	}
+
+	client, err := getGithubClientFromEnvironment(config, env)
+
+	if err != nil {
+		return err
+	}
+
+	prClosed, err := isGithubPRClosed(client, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID))
+
+	if err != nil {
+		return fmt.Errorf("error fetching details of github PR for deployment ID: %d. Error: %w", depl.ID, err)
+	}
+
+	// comments are not added to closed pull requests
+	if prClosed {
+		return nil
+	}
+
+	return createOrUpdateComment(client, config.Repo, env.NewCommentsDisabled, depl, github.String(payload.Body))
+}
+
+func runDeploymentStatusJob(ctx context.Context, config *config.Config, job *models.BackgroundJob) error {
+	payload := &deploymentStatusPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	// deployments which were reported by an external CI system may not have a GitHub deployment
+	if payload.GHDeploymentID == 0 {
+		return nil
+	}
+
+	env, err := config.Repo.Environment().ReadEnvironmentByID(payload.ProjectID, payload.ClusterID, payload.EnvironmentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment: %w", err)
+	}
+
+	client, err := getGithubClientFromEnvironment(config, env)
+
+	if err != nil {
+		return err
+	}
+
+	status := &github.DeploymentStatusRequest{
+		State: github.String(payload.State),
+	}
+
+	if payload.EnvironmentURL != "" {
+		status.EnvironmentURL = github.String(payload.EnvironmentURL)
+	}
+
+	if payload.Description != "" {
+		status.Description = github.String(payload.Description)
+	}
+
+	if payload.LogURL != "" {
+		status.LogURL = github.String(payload.LogURL)
+	}
+
+	_, _, err = client.Repositories.CreateDeploymentStatus(
+		ctx,
+		env.GitRepoOwner,
+		env.GitRepoName,
+		payload.GHDeploymentID,
+		status,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%v: %w", errGithubAPI, err)
+	}
+
+	return nil
+}
+
+func runDeploymentTeardownJob(ctx context.Context, config *config.Config, job *models.BackgroundJob) error {
+	payload := &deploymentTeardownPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	// make sure we do not delete any kubernetes "system" namespaces
+	if !isSystemNamespace(payload.Namespace) {
+		cluster, err := config.Repo.Cluster().ReadCluster(payload.ProjectID, payload.ClusterID)
+
+		if err != nil {
+			return fmt.Errorf("error reading cluster: %w", err)
+		}
+
+		agent, err := kubernetes.GetAgentOutOfClusterConfig(
+			authz.NewOutOfClusterAgentGetter(config).GetOutOfClusterConfig(cluster),
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to get agent: %w", err)
+		}
+
+		if err := agent.DeleteNamespace(payload.Namespace); err != nil {
+			return fmt.Errorf("error deleting preview deployment namespace: %w", err)
+		}
+	}
+
+	if payload.GHDeploymentID == 0 {
+		return nil
+	}
+
+	env, err := config.Repo.Environment().ReadEnvironmentByID(payload.ProjectID, payload.ClusterID, payload.EnvironmentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment: %w", err)
+	}
+
+	client, err := getGithubClientFromEnvironment(config, env)
+
+	if err != nil {
+		return err
+	}
+
+	// set the GitHub deployment status to be inactive
+	_, _, err = client.Repositories.CreateDeploymentStatus(
+		ctx,
+		env.GitRepoOwner,
+		env.GitRepoName,
+		payload.GHDeploymentID,
+		&github.DeploymentStatusRequest{
+			State: github.String("inactive"),
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("%v: %w", errGithubAPI, err)
+	}
+
+	return nil
+}
+
+func runDeploymentJiraJob(ctx context.Context, config *config.Config, job *models.BackgroundJob) error {
+	payload := &deploymentJiraPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	jiraInts, err := config.Repo.JiraIntegration().ListJiraIntegrationsByProjectID(payload.ProjectID)
+
+	if err != nil {
+		return fmt.Errorf("error listing jira integrations: %w", err)
+	}
+
+	// the integrations may have been deleted since the job was enqueued
+	if len(jiraInts) == 0 {
+		return nil
+	}
+
+	depl, err := config.Repo.Environment().ReadDeploymentByID(payload.ProjectID, payload.ClusterID, payload.DeploymentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading deployment: %w", err)
+	}
+
+	env, err := config.Repo.Environment().ReadEnvironmentByID(payload.ProjectID, payload.ClusterID, depl.EnvironmentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment: %w", err)
+	}
+
+	client, err := getGithubClientFromEnvironment(config, env)
+
+	if err != nil {
+		return err
+	}
+
+	messages, err := getDeploymentCommitMessages(ctx, client, depl)
+
+	if err != nil {
+		return fmt.Errorf("%v: %w", errGithubAPI, err)
+	}
+
+	keys := jira.ParseIssueKeys(append([]string{depl.PRName, depl.PRBranchFrom}, messages...)...)
+
+	linked := depl.GetJiraIssueKeys()
+	isLinked := make(map[string]bool)
+
+	for _, key := range linked {
+		isLinked[key] = true
+	}
+
+	body := getJiraCommentBody(depl)
+
+	// failed comments are not retried, since retrying the job would comment on the other
+	// issues again
+	for _, key := range keys {
+		for _, jiraInt := range jiraInts {
+			if !jira.MatchesProject(jiraInt, key) {
+				continue
+			}
+
+			err := jira.NewClient(jiraInt).AddComment(key, body)
+
+			if errors.Is(err, jira.ErrIssueNotFound) {
+				continue
+			} else if err != nil {
+				config.Logger.Error().Err(err).Msgf("error commenting on jira issue %s for deployment %d", key, depl.ID)
+				continue
+			}
+
+			if !isLinked[key] {
+				isLinked[key] = true
+				linked = append(linked, key)
+			}
+
+			break
+		}
+	}
+
+	depl.JiraIssueKeys = strings.Join(linked, ",")
+
+	if _, err := config.Repo.Environment().UpdateDeploymentFields(depl, "JiraIssueKeys"); err != nil {
+		return fmt.Errorf("error updating deployment with ID: %d. Error: %w", depl.ID, err)
+	}
+
+	return nil
+}
+
+func runDeploymentLinearJob(config *config.Config, job *models.BackgroundJob) error {
+	payload := &deploymentLinearPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	depl, err := config.Repo.Environment().ReadDeploymentByID(payload.ProjectID, payload.ClusterID, payload.DeploymentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading deployment: %w", err)
+	}
+
+	env, err := config.Repo.Environment().ReadEnvironmentByID(payload.ProjectID, payload.ClusterID, depl.EnvironmentID)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment: %w", err)
+	}
+
+	// the integration may have been disabled for the environment since the job was enqueued
+	if env.LinearIntegrationID == 0 {
+		return nil
+	}
+
+	linearInt, err := config.Repo.LinearIntegration().ReadLinearIntegration(payload.ProjectID, env.LinearIntegrationID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading linear integration: %w", err)
+	}
+
+	identifiers := linear.ParseIssueIdentifiers(depl.PRBranchFrom)
+
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	client := linear.NewClient(linearInt)
+	attachment := getLinearAttachment(config, env, depl)
+
+	// Linear updates the existing attachment with the same URL, so the job can be retried
+	// without duplicating attachments
+	for _, identifier := range identifiers {
+		issueID, err := client.GetIssueID(identifier)
+
+		if errors.Is(err, linear.ErrIssueNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading linear issue %s: %w", identifier, err)
+		}
+
+		if err := client.CreateAttachment(issueID, attachment); err != nil {
+			return fmt.Errorf("error attaching deployment to linear issue %s: %w", identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// getDeploymentCommitMessages returns the messages of the commits of a deployment's pull
+// request, or of the deployed commit for branch deployments
+func getDeploymentCommitMessages(ctx context.Context, client *github.Client, depl *models.Deployment) ([]string, error) {
+	messages := make([]string, 0)
+
+	if depl.PullRequestID == 0 {
+		if depl.CommitSHA == "" {
+			return messages, nil
+		}
+
+		commit, _, err := client.Repositories.GetCommit(ctx, depl.RepoOwner, depl.RepoName, depl.CommitSHA, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return append(messages, commit.GetCommit().GetMessage()), nil
+	}
+
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		commits, resp, err := client.PullRequests.ListCommits(ctx, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID), opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commit := range commits {
+			messages = append(messages, commit.GetCommit().GetMessage())
+		}
+
+		if resp.NextPage == 0 {
+			return messages, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+// getJiraCommentBody returns the comment, in Jira's wiki markup, which is posted to the Jira
+// issues linked to a deployment
+func getJiraCommentBody(depl *models.Deployment) string {
+	repoURL := fmt.Sprintf("https://github.com/%s/%s", depl.RepoOwner, depl.RepoName)
+
+	var source string
+
+	if depl.PullRequestID != 0 {
+		source = fmt.Sprintf("[%s/%s#%d|%s/pull/%d]", depl.RepoOwner, depl.RepoName, depl.PullRequestID, repoURL, depl.PullRequestID)
+	} else {
+		source = fmt.Sprintf("branch {{%s}} of [%s/%s|%s]", depl.PRBranchFrom, depl.RepoOwner, depl.RepoName, repoURL)
+	}
+
+	body := fmt.Sprintf("Deployed %s to a Porter preview environment", source)
+
+	if depl.CommitSHA != "" {
+		shortSHA := depl.CommitSHA
+
+		if len(shortSHA) > 7 {
+			shortSHA = shortSHA[:7]
+		}
+
+		body += fmt.Sprintf(" at commit [%s|%s/commit/%s]", shortSHA, repoURL, depl.CommitSHA)
+	}
+
+	body += "."
+
+	if depl.Subdomain != "" {
+		body += fmt.Sprintf("\nPreview URL: %s", depl.Subdomain)
+	}
+
+	return body
+}
+
+// getLinearAttachment returns the attachment which links a deployment to Linear issues. The
+// attachment URL is the deployment's page on the dashboard, since it exists whether or not the
+// deployment succeeded.
+func getLinearAttachment(config *config.Config, env *models.Environment, depl *models.Deployment) *linear.Attachment {
+	var subtitle string
+
+	switch depl.Status {
+	case types.DeploymentStatusCreated:
+		subtitle = "Deployed"
+
+		if depl.Subdomain != "" {
+			subtitle = fmt.Sprintf("Deployed to %s", depl.Subdomain)
+		}
+	case types.DeploymentStatusFailed:
+		subtitle = "Deployment failed"
+	default:
+		subtitle = fmt.Sprintf("Deployment %s", depl.Status)
+	}
+
+	if depl.CommitSHA != "" {
+		shortSHA := depl.CommitSHA
+
+		if len(shortSHA) > 7 {
+			shortSHA = shortSHA[:7]
+		}
+
+		subtitle += fmt.Sprintf(" at %s", shortSHA)
+	}
+
+	return &linear.Attachment{
+		URL: fmt.Sprintf(
+			"%s/preview-environments/details/%d?environment_id=%d&project_id=%d",
+			config.ServerConf.ServerURL,
+			depl.ID,
+			depl.EnvironmentID,
+			env.ProjectID,
+		),
+		Title:    fmt.Sprintf("Porter preview: %s", env.Name),
+		Subtitle: subtitle,
+	}
+}
+
+func runBulkDeploymentItemJob(config *config.Config, job *models.BackgroundJob) error {
+	payload := &bulkDeploymentItemPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	cluster, err := config.Repo.Cluster().ReadCluster(payload.ProjectID, payload.ClusterID)
+
+	if err != nil {
+		return fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	op, err := config.Repo.BulkDeploymentOperation().ReadBulkDeploymentOperation(
+		payload.ProjectID, payload.ClusterID, payload.OperationID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error reading bulk deployment operation: %w", err)
+	}
+
+	var user *models.User
+
+	if payload.UserID != 0 {
+		user, err = config.Repo.User().ReadUser(payload.UserID)
+
+		if err != nil {
+			return fmt.Errorf("error reading user: %w", err)
+		}
+	}
+
+	executor := newBulkDeploymentExecutor(config, cluster, op, payload.PRState, user, types.FreezeOverride{
+		OverrideFreeze: payload.OverrideFreeze,
+		OverrideReason: payload.OverrideReason,
+	})
+
+	err = executor.runItem(payload.ItemID)
+
+	// an item whose job will not be retried is failed, so that the operation can complete
+	if err != nil && job.Attempts >= job.MaxAttempts {
+		if failErr := executor.failItem(payload.ItemID, err); failErr != nil {
+			config.Logger.Error().Err(failErr).Msgf("error failing item %d of bulk deployment operation %d",
+				payload.ItemID, payload.OperationID)
+		}
+	}
+
+	return err
+}
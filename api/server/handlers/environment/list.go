@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
 type ListEnvironmentHandler struct {
@@ -29,7 +30,7 @@ func (c *ListEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
-	envs, err := c.Repo().Environment().ListEnvironments(project.ID, cluster.ID)
+	envs, err := c.Repo().Environment().ListEnvironments(project.ID, cluster.ID, nil)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
@@ -43,7 +44,7 @@ func (c *ListEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	for _, env := range envs {
 		environment := env.ToEnvironmentType()
 
-		depls, err := c.Repo().Environment().ListDeployments(env.ID)
+		depls, err := c.Repo().Environment().ListDeployments(env.ID, &repository.ListOptions{ReadReplica: true})
 
 		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
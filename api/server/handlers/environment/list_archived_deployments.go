@@ -0,0 +1,69 @@
+package environment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ListArchivedDeploymentsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListArchivedDeploymentsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListArchivedDeploymentsHandler {
+	return &ListArchivedDeploymentsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListArchivedDeploymentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListArchivedDeploymentsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	opts := commonutils.GetListOptions(
+		&request.ListOptions,
+		repository.Filter{Field: "environment_id", Values: request.EnvironmentID},
+		repository.Filter{Field: "repo_owner", Values: request.RepoOwner},
+		repository.Filter{Field: "repo_name", Values: request.RepoName},
+		repository.Filter{Field: "pull_request_id", Values: request.PullRequestID},
+	)
+
+	depls, err := c.Repo().Archive().ListArchivedDeployments(project.ID, cluster.ID, opts)
+
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidListOptions) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListArchivedDeploymentsResponse, 0)
+
+	for _, depl := range depls {
+		res = append(res, depl.ToArchivedDeploymentType())
+	}
+
+	commonutils.SetNextCursor(w, opts)
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,45 @@
+package environment
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListBulkDeploymentOperationsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListBulkDeploymentOperationsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListBulkDeploymentOperationsHandler {
+	return &ListBulkDeploymentOperationsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListBulkDeploymentOperationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	ops, err := c.Repo().BulkDeploymentOperation().ListBulkDeploymentOperations(project.ID, cluster.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListBulkDeploymentOperationsResponse, 0, len(ops))
+
+	for _, op := range ops {
+		res = append(res, op.ToBulkDeploymentOperationType())
+	}
+
+	c.WriteResult(w, r, res)
+}
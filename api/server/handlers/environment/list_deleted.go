@@ -0,0 +1,55 @@
+package environment
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListDeletedEnvironmentsHandler lists the deleted preview environments of a cluster which
+// are within the retention window, and can be restored
+type ListDeletedEnvironmentsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListDeletedEnvironmentsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListDeletedEnvironmentsHandler {
+	return &ListDeletedEnvironmentsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListDeletedEnvironmentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	retentionDays := c.Config().ServerConf.SoftDeleteRetentionDays
+
+	envs, err := c.Repo().Environment().ListDeletedEnvironments(
+		project.ID, cluster.ID, time.Now().AddDate(0, 0, -int(retentionDays)),
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDeletedEnvironmentsResponse, 0)
+
+	for _, env := range envs {
+		res = append(res, &types.DeletedEnvironment{
+			Environment:  env.ToEnvironmentType(),
+			DeletionMeta: types.NewDeletionMeta(env.DeletedAt.Time, retentionDays),
+		})
+	}
+
+	c.WriteResult(w, r, res)
+}
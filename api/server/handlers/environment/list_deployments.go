@@ -13,6 +13,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -61,9 +62,19 @@ func (c *ListDeploymentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	depls, err := c.Repo().Environment().ListDeployments(env.ID)
+	opts := commonutils.GetListOptions(&req.ListOptions, repository.Filter{Field: "status", Values: req.Status})
+
+	// the deployments are only returned to the client, so they can be read from the replica
+	opts.ReadReplica = true
+
+	depls, err := c.Repo().Environment().ListDeployments(env.ID, opts)
 
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidListOptions) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
@@ -74,5 +85,6 @@ func (c *ListDeploymentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		res = append(res, depl.ToDeploymentType())
 	}
 
+	commonutils.SetNextCursor(w, opts)
 	c.WriteResult(w, r, res)
 }
@@ -2,6 +2,7 @@ package environment
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -14,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
 type ListDeploymentsByClusterHandler struct {
@@ -40,17 +42,41 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 		return
 	}
 
+	opts := commonutils.GetListOptions(&req.ListOptions, repository.Filter{Field: "status", Values: req.Status})
+
+	// the deployments are only returned to the client, so they can be read from the replica
+	opts.ReadReplica = true
+
 	var deployments []*types.Deployment
 	var pullRequests []*types.PullRequest
 
 	if req.EnvironmentID == 0 {
-		depls, err := c.Repo().Environment().ListDeploymentsByCluster(project.ID, cluster.ID)
+		depls, err := c.Repo().Environment().ListDeploymentsByCluster(project.ID, cluster.ID, opts)
+
+		if err != nil {
+			if errors.Is(err, repository.ErrInvalidListOptions) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		// the environments are read once, rather than once per deployment
+		envList, err := c.Repo().Environment().ListEnvironments(project.ID, cluster.ID, nil)
 
 		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
 
+		envMap := make(map[uint]*models.Environment)
+
+		for _, env := range envList {
+			envMap[env.ID] = env
+		}
+
 		deplInfoMap := make(map[string]bool)
 
 		for _, depl := range depls {
@@ -59,10 +85,12 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 				"%s-%s-%d", deployment.RepoOwner, deployment.RepoName, deployment.PullRequestID,
 			)] = true
 
-			env, err := c.Repo().Environment().ReadEnvironmentByID(project.ID, cluster.ID, deployment.EnvironmentID)
+			env, ok := envMap[deployment.EnvironmentID]
 
-			if err != nil {
-				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			if !ok {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(
+					fmt.Errorf("environment %d of deployment %d not found", deployment.EnvironmentID, deployment.ID),
+				))
 				return
 			}
 
@@ -73,44 +101,34 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 
 		envToGithubClientMap := make(map[uint]*github.Client)
 
-		var wg sync.WaitGroup
-		wg.Add(len(deployments))
-
 		for _, deployment := range deployments {
-			env, err := c.Repo().Environment().ReadEnvironmentByID(project.ID, cluster.ID, deployment.EnvironmentID)
-
-			if err != nil {
-				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-				return
-			}
-
-			if _, ok := envToGithubClientMap[env.ID]; !ok {
-				client, err := getGithubClientFromEnvironment(c.Config(), env)
+			if _, ok := envToGithubClientMap[deployment.EnvironmentID]; !ok {
+				client, err := getGithubClientFromEnvironment(c.Config(), envMap[deployment.EnvironmentID])
 
 				if err != nil {
 					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 					return
 				}
 
-				envToGithubClientMap[env.ID] = client
+				envToGithubClientMap[deployment.EnvironmentID] = client
 			}
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(len(deployments))
 
+		for _, deployment := range deployments {
 			go func(depl *types.Deployment) {
 				defer wg.Done()
 
-				updateDeploymentWithGithubWorkflowRunStatus(c.Config(), envToGithubClientMap[env.ID], env, depl)
+				updateDeploymentWithGithubWorkflowRunStatus(
+					c.Config(), envToGithubClientMap[depl.EnvironmentID], envMap[depl.EnvironmentID], depl,
+				)
 			}(deployment)
 		}
 
 		wg.Wait()
 
-		envList, err := c.Repo().Environment().ListEnvironments(project.ID, cluster.ID)
-
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
-
 		for _, env := range envList {
 			if _, ok := envToGithubClientMap[env.ID]; !ok {
 				client, err := getGithubClientFromEnvironment(c.Config(), env)
@@ -140,9 +158,14 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 			return
 		}
 
-		depls, err := c.Repo().Environment().ListDeployments(env.ID)
+		depls, err := c.Repo().Environment().ListDeployments(env.ID, opts)
 
 		if err != nil {
+			if errors.Is(err, repository.ErrInvalidListOptions) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+				return
+			}
+
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
@@ -190,6 +213,7 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 		pullRequests = append(pullRequests, prs...)
 	}
 
+	commonutils.SetNextCursor(w, opts)
 	c.WriteResult(w, r, map[string]interface{}{
 		"pull_requests": pullRequests,
 		"deployments":   deployments,
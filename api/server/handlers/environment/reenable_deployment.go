@@ -3,13 +3,12 @@ package environment
 import (
 	"fmt"
 	"net/http"
-	"strconv"
 
-	"github.com/google/go-github/v41/github"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
@@ -43,6 +42,12 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	request := &types.ReenableDeploymentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
 	depl, err := c.Repo().Environment().ReadDeploymentByID(project.ID, cluster.ID, deplID)
 
 	if err != nil {
@@ -64,6 +69,15 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if apiErr := commonutils.CheckFreezeWindows(
+		c.Config(), cluster, depl.Namespace, "", user, request.FreezeOverride,
+	); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -99,18 +113,7 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	ghResp, err := client.Actions.CreateWorkflowDispatchEventByFileName(
-		r.Context(), env.GitRepoOwner, env.GitRepoName, fmt.Sprintf("porter_%s_env.yml", env.Name),
-		github.CreateWorkflowDispatchEventRequest{
-			Ref: depl.PRBranchFrom,
-			Inputs: map[string]interface{}{
-				"pr_number":      strconv.FormatUint(uint64(depl.PullRequestID), 10),
-				"pr_title":       depl.PRName,
-				"pr_branch_from": depl.PRBranchFrom,
-				"pr_branch_into": depl.PRBranchInto,
-			},
-		},
-	)
+	ghResp, err := dispatchDeploymentWorkflow(r.Context(), client, env, depl)
 
 	if ghResp != nil && ghResp.StatusCode == 404 {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("workflow file not found"), 404))
@@ -0,0 +1,49 @@
+package environment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func (f *deploymentFixture) reenableDeploymentHandler() *ReenableDeploymentHandler {
+	return NewReenableDeploymentHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+}
+
+func TestReenableDeploymentBlockedByFreezeWindow(t *testing.T) {
+	f := newDeploymentFixture(t)
+	apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+	depl, err := f.config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: f.env.ID,
+		Namespace:     "pr-2-porter",
+		Status:        types.DeploymentStatusInactive,
+		PullRequestID: 2,
+		RepoOwner:     "porter-dev",
+		RepoName:      "porter",
+		PRBranchFrom:  "fix",
+		PRBranchInto:  "main",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := f.newDeploymentRequest(t, depl.ID, &types.ReenableDeploymentRequest{})
+
+	f.reenableDeploymentHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusLocked {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusLocked, rr.Code, rr.Body.String())
+	}
+
+	f.assertStoredDeploymentStatus(t, depl, types.DeploymentStatusInactive)
+}
@@ -0,0 +1,151 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReportCIStatusHandler receives build statuses of deployments from CI systems other than GitHub
+// Actions, such as Jenkins, CircleCI and Buildkite
+type ReportCIStatusHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewReportCIStatusHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ReportCIStatusHandler {
+	return &ReportCIStatusHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ReportCIStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	deplID, reqErr := requestutils.GetURLParamUint(r, "deployment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.ReportCIStatusRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	depl, err := c.Repo().Environment().ReadDeploymentByID(project.ID, cluster.ID, deplID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	env, err := c.Repo().Environment().ReadEnvironmentByID(project.ID, cluster.ID, depl.EnvironmentID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if depl.Status == types.DeploymentStatusInactive && request.Status != types.CIBuildStarted {
+		// a deployment from "inactive" state can only transition to "creating"
+		c.WriteResult(w, r, depl.ToDeploymentType())
+		return
+	}
+
+	if request.CommitSHA != "" {
+		depl.CommitSHA = request.CommitSHA
+	}
+
+	var jobs []*models.BackgroundJob
+	var info string
+
+	switch request.Status {
+	case types.CIBuildStarted:
+		depl.Status = types.DeploymentStatusCreating
+
+		statusJob, err := newDeploymentStatusJob(c.Config(), env, depl, &github.DeploymentStatusRequest{
+			State:       github.String("in_progress"),
+			Description: github.String(fmt.Sprintf("build started in %s", request.Provider)),
+			LogURL:      github.String(request.BuildURL),
+		})
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		jobs = append(jobs, statusJob)
+	case types.CIBuildSucceeded:
+		if request.Subdomain != "" {
+			depl.Subdomain = request.Subdomain
+		}
+
+		depl.Status = types.DeploymentStatusCreated
+
+		jobs, err = getDeploymentSucceededJobs(c.Config(), env, depl)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	case types.CIBuildFailed:
+		errs := request.Errors
+
+		// builds can fail before any resource is deployed, in which case the build itself is
+		// reported as the failed resource
+		if len(errs) == 0 {
+			errs = map[string]string{
+				"build": fmt.Sprintf("the %s build failed", request.Provider),
+			}
+		}
+
+		depl.Status = types.DeploymentStatusFailed
+		info = getFailedResourcesSummary(errs)
+
+		jobs, err = getDeploymentFailedJobs(c.Config(), cluster, env, depl, request.BuildURL, request.SuccessfulResources, errs)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	depl, err = c.Repo().Environment().UpdateDeploymentWithJobs(depl, jobs...)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, depl.Status, info)
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
@@ -0,0 +1,95 @@
+package environment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func (f *deploymentFixture) reportCIStatusHandler() *ReportCIStatusHandler {
+	return NewReportCIStatusHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+}
+
+func TestReportCIStatusTransitions(t *testing.T) {
+	transitions := map[types.CIBuildStatus]types.DeploymentStatus{
+		types.CIBuildStarted:   types.DeploymentStatusCreating,
+		types.CIBuildSucceeded: types.DeploymentStatusCreated,
+		types.CIBuildFailed:    types.DeploymentStatusFailed,
+	}
+
+	for buildStatus, deplStatus := range transitions {
+		t.Run(string(buildStatus), func(t *testing.T) {
+			f := newDeploymentFixture(t)
+
+			req, rr := f.newDeploymentRequest(t, f.prDepl.ID, &types.ReportCIStatusRequest{
+				Provider: "jenkins",
+				Status:   buildStatus,
+				BuildURL: "https://jenkins.example.com/job/porter/1",
+			})
+
+			f.reportCIStatusHandler().ServeHTTP(rr, req)
+
+			f.assertDeploymentStatus(t, rr, f.prDepl, deplStatus)
+		})
+	}
+}
+
+func TestReportCIStatusMissingDeployment(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	req, rr := f.newDeploymentRequest(t, 3, &types.ReportCIStatusRequest{
+		Provider: "circleci",
+		Status:   types.CIBuildSucceeded,
+	})
+
+	f.reportCIStatusHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+}
+
+func TestReportCIStatusMissingEnvironment(t *testing.T) {
+	f := newDeploymentFixture(t)
+
+	// the deployments of a deleted environment are still found, but can't be updated
+	if _, err := f.config.Repo.Environment().DeleteEnvironment(f.env); err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := f.newDeploymentRequest(t, f.prDepl.ID, &types.ReportCIStatusRequest{
+		Provider: "buildkite",
+		Status:   types.CIBuildSucceeded,
+	})
+
+	f.reportCIStatusHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+
+	f.assertStoredDeploymentStatus(t, f.prDepl, types.DeploymentStatusCreating)
+}
+
+func TestReportCIStatusUpdateError(t *testing.T) {
+	f := newDeploymentFixture(t, test.UpdateDeploymentWithJobsMethod)
+
+	req, rr := f.newDeploymentRequest(t, f.prDepl.ID, &types.ReportCIStatusRequest{
+		Provider: "other",
+		Status:   types.CIBuildFailed,
+	})
+
+	f.reportCIStatusHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseInternalServerError(t, rr)
+
+	f.assertStoredDeploymentStatus(t, f.prDepl, types.DeploymentStatusCreating)
+}
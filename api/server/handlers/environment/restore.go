@@ -0,0 +1,163 @@
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/integrations/ci/actions"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// RestoreEnvironmentHandler restores a deleted preview environment. Since deleting an
+// environment removes its webhook and workflow files from the repository, these are created
+// again. Deployments are not restored, and are created by the next pull request event.
+type RestoreEnvironmentHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRestoreEnvironmentHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RestoreEnvironmentHandler {
+	return &RestoreEnvironmentHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *RestoreEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	envID, reqErr := requestutils.GetURLParamUint(r, "environment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	retentionDays := c.Config().ServerConf.SoftDeleteRetentionDays
+
+	env, err := c.Repo().Environment().RestoreEnvironment(
+		project.ID, cluster.ID, envID, time.Now().AddDate(0, 0, -int(retentionDays)),
+	)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(
+				errors.New("environment not found, or deleted before the retention window"),
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if apiErr := c.setupRepository(user, env); apiErr != nil {
+		// delete the environment again, so that it is not left restored without its webhook
+		if _, deleteErr := c.Repo().Environment().DeleteEnvironment(env); deleteErr != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(deleteErr))
+		}
+
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	c.WriteResult(w, r, env.ToEnvironmentType())
+}
+
+// setupRepository creates the webhook and workflow files of a restored environment
+func (c *RestoreEnvironmentHandler) setupRepository(user *models.User, env *models.Environment) apierrors.RequestError {
+	workflowTemplate, err := actions.GetWorkflowTemplateSpec(c.Repo(), env.ProjectID, types.WorkflowTemplateKindPreview)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	client, err := getGithubClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	hook, _, err := client.Repositories.CreateHook(
+		context.Background(), env.GitRepoOwner, env.GitRepoName, &github.Hook{
+			Config: map[string]interface{}{
+				"url":          getGithubWebhookURLFromUID(c.Config().ServerConf.ServerURL, env.WebhookID),
+				"content_type": "json",
+				"secret":       c.Config().ServerConf.GithubIncomingWebhookSecret,
+			},
+			Events: []string{"pull_request", "push"},
+			Active: github.Bool(true),
+		},
+	)
+
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGithubAPI, err), http.StatusConflict)
+	}
+
+	if hook != nil {
+		env.GithubWebhookID = hook.GetID()
+
+		if _, err := c.Repo().Environment().UpdateEnvironment(env); err != nil {
+			return apierrors.NewErrInternal(err)
+		}
+	}
+
+	jwt, err := token.GetTokenForAPI(user.ID, env.ProjectID)
+
+	if err != nil {
+		return apierrors.NewErrInternal(fmt.Errorf("error getting token for API: %w", err))
+	}
+
+	encoded, err := jwt.EncodeToken(c.Config().TokenConf)
+
+	if err != nil {
+		return apierrors.NewErrInternal(fmt.Errorf("error encoding API token: %w", err))
+	}
+
+	err = actions.SetupEnv(&actions.EnvOpts{
+		Client:            client,
+		ServerURL:         c.Config().ServerConf.ServerURL,
+		PorterToken:       encoded,
+		GitRepoOwner:      env.GitRepoOwner,
+		GitRepoName:       env.GitRepoName,
+		ProjectID:         env.ProjectID,
+		ClusterID:         env.ClusterID,
+		GitInstallationID: env.GitInstallationID,
+		EnvironmentName:   env.Name,
+		InstanceName:      c.Config().ServerConf.InstanceName,
+		WorkflowTemplate:  workflowTemplate,
+	})
+
+	if err != nil {
+		unwrappedErr := errors.Unwrap(err)
+
+		// when the default branch is protected, a pull request which adds the workflow files
+		// is opened instead, so the environment stays restored
+		if unwrappedErr != nil && errors.Is(unwrappedErr, actions.ErrCreatePRForProtectedBranch) {
+			return nil
+		} else if unwrappedErr != nil && errors.Is(unwrappedErr, actions.ErrProtectedBranch) {
+			return apierrors.NewErrPassThroughToClient(err, http.StatusConflict)
+		}
+
+		return apierrors.NewErrInternal(fmt.Errorf("error setting up preview environment in the github "+
+			"repo: %w", err))
+	}
+
+	return nil
+}
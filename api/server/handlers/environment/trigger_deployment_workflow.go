@@ -44,6 +44,12 @@ func (c *TriggerDeploymentWorkflowHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	request := &types.TriggerDeploymentWorkflowRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
 	depl, err := c.Repo().Environment().ReadDeploymentByID(project.ID, cluster.ID, deplID)
 
 	if err != nil {
@@ -65,6 +71,15 @@ func (c *TriggerDeploymentWorkflowHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if apiErr := commonutils.CheckFreezeWindows(
+		c.Config(), cluster, depl.Namespace, "", user, request.FreezeOverride,
+	); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -57,47 +57,39 @@ func (c *UpdateDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var err error
-
-	// read the environment to get the environment id
-	env, err := c.Repo().Environment().ReadEnvironment(project.ID, cluster.ID, uint(ga.InstallationID), owner, name)
+	// read the environment and the deployment in a single query
+	env, depl, err := c.Repo().Environment().ReadEnvironmentAndDeployment(
+		project.ID, cluster.ID, uint(ga.InstallationID), owner, name, request.PRNumber, request.Namespace,
+	)
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	var depl *models.Deployment
-
-	// read the deployment
-	if request.PRNumber != 0 {
-		depl, err = c.Repo().Environment().ReadDeploymentByGitDetails(env.ID, owner, name, request.PRNumber)
-
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if env == nil {
+				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
 				return
 			}
 
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
 			return
 		}
-	} else if request.Namespace != "" {
-		depl, err = c.Repo().Environment().ReadDeployment(env.ID, request.Namespace)
 
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
-				return
-			}
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
 
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
+	// pull request deployments are moved to the namespace of the request
+	namespace := depl.Namespace
+
+	if !depl.IsBranchDeploy() {
+		namespace = request.Namespace
 	}
 
-	if depl == nil {
-		c.HandleAPIError(w, r, apierrors.NewErrNotFound(errDeploymentNotFound))
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if apiErr := commonutils.CheckFreezeWindows(
+		c.Config(), cluster, namespace, "", user, request.FreezeOverride,
+	); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
 		return
 	}
 
@@ -0,0 +1,71 @@
+package environment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+)
+
+func (f *deploymentFixture) updateDeploymentHandler() *UpdateDeploymentHandler {
+	return NewUpdateDeploymentHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+}
+
+func newUpdateDeploymentRequest(override types.FreezeOverride) *types.UpdateDeploymentRequest {
+	return &types.UpdateDeploymentRequest{
+		CreateGHDeploymentRequest: &types.CreateGHDeploymentRequest{
+			ActionID: 1,
+		},
+		PRBranchFrom:   "feature",
+		CommitSHA:      "def5678",
+		PRNumber:       1,
+		Namespace:      "pr-1-porter",
+		FreezeOverride: override,
+	}
+}
+
+func TestUpdateDeploymentBlockedByFreezeWindow(t *testing.T) {
+	f := newDeploymentFixture(t)
+	apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", newUpdateDeploymentRequest(types.FreezeOverride{}))
+
+	f.updateDeploymentHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusLocked {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusLocked, rr.Code, rr.Body.String())
+	}
+
+	depl, err := f.config.Repo.Environment().ReadDeploymentByID(f.project.ID, f.cluster.ID, f.prDepl.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if depl.CommitSHA != f.prDepl.CommitSHA {
+		t.Errorf("expected the commit of the deployment to be %s, got %s", f.prDepl.CommitSHA, depl.CommitSHA)
+	}
+}
+
+func TestUpdateDeploymentFreezeOverrideRequiresReason(t *testing.T) {
+	f := newDeploymentFixture(t)
+	user := apitest.CreateTestUser(t, f.config, true)
+	apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+	req, rr := f.newRepoRequest(t, "porter-dev", "porter", newUpdateDeploymentRequest(types.FreezeOverride{
+		OverrideFreeze: true,
+	}))
+	req = apitest.WithAuthenticatedUser(t, req, user)
+
+	f.updateDeploymentHandler().ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusBadRequest, &types.ExternalError{
+		Error: "a reason is required to override freeze window test-freeze",
+	})
+}
@@ -145,6 +145,28 @@ func (c *UpdateEnvironmentSettingsHandler) ServeHTTP(w http.ResponseWriter, r *h
 		changed = true
 	}
 
+	if request.LinearIntegrationID != env.LinearIntegrationID {
+		if request.LinearIntegrationID != 0 {
+			_, err := c.Repo().LinearIntegration().ReadLinearIntegration(project.ID, request.LinearIntegrationID)
+
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					c.HandleAPIError(w, r, apierrors.NewErrNotFound(
+						fmt.Errorf("no such linear integration with ID: %d", request.LinearIntegrationID),
+					))
+
+					return
+				}
+
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+
+		env.LinearIntegrationID = request.LinearIntegrationID
+		changed = true
+	}
+
 	if len(request.NamespaceLabels) > 0 {
 		var labels []string
 
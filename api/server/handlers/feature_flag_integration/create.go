@@ -0,0 +1,94 @@
+package feature_flag_integration
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/featureflags"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+type FeatureFlagIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewFeatureFlagIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *FeatureFlagIntegrationCreateHandler {
+	return &FeatureFlagIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *FeatureFlagIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateFeatureFlagIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.URL == "" {
+		if request.Provider == types.FeatureFlagProviderUnleash {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("url is required for unleash integrations"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		request.URL = featureflags.LaunchDarklyURL
+	} else if err := netguard.ValidateURL(r.Context(), request.URL); err != nil {
+		// the provider's API is called from inside the network of Porter, so URLs which
+		// resolve to internal addresses are rejected
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	ffInt := &integrations.FeatureFlagIntegration{
+		UserID:      user.ID,
+		ProjectID:   project.ID,
+		Name:        request.Name,
+		Provider:    request.Provider,
+		URL:         request.URL,
+		FlagProject: request.FlagProject,
+		APIToken:    []byte(request.APIToken),
+	}
+
+	client, err := featureflags.NewClient(ffInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := client.Validate(r.Context()); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not read project %s with the api token: %w", request.FlagProject, err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	ffInt, err = p.Repo().FeatureFlagIntegration().CreateFeatureFlagIntegration(ffInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, ffInt.ToFeatureFlagIntegrationType())
+}
@@ -0,0 +1,44 @@
+package feature_flag_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type FeatureFlagIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewFeatureFlagIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *FeatureFlagIntegrationListHandler {
+	return &FeatureFlagIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *FeatureFlagIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	ffInts, err := p.Repo().FeatureFlagIntegration().ListFeatureFlagIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListFeatureFlagIntegrationsResponse, 0)
+
+	for _, ffInt := range ffInts {
+		res = append(res, ffInt.ToFeatureFlagIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
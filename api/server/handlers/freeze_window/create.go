@@ -0,0 +1,89 @@
+package freeze_window
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/freeze"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type FreezeWindowCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewFreezeWindowCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *FreezeWindowCreateHandler {
+	return &FreezeWindowCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *FreezeWindowCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if apiErr := checkAdmin(p.Config(), project, user); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	request := &types.CreateFreezeWindowRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if _, err := p.Repo().Cluster().ReadCluster(project.ID, request.ClusterID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("cluster %d not found", request.ClusterID),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	window := &models.FreezeWindow{
+		ProjectID:       project.ID,
+		ClusterID:       request.ClusterID,
+		Namespaces:      strings.Join(request.Namespaces, ","),
+		Name:            request.Name,
+		Reason:          request.Reason,
+		Schedule:        request.Schedule,
+		DurationMinutes: request.DurationMinutes,
+		Timezone:        request.Timezone,
+		StartsAt:        request.StartsAt,
+		EndsAt:          request.EndsAt,
+		OverrideUserIDs: models.FormatIDs(request.OverrideUserIDs),
+	}
+
+	if err := freeze.Validate(window); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	window, err := p.Repo().FreezeWindow().CreateFreezeWindow(window)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, window.ToFreezeWindowType())
+}
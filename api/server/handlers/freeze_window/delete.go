@@ -0,0 +1,63 @@
+package freeze_window
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type FreezeWindowDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewFreezeWindowDeleteHandler(
+	config *config.Config,
+) *FreezeWindowDeleteHandler {
+	return &FreezeWindowDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *FreezeWindowDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if apiErr := checkAdmin(p.Config(), project, user); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	windowID, reqErr := requestutils.GetURLParamUint(r, types.URLParamFreezeWindowID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	window, err := p.Repo().FreezeWindow().ReadFreezeWindow(project.ID, windowID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("freeze window not found")))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := p.Repo().FreezeWindow().DeleteFreezeWindow(window); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
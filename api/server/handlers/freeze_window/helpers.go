@@ -0,0 +1,34 @@
+package freeze_window
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+var errNotAdmin = errors.New("only project admins can manage freeze windows")
+
+// checkAdmin returns an error if the user is not an admin of the project
+func checkAdmin(config *config.Config, project *models.Project, user *models.User) apierrors.RequestError {
+	role, err := config.Repo.Project().ReadProjectRole(project.ID, user.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrForbidden(fmt.Errorf("user %d is not a member of project %d", user.ID, project.ID))
+		}
+
+		return apierrors.NewErrInternal(err)
+	}
+
+	if role.Kind != types.RoleAdmin {
+		return apierrors.NewErrPassThroughToClient(errNotAdmin, http.StatusForbidden)
+	}
+
+	return nil
+}
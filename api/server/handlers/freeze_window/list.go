@@ -0,0 +1,44 @@
+package freeze_window
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type FreezeWindowListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewFreezeWindowListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *FreezeWindowListHandler {
+	return &FreezeWindowListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *FreezeWindowListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	windows, err := p.Repo().FreezeWindow().ListFreezeWindows(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListFreezeWindowsResponse, 0, len(windows))
+
+	for _, window := range windows {
+		res = append(res, window.ToFreezeWindowType())
+	}
+
+	p.WriteResult(w, r, res)
+}
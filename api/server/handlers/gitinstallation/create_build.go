@@ -0,0 +1,188 @@
+package gitinstallation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/builds"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+// CreateBuildHandler starts a buildpacks build of a branch of a GitHub repository as a job in a
+// cluster, which pushes the built image to a registry linked to the project
+type CreateBuildHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateBuildHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateBuildHandler {
+	return &CreateBuildHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateBuildHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+	ga, _ := r.Context().Value(types.GitInstallationScope).(*integrations.GithubAppInstallation)
+
+	request := &types.CreateBuildRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	owner, name, ok := commonutils.GetOwnerAndNameParams(c, w, r)
+
+	if !ok {
+		return
+	}
+
+	branch, ok := commonutils.GetBranchParam(c, w, r)
+
+	if !ok {
+		return
+	}
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	reg := registry.GetRegistryForImageRepo(registries, request.ImageRepoURI)
+
+	if reg == nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("image repository %s does not belong to a registry linked to this project", request.ImageRepoURI),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	client, err := GetGithubAppClientFromRequest(c.Config(), r)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	branchResp, _, err := client.Repositories.GetBranch(context.TODO(), owner, name, branch, false)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not read branch %s of %s/%s: %w", branch, owner, name, err),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	commitSHA := branchResp.GetCommit().GetSHA()
+
+	gitToken, err := GetGithubAppInstallationTokenFromRequest(c.Config(), r)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	_reg := registry.Registry(*reg)
+
+	dockerConfig, err := _reg.GetDockerConfigJSON(c.Repo(), c.Config().DOConf)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	tag := request.Tag
+
+	if tag == "" {
+		tag = commitSHA
+	}
+
+	builder := request.Builder
+
+	if builder == "" {
+		builder = builds.DefaultBuilder
+	}
+
+	build, err := c.Repo().Build().CreateBuild(&models.Build{
+		ProjectID:            cluster.ProjectID,
+		ClusterID:            cluster.ID,
+		Namespace:            namespace,
+		GitRepo:              fmt.Sprintf("%s/%s", owner, name),
+		GitBranch:            branch,
+		CommitSHA:            commitSHA,
+		GithubInstallationID: uint(ga.InstallationID),
+		FolderPath:           request.FolderPath,
+		Builder:              builder,
+		Image:                fmt.Sprintf("%s:%s", request.ImageRepoURI, tag),
+		Status:               types.BuildStatusQueued,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	build.JobName = fmt.Sprintf("porter-build-%d", build.ID)
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	_, err = builds.CreateBuildJob(agent.Clientset, &builds.BuildOpts{
+		Name:             build.JobName,
+		Namespace:        namespace,
+		GitRepo:          build.GitRepo,
+		CommitSHA:        commitSHA,
+		FolderPath:       request.FolderPath,
+		GitToken:         gitToken,
+		Builder:          builder,
+		Image:            build.Image,
+		DockerConfigJSON: dockerConfig,
+		Labels: map[string]string{
+			builds.BuildIDLabel: fmt.Sprintf("%d", build.ID),
+		},
+	})
+
+	if err != nil {
+		build.Status = types.BuildStatusFailed
+		build.Error = err.Error()
+	}
+
+	build, updateErr := c.Repo().Build().UpdateBuild(build)
+
+	if updateErr != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(updateErr))
+		return
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.WriteResult(w, r, build.ToBuildType())
+}
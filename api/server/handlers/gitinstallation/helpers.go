@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/models/integrations"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/tracing"
 	"golang.org/x/oauth2"
 )
 
@@ -59,7 +60,7 @@ func GetGithubAppClientFromRequest(config *config.Config, r *http.Request) (*git
 	ga, _ := r.Context().Value(types.GitInstallationScope).(*integrations.GithubAppInstallation)
 
 	itr, err := ghinstallation.NewKeyFromFile(
-		http.DefaultTransport,
+		tracing.NewTransport(http.DefaultTransport),
 		config.GithubAppConf.AppID,
 		ga.InstallationID,
 		config.GithubAppConf.SecretPath,
@@ -72,6 +73,25 @@ func GetGithubAppClientFromRequest(config *config.Config, r *http.Request) (*git
 	return github.NewClient(&http.Client{Transport: itr}), nil
 }
 
+// GetGithubAppInstallationTokenFromRequest returns an installation token for the github app
+// installation of the request, which can be used to clone the installation's repositories
+func GetGithubAppInstallationTokenFromRequest(config *config.Config, r *http.Request) (string, error) {
+	ga, _ := r.Context().Value(types.GitInstallationScope).(*integrations.GithubAppInstallation)
+
+	itr, err := ghinstallation.NewKeyFromFile(
+		tracing.NewTransport(http.DefaultTransport),
+		config.GithubAppConf.AppID,
+		ga.InstallationID,
+		config.GithubAppConf.SecretPath,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return itr.Token(context.Background())
+}
+
 type GithubAppPermissions struct {
 	Actions           string
 	Administration    string
@@ -92,7 +112,7 @@ func GetGithubAppPermissions(config *config.Config, r *http.Request) (*GithubApp
 	ga, _ := r.Context().Value(types.GitInstallationScope).(*integrations.GithubAppInstallation)
 
 	itr, err := ghinstallation.NewKeyFromFile(
-		http.DefaultTransport,
+		tracing.NewTransport(http.DefaultTransport),
 		config.GithubAppConf.AppID,
 		ga.InstallationID,
 		config.GithubAppConf.SecretPath,
@@ -0,0 +1,77 @@
+package grafana_integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/grafana"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+type GrafanaIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGrafanaIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GrafanaIntegrationCreateHandler {
+	return &GrafanaIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *GrafanaIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateGrafanaIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// Grafana is called from inside the network of Porter, so URLs which resolve to internal
+	// addresses are rejected
+	if err := netguard.ValidateURL(r.Context(), request.URL); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	grafanaInt := &integrations.GrafanaIntegration{
+		UserID:        user.ID,
+		ProjectID:     project.ID,
+		Name:          request.Name,
+		URL:           strings.TrimSuffix(request.URL, "/"),
+		FolderUID:     request.FolderUID,
+		DatasourceUID: request.DatasourceUID,
+		APIKey:        []byte(request.APIKey),
+	}
+
+	if err := grafana.NewClient(grafanaInt).ValidateAPIKey(); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not validate Grafana API key: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	grafanaInt, err := p.Repo().GrafanaIntegration().CreateGrafanaIntegration(grafanaInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, grafanaInt.ToGrafanaIntegrationType())
+}
@@ -0,0 +1,44 @@
+package grafana_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type GrafanaIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGrafanaIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GrafanaIntegrationListHandler {
+	return &GrafanaIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *GrafanaIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	grafanaInts, err := p.Repo().GrafanaIntegration().ListGrafanaIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListGrafanaIntegrationsResponse, 0)
+
+	for _, grafanaInt := range grafanaInts {
+		res = append(res, grafanaInt.ToGrafanaIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
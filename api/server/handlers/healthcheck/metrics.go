@@ -0,0 +1,31 @@
+package healthcheck
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMetricsHandler returns a handler which serves prometheus metrics for the server. The
+// database connection pool is reported through the open, in-use and idle connection counts,
+// and through the number of times and total duration that queries waited for a connection.
+func NewMetricsHandler(config *config.Config) (http.Handler, error) {
+	db, err := config.DB.DB()
+
+	if err != nil {
+		return nil, err
+	}
+
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(
+		collectors.NewDBStatsCollector(db, config.DBConf.DbName),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -51,23 +52,23 @@ func (p *HelmRepoDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if helmRepo.BasicAuthIntegrationID != 0 {
-		basicAuthInt, err := p.Repo().BasicIntegration().ReadBasicIntegration(proj.ID, helmRepo.BasicAuthIntegrationID)
-
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		} else if err == nil {
-			_, err = p.Repo().BasicIntegration().DeleteBasicIntegration(basicAuthInt)
-
-			if err != nil {
-				p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-				return
+	// the basic integration and the helm repo are deleted together, so that the helm repo is
+	// not left pointing to a deleted integration
+	err = p.Repo().Transaction(func(tx repository.Repository) error {
+		if helmRepo.BasicAuthIntegrationID != 0 {
+			basicAuthInt, err := tx.BasicIntegration().ReadBasicIntegration(proj.ID, helmRepo.BasicAuthIntegrationID)
+
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			} else if err == nil {
+				if _, err := tx.BasicIntegration().DeleteBasicIntegration(basicAuthInt); err != nil {
+					return err
+				}
 			}
 		}
-	}
 
-	err = p.Repo().HelmRepo().DeleteHelmRepo(helmRepo)
+		return tx.HelmRepo().DeleteHelmRepo(helmRepo)
+	})
 
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -1,14 +1,17 @@
 package infra
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
 type InfraListHandler struct {
@@ -34,10 +37,18 @@ func (p *InfraListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	infras, err := p.Repo().Infra().ListInfrasByProjectID(proj.ID, req.Version)
+	opts := commonutils.GetListOptions(&req.ListOptions)
+
+	infras, err := p.Repo().Infra().ListInfrasByProjectID(proj.ID, req.Version, opts)
 
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidListOptions) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
 	infraList := make([]*types.Infra, 0)
@@ -48,5 +59,6 @@ func (p *InfraListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var res types.ListProjectInfraResponse = infraList
 
+	commonutils.SetNextCursor(w, opts)
 	p.WriteResult(w, r, res)
 }
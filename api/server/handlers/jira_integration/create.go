@@ -0,0 +1,85 @@
+package jira_integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/jira"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+type JiraIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewJiraIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *JiraIntegrationCreateHandler {
+	return &JiraIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *JiraIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateJiraIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// Jira is called from inside the network of Porter, so URLs which resolve to internal
+	// addresses are rejected
+	if err := netguard.ValidateURL(r.Context(), request.BaseURL); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	jiraProjectKeys := make([]string, 0, len(request.JiraProjectKeys))
+
+	for _, key := range request.JiraProjectKeys {
+		jiraProjectKeys = append(jiraProjectKeys, strings.ToUpper(key))
+	}
+
+	jiraInt := &integrations.JiraIntegration{
+		UserID:          user.ID,
+		ProjectID:       project.ID,
+		Name:            request.Name,
+		BaseURL:         strings.TrimSuffix(request.BaseURL, "/"),
+		Email:           request.Email,
+		JiraProjectKeys: strings.Join(jiraProjectKeys, ","),
+		APIToken:        []byte(request.APIToken),
+	}
+
+	// the credentials are checked before they are stored, so that comments are not silently
+	// dropped
+	if err := jira.NewClient(jiraInt).ValidateCredentials(); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not validate Jira credentials: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	jiraInt, err := p.Repo().JiraIntegration().CreateJiraIntegration(jiraInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, jiraInt.ToJiraIntegrationType())
+}
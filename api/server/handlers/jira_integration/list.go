@@ -0,0 +1,44 @@
+package jira_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type JiraIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewJiraIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *JiraIntegrationListHandler {
+	return &JiraIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *JiraIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	jiraInts, err := p.Repo().JiraIntegration().ListJiraIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListJiraIntegrationsResponse, 0)
+
+	for _, jiraInt := range jiraInts {
+		res = append(res, jiraInt.ToJiraIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
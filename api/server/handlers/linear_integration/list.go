@@ -0,0 +1,44 @@
+package linear_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type LinearIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewLinearIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *LinearIntegrationListHandler {
+	return &LinearIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *LinearIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	linearInts, err := p.Repo().LinearIntegration().ListLinearIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListLinearIntegrationsResponse, 0)
+
+	for _, linearInt := range linearInts {
+		res = append(res, linearInt.ToLinearIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
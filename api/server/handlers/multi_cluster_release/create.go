@@ -0,0 +1,76 @@
+package multi_cluster_release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterReleaseCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewMultiClusterReleaseCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *MultiClusterReleaseCreateHandler {
+	return &MultiClusterReleaseCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *MultiClusterReleaseCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateMultiClusterReleaseRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	releases, err := p.Repo().MultiClusterRelease().ListMultiClusterReleases(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, release := range releases {
+		if release.Name == request.Name {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("multi-cluster release %s already exists", request.Name),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	targets, ok := toTargets(p, w, r, project.ID, request.Targets)
+
+	if !ok {
+		return
+	}
+
+	release, err := p.Repo().MultiClusterRelease().CreateMultiClusterRelease(&models.MultiClusterRelease{
+		ProjectID:         project.ID,
+		Name:              request.Name,
+		Strategy:          request.Strategy,
+		RollbackOnFailure: request.RollbackOnFailure,
+		Targets:           targets,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, release.ToMultiClusterReleaseType())
+}
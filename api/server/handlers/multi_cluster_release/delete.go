@@ -0,0 +1,46 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterReleaseDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewMultiClusterReleaseDeleteHandler(
+	config *config.Config,
+) *MultiClusterReleaseDeleteHandler {
+	return &MultiClusterReleaseDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+// ServeHTTP deletes a multi-cluster release. The releases in the target clusters are left
+// unchanged.
+func (p *MultiClusterReleaseDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	if ok := checkNoRunningRollout(p, w, r, release); !ok {
+		return
+	}
+
+	if err := p.Repo().MultiClusterRelease().DeleteMultiClusterRelease(release); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
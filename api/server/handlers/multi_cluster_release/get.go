@@ -0,0 +1,36 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterReleaseGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewMultiClusterReleaseGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *MultiClusterReleaseGetHandler {
+	return &MultiClusterReleaseGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *MultiClusterReleaseGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	p.WriteResult(w, r, release.ToMultiClusterReleaseType())
+}
@@ -0,0 +1,42 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterRolloutGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewMultiClusterRolloutGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *MultiClusterRolloutGetHandler {
+	return &MultiClusterRolloutGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *MultiClusterRolloutGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	rollout, ok := readMultiClusterRollout(p, w, r, release)
+
+	if !ok {
+		return
+	}
+
+	p.WriteResult(w, r, rollout.ToMultiClusterRolloutType())
+}
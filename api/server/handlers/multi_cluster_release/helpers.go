@@ -0,0 +1,251 @@
+package multi_cluster_release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	releaseHandler "github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// readMultiClusterRelease reads the multi-cluster release in the URL params of a request, and
+// writes an error and returns false if it cannot be read
+func readMultiClusterRelease(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+) (*models.MultiClusterRelease, bool) {
+	releaseID, reqErr := requestutils.GetURLParamUint(r, types.URLParamMultiClusterReleaseID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	release, err := p.Repo().MultiClusterRelease().ReadMultiClusterRelease(projectID, releaseID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("multi-cluster release not found")))
+			return nil, false
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return release, true
+}
+
+// readMultiClusterRollout reads the rollout of a multi-cluster release in the URL params of a
+// request, and writes an error and returns false if it cannot be read
+func readMultiClusterRollout(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	release *models.MultiClusterRelease,
+) (*models.MultiClusterRollout, bool) {
+	rolloutID, reqErr := requestutils.GetURLParamUint(r, types.URLParamMultiClusterRolloutID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	rollout, err := p.Repo().MultiClusterRelease().ReadMultiClusterRollout(release.ID, rolloutID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("multi-cluster rollout not found")))
+			return nil, false
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return rollout, true
+}
+
+// toTargets converts the targets of a request to the targets of a multi-cluster release, in
+// the order of the request. Every target must be a cluster of the project, and each cluster
+// and namespace can only be a target once.
+func toTargets(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+	reqTargets []types.MultiClusterTarget,
+) ([]models.MultiClusterReleaseTarget, bool) {
+	targets := make([]models.MultiClusterReleaseTarget, 0, len(reqTargets))
+	seen := make(map[types.MultiClusterTarget]bool)
+
+	for i, target := range reqTargets {
+		if seen[target] {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("namespace %s of cluster %d is a target more than once", target.Namespace, target.ClusterID),
+				http.StatusBadRequest,
+			))
+
+			return nil, false
+		}
+
+		seen[target] = true
+
+		if _, err := p.Repo().Cluster().ReadCluster(projectID, target.ClusterID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("cluster %d not found in project", target.ClusterID),
+					http.StatusBadRequest,
+				))
+
+				return nil, false
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return nil, false
+		}
+
+		targets = append(targets, models.MultiClusterReleaseTarget{
+			ClusterID: target.ClusterID,
+			Namespace: target.Namespace,
+			Position:  uint(i),
+		})
+	}
+
+	return targets, true
+}
+
+// helmDeployer changes the release of a multi-cluster release in a target with Helm
+type helmDeployer struct {
+	config    *config.Config
+	projectID uint
+	name      string
+
+	// the user who started the rollout
+	user *models.User
+
+	// the values which targets are upgraded with
+	values map[string]interface{}
+
+	// (optional) returns the Helm agent of a namespace of a cluster. If it is not set, the agent
+	// connects to the cluster out of cluster.
+	getAgent func(cluster *models.Cluster, namespace string) (*helm.Agent, error)
+}
+
+// Upgrade upgrades the release in a target through the deploys of the release handlers, so the
+// upgrade is blocked by the freeze windows and image policies of the target. A rollout upgrades
+// its targets in order, so an upgrade which needs an approval, or which waits for another
+// upgrade of the release, fails the target.
+func (d *helmDeployer) Upgrade(target *models.MultiClusterRolloutTarget) (int, int, error) {
+	cluster, helmAgent, err := d.getHelmAgent(target)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	prevRelease, err := helmAgent.GetRelease(d.name, 0, false)
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading release %s: %w", d.name, err)
+	}
+
+	res, apiErr := releaseHandler.Deploy(d.config, &releaseHandler.DeployOpts{
+		Cluster:     cluster,
+		HelmAgent:   helmAgent,
+		HelmRelease: prevRelease,
+		User:        d.user,
+		Source:      types.DeploySourceMultiCluster,
+		Values:      d.values,
+	})
+
+	if apiErr != nil {
+		return prevRelease.Version, 0, fmt.Errorf("error upgrading release %s: %w", d.name, apiErr)
+	}
+
+	return prevRelease.Version, res.Release.Version, nil
+}
+
+func (d *helmDeployer) Rollback(target *models.MultiClusterRolloutTarget, revision int) (int, error) {
+	_, helmAgent, err := d.getHelmAgent(target)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := helmAgent.RollbackRelease(d.name, revision); err != nil {
+		return 0, fmt.Errorf("error rolling back release %s to revision %d: %w", d.name, revision, err)
+	}
+
+	rel, err := helmAgent.GetRelease(d.name, 0, false)
+
+	if err != nil {
+		return 0, fmt.Errorf("error reading release %s: %w", d.name, err)
+	}
+
+	return rel.Version, nil
+}
+
+func (d *helmDeployer) getHelmAgent(target *models.MultiClusterRolloutTarget) (*models.Cluster, *helm.Agent, error) {
+	cluster, err := d.config.Repo.Cluster().ReadCluster(d.projectID, target.ClusterID)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading cluster %d: %w", target.ClusterID, err)
+	}
+
+	if d.getAgent != nil {
+		helmAgent, err := d.getAgent(cluster, target.Namespace)
+
+		return cluster, helmAgent, err
+	}
+
+	helmAgent, err := helm.GetAgentOutOfClusterConfig(&helm.Form{
+		Cluster:           cluster,
+		Repo:              d.config.Repo,
+		DigitalOceanOAuth: d.config.DOConf,
+		Storage:           "secret",
+		Namespace:         target.Namespace,
+	}, d.config.Logger)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to cluster %d: %w", target.ClusterID, err)
+	}
+
+	return cluster, helmAgent, nil
+}
+
+// checkNoRunningRollout writes an error and returns false if the latest rollout of a
+// multi-cluster release is still running
+func checkNoRunningRollout(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	release *models.MultiClusterRelease,
+) bool {
+	rollouts, err := p.Repo().MultiClusterRelease().ListMultiClusterRollouts(release.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return false
+	}
+
+	if len(rollouts) > 0 && rollouts[0].Status == types.MultiClusterRolloutRunning {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("rollout %d of multi-cluster release %s is still running", rollouts[0].ID, release.Name),
+			http.StatusConflict,
+		))
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,88 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// upgradeTarget creates a release in a target of a rollout, runs setup, and upgrades the
+// release to a new image
+func upgradeTarget(
+	t *testing.T,
+	setup func(config *config.Config, user *models.User, cluster *models.Cluster),
+) (*helm.Agent, error) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	cluster := apitest.CreateTestCluster(t, config, 1)
+
+	helmAgent, _ := apitest.CreateTestRelease(t, config, "default", "test-release", map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "porter/app",
+			"tag":        "v1",
+		},
+	})
+
+	setup(config, user, cluster)
+
+	deployer := &helmDeployer{
+		config:    config,
+		projectID: cluster.ProjectID,
+		name:      "test-release",
+		user:      user,
+		values: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "porter/app",
+				"tag":        "v2",
+			},
+		},
+		getAgent: func(cluster *models.Cluster, namespace string) (*helm.Agent, error) {
+			return helmAgent, nil
+		},
+	}
+
+	_, _, err := deployer.Upgrade(&models.MultiClusterRolloutTarget{
+		ClusterID: cluster.ID,
+		Namespace: "default",
+	})
+
+	return helmAgent, err
+}
+
+func TestHelmDeployerUpgradeRequiresApproval(t *testing.T) {
+	helmAgent, err := upgradeTarget(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestApprovalPolicy(t, config, cluster, user.ID)
+	})
+
+	// a rollout upgrades its targets in order, so it can't wait for an approval
+	apitest.AssertRequestError(t, err, http.StatusForbidden)
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
+
+func TestHelmDeployerUpgradeBlockedByFreezeWindow(t *testing.T) {
+	helmAgent, err := upgradeTarget(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestFreezeWindow(t, config, cluster)
+	})
+
+	apitest.AssertRequestError(t, err, http.StatusLocked)
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
+
+func TestHelmDeployerUpgradeConflictsWithRunningDeploy(t *testing.T) {
+	var conf *config.Config
+	var targetCluster *models.Cluster
+
+	helmAgent, err := upgradeTarget(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		conf, targetCluster = config, cluster
+		apitest.CreateTestRunningDeploy(t, config, cluster, "default", "test-release")
+	})
+
+	// a rollout can't wait for another upgrade of the release, so its deploy is cancelled
+	apitest.AssertRequestError(t, err, http.StatusConflict)
+	apitest.AssertDeployNotQueued(t, conf, targetCluster, "default", "test-release")
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
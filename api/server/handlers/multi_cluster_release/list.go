@@ -0,0 +1,44 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterReleaseListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewMultiClusterReleaseListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *MultiClusterReleaseListHandler {
+	return &MultiClusterReleaseListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *MultiClusterReleaseListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	releases, err := p.Repo().MultiClusterRelease().ListMultiClusterReleases(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListMultiClusterReleasesResponse, 0)
+
+	for _, release := range releases {
+		res = append(res, release.ToMultiClusterReleaseType())
+	}
+
+	p.WriteResult(w, r, res)
+}
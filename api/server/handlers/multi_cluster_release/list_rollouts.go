@@ -0,0 +1,50 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterRolloutListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewMultiClusterRolloutListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *MultiClusterRolloutListHandler {
+	return &MultiClusterRolloutListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *MultiClusterRolloutListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	rollouts, err := p.Repo().MultiClusterRelease().ListMultiClusterRollouts(release.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListMultiClusterRolloutsResponse, 0)
+
+	for _, rollout := range rollouts {
+		res = append(res, rollout.ToMultiClusterRolloutType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,76 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/multicluster"
+)
+
+type MultiClusterRolloutRollbackHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewMultiClusterRolloutRollbackHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *MultiClusterRolloutRollbackHandler {
+	return &MultiClusterRolloutRollbackHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP starts a rollout which rolls back every target which an upgrade rollout upgraded,
+// and returns the rollout while it runs in the background
+func (c *MultiClusterRolloutRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(c, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	upgrade, ok := readMultiClusterRollout(c, w, r, release)
+
+	if !ok {
+		return
+	}
+
+	if ok := checkNoRunningRollout(c, w, r, release); !ok {
+		return
+	}
+
+	rollout, err := multicluster.NewRollbackRollout(upgrade)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	rollout, err = c.Repo().MultiClusterRelease().CreateMultiClusterRollout(rollout)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	deployer := &helmDeployer{
+		config:    c.Config(),
+		projectID: project.ID,
+		name:      release.Name,
+	}
+
+	// the rollout is converted before it starts, since the executor changes it as it runs
+	res := rollout.ToMultiClusterRolloutType()
+
+	go multicluster.NewExecutor(c.Repo().MultiClusterRelease(), deployer, c.Config().Logger).
+		Run(rollout, false)
+
+	c.WriteResult(w, r, res)
+}
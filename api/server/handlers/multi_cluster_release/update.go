@@ -0,0 +1,61 @@
+package multi_cluster_release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MultiClusterReleaseUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewMultiClusterReleaseUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *MultiClusterReleaseUpdateHandler {
+	return &MultiClusterReleaseUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *MultiClusterReleaseUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	request := &types.UpdateMultiClusterReleaseRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	targets, ok := toTargets(p, w, r, project.ID, request.Targets)
+
+	if !ok {
+		return
+	}
+
+	release.Strategy = request.Strategy
+	release.RollbackOnFailure = request.RollbackOnFailure
+	release.Targets = targets
+
+	release, err := p.Repo().MultiClusterRelease().UpdateMultiClusterRelease(release)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, release.ToMultiClusterReleaseType())
+}
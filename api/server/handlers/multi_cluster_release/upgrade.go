@@ -0,0 +1,86 @@
+package multi_cluster_release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/multicluster"
+	"sigs.k8s.io/yaml"
+)
+
+type MultiClusterReleaseUpgradeHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewMultiClusterReleaseUpgradeHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *MultiClusterReleaseUpgradeHandler {
+	return &MultiClusterReleaseUpgradeHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP starts a rollout which upgrades the release in every target of a multi-cluster
+// release, and returns the rollout while it runs in the background
+func (c *MultiClusterReleaseUpgradeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	release, ok := readMultiClusterRelease(c, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	request := &types.UpgradeMultiClusterReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	values := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(request.Values), &values); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not parse values: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if ok := checkNoRunningRollout(c, w, r, release); !ok {
+		return
+	}
+
+	rollout, err := c.Repo().MultiClusterRelease().CreateMultiClusterRollout(multicluster.NewUpgradeRollout(release))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	deployer := &helmDeployer{
+		config:    c.Config(),
+		projectID: project.ID,
+		name:      release.Name,
+		user:      user,
+		values:    values,
+	}
+
+	// the rollout is converted before it starts, since the executor changes it as it runs
+	res := rollout.ToMultiClusterRolloutType()
+
+	go multicluster.NewExecutor(c.Repo().MultiClusterRelease(), deployer, c.Config().Logger).
+		Run(rollout, release.RollbackOnFailure)
+
+	c.WriteResult(w, r, res)
+}
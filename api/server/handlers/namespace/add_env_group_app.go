@@ -63,7 +63,31 @@ func (c *AddEnvGroupAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	cm, err = agent.AddApplicationToVersionedConfigMap(cm, request.ApplicationName)
+	if latest, err := envgroup.ToEnvGroup(cm); err == nil {
+		if apiErr := checkEnvGroupNotLinkedCopy(latest); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
+	// applications in other namespaces use a copy of the env group in their namespace, which is
+	// updated with each new version of the env group
+	if request.ApplicationNamespace != "" && request.ApplicationNamespace != namespace {
+		if err := envgroup.CopyEnvGroup(agent, cm, request.ApplicationNamespace); err != nil {
+			if errors.Is(err, envgroup.ErrLinkConflict) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	cm, err = agent.AddApplicationToVersionedConfigMap(
+		cm,
+		envgroup.FormatApplication(namespace, request.ApplicationNamespace, request.ApplicationName),
+	)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
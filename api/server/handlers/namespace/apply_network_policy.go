@@ -0,0 +1,103 @@
+package namespace
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/networkpolicy"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ApplyNetworkPolicyHandler applies a preset or custom network policy to a namespace. If
+// preview is set, the policy is not applied, and the connections it would block are
+// returned instead.
+type ApplyNetworkPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+
+	preview bool
+}
+
+func NewApplyNetworkPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApplyNetworkPolicyHandler {
+	return &ApplyNetworkPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func NewPreviewNetworkPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApplyNetworkPolicyHandler {
+	return &ApplyNetworkPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+		preview:                 true,
+	}
+}
+
+func (c *ApplyNetworkPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ApplyNetworkPolicyRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+
+	policy, err := networkpolicy.BuildNetworkPolicy(namespace, request)
+
+	if err != nil {
+		if errors.Is(err, networkpolicy.ErrInvalidPolicy) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if c.preview {
+		blocked, err := networkpolicy.PreviewNetworkPolicy(agent.Clientset, policy)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		c.WriteResult(w, r, &types.PreviewNetworkPolicyResponse{
+			Policy:             networkpolicy.ToNetworkPolicyType(policy),
+			BlockedConnections: blocked,
+		})
+
+		return
+	}
+
+	res, err := networkpolicy.ApplyNetworkPolicy(agent.Clientset, policy)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,97 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/debug"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type AttachDebugContainerHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewAttachDebugContainerHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *AttachDebugContainerHandler {
+	return &AttachDebugContainerHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP attaches the websocket connection to the TTY of a debug container. Websocket
+// messages from the client are written to the container's stdin, and the container's
+// output is written back as websocket messages.
+func (c *AttachDebugContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	safeRW := r.Context().Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	podName, _ := requestutils.GetURLParamString(r, types.URLParamPodName)
+	containerName, _ := requestutils.GetURLParamString(r, types.URLParamDebugContainerName)
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = debug.WaitForDebugContainer(r.Context(), agent.Clientset, namespace, podName, containerName)
+
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) || errors.Is(err, debug.ErrDebugContainerNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("debug container %s was not found in pod %s/%s", containerName, namespace, podName),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	stdinReader, stdinWriter := io.Pipe()
+
+	go func() {
+		defer stdinWriter.Close()
+
+		for {
+			_, msg, err := safeRW.ReadMessage()
+
+			if err != nil {
+				return
+			}
+
+			if _, err := stdinWriter.Write(msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = debug.AttachDebugContainer(agent, namespace, podName, containerName, stdinReader, safeRW)
+
+	stdinReader.Close()
+	safeRW.Close()
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+}
@@ -50,6 +50,8 @@ func (c *CloneEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	cm, _, err := agent.GetLatestVersionedConfigMap(request.Name, namespace)
 
 	if err != nil {
@@ -65,27 +67,64 @@ func (c *CloneEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	secret, _, err := agent.GetLatestVersionedSecret(request.Name, namespace)
+	store, err := envgroup.GetExternalSecretStore(cm)
 
 	if err != nil {
-		if errors.Is(err, kubernetes.IsNotFoundError) {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("error cloning env group: envgroup %s in namespace %s not found", request.Name, namespace), http.StatusNotFound,
-				"no k8s secret found for envgroup",
-			))
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	secretVars := make(map[string]string)
+
+	if store != nil {
+		// clones of env groups synced from an external secret store are synced from the same
+		// store, so the references to the secrets are cloned rather than their values
+		secretVars, err = envgroup.GetRemoteRefs(agent, cm)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	} else {
+		secret, _, err := agent.GetLatestVersionedSecret(request.Name, namespace)
+
+		if err != nil {
+			if errors.Is(err, kubernetes.IsNotFoundError) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("error cloning env group: envgroup %s in namespace %s not found", request.Name, namespace), http.StatusNotFound,
+					"no k8s secret found for envgroup",
+				))
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
 
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		for key, val := range secret.Data {
+			secretVars[key] = string(val)
+		}
+
+		secretVars = envgroup.RestoreTemplates(secretVars, envgroup.GetTemplates(secret.Annotations))
 	}
 
 	if request.CloneName == "" {
 		request.CloneName = request.Name
 	}
 
+	if apiErr := checkEnvGroupNotSynced(c.Config(), cluster.ID, request.Namespace, request.CloneName); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	if target, err := envgroup.GetEnvGroup(agent, request.CloneName, request.Namespace, 0); err == nil {
+		if apiErr := checkEnvGroupNotLinkedCopy(target); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
 	vars := make(map[string]string)
-	secretVars := make(map[string]string)
 
 	for key, val := range cm.Data {
 		if !strings.Contains(val, "PORTERSECRET") {
@@ -93,19 +132,20 @@ func (c *CloneEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	for key, val := range secret.Data {
-		secretVars[key] = string(val)
-	}
+	// the clone is resolved from the templates of the env group, rather than from its values
+	vars = envgroup.RestoreTemplates(vars, envgroup.GetTemplates(cm.Annotations))
 
-	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
-		Name:            request.CloneName,
-		Namespace:       request.Namespace,
-		Variables:       vars,
-		SecretVariables: secretVars,
+	configMap, err := envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
+		Name:                request.CloneName,
+		Namespace:           request.Namespace,
+		Variables:           vars,
+		SecretVariables:     secretVars,
+		ExternalSecretStore: store,
+		ResolveOutput:       newOutputResolver(c.Config(), cluster.ProjectID),
 	})
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		c.HandleAPIError(w, r, toCreateEnvGroupAPIError(err))
 		return
 	}
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	releaseHandler "github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -49,6 +50,11 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	namespace := r.Context().Value(types.NamespaceScope).(string)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
+	if apiErr := checkEnvGroupNotSynced(c.Config(), cluster.ID, namespace, request.Name); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	agent, err := c.GetAgent(r, cluster, namespace)
 
 	if err != nil {
@@ -56,6 +62,8 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, 0)
 
 	// if the environment group exists and has MetaVersion=1, throw an error
@@ -68,6 +76,11 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if apiErr := checkEnvGroupNotLinkedCopy(envGroup); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
 
 	if err != nil {
@@ -75,36 +88,52 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
-		Name:            request.Name,
-		Namespace:       namespace,
-		Variables:       request.Variables,
-		SecretVariables: request.SecretVariables,
-	})
+	// new env groups sync their secret variables from the external secret store of the project,
+	// if it has one
+	store, err := getExternalSecretStore(c.Config(), cluster.ProjectID)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	envGroup, err = envgroup.ToEnvGroup(configMap)
+	configMap, err := envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
+		Name:                request.Name,
+		Namespace:           namespace,
+		Variables:           request.Variables,
+		SecretVariables:     request.SecretVariables,
+		ExternalSecretStore: store,
+		ResolveOutput:       newOutputResolver(c.Config(), cluster.ProjectID),
+	})
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		c.HandleAPIError(w, r, toCreateEnvGroupAPIError(err))
 		return
 	}
 
-	releases, err := envgroup.GetSyncedReleases(helmAgent, configMap)
+	envGroup, err = envgroup.ToEnvGroup(configMap)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
+	releases := make([]*release.Release, 0)
+
+	if !request.SkipRestart {
+		releases, err = envgroup.GetSyncedReleases(helmAgent, configMap)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
 	c.WriteResult(w, r, envGroup)
 
 	// trigger rollout of new applications after writing the result
 	errors := rolloutApplications(c.Config(), cluster, helmAgent, envGroup, configMap, releases)
+	errors = append(errors, propagateEnvGroup(c.Config(), cluster, agent, envGroup, configMap, !request.SkipRestart)...)
 
 	if len(errors) > 0 {
 		errStrArr := make([]string, 0)
@@ -133,12 +162,6 @@ func rolloutApplications(
 	configMap *v1.ConfigMap,
 	releases []*release.Release,
 ) []error {
-	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
-
-	if err != nil {
-		return []error{err}
-	}
-
 	// construct the synced env section that should be written
 	newSection := &SyncedEnvSection{
 		Name:    envGroup.Name,
@@ -161,8 +184,7 @@ func rolloutApplications(
 	mu := &sync.Mutex{}
 	errors := make([]error, 0)
 
-	for i, rel := range releases {
-		index := i
+	for _, rel := range releases {
 		release := rel
 		wg.Add(1)
 
@@ -183,19 +205,20 @@ func rolloutApplications(
 				newConfig["paused"] = true
 			}
 
-			conf := &helm.UpgradeReleaseConfig{
-				Name:       releases[index].Name,
-				Cluster:    cluster,
-				Repo:       config.Repo,
-				Registries: registries,
-				Values:     newConfig,
-			}
-
-			_, err = helmAgent.UpgradeReleaseByValues(conf, config.DOConf, config.ServerConf.DisablePullSecretsInjection)
-
-			if err != nil {
+			// rollouts of an env group are not made by a user, so they cannot override freeze
+			// windows. A rollout which waits for an approval, or in the deploy queue of the
+			// release, runs later, so it is not an error.
+			_, apiErr := releaseHandler.Deploy(config, &releaseHandler.DeployOpts{
+				Cluster:     cluster,
+				HelmAgent:   helmAgent,
+				HelmRelease: release,
+				Source:      types.DeploySourceEnvGroupRollout,
+				Values:      newConfig,
+			})
+
+			if apiErr != nil {
 				mu.Lock()
-				errors = append(errors, err)
+				errors = append(errors, apiErr)
 				mu.Unlock()
 				return
 			}
@@ -0,0 +1,181 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreateEnvGroupRotationPolicyHandler creates the rotation policy of a secret variable of an env
+// group, or updates the existing policy of the variable. The first rotation is scheduled one
+// interval after the last rotation, or after now if the variable wasn't rotated yet.
+type CreateEnvGroupRotationPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateEnvGroupRotationPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateEnvGroupRotationPolicyHandler {
+	return &CreateEnvGroupRotationPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateEnvGroupRotationPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.CreateEnvGroupRotationPolicyRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	if request.Generator == "" {
+		request.Generator = types.RotationGeneratorRandom
+	}
+
+	if request.Generator == types.RotationGeneratorRDSPassword {
+		if apiErr := c.checkRDSInfra(cluster.ProjectID, request.InfraID); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
+	if apiErr := checkEnvGroupNotSynced(c.Config(), cluster.ID, namespace, request.Name); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, 0); err == nil {
+		if apiErr := checkEnvGroupNotLinkedCopy(envGroup); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+
+		if apiErr := checkEnvGroupNotExternal(envGroup); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
+	if _, _, _, err := envgroup.GetVariables(agent, request.Name, namespace, 0); err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("env group %s not found", request.Name),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	policy, err := c.Repo().EnvGroupRotationPolicy().ReadEnvGroupRotationPolicy(cluster.ID, namespace, request.Name, request.Key)
+	isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNotFound {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if isNotFound {
+		policy = &models.EnvGroupRotationPolicy{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			Namespace: namespace,
+			Name:      request.Name,
+			Key:       request.Key,
+		}
+	}
+
+	policy.Generator = request.Generator
+	policy.IntervalDays = request.IntervalDays
+	policy.Length = request.Length
+	policy.Charset = request.Charset
+	policy.InfraID = request.InfraID
+
+	from := time.Now()
+
+	if policy.LastRotatedAt != nil {
+		from = *policy.LastRotatedAt
+	}
+
+	policy.NextRotationAt = getNextRotationAt(from, policy.IntervalDays)
+
+	// a rotation which is overdue after the interval was shortened runs right away
+	if policy.NextRotationAt.Before(time.Now()) {
+		policy.NextRotationAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	if isNotFound {
+		policy, err = c.Repo().EnvGroupRotationPolicy().CreateEnvGroupRotationPolicy(policy)
+	} else {
+		policy, err = c.Repo().EnvGroupRotationPolicy().UpdateEnvGroupRotationPolicy(policy)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the job which was enqueued for the previous schedule of the policy does nothing when it runs
+	if err := enqueueEnvGroupRotation(c.Config(), policy); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToEnvGroupRotationPolicyType())
+}
+
+// checkRDSInfra returns an error which can be passed to the client if an infra can't be rotated
+// by the rds_password generator
+func (c *CreateEnvGroupRotationPolicyHandler) checkRDSInfra(projectID, infraID uint) apierrors.RequestError {
+	if infraID == 0 {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("infra_id is required for the %s generator", types.RotationGeneratorRDSPassword),
+			http.StatusBadRequest,
+		)
+	}
+
+	infra, err := c.Repo().Infra().ReadInfra(projectID, infraID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrPassThroughToClient(fmt.Errorf("infra %d not found", infraID), http.StatusNotFound)
+		}
+
+		return apierrors.NewErrInternal(err)
+	}
+
+	if infra.Kind != types.InfraRDS || infra.DatabaseID == 0 {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("infra %d is not a provisioned RDS database", infraID),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/integrations/doppler"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CreateEnvGroupSourceHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateEnvGroupSourceHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateEnvGroupSourceHandler {
+	return &CreateEnvGroupSourceHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateEnvGroupSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.CreateEnvGroupSourceRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	if request.Kind == "" {
+		request.Kind = types.EnvGroupSourceAWSSecretsManager
+	}
+
+	if apiErr := checkEnvGroupNotSynced(c.Config(), cluster.ID, namespace, request.Name); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	if apiErr := c.checkIntegration(cluster.ProjectID, request); apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	source := &models.EnvGroupSource{
+		ProjectID: cluster.ProjectID,
+		ClusterID: cluster.ID,
+		Namespace: namespace,
+		Name:      request.Name,
+		Kind:      request.Kind,
+		SecretID:  request.SecretID,
+	}
+
+	switch request.Kind {
+	case types.EnvGroupSourceDoppler:
+		source.DopplerIntegrationID = request.DopplerIntegrationID
+
+		// Doppler calls the webhook of the source when the config changes, so that the env group
+		// is synced without waiting for the next sync
+		token, err := encryption.GenerateRandomBytes(16)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		source.WebhookToken = token
+	default:
+		source.AWSIntegrationID = request.AWSIntegrationID
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, 0); err == nil {
+		if apiErr := checkEnvGroupNotLinkedCopy(envGroup); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+
+		if apiErr := checkEnvGroupNotExternal(envGroup); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	source, err = c.Repo().EnvGroupSource().CreateEnvGroupSource(source)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the source is kept if the initial sync fails, so that the error is visible and the sync is
+	// retried
+	if err := syncEnvGroupSource(r.Context(), c.Config(), cluster, agent, helmAgent, source); err != nil {
+		c.Config().Logger.Error().Err(err).Msgf("error syncing env group %s in namespace %s", source.Name, source.Namespace)
+	}
+
+	if err := enqueueEnvGroupSourceSync(c.Config(), source); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := source.ToEnvGroupSourceType()
+
+	if source.WebhookToken != "" {
+		res.WebhookURL = fmt.Sprintf("%s/api/webhooks/doppler/%s", c.Config().ServerConf.ServerURL, source.WebhookToken)
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// checkIntegration returns an error which can be passed to the client if the integration which
+// the secret store of a source is read with doesn't exist in the project
+func (c *CreateEnvGroupSourceHandler) checkIntegration(
+	projectID uint,
+	request *types.CreateEnvGroupSourceRequest,
+) apierrors.RequestError {
+	var err error
+
+	switch request.Kind {
+	case types.EnvGroupSourceDoppler:
+		if _, _, parseErr := doppler.ParseConfigID(request.SecretID); parseErr != nil {
+			return apierrors.NewErrPassThroughToClient(parseErr, http.StatusBadRequest)
+		}
+
+		if request.DopplerIntegrationID == 0 {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("doppler_integration_id is required for doppler sources"),
+				http.StatusBadRequest,
+			)
+		}
+
+		_, err = c.Repo().DopplerIntegration().ReadDopplerIntegration(projectID, request.DopplerIntegrationID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("doppler integration %d not found", request.DopplerIntegrationID),
+				http.StatusNotFound,
+			)
+		}
+	default:
+		if request.AWSIntegrationID == 0 {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("aws_integration_id is required for aws_secrets_manager sources"),
+				http.StatusBadRequest,
+			)
+		}
+
+		_, err = c.Repo().AWSIntegration().ReadAWSIntegration(projectID, request.AWSIntegrationID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("aws integration %d not found", request.AWSIntegrationID),
+				http.StatusNotFound,
+			)
+		}
+	}
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package namespace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rolloutTestEnvGroup creates a release which syncs an env group, runs setup, and rolls out a
+// new version of the env group to the release
+func rolloutTestEnvGroup(
+	t *testing.T,
+	setup func(config *config.Config, user *models.User, cluster *models.Cluster),
+) ([]error, *config.Config, *models.Cluster, *helm.Agent) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	cluster := apitest.CreateTestCluster(t, config, 1)
+
+	helmAgent, helmRelease := apitest.CreateTestRelease(t, config, "default", "test-release", map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "porter/app",
+			"tag":        "v1",
+		},
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"synced": []interface{}{
+					map[string]interface{}{
+						"name":    "test-env-group",
+						"version": float64(1),
+						"keys":    []interface{}{},
+					},
+				},
+			},
+		},
+	})
+
+	setup(config, user, cluster)
+
+	envGroup := &types.EnvGroup{
+		Name:      "test-env-group",
+		Namespace: "default",
+		Version:   2,
+	}
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-env-group.v2",
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			"PORT": "8080",
+		},
+	}
+
+	errs := rolloutApplications(config, cluster, helmAgent, envGroup, configMap, []*release.Release{helmRelease})
+
+	return errs, config, cluster, helmAgent
+}
+
+func TestRolloutApplicationsRequiresApproval(t *testing.T) {
+	errs, config, cluster, helmAgent := rolloutTestEnvGroup(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestApprovalPolicy(t, config, cluster, user.ID)
+	})
+
+	// a rollout which waits for an approval is not an error
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	apitest.AssertApprovalRequested(t, config, cluster, "default", "test-release")
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
+
+func TestRolloutApplicationsBlockedByFreezeWindow(t *testing.T) {
+	errs, _, _, helmAgent := rolloutTestEnvGroup(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestFreezeWindow(t, config, cluster)
+	})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+
+	apitest.AssertRequestError(t, errs[0], http.StatusLocked)
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
+
+func TestRolloutApplicationsQueued(t *testing.T) {
+	errs, config, cluster, helmAgent := rolloutTestEnvGroup(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestRunningDeploy(t, config, cluster, "default", "test-release")
+	})
+
+	// a rollout which waits in the deploy queue of the release is not an error
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	apitest.AssertDeployQueued(t, config, cluster, "default", "test-release")
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
@@ -1,6 +1,7 @@
 package namespace
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type DeleteEnvGroupHandler struct {
@@ -48,6 +50,8 @@ func (c *DeleteEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	// get the env group: if it's MetaVersion=2, return an error
 	envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, 0)
 
@@ -62,6 +66,11 @@ func (c *DeleteEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			return
 		}
 	} else if envGroup != nil && envGroup.MetaVersion == 2 {
+		if apiErr := checkEnvGroupNotLinkedCopy(envGroup); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+
 		if len(envGroup.Applications) != 0 {
 			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
 				fmt.Errorf("env group must not have any connected applications"),
@@ -69,7 +78,40 @@ func (c *DeleteEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			))
 
 			return
-		} else if err = envgroup.DeleteEnvGroup(agent, request.Name, namespace); err != nil {
+		} else if err = envgroup.DeleteStoredEnvGroup(agent, storage, request.Name, namespace); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if err := envgroup.DeleteEnvGroupCopies(agent, request.Name, namespace); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	// stop syncing the deleted env group from its source, if it has one
+	source, err := c.Repo().EnvGroupSource().ReadEnvGroupSource(cluster.ID, namespace, request.Name)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if err == nil {
+		if err := c.Repo().EnvGroupSource().DeleteEnvGroupSource(source); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	// stop rotating the secret variables of the deleted env group
+	policies, err := c.Repo().EnvGroupRotationPolicy().ListEnvGroupRotationPolicies(cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, policy := range policies {
+		if err := c.Repo().EnvGroupRotationPolicy().DeleteEnvGroupRotationPolicy(policy); err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
@@ -0,0 +1,61 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeleteEnvGroupRotationPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDeleteEnvGroupRotationPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeleteEnvGroupRotationPolicyHandler {
+	return &DeleteEnvGroupRotationPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *DeleteEnvGroupRotationPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.DeleteEnvGroupRotationPolicyRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	policy, err := c.Repo().EnvGroupRotationPolicy().ReadEnvGroupRotationPolicy(cluster.ID, namespace, request.Name, request.Key)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("%s of env group %s has no rotation policy", request.Key, request.Name),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the enqueued rotation of the policy does nothing once the policy is deleted
+	if err := c.Repo().EnvGroupRotationPolicy().DeleteEnvGroupRotationPolicy(policy); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
@@ -0,0 +1,77 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/quotas"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// DeleteQuotaHandler removes either the Porter-managed resource quota or the Porter-managed
+// limit range from a namespace
+type DeleteQuotaHandler struct {
+	handlers.PorterHandler
+	authz.KubernetesAgentGetter
+
+	limitRange bool
+}
+
+func NewDeleteResourceQuotaHandler(
+	config *config.Config,
+) *DeleteQuotaHandler {
+	return &DeleteQuotaHandler{
+		PorterHandler:         handlers.NewDefaultPorterHandler(config, nil, nil),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func NewDeleteLimitRangeHandler(
+	config *config.Config,
+) *DeleteQuotaHandler {
+	return &DeleteQuotaHandler{
+		PorterHandler:         handlers.NewDefaultPorterHandler(config, nil, nil),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+		limitRange:            true,
+	}
+}
+
+func (c *DeleteQuotaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if c.limitRange {
+		err = quotas.DeleteLimitRange(agent.Clientset, namespace)
+	} else {
+		err = quotas.DeleteResourceQuota(agent.Clientset, namespace)
+	}
+
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(
+				fmt.Errorf("no Porter-managed quota found in namespace %s", namespace),
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,68 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// DopplerWebhookHandler receives the webhooks which Doppler calls when a config changes, and
+// syncs the env group of the source which the webhook was created for
+type DopplerWebhookHandler struct {
+	handlers.PorterHandler
+}
+
+func NewDopplerWebhookHandler(
+	config *config.Config,
+) *DopplerWebhookHandler {
+	return &DopplerWebhookHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (c *DopplerWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, _ := requestutils.GetURLParamString(r, types.URLParamToken)
+
+	source, err := c.Repo().EnvGroupSource().ReadEnvGroupSourceByWebhookToken(token)
+
+	if err == nil && source.Kind != types.EnvGroupSourceDoppler {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// throw forbidden error, since we don't want a way to verify if webhooks exist
+			c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+				fmt.Errorf("env group source not found with given webhook"),
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	cluster, err := c.Repo().Cluster().ReadCluster(source.ProjectID, source.ClusterID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the sync isn't enqueued as a job, since each sync job enqueues the next sync. The result
+	// of the sync is recorded on the source, and the periodic sync retries it if it fails.
+	if err := syncEnvGroupSourceOutOfCluster(r.Context(), c.Config(), cluster, source); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
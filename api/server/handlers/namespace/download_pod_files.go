@@ -0,0 +1,113 @@
+package namespace
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/podfiles"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// DownloadPodFilesHandler copies a file or directory out of a pod container as a tar archive.
+// Downloads are recorded in the audit log.
+type DownloadPodFilesHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewDownloadPodFilesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DownloadPodFilesHandler {
+	return &DownloadPodFilesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *DownloadPodFilesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.DownloadPodFilesRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamPodName)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the archive is buffered so that errors, including exceeding the size limit, can be
+	// reported to the client before any of the archive is written
+	buf := &bytes.Buffer{}
+
+	size, err := podfiles.DownloadFiles(agent, namespace, name, request.Container, request.Path, buf)
+
+	if err != nil {
+		handlePodFilesError(c, w, r, err)
+		return
+	}
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"container": request.Container,
+		"path":      request.Path,
+		"bytes":     size,
+	})
+
+	_, err = c.Repo().AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    cluster.ProjectID,
+		ClusterID:    cluster.ID,
+		UserID:       user.ID,
+		Action:       string(types.AuditLogActionPodFilesDownload),
+		ResourceKind: "Pod",
+		ResourceName: name,
+		Namespace:    namespace,
+		Metadata:     metadata,
+	})
+
+	// files should not be copied out of the cluster without a record of the copy
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(request.Path)+".tar"))
+	w.Write(buf.Bytes())
+}
+
+func handlePodFilesError(handler handlers.PorterHandler, w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, podfiles.ErrArchiveTooLarge) {
+		handler.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusRequestEntityTooLarge))
+		return
+	} else if errors.Is(err, podfiles.ErrInvalidPath) || errors.Is(err, podfiles.ErrInvalidArchive) {
+		handler.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	// errors from the exec stream, such as a missing tar binary or file, are caused by the
+	// state of the container
+	handler.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+		fmt.Errorf("error copying files: %w", err),
+		http.StatusBadRequest,
+	))
+}
@@ -0,0 +1,56 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/kubernetes/externalsecrets"
+	"gorm.io/gorm"
+)
+
+// getExternalSecretStore returns the external secret store of a project, or nil if the project
+// doesn't have one
+func getExternalSecretStore(config *config.Config, projectID uint) (*types.ExternalSecretStore, error) {
+	store, err := config.Repo.ExternalSecretStore().ReadExternalSecretStore(projectID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return store.ToExternalSecretStoreType(), nil
+}
+
+// checkEnvGroupNotExternal returns an error which can be passed to the client if the secret
+// variables of the env group are synced from an external secret store, whose references can't
+// be overwritten with values generated by Porter
+func checkEnvGroupNotExternal(envGroup *types.EnvGroup) apierrors.RequestError {
+	if envGroup == nil || envGroup.ExternalSecretStore == nil {
+		return nil
+	}
+
+	return apierrors.NewErrPassThroughToClient(
+		fmt.Errorf("the secret variables of env group %s are synced from %s %s, and must be updated there",
+			envGroup.Name, envGroup.ExternalSecretStore.Kind, envGroup.ExternalSecretStore.Name),
+		http.StatusBadRequest,
+	)
+}
+
+// toCreateEnvGroupAPIError returns the error of creating a version of an env group, which is
+// passed to the client if its templates or the references of its secret variables are invalid,
+// or the External Secrets Operator isn't installed
+func toCreateEnvGroupAPIError(err error) apierrors.RequestError {
+	if errors.Is(err, envgroup.ErrInvalidTemplate) ||
+		errors.Is(err, externalsecrets.ErrInvalidRemoteRef) ||
+		errors.Is(err, externalsecrets.ErrNotInstalled) {
+		return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	return apierrors.NewErrInternal(err)
+}
@@ -0,0 +1,163 @@
+package namespace
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
+)
+
+// checkEnvGroupNotLinkedCopy returns an error which can be passed to the client if the env group
+// is a copy of an env group in another namespace, which is only updated by propagating the
+// versions of that env group
+func checkEnvGroupNotLinkedCopy(envGroup *types.EnvGroup) apierrors.RequestError {
+	if envGroup == nil || envGroup.LinkedFrom == "" {
+		return nil
+	}
+
+	return apierrors.NewErrPassThroughToClient(
+		fmt.Errorf("env group %s is linked from namespace %s and can only be updated there", envGroup.Name, envGroup.LinkedFrom),
+		http.StatusBadRequest,
+	)
+}
+
+// propagateEnvGroup copies a new version of an env group to the namespaces of the applications
+// which are linked to it from other namespaces, and redeploys those applications if restart is
+// set. The applications in the namespace of the env group are redeployed by rolloutApplications.
+func propagateEnvGroup(
+	config *config.Config,
+	cluster *models.Cluster,
+	agent *kubernetes.Agent,
+	envGroup *types.EnvGroup,
+	configMap *v1.ConfigMap,
+	restart bool,
+) []error {
+	errs := make([]error, 0)
+
+	for _, namespace := range envgroup.GetLinkedNamespaces(configMap) {
+		if err := envgroup.CopyEnvGroup(agent, configMap, namespace); err != nil {
+			errs = append(errs, fmt.Errorf("error copying env group to namespace %s: %w", namespace, err))
+			continue
+		}
+
+		if !restart {
+			continue
+		}
+
+		helmAgent, err := helm.GetAgentFromK8sAgent("secret", namespace, config.Logger, agent)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		releases, err := envgroup.GetLinkedReleases(helmAgent, configMap, namespace)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		errs = append(errs, rolloutApplications(config, cluster, helmAgent, envGroup, configMap, releases)...)
+	}
+
+	return errs
+}
+
+// getEnvGroupConsumers returns the applications which are linked to an env group, along with the
+// version of the env group which each application is deployed with
+func getEnvGroupConsumers(
+	config *config.Config,
+	agent *kubernetes.Agent,
+	envGroup *types.EnvGroup,
+) ([]*types.EnvGroupConsumer, error) {
+	res := make([]*types.EnvGroupConsumer, 0)
+	helmAgents := make(map[string]*helm.Agent)
+
+	for _, app := range envGroup.Applications {
+		namespace, name := envgroup.ParseApplication(envGroup.Namespace, app)
+
+		consumer := &types.EnvGroupConsumer{
+			Namespace: namespace,
+			Name:      name,
+		}
+
+		res = append(res, consumer)
+
+		helmAgent, ok := helmAgents[namespace]
+
+		if !ok {
+			var err error
+
+			helmAgent, err = helm.GetAgentFromK8sAgent("secret", namespace, config.Logger, agent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			helmAgents[namespace] = helmAgent
+		}
+
+		rel, err := helmAgent.GetRelease(name, 0, false)
+
+		if err != nil {
+			// applications which were deleted after they were linked are listed without a version
+			if strings.Contains(err.Error(), "not found") {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if rel.Info != nil {
+			consumer.Status = rel.Info.Status.String()
+		}
+
+		consumer.Version = getSyncedVersion(rel.Config, envGroup.Name)
+	}
+
+	return res, nil
+}
+
+// getSyncedVersion returns the version of an env group in the container.env.synced section of
+// the values of a release, or 0 if the release doesn't use the env group
+func getSyncedVersion(values map[string]interface{}, name string) uint {
+	envConf, err := getNestedMap(values, "container", "env")
+
+	if err != nil {
+		return 0
+	}
+
+	syncedArr, ok := envConf["synced"].([]interface{})
+
+	if !ok {
+		return 0
+	}
+
+	for _, syncedInter := range syncedArr {
+		synced, ok := syncedInter.(map[string]interface{})
+
+		if !ok || synced["name"] != name {
+			continue
+		}
+
+		switch version := synced["version"].(type) {
+		case float64:
+			return uint(version)
+		case int:
+			return uint(version)
+		case int64:
+			return uint(version)
+		}
+	}
+
+	return 0
+}
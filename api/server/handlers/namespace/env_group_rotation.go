@@ -0,0 +1,145 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/secretrotation"
+)
+
+func rotateEnvGroupSecretOutOfCluster(
+	ctx context.Context,
+	config *config.Config,
+	cluster *models.Cluster,
+	policy *models.EnvGroupRotationPolicy,
+) error {
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(
+		authz.NewOutOfClusterAgentGetter(config).GetOutOfClusterConfig(cluster),
+	)
+
+	if err != nil {
+		policy.NextRotationAt = getNextRotationAt(time.Now(), policy.IntervalDays)
+		return recordEnvGroupRotation(config, policy, fmt.Errorf("failed to get agent: %w", err))
+	}
+
+	helmAgent, err := helm.GetAgentFromK8sAgent("secret", policy.Namespace, config.Logger, agent)
+
+	if err != nil {
+		policy.NextRotationAt = getNextRotationAt(time.Now(), policy.IntervalDays)
+		return recordEnvGroupRotation(config, policy, fmt.Errorf("failed to get helm agent: %w", err))
+	}
+
+	return rotateEnvGroupSecret(ctx, config, cluster, agent, helmAgent, policy)
+}
+
+// rotateEnvGroupSecret sets a newly generated value for the secret variable of a policy, by
+// creating a new version of the env group, and redeploys the applications which are synced to the
+// env group. The next rotation is scheduled from now, whether or not the rotation succeeds, and
+// the result is recorded on the policy.
+func rotateEnvGroupSecret(
+	ctx context.Context,
+	config *config.Config,
+	cluster *models.Cluster,
+	agent *kubernetes.Agent,
+	helmAgent *helm.Agent,
+	policy *models.EnvGroupRotationPolicy,
+) error {
+	now := time.Now().UTC()
+	policy.NextRotationAt = getNextRotationAt(now, policy.IntervalDays)
+
+	// the source of an env group would overwrite the rotated value with its next sync
+	if apiErr := checkEnvGroupNotSynced(config, cluster.ID, policy.Namespace, policy.Name); apiErr != nil {
+		return recordEnvGroupRotation(config, policy, apiErr)
+	}
+
+	generator, err := secretrotation.GetGenerator(config.Repo, policy.Generator)
+
+	if err != nil {
+		return recordEnvGroupRotation(config, policy, err)
+	}
+
+	storage := envgroup.NewSecretStorage(config.CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	_, variables, secretVariables, err := envgroup.GetStoredVariables(agent, storage, policy.Name, policy.Namespace, 0)
+
+	if err != nil {
+		return recordEnvGroupRotation(config, policy, fmt.Errorf("error reading env group: %w", err))
+	}
+
+	value, err := generator.Generate(ctx, policy)
+
+	if err != nil {
+		return recordEnvGroupRotation(config, policy, err)
+	}
+
+	delete(variables, policy.Key)
+	secretVariables[policy.Key] = envgroup.EscapeTemplate(value)
+
+	// the templates of the other variables are carried over and resolved again, so variables
+	// which reference the rotated variable are updated with it
+	configMap, err := envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
+		Name:            policy.Name,
+		Namespace:       policy.Namespace,
+		Variables:       variables,
+		SecretVariables: secretVariables,
+		ResolveOutput:   newOutputResolver(config, cluster.ProjectID),
+	})
+
+	if err != nil {
+		return recordEnvGroupRotation(config, policy, err)
+	}
+
+	policy.LastRotatedAt = &now
+
+	envGroup, err := envgroup.ToEnvGroup(configMap)
+
+	if err != nil {
+		return recordEnvGroupRotation(config, policy, err)
+	}
+
+	releases, err := envgroup.GetSyncedReleases(helmAgent, configMap)
+
+	if err != nil {
+		return recordEnvGroupRotation(config, policy, err)
+	}
+
+	errs := rolloutApplications(config, cluster, helmAgent, envGroup, configMap, releases)
+	errs = append(errs, propagateEnvGroup(config, cluster, agent, envGroup, configMap, true)...)
+
+	if len(errs) > 0 {
+		return recordEnvGroupRotation(config, policy, fmt.Errorf("error rolling out applications: %v", errs))
+	}
+
+	return recordEnvGroupRotation(config, policy, postUpgrade(config, cluster.ProjectID, cluster.ID, envGroup))
+}
+
+// recordEnvGroupRotation stores the result of a rotation on the policy, and returns the error of
+// the rotation
+func recordEnvGroupRotation(config *config.Config, policy *models.EnvGroupRotationPolicy, rotationErr error) error {
+	if rotationErr != nil {
+		policy.Error = rotationErr.Error()
+	} else {
+		policy.Error = ""
+	}
+
+	if _, err := config.Repo.EnvGroupRotationPolicy().UpdateEnvGroupRotationPolicy(policy); err != nil {
+		return fmt.Errorf("error updating env group rotation policy: %w", err)
+	}
+
+	return rotationErr
+}
+
+// getNextRotationAt returns the time of the rotation which follows a rotation at from. The time
+// is truncated to the second, so that it's unchanged when it's stored in the database and in the
+// payload of a job.
+func getNextRotationAt(from time.Time, intervalDays uint) time.Time {
+	return from.UTC().Add(time.Duration(intervalDays) * 24 * time.Hour).Truncate(time.Second)
+}
@@ -0,0 +1,226 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/integrations/doppler"
+	"github.com/porter-dev/porter/internal/integrations/secretsmanager"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+func syncEnvGroupSourceOutOfCluster(
+	ctx context.Context,
+	config *config.Config,
+	cluster *models.Cluster,
+	source *models.EnvGroupSource,
+) error {
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(
+		authz.NewOutOfClusterAgentGetter(config).GetOutOfClusterConfig(cluster),
+	)
+
+	if err != nil {
+		return recordEnvGroupSourceSync(config, source, fmt.Errorf("failed to get agent: %w", err))
+	}
+
+	helmAgent, err := helm.GetAgentFromK8sAgent("secret", source.Namespace, config.Logger, agent)
+
+	if err != nil {
+		return recordEnvGroupSourceSync(config, source, fmt.Errorf("failed to get helm agent: %w", err))
+	}
+
+	return syncEnvGroupSource(ctx, config, cluster, agent, helmAgent, source)
+}
+
+// syncEnvGroupSource writes the values of the secret of a source to its env group, if they differ
+// from the values of the latest version of the env group. The linked applications are rolled out
+// with the new version. The result of the sync is recorded on the source.
+func syncEnvGroupSource(
+	ctx context.Context,
+	config *config.Config,
+	cluster *models.Cluster,
+	agent *kubernetes.Agent,
+	helmAgent *helm.Agent,
+	source *models.EnvGroupSource,
+) error {
+	now := time.Now().UTC()
+	source.LastAttemptedAt = &now
+
+	secret, err := getEnvGroupSourceSecret(ctx, config, source)
+
+	if err != nil {
+		return recordEnvGroupSourceSync(config, source, err)
+	}
+
+	storage := envgroup.NewSecretStorage(config.CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	version, variables, secretVariables, err := envgroup.GetStoredVariables(agent, storage, source.Name, source.Namespace, 0)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return recordEnvGroupSourceSync(config, source, err)
+	}
+
+	exists := err == nil
+
+	if exists && len(variables) == 0 && equalVariables(secretVariables, secret.Values) {
+		source.SecretVersionID = secret.VersionID
+		source.EnvGroupVersion = version
+
+		return recordEnvGroupSourceSync(config, source, nil)
+	}
+
+	// if the env group differs from the secret although the secret hasn't changed since the last
+	// sync, or a version was added since the last sync, the env group was changed outside of Porter
+	if exists && source.EnvGroupVersion != 0 &&
+		(secret.VersionID == source.SecretVersionID || version != source.EnvGroupVersion) {
+		source.DriftDetectedAt = &now
+
+		config.Logger.Warn().Msgf(
+			"env group %s in namespace %s drifted from its source and was overwritten",
+			source.Name,
+			source.Namespace,
+		)
+	}
+
+	configMap, err := envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
+		Name:            source.Name,
+		Namespace:       source.Namespace,
+		Variables:       make(map[string]string),
+		SecretVariables: envgroup.EscapeTemplates(secret.Values),
+	})
+
+	if err != nil {
+		return recordEnvGroupSourceSync(config, source, err)
+	}
+
+	envGroup, err := envgroup.ToEnvGroup(configMap)
+
+	if err != nil {
+		return recordEnvGroupSourceSync(config, source, err)
+	}
+
+	source.SecretVersionID = secret.VersionID
+	source.EnvGroupVersion = envGroup.Version
+
+	releases, err := envgroup.GetSyncedReleases(helmAgent, configMap)
+
+	if err != nil {
+		return recordEnvGroupSourceSync(config, source, err)
+	}
+
+	errs := rolloutApplications(config, cluster, helmAgent, envGroup, configMap, releases)
+	errs = append(errs, propagateEnvGroup(config, cluster, agent, envGroup, configMap, true)...)
+
+	if len(errs) > 0 {
+		return recordEnvGroupSourceSync(config, source, fmt.Errorf("error rolling out applications: %v", errs))
+	}
+
+	return recordEnvGroupSourceSync(config, source, postUpgrade(config, cluster.ProjectID, cluster.ID, envGroup))
+}
+
+// getEnvGroupSourceSecret reads the current version of the secret of a source from its secret
+// store
+func getEnvGroupSourceSecret(
+	ctx context.Context,
+	config *config.Config,
+	source *models.EnvGroupSource,
+) (*secretsmanager.Secret, error) {
+	switch source.Kind {
+	case types.EnvGroupSourceDoppler:
+		dopplerInt, err := config.Repo.DopplerIntegration().ReadDopplerIntegration(source.ProjectID, source.DopplerIntegrationID)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading doppler integration: %w", err)
+		}
+
+		dopplerConfig, err := doppler.NewClient(dopplerInt).GetConfig(ctx, source.SecretID)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading config from doppler: %w", err)
+		}
+
+		return &secretsmanager.Secret{
+			VersionID: dopplerConfig.VersionID,
+			Values:    dopplerConfig.Values,
+		}, nil
+	default:
+		awsInt, err := config.Repo.AWSIntegration().ReadAWSIntegration(source.ProjectID, source.AWSIntegrationID)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading aws integration: %w", err)
+		}
+
+		secret, err := secretsmanager.GetSecret(ctx, awsInt, source.SecretID)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret from aws secrets manager: %w", err)
+		}
+
+		return secret, nil
+	}
+}
+
+// recordEnvGroupSourceSync stores the result of a sync on the source, and returns the error of
+// the sync
+func recordEnvGroupSourceSync(config *config.Config, source *models.EnvGroupSource, syncErr error) error {
+	if syncErr != nil {
+		source.Error = syncErr.Error()
+	} else {
+		source.Error = ""
+		source.LastSyncedAt = source.LastAttemptedAt
+	}
+
+	if _, err := config.Repo.EnvGroupSource().UpdateEnvGroupSource(source); err != nil {
+		return fmt.Errorf("error updating env group source: %w", err)
+	}
+
+	return syncErr
+}
+
+func equalVariables(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, val := range a {
+		if bVal, ok := b[key]; !ok || bVal != val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkEnvGroupNotSynced returns an error which can be passed to the client if an env group is
+// synced from a source, since the env group would be overwritten by the next sync
+func checkEnvGroupNotSynced(config *config.Config, clusterID uint, namespace, name string) apierrors.RequestError {
+	source, err := config.Repo.EnvGroupSource().ReadEnvGroupSource(clusterID, namespace, name)
+
+	if err == nil {
+		storeName := "AWS Secrets Manager"
+
+		if source.Kind == types.EnvGroupSourceDoppler {
+			storeName = "Doppler"
+		}
+
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("env group %s is synced from %s and can't be updated", name, storeName),
+			http.StatusBadRequest,
+		)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierrors.NewErrInternal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package namespace
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type infraOutputs struct {
+	outputs       map[string]string
+	secretOutputs map[string]string
+}
+
+// newOutputResolver returns a resolver of the outputs of the infra of a project, which the
+// templates of env groups reference as ${infra.ID.OUTPUT}. The outputs of each infra are read
+// once per resolver.
+func newOutputResolver(config *config.Config, projectID uint) envgroup.OutputResolver {
+	cache := make(map[uint]*infraOutputs)
+
+	return func(ref string) (string, bool, error) {
+		strs := strings.SplitN(ref, ".", 2)
+
+		if len(strs) != 2 {
+			return "", false, fmt.Errorf("infra outputs are referenced as infra.ID.OUTPUT")
+		}
+
+		infraID, err := strconv.ParseUint(strs[0], 10, 32)
+
+		if err != nil {
+			return "", false, fmt.Errorf("invalid infra id %s", strs[0])
+		}
+
+		outputs, ok := cache[uint(infraID)]
+
+		if !ok {
+			outputs, err = getInfraOutputs(config, projectID, uint(infraID))
+
+			if err != nil {
+				return "", false, err
+			}
+
+			cache[uint(infraID)] = outputs
+		}
+
+		if val, ok := outputs.outputs[strs[1]]; ok {
+			return val, false, nil
+		} else if val, ok := outputs.secretOutputs[strs[1]]; ok {
+			return val, true, nil
+		}
+
+		return "", false, fmt.Errorf("infra %d has no output %s", infraID, strs[1])
+	}
+}
+
+// getInfraOutputs returns the outputs of provisioned infra which can be referenced by the
+// templates of env groups. Only the outputs of RDS databases can be referenced.
+func getInfraOutputs(config *config.Config, projectID, infraID uint) (*infraOutputs, error) {
+	infra, err := config.Repo.Infra().ReadInfra(projectID, infraID)
+
+	if err != nil {
+		return nil, fmt.Errorf("infra %d not found", infraID)
+	}
+
+	if infra.Kind != types.InfraRDS {
+		return nil, fmt.Errorf("infra %d is not an RDS database", infraID)
+	}
+
+	database, err := config.Repo.Database().ReadDatabaseByInfraID(projectID, infraID)
+
+	if err != nil {
+		return nil, fmt.Errorf("the database of infra %d is not provisioned", infraID)
+	}
+
+	// split the instance endpoint on the port, as in the env group created for the database
+	port := "5432"
+	host := database.InstanceEndpoint
+
+	if strArr := strings.Split(database.InstanceEndpoint, ":"); len(strArr) == 2 {
+		host = strArr[0]
+		port = strArr[1]
+	}
+
+	res := &infraOutputs{
+		outputs: map[string]string{
+			"host":          host,
+			"port":          port,
+			"endpoint":      database.InstanceEndpoint,
+			"instance_name": database.InstanceName,
+		},
+		secretOutputs: make(map[string]string),
+	}
+
+	lastApplied, err := getLastApplied(config, infra)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dbName, ok := lastApplied["db_name"].(string); ok {
+		res.outputs["db_name"] = dbName
+	}
+
+	if dbUser, ok := lastApplied["db_user"].(string); ok {
+		res.outputs["username"] = dbUser
+	}
+
+	if dbPassword, ok := lastApplied["db_passwd"].(string); ok {
+		res.secretOutputs["password"] = dbPassword
+	}
+
+	return res, nil
+}
+
+func getLastApplied(config *config.Config, infra *models.Infra) (map[string]interface{}, error) {
+	operation, err := config.Repo.Infra().GetLatestOperation(infra)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading the latest operation of infra %d: %w", infra.ID, err)
+	}
+
+	lastApplied := make(map[string]interface{})
+
+	if err := json.Unmarshal(operation.LastApplied, &lastApplied); err != nil {
+		return nil, fmt.Errorf("error reading the values of infra %d: %w", infra.ID, err)
+	}
+
+	return lastApplied, nil
+}
@@ -0,0 +1,164 @@
+package namespace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/podexec"
+	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ExecPodHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewExecPodHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ExecPodHandler {
+	return &ExecPodHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP runs a command in a container of a pod, with the command's streams multiplexed over
+// the websocket connection as described by types.ExecRequest
+func (c *ExecPodHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ExecRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	safeRW := r.Context().Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	podName, _ := requestutils.GetURLParamString(r, types.URLParamPodName)
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	pod, err := agent.Clientset.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("pod %s/%s was not found", namespace, podName),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if pod.Status.Phase != v1.PodRunning {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("pod %s/%s is not running", namespace, podName),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	container := request.Container
+
+	if container == "" {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	stdinReader, stdinWriter := io.Pipe()
+	sizeQueue := podexec.NewSizeQueue()
+
+	go func() {
+		defer stdinWriter.Close()
+		defer sizeQueue.Close()
+
+		for {
+			_, msg, err := safeRW.ReadMessage()
+
+			if err != nil {
+				return
+			}
+
+			if len(msg) == 0 {
+				continue
+			}
+
+			switch msg[0] {
+			case types.ExecChannelStdin:
+				if _, err := stdinWriter.Write(msg[1:]); err != nil {
+					return
+				}
+			case types.ExecChannelStdinClose:
+				stdinWriter.Close()
+			case types.ExecChannelResize:
+				resize := &types.ExecResize{}
+
+				if err := json.Unmarshal(msg[1:], resize); err == nil {
+					sizeQueue.Push(resize.Width, resize.Height)
+				}
+			}
+		}
+	}()
+
+	opts := &podexec.Opts{
+		Container: container,
+		Command:   request.Command,
+		TTY:       request.TTY,
+		Stdin:     stdinReader,
+		Stdout:    &execChannelWriter{rw: safeRW, channel: types.ExecChannelStdout},
+		Stderr:    &execChannelWriter{rw: safeRW, channel: types.ExecChannelStderr},
+	}
+
+	if request.TTY {
+		opts.SizeQueue = sizeQueue
+	}
+
+	err = podexec.Exec(agent, namespace, podName, opts)
+
+	stdinReader.Close()
+	sizeQueue.Close()
+
+	statusBytes, _ := json.Marshal(podexec.GetStatus(err))
+
+	safeRW.Write(append([]byte{types.ExecChannelStatus}, statusBytes...))
+	safeRW.Close()
+}
+
+// execChannelWriter writes the output of a command to a channel of the websocket connection
+type execChannelWriter struct {
+	rw      *websocket.WebsocketSafeReadWriter
+	channel byte
+}
+
+func (e *execChannelWriter) Write(p []byte) (int, error) {
+	if _, err := e.rw.Write(append([]byte{e.channel}, p...)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
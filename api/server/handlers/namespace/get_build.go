@@ -0,0 +1,125 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/builds"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type GetBuildHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetBuildHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetBuildHandler {
+	return &GetBuildHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetBuildHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+
+	build, ok := readBuild(c, w, r, cluster, namespace)
+
+	if !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := syncBuildStatus(c.Repo(), agent, build); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, build.ToBuildType())
+}
+
+// readBuild reads the build from the build ID URL param, writing an error if the build does not
+// exist in the namespace
+func readBuild(
+	c handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	cluster *models.Cluster,
+	namespace string,
+) (*models.Build, bool) {
+	buildID, reqErr := requestutils.GetURLParamUint(r, types.URLParamBuildID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	build, err := c.Repo().Build().ReadBuild(cluster.ProjectID, cluster.ID, buildID)
+
+	if err == nil && build.Namespace != namespace {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("build %d not found", buildID)))
+			return nil, false
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return build, true
+}
+
+// syncBuildStatus updates the status of an unfinished build from the status of its job. Build
+// jobs are deleted a day after they finish, so builds whose jobs no longer exist are marked
+// as failed.
+func syncBuildStatus(repo repository.Repository, agent *kubernetes.Agent, build *models.Build) error {
+	if build.Status == types.BuildStatusSucceeded || build.Status == types.BuildStatusFailed {
+		return nil
+	}
+
+	status, message, finishedAt, err := builds.GetBuildStatus(agent.Clientset, build.Namespace, build.JobName)
+
+	if err != nil && errors.Is(err, builds.ErrBuildJobNotFound) {
+		status = types.BuildStatusFailed
+		message = "the build job was deleted before the build finished"
+	} else if err != nil {
+		return err
+	}
+
+	if status == build.Status {
+		return nil
+	}
+
+	build.Status = status
+	build.Error = message
+	build.FinishedAt = finishedAt
+
+	_, err = repo.Build().UpdateBuild(build)
+
+	return err
+}
@@ -65,11 +65,18 @@ func (c *GetEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	consumers, err := getEnvGroupConsumers(c.Config(), agent, envGroup)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	stackId, err := stacks.GetStackForEnvGroup(c.Config(), cluster.ProjectID, cluster.ID, envGroup)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.WriteResult(w, r, &types.GetEnvGroupResponse{EnvGroup: envGroup})
+			c.WriteResult(w, r, &types.GetEnvGroupResponse{EnvGroup: envGroup, Consumers: consumers})
 			return
 		}
 
@@ -78,8 +85,9 @@ func (c *GetEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	res := &types.GetEnvGroupResponse{
-		EnvGroup: envGroup,
-		StackID:  stackId,
+		EnvGroup:  envGroup,
+		StackID:   stackId,
+		Consumers: consumers,
 	}
 
 	c.WriteResult(w, r, res)
@@ -72,9 +72,11 @@ func (c *GetEnvGroupAllVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http
 		}
 
 		res = append(res, &types.EnvGroupMeta{
-			Name:      eg.Name,
-			Namespace: eg.Namespace,
-			Version:   eg.Version,
+			MetaVersion: eg.MetaVersion,
+			CreatedAt:   eg.CreatedAt,
+			Name:        eg.Name,
+			Namespace:   eg.Namespace,
+			Version:     eg.Version,
 		})
 	}
 
@@ -0,0 +1,131 @@
+package namespace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// auditLogResourceKindEnvGroup is the resource kind of the audit logs of env groups
+const auditLogResourceKindEnvGroup = "EnvGroup"
+
+// GetEnvGroupSecretsHandler returns the unmasked values of the secret variables of an env group,
+// and records the read in the audit logs of the project
+type GetEnvGroupSecretsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetEnvGroupSecretsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetEnvGroupSecretsHandler {
+	return &GetEnvGroupSecretsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetEnvGroupSecretsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.GetEnvGroupSecretsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	version, _, secretVariables, err := envgroup.GetStoredVariables(agent, storage, request.Name, namespace, request.Version)
+
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("env group not found"),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.GetEnvGroupSecretsResponse{
+		Version:         version,
+		SecretVariables: make(map[string]string),
+	}
+
+	if len(request.Keys) == 0 {
+		res.SecretVariables = secretVariables
+	}
+
+	for _, key := range request.Keys {
+		val, ok := secretVariables[key]
+
+		if !ok {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("secret variable %s not found in version %d of env group %s", key, version, request.Name),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		res.SecretVariables[key] = val
+	}
+
+	keys := make([]string, 0, len(res.SecretVariables))
+
+	for key := range res.SecretVariables {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"version": version,
+		"keys":    keys,
+	})
+
+	_, err = c.Repo().AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    cluster.ProjectID,
+		ClusterID:    cluster.ID,
+		UserID:       user.ID,
+		Action:       string(types.AuditLogActionEnvGroupSecretsRead),
+		ResourceKind: auditLogResourceKindEnvGroup,
+		ResourceName: request.Name,
+		Namespace:    namespace,
+		Metadata:     metadata,
+	})
+
+	// secret values should not be returned without a record of the read
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,171 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/jobqueue"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// jobKindEnvGroupSourceSync syncs an env group from its source, and enqueues the next sync
+	jobKindEnvGroupSourceSync = "env-group-source-sync"
+
+	// jobKindEnvGroupRotation rotates a secret variable of an env group, and enqueues the next
+	// rotation
+	jobKindEnvGroupRotation = "env-group-rotation"
+)
+
+type envGroupSourceSyncPayload struct {
+	ProjectID uint `json:"project_id"`
+	ClusterID uint `json:"cluster_id"`
+	SourceID  uint `json:"source_id"`
+}
+
+type envGroupRotationPayload struct {
+	ProjectID uint `json:"project_id"`
+	ClusterID uint `json:"cluster_id"`
+	PolicyID  uint `json:"policy_id"`
+
+	// NextRotationAt is the scheduled rotation of the policy which the job was enqueued for. The
+	// job does nothing if the policy was rescheduled since, since another job was enqueued for
+	// the new schedule.
+	NextRotationAt time.Time `json:"next_rotation_at"`
+}
+
+// RegisterJobHandlers registers the handlers of the background jobs which are enqueued by
+// the namespace endpoints
+func RegisterJobHandlers(config *config.Config) {
+	config.JobQueue.Register(jobKindEnvGroupSourceSync, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runEnvGroupSourceSyncJob(ctx, config, job)
+	})
+
+	config.JobQueue.Register(jobKindEnvGroupRotation, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runEnvGroupRotationJob(ctx, config, job)
+	})
+}
+
+// enqueueEnvGroupSourceSync enqueues a job which syncs an env group from its source after the
+// sync interval
+func enqueueEnvGroupSourceSync(config *config.Config, source *models.EnvGroupSource) error {
+	job, err := config.JobQueue.NewJob(source.ProjectID, jobKindEnvGroupSourceSync, &envGroupSourceSyncPayload{
+		ProjectID: source.ProjectID,
+		ClusterID: source.ClusterID,
+		SourceID:  source.ID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	job.RunAfter = time.Now().UTC().Add(config.ServerConf.EnvGroupSourceSyncInterval)
+
+	_, err = config.Repo.BackgroundJob().CreateBackgroundJob(job)
+
+	return err
+}
+
+// enqueueEnvGroupRotation enqueues a job which rotates a secret variable of an env group at the
+// next rotation of its policy
+func enqueueEnvGroupRotation(config *config.Config, policy *models.EnvGroupRotationPolicy) error {
+	job, err := config.JobQueue.NewJob(policy.ProjectID, jobKindEnvGroupRotation, &envGroupRotationPayload{
+		ProjectID:      policy.ProjectID,
+		ClusterID:      policy.ClusterID,
+		PolicyID:       policy.ID,
+		NextRotationAt: policy.NextRotationAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	job.RunAfter = policy.NextRotationAt
+
+	_, err = config.Repo.BackgroundJob().CreateBackgroundJob(job)
+
+	return err
+}
+
+func runEnvGroupSourceSyncJob(ctx context.Context, config *config.Config, job *models.BackgroundJob) error {
+	payload := &envGroupSourceSyncPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	// the env group is no longer synced once its source is deleted, which ends the chain of
+	// sync jobs
+	source, err := config.Repo.EnvGroupSource().ReadEnvGroupSourceByID(payload.SourceID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading env group source: %w", err)
+	}
+
+	cluster, err := config.Repo.Cluster().ReadCluster(payload.ProjectID, payload.ClusterID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	// failed syncs are recorded on the source and retried with the next sync, rather than
+	// retrying the job, so that the chain of sync jobs isn't broken
+	if err := syncEnvGroupSourceOutOfCluster(ctx, config, cluster, source); err != nil {
+		config.Logger.Error().Err(err).Msgf("error syncing env group %s in namespace %s", source.Name, source.Namespace)
+	}
+
+	return enqueueEnvGroupSourceSync(config, source)
+}
+
+func runEnvGroupRotationJob(ctx context.Context, config *config.Config, job *models.BackgroundJob) error {
+	payload := &envGroupRotationPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	policy, err := config.Repo.EnvGroupRotationPolicy().ReadEnvGroupRotationPolicyByID(payload.PolicyID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading env group rotation policy: %w", err)
+	}
+
+	if !policy.NextRotationAt.Equal(payload.NextRotationAt) {
+		return nil
+	}
+
+	cluster, err := config.Repo.Cluster().ReadCluster(payload.ProjectID, payload.ClusterID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	// failed rotations are recorded on the policy and retried with the next rotation, rather than
+	// retrying the job, since a generator may have changed the value in an external system
+	if err := rotateEnvGroupSecretOutOfCluster(ctx, config, cluster, policy); err != nil {
+		config.Logger.Error().Err(err).Msgf("error rotating %s of env group %s in namespace %s",
+			policy.Key, policy.Name, policy.Namespace)
+	}
+
+	return enqueueEnvGroupRotation(config, policy)
+}
@@ -0,0 +1,52 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListEnvGroupRotationPoliciesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListEnvGroupRotationPoliciesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListEnvGroupRotationPoliciesHandler {
+	return &ListEnvGroupRotationPoliciesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListEnvGroupRotationPoliciesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ListEnvGroupRotationPoliciesRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	policies, err := c.Repo().EnvGroupRotationPolicy().ListEnvGroupRotationPolicies(cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListEnvGroupRotationPoliciesResponse, 0, len(policies))
+
+	for _, policy := range policies {
+		res = append(res, policy.ToEnvGroupRotationPolicyType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,69 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListEnvGroupSecretAccessHandler lists the reads of the secret values of an env group, most
+// recent first
+type ListEnvGroupSecretAccessHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListEnvGroupSecretAccessHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListEnvGroupSecretAccessHandler {
+	return &ListEnvGroupSecretAccessHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListEnvGroupSecretAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ListEnvGroupSecretAccessRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	opts := &types.ListAuditLogsRequest{
+		Limit:        request.Limit,
+		Skip:         request.Skip,
+		ClusterID:    cluster.ID,
+		Action:       string(types.AuditLogActionEnvGroupSecretsRead),
+		Namespace:    namespace,
+		ResourceKind: auditLogResourceKindEnvGroup,
+		ResourceName: request.Name,
+	}
+
+	auditLogs, count, err := c.Repo().AuditLog().ListAuditLogsByProjectID(cluster.ProjectID, opts)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ListAuditLogsResponse{
+		Count:     count,
+		Limit:     opts.Limit,
+		Skip:      opts.Skip,
+		AuditLogs: make([]*types.AuditLog, 0),
+	}
+
+	for _, auditLog := range auditLogs {
+		res.AuditLogs = append(res.AuditLogs, auditLog.ToAuditLogType())
+	}
+
+	c.WriteResult(w, r, res)
+}
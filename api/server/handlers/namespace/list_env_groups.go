@@ -62,6 +62,7 @@ func (c *ListEnvGroupsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			Name:        eg.Name,
 			Namespace:   eg.Namespace,
 			Version:     eg.Version,
+			LinkedFrom:  eg.LinkedFrom,
 		})
 	}
 
@@ -86,6 +87,7 @@ func (c *ListEnvGroupsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			Name:        eg.Name,
 			Namespace:   eg.Namespace,
 			Version:     eg.Version,
+			LinkedFrom:  eg.LinkedFrom,
 		})
 	}
 
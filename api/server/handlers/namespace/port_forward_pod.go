@@ -0,0 +1,122 @@
+package namespace
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/podportforward"
+	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PortForwardPodHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewPortForwardPodHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PortForwardPodHandler {
+	return &PortForwardPodHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP forwards a single connection to a port of a pod over the websocket connection, as
+// described by types.PortForwardRequest
+func (c *PortForwardPodHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.PortForwardRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	safeRW := r.Context().Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	podName, _ := requestutils.GetURLParamString(r, types.URLParamPodName)
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	pod, err := agent.Clientset.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("pod %s/%s was not found", namespace, podName),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if pod.Status.Phase != v1.PodRunning {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("pod %s/%s is not running", namespace, podName),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	inReader, inWriter := io.Pipe()
+
+	go func() {
+		defer inWriter.Close()
+
+		for {
+			_, msg, err := safeRW.ReadMessage()
+
+			if err != nil {
+				return
+			}
+
+			if _, err := inWriter.Write(msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = podportforward.Forward(agent, namespace, podName, request.Port, inReader, &binaryWriter{rw: safeRW})
+
+	inReader.Close()
+	safeRW.Close()
+
+	if err != nil {
+		// the connection is closed, so the error can't be sent to the client
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+}
+
+// binaryWriter writes data to the websocket connection as binary messages
+type binaryWriter struct {
+	rw *websocket.WebsocketSafeReadWriter
+}
+
+func (b *binaryWriter) Write(p []byte) (int, error) {
+	return b.rw.WriteBinary(p)
+}
@@ -0,0 +1,87 @@
+package namespace
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// PreviewEnvGroupHandler resolves the templates of a new version of an env group without creating
+// the version. The values of secret variables aren't returned, since they're only readable
+// through the audited secrets endpoint.
+type PreviewEnvGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewPreviewEnvGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PreviewEnvGroupHandler {
+	return &PreviewEnvGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *PreviewEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.PreviewEnvGroupRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	store, err := getExternalSecretStore(c.Config(), cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	resolved, err := envgroup.PreviewEnvGroup(agent, types.ConfigMapInput{
+		Name:                request.Name,
+		Namespace:           namespace,
+		Variables:           request.Variables,
+		SecretVariables:     request.SecretVariables,
+		ExternalSecretStore: store,
+		ResolveOutput:       newOutputResolver(c.Config(), cluster.ProjectID),
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, toCreateEnvGroupAPIError(err))
+		return
+	}
+
+	res := &types.PreviewEnvGroupResponse{
+		Variables:  resolved.Variables,
+		Templates:  resolved.Templates,
+		SecretKeys: make([]string, 0),
+	}
+
+	for key := range resolved.SecretVariables {
+		res.SecretKeys = append(res.SecretKeys, key)
+	}
+
+	sort.Strings(res.SecretKeys)
+
+	c.WriteResult(w, r, res)
+}
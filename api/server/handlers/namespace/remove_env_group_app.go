@@ -63,7 +63,12 @@ func (c *RemoveEnvGroupAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	cm, err = agent.RemoveApplicationFromVersionedConfigMap(cm, request.ApplicationName)
+	// copies of the env group in the namespace of the application are kept, since the application
+	// may still be deployed with them
+	cm, err = agent.RemoveApplicationFromVersionedConfigMap(
+		cm,
+		envgroup.FormatApplication(namespace, request.ApplicationNamespace, request.ApplicationName),
+	)
 
 	if err != nil && errors.Is(err, kubernetes.IsNotFoundError) {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
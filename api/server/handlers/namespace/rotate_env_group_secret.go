@@ -0,0 +1,89 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// RotateEnvGroupSecretHandler rotates a secret variable of an env group right away, and schedules
+// the next rotation of its policy from now
+type RotateEnvGroupSecretHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRotateEnvGroupSecretHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RotateEnvGroupSecretHandler {
+	return &RotateEnvGroupSecretHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RotateEnvGroupSecretHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.RotateEnvGroupSecretRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	policy, err := c.Repo().EnvGroupRotationPolicy().ReadEnvGroupRotationPolicy(cluster.ID, namespace, request.Name, request.Key)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("%s of env group %s has no rotation policy", request.Key, request.Name),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rotationErr := rotateEnvGroupSecret(r.Context(), c.Config(), cluster, agent, helmAgent, policy)
+
+	// the rotation reschedules the policy whether or not it succeeded
+	if err := enqueueEnvGroupRotation(c.Config(), policy); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if rotationErr != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(rotationErr, http.StatusBadRequest))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToEnvGroupRotationPolicyType())
+}
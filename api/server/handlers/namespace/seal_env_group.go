@@ -0,0 +1,129 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/kubernetes/sealedsecrets"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// SealEnvGroupHandler encrypts the variables of an env group with the public key of the cluster's
+// sealed-secrets controller, so that they can be committed to git as a SealedSecret manifest
+type SealEnvGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewSealEnvGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SealEnvGroupHandler {
+	return &SealEnvGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *SealEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.SealEnvGroupRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	if request.SecretName == "" {
+		request.SecretName = request.Name
+	}
+
+	if request.Scope == "" {
+		request.Scope = types.SealedSecretScopeStrict
+	}
+
+	if request.ControllerNamespace == "" {
+		request.ControllerNamespace = sealedsecrets.DefaultControllerNamespace
+	}
+
+	if request.ControllerName == "" {
+		request.ControllerName = sealedsecrets.DefaultControllerName
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	version, variables, secretVariables, err := envgroup.GetStoredVariables(agent, storage, request.Name, namespace, request.Version)
+
+	if err != nil && errors.Is(err, kubernetes.IsNotFoundError) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("env group %s not found", request.Name),
+			http.StatusNotFound,
+		))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	pubKey, err := sealedsecrets.GetPublicKey(agent.Clientset, request.ControllerNamespace, request.ControllerName)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not get the public key of the sealed-secrets controller: is the controller installed in the cluster?"),
+			http.StatusBadRequest,
+			err.Error(),
+		))
+		return
+	}
+
+	// the variables and secret variables of the env group are sealed into a single secret, so that
+	// none of the env group's values are committed in plain text
+	data := make(map[string]string)
+
+	for key, val := range variables {
+		data[key] = val
+	}
+
+	for key, val := range secretVariables {
+		data[key] = val
+	}
+
+	sealedSecret, err := sealedsecrets.Seal(pubKey, request.SecretName, namespace, request.Scope, data)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	manifest, err := yaml.Marshal(sealedSecret)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.SealEnvGroupResponse{
+		Version:  version,
+		Manifest: string(manifest),
+	})
+}
@@ -0,0 +1,89 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/logs"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// StreamLogsHandler streams the aggregated logs of every pod which matches a selector, with each
+// line sent as a JSON message
+type StreamLogsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewStreamLogsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *StreamLogsHandler {
+	return &StreamLogsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *StreamLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.StreamLogsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	safeRW := r.Context().Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// the streams are stopped when the client closes the websocket
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, err := safeRW.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = logs.Stream(ctx, agent.Clientset, namespace, &logs.StreamOpts{
+		Selector:     request.Selector,
+		Container:    request.Container,
+		Follow:       request.Follow,
+		SinceTime:    request.SinceTime,
+		SinceSeconds: request.SinceSeconds,
+		TailLines:    request.TailLines,
+	}, func(line *types.PodLogLine) error {
+		return safeRW.WriteJSON(line)
+	})
+
+	if err != nil && ctx.Err() == nil {
+		if errors.Is(err, logs.ErrNoPods) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
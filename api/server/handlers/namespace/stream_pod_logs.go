@@ -53,7 +53,7 @@ func (c *StreamPodLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = agent.GetPodLogs(namespace, name, request.Container, safeRW)
+	err = agent.GetPodLogs(namespace, name, request.Container, request.Previous, safeRW)
 
 	if err != nil {
 		if errors.Is(err, kubernetes.IsNotFoundError) {
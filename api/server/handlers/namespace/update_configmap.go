@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/kubernetes/restarts"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -102,4 +103,17 @@ func (c *UpdateConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	}
 
 	c.WriteResult(w, r, res)
+
+	// the linked secret is updated in place, so workloads which reference it directly need to
+	// be restarted to pick up the new values
+	if request.RestartWorkloads {
+		_, err = restarts.RestartWorkloads(agent.Clientset, namespace, &types.WorkloadRestartRequest{
+			Kind: types.WorkloadRestartSourceSecret,
+			Name: request.Name,
+		})
+
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
 }
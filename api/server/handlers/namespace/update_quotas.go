@@ -0,0 +1,113 @@
+package namespace
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/quotas"
+	"github.com/porter-dev/porter/internal/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type UpdateResourceQuotaHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUpdateResourceQuotaHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateResourceQuotaHandler {
+	return &UpdateResourceQuotaHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *UpdateResourceQuotaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.UpdateResourceQuotaRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := quotas.SetResourceQuota(agent.Clientset, namespace, request)
+
+	if err != nil {
+		handleQuotaError(c.PorterHandlerReadWriter, w, r, err)
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+type UpdateLimitRangeHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUpdateLimitRangeHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateLimitRangeHandler {
+	return &UpdateLimitRangeHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *UpdateLimitRangeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.UpdateLimitRangeRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := quotas.SetLimitRange(agent.Clientset, namespace, request)
+
+	if err != nil {
+		handleQuotaError(c.PorterHandlerReadWriter, w, r, err)
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// handleQuotaError passes validation errors from the Porter API or the Kubernetes API server
+// through to the client
+func handleQuotaError(handler handlers.PorterHandler, w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, quotas.ErrInvalidQuantity) || k8serrors.IsInvalid(err) {
+		handler.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	handler.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+}
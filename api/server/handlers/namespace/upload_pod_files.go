@@ -0,0 +1,99 @@
+package namespace
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/podfiles"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// UploadPodFilesHandler extracts a tar archive into a directory of a pod container. Uploads
+// are recorded in the audit log.
+type UploadPodFilesHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUploadPodFilesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UploadPodFilesHandler {
+	return &UploadPodFilesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *UploadPodFilesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the archive is base64-encoded in the request body, which adds a third to its size
+	r.Body = http.MaxBytesReader(w, r.Body, podfiles.MaxArchiveSize*4/3+4096)
+
+	request := &types.UploadPodFilesRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamPodName)
+
+	files, size, err := podfiles.ValidateArchive(request.Archive)
+
+	if err != nil {
+		handlePodFilesError(c, w, r, err)
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := podfiles.UploadFiles(agent, namespace, name, request.Container, request.Path, request.Archive); err != nil {
+		handlePodFilesError(c, w, r, err)
+		return
+	}
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"container": request.Container,
+		"path":      request.Path,
+		"files":     files,
+		"bytes":     size,
+	})
+
+	_, err = c.Repo().AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    cluster.ProjectID,
+		ClusterID:    cluster.ID,
+		UserID:       user.ID,
+		Action:       string(types.AuditLogActionPodFilesUpload),
+		ResourceKind: "Pod",
+		ResourceName: name,
+		Namespace:    namespace,
+		Metadata:     metadata,
+	})
+
+	// the files have already been copied, so we report the error without failing the request
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
+	c.WriteResult(w, r, &types.UploadPodFilesResponse{
+		Path:  path.Clean(request.Path),
+		Files: files,
+		Bytes: size,
+	})
+}
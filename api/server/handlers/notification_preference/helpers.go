@@ -0,0 +1,136 @@
+package notification_preference
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// listPreferences returns the preferences of every channel for a project if the user id is 0,
+// or for a user of the project otherwise. Users only have preferences for the email channel,
+// since the other channels are not sent to individual users.
+func listPreferences(
+	repo repository.Repository,
+	projectID, userID uint,
+) (types.ListNotificationPreferencesResponse, error) {
+	channels := types.NotificationChannels
+
+	if userID != 0 {
+		channels = []types.NotificationChannel{types.NotificationChannelEmail}
+	}
+
+	res := make(types.ListNotificationPreferencesResponse, 0)
+
+	for _, channel := range channels {
+		pref, err := readPreference(repo, projectID, userID, channel)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, pref.ToNotificationPreferenceType())
+	}
+
+	return res, nil
+}
+
+// updatePreference stores the preference of a channel for a project if the user id is 0, or
+// for a user of the project otherwise
+func updatePreference(
+	repo repository.Repository,
+	projectID, userID uint,
+	request *types.UpdateNotificationPreferenceRequest,
+) (*models.NotificationPreference, apierrors.RequestError) {
+	if userID != 0 && request.Channel != types.NotificationChannelEmail {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("users can only set preferences for the email channel"),
+			http.StatusBadRequest,
+		)
+	}
+
+	pref, err := readPreference(repo, projectID, userID, request.Channel)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	events := make([]string, 0)
+
+	for _, event := range request.Events {
+		events = append(events, string(event))
+	}
+
+	pref.Events = strings.Join(events, ",")
+	pref.MinSeverity = request.MinSeverity
+
+	if pref.MinSeverity == "" {
+		pref.MinSeverity = types.NotificationSeverityInfo
+	}
+
+	pref.QuietHoursStart = ""
+	pref.QuietHoursEnd = ""
+	pref.QuietHoursTimezone = ""
+
+	if qh := request.QuietHours; qh != nil {
+		if reqErr := validateQuietHours(qh); reqErr != nil {
+			return nil, reqErr
+		}
+
+		pref.QuietHoursStart = qh.Start
+		pref.QuietHoursEnd = qh.End
+		pref.QuietHoursTimezone = qh.Timezone
+	}
+
+	if pref.ID == 0 {
+		pref, err = repo.NotificationPreference().CreateNotificationPreference(pref)
+	} else {
+		pref, err = repo.NotificationPreference().UpdateNotificationPreference(pref)
+	}
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return pref, nil
+}
+
+// readPreference returns the stored preference of a channel, or the default preference if the
+// channel has not been configured
+func readPreference(
+	repo repository.Repository,
+	projectID, userID uint,
+	channel types.NotificationChannel,
+) (*models.NotificationPreference, error) {
+	pref, err := repo.NotificationPreference().ReadNotificationPreference(projectID, userID, channel)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultNotificationPreference(projectID, userID, channel), nil
+	}
+
+	return pref, err
+}
+
+func validateQuietHours(qh *types.QuietHours) apierrors.RequestError {
+	for _, value := range []string{qh.Start, qh.End} {
+		if _, err := models.ParseTimeOfDay(value); err != nil {
+			return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+		}
+	}
+
+	if _, err := time.LoadLocation(qh.Timezone); err != nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid timezone %s", qh.Timezone),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
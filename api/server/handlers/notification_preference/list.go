@@ -0,0 +1,68 @@
+package notification_preference
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NotificationPreferenceListHandler lists the notification preferences of a project
+type NotificationPreferenceListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationPreferenceListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationPreferenceListHandler {
+	return &NotificationPreferenceListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationPreferenceListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	res, err := listPreferences(p.Repo(), project.ID, 0)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
+
+// UserNotificationPreferenceListHandler lists the notification preferences of the current
+// user in a project
+type UserNotificationPreferenceListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewUserNotificationPreferenceListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *UserNotificationPreferenceListHandler {
+	return &UserNotificationPreferenceListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *UserNotificationPreferenceListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	res, err := listPreferences(p.Repo(), project.ID, user.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
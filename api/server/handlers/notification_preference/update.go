@@ -0,0 +1,82 @@
+package notification_preference
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NotificationPreferenceUpdateHandler sets the notification preference of a channel for a
+// project
+type NotificationPreferenceUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewNotificationPreferenceUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *NotificationPreferenceUpdateHandler {
+	return &NotificationPreferenceUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *NotificationPreferenceUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateNotificationPreferenceRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	pref, reqErr := updatePreference(p.Repo(), project.ID, 0, request)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, pref.ToNotificationPreferenceType())
+}
+
+// UserNotificationPreferenceUpdateHandler sets the notification preference of a channel for
+// the current user in a project
+type UserNotificationPreferenceUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserNotificationPreferenceUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserNotificationPreferenceUpdateHandler {
+	return &UserNotificationPreferenceUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserNotificationPreferenceUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateNotificationPreferenceRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	pref, reqErr := updatePreference(p.Repo(), project.ID, user.ID, request)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, pref.ToNotificationPreferenceType())
+}
@@ -0,0 +1,73 @@
+package pagerduty_integration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+)
+
+type PagerDutyIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewPagerDutyIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PagerDutyIntegrationCreateHandler {
+	return &PagerDutyIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *PagerDutyIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreatePagerDutyIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.ClusterID != 0 {
+		if _, err := p.Repo().Cluster().ReadCluster(project.ID, request.ClusterID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("cluster with id %d not found in project", request.ClusterID),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	pdInt, err := p.Repo().PagerDutyIntegration().CreatePagerDutyIntegration(&integrations.PagerDutyIntegration{
+		UserID:     user.ID,
+		ProjectID:  project.ID,
+		Name:       request.Name,
+		ClusterID:  request.ClusterID,
+		Namespace:  request.Namespace,
+		RoutingKey: []byte(request.RoutingKey),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, pdInt.ToPagerDutyIntegrationType())
+}
@@ -0,0 +1,44 @@
+package pagerduty_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type PagerDutyIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewPagerDutyIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *PagerDutyIntegrationListHandler {
+	return &PagerDutyIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *PagerDutyIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	pdInts, err := p.Repo().PagerDutyIntegration().ListPagerDutyIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListPagerDutyIntegrationsResponse, 0)
+
+	for _, pdInt := range pdInts {
+		res = append(res, pdInt.ToPagerDutyIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
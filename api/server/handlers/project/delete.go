@@ -9,6 +9,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
 type ProjectDeleteHandler struct {
@@ -28,7 +29,15 @@ func (p *ProjectDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
-	proj, err := p.Repo().Project().DeleteProject(proj)
+	// the project is deleted in a transaction, so that the cached project is only invalidated
+	// once the delete is committed
+	err := p.Repo().Transaction(func(tx repository.Repository) error {
+		var err error
+
+		proj, err = tx.Project().DeleteProject(proj)
+
+		return err
+	})
 
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -0,0 +1,53 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// DeleteExternalSecretStoreHandler removes the external secret store of a project. Env groups
+// which were created with the store keep syncing their secret variables from it, and new env
+// groups store their secret variables in Secrets.
+type DeleteExternalSecretStoreHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDeleteExternalSecretStoreHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DeleteExternalSecretStoreHandler {
+	return &DeleteExternalSecretStoreHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *DeleteExternalSecretStoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	store, err := c.Repo().ExternalSecretStore().ReadExternalSecretStore(proj.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("project %d has no external secret store", proj.ID),
+			http.StatusNotFound,
+		))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := c.Repo().ExternalSecretStore().DeleteExternalSecretStore(store); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
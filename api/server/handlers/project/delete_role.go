@@ -1,11 +1,13 @@
 package project
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -26,6 +28,7 @@ func NewRoleDeleteHandler(
 }
 
 func (p *RoleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
 	request := &types.DeleteRoleRequest{}
@@ -47,8 +50,15 @@ func (p *RoleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
+	commonutils.RecordAccessChange(p.Config(), proj.ID, user.ID, types.AuditLogActionRoleDelete, "Role",
+		fmt.Sprintf("%d", request.UserID), map[string]interface{}{
+			"user_id": request.UserID,
+			"kind":    role.Kind,
+		})
+
 	res := &types.DeleteRoleResponse{
 		Role: role.ToRoleType(),
 	}
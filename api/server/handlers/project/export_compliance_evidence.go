@@ -0,0 +1,147 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/compliance"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxComplianceExportPeriod is the longest period which a single export can cover
+const maxComplianceExportPeriod = 366 * 24 * time.Hour
+
+type ComplianceExportHandler struct {
+	handlers.PorterHandlerReader
+}
+
+func NewComplianceExportHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *ComplianceExportHandler {
+	return &ComplianceExportHandler{
+		PorterHandlerReader: handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+	}
+}
+
+// ServeHTTP returns a signed zip bundle of the audit logs, access changes, secret reads and
+// deployment approvals of a project over a period. Only project admins can export evidence.
+func (p *ComplianceExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ComplianceExportRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	signer, reqErr := getComplianceSigner(p.Config())
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	role, err := p.Repo().Project().ReadProjectRole(proj.ID, user.ID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err != nil || role.Kind != types.RoleAdmin {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not an admin of project %d", user.ID, proj.ID),
+		))
+		return
+	}
+
+	now := time.Now().UTC()
+	from := request.From.UTC()
+	to := now
+
+	if request.To != nil {
+		to = request.To.UTC()
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxComplianceExportPeriod {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("from must be before to, and the period can be at most 366 days"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	format := request.Format
+
+	if format == "" {
+		format = types.ComplianceExportFormatJSON
+	}
+
+	evidence, err := compliance.Collect(p.Repo(), proj, from, to)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the bundle is buffered so that errors can be reported before any of it is written
+	buf := &bytes.Buffer{}
+
+	if err := compliance.WriteBundle(buf, evidence, format, user.Email, signer, now); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	_, err = p.Repo().AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    proj.ID,
+		UserID:       user.ID,
+		Action:       string(types.AuditLogActionComplianceExport),
+		ResourceKind: "Project",
+		ResourceName: proj.Name,
+		Metadata: []byte(fmt.Sprintf(
+			`{"from":%q,"to":%q,"format":%q}`, from.Format(time.RFC3339), to.Format(time.RFC3339), format,
+		)),
+	})
+
+	// evidence should not leave Porter without a record of the export
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	filename := fmt.Sprintf("compliance-%d-%s-%s.zip", proj.ID, from.Format("20060102"), to.Format("20060102"))
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Write(buf.Bytes())
+}
+
+// getComplianceSigner returns the signer of compliance exports, or an error if exports are
+// not configured
+func getComplianceSigner(config *config.Config) (*compliance.Signer, apierrors.RequestError) {
+	if config.ServerConf.ComplianceSigningKey == "" {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("compliance exports are not enabled: COMPLIANCE_SIGNING_KEY is not set"),
+			http.StatusBadRequest,
+		)
+	}
+
+	signer, err := compliance.NewSigner(config.ServerConf.ComplianceSigningKey)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return signer, nil
+}
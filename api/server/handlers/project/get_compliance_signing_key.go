@@ -0,0 +1,39 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+)
+
+type GetComplianceSigningKeyHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetComplianceSigningKeyHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetComplianceSigningKeyHandler {
+	return &GetComplianceSigningKeyHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the public key which verifies the signatures of compliance exports, which
+// auditors use to check that a bundle wasn't modified after it was exported
+func (p *GetComplianceSigningKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	signer, reqErr := getComplianceSigner(p.Config())
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, &types.ComplianceSigningKeyResponse{
+		Algorithm: "ed25519",
+		PublicKey: signer.PublicKey(),
+	})
+}
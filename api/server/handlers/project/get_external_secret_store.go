@@ -0,0 +1,44 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetExternalSecretStoreHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetExternalSecretStoreHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetExternalSecretStoreHandler {
+	return &GetExternalSecretStoreHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetExternalSecretStoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	store, err := c.Repo().ExternalSecretStore().ReadExternalSecretStore(proj.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		// projects without a store keep the secret variables of env groups in Secrets
+		c.WriteResult(w, r, &types.ExternalSecretStore{ProjectID: proj.ID})
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, store.ToExternalSecretStoreType())
+}
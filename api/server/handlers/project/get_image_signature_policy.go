@@ -0,0 +1,44 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetImageSignaturePolicyHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetImageSignaturePolicyHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetImageSignaturePolicyHandler {
+	return &GetImageSignaturePolicyHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetImageSignaturePolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	policy, err := c.Repo().ImageSignaturePolicy().ReadImageSignaturePolicy(proj.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		// projects without a policy deploy images without verifying signatures
+		c.WriteResult(w, r, &types.ImageSignaturePolicy{ProjectID: proj.ID})
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToImageSignaturePolicyType())
+}
@@ -0,0 +1,71 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/usage"
+)
+
+// ProjectGetUsageHistoryHandler returns the hourly usage which was metered for a project,
+// which defaults to the usage of the current billing period
+type ProjectGetUsageHistoryHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewProjectGetUsageHistoryHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ProjectGetUsageHistoryHandler {
+	return &ProjectGetUsageHistoryHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ProjectGetUsageHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.GetProjectUsageHistoryRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	from := usage.GetBillingPeriod(now)
+	to := now
+
+	if request.From != nil {
+		from = *request.From
+	}
+
+	if request.To != nil {
+		to = *request.To
+	}
+
+	if !from.Before(to) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("from must be before to"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	res, err := usage.GetUsageHistory(p.Repo(), proj.ID, from, to)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,66 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ListBackgroundJobsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListBackgroundJobsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListBackgroundJobsHandler {
+	return &ListBackgroundJobsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ListBackgroundJobsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListBackgroundJobsRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	opts := commonutils.GetListOptions(
+		&request.ListOptions,
+		repository.Filter{Field: "status", Values: request.Status},
+		repository.Filter{Field: "kind", Values: request.Kind},
+	)
+
+	jobs, err := p.Repo().BackgroundJob().ListBackgroundJobs(proj.ID, opts)
+
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidListOptions) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListBackgroundJobsResponse, 0)
+
+	for _, job := range jobs {
+		res = append(res, job.ToBackgroundJobType())
+	}
+
+	commonutils.SetNextCursor(w, opts)
+	p.WriteResult(w, r, res)
+}
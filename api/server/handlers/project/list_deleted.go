@@ -0,0 +1,66 @@
+package project
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListDeletedProjectsHandler lists the deleted projects which the user can restore, which
+// are the projects that the user is an admin of and that are within the retention window
+type ListDeletedProjectsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListDeletedProjectsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListDeletedProjectsHandler {
+	return &ListDeletedProjectsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ListDeletedProjectsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	retentionDays := p.Config().ServerConf.SoftDeleteRetentionDays
+
+	projects, err := p.Repo().Project().ListDeletedProjectsByUserID(user.ID, time.Now().AddDate(0, 0, -int(retentionDays)))
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDeletedProjectsResponse, 0)
+
+	for _, proj := range projects {
+		if !isProjectAdmin(proj, user.ID) {
+			continue
+		}
+
+		res = append(res, &types.DeletedProject{
+			Project:      proj.ToProjectType(),
+			DeletionMeta: types.NewDeletionMeta(proj.DeletedAt.Time, retentionDays),
+		})
+	}
+
+	p.WriteResult(w, r, res)
+}
+
+func isProjectAdmin(proj *models.Project, userID uint) bool {
+	for _, role := range proj.Roles {
+		if role.UserID == userID && role.Kind == types.RoleAdmin {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type RequeueBackgroundJobHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRequeueBackgroundJobHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RequeueBackgroundJobHandler {
+	return &RequeueBackgroundJobHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *RequeueBackgroundJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	jobID, reqErr := requestutils.GetURLParamUint(r, "job_id")
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	job, err := p.Repo().BackgroundJob().ReadBackgroundJob(proj.ID, jobID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(errors.New("no such background job exists")))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// only jobs which will not run again can be requeued
+	if job.Status != types.BackgroundJobDead && job.Status != types.BackgroundJobSucceeded {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("cannot requeue background job with status %s", job.Status), http.StatusConflict,
+		))
+		return
+	}
+
+	job, err = p.Config().JobQueue.Requeue(job)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, job.ToBackgroundJobType())
+}
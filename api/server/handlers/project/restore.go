@@ -0,0 +1,94 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+var errProjectNotRestorable = errors.New("project not found, or deleted before the retention window")
+
+// ProjectRestoreHandler restores a deleted project. Since the project scope cannot be read for
+// deleted projects, the handler checks that the user is an admin of the project itself.
+type ProjectRestoreHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewProjectRestoreHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ProjectRestoreHandler {
+	return &ProjectRestoreHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ProjectRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	projID, reqErr := requestutils.GetURLParamUint(r, types.URLParamProjectID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	role, err := p.Repo().Project().ReadProjectRole(projID, user.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(errProjectNotRestorable))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if role.Kind != types.RoleAdmin {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not an admin of project %d", user.ID, projID),
+		))
+		return
+	}
+
+	retentionDays := p.Config().ServerConf.SoftDeleteRetentionDays
+
+	var proj *models.Project
+
+	err = p.Repo().Transaction(func(tx repository.Repository) error {
+		proj, err = tx.Project().RestoreProject(projID, time.Now().AddDate(0, 0, -int(retentionDays)))
+
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(errProjectNotRestorable))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, proj.ToProjectType())
+
+	// the billing team of the project was deleted along with it, so it is created again
+	if _, err := p.Config().BillingManager.CreateTeam(user, proj); err != nil {
+		// we do not write error response, since setting up billing error can be
+		// resolved later and may not be fatal
+		p.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+}
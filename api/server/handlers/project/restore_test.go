@@ -0,0 +1,72 @@
+package project_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/billing"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// teamRecorder records the projects which billing teams are created for
+type teamRecorder struct {
+	billing.NoopBillingManager
+
+	teams []uint
+}
+
+func (b *teamRecorder) CreateTeam(user *models.User, proj *models.Project) (string, error) {
+	b.teams = append(b.teams, proj.ID)
+
+	return fmt.Sprintf("%d", proj.ID), nil
+}
+
+func TestRestoreProjectCreatesBillingTeam(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{Name: "test-project"}, user)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, err := config.Repo.Project().DeleteProject(proj); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	billingManager := &teamRecorder{}
+	config.BillingManager = billingManager
+	config.ServerConf.SoftDeleteRetentionDays = 30
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/restore", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamProjectID): fmt.Sprintf("%d", proj.ID),
+	})
+
+	handler := project.NewProjectRestoreHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	if _, err := config.Repo.Project().ReadProject(proj.ID); err != nil {
+		t.Errorf("expected the project to be restored, got %v", err)
+	}
+
+	// the billing team which was deleted with the project is created again
+	if len(billingManager.teams) != 1 || billingManager.teams[0] != proj.ID {
+		t.Errorf("expected a billing team to be created for project %d, got %v", proj.ID, billingManager.teams)
+	}
+}
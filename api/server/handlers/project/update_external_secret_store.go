@@ -0,0 +1,78 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// UpdateExternalSecretStoreHandler sets the secret store of the External Secrets Operator which
+// the env groups created in a project sync their secret variables from
+type UpdateExternalSecretStoreHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateExternalSecretStoreHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateExternalSecretStoreHandler {
+	return &UpdateExternalSecretStoreHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateExternalSecretStoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateExternalSecretStoreRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.RefreshInterval == "" {
+		request.RefreshInterval = types.DefaultExternalSecretRefreshInterval
+	}
+
+	if interval, err := time.ParseDuration(request.RefreshInterval); err != nil || interval <= 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid refresh interval %s: must be a positive duration such as 1h", request.RefreshInterval),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	store, err := c.Repo().ExternalSecretStore().ReadExternalSecretStore(proj.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		store = &models.ExternalSecretStore{
+			ProjectID: proj.ID,
+		}
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	store.Kind = request.Kind
+	store.Name = request.Name
+	store.RefreshInterval = request.RefreshInterval
+
+	store, err = c.Repo().ExternalSecretStore().CreateOrUpdateExternalSecretStore(store)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, store.ToExternalSecretStoreType())
+}
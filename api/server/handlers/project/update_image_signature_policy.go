@@ -0,0 +1,77 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"gorm.io/gorm"
+)
+
+type UpdateImageSignaturePolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateImageSignaturePolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateImageSignaturePolicyHandler {
+	return &UpdateImageSignaturePolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateImageSignaturePolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateImageSignaturePolicyRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Enabled && request.PublicKey == "" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a public key is required to enable image signature verification"), http.StatusBadRequest,
+		))
+		return
+	}
+
+	if request.PublicKey != "" {
+		if _, err := registry.ParseSigningPublicKey(request.PublicKey); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	}
+
+	policy, err := c.Repo().ImageSignaturePolicy().ReadImageSignaturePolicy(proj.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		policy = &models.ImageSignaturePolicy{
+			ProjectID: proj.ID,
+		}
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	policy.Enabled = request.Enabled
+	policy.PublicKey = request.PublicKey
+
+	policy, err = c.Repo().ImageSignaturePolicy().CreateOrUpdateImageSignaturePolicy(policy)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToImageSignaturePolicyType())
+}
@@ -1,11 +1,13 @@
 package project
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -26,6 +28,7 @@ func NewRoleUpdateHandler(
 }
 
 func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
 	request := &types.UpdateRoleRequest{}
@@ -43,6 +46,7 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previousKind := role.Kind
 	role.Kind = types.RoleKind(request.Kind)
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
@@ -52,6 +56,13 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	commonutils.RecordAccessChange(p.Config(), proj.ID, user.ID, types.AuditLogActionRoleUpdate, "Role",
+		fmt.Sprintf("%d", request.UserID), map[string]interface{}{
+			"user_id":       request.UserID,
+			"previous_kind": previousKind,
+			"kind":          role.Kind,
+		})
+
 	var res = types.UpdateRoleResponse{
 		Role: role.ToRoleType(),
 	}
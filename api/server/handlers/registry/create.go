@@ -80,6 +80,14 @@ func (p *RegistryCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if request.Service != "" && (request.BasicIntegrationID == 0 ||
+		(request.Service != types.DockerHub && request.Service != types.Harbor && request.Service != types.Quay)) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("service %s is not supported for this integration", request.Service), http.StatusBadRequest,
+		))
+		return
+	}
+
 	var err error
 
 	if request.GCPIntegrationID != 0 {
@@ -169,9 +177,31 @@ func (p *RegistryCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		DOIntegrationID:    request.DOIntegrationID,
 		BasicIntegrationID: request.BasicIntegrationID,
 		AzureIntegrationID: request.AzureIntegrationID,
+		Service:            request.Service,
 	}
 
-	if regModel.URL == "" && regModel.AWSIntegrationID != 0 {
+	if regModel.Service == types.DockerHub {
+		regModel.URL, err = registry.NormalizeDockerHubURL(request.URL)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	} else if regModel.Service == types.Harbor {
+		regModel.URL, err = registry.NormalizeHarborURL(request.URL)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	} else if regModel.Service == types.Quay {
+		regModel.URL, err = registry.NormalizeQuayURL(request.URL)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	} else if regModel.URL == "" && regModel.AWSIntegrationID != 0 {
 		url, err := registry.GetECRRegistryURL(p.Repo().AWSIntegration(), regModel.ProjectID, regModel.AWSIntegrationID)
 
 		if err != nil {
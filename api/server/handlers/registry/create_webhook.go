@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RegistryCreateWebhookHandler generates a new push webhook URL for a registry, invalidating
+// the previous URL
+type RegistryCreateWebhookHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRegistryCreateWebhookHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryCreateWebhookHandler {
+	return &RegistryCreateWebhookHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *RegistryCreateWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	token, err := encryption.GenerateRandomBytes(16)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	reg.WebhookToken = token
+
+	if _, err := c.Repo().Registry().UpdateRegistry(reg); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.WriteResult(w, r, &types.CreateRegistryWebhookResponse{
+		WebhookURL: fmt.Sprintf("%s/api/webhooks/registry/%s", c.Config().ServerConf.ServerURL, token),
+	})
+}
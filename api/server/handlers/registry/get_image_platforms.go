@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+// RegistryGetImagePlatformsHandler returns the platforms which an image of the registry
+// supports, such as linux/amd64 and linux/arm64 for multi-architecture images
+type RegistryGetImagePlatformsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryGetImagePlatformsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryGetImagePlatformsHandler {
+	return &RegistryGetImagePlatformsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *RegistryGetImagePlatformsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.GetImagePlatformsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	regURL := strings.TrimSuffix(reg.ToRegistryType().URL, "/")
+	repoName := strings.TrimPrefix(strings.Trim(request.Repository, "/"), regURL+"/")
+
+	image := fmt.Sprintf("%s/%s:%s", regURL, repoName, request.Reference)
+
+	if strings.HasPrefix(request.Reference, "sha256:") {
+		if err := registry.ValidateImageDigest(request.Reference); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		image = fmt.Sprintf("%s/%s@%s", regURL, repoName, request.Reference)
+	}
+
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	platforms, err := regAPI.GetImagePlatforms(c.Repo(), c.Config().DOConf, image)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not read platforms of image %s: %w", image, err), http.StatusBadRequest,
+		))
+		return
+	}
+
+	c.WriteResult(w, r, &types.GetImagePlatformsResponse{
+		Image:     image,
+		Platforms: platforms,
+	})
+}
@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type RegistryGetRetentionPolicyHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRegistryGetRetentionPolicyHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryGetRetentionPolicyHandler {
+	return &RegistryGetRetentionPolicyHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *RegistryGetRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	policy, err := c.Repo().RegistryRetentionPolicy().ReadRegistryRetentionPolicy(reg.ProjectID, reg.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		// registries without a policy keep all images
+		c.WriteResult(w, r, &types.RegistryRetentionPolicy{RegistryID: reg.ID})
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToRegistryRetentionPolicyType())
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"gorm.io/gorm"
+)
+
+// RegistryGetRetentionReportHandler runs the registry's retention policy as a dry run, and
+// reports the images which would be deleted without deleting them
+type RegistryGetRetentionReportHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRegistryGetRetentionReportHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryGetRetentionReportHandler {
+	return &RegistryGetRetentionReportHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *RegistryGetRetentionReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	policy, err := c.Repo().RegistryRetentionPolicy().ReadRegistryRetentionPolicy(reg.ProjectID, reg.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(errors.New("no retention policy is configured for this registry")))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// cast to a registry from registry package
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	report, err := regAPI.EnforceRetention(policy, c.Repo(), c.Config().DOConf, true, time.Now().UTC())
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, report)
+}
@@ -327,7 +327,7 @@ func (c *RegistryGetDockerhubTokenHandler) ServeHTTP(w http.ResponseWriter, r *h
 	var expiresAt *time.Time
 
 	for _, reg := range regs {
-		if reg.BasicIntegrationID != 0 && strings.Contains(reg.URL, "index.docker.io") {
+		if reg.BasicIntegrationID != 0 && (reg.Service == types.DockerHub || strings.Contains(reg.URL, "index.docker.io")) {
 			basic, err := c.Repo().BasicIntegration().ReadBasicIntegration(reg.ProjectID, reg.BasicIntegrationID)
 
 			if err != nil {
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+const defaultUsageRepositoryLimit = 10
+
+// RegistryGetUsageHandler returns the number of repositories and images of a registry, along
+// with the storage used by its largest repositories
+type RegistryGetUsageHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryGetUsageHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryGetUsageHandler {
+	return &RegistryGetUsageHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *RegistryGetUsageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.GetRegistryUsageRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	limit := int(request.Limit)
+
+	if limit == 0 {
+		limit = defaultUsageRepositoryLimit
+	}
+
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	usage, err := regAPI.GetUsage(c.Repo(), c.Config().DOConf, limit, time.Now())
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, usage)
+}
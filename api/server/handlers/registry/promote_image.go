@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"gorm.io/gorm"
+)
+
+// RegistryPromoteImageHandler copies an image from this registry to another registry of the
+// project, such as from a development ECR registry to a production ECR registry
+type RegistryPromoteImageHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryPromoteImageHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryPromoteImageHandler {
+	return &RegistryPromoteImageHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *RegistryPromoteImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.PromoteImageRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if err := registry.ValidateImageDigest(request.Digest); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	targetReg, err := c.Repo().Registry().ReadRegistry(reg.ProjectID, request.TargetRegistryID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("target registry %d not found", request.TargetRegistryID), http.StatusNotFound,
+			))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	targetRepository := request.TargetRepository
+
+	if targetRepository == "" {
+		targetRepository = request.SourceRepository
+	}
+
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	_targetReg := registry.Registry(*targetReg)
+
+	image, err := regAPI.PromoteImage(
+		c.Repo(),
+		c.Config().DOConf,
+		request.SourceRepository,
+		request.Digest,
+		&_targetReg,
+		targetRepository,
+		request.Tag,
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error promoting image: %w", err), http.StatusBadRequest,
+		))
+		return
+	}
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"source_registry_id": reg.ID,
+		"source_repository":  request.SourceRepository,
+		"digest":             request.Digest,
+		"target_registry_id": targetReg.ID,
+		"target_repository":  targetRepository,
+		"tag":                request.Tag,
+	})
+
+	_, err = c.Repo().AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    reg.ProjectID,
+		UserID:       user.ID,
+		Action:       string(types.AuditLogActionImagePromote),
+		ResourceKind: "Image",
+		ResourceName: image,
+		Metadata:     metadata,
+	})
+
+	// the image has already been promoted, so we report the error without failing the request
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
+	c.WriteResult(w, r, &types.PromoteImageResponse{
+		Image:  image,
+		Digest: request.Digest,
+	})
+}
@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"gorm.io/gorm"
+)
+
+type RegistryUpdateRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryUpdateRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryUpdateRetentionPolicyHandler {
+	return &RegistryUpdateRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *RegistryUpdateRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.UpdateRegistryRetentionPolicyRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	policy, err := c.Repo().RegistryRetentionPolicy().ReadRegistryRetentionPolicy(reg.ProjectID, reg.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		policy = &models.RegistryRetentionPolicy{
+			ProjectID:  reg.ProjectID,
+			RegistryID: reg.ID,
+		}
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	policy.Enabled = request.Enabled
+	policy.KeepLastTags = request.KeepLastTags
+	policy.DeleteUntaggedAfterDays = request.DeleteUntaggedAfterDays
+	policy.ProtectedTagPattern = request.ProtectedTagPattern
+
+	if err := registry.ValidateRetentionPolicy(policy); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	policy, err = c.Repo().RegistryRetentionPolicy().CreateOrUpdateRegistryRetentionPolicy(policy)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToRegistryRetentionPolicyType())
+}
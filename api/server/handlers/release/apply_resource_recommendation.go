@@ -0,0 +1,117 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes/rightsizing"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// ApplyResourceRecommendationHandler upgrades a release with its recommended resource requests
+// and limits, leaving the rest of its values unchanged
+type ApplyResourceRecommendationHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewApplyResourceRecommendationHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApplyResourceRecommendationHandler {
+	return &ApplyResourceRecommendationHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ApplyResourceRecommendationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.ApplyResourceRecommendationRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the recommendation is applied to the latest revision, so that the upgrade doesn't revert
+	// changes made since the revision of the request
+	currHelmRelease, err := helmAgent.GetRelease(helmRelease.Name, 0, false)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.LatestRevision != 0 && currHelmRelease.Version != int(request.LatestRevision) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("the provided revision %d is not the latest revision %d", request.LatestRevision, currHelmRelease.Version),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rec, apiErr := getResourceRecommendation(agent.Clientset, currHelmRelease, request.WindowDays)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	res, apiErr := applyResourceRecommendation(c.Config(), user, cluster, helmAgent, currHelmRelease, rec)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	writeDeployResult(c, w, r, res, rec)
+}
+
+// applyResourceRecommendation upgrades the latest revision of a release with its recommended
+// resource requests and limits
+func applyResourceRecommendation(
+	config *config.Config,
+	user *models.User,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+	rec *types.ResourceRecommendation,
+) (*DeployResult, apierrors.RequestError) {
+	return Deploy(config, &DeployOpts{
+		Cluster:        cluster,
+		HelmAgent:      helmAgent,
+		HelmRelease:    helmRelease,
+		User:           user,
+		Source:         types.DeploySourceRightsizing,
+		Values:         rightsizing.ApplyToValues(helmRelease.Config, rec.Recommended),
+		LatestRevision: uint(helmRelease.Version),
+	})
+}
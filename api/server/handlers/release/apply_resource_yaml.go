@@ -0,0 +1,123 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/resources"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// ApplyResourceYAMLHandler applies an edited version of a resource owned by a release, using
+// server-side apply. Applies which are not dry runs are recorded in the audit log.
+type ApplyResourceYAMLHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewApplyResourceYAMLHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ApplyResourceYAMLHandler {
+	return &ApplyResourceYAMLHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ApplyResourceYAMLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ApplyResourceYAMLRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	obj := &unstructured.Unstructured{}
+
+	if err := yaml.Unmarshal([]byte(request.YAML), &obj.Object); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	gvk, namespace, err := resources.FindReleaseResource(helmRelease.Manifest, helmRelease.Namespace, obj.GetKind(), obj.GetName())
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	client, err := resources.NewClient(agent, dynClient)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	applied, err := client.Apply(gvk, namespace, obj.GetName(), []byte(request.YAML), request.DryRun)
+
+	if errors.Is(err, resources.ErrInvalidResource) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !request.DryRun {
+		metadata, _ := json.Marshal(map[string]interface{}{
+			"api_version":     applied.APIVersion,
+			"release_version": helmRelease.Version,
+		})
+
+		_, err = c.Repo().AuditLog().CreateAuditLog(&models.AuditLog{
+			ProjectID:    cluster.ProjectID,
+			ClusterID:    cluster.ID,
+			UserID:       user.ID,
+			Action:       string(types.AuditLogActionResourceApply),
+			ResourceKind: applied.Kind,
+			ResourceName: applied.Name,
+			Namespace:    applied.Namespace,
+			ReleaseName:  helmRelease.Name,
+			Metadata:     metadata,
+		})
+
+		// the change has already been applied, so we report the error without failing the request
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
+	c.WriteResult(w, r, &types.ApplyResourceYAMLResponse{
+		ResourceYAML: applied,
+		DryRun:       request.DryRun,
+	})
+}
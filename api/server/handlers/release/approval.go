@@ -0,0 +1,283 @@
+package release
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/email"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// requestApproval stores an upgrade as a pending approval if an approval policy applies to the
+// release, and notifies the approvers. Older pending approvals of the release are superseded.
+// It returns nil if no approval policy applies to the release.
+func requestApproval(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+	userID uint,
+	request *types.UpgradeReleaseRequest,
+) (*models.Approval, apierrors.RequestError) {
+	policyID, approverIDs, apiErr := findApprovalPolicy(config, cluster, helmRelease.Namespace)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if policyID == 0 {
+		return nil, nil
+	}
+
+	// a stale upgrade should fail now, rather than when it is approved
+	if apiErr := checkLatestRevision(helmAgent, helmRelease.Name, request.LatestRevision); apiErr != nil {
+		return nil, apiErr
+	}
+
+	featureFlags, err := models.FormatFeatureFlagChanges(request.FeatureFlags)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	pending, err := config.Repo.Approval().ListPendingApprovalsByRelease(cluster.ID, helmRelease.Namespace, helmRelease.Name)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	for _, approval := range pending {
+		approval.Status = types.ApprovalStatusSuperseded
+
+		if _, err := config.Repo.Approval().UpdateApproval(approval); err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+	}
+
+	approval, err := config.Repo.Approval().CreateApproval(&models.Approval{
+		ProjectID:         cluster.ProjectID,
+		ClusterID:         cluster.ID,
+		Namespace:         helmRelease.Namespace,
+		Name:              helmRelease.Name,
+		PolicyID:          policyID,
+		RequestedByUserID: userID,
+		Values:            []byte(request.Values),
+		ChartVersion:      request.ChartVersion,
+		LatestRevision:    request.LatestRevision,
+		FeatureFlags:      featureFlags,
+		ApproverIDs:       models.FormatIDs(approverIDs),
+		Status:            types.ApprovalStatusPending,
+	})
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	var requestedBy string
+
+	if userID != 0 {
+		if user, err := config.Repo.User().ReadUser(userID); err == nil {
+			requestedBy = user.Email
+		}
+	}
+
+	err = email.SendApprovalRequest(config.Repo, config.EmailSender, &email.ApprovalRequestOpts{
+		Approval:    approval,
+		ClusterName: cluster.Name,
+		RequestedBy: requestedBy,
+		URL: fmt.Sprintf(
+			"%s/applications/%s/%s/%s?project_id=%d",
+			config.ServerConf.ServerURL,
+			url.PathEscape(cluster.Name),
+			helmRelease.Namespace,
+			helmRelease.Name,
+			cluster.ProjectID,
+		),
+	})
+
+	// the approval can be reviewed even if the approvers could not be notified
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error notifying the approvers of approval %d", approval.ID)
+	}
+
+	return approval, nil
+}
+
+// findApprovalPolicy returns the first approval policy which applies to the releases in a
+// namespace of a cluster, and the approvers of every policy which applies to them. The policy
+// is 0 if no approval policy applies to the releases.
+func findApprovalPolicy(
+	config *config.Config,
+	cluster *models.Cluster,
+	namespace string,
+) (uint, []uint, apierrors.RequestError) {
+	policies, err := config.Repo.Approval().ListApprovalPolicies(cluster.ProjectID)
+
+	if err != nil {
+		return 0, nil, apierrors.NewErrInternal(err)
+	}
+
+	var policyID uint
+	approverIDs := make([]uint, 0)
+	seen := make(map[uint]bool)
+
+	// the approvers of every policy which applies to the release can approve the upgrade
+	for _, policy := range policies {
+		if !policy.Applies(cluster.ID, namespace) {
+			continue
+		}
+
+		if policyID == 0 {
+			policyID = policy.ID
+		}
+
+		for _, id := range policy.ToApprovalPolicyType().ApproverIDs {
+			if !seen[id] {
+				seen[id] = true
+				approverIDs = append(approverIDs, id)
+			}
+		}
+	}
+
+	return policyID, approverIDs, nil
+}
+
+// ExecuteApproval executes the upgrade of an approval which was approved by the reviewer, and
+// records the revision which the upgrade created, or the reason the upgrade failed, on the
+// approval
+func ExecuteApproval(
+	config *config.Config,
+	approval *models.Approval,
+	reviewer *models.User,
+	comment string,
+) (*models.Approval, apierrors.RequestError) {
+	cluster, err := config.Repo.Cluster().ReadCluster(approval.ProjectID, approval.ClusterID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	now := time.Now().UTC()
+
+	approval.Status = types.ApprovalStatusApproved
+	approval.ReviewedByUserID = reviewer.ID
+	approval.ReviewedAt = &now
+	approval.Comment = comment
+
+	helmRelease, apiErr := executeApproval(config, cluster, approval)
+
+	if apiErr != nil {
+		approval.Status = types.ApprovalStatusFailed
+		approval.Error = apiErr.ExternalError()
+	} else {
+		approval.Revision = helmRelease.Version
+	}
+
+	approval, err = config.Repo.Approval().UpdateApproval(approval)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if apiErr != nil {
+		return approval, apiErr
+	}
+
+	recordReleaseUpgrade(config, approval.ProjectID, approval.ClusterID, approval.RequestedByUserID, helmRelease, map[string]interface{}{
+		"approval_id":    approval.ID,
+		"approved_by_id": reviewer.ID,
+		"revision":       approval.Revision,
+	})
+
+	applyFeatureFlags(
+		config, approval.ProjectID, approval.ClusterID, approval.Namespace, approval.Name,
+		approval.Revision, models.ParseFeatureFlagChanges(approval.FeatureFlags),
+	)
+
+	if err := postUpgrade(config, cluster.ProjectID, cluster.ID, helmRelease); err != nil {
+		config.Logger.Error().Err(err).Msgf("error running post-upgrade steps of approval %d", approval.ID)
+	}
+
+	return approval, nil
+}
+
+func executeApproval(
+	config *config.Config,
+	cluster *models.Cluster,
+	approval *models.Approval,
+) (*release.Release, apierrors.RequestError) {
+	target, apiErr := readUpgradeTarget(config, cluster, approval.Namespace, approval.Name, approval.RequestedByUserID)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return upgradeRelease(config, target.user, cluster, target.helmAgent, target.helmRelease, target.registries, &types.UpgradeReleaseRequest{
+		Values:         string(approval.Values),
+		ChartVersion:   approval.ChartVersion,
+		LatestRevision: approval.LatestRevision,
+	}, nil)
+}
+
+// upgradeTarget is the release which a stored upgrade is executed on, read when the upgrade
+// is executed rather than when it was requested
+type upgradeTarget struct {
+	helmAgent   *helm.Agent
+	helmRelease *release.Release
+	registries  []*models.Registry
+
+	// the user who requested the upgrade, which is nil if the upgrade was triggered by a
+	// webhook or the user no longer exists
+	user *models.User
+}
+
+func readUpgradeTarget(
+	config *config.Config,
+	cluster *models.Cluster,
+	namespace, name string,
+	userID uint,
+) (*upgradeTarget, apierrors.RequestError) {
+	helmAgent, err := helm.GetAgentOutOfClusterConfig(&helm.Form{
+		Cluster:           cluster,
+		Repo:              config.Repo,
+		DigitalOceanOAuth: config.DOConf,
+		Storage:           "secret",
+		Namespace:         namespace,
+	}, config.Logger)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	helmRelease, err := helmAgent.GetRelease(name, 0, false)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(fmt.Errorf("error reading release %s: %w", name, err))
+	}
+
+	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	// the upgrade is made on behalf of the user who requested it, if the user still exists
+	var user *models.User
+
+	if userID != 0 {
+		user, _ = config.Repo.User().ReadUser(userID)
+	}
+
+	return &upgradeTarget{
+		helmAgent:   helmAgent,
+		helmRelease: helmRelease,
+		registries:  registries,
+		user:        user,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/kubernetes/nodes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"k8s.io/client-go/kubernetes"
+)
+
+// checkArchitecturePolicy checks that the image to deploy supports the architectures of the
+// cluster's nodes. If the image supports none of them, the upgrade is rejected when the
+// release blocks architecture mismatches, and a warning is returned otherwise. A warning is
+// also returned when only some of the nodes are supported. Images whose platforms can't be
+// read are allowed, so that registries without manifest access don't block all deploys.
+func checkArchitecturePolicy(
+	config *config.Config,
+	rel *models.Release,
+	clientset kubernetes.Interface,
+	registries []*models.Registry,
+	image string,
+) (string, apierrors.RequestError) {
+	if image == "" {
+		return "", nil
+	}
+
+	regModel := registry.GetRegistryForImageRepo(registries, image)
+
+	if regModel == nil {
+		return "", nil
+	}
+
+	_reg := registry.Registry(*regModel)
+
+	platforms, err := _reg.GetImagePlatforms(config.Repo, config.DOConf, image)
+
+	if err != nil || len(platforms) == 0 {
+		return "", nil
+	}
+
+	nodeArchs, err := nodes.GetNodeArchitectures(clientset)
+
+	if err != nil || len(nodeArchs) == 0 {
+		return "", nil
+	}
+
+	unsupported := registry.GetUnsupportedArchitectures(platforms, nodeArchs)
+
+	if len(unsupported) == 0 {
+		return "", nil
+	}
+
+	if len(unsupported) < len(nodeArchs) {
+		return fmt.Sprintf(
+			"image %s supports %s, so it can't run on the %s nodes of this cluster",
+			image, registry.FormatPlatforms(platforms), strings.Join(unsupported, ", "),
+		), nil
+	}
+
+	message := fmt.Sprintf(
+		"image %s supports %s, but the nodes of this cluster are %s",
+		image, registry.FormatPlatforms(platforms), strings.Join(nodeArchs, ", "),
+	)
+
+	if rel != nil && rel.BlockArchitectureMismatch {
+		return "", apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("%s, and this release blocks upgrades with mismatched architectures", message),
+			http.StatusPreconditionFailed,
+		)
+	}
+
+	return message, nil
+}
+
+// writeWarning adds a warning to the response, in the format used by the Kubernetes API
+func writeWarning(w http.ResponseWriter, warning string) {
+	w.Header().Add("Warning", fmt.Sprintf("299 - %q", warning))
+}
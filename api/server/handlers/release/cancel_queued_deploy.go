@@ -0,0 +1,80 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CancelQueuedDeployHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCancelQueuedDeployHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CancelQueuedDeployHandler {
+	return &CancelQueuedDeployHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP cancels a queued upgrade of a release. Upgrades which have started running
+// cannot be cancelled.
+func (c *CancelQueuedDeployHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	deployID, reqErr := requestutils.GetURLParamUint(r, types.URLParamDeployID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	deploy, err := c.Repo().QueuedDeploy().ReadQueuedDeploy(cluster.ID, deployID)
+
+	if err == nil && (deploy.Namespace != namespace || deploy.Name != name) {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("deploy %d not found", deployID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	cancelled, err := c.Repo().QueuedDeploy().CancelQueuedDeploy(deploy, user.ID, time.Now().UTC())
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !cancelled {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("deploy %d is no longer queued and cannot be cancelled", deployID),
+			http.StatusConflict,
+		))
+
+		return
+	}
+
+	c.WriteResult(w, r, deploy.ToQueuedDeployType())
+}
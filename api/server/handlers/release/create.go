@@ -27,6 +27,7 @@ import (
 	"github.com/porter-dev/porter/internal/registry"
 	"golang.org/x/crypto/bcrypt"
 	"gopkg.in/yaml.v2"
+	"gorm.io/gorm"
 	"helm.sh/helm/v3/pkg/release"
 	v1 "k8s.io/api/core/v1"
 )
@@ -77,6 +78,23 @@ func (c *CreateReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		request.RepoURL = c.Config().ServerConf.DefaultApplicationHelmRepoURL
 	}
 
+	if request.GrafanaIntegrationID != 0 {
+		_, err := c.Repo().GrafanaIntegration().ReadGrafanaIntegration(cluster.ProjectID, request.GrafanaIntegrationID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.HandleAPIError(w, r, apierrors.NewErrNotFound(
+					fmt.Errorf("no such grafana integration with ID: %d", request.GrafanaIntegrationID),
+				))
+
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
 	// if the repo url is not an addon or application url, validate against the helm repos
 	if request.RepoURL != c.Config().ServerConf.DefaultAddonHelmRepoURL && request.RepoURL != c.Config().ServerConf.DefaultApplicationHelmRepoURL {
 		// load the helm repos in the project
@@ -224,6 +242,14 @@ func (c *CreateReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// the dashboard is provisioned in the background, since the release has already been
+	// installed and should not fail to be created if Grafana is unavailable
+	if request.GrafanaIntegrationID != 0 {
+		if err := enqueueGrafanaDashboard(c.Config(), cluster, release, request.GrafanaIntegrationID); err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
 	c.Config().AnalyticsClient.Track(analytics.ApplicationLaunchSuccessTrack(
 		&analytics.ApplicationLaunchSuccessTrackOpts{
 			ApplicationScopedTrackOpts: analytics.GetApplicationScopedTrackOpts(
@@ -368,6 +394,12 @@ func createGitAction(
 
 		gitErr = giRunner.Setup()
 	} else {
+		workflowTemplate, err := actions.GetWorkflowTemplateSpec(config.Repo, projectID, types.WorkflowTemplateKindDeploy)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
 		// create the commit in the git repo
 		gaRunner := &actions.GithubActions{
 			InstanceName:           config.ServerConf.InstanceName,
@@ -391,6 +423,7 @@ func createGitAction(
 			Version:                "v0.1.0",
 			ShouldCreateWorkflow:   request.ShouldCreateWorkflow,
 			DryRun:                 release == nil,
+			WorkflowTemplate:       workflowTemplate,
 		}
 
 		// Save the github err for after creating the git action config. However, we
@@ -418,6 +451,8 @@ func createGitAction(
 		GitlabIntegrationID: request.GitlabIntegrationID,
 		DockerfilePath:      request.DockerfilePath,
 		FolderPath:          request.FolderPath,
+		DockerTarget:        request.DockerTarget,
+		BuildArgsEnvGroups:  strings.Join(request.BuildArgsEnvGroups, ","),
 		IsInstallation:      true,
 		Version:             "v0.1.0",
 	})
@@ -0,0 +1,149 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+)
+
+type CreateCustomDomainHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateCustomDomainHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateCustomDomainHandler {
+	return &CreateCustomDomainHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateCustomDomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.CreateCustomDomainRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// if the domain's DNS is managed by an integration, the provider and the ingress endpoint
+	// are read before the domain is added, so that a domain isn't added without its record
+	var dnsInt *integrations.DNSProviderIntegration
+	var provider dns.Provider
+	var record *dns.Record
+
+	if request.DNSProviderIntegrationID != 0 {
+		dnsInt, provider, err = getDNSProvider(c.Config(), cluster.ProjectID, request.DNSProviderIntegrationID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("dns provider integration %d not found", request.DNSProviderIntegrationID),
+					http.StatusNotFound,
+				))
+
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		endpoint, found, err := domain.GetNGINXIngressServiceIP(agent.Clientset)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		} else if !found {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("target cluster does not have nginx ingress"),
+				http.StatusPreconditionFailed,
+			))
+
+			return
+		}
+
+		record = dns.GetRecord(endpoint)
+	}
+
+	res, err := domain.AddCustomDomain(agent.Clientset, namespace, name, request)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrCustomDomainExists) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+			return
+		} else if errors.Is(err, domain.ErrServiceNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if provider != nil {
+		if err := provider.UpsertRecord(r.Context(), res.Host, record); err != nil {
+			// the domain is removed, so that it can be added again once the record can be created
+			if rmErr := domain.RemoveCustomDomain(agent.Clientset, namespace, name, res.Host); rmErr != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(rmErr))
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("could not create the dns record of %s: %w", res.Host, err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		dnsRecord, err := c.Repo().CustomDomainDNSRecord().CreateCustomDomainDNSRecord(&models.CustomDomainDNSRecord{
+			ProjectID:                cluster.ProjectID,
+			ClusterID:                cluster.ID,
+			Namespace:                namespace,
+			ReleaseName:              name,
+			Host:                     res.Host,
+			DNSProviderIntegrationID: dnsInt.ID,
+			RecordType:               record.Type,
+			Value:                    record.Value,
+			Status:                   types.CustomDomainDNSPending,
+		})
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res.DNS = dnsRecord.ToCustomDomainDNSType()
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,89 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CreateLogAlertRuleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateLogAlertRuleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateLogAlertRuleHandler {
+	return &CreateLogAlertRuleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP creates a log alert rule for a release. Rules are evaluated by the relay agent of
+// the cluster, so they only open incidents in clusters with a relay.
+func (c *CreateLogAlertRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	request := &types.CreateLogAlertRuleRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if _, err := regexp.Compile(request.Pattern); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid pattern: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	rules, err := c.Repo().LogAlertRule().ListLogAlertRulesByRelease(cluster.ID, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the name of a rule identifies its incidents, so it must be unique within the release
+	for _, rule := range rules {
+		if rule.Name == request.Name {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("release %s already has a log alert rule named %s", name, request.Name),
+				http.StatusConflict,
+			))
+
+			return
+		}
+	}
+
+	rule, err := c.Repo().LogAlertRule().CreateLogAlertRule(&models.LogAlertRule{
+		ProjectID:     cluster.ProjectID,
+		ClusterID:     cluster.ID,
+		Namespace:     namespace,
+		ReleaseName:   name,
+		Name:          request.Name,
+		Pattern:       request.Pattern,
+		Threshold:     request.Threshold,
+		WindowSeconds: request.WindowSeconds,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, rule.ToLogAlertRuleType())
+}
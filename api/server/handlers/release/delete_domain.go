@@ -0,0 +1,100 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeleteCustomDomainHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewDeleteCustomDomainHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *DeleteCustomDomainHandler {
+	return &DeleteCustomDomainHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *DeleteCustomDomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.DeleteCustomDomainRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the DNS record of the domain is deleted first, so that the deletion can be retried if the
+	// record can't be deleted
+	dnsRecord, err := c.Repo().CustomDomainDNSRecord().ReadCustomDomainDNSRecord(cluster.ID, strings.ToLower(request.Host))
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if err == nil && (dnsRecord.Namespace != namespace || dnsRecord.ReleaseName != name) {
+		dnsRecord = nil
+	}
+
+	if dnsRecord != nil {
+		_, provider, err := getDNSProvider(c.Config(), cluster.ProjectID, dnsRecord.DNSProviderIntegrationID)
+
+		// if the integration was deleted, the record is left to be deleted in the DNS provider
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		} else if err == nil {
+			if err := provider.DeleteRecord(r.Context(), dnsRecord.Host); err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("could not delete the dns record of %s: %w", dnsRecord.Host, err),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+		}
+	}
+
+	err = domain.RemoveCustomDomain(agent.Clientset, namespace, name, request.Host)
+
+	if errors.Is(err, domain.ErrCustomDomainNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if dnsRecord != nil {
+		if err := c.Repo().CustomDomainDNSRecord().DeleteCustomDomainDNSRecord(dnsRecord); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+}
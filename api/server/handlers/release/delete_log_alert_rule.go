@@ -0,0 +1,65 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeleteLogAlertRuleHandler struct {
+	handlers.PorterHandler
+}
+
+func NewDeleteLogAlertRuleHandler(
+	config *config.Config,
+) *DeleteLogAlertRuleHandler {
+	return &DeleteLogAlertRuleHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+// ServeHTTP deletes a log alert rule of a release. The open incident of the rule is resolved
+// once the relay agent stops reporting it.
+func (c *DeleteLogAlertRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	ruleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamLogAlertRuleID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	rule, err := c.Repo().LogAlertRule().ReadLogAlertRule(cluster.ID, ruleID)
+
+	if err == nil && (rule.Namespace != namespace || rule.ReleaseName != name) {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("log alert rule %d not found", ruleID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := c.Repo().LogAlertRule().DeleteLogAlertRule(rule); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,374 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// DeployOpts is an upgrade of a release which is made by Deploy
+type DeployOpts struct {
+	Cluster   *models.Cluster
+	HelmAgent *helm.Agent
+
+	// the latest revision of the release
+	HelmRelease *release.Release
+
+	// the user who requested the upgrade, which is nil for upgrades which are not made by a
+	// user, such as webhooks
+	User   *models.User
+	Source types.DeploySource
+
+	// the values which the release is upgraded with, which replace the values of the release
+	Values map[string]interface{}
+
+	// (optional) the version of the release's chart which the release is upgraded to
+	ChartVersion string
+
+	// (optional) the revision which the upgrade was made from, which must be the latest
+	// revision of the release
+	LatestRevision uint
+
+	// (optional) feature flags which are changed once the upgrade succeeds
+	FeatureFlags []*types.FeatureFlagChange
+
+	FreezeOverride types.FreezeOverride
+
+	// (optional) the chart and stack revision of an upgrade of a stack's app resource, which
+	// are set instead of the chart version
+	Chart         *chart.Chart
+	StackName     string
+	StackRevision uint
+
+	// (optional) the dynamic client of the cluster, which the vulnerability reports of the
+	// release's image are read with. It is created from the cluster if it is not set.
+	DynamicClient dynamic.Interface
+}
+
+// DeployResult is the result of Deploy. Only one of the release, approval and queued deploy
+// is set.
+type DeployResult struct {
+	// the upgraded release
+	Release *release.Release
+
+	// the approval which the upgrade waits for, if an approval policy applies to the release
+	Approval *models.Approval
+
+	// the deploy which waits in the deploy queue of the release, if another upgrade of the
+	// release is running
+	QueuedDeploy *models.QueuedDeploy
+
+	// a warning about the upgrade which did not block it, such as an image which can't run on
+	// some of the cluster's nodes
+	Warning string
+}
+
+// Deploy upgrades a release. Every upgrade of a release goes through Deploy, which blocks the
+// upgrade during a freeze window of the release's namespace, or if its image breaks the image
+// policies of the project or the release. If an approval policy applies to the release, the
+// upgrade is stored until it is approved, and if another upgrade of the release is running, it
+// waits in the deploy queue of the release.
+func Deploy(config *config.Config, opts *DeployOpts) (*DeployResult, apierrors.RequestError) {
+	cluster := opts.Cluster
+	helmRelease := opts.HelmRelease
+
+	if apiErr := commonutils.CheckFreezeWindows(
+		config, cluster, helmRelease.Namespace, helmRelease.Name, opts.User, opts.FreezeOverride,
+	); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if apiErr := validateFeatureFlags(config, cluster.ProjectID, opts.FeatureFlags); apiErr != nil {
+		return nil, apiErr
+	}
+
+	featureFlags, err := models.FormatFeatureFlagChanges(opts.FeatureFlags)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if opts.Values == nil {
+		opts.Values = make(map[string]interface{})
+	}
+
+	res := &DeployResult{}
+
+	warning, apiErr := checkImagePolicies(config, opts, registries)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	res.Warning = warning
+
+	values, err := yaml.Marshal(opts.Values)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	var userID uint
+
+	if opts.User != nil {
+		userID = opts.User.ID
+	}
+
+	request := &types.UpgradeReleaseRequest{
+		Values:         string(values),
+		ChartVersion:   opts.ChartVersion,
+		LatestRevision: opts.LatestRevision,
+		FeatureFlags:   opts.FeatureFlags,
+	}
+
+	canWait := deployCanWait(opts.Source)
+
+	// if an approval policy applies to the release, the upgrade is executed once it is approved
+	if !canWait {
+		policyID, _, apiErr := findApprovalPolicy(config, cluster, helmRelease.Namespace)
+
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		if policyID != 0 {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf(
+					"upgrades of release %s require approval by approval policy %d, so the release must be upgraded on its own",
+					helmRelease.Name, policyID,
+				),
+				http.StatusForbidden,
+			)
+		}
+	} else {
+		approval, apiErr := requestApproval(config, opts.HelmAgent, cluster, helmRelease, userID, request)
+
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		if approval != nil {
+			res.Approval = approval
+			return res, nil
+		}
+	}
+
+	// upgrades of the release run one at a time, so the upgrade waits in the release's deploy
+	// queue if another upgrade is running
+	deploy := &models.QueuedDeploy{
+		ProjectID:         cluster.ProjectID,
+		ClusterID:         cluster.ID,
+		Namespace:         helmRelease.Namespace,
+		Name:              helmRelease.Name,
+		Source:            opts.Source,
+		RequestedByUserID: userID,
+		Values:            values,
+		ChartVersion:      opts.ChartVersion,
+		LatestRevision:    opts.LatestRevision,
+		FeatureFlags:      featureFlags,
+	}
+
+	// a queued upgrade which only changes the image deploys the image on the values which the
+	// release has when the upgrade runs
+	if isImageDeploy(opts.Source) {
+		deploy.Values = nil
+		deploy.LatestRevision = 0
+		deploy.ImageRepository, deploy.ImageTag = getImageRepositoryAndTag(opts.Values)
+	}
+
+	deploy, apiErr = queueDeploy(config, deploy)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if deploy.Status == types.DeployStatusQueued {
+		if canWait {
+			res.QueuedDeploy = deploy
+			return res, nil
+		}
+
+		if _, err := config.Repo.QueuedDeploy().CancelQueuedDeploy(deploy, userID, time.Now().UTC()); err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("another upgrade of release %s is running", helmRelease.Name),
+			http.StatusConflict,
+		)
+	}
+
+	var overrides *upgradeOverrides
+
+	if opts.Chart != nil || opts.StackName != "" {
+		overrides = &upgradeOverrides{
+			chart:         opts.Chart,
+			stackName:     opts.StackName,
+			stackRevision: opts.StackRevision,
+		}
+	}
+
+	newHelmRelease, apiErr := upgradeRelease(config, opts.User, cluster, opts.HelmAgent, helmRelease, registries, request, overrides)
+
+	finishDeploy(config, deploy, newHelmRelease, apiErr)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	res.Release = newHelmRelease
+
+	// the stack revision of an upgrade of a stack's app resource is created by the stack
+	if opts.StackName != "" {
+		err = reapplyMaintenanceMode(config, cluster.ID, newHelmRelease.Namespace, newHelmRelease.Name)
+	} else {
+		err = postUpgrade(config, cluster.ProjectID, cluster.ID, newHelmRelease)
+	}
+
+	// the release was upgraded, so the upgrade succeeds even if the steps after it failed
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error running post-upgrade steps of release %s", newHelmRelease.Name)
+	}
+
+	return res, nil
+}
+
+// checkImagePolicies checks the image of an upgrade against the image signature policy of the
+// project, and the vulnerability and architecture policies of the release. It returns a warning
+// if the image can't run on some of the cluster's nodes.
+func checkImagePolicies(
+	config *config.Config,
+	opts *DeployOpts,
+	registries []*models.Registry,
+) (string, apierrors.RequestError) {
+	cluster := opts.Cluster
+	image := getImageFromValues(opts.Values)
+
+	if image == "" {
+		return "", nil
+	}
+
+	// if the project only deploys signed images, verify the image which is being deployed
+	if apiErr := checkImageSignaturePolicy(config, cluster.ProjectID, registries, image); apiErr != nil {
+		return "", apiErr
+	}
+
+	// releases which are not stored in the database do not have policies
+	rel, err := config.Repo.Release().ReadRelease(cluster.ID, opts.HelmRelease.Name, opts.HelmRelease.Namespace)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		rel = nil
+	} else if err != nil {
+		return "", apierrors.NewErrInternal(err)
+	}
+
+	// if the release blocks critical vulnerabilities, check the image which is being deployed
+	if rel != nil && rel.BlockCriticalVulnerabilities {
+		dynClient := opts.DynamicClient
+
+		if dynClient == nil {
+			dynClient, err = kubernetes.GetDynamicClientOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+				Repo:                      config.Repo,
+				DigitalOceanOAuth:         config.DOConf,
+				Cluster:                   cluster,
+				AllowInClusterConnections: config.ServerConf.InitInCluster,
+			})
+
+			if err != nil {
+				return "", apierrors.NewErrInternal(err)
+			}
+		}
+
+		if apiErr := checkVulnerabilityPolicy(config, rel, dynClient, registries, image); apiErr != nil {
+			return "", apiErr
+		}
+	}
+
+	// if the image is changing, check that it supports the architectures of the cluster's nodes.
+	// Upgrades which are not made by a user are not interactive, so architectures are only
+	// checked for them when mismatches are blocked.
+	if image == getImageFromValues(opts.HelmRelease.Config) {
+		return "", nil
+	}
+
+	if opts.User == nil && (rel == nil || !rel.BlockArchitectureMismatch) {
+		return "", nil
+	}
+
+	if opts.HelmAgent.K8sAgent == nil {
+		return "", nil
+	}
+
+	return checkArchitecturePolicy(config, rel, opts.HelmAgent.K8sAgent.Clientset, registries, image)
+}
+
+// recordReleaseUpgrade adds an audit log entry for a successful upgrade of a release. The
+// release was already upgraded when the upgrade is recorded, so a failure to record it is logged
+// rather than returned.
+func recordReleaseUpgrade(
+	config *config.Config,
+	projectID, clusterID, userID uint,
+	helmRelease *release.Release,
+	metadata map[string]interface{},
+) {
+	rawMetadata, _ := json.Marshal(metadata)
+
+	_, err := config.Repo.AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    projectID,
+		ClusterID:    clusterID,
+		UserID:       userID,
+		Action:       string(types.AuditLogActionReleaseUpgrade),
+		ResourceKind: "Release",
+		ResourceName: helmRelease.Name,
+		Namespace:    helmRelease.Namespace,
+		ReleaseName:  helmRelease.Name,
+		Metadata:     rawMetadata,
+	})
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error recording the upgrade of release %s", helmRelease.Name)
+	}
+}
+
+// deployCanWait returns false for the upgrades of a multi-cluster rollout or a stack revision,
+// which upgrade their releases in order, so their upgrades fail rather than waiting for an
+// approval or in the deploy queue of the release
+func deployCanWait(source types.DeploySource) bool {
+	return source != types.DeploySourceStack && source != types.DeploySourceMultiCluster
+}
+
+// isImageDeploy returns true for the upgrades which only change the image of the release
+func isImageDeploy(source types.DeploySource) bool {
+	return source == types.DeploySourceWebhook || source == types.DeploySourceRegistryPush
+}
+
+func getImageRepositoryAndTag(values map[string]interface{}) (string, string) {
+	imageVals, ok := values["image"].(map[string]interface{})
+
+	if !ok {
+		return "", ""
+	}
+
+	return fmt.Sprintf("%v", imageVals["repository"]), fmt.Sprintf("%v", imageVals["tag"])
+}
@@ -0,0 +1,249 @@
+package release
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/jobqueue"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+// jobKindDeployQueue runs the next deploy in the deploy queue of a release
+const jobKindDeployQueue = "release-deploy-queue"
+
+// deployTimeout is the duration after which a running deploy is assumed to belong to a server
+// which stopped before the deploy finished
+const deployTimeout = 15 * time.Minute
+
+type deployQueuePayload struct {
+	ProjectID uint   `json:"project_id"`
+	ClusterID uint   `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// queueDeploy adds a deploy to the deploy queue of its release. If no other deploy of the
+// release is running or queued, the deploy is returned as running, and the caller runs it and
+// calls finishDeploy. Otherwise the deploy is returned as queued, and runs in the background
+// once the deploys before it have finished.
+func queueDeploy(config *config.Config, deploy *models.QueuedDeploy) (*models.QueuedDeploy, apierrors.RequestError) {
+	deploy.Status = types.DeployStatusQueued
+
+	deploy, err := config.Repo.QueuedDeploy().CreateQueuedDeploy(deploy)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	next, err := nextDeploy(config, deploy.ClusterID, deploy.Namespace, deploy.Name)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if next != nil && next.ID == deploy.ID {
+		if _, err := config.Repo.QueuedDeploy().ClaimQueuedDeploy(deploy, time.Now().UTC()); err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+	} else if next != nil && next.Status == types.DeployStatusQueued {
+		// the deploy before this one is waiting for a server to run it, which is the case if the
+		// running deploy before it failed to enqueue the next deploy
+		if err := enqueueDeployQueue(config, deploy); err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+	}
+
+	return deploy, nil
+}
+
+// finishDeploy records the result of a running deploy, audits and changes the feature flags of
+// a successful deploy, and starts the next deploy in the queue of the release
+func finishDeploy(
+	config *config.Config,
+	deploy *models.QueuedDeploy,
+	helmRelease *release.Release,
+	apiErr apierrors.RequestError,
+) {
+	now := time.Now().UTC()
+
+	deploy.FinishedAt = &now
+
+	if apiErr != nil {
+		deploy.Status = types.DeployStatusFailed
+		deploy.Error = apiErr.ExternalError()
+	} else {
+		deploy.Status = types.DeployStatusSucceeded
+		deploy.Revision = helmRelease.Version
+	}
+
+	if _, err := config.Repo.QueuedDeploy().UpdateQueuedDeploy(deploy); err != nil {
+		config.Logger.Error().Err(err).Msgf("error recording the result of deploy %d", deploy.ID)
+	}
+
+	// the flags of the deploy are changed before the next deploy starts, so that the flag
+	// changes of the release's deploys are made in order
+	if apiErr == nil {
+		recordReleaseUpgrade(config, deploy.ProjectID, deploy.ClusterID, deploy.RequestedByUserID, helmRelease, map[string]interface{}{
+			"source":   deploy.Source,
+			"revision": deploy.Revision,
+		})
+
+		applyFeatureFlags(
+			config, deploy.ProjectID, deploy.ClusterID, deploy.Namespace, deploy.Name,
+			deploy.Revision, models.ParseFeatureFlagChanges(deploy.FeatureFlags),
+		)
+	}
+
+	queue, err := config.Repo.QueuedDeploy().ListQueuedDeploys(deploy.ClusterID, deploy.Namespace, deploy.Name)
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error listing the deploy queue of release %s", deploy.Name)
+		return
+	}
+
+	if len(queue) > 0 {
+		if err := enqueueDeployQueue(config, deploy); err != nil {
+			config.Logger.Error().Err(err).Msgf("error starting the next deploy of release %s", deploy.Name)
+		}
+	}
+}
+
+// nextDeploy returns the running deploy of a release, or the queued deploy which runs next if
+// no deploy is running. Running deploys whose server stopped before they finished are marked
+// as failed. It returns nil if the queue of the release is empty.
+func nextDeploy(config *config.Config, clusterID uint, namespace, name string) (*models.QueuedDeploy, error) {
+	queue, err := config.Repo.QueuedDeploy().ListQueuedDeploys(clusterID, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	for _, deploy := range queue {
+		if deploy.Status != types.DeployStatusRunning ||
+			(deploy.StartedAt != nil && now.Sub(*deploy.StartedAt) < deployTimeout) {
+			return deploy, nil
+		}
+
+		deploy.Status = types.DeployStatusFailed
+		deploy.Error = "the deploy did not finish"
+		deploy.FinishedAt = &now
+
+		if _, err := config.Repo.QueuedDeploy().UpdateQueuedDeploy(deploy); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func enqueueDeployQueue(config *config.Config, deploy *models.QueuedDeploy) error {
+	_, err := config.JobQueue.Enqueue(deploy.ProjectID, jobKindDeployQueue, &deployQueuePayload{
+		ProjectID: deploy.ProjectID,
+		ClusterID: deploy.ClusterID,
+		Namespace: deploy.Namespace,
+		Name:      deploy.Name,
+	})
+
+	return err
+}
+
+// runDeployQueueJob runs the next queued deploy of a release. The result of the deploy is
+// recorded on the deploy, so a failed deploy does not fail the job.
+func runDeployQueueJob(config *config.Config, job *models.BackgroundJob) error {
+	payload := &deployQueuePayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	deploy, err := nextDeploy(config, payload.ClusterID, payload.Namespace, payload.Name)
+
+	if err != nil {
+		return fmt.Errorf("error reading the deploy queue of release %s: %w", payload.Name, err)
+	}
+
+	// the queue is empty, or its deploy is run by another server
+	if deploy == nil || deploy.Status != types.DeployStatusQueued {
+		return nil
+	}
+
+	claimed, err := config.Repo.QueuedDeploy().ClaimQueuedDeploy(deploy, time.Now().UTC())
+
+	if err != nil {
+		return fmt.Errorf("error claiming deploy %d: %w", deploy.ID, err)
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	cluster, err := config.Repo.Cluster().ReadCluster(payload.ProjectID, payload.ClusterID)
+
+	if err != nil {
+		finishDeploy(config, deploy, nil, apierrors.NewErrInternal(fmt.Errorf("error reading cluster: %w", err)))
+		return nil
+	}
+
+	helmRelease, apiErr := executeQueuedDeploy(config, cluster, deploy)
+
+	finishDeploy(config, deploy, helmRelease, apiErr)
+
+	if apiErr == nil {
+		if err := postUpgrade(config, cluster.ProjectID, cluster.ID, helmRelease); err != nil {
+			config.Logger.Error().Err(err).Msgf("error running post-upgrade steps of deploy %d", deploy.ID)
+		}
+	}
+
+	return nil
+}
+
+// executeQueuedDeploy runs a deploy which was queued behind other deploys of its release. The
+// image of a webhook or registry push deploy is set on the values of the release when the deploy runs, so that
+// the changes made by the deploys before it are kept.
+func executeQueuedDeploy(
+	config *config.Config,
+	cluster *models.Cluster,
+	deploy *models.QueuedDeploy,
+) (*release.Release, apierrors.RequestError) {
+	target, apiErr := readUpgradeTarget(config, cluster, deploy.Namespace, deploy.Name, deploy.RequestedByUserID)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	request := &types.UpgradeReleaseRequest{
+		Values:         string(deploy.Values),
+		ChartVersion:   deploy.ChartVersion,
+		LatestRevision: deploy.LatestRevision,
+	}
+
+	if isImageDeploy(deploy.Source) {
+		values := target.helmRelease.Config
+
+		if values == nil {
+			values = make(map[string]interface{})
+		}
+
+		values["image"] = map[string]interface{}{
+			"repository": deploy.ImageRepository,
+			"tag":        deploy.ImageTag,
+		}
+
+		data, err := yaml.Marshal(values)
+
+		if err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+
+		request.Values = string(data)
+	}
+
+	return upgradeRelease(config, target.user, cluster, target.helmAgent, target.helmRelease, target.registries, request, nil)
+}
@@ -0,0 +1,366 @@
+package release
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const (
+	testNamespace   = "default"
+	testReleaseName = "test-release"
+)
+
+type deployFixture struct {
+	config      *config.Config
+	user        *models.User
+	cluster     *models.Cluster
+	helmAgent   *helm.Agent
+	helmRelease *release.Release
+}
+
+func newDeployFixture(t *testing.T) *deployFixture {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	cluster := apitest.CreateTestCluster(t, config, 1)
+
+	helmAgent, helmRelease := apitest.CreateTestRelease(t, config, testNamespace, testReleaseName, map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "porter/app",
+			"tag":        "v1",
+		},
+	})
+
+	return &deployFixture{config, user, cluster, helmAgent, helmRelease}
+}
+
+// deployEntryPoints upgrade the release of a fixture to a new image through each entry point of
+// the release handlers which deploys without a request to the upgrade endpoint
+var deployEntryPoints = map[string]func(t *testing.T, f *deployFixture) (*DeployResult, error){
+	"registry push": func(t *testing.T, f *deployFixture) (*DeployResult, error) {
+		req, _ := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/webhooks/registry/token", nil)
+		req = apitest.WithHelmAgent(t, req, f.helmAgent)
+
+		handler := NewRegistryPushWebhookHandler(f.config, shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter))
+
+		return handler.deployTag(req, f.cluster, &models.Release{
+			ClusterID: f.cluster.ID,
+			ProjectID: f.cluster.ProjectID,
+			Name:      testReleaseName,
+			Namespace: testNamespace,
+		}, "v2")
+	},
+	"resource recommendation": func(t *testing.T, f *deployFixture) (*DeployResult, error) {
+		res, apiErr := applyResourceRecommendation(f.config, f.user, f.cluster, f.helmAgent, f.helmRelease, &types.ResourceRecommendation{
+			Recommended: &types.ResourceValues{
+				CPURequest:    "100m",
+				MemoryRequest: "256Mi",
+			},
+			Changed: true,
+		})
+
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		return res, nil
+	},
+	"image batch": func(t *testing.T, f *deployFixture) (*DeployResult, error) {
+		res, apiErr := updateJobImage(f.config, f.user, f.cluster, f.helmAgent, f.helmRelease, "porter/app", "v2")
+
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		return res, nil
+	},
+}
+
+// upgradeThroughHandler upgrades the release of a fixture to a new image with a request to the
+// upgrade endpoint
+func upgradeThroughHandler(t *testing.T, f *deployFixture) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/clusters/1/namespaces/default/releases/test-release/0/upgrade",
+		&types.UpgradeReleaseRequest{
+			Values: "image:\n  repository: porter/app\n  tag: v2\n",
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, f.user)
+	req = apitest.WithCluster(t, req, f.cluster)
+	req = apitest.WithRelease(t, req, f.helmRelease)
+	req = apitest.WithHelmAgent(t, req, f.helmAgent)
+
+	handler := NewUpgradeReleaseHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestUpgradeReleaseRequiresApproval(t *testing.T) {
+	f := newDeployFixture(t)
+	apitest.CreateTestApprovalPolicy(t, f.config, f.cluster, f.user.ID)
+
+	rr := upgradeThroughHandler(t, f)
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+
+	apitest.AssertApprovalRequested(t, f.config, f.cluster, testNamespace, testReleaseName)
+	apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+}
+
+func TestUpgradeReleaseBlockedByFreezeWindow(t *testing.T) {
+	f := newDeployFixture(t)
+	apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+	rr := upgradeThroughHandler(t, f)
+
+	if rr.Code != http.StatusLocked {
+		t.Fatalf("expected status %d, got %d", http.StatusLocked, rr.Code)
+	}
+
+	apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+}
+
+func TestUpgradeReleaseQueued(t *testing.T) {
+	f := newDeployFixture(t)
+	apitest.CreateTestRunningDeploy(t, f.config, f.cluster, testNamespace, testReleaseName)
+
+	rr := upgradeThroughHandler(t, f)
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+
+	apitest.AssertDeployQueued(t, f.config, f.cluster, testNamespace, testReleaseName)
+	apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+}
+
+func TestDeployRequiresApproval(t *testing.T) {
+	for name, deploy := range deployEntryPoints {
+		t.Run(name, func(t *testing.T) {
+			f := newDeployFixture(t)
+			apitest.CreateTestApprovalPolicy(t, f.config, f.cluster, f.user.ID)
+
+			res, err := deploy(t, f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res == nil || res.Approval == nil {
+				t.Fatalf("expected the upgrade to wait for an approval")
+			}
+
+			apitest.AssertApprovalRequested(t, f.config, f.cluster, testNamespace, testReleaseName)
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+		})
+	}
+}
+
+func TestDeployBlockedByFreezeWindow(t *testing.T) {
+	for name, deploy := range deployEntryPoints {
+		t.Run(name, func(t *testing.T) {
+			f := newDeployFixture(t)
+			apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+			_, err := deploy(t, f)
+
+			apitest.AssertRequestError(t, err, http.StatusLocked)
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+		})
+	}
+}
+
+func TestDeployQueued(t *testing.T) {
+	for name, deploy := range deployEntryPoints {
+		t.Run(name, func(t *testing.T) {
+			f := newDeployFixture(t)
+			apitest.CreateTestRunningDeploy(t, f.config, f.cluster, testNamespace, testReleaseName)
+
+			res, err := deploy(t, f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res == nil || res.QueuedDeploy == nil {
+				t.Fatalf("expected the upgrade to wait in the deploy queue")
+			}
+
+			apitest.AssertDeployQueued(t, f.config, f.cluster, testNamespace, testReleaseName)
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+		})
+	}
+}
+
+func TestDeployBlockedBySignaturePolicy(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, deploy := range deployEntryPoints {
+		t.Run(name, func(t *testing.T) {
+			f := newDeployFixture(t)
+
+			_, err := f.config.Repo.ImageSignaturePolicy().CreateOrUpdateImageSignaturePolicy(&models.ImageSignaturePolicy{
+				ProjectID: f.cluster.ProjectID,
+				Enabled:   true,
+				PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKey})),
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the image is not in a registry of the project, so its signature can't be verified
+			_, err = deploy(t, f)
+
+			apitest.AssertRequestError(t, err, http.StatusPreconditionFailed)
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+		})
+	}
+}
+
+func TestDeployBlockedByVulnerabilityPolicy(t *testing.T) {
+	f := newDeployFixture(t)
+
+	_, err := f.config.Repo.Release().CreateRelease(&models.Release{
+		ClusterID:                    f.cluster.ID,
+		ProjectID:                    f.cluster.ProjectID,
+		Name:                         testReleaseName,
+		Namespace:                    testNamespace,
+		BlockCriticalVulnerabilities: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    "aquasecurity.github.io",
+		Version:  "v1alpha1",
+		Resource: "vulnerabilityreports",
+	}
+
+	report := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "aquasecurity.github.io/v1alpha1",
+		"kind":       "VulnerabilityReport",
+		"metadata": map[string]interface{}{
+			"name":      "replicaset-test-release-web",
+			"namespace": testNamespace,
+		},
+		"report": map[string]interface{}{
+			"registry": map[string]interface{}{
+				"server": "index.docker.io",
+			},
+			"artifact": map[string]interface{}{
+				"repository": "porter/app",
+				"tag":        "v2",
+			},
+			"summary": map[string]interface{}{
+				"criticalCount": int64(1),
+			},
+		},
+	}}
+
+	_, err = Deploy(f.config, &DeployOpts{
+		Cluster:     f.cluster,
+		HelmAgent:   f.helmAgent,
+		HelmRelease: f.helmRelease,
+		Source:      types.DeploySourceWebhook,
+		Values: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "porter/app",
+				"tag":        "v2",
+			},
+		},
+		DynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "VulnerabilityReportList"},
+			report,
+		),
+	})
+
+	apitest.AssertRequestError(t, err, http.StatusPreconditionFailed)
+	apitest.AssertReleaseNotUpgraded(t, f.helmAgent, testReleaseName)
+}
+
+func TestFinishDeployRecordsUpgrade(t *testing.T) {
+	f := newDeployFixture(t)
+	start := time.Now()
+
+	deploy, apiErr := queueDeploy(f.config, &models.QueuedDeploy{
+		ProjectID: f.cluster.ProjectID,
+		ClusterID: f.cluster.ID,
+		Namespace: testNamespace,
+		Name:      testReleaseName,
+		Source:    types.DeploySourceRegistryPush,
+		ImageTag:  "v2",
+	})
+
+	if apiErr != nil {
+		t.Fatal(apiErr)
+	}
+
+	if deploy.Status != types.DeployStatusRunning {
+		t.Fatalf("expected deploy status %s, got %s", types.DeployStatusRunning, deploy.Status)
+	}
+
+	finishDeploy(f.config, deploy, &release.Release{
+		Name:      testReleaseName,
+		Namespace: testNamespace,
+		Version:   2,
+	}, nil)
+
+	auditLogs, err := f.config.Repo.AuditLog().ListAuditLogsByDateRange(
+		f.cluster.ProjectID, start, time.Now().Add(time.Minute),
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(auditLogs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(auditLogs))
+	}
+
+	if auditLogs[0].Action != string(types.AuditLogActionReleaseUpgrade) || auditLogs[0].ReleaseName != testReleaseName {
+		t.Errorf("unexpected audit log: %+v", auditLogs[0].ToAuditLogType())
+	}
+}
@@ -0,0 +1,125 @@
+package release
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/datadog"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+	"github.com/porter-dev/porter/internal/notifier/email"
+	"github.com/porter-dev/porter/internal/notifier/pagerduty"
+	"github.com/porter-dev/porter/internal/notifier/sentry"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+// newDeploymentNotifier returns the notifier for the upgrades of a release in a namespace of a
+// cluster, which notifies every integration of the project which the release is routed to. The
+// release is nil if it is not stored in the database. Integrations which cannot be read are
+// logged and skipped, so that they do not stop the notifications of the other integrations.
+func newDeploymentNotifier(
+	config *config.Config,
+	rel *models.Release,
+	cluster *models.Cluster,
+	namespace string,
+	commits *sentry.CommitRange,
+) notifier.Notifier {
+	projectID := cluster.ProjectID
+
+	logErr := func(kind string, err error) {
+		config.Logger.Error().Err(err).Msgf(
+			"error reading %s for deployment notifications of cluster %d", kind, cluster.ID,
+		)
+	}
+
+	slackInts, err := slack.ListRoutedIntegrations(
+		config.Repo.SlackIntegration(), projectID, types.SlackEventReleaseUpgrade, cluster.ID, namespace,
+	)
+
+	if err != nil {
+		logErr("slack integrations", err)
+	}
+
+	discordInts, err := discord.ListRoutedIntegrations(config.Repo, projectID, cluster.ID, namespace)
+
+	if err != nil {
+		logErr("discord integrations", err)
+	}
+
+	teamsInts, err := config.Repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		logErr("teams integrations", err)
+	}
+
+	pdInts, err := pagerduty.ListRoutedIntegrations(config.Repo.PagerDutyIntegration(), projectID, cluster.ID, namespace)
+
+	if err != nil {
+		logErr("pagerduty integrations", err)
+	}
+
+	ddInts, err := config.Repo.DatadogIntegration().ListDatadogIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		logErr("datadog integrations", err)
+	}
+
+	sentryInts, err := config.Repo.SentryIntegration().ListSentryIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		logErr("sentry integrations", err)
+	}
+
+	var notifConf *types.NotificationConfig
+
+	if rel != nil && rel.NotificationConfig != 0 {
+		conf, err := config.Repo.NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
+
+		if err != nil {
+			logErr("notification config", err)
+		} else {
+			notifConf = conf.ToNotificationConfigType()
+		}
+	}
+
+	notifPrefs, err := config.Repo.NotificationPreference().ListNotificationPreferencesByProjectID(projectID)
+
+	if err != nil {
+		logErr("notification preferences", err)
+	}
+
+	return notifier.NewMultiNotifier(
+		notifier.NewPreferenceNotifier(notifPrefs, projectID, types.NotificationChannelSlack, slack.NewDeploymentNotifier(notifConf, slackInts...)),
+		discord.NewDeploymentNotifier(notifConf, discordInts...),
+		teams.NewDeploymentNotifier(notifConf, teamsInts...),
+		pagerduty.NewDeploymentNotifier(pdInts...),
+		datadog.NewDeploymentNotifier(ddInts...),
+		sentry.NewDeploymentNotifier(commits, sentryInts...),
+		notifier.NewPreferenceNotifier(notifPrefs, projectID, types.NotificationChannelWebhook, webhook.NewDeploymentNotifier(config.Repo)),
+		email.NewDeploymentNotifier(notifConf, config.Repo, config.EmailSender),
+	)
+}
+
+// newDeploymentNotifyOpts returns the options which the upgrades of a release are notified with
+func newDeploymentNotifyOpts(config *config.Config, cluster *models.Cluster, namespace, name string) *notifier.NotifyOpts {
+	return &notifier.NotifyOpts{
+		ProjectID:   cluster.ProjectID,
+		ClusterID:   cluster.ID,
+		ClusterName: cluster.Name,
+		Name:        name,
+		Namespace:   namespace,
+		URL: fmt.Sprintf(
+			"%s/applications/%s/%s/%s?project_id=%d",
+			config.ServerConf.ServerURL,
+			url.PathEscape(cluster.Name),
+			namespace,
+			name,
+			cluster.ProjectID,
+		),
+	}
+}
@@ -0,0 +1,79 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type DiffUpgradeHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewDiffUpgradeHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiffUpgradeHandler {
+	return &DiffUpgradeHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP diffs the values and manifest of the release against the release which an upgrade
+// would produce, without upgrading the release
+func (c *DiffUpgradeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.UpgradeReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	upgraded, apiErr := templateUpgrade(c.Config(), helmAgent, cluster, helmRelease, request)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	valuesDiff, err := helm.DiffValues(helmRelease.Config, upgraded.Config)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	manifestDiff, err := helm.DiffManifests(helmRelease.Manifest, upgraded.Manifest)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.DiffUpgradeResponse{
+		FromChartVersion: helmRelease.Chart.Metadata.Version,
+		ToChartVersion:   upgraded.Chart.Metadata.Version,
+		ValuesDiff:       valuesDiff,
+		ManifestDiff:     manifestDiff,
+	})
+}
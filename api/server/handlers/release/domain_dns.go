@@ -0,0 +1,39 @@
+package release
+
+import (
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// getDNSProvider reads a DNS provider integration of a project and returns its provider. The
+// AWS integration of a Route53 integration is read for its credentials.
+func getDNSProvider(
+	config *config.Config,
+	projectID, integrationID uint,
+) (*integrations.DNSProviderIntegration, dns.Provider, error) {
+	dnsInt, err := config.Repo.DNSProviderIntegration().ReadDNSProviderIntegration(projectID, integrationID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var awsInt *integrations.AWSIntegration
+
+	if dnsInt.Provider == types.DNSProviderRoute53 {
+		awsInt, err = config.Repo.AWSIntegration().ReadAWSIntegration(projectID, dnsInt.AWSIntegrationID)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	provider, err := dns.NewProvider(dnsInt, awsInt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dnsInt, provider, nil
+}
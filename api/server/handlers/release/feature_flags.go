@@ -0,0 +1,145 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/featureflags"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// validateFeatureFlags checks that the feature flag integrations of an upgrade's flag changes
+// belong to the project, so that an upgrade doesn't fail to change its flags once it succeeds
+func validateFeatureFlags(config *config.Config, projectID uint, changes []*types.FeatureFlagChange) apierrors.RequestError {
+	for _, change := range changes {
+		_, err := config.Repo.FeatureFlagIntegration().ReadFeatureFlagIntegration(projectID, change.IntegrationID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("feature flag integration %d not found", change.IntegrationID),
+				http.StatusBadRequest,
+			)
+		} else if err != nil {
+			return apierrors.NewErrInternal(err)
+		}
+	}
+
+	return nil
+}
+
+// applyFeatureFlags changes the feature flags of an upgrade which created a revision of a
+// release. The previous state of each flag is recorded so that the change can be reverted by
+// a rollback. Flags are best-effort: a flag which can't be changed is recorded as failed, and
+// does not fail the upgrade.
+func applyFeatureFlags(
+	config *config.Config,
+	projectID, clusterID uint,
+	namespace, name string,
+	revision int,
+	changes []*types.FeatureFlagChange,
+) {
+	ctx := context.Background()
+
+	for _, change := range changes {
+		flag := &models.DeployFeatureFlag{
+			ProjectID:     projectID,
+			ClusterID:     clusterID,
+			Namespace:     namespace,
+			Name:          name,
+			Revision:      revision,
+			IntegrationID: change.IntegrationID,
+			Environment:   change.Environment,
+			FlagKey:       change.Key,
+			Enabled:       change.Enabled,
+			Status:        types.DeployFeatureFlagStatusApplied,
+		}
+
+		if err := setFeatureFlag(ctx, config, flag); err != nil {
+			flag.Status = types.DeployFeatureFlagStatusFailed
+			flag.Error = err.Error()
+		}
+
+		if _, err := config.Repo.DeployFeatureFlag().CreateDeployFeatureFlag(flag); err != nil {
+			config.Logger.Error().Err(err).Msgf("error recording feature flag %s of release %s", change.Key, name)
+		}
+	}
+}
+
+func setFeatureFlag(ctx context.Context, config *config.Config, flag *models.DeployFeatureFlag) error {
+	client, err := getFeatureFlagClient(config, flag.ProjectID, flag.IntegrationID)
+
+	if err != nil {
+		return err
+	}
+
+	prev, err := client.GetFlag(ctx, flag.Environment, flag.FlagKey)
+
+	if err != nil {
+		return fmt.Errorf("error reading flag %s: %w", flag.FlagKey, err)
+	}
+
+	if err := client.SetFlag(ctx, flag.Environment, flag.FlagKey, flag.Enabled); err != nil {
+		return fmt.Errorf("error changing flag %s: %w", flag.FlagKey, err)
+	}
+
+	now := time.Now().UTC()
+
+	flag.PreviousEnabled = prev
+	flag.AppliedAt = &now
+
+	return nil
+}
+
+// revertFeatureFlags sets the feature flags which were changed by the upgrades after a
+// revision of a release back to their previous state, when the release is rolled back to the
+// revision. Flags are reverted from the most to the least recently changed, so a flag which
+// was changed by several upgrades ends up in the state it had before the first of them.
+func revertFeatureFlags(config *config.Config, clusterID uint, namespace, name string, revision int) {
+	flags, err := config.Repo.DeployFeatureFlag().ListAppliedDeployFeatureFlags(clusterID, namespace, name, revision)
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error listing the feature flags of release %s", name)
+		return
+	}
+
+	ctx := context.Background()
+
+	for _, flag := range flags {
+		client, err := getFeatureFlagClient(config, flag.ProjectID, flag.IntegrationID)
+
+		if err == nil {
+			err = client.SetFlag(ctx, flag.Environment, flag.FlagKey, flag.PreviousEnabled)
+		}
+
+		if err != nil {
+			flag.Status = types.DeployFeatureFlagStatusRevertFailed
+			flag.Error = fmt.Sprintf("error reverting flag %s: %s", flag.FlagKey, err.Error())
+		} else {
+			now := time.Now().UTC()
+
+			flag.Status = types.DeployFeatureFlagStatusReverted
+			flag.RevertedAt = &now
+		}
+
+		if _, err := config.Repo.DeployFeatureFlag().UpdateDeployFeatureFlag(flag); err != nil {
+			config.Logger.Error().Err(err).Msgf("error recording the revert of feature flag %s of release %s", flag.FlagKey, name)
+		}
+	}
+}
+
+func getFeatureFlagClient(config *config.Config, projectID, integrationID uint) (featureflags.Client, error) {
+	ffInt, err := config.Repo.FeatureFlagIntegration().ReadFeatureFlagIntegration(projectID, integrationID)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading feature flag integration %d: %w", integrationID, err)
+	}
+
+	return featureflags.NewClient(ffInt)
+}
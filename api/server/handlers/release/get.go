@@ -11,6 +11,8 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/kubernetes/velero"
+	"github.com/porter-dev/porter/internal/kubernetes/vulnerabilities"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/templater/parser"
 	"gorm.io/gorm"
@@ -131,6 +133,19 @@ func (c *ReleaseGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// vulnerability summaries are best-effort, since a scanner may not be available
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err == nil {
+		scanner := vulnerabilities.NewScanner(dynClient, helmRelease.Namespace, registries, c.Repo())
+		res.Vulnerabilities = scanner.ScanRelease(helmRelease.Manifest)
+	}
+
+	// backups are best-effort, since Velero may not be installed
+	if backups, err := velero.ListBackups(dynClient, helmRelease.Namespace, helmRelease.Name); err == nil && len(backups) > 0 {
+		res.LatestBackup = backups[0]
+	}
+
 	parserDef := &parser.ClientConfigDefault{
 		DynamicClient: dynClient,
 		HelmChart:     helmRelease.Chart,
@@ -11,6 +11,9 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
 )
 
 type GetReleaseHistoryHandler struct {
@@ -31,6 +34,13 @@ func NewGetReleaseHistoryHandler(
 
 func (c *GetReleaseHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+
+	request := &types.GetReleaseHistoryRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
 
 	helmAgent, err := c.GetHelmAgent(r, cluster, "")
 
@@ -48,5 +58,65 @@ func (c *GetReleaseHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if request.IncludePruned {
+		// the deploys are only returned to the client, so they are read from the read replica
+		records, err := c.Repo().Release().ListReleaseHistory(cluster.ID, namespace, name, &repository.ListOptions{
+			ReadReplica: true,
+		})
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		history = appendPrunedRevisions(history, records, namespace, name)
+	}
+
 	c.WriteResult(w, r, history)
 }
+
+// appendPrunedRevisions adds a revision for every recorded deploy whose version is older than
+// the oldest revision of the helm history. The records are sorted newest first, so the latest
+// deploy of a version sets its status.
+func appendPrunedRevisions(
+	history []*release.Release,
+	records []*models.DeploymentRecord,
+	namespace, name string,
+) []*release.Release {
+	oldest := 0
+
+	for _, rel := range history {
+		if oldest == 0 || rel.Version < oldest {
+			oldest = rel.Version
+		}
+	}
+
+	seen := make(map[int]bool)
+
+	for _, record := range records {
+		if (oldest != 0 && record.Version >= oldest) || seen[record.Version] {
+			continue
+		}
+
+		seen[record.Version] = true
+
+		status := release.StatusSuperseded
+
+		if record.Status == "failed" {
+			status = release.StatusFailed
+		}
+
+		history = append(history, &release.Release{
+			Name:      name,
+			Namespace: namespace,
+			Version:   record.Version,
+			Info: &release.Info{
+				LastDeployed: helmtime.Time{Time: record.CreatedAt},
+				Status:       status,
+				Description:  "Pruned from the helm history",
+			},
+		})
+	}
+
+	return history
+}
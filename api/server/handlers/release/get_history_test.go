@@ -0,0 +1,60 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestAppendPrunedRevisions(t *testing.T) {
+	now := time.Now()
+
+	history := []*release.Release{
+		{Name: testReleaseName, Namespace: testNamespace, Version: 4, Info: &release.Info{Status: release.StatusDeployed}},
+		{Name: testReleaseName, Namespace: testNamespace, Version: 3, Info: &release.Info{Status: release.StatusSuperseded}},
+	}
+
+	// records are sorted newest first, and version 2 was retried after failing
+	records := []*models.DeploymentRecord{
+		{Model: gorm.Model{CreatedAt: now}, Version: 4, Status: "succeeded"},
+		{Model: gorm.Model{CreatedAt: now.Add(-time.Hour)}, Version: 3, Status: "succeeded"},
+		{Model: gorm.Model{CreatedAt: now.Add(-2 * time.Hour)}, Version: 2, Status: "succeeded"},
+		{Model: gorm.Model{CreatedAt: now.Add(-3 * time.Hour)}, Version: 2, Status: "failed"},
+		{Model: gorm.Model{CreatedAt: now.Add(-4 * time.Hour)}, Version: 1, Status: "failed"},
+	}
+
+	res := appendPrunedRevisions(history, records, testNamespace, testReleaseName)
+
+	expected := []struct {
+		version int
+		status  release.Status
+	}{
+		{4, release.StatusDeployed},
+		{3, release.StatusSuperseded},
+		{2, release.StatusSuperseded},
+		{1, release.StatusFailed},
+	}
+
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d revisions, got %d\n", len(expected), len(res))
+	}
+
+	for i, exp := range expected {
+		if res[i].Version != exp.version || res[i].Info.Status != exp.status {
+			t.Errorf("revision %d: expected version %d to be %s, got version %d with status %s\n",
+				i, exp.version, exp.status, res[i].Version, res[i].Info.Status)
+		}
+
+		if res[i].Name != testReleaseName || res[i].Namespace != testNamespace {
+			t.Errorf("revision %d: expected release %s/%s, got %s/%s\n",
+				i, testNamespace, testReleaseName, res[i].Namespace, res[i].Name)
+		}
+	}
+
+	if !res[2].Info.LastDeployed.Time.Equal(now.Add(-2 * time.Hour)) {
+		t.Errorf("expected pruned revision 2 to be deployed at the time of its latest deploy, got %s\n", res[2].Info.LastDeployed)
+	}
+}
@@ -0,0 +1,83 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetQueuedDeployHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetQueuedDeployHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetQueuedDeployHandler {
+	return &GetQueuedDeployHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns an upgrade of a release, with the state of the feature flags which the
+// upgrade changes
+func (c *GetQueuedDeployHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	deployID, reqErr := requestutils.GetURLParamUint(r, types.URLParamDeployID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	deploy, err := c.Repo().QueuedDeploy().ReadQueuedDeploy(cluster.ID, deployID)
+
+	if err == nil && (deploy.Namespace != namespace || deploy.Name != name) {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("deploy %d not found", deployID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := deploy.ToQueuedDeployType()
+
+	// the flags of a successful upgrade are recorded on the revision it created, with the
+	// result of changing and reverting them
+	if deploy.Status == types.DeployStatusSucceeded && len(res.FeatureFlags) > 0 {
+		flags, err := c.Repo().DeployFeatureFlag().ListDeployFeatureFlagsByRevision(cluster.ID, namespace, name, deploy.Revision)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if len(flags) > 0 {
+			res.FeatureFlags = make([]*types.DeployFeatureFlag, 0, len(flags))
+
+			for _, flag := range flags {
+				res.FeatureFlags = append(res.FeatureFlags, flag.ToDeployFeatureFlagType())
+			}
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
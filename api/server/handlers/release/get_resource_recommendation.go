@@ -0,0 +1,79 @@
+package release
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	"github.com/porter-dev/porter/internal/kubernetes/rightsizing"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetResourceRecommendationHandler recommends the resource requests and limits of a release,
+// based on the historical usage of its pods
+type GetResourceRecommendationHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetResourceRecommendationHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetResourceRecommendationHandler {
+	return &GetResourceRecommendationHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetResourceRecommendationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.GetResourceRecommendationRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rec, apiErr := getResourceRecommendation(agent.Clientset, helmRelease, request.WindowDays)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	c.WriteResult(w, r, rec)
+}
+
+func getResourceRecommendation(
+	clientset kubernetes.Interface,
+	helmRelease *release.Release,
+	windowDays uint,
+) (*types.ResourceRecommendation, apierrors.RequestError) {
+	rec, err := rightsizing.GetRecommendation(clientset, helmRelease, windowDays)
+
+	if errors.Is(err, rightsizing.ErrPrometheusNotInstalled) || errors.Is(err, prometheus.ErrNoUsageData) {
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,112 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/integrations/grafana"
+	"github.com/porter-dev/porter/internal/jobqueue"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// jobKindGrafanaDashboard provisions the Grafana dashboard of a release
+const jobKindGrafanaDashboard = "release-grafana-dashboard"
+
+type grafanaDashboardPayload struct {
+	ProjectID            uint   `json:"project_id"`
+	ClusterID            uint   `json:"cluster_id"`
+	Name                 string `json:"name"`
+	Namespace            string `json:"namespace"`
+	GrafanaIntegrationID uint   `json:"grafana_integration_id"`
+}
+
+// RegisterJobHandlers registers the handlers of the background jobs which are enqueued by
+// the release endpoints
+func RegisterJobHandlers(config *config.Config) {
+	config.JobQueue.Register(jobKindGrafanaDashboard, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runGrafanaDashboardJob(config, job)
+	})
+
+	config.JobQueue.Register(jobKindDeployQueue, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeployQueueJob(config, job)
+	})
+}
+
+// enqueueGrafanaDashboard enqueues a job which provisions the Grafana dashboard of a release
+func enqueueGrafanaDashboard(
+	config *config.Config,
+	cluster *models.Cluster,
+	release *models.Release,
+	grafanaIntegrationID uint,
+) error {
+	_, err := config.JobQueue.Enqueue(cluster.ProjectID, jobKindGrafanaDashboard, &grafanaDashboardPayload{
+		ProjectID:            cluster.ProjectID,
+		ClusterID:            cluster.ID,
+		Name:                 release.Name,
+		Namespace:            release.Namespace,
+		GrafanaIntegrationID: grafanaIntegrationID,
+	})
+
+	return err
+}
+
+func runGrafanaDashboardJob(config *config.Config, job *models.BackgroundJob) error {
+	payload := &grafanaDashboardPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	// the release or integration may have been deleted since the job was enqueued
+	grafanaInt, err := config.Repo.GrafanaIntegration().ReadGrafanaIntegration(payload.ProjectID, payload.GrafanaIntegrationID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading grafana integration: %w", err)
+	}
+
+	cluster, err := config.Repo.Cluster().ReadCluster(payload.ProjectID, payload.ClusterID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	release, err := config.Repo.Release().ReadRelease(payload.ClusterID, payload.Name, payload.Namespace)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading release: %w", err)
+	}
+
+	dashboardURL, err := grafana.NewClient(grafanaInt).ProvisionDashboard(&grafana.DashboardOpts{
+		UID:         grafana.GetDashboardUID(release.ID),
+		ClusterName: cluster.Name,
+		Namespace:   release.Namespace,
+		Name:        release.Name,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error provisioning grafana dashboard: %w", err)
+	}
+
+	release.GrafanaDashboardURL = dashboardURL
+
+	if _, err := config.Repo.Release().UpdateRelease(release); err != nil {
+		return fmt.Errorf("error updating release: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/rollouts"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListAnalysisRunsHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewListAnalysisRunsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListAnalysisRunsHandler {
+	return &ListAnalysisRunsHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP lists the analysis runs of a rollout of a release, most recent first
+func (c *ListAnalysisRunsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	rolloutName, reqErr := requestutils.GetURLParamString(r, types.URLParamRolloutName)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := rollouts.ListAnalysisRuns(dynClient, namespace, name, rolloutName)
+
+	if err != nil {
+		c.HandleAPIError(w, r, toRolloutsAPIError(err))
+		return
+	}
+
+	c.WriteResult(w, r, types.ListAnalysisRunsResponse(res))
+}
@@ -0,0 +1,49 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListDeployQueueHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListDeployQueueHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListDeployQueueHandler {
+	return &ListDeployQueueHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP lists the running upgrade of a release, followed by its queued upgrades in the
+// order they will run
+func (c *ListDeployQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	deploys, err := c.Repo().QueuedDeploy().ListQueuedDeploys(cluster.ID, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDeployQueueResponse, 0, len(deploys))
+
+	for _, deploy := range deploys {
+		res = append(res, deploy.ToQueuedDeployType())
+	}
+
+	c.WriteResult(w, r, res)
+}
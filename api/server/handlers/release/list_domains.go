@@ -0,0 +1,71 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListCustomDomainsHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewListCustomDomainsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListCustomDomainsHandler {
+	return &ListCustomDomainsHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ListCustomDomainsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	domains, err := domain.ListCustomDomains(agent.Clientset, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	dnsRecords, err := c.Repo().CustomDomainDNSRecord().ListCustomDomainDNSRecordsByRelease(cluster.ID, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	dnsByHost := make(map[string]*types.CustomDomainDNS)
+
+	for _, dnsRecord := range dnsRecords {
+		dnsByHost[dnsRecord.Host] = dnsRecord.ToCustomDomainDNSType()
+	}
+
+	for _, customDomain := range domains {
+		customDomain.DNS = dnsByHost[customDomain.Host]
+	}
+
+	var res types.ListCustomDomainsResponse = domains
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,47 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListLogAlertRulesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListLogAlertRulesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListLogAlertRulesHandler {
+	return &ListLogAlertRulesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListLogAlertRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	rules, err := c.Repo().LogAlertRule().ListLogAlertRulesByRelease(cluster.ID, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListLogAlertRulesResponse, 0, len(rules))
+
+	for _, rule := range rules {
+		res = append(res, rule.ToLogAlertRuleType())
+	}
+
+	c.WriteResult(w, r, res)
+}
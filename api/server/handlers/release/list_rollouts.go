@@ -0,0 +1,66 @@
+package release
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/rollouts"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListRolloutsHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewListRolloutsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListRolloutsHandler {
+	return &ListRolloutsHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP lists the Argo Rollouts which are rendered by a release
+func (c *ListRolloutsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := rollouts.ListRollouts(dynClient, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, toRolloutsAPIError(err))
+		return
+	}
+
+	c.WriteResult(w, r, types.ListRolloutsResponse(res))
+}
+
+// toRolloutsAPIError returns the error of a request to Argo Rollouts, which is passed to the
+// client if Argo Rollouts isn't installed in the cluster or the rollout isn't part of the release
+func toRolloutsAPIError(err error) apierrors.RequestError {
+	if errors.Is(err, rollouts.ErrNotInstalled) {
+		return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	} else if errors.Is(err, rollouts.ErrNotFound) {
+		return apierrors.NewErrNotFound(err)
+	}
+
+	return apierrors.NewErrInternal(err)
+}
@@ -0,0 +1,197 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"gorm.io/gorm"
+)
+
+// maxRegistryWebhookSize is the maximum size of a registry webhook payload
+const maxRegistryWebhookSize = 1 << 20
+
+// RegistryPushWebhookHandler receives push events from a registry, and upgrades the releases
+// which use the pushed image repository and have opted in to push deploys
+type RegistryPushWebhookHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRegistryPushWebhookHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryPushWebhookHandler {
+	return &RegistryPushWebhookHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RegistryPushWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, _ := requestutils.GetURLParamString(r, types.URLParamToken)
+
+	reg, err := c.Repo().Registry().ReadRegistryByWebhookToken(token)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// throw forbidden error, since we don't want a way to verify if webhooks exist
+			c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+				fmt.Errorf("registry not found with given webhook"),
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRegistryWebhookSize))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	events, err := registry.ParsePushEvents(body)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	res := &types.RegistryPushWebhookResponse{
+		Deployments: make([]*types.RegistryPushDeployment, 0),
+	}
+
+	if len(events) == 0 {
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	clusters, err := c.Repo().Cluster().ListClustersByProjectID(reg.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, event := range events {
+		for _, cluster := range clusters {
+			for _, repoURI := range registry.GetImageRepoURICandidates(event.RepositoryURI) {
+				releases, err := c.Repo().Release().ListReleasesByImageRepoURI(cluster.ID, repoURI, nil)
+
+				if err != nil {
+					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+					return
+				}
+
+				for _, release := range releases {
+					if !release.PushDeployEnabled || !registry.MatchesTagPattern(release.PushDeployTagPattern, event.Tag) {
+						continue
+					}
+
+					deployment := &types.RegistryPushDeployment{
+						ClusterID: cluster.ID,
+						Namespace: release.Namespace,
+						Name:      release.Name,
+						Image:     fmt.Sprintf("%s:%s", repoURI, event.Tag),
+					}
+
+					deployRes, err := c.deployTag(r, cluster, release, event.Tag)
+
+					if err != nil {
+						deployment.Error = err.Error()
+					} else if deployRes != nil && deployRes.Approval != nil {
+						deployment.ApprovalID = deployRes.Approval.ID
+					} else if deployRes != nil && deployRes.QueuedDeploy != nil {
+						deployment.QueuedDeployID = deployRes.QueuedDeploy.ID
+					}
+
+					res.Deployments = append(res.Deployments, deployment)
+				}
+			}
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// deployTag upgrades a release to a new tag of its current image repository. It returns nil
+// if the release already runs the tag.
+func (c *RegistryPushWebhookHandler) deployTag(
+	r *http.Request,
+	cluster *models.Cluster,
+	release *models.Release,
+	tag string,
+) (*DeployResult, error) {
+	helmAgent, err := c.GetHelmAgent(r, cluster, release.Namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := helmAgent.GetRelease(release.Name, 0, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rel.Config["auto_deploy"] == false {
+		return nil, fmt.Errorf("deploy webhook is disabled for this deployment")
+	}
+
+	imageVals, ok := rel.Config["image"].(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("release does not set an image")
+	}
+
+	if imageVals["tag"] == tag {
+		return nil, nil
+	}
+
+	// the values of the release are copied, so that the image of the upgrade can be compared
+	// to the image of the release
+	values := make(map[string]interface{}, len(rel.Config))
+
+	for key, val := range rel.Config {
+		values[key] = val
+	}
+
+	image := make(map[string]interface{}, len(imageVals))
+
+	for key, val := range imageVals {
+		image[key] = val
+	}
+
+	image["tag"] = tag
+	values["image"] = image
+
+	// registry pushes are not made by a user, so they cannot override freeze windows
+	res, apiErr := Deploy(c.Config(), &DeployOpts{
+		Cluster:        cluster,
+		HelmAgent:      helmAgent,
+		HelmRelease:    rel,
+		Source:         types.DeploySourceRegistryPush,
+		Values:         values,
+		LatestRevision: uint(rel.Version),
+	})
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return res, nil
+}
@@ -0,0 +1,126 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/runjob"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	v1 "k8s.io/api/core/v1"
+)
+
+// RunJobHandler runs a one-off job from the pod template of a release
+type RunJobHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRunJobHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RunJobHandler {
+	return &RunJobHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RunJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.RunJobRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	template, err := getPodTemplate(helmRelease, agent)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	res, err := runjob.Run(agent.Clientset, helmRelease.Namespace, helmRelease.Name, template, request)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// getPodTemplate returns the pod template of the first controller of a release
+func getPodTemplate(helmRelease *release.Release, agent *kubernetes.Agent) (*v1.PodTemplateSpec, error) {
+	yamlArr := grapher.ImportMultiDocYAML([]byte(helmRelease.Manifest))
+	controllers := grapher.ParseControllers(yamlArr)
+
+	for _, controller := range controllers {
+		controller.Namespace = helmRelease.Namespace
+
+		switch strings.ToLower(controller.Kind) {
+		case "deployment":
+			obj, err := agent.GetDeployment(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &obj.Spec.Template, nil
+		case "statefulset":
+			obj, err := agent.GetStatefulSet(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &obj.Spec.Template, nil
+		case "daemonset":
+			obj, err := agent.GetDaemonSet(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &obj.Spec.Template, nil
+		case "cronjob":
+			obj, err := agent.GetCronJob(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &obj.Spec.JobTemplate.Spec.Template, nil
+		case "job":
+			obj, err := agent.GetJob(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &obj.Spec.Template, nil
+		}
+	}
+
+	return nil, fmt.Errorf("release %s has no deployment, statefulset, daemonset, cronjob or job to run a job from", helmRelease.Name)
+}
@@ -0,0 +1,77 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"gorm.io/gorm"
+)
+
+// checkImageSignaturePolicy rejects an upgrade if the project requires signed images and the
+// image to deploy does not have a cosign signature which is verified by the project's key
+func checkImageSignaturePolicy(
+	config *config.Config,
+	projectID uint,
+	registries []*models.Registry,
+	image string,
+) apierrors.RequestError {
+	if image == "" {
+		return nil
+	}
+
+	policy, err := config.Repo.ImageSignaturePolicy().ReadImageSignaturePolicy(projectID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if !policy.Enabled {
+		return nil
+	}
+
+	pubKey, err := registry.ParseSigningPublicKey(policy.PublicKey)
+
+	if err != nil {
+		return apierrors.NewErrInternal(fmt.Errorf("invalid image signature policy for project %d: %w", projectID, err))
+	}
+
+	regModel := registry.GetRegistryForImageRepo(registries, image)
+
+	if regModel == nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf(
+				"image %s is not in a registry linked to this project, so its signature cannot be verified",
+				image,
+			),
+			http.StatusPreconditionFailed,
+		)
+	}
+
+	_reg := registry.Registry(*regModel)
+
+	err = _reg.VerifyImageSignature(config.Repo, config.DOConf, image, pubKey)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, registry.ErrImageNotSigned):
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("image %s is not signed, and this project only deploys signed images", image),
+			http.StatusPreconditionFailed,
+		)
+	case errors.Is(err, registry.ErrInvalidImageSignature), errors.Is(err, registry.ErrSignatureDigestInvalid):
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("image %s does not have a signature which is valid for the project's public key: %w", image, err),
+			http.StatusPreconditionFailed,
+		)
+	}
+
+	return apierrors.NewErrInternal(fmt.Errorf("error verifying signature of image %s: %w", image, err))
+}
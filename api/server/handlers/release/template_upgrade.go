@@ -0,0 +1,121 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+type TemplateUpgradeHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewTemplateUpgradeHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TemplateUpgradeHandler {
+	return &TemplateUpgradeHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP renders the values and manifest which an upgrade of the release would produce,
+// without upgrading the release
+func (c *TemplateUpgradeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.UpgradeReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	upgraded, apiErr := templateUpgrade(c.Config(), helmAgent, cluster, helmRelease, request)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	c.WriteResult(w, r, &types.TemplateUpgradeResponse{
+		ChartVersion: upgraded.Chart.Metadata.Version,
+		Values:       upgraded.Config,
+		Manifest:     upgraded.Manifest,
+	})
+}
+
+// templateUpgrade renders the release which an upgrade request would produce, in the same way
+// as UpgradeReleaseHandler upgrades it
+func templateUpgrade(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+	request *types.UpgradeReleaseRequest,
+) (*release.Release, apierrors.RequestError) {
+	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	values := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(request.Values), &values); err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not parse values: %w", err),
+			http.StatusBadRequest,
+		)
+	}
+
+	conf := &helm.UpgradeReleaseConfig{
+		Name:       helmRelease.Name,
+		Values:     values,
+		Cluster:    cluster,
+		Repo:       config.Repo,
+		Registries: registries,
+	}
+
+	if request.ChartVersion != "" {
+		ch, apiErr := loadUpgradeChart(config, cluster, helmRelease, request.ChartVersion)
+
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		conf.Chart = ch
+	}
+
+	if err := setUpgradeStack(config.Repo, cluster, helmRelease, conf); err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	upgraded, err := helmAgent.TemplateUpgrade(conf, config.DOConf, config.ServerConf.DisablePullSecretsInjection)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	return upgraded, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
 )
 
 type UpdateImageBatchHandler struct {
@@ -33,6 +34,7 @@ func NewUpdateImageBatchHandler(
 }
 
 func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
 	helmAgent, err := c.GetHelmAgent(r, cluster, "")
@@ -48,7 +50,7 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	releases, err := c.Repo().Release().ListReleasesByImageRepoURI(cluster.ID, request.ImageRepoURI)
+	releases, err := c.Repo().Release().ListReleasesByImageRepoURI(cluster.ID, request.ImageRepoURI, nil)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
@@ -59,13 +61,6 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
 	// asynchronously update releases with that image repo uri
 	var wg sync.WaitGroup
 	mu := &sync.Mutex{}
@@ -94,31 +89,17 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 			}
 
 			if rel.Chart.Name() == "job" {
-				image := map[string]interface{}{}
-				image["repository"] = releases[index].ImageRepoURI
-				image["tag"] = request.Tag
-				rel.Config["image"] = image
-				rel.Config["paused"] = true
-
-				conf := &helm.UpgradeReleaseConfig{
-					Name:       releases[index].Name,
-					Cluster:    cluster,
-					Repo:       c.Repo(),
-					Registries: registries,
-					Values:     rel.Config,
-				}
-
-				_, err = helmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection)
+				_, apiErr := updateJobImage(c.Config(), user, cluster, helmAgent, rel, releases[index].ImageRepoURI, request.Tag)
 
-				if err != nil {
+				if apiErr != nil {
 					// if this is a release not found error, just return - the release has likely been deleted from the underlying
 					// cluster in the time since we've read the release, but has not been deleted from the Porter database yet
-					if strings.Contains(err.Error(), "release: not found") {
+					if strings.Contains(apiErr.Error(), "release: not found") {
 						return
 					}
 
 					mu.Lock()
-					errors = append(errors, fmt.Sprintf("Error for %s, index %d: %s", releases[index].Name, index, err.Error()))
+					errors = append(errors, fmt.Sprintf("Error for %s, index %d: %s", releases[index].Name, index, apiErr.Error()))
 					mu.Unlock()
 				}
 			}
@@ -136,3 +117,37 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 }
+
+// updateJobImage upgrades a job release to a tag of an image repository. The job is paused, so
+// that the upgrade does not run it. Upgrades which wait for an approval or in the deploy queue
+// of the release are not errors.
+func updateJobImage(
+	config *config.Config,
+	user *models.User,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+	imageRepoURI, tag string,
+) (*DeployResult, apierrors.RequestError) {
+	values := make(map[string]interface{}, len(helmRelease.Config))
+
+	for key, val := range helmRelease.Config {
+		values[key] = val
+	}
+
+	values["image"] = map[string]interface{}{
+		"repository": imageRepoURI,
+		"tag":        tag,
+	}
+
+	values["paused"] = true
+
+	return Deploy(config, &DeployOpts{
+		Cluster:     cluster,
+		HelmAgent:   helmAgent,
+		HelmRelease: helmRelease,
+		User:        user,
+		Source:      types.DeploySourceImageBatch,
+		Values:      values,
+	})
+}
@@ -0,0 +1,125 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/maintenance"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type UpdateMaintenanceModeHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUpdateMaintenanceModeHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateMaintenanceModeHandler {
+	return &UpdateMaintenanceModeHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *UpdateMaintenanceModeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.UpdateMaintenanceModeRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, name, namespace)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("release %s not found", name)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.Enabled {
+		err = maintenance.Enable(agent.Clientset, namespace, name, request.PageHTML)
+	} else {
+		err = maintenance.Disable(agent.Clientset, namespace, name)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if release.MaintenanceMode != request.Enabled {
+		release.MaintenanceMode = request.Enabled
+
+		release, err = c.Repo().Release().UpdateRelease(release)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	c.WriteResult(w, r, release.ToReleaseType())
+}
+
+// reapplyMaintenanceMode routes the ingresses of a release which is in maintenance mode to the
+// maintenance page after an upgrade, since the upgrade may have re-rendered the ingresses with
+// their original backends
+func reapplyMaintenanceMode(config *config.Config, clusterID uint, namespace, name string) error {
+	release, err := config.Repo.Release().ReadRelease(clusterID, name, namespace)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !release.MaintenanceMode {
+		return nil
+	}
+
+	cluster, err := config.Repo.Cluster().ReadCluster(release.ProjectID, clusterID)
+
+	if err != nil {
+		return err
+	}
+
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(
+		authz.NewOutOfClusterAgentGetter(config).GetOutOfClusterConfig(cluster),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return maintenance.Reapply(agent.Clientset, namespace, name)
+}
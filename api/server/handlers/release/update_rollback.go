@@ -3,6 +3,7 @@ package release
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/porter-dev/porter/api/server/authz"
@@ -12,6 +13,8 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/datadog"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -60,6 +63,37 @@ func (c *RollbackReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// the feature flags changed by the upgrades after the revision are set back to their
+	// previous state
+	revertFeatureFlags(c.Config(), cluster.ID, helmRelease.Namespace, helmRelease.Name, request.Revision)
+
+	// webhooks are best-effort, so a failed dispatch does not fail the rollback
+	webhook.Dispatch(c.Repo(), cluster.ProjectID, types.WebhookEventRollback, &types.WebhookRollbackData{
+		ClusterID: cluster.ID,
+		Name:      helmRelease.Name,
+		Namespace: helmRelease.Namespace,
+		Revision:  request.Revision,
+	})
+
+	if !cluster.NotificationsDisabled {
+		ddInts, _ := c.Repo().DatadogIntegration().ListDatadogIntegrationsByProjectID(cluster.ProjectID)
+
+		datadog.NewRollbackNotifier(ddInts...).Notify(&datadog.RollbackOpts{
+			ClusterName: cluster.Name,
+			Namespace:   helmRelease.Namespace,
+			Name:        helmRelease.Name,
+			Revision:    request.Revision,
+			URL: fmt.Sprintf(
+				"%s/applications/%s/%s/%s?project_id=%d",
+				c.Config().ServerConf.ServerURL,
+				url.PathEscape(cluster.Name),
+				helmRelease.Namespace,
+				helmRelease.Name,
+				cluster.ProjectID,
+			),
+		})
+	}
+
 	// update the github actions env if the release exists and is built from source
 	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
 		rel, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
@@ -0,0 +1,91 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/rollouts"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RolloutAction is an action which is taken on a rollout of a release
+type RolloutAction string
+
+const (
+	RolloutActionPause   RolloutAction = "pause"
+	RolloutActionPromote RolloutAction = "promote"
+	RolloutActionAbort   RolloutAction = "abort"
+)
+
+type UpdateRolloutHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+
+	action RolloutAction
+}
+
+func NewUpdateRolloutHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+	action RolloutAction,
+) *UpdateRolloutHandler {
+	return &UpdateRolloutHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+		action:                  action,
+	}
+}
+
+// ServeHTTP pauses, promotes or aborts a rollout of a release, and returns the updated rollout
+func (c *UpdateRolloutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace, _ := requestutils.GetURLParamString(r, types.URLParamNamespace)
+
+	rolloutName, reqErr := requestutils.GetURLParamString(r, types.URLParamRolloutName)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.PromoteRolloutRequest{}
+
+	if c.action == RolloutActionPromote {
+		if ok := c.DecodeAndValidate(w, r, request); !ok {
+			return
+		}
+	}
+
+	dynClient, err := c.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var rollout *types.Rollout
+
+	switch c.action {
+	case RolloutActionPause:
+		rollout, err = rollouts.Pause(dynClient, namespace, name, rolloutName)
+	case RolloutActionPromote:
+		rollout, err = rollouts.Promote(dynClient, namespace, name, rolloutName, request.Full)
+	case RolloutActionAbort:
+		rollout, err = rollouts.Abort(dynClient, namespace, name, rolloutName)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, toRolloutsAPIError(err))
+		return
+	}
+
+	c.WriteResult(w, r, rollout)
+}
@@ -3,7 +3,6 @@ package release
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	semver "github.com/Masterminds/semver/v3"
 
@@ -16,9 +15,12 @@ import (
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/sentry"
+	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/stacks"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -59,148 +61,135 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+	values := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(request.Values), &values); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not parse values: %w", err),
+			http.StatusBadRequest,
+		))
 
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	conf := &helm.UpgradeReleaseConfig{
-		Name:       helmRelease.Name,
-		Cluster:    cluster,
-		Repo:       c.Repo(),
-		Registries: registries,
+	res, apiErr := Deploy(c.Config(), &DeployOpts{
+		Cluster:        cluster,
+		HelmAgent:      helmAgent,
+		HelmRelease:    helmRelease,
+		User:           user,
+		Source:         types.DeploySourceAPI,
+		Values:         values,
+		ChartVersion:   request.ChartVersion,
+		LatestRevision: request.LatestRevision,
+		FeatureFlags:   request.FeatureFlags,
+		FreezeOverride: request.FreezeOverride,
+	})
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
 	}
 
-	// if the chart version is set, load a chart from the repo
-	if request.ChartVersion != "" {
-		cache := c.Config().URLCache
-		chartRepoURL, foundFirst := cache.GetURL(helmRelease.Chart.Metadata.Name)
-
-		if !foundFirst {
-			cache.Update()
-
-			var found bool
-
-			chartRepoURL, found = cache.GetURL(helmRelease.Chart.Metadata.Name)
-
-			if !found {
-				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-					fmt.Errorf("chart not found"),
-					http.StatusBadRequest,
-				))
-
-				return
-			}
-		}
-
-		chart, err := LoadChart(c.Config(), &LoadAddonChartOpts{
-			ProjectID:       cluster.ProjectID,
-			RepoURL:         chartRepoURL,
-			TemplateName:    helmRelease.Chart.Metadata.Name,
-			TemplateVersion: request.ChartVersion,
-		})
+	writeDeployResult(c, w, r, res, nil)
+}
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
+// writeDeployResult writes the warning of an upgrade, and the approval or queued deploy which
+// the upgrade waits for with a 202 status. The result is written if the upgrade succeeded.
+func writeDeployResult(
+	c handlers.PorterHandlerWriter,
+	w http.ResponseWriter,
+	r *http.Request,
+	res *DeployResult,
+	result interface{},
+) {
+	if res.Warning != "" {
+		writeWarning(w, res.Warning)
+	}
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("chart not found"),
-				http.StatusBadRequest,
-			))
+	switch {
+	case res.Approval != nil:
+		w.WriteHeader(http.StatusAccepted)
+		c.WriteResult(w, r, res.Approval.ToApprovalType())
+	case res.QueuedDeploy != nil:
+		w.WriteHeader(http.StatusAccepted)
+		c.WriteResult(w, r, res.QueuedDeploy.ToQueuedDeployType())
+	default:
+		c.WriteResult(w, r, result)
+	}
+}
 
-			return
-		}
+// upgradeOverrides are the chart and stack revision of an upgrade which are set by the caller,
+// rather than loaded from the chart version of the upgrade and the stack of the release
+type upgradeOverrides struct {
+	chart         *chart.Chart
+	stackName     string
+	stackRevision uint
+}
 
-		conf.Chart = chart
+// upgradeRelease upgrades a release with the values of an upgrade request, notifies the
+// integrations of the project of the result, and updates the GitHub Actions environment of
+// releases which are built from source. The user is nil for upgrades which are not made by a
+// user, and the overrides are nil for upgrades which are not part of a stack revision.
+func upgradeRelease(
+	config *config.Config,
+	user *models.User,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+	registries []*models.Registry,
+	request *types.UpgradeReleaseRequest,
+	overrides *upgradeOverrides,
+) (*release.Release, apierrors.RequestError) {
+	conf := &helm.UpgradeReleaseConfig{
+		Name:       helmRelease.Name,
+		Cluster:    cluster,
+		Repo:       config.Repo,
+		Registries: registries,
 	}
 
-	// if LatestRevision is set, check that the revision matches the latest revision in the database
-	if request.LatestRevision != 0 {
-		currHelmRelease, err := helmAgent.GetRelease(helmRelease.Name, 0, false)
+	if overrides != nil {
+		conf.Chart = overrides.chart
+		conf.StackName = overrides.stackName
+		conf.StackRevision = overrides.stackRevision
+	} else if request.ChartVersion != "" {
+		// if the chart version is set, load a chart from the repo
+		ch, apiErr := loadUpgradeChart(config, cluster, helmRelease, request.ChartVersion)
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("could not retrieve latest revision"),
-				http.StatusBadRequest,
-			))
-
-			return
+		if apiErr != nil {
+			return nil, apiErr
 		}
 
-		if currHelmRelease.Version != int(request.LatestRevision) {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("The provided revision is not up to date with the current revision (you may need to refresh the deployment). Provided revision is %d, latest revision is %d. If you would like to deploy from this revision, please revert first and update the configuration.", request.LatestRevision, currHelmRelease.Version),
-				http.StatusBadRequest,
-			))
-
-			return
-		}
+		conf.Chart = ch
 	}
 
-	// check if release is part of a stack
-	stacks, err := c.Repo().Stack().ListStacks(cluster.ProjectID, cluster.ID, helmRelease.Namespace)
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+	if apiErr := checkLatestRevision(helmAgent, helmRelease.Name, request.LatestRevision); apiErr != nil {
+		return nil, apiErr
 	}
 
-	for _, stk := range stacks {
-		for _, res := range stk.Revisions[0].Resources {
-			if res.Name == helmRelease.Name {
-				conf.StackName = stk.Name
-				conf.StackRevision = stk.Revisions[0].RevisionNumber + 1
-				break
-			}
+	// check if release is part of a stack
+	if overrides == nil {
+		if err := setUpgradeStack(config.Repo, cluster, helmRelease, conf); err != nil {
+			return nil, apierrors.NewErrInternal(err)
 		}
 	}
 
-	newHelmRelease, upgradeErr := helmAgent.UpgradeRelease(conf, request.Values, c.Config().DOConf,
-		c.Config().ServerConf.DisablePullSecretsInjection)
+	prevTag := sentry.GetImageTag(helmRelease.Config)
+
+	newHelmRelease, upgradeErr := helmAgent.UpgradeRelease(conf, request.Values, config.DOConf,
+		config.ServerConf.DisablePullSecretsInjection)
 
 	if upgradeErr == nil && newHelmRelease != nil {
 		helmRelease = newHelmRelease
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
-	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+	rel, releaseErr := config.Repo.Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
-	var notifConf *types.NotificationConfig
-	notifConf = nil
-	if rel != nil && rel.NotificationConfig != 0 {
-		conf, err := c.Repo().NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
+	deplNotifier := newDeploymentNotifier(
+		config, rel, cluster, helmRelease.Namespace,
+		sentry.GetCommitRange(rel, prevTag, sentry.GetImageTag(helmRelease.Config)),
+	)
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
-
-		notifConf = conf.ToNotificationConfigType()
-	}
-
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
-
-	notifyOpts := &notifier.NotifyOpts{
-		ProjectID:   cluster.ProjectID,
-		ClusterID:   cluster.ID,
-		ClusterName: cluster.Name,
-		Name:        helmRelease.Name,
-		Namespace:   helmRelease.Namespace,
-		URL: fmt.Sprintf(
-			"%s/applications/%s/%s/%s?project_id=%d",
-			c.Config().ServerConf.ServerURL,
-			url.PathEscape(cluster.Name),
-			helmRelease.Namespace,
-			helmRelease.Name,
-			cluster.ProjectID,
-		),
-	}
+	notifyOpts := newDeploymentNotifyOpts(config, cluster, helmRelease.Namespace, helmRelease.Name)
 
 	if upgradeErr != nil {
 		notifyOpts.Status = notifier.StatusHelmFailed
@@ -210,12 +199,10 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			deplNotifier.Notify(notifyOpts)
 		}
 
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+		return nil, apierrors.NewErrPassThroughToClient(
 			upgradeErr,
 			http.StatusBadRequest,
-		))
-
-		return
+		)
 	}
 
 	if helmRelease.Chart != nil && helmRelease.Chart.Metadata.Name != "job" {
@@ -230,18 +217,15 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	// update the github actions env if the release exists and is built from source
 	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
 		if releaseErr == nil && rel != nil {
-			err = UpdateReleaseRepo(c.Config(), rel, helmRelease)
-
-			if err != nil {
-				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-				return
+			if err := UpdateReleaseRepo(config, rel, helmRelease); err != nil {
+				return nil, apierrors.NewErrInternal(err)
 			}
 
 			gitAction := rel.GitActionConfig
 
-			if gitAction != nil && gitAction.ID != 0 && gitAction.GitlabIntegrationID == 0 {
+			if user != nil && gitAction != nil && gitAction.ID != 0 && gitAction.GitlabIntegrationID == 0 {
 				gaRunner, err := GetGARunner(
-					c.Config(),
+					config,
 					user.ID,
 					cluster.ProjectID,
 					cluster.ID,
@@ -253,39 +237,124 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 				)
 
 				if err != nil {
-					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-					return
+					return nil, apierrors.NewErrInternal(err)
 				}
 
 				actionVersion, err := semver.NewVersion(gaRunner.Version)
 
 				if err != nil {
-					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-					return
+					return nil, apierrors.NewErrInternal(err)
 				}
 
 				if createEnvSecretConstraint.Check(actionVersion) {
 					if err := gaRunner.CreateEnvSecret(); err != nil {
-						c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-						return
+						return nil, apierrors.NewErrInternal(err)
 					}
 				}
 			}
 		}
 	}
 
-	c.WriteResult(w, r, nil)
+	return helmRelease, nil
+}
 
-	err = postUpgrade(c.Config(), cluster.ProjectID, cluster.ID, helmRelease)
+// checkLatestRevision checks that the latest revision of a release is the revision which an
+// upgrade was made from, if the revision is set
+func checkLatestRevision(helmAgent *helm.Agent, name string, latestRevision uint) apierrors.RequestError {
+	if latestRevision == 0 {
+		return nil
+	}
+
+	currHelmRelease, err := helmAgent.GetRelease(name, 0, false)
 
 	if err != nil {
-		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
-		return
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not retrieve latest revision"),
+			http.StatusBadRequest,
+		)
+	}
+
+	if currHelmRelease.Version != int(latestRevision) {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("The provided revision is not up to date with the current revision (you may need to refresh the deployment). Provided revision is %d, latest revision is %d. If you would like to deploy from this revision, please revert first and update the configuration.", latestRevision, currHelmRelease.Version),
+			http.StatusBadRequest,
+		)
 	}
+
+	return nil
 }
 
 // postUpgrade runs any necessary scripting after the release has been upgraded.
 func postUpgrade(config *config.Config, projectID, clusterID uint, release *release.Release) error {
 	// update the relevant helm revision number if tied to a stack resource
-	return stacks.UpdateHelmRevision(config, projectID, clusterID, release)
+	if err := stacks.UpdateHelmRevision(config, projectID, clusterID, release); err != nil {
+		return err
+	}
+
+	return reapplyMaintenanceMode(config, clusterID, release.Namespace, release.Name)
+}
+
+// loadUpgradeChart loads the version of a release's chart which the release is upgraded to
+func loadUpgradeChart(
+	config *config.Config,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+	version string,
+) (*chart.Chart, apierrors.RequestError) {
+	cache := config.URLCache
+	chartRepoURL, foundFirst := cache.GetURL(helmRelease.Chart.Metadata.Name)
+
+	if !foundFirst {
+		cache.Update()
+
+		var found bool
+
+		chartRepoURL, found = cache.GetURL(helmRelease.Chart.Metadata.Name)
+
+		if !found {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("chart not found"),
+				http.StatusBadRequest,
+			)
+		}
+	}
+
+	ch, err := LoadChart(config, &LoadAddonChartOpts{
+		ProjectID:       cluster.ProjectID,
+		RepoURL:         chartRepoURL,
+		TemplateName:    helmRelease.Chart.Metadata.Name,
+		TemplateVersion: version,
+	})
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return ch, nil
+}
+
+// setUpgradeStack sets the stack revision of an upgrade, if the release is part of a stack
+func setUpgradeStack(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+	conf *helm.UpgradeReleaseConfig,
+) error {
+	stacks, err := repo.Stack().ListStacks(cluster.ProjectID, cluster.ID, helmRelease.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	for _, stk := range stacks {
+		for _, res := range stk.Revisions[0].Resources {
+			if res.Name == helmRelease.Name {
+				conf.StackName = stk.Name
+				conf.StackRevision = stk.Revisions[0].RevisionNumber + 1
+				break
+			}
+		}
+	}
+
+	return nil
 }
@@ -3,7 +3,6 @@ package release
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -13,9 +12,6 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/analytics"
-	"github.com/porter-dev/porter/internal/helm"
-	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/slack"
 	"gorm.io/gorm"
 )
 
@@ -115,7 +111,15 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		image["tag"] = currTag
 	}
 
-	rel.Config["image"] = image
+	// the values of the release are copied, so that the image of the upgrade can be compared
+	// to the image of the release
+	values := make(map[string]interface{}, len(rel.Config))
+
+	for key, val := range rel.Config {
+		values[key] = val
+	}
+
+	values["image"] = image
 
 	if rel.Config["auto_deploy"] == false {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
@@ -126,99 +130,34 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	registries, err := c.Repo().Registry().ListRegistriesByProjectID(release.ProjectID)
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	conf := &helm.UpgradeReleaseConfig{
-		Name:       release.Name,
-		Cluster:    cluster,
-		Repo:       c.Repo(),
-		Registries: registries,
-		Values:     rel.Config,
-	}
-
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
-
-	var notifConf *types.NotificationConfig
-	notifConf = nil
-	if release != nil && release.NotificationConfig != 0 {
-		conf, err := c.Repo().NotificationConfig().ReadNotificationConfig(release.NotificationConfig)
-
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
-
-		notifConf = conf.ToNotificationConfigType()
-	}
-
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
-
-	notifyOpts := &notifier.NotifyOpts{
-		ProjectID:   release.ProjectID,
-		ClusterID:   cluster.ID,
-		ClusterName: cluster.Name,
-		Name:        rel.Name,
-		Namespace:   rel.Namespace,
-		URL: fmt.Sprintf(
-			"%s/applications/%s/%s/%s?project_id=%d",
-			c.Config().ServerConf.ServerURL,
-			url.PathEscape(cluster.Name),
-			release.Namespace,
-			rel.Name,
-			cluster.ProjectID,
-		),
-	}
-
-	rel, err = helmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection)
-
-	if err != nil {
-		notifyOpts.Status = notifier.StatusHelmFailed
-		notifyOpts.Info = err.Error()
-
-		if !cluster.NotificationsDisabled {
-			deplNotifier.Notify(notifyOpts)
-		}
-
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-			err,
-			http.StatusBadRequest,
-		))
+	// webhooks are not made by a user, so they cannot override freeze windows
+	res, apiErr := Deploy(c.Config(), &DeployOpts{
+		Cluster:        cluster,
+		HelmAgent:      helmAgent,
+		HelmRelease:    rel,
+		Source:         types.DeploySourceWebhook,
+		Values:         values,
+		LatestRevision: uint(rel.Version),
+	})
 
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
 		return
 	}
 
-	if rel.Chart != nil && rel.Chart.Metadata.Name != "job" {
-		notifyOpts.Status = notifier.StatusHelmDeployed
-		notifyOpts.Version = rel.Version
-
-		if !cluster.NotificationsDisabled {
-			deplNotifier.Notify(notifyOpts)
-		}
+	if res.Release != nil {
+		c.Config().AnalyticsClient.Track(analytics.ApplicationDeploymentWebhookTrack(&analytics.ApplicationDeploymentWebhookTrackOpts{
+			ImageURI: fmt.Sprintf("%v", repository),
+			ApplicationScopedTrackOpts: analytics.GetApplicationScopedTrackOpts(
+				0,
+				release.ProjectID,
+				release.ClusterID,
+				release.Name,
+				release.Namespace,
+				res.Release.Chart.Metadata.Name,
+			),
+		}))
 	}
 
-	c.Config().AnalyticsClient.Track(analytics.ApplicationDeploymentWebhookTrack(&analytics.ApplicationDeploymentWebhookTrackOpts{
-		ImageURI: fmt.Sprintf("%v", repository),
-		ApplicationScopedTrackOpts: analytics.GetApplicationScopedTrackOpts(
-			0,
-			release.ProjectID,
-			release.ClusterID,
-			release.Name,
-			release.Namespace,
-			rel.Chart.Metadata.Name,
-		),
-	}))
-
-	c.WriteResult(w, r, nil)
-
-	err = postUpgrade(c.Config(), cluster.ProjectID, cluster.ID, rel)
-
-	if err != nil {
-		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
-		return
-	}
+	writeDeployResult(c, w, r, res, nil)
 }
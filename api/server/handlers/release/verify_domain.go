@@ -0,0 +1,93 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// VerifyCustomDomainHandler checks that a custom domain's DNS records point to the
+// cluster's NGINX ingress controller
+type VerifyCustomDomainHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewVerifyCustomDomainHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *VerifyCustomDomainHandler {
+	return &VerifyCustomDomainHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *VerifyCustomDomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.VerifyCustomDomainRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	endpoint, found, err := domain.GetNGINXIngressServiceIP(agent.Clientset)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !found {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("target cluster does not have nginx ingress"),
+			http.StatusPreconditionFailed,
+		))
+		return
+	}
+
+	res := domain.VerifyCustomDomain(request.Host, endpoint)
+
+	// a verified domain whose DNS is managed by an integration is marked as active without
+	// waiting for the next verification of pending records
+	if res.Verified {
+		dnsRecord, err := c.Repo().CustomDomainDNSRecord().ReadCustomDomainDNSRecord(cluster.ID, strings.ToLower(request.Host))
+
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		} else if err == nil && dnsRecord.Status != types.CustomDomainDNSActive {
+			now := time.Now()
+
+			dnsRecord.Status = types.CustomDomainDNSActive
+			dnsRecord.Message = ""
+			dnsRecord.VerifiedAt = &now
+
+			if _, err := c.Repo().CustomDomainDNSRecord().UpdateCustomDomainDNSRecord(dnsRecord); err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,71 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/kubernetes/vulnerabilities"
+	"github.com/porter-dev/porter/internal/models"
+	"k8s.io/client-go/dynamic"
+)
+
+// checkVulnerabilityPolicy rejects an upgrade if the release blocks critical vulnerabilities
+// and the image to deploy has critical vulnerabilities. Images without scan results are
+// allowed, so that a missing scanner does not block all deploys.
+func checkVulnerabilityPolicy(
+	config *config.Config,
+	rel *models.Release,
+	dynClient dynamic.Interface,
+	registries []*models.Registry,
+	image string,
+) apierrors.RequestError {
+	if rel == nil || !rel.BlockCriticalVulnerabilities || image == "" {
+		return nil
+	}
+
+	scanner := vulnerabilities.NewScanner(dynClient, rel.Namespace, registries, config.Repo)
+
+	summary, err := scanner.Scan(image)
+
+	if err == vulnerabilities.ErrNoScanResults {
+		return nil
+	} else if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if summary.Critical > 0 {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf(
+				"image %s has %d critical vulnerabilities, and this release blocks upgrades with critical vulnerabilities",
+				image, summary.Critical,
+			),
+			http.StatusPreconditionFailed,
+		)
+	}
+
+	return nil
+}
+
+// getImageFromValues returns the image reference set by the image.repository and image.tag
+// values of a Porter chart
+func getImageFromValues(values map[string]interface{}) string {
+	imageVals, ok := values["image"].(map[string]interface{})
+
+	if !ok {
+		return ""
+	}
+
+	repository, _ := imageVals["repository"].(string)
+
+	if repository == "" {
+		return ""
+	}
+
+	if tag := fmt.Sprintf("%v", imageVals["tag"]); imageVals["tag"] != nil && tag != "" {
+		return repository + ":" + tag
+	}
+
+	return repository
+}
@@ -0,0 +1,75 @@
+package sentry_integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier/sentry"
+)
+
+type SentryIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewSentryIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SentryIntegrationCreateHandler {
+	return &SentryIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *SentryIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateSentryIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.BaseURL == "" {
+		request.BaseURL = sentry.DefaultBaseURL
+	}
+
+	// the auth token is checked before it is stored, so that releases are not silently dropped.
+	// Sentry is called from inside the network of Porter, so base URLs which resolve to
+	// internal addresses are rejected as well.
+	if err := sentry.ValidateAuthToken(r.Context(), request.BaseURL, request.OrganizationSlug, request.AuthToken); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("could not validate Sentry auth token: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	sentryInt, err := p.Repo().SentryIntegration().CreateSentryIntegration(&integrations.SentryIntegration{
+		UserID:           user.ID,
+		ProjectID:        project.ID,
+		Name:             request.Name,
+		BaseURL:          strings.TrimSuffix(request.BaseURL, "/"),
+		OrganizationSlug: request.OrganizationSlug,
+		SentryProjects:   strings.Join(request.SentryProjects, ","),
+		Environment:      request.Environment,
+		AuthToken:        []byte(request.AuthToken),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, sentryInt.ToSentryIntegrationType())
+}
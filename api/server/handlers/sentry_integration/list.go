@@ -0,0 +1,44 @@
+package sentry_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type SentryIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewSentryIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *SentryIntegrationListHandler {
+	return &SentryIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *SentryIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sentryInts, err := p.Repo().SentryIntegration().ListSentryIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListSentryIntegrationsResponse, 0)
+
+	for _, sentryInt := range sentryInts {
+		res = append(res, sentryInt.ToSentryIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,85 @@
+package slack_integration
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type SlackRoutingRuleCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewSlackRoutingRuleCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SlackRoutingRuleCreateHandler {
+	return &SlackRoutingRuleCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *SlackRoutingRuleCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateSlackRoutingRuleRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	slackInts, err := p.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	found := false
+
+	for _, slackInt := range slackInts {
+		if slackInt.ID == request.SlackIntegrationID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("slack integration not found")))
+		return
+	}
+
+	if request.ClusterID != 0 {
+		if _, err := p.Repo().Cluster().ReadCluster(project.ID, request.ClusterID); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("cluster %d not found in project", request.ClusterID),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	rule, err := p.Repo().SlackIntegration().CreateSlackRoutingRule(&integrations.SlackRoutingRule{
+		ProjectID:          project.ID,
+		SlackIntegrationID: request.SlackIntegrationID,
+		Event:              request.Event,
+		ClusterID:          request.ClusterID,
+		Namespace:          request.Namespace,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, rule.ToSlackRoutingRuleType())
+}
@@ -0,0 +1,66 @@
+package slack_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type SlackWebhookIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewSlackWebhookIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SlackWebhookIntegrationCreateHandler {
+	return &SlackWebhookIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *SlackWebhookIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateSlackWebhookIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// only Slack webhooks are accepted, so that notifications cannot be sent to arbitrary URLs
+	webhookURL, err := url.Parse(request.Webhook)
+
+	if err != nil || webhookURL.Scheme != "https" || webhookURL.Host != "hooks.slack.com" {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("webhook must be a Slack incoming webhook URL starting with https://hooks.slack.com"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	slackInt, err := p.Repo().SlackIntegration().CreateSlackIntegration(&integrations.SlackIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Channel:   request.Channel,
+		Webhook:   []byte(request.Webhook),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, slackInt.ToSlackIntegraionType())
+}
@@ -0,0 +1,44 @@
+package slack_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type SlackRoutingRuleListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewSlackRoutingRuleListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *SlackRoutingRuleListHandler {
+	return &SlackRoutingRuleListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *SlackRoutingRuleListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	rules, err := p.Repo().SlackIntegration().ListSlackRoutingRules(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListSlackRoutingRulesResponse, 0)
+
+	for _, rule := range rules {
+		res = append(res, rule.ToSlackRoutingRuleType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -45,6 +45,11 @@ func (p *StackAddApplicationHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if apiErr := checkNotExported(p.Repo(), stack); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	if len(stack.Revisions) == 0 {
 		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
 			fmt.Errorf("no stack revisions exist"), http.StatusBadRequest,
@@ -123,9 +123,11 @@ func (p *StackAddEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(p.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	envGroupDeployErrors := make([]string, 0)
 
-	cm, err := envgroup.CreateEnvGroup(k8sAgent, types.ConfigMapInput{
+	cm, err := envgroup.CreateStoredEnvGroup(k8sAgent, storage, types.ConfigMapInput{
 		Name:            req.Name,
 		Namespace:       namespace,
 		Variables:       req.Variables,
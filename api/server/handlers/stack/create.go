@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -131,10 +132,12 @@ func (p *StackCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(p.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	envGroupDeployErrors := make([]string, 0)
 
 	for _, envGroup := range req.EnvGroups {
-		cm, err := envgroup.CreateEnvGroup(k8sAgent, types.ConfigMapInput{
+		cm, err := envgroup.CreateStoredEnvGroup(k8sAgent, storage, types.ConfigMapInput{
 			Name:            envGroup.Name,
 			Namespace:       namespace,
 			Variables:       envGroup.Variables,
@@ -277,24 +280,25 @@ func (p *StackCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func getSourceConfigModels(sourceConfigs []*types.CreateStackSourceConfigRequest) ([]models.StackSourceConfig, error) {
 	res := make([]models.StackSourceConfig, 0)
 
-	// for now, only write source configs which are deployed as a docker image
-	// TODO: add parsing/writes for git-based sources
 	for _, sourceConfig := range sourceConfigs {
-		if sourceConfig.StackSourceConfigBuild == nil {
-			uid, err := encryption.GenerateRandomBytes(16)
+		uid, err := encryption.GenerateRandomBytes(16)
 
-			if err != nil {
-				return nil, err
-			}
+		if err != nil {
+			return nil, err
+		}
 
-			res = append(res, models.StackSourceConfig{
-				UID:          uid,
-				DisplayName:  sourceConfig.DisplayName,
-				Name:         sourceConfig.Name,
-				ImageRepoURI: sourceConfig.ImageRepoURI,
-				ImageTag:     sourceConfig.ImageTag,
-			})
+		model := models.StackSourceConfig{
+			UID:          uid,
+			DisplayName:  sourceConfig.DisplayName,
+			Name:         sourceConfig.Name,
+			ImageRepoURI: sourceConfig.ImageRepoURI,
+			ImageTag:     sourceConfig.ImageTag,
 		}
+
+		// the image is still deployed from the image repo uri, which the built image is pushed to
+		model.SetBuild(sourceConfig.StackSourceConfigBuild)
+
+		res = append(res, model)
 	}
 
 	return res, nil
@@ -326,6 +330,14 @@ func getResourceModels(appResources []*types.CreateStackAppResourceRequest, sour
 			return nil, fmt.Errorf("source config %s does not exist in source config list", appResource.SourceConfigName)
 		}
 
+		// the values are stored so that the resource can be installed again when the stack is
+		// rolled back to a revision from before the resource was deleted
+		values, err := json.Marshal(appResource.Values)
+
+		if err != nil {
+			return nil, err
+		}
+
 		res = append(res, models.StackResource{
 			Name:                 appResource.Name,
 			UID:                  uid,
@@ -334,6 +346,7 @@ func getResourceModels(appResources []*types.CreateStackAppResourceRequest, sour
 			TemplateName:         appResource.TemplateName,
 			TemplateVersion:      appResource.TemplateVersion,
 			HelmRevisionID:       1,
+			Values:               values,
 		})
 	}
 
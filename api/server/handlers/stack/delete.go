@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -33,6 +34,38 @@ func (p *StackDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
 
+	export, err := readGitOpsExport(p.Repo(), stack.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the manifests of an exported stack are removed from git, so that they're no longer applied
+	if export != nil {
+		_, err := commitStackManifests(
+			p.Config(),
+			export,
+			stack,
+			map[string]string{},
+			fmt.Sprintf("Delete stack %s/%s", namespace, stack.Name),
+		)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("error removing the manifests of the stack from git: %w", err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		if err := p.Repo().GitOpsExport().DeleteGitOpsExport(export); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
 	if len(stack.Revisions) > 0 {
 		revision, err := p.Repo().Stack().ReadStackRevisionByNumber(stack.ID, stack.Revisions[0].RevisionNumber)
 
@@ -48,6 +81,8 @@ func (p *StackDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		storage := envgroup.NewSecretStorage(p.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 		helmAgent, err := p.GetHelmAgent(r, cluster, namespace)
 
 		if err != nil {
@@ -65,11 +100,11 @@ func (p *StackDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		// delete all env groups in stack
 		for _, envGroup := range revision.EnvGroups {
-			envgroup.DeleteEnvGroup(k8sAgent, envGroup.Name, envGroup.Namespace)
+			envgroup.DeleteStoredEnvGroup(k8sAgent, storage, envGroup.Name, envGroup.Namespace)
 		}
 	}
 
-	stack, err := p.Repo().Stack().DeleteStack(stack)
+	stack, err = p.Repo().Stack().DeleteStack(stack)
 
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -0,0 +1,48 @@
+package stack
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// StackDeleteGitOpsExportHandler stops the export of a stack to git, after which the stack is
+// deployed by Porter again. The manifests which were exported are left in the repository.
+type StackDeleteGitOpsExportHandler struct {
+	handlers.PorterHandler
+}
+
+func NewStackDeleteGitOpsExportHandler(
+	config *config.Config,
+) *StackDeleteGitOpsExportHandler {
+	return &StackDeleteGitOpsExportHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *StackDeleteGitOpsExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stack, _ := r.Context().Value(types.StackScope).(*models.Stack)
+
+	export, err := readGitOpsExport(p.Repo(), stack.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if export == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := p.Repo().GitOpsExport().DeleteGitOpsExport(export); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,46 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type StackGetGitOpsExportHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewStackGetGitOpsExportHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *StackGetGitOpsExportHandler {
+	return &StackGetGitOpsExportHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *StackGetGitOpsExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stack, _ := r.Context().Value(types.StackScope).(*models.Stack)
+
+	export, err := p.Repo().GitOpsExport().ReadGitOpsExportByStackID(stack.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("stack %s is not exported to git", stack.Name)))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, export.ToGitOpsExportType())
+}
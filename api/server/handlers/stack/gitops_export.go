@@ -0,0 +1,268 @@
+package stack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ghinstallation "github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/gitops"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/tracing"
+	"gorm.io/gorm"
+)
+
+// readGitOpsExport returns the export of a stack, or nil if the stack is deployed by Porter
+func readGitOpsExport(repo repository.Repository, stackID uint) (*models.GitOpsExport, error) {
+	export, err := repo.GitOpsExport().ReadGitOpsExportByStackID(stackID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// checkNotExported returns an error if a stack is exported to git, for the updates of a stack
+// which can only be deployed by Porter
+func checkNotExported(repo repository.Repository, stack *models.Stack) apierrors.RequestError {
+	export, err := readGitOpsExport(repo, stack.ID)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if export != nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("stack %s is exported to git, so it can only be updated through its spec or source configs", stack.Name),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
+
+// getGitOpsExportClient returns a client for the github app installation of an export
+func getGitOpsExportClient(config *config.Config, export *models.GitOpsExport) (*github.Client, error) {
+	if config.GithubAppConf == nil {
+		return nil, fmt.Errorf("the github app is not configured")
+	}
+
+	itr, err := ghinstallation.NewKeyFromFile(
+		tracing.NewTransport(http.DefaultTransport),
+		config.GithubAppConf.AppID,
+		int64(export.GitInstallationID),
+		config.GithubAppConf.SecretPath,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return github.NewClient(&http.Client{Transport: itr}), nil
+}
+
+type exportStackRevisionOpts struct {
+	config     *config.Config
+	helmAgent  *helm.Agent
+	cluster    *models.Cluster
+	namespace  string
+	registries []*models.Registry
+	stack      *models.Stack
+	revision   *models.StackRevision
+	export     *models.GitOpsExport
+}
+
+// exportStackRevision renders the app resources of a revision of an exported stack, and
+// commits their manifests to the repository of the export in place of deploying them. The
+// result of the export is recorded on the export and on the status of the revision.
+func exportStackRevision(opts *exportStackRevisionOpts) error {
+	manifests := make(map[string]string)
+	renderErrs := make([]string, 0)
+
+	for _, resource := range opts.revision.Resources {
+		manifest, err := renderAppResource(opts, resource)
+
+		if err != nil {
+			renderErrs = append(renderErrs, fmt.Sprintf("error rendering %s: %s", resource.Name, err.Error()))
+			continue
+		}
+
+		manifests[resource.Name] = manifest
+	}
+
+	// a partial export would delete the manifests of the resources which failed to render
+	if len(renderErrs) > 0 {
+		opts.revision.Status = string(types.StackRevisionStatusFailed)
+		opts.revision.Reason = "GitOpsRenderError"
+		opts.revision.Message = strings.Join(renderErrs, " , ")
+
+		return recordGitOpsExport(opts.config, opts.export, nil, errors.New(opts.revision.Message))
+	}
+
+	res, err := commitStackManifests(
+		opts.config,
+		opts.export,
+		opts.stack,
+		manifests,
+		fmt.Sprintf("Deploy revision %d of stack %s/%s", opts.revision.RevisionNumber, opts.namespace, opts.stack.Name),
+	)
+
+	if err != nil {
+		opts.revision.Status = string(types.StackRevisionStatusFailed)
+		opts.revision.Reason = "GitOpsExportError"
+		opts.revision.Message = err.Error()
+	} else {
+		opts.revision.Status = string(types.StackRevisionStatusDeployed)
+		opts.revision.Reason = "GitOpsExport"
+		opts.revision.Message = getExportMessage(opts.export, res)
+	}
+
+	return recordGitOpsExport(opts.config, opts.export, res, err)
+}
+
+// commitStackManifests commits the manifests of a stack to the directory of its export, or
+// opens a pull request with the manifests if the export uses pull requests
+func commitStackManifests(
+	config *config.Config,
+	export *models.GitOpsExport,
+	stack *models.Stack,
+	manifests map[string]string,
+	message string,
+) (*gitops.Result, error) {
+	client, err := getGitOpsExportClient(config, export)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return gitops.Export(
+		client,
+		&gitops.Target{
+			Owner:       export.GitRepoOwner,
+			Name:        export.GitRepoName,
+			Branch:      export.GitBranch,
+			Path:        export.Path,
+			PullRequest: export.PullRequest,
+		},
+		gitops.ManifestFiles(export.Path, manifests),
+		&gitops.Commit{
+			Message:          message,
+			HeadBranch:       fmt.Sprintf("porter/stack-%s", stack.UID),
+			PullRequestTitle: message,
+			PullRequestBody: fmt.Sprintf(
+				"This pull request was opened by Porter. It updates the manifests of stack `%s` in `%s`.",
+				stack.Name, export.Path,
+			),
+		},
+	)
+}
+
+// renderAppResource renders the manifests of an app resource of a stack revision, with the
+// image tag of its source config
+func renderAppResource(opts *exportStackRevisionOpts, resource models.StackResource) (string, error) {
+	values := make(map[string]interface{})
+
+	if len(resource.Values) > 0 {
+		if err := json.Unmarshal(resource.Values, &values); err != nil {
+			return "", err
+		}
+	}
+
+	for _, sourceConfig := range opts.revision.SourceConfigs {
+		if sourceConfig.UID != resource.StackSourceConfigUID || sourceConfig.ImageTag == "" {
+			continue
+		}
+
+		if image, ok := values["image"].(map[string]interface{}); ok {
+			image["tag"] = sourceConfig.ImageTag
+		}
+	}
+
+	values["stack"] = map[string]interface{}{
+		"enabled":  true,
+		"name":     opts.stack.Name,
+		"revision": opts.revision.RevisionNumber,
+	}
+
+	version := resource.TemplateVersion
+
+	if version == "latest" {
+		version = ""
+	}
+
+	chart, err := loader.LoadChartPublic(resource.TemplateRepoURL, resource.TemplateName, version)
+
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := opts.helmAgent.TemplateInstall(&helm.InstallChartConfig{
+		Chart:      chart,
+		Name:       resource.Name,
+		Namespace:  opts.namespace,
+		Values:     values,
+		Cluster:    opts.cluster,
+		Repo:       opts.config.Repo,
+		Registries: opts.registries,
+	}, opts.config.DOConf, opts.config.ServerConf.DisablePullSecretsInjection)
+
+	if err != nil {
+		return "", err
+	}
+
+	manifest := rel.Manifest
+
+	// hooks are exported with the manifests, since GitOps controllers run helm hooks from their
+	// annotations
+	for _, hook := range rel.Hooks {
+		manifest += fmt.Sprintf("\n---\n# Source: %s\n%s", hook.Path, hook.Manifest)
+	}
+
+	return manifest, nil
+}
+
+// recordGitOpsExport records the result of the latest export of a stack
+func recordGitOpsExport(config *config.Config, export *models.GitOpsExport, res *gitops.Result, exportErr error) error {
+	now := time.Now().UTC()
+
+	export.LastExportedAt = &now
+	export.LastError = ""
+
+	if exportErr != nil {
+		export.LastError = exportErr.Error()
+	} else if res.CommitSHA != "" {
+		export.LastCommitSHA = res.CommitSHA
+		export.LastPullRequestURL = res.PullRequestURL
+	}
+
+	_, err := config.Repo.GitOpsExport().UpdateGitOpsExport(export)
+
+	return err
+}
+
+func getExportMessage(export *models.GitOpsExport, res *gitops.Result) string {
+	repo := fmt.Sprintf("%s/%s", export.GitRepoOwner, export.GitRepoName)
+
+	if res.CommitSHA == "" {
+		return fmt.Sprintf("The manifests of the revision are already committed to %s", repo)
+	} else if res.PullRequestURL != "" {
+		return fmt.Sprintf("The manifests of the revision were opened in a pull request against %s: %s", repo, res.PullRequestURL)
+	}
+
+	return fmt.Sprintf("The manifests of the revision were committed to %s@%s", repo, res.CommitSHA)
+}
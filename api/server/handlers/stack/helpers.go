@@ -1,12 +1,18 @@
 package stack
 
 import (
+	"fmt"
+	"strings"
+
+	releaseHandler "github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
 	"github.com/porter-dev/porter/internal/models"
 	"helm.sh/helm/v3/pkg/release"
+	v1 "k8s.io/api/core/v1"
 )
 
 type applyAppResourceOpts struct {
@@ -18,6 +24,9 @@ type applyAppResourceOpts struct {
 	request    *types.CreateStackAppResourceRequest
 	registries []*models.Registry
 
+	// the user who changed the stack
+	user *models.User
+
 	// stack related info
 	stackName     string
 	stackRevision uint
@@ -57,6 +66,107 @@ func applyAppResource(opts *applyAppResourceOpts) (*release.Release, error) {
 	return opts.helmAgent.InstallChart(conf, opts.config.DOConf, opts.config.ServerConf.DisablePullSecretsInjection)
 }
 
+// upgradeAppResource upgrades an existing app resource to the template version and values of
+// the request, replacing its previous values
+func upgradeAppResource(opts *applyAppResourceOpts) (*release.Release, error) {
+	if opts.request.TemplateVersion == "latest" {
+		opts.request.TemplateVersion = ""
+	}
+
+	helmRelease, err := opts.helmAgent.GetRelease(opts.request.Name, 0, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	chart, err := loader.LoadChartPublic(opts.request.TemplateRepoURL, opts.request.TemplateName, opts.request.TemplateVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deployAppResource(opts.config, opts.user, opts.cluster, opts.helmAgent, helmRelease, &releaseHandler.DeployOpts{
+		Values:        opts.request.Values,
+		Chart:         chart,
+		StackName:     opts.stackName,
+		StackRevision: opts.stackRevision,
+	})
+}
+
+// deployAppResource upgrades an app resource of a stack through the deploys of the release
+// handlers, so the upgrade is blocked by the freeze windows and image policies of the release.
+// The app resources of a stack revision are upgraded together, so an upgrade which needs an
+// approval, or which waits for another upgrade of the release, fails.
+func deployAppResource(
+	config *config.Config,
+	user *models.User,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+	opts *releaseHandler.DeployOpts,
+) (*release.Release, error) {
+	opts.Cluster = cluster
+	opts.HelmAgent = helmAgent
+	opts.HelmRelease = helmRelease
+	opts.User = user
+	opts.Source = types.DeploySourceStack
+
+	res, apiErr := releaseHandler.Deploy(config, opts)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return res.Release, nil
+}
+
+// setSyncedEnvGroups sets the env groups whose variables are injected into an app resource in the
+// container.env.synced section of its values
+func setSyncedEnvGroups(values map[string]interface{}, configMaps []*v1.ConfigMap) error {
+	synced := make([]interface{}, 0)
+
+	for _, cm := range configMaps {
+		envGroup, err := envgroup.ToEnvGroup(cm)
+
+		if err != nil {
+			return err
+		}
+
+		keys := make([]interface{}, 0)
+
+		for key, val := range cm.Data {
+			keys = append(keys, map[string]interface{}{
+				"name":   key,
+				"secret": strings.Contains(val, "PORTERSECRET"),
+			})
+		}
+
+		synced = append(synced, map[string]interface{}{
+			"name":    envGroup.Name,
+			"version": envGroup.Version,
+			"keys":    keys,
+		})
+	}
+
+	container, ok := values["container"].(map[string]interface{})
+
+	if !ok {
+		container = make(map[string]interface{})
+		values["container"] = container
+	}
+
+	env, ok := container["env"].(map[string]interface{})
+
+	if !ok {
+		env = make(map[string]interface{})
+		container["env"] = env
+	}
+
+	env["synced"] = synced
+
+	return nil
+}
+
 type rollbackAppResourceOpts struct {
 	helmAgent      *helm.Agent
 	helmRevisionID uint
@@ -68,13 +178,15 @@ func rollbackAppResource(opts *rollbackAppResourceOpts) error {
 }
 
 type updateAppResourceTagOpts struct {
-	helmAgent  *helm.Agent
-	name, tag  string
-	config     *config.Config
-	projectID  uint
-	namespace  string
-	cluster    *models.Cluster
-	registries []*models.Registry
+	helmAgent *helm.Agent
+	name, tag string
+	config    *config.Config
+	projectID uint
+	namespace string
+	cluster   *models.Cluster
+
+	// the user who changed the stack
+	user *models.User
 
 	// stack related info
 	stackName     string
@@ -89,25 +201,34 @@ func updateAppResourceTag(opts *updateAppResourceTagOpts) error {
 		return err
 	}
 
-	imagePre := rel.Config["image"]
-	image := imagePre.(map[string]interface{})
-	image["tag"] = opts.tag
-	rel.Config["image"] = image
+	imageVals, ok := rel.Config["image"].(map[string]interface{})
 
-	conf := &helm.UpgradeReleaseConfig{
-		Name:       opts.name,
-		Cluster:    opts.cluster,
-		Repo:       opts.config.Repo,
-		Registries: opts.registries,
-		Values:     rel.Config,
+	if !ok {
+		return fmt.Errorf("release %s does not set an image", opts.name)
+	}
 
-		// stack related info
-		StackName:     opts.stackName,
-		StackRevision: opts.stackRevision,
+	// the values of the release are copied, so that the image of the upgrade can be compared
+	// to the image of the release
+	values := make(map[string]interface{}, len(rel.Config))
+
+	for key, val := range rel.Config {
+		values[key] = val
+	}
+
+	image := make(map[string]interface{}, len(imageVals))
+
+	for key, val := range imageVals {
+		image[key] = val
 	}
 
-	_, err = opts.helmAgent.UpgradeReleaseByValues(conf, opts.config.DOConf,
-		opts.config.ServerConf.DisablePullSecretsInjection)
+	image["tag"] = opts.tag
+	values["image"] = image
+
+	_, err = deployAppResource(opts.config, opts.user, opts.cluster, opts.helmAgent, rel, &releaseHandler.DeployOpts{
+		Values:        values,
+		StackName:     opts.stackName,
+		StackRevision: opts.stackRevision,
+	})
 
 	return err
 }
@@ -0,0 +1,110 @@
+package stack
+
+import (
+	"net/http"
+	"testing"
+
+	releaseHandler "github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type appResourceFixture struct {
+	config      *config.Config
+	user        *models.User
+	cluster     *models.Cluster
+	helmAgent   *helm.Agent
+	helmRelease *release.Release
+}
+
+func newAppResourceFixture(t *testing.T) *appResourceFixture {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	cluster := apitest.CreateTestCluster(t, config, 1)
+
+	helmAgent, helmRelease := apitest.CreateTestRelease(t, config, "default", "test-app", map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "porter/app",
+			"tag":        "v1",
+		},
+	})
+
+	return &appResourceFixture{config, user, cluster, helmAgent, helmRelease}
+}
+
+// appResourceUpgrades upgrade the app resource of a fixture to a new image through each helper
+// which upgrades the app resources of a stack
+var appResourceUpgrades = map[string]func(f *appResourceFixture) error{
+	"deploy app resource": func(f *appResourceFixture) error {
+		_, err := deployAppResource(f.config, f.user, f.cluster, f.helmAgent, f.helmRelease, &releaseHandler.DeployOpts{
+			Values: map[string]interface{}{
+				"image": map[string]interface{}{
+					"repository": "porter/app",
+					"tag":        "v2",
+				},
+			},
+			Chart:         f.helmRelease.Chart,
+			StackName:     "test-stack",
+			StackRevision: 2,
+		})
+
+		return err
+	},
+	"update app resource tag": func(f *appResourceFixture) error {
+		return updateAppResourceTag(&updateAppResourceTagOpts{
+			helmAgent:     f.helmAgent,
+			name:          "test-app",
+			tag:           "v2",
+			config:        f.config,
+			projectID:     f.cluster.ProjectID,
+			namespace:     "default",
+			cluster:       f.cluster,
+			user:          f.user,
+			stackName:     "test-stack",
+			stackRevision: 2,
+		})
+	},
+}
+
+func TestAppResourceUpgradeRequiresApproval(t *testing.T) {
+	for name, upgrade := range appResourceUpgrades {
+		t.Run(name, func(t *testing.T) {
+			f := newAppResourceFixture(t)
+			apitest.CreateTestApprovalPolicy(t, f.config, f.cluster, f.user.ID)
+
+			// the app resources of a stack revision are upgraded together, so they can't wait
+			// for an approval
+			apitest.AssertRequestError(t, upgrade(f), http.StatusForbidden)
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, "test-app")
+		})
+	}
+}
+
+func TestAppResourceUpgradeBlockedByFreezeWindow(t *testing.T) {
+	for name, upgrade := range appResourceUpgrades {
+		t.Run(name, func(t *testing.T) {
+			f := newAppResourceFixture(t)
+			apitest.CreateTestFreezeWindow(t, f.config, f.cluster)
+
+			apitest.AssertRequestError(t, upgrade(f), http.StatusLocked)
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, "test-app")
+		})
+	}
+}
+
+func TestAppResourceUpgradeConflictsWithRunningDeploy(t *testing.T) {
+	for name, upgrade := range appResourceUpgrades {
+		t.Run(name, func(t *testing.T) {
+			f := newAppResourceFixture(t)
+			apitest.CreateTestRunningDeploy(t, f.config, f.cluster, "default", "test-app")
+
+			// the upgrade can't wait for another upgrade of the release, so its deploy is cancelled
+			apitest.AssertRequestError(t, upgrade(f), http.StatusConflict)
+			apitest.AssertDeployNotQueued(t, f.config, f.cluster, "default", "test-app")
+			apitest.AssertReleaseNotUpgraded(t, f.helmAgent, "test-app")
+		})
+	}
+}
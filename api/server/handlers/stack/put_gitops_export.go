@@ -0,0 +1,158 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// StackPutGitOpsExportHandler configures a stack to be exported to a git repository. Once the
+// stack is exported, updates of its spec and source configs are committed to the repository
+// instead of being deployed by Porter.
+type StackPutGitOpsExportHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewStackPutGitOpsExportHandler(
+	config *config.Config,
+	reader shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *StackPutGitOpsExportHandler {
+	return &StackPutGitOpsExportHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, reader, writer),
+	}
+}
+
+func (p *StackPutGitOpsExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	stack, _ := r.Context().Value(types.StackScope).(*models.Stack)
+
+	req := &types.PutGitOpsExportRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, req); !ok {
+		return
+	}
+
+	// the directory is fully managed by the export, so the root of the repository can't be used
+	if strings.Trim(req.Path, "/.") == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("the path must be a directory of the repository"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if p.Config().GithubAppConf == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("the github app is not configured"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if _, err := p.Repo().GithubAppInstallation().ReadGithubAppInstallationByInstallationID(req.GitInstallationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("github app installation %d not found", req.GitInstallationID),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if apiErr := p.checkRepoAccess(r, req); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	export, err := readGitOpsExport(p.Repo(), stack.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	isNew := export == nil
+
+	if isNew {
+		export = &models.GitOpsExport{
+			ProjectID: proj.ID,
+			ClusterID: cluster.ID,
+			StackID:   stack.ID,
+		}
+	}
+
+	export.GitInstallationID = req.GitInstallationID
+	export.GitRepoOwner = req.GitRepoOwner
+	export.GitRepoName = req.GitRepoName
+	export.GitBranch = req.GitBranch
+	export.Path = req.Path
+	export.PullRequest = req.PullRequest
+
+	if isNew {
+		export, err = p.Repo().GitOpsExport().CreateGitOpsExport(export)
+	} else {
+		export, err = p.Repo().GitOpsExport().UpdateGitOpsExport(export)
+	}
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, export.ToGitOpsExportType())
+}
+
+// checkRepoAccess checks that the user can push to the repository of the export, and that the
+// github app installation can read its branch
+func (p *StackPutGitOpsExportHandler) checkRepoAccess(r *http.Request, req *types.PutGitOpsExportRequest) apierrors.RequestError {
+	tok, err := gitinstallation.GetGithubAppOauthTokenFromRequest(p.Config(), r)
+
+	if err != nil {
+		return apierrors.NewErrForbidden(fmt.Errorf("error reading the github token of the user: %w", err))
+	}
+
+	userClient := github.NewClient(p.Config().GithubAppConf.Client(oauth2.NoContext, tok))
+
+	repo, _, err := userClient.Repositories.Get(context.Background(), req.GitRepoOwner, req.GitRepoName)
+
+	if err != nil || !repo.GetPermissions()["push"] {
+		return apierrors.NewErrForbidden(fmt.Errorf("user cannot push to repository %s/%s", req.GitRepoOwner, req.GitRepoName))
+	}
+
+	client, err := getGitOpsExportClient(p.Config(), &models.GitOpsExport{GitInstallationID: req.GitInstallationID})
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if _, _, err := client.Repositories.GetBranch(context.Background(), req.GitRepoOwner, req.GitRepoName, req.GitBranch, true); err != nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("branch %s of repository %s/%s cannot be read by the github app installation",
+				req.GitBranch, req.GitRepoOwner, req.GitRepoName),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,440 @@
+package stack
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
+)
+
+// StackPutSpecHandler replaces the app resources, source configs and env groups of a stack, and
+// deploys them as a single new revision of the stack
+type StackPutSpecHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewStackPutSpecHandler(
+	config *config.Config,
+	reader shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *StackPutSpecHandler {
+	return &StackPutSpecHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, reader, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (p *StackPutSpecHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+	stack, _ := r.Context().Value(types.StackScope).(*models.Stack)
+
+	req := &types.PutStackSpecRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, req); !ok {
+		return
+	}
+
+	if len(stack.Revisions) == 0 {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("no stack revisions exist"), http.StatusBadRequest,
+		))
+		return
+	}
+
+	if err := validateStackSpec(req); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	latestRevision, err := p.Repo().Stack().ReadStackRevisionByNumber(stack.ID, stack.Revisions[0].RevisionNumber)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sourceConfigs, err := getSourceConfigModels(req.SourceConfigs)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envGroups, err := getEnvGroupModels(req.EnvGroups, proj.ID, cluster.ID, namespace)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	k8sAgent, err := p.GetAgent(r, cluster, "")
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	storage := envgroup.NewSecretStorage(p.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	// the applications of the stack, before and after the update, whose links to the env groups
+	// of the stack are managed by the stack
+	stackApps := make(map[string]bool)
+
+	for _, resource := range latestRevision.Resources {
+		stackApps[resource.Name] = true
+	}
+
+	for _, resource := range req.AppResources {
+		stackApps[resource.Name] = true
+	}
+
+	// the env groups are applied before the revision is written, so that the revision records
+	// the versions of the env groups which it deployed
+	envGroupDeployErrors := make([]string, 0)
+	appConfigMaps := make(map[string][]*v1.ConfigMap)
+
+	for i, envGroupReq := range req.EnvGroups {
+		cm, err := envgroup.CreateStoredEnvGroup(k8sAgent, storage, types.ConfigMapInput{
+			Name:            envGroupReq.Name,
+			Namespace:       namespace,
+			Variables:       envGroupReq.Variables,
+			SecretVariables: envGroupReq.SecretVariables,
+		})
+
+		if err == nil {
+			cm, err = linkStackApplications(k8sAgent, cm, envGroupReq.LinkedApplications, stackApps)
+		}
+
+		var eg *types.EnvGroup
+
+		if err == nil {
+			eg, err = envgroup.ToEnvGroup(cm)
+		}
+
+		if err != nil {
+			envGroupDeployErrors = append(envGroupDeployErrors, fmt.Sprintf("error creating env group %s: %s", envGroupReq.Name, err.Error()))
+			continue
+		}
+
+		envGroups[i].EnvGroupVersion = eg.Version
+
+		for _, appName := range envGroupReq.LinkedApplications {
+			appConfigMaps[appName] = append(appConfigMaps[appName], cm)
+		}
+	}
+
+	// env groups which were removed from the stack are deleted
+	for _, envGroup := range latestRevision.EnvGroups {
+		if !hasEnvGroup(req.EnvGroups, envGroup.Name) {
+			if err := envgroup.DeleteStoredEnvGroup(k8sAgent, storage, envGroup.Name, envGroup.Namespace); err != nil {
+				envGroupDeployErrors = append(envGroupDeployErrors, fmt.Sprintf("error deleting env group %s: %s", envGroup.Name, err.Error()))
+			}
+		}
+	}
+
+	for _, appResource := range req.AppResources {
+		if appResource.Values == nil {
+			appResource.Values = make(map[string]interface{})
+		}
+
+		if err := setSyncedEnvGroups(appResource.Values, appConfigMaps[appResource.Name]); err != nil {
+			envGroupDeployErrors = append(envGroupDeployErrors, err.Error())
+		}
+	}
+
+	// the resources are created from the values with the synced env groups, which are stored
+	// with the resources
+	resources, err := getResourceModels(req.AppResources, sourceConfigs, p.Config().ServerConf.DefaultApplicationHelmRepoURL)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	revision, err := p.Repo().Stack().AppendNewRevision(&models.StackRevision{
+		StackID:        stack.ID,
+		RevisionNumber: latestRevision.RevisionNumber + 1,
+		Status:         string(types.StackRevisionStatusDeploying),
+		SourceConfigs:  sourceConfigs,
+		Resources:      resources,
+		EnvGroups:      envGroups,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	export, err := readGitOpsExport(p.Repo(), stack.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if len(envGroupDeployErrors) > 0 {
+		revision.Status = string(types.StackRevisionStatusFailed)
+		revision.Reason = "EnvGroupDeployErr"
+		revision.Message = strings.Join(envGroupDeployErrors, " , ")
+	} else if export != nil {
+		// the env groups of an exported stack are still applied by Porter, so that their
+		// secrets aren't committed to git
+		registries, err := p.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		helmAgent, err := p.GetHelmAgent(r, cluster, namespace)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		err = exportStackRevision(&exportStackRevisionOpts{
+			config:     p.Config(),
+			helmAgent:  helmAgent,
+			cluster:    cluster,
+			namespace:  namespace,
+			registries: registries,
+			stack:      stack,
+			revision:   revision,
+			export:     export,
+		})
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	} else {
+		registries, err := p.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		helmAgent, err := p.GetHelmAgent(r, cluster, "")
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		existingResources := make(map[string]bool)
+
+		for _, resource := range latestRevision.Resources {
+			existingResources[resource.Name] = true
+		}
+
+		deployErrs := make([]string, 0)
+
+		for i, appResource := range req.AppResources {
+			opts := &applyAppResourceOpts{
+				config:        p.Config(),
+				projectID:     proj.ID,
+				namespace:     namespace,
+				cluster:       cluster,
+				registries:    registries,
+				user:          user,
+				helmAgent:     helmAgent,
+				request:       appResource,
+				stackName:     stack.Name,
+				stackRevision: revision.RevisionNumber,
+			}
+
+			resource := &revision.Resources[i]
+
+			if existingResources[appResource.Name] {
+				rel, err := upgradeAppResource(opts)
+
+				if err != nil {
+					deployErrs = append(deployErrs, err.Error())
+					continue
+				}
+
+				resource.HelmRevisionID = uint(rel.Version)
+			} else {
+				rel, err := applyAppResource(opts)
+
+				if err != nil {
+					deployErrs = append(deployErrs, err.Error())
+					continue
+				}
+
+				resource.HelmRevisionID = uint(rel.Version)
+
+				_, err = release.CreateAppReleaseFromHelmRelease(p.Config(), proj.ID, cluster.ID, resource.ID, rel)
+
+				if err != nil {
+					deployErrs = append(deployErrs, fmt.Sprintf("the resource %s/%s could not be saved right now", namespace, appResource.Name))
+				}
+			}
+
+			// the helm revision is stored so that rolling back the stack rolls back the resource
+			// to this helm revision
+			if _, err := p.Repo().Stack().UpdateStackResource(resource); err != nil {
+				p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+
+		// app resources which were removed from the stack are deleted
+		for _, resource := range latestRevision.Resources {
+			if hasAppResource(req.AppResources, resource.Name) {
+				continue
+			}
+
+			if err := deleteAppResource(&deleteAppResourceOpts{
+				helmAgent: helmAgent,
+				name:      resource.Name,
+			}); err != nil {
+				deployErrs = append(deployErrs, err.Error())
+			}
+		}
+
+		if len(deployErrs) > 0 {
+			revision.Status = string(types.StackRevisionStatusFailed)
+			revision.Reason = "DeployError"
+			revision.Message = strings.Join(deployErrs, " , ")
+		} else {
+			revision.Status = string(types.StackRevisionStatusDeployed)
+			revision.Reason = "SpecUpdate"
+			revision.Message = "The stack was updated from its spec"
+		}
+	}
+
+	revision, err = p.Repo().Stack().UpdateStackRevision(revision)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// read the stack again to get the latest revision info
+	stack, err = p.Repo().Stack().ReadStackByStringID(proj.ID, stack.UID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, stack.ToStackType())
+}
+
+// validateStackSpec returns an error if the names of the app resources or env groups of a spec
+// are not unique, or if they reference source configs or applications which aren't in the spec
+func validateStackSpec(req *types.PutStackSpecRequest) error {
+	sourceConfigNames := make(map[string]bool)
+
+	for _, sourceConfig := range req.SourceConfigs {
+		sourceConfigNames[sourceConfig.Name] = true
+	}
+
+	nameValidator := make(map[string]bool)
+
+	for _, res := range req.AppResources {
+		if nameValidator[res.Name] {
+			return fmt.Errorf("duplicate app resource name: %s", res.Name)
+		}
+
+		if !sourceConfigNames[res.SourceConfigName] {
+			return fmt.Errorf("source config %s does not exist in source config list", res.SourceConfigName)
+		}
+
+		nameValidator[res.Name] = true
+	}
+
+	envGroupNameValidator := make(map[string]bool)
+
+	for _, eg := range req.EnvGroups {
+		if envGroupNameValidator[eg.Name] {
+			return fmt.Errorf("duplicate env group name: %s", eg.Name)
+		}
+
+		envGroupNameValidator[eg.Name] = true
+
+		for _, appName := range eg.LinkedApplications {
+			if !nameValidator[appName] {
+				return fmt.Errorf("env group %s is linked to %s, which is not an app resource of the stack", eg.Name, appName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// linkStackApplications links the new version of an env group to the applications of the spec,
+// and unlinks the applications of the stack which are no longer linked in the spec. Links to
+// applications outside of the stack are kept.
+func linkStackApplications(
+	agent *kubernetes.Agent,
+	cm *v1.ConfigMap,
+	linkedApps []string,
+	stackApps map[string]bool,
+) (*v1.ConfigMap, error) {
+	linked := make(map[string]bool)
+
+	for _, appName := range linkedApps {
+		linked[appName] = true
+	}
+
+	eg, err := envgroup.ToEnvGroup(cm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, appName := range eg.Applications {
+		if stackApps[appName] && !linked[appName] {
+			if cm, err = agent.RemoveApplicationFromVersionedConfigMap(cm, appName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, appName := range linkedApps {
+		if cm, err = agent.AddApplicationToVersionedConfigMap(cm, appName); err != nil {
+			return nil, err
+		}
+	}
+
+	return cm, nil
+}
+
+func hasEnvGroup(envGroups []*types.CreateStackEnvGroupRequest, name string) bool {
+	for _, eg := range envGroups {
+		if eg.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAppResource(appResources []*types.CreateStackAppResourceRequest, name string) bool {
+	for _, res := range appResources {
+		if res.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
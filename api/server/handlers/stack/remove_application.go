@@ -35,6 +35,11 @@ func (p *StackRemoveApplicationHandler) ServeHTTP(w http.ResponseWriter, r *http
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
 
+	if apiErr := checkNotExported(p.Repo(), stack); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	appResourceName, reqErr := requestutils.GetURLParamString(r, "app_resource_name")
 
 	if reqErr != nil {
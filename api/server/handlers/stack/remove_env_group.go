@@ -111,7 +111,9 @@ func (p *StackRemoveEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = envgroup.DeleteEnvGroup(k8sAgent, envGroupName, envGroupNS)
+	storage := envgroup.NewSecretStorage(p.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	err = envgroup.DeleteStoredEnvGroup(k8sAgent, storage, envGroupName, envGroupNS)
 
 	if err == nil {
 		revision.Status = string(types.StackRevisionStatusDeployed)
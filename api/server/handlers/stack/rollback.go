@@ -1,16 +1,20 @@
 package stack
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/stacks"
 	"gorm.io/gorm"
@@ -42,8 +46,7 @@ func (p *StackRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
-	// namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
 	stack, _ := r.Context().Value(types.StackScope).(*models.Stack)
 
 	req := &types.StackRollbackRequest{}
@@ -52,6 +55,11 @@ func (p *StackRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if apiErr := checkNotExported(p.Repo(), stack); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
 	// read the target revision
 	revision, err := p.Repo().Stack().ReadStackRevisionByNumber(stack.ID, req.TargetRevision)
 
@@ -108,18 +116,89 @@ func (p *StackRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	// apply to cluster
 	rollbackErrors := make([]string, 0)
 
-	for _, resource := range revision.Resources {
-		err := rollbackAppResource(&rollbackAppResourceOpts{
-			helmAgent:      helmAgent,
-			helmRevisionID: resource.HelmRevisionID,
-			name:           resource.Name,
-		})
+	latestResources := make(map[string]bool)
 
-		if err != nil {
+	for _, resource := range latestRevision.Resources {
+		latestResources[resource.Name] = true
+	}
+
+	targetResources := make(map[string]bool)
+
+	for i := range revision.Resources {
+		resource := &revision.Resources[i]
+		targetResources[resource.Name] = true
+
+		if latestResources[resource.Name] {
+			err := rollbackAppResource(&rollbackAppResourceOpts{
+				helmAgent:      helmAgent,
+				helmRevisionID: resource.HelmRevisionID,
+				name:           resource.Name,
+			})
+
+			if err != nil {
+				rollbackErrors = append(rollbackErrors, err.Error())
+			}
+
+			continue
+		}
+
+		// the resource was deleted since the target revision, along with its helm history, so
+		// it's installed again with the values it was deployed with
+		if err := p.reinstallAppResource(helmAgent, proj, cluster, namespace, stack, revision, resource); err != nil {
 			rollbackErrors = append(rollbackErrors, err.Error())
 		}
 	}
 
+	// resources which were added since the target revision are deleted
+	for _, resource := range latestRevision.Resources {
+		if targetResources[resource.Name] {
+			continue
+		}
+
+		if err := deleteAppResource(&deleteAppResourceOpts{
+			helmAgent: helmAgent,
+			name:      resource.Name,
+		}); err != nil {
+			rollbackErrors = append(rollbackErrors, err.Error())
+		}
+	}
+
+	// the env groups of the stack are rolled back to the versions of the target revision. The
+	// rolled back resources keep reading the versions they were deployed with.
+	if len(revision.EnvGroups) > 0 {
+		k8sAgent, err := p.GetAgent(r, cluster, "")
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		storage := envgroup.NewSecretStorage(p.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
+		for _, envGroup := range revision.EnvGroups {
+			current, err := envgroup.GetEnvGroup(k8sAgent, envGroup.Name, envGroup.Namespace, 0)
+
+			if err != nil {
+				rollbackErrors = append(rollbackErrors, fmt.Sprintf("error reading env group %s: %s", envGroup.Name, err.Error()))
+				continue
+			}
+
+			if current.Version == envGroup.EnvGroupVersion {
+				continue
+			}
+
+			cm, err := envgroup.RollbackEnvGroup(k8sAgent, envGroup.Name, envGroup.Namespace, envGroup.EnvGroupVersion, nil)
+
+			if err == nil {
+				err = storage.WriteSecrets(k8sAgent, cm)
+			}
+
+			if err != nil {
+				rollbackErrors = append(rollbackErrors, fmt.Sprintf("error rolling back env group %s: %s", envGroup.Name, err.Error()))
+			}
+		}
+	}
+
 	if len(rollbackErrors) > 0 {
 		revision.Status = string(types.StackRevisionStatusFailed)
 		revision.Reason = "RollbackError"
@@ -147,3 +226,63 @@ func (p *StackRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 
 	p.WriteResult(w, r, stack.ToStackType())
 }
+
+// reinstallAppResource installs a resource of the target revision again from its stored values,
+// and records the helm revision it was installed with
+func (p *StackRollbackHandler) reinstallAppResource(
+	helmAgent *helm.Agent,
+	proj *models.Project,
+	cluster *models.Cluster,
+	namespace string,
+	stack *models.Stack,
+	revision *models.StackRevision,
+	resource *models.StackResource,
+) error {
+	if len(resource.Values) == 0 {
+		return fmt.Errorf("the resource %s was deleted and its values were not stored, so it can't be restored", resource.Name)
+	}
+
+	values := make(map[string]interface{})
+
+	if err := json.Unmarshal(resource.Values, &values); err != nil {
+		return err
+	}
+
+	registries, err := p.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return err
+	}
+
+	rel, err := applyAppResource(&applyAppResourceOpts{
+		config:     p.Config(),
+		projectID:  proj.ID,
+		namespace:  namespace,
+		cluster:    cluster,
+		registries: registries,
+		helmAgent:  helmAgent,
+		request: &types.CreateStackAppResourceRequest{
+			TemplateRepoURL: resource.TemplateRepoURL,
+			TemplateName:    resource.TemplateName,
+			TemplateVersion: resource.TemplateVersion,
+			Values:          values,
+			Name:            resource.Name,
+		},
+		stackName:     stack.Name,
+		stackRevision: revision.RevisionNumber,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	resource.HelmRevisionID = uint(rel.Version)
+
+	if _, err := p.Repo().Stack().UpdateStackResource(resource); err != nil {
+		return err
+	}
+
+	_, err = release.CreateAppReleaseFromHelmRelease(p.Config(), proj.ID, cluster.ID, resource.ID, rel)
+
+	return err
+}
@@ -33,6 +33,7 @@ func NewStackPutSourceConfigHandler(
 }
 
 func (p *StackPutSourceConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
@@ -106,6 +107,48 @@ func (p *StackPutSourceConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	export, err := readGitOpsExport(p.Repo(), stack.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if export != nil {
+		err = exportStackRevision(&exportStackRevisionOpts{
+			config:     p.Config(),
+			helmAgent:  helmAgent,
+			cluster:    cluster,
+			namespace:  namespace,
+			registries: registries,
+			stack:      stack,
+			revision:   revision,
+			export:     export,
+		})
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		revision, err = p.Repo().Stack().UpdateStackRevision(revision)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		stack, err = p.Repo().Stack().ReadStackByStringID(proj.ID, stack.UID)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		p.WriteResult(w, r, stack.ToStackType())
+		return
+	}
+
 	for i, appResource := range clonedAppResources {
 		// get the corresponding source config tag
 		var imageTag string
@@ -126,7 +169,7 @@ func (p *StackPutSourceConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 			projectID:     proj.ID,
 			namespace:     namespace,
 			cluster:       cluster,
-			registries:    registries,
+			user:          user,
 			stackName:     stack.Name,
 			stackRevision: stack.Revisions[0].RevisionNumber,
 		})
@@ -0,0 +1,50 @@
+package status_page
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/statuspage"
+	"gorm.io/gorm"
+)
+
+// StatusPageGetHandler returns the status page configuration of a project
+type StatusPageGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewStatusPageGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *StatusPageGetHandler {
+	return &StatusPageGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *StatusPageGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	page, err := p.Repo().StatusPage().ReadStatusPageByProjectID(project.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("status page not found")))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sc := p.Config().ServerConf
+
+	p.WriteResult(w, r, page.ToStatusPageType(statuspage.GetURL(sc.ServerURL, sc.StatusPageDomain, page.Subdomain)))
+}
@@ -0,0 +1,96 @@
+package status_page
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/statuspage"
+	"gorm.io/gorm"
+)
+
+// PublicStatusPageGetHandler returns the current health of the releases on a status page. This
+// endpoint is unauthenticated, and is used by the page served under the status page domain.
+type PublicStatusPageGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewPublicStatusPageGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *PublicStatusPageGetHandler {
+	return &PublicStatusPageGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *PublicStatusPageGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	subdomain, reqErr := requestutils.GetURLParamString(r, types.URLParamStatusPageSubdomain)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	res, err := GetPublicStatusPage(p.Config(), subdomain)
+
+	if err != nil {
+		p.HandleAPIError(w, r, err)
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
+
+// RenderPublicStatusPage serves a status page as HTML for requests made to a subdomain of the
+// status page domain
+func RenderPublicStatusPage(config *config.Config, subdomain string, w http.ResponseWriter, r *http.Request) {
+	res, err := GetPublicStatusPage(config, subdomain)
+
+	if err != nil {
+		apierrors.HandleAPIError(config.Logger, config.Alerter, w, r, err, false)
+		http.Error(w, http.StatusText(err.GetStatusCode()), err.GetStatusCode())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+
+	if err := statuspage.Render(w, res); err != nil {
+		config.Logger.Error().Err(err).Msg("could not render status page")
+	}
+}
+
+// GetPublicStatusPage reads the status page with the given subdomain and computes the health of
+// its releases. Unknown subdomains are returned as not found.
+func GetPublicStatusPage(config *config.Config, subdomain string) (*types.PublicStatusPage, apierrors.RequestError) {
+	if config.ServerConf.StatusPageDomain == "" {
+		return nil, apierrors.NewErrNotFound(fmt.Errorf("status pages are not enabled"))
+	}
+
+	page, err := config.Repo.StatusPage().ReadStatusPageBySubdomain(subdomain)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrNotFound(fmt.Errorf("status page not found"))
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	res, err := statuspage.GetPublicStatusPage(config.Repo.ClusterIncident(), page, time.Now().UTC())
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,137 @@
+package status_page
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/statuspage"
+	"gorm.io/gorm"
+)
+
+// StatusPageUpdateHandler creates or replaces the status page of a project
+type StatusPageUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewStatusPageUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *StatusPageUpdateHandler {
+	return &StatusPageUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *StatusPageUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sc := p.Config().ServerConf
+
+	if sc.StatusPageDomain == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("status pages are not enabled on this instance"), http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	request := &types.UpdateStatusPageRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if err := statuspage.ValidateSubdomain(request.Subdomain); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	existing, err := p.Repo().StatusPage().ReadStatusPageBySubdomain(request.Subdomain)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if err == nil && existing.ProjectID != project.ID {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("subdomain %s is already in use", request.Subdomain), http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	releases := make([]models.StatusPageRelease, 0, len(request.Releases))
+
+	for _, rel := range request.Releases {
+		// releases can only be shown if they belong to a cluster in this project
+		if _, err := p.Repo().Cluster().ReadCluster(project.ID, rel.ClusterID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("cluster %d not found", rel.ClusterID), http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if _, err := p.Repo().Release().ReadRelease(rel.ClusterID, rel.ReleaseName, rel.Namespace); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("release %s/%s not found", rel.Namespace, rel.ReleaseName), http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		releases = append(releases, models.StatusPageRelease{
+			ClusterID:   rel.ClusterID,
+			Namespace:   rel.Namespace,
+			ReleaseName: rel.ReleaseName,
+			DisplayName: rel.DisplayName,
+		})
+	}
+
+	page, err := p.Repo().StatusPage().ReadStatusPageByProjectID(project.ID)
+	isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if isNotFound {
+		page, err = p.Repo().StatusPage().CreateStatusPage(&models.StatusPage{
+			ProjectID: project.ID,
+			Subdomain: request.Subdomain,
+			Title:     request.Title,
+			Releases:  releases,
+		})
+	} else {
+		page.Subdomain = request.Subdomain
+		page.Title = request.Title
+		page.Releases = releases
+
+		page, err = p.Repo().StatusPage().UpdateStatusPage(page)
+	}
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, page.ToStatusPageType(statuspage.GetURL(sc.ServerURL, sc.StatusPageDomain, page.Subdomain)))
+}
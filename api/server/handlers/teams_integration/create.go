@@ -0,0 +1,77 @@
+package teams_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type TeamsIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTeamsIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TeamsIntegrationCreateHandler {
+	return &TeamsIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *TeamsIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateTeamsIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// only Teams webhooks are accepted, so that notifications cannot be sent to arbitrary URLs
+	if !isTeamsWebhook(request.Webhook) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("webhook must be a Microsoft Teams incoming webhook or Power Automate workflow URL"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	teamsInt, err := p.Repo().TeamsIntegration().CreateTeamsIntegration(&integrations.TeamsIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Webhook:   []byte(request.Webhook),
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, teamsInt.ToTeamsIntegrationType())
+}
+
+func isTeamsWebhook(webhook string) bool {
+	webhookURL, err := url.Parse(webhook)
+
+	if err != nil || webhookURL.Scheme != "https" {
+		return false
+	}
+
+	host := webhookURL.Hostname()
+
+	return strings.HasSuffix(host, ".webhook.office.com") || strings.HasSuffix(host, ".logic.azure.com")
+}
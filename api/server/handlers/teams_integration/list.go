@@ -0,0 +1,44 @@
+package teams_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TeamsIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewTeamsIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *TeamsIntegrationListHandler {
+	return &TeamsIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *TeamsIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	teamsInts, err := p.Repo().TeamsIntegration().ListTeamsIntegrationsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListTeamsIntegrationsResponse, 0)
+
+	for _, teamsInt := range teamsInts {
+		res = append(res, teamsInt.ToTeamsIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type EmailPreferencesGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewEmailPreferencesGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *EmailPreferencesGetHandler {
+	return &EmailPreferencesGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (a *EmailPreferencesGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	pref, err := readEmailPreference(a.Repo(), user.ID)
+
+	if err != nil {
+		a.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	a.WriteResult(w, r, pref.ToEmailPreferencesType())
+}
+
+type EmailPreferencesUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewEmailPreferencesUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *EmailPreferencesUpdateHandler {
+	return &EmailPreferencesUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (a *EmailPreferencesUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.UpdateEmailPreferencesRequest{}
+
+	if ok := a.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	pref, err := readEmailPreference(a.Repo(), user.ID)
+
+	if err != nil {
+		a.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.ImmediateFailures != nil {
+		pref.ImmediateFailures = *request.ImmediateFailures
+	}
+
+	if request.DailyDigest != nil {
+		pref.DailyDigest = *request.DailyDigest
+	}
+
+	if pref.ID == 0 {
+		pref, err = a.Repo().EmailPreference().CreateEmailPreference(pref)
+	} else {
+		pref, err = a.Repo().EmailPreference().UpdateEmailPreference(pref)
+	}
+
+	if err != nil {
+		a.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	a.WriteResult(w, r, pref.ToEmailPreferencesType())
+}
+
+// readEmailPreference returns the stored preference of a user, or the default preference if
+// the user has not changed their preferences
+func readEmailPreference(repo repository.Repository, userID uint) (*models.EmailPreference, error) {
+	pref, err := repo.EmailPreference().ReadEmailPreference(userID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultEmailPreference(userID), nil
+	}
+
+	return pref, err
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/gorm"
+)
+
+// ExportInstanceHandler writes an encrypted export of the instance database, which can be
+// imported into another instance with "migrate import". Only the admin user of a self-hosted
+// instance can export the instance.
+type ExportInstanceHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewExportInstanceHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ExportInstanceHandler {
+	return &ExportInstanceHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ExportInstanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if adminEmail := c.Config().ServerConf.AdminEmail; adminEmail == "" || adminEmail != user.Email {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not the admin user of the instance", user.ID),
+		))
+		return
+	}
+
+	request := &types.ExportInstanceRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(c.Config().DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	export, err := gorm.ExportDatabase(c.Config().DB, &key)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	data, err := gorm.EncodeExport(export, request.Passphrase)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.Config().Logger.Info().Msgf("instance exported by user %d", user.ID)
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=%q", fmt.Sprintf("porter-export-%s.bin", export.CreatedAt.UTC().Format("20060102150405")),
+	))
+	w.Write(data)
+}
@@ -0,0 +1,172 @@
+package user
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const defaultSearchLimit = 20
+
+type SearchHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewSearchHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SearchHandler {
+	return &SearchHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP searches the projects of the user, and the releases, deployments and custom domains
+// in them
+func (c *SearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.SearchRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	limit := request.Limit
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	projects, err := c.Repo().Project().ListProjectsByUserID(user.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.SearchResponse, 0)
+	projectIDs := make([]uint, 0, len(projects))
+
+	for _, project := range projects {
+		projectIDs = append(projectIDs, project.ID)
+
+		if matches := searchMatches(request.Query, "name", project.Name); len(matches) > 0 && len(res) < limit {
+			res = append(res, &types.SearchResult{
+				Kind:      types.SearchResultProject,
+				ID:        project.ID,
+				ProjectID: project.ID,
+				Name:      project.Name,
+				Matches:   matches,
+			})
+		}
+	}
+
+	// the limit applies to the merged results, so each kind only fetches the results which
+	// still fit after the kinds before it
+	if len(res) < limit {
+		releases, err := c.Repo().Release().SearchReleases(projectIDs, request.Query, limit-len(res))
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		for _, release := range releases {
+			gitRepo := ""
+
+			if release.GitActionConfig != nil {
+				gitRepo = release.GitActionConfig.GitRepo
+			}
+
+			res = append(res, &types.SearchResult{
+				Kind:      types.SearchResultRelease,
+				ID:        release.ID,
+				ProjectID: release.ProjectID,
+				ClusterID: release.ClusterID,
+				Name:      release.Name,
+				Namespace: release.Namespace,
+				Matches: searchMatches(
+					request.Query,
+					"name", release.Name,
+					"namespace", release.Namespace,
+					"git_repo", gitRepo,
+				),
+			})
+		}
+	}
+
+	if len(res) < limit {
+		deployments, err := c.Repo().Environment().SearchDeployments(projectIDs, request.Query, limit-len(res))
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		for _, depl := range deployments {
+			res = append(res, &types.SearchResult{
+				Kind:      types.SearchResultDeployment,
+				ID:        depl.ID,
+				ProjectID: depl.ProjectID,
+				ClusterID: depl.ClusterID,
+				Name:      depl.PRName,
+				Namespace: depl.Namespace,
+				Matches: searchMatches(
+					request.Query,
+					"namespace", depl.Namespace,
+					"repo_name", depl.RepoName,
+					"commit_sha", depl.CommitSHA,
+					"pr_name", depl.PRName,
+					"subdomain", depl.Subdomain,
+				),
+			})
+		}
+	}
+
+	if len(res) < limit {
+		domains, err := c.Repo().CustomDomainDNSRecord().SearchCustomDomains(projectIDs, request.Query, limit-len(res))
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		for _, domain := range domains {
+			res = append(res, &types.SearchResult{
+				Kind:        types.SearchResultDomain,
+				ID:          domain.ID,
+				ProjectID:   domain.ProjectID,
+				ClusterID:   domain.ClusterID,
+				Name:        domain.Host,
+				Namespace:   domain.Namespace,
+				ReleaseName: domain.ReleaseName,
+				Matches:     searchMatches(request.Query, "host", domain.Host),
+			})
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// searchMatches returns the names of the fields whose values contain the query, ignoring
+// case. Fields are passed as name and value pairs.
+func searchMatches(query string, fields ...string) []string {
+	query = strings.ToLower(query)
+	matches := make([]string, 0)
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		if strings.Contains(strings.ToLower(fields[i+1]), query) {
+			matches = append(matches, fields[i])
+		}
+	}
+
+	return matches
+}
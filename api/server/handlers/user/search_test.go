@@ -0,0 +1,145 @@
+package user_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// initSearchResults creates two projects of the user named web, with a release and a deployment
+// named web in the first project
+func initSearchResults(t *testing.T, config *config.Config, authUser *models.User) {
+	for i := 1; i <= 2; i++ {
+		project, err := config.Repo.Project().CreateProject(&models.Project{
+			Name: fmt.Sprintf("web-%d", i),
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = config.Repo.Project().CreateProjectRole(project, &models.Role{
+			Role: types.Role{
+				UserID:    authUser.ID,
+				ProjectID: project.ID,
+				Kind:      types.RoleAdmin,
+			},
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := config.Repo.Release().CreateRelease(&models.Release{
+		Name:      "web",
+		Namespace: "default",
+		ProjectID: 1,
+		ClusterID: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := config.Repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: 1,
+		ClusterID: 1,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1-web",
+		PRName:        "Add checkout",
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func search(t *testing.T, config *config.Config, authUser *models.User, query string) types.SearchResponse {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/search?"+query, nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+
+	handler := user.NewSearchHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	res := make(types.SearchResponse, 0)
+
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestSearchSuccessful(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	initSearchResults(t, config, authUser)
+
+	res := search(t, config, authUser, "q=web")
+
+	expected := []types.SearchResultKind{
+		types.SearchResultProject,
+		types.SearchResultProject,
+		types.SearchResultRelease,
+		types.SearchResultDeployment,
+	}
+
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(res))
+	}
+
+	for i, kind := range expected {
+		if res[i].Kind != kind {
+			t.Errorf("result %d: expected kind %s, got %s", i, kind, res[i].Kind)
+		}
+	}
+}
+
+func TestSearchLimitsMergedResults(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	initSearchResults(t, config, authUser)
+
+	// the limit applies to every kind together, so the deployment doesn't fit after the
+	// projects and the release
+	res := search(t, config, authUser, "q=web&limit=3")
+
+	if len(res) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(res))
+	}
+
+	if res[2].Kind != types.SearchResultRelease {
+		t.Errorf("expected the last result to be the release, got %s", res[2].Kind)
+	}
+
+	res = search(t, config, authUser, "q=web&limit=1")
+
+	if len(res) != 1 || res[0].Kind != types.SearchResultProject {
+		t.Errorf("expected a single project, got %d results", len(res))
+	}
+}
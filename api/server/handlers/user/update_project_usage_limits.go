@@ -0,0 +1,106 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// UpdateProjectUsageLimitsHandler sets the usage limits of a project, which are enforced when
+// usage tracking is enabled. Only the admin user of a self-hosted instance can set the limits
+// of a project, whether or not they are a member of the project.
+type UpdateProjectUsageLimitsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateProjectUsageLimitsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateProjectUsageLimitsHandler {
+	return &UpdateProjectUsageLimitsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateProjectUsageLimitsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if adminEmail := c.Config().ServerConf.AdminEmail; adminEmail == "" || adminEmail != user.Email {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not the admin user of the instance", user.ID),
+		))
+		return
+	}
+
+	projID, reqErr := requestutils.GetURLParamUint(r, types.URLParamProjectID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateProjectUsageLimitsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if _, err := c.Repo().Project().ReadProject(projID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("project %d not found", projID),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	limits, err := c.Repo().ProjectUsage().ReadProjectUsage(projID)
+	isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNotFound {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if isNotFound {
+		limits = &models.ProjectUsage{
+			ProjectID: projID,
+		}
+	}
+
+	limits.ResourceCPU = request.ResourceCPU
+	limits.ResourceMemory = request.ResourceMemory
+	limits.Clusters = request.Clusters
+	limits.Users = request.Users
+	limits.Apps = request.Apps
+	limits.BuildMinutes = request.BuildMinutes
+	limits.PreviewEnvironmentHours = request.PreviewEnvironmentHours
+
+	if isNotFound {
+		limits, err = c.Repo().ProjectUsage().CreateProjectUsage(limits)
+	} else {
+		limits, err = c.Repo().ProjectUsage().UpdateProjectUsage(limits)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.Config().Logger.Info().Msgf("usage limits of project %d updated by user %d", projID, user.ID)
+
+	c.WriteResult(w, r, limits.ToProjectUsageType())
+}
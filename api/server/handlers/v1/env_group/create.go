@@ -13,6 +13,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	releaseHandler "github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -56,6 +57,8 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, 0)
 
 	// if the environment group exists and has MetaVersion=1, throw an error
@@ -77,7 +80,7 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+	configMap, err := envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
 		Name:            request.Name,
 		Namespace:       namespace,
 		Variables:       request.Variables,
@@ -149,12 +152,6 @@ func rolloutApplications(
 	configMap *v1.ConfigMap,
 	releases []*release.Release,
 ) []error {
-	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
-
-	if err != nil {
-		return []error{err}
-	}
-
 	// construct the synced env section that should be written
 	newSection := &SyncedEnvSection{
 		Name:    envGroup.Name,
@@ -177,8 +174,7 @@ func rolloutApplications(
 	mu := &sync.Mutex{}
 	errors := make([]error, 0)
 
-	for i, rel := range releases {
-		index := i
+	for _, rel := range releases {
 		release := rel
 		wg.Add(1)
 
@@ -199,19 +195,20 @@ func rolloutApplications(
 				newConfig["paused"] = true
 			}
 
-			conf := &helm.UpgradeReleaseConfig{
-				Name:       releases[index].Name,
-				Cluster:    cluster,
-				Repo:       config.Repo,
-				Registries: registries,
-				Values:     newConfig,
-			}
-
-			_, err = helmAgent.UpgradeReleaseByValues(conf, config.DOConf, config.ServerConf.DisablePullSecretsInjection)
-
-			if err != nil {
+			// rollouts of an env group are not made by a user, so they cannot override freeze
+			// windows. A rollout which waits for an approval, or in the deploy queue of the
+			// release, runs later, so it is not an error.
+			_, apiErr := releaseHandler.Deploy(config, &releaseHandler.DeployOpts{
+				Cluster:     cluster,
+				HelmAgent:   helmAgent,
+				HelmRelease: release,
+				Source:      types.DeploySourceEnvGroupRollout,
+				Values:      newConfig,
+			})
+
+			if apiErr != nil {
 				mu.Lock()
-				errors = append(errors, err)
+				errors = append(errors, apiErr)
 				mu.Unlock()
 				return
 			}
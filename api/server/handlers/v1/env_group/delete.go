@@ -50,6 +50,8 @@ func (c *DeleteEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	storage := envgroup.NewSecretStorage(c.Config().CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	// get the env group: if it's MetaVersion=2, return an error
 	envGroup, err := envgroup.GetEnvGroup(agent, name, namespace, 0)
 
@@ -71,7 +73,7 @@ func (c *DeleteEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			))
 
 			return
-		} else if err = envgroup.DeleteEnvGroup(agent, name, namespace); err != nil {
+		} else if err = envgroup.DeleteStoredEnvGroup(agent, storage, name, namespace); err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
@@ -80,6 +80,13 @@ func (c *RegistryListImagesHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 		res.Images = append(res.Images, imgs...)
 	} else {
+		if request.Num < 0 || request.Num > 1000 {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("num should be between 1 and 1000"), http.StatusBadRequest,
+			))
+			return
+		}
+
 		imgs, err := regAPI.ListImages(repoName, c.Repo(), c.Config().DOConf)
 
 		if err != nil {
@@ -87,6 +94,19 @@ func (c *RegistryListImagesHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 			return
 		}
 
+		imgs, res.NextPage = registry.PaginateImages(imgs, request.Page, uint(request.Num))
+
+		// manifests are only read for paginated requests, to limit the number of requests
+		// made to the registry
+		if request.Num != 0 {
+			err = regAPI.PopulateImageMetadata(repoName, imgs, c.Repo())
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+
 		res.Images = append(res.Images, imgs...)
 	}
 
@@ -0,0 +1,102 @@
+package release_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/v1/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// upgradeRelease creates a release, runs setup, and upgrades the release to a new image with
+// a request to the upgrade endpoint
+func upgradeRelease(
+	t *testing.T,
+	setup func(config *config.Config, user *models.User, cluster *models.Cluster),
+) (*httptest.ResponseRecorder, *config.Config, *models.Cluster, *helm.Agent) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	cluster := apitest.CreateTestCluster(t, config, 1)
+
+	helmAgent, helmRelease := apitest.CreateTestRelease(t, config, "default", "test-release", map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "porter/app",
+			"tag":        "v1",
+		},
+	})
+
+	setup(config, user, cluster)
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPatch),
+		"/api/v1/projects/1/clusters/1/namespaces/default/releases/test-release/0",
+		&types.V1UpgradeReleaseRequest{
+			Values: map[string]interface{}{
+				"image": map[string]interface{}{
+					"repository": "porter/app",
+					"tag":        "v2",
+				},
+			},
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithCluster(t, req, cluster)
+	req = apitest.WithRelease(t, req, helmRelease)
+	req = apitest.WithHelmAgent(t, req, helmAgent)
+
+	handler := release.NewUpgradeReleaseHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr, config, cluster, helmAgent
+}
+
+func TestUpgradeReleaseRequiresApproval(t *testing.T) {
+	rr, config, cluster, helmAgent := upgradeRelease(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestApprovalPolicy(t, config, cluster, user.ID)
+	})
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+
+	apitest.AssertApprovalRequested(t, config, cluster, "default", "test-release")
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
+
+func TestUpgradeReleaseBlockedByFreezeWindow(t *testing.T) {
+	rr, _, _, helmAgent := upgradeRelease(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestFreezeWindow(t, config, cluster)
+	})
+
+	if rr.Code != http.StatusLocked {
+		t.Fatalf("expected status %d, got %d", http.StatusLocked, rr.Code)
+	}
+
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
+
+func TestUpgradeReleaseQueued(t *testing.T) {
+	rr, config, cluster, helmAgent := upgradeRelease(t, func(config *config.Config, user *models.User, cluster *models.Cluster) {
+		apitest.CreateTestRunningDeploy(t, config, cluster, "default", "test-release")
+	})
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+
+	apitest.AssertDeployQueued(t, config, cluster, "default", "test-release")
+	apitest.AssertReleaseNotUpgraded(t, helmAgent, "test-release")
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/tracing"
 	"gorm.io/gorm"
 )
 
@@ -157,6 +159,10 @@ func (c *GithubIncomingWebhookHandler) processPullRequestEvent(event *github.Pul
 				"error creating new deployment: %w", webhookID, owner, repo, env.ID, event.GetPullRequest().GetNumber(), err)
 		}
 
+		if cluster, err := c.Repo().Cluster().ReadCluster(env.ProjectID, env.ClusterID); err == nil {
+			commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, types.DeploymentStatusCreating, "")
+		}
+
 		_, err := client.Actions.CreateWorkflowDispatchEventByFileName(
 			r.Context(), owner, repo, fmt.Sprintf("porter_%s_env.yml", env.Name),
 			github.CreateWorkflowDispatchEventRequest{
@@ -262,20 +268,20 @@ func (c *GithubIncomingWebhookHandler) processPullRequestEvent(event *github.Pul
 					event.GetPullRequest().GetNumber(), chanErr)
 			}
 		} else if event.GetChanges() != nil {
-			shouldUpdate := false
+			fields := make([]string, 0)
 
 			if event.GetChanges().GetTitle() != nil && event.GetPullRequest().GetTitle() != depl.PRName {
 				depl.PRName = event.GetPullRequest().GetTitle()
-				shouldUpdate = true
+				fields = append(fields, "PRName")
 			}
 
 			if event.GetChanges().GetBase() != nil && event.GetChanges().GetBase().GetRef() != nil && event.GetPullRequest().GetBase().GetRef() != depl.PRBranchInto {
 				depl.PRBranchInto = event.GetPullRequest().GetBase().GetRef()
-				shouldUpdate = true
+				fields = append(fields, "PRBranchInto")
 			}
 
-			if shouldUpdate {
-				_, err := c.Repo().Environment().UpdateDeployment(depl)
+			if len(fields) > 0 {
+				_, err := c.Repo().Environment().UpdateDeploymentFields(depl, fields...)
 
 				if err != nil {
 					return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, prNumber: %d] "+
@@ -340,6 +346,8 @@ func (c *GithubIncomingWebhookHandler) deleteDeployment(
 			env.GitRepoOwner, env.GitRepoName, env.ID, depl.ID, err)
 	}
 
+	commonutils.NotifyPreviewDeployment(c.Config(), cluster, depl, types.DeploymentStatusInactive, "")
+
 	return nil
 }
 
@@ -472,7 +480,7 @@ func getGithubClientFromEnvironment(config *config.Config, env *models.Environme
 
 	// authenticate as github app installation
 	itr, err := ghinstallation.NewKeyFromFile(
-		http.DefaultTransport,
+		tracing.NewTransport(http.DefaultTransport),
 		int64(ghAppId),
 		int64(env.GitInstallationID),
 		config.ServerConf.GithubAppSecretPath,
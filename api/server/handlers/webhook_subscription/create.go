@@ -0,0 +1,74 @@
+package webhook_subscription
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+type WebhookSubscriptionCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWebhookSubscriptionCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WebhookSubscriptionCreateHandler {
+	return &WebhookSubscriptionCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WebhookSubscriptionCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateWebhookSubscriptionRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// deliveries are sent from inside the network of Porter, so URLs which resolve to
+	// internal addresses are rejected
+	if err := netguard.ValidateURL(r.Context(), request.URL); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	secret, err := encryption.GenerateRandomBytes(32)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sub := &models.WebhookSubscription{
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		URL:       request.URL,
+		Secret:    []byte(secret),
+	}
+
+	sub.SetEvents(request.Events)
+
+	sub, err = p.Repo().WebhookSubscription().CreateWebhookSubscription(sub)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.CreateWebhookSubscriptionResponse{
+		WebhookSubscription: sub.ToWebhookSubscriptionType(),
+		Secret:              secret,
+	})
+}
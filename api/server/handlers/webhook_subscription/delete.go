@@ -0,0 +1,40 @@
+package webhook_subscription
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type WebhookSubscriptionDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewWebhookSubscriptionDeleteHandler(
+	config *config.Config,
+) *WebhookSubscriptionDeleteHandler {
+	return &WebhookSubscriptionDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *WebhookSubscriptionDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sub, ok := readWebhookSubscription(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	if err := p.Repo().WebhookSubscription().DeleteWebhookSubscription(sub); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,44 @@
+package webhook_subscription
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// readWebhookSubscription reads the subscription in the URL params of a request, and writes an
+// error and returns false if it cannot be read
+func readWebhookSubscription(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+) (*models.WebhookSubscription, bool) {
+	subID, reqErr := requestutils.GetURLParamUint(r, types.URLParamWebhookSubscriptionID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	sub, err := p.Repo().WebhookSubscription().ReadWebhookSubscription(projectID, subID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("webhook subscription not found")))
+			return nil, false
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return sub, true
+}
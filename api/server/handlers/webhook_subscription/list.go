@@ -0,0 +1,44 @@
+package webhook_subscription
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type WebhookSubscriptionListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewWebhookSubscriptionListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *WebhookSubscriptionListHandler {
+	return &WebhookSubscriptionListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *WebhookSubscriptionListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	subs, err := p.Repo().WebhookSubscription().ListWebhookSubscriptionsByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWebhookSubscriptionsResponse, 0)
+
+	for _, sub := range subs {
+		res = append(res, sub.ToWebhookSubscriptionType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,72 @@
+package webhook_subscription
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type WebhookDeliveryListHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWebhookDeliveryListHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WebhookDeliveryListHandler {
+	return &WebhookDeliveryListHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WebhookDeliveryListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sub, ok := readWebhookSubscription(p, w, r, project.ID)
+
+	if !ok {
+		return
+	}
+
+	request := &types.ListWebhookDeliveriesRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	opts := commonutils.GetListOptions(
+		&request.ListOptions,
+		repository.Filter{Field: "status", Values: request.Status},
+		repository.Filter{Field: "event", Values: request.Event},
+	)
+
+	deliveries, err := p.Repo().WebhookSubscription().ListWebhookDeliveries(project.ID, sub.ID, opts)
+
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidListOptions) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWebhookDeliveriesResponse, 0)
+
+	for _, delivery := range deliveries {
+		res = append(res, delivery.ToWebhookDeliveryType())
+	}
+
+	commonutils.SetNextCursor(w, opts)
+	p.WriteResult(w, r, res)
+}
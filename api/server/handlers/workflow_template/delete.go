@@ -0,0 +1,62 @@
+package workflow_template
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type WorkflowTemplateDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewWorkflowTemplateDeleteHandler(
+	config *config.Config,
+) *WorkflowTemplateDeleteHandler {
+	return &WorkflowTemplateDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *WorkflowTemplateDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if apiErr := checkAdmin(p.Config(), project, user); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	kind, reqErr := getKind(r)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	template, err := p.Repo().WorkflowTemplate().ReadWorkflowTemplate(project.ID, string(kind))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("workflow template not found")))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := p.Repo().WorkflowTemplate().DeleteWorkflowTemplate(template); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,54 @@
+package workflow_template
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+var errNotAdmin = errors.New("only project admins can manage workflow templates")
+
+// checkAdmin returns an error if the user is not an admin of the project
+func checkAdmin(config *config.Config, project *models.Project, user *models.User) apierrors.RequestError {
+	role, err := config.Repo.Project().ReadProjectRole(project.ID, user.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrForbidden(fmt.Errorf("user %d is not a member of project %d", user.ID, project.ID))
+		}
+
+		return apierrors.NewErrInternal(err)
+	}
+
+	if role.Kind != types.RoleAdmin {
+		return apierrors.NewErrPassThroughToClient(errNotAdmin, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// getKind returns the workflow template kind in the url of a request
+func getKind(r *http.Request) (types.WorkflowTemplateKind, apierrors.RequestError) {
+	kind, reqErr := requestutils.GetURLParamString(r, types.URLParamWorkflowTemplateKind)
+
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	switch types.WorkflowTemplateKind(kind) {
+	case types.WorkflowTemplateKindDeploy, types.WorkflowTemplateKindPreview:
+		return types.WorkflowTemplateKind(kind), nil
+	}
+
+	return "", apierrors.NewErrPassThroughToClient(
+		fmt.Errorf("invalid workflow template kind %s: must be one of deploy or preview", kind),
+		http.StatusBadRequest,
+	)
+}
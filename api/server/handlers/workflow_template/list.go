@@ -0,0 +1,51 @@
+package workflow_template
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type WorkflowTemplateListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewWorkflowTemplateListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *WorkflowTemplateListHandler {
+	return &WorkflowTemplateListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *WorkflowTemplateListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	templates, err := p.Repo().WorkflowTemplate().ListWorkflowTemplates(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWorkflowTemplatesResponse, 0, len(templates))
+
+	for _, template := range templates {
+		apiTemplate, err := template.ToWorkflowTemplateType()
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res = append(res, apiTemplate)
+	}
+
+	p.WriteResult(w, r, res)
+}
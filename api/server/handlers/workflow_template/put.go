@@ -0,0 +1,101 @@
+package workflow_template
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/actions"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type WorkflowTemplatePutHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWorkflowTemplatePutHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WorkflowTemplatePutHandler {
+	return &WorkflowTemplatePutHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WorkflowTemplatePutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if apiErr := checkAdmin(p.Config(), project, user); apiErr != nil {
+		p.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	kind, reqErr := getKind(r)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.PutWorkflowTemplateRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// the template is rendered before it's saved, so that a template which can't be rendered
+	// never reaches the workflows committed to repositories
+	_, err := actions.RenderWorkflowTemplate(kind, request.Spec, p.Config().ServerConf.ServerURL, project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	spec, err := json.Marshal(request.Spec)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	template, err := p.Repo().WorkflowTemplate().ReadWorkflowTemplate(project.ID, string(kind))
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err != nil {
+		template, err = p.Repo().WorkflowTemplate().CreateWorkflowTemplate(&models.WorkflowTemplate{
+			ProjectID: project.ID,
+			Kind:      string(kind),
+			Spec:      spec,
+		})
+	} else {
+		template.Spec = spec
+		template, err = p.Repo().WorkflowTemplate().UpdateWorkflowTemplate(template)
+	}
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := template.ToWorkflowTemplateType()
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,50 @@
+package workflow_template
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/actions"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WorkflowTemplateRenderHandler validates a workflow template and previews the workflow which
+// it renders, without saving the template
+type WorkflowTemplateRenderHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWorkflowTemplateRenderHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WorkflowTemplateRenderHandler {
+	return &WorkflowTemplateRenderHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WorkflowTemplateRenderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.RenderWorkflowTemplateRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	workflow, err := actions.RenderWorkflowTemplate(request.Kind, request.Spec, p.Config().ServerConf.ServerURL, project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	p.WriteResult(w, r, &types.RenderWorkflowTemplateResponse{
+		Workflow: string(workflow),
+	})
+}
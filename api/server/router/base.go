@@ -4,11 +4,14 @@ import (
 	"fmt"
 
 	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/handlers/cluster"
 	"github.com/porter-dev/porter/api/server/handlers/credentials"
 	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
 	"github.com/porter-dev/porter/api/server/handlers/healthcheck"
 	"github.com/porter-dev/porter/api/server/handlers/metadata"
+	"github.com/porter-dev/porter/api/server/handlers/namespace"
 	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/handlers/status_page"
 	"github.com/porter-dev/porter/api/server/handlers/user"
 	"github.com/porter-dev/porter/api/server/handlers/webhook"
 	"github.com/porter-dev/porter/api/server/shared"
@@ -342,6 +345,77 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// POST /api/webhooks/registry/{token} -> release.NewRegistryPushWebhookHandler
+	registryPushWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/webhooks/registry/{token}",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	registryPushWebhookHandler := release.NewRegistryPushWebhookHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: registryPushWebhookEndpoint,
+		Handler:  registryPushWebhookHandler,
+		Router:   r,
+	})
+
+	// POST /api/webhooks/doppler/{token} -> namespace.NewDopplerWebhookHandler
+	dopplerWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/webhooks/doppler/{token}",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	dopplerWebhookHandler := namespace.NewDopplerWebhookHandler(
+		config,
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: dopplerWebhookEndpoint,
+		Handler:  dopplerWebhookHandler,
+		Router:   r,
+	})
+
+	// GET /api/status_pages/{subdomain} -> status_page.NewPublicStatusPageGetHandler
+	publicStatusPageEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("/status_pages/{%s}", types.URLParamStatusPageSubdomain),
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	publicStatusPageHandler := status_page.NewPublicStatusPageGetHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: publicStatusPageEndpoint,
+		Handler:  publicStatusPageHandler,
+		Router:   r,
+	})
+
 	//  GET /api/integrations/github-app/install
 	githubAppInstallEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -515,6 +589,54 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/tunnels/connect -> cluster.NewTunnelConnectHandler
+	tunnelConnectEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/tunnels/connect",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	tunnelConnectHandler := cluster.NewTunnelConnectHandler(
+		config,
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: tunnelConnectEndpoint,
+		Handler:  tunnelConnectHandler,
+		Router:   r,
+	})
+
+	// POST /api/relay/report -> cluster.NewRelayReportHandler
+	relayReportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/relay/report",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	relayReportHandler := cluster.NewRelayReportHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: relayReportEndpoint,
+		Handler:  relayReportHandler,
+		Router:   r,
+	})
+
 	if config.ServerConf.GithubIncomingWebhookSecret != "" {
 		// POST /api/github/incoming_webhook/{webhook_id} -> webhook.NewGithubIncomingWebhook
 		githubIncomingWebhookEndpoint := factory.NewAPIEndpoint(
@@ -174,6 +174,8 @@ func getGitInstallationRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				CheckUsage:  true,
+				UsageMetric: types.PreviewEnvironmentHours,
 			},
 		)
 
@@ -698,5 +700,46 @@ func getGitInstallationRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/gitrepos/{git_installation_id}/repos/{kind}/{owner}/{name}/{branch}/clusters/{cluster_id}/namespaces/{namespace}/builds ->
+	// gitinstallation.NewCreateBuildHandler
+	createBuildEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent: basePath,
+				RelativePath: fmt.Sprintf(
+					"%s/repos/{%s}/{%s}/{%s}/{%s}/clusters/{cluster_id}/namespaces/{namespace}/builds",
+					relPath,
+					types.URLParamGitKind,
+					types.URLParamGitRepoOwner,
+					types.URLParamGitRepoName,
+					types.URLParamGitBranch,
+				),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.GitInstallationScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+			},
+			CheckUsage:  true,
+			UsageMetric: types.BuildMinutes,
+		},
+	)
+
+	createBuildHandler := gitinstallation.NewCreateBuildHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createBuildEndpoint,
+		Handler:  createBuildHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
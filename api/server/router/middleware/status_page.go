@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers/status_page"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/statuspage"
+)
+
+// StatusPageMiddleware serves public status pages for requests made to a subdomain of the
+// status page domain. All other requests are passed through.
+type StatusPageMiddleware struct {
+	config *config.Config
+
+	// the host of the Porter server, which is never treated as a status page in case the
+	// server is hosted under the status page domain
+	serverHost string
+}
+
+func NewStatusPageMiddleware(config *config.Config) *StatusPageMiddleware {
+	var serverHost string
+
+	if serverURL, err := url.Parse(config.ServerConf.ServerURL); err == nil {
+		serverHost = strings.ToLower(serverURL.Hostname())
+	}
+
+	return &StatusPageMiddleware{config, serverHost}
+}
+
+func (mw *StatusPageMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subdomain, ok := statuspage.GetSubdomain(r.Host, mw.config.ServerConf.StatusPageDomain)
+
+		if !ok || subdomain+"."+strings.ToLower(mw.config.ServerConf.StatusPageDomain) == mw.serverHost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		status_page.RenderPublicStatusPage(mw.config, subdomain, w, r)
+	})
+}
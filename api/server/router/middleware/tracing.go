@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing creates a span for every API request. Spans are named after the route of the request,
+// which is only known once the request has been routed.
+func Tracing(next http.Handler) http.Handler {
+	return tracing.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route := rctx.RoutePattern()
+
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(fmt.Sprintf("%s %s", r.Method, route))
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+	}), "api")
+}
@@ -79,6 +79,13 @@ func allowUsage(
 		return plan.Users == 0 || plan.Users >= current.Users+1
 	case types.Clusters:
 		return plan.Clusters == 0 || plan.Clusters >= current.Clusters+1
+	case types.Apps:
+		return plan.Apps == 0 || plan.Apps >= current.Apps+1
+	case types.BuildMinutes:
+		// builds are allowed until the build minutes of the billing period are used up
+		return plan.BuildMinutes == 0 || plan.BuildMinutes > current.BuildMinutes
+	case types.PreviewEnvironmentHours:
+		return plan.PreviewEnvironmentHours == 0 || plan.PreviewEnvironmentHours > current.PreviewEnvironmentHours
 	default:
 		return false
 	}
@@ -97,6 +104,12 @@ func getMetricUsage(
 		return plan.Users, current.Users
 	case types.Clusters:
 		return plan.Clusters, current.Clusters
+	case types.Apps:
+		return plan.Apps, current.Apps
+	case types.BuildMinutes:
+		return plan.BuildMinutes, current.BuildMinutes
+	case types.PreviewEnvironmentHours:
+		return plan.PreviewEnvironmentHours, current.PreviewEnvironmentHours
 	default:
 		return 0, 0
 	}
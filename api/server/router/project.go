@@ -227,6 +227,34 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/usage/history -> project.NewProjectGetUsageHistoryHandler
+	getUsageHistoryEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/usage/history",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getUsageHistoryHandler := project.NewProjectGetUsageHistoryHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getUsageHistoryEndpoint,
+		Handler:  getUsageHistoryHandler,
+		Router:   r,
+	})
+
 	// GET /api/project/{project_id}/billing/redirect -> billing.NewRedirectBillingHandler
 	redirectBillingEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -1261,5 +1289,363 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/audit_logs -> project.NewListAuditLogsHandler
+	listAuditLogsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/audit_logs",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listAuditLogsHandler := project.NewListAuditLogsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listAuditLogsEndpoint,
+		Handler:  listAuditLogsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/compliance/export -> project.NewComplianceExportHandler
+	complianceExportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/compliance/export",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	complianceExportHandler := project.NewComplianceExportHandler(
+		config,
+		factory.GetDecoderValidator(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: complianceExportEndpoint,
+		Handler:  complianceExportHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/compliance/signing_key -> project.NewGetComplianceSigningKeyHandler
+	getComplianceSigningKeyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/compliance/signing_key",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getComplianceSigningKeyHandler := project.NewGetComplianceSigningKeyHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getComplianceSigningKeyEndpoint,
+		Handler:  getComplianceSigningKeyHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/image_signature_policy -> project.NewGetImageSignaturePolicyHandler
+	getImageSignaturePolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/image_signature_policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getImageSignaturePolicyHandler := project.NewGetImageSignaturePolicyHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getImageSignaturePolicyEndpoint,
+		Handler:  getImageSignaturePolicyHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/image_signature_policy -> project.NewUpdateImageSignaturePolicyHandler
+	updateImageSignaturePolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/image_signature_policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateImageSignaturePolicyHandler := project.NewUpdateImageSignaturePolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateImageSignaturePolicyEndpoint,
+		Handler:  updateImageSignaturePolicyHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/external_secret_store -> project.NewGetExternalSecretStoreHandler
+	getExternalSecretStoreEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/external_secret_store",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getExternalSecretStoreHandler := project.NewGetExternalSecretStoreHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getExternalSecretStoreEndpoint,
+		Handler:  getExternalSecretStoreHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/external_secret_store -> project.NewUpdateExternalSecretStoreHandler
+	updateExternalSecretStoreEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/external_secret_store",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateExternalSecretStoreHandler := project.NewUpdateExternalSecretStoreHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateExternalSecretStoreEndpoint,
+		Handler:  updateExternalSecretStoreHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/external_secret_store -> project.NewDeleteExternalSecretStoreHandler
+	deleteExternalSecretStoreEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/external_secret_store",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteExternalSecretStoreHandler := project.NewDeleteExternalSecretStoreHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteExternalSecretStoreEndpoint,
+		Handler:  deleteExternalSecretStoreHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/deleted_clusters -> cluster.NewListDeletedClustersHandler
+	listDeletedClustersEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/deleted_clusters",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listDeletedClustersHandler := cluster.NewListDeletedClustersHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listDeletedClustersEndpoint,
+		Handler:  listDeletedClustersHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/deleted_clusters/{cluster_id}/restore -> cluster.NewClusterRestoreHandler
+	restoreClusterEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/deleted_clusters/{cluster_id}/restore",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	restoreClusterHandler := cluster.NewClusterRestoreHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: restoreClusterEndpoint,
+		Handler:  restoreClusterHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/background_jobs -> project.NewListBackgroundJobsHandler
+	listBackgroundJobsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/background_jobs",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listBackgroundJobsHandler := project.NewListBackgroundJobsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listBackgroundJobsEndpoint,
+		Handler:  listBackgroundJobsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/background_jobs/{job_id} -> project.NewGetBackgroundJobHandler
+	getBackgroundJobEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/background_jobs/{job_id}",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	getBackgroundJobHandler := project.NewGetBackgroundJobHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getBackgroundJobEndpoint,
+		Handler:  getBackgroundJobHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/background_jobs/{job_id}/requeue -> project.NewRequeueBackgroundJobHandler
+	requeueBackgroundJobEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/background_jobs/{job_id}/requeue",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	requeueBackgroundJobHandler := project.NewRequeueBackgroundJobHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: requeueBackgroundJobEndpoint,
+		Handler:  requeueBackgroundJobHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
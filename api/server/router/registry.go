@@ -229,5 +229,234 @@ func getRegistryRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/registries/{registry_id}/build_triggers -> registry.NewRegistryListBuildTriggersHandler
+	listBuildTriggersEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/build_triggers",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	listBuildTriggersHandler := registry.NewRegistryListBuildTriggersHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listBuildTriggersEndpoint,
+		Handler:  listBuildTriggersHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/registries/{registry_id}/retention_policy -> registry.NewRegistryGetRetentionPolicyHandler
+	getRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	getRetentionPolicyHandler := registry.NewRegistryGetRetentionPolicyHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getRetentionPolicyEndpoint,
+		Handler:  getRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/registries/{registry_id}/retention_policy -> registry.NewRegistryUpdateRetentionPolicyHandler
+	updateRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	updateRetentionPolicyHandler := registry.NewRegistryUpdateRetentionPolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateRetentionPolicyEndpoint,
+		Handler:  updateRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/registries/{registry_id}/retention_policy/report -> registry.NewRegistryGetRetentionReportHandler
+	getRetentionReportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policy/report",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	getRetentionReportHandler := registry.NewRegistryGetRetentionReportHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getRetentionReportEndpoint,
+		Handler:  getRetentionReportHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/registries/{registry_id}/webhook -> registry.NewRegistryCreateWebhookHandler
+	createRegistryWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/webhook",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	createRegistryWebhookHandler := registry.NewRegistryCreateWebhookHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createRegistryWebhookEndpoint,
+		Handler:  createRegistryWebhookHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/registries/{registry_id}/images/promote -> registry.NewRegistryPromoteImageHandler
+	promoteImageEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/images/promote",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	promoteImageHandler := registry.NewRegistryPromoteImageHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: promoteImageEndpoint,
+		Handler:  promoteImageHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/registries/{registry_id}/images/platforms -> registry.NewRegistryGetImagePlatformsHandler
+	getImagePlatformsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/images/platforms",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	getImagePlatformsHandler := registry.NewRegistryGetImagePlatformsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getImagePlatformsEndpoint,
+		Handler:  getImagePlatformsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/registries/{registry_id}/usage -> registry.NewRegistryGetUsageHandler
+	getUsageEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/usage",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	getUsageHandler := registry.NewRegistryGetUsageHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getUsageEndpoint,
+		Handler:  getUsageHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
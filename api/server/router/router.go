@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/healthcheck"
 	"github.com/porter-dev/porter/api/server/router/middleware"
 	v1 "github.com/porter-dev/porter/api/server/router/v1"
 	"github.com/porter-dev/porter/api/server/shared"
@@ -39,6 +40,24 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	projectIntegrationRegisterer := NewProjectIntegrationScopedRegisterer()
 	projectOAuthRegisterer := NewProjectOAuthScopedRegisterer()
 	slackIntegrationRegisterer := NewSlackIntegrationScopedRegisterer()
+	discordIntegrationRegisterer := NewDiscordIntegrationScopedRegisterer()
+	teamsIntegrationRegisterer := NewTeamsIntegrationScopedRegisterer()
+	pagerDutyIntegrationRegisterer := NewPagerDutyIntegrationScopedRegisterer()
+	datadogIntegrationRegisterer := NewDatadogIntegrationScopedRegisterer()
+	sentryIntegrationRegisterer := NewSentryIntegrationScopedRegisterer()
+	jiraIntegrationRegisterer := NewJiraIntegrationScopedRegisterer()
+	linearIntegrationRegisterer := NewLinearIntegrationScopedRegisterer()
+	dopplerIntegrationRegisterer := NewDopplerIntegrationScopedRegisterer()
+	grafanaIntegrationRegisterer := NewGrafanaIntegrationScopedRegisterer()
+	dnsProviderIntegrationRegisterer := NewDNSProviderIntegrationScopedRegisterer()
+	featureFlagIntegrationRegisterer := NewFeatureFlagIntegrationScopedRegisterer()
+	notificationPreferenceRegisterer := NewNotificationPreferenceScopedRegisterer()
+	statusPageRegisterer := NewStatusPageScopedRegisterer()
+	webhookSubscriptionRegisterer := NewWebhookSubscriptionScopedRegisterer()
+	multiClusterReleaseRegisterer := NewMultiClusterReleaseScopedRegisterer()
+	approvalRegisterer := NewApprovalScopedRegisterer()
+	freezeWindowRegisterer := NewFreezeWindowScopedRegisterer()
+	workflowTemplateRegisterer := NewWorkflowTemplateScopedRegisterer()
 	projRegisterer := NewProjectScopedRegisterer(
 		clusterRegisterer,
 		registryRegisterer,
@@ -49,17 +68,53 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		projectIntegrationRegisterer,
 		projectOAuthRegisterer,
 		slackIntegrationRegisterer,
+		discordIntegrationRegisterer,
+		teamsIntegrationRegisterer,
+		pagerDutyIntegrationRegisterer,
+		datadogIntegrationRegisterer,
+		sentryIntegrationRegisterer,
+		jiraIntegrationRegisterer,
+		linearIntegrationRegisterer,
+		dopplerIntegrationRegisterer,
+		grafanaIntegrationRegisterer,
+		dnsProviderIntegrationRegisterer,
+		featureFlagIntegrationRegisterer,
+		notificationPreferenceRegisterer,
+		statusPageRegisterer,
+		webhookSubscriptionRegisterer,
+		multiClusterReleaseRegisterer,
+		approvalRegisterer,
+		freezeWindowRegisterer,
+		workflowTemplateRegisterer,
 	)
 	statusRegisterer := NewStatusScopedRegisterer()
 
 	userRegisterer := NewUserScopedRegisterer(projRegisterer, statusRegisterer)
 	panicMW := middleware.NewPanicMiddleware(config)
 
+	// requests to subdomains of the status page domain are served the matching status page
+	if config.ServerConf.StatusPageDomain != "" {
+		r.Use(middleware.NewStatusPageMiddleware(config).Middleware)
+	}
+
 	if config.ServerConf.PprofEnabled {
 		r.Mount("/debug", chiMiddleware.Profiler())
 	}
 
+	if config.ServerConf.MetricsEnabled {
+		metricsHandler, err := healthcheck.NewMetricsHandler(config)
+
+		if err != nil {
+			config.Logger.Error().Err(err).Msg("could not create metrics handler")
+		} else {
+			r.Handle("/metrics", metricsHandler)
+		}
+	}
+
 	r.Route("/api", func(r chi.Router) {
+		// trace all API endpoints, including the panics which are caught below
+		r.Use(middleware.Tracing)
+
 		// set panic middleware for all API endpoints to catch panics
 		r.Use(panicMW.Middleware)
 
@@ -109,6 +164,9 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	})
 
 	r.Route("/api/v1", func(r chi.Router) {
+		// trace all API endpoints, including the panics which are caught below
+		r.Use(middleware.Tracing)
+
 		// set panic middleware for all API endpoints to catch panics
 		r.Use(panicMW.Middleware)
 
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"fmt"
+
 	"github.com/go-chi/chi"
 	"github.com/porter-dev/porter/api/server/handlers/slack_integration"
 	"github.com/porter-dev/porter/api/server/shared"
@@ -128,5 +130,114 @@ func getSlackIntegrationRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/slack_integrations -> slack_integration.NewSlackWebhookIntegrationCreateHandler
+	createWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createWebhookHandler := slack_integration.NewSlackWebhookIntegrationCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createWebhookEndpoint,
+		Handler:  createWebhookHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/slack_integrations/routing_rules -> slack_integration.NewSlackRoutingRuleListHandler
+	listRoutingRulesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/routing_rules",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listRoutingRulesHandler := slack_integration.NewSlackRoutingRuleListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listRoutingRulesEndpoint,
+		Handler:  listRoutingRulesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/slack_integrations/routing_rules -> slack_integration.NewSlackRoutingRuleCreateHandler
+	createRoutingRuleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/routing_rules",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createRoutingRuleHandler := slack_integration.NewSlackRoutingRuleCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createRoutingRuleEndpoint,
+		Handler:  createRoutingRuleHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/slack_integrations/routing_rules/{slack_routing_rule_id} -> slack_integration.NewSlackRoutingRuleDeleteHandler
+	deleteRoutingRuleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/routing_rules/{%s}", relPath, types.URLParamSlackRoutingRuleID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteRoutingRuleHandler := slack_integration.NewSlackRoutingRuleDeleteHandler(
+		config,
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteRoutingRuleEndpoint,
+		Handler:  deleteRoutingRuleHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
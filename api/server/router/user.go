@@ -218,6 +218,81 @@ func getUserRoutes(
 		Router:   r,
 	})
 
+	// GET /api/search -> user.NewSearchHandler
+	searchEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/search",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	searchHandler := user.NewSearchHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: searchEndpoint,
+		Handler:  searchHandler,
+		Router:   r,
+	})
+
+	// POST /api/admin/export -> user.NewExportInstanceHandler
+	exportInstanceEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/admin/export",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	exportInstanceHandler := user.NewExportInstanceHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: exportInstanceEndpoint,
+		Handler:  exportInstanceHandler,
+		Router:   r,
+	})
+
+	// PUT /api/admin/projects/{project_id}/usage_limits -> user.NewUpdateProjectUsageLimitsHandler
+	updateProjectUsageLimitsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("/admin/projects/{%s}/usage_limits", types.URLParamProjectID),
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	updateProjectUsageLimitsHandler := user.NewUpdateProjectUsageLimitsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateProjectUsageLimitsEndpoint,
+		Handler:  updateProjectUsageLimitsHandler,
+		Router:   r,
+	})
+
 	// POST /email/verify/initiate -> user.VerifyEmailInitiateHandler
 	emailVerifyInitiateEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -447,5 +522,106 @@ func getUserRoutes(
 		Router:   r,
 	})
 
+	// GET /api/deleted_projects -> project.NewListDeletedProjectsHandler
+	listDeletedProjectsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/deleted_projects",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+			},
+		},
+	)
+
+	listDeletedProjectsHandler := project.NewListDeletedProjectsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listDeletedProjectsEndpoint,
+		Handler:  listDeletedProjectsHandler,
+		Router:   r,
+	})
+
+	// POST /api/deleted_projects/{project_id}/restore -> project.NewProjectRestoreHandler
+	restoreProjectEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/deleted_projects/{project_id}/restore",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+			},
+		},
+	)
+
+	restoreProjectHandler := project.NewProjectRestoreHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: restoreProjectEndpoint,
+		Handler:  restoreProjectHandler,
+		Router:   r,
+	})
+
+	// GET /api/users/current/email_preferences -> user.NewEmailPreferencesGetHandler
+	getEmailPreferencesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/email_preferences",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	getEmailPreferencesHandler := user.NewEmailPreferencesGetHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getEmailPreferencesEndpoint,
+		Handler:  getEmailPreferencesHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/email_preferences -> user.NewEmailPreferencesUpdateHandler
+	updateEmailPreferencesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/email_preferences",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	updateEmailPreferencesHandler := user.NewEmailPreferencesUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateEmailPreferencesEndpoint,
+		Handler:  updateEmailPreferencesHandler,
+		Router:   r,
+	})
+
 	return routes
 }
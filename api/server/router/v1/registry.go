@@ -396,20 +396,21 @@ func getV1RegistryRoutes(
 	//   - name: num
 	//     in: query
 	//     description: |
-	//       The number of images to list.
-	//       For ECR images, a maximum of 1000 is allowed.
+	//       The number of images to list, with a maximum of 1000. For registries other than
+	//       ECR, all images are listed if this is not set, and images are sorted by when
+	//       they were pushed, most recent first.
 	//     type: integer
 	//     required: false
 	//     minimum: 1
 	//   - name: next
 	//     in: query
-	//     description: The next page string used for pagination, from a previous request.
+	//     description: The next page string used for pagination, from a previous request. (**ECR only**)
 	//     type: string
 	//   - name: page
 	//     in: query
 	//     description: |
 	//       The page number used for pagination, possibly from a previous request.
-	//       (**DigitalOcean only**)
+	//       (**all registries except ECR**)
 	//     type: integer
 	//     minimum: 1
 	// responses:
@@ -140,6 +140,8 @@ func getV1ReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			CheckUsage:  true,
+			UsageMetric: types.Apps,
 		},
 	)
 
@@ -286,10 +288,14 @@ func getV1ReleaseRoutes(
 	// responses:
 	//   '200':
 	//     description: Successfully updated the release
+	//   '202':
+	//     description: The upgrade waits for an approval, or in the deploy queue of the release
 	//   '400':
 	//     description: A malformed or bad request
 	//   '403':
 	//     description: Forbidden
+	//   '423':
+	//     description: Deploys to the namespace are frozen by a freeze window
 	upgradeEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
 			Verb:   types.APIVerbUpdate,
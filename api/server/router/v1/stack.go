@@ -210,6 +210,8 @@ func getV1StackRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			CheckUsage:  true,
+			UsageMetric: types.Apps,
 		},
 	)
 
@@ -490,6 +492,67 @@ func getV1StackRoutes(
 		Router:   r,
 	})
 
+	// PUT /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/spec -> stack.NewStackPutSpecHandler
+	// swagger:operation PUT /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/spec putStackSpec
+	//
+	// Replaces the app resources, source configurations and env groups of a stack, and deploys them
+	// as a new revision of the stack. App resources and env groups which are no longer listed are deleted.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Update stack spec
+	// tags:
+	// - Stacks
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: stack_id
+	//   - in: body
+	//     name: PutStackSpecRequest
+	//     description: The spec of the stack
+	//     schema:
+	//       $ref: '#/definitions/PutStackSpecRequest'
+	// responses:
+	//   '200':
+	//     description: Successfully updated the stack
+	//     schema:
+	//       $ref: '#/definitions/Stack'
+	//   '400':
+	//     description: Invalid stack spec
+	//   '403':
+	//     description: Forbidden
+	putSpecEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/{stack_id}/spec",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+				types.StackScope,
+			},
+		},
+	)
+
+	putSpecHandler := stack.NewStackPutSpecHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: putSpecEndpoint,
+		Handler:  putSpecHandler,
+		Router:   r,
+	})
+
 	// POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/rollback -> stack.NewStackRollbackHandler
 	// swagger:operation POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/rollback rollbackStack
 	//
@@ -876,5 +939,225 @@ func getV1StackRoutes(
 		Router:   r,
 	})
 
+	// GET /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export -> stack.NewStackGetGitOpsExportHandler
+	// swagger:operation GET /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export getStackGitOpsExport
+	//
+	// Gets the configuration of the export of a stack to a git repository
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Get the GitOps export of a stack
+	// tags:
+	// - Stacks
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: stack_id
+	// responses:
+	//   '200':
+	//     description: Successfully got the export
+	//     schema:
+	//       $ref: '#/definitions/GitOpsExport'
+	//   '404':
+	//     description: The stack is not exported
+	//   '403':
+	//     description: Forbidden
+	getGitOpsExportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/{stack_id}/gitops_export",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+				types.StackScope,
+			},
+		},
+	)
+
+	getGitOpsExportHandler := stack.NewStackGetGitOpsExportHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getGitOpsExportEndpoint,
+		Handler:  getGitOpsExportHandler,
+		Router:   r,
+	})
+
+	// PUT /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export -> stack.NewStackPutGitOpsExportHandler
+	// swagger:operation PUT /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export putStackGitOpsExport
+	//
+	// Exports a stack to a git repository. Once a stack is exported, updates of its spec and source configurations
+	// render its manifests and commit them to the repository, or open a pull request with them, instead of
+	// deploying them. The manifests are applied by a GitOps controller such as Argo CD or Flux.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Export a stack to git
+	// tags:
+	// - Stacks
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: stack_id
+	//   - in: body
+	//     name: PutGitOpsExportRequest
+	//     description: The configuration of the export
+	//     schema:
+	//       $ref: '#/definitions/PutGitOpsExportRequest'
+	// responses:
+	//   '200':
+	//     description: Successfully configured the export
+	//     schema:
+	//       $ref: '#/definitions/GitOpsExport'
+	//   '400':
+	//     description: Invalid repository, branch or path
+	//   '403':
+	//     description: Forbidden
+	putGitOpsExportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/{stack_id}/gitops_export",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+				types.StackScope,
+			},
+		},
+	)
+
+	putGitOpsExportHandler := stack.NewStackPutGitOpsExportHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: putGitOpsExportEndpoint,
+		Handler:  putGitOpsExportHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export -> stack.NewStackDeleteGitOpsExportHandler
+	// swagger:operation DELETE /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export deleteStackGitOpsExport
+	//
+	// Stops the export of a stack to git, after which the stack is deployed by Porter again. The manifests
+	// which were exported are left in the repository.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Stop the GitOps export of a stack
+	// tags:
+	// - Stacks
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: stack_id
+	// responses:
+	//   '200':
+	//     description: Successfully stopped the export
+	//   '403':
+	//     description: Forbidden
+	deleteGitOpsExportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/{stack_id}/gitops_export",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+				types.StackScope,
+			},
+		},
+	)
+
+	deleteGitOpsExportHandler := stack.NewStackDeleteGitOpsExportHandler(
+		config,
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteGitOpsExportEndpoint,
+		Handler:  deleteGitOpsExportHandler,
+		Router:   r,
+	})
+
+	// POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export/sync -> stack.NewStackSyncGitOpsExportHandler
+	// swagger:operation POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/stacks/{stack_id}/gitops_export/sync syncStackGitOpsExport
+	//
+	// Exports the latest revision of a stack to git again
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Sync the GitOps export of a stack
+	// tags:
+	// - Stacks
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: stack_id
+	// responses:
+	//   '200':
+	//     description: Successfully exported the latest revision
+	//     schema:
+	//       $ref: '#/definitions/GitOpsExport'
+	//   '400':
+	//     description: The stack is not exported
+	//   '403':
+	//     description: Forbidden
+	syncGitOpsExportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/{stack_id}/gitops_export/sync",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+				types.StackScope,
+			},
+		},
+	)
+
+	syncGitOpsExportHandler := stack.NewStackSyncGitOpsExportHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: syncGitOpsExportEndpoint,
+		Handler:  syncGitOpsExportHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
@@ -2,11 +2,15 @@ package apierrors
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/tunnel"
 	"github.com/porter-dev/porter/pkg/logger"
 )
 
@@ -41,6 +45,10 @@ func (e *ErrInternal) GetStatusCode() int {
 	return http.StatusInternalServerError
 }
 
+func (e *ErrInternal) Unwrap() error {
+	return e.err
+}
+
 type ErrForbidden struct {
 	err error
 }
@@ -92,6 +100,40 @@ func (e *ErrPassThroughToClient) GetStatusCode() int {
 	return e.statusCode
 }
 
+func (e *ErrPassThroughToClient) Unwrap() error {
+	return e.err
+}
+
+// errors that denote that a request can't be served right now, but can be retried
+type ErrUnavailable struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func NewErrUnavailable(err error, retryAfter time.Duration) RequestError {
+	return &ErrUnavailable{err, retryAfter}
+}
+
+func (e *ErrUnavailable) Error() string {
+	return e.err.Error()
+}
+
+func (e *ErrUnavailable) InternalError() string {
+	return e.err.Error()
+}
+
+func (e *ErrUnavailable) ExternalError() string {
+	return e.err.Error()
+}
+
+func (e *ErrUnavailable) GetStatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+func (e *ErrUnavailable) Unwrap() error {
+	return e.err
+}
+
 // errors that denote that a resource was not found
 type ErrNotFound struct {
 	err error
@@ -130,6 +172,12 @@ func HandleAPIError(
 	writeErr bool,
 	opts ...ErrorOpts,
 ) {
+	// the tunnel agent of a cluster is only connected to one replica, so handlers which fail to
+	// reach a tunneled cluster from another replica report that the request can be retried
+	if _, ok := err.(*ErrUnavailable); !ok && errors.Is(err, tunnel.ErrNotConnected) {
+		err = NewErrUnavailable(err, tunnel.RetryAfter)
+	}
+
 	extErrorStr := err.ExternalError()
 
 	// log the internal error
@@ -160,6 +208,10 @@ func HandleAPIError(
 			resp.Code = opts[0].Code
 		}
 
+		if unavailableErr, ok := err.(*ErrUnavailable); ok && unavailableErr.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(unavailableErr.retryAfter.Seconds())))
+		}
+
 		// write the status code
 		w.WriteHeader(err.GetStatusCode())
 
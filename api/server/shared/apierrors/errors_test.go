@@ -0,0 +1,45 @@
+package apierrors_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/internal/tunnel"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+func TestHandleAPIErrorTunnelNotConnected(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/1/clusters/1/namespaces", nil)
+	rr := httptest.NewRecorder()
+
+	// handlers wrap the errors of agents as internal errors
+	err := apierrors.NewErrInternal(fmt.Errorf("failed to get agent: %w", tunnel.ErrNotConnected))
+
+	apierrors.HandleAPIError(logger.NewConsole(false), nil, rr, req, err, true)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	if retryAfter := rr.Header().Get("Retry-After"); retryAfter != fmt.Sprintf("%d", int(tunnel.RetryAfter.Seconds())) {
+		t.Errorf("expected a Retry-After header, got %q", retryAfter)
+	}
+}
+
+func TestHandleAPIErrorInternal(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/1", nil)
+	rr := httptest.NewRecorder()
+
+	apierrors.HandleAPIError(logger.NewConsole(false), nil, rr, req, apierrors.NewErrInternal(fmt.Errorf("error")), true)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+
+	if retryAfter := rr.Header().Get("Retry-After"); retryAfter != "" {
+		t.Errorf("expected no Retry-After header, got %q", retryAfter)
+	}
+}
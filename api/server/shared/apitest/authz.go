@@ -7,7 +7,10 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"helm.sh/helm/v3/pkg/release"
 )
 
 func WithProject(t *testing.T, req *http.Request, proj *models.Project) *http.Request {
@@ -25,3 +28,35 @@ func WithRequestScopes(t *testing.T, req *http.Request, reqScopes map[types.Perm
 
 	return req
 }
+
+func WithCluster(t *testing.T, req *http.Request, cluster *models.Cluster) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, types.ClusterScope, cluster)
+	req = req.WithContext(ctx)
+
+	return req
+}
+
+func WithRelease(t *testing.T, req *http.Request, helmRelease *release.Release) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, types.ReleaseScope, helmRelease)
+	req = req.WithContext(ctx)
+
+	return req
+}
+
+func WithHelmAgent(t *testing.T, req *http.Request, helmAgent *helm.Agent) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, authz.HelmAgentCtxKey, helmAgent)
+	req = req.WithContext(ctx)
+
+	return req
+}
+
+func WithGitInstallation(t *testing.T, req *http.Request, ga *integrations.GithubAppInstallation) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, types.GitInstallationScope, ga)
+	req = req.WithContext(ctx)
+
+	return req
+}
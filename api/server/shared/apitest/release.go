@@ -0,0 +1,196 @@
+package apitest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func CreateTestCluster(t *testing.T, config *config.Config, projectID uint) *models.Cluster {
+	cluster, err := config.Repo.Cluster().CreateCluster(&models.Cluster{
+		ProjectID: projectID,
+		Name:      "test-cluster",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cluster
+}
+
+// CreateTestRelease returns a Helm agent which stores releases in memory, with the first
+// revision of a release of the web chart which is deployed with the values
+func CreateTestRelease(
+	t *testing.T,
+	config *config.Config,
+	namespace, name string,
+	values map[string]interface{},
+) (*helm.Agent, *release.Release) {
+	helmAgent := helm.GetAgentTesting(&helm.Form{Namespace: namespace}, nil, config.Logger, nil)
+
+	helmRelease := &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   1,
+		Config:    values,
+		Info: &release.Info{
+			Status: release.StatusDeployed,
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:    "web",
+				Version: "0.1.0",
+			},
+		},
+	}
+
+	if err := helmAgent.ActionConfig.Releases.Create(helmRelease); err != nil {
+		t.Fatal(err)
+	}
+
+	return helmAgent, helmRelease
+}
+
+// CreateTestApprovalPolicy creates an approval policy which applies to every namespace of the
+// cluster
+func CreateTestApprovalPolicy(t *testing.T, config *config.Config, cluster *models.Cluster, approverID uint) *models.ApprovalPolicy {
+	policy, err := config.Repo.Approval().CreateApprovalPolicy(&models.ApprovalPolicy{
+		ProjectID:   cluster.ProjectID,
+		ClusterID:   cluster.ID,
+		ApproverIDs: models.FormatIDs([]uint{approverID}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return policy
+}
+
+// AssertReleaseNotUpgraded fails the test if the release has a revision after its first revision
+func AssertReleaseNotUpgraded(t *testing.T, helmAgent *helm.Agent, name string) {
+	helmRelease, err := helmAgent.GetRelease(name, 0, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if helmRelease.Version != 1 {
+		t.Errorf("release %s was upgraded to revision %d", name, helmRelease.Version)
+	}
+}
+
+// AssertApprovalRequested fails the test unless an upgrade of the release waits for an approval
+func AssertApprovalRequested(t *testing.T, config *config.Config, cluster *models.Cluster, namespace, name string) {
+	approvals, err := config.Repo.Approval().ListPendingApprovalsByRelease(cluster.ID, namespace, name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(approvals) != 1 {
+		t.Fatalf("expected 1 pending approval of release %s, got %d", name, len(approvals))
+	}
+
+	if approvals[0].Status != types.ApprovalStatusPending {
+		t.Errorf("expected approval status %s, got %s", types.ApprovalStatusPending, approvals[0].Status)
+	}
+}
+
+// CreateTestFreezeWindow creates a freeze window which applies to every namespace of the cluster,
+// and is active for the next hour
+func CreateTestFreezeWindow(t *testing.T, config *config.Config, cluster *models.Cluster) *models.FreezeWindow {
+	now := time.Now().UTC()
+	startsAt := now.Add(-time.Hour)
+	endsAt := now.Add(time.Hour)
+
+	window, err := config.Repo.FreezeWindow().CreateFreezeWindow(&models.FreezeWindow{
+		ProjectID: cluster.ProjectID,
+		ClusterID: cluster.ID,
+		Name:      "test-freeze",
+		StartsAt:  &startsAt,
+		EndsAt:    &endsAt,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return window
+}
+
+// AssertRequestError fails the test unless the error is a request error with the status code
+func AssertRequestError(t *testing.T, err error, statusCode int) {
+	var apiErr apierrors.RequestError
+
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected a request error with status %d, got %v", statusCode, err)
+	}
+
+	if apiErr.GetStatusCode() != statusCode {
+		t.Fatalf("expected status %d, got %d: %s", statusCode, apiErr.GetStatusCode(), apiErr.Error())
+	}
+}
+
+// CreateTestRunningDeploy creates a running deploy of the release, so that other upgrades of the
+// release wait in its deploy queue
+func CreateTestRunningDeploy(t *testing.T, config *config.Config, cluster *models.Cluster, namespace, name string) *models.QueuedDeploy {
+	now := time.Now().UTC()
+
+	deploy, err := config.Repo.QueuedDeploy().CreateQueuedDeploy(&models.QueuedDeploy{
+		ProjectID: cluster.ProjectID,
+		ClusterID: cluster.ID,
+		Namespace: namespace,
+		Name:      name,
+		Source:    types.DeploySourceAPI,
+		Status:    types.DeployStatusRunning,
+		StartedAt: &now,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return deploy
+}
+
+// AssertDeployQueued fails the test unless an upgrade of the release waits in its deploy queue
+// behind the running deploy
+func AssertDeployQueued(t *testing.T, config *config.Config, cluster *models.Cluster, namespace, name string) {
+	queue, err := config.Repo.QueuedDeploy().ListQueuedDeploys(cluster.ID, namespace, name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queue) != 2 {
+		t.Fatalf("expected 2 deploys in the queue of release %s, got %d", name, len(queue))
+	}
+
+	if queue[1].Status != types.DeployStatusQueued {
+		t.Errorf("expected deploy status %s, got %s", types.DeployStatusQueued, queue[1].Status)
+	}
+}
+
+// AssertDeployNotQueued fails the test if an upgrade of the release waits in its deploy queue
+// behind the running deploy
+func AssertDeployNotQueued(t *testing.T, config *config.Config, cluster *models.Cluster, namespace, name string) {
+	queue, err := config.Repo.QueuedDeploy().ListQueuedDeploys(cluster.ID, namespace, name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queue) != 1 {
+		t.Fatalf("expected only the running deploy in the queue of release %s, got %d deploys", name, len(queue))
+	}
+}
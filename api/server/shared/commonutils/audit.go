@@ -0,0 +1,39 @@
+package commonutils
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RecordAccessChange adds an audit log entry for a change of the access to a project, such as
+// a role update or a new API token. The change was already made when it's recorded, so a
+// failure to record it is logged rather than returned.
+func RecordAccessChange(
+	config *config.Config,
+	projectID, userID uint,
+	action types.AuditLogAction,
+	resourceKind, resourceName string,
+	metadata map[string]interface{},
+) {
+	var rawMetadata []byte
+
+	if len(metadata) > 0 {
+		rawMetadata, _ = json.Marshal(metadata)
+	}
+
+	_, err := config.Repo.AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    projectID,
+		UserID:       userID,
+		Action:       string(action),
+		ResourceKind: resourceKind,
+		ResourceName: resourceName,
+		Metadata:     rawMetadata,
+	})
+
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error recording %s in project %d", action, projectID)
+	}
+}
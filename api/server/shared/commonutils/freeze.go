@@ -0,0 +1,101 @@
+package commonutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/freeze"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CheckFreezeWindows returns a 423 error if a freeze window is active for the namespace of the
+// cluster. Project admins, and the users allowed by the window, can deploy anyway by setting
+// override_freeze with a reason, which is recorded in the audit log. The user is nil for
+// deploys which are not made by a user, such as webhooks, which cannot override windows.
+func CheckFreezeWindows(
+	config *config.Config,
+	cluster *models.Cluster,
+	namespace, releaseName string,
+	user *models.User,
+	override types.FreezeOverride,
+) apierrors.RequestError {
+	windows, err := config.Repo.FreezeWindow().ListFreezeWindows(cluster.ProjectID)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	window, end, err := freeze.ActiveWindow(windows, cluster.ID, namespace, time.Now())
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if window == nil {
+		return nil
+	}
+
+	if !override.OverrideFreeze || user == nil {
+		msg := fmt.Sprintf(
+			"deploys to namespace %s are frozen by freeze window %s until %s",
+			namespace, window.Name, end.UTC().Format(time.RFC3339),
+		)
+
+		if window.Reason != "" {
+			msg = fmt.Sprintf("%s: %s", msg, window.Reason)
+		}
+
+		return apierrors.NewErrPassThroughToClient(errors.New(msg), http.StatusLocked)
+	}
+
+	if override.OverrideReason == "" {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a reason is required to override freeze window %s", window.Name),
+			http.StatusBadRequest,
+		)
+	}
+
+	if !window.CanOverride(user.ID) {
+		role, err := config.Repo.Project().ReadProjectRole(cluster.ProjectID, user.ID)
+
+		if err != nil {
+			return apierrors.NewErrInternal(err)
+		}
+
+		if role.Kind != types.RoleAdmin {
+			return apierrors.NewErrForbidden(
+				fmt.Errorf("user %d is not allowed to override freeze window %d", user.ID, window.ID),
+			)
+		}
+	}
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"freeze_window_id": window.ID,
+		"reason":           override.OverrideReason,
+	})
+
+	_, err = config.Repo.AuditLog().CreateAuditLog(&models.AuditLog{
+		ProjectID:    cluster.ProjectID,
+		ClusterID:    cluster.ID,
+		UserID:       user.ID,
+		Action:       string(types.AuditLogActionFreezeOverride),
+		ResourceKind: "FreezeWindow",
+		ResourceName: window.Name,
+		Namespace:    namespace,
+		ReleaseName:  releaseName,
+		Metadata:     metadata,
+	})
+
+	// overrides are emergencies, so they are not blocked by a failure to record them
+	if err != nil {
+		config.Logger.Error().Err(err).Msgf("error recording override of freeze window %d", window.ID)
+	}
+
+	return nil
+}
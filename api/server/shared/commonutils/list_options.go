@@ -0,0 +1,35 @@
+package commonutils
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// GetListOptions converts the list query parameters of a request to repository list options
+func GetListOptions(req *types.ListOptions, filters ...repository.Filter) *repository.ListOptions {
+	opts := &repository.ListOptions{}
+
+	for _, filter := range filters {
+		if len(filter.Values) > 0 {
+			opts.Filters = append(opts.Filters, filter)
+		}
+	}
+
+	if req != nil {
+		opts.Limit = req.Limit
+		opts.Cursor = req.Cursor
+		opts.SortBy = req.SortBy
+		opts.Order = repository.SortOrder(req.Order)
+	}
+
+	return opts
+}
+
+// SetNextCursor writes the cursor of the next page to the response headers, if there is a next page
+func SetNextCursor(w http.ResponseWriter, opts *repository.ListOptions) {
+	if opts != nil && opts.NextCursor != "" {
+		w.Header().Set(types.NextCursorHeader, opts.NextCursor)
+	}
+}
@@ -0,0 +1,72 @@
+package commonutils
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+	"github.com/porter-dev/porter/internal/notifier/email"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+)
+
+// NotifyPreviewDeployment posts a lifecycle event of a preview deployment, such as its creation,
+// finalization or deletion, to the Slack, Discord and Teams integrations of the project which are
+// routed the deployment. Failures are also emailed to the users who have opted in to failure
+// alerts. Notifications are best-effort, so errors are not returned to the caller.
+func NotifyPreviewDeployment(
+	config *config.Config,
+	cluster *models.Cluster,
+	depl *models.Deployment,
+	status types.DeploymentStatus,
+	info string,
+) {
+	if cluster.NotificationsDisabled {
+		return
+	}
+
+	opts := &notifier.PreviewDeploymentNotifyOpts{
+		ProjectID: cluster.ProjectID,
+		ClusterID: cluster.ID,
+		Namespace: depl.Namespace,
+		RepoOwner: depl.RepoOwner,
+		RepoName:  depl.RepoName,
+		PRNumber:  depl.PullRequestID,
+		PRName:    depl.PRName,
+		Status:    status,
+		Info:      info,
+		Subdomain: depl.Subdomain,
+		URL: fmt.Sprintf(
+			"%s/preview-environments/details/%d?environment_id=%d&project_id=%d",
+			config.ServerConf.ServerURL,
+			depl.ID,
+			depl.EnvironmentID,
+			cluster.ProjectID,
+		),
+	}
+
+	slackInts, err := slack.ListRoutedIntegrations(
+		config.Repo.SlackIntegration(), cluster.ProjectID, types.SlackEventPreviewDeployment, cluster.ID, depl.Namespace,
+	)
+
+	if err == nil {
+		slack.NewPreviewDeploymentNotifier(slackInts...).Notify(opts)
+	}
+
+	discordInts, err := discord.ListRoutedIntegrations(config.Repo, cluster.ProjectID, cluster.ID, depl.Namespace)
+
+	if err == nil {
+		discord.NewPreviewDeploymentNotifier(discordInts...).Notify(opts)
+	}
+
+	teamsInts, err := config.Repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(cluster.ProjectID)
+
+	if err == nil {
+		teams.NewPreviewDeploymentNotifier(teamsInts...).Notify(opts)
+	}
+
+	email.NewPreviewDeploymentNotifier(config.Repo, config.EmailSender).Notify(opts)
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
+	"github.com/porter-dev/porter/internal/jobqueue"
 	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/email"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/repository/credentials"
@@ -54,6 +56,10 @@ type Config struct {
 	// verification, etc)
 	UserNotifier notifier.UserNotifier
 
+	// EmailSender sends failure alerts and digests to the users who have opted in to them. It
+	// is nil if no email provider is configured.
+	EmailSender email.Sender
+
 	// DOConf is the configuration for a DigitalOcean OAuth client
 	DOConf *oauth2.Config
 
@@ -96,6 +102,10 @@ type Config struct {
 	// CredentialBackend is the backend for credential storage, if external cred storage (like Vault)
 	// is used
 	CredentialBackend credentials.CredentialStorage
+
+	// JobQueue runs side effects of requests, like GitHub comments and namespace teardowns,
+	// in the background with retries
+	JobQueue *jobqueue.Queue
 }
 
 type ConfigLoader interface {
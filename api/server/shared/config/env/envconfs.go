@@ -16,6 +16,9 @@ type ServerConf struct {
 
 	UsageTrackingEnabled bool `env:"USAGE_TRACKING_ENABLED,default=false"`
 
+	// Whether the cost of every cluster is recorded each hour, for the cost dashboards
+	CostMonitoringEnabled bool `env:"COST_MONITORING_ENABLED,default=false"`
+
 	Port                 int           `env:"SERVER_PORT,default=8080"`
 	StaticFilePath       string        `env:"STATIC_FILE_PATH,default=/porter/static"`
 	CookieName           string        `env:"COOKIE_NAME,default=porter"`
@@ -29,6 +32,11 @@ type ServerConf struct {
 	IsTesting            bool          `env:"IS_TESTING,default=false"`
 	AppRootDomain        string        `env:"APP_ROOT_DOMAIN,default=porter.run"`
 
+	// StatusPageDomain is the domain which public project status pages are served under, so
+	// that a status page with the subdomain "acme" is served at acme.<StatusPageDomain>. Status
+	// pages are disabled if this is not set.
+	StatusPageDomain string `env:"STATUS_PAGE_DOMAIN"`
+
 	DefaultApplicationHelmRepoURL string `env:"HELM_APP_REPO_URL,default=https://charts.dev.getporter.dev"`
 	DefaultAddonHelmRepoURL       string `env:"HELM_ADD_ON_REPO_URL,default=https://chart-addons.dev.getporter.dev"`
 
@@ -61,6 +69,14 @@ type ServerConf struct {
 	SendgridIncidentResolvedTemplateID string `env:"SENDGRID_INCIDENT_RESOLVED_TEMPLATE_ID"`
 	SendgridSenderEmail                string `env:"SENDGRID_SENDER_EMAIL"`
 
+	// SMTP server which sends failure alerts and digests instead of SendGrid, along with the
+	// address which the emails are sent from
+	SMTPHost        string `env:"SMTP_HOST"`
+	SMTPPort        uint   `env:"SMTP_PORT,default=587"`
+	SMTPUsername    string `env:"SMTP_USERNAME"`
+	SMTPPassword    string `env:"SMTP_PASSWORD"`
+	SMTPSenderEmail string `env:"SMTP_SENDER_EMAIL"`
+
 	SlackClientID     string `env:"SLACK_CLIENT_ID"`
 	SlackClientSecret string `env:"SLACK_CLIENT_SECRET"`
 
@@ -101,6 +117,21 @@ type ServerConf struct {
 	PprofEnabled    bool `env:"PPROF_ENABLED,default=false"`
 	ProvisionerTest bool `env:"PROVISIONER_TEST,default=false"`
 
+	// Enable the prometheus metrics endpoint, which reports the database connection pool stats
+	MetricsEnabled bool `env:"METRICS_ENABLED,default=false"`
+
+	// Export OpenTelemetry traces of API requests, helm operations, GitHub API calls and database
+	// queries to an OTLP/HTTP collector, such as otel-collector:4318. Tracing is disabled when no
+	// endpoint is set.
+	TracingOTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `env:"TRACING_OTLP_INSECURE,default=false"`
+	TracingServiceName  string  `env:"TRACING_SERVICE_NAME,default=porter-server"`
+	TracingSampleRate   float64 `env:"TRACING_SAMPLE_RATE,default=1"`
+
+	// The base64-encoded ed25519 seed of 32 bytes which signs compliance evidence exports.
+	// Exports are disabled when no key is set.
+	ComplianceSigningKey string `env:"COMPLIANCE_SIGNING_KEY"`
+
 	// Disable filtering for project creation
 	DisableAllowlist bool `env:"DISABLE_ALLOWLIST,default=true"`
 
@@ -110,6 +141,29 @@ type ServerConf struct {
 	// DisableRegistrySecretsInjection is used to denote if Porter should not inject
 	// imagePullSecrets into a kubernetes deployment (Porter application)
 	DisablePullSecretsInjection bool `env:"DISABLE_PULL_SECRETS_INJECTION,default=false"`
+
+	// RunMigrations applies pending database migrations when the server starts, rather than
+	// requiring the migrate entrypoint to run first
+	RunMigrations bool `env:"RUN_MIGRATIONS,default=false"`
+
+	// SoftDeleteRetentionDays is the number of days that deleted projects, clusters and
+	// environments can be restored, before they are permanently deleted
+	SoftDeleteRetentionDays uint `env:"SOFT_DELETE_RETENTION_DAYS,default=30"`
+
+	// Options for the background job queue: jobs are attempted JobQueueMaxAttempts times, and
+	// attempts which take longer than JobQueueTimeout are cancelled and retried
+	JobQueueWorkers      int           `env:"JOB_QUEUE_WORKERS,default=2"`
+	JobQueuePollInterval time.Duration `env:"JOB_QUEUE_POLL_INTERVAL,default=5s"`
+	JobQueueMaxAttempts  uint          `env:"JOB_QUEUE_MAX_ATTEMPTS,default=5"`
+	JobQueueTimeout      time.Duration `env:"JOB_QUEUE_TIMEOUT,default=10m"`
+
+	// LeaderLeaseDuration is how long a replica keeps the lease of a background worker without
+	// renewing it, which is how long the worker can go without running after its replica stops
+	LeaderLeaseDuration time.Duration `env:"LEADER_LEASE_DURATION,default=30s"`
+
+	// EnvGroupSourceSyncInterval is the time between syncs of env groups which are synced from
+	// an external secret store
+	EnvGroupSourceSyncInterval time.Duration `env:"ENV_GROUP_SOURCE_SYNC_INTERVAL,default=5m"`
 }
 
 // DBConf is the database configuration: if generated from environment variables,
@@ -118,6 +172,10 @@ type DBConf struct {
 	// EncryptionKey is the key to use for sensitive values that are encrypted at rest
 	EncryptionKey string `env:"ENCRYPTION_KEY,default=__random_strong_encryption_key__"`
 
+	// PreviousEncryptionKeys are keys which were previously used as the encryption key. They are
+	// only used to decrypt values which have not been re-encrypted with the current key.
+	PreviousEncryptionKeys []string `env:"PREVIOUS_ENCRYPTION_KEYS"`
+
 	Host     string `env:"DB_HOST,default=postgres"`
 	Port     int    `env:"DB_PORT,default=5432"`
 	Username string `env:"DB_USER,default=porter"`
@@ -128,12 +186,27 @@ type DBConf struct {
 	SQLLite     bool   `env:"SQL_LITE,default=false"`
 	SQLLitePath string `env:"SQL_LITE_PATH,default=/porter/porter.db"`
 
+	// ReadReplicaDSN is the DSN of a read-only replica of the database. When set, read queries
+	// on the read-heavy tables are routed to the replica, and every other query to the primary.
+	ReadReplicaDSN string `env:"DB_READ_REPLICA_DSN"`
+
+	// Connection pool settings: a MaxOpenConns of 0 means the number of open connections is
+	// unlimited, and a ConnMaxLifetime of 0 means connections are never closed due to their age
+	MaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS,default=0"`
+	MaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS,default=2"`
+	ConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME,default=0s"`
+
 	VaultPrefix    string `env:"VAULT_PREFIX,default=production"`
 	VaultAPIKey    string `env:"VAULT_API_KEY"`
 	VaultServerURL string `env:"VAULT_SERVER_URL"`
+
+	// VaultTokenRenewInterval is the longest time between renewals of the Vault token. The token is
+	// renewed earlier when it would expire before then.
+	VaultTokenRenewInterval time.Duration `env:"VAULT_TOKEN_RENEW_INTERVAL,default=1h"`
 }
 
-// RedisConf is the redis config required for the provisioner container
+// RedisConf is the redis config required for the provisioner container, and for
+// the repository cache of the server
 type RedisConf struct {
 	// if redis should be used
 	Enabled bool `env:"REDIS_ENABLED,default=true"`
@@ -143,4 +216,11 @@ type RedisConf struct {
 	Username string `env:"REDIS_USER"`
 	Password string `env:"REDIS_PASS"`
 	DB       int    `env:"REDIS_DB,default=0"`
+
+	// if the server should cache hot repository reads in redis, and the TTLs of the cached
+	// models: a TTL of 0 disables caching of the model
+	CacheEnabled        bool          `env:"REDIS_CACHE_ENABLED,default=false"`
+	CacheProjectTTL     time.Duration `env:"REDIS_CACHE_PROJECT_TTL,default=5m"`
+	CacheClusterTTL     time.Duration `env:"REDIS_CACHE_CLUSTER_TTL,default=1m"`
+	CacheEnvironmentTTL time.Duration `env:"REDIS_CACHE_ENVIRONMENT_TTL,default=1m"`
 }
@@ -4,6 +4,8 @@
 package loader
 
 import (
+	"log"
+
 	eeBilling "github.com/porter-dev/porter/ee/billing"
 	"github.com/porter-dev/porter/ee/integrations/vault"
 	"github.com/porter-dev/porter/ee/models"
@@ -40,10 +42,16 @@ func init() {
 	}
 
 	if InstanceEnvConf.DBConf.VaultAPIKey != "" && InstanceEnvConf.DBConf.VaultServerURL != "" && InstanceEnvConf.DBConf.VaultPrefix != "" {
-		InstanceCredentialBackend = vault.NewClient(
+		vaultClient := vault.NewClient(
 			InstanceEnvConf.DBConf.VaultServerURL,
 			InstanceEnvConf.DBConf.VaultAPIKey,
 			InstanceEnvConf.DBConf.VaultPrefix,
 		)
+
+		vaultClient.StartTokenRenewal(InstanceEnvConf.DBConf.VaultTokenRenewInterval, func(err error) {
+			log.Printf("error renewing vault token: %v", err)
+		})
+
+		InstanceCredentialBackend = vaultClient
 	}
 }
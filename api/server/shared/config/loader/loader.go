@@ -17,13 +17,19 @@ import (
 	"github.com/porter-dev/porter/internal/auth/sessionstore"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
+	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
+	"github.com/porter-dev/porter/internal/jobqueue"
 	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/email"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository/cache"
 	"github.com/porter-dev/porter/internal/repository/credentials"
 	"github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/repository/gorm/migrations"
+	"github.com/porter-dev/porter/internal/tracing"
 	"github.com/porter-dev/porter/provisioner/client"
 
 	lr "github.com/porter-dev/porter/pkg/logger"
@@ -73,20 +79,81 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 	res.Metadata = config.MetadataFromConf(envConf.ServerConf, e.version)
 	res.DB = InstanceDB
 
-	err = gorm.AutoMigrate(InstanceDB, sc.Debug)
+	if sc.TracingOTLPEndpoint != "" {
+		err := tracing.InitTracerProvider(&tracing.TracerOpts{
+			Endpoint:       sc.TracingOTLPEndpoint,
+			Insecure:       sc.TracingOTLPInsecure,
+			ServiceName:    sc.TracingServiceName,
+			ServiceVersion: e.version,
+			SampleRate:     sc.TracingSampleRate,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize tracing: %w", err)
+		}
+
+		if err := InstanceDB.Use(tracing.NewGormPlugin()); err != nil {
+			return nil, fmt.Errorf("could not initialize database tracing: %w", err)
+		}
+	}
+
+	migrator, err := migrations.NewMigrator(InstanceDB, migrations.All())
 
 	if err != nil {
 		return nil, err
 	}
 
+	// the local server uses sqlite without a separate migrate step, so it always applies
+	// pending migrations at startup
+	if sc.RunMigrations || envConf.DBConf.SQLLite {
+		if _, err := migrator.Up(0); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := migrator.CheckVersion(); err != nil {
+		return nil, err
+	}
+
 	var key [32]byte
 
 	for i, b := range []byte(envConf.DBConf.EncryptionKey) {
 		key[i] = b
 	}
 
+	for _, prevKey := range envConf.DBConf.PreviousEncryptionKeys {
+		var decryptionKey [32]byte
+
+		for i, b := range []byte(prevKey) {
+			decryptionKey[i] = b
+		}
+
+		encryption.AddDecryptionKeys(&decryptionKey)
+	}
+
 	res.Repo = gorm.NewRepository(InstanceDB, &key, InstanceCredentialBackend)
 
+	if envConf.RedisConf.Enabled && envConf.RedisConf.CacheEnabled {
+		redisClient, err := adapter.NewRedisClient(envConf.RedisConf)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to redis for the repository cache: %w", err)
+		}
+
+		res.Repo = cache.NewRepository(res.Repo, cache.NewRedisStore(redisClient), &key, &cache.Conf{
+			ProjectTTL:     envConf.RedisConf.CacheProjectTTL,
+			ClusterTTL:     envConf.RedisConf.CacheClusterTTL,
+			EnvironmentTTL: envConf.RedisConf.CacheEnvironmentTTL,
+		})
+	}
+
+	res.JobQueue = jobqueue.NewQueue(res.Repo, res.Logger, &jobqueue.QueueOpts{
+		Workers:      envConf.ServerConf.JobQueueWorkers,
+		PollInterval: envConf.ServerConf.JobQueuePollInterval,
+		MaxAttempts:  envConf.ServerConf.JobQueueMaxAttempts,
+		Timeout:      envConf.ServerConf.JobQueueTimeout,
+	})
+
 	// create the session store
 	res.Store, err = sessionstore.NewStore(
 		&sessionstore.NewStoreOpts{
@@ -119,6 +186,8 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		})
 	}
 
+	res.EmailSender = email.NewSender(getEmailSenderOpts(envConf.ServerConf))
+
 	res.Alerter = alerter.NoOpAlerter{}
 
 	if envConf.ServerConf.SentryDSN != "" {
@@ -244,3 +313,21 @@ func getProvisionerServiceClient(sc *env.ServerConf) (*client.Client, error) {
 
 	return nil, fmt.Errorf("required env vars not set for provisioner")
 }
+
+// getEmailSenderOpts uses the SMTP server if it is configured, and SendGrid otherwise
+func getEmailSenderOpts(sc *env.ServerConf) *email.SenderOpts {
+	if sc.SMTPHost != "" {
+		return &email.SenderOpts{
+			SenderEmail:  sc.SMTPSenderEmail,
+			SMTPHost:     sc.SMTPHost,
+			SMTPPort:     sc.SMTPPort,
+			SMTPUsername: sc.SMTPUsername,
+			SMTPPassword: sc.SMTPPassword,
+		}
+	}
+
+	return &email.SenderOpts{
+		SenderEmail:    sc.SendgridSenderEmail,
+		SendgridAPIKey: sc.SendgridAPIKey,
+	}
+}
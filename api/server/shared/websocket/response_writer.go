@@ -50,6 +50,23 @@ func (w *WebsocketSafeReadWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// WriteBinary writes data as a binary message, for streams which may not be valid UTF-8
+func (w *WebsocketSafeReadWriter) WriteBinary(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.conn.WriteMessage(websocket.BinaryMessage, data)
+
+	if err != nil {
+		if errOr(err, websocket.ErrCloseSent, syscall.EPIPE, syscall.ECONNRESET) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
 func (w *WebsocketSafeReadWriter) ReadMessage() (messageType int, p []byte, err error) {
 	return w.conn.ReadMessage()
 }
@@ -0,0 +1,93 @@
+package types
+
+import "time"
+
+const (
+	URLParamApprovalID       URLParam = "approval_id"
+	URLParamApprovalPolicyID URLParam = "approval_policy_id"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending ApprovalStatus = "pending"
+
+	// ApprovalStatusApproved means that the upgrade was approved and executed
+	ApprovalStatusApproved ApprovalStatus = "approved"
+
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+
+	// ApprovalStatusFailed means that the upgrade was approved, but failed when it was executed
+	ApprovalStatusFailed ApprovalStatus = "failed"
+
+	// ApprovalStatusSuperseded means that a newer upgrade of the release was requested before
+	// the upgrade was reviewed
+	ApprovalStatusSuperseded ApprovalStatus = "superseded"
+)
+
+// ApprovalPolicy requires upgrades of the releases in a cluster, or in some namespaces of a
+// cluster, to be approved by one of the approvers before they are executed
+type ApprovalPolicy struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+	ClusterID uint      `json:"cluster_id"`
+
+	// the namespaces which the policy applies to. The policy applies to every namespace of the
+	// cluster if empty.
+	Namespaces []string `json:"namespaces"`
+
+	// the ids of the users who can approve upgrades
+	ApproverIDs []uint `json:"approver_ids"`
+}
+
+type CreateApprovalPolicyRequest struct {
+	ClusterID   uint     `json:"cluster_id" form:"required"`
+	Namespaces  []string `json:"namespaces"`
+	ApproverIDs []uint   `json:"approver_ids" form:"required,min=1"`
+}
+
+type ListApprovalPoliciesResponse []*ApprovalPolicy
+
+// Approval is an upgrade of a release which is waiting for, or has received, the review of an
+// approver
+type Approval struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+	ClusterID uint      `json:"cluster_id"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+
+	// the version of the chart which the release is upgraded to, if the chart is upgraded
+	ChartVersion string `json:"chart_version,omitempty"`
+
+	// the user who requested the upgrade, which is 0 for upgrades triggered by a webhook
+	RequestedByUserID uint `json:"requested_by_user_id"`
+
+	ApproverIDs []uint         `json:"approver_ids"`
+	Status      ApprovalStatus `json:"status"`
+
+	ReviewedByUserID uint       `json:"reviewed_by_user_id,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	Comment          string     `json:"comment,omitempty"`
+
+	// the revision of the release which the approved upgrade created
+	Revision int `json:"revision,omitempty"`
+
+	// the reason the approved upgrade failed
+	Error string `json:"error,omitempty"`
+}
+
+type ListApprovalsRequest struct {
+	Status ApprovalStatus `schema:"status"`
+}
+
+type ListApprovalsResponse []*Approval
+
+type ReviewApprovalRequest struct {
+	Comment string `json:"comment"`
+
+	// only used when approving an upgrade during a freeze window
+	FreezeOverride
+}
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+type ArchivedDeployment struct {
+	ID         uint      `json:"id"`
+	ArchivedAt time.Time `json:"archived_at"`
+	ProjectID  uint      `json:"project_id"`
+	ClusterID  uint      `json:"cluster_id"`
+
+	Deployment *Deployment `json:"deployment"`
+}
+
+type ListArchivedDeploymentsRequest struct {
+	ListOptions
+
+	EnvironmentID []string `schema:"environment_id"`
+	RepoOwner     []string `schema:"repo_owner"`
+	RepoName      []string `schema:"repo_name"`
+	PullRequestID []string `schema:"pull_request_id"`
+}
+
+type ListArchivedDeploymentsResponse []*ArchivedDeployment
+
+type ArchivedKubeEvent struct {
+	ID         uint      `json:"id"`
+	ArchivedAt time.Time `json:"archived_at"`
+
+	KubeEvent *KubeEvent `json:"kube_event"`
+}
+
+type ListArchivedKubeEventsRequest struct {
+	ListOptions
+
+	Namespace    []string `schema:"namespace"`
+	ResourceType []string `schema:"resource_type"`
+	Name         []string `schema:"name"`
+}
+
+type ListArchivedKubeEventsResponse []*ArchivedKubeEvent
@@ -0,0 +1,88 @@
+package types
+
+import "time"
+
+type AuditLogAction string
+
+const (
+	AuditLogActionResourceApply    AuditLogAction = "resource.apply"
+	AuditLogActionPodFilesDownload AuditLogAction = "pod.files.download"
+	AuditLogActionPodFilesUpload   AuditLogAction = "pod.files.upload"
+	AuditLogActionImagePromote     AuditLogAction = "registry.image.promote"
+
+	// AuditLogActionEnvGroupSecretsRead records a read of the unmasked values of the secret
+	// variables of an env group
+	AuditLogActionEnvGroupSecretsRead AuditLogAction = "envgroup.secrets.read"
+
+	// AuditLogActionFreezeOverride records a deploy which overrode an active freeze window
+	AuditLogActionFreezeOverride AuditLogAction = "freeze.override"
+
+	// AuditLogActionReleaseUpgrade records an upgrade of a release, which was made by a user or
+	// triggered by a webhook
+	AuditLogActionReleaseUpgrade AuditLogAction = "release.upgrade"
+
+	// the actions which change the access of users and API tokens to a project
+	AuditLogActionRoleCreate     AuditLogAction = "project.role.create"
+	AuditLogActionRoleUpdate     AuditLogAction = "project.role.update"
+	AuditLogActionRoleDelete     AuditLogAction = "project.role.delete"
+	AuditLogActionInviteCreate   AuditLogAction = "project.invite.create"
+	AuditLogActionAPITokenCreate AuditLogAction = "project.api_token.create"
+	AuditLogActionAPITokenRevoke AuditLogAction = "project.api_token.revoke"
+
+	// AuditLogActionComplianceExport records an export of the compliance evidence of a project
+	AuditLogActionComplianceExport AuditLogAction = "compliance.export"
+)
+
+// AccessAuditLogActions are the audit log actions which change the access to a project
+var AccessAuditLogActions = []AuditLogAction{
+	AuditLogActionRoleCreate,
+	AuditLogActionRoleUpdate,
+	AuditLogActionRoleDelete,
+	AuditLogActionInviteCreate,
+	AuditLogActionAPITokenCreate,
+	AuditLogActionAPITokenRevoke,
+}
+
+// AuditLog records a sensitive action taken by a user in a project
+type AuditLog struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProjectID uint `json:"project_id"`
+	ClusterID uint `json:"cluster_id,omitempty"`
+	UserID    uint `json:"user_id"`
+
+	Action AuditLogAction `json:"action"`
+
+	// the kind, name and namespace of the resource that the action was taken on
+	ResourceKind string `json:"resource_kind,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+
+	// the release that the resource belongs to, if any
+	ReleaseName string `json:"release_name,omitempty"`
+
+	// arbitrary data describing the action
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type ListAuditLogsRequest struct {
+	Limit int `schema:"limit"`
+	Skip  int `schema:"skip"`
+
+	ClusterID uint   `schema:"cluster_id"`
+	UserID    uint   `schema:"user_id"`
+	Action    string `schema:"action"`
+
+	Namespace    string `schema:"namespace"`
+	ResourceKind string `schema:"resource_kind"`
+	ResourceName string `schema:"resource_name"`
+}
+
+type ListAuditLogsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	AuditLogs []*AuditLog `json:"audit_logs"`
+}
@@ -0,0 +1,44 @@
+package types
+
+import "time"
+
+type BackgroundJobStatus string
+
+const (
+	// BackgroundJobQueued jobs are waiting to run, either for the first time or after a
+	// failed attempt
+	BackgroundJobQueued BackgroundJobStatus = "queued"
+
+	// BackgroundJobRunning jobs have been claimed by a worker
+	BackgroundJobRunning BackgroundJobStatus = "running"
+
+	// BackgroundJobSucceeded jobs have completed successfully
+	BackgroundJobSucceeded BackgroundJobStatus = "succeeded"
+
+	// BackgroundJobDead jobs have failed every attempt, and will not run again unless
+	// they are requeued
+	BackgroundJobDead BackgroundJobStatus = "dead"
+)
+
+type BackgroundJob struct {
+	ID          uint                `json:"id"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Kind        string              `json:"kind"`
+	Status      BackgroundJobStatus `json:"status"`
+	Attempts    uint                `json:"attempts"`
+	MaxAttempts uint                `json:"max_attempts"`
+	RunAfter    time.Time           `json:"run_after"`
+
+	// The error of the last failed attempt
+	LastError string `json:"last_error,omitempty"`
+}
+
+type ListBackgroundJobsRequest struct {
+	ListOptions
+
+	Status []string `schema:"status"`
+	Kind   []string `schema:"kind"`
+}
+
+type ListBackgroundJobsResponse []*BackgroundJob
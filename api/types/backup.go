@@ -0,0 +1,114 @@
+package types
+
+import "time"
+
+const (
+	URLParamBackupName         URLParam = "backup_name"
+	URLParamBackupScheduleName URLParam = "backup_schedule_name"
+)
+
+// BackupScope is the set of resources which a backup contains, which are the resources of a
+// namespace, or the resources of a single release in a namespace
+type BackupScope struct {
+	Namespace string `json:"namespace" form:"required"`
+
+	// if set, only the resources and the release history of this release are backed up
+	ReleaseName string `json:"release_name,omitempty"`
+}
+
+type CreateBackupRequest struct {
+	BackupScope
+
+	// the number of hours to keep the backup for, which defaults to the Velero default of 30 days
+	TTLHours uint `json:"ttl_hours"`
+}
+
+type CreateBackupScheduleRequest struct {
+	BackupScope
+
+	// the cron schedule of the backups
+	Schedule string `json:"schedule" form:"required"`
+
+	TTLHours uint `json:"ttl_hours"`
+}
+
+type ListBackupsRequest struct {
+	Namespace   string `schema:"namespace"`
+	ReleaseName string `schema:"release_name"`
+}
+
+// Backup is a Velero backup of the manifests and persistent volume snapshots of a namespace
+// or a release
+type Backup struct {
+	Name string `json:"name"`
+	BackupScope
+
+	// the schedule which created the backup, if it was created by a schedule
+	ScheduleName string `json:"schedule_name,omitempty"`
+
+	// the phase of the backup, which is one of the Velero backup phases, such as "InProgress",
+	// "Completed", "PartiallyFailed" or "Failed"
+	Phase string `json:"phase"`
+
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+
+	VolumeSnapshotsAttempted int `json:"volume_snapshots_attempted"`
+	VolumeSnapshotsCompleted int `json:"volume_snapshots_completed"`
+
+	ValidationErrors []string `json:"validation_errors,omitempty"`
+}
+
+type ListBackupsResponse []*Backup
+
+type BackupSchedule struct {
+	Name string `json:"name"`
+	BackupScope
+
+	Schedule string `json:"schedule"`
+	TTLHours uint   `json:"ttl_hours,omitempty"`
+
+	// the phase of the schedule, which is one of "New", "Enabled" or "FailedValidation"
+	Phase        string     `json:"phase"`
+	LastBackupAt *time.Time `json:"last_backup_at,omitempty"`
+
+	ValidationErrors []string `json:"validation_errors,omitempty"`
+}
+
+type ListBackupSchedulesResponse []*BackupSchedule
+
+type CreateRestoreRequest struct {
+	// the cluster to restore the backup into, which defaults to the cluster of the backup. The
+	// Velero installations of both clusters must share a backup storage location.
+	TargetClusterID uint `json:"target_cluster_id"`
+
+	// the namespace to restore the backup into, which defaults to the namespace of the backup
+	TargetNamespace string `json:"target_namespace"`
+}
+
+// Restore is a Velero restore of a backup into a cluster
+type Restore struct {
+	Name       string `json:"name"`
+	BackupName string `json:"backup_name"`
+
+	// the namespace which the resources were restored into
+	TargetNamespace string `json:"target_namespace"`
+
+	// the phase of the restore, which is one of the Velero restore phases, such as
+	// "InProgress", "Completed", "PartiallyFailed" or "Failed"
+	Phase string `json:"phase"`
+
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+
+	ValidationErrors []string `json:"validation_errors,omitempty"`
+}
+
+type ListRestoresResponse []*Restore
@@ -0,0 +1,65 @@
+package types
+
+import "time"
+
+const URLParamBuildID URLParam = "build_id"
+
+// BuildStatus is the status of an image build run by Porter
+type BuildStatus string
+
+const (
+	BuildStatusQueued    BuildStatus = "queued"
+	BuildStatusRunning   BuildStatus = "running"
+	BuildStatusSucceeded BuildStatus = "succeeded"
+	BuildStatusFailed    BuildStatus = "failed"
+)
+
+// Build is an image build from a git repository, run with Cloud Native Buildpacks as a job in
+// a cluster
+type Build struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProjectID uint   `json:"project_id"`
+	ClusterID uint   `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+
+	// The git repo in ${owner}/${repo} form
+	GitRepo   string `json:"git_repo"`
+	GitBranch string `json:"git_branch"`
+	CommitSHA string `json:"commit_sha"`
+
+	// The path of the app in the git repo
+	FolderPath string `json:"folder_path"`
+
+	// The buildpacks builder image used for the build
+	Builder string `json:"builder"`
+
+	// The image which the build pushes to
+	Image string `json:"image"`
+
+	Status BuildStatus `json:"status"`
+
+	// Set if the build failed
+	Error string `json:"error,omitempty"`
+
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type CreateBuildRequest struct {
+	// The path of the app in the git repo. Defaults to the root of the repo.
+	FolderPath string `json:"folder_path"`
+
+	// The buildpacks builder image to use. Defaults to the Paketo full builder.
+	Builder string `json:"builder"`
+
+	// The complete image repository uri to push to, which must belong to a registry
+	// linked to the project
+	// required: true
+	ImageRepoURI string `json:"image_repo_uri" form:"required"`
+
+	// The tag of the built image. Defaults to the commit SHA of the branch.
+	Tag string `json:"tag"`
+}
+
+type ListBuildsResponse []*Build
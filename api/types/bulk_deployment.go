@@ -0,0 +1,73 @@
+package types
+
+import "time"
+
+type BulkDeploymentAction string
+
+const (
+	// BulkDeploymentActionDisable deletes the namespaces of deployments and marks them as
+	// inactive, so that they can be re-enabled later
+	BulkDeploymentActionDisable BulkDeploymentAction = "disable"
+
+	// BulkDeploymentActionReenable re-runs the workflows of inactive deployments
+	BulkDeploymentActionReenable BulkDeploymentAction = "reenable"
+
+	// BulkDeploymentActionDelete deletes the namespaces and the records of deployments
+	BulkDeploymentActionDelete BulkDeploymentAction = "delete"
+)
+
+type BulkDeploymentOperationStatus string
+
+const (
+	BulkDeploymentOperationRunning   BulkDeploymentOperationStatus = "running"
+	BulkDeploymentOperationCompleted BulkDeploymentOperationStatus = "completed"
+)
+
+type BulkDeploymentItemStatus string
+
+const (
+	BulkDeploymentItemPending   BulkDeploymentItemStatus = "pending"
+	BulkDeploymentItemRunning   BulkDeploymentItemStatus = "running"
+	BulkDeploymentItemSucceeded BulkDeploymentItemStatus = "succeeded"
+	BulkDeploymentItemSkipped   BulkDeploymentItemStatus = "skipped"
+	BulkDeploymentItemFailed    BulkDeploymentItemStatus = "failed"
+)
+
+// CreateBulkDeploymentOperationRequest selects the deployments of a cluster to run an action on.
+// Deployments must match every filter which is set.
+type CreateBulkDeploymentOperationRequest struct {
+	Action BulkDeploymentAction `json:"action" form:"required,oneof=disable reenable delete"`
+
+	EnvironmentIDs []uint `json:"environment_ids"`
+	DeploymentIDs  []uint `json:"deployment_ids"`
+
+	// Select deployments which have not been updated for this many hours
+	OlderThanHours uint `json:"older_than_hours"`
+
+	// Select deployments whose pull request is open or closed. Branch deployments never
+	// match this filter.
+	PRState string `json:"pr_state" form:"omitempty,oneof=open closed"`
+
+	// Re-enabled deployments are checked against the freeze windows of their namespace
+	FreezeOverride
+}
+
+type BulkDeploymentOperationItem struct {
+	DeploymentID uint                     `json:"deployment_id"`
+	Namespace    string                   `json:"namespace"`
+	Status       BulkDeploymentItemStatus `json:"status"`
+
+	// The reason an item was skipped or failed
+	Message string `json:"message,omitempty"`
+}
+
+type BulkDeploymentOperation struct {
+	ID        uint                           `json:"id"`
+	CreatedAt time.Time                      `json:"created_at"`
+	UpdatedAt time.Time                      `json:"updated_at"`
+	Action    BulkDeploymentAction           `json:"action"`
+	Status    BulkDeploymentOperationStatus  `json:"status"`
+	Items     []*BulkDeploymentOperationItem `json:"items"`
+}
+
+type ListBulkDeploymentOperationsResponse []*BulkDeploymentOperation
@@ -0,0 +1,52 @@
+package types
+
+import "time"
+
+type CertificateStatus string
+
+const (
+	CertificateStatusReady   CertificateStatus = "ready"
+	CertificateStatusIssuing CertificateStatus = "issuing"
+	CertificateStatusFailed  CertificateStatus = "failed"
+	CertificateStatusUnknown CertificateStatus = "unknown"
+)
+
+// Certificate is a cert-manager Certificate resource
+type Certificate struct {
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	SecretName string   `json:"secret_name"`
+	DNSNames   []string `json:"dns_names"`
+
+	// the kind and name of the issuer for this certificate
+	IssuerKind string `json:"issuer_kind"`
+	IssuerName string `json:"issuer_name"`
+
+	// the issuance state of the certificate
+	Status CertificateStatus `json:"status"`
+
+	// the reason and message from the certificate's latest condition, populated when the
+	// certificate is not ready
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// the number of consecutive failed issuance attempts
+	FailedIssuanceAttempts int64 `json:"failed_issuance_attempts,omitempty"`
+
+	NotBefore   *time.Time `json:"not_before,omitempty"`
+	NotAfter    *time.Time `json:"not_after,omitempty"`
+	RenewalTime *time.Time `json:"renewal_time,omitempty"`
+
+	// the time of the last failed issuance attempt
+	LastFailureTime *time.Time `json:"last_failure_time,omitempty"`
+
+	// the release that this certificate belongs to, if any
+	ReleaseName string `json:"release_name,omitempty"`
+}
+
+type ListCertificatesRequest struct {
+	// (optional) only return certificates which are not ready
+	OnlyFailing bool `schema:"only_failing"`
+}
+
+type ListCertificatesResponse []*Certificate
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
 )
 
@@ -38,6 +40,9 @@ type Cluster struct {
 
 	// Whether preview environments is enabled on this cluster
 	PreviewEnvsEnabled bool `json:"preview_envs_enabled"`
+
+	// Whether the cluster is connected through a reverse tunnel
+	TunnelEnabled bool `json:"tunnel_enabled"`
 }
 
 type ClusterCandidate struct {
@@ -232,8 +237,22 @@ type CreateNamespaceRequest struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+type GetTemporaryKubeconfigRequest struct {
+	// (optional) the namespace to limit access to. If not set, access is granted in
+	// all namespaces.
+	Namespace string `schema:"namespace"`
+
+	// (optional) the lifetime of the kubeconfig, defaults to one hour
+	ExpirySeconds int64 `schema:"expiry_seconds"`
+}
+
 type GetTemporaryKubeconfigResponse struct {
 	Kubeconfig []byte `json:"kubeconfig"`
+
+	// the namespace which the kubeconfig is limited to, if any
+	Namespace string `json:"namespace,omitempty"`
+
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type ListNGINXIngressesResponse []prometheus.SimpleIngress
@@ -285,3 +304,41 @@ type ListClusterResponse []*Cluster
 type CreateClusterCandidateResponse []*ClusterCandidate
 
 type ListClusterCandidateResponse []*ClusterCandidate
+
+type DrainNodeRequest struct {
+	// the grace period given to each evicted pod, in seconds. If not set, the
+	// pod's own termination grace period is used.
+	GracePeriodSeconds *int64 `schema:"grace_period_seconds"`
+
+	// the total time to wait for the drain to complete, in seconds
+	TimeoutSeconds uint `schema:"timeout_seconds"`
+
+	// whether to evict pods using emptyDir volumes, whose data will be lost
+	DeleteEmptyDirData bool `schema:"delete_emptydir_data"`
+
+	// whether to evict pods which are not managed by a controller
+	Force bool `schema:"force"`
+}
+
+type NodeDrainEventType string
+
+const (
+	NodeDrainEventCordoned  NodeDrainEventType = "cordoned"
+	NodeDrainEventEvicting  NodeDrainEventType = "evicting"
+	NodeDrainEventEvicted   NodeDrainEventType = "evicted"
+	NodeDrainEventBlocked   NodeDrainEventType = "blocked"
+	NodeDrainEventFailed    NodeDrainEventType = "failed"
+	NodeDrainEventCompleted NodeDrainEventType = "completed"
+)
+
+// NodeDrainEvent is written to the websocket connection as a node is drained
+type NodeDrainEvent struct {
+	Type      NodeDrainEventType `json:"type"`
+	Node      string             `json:"node"`
+	Pod       string             `json:"pod,omitempty"`
+	Namespace string             `json:"namespace,omitempty"`
+	Message   string             `json:"message,omitempty"`
+
+	// the number of pods remaining to be evicted
+	Remaining int `json:"remaining"`
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+type ClusterHealthCheckName string
+
+const (
+	ClusterHealthCheckAPIServer   ClusterHealthCheckName = "api_server"
+	ClusterHealthCheckCredentials ClusterHealthCheckName = "credentials"
+	ClusterHealthCheckMetrics     ClusterHealthCheckName = "metrics"
+	ClusterHealthCheckAgent       ClusterHealthCheckName = "agent"
+)
+
+// ClusterHealthCheck is the result of a single connectivity check against a cluster
+type ClusterHealthCheck struct {
+	Name    ClusterHealthCheckName `json:"name"`
+	Healthy bool                   `json:"healthy"`
+
+	// whether deploys to the cluster fail when this check is unhealthy. Non-critical checks
+	// only affect features such as metrics and incident detection.
+	Critical bool `json:"critical"`
+
+	// a human-readable description of the result of the check
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterHealth describes whether Porter can connect to and operate a cluster
+type ClusterHealth struct {
+	// whether all critical checks passed
+	Healthy   bool      `json:"healthy"`
+	CheckedAt time.Time `json:"checked_at"`
+
+	// the Kubernetes version reported by the API server, if reachable
+	ServerVersion string `json:"server_version,omitempty"`
+
+	// the time that the credentials used to connect to the cluster expire, if known
+	CredentialsExpireAt *time.Time `json:"credentials_expire_at,omitempty"`
+
+	// the version of the porter agent, if installed
+	AgentVersion string `json:"agent_version,omitempty"`
+
+	Checks []*ClusterHealthCheck `json:"checks"`
+}
@@ -0,0 +1,75 @@
+package types
+
+import "time"
+
+const URLParamClusterIncidentID URLParam = "cluster_incident_id"
+
+// ClusterIncidentReason is the failure which caused an incident to be opened
+type ClusterIncidentReason string
+
+const (
+	ClusterIncidentReasonCrashLoop   ClusterIncidentReason = "crash_loop"
+	ClusterIncidentReasonImagePull   ClusterIncidentReason = "image_pull"
+	ClusterIncidentReasonOOMKilled   ClusterIncidentReason = "oom_killed"
+	ClusterIncidentReasonProbeFailed ClusterIncidentReason = "probe_failed"
+
+	// the logs of a release matched a log alert rule
+	ClusterIncidentReasonLogAlert ClusterIncidentReason = "log_alert"
+)
+
+// ClusterIncident is an incident detected by Porter from the pod states and events of a
+// cluster. Unlike incidents reported by the porter agent, these are stored in the Porter
+// database.
+type ClusterIncident struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+	ClusterID uint `json:"cluster_id"`
+
+	Reason  ClusterIncidentReason `json:"reason"`
+	Status  IncidentStatus        `json:"status"`
+	Message string                `json:"message"`
+
+	Namespace          string `json:"namespace"`
+	ReleaseName        string `json:"release_name,omitempty"`
+	InvolvedObjectKind string `json:"involved_object_kind"`
+	InvolvedObjectName string `json:"involved_object_name"`
+
+	// the pods which were affected by this incident
+	Pods []string `json:"pods"`
+
+	StartedAt  time.Time  `json:"started_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+type ListClusterIncidentsRequest struct {
+	Limit int `schema:"limit"`
+	Skip  int `schema:"skip"`
+
+	Status      IncidentStatus `schema:"status"`
+	Namespace   string         `schema:"namespace"`
+	ReleaseName string         `schema:"release_name"`
+}
+
+type ListClusterIncidentsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	Incidents []*ClusterIncident `json:"incidents"`
+}
+
+type ClusterIncidentEventType string
+
+const (
+	ClusterIncidentEventOpened   ClusterIncidentEventType = "opened"
+	ClusterIncidentEventUpdated  ClusterIncidentEventType = "updated"
+	ClusterIncidentEventResolved ClusterIncidentEventType = "resolved"
+)
+
+// ClusterIncidentEvent is sent over the incident stream when an incident is opened,
+// updated or resolved
+type ClusterIncidentEvent struct {
+	Type     ClusterIncidentEventType `json:"type"`
+	Incident *ClusterIncident         `json:"incident"`
+}
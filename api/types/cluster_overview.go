@@ -0,0 +1,51 @@
+package types
+
+import "time"
+
+// GetClusterOverviewResponse is everything which the dashboard home shows for a cluster
+type GetClusterOverviewResponse struct {
+	Nodes      *ClusterOverviewNodes      `json:"nodes"`
+	Namespaces *ClusterOverviewNamespaces `json:"namespaces"`
+
+	// the number of pods in each phase, across all namespaces
+	PodsByPhase map[string]int `json:"pods_by_phase"`
+
+	// the releases whose install, upgrade or rollback has not finished
+	PendingUpgrades []*ClusterOverviewPendingUpgrade `json:"pending_upgrades"`
+
+	ActiveIncidents int64              `json:"active_incidents"`
+	RecentIncidents []*ClusterIncident `json:"recent_incidents"`
+}
+
+// ClusterOverviewNodes is the capacity of the nodes of a cluster, and how much of it is
+// requested by the pods which run on the nodes. CPU is in millicores and memory is in bytes.
+type ClusterOverviewNodes struct {
+	Count         int `json:"count"`
+	Ready         int `json:"ready"`
+	Unschedulable int `json:"unschedulable"`
+
+	CPUCapacity    int64 `json:"cpu_capacity"`
+	CPUAllocatable int64 `json:"cpu_allocatable"`
+	CPURequests    int64 `json:"cpu_requests"`
+
+	MemoryCapacity    int64 `json:"memory_capacity"`
+	MemoryAllocatable int64 `json:"memory_allocatable"`
+	MemoryRequests    int64 `json:"memory_requests"`
+}
+
+type ClusterOverviewNamespaces struct {
+	Count       int `json:"count"`
+	Active      int `json:"active"`
+	Terminating int `json:"terminating"`
+}
+
+type ClusterOverviewPendingUpgrade struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// the status of the release, which is one of "pending-install", "pending-upgrade" or
+	// "pending-rollback"
+	Status   string    `json:"status"`
+	Revision int       `json:"revision"`
+	Since    time.Time `json:"since"`
+}
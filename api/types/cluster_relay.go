@@ -0,0 +1,97 @@
+package types
+
+import "time"
+
+// ClusterRelay is the in-cluster agent of a cluster, which watches the workloads of the cluster
+// and pushes their incidents, events and metrics to Porter, so that Porter doesn't poll the
+// Kubernetes API of the cluster for them
+type ClusterRelay struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ClusterID uint      `json:"cluster_id"`
+
+	// the version of the agent which sent the last report
+	AgentVersion string `json:"agent_version,omitempty"`
+
+	LastReportAt *time.Time `json:"last_report_at,omitempty"`
+
+	// whether the last report is recent enough for incidents to be detected from it
+	Active bool `json:"active"`
+}
+
+type CreateClusterRelayResponse struct {
+	Relay *ClusterRelay `json:"relay"`
+
+	// the token which the agent sends its reports with. It's only returned when the relay is
+	// created or its token is rotated.
+	Token string `json:"token"`
+}
+
+// RelayReport is sent by the in-cluster agent on an interval
+type RelayReport struct {
+	AgentVersion string    `json:"agent_version"`
+	CollectedAt  time.Time `json:"collected_at"`
+
+	// all incidents which are ongoing in the cluster
+	Incidents []*RelayIncident `json:"incidents"`
+
+	// the warning events which were observed since the last accepted report
+	Events []*RelayEvent `json:"events"`
+
+	// the resource usage of the pods of the cluster. It's empty if the metrics API isn't
+	// available in the cluster.
+	PodMetrics []*RelayPodMetrics `json:"pod_metrics"`
+}
+
+// RelayReportResponse is returned to the in-cluster agent when its report is accepted
+type RelayReportResponse struct {
+	// the log alert rules of the releases of the cluster, which the agent evaluates on the
+	// logs of their pods
+	LogAlertRules []*LogAlertRule `json:"log_alert_rules"`
+}
+
+// RelayIncident is an incident which was detected by the in-cluster agent
+type RelayIncident struct {
+	Key     string                `json:"key" form:"required"`
+	Reason  ClusterIncidentReason `json:"reason" form:"required"`
+	Message string                `json:"message"`
+
+	Namespace          string `json:"namespace"`
+	ReleaseName        string `json:"release_name"`
+	InvolvedObjectKind string `json:"involved_object_kind"`
+	InvolvedObjectName string `json:"involved_object_name"`
+
+	Pods []string `json:"pods"`
+
+	// the last lines of the logs of the incident's pods, or the matching lines of a log alert.
+	// It's only sent in the first report which contains the incident.
+	LogExcerpt string `json:"log_excerpt,omitempty"`
+}
+
+type RelayEvent struct {
+	Namespace          string    `json:"namespace"`
+	InvolvedObjectKind string    `json:"involved_object_kind"`
+	InvolvedObjectName string    `json:"involved_object_name"`
+	Reason             string    `json:"reason"`
+	Message            string    `json:"message"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+type RelayPodMetrics struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	ReleaseName string `json:"release_name,omitempty"`
+
+	CPUMillicores int64 `json:"cpu_millicores"`
+	MemoryBytes   int64 `json:"memory_bytes"`
+}
+
+type GetClusterRelayMetricsRequest struct {
+	Namespace   string `schema:"namespace"`
+	ReleaseName string `schema:"release_name"`
+}
+
+type GetClusterRelayMetricsResponse struct {
+	CollectedAt *time.Time         `json:"collected_at"`
+	Pods        []*RelayPodMetrics `json:"pods"`
+}
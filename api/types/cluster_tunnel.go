@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// ClusterTunnel is the reverse tunnel of a cluster, which routes the traffic from Porter to
+// the Kubernetes API of the cluster through an agent which runs in the cluster
+type ClusterTunnel struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ClusterID uint      `json:"cluster_id"`
+
+	// whether the tunnel agent is connected to the server which served the request
+	Connected bool `json:"connected"`
+
+	LastConnectedAt    *time.Time `json:"last_connected_at,omitempty"`
+	LastDisconnectedAt *time.Time `json:"last_disconnected_at,omitempty"`
+}
+
+type CreateClusterTunnelResponse struct {
+	Tunnel *ClusterTunnel `json:"tunnel"`
+
+	// the token which the tunnel agent connects with. It's only returned when the tunnel is
+	// created or its token is rotated.
+	Token string `json:"token"`
+
+	// the address of the Kubernetes API server which the agent must allow connections to
+	AgentTarget string `json:"agent_target"`
+}
@@ -0,0 +1,53 @@
+package types
+
+import "time"
+
+type ComplianceExportFormat string
+
+const (
+	ComplianceExportFormatJSON ComplianceExportFormat = "json"
+	ComplianceExportFormatCSV  ComplianceExportFormat = "csv"
+)
+
+type ComplianceExportRequest struct {
+	// The start of the period of the export
+	From *time.Time `schema:"from" form:"required"`
+
+	// The end of the period of the export, which defaults to now
+	To *time.Time `schema:"to"`
+
+	// The format of the records in the bundle, which defaults to json
+	Format ComplianceExportFormat `schema:"format" form:"omitempty,oneof=json csv"`
+}
+
+// ComplianceExportManifest describes the files of a compliance evidence bundle. The manifest
+// is signed, and contains the SHA-256 digest of each file, so that the whole bundle can be
+// verified with the signing key of the Porter instance.
+type ComplianceExportManifest struct {
+	ProjectID   uint                   `json:"project_id"`
+	ProjectName string                 `json:"project_name"`
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Format      ComplianceExportFormat `json:"format"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+
+	// the base64-encoded ed25519 public key which the manifest is signed with
+	PublicKey string `json:"public_key"`
+
+	Files []*ComplianceExportFile `json:"files"`
+}
+
+type ComplianceExportFile struct {
+	Name    string `json:"name"`
+	SHA256  string `json:"sha256"`
+	Records int    `json:"records"`
+}
+
+type ComplianceSigningKeyResponse struct {
+	Algorithm string `json:"algorithm"`
+
+	// the base64-encoded public key which verifies the signatures of compliance exports
+	PublicKey string `json:"public_key"`
+}
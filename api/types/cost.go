@@ -0,0 +1,91 @@
+package types
+
+import "time"
+
+// CostGroupBy is how the costs of a cluster are grouped
+type CostGroupBy string
+
+const (
+	CostGroupByNamespace CostGroupBy = "namespace"
+	CostGroupByRelease   CostGroupBy = "release"
+)
+
+type ListCostsRequest struct {
+	// The start of the period, which defaults to the start of the current month
+	From *time.Time `schema:"from"`
+
+	// The end of the period, which defaults to now
+	To *time.Time `schema:"to"`
+
+	// Whether the costs are grouped by namespace or by release, which defaults to namespace
+	GroupBy CostGroupBy `schema:"group_by" form:"omitempty,oneof=namespace release"`
+}
+
+// Cost is the cost of the resources which the pods of a namespace or release requested over a
+// period, in US dollars
+type Cost struct {
+	Namespace string `json:"namespace"`
+
+	// The name of the release, if the costs are grouped by release. Pods which don't belong to
+	// a release are grouped under an empty release name.
+	ReleaseName string `json:"release_name,omitempty"`
+
+	Cost           float64 `json:"cost"`
+	CPUCoreHours   float64 `json:"cpu_core_hours"`
+	MemoryGiBHours float64 `json:"memory_gib_hours"`
+}
+
+type ListCostsResponse struct {
+	// The costs, from the most to the least expensive
+	Costs []*Cost `json:"costs"`
+
+	Total float64 `json:"total"`
+}
+
+type GetCostHistoryRequest struct {
+	From *time.Time `schema:"from"`
+	To   *time.Time `schema:"to"`
+
+	// If set, only the costs of the namespace are returned
+	Namespace string `schema:"namespace"`
+
+	// If set, only the costs of the release in the namespace are returned
+	ReleaseName string `schema:"release_name"`
+}
+
+// CostHistoryPoint is the cost of an hour, in US dollars
+type CostHistoryPoint struct {
+	Hour time.Time `json:"hour"`
+	Cost float64   `json:"cost"`
+}
+
+type GetCostHistoryResponse struct {
+	Points []*CostHistoryPoint `json:"points"`
+	Total  float64             `json:"total"`
+}
+
+type ListPreviewEnvironmentCostsRequest struct {
+	From *time.Time `schema:"from"`
+	To   *time.Time `schema:"to"`
+
+	// The number of preview environments to return, which defaults to 10
+	Limit uint `schema:"limit"`
+}
+
+// PreviewEnvironmentCost is the cost of the namespace of a preview environment deployment
+// over a period, in US dollars
+type PreviewEnvironmentCost struct {
+	DeploymentID  uint             `json:"deployment_id"`
+	EnvironmentID uint             `json:"environment_id"`
+	Namespace     string           `json:"namespace"`
+	RepoOwner     string           `json:"repo_owner"`
+	RepoName      string           `json:"repo_name"`
+	PRName        string           `json:"pr_name"`
+	PullRequestID uint             `json:"pull_request_id"`
+	Status        DeploymentStatus `json:"status"`
+	Cost          float64          `json:"cost"`
+}
+
+// ListPreviewEnvironmentCostsResponse lists preview environments from the most to the least
+// expensive
+type ListPreviewEnvironmentCostsResponse []*PreviewEnvironmentCost
@@ -14,3 +14,63 @@ type StreamCRDRequest struct {
 	Version   string `json:"version" form:"required"`
 	Resource  string `json:"resource" form:"required"`
 }
+
+const (
+	URLParamCRDName            URLParam = "crd_name"
+	URLParamCustomResourceName URLParam = "resource_name"
+)
+
+// CustomResourceDefinition describes a custom resource type installed on a cluster
+type CustomResourceDefinition struct {
+	// the name of the definition, in the form <plural>.<group>
+	Name string `json:"name"`
+
+	Group    string `json:"group"`
+	Kind     string `json:"kind"`
+	Plural   string `json:"plural"`
+	Singular string `json:"singular"`
+
+	// whether instances are namespaced or cluster-scoped
+	Namespaced bool `json:"namespaced"`
+
+	// the versions served by the API server
+	Versions []string `json:"versions"`
+
+	// the version in which instances are persisted, used by default when listing instances
+	StorageVersion string `json:"storage_version"`
+
+	Categories []string `json:"categories,omitempty"`
+}
+
+type ListCRDsRequest struct {
+	// (optional) only return definitions in this API group
+	Group string `schema:"group"`
+}
+
+type ListCRDsResponse []*CustomResourceDefinition
+
+type ListCustomResourcesRequest struct {
+	// (optional) the namespace to list instances in. If not set, instances in all
+	// namespaces are listed.
+	Namespace string `schema:"namespace"`
+
+	// (optional) the version of the custom resource, defaults to the storage version
+	Version string `schema:"version"`
+
+	LabelSelector string `schema:"label_selector"`
+	FieldSelector string `schema:"field_selector"`
+
+	// (optional) dot-separated paths of the fields to return for each instance, for example
+	// status.phase. The name and namespace of each instance are always returned.
+	Fields []string `schema:"fields"`
+}
+
+type ListCustomResourcesResponse []map[string]interface{}
+
+type GetCustomResourceRequest struct {
+	// the namespace of the instance, required for namespaced custom resources
+	Namespace string `schema:"namespace"`
+
+	// (optional) the version of the custom resource, defaults to the storage version
+	Version string `schema:"version"`
+}
@@ -0,0 +1,35 @@
+package types
+
+const (
+	URLParamDatadogIntegrationID URLParam = "datadog_integration_id"
+)
+
+// DatadogIntegration is a Datadog organization which is sent an event whenever a release of a
+// project is upgraded or rolled back. Events are tagged with the cluster, namespace, service and
+// version of the release, so that they can be overlaid on the release's metric graphs.
+type DatadogIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Datadog organization
+	Name string `json:"name"`
+
+	// The Datadog site of the organization, such as datadoghq.com or datadoghq.eu
+	Site string `json:"site"`
+
+	// Additional tags added to every event, such as env:production
+	Tags []string `json:"tags"`
+}
+
+type CreateDatadogIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	Site string `json:"site" form:"required,oneof=datadoghq.com us3.datadoghq.com us5.datadoghq.com datadoghq.eu ap1.datadoghq.com ddog-gov.com"`
+
+	APIKey string `json:"api_key" form:"required"`
+
+	Tags []string `json:"tags" form:"dive,required,excludesall=0x2C"`
+}
+
+type ListDatadogIntegrationsResponse []*DatadogIntegration
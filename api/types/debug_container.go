@@ -0,0 +1,30 @@
+package types
+
+const URLParamDebugContainerName URLParam = "debug_container"
+
+type CreateDebugContainerRequest struct {
+	// (optional) the image of the debug container, defaults to busybox
+	Image string `json:"image"`
+
+	// (optional) the container whose process namespace the debug container should share.
+	// Defaults to the first container in the pod.
+	TargetContainer string `json:"target_container"`
+
+	// (optional) the command to run in the debug container, defaults to a shell
+	Command []string `json:"command"`
+}
+
+// DebugContainer is an ephemeral container injected into a running pod for debugging
+type DebugContainer struct {
+	Name            string `json:"name"`
+	Pod             string `json:"pod"`
+	Namespace       string `json:"namespace"`
+	Image           string `json:"image"`
+	TargetContainer string `json:"target_container"`
+
+	// the state of the container: one of waiting, running or terminated
+	State string `json:"state"`
+
+	// the reason the container is waiting or terminated, if any
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+const URLParamDeployID URLParam = "deploy_id"
+
+type DeployStatus string
+
+const (
+	DeployStatusQueued    DeployStatus = "queued"
+	DeployStatusRunning   DeployStatus = "running"
+	DeployStatusSucceeded DeployStatus = "succeeded"
+	DeployStatusFailed    DeployStatus = "failed"
+	DeployStatusCancelled DeployStatus = "cancelled"
+)
+
+type DeploySource string
+
+const (
+	DeploySourceAPI             DeploySource = "api"
+	DeploySourceWebhook         DeploySource = "webhook"
+	DeploySourceRegistryPush    DeploySource = "registry_push"
+	DeploySourceImageBatch      DeploySource = "image_batch"
+	DeploySourceRightsizing     DeploySource = "rightsizing"
+	DeploySourceEnvGroupRollout DeploySource = "env_group_rollout"
+	DeploySourceStack           DeploySource = "stack"
+	DeploySourceMultiCluster    DeploySource = "multi_cluster"
+)
+
+// QueuedDeploy is an upgrade of a release in the release's deploy queue. The upgrades of a
+// release run one at a time, in the order they were requested.
+type QueuedDeploy struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ClusterID uint      `json:"cluster_id"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+
+	Source DeploySource `json:"source"`
+
+	// the user who requested the upgrade, which is 0 for upgrades which are not made by a user,
+	// such as webhooks
+	RequestedByUserID uint `json:"requested_by_user_id"`
+
+	// the version of the chart which the release is upgraded to, if the chart is upgraded
+	ChartVersion string `json:"chart_version,omitempty"`
+
+	// the image tag which a webhook or registry push upgrade deploys
+	ImageTag string `json:"image_tag,omitempty"`
+
+	Status DeployStatus `json:"status"`
+
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	CancelledByUserID uint `json:"cancelled_by_user_id,omitempty"`
+
+	// the revision of the release which the upgrade created
+	Revision int `json:"revision,omitempty"`
+
+	// the reason the upgrade failed
+	Error string `json:"error,omitempty"`
+
+	// the feature flags which the upgrade changes once it succeeds
+	FeatureFlags []*DeployFeatureFlag `json:"feature_flags,omitempty"`
+}
+
+// ListDeployQueueResponse is the running upgrade of a release, followed by its queued
+// upgrades in the order they will run
+type ListDeployQueueResponse []*QueuedDeploy
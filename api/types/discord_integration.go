@@ -0,0 +1,35 @@
+package types
+
+const (
+	URLParamDiscordIntegrationID URLParam = "discord_integration_id"
+)
+
+// DiscordIntegration is a Discord webhook which is notified of the events of a project. A
+// Discord integration which belongs to a preview environment is only notified of the events
+// of that environment's deployments.
+type DiscordIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Discord channel
+	Name string `json:"name"`
+
+	// The preview environment that the integration belongs to, or 0 for the whole project
+	EnvironmentID uint `json:"environment_id"`
+}
+
+type CreateDiscordIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	// The webhook URL, which must be a Discord webhook URL
+	Webhook string `json:"webhook" form:"required,url"`
+
+	// The preview environment that the integration belongs to, or 0 for the whole project
+	EnvironmentID uint `json:"environment_id"`
+
+	// The cluster of the preview environment, which is required if the environment is set
+	ClusterID uint `json:"cluster_id" form:"required_with=EnvironmentID"`
+}
+
+type ListDiscordIntegrationsResponse []*DiscordIntegration
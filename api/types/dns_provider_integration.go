@@ -0,0 +1,39 @@
+package types
+
+const (
+	URLParamDNSProviderIntegrationID URLParam = "dns_provider_integration_id"
+)
+
+type DNSProvider string
+
+const (
+	DNSProviderRoute53    DNSProvider = "route53"
+	DNSProviderCloudflare DNSProvider = "cloudflare"
+)
+
+// DNSProviderIntegration is a Route53 or Cloudflare account which manages the DNS records of
+// custom domains
+type DNSProviderIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	Name     string      `json:"name"`
+	Provider DNSProvider `json:"provider"`
+
+	// the AWS integration whose credentials manage Route53 records
+	AWSIntegrationID uint `json:"aws_integration_id,omitempty"`
+}
+
+type CreateDNSProviderIntegrationRequest struct {
+	Name     string      `json:"name" form:"required"`
+	Provider DNSProvider `json:"provider" form:"required,oneof=route53 cloudflare"`
+
+	// the AWS integration of the project which manages Route53 records, required for route53
+	AWSIntegrationID uint `json:"aws_integration_id"`
+
+	// an API token with the Zone:Read and DNS:Edit permissions, required for cloudflare
+	CloudflareAPIToken string `json:"cloudflare_api_token"`
+}
+
+type ListDNSProviderIntegrationsResponse []*DNSProviderIntegration
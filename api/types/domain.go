@@ -0,0 +1,123 @@
+package types
+
+import "time"
+
+// CustomDomain is a custom domain attached to a release through a Porter-managed ingress
+type CustomDomain struct {
+	// the hostname of the custom domain
+	Host string `json:"host"`
+
+	// whether a TLS certificate is requested for this domain
+	TLSEnabled bool `json:"tls_enabled"`
+
+	// the name of the secret storing the TLS certificate for this domain
+	TLSSecretName string `json:"tls_secret_name,omitempty"`
+
+	// the name of the service which traffic to this domain is routed to
+	ServiceName string `json:"service_name"`
+
+	// the port of the service which traffic to this domain is routed to
+	ServicePort int32 `json:"service_port"`
+
+	// the DNS record of this domain, if it's managed by a DNS provider integration
+	DNS *CustomDomainDNS `json:"dns,omitempty"`
+}
+
+type CustomDomainDNSStatus string
+
+const (
+	// CustomDomainDNSPending is the status of a DNS record which has been created, but which
+	// does not resolve to the ingress controller yet
+	CustomDomainDNSPending CustomDomainDNSStatus = "pending"
+
+	CustomDomainDNSActive CustomDomainDNSStatus = "active"
+
+	// CustomDomainDNSFailed is the status of a DNS record which did not propagate in time
+	CustomDomainDNSFailed CustomDomainDNSStatus = "failed"
+)
+
+// CustomDomainDNS is a DNS record of a custom domain which Porter manages through a DNS
+// provider integration. The domain is only active once the record has propagated.
+type CustomDomainDNS struct {
+	DNSProviderIntegrationID uint `json:"dns_provider_integration_id"`
+
+	// the type of the record, which is A for an ingress IP address and CNAME for an ingress
+	// load balancer hostname
+	RecordType string `json:"record_type"`
+	Value      string `json:"value"`
+
+	Status     CustomDomainDNSStatus `json:"status"`
+	Message    string                `json:"message,omitempty"`
+	VerifiedAt *time.Time            `json:"verified_at,omitempty"`
+}
+
+type ListCustomDomainsResponse []*CustomDomain
+
+type CreateCustomDomainRequest struct {
+	// the hostname to attach to the release
+	Host string `json:"host" form:"required,fqdn"`
+
+	// whether to request a TLS certificate via cert-manager for this domain
+	TLSEnabled bool `json:"tls_enabled"`
+
+	// (optional) the cert-manager cluster issuer to use, defaults to letsencrypt-prod
+	ClusterIssuer string `json:"cluster_issuer"`
+
+	// (optional) the service to route traffic to. If not set, the release's service is
+	// detected automatically.
+	ServiceName string `json:"service_name"`
+
+	// (optional) the service port to route traffic to. If not set, the first port of the
+	// service is used.
+	ServicePort int32 `json:"service_port"`
+
+	// (optional) the DNS provider integration which creates the DNS record of this domain,
+	// pointing it to the cluster's ingress controller
+	DNSProviderIntegrationID uint `json:"dns_provider_integration_id"`
+}
+
+type DeleteCustomDomainRequest struct {
+	Host string `schema:"host" form:"required"`
+}
+
+type VerifyCustomDomainRequest struct {
+	Host string `schema:"host" form:"required,fqdn"`
+}
+
+// VerifyCustomDomainResponse describes whether the DNS records for a custom domain point
+// to the cluster's ingress controller
+type VerifyCustomDomainResponse struct {
+	Host string `json:"host"`
+
+	// the external address of the cluster's ingress controller
+	Endpoint string `json:"endpoint"`
+
+	// whether the domain resolves to the ingress controller
+	Verified bool `json:"verified"`
+
+	// the CNAME record of the domain, if any
+	CNAME string `json:"cname,omitempty"`
+
+	// the addresses the domain resolves to
+	Addresses []string `json:"addresses"`
+
+	// a human-readable description of why verification failed
+	Message string `json:"message,omitempty"`
+}
+
+// IngressHostname is a hostname routed by an ingress in the cluster
+type IngressHostname struct {
+	Host        string `json:"host"`
+	Namespace   string `json:"namespace"`
+	IngressName string `json:"ingress_name"`
+
+	// the release which owns the ingress, if any
+	ReleaseName string `json:"release_name,omitempty"`
+
+	TLSEnabled bool `json:"tls_enabled"`
+
+	// whether this hostname was attached as a custom domain through Porter
+	IsCustomDomain bool `json:"is_custom_domain"`
+}
+
+type ListIngressHostnamesResponse []*IngressHostname
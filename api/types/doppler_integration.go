@@ -0,0 +1,25 @@
+package types
+
+const (
+	URLParamDopplerIntegrationID URLParam = "doppler_integration_id"
+)
+
+// DopplerIntegration is a Doppler workplace whose configs can be synced into env groups. See
+// EnvGroupSource.
+type DopplerIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Doppler workplace
+	Name string `json:"name"`
+}
+
+type CreateDopplerIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	// a service account token, or a service token if only a single config is synced
+	APIToken string `json:"api_token" form:"required"`
+}
+
+type ListDopplerIntegrationsResponse []*DopplerIntegration
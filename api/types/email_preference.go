@@ -0,0 +1,15 @@
+package types
+
+// EmailPreferences are the emails which Porter sends to a user for the projects they belong to
+type EmailPreferences struct {
+	// ImmediateFailures sends an email as soon as a deployment or a preview deployment fails
+	ImmediateFailures bool `json:"immediate_failures"`
+
+	// DailyDigest sends a daily summary of the deployments and open preview environments
+	DailyDigest bool `json:"daily_digest"`
+}
+
+type UpdateEmailPreferencesRequest struct {
+	ImmediateFailures *bool `json:"immediate_failures"`
+	DailyDigest       *bool `json:"daily_digest"`
+}
@@ -18,6 +18,7 @@ type Environment struct {
 	NewCommentsDisabled  bool              `json:"new_comments_disabled"`
 	NamespaceLabels      map[string]string `json:"namespace_labels,omitempty"`
 	GitDeployBranches    []string          `json:"git_deploy_branches"`
+	LinearIntegrationID  uint              `json:"linear_integration_id"`
 }
 
 type CreateEnvironmentRequest struct {
@@ -63,6 +64,9 @@ type Deployment struct {
 	PullRequestID      uint             `json:"pull_request_id"`
 	InstallationID     uint             `json:"gh_installation_id"`
 	LastWorkflowRunURL string           `json:"last_workflow_run_url"`
+
+	// the Jira issues referenced by the pull request and commits of the deployment
+	JiraIssues []*JiraIssue `json:"jira_issues"`
 }
 
 type CreateGHDeploymentRequest struct {
@@ -75,6 +79,8 @@ type CreateDeploymentRequest struct {
 
 	Namespace     string `json:"namespace" form:"required"`
 	PullRequestID uint   `json:"pull_request_id"`
+
+	FreezeOverride
 }
 
 type SuccessfullyDeployedResource struct {
@@ -103,10 +109,32 @@ type UpdateDeploymentRequest struct {
 	CommitSHA    string `json:"commit_sha" form:"required"`
 	PRNumber     uint   `json:"pr_number"`
 	Namespace    string `json:"namespace"`
+
+	FreezeOverride
+}
+
+// ReenableDeploymentRequest re-enables an inactive deployment
+type ReenableDeploymentRequest struct {
+	FreezeOverride
+}
+
+// TriggerDeploymentWorkflowRequest runs the workflow of a deployment again
+type TriggerDeploymentWorkflowRequest struct {
+	FreezeOverride
 }
 
 type ListDeploymentRequest struct {
-	EnvironmentID uint `schema:"environment_id"`
+	ListOptions
+
+	EnvironmentID uint     `schema:"environment_id"`
+	Status        []string `schema:"status"`
+}
+
+// ListDeploymentsResponse is the list of deployments of a cluster, along with the open pull
+// requests which don't have a deployment yet
+type ListDeploymentsResponse struct {
+	PullRequests []*PullRequest `json:"pull_requests"`
+	Deployments  []*Deployment  `json:"deployments"`
 }
 
 type UpdateDeploymentStatusRequest struct {
@@ -118,6 +146,48 @@ type UpdateDeploymentStatusRequest struct {
 	Namespace    string `json:"namespace"`
 }
 
+// CIProvider is an external CI system which reports the builds of deployments
+type CIProvider string
+
+const (
+	CIProviderJenkins   CIProvider = "jenkins"
+	CIProviderCircleCI  CIProvider = "circleci"
+	CIProviderBuildkite CIProvider = "buildkite"
+	CIProviderOther     CIProvider = "other"
+)
+
+// CIBuildStatus is the status of a build which is reported by an external CI system
+type CIBuildStatus string
+
+const (
+	CIBuildStarted   CIBuildStatus = "started"
+	CIBuildSucceeded CIBuildStatus = "succeeded"
+	CIBuildFailed    CIBuildStatus = "failed"
+)
+
+// ReportCIStatusRequest reports the status of a deployment's build from a CI system other than
+// GitHub Actions. Reports drive the same status transitions, GitHub deployment statuses and pull
+// request comments as the GitHub Actions workflow.
+type ReportCIStatusRequest struct {
+	Provider CIProvider    `json:"provider" form:"required,oneof=jenkins circleci buildkite other"`
+	Status   CIBuildStatus `json:"status" form:"required,oneof=started succeeded failed"`
+
+	// The URL of the build's logs, which is linked from the pull request
+	BuildURL string `json:"build_url" form:"omitempty,url"`
+
+	// The commit which is built, if it has changed since the deployment was created
+	CommitSHA string `json:"commit_sha"`
+
+	// The URL of the deployment, reported when the build succeeds
+	Subdomain string `json:"subdomain"`
+
+	SuccessfulResources []*SuccessfullyDeployedResource `json:"successful_resources"`
+
+	// The errors of the resources which failed to deploy, keyed by resource name, reported when
+	// the build fails
+	Errors map[string]string `json:"errors"`
+}
+
 type DeleteDeploymentRequest struct {
 	Namespace string `json:"namespace" form:"required"`
 }
@@ -139,6 +209,13 @@ type PullRequest struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// EnablePullRequestRequest creates a deployment for a pull request which has not been deployed
+type EnablePullRequestRequest struct {
+	PullRequest
+
+	FreezeOverride
+}
+
 type ToggleNewCommentRequest struct {
 	Disable bool `json:"disable"`
 }
@@ -159,4 +236,8 @@ type UpdateEnvironmentSettingsRequest struct {
 	GitRepoBranches    []string          `json:"git_repo_branches"`
 	NamespaceLabels    map[string]string `json:"namespace_labels"`
 	GitDeployBranches  []string          `json:"git_deploy_branches"`
+
+	// LinearIntegrationID sets the Linear integration which deployments are attached to, and
+	// 0 stops attaching deployments to Linear issues
+	LinearIntegrationID uint `json:"linear_integration_id"`
 }
@@ -0,0 +1,53 @@
+package types
+
+// ExecRequest runs a command in a container of a pod. The command's streams are multiplexed over
+// a websocket connection: the first byte of each message is one of the exec channels below, and
+// the rest of the message is the channel's data.
+type ExecRequest struct {
+	// (optional) the container to run the command in, defaults to the first container in the pod
+	Container string `schema:"container_name"`
+
+	Command []string `schema:"command" form:"required,min=1"`
+
+	// whether to allocate a TTY for the command, in which case stderr is written to stdout
+	TTY bool `schema:"tty"`
+}
+
+const (
+	// ExecChannelStdin carries the command's input, from the client
+	ExecChannelStdin byte = 0
+
+	// ExecChannelStdout carries the command's output, to the client
+	ExecChannelStdout byte = 1
+
+	// ExecChannelStderr carries the command's error output, to the client
+	ExecChannelStderr byte = 2
+
+	// ExecChannelStatus carries an ExecStatus as JSON, which is the last message sent to the client
+	ExecChannelStatus byte = 3
+
+	// ExecChannelResize carries an ExecResize as JSON, from the client
+	ExecChannelResize byte = 4
+
+	// ExecChannelStdinClose closes the command's input, from the client, once the client's input
+	// has been read to the end
+	ExecChannelStdinClose byte = 5
+)
+
+// ExecResize resizes the TTY of a command
+type ExecResize struct {
+	Width  uint16 `json:"width"`
+	Height uint16 `json:"height"`
+}
+
+// ExecStatus is the result of a command
+type ExecStatus struct {
+	ExitCode int `json:"exit_code"`
+
+	// the reason the command could not be run or did not complete, if any
+	Error string `json:"error,omitempty"`
+
+	// whether the command's executable does not exist in the container, which is the case
+	// for shells in containers built from distroless images
+	CommandNotFound bool `json:"command_not_found,omitempty"`
+}
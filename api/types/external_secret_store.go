@@ -0,0 +1,39 @@
+package types
+
+import "time"
+
+type ExternalSecretStoreKind string
+
+const (
+	ExternalSecretStoreKindSecretStore        ExternalSecretStoreKind = "SecretStore"
+	ExternalSecretStoreKindClusterSecretStore ExternalSecretStoreKind = "ClusterSecretStore"
+)
+
+// DefaultExternalSecretRefreshInterval is the interval at which the External Secrets Operator
+// refreshes secrets from the store, unless the store of the project sets an interval
+const DefaultExternalSecretRefreshInterval = "1h"
+
+// ExternalSecretStore is a secret store of the External Secrets Operator. When a project has an
+// external secret store, the secret variables of the env groups created in the project are
+// synced from the store by ExternalSecret resources, instead of being stored in Secrets by Porter.
+type ExternalSecretStore struct {
+	ProjectID uint `json:"project_id,omitempty"`
+
+	// Whether the store is a namespaced SecretStore, which must exist in the namespace of each
+	// env group, or a ClusterSecretStore
+	Kind ExternalSecretStoreKind `json:"kind"`
+
+	// The name of the SecretStore or ClusterSecretStore
+	Name string `json:"name"`
+
+	// The interval at which secrets are refreshed from the store, such as 15m or 1h
+	RefreshInterval string `json:"refresh_interval"`
+
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type UpdateExternalSecretStoreRequest struct {
+	Kind            ExternalSecretStoreKind `json:"kind" form:"required,oneof=SecretStore ClusterSecretStore"`
+	Name            string                  `json:"name" form:"required,dns1123"`
+	RefreshInterval string                  `json:"refresh_interval"`
+}
@@ -0,0 +1,92 @@
+package types
+
+import "time"
+
+const (
+	URLParamFeatureFlagIntegrationID URLParam = "feature_flag_integration_id"
+)
+
+type FeatureFlagProvider string
+
+const (
+	FeatureFlagProviderLaunchDarkly FeatureFlagProvider = "launchdarkly"
+	FeatureFlagProviderUnleash      FeatureFlagProvider = "unleash"
+)
+
+// FeatureFlagIntegration is a LaunchDarkly or Unleash project whose flags can be changed by
+// the upgrades of releases
+type FeatureFlagIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	Name     string              `json:"name"`
+	Provider FeatureFlagProvider `json:"provider"`
+
+	// the url of the provider's API
+	URL string `json:"url"`
+
+	// the key of the LaunchDarkly project, or the id of the Unleash project, which contains
+	// the flags
+	FlagProject string `json:"flag_project"`
+}
+
+type CreateFeatureFlagIntegrationRequest struct {
+	Name     string              `json:"name" form:"required"`
+	Provider FeatureFlagProvider `json:"provider" form:"required,oneof=launchdarkly unleash"`
+
+	// the url of the provider's API, which defaults to https://app.launchdarkly.com for
+	// launchdarkly and is required for unleash
+	URL string `json:"url" form:"omitempty,url"`
+
+	FlagProject string `json:"flag_project" form:"required"`
+
+	// a LaunchDarkly access token with the writer role, or an Unleash admin API token
+	APIToken string `json:"api_token" form:"required"`
+}
+
+type ListFeatureFlagIntegrationsResponse []*FeatureFlagIntegration
+
+// FeatureFlagChange is a flag which an upgrade turns on or off once it succeeds
+type FeatureFlagChange struct {
+	IntegrationID uint `json:"integration_id" form:"required"`
+
+	// the environment of the provider in which the flag is changed, such as production
+	Environment string `json:"environment" form:"required"`
+
+	Key     string `json:"key" form:"required"`
+	Enabled bool   `json:"enabled"`
+}
+
+type DeployFeatureFlagStatus string
+
+const (
+	// the upgrade has not finished, so the flag has not been changed yet
+	DeployFeatureFlagStatusPending DeployFeatureFlagStatus = "pending"
+
+	// the upgrade failed or was cancelled, so the flag was not changed
+	DeployFeatureFlagStatusSkipped DeployFeatureFlagStatus = "skipped"
+
+	DeployFeatureFlagStatusApplied      DeployFeatureFlagStatus = "applied"
+	DeployFeatureFlagStatusFailed       DeployFeatureFlagStatus = "failed"
+	DeployFeatureFlagStatusReverted     DeployFeatureFlagStatus = "reverted"
+	DeployFeatureFlagStatusRevertFailed DeployFeatureFlagStatus = "revert_failed"
+)
+
+// DeployFeatureFlag is the state of a flag change declared by an upgrade. Flags are changed
+// when the upgrade succeeds, and set back to their previous state when the release is rolled
+// back to a revision before the upgrade.
+type DeployFeatureFlag struct {
+	FeatureFlagChange
+
+	Status DeployFeatureFlagStatus `json:"status"`
+
+	// the state of the flag before it was changed, which it is reverted to
+	PreviousEnabled *bool `json:"previous_enabled,omitempty"`
+
+	AppliedAt  *time.Time `json:"applied_at,omitempty"`
+	RevertedAt *time.Time `json:"reverted_at,omitempty"`
+
+	// the reason the flag could not be changed or reverted
+	Error string `json:"error,omitempty"`
+}
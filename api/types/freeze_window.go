@@ -0,0 +1,59 @@
+package types
+
+import "time"
+
+const URLParamFreezeWindowID URLParam = "freeze_window_id"
+
+// FreezeWindow blocks upgrades and preview deployments in a cluster, or in some namespaces of a
+// cluster. A window either recurs on a cron schedule, lasting DurationMinutes after each start,
+// or covers a date range.
+type FreezeWindow struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+	ClusterID uint      `json:"cluster_id"`
+
+	// the namespaces which the window applies to. The window applies to every namespace of the
+	// cluster if empty.
+	Namespaces []string `json:"namespaces"`
+
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+
+	Schedule        string `json:"schedule,omitempty"`
+	DurationMinutes uint   `json:"duration_minutes,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
+
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+
+	// the users, in addition to the project admins, who can override the window in an emergency
+	OverrideUserIDs []uint `json:"override_user_ids"`
+}
+
+type CreateFreezeWindowRequest struct {
+	ClusterID  uint     `json:"cluster_id" form:"required"`
+	Namespaces []string `json:"namespaces"`
+	Name       string   `json:"name" form:"required"`
+	Reason     string   `json:"reason"`
+
+	// a cron expression such as "0 18 * * FRI", evaluated in the timezone, which is UTC if
+	// not set
+	Schedule        string `json:"schedule"`
+	DurationMinutes uint   `json:"duration_minutes"`
+	Timezone        string `json:"timezone"`
+
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+
+	OverrideUserIDs []uint `json:"override_user_ids"`
+}
+
+type ListFreezeWindowsResponse []*FreezeWindow
+
+// FreezeOverride is set by requests which deploy during a freeze window in an emergency. The
+// override is recorded in the audit log with its reason.
+type FreezeOverride struct {
+	OverrideFreeze bool   `json:"override_freeze"`
+	OverrideReason string `json:"override_reason"`
+}
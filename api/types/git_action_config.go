@@ -22,6 +22,13 @@ type GitActionConfig struct {
 
 	// The build context
 	FolderPath string `json:"folder_path"`
+
+	// The stage of a multi-stage Dockerfile to build. The final stage is built if empty.
+	DockerTarget string `json:"docker_target,omitempty"`
+
+	// The env groups in the release namespace whose variables are passed to Dockerfile
+	// builds as build args
+	BuildArgsEnvGroups []string `json:"build_args_env_groups,omitempty"`
 }
 
 // The git configuration for this new release (when deploying from a git repository)
@@ -43,6 +50,12 @@ type CreateGitActionConfigRequest struct {
 	// The path to use as the base directory in the git repository
 	FolderPath string `json:"folder_path"`
 
+	// The stage of a multi-stage Dockerfile to build
+	DockerTarget string `json:"docker_target"`
+
+	// The env groups whose variables are passed to Dockerfile builds as build args
+	BuildArgsEnvGroups []string `json:"build_args_env_groups" form:"dive,required"`
+
 	// The Github installation ID with access to the repository
 	GitRepoID uint `json:"git_repo_id"`
 
@@ -55,3 +68,17 @@ type CreateGitActionConfigRequest struct {
 	// Denotes if the Github workflow files need to be created
 	ShouldCreateWorkflow bool `json:"should_create_workflow"`
 }
+
+// The Dockerfile build options of a release deployed from a git repository
+type UpdateDockerBuildConfigRequest struct {
+	// The path to the Dockerfile in the git repository
+	// required: true
+	DockerfilePath string `json:"dockerfile_path" form:"required"`
+
+	// The stage of a multi-stage Dockerfile to build. The final stage is built if empty.
+	DockerTarget string `json:"docker_target"`
+
+	// The env groups in the release namespace whose variables are passed to the build as
+	// build args. Secret variables are never passed as build args.
+	BuildArgsEnvGroups []string `json:"build_args_env_groups" form:"dive,required"`
+}
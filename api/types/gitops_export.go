@@ -0,0 +1,52 @@
+package types
+
+import "time"
+
+// GitOpsExport is the configuration of the export of a stack to a git repository. Stacks which
+// are exported are not deployed by Porter: the manifests of each revision are committed to
+// the repository instead, to be applied by a GitOps controller such as Argo CD or Flux.
+//
+// swagger:model
+type GitOpsExport struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	GitInstallationID uint   `json:"git_installation_id"`
+	GitRepoOwner      string `json:"git_repo_owner"`
+	GitRepoName       string `json:"git_repo_name"`
+	GitBranch         string `json:"git_branch"`
+	Path              string `json:"path"`
+
+	// whether each export opens a pull request against the branch, instead of committing to
+	// the branch directly
+	PullRequest bool `json:"pull_request"`
+
+	LastExportedAt     *time.Time `json:"last_exported_at,omitempty"`
+	LastCommitSHA      string     `json:"last_commit_sha,omitempty"`
+	LastPullRequestURL string     `json:"last_pull_request_url,omitempty"`
+	LastError          string     `json:"last_error,omitempty"`
+}
+
+// swagger:model
+type PutGitOpsExportRequest struct {
+	// the id of the github app installation which has access to the repository
+	// required: true
+	GitInstallationID uint `json:"git_installation_id" form:"required"`
+
+	// required: true
+	GitRepoOwner string `json:"git_repo_owner" form:"required"`
+
+	// required: true
+	GitRepoName string `json:"git_repo_name" form:"required"`
+
+	// required: true
+	GitBranch string `json:"git_branch" form:"required"`
+
+	// the directory of the repository which the manifests are written to. Files in the directory
+	// which aren't part of the stack are deleted by each export.
+	// required: true
+	Path string `json:"path" form:"required"`
+
+	// open a pull request for each export, which is recommended for production stacks
+	PullRequest bool `json:"pull_request"`
+}
@@ -0,0 +1,40 @@
+package types
+
+const (
+	URLParamGrafanaIntegrationID URLParam = "grafana_integration_id"
+)
+
+// GrafanaIntegration is a Grafana instance which dashboards are provisioned in. Releases which
+// are created with a Grafana integration are given a dashboard of the CPU, memory and request
+// metrics of their workloads, which is linked from the release.
+type GrafanaIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Grafana instance
+	Name string `json:"name"`
+
+	// The base URL of the Grafana instance, such as https://acme.grafana.net
+	URL string `json:"url"`
+
+	// The uid of the folder which dashboards are created in
+	FolderUID string `json:"folder_uid,omitempty"`
+
+	// The uid of the Prometheus data source which dashboards query
+	DatasourceUID string `json:"datasource_uid,omitempty"`
+}
+
+type CreateGrafanaIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	URL string `json:"url" form:"required,url"`
+
+	FolderUID string `json:"folder_uid" form:"max=40"`
+
+	DatasourceUID string `json:"datasource_uid" form:"max=40"`
+
+	APIKey string `json:"api_key" form:"required"`
+}
+
+type ListGrafanaIntegrationsResponse []*GrafanaIntegration
@@ -0,0 +1,20 @@
+package types
+
+// ImageSignaturePolicy requires images deployed in a project to have a cosign signature which
+// is verified by a public key
+type ImageSignaturePolicy struct {
+	ProjectID uint `json:"project_id"`
+
+	// Whether upgrades are rejected if the image to deploy is unsigned or has an invalid
+	// signature
+	Enabled bool `json:"enabled"`
+
+	// The PEM-encoded public key used to verify image signatures, such as the cosign.pub file
+	// created by "cosign generate-key-pair"
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+type UpdateImageSignaturePolicyRequest struct {
+	Enabled   bool   `json:"enabled"`
+	PublicKey string `json:"public_key"`
+}
@@ -94,6 +94,8 @@ type CreateInfraRequest struct {
 }
 
 type ListInfraRequest struct {
+	ListOptions
+
 	Version string `schema:"version"`
 }
 
@@ -142,3 +144,11 @@ type InfraTemplate struct {
 
 	Form *FormYAML `json:"form"`
 }
+
+// InfraStateUpdate is sent over the state stream of an operation when the status of one of the
+// resources managed by the operation changes
+type InfraStateUpdate struct {
+	ResourceID string `json:"resource_id"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
@@ -0,0 +1,7 @@
+package types
+
+type ExportInstanceRequest struct {
+	// The passphrase which the export is encrypted with. It is required to import the export
+	// with "migrate import".
+	Passphrase string `json:"passphrase" form:"required,min=12"`
+}
@@ -0,0 +1,50 @@
+package types
+
+const (
+	URLParamJiraIntegrationID URLParam = "jira_integration_id"
+)
+
+// JiraIntegration is a Jira site whose issues are linked to preview deployments. Issue keys,
+// such as PORTER-123, are parsed from the title, branch and commit messages of the pull
+// request of a deployment, and a comment is posted to each linked issue whenever the
+// deployment is finalized.
+type JiraIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Jira site
+	Name string `json:"name"`
+
+	// The URL of the Jira site, such as https://acme.atlassian.net
+	BaseURL string `json:"base_url"`
+
+	// The email of the Jira user which comments are posted as
+	Email string `json:"email"`
+
+	// The keys of the Jira projects which issues are linked in. If empty, issues of any
+	// project are linked.
+	JiraProjectKeys []string `json:"jira_project_keys"`
+}
+
+type CreateJiraIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	BaseURL string `json:"base_url" form:"required,url"`
+
+	Email string `json:"email" form:"required,email"`
+
+	JiraProjectKeys []string `json:"jira_project_keys" form:"omitempty,dive,required,excludesall=0x2C"`
+
+	APIToken string `json:"api_token" form:"required"`
+}
+
+type ListJiraIntegrationsResponse []*JiraIntegration
+
+// JiraIssue is a Jira issue linked to a deployment
+type JiraIssue struct {
+	Key string `json:"key"`
+
+	// The URL of the issue, if the deployment's project has a Jira integration
+	URL string `json:"url,omitempty"`
+}
@@ -0,0 +1,26 @@
+package types
+
+const (
+	URLParamLinearIntegrationID URLParam = "linear_integration_id"
+)
+
+// LinearIntegration is a Linear workspace whose issues are linked to preview deployments.
+// When enabled for an environment, issue identifiers such as ENG-123 are parsed from the
+// branches of the environment's deployments, and the preview URL and status of each
+// deployment are attached to the referenced issues.
+type LinearIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Linear workspace
+	Name string `json:"name"`
+}
+
+type CreateLinearIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	APIKey string `json:"api_key" form:"required"`
+}
+
+type ListLinearIntegrationsResponse []*LinearIntegration
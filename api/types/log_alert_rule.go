@@ -0,0 +1,39 @@
+package types
+
+import "time"
+
+const URLParamLogAlertRuleID URLParam = "log_alert_rule_id"
+
+// LogAlertRuleKind is the kind of the involved object of the incidents opened by log alert
+// rules
+const LogAlertRuleKind = "LogAlertRule"
+
+// LogAlertRule opens an incident for a release when the logs of its pods match a pattern at
+// least a threshold number of times within a window. Rules are evaluated by the cluster's
+// relay agent, so the logs of the release are not sent to Porter.
+type LogAlertRule struct {
+	ID          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	ClusterID   uint      `json:"cluster_id"`
+	Namespace   string    `json:"namespace"`
+	ReleaseName string    `json:"release_name"`
+
+	Name string `json:"name"`
+
+	// a regular expression which is matched against each log line
+	Pattern string `json:"pattern"`
+
+	// the number of matching lines within the window which open an incident
+	Threshold uint `json:"threshold"`
+
+	WindowSeconds uint `json:"window_seconds"`
+}
+
+type CreateLogAlertRuleRequest struct {
+	Name          string `json:"name" form:"required,max=63"`
+	Pattern       string `json:"pattern" form:"required"`
+	Threshold     uint   `json:"threshold" form:"required,min=1,max=10000"`
+	WindowSeconds uint   `json:"window_seconds" form:"required,min=10,max=86400"`
+}
+
+type ListLogAlertRulesResponse []*LogAlertRule
@@ -0,0 +1,122 @@
+package types
+
+import "time"
+
+const (
+	URLParamMultiClusterReleaseID URLParam = "multi_cluster_release_id"
+	URLParamMultiClusterRolloutID URLParam = "multi_cluster_rollout_id"
+)
+
+type MultiClusterStrategy string
+
+const (
+	// MultiClusterStrategySequential upgrades one target at a time, in the order of the
+	// targets, and stops at the first target which fails
+	MultiClusterStrategySequential MultiClusterStrategy = "sequential"
+
+	// MultiClusterStrategyParallel upgrades every target at the same time
+	MultiClusterStrategyParallel MultiClusterStrategy = "parallel"
+)
+
+// MultiClusterTarget is a cluster and namespace which a multi-cluster release is deployed to.
+// The release must have the name of the multi-cluster release in every target.
+type MultiClusterTarget struct {
+	ClusterID uint   `json:"cluster_id" form:"required"`
+	Namespace string `json:"namespace" form:"required"`
+}
+
+type CreateMultiClusterReleaseRequest struct {
+	// the name of the release in each target
+	Name string `json:"name" form:"required"`
+
+	Strategy MultiClusterStrategy `json:"strategy" form:"required,oneof=sequential parallel"`
+
+	// the targets, in the order in which they are upgraded by the sequential strategy
+	Targets []MultiClusterTarget `json:"targets" form:"required,min=1,dive"`
+
+	// if set, the targets which were upgraded are rolled back when any target fails
+	RollbackOnFailure bool `json:"rollback_on_failure"`
+}
+
+type UpdateMultiClusterReleaseRequest struct {
+	Strategy          MultiClusterStrategy `json:"strategy" form:"required,oneof=sequential parallel"`
+	Targets           []MultiClusterTarget `json:"targets" form:"required,min=1,dive"`
+	RollbackOnFailure bool                 `json:"rollback_on_failure"`
+}
+
+type MultiClusterRelease struct {
+	ID                uint                  `json:"id"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+	Name              string                `json:"name"`
+	Strategy          MultiClusterStrategy  `json:"strategy"`
+	Targets           []*MultiClusterTarget `json:"targets"`
+	RollbackOnFailure bool                  `json:"rollback_on_failure"`
+}
+
+type ListMultiClusterReleasesResponse []*MultiClusterRelease
+
+type UpgradeMultiClusterReleaseRequest struct {
+	// the values of the release, as yaml, which every target is upgraded with
+	Values string `json:"values" form:"required"`
+}
+
+type MultiClusterRolloutKind string
+
+const (
+	MultiClusterRolloutUpgrade  MultiClusterRolloutKind = "upgrade"
+	MultiClusterRolloutRollback MultiClusterRolloutKind = "rollback"
+)
+
+type MultiClusterRolloutStatus string
+
+const (
+	MultiClusterRolloutRunning   MultiClusterRolloutStatus = "running"
+	MultiClusterRolloutSucceeded MultiClusterRolloutStatus = "succeeded"
+	MultiClusterRolloutFailed    MultiClusterRolloutStatus = "failed"
+
+	// MultiClusterRolloutRolledBack is the status of an upgrade which failed in a target, after
+	// which the targets which were upgraded were rolled back
+	MultiClusterRolloutRolledBack MultiClusterRolloutStatus = "rolled_back"
+)
+
+type MultiClusterRolloutTargetStatus string
+
+const (
+	MultiClusterTargetPending    MultiClusterRolloutTargetStatus = "pending"
+	MultiClusterTargetRunning    MultiClusterRolloutTargetStatus = "running"
+	MultiClusterTargetSucceeded  MultiClusterRolloutTargetStatus = "succeeded"
+	MultiClusterTargetFailed     MultiClusterRolloutTargetStatus = "failed"
+	MultiClusterTargetSkipped    MultiClusterRolloutTargetStatus = "skipped"
+	MultiClusterTargetRolledBack MultiClusterRolloutTargetStatus = "rolled_back"
+)
+
+// MultiClusterRolloutTarget is the status of a rollout in a single target
+type MultiClusterRolloutTarget struct {
+	ClusterID uint                            `json:"cluster_id"`
+	Namespace string                          `json:"namespace"`
+	Status    MultiClusterRolloutTargetStatus `json:"status"`
+
+	// the revision of the release before the rollout, which an upgrade is rolled back to
+	PreviousRevision int `json:"previous_revision,omitempty"`
+
+	// the revision of the release which the rollout created
+	Revision int `json:"revision,omitempty"`
+
+	// the reason a target failed or was skipped
+	Message string `json:"message,omitempty"`
+}
+
+// MultiClusterRollout is an upgrade or a rollback of every target of a multi-cluster release
+type MultiClusterRollout struct {
+	ID                    uint                         `json:"id"`
+	CreatedAt             time.Time                    `json:"created_at"`
+	UpdatedAt             time.Time                    `json:"updated_at"`
+	MultiClusterReleaseID uint                         `json:"multi_cluster_release_id"`
+	Kind                  MultiClusterRolloutKind      `json:"kind"`
+	Strategy              MultiClusterStrategy         `json:"strategy"`
+	Status                MultiClusterRolloutStatus    `json:"status"`
+	Targets               []*MultiClusterRolloutTarget `json:"targets"`
+}
+
+type ListMultiClusterRolloutsResponse []*MultiClusterRollout
@@ -101,6 +101,16 @@ type ConfigMapInput struct {
 	Namespace       string
 	Variables       map[string]string
 	SecretVariables map[string]string
+
+	// ExternalSecretStore is the store which the secret variables of a new env group are synced
+	// from, in which case the values of the secret variables are references to secrets in the
+	// store. It's ignored for existing env groups, which keep the store they were created with.
+	ExternalSecretStore *ExternalSecretStore
+
+	// ResolveOutput returns the value of an output of provisioned infra referenced by the
+	// templates of the variables as ${infra.ID.OUTPUT}, and whether the output is secret. If
+	// it's nil, the templates can't reference infra outputs.
+	ResolveOutput func(ref string) (value string, secret bool, err error)
 }
 
 type CreateConfigMapRequest struct {
@@ -117,6 +127,31 @@ type EnvGroup struct {
 	Namespace    string            `json:"namespace"`
 	Applications []string          `json:"applications"`
 	Variables    map[string]string `json:"variables"`
+
+	// LinkedFrom is the namespace of the env group which this env group is a copy of, if it was
+	// created for applications linked to an env group in another namespace
+	LinkedFrom string `json:"linked_from,omitempty"`
+
+	// ExternalSecretStore is the store which the secret variables of the env group are synced
+	// from by the External Secrets Operator, if any
+	ExternalSecretStore *ExternalSecretStore `json:"external_secret_store,omitempty"`
+
+	// Templates are the templates which the values of the variables were resolved from, for the
+	// variables whose values reference other variables or infra outputs
+	Templates map[string]string `json:"templates,omitempty"`
+}
+
+// EnvGroupConsumer is an application which is linked to an env group
+type EnvGroupConsumer struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Version is the version of the env group which the application is deployed with, or 0 if
+	// the application doesn't use the env group or wasn't found
+	Version uint `json:"version"`
+
+	// Status is the status of the latest revision of the application
+	Status string `json:"status"`
 }
 
 type EnvGroupMeta struct {
@@ -125,6 +160,7 @@ type EnvGroupMeta struct {
 	Version     uint      `json:"version"`
 	Name        string    `json:"name"`
 	Namespace   string    `json:"namespace"`
+	LinkedFrom  string    `json:"linked_from,omitempty"`
 }
 
 type GetEnvGroupRequest struct {
@@ -147,13 +183,252 @@ type DeleteEnvGroupRequest struct {
 	Name string `json:"name,required"`
 }
 
+type GetEnvGroupSecretsRequest struct {
+	Name    string `schema:"name,required"`
+	Version uint   `schema:"version"`
+
+	// the keys of the secret variables to read, or all secret variables if empty
+	Keys []string `schema:"keys"`
+}
+
+// GetEnvGroupSecretsResponse contains the unmasked values of the secret variables of a version of
+// an env group. Each read is recorded in the audit logs of the project.
+type GetEnvGroupSecretsResponse struct {
+	Version         uint              `json:"version"`
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
+type ListEnvGroupSecretAccessRequest struct {
+	Name  string `schema:"name,required"`
+	Limit int    `schema:"limit"`
+	Skip  int    `schema:"skip"`
+}
+
 type AddEnvGroupApplicationRequest struct {
 	Name            string `json:"name" form:"required,dns1123"`
 	ApplicationName string `json:"app_name" form:"required"`
+
+	// the namespace of the application, if it's not in the namespace of the env group
+	ApplicationNamespace string `json:"app_namespace" form:"omitempty,dns1123"`
 }
 
 type ListEnvGroupsResponse []*EnvGroupMeta
 
+// RollbackEnvGroupRequest represents the request body to roll an env group back to a previous version
+//
+// swagger:model
+type RollbackEnvGroupRequest struct {
+	// the name of the env group to roll back
+	// example: prod-env-group
+	Name string `json:"name" form:"required,dns1123"`
+
+	// the version to roll back to, which is copied into a new version of the env group
+	// example: 3
+	Version uint `json:"version" form:"required"`
+
+	// whether to redeploy the applications linked to the env group with the new version
+	RollbackApplications bool `json:"rollback_applications"`
+}
+
+// EnvGroupSourceKind is the kind of external secret store which an env group can be synced from
+type EnvGroupSourceKind string
+
+const (
+	EnvGroupSourceAWSSecretsManager EnvGroupSourceKind = "aws_secrets_manager"
+	EnvGroupSourceDoppler           EnvGroupSourceKind = "doppler"
+)
+
+// EnvGroupSource is an external secret whose values are synced into an env group
+type EnvGroupSource struct {
+	Name                 string             `json:"name"`
+	Namespace            string             `json:"namespace"`
+	Kind                 EnvGroupSourceKind `json:"kind"`
+	AWSIntegrationID     uint               `json:"aws_integration_id,omitempty"`
+	DopplerIntegrationID uint               `json:"doppler_integration_id,omitempty"`
+	SecretID             string             `json:"secret_id"`
+
+	// the URL which syncs the env group when it's called, for secret stores which support
+	// webhooks. It's only returned when the source is created.
+	WebhookURL string `json:"webhook_url,omitempty"`
+
+	// the version of the env group which the secret was last synced to
+	EnvGroupVersion uint `json:"env_group_version"`
+
+	LastSyncedAt    *time.Time `json:"last_synced_at,omitempty"`
+	LastAttemptedAt *time.Time `json:"last_attempted_at,omitempty"`
+
+	// the last time the env group was changed outside of Porter, after which it was overwritten
+	// with the values of the secret
+	DriftDetectedAt *time.Time `json:"drift_detected_at,omitempty"`
+
+	// the error of the last sync, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// CreateEnvGroupSourceRequest represents the request body to sync an env group from an external secret
+//
+// swagger:model
+type CreateEnvGroupSourceRequest struct {
+	// the name of the env group to sync, which is created if it doesn't exist
+	// example: prod-env-group
+	Name string `json:"name" form:"required,dns1123"`
+
+	// the kind of secret store, which defaults to aws_secrets_manager
+	Kind EnvGroupSourceKind `json:"kind" form:"omitempty,oneof=aws_secrets_manager doppler"`
+
+	// the AWS integration which is used to read the secret from AWS Secrets Manager, required
+	// for aws_secrets_manager sources
+	AWSIntegrationID uint `json:"aws_integration_id"`
+
+	// the Doppler integration which is used to read the config from Doppler, required for
+	// doppler sources
+	DopplerIntegrationID uint `json:"doppler_integration_id"`
+
+	// for aws_secrets_manager sources, the name or ARN of the secret, whose value must be a
+	// JSON object of keys and values. For doppler sources, the config as project/config.
+	// example: arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/web-AbCdEf
+	SecretID string `json:"secret_id" form:"required"`
+}
+
+type GetEnvGroupSourceRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type SyncEnvGroupSourceRequest struct {
+	Name string `json:"name" form:"required"`
+}
+
+type DeleteEnvGroupSourceRequest struct {
+	Name string `json:"name" form:"required"`
+}
+
+// SealedSecretScope determines whether a sealed secret can be renamed or moved to another
+// namespace after it's sealed
+type SealedSecretScope string
+
+const (
+	SealedSecretScopeStrict        SealedSecretScope = "strict"
+	SealedSecretScopeNamespaceWide SealedSecretScope = "namespace-wide"
+	SealedSecretScopeClusterWide   SealedSecretScope = "cluster-wide"
+)
+
+// SealEnvGroupRequest represents the request body to encrypt the variables of an env group into
+// a SealedSecret manifest
+//
+// swagger:model
+type SealEnvGroupRequest struct {
+	// the name of the env group
+	// example: prod-env-group
+	Name string `json:"name" form:"required"`
+
+	// the version of the env group, which defaults to the latest version
+	Version uint `json:"version"`
+
+	// the name of the secret which the sealed secret creates, which defaults to the name of the
+	// env group
+	SecretName string `json:"secret_name" form:"omitempty,dns1123"`
+
+	// the scope of the sealed secret, which defaults to strict
+	Scope SealedSecretScope `json:"scope" form:"omitempty,oneof=strict namespace-wide cluster-wide"`
+
+	// the namespace and name of the service of the sealed-secrets controller, which default to
+	// kube-system and sealed-secrets-controller
+	ControllerNamespace string `json:"controller_namespace"`
+	ControllerName      string `json:"controller_name"`
+}
+
+// SealEnvGroupResponse contains the SealedSecret manifest of an env group, which can be committed
+// to git and applied to the cluster
+type SealEnvGroupResponse struct {
+	Version  uint   `json:"version"`
+	Manifest string `json:"manifest"`
+}
+
+// RotationGeneratorKind is the kind of generator which generates the new values of a rotated
+// secret variable
+type RotationGeneratorKind string
+
+const (
+	// RotationGeneratorRandom generates random strings
+	RotationGeneratorRandom RotationGeneratorKind = "random"
+
+	// RotationGeneratorRDSPassword generates a random password and sets it as the master password
+	// of an RDS database provisioned by Porter
+	RotationGeneratorRDSPassword RotationGeneratorKind = "rds_password"
+)
+
+// RotationCharset is the set of characters of randomly generated values
+type RotationCharset string
+
+const (
+	RotationCharsetAlphanumeric RotationCharset = "alphanumeric"
+	RotationCharsetHex          RotationCharset = "hex"
+)
+
+// EnvGroupRotationPolicy rotates the value of a secret variable of an env group on a schedule
+type EnvGroupRotationPolicy struct {
+	Name         string                `json:"name"`
+	Namespace    string                `json:"namespace"`
+	Key          string                `json:"key"`
+	Generator    RotationGeneratorKind `json:"generator"`
+	IntervalDays uint                  `json:"interval_days"`
+	Length       uint                  `json:"length,omitempty"`
+	Charset      RotationCharset       `json:"charset,omitempty"`
+	InfraID      uint                  `json:"infra_id,omitempty"`
+
+	LastRotatedAt  *time.Time `json:"last_rotated_at,omitempty"`
+	NextRotationAt time.Time  `json:"next_rotation_at"`
+
+	// the error of the last rotation, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// CreateEnvGroupRotationPolicyRequest represents the request body to create or update the rotation
+// policy of a secret variable of an env group
+//
+// swagger:model
+type CreateEnvGroupRotationPolicyRequest struct {
+	// the name of the env group
+	// example: prod-env-group
+	Name string `json:"name" form:"required"`
+
+	// the secret variable to rotate
+	// example: DB_PASSWORD
+	Key string `json:"key" form:"required"`
+
+	// the generator of the new values, which defaults to random
+	Generator RotationGeneratorKind `json:"generator" form:"omitempty,oneof=random rds_password"`
+
+	// the number of days between rotations
+	// example: 30
+	IntervalDays uint `json:"interval_days" form:"required,min=1"`
+
+	// the length of generated values, which defaults to 32
+	Length uint `json:"length" form:"omitempty,min=8,max=256"`
+
+	// the characters of values generated by the random generator, which defaults to alphanumeric
+	Charset RotationCharset `json:"charset" form:"omitempty,oneof=alphanumeric hex"`
+
+	// the RDS infra whose master password is rotated by the rds_password generator
+	InfraID uint `json:"infra_id"`
+}
+
+type ListEnvGroupRotationPoliciesRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type ListEnvGroupRotationPoliciesResponse []*EnvGroupRotationPolicy
+
+type RotateEnvGroupSecretRequest struct {
+	Name string `json:"name" form:"required"`
+	Key  string `json:"key" form:"required"`
+}
+
+type DeleteEnvGroupRotationPolicyRequest struct {
+	Name string `json:"name" form:"required"`
+	Key  string `json:"key" form:"required"`
+}
+
 // CreateEnvGroupRequest represents the request body to create or update an env group
 //
 // swagger:model
@@ -167,6 +442,33 @@ type CreateEnvGroupRequest struct {
 
 	// the secret variables to include in the env group
 	SecretVariables map[string]string `json:"secret_variables"`
+
+	// whether to skip redeploying the applications linked to the env group. The new version is
+	// still copied to the namespaces of linked applications in other namespaces.
+	SkipRestart bool `json:"skip_restart"`
+}
+
+// PreviewEnvGroupRequest is a new version of an env group whose templates are resolved without
+// creating the version
+type PreviewEnvGroupRequest struct {
+	Name string `json:"name" form:"required,dns1123"`
+
+	// the variables and secret variables as they would be sent to create the version. Secret
+	// variables of the latest version are carried over as when creating the version.
+	Variables       map[string]string `json:"variables" form:"required"`
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
+type PreviewEnvGroupResponse struct {
+	// the variables with their templates resolved
+	Variables map[string]string `json:"variables"`
+
+	// the templates of the variables which reference other variables or infra outputs
+	Templates map[string]string `json:"templates"`
+
+	// the keys of the secret variables, whose templates were resolved but whose values aren't
+	// returned
+	SecretKeys []string `json:"secret_keys"`
 }
 
 type CreateConfigMapResponse struct {
@@ -177,6 +479,9 @@ type UpdateConfigMapRequest struct {
 	Name            string            `json:"name,required"`
 	Variables       map[string]string `json:"variables,required"`
 	SecretVariables map[string]string `json:"secret_variables,required"`
+
+	// whether to trigger a rolling restart of workloads which reference the linked secret
+	RestartWorkloads bool `json:"restart_workloads"`
 }
 
 type UpdateConfigMapResponse struct {
@@ -198,6 +503,45 @@ type DeleteConfigMapRequest struct {
 
 type GetPodLogsRequest struct {
 	Container string `schema:"container_name"`
+
+	// if set, the logs of the previous instance of the container are streamed, which is
+	// useful after the container has crashed
+	Previous bool `schema:"previous"`
+}
+
+// StreamLogsRequest streams the aggregated logs of the pods which match a label selector
+type StreamLogsRequest struct {
+	Selector string `schema:"selector" form:"required"`
+
+	// if set, only the logs of containers with this name are streamed. Otherwise, the logs of
+	// every app container of each pod are streamed.
+	Container string `schema:"container_name"`
+
+	Follow bool `schema:"follow"`
+
+	// if set, only logs written after this time are streamed
+	SinceTime *time.Time `schema:"since_time"`
+
+	// if set, only logs written in this many seconds are streamed
+	SinceSeconds int64 `schema:"since_seconds" form:"min=0"`
+
+	// the number of lines from the end of each container's logs to stream, 100 by default
+	TailLines int64 `schema:"tail_lines" form:"min=0"`
+}
+
+// PodLogLine is a line of a container's logs, which is sent as a JSON message by the aggregated
+// log stream
+type PodLogLine struct {
+	Pod       string     `json:"pod"`
+	Container string     `json:"container"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Line      string     `json:"line"`
+}
+
+// PortForwardRequest forwards a connection to a port of a pod. Each websocket connection carries
+// a single TCP connection, whose data is sent in both directions as binary messages.
+type PortForwardRequest struct {
+	Port uint16 `schema:"port" form:"required"`
 }
 
 type GetPreviousPodLogsRequest struct {
@@ -208,6 +552,35 @@ type GetPreviousPodLogsResponse struct {
 	PrevLogs []string `json:"previous_logs"`
 }
 
+type PodContainerType string
+
+const (
+	PodContainerTypeInit      PodContainerType = "init"
+	PodContainerTypeApp       PodContainerType = "app"
+	PodContainerTypeEphemeral PodContainerType = "ephemeral"
+)
+
+// PodContainer is a container in a pod whose logs can be read
+type PodContainer struct {
+	Name  string           `json:"name"`
+	Type  PodContainerType `json:"type"`
+	Image string           `json:"image"`
+
+	// the state of the container: one of waiting, running or terminated
+	State string `json:"state"`
+
+	// the reason the container is waiting or terminated, if any
+	Reason string `json:"reason,omitempty"`
+
+	Ready        bool  `json:"ready"`
+	RestartCount int32 `json:"restart_count"`
+
+	// whether logs from a previous instance of the container are available
+	HasPreviousLogs bool `json:"has_previous_logs"`
+}
+
+type ListPodContainersResponse []*PodContainer
+
 type GetJobsRequest struct {
 	Revision uint `schema:"revision"`
 }
@@ -224,6 +597,9 @@ type StreamJobRunsRequest struct {
 type GetEnvGroupResponse struct {
 	*EnvGroup
 	StackID string `json:"stack_id,omitempty"`
+
+	// the applications linked to the env group, across namespaces
+	Consumers []*EnvGroupConsumer `json:"consumers"`
 }
 
 // V1EnvGroupReleaseRequest represents the request body to add or remove a release in an env group
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"time"
+
+	netv1 "k8s.io/api/networking/v1"
+)
+
+const URLParamNetworkPolicyName URLParam = "name"
+
+type NetworkPolicyPreset string
+
+const (
+	// NetworkPolicyPresetDenyAll blocks all ingress traffic to pods in the namespace
+	NetworkPolicyPresetDenyAll NetworkPolicyPreset = "deny-all"
+
+	// NetworkPolicyPresetNamespaceIsolated only allows ingress traffic from pods in the same namespace
+	NetworkPolicyPresetNamespaceIsolated NetworkPolicyPreset = "namespace-isolated"
+
+	// NetworkPolicyPresetAllowFromIngress allows ingress traffic from pods in the same namespace
+	// and from the cluster's ingress controller
+	NetworkPolicyPresetAllowFromIngress NetworkPolicyPreset = "allow-from-ingress"
+)
+
+type NetworkPolicy struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// the preset this policy was created from, empty for custom policies
+	Preset NetworkPolicyPreset `json:"preset,omitempty"`
+
+	Spec      netv1.NetworkPolicySpec `json:"spec"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+type ListNetworkPoliciesResponse []*NetworkPolicy
+
+type ApplyNetworkPolicyRequest struct {
+	// the preset profile to apply. Either a preset or a custom spec must be set.
+	Preset NetworkPolicyPreset `json:"preset" form:"omitempty,oneof=deny-all namespace-isolated allow-from-ingress"`
+
+	// the name of a custom policy, required if spec is set
+	Name string `json:"name" form:"omitempty,dns1123"`
+
+	// the spec of a custom policy
+	Spec *netv1.NetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// BlockedConnection is a connection between two workloads which is allowed by the current
+// network policies, but would be blocked after applying a policy
+type BlockedConnection struct {
+	SourceNamespace string `json:"source_namespace"`
+
+	// the release or pod name of the source workload
+	SourceWorkload string `json:"source_workload"`
+
+	DestinationNamespace string `json:"destination_namespace"`
+	DestinationService   string `json:"destination_service"`
+	DestinationPort      int32  `json:"destination_port"`
+
+	// whether the connection is blocked by an ingress or egress rule
+	Direction string `json:"direction"`
+}
+
+type PreviewNetworkPolicyResponse struct {
+	Policy             *NetworkPolicy       `json:"policy"`
+	BlockedConnections []*BlockedConnection `json:"blocked_connections"`
+}
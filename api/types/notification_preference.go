@@ -0,0 +1,85 @@
+package types
+
+// NotificationChannel is a channel through which Porter sends notifications
+type NotificationChannel string
+
+const (
+	NotificationChannelSlack   NotificationChannel = "slack"
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+)
+
+// NotificationChannels are the channels which notification preferences can be set for
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelSlack,
+	NotificationChannelEmail,
+	NotificationChannelWebhook,
+}
+
+// NotificationEvent is a type of event which notifications are sent for
+type NotificationEvent string
+
+const (
+	NotificationEventDeploySucceeded NotificationEvent = "deploy_succeeded"
+	NotificationEventDeployFailed    NotificationEvent = "deploy_failed"
+	NotificationEventIncident        NotificationEvent = "incident"
+)
+
+// NotificationSeverity is the severity of a notification event. Severities are ordered from
+// info to critical.
+type NotificationSeverity string
+
+const (
+	NotificationSeverityInfo     NotificationSeverity = "info"
+	NotificationSeverityWarning  NotificationSeverity = "warning"
+	NotificationSeverityCritical NotificationSeverity = "critical"
+)
+
+// NotificationEventSeverities are the severities of each type of notification event
+var NotificationEventSeverities = map[NotificationEvent]NotificationSeverity{
+	NotificationEventDeploySucceeded: NotificationSeverityInfo,
+	NotificationEventIncident:        NotificationSeverityWarning,
+	NotificationEventDeployFailed:    NotificationSeverityCritical,
+}
+
+// QuietHours is a daily period during which only critical notifications are sent. Periods which
+// end before they start, such as 22:00 to 07:00, span midnight.
+type QuietHours struct {
+	// Start is the time at which the quiet hours start, in HH:MM form
+	Start string `json:"start" form:"required"`
+
+	// End is the time at which the quiet hours end, in HH:MM form
+	End string `json:"end" form:"required"`
+
+	// Timezone is the IANA timezone of the start and end times, such as America/New_York. The
+	// times are in UTC if the timezone is empty.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// NotificationPreference configures which events are sent through a notification channel. A
+// project's preferences apply to every notification of the project, while a user's preferences
+// in a project only apply to the emails which are sent to them.
+type NotificationPreference struct {
+	Channel NotificationChannel `json:"channel"`
+
+	// Events are the types of events which are sent. Every type of event is sent if empty.
+	Events []NotificationEvent `json:"events"`
+
+	// MinSeverity is the lowest severity of the events which are sent
+	MinSeverity NotificationSeverity `json:"min_severity"`
+
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	Channel NotificationChannel `json:"channel" form:"required,oneof=slack email webhook"`
+
+	Events []NotificationEvent `json:"events" form:"dive,oneof=deploy_succeeded deploy_failed incident"`
+
+	MinSeverity NotificationSeverity `json:"min_severity" form:"omitempty,oneof=info warning critical"`
+
+	// QuietHours are removed if they are not set
+	QuietHours *QuietHours `json:"quiet_hours"`
+}
+
+type ListNotificationPreferencesResponse []*NotificationPreference
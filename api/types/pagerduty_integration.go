@@ -0,0 +1,37 @@
+package types
+
+const (
+	URLParamPagerDutyIntegrationID URLParam = "pagerduty_integration_id"
+)
+
+// PagerDutyIntegration maps the releases of a cluster, and optionally of a single namespace, to a
+// PagerDuty service. Failed upgrades and sustained crash loops of matching releases open
+// incidents on the service.
+type PagerDutyIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the PagerDuty service
+	Name string `json:"name"`
+
+	// The cluster whose releases are mapped to the service. If 0, releases of every
+	// cluster in the project are mapped.
+	ClusterID uint `json:"cluster_id"`
+
+	// The namespace whose releases are mapped to the service. If empty, releases of every
+	// namespace are mapped.
+	Namespace string `json:"namespace"`
+}
+
+type CreatePagerDutyIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	ClusterID uint   `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+
+	// The integration key of an Events API v2 integration on the PagerDuty service
+	RoutingKey string `json:"routing_key" form:"required,len=32,alphanum"`
+}
+
+type ListPagerDutyIntegrationsResponse []*PagerDutyIntegration
@@ -0,0 +1,29 @@
+package types
+
+type DownloadPodFilesRequest struct {
+	// (optional) the container to copy files from, defaults to the first container in the pod
+	Container string `schema:"container"`
+
+	// the absolute path of the file or directory to copy
+	Path string `schema:"path" form:"required"`
+}
+
+type UploadPodFilesRequest struct {
+	// (optional) the container to copy files to, defaults to the first container in the pod
+	Container string `json:"container"`
+
+	// the absolute path of the directory to extract the archive into, which is created if
+	// it does not exist
+	Path string `json:"path" form:"required"`
+
+	// the base64-encoded tar archive of the files to copy
+	Archive []byte `json:"archive" form:"required"`
+}
+
+type UploadPodFilesResponse struct {
+	Path string `json:"path"`
+
+	// the number of files and the total size of the files extracted from the archive
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
@@ -0,0 +1,21 @@
+package types
+
+// PullThroughCacheRule rewrites the images of an upstream registry to be pulled through a
+// cache. For example, with an upstream registry of "docker.io" and a mirror prefix of
+// "123456789012.dkr.ecr.us-east-1.amazonaws.com/docker-hub", the image "nginx:1.23" is
+// deployed as "123456789012.dkr.ecr.us-east-1.amazonaws.com/docker-hub/library/nginx:1.23".
+type PullThroughCacheRule struct {
+	// The host of the upstream registry, such as docker.io, ghcr.io or quay.io
+	UpstreamRegistry string `json:"upstream_registry" form:"required,max=255"`
+
+	// The registry host and optional path which replaces the upstream registry host
+	MirrorPrefix string `json:"mirror_prefix" form:"required,max=255"`
+}
+
+type ListPullThroughCacheRulesResponse []*PullThroughCacheRule
+
+type UpdatePullThroughCacheRulesRequest struct {
+	// The rules of the cluster, which replace any existing rules. An empty list disables
+	// the pull through cache.
+	Rules []*PullThroughCacheRule `json:"rules" form:"dive,required"`
+}
@@ -0,0 +1,51 @@
+package types
+
+// ResourceQuota is a resource quota in a namespace, along with the current consumption of
+// each quota-limited resource
+type ResourceQuota struct {
+	Name string `json:"name"`
+
+	// whether the quota is managed through Porter
+	Managed bool `json:"managed"`
+
+	// the hard limits of the quota, keyed by resource name (e.g. requests.cpu)
+	Hard map[string]string `json:"hard"`
+
+	// the current consumption of each limited resource
+	Used map[string]string `json:"used"`
+}
+
+type LimitRangeItem struct {
+	// the kind of object the limits apply to: one of Container, Pod or PersistentVolumeClaim
+	Type string `json:"type" form:"required,oneof=Container Pod PersistentVolumeClaim"`
+
+	Max            map[string]string `json:"max,omitempty"`
+	Min            map[string]string `json:"min,omitempty"`
+	Default        map[string]string `json:"default,omitempty"`
+	DefaultRequest map[string]string `json:"default_request,omitempty"`
+}
+
+// LimitRange is a limit range in a namespace, which constrains and sets defaults for the
+// resources of individual containers, pods and volume claims
+type LimitRange struct {
+	Name string `json:"name"`
+
+	// whether the limit range is managed through Porter
+	Managed bool `json:"managed"`
+
+	Limits []LimitRangeItem `json:"limits"`
+}
+
+type GetNamespaceQuotasResponse struct {
+	ResourceQuotas []*ResourceQuota `json:"resource_quotas"`
+	LimitRanges    []*LimitRange    `json:"limit_ranges"`
+}
+
+type UpdateResourceQuotaRequest struct {
+	// the hard limits of the quota, for example {"requests.cpu": "4", "pods": "20"}
+	Hard map[string]string `json:"hard" form:"required"`
+}
+
+type UpdateLimitRangeRequest struct {
+	Limits []LimitRangeItem `json:"limits" form:"required,dive"`
+}
@@ -26,7 +26,7 @@ type Registry struct {
 	URL string `json:"url"`
 
 	// The integration service for this registry
-	// enum: gcr,gar,ecr,acr,docr,dockerhub
+	// enum: gcr,gar,ecr,acr,docr,dockerhub,harbor
 	// example: ecr
 	Service string `json:"service"`
 
@@ -90,6 +90,17 @@ type Image struct {
 
 	// When the image was pushed
 	PushedAt *time.Time `json:"pushed_at"`
+
+	// The compressed size of the image in bytes, if reported by the registry. This is not
+	// set for multi-architecture images.
+	Size int64 `json:"size,omitempty"`
+
+	// The CPU architectures that the image was built for, such as "amd64" or "arm64"
+	Architectures []string `json:"architectures,omitempty"`
+
+	// The vulnerabilities found by the registry's scanner, if the registry reports them
+	// when listing images (**Harbor only**)
+	Vulnerabilities *ImageVulnerabilitySummary `json:"vulnerabilities,omitempty"`
 }
 
 // Type of registry service
@@ -102,6 +113,8 @@ const (
 	ACR       RegistryService = "acr"
 	DOCR      RegistryService = "docr"
 	DockerHub RegistryService = "dockerhub"
+	Harbor    RegistryService = "harbor"
+	Quay      RegistryService = "quay"
 )
 
 // swagger:model ListRegistriesResponse
@@ -143,6 +156,17 @@ type CreateRegistryRequest struct {
 	// example: 0
 	AzureIntegrationID uint `json:"azure_integration_id"`
 
+	// The registry service, used to select the API for registries connected with a basic
+	// integration. For Docker Hub, the URL may be a Docker Hub namespace, such as
+	// "my-org", or a single repository, such as "my-org/my-app". For Harbor, the URL must
+	// include the Harbor project, such as "harbor.example.com/my-project", and the basic
+	// integration should hold robot account credentials. For Quay, the URL is a Quay
+	// namespace, such as "quay.io/my-org", and the basic integration should hold either a
+	// robot account or an OAuth token with the username "$oauthtoken".
+	// enum: dockerhub,harbor,quay
+	// example: dockerhub
+	Service RegistryService `json:"service"`
+
 	// Additional Azure-specific fields
 
 	// ACR resource group name (**Azure only**)
@@ -210,9 +234,167 @@ type V1ListImageResponse struct {
 	// The list of repository images with tags
 	Images []*Image `json:"images" form:"required"`
 
-	// The next page number used for pagination (**DigitalOcean only**)
+	// The next page number used for pagination (**all registries except ECR**)
 	NextPage uint `json:"next_page,omitempty"`
 
 	// The next page cursor used for pagination
 	Next string `json:"next,omitempty"`
 }
+
+type ListRegistryBuildTriggersRequest struct {
+	// The repository to list build triggers for, such as "my-org/my-app"
+	Repository string `schema:"repository" form:"required"`
+}
+
+// BuildTrigger is a trigger configured in the registry which builds images for a repository
+// when changes are pushed to a source repository (**Quay only**)
+type BuildTrigger struct {
+	ID string `json:"id"`
+
+	// The source control service of the trigger, such as "github"
+	Service string `json:"service"`
+
+	// The source repository that the trigger builds from
+	BuildSource string `json:"build_source"`
+
+	Active bool `json:"active"`
+
+	// The phase of the most recent build started by the trigger, such as "complete" or "error"
+	LastBuildPhase string `json:"last_build_phase,omitempty"`
+
+	// When the most recent build started by the trigger started
+	LastBuildStartedAt *time.Time `json:"last_build_started_at,omitempty"`
+}
+
+type ListRegistryBuildTriggersResponse []*BuildTrigger
+
+type CreateRegistryWebhookResponse struct {
+	// The URL which the registry should send push events to. Creating a new webhook
+	// invalidates the URL of any previous webhook for this registry.
+	WebhookURL string `json:"webhook_url"`
+}
+
+// RegistryPushDeployment is the result of upgrading a release after a tag was pushed
+type RegistryPushDeployment struct {
+	ClusterID uint   `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// The full image reference which was deployed
+	Image string `json:"image"`
+
+	// Set if the upgrade waits for an approval
+	ApprovalID uint `json:"approval_id,omitempty"`
+
+	// Set if the upgrade waits in the deploy queue of the release
+	QueuedDeployID uint `json:"queued_deploy_id,omitempty"`
+
+	// Set if the release could not be upgraded
+	Error string `json:"error,omitempty"`
+}
+
+type RegistryPushWebhookResponse struct {
+	Deployments []*RegistryPushDeployment `json:"deployments"`
+}
+
+// RegistryCredentialStatus is the status of the image pull secrets of a registry in a
+// cluster, which are periodically regenerated for registries with short-lived credentials
+type RegistryCredentialStatus struct {
+	RegistryID   uint   `json:"registry_id"`
+	RegistryName string `json:"registry_name"`
+
+	// The number of namespaces whose pull secrets were regenerated during the last refresh
+	Namespaces uint `json:"namespaces"`
+
+	// When the pull secrets were last refreshed successfully
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty"`
+
+	// When the last refresh was attempted
+	LastAttemptedAt time.Time `json:"last_attempted_at"`
+
+	// Set if the last refresh failed
+	Error string `json:"error,omitempty"`
+}
+
+type ListRegistryCredentialStatusResponse []*RegistryCredentialStatus
+
+type PromoteImageRequest struct {
+	// The repository of the image in the source registry
+	SourceRepository string `json:"source_repository" form:"required"`
+
+	// The digest of the image to promote, of the form sha256:<hex>
+	Digest string `json:"digest" form:"required"`
+
+	// The registry to copy the image to, which must be linked to the same project
+	TargetRegistryID uint `json:"target_registry_id" form:"required"`
+
+	// The repository to copy the image to. Defaults to the source repository.
+	TargetRepository string `json:"target_repository"`
+
+	// An optional tag for the promoted image in the target repository
+	Tag string `json:"tag"`
+}
+
+type PromoteImageResponse struct {
+	// The reference of the promoted image in the target registry
+	Image  string `json:"image"`
+	Digest string `json:"digest"`
+}
+
+// ImagePlatform is a platform which an image was built for
+type ImagePlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+type GetImagePlatformsRequest struct {
+	// The name of the repository in the registry
+	Repository string `schema:"repository" form:"required"`
+
+	// The tag or digest of the image
+	Reference string `schema:"reference" form:"required"`
+}
+
+type GetImagePlatformsResponse struct {
+	// The full image reference
+	Image string `json:"image"`
+
+	// The platforms supported by the image. Multi-architecture images list a platform for
+	// each image in their manifest list.
+	Platforms []*ImagePlatform `json:"platforms"`
+}
+
+type GetRegistryUsageRequest struct {
+	// The number of largest repositories to return, 10 by default
+	Limit uint `schema:"limit" form:"max=100"`
+}
+
+// RegistryUsage summarizes the repositories and storage used by a registry
+type RegistryUsage struct {
+	RegistryID uint `json:"registry_id"`
+
+	RepositoryCount int `json:"repository_count"`
+	ImageCount      int `json:"image_count"`
+
+	// Whether the registry reports image sizes. If false, sizes are not set.
+	StorageReported bool `json:"storage_reported"`
+
+	// The total compressed size of the images in the registry, in bytes. Layers shared between
+	// images are counted once per image, so this may exceed the storage billed by the provider.
+	TotalSizeBytes int64 `json:"total_size_bytes,omitempty"`
+
+	// The largest repositories by size, or by image count if sizes are not reported
+	LargestRepositories []*RepositoryUsage `json:"largest_repositories"`
+
+	// The repositories whose images could not be listed, which are not included in the totals
+	FailedRepositories []string `json:"failed_repositories,omitempty"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+type RepositoryUsage struct {
+	Name       string `json:"name"`
+	ImageCount int    `json:"image_count"`
+	SizeBytes  int64  `json:"size_bytes,omitempty"`
+}
@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+// RegistryRetentionPolicy holds the rules used to delete old images from the repositories of
+// a registry
+type RegistryRetentionPolicy struct {
+	RegistryID uint `json:"registry_id"`
+
+	// Whether the policy is enforced by the retention worker. Disabled policies can still be
+	// previewed with a dry run.
+	Enabled bool `json:"enabled"`
+
+	// The number of most recently pushed tags to keep in each repository. Tags beyond this
+	// number are deleted unless they are protected. If 0, tags are not deleted by count.
+	KeepLastTags uint `json:"keep_last_tags"`
+
+	// The number of days after which untagged images are deleted. If 0, untagged images are
+	// not deleted.
+	DeleteUntaggedAfterDays uint `json:"delete_untagged_after_days"`
+
+	// A regular expression matching tags which are never deleted, such as "^v[0-9]+\\."
+	ProtectedTagPattern string `json:"protected_tag_pattern,omitempty"`
+
+	// When the policy was last enforced by the retention worker
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+}
+
+type UpdateRegistryRetentionPolicyRequest struct {
+	Enabled                 bool   `json:"enabled"`
+	KeepLastTags            uint   `json:"keep_last_tags" form:"max=1000"`
+	DeleteUntaggedAfterDays uint   `json:"delete_untagged_after_days" form:"max=3650"`
+	ProtectedTagPattern     string `json:"protected_tag_pattern"`
+}
+
+type RetentionDeletionReason string
+
+const (
+	RetentionDeletionReasonTagCount RetentionDeletionReason = "tag_count"
+	RetentionDeletionReasonUntagged RetentionDeletionReason = "untagged"
+)
+
+// RetentionImageDeletion is an image selected for deletion by a retention policy. Untagged
+// images have an empty tag.
+type RetentionImageDeletion struct {
+	Tag      string                  `json:"tag,omitempty"`
+	Digest   string                  `json:"digest"`
+	PushedAt *time.Time              `json:"pushed_at,omitempty"`
+	Reason   RetentionDeletionReason `json:"reason"`
+}
+
+type RetentionRepositoryReport struct {
+	Repository string                    `json:"repository"`
+	Deletions  []*RetentionImageDeletion `json:"deletions"`
+
+	// The number of images kept in the repository
+	Kept int `json:"kept"`
+
+	// Set if the images of the repository could not be listed or deleted
+	Error string `json:"error,omitempty"`
+}
+
+// RegistryRetentionReport lists the images deleted, or which would be deleted during a dry
+// run, by enforcing a retention policy
+type RegistryRetentionReport struct {
+	RegistryID   uint                         `json:"registry_id"`
+	DryRun       bool                         `json:"dry_run"`
+	GeneratedAt  time.Time                    `json:"generated_at"`
+	Repositories []*RetentionRepositoryReport `json:"repositories"`
+}
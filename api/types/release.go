@@ -12,6 +12,14 @@ type Release struct {
 	*PorterRelease
 
 	Form *FormYAML `json:"form,omitempty"`
+
+	// The vulnerability summaries of the images deployed by this release, if a scanner
+	// is available
+	Vulnerabilities []*ImageVulnerabilitySummary `json:"vulnerabilities,omitempty"`
+
+	// The most recent Velero backup of this release, if Velero is installed and the release
+	// has been backed up
+	LatestBackup *Backup `json:"latest_backup,omitempty"`
 }
 
 type PorterRelease struct {
@@ -41,6 +49,45 @@ type PorterRelease struct {
 
 	// The canonical name of this release
 	CanonicalName string `json:"canonical_name"`
+
+	// Whether upgrades which deploy images with critical vulnerabilities are rejected
+	BlockCriticalVulnerabilities bool `json:"block_critical_vulnerabilities"`
+
+	// Whether the release is upgraded when a new tag is pushed to its image repository
+	PushDeployEnabled bool `json:"push_deploy_enabled"`
+
+	// A regular expression which pushed tags must match to be deployed. All tags are
+	// deployed if empty.
+	PushDeployTagPattern string `json:"push_deploy_tag_pattern,omitempty"`
+
+	// Whether upgrades which deploy images that support none of the architectures of the
+	// cluster's nodes are rejected, rather than allowed with a warning
+	BlockArchitectureMismatch bool `json:"block_architecture_mismatch"`
+
+	// The URL of the Grafana dashboard of this release, if one was provisioned
+	GrafanaDashboardURL string `json:"grafana_dashboard_url,omitempty"`
+
+	// Whether the ingresses of this release are routed to a maintenance page
+	MaintenanceMode bool `json:"maintenance_mode"`
+}
+
+type UpdatePushDeployPolicyRequest struct {
+	Enabled    bool   `json:"enabled"`
+	TagPattern string `json:"tag_pattern"`
+}
+
+type UpdateArchitecturePolicyRequest struct {
+	// whether upgrades which deploy images that support none of the architectures of the
+	// cluster's nodes should be rejected
+	BlockArchitectureMismatch bool `json:"block_architecture_mismatch"`
+}
+
+type UpdateMaintenanceModeRequest struct {
+	Enabled bool `json:"enabled"`
+
+	// (optional) the HTML of the maintenance page, which defaults to a generic page. Only used
+	// when maintenance mode is enabled.
+	PageHTML string `json:"page_html"`
 }
 
 // swagger:model
@@ -93,6 +140,10 @@ type CreateReleaseRequest struct {
 
 	// The list of synced environment groups for this release
 	SyncedEnvGroups []string `json:"synced_env_groups,omitempty"`
+
+	// The ID of a Grafana integration which a dashboard of the release's metrics is
+	// provisioned in, if set
+	GrafanaIntegrationID uint `json:"grafana_integration_id,omitempty"`
 }
 
 type CreateAddonRequest struct {
@@ -117,6 +168,8 @@ type V1UpgradeReleaseRequest struct {
 	// (optional) if set, the backend will validate that the user was upgrading from the revision specified by
 	// LatestRevision, and there hasn't been an upgrade in the meantime.
 	LatestRevision uint `json:"latest_revision"`
+
+	FreezeOverride
 }
 
 type UpgradeReleaseRequest struct {
@@ -126,6 +179,29 @@ type UpgradeReleaseRequest struct {
 	// (optional) if set, the backend will validate that the user was upgrading from the revision specified by
 	// LatestRevision, and there hasn't been an upgrade in the meantime.
 	LatestRevision uint `json:"latest_revision"`
+
+	// (optional) feature flags which are changed once the upgrade succeeds, and reverted when
+	// the release is rolled back to a revision before the upgrade
+	FeatureFlags []*FeatureFlagChange `json:"feature_flags,omitempty" form:"omitempty,dive,required"`
+
+	FreezeOverride
+}
+
+// TemplateUpgradeResponse is the release which an upgrade would produce, rendered without changing
+// the release
+type TemplateUpgradeResponse struct {
+	ChartVersion string                 `json:"chart_version"`
+	Values       map[string]interface{} `json:"values"`
+	Manifest     string                 `json:"manifest"`
+}
+
+// DiffUpgradeResponse is the difference between a release and the release which an upgrade would
+// produce. The diffs are unified diffs, which are empty if nothing changes.
+type DiffUpgradeResponse struct {
+	FromChartVersion string `json:"from_chart_version"`
+	ToChartVersion   string `json:"to_chart_version"`
+	ValuesDiff       string `json:"values_diff"`
+	ManifestDiff     string `json:"manifest_diff"`
 }
 
 type UpdateImageBatchRequest struct {
@@ -138,6 +214,26 @@ type GetJobsStatusResponse struct {
 	StartTime *metav1.Time `json:"start_time,omitempty"`
 }
 
+// RunJobRequest runs a one-off job from the pod template of a release
+type RunJobRequest struct {
+	// the command to run, which replaces the entrypoint of the container
+	Command []string `json:"command" form:"required,min=1"`
+
+	// environment variables which are set in addition to, or in place of, the container's variables
+	Env map[string]string `json:"env"`
+
+	// the container of the pod template to run the command in, which defaults to the first container
+	Container string `json:"container_name"`
+}
+
+type RunJobResponse struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// the name of the container in the job's pod which runs the command
+	Container string `json:"container_name"`
+}
+
 const URLParamToken URLParam = "token"
 
 type WebhookRequest struct {
@@ -148,6 +244,13 @@ type WebhookRequest struct {
 	Repository string `schema:"repository"`
 }
 
+type GetReleaseHistoryRequest struct {
+	// IncludePruned adds the revisions which were pruned from the helm history, from the deploys
+	// recorded by Porter. Deploys are only recorded for a week, and pruned revisions only have
+	// a version and an info, since their chart and values are no longer stored.
+	IncludePruned bool `schema:"include_pruned"`
+}
+
 type GetGHATemplateRequest struct {
 	ReleaseName        string                        `json:"release_name"`
 	GithubActionConfig *CreateGitActionConfigRequest `json:"github_action_config" form:"required"`
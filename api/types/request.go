@@ -82,3 +82,16 @@ type RequestAction struct {
 }
 
 var RequestCtxWebsocketKey = "websocket"
+
+// NextCursorHeader is the response header which contains the cursor of the next page of
+// a paginated list endpoint
+const NextCursorHeader = "X-Next-Cursor"
+
+// ListOptions are the query parameters used to sort and paginate list endpoints. When limit is
+// set, the cursor of the next page is returned in the NextCursorHeader header.
+type ListOptions struct {
+	Limit  int    `schema:"limit" form:"omitempty,min=0,max=1000"`
+	Cursor string `schema:"cursor"`
+	SortBy string `schema:"sort_by" form:"omitempty,oneof=created_at updated_at"`
+	Order  string `schema:"order" form:"omitempty,oneof=asc desc"`
+}
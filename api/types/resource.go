@@ -0,0 +1,30 @@
+package types
+
+type GetResourceYAMLRequest struct {
+	Kind string `schema:"kind" form:"required"`
+	Name string `schema:"name" form:"required"`
+}
+
+// ResourceYAML is the live state of a resource owned by a release
+type ResourceYAML struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+
+	YAML string `json:"yaml"`
+}
+
+type ApplyResourceYAMLRequest struct {
+	// the edited YAML of the resource. The resource must be owned by the release.
+	YAML string `json:"yaml" form:"required"`
+
+	// if set, the resource is validated by the server but not persisted
+	DryRun bool `json:"dry_run"`
+}
+
+type ApplyResourceYAMLResponse struct {
+	*ResourceYAML
+
+	DryRun bool `json:"dry_run"`
+}
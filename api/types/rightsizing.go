@@ -0,0 +1,37 @@
+package types
+
+import "github.com/porter-dev/porter/internal/kubernetes/prometheus"
+
+type GetResourceRecommendationRequest struct {
+	// the number of days of usage to analyze, which defaults to 7
+	WindowDays uint `schema:"window_days" form:"omitempty,max=30"`
+}
+
+type ApplyResourceRecommendationRequest struct {
+	// the number of days of usage to analyze, which defaults to 7
+	WindowDays uint `json:"window_days" form:"omitempty,max=30"`
+
+	// if set, the recommendation is only applied if this is the latest revision of the release
+	LatestRevision uint `json:"latest_revision"`
+}
+
+// ResourceValues are the resources values of a release, as quantities such as 250m or 512Mi.
+// Values which are not set are empty.
+type ResourceValues struct {
+	CPURequest    string `json:"cpu_request,omitempty"`
+	CPULimit      string `json:"cpu_limit,omitempty"`
+	MemoryRequest string `json:"memory_request,omitempty"`
+	MemoryLimit   string `json:"memory_limit,omitempty"`
+}
+
+// ResourceRecommendation recommends the resources values of a release, based on the usage of
+// its pods
+type ResourceRecommendation struct {
+	WindowDays  uint                      `json:"window_days"`
+	Usage       *prometheus.ResourceUsage `json:"usage"`
+	Current     *ResourceValues           `json:"current"`
+	Recommended *ResourceValues           `json:"recommended"`
+
+	// whether the recommended values differ from the current values
+	Changed bool `json:"changed"`
+}
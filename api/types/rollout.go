@@ -0,0 +1,80 @@
+package types
+
+import "time"
+
+const URLParamRolloutName URLParam = "rollout_name"
+
+// ArgoRolloutsStatus describes whether the Argo Rollouts controller is installed in a cluster
+type ArgoRolloutsStatus struct {
+	Installed bool `json:"installed"`
+
+	// the version of the controller, if it could be detected
+	Version string `json:"version,omitempty"`
+}
+
+// Rollout is an Argo Rollout which is rendered by a release
+type Rollout struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// the strategy of the rollout, which is "canary" or "blueGreen"
+	Strategy string `json:"strategy"`
+
+	// the phase of the rollout, such as "Healthy", "Progressing", "Paused" or "Degraded"
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+
+	Paused  bool `json:"paused"`
+	Aborted bool `json:"aborted"`
+
+	// the current step of a canary rollout, out of its total steps
+	CurrentStepIndex *int `json:"current_step_index,omitempty"`
+	TotalSteps       int  `json:"total_steps,omitempty"`
+
+	Replicas          int `json:"replicas"`
+	UpdatedReplicas   int `json:"updated_replicas"`
+	ReadyReplicas     int `json:"ready_replicas"`
+	AvailableReplicas int `json:"available_replicas"`
+
+	// the pod template hashes of the stable version and of the version being rolled out
+	StableRevision  string `json:"stable_revision,omitempty"`
+	CurrentRevision string `json:"current_revision,omitempty"`
+}
+
+type ListRolloutsResponse []*Rollout
+
+type PromoteRolloutRequest struct {
+	// if set, the remaining steps and analyses are skipped and the new version is fully
+	// promoted
+	Full bool `json:"full"`
+}
+
+// AnalysisRun is a run of the analysis of a rollout, which decides if the new version of the
+// rollout is promoted
+type AnalysisRun struct {
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// the phase of the run, such as "Running", "Successful", "Failed" or "Inconclusive"
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+
+	Metrics []*AnalysisRunMetric `json:"metrics"`
+}
+
+type AnalysisRunMetric struct {
+	Name    string `json:"name"`
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+
+	Count        int `json:"count"`
+	Successful   int `json:"successful"`
+	Failed       int `json:"failed"`
+	Inconclusive int `json:"inconclusive"`
+	Error        int `json:"error"`
+
+	// the value of the latest measurement of the metric
+	LatestValue string `json:"latest_value,omitempty"`
+}
+
+type ListAnalysisRunsResponse []*AnalysisRun
@@ -0,0 +1,36 @@
+package types
+
+type SearchResultKind string
+
+const (
+	SearchResultProject    SearchResultKind = "project"
+	SearchResultRelease    SearchResultKind = "release"
+	SearchResultDeployment SearchResultKind = "deployment"
+	SearchResultDomain     SearchResultKind = "domain"
+)
+
+type SearchRequest struct {
+	// The text to search for, which is matched case-insensitively anywhere in a field
+	Query string `schema:"q" form:"required,min=2"`
+
+	// The maximum number of results, which defaults to 20. Projects are returned first,
+	// followed by releases, deployments and custom domains.
+	Limit int `schema:"limit" form:"omitempty,min=1,max=100"`
+}
+
+type SearchResult struct {
+	Kind      SearchResultKind `json:"kind"`
+	ID        uint             `json:"id"`
+	ProjectID uint             `json:"project_id"`
+	ClusterID uint             `json:"cluster_id,omitempty"`
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace,omitempty"`
+
+	// The release of a custom domain, whose host is the name of the result
+	ReleaseName string `json:"release_name,omitempty"`
+
+	// The fields of the result which matched the query
+	Matches []string `json:"matches"`
+}
+
+type SearchResponse []*SearchResult
@@ -0,0 +1,47 @@
+package types
+
+const (
+	URLParamSentryIntegrationID URLParam = "sentry_integration_id"
+)
+
+// SentryIntegration is a Sentry organization in which a release is created whenever a release
+// of a project is upgraded. Releases of applications which are built from a git repository
+// are named after the deployed commit and are associated with the commits since the previous
+// deployment, so that errors reported with the same release are attributed to the deployment.
+type SentryIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Sentry organization
+	Name string `json:"name"`
+
+	// The URL of the Sentry instance, which is https://sentry.io unless Sentry is self-hosted
+	BaseURL string `json:"base_url"`
+
+	// The slug of the Sentry organization
+	OrganizationSlug string `json:"organization_slug"`
+
+	// The slugs of the Sentry projects which releases are created in
+	SentryProjects []string `json:"sentry_projects"`
+
+	// The environment which deploys are marked in. If empty, deploys are marked in an
+	// environment named after the cluster of the release.
+	Environment string `json:"environment"`
+}
+
+type CreateSentryIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	BaseURL string `json:"base_url" form:"omitempty,url"`
+
+	OrganizationSlug string `json:"organization_slug" form:"required"`
+
+	SentryProjects []string `json:"sentry_projects" form:"required,min=1,dive,required,excludesall=0x2C"`
+
+	Environment string `json:"environment" form:"omitempty,excludesall=/"`
+
+	AuthToken string `json:"auth_token" form:"required"`
+}
+
+type ListSentryIntegrationsResponse []*SentryIntegration
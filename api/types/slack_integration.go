@@ -2,6 +2,7 @@ package types
 
 const (
 	URLParamSlackIntegrationID = "slack_integration_id"
+	URLParamSlackRoutingRuleID = "slack_routing_rule_id"
 )
 
 type SlackIntegration struct {
@@ -26,3 +27,51 @@ type SlackIntegration struct {
 }
 
 type ListSlackIntegrationsResponse []*SlackIntegration
+
+// CreateSlackWebhookIntegrationRequest creates a Slack integration from an incoming webhook,
+// for workspaces where the Porter Slack app cannot be installed
+type CreateSlackWebhookIntegrationRequest struct {
+	// The incoming webhook URL, which must be a Slack webhook URL
+	Webhook string `json:"webhook" form:"required,url"`
+
+	// The channel name that the webhook posts to
+	Channel string `json:"channel" form:"required"`
+}
+
+// SlackEvent is a type of event which is posted to Slack integrations
+type SlackEvent string
+
+const (
+	SlackEventReleaseUpgrade    SlackEvent = "release_upgrade"
+	SlackEventPreviewDeployment SlackEvent = "preview_deployment"
+	SlackEventProvisionFailed   SlackEvent = "provision_failed"
+	SlackEventIncident          SlackEvent = "incident"
+)
+
+// SlackRoutingRule routes events to a Slack integration. Slack integrations without routing
+// rules receive every event, while Slack integrations with routing rules only receive the
+// events which match one of their rules.
+type SlackRoutingRule struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	SlackIntegrationID uint `json:"slack_integration_id"`
+
+	Event SlackEvent `json:"event"`
+
+	// The cluster which the rule is restricted to, or 0 for every cluster
+	ClusterID uint `json:"cluster_id"`
+
+	// The namespace which the rule is restricted to, or empty for every namespace
+	Namespace string `json:"namespace"`
+}
+
+type CreateSlackRoutingRuleRequest struct {
+	SlackIntegrationID uint       `json:"slack_integration_id" form:"required"`
+	Event              SlackEvent `json:"event" form:"required,oneof=release_upgrade preview_deployment provision_failed incident"`
+	ClusterID          uint       `json:"cluster_id"`
+	Namespace          string     `json:"namespace"`
+}
+
+type ListSlackRoutingRulesResponse []*SlackRoutingRule
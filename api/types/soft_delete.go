@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// DeletionMeta describes when a soft-deleted resource was deleted, and the time until which
+// it can be restored before it is permanently deleted
+type DeletionMeta struct {
+	DeletedAt       time.Time `json:"deleted_at"`
+	RestorableUntil time.Time `json:"restorable_until"`
+}
+
+func NewDeletionMeta(deletedAt time.Time, retentionDays uint) DeletionMeta {
+	return DeletionMeta{
+		DeletedAt:       deletedAt,
+		RestorableUntil: deletedAt.AddDate(0, 0, int(retentionDays)),
+	}
+}
+
+type DeletedProject struct {
+	*Project
+	DeletionMeta
+}
+
+type ListDeletedProjectsResponse []*DeletedProject
+
+type DeletedCluster struct {
+	*Cluster
+	DeletionMeta
+}
+
+type ListDeletedClustersResponse []*DeletedCluster
+
+type DeletedEnvironment struct {
+	*Environment
+	DeletionMeta
+}
+
+type ListDeletedEnvironmentsResponse []*DeletedEnvironment
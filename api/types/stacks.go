@@ -22,6 +22,22 @@ type CreateStackRequest struct {
 	EnvGroups []*CreateStackEnvGroupRequest `json:"env_groups,omitempty" form:"required,dive,required"`
 }
 
+// swagger:model
+type PutStackSpecRequest struct {
+	// The app resources of the stack. Resources which exist in the stack are upgraded, new
+	// resources are installed, and resources which are no longer listed are deleted.
+	// required: true
+	AppResources []*CreateStackAppResourceRequest `json:"app_resources,omitempty" form:"required,dive,required"`
+
+	// The source configs of the stack, which replace the existing source configs
+	// required: true
+	SourceConfigs []*CreateStackSourceConfigRequest `json:"source_configs,omitempty" form:"required,dive,required"`
+
+	// The env groups of the stack. Each env group is updated with a new version, and env groups
+	// which are no longer listed are deleted.
+	EnvGroups []*CreateStackEnvGroupRequest `json:"env_groups,omitempty" form:"dive,required"`
+}
+
 // swagger:model
 type PutStackSourceConfigRequest struct {
 	SourceConfigs []*CreateStackSourceConfigRequest `json:"source_configs,omitempty" form:"required,dive,required"`
@@ -0,0 +1,65 @@
+package types
+
+import "time"
+
+const URLParamStatusPageSubdomain URLParam = "subdomain"
+
+// StatusPageReleaseStatus is the current health of a release shown on a status page
+type StatusPageReleaseStatus string
+
+const (
+	StatusPageReleaseOperational StatusPageReleaseStatus = "operational"
+	StatusPageReleaseDegraded    StatusPageReleaseStatus = "degraded"
+	StatusPageReleaseDown        StatusPageReleaseStatus = "down"
+)
+
+// StatusPageRelease is a release which is shown on a status page
+type StatusPageRelease struct {
+	ClusterID   uint   `json:"cluster_id" form:"required"`
+	Namespace   string `json:"namespace" form:"required"`
+	ReleaseName string `json:"release_name" form:"required"`
+
+	// the name shown for the release on the status page, which defaults to the release name
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// StatusPage is the configuration of a project's public status page
+type StatusPage struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"project_id"`
+	Subdomain string `json:"subdomain"`
+	Title     string `json:"title"`
+
+	// the public URL of the status page, if a status page domain is configured for this instance
+	URL string `json:"url,omitempty"`
+
+	Releases []*StatusPageRelease `json:"releases"`
+}
+
+type UpdateStatusPageRequest struct {
+	Subdomain string               `json:"subdomain" form:"required,max=63"`
+	Title     string               `json:"title" form:"required,max=255"`
+	Releases  []*StatusPageRelease `json:"releases" form:"required,max=50,dive"`
+}
+
+// PublicStatusPageRelease is the health of a single release on a public status page
+type PublicStatusPageRelease struct {
+	Name   string                  `json:"name"`
+	Status StatusPageReleaseStatus `json:"status"`
+
+	// the percentage of the uptime window in which the release had no open incidents
+	Uptime float64 `json:"uptime"`
+}
+
+// PublicStatusPage is the unauthenticated view of a status page, which does not expose the
+// clusters or namespaces of the releases
+type PublicStatusPage struct {
+	Title string `json:"title"`
+
+	// the least healthy status of all releases on the page
+	Status StatusPageReleaseStatus `json:"status"`
+
+	UptimeDays uint                       `json:"uptime_days"`
+	Releases   []*PublicStatusPageRelease `json:"releases"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+}
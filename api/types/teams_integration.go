@@ -0,0 +1,25 @@
+package types
+
+const (
+	URLParamTeamsIntegrationID URLParam = "teams_integration_id"
+)
+
+// TeamsIntegration is a Microsoft Teams incoming webhook which is notified of the deployments
+// and preview environments of a project
+type TeamsIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the integration, such as the name of the Teams channel
+	Name string `json:"name"`
+}
+
+type CreateTeamsIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+
+	// The incoming webhook URL, which must be a Microsoft Teams or Power Automate webhook URL
+	Webhook string `json:"webhook" form:"required,url"`
+}
+
+type ListTeamsIntegrationsResponse []*TeamsIntegration
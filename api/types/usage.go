@@ -9,6 +9,10 @@ const (
 	Memory   UsageMetric = "memory"
 	Clusters UsageMetric = "clusters"
 	Users    UsageMetric = "users"
+	Apps     UsageMetric = "apps"
+
+	BuildMinutes            UsageMetric = "build_minutes"
+	PreviewEnvironmentHours UsageMetric = "preview_environment_hours"
 )
 
 type ProjectUsage struct {
@@ -23,6 +27,15 @@ type ProjectUsage struct {
 
 	// The number of users
 	Users uint `json:"users"`
+
+	// The number of deployed applications
+	Apps uint `json:"apps"`
+
+	// The minutes of builds in the current month
+	BuildMinutes uint `json:"build_minutes"`
+
+	// The hours of preview environments in the current month
+	PreviewEnvironmentHours uint `json:"preview_environment_hours"`
 }
 
 var BasicPlan = ProjectUsage{
@@ -64,3 +77,50 @@ type GetProjectUsageResponse struct {
 	// When the usage has been exceeded since, if IsExceeded
 	ExceededSince *time.Time `json:"exceeded_since,omitempty"`
 }
+
+// ProjectUsageRecord is the usage of a project which was metered for an hour
+type ProjectUsageRecord struct {
+	// The start of the hour which was metered
+	Hour time.Time `json:"hour"`
+
+	// The number of deployed applications at the end of the hour
+	Apps uint `json:"apps"`
+
+	// The number of clusters at the end of the hour
+	Clusters uint `json:"clusters"`
+
+	// The seconds of the builds which finished during the hour
+	BuildSeconds uint `json:"build_seconds"`
+
+	// The number of preview environments which were active at the end of the hour
+	PreviewEnvironmentHours uint `json:"preview_environment_hours"`
+}
+
+type GetProjectUsageHistoryRequest struct {
+	// The start of the history, which defaults to the start of the current month
+	From *time.Time `schema:"from"`
+
+	// The end of the history, which defaults to now
+	To *time.Time `schema:"to"`
+}
+
+type GetProjectUsageHistoryResponse struct {
+	Records []*ProjectUsageRecord `json:"records"`
+
+	// The minutes of builds over the history, rounded up
+	BuildMinutes uint `json:"build_minutes"`
+
+	// The hours of preview environments over the history
+	PreviewEnvironmentHours uint `json:"preview_environment_hours"`
+}
+
+// UpdateProjectUsageLimitsRequest sets the usage limits of a project. A limit of 0 is unlimited.
+type UpdateProjectUsageLimitsRequest struct {
+	ResourceCPU             uint `json:"resource_cpu"`
+	ResourceMemory          uint `json:"resource_memory"`
+	Clusters                uint `json:"clusters"`
+	Users                   uint `json:"users"`
+	Apps                    uint `json:"apps"`
+	BuildMinutes            uint `json:"build_minutes"`
+	PreviewEnvironmentHours uint `json:"preview_environment_hours"`
+}
@@ -0,0 +1,79 @@
+package types
+
+import "time"
+
+const (
+	URLParamPVCName URLParam = "name"
+)
+
+// PersistentVolumeClaim represents a persistent volume claim in a namespace, along with
+// the storage class capabilities and Porter-specific settings that apply to it
+type PersistentVolumeClaim struct {
+	// the name of the persistent volume claim
+	Name string `json:"name"`
+
+	// the namespace of the persistent volume claim
+	Namespace string `json:"namespace"`
+
+	// the name of the release which owns this persistent volume claim, if any
+	ReleaseName string `json:"release_name,omitempty"`
+
+	// the phase of the persistent volume claim (Pending, Bound, Lost)
+	Status string `json:"status"`
+
+	// the name of the storage class backing this claim
+	StorageClass string `json:"storage_class"`
+
+	// the name of the bound persistent volume
+	VolumeName string `json:"volume_name"`
+
+	// the access modes of the claim
+	AccessModes []string `json:"access_modes"`
+
+	// the requested size of the claim, for example "10Gi"
+	RequestedSize string `json:"requested_size"`
+
+	// the actual size of the bound volume, for example "10Gi"
+	Capacity string `json:"capacity"`
+
+	// whether the storage class of this claim allows volume expansion
+	CanExpand bool `json:"can_expand"`
+
+	// whether a resize of this claim is currently in progress
+	Resizing bool `json:"resizing"`
+
+	// whether deletion protection is enabled for this claim
+	DeletionProtection bool `json:"deletion_protection"`
+
+	// the filesystem usage of the volume, if reported by the kubelet
+	Usage *PersistentVolumeClaimUsage `json:"usage,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PersistentVolumeClaimUsage is the filesystem usage of a mounted volume, as reported
+// by the kubelet
+type PersistentVolumeClaimUsage struct {
+	UsedBytes      uint64 `json:"used_bytes"`
+	AvailableBytes uint64 `json:"available_bytes"`
+	CapacityBytes  uint64 `json:"capacity_bytes"`
+}
+
+type ListPersistentVolumeClaimsRequest struct {
+	// (optional) only return claims owned by this release
+	ReleaseName string `schema:"release_name"`
+
+	// whether to query the kubelet for volume usage
+	WithUsage bool `schema:"with_usage"`
+}
+
+type ListPersistentVolumeClaimsResponse []*PersistentVolumeClaim
+
+type ResizePersistentVolumeClaimRequest struct {
+	// the new size of the claim, for example "20Gi". Volumes can only be expanded.
+	Size string `json:"size" form:"required"`
+}
+
+type UpdatePVCDeletionProtectionRequest struct {
+	Enabled bool `json:"enabled"`
+}
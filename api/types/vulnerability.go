@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+type VulnerabilityScanner string
+
+const (
+	VulnerabilityScannerTrivy  VulnerabilityScanner = "trivy"
+	VulnerabilityScannerECR    VulnerabilityScanner = "ecr"
+	VulnerabilityScannerHarbor VulnerabilityScanner = "harbor"
+)
+
+// ImageVulnerabilitySummary is the number of vulnerabilities found in a container image,
+// grouped by severity
+type ImageVulnerabilitySummary struct {
+	// the full image reference, including the tag
+	Image string `json:"image"`
+
+	// the scanner which reported the vulnerabilities
+	Scanner VulnerabilityScanner `json:"scanner"`
+
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown"`
+
+	// the time that the image was last scanned, if reported by the scanner
+	ScannedAt *time.Time `json:"scanned_at,omitempty"`
+}
+
+type UpdateVulnerabilityPolicyRequest struct {
+	// whether upgrades which deploy images with critical vulnerabilities should be rejected
+	BlockCriticalVulnerabilities bool `json:"block_critical_vulnerabilities"`
+}
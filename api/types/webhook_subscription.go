@@ -0,0 +1,150 @@
+package types
+
+import "time"
+
+const (
+	URLParamWebhookSubscriptionID URLParam = "webhook_subscription_id"
+)
+
+// WebhookEvent is a Porter event which is delivered to the webhook subscriptions of a project
+type WebhookEvent string
+
+const (
+	// WebhookEventDeploy is sent when a release is deployed or upgraded
+	WebhookEventDeploy WebhookEvent = "deploy"
+
+	// WebhookEventRollback is sent when a release is rolled back to a previous revision
+	WebhookEventRollback WebhookEvent = "rollback"
+
+	// WebhookEventInfraChange is sent when an infrastructure operation succeeds or fails
+	WebhookEventInfraChange WebhookEvent = "infra_change"
+
+	// WebhookEventIncident is sent when an incident is opened or resolved
+	WebhookEventIncident WebhookEvent = "incident"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending deliveries have not been attempted yet, or are waiting to be
+	// retried after a failed attempt
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+
+	// WebhookDeliverySucceeded deliveries received a 2xx response
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+
+	// WebhookDeliveryFailed deliveries failed every attempt, and will not be retried
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription is a URL which is sent the events of a project, signed with the secret
+// of the subscription
+type WebhookSubscription struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+	URL       string    `json:"url"`
+
+	// The events which are sent to the URL, where an empty list sends every event
+	Events []WebhookEvent `json:"events"`
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL    string         `json:"url" form:"required,url"`
+	Events []WebhookEvent `json:"events" form:"dive,oneof=deploy rollback infra_change incident"`
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	*WebhookSubscription
+
+	// The secret which signs the payloads sent to the URL. It is only returned when the
+	// subscription is created.
+	Secret string `json:"secret"`
+}
+
+type ListWebhookSubscriptionsResponse []*WebhookSubscription
+
+// WebhookPayload is the body of the requests sent to webhook subscriptions. The id of the
+// delivery is sent in the X-Porter-Delivery header, so that receivers can ignore retries of
+// deliveries which they have already processed.
+type WebhookPayload struct {
+	Event     WebhookEvent `json:"event"`
+	ProjectID uint         `json:"project_id"`
+	Timestamp time.Time    `json:"timestamp"`
+	Data      interface{}  `json:"data"`
+}
+
+// WebhookDeployData is the data of deploy events
+type WebhookDeployData struct {
+	ClusterID   uint   `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+
+	// Status is either "succeeded" or "failed"
+	Status  string `json:"status"`
+	Version int    `json:"version"`
+	Info    string `json:"info,omitempty"`
+	URL     string `json:"url"`
+}
+
+// WebhookRollbackData is the data of rollback events
+type WebhookRollbackData struct {
+	ClusterID uint   `json:"cluster_id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Revision  int    `json:"revision"`
+}
+
+// WebhookInfraChangeData is the data of infra_change events
+type WebhookInfraChangeData struct {
+	InfraID   uint   `json:"infra_id"`
+	Kind      string `json:"kind"`
+	Operation string `json:"operation"`
+
+	// Status is the status of the infra after the operation, such as "created", "destroyed"
+	// or "error"
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	URL    string `json:"url"`
+}
+
+// WebhookIncidentData is the data of incident events. Incident is set for incidents reported
+// by the agent of a cluster, and ClusterIncident for incidents detected by Porter.
+type WebhookIncidentData struct {
+	// Action is either "opened" or "resolved"
+	Action          string           `json:"action"`
+	URL             string           `json:"url"`
+	Incident        *Incident        `json:"incident,omitempty"`
+	ClusterIncident *ClusterIncident `json:"cluster_incident,omitempty"`
+}
+
+// WebhookDelivery is an event sent to a webhook subscription, along with the status code and
+// latency of the last attempt
+type WebhookDelivery struct {
+	ID                    uint                  `json:"id"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+	WebhookSubscriptionID uint                  `json:"webhook_subscription_id"`
+	Event                 WebhookEvent          `json:"event"`
+	Status                WebhookDeliveryStatus `json:"status"`
+	Attempts              uint                  `json:"attempts"`
+	Payload               string                `json:"payload"`
+
+	// The status code of the response to the last attempt, which is empty if the request
+	// could not be sent, and the latency of the attempt in milliseconds
+	ResponseCode int   `json:"response_code,omitempty"`
+	LatencyMs    int64 `json:"latency_ms"`
+
+	// The error of the last failed attempt
+	LastError string `json:"last_error,omitempty"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	ListOptions
+
+	Status []string `schema:"status"`
+	Event  []string `schema:"event"`
+}
+
+type ListWebhookDeliveriesResponse []*WebhookDelivery
@@ -0,0 +1,56 @@
+package types
+
+import "time"
+
+const (
+	URLParamWildcardCertificateID URLParam = "wildcard_certificate_id"
+)
+
+// WildcardCertificate is a certificate for a domain and its subdomains, such as example.com and
+// *.example.com, which is issued by cert-manager through a DNS-01 challenge with a DNS provider
+// integration. The certificate is renewed by cert-manager, and copied to namespaces as a TLS
+// secret, so that the ingresses of preview environments don't each request a certificate.
+type WildcardCertificate struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+	ClusterID uint `json:"cluster_id"`
+
+	Domain string `json:"domain"`
+
+	DNSProviderIntegrationID uint `json:"dns_provider_integration_id"`
+
+	// the name of the TLS secret which the certificate is copied to in each namespace
+	SecretName string `json:"secret_name"`
+
+	// the namespaces which the certificate is copied to. If empty, the certificate is copied
+	// to every namespace except the system namespaces.
+	Namespaces []string `json:"namespaces"`
+
+	// the time the certificate was last copied to its namespaces, and the error of the last
+	// copy, if it failed
+	DistributedAt     *time.Time `json:"distributed_at,omitempty"`
+	DistributionError string     `json:"distribution_error,omitempty"`
+
+	// the issuance state of the certificate in cert-manager, which is only read for a single
+	// certificate
+	Certificate *Certificate `json:"certificate,omitempty"`
+}
+
+type CreateWildcardCertificateRequest struct {
+	// the domain of the certificate, which covers the domain and its subdomains
+	Domain string `json:"domain" form:"required,fqdn"`
+
+	// the Route53 or Cloudflare integration which solves the DNS-01 challenges of the domain
+	DNSProviderIntegrationID uint `json:"dns_provider_integration_id" form:"required"`
+
+	// (optional) the email of the ACME account, which defaults to the email of the user
+	Email string `json:"email" form:"omitempty,email"`
+
+	Namespaces []string `json:"namespaces"`
+}
+
+type UpdateWildcardCertificateRequest struct {
+	Namespaces []string `json:"namespaces"`
+}
+
+type ListWildcardCertificatesResponse []*WildcardCertificate
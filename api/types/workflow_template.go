@@ -0,0 +1,88 @@
+package types
+
+import "time"
+
+const URLParamWorkflowTemplateKind URLParam = "workflow_template_kind"
+
+// WorkflowTemplateKind is the kind of Github Actions workflow which a template customizes
+type WorkflowTemplateKind string
+
+const (
+	// WorkflowTemplateKindDeploy customizes the porter_<app>.yml workflows which deploy an
+	// application on push
+	WorkflowTemplateKindDeploy WorkflowTemplateKind = "deploy"
+
+	// WorkflowTemplateKindPreview customizes the porter_<env>_env.yml workflows which deploy
+	// preview environments
+	WorkflowTemplateKindPreview WorkflowTemplateKind = "preview"
+)
+
+// WorkflowTemplate customizes the Github Actions workflows which Porter generates for the
+// applications or preview environments of a project
+type WorkflowTemplate struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ProjectID uint                 `json:"project_id"`
+	Kind      WorkflowTemplateKind `json:"kind"`
+
+	Spec *WorkflowTemplateSpec `json:"spec"`
+}
+
+// WorkflowTemplateSpec is the set of overrides which is applied to a generated workflow
+type WorkflowTemplateSpec struct {
+	// the labels of the runner of the workflow job, such as ["self-hosted", "linux"]. The job
+	// runs on ubuntu-latest if no labels are set.
+	RunsOn []string `json:"runs_on,omitempty"`
+
+	// the timeout of the workflow job
+	TimeoutMinutes uint64 `json:"timeout_minutes,omitempty"`
+
+	// environment variables which are set for every step of the workflow job
+	Env map[string]string `json:"env,omitempty"`
+
+	// caches the paths between runs of the workflow, with the actions/cache action
+	Cache *WorkflowCacheSpec `json:"cache,omitempty"`
+
+	// steps which run after the code is checked out and before Porter deploys
+	PreSteps []*WorkflowStep `json:"pre_steps,omitempty"`
+
+	// steps which run after Porter deploys
+	PostSteps []*WorkflowStep `json:"post_steps,omitempty"`
+}
+
+type WorkflowCacheSpec struct {
+	Paths       []string `json:"paths"`
+	Key         string   `json:"key"`
+	RestoreKeys []string `json:"restore_keys,omitempty"`
+}
+
+// WorkflowStep is a step of a workflow job, which either uses an action or runs a command
+type WorkflowStep struct {
+	Name           string            `json:"name"`
+	ID             string            `json:"id,omitempty"`
+	If             string            `json:"if,omitempty"`
+	Uses           string            `json:"uses,omitempty"`
+	Run            string            `json:"run,omitempty"`
+	With           map[string]string `json:"with,omitempty"`
+	Env            map[string]string `json:"env,omitempty"`
+	TimeoutMinutes uint64            `json:"timeout_minutes,omitempty"`
+}
+
+type ListWorkflowTemplatesResponse []*WorkflowTemplate
+
+type PutWorkflowTemplateRequest struct {
+	Spec *WorkflowTemplateSpec `json:"spec" form:"required"`
+}
+
+// RenderWorkflowTemplateRequest renders a workflow with a template, without saving the template
+type RenderWorkflowTemplateRequest struct {
+	Kind WorkflowTemplateKind  `json:"kind" form:"required,oneof=deploy preview"`
+	Spec *WorkflowTemplateSpec `json:"spec" form:"required"`
+}
+
+type RenderWorkflowTemplateResponse struct {
+	// the rendered workflow, for an example application or preview environment
+	Workflow string `json:"workflow"`
+}
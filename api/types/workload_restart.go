@@ -0,0 +1,47 @@
+package types
+
+type WorkloadRestartSourceKind string
+
+const (
+	WorkloadRestartSourceConfigMap WorkloadRestartSourceKind = "configmap"
+	WorkloadRestartSourceSecret    WorkloadRestartSourceKind = "secret"
+)
+
+type WorkloadRestartRequest struct {
+	// the kind of the changed object: either configmap or secret
+	Kind WorkloadRestartSourceKind `json:"kind" schema:"kind" form:"required,oneof=configmap secret"`
+
+	// the name of the changed object
+	Name string `json:"name" schema:"name" form:"required"`
+}
+
+// DependentWorkload is a workload whose pods reference a configmap or secret, either as
+// environment variables or as a mounted volume
+type DependentWorkload struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	ReleaseName string `json:"release_name,omitempty"`
+
+	// the hash of the configmap or secret data which the workload was last restarted with
+	CurrentHash string `json:"current_hash,omitempty"`
+
+	// whether the workload was started before the latest change to the configmap or secret
+	NeedsRestart bool `json:"needs_restart"`
+}
+
+type WorkloadRestartPreview struct {
+	Kind WorkloadRestartSourceKind `json:"kind"`
+	Name string                    `json:"name"`
+
+	// the hash of the current configmap or secret data
+	Hash string `json:"hash"`
+
+	Workloads []*DependentWorkload `json:"workloads"`
+}
+
+type RestartWorkloadsResponse struct {
+	Hash string `json:"hash"`
+
+	// the workloads for which a rolling restart was triggered
+	Restarted []*DependentWorkload `json:"restarted"`
+}
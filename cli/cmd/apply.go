@@ -17,9 +17,11 @@ import (
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/declarative"
 	"github.com/porter-dev/porter/cli/cmd/deploy"
 	"github.com/porter-dev/porter/cli/cmd/deploy/wait"
 	"github.com/porter-dev/porter/cli/cmd/preview"
+	"github.com/porter-dev/porter/cli/cmd/stackspec"
 	previewInt "github.com/porter-dev/porter/internal/integrations/preview"
 	"github.com/porter-dev/porter/internal/templater/utils"
 	"github.com/porter-dev/switchboard/pkg/drivers"
@@ -59,9 +61,69 @@ applying a configuration:
   PORTER_SOURCE_REPO          The URL of the Helm charts registry
   PORTER_SOURCE_VERSION       The version of the Helm chart to use
   PORTER_TAG                  The Docker image tag to use (like the git commit hash)
+
+A porter.yaml file may instead declare the apps, env groups, domains and addons of a namespace.
+The namespace is then reconciled against the file: resources are created or upgraded to match
+it and, if "prune: true" is set, releases and env groups which are not declared are deleted.
+For example:
+
+  namespace: staging
+  prune: true
+  env_groups:
+  - name: shared
+    variables:
+      LOG_LEVEL: info
+    secret_variables:
+      API_KEY: my-key
+  apps:
+  - name: web
+    type: web
+    image: my-registry/web:v1
+    env_groups: [shared]
+    values:
+      container:
+        port: 8080
+  domains:
+  - host: app.example.com
+    app: web
+  addons:
+  - name: redis
+    chart: redis
+    version: 0.1.0
+    repo_url: https://chart-addons.getporter.dev
+
+A porter.yaml file with "version: v2" declares a stack instead: services which are deployed from
+one image, along with the env groups which are synced to them. Every apply deploys the services
+and env groups together as a new revision of the stack, which can be rolled back as a whole.
+PORTER_TAG, if set, overrides the tag of the image. For example:
+
+  version: v2
+  name: shop
+  image:
+    repository: my-registry/shop
+    tag: v1
+  env_groups:
+  - name: shared
+    variables:
+      LOG_LEVEL: info
+  services:
+    web:
+      type: web
+      run: bundle exec puma
+      port: 3000
+      env_groups: [shared]
+    cleanup:
+      type: cron
+      run: rake cleanup
+      schedule: "0 * * * *"
+
+Use --dry-run to print the diff of the changes without applying them:
+
+  %s
 	`,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml --dry-run"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, apply)
@@ -71,7 +133,7 @@ applying a configuration:
 				color.New(color.FgRed).Fprintf(os.Stderr, "You may have to update your GitHub secret token")
 			}
 
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 	},
 }
@@ -94,6 +156,7 @@ var applyValidateCmd = &cobra.Command{
 }
 
 var porterYAML string
+var applyDryRun bool
 
 func init() {
 	rootCmd.AddCommand(applyCmd)
@@ -102,9 +165,34 @@ func init() {
 
 	applyCmd.PersistentFlags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
 	applyCmd.MarkFlagRequired("file")
+
+	applyCmd.Flags().BoolVar(
+		&applyDryRun,
+		"dry-run",
+		false,
+		"print the changes which would be made without applying them",
+	)
 }
 
 func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string) error {
+	fileBytes, err := ioutil.ReadFile(porterYAML)
+
+	if err != nil {
+		return fmt.Errorf("error reading porter.yaml: %w", err)
+	}
+
+	if stackspec.IsSpec(fileBytes) {
+		return applyStackSpec(client, fileBytes)
+	}
+
+	if declarative.IsSpec(fileBytes) {
+		return applySpec(client, fileBytes)
+	}
+
+	if applyDryRun {
+		return fmt.Errorf("--dry-run is only supported for porter.yaml files which declare apps, env groups, domains, addons or a stack")
+	}
+
 	if _, ok := os.LookupEnv("PORTER_VALIDATE_YAML"); ok {
 		err := applyValidate()
 
@@ -113,12 +201,6 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 		}
 	}
 
-	fileBytes, err := ioutil.ReadFile(porterYAML)
-
-	if err != nil {
-		return fmt.Errorf("error reading porter.yaml: %w", err)
-	}
-
 	resGroup, err := parser.ParseRawBytes(fileBytes)
 
 	if err != nil {
@@ -166,6 +248,42 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 	})
 }
 
+// applySpec reconciles the namespace of a declarative spec against it, creating, upgrading and
+// deleting resources to match
+func applySpec(client *api.Client, fileBytes []byte) error {
+	spec, err := declarative.Parse(fileBytes)
+
+	if err != nil {
+		return err
+	}
+
+	target := &declarative.Target{
+		ProjectID: cliConf.Project,
+		ClusterID: cliConf.Cluster,
+		Namespace: spec.Namespace,
+	}
+
+	state, err := declarative.GetState(context.Background(), client, target)
+
+	if err != nil {
+		return err
+	}
+
+	changes, err := declarative.ComputePlan(spec, state)
+
+	if err != nil {
+		return err
+	}
+
+	declarative.PrintPlan(os.Stdout, changes)
+
+	if applyDryRun || len(changes) == 0 {
+		return nil
+	}
+
+	return declarative.Apply(context.Background(), client, target, changes)
+}
+
 func applyValidate() error {
 	fileBytes, err := ioutil.ReadFile(porterYAML)
 
@@ -173,6 +291,11 @@ func applyValidate() error {
 		return fmt.Errorf("error reading porter.yaml: %w", err)
 	}
 
+	if stackspec.IsSpec(fileBytes) {
+		_, err := stackspec.Parse(fileBytes)
+		return err
+	}
+
 	validationErrors := previewInt.Validate(string(fileBytes))
 
 	if len(validationErrors) > 0 {
@@ -594,14 +717,14 @@ func (d *DeployDriver) updateApplication(resource *switchboardModels.Resource, c
 			}
 		}
 
-		err = updateAgent.Build(buildConfig)
+		err = runStep(stepBuild, func() error { return updateAgent.Build(buildConfig) }, "app", resource.Name)
 
 		if err != nil {
 			return nil, err
 		}
 
 		if !appConf.Build.UseCache {
-			err = updateAgent.Push()
+			err = runStep(stepPush, updateAgent.Push, "app", resource.Name)
 
 			if err != nil {
 				return nil, err
@@ -609,7 +732,7 @@ func (d *DeployDriver) updateApplication(resource *switchboardModels.Resource, c
 		}
 	}
 
-	err = updateAgent.UpdateImageAndValues(appConf.Values)
+	err = runStep(stepDeploy, func() error { return updateAgent.UpdateImageAndValues(appConf.Values) }, "app", resource.Name)
 
 	if err != nil {
 		return nil, err
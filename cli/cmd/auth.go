@@ -122,6 +122,15 @@ func login() error {
 		return nil
 	}
 
+	// browser and manual logins need a user, so an API token is required in CI mode
+	if ci {
+		if cliConf.Token != "" {
+			return fmt.Errorf("the API token is invalid: %w", err)
+		}
+
+		return fmt.Errorf("an API token is required to log in in CI mode, pass one with --token or PORTER_TOKEN")
+	}
+
 	// check for the --manual flag
 	if manual {
 		return loginManual()
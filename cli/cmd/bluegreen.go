@@ -16,10 +16,6 @@ import (
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
 )
 
-var deployCmd = &cobra.Command{
-	Use: "deploy",
-}
-
 var bluegreenCmd = &cobra.Command{
 	Use:   "blue-green-switch",
 	Short: "Automatically switches the traffic of a blue-green deployment once the new application is ready.",
@@ -33,7 +29,6 @@ var bluegreenCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.AddCommand(deployCmd)
 	deployCmd.AddCommand(bluegreenCmd)
 
 	bluegreenCmd.PersistentFlags().StringVar(
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+// ci is set by the --ci flag, and is also enabled by setting PORTER_CI to a true value
+var ci bool
+
+// exit codes of commands which build and deploy applications, which let CI pipelines tell
+// failures apart without parsing the output
+const (
+	exitCodeBuildFailed       = 10
+	exitCodeDeployFailed      = 11
+	exitCodeHealthCheckFailed = 12
+)
+
+// the steps of a deployment which are reported in CI mode
+const (
+	stepBuild       = "build"
+	stepPush        = "push"
+	stepDeploy      = "deploy"
+	stepHealthCheck = "health-check"
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(
+		&ci,
+		"ci",
+		false,
+		"run in CI mode, which disables prompts, spinners and colors, and reports the progress of deployments as structured lines",
+	)
+
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if env, err := strconv.ParseBool(os.Getenv("PORTER_CI")); err == nil && env {
+			ci = true
+		}
+
+		if ci {
+			utils.SetCIMode(true)
+			color.NoColor = true
+
+			// commands which can pick a pod use the first available pod instead of prompting
+			nonInteractive = true
+		}
+	}
+}
+
+// stepError is returned when a step of a deployment fails, so that the command exits with the
+// exit code of the step
+type stepError struct {
+	step string
+	err  error
+}
+
+func (e *stepError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stepError) Unwrap() error {
+	return e.err
+}
+
+// runStep runs a step of a deployment, reporting when it starts and finishes in CI mode
+func runStep(step string, run func() error, fields ...string) error {
+	reportStep(step, "started", fields...)
+
+	start := time.Now()
+
+	if err := run(); err != nil {
+		return failStep(step, err, fields...)
+	}
+
+	reportStep(step, "succeeded", append(fields, "duration", time.Since(start).Round(time.Millisecond).String())...)
+
+	return nil
+}
+
+// failStep reports that a step of a deployment failed, and returns an error which makes the
+// command exit with the step's exit code
+func failStep(step string, err error, fields ...string) error {
+	reportStep(step, "failed", append(fields, "error", err.Error())...)
+
+	return &stepError{step, err}
+}
+
+// exitCode returns the exit code of a command which returned err
+func exitCode(err error) int {
+	var stepErr *stepError
+
+	if !errors.As(err, &stepErr) {
+		return 1
+	}
+
+	switch stepErr.step {
+	case stepBuild, stepPush:
+		return exitCodeBuildFailed
+	case stepDeploy:
+		return exitCodeDeployFailed
+	case stepHealthCheck:
+		return exitCodeHealthCheckFailed
+	}
+
+	return 1
+}
+
+// reportStep writes a progress line for a step of a deployment in CI mode, in the logfmt format
+// so that it can be parsed by log tools, for example:
+//
+//	porter step=build status=succeeded app=web
+//
+// fields are pairs of keys and values which are added to the line.
+func reportStep(step, status string, fields ...string) {
+	if !ci {
+		return
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "porter step=%s status=%s", step, status)
+
+	for i := 0; i+1 < len(fields); i += 2 {
+		fmt.Fprintf(&sb, " %s=%s", fields[i], logfmtValue(fields[i+1]))
+	}
+
+	fmt.Fprintln(os.Stderr, sb.String())
+}
+
+func logfmtValue(val string) string {
+	if val == "" || strings.ContainsAny(val, " =\"\t\n") {
+		return strconv.Quote(val)
+	}
+
+	return val
+}
@@ -85,24 +85,39 @@ func listClusters(user *types.GetAuthenticatedUserResponse, client *api.Client,
 
 	clusters := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "NAME", "SERVER")
-
-	currClusterID := cliConf.Cluster
+	res := make([]*clusterOutput, 0, len(clusters))
 
 	for _, cluster := range clusters {
-		if currClusterID == cluster.ID {
-			color.New(color.FgGreen).Fprintf(w, "%d\t%s\t%s (current cluster)\n", cluster.ID, cluster.Name, cluster.Server)
+		res = append(res, &clusterOutput{
+			ID:      cluster.ID,
+			Name:    cluster.Name,
+			Server:  cluster.Server,
+			Service: string(cluster.Service),
+			Current: cluster.ID == cliConf.Cluster,
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "ID", "NAME", "SERVICE", "SERVER")
 		} else {
-			fmt.Fprintf(w, "%d\t%s\t%s\n", cluster.ID, cluster.Name, cluster.Server)
+			fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "NAME", "SERVER")
 		}
-	}
 
-	w.Flush()
+		for _, cluster := range res {
+			row := fmt.Sprintf("%d\t%s\t%s", cluster.ID, cluster.Name, cluster.Server)
 
-	return nil
+			if wide {
+				row = fmt.Sprintf("%d\t%s\t%s\t%s", cluster.ID, cluster.Name, cluster.Service, cluster.Server)
+			}
+
+			if cluster.Current {
+				color.New(color.FgGreen).Fprintf(w, "%s (current cluster)\n", row)
+			} else {
+				fmt.Fprintln(w, row)
+			}
+		}
+	})
 }
 
 func deleteCluster(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -154,18 +169,31 @@ func listNamespaces(user *types.GetAuthenticatedUserResponse, client *api.Client
 		return err
 	}
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\n", "NAME", "STATUS")
-
 	namespaces := *namespaceList
 
-	for _, namespace := range namespaces {
-		fmt.Fprintf(w, "%s\t%s\n", namespace.Name, namespace.Status)
+	res := make([]*namespaceOutput, 0, len(namespaces))
+
+	for _, ns := range namespaces {
+		res = append(res, &namespaceOutput{
+			Name:              ns.Name,
+			Status:            ns.Status,
+			CreationTimestamp: ns.CreationTimestamp,
+		})
 	}
 
-	w.Flush()
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", "NAME", "STATUS", "CREATED")
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", "NAME", "STATUS")
+		}
 
-	return nil
+		for _, ns := range res {
+			if wide {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", ns.Name, ns.Status, ns.CreationTimestamp)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", ns.Name, ns.Status)
+			}
+		}
+	})
 }
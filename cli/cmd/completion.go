@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+// completionTimeout limits the time spent fetching completions from the API, so that the shell
+// doesn't hang when the server can't be reached
+const completionTimeout = 5 * time.Second
+
+// registerCompletions registers the functions which complete the names of releases, env groups,
+// namespaces, projects, clusters and registries from the API. It's called after all commands
+// and flags have been added to the root command.
+func registerCompletions() {
+	for _, cmd := range []*cobra.Command{
+		devSyncCmd, execCmd, getCmd, getValuesCmd, logsCmd, portForwardCmd, runCmd, runJobCmd,
+		deleteAppsCmd, deleteJobsCmd, deleteAddonsCmd,
+	} {
+		cmd.ValidArgsFunction = completeFirstArg(listReleaseNames)
+	}
+
+	for _, cmd := range []*cobra.Command{envSetCmd, envGetCmd, envUnsetCmd, envPullCmd, envPushCmd, envHistoryCmd, envAccessCmd, envRollbackCmd, envSealCmd} {
+		cmd.ValidArgsFunction = completeFirstArg(listEnvGroupNames)
+	}
+
+	configSetProjectCmd.ValidArgsFunction = completeFirstArg(listProjectIDs)
+	configSetClusterCmd.ValidArgsFunction = completeFirstArg(listClusterIDs)
+	configSetRegistryCmd.ValidArgsFunction = completeFirstArg(listRegistryIDs)
+	configUseContextCmd.ValidArgsFunction = completeFirstArg(listContextNames)
+	configDeleteContextCmd.ValidArgsFunction = completeFirstArg(listContextNames)
+
+	flagCompletions := map[string]func(client *api.Client) ([]string, error){
+		"namespace": listNamespaceNames,
+		"app":       listReleaseNames,
+		"project":   listProjectIDs,
+		"cluster":   listClusterIDs,
+		"registry":  listRegistryIDs,
+	}
+
+	// flags such as --namespace are defined by each command rather than by the root command, so
+	// every command is checked for them. Registering a function for a flag set which is shared by
+	// several commands returns an error after the first time, which is ignored.
+	var walk func(cmd *cobra.Command)
+
+	walk = func(cmd *cobra.Command) {
+		for name, list := range flagCompletions {
+			if cmd.LocalFlags().Lookup(name) != nil {
+				cmd.RegisterFlagCompletionFunc(name, completeWith(list))
+			}
+		}
+
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+
+	walk(rootCmd)
+}
+
+// completeFirstArg completes the first argument of a command with the values returned by list,
+// and doesn't complete any other arguments
+func completeFirstArg(list func(client *api.Client) ([]string, error)) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	complete := completeWith(list)
+
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return complete(cmd, args, toComplete)
+	}
+}
+
+func completeWith(list func(client *api.Client) ([]string, error)) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		client := config.GetAPIClient()
+		client.HTTPClient.Timeout = completionTimeout
+
+		values, err := list(client)
+
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("error listing completions: %v", err), true)
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// isCompletionRequest returns whether the CLI was run by a shell to get completions
+func isCompletionRequest(args []string) bool {
+	return len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd)
+}
+
+func listReleaseNames(client *api.Client) ([]string, error) {
+	releases, err := listReleases(client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(releases))
+
+	for _, rel := range releases {
+		res = append(res, fmt.Sprintf("%s\t%s", rel.Name, rel.Chart.Name()))
+	}
+
+	return res, nil
+}
+
+// listReleases lists the releases in the namespace, other than releases which were uninstalled
+func listReleases(client *api.Client) ([]*release.Release, error) {
+	return client.ListReleases(context.Background(), cliConf.Project, cliConf.Cluster, namespace,
+		&types.ListReleasesRequest{
+			ReleaseListFilter: &types.ReleaseListFilter{
+				Limit: 50,
+				Skip:  0,
+				StatusFilter: []string{
+					"deployed",
+					"pending",
+					"pending-install",
+					"pending-upgrade",
+					"pending-rollback",
+					"failed",
+				},
+			},
+		},
+	)
+}
+
+func listEnvGroupNames(client *api.Client) ([]string, error) {
+	resp, err := client.ListEnvGroups(context.Background(), cliConf.Project, cliConf.Cluster, namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(*resp))
+
+	for _, envGroup := range *resp {
+		res = append(res, envGroup.Name)
+	}
+
+	return res, nil
+}
+
+func listNamespaceNames(client *api.Client) ([]string, error) {
+	resp, err := client.GetK8sNamespaces(context.Background(), cliConf.Project, cliConf.Cluster)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(*resp))
+
+	for _, ns := range *resp {
+		res = append(res, ns.Name)
+	}
+
+	return res, nil
+}
+
+func listProjectIDs(client *api.Client) ([]string, error) {
+	resp, err := client.ListUserProjects(context.Background())
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(*resp))
+
+	for _, project := range *resp {
+		res = append(res, fmt.Sprintf("%d\t%s", project.ID, project.Name))
+	}
+
+	return res, nil
+}
+
+func listClusterIDs(client *api.Client) ([]string, error) {
+	resp, err := client.ListProjectClusters(context.Background(), cliConf.Project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(*resp))
+
+	for _, cluster := range *resp {
+		res = append(res, fmt.Sprintf("%d\t%s", cluster.ID, cluster.Name))
+	}
+
+	return res, nil
+}
+
+func listRegistryIDs(client *api.Client) ([]string, error) {
+	resp, err := client.ListRegistries(context.Background(), cliConf.Project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(*resp))
+
+	for _, registry := range *resp {
+		res = append(res, fmt.Sprintf("%d\t%s", registry.ID, registry.Name))
+	}
+
+	return res, nil
+}
+
+func listContextNames(_ *api.Client) ([]string, error) {
+	contexts, err := config.ReadContexts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return contexts.Names(), nil
+}
+
+// withReleaseArg returns the arguments of a command which takes a release as its first argument.
+// When the release is omitted in an interactive shell, the user is prompted to pick one of the
+// releases in the namespace.
+func withReleaseArg(client *api.Client, args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	if ci || !term.IsTerminal(os.Stdin) {
+		return nil, fmt.Errorf("a release name is required")
+	}
+
+	s := utils.NewSpinner()
+	s.Suffix = fmt.Sprintf(" Loading list of releases in namespace '%s'", namespace)
+	s.Start()
+
+	releases, err := listReleases(client)
+
+	s.Stop()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases were found in namespace %s", namespace)
+	}
+
+	options := make([]string, 0, len(releases))
+
+	for _, rel := range releases {
+		options = append(options, fmt.Sprintf("%s (%s)", rel.Name, rel.Chart.Name()))
+	}
+
+	selected, err := utils.PromptSelect("Select a release, typing to filter", options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name, _, _ := strings.Cut(selected, " (")
+
+	return []string{name}, nil
+}
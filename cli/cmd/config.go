@@ -8,9 +8,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
@@ -192,8 +190,7 @@ func printConfig() error {
 }
 
 func listAndSetProject(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Color("cyan")
+	s := utils.NewSpinner()
 	s.Suffix = " Loading list of projects"
 	s.Start()
 
@@ -234,8 +231,7 @@ func listAndSetProject(_ *types.GetAuthenticatedUserResponse, client *api.Client
 }
 
 func listAndSetCluster(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Color("cyan")
+	s := utils.NewSpinner()
 	s.Suffix = " Loading list of clusters"
 	s.Start()
 
@@ -275,8 +271,7 @@ func listAndSetCluster(_ *types.GetAuthenticatedUserResponse, client *api.Client
 }
 
 func listAndSetRegistry(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Color("cyan")
+	s := utils.NewSpinner()
 	s.Suffix = " Loading list of registries"
 	s.Start()
 
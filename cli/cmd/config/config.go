@@ -208,7 +208,9 @@ func (c *CLIConfig) SetHost(host string) error {
 
 	config.Host = host
 
-	return nil
+	return syncCurrentContext(func(ctx *Context) {
+		ctx.Host = host
+	})
 }
 
 func (c *CLIConfig) SetProject(projectID uint) error {
@@ -228,7 +230,9 @@ func (c *CLIConfig) SetProject(projectID uint) error {
 
 	config.Project = projectID
 
-	return nil
+	return syncCurrentContext(func(ctx *Context) {
+		ctx.Project = projectID
+	})
 }
 
 func (c *CLIConfig) SetCluster(clusterID uint) error {
@@ -248,7 +252,9 @@ func (c *CLIConfig) SetCluster(clusterID uint) error {
 
 	config.Cluster = clusterID
 
-	return nil
+	return syncCurrentContext(func(ctx *Context) {
+		ctx.Cluster = clusterID
+	})
 }
 
 func (c *CLIConfig) SetToken(token string) error {
@@ -261,7 +267,9 @@ func (c *CLIConfig) SetToken(token string) error {
 
 	config.Token = token
 
-	return nil
+	return syncCurrentContext(func(ctx *Context) {
+		ctx.Token = token
+	})
 }
 
 func (c *CLIConfig) SetRegistry(registryID uint) error {
@@ -275,7 +283,9 @@ func (c *CLIConfig) SetRegistry(registryID uint) error {
 
 	config.Registry = registryID
 
-	return nil
+	return syncCurrentContext(func(ctx *Context) {
+		ctx.Registry = registryID
+	})
 }
 
 func (c *CLIConfig) SetHelmRepo(helmRepoID uint) error {
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+)
+
+// Context is a named set of the host, project, cluster and registry which commands are run
+// against, so that users can switch between them with "porter config use-context"
+type Context struct {
+	Host     string `json:"host"`
+	Project  uint   `json:"project"`
+	Cluster  uint   `json:"cluster"`
+	Registry uint   `json:"registry,omitempty"`
+
+	// the token used to authenticate against the host, if the CLI was logged in with a token
+	Token string `json:"token,omitempty"`
+}
+
+// Contexts is the set of named contexts. Contexts are stored in their own file rather than in
+// porter.yaml, since viper can't remove keys from a config file.
+type Contexts struct {
+	Current  string              `json:"current_context"`
+	Contexts map[string]*Context `json:"contexts"`
+}
+
+var contextNameRegex = regexp.MustCompile(`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`)
+
+func contextsPath() string {
+	return filepath.Join(home, ".porter", "contexts.yaml")
+}
+
+// ReadContexts reads the named contexts, which are empty if none have been saved
+func ReadContexts() (*Contexts, error) {
+	res := &Contexts{
+		Contexts: make(map[string]*Context),
+	}
+
+	data, err := ioutil.ReadFile(contextsPath())
+
+	if errors.Is(err, os.ErrNotExist) {
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, res); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", contextsPath(), err)
+	}
+
+	if res.Contexts == nil {
+		res.Contexts = make(map[string]*Context)
+	}
+
+	return res, nil
+}
+
+// Names returns the names of the contexts in alphabetical order
+func (c *Contexts) Names() []string {
+	names := make([]string, 0, len(c.Contexts))
+
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (c *Contexts) write() error {
+	data, err := yaml.Marshal(c)
+
+	if err != nil {
+		return err
+	}
+
+	// contexts may contain tokens, so the file is only readable by the user
+	return ioutil.WriteFile(contextsPath(), data, 0600)
+}
+
+// SetContext creates or overwrites a named context
+func (c *CLIConfig) SetContext(name string, ctx *Context) error {
+	if !contextNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid context name %s: names may only contain lowercase letters, numbers, - and _", name)
+	}
+
+	contexts, err := ReadContexts()
+
+	if err != nil {
+		return err
+	}
+
+	contexts.Contexts[name] = ctx
+
+	if err := contexts.write(); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Saved context %s\n", name)
+
+	// the config is kept in sync with the current context
+	if contexts.Current == name {
+		return c.applyContext(ctx)
+	}
+
+	return nil
+}
+
+// UseContext sets the host, project, cluster and registry of the config to those of a named
+// context, which becomes the current context
+func (c *CLIConfig) UseContext(name string) error {
+	contexts, err := ReadContexts()
+
+	if err != nil {
+		return err
+	}
+
+	ctx, ok := contexts.Contexts[name]
+
+	if !ok {
+		return fmt.Errorf("context %s does not exist", name)
+	}
+
+	contexts.Current = name
+
+	if err := contexts.write(); err != nil {
+		return err
+	}
+
+	if err := c.applyContext(ctx); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Switched to context %s\n", name)
+
+	return nil
+}
+
+// DeleteContext deletes a named context. The config is left unchanged if the context is the
+// current context.
+func (c *CLIConfig) DeleteContext(name string) error {
+	contexts, err := ReadContexts()
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := contexts.Contexts[name]; !ok {
+		return fmt.Errorf("context %s does not exist", name)
+	}
+
+	delete(contexts.Contexts, name)
+
+	if contexts.Current == name {
+		contexts.Current = ""
+	}
+
+	if err := contexts.write(); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deleted context %s\n", name)
+
+	return nil
+}
+
+func (c *CLIConfig) applyContext(ctx *Context) error {
+	if config.Kubeconfig != "" || viper.IsSet("kubeconfig") {
+		viper.Set("kubeconfig", "")
+		config.Kubeconfig = ""
+	}
+
+	viper.Set("host", ctx.Host)
+	viper.Set("project", ctx.Project)
+	viper.Set("cluster", ctx.Cluster)
+	viper.Set("registry", ctx.Registry)
+	viper.Set("token", ctx.Token)
+
+	if err := viper.WriteConfig(); err != nil {
+		return err
+	}
+
+	config.Host = ctx.Host
+	config.Project = ctx.Project
+	config.Cluster = ctx.Cluster
+	config.Registry = ctx.Registry
+	config.Token = ctx.Token
+
+	return nil
+}
+
+// syncCurrentContext applies a change of the config to the current context, so that the change
+// is kept when switching back to the context
+func syncCurrentContext(update func(ctx *Context)) error {
+	contexts, err := ReadContexts()
+
+	if err != nil {
+		return err
+	}
+
+	ctx, ok := contexts.Contexts[contexts.Current]
+
+	if !ok {
+		return nil
+	}
+
+	update(ctx)
+
+	return contexts.write()
+}
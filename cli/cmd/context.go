@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	cliConfig "github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var configSetContextCmd = &cobra.Command{
+	Use:   "set-context [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Saves the current host, project, cluster and registry as a named context",
+	Long: fmt.Sprintf(`
+%s
+
+Saves the host, project, cluster and registry in the current configuration as a named context,
+overwriting the context if it already exists. The --host, --project, --cluster and --registry
+flags override the values in the current configuration. For example:
+
+  %s
+
+Switch to the context with "porter config use-context".
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter config set-context\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter config set-context staging --project 2 --cluster 7"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := cliConf.SetContext(args[0], &cliConfig.Context{
+			Host:     cliConf.Host,
+			Project:  cliConf.Project,
+			Cluster:  cliConf.Cluster,
+			Registry: cliConf.Registry,
+			Token:    cliConf.Token,
+		})
+
+		if err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context [name]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Sets the host, project, cluster and registry of the configuration to those of a named context",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := useContext(args); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configGetContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Args:  cobra.NoArgs,
+	Short: "Lists the named contexts",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := getContexts(); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configDeleteContextCmd = &cobra.Command{
+	Use:   "delete-context [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Deletes a named context",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cliConf.DeleteContext(args[0]); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	configSetContextCmd.Flags().AddFlagSet(utils.RegistryFlagSet)
+
+	configCmd.AddCommand(configSetContextCmd)
+	configCmd.AddCommand(configUseContextCmd)
+	configCmd.AddCommand(configGetContextsCmd)
+	configCmd.AddCommand(configDeleteContextCmd)
+}
+
+func useContext(args []string) error {
+	if len(args) == 1 {
+		return cliConf.UseContext(args[0])
+	}
+
+	contexts, err := cliConfig.ReadContexts()
+
+	if err != nil {
+		return err
+	}
+
+	if len(contexts.Contexts) == 0 {
+		return fmt.Errorf("no contexts have been saved: create one with \"porter config set-context\"")
+	}
+
+	name, err := utils.PromptSelect("Select a context", contexts.Names())
+
+	if err != nil {
+		return err
+	}
+
+	return cliConf.UseContext(name)
+}
+
+func getContexts() error {
+	contexts, err := cliConfig.ReadContexts()
+
+	if err != nil {
+		return err
+	}
+
+	res := make([]*contextOutput, 0, len(contexts.Contexts))
+
+	for _, name := range contexts.Names() {
+		ctx := contexts.Contexts[name]
+
+		res = append(res, &contextOutput{
+			Name:     name,
+			Host:     ctx.Host,
+			Project:  ctx.Project,
+			Cluster:  ctx.Cluster,
+			Registry: ctx.Registry,
+			Current:  name == contexts.Current,
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "CURRENT", "NAME", "HOST", "PROJECT", "CLUSTER", "REGISTRY")
+
+		for _, ctx := range res {
+			current := ""
+
+			if ctx.Current {
+				current = "*"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n", current, ctx.Name, ctx.Host, ctx.Project, ctx.Cluster, ctx.Registry)
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// Target is the project and cluster which a spec is applied to
+type Target struct {
+	ProjectID uint
+	ClusterID uint
+	Namespace string
+}
+
+// GetState reads the releases and env groups of the target namespace
+func GetState(ctx context.Context, client *api.Client, target *Target) (*State, error) {
+	state := &State{
+		Releases:  make(map[string]*Release),
+		EnvGroups: make(map[string]*EnvGroupState),
+	}
+
+	releases, err := client.ListReleases(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.ListReleasesRequest{
+		ReleaseListFilter: &types.ReleaseListFilter{
+			StatusFilter: []string{
+				"deployed",
+				"pending",
+				"pending-install",
+				"pending-upgrade",
+				"pending-rollback",
+				"failed",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing releases: %w", err)
+	}
+
+	for _, rel := range releases {
+		if rel.Chart == nil || rel.Chart.Metadata == nil {
+			continue
+		}
+
+		state.Releases[rel.Name] = &Release{
+			Name:    rel.Name,
+			Chart:   rel.Chart.Metadata.Name,
+			Version: rel.Chart.Metadata.Version,
+			Values:  rel.Config,
+		}
+	}
+
+	envGroups, err := client.ListEnvGroups(ctx, target.ProjectID, target.ClusterID, target.Namespace)
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing env groups: %w", err)
+	}
+
+	for _, meta := range *envGroups {
+		group, err := client.GetEnvGroup(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.GetEnvGroupRequest{
+			Name: meta.Name,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading env group %s: %w", meta.Name, err)
+		}
+
+		state.EnvGroups[meta.Name] = &EnvGroupState{
+			Name:         meta.Name,
+			Variables:    group.Variables,
+			Applications: group.Applications,
+		}
+	}
+
+	return state, nil
+}
+
+// PrintPlan writes the changes of a plan, with a diff of each created or updated resource
+func PrintPlan(w io.Writer, changes []*Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes: the namespace matches the spec")
+		return
+	}
+
+	for _, change := range changes {
+		var c *color.Color
+
+		switch change.Action {
+		case ActionCreate:
+			c = color.New(color.FgGreen, color.Bold)
+		case ActionUpdate:
+			c = color.New(color.FgYellow, color.Bold)
+		default:
+			c = color.New(color.FgRed, color.Bold)
+		}
+
+		c.Fprintf(w, "%s %s %s\n", change.Action, change.Kind, change.Name)
+
+		if change.Diff == "" {
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+			switch {
+			case len(line) > 0 && line[0] == '+':
+				color.New(color.FgGreen).Fprintf(w, "    %s\n", line)
+			case len(line) > 0 && line[0] == '-':
+				color.New(color.FgRed).Fprintf(w, "    %s\n", line)
+			default:
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+}
+
+// Apply makes the changes of a plan in order, stopping at the first change which fails
+func Apply(ctx context.Context, client *api.Client, target *Target, changes []*Change) error {
+	for _, change := range changes {
+		var err error
+
+		switch change.Kind {
+		case KindEnvGroup:
+			err = applyEnvGroup(ctx, client, target, change)
+		case KindApp:
+			err = applyApp(ctx, client, target, change)
+		case KindAddon:
+			err = applyAddon(ctx, client, target, change)
+		}
+
+		if err != nil {
+			return fmt.Errorf("error applying %s %s: %w", change.Kind, change.Name, err)
+		}
+
+		color.New(color.FgGreen).Printf("%s %s %s: done\n", change.Action, change.Kind, change.Name)
+	}
+
+	return nil
+}
+
+func applyEnvGroup(ctx context.Context, client *api.Client, target *Target, change *Change) error {
+	if change.Action == ActionDelete {
+		return client.DeleteEnvGroup(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.DeleteEnvGroupRequest{
+			Name: change.Name,
+		})
+	}
+
+	variables := change.EnvGroup.Variables
+
+	if variables == nil {
+		variables = make(map[string]string)
+	}
+
+	// creating an env group which already exists adds a new version of it
+	_, err := client.CreateEnvGroup(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.CreateEnvGroupRequest{
+		Name:            change.Name,
+		Variables:       variables,
+		SecretVariables: change.EnvGroup.SecretVariables,
+	})
+
+	return err
+}
+
+func applyApp(ctx context.Context, client *api.Client, target *Target, change *Change) error {
+	switch change.Action {
+	case ActionDelete:
+		return client.DeleteRelease(ctx, target.ProjectID, target.ClusterID, target.Namespace, change.Name)
+	case ActionCreate:
+		version := change.App.Version
+
+		if version == "" {
+			latest, err := getLatestTemplateVersion(ctx, client, target.ProjectID, change.App.Type)
+
+			if err != nil {
+				return err
+			}
+
+			version = latest
+		}
+
+		repo, _, _ := SplitImage(change.App.Image)
+
+		return client.DeployTemplate(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.CreateReleaseRequest{
+			CreateReleaseBaseRequest: &types.CreateReleaseBaseRequest{
+				TemplateName:    change.App.Type,
+				TemplateVersion: version,
+				Values:          change.Values,
+				Name:            change.Name,
+			},
+			ImageURL:        repo,
+			SyncedEnvGroups: change.App.EnvGroups,
+		})
+	}
+
+	if err := upgrade(ctx, client, target, change); err != nil {
+		return err
+	}
+
+	for _, group := range change.LinkEnvGroups {
+		err := client.AddEnvGroupApplication(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.AddEnvGroupApplicationRequest{
+			Name:            group,
+			ApplicationName: change.Name,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error syncing env group %s: %w", group, err)
+		}
+	}
+
+	for _, group := range change.UnlinkEnvGroups {
+		err := client.RemoveEnvGroupApplication(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.AddEnvGroupApplicationRequest{
+			Name:            group,
+			ApplicationName: change.Name,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error removing env group %s: %w", group, err)
+		}
+	}
+
+	return nil
+}
+
+func applyAddon(ctx context.Context, client *api.Client, target *Target, change *Change) error {
+	switch change.Action {
+	case ActionDelete:
+		return client.DeleteRelease(ctx, target.ProjectID, target.ClusterID, target.Namespace, change.Name)
+	case ActionCreate:
+		return client.DeployAddon(ctx, target.ProjectID, target.ClusterID, target.Namespace, &types.CreateAddonRequest{
+			CreateReleaseBaseRequest: &types.CreateReleaseBaseRequest{
+				RepoURL:         change.Addon.RepoURL,
+				TemplateName:    change.Addon.Chart,
+				TemplateVersion: change.Addon.Version,
+				Values:          change.Values,
+				Name:            change.Name,
+			},
+		})
+	}
+
+	return upgrade(ctx, client, target, change)
+}
+
+func upgrade(ctx context.Context, client *api.Client, target *Target, change *Change) error {
+	bytes, err := json.Marshal(change.Values)
+
+	if err != nil {
+		return err
+	}
+
+	return client.UpgradeRelease(ctx, target.ProjectID, target.ClusterID, target.Namespace, change.Name, &types.UpgradeReleaseRequest{
+		Values:       string(bytes),
+		ChartVersion: change.Version,
+	})
+}
+
+func getLatestTemplateVersion(ctx context.Context, client *api.Client, projectID uint, name string) (string, error) {
+	resp, err := client.ListTemplates(ctx, projectID, &types.ListTemplatesRequest{})
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, template := range *resp {
+		if template.Name == name && len(template.Versions) > 0 {
+			return template.Versions[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no version of the %s template was found", name)
+}
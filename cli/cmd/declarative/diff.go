@@ -0,0 +1,90 @@
+package declarative
+
+import "strings"
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// lineDiff returns a diff of two sets of lines, with removed lines prefixed by "- " and added
+// lines prefixed by "+ ". Runs of unchanged lines far from a change are collapsed.
+func lineDiff(from, to []string) string {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type diffLine struct {
+		prefix string
+		text   string
+	}
+
+	lines := make([]diffLine, 0, len(from)+len(to))
+	i, j := 0, 0
+
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			lines = append(lines, diffLine{"  ", from[i]})
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{"- ", from[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{"+ ", to[j]})
+			j++
+		}
+	}
+
+	// only show unchanged lines which are within the context of a change
+	show := make([]bool, len(lines))
+
+	for k, line := range lines {
+		if line.prefix == "  " {
+			continue
+		}
+
+		for c := k - diffContext; c <= k+diffContext; c++ {
+			if c >= 0 && c < len(lines) {
+				show[c] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	skipped := false
+
+	for k, line := range lines {
+		if !show[k] {
+			skipped = true
+			continue
+		}
+
+		if skipped {
+			sb.WriteString("  ...\n")
+			skipped = false
+		}
+
+		sb.WriteString(line.prefix + line.text + "\n")
+	}
+
+	if skipped && sb.Len() > 0 {
+		sb.WriteString("  ...\n")
+	}
+
+	return sb.String()
+}
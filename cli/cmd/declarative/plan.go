@@ -0,0 +1,469 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Action is the operation a change performs on a resource
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// ResourceKind is the kind of resource a change is made to
+type ResourceKind string
+
+const (
+	KindEnvGroup ResourceKind = "env group"
+	KindApp      ResourceKind = "app"
+	KindAddon    ResourceKind = "addon"
+)
+
+// Release is the current state of a release in the namespace
+type Release struct {
+	Name    string
+	Chart   string
+	Version string
+	Values  map[string]interface{}
+}
+
+// EnvGroupState is the current state of an env group in the namespace. The values of secret
+// variables can't be read back, so they are returned as references starting with PORTERSECRET.
+type EnvGroupState struct {
+	Name         string
+	Variables    map[string]string
+	Applications []string
+}
+
+// State is the current state of the namespace a spec is applied to
+type State struct {
+	Releases  map[string]*Release
+	EnvGroups map[string]*EnvGroupState
+}
+
+// Change is a change which brings a resource in line with the spec
+type Change struct {
+	Kind   ResourceKind
+	Name   string
+	Action Action
+
+	// a line diff of the resource's configuration, which is empty for deletions
+	Diff string
+
+	// the spec of the resource, which is set unless the resource is deleted
+	App      *App
+	Addon    *Addon
+	EnvGroup *EnvGroup
+
+	// the values which apps and addons are deployed with
+	Values map[string]interface{}
+
+	// the chart version which the release is upgraded to, if it changes
+	Version string
+
+	// the env groups to sync to, or stop syncing to, an existing app
+	LinkEnvGroups   []string
+	UnlinkEnvGroups []string
+}
+
+// ComputePlan returns the changes which bring the namespace in line with the spec. Env groups are
+// changed first, so that they exist before the apps which sync them are created, and deletions
+// are made last.
+func ComputePlan(spec *Spec, state *State) ([]*Change, error) {
+	changes := make([]*Change, 0)
+
+	// releases are deleted before env groups, which can't be deleted while synced to an app
+	releaseDeletions := make([]*Change, 0)
+	envGroupDeletions := make([]*Change, 0)
+
+	inSpec := make(map[string]bool)
+
+	for _, group := range spec.EnvGroups {
+		inSpec[group.Name] = true
+
+		if change := planEnvGroup(group, state.EnvGroups[group.Name]); change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	if spec.Prune {
+		for _, name := range envGroupNames(state.EnvGroups) {
+			if !inSpec[name] {
+				envGroupDeletions = append(envGroupDeletions, &Change{Kind: KindEnvGroup, Name: name, Action: ActionDelete})
+			}
+		}
+	}
+
+	hosts := make(map[string][]string)
+
+	for _, domain := range spec.Domains {
+		hosts[domain.App] = append(hosts[domain.App], domain.Host)
+	}
+
+	inSpec = make(map[string]bool)
+
+	for _, app := range spec.Apps {
+		inSpec[app.Name] = true
+
+		change, err := planApp(app, hosts[app.Name], state)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, addon := range spec.Addons {
+		inSpec[addon.Name] = true
+
+		change, err := planAddon(addon, state.Releases[addon.Name])
+
+		if err != nil {
+			return nil, err
+		}
+
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	if spec.Prune {
+		for _, name := range releaseNames(state.Releases) {
+			if inSpec[name] {
+				continue
+			}
+
+			kind := KindAddon
+
+			if appTypes[state.Releases[name].Chart] {
+				kind = KindApp
+			}
+
+			releaseDeletions = append(releaseDeletions, &Change{Kind: kind, Name: name, Action: ActionDelete})
+		}
+	}
+
+	changes = append(changes, releaseDeletions...)
+
+	return append(changes, envGroupDeletions...), nil
+}
+
+func planEnvGroup(group *EnvGroup, current *EnvGroupState) *Change {
+	desired := make(map[string]string)
+
+	for key, val := range group.Variables {
+		desired[key] = val
+	}
+
+	// secret values can't be read back, so only changes to the set of secret keys are detected
+	for key := range group.SecretVariables {
+		desired[key] = secretPlaceholder
+	}
+
+	change := &Change{
+		Kind:     KindEnvGroup,
+		Name:     group.Name,
+		Action:   ActionCreate,
+		EnvGroup: group,
+	}
+
+	if current == nil {
+		change.Diff = lineDiff(nil, envLines(desired))
+		return change
+	}
+
+	currVars := make(map[string]string)
+
+	for key, val := range current.Variables {
+		if strings.HasPrefix(val, "PORTERSECRET") {
+			val = secretPlaceholder
+		}
+
+		currVars[key] = val
+	}
+
+	if reflect.DeepEqual(currVars, desired) {
+		return nil
+	}
+
+	change.Action = ActionUpdate
+	change.Diff = lineDiff(envLines(currVars), envLines(desired))
+
+	return change
+}
+
+func planApp(app *App, hosts []string, state *State) (*Change, error) {
+	values, err := normalize(app.Values)
+
+	if err != nil {
+		return nil, fmt.Errorf("app '%s': %w", app.Name, err)
+	}
+
+	repo, tag, _ := SplitImage(app.Image)
+
+	values["image"] = mergeMaps(getMap(values, "image"), map[string]interface{}{
+		"repository": repo,
+		"tag":        tag,
+	})
+
+	if len(hosts) > 0 {
+		sort.Strings(hosts)
+
+		hostsArr := make([]interface{}, 0, len(hosts))
+
+		for _, host := range hosts {
+			hostsArr = append(hostsArr, host)
+		}
+
+		values["ingress"] = mergeMaps(getMap(values, "ingress"), map[string]interface{}{
+			"enabled":       true,
+			"custom_domain": true,
+			"hosts":         hostsArr,
+		})
+	}
+
+	change := &Change{
+		Kind:   KindApp,
+		Name:   app.Name,
+		Action: ActionCreate,
+		App:    app,
+		Values: values,
+	}
+
+	current := state.Releases[app.Name]
+
+	if current == nil {
+		change.Diff = valuesDiff(nil, values)
+
+		for _, group := range app.EnvGroups {
+			change.Diff += fmt.Sprintf("+ env group: %s\n", group)
+		}
+
+		return change, nil
+	}
+
+	if current.Chart != app.Type {
+		return nil, fmt.Errorf(
+			"app '%s' is a %s app, and can't be changed to a %s app without deleting it first",
+			app.Name, current.Chart, app.Type,
+		)
+	}
+
+	currValues, err := normalize(current.Values)
+
+	if err != nil {
+		return nil, fmt.Errorf("app '%s': %w", app.Name, err)
+	}
+
+	// the env groups synced to an app are written to its values by the server, so they are
+	// carried over rather than removed
+	if synced, ok := getMap(getMap(currValues, "container"), "env")["synced"]; ok {
+		container := getMap(values, "container")
+		env := getMap(container, "env")
+		env["synced"] = synced
+		container["env"] = env
+		values["container"] = container
+	}
+
+	change.Action = ActionUpdate
+
+	if app.Version != "" && app.Version != current.Version {
+		change.Version = app.Version
+		change.Diff += fmt.Sprintf("- version: %s\n+ version: %s\n", current.Version, app.Version)
+	}
+
+	if !reflect.DeepEqual(currValues, values) {
+		change.Diff += valuesDiff(currValues, values)
+	}
+
+	desiredGroups := make(map[string]bool)
+
+	for _, group := range app.EnvGroups {
+		desiredGroups[group] = true
+
+		if currGroup := state.EnvGroups[group]; currGroup == nil || !contains(currGroup.Applications, app.Name) {
+			change.LinkEnvGroups = append(change.LinkEnvGroups, group)
+			change.Diff += fmt.Sprintf("+ env group: %s\n", group)
+		}
+	}
+
+	for _, name := range envGroupNames(state.EnvGroups) {
+		if !desiredGroups[name] && contains(state.EnvGroups[name].Applications, app.Name) {
+			change.UnlinkEnvGroups = append(change.UnlinkEnvGroups, name)
+			change.Diff += fmt.Sprintf("- env group: %s\n", name)
+		}
+	}
+
+	if change.Diff == "" {
+		return nil, nil
+	}
+
+	return change, nil
+}
+
+func planAddon(addon *Addon, current *Release) (*Change, error) {
+	values, err := normalize(addon.Values)
+
+	if err != nil {
+		return nil, fmt.Errorf("addon '%s': %w", addon.Name, err)
+	}
+
+	change := &Change{
+		Kind:   KindAddon,
+		Name:   addon.Name,
+		Action: ActionCreate,
+		Addon:  addon,
+		Values: values,
+	}
+
+	if current == nil {
+		change.Diff = valuesDiff(nil, values)
+		return change, nil
+	}
+
+	if current.Chart != addon.Chart {
+		return nil, fmt.Errorf(
+			"addon '%s' is a release of the %s chart, and can't be changed to the %s chart without deleting it first",
+			addon.Name, current.Chart, addon.Chart,
+		)
+	}
+
+	currValues, err := normalize(current.Values)
+
+	if err != nil {
+		return nil, fmt.Errorf("addon '%s': %w", addon.Name, err)
+	}
+
+	change.Action = ActionUpdate
+
+	if addon.Version != current.Version {
+		change.Version = addon.Version
+		change.Diff += fmt.Sprintf("- version: %s\n+ version: %s\n", current.Version, addon.Version)
+	}
+
+	if !reflect.DeepEqual(currValues, values) {
+		change.Diff += valuesDiff(currValues, values)
+	}
+
+	if change.Diff == "" {
+		return nil, nil
+	}
+
+	return change, nil
+}
+
+const secretPlaceholder = "********"
+
+func envLines(vars map[string]string) []string {
+	lines := make([]string, 0, len(vars))
+
+	for key, val := range vars {
+		lines = append(lines, fmt.Sprintf("%s=%s", key, val))
+	}
+
+	sort.Strings(lines)
+
+	return lines
+}
+
+func valuesDiff(from, to map[string]interface{}) string {
+	return lineDiff(yamlLines(from), yamlLines(to))
+}
+
+func yamlLines(values map[string]interface{}) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	bytes, err := yaml.Marshal(values)
+
+	if err != nil {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n")
+}
+
+// normalize copies values through JSON, so that they can be compared with the values of a
+// release read from the API
+func normalize(values map[string]interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+
+	if values == nil {
+		return res, nil
+	}
+
+	bytes, err := json.Marshal(values)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid values: %w", err)
+	}
+
+	if err := json.Unmarshal(bytes, &res); err != nil {
+		return nil, fmt.Errorf("invalid values: %w", err)
+	}
+
+	return res, nil
+}
+
+func getMap(values map[string]interface{}, key string) map[string]interface{} {
+	if res, ok := values[key].(map[string]interface{}); ok {
+		return res
+	}
+
+	return make(map[string]interface{})
+}
+
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	for key, val := range override {
+		base[key] = val
+	}
+
+	return base
+}
+
+func contains(arr []string, val string) bool {
+	for _, v := range arr {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
+
+func envGroupNames(groups map[string]*EnvGroupState) []string {
+	names := make([]string, 0, len(groups))
+
+	for name := range groups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func releaseNames(releases map[string]*Release) []string {
+	names := make([]string, 0, len(releases))
+
+	for name := range releases {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,203 @@
+package declarative
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSpec = `
+namespace: staging
+prune: true
+env_groups:
+- name: shared
+  variables:
+    LOG_LEVEL: info
+  secret_variables:
+    API_KEY: abc
+apps:
+- name: web
+  type: web
+  image: registry.example.com:5000/web:v2
+  env_groups:
+  - shared
+  values:
+    container:
+      port: 8080
+- name: worker
+  type: worker
+  image: registry.example.com/worker:v1
+domains:
+- host: app.example.com
+  app: web
+addons:
+- name: redis
+  chart: redis
+  version: 1.0.0
+  repo_url: https://charts.example.com
+`
+
+func TestIsSpec(t *testing.T) {
+	if !IsSpec([]byte(testSpec)) {
+		t.Errorf("expected the spec to be detected")
+	}
+
+	if IsSpec([]byte("version: v1\nresources:\n- name: web\n")) {
+		t.Errorf("expected a preview environment porter.yaml not to be detected as a spec")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse([]byte(`
+apps:
+- name: web
+  type: api
+  image: web
+domains:
+- host: app.example.com
+  app: worker
+`))
+
+	if err == nil {
+		t.Fatalf("expected the spec to be invalid")
+	}
+
+	for _, expected := range []string{"type must be one of", "repository:tag", "not a web app"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %s", expected, err.Error())
+		}
+	}
+}
+
+func TestComputePlan(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	state := &State{
+		Releases: map[string]*Release{
+			"web": {
+				Name:    "web",
+				Chart:   "web",
+				Version: "0.50.0",
+				Values: map[string]interface{}{
+					"container": map[string]interface{}{
+						"port": 8080,
+						"env": map[string]interface{}{
+							"synced": []interface{}{map[string]interface{}{"name": "shared"}},
+						},
+					},
+					"image": map[string]interface{}{
+						"repository": "registry.example.com:5000/web",
+						"tag":        "v1",
+					},
+					"ingress": map[string]interface{}{
+						"enabled":       true,
+						"custom_domain": true,
+						"hosts":         []interface{}{"app.example.com"},
+					},
+				},
+			},
+			"worker": {
+				Name:  "worker",
+				Chart: "worker",
+				Values: map[string]interface{}{
+					"image": map[string]interface{}{
+						"repository": "registry.example.com/worker",
+						"tag":        "v1",
+					},
+				},
+			},
+			"old-app": {Name: "old-app", Chart: "worker"},
+		},
+		EnvGroups: map[string]*EnvGroupState{
+			"shared": {
+				Name: "shared",
+				Variables: map[string]string{
+					"LOG_LEVEL": "debug",
+					"API_KEY":   "PORTERSECRET_shared.v1",
+				},
+				Applications: []string{"web"},
+			},
+			"unused": {Name: "unused"},
+		},
+	}
+
+	changes, err := ComputePlan(spec, state)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expected := []struct {
+		kind   ResourceKind
+		name   string
+		action Action
+	}{
+		{KindEnvGroup, "shared", ActionUpdate},
+		{KindApp, "web", ActionUpdate},
+		{KindAddon, "redis", ActionCreate},
+		{KindApp, "old-app", ActionDelete},
+		{KindEnvGroup, "unused", ActionDelete},
+	}
+
+	if len(changes) != len(expected) {
+		for _, change := range changes {
+			t.Logf("%s %s %s", change.Action, change.Kind, change.Name)
+		}
+
+		t.Fatalf("expected %d changes, got %d", len(expected), len(changes))
+	}
+
+	for i, e := range expected {
+		if changes[i].Kind != e.kind || changes[i].Name != e.name || changes[i].Action != e.action {
+			t.Errorf("change %d: expected %s %s %s, got %s %s %s", i, e.action, e.kind, e.name,
+				changes[i].Action, changes[i].Kind, changes[i].Name)
+		}
+	}
+
+	// the secret variable is unchanged, since its value can't be compared
+	if diff := changes[0].Diff; !strings.Contains(diff, "- LOG_LEVEL=debug\n+ LOG_LEVEL=info\n") ||
+		strings.Contains(diff, "- API_KEY") || strings.Contains(diff, "+ API_KEY") {
+		t.Errorf("unexpected env group diff:\n%s", diff)
+	}
+
+	// only the image tag of the web app has changed, and its synced env groups are carried over
+	if diff := changes[1].Diff; !strings.Contains(diff, "-   tag: v1\n+   tag: v2\n") || strings.Contains(diff, "synced") {
+		t.Errorf("unexpected app diff:\n%s", diff)
+	}
+
+	if len(changes[1].LinkEnvGroups) != 0 || len(changes[1].UnlinkEnvGroups) != 0 {
+		t.Errorf("expected no env group links to change")
+	}
+}
+
+func TestComputePlanChangedType(t *testing.T) {
+	spec, err := Parse([]byte("apps:\n- name: web\n  type: web\n  image: web:v1\n"))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = ComputePlan(spec, &State{
+		Releases: map[string]*Release{
+			"web": {Name: "web", Chart: "worker"},
+		},
+	})
+
+	if err == nil {
+		t.Errorf("expected an error when the type of an app changes")
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	from := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+	to := []string{"a", "b", "c", "d", "e", "f", "g", "h", "x"}
+
+	expected := "  ...\n  f\n  g\n  h\n- i\n+ x\n"
+
+	if diff := lineDiff(from, to); diff != expected {
+		t.Errorf("expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+}
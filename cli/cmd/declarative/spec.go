@@ -0,0 +1,215 @@
+package declarative
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Spec is a declarative description of the apps, env groups, domains and addons of a namespace,
+// which "porter apply" reconciles the cluster against
+type Spec struct {
+	// the namespace which the resources are deployed in, "default" if not set
+	Namespace string `json:"namespace"`
+
+	// if set, releases and env groups in the namespace which are not in the spec are deleted
+	Prune bool `json:"prune"`
+
+	EnvGroups []*EnvGroup `json:"env_groups"`
+	Apps      []*App      `json:"apps"`
+	Domains   []*Domain   `json:"domains"`
+	Addons    []*Addon    `json:"addons"`
+}
+
+// EnvGroup is an env group of the spec
+type EnvGroup struct {
+	Name            string            `json:"name"`
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
+// App is an application deployed from one of the web, worker or job charts
+type App struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// the version of the chart, which defaults to the latest version when the app is created
+	// and is left unchanged on upgrades if not set
+	Version string `json:"version"`
+
+	// the image to deploy, in the form repository:tag
+	Image string `json:"image"`
+
+	Values map[string]interface{} `json:"values"`
+
+	// the names of the env groups which are synced to the app
+	EnvGroups []string `json:"env_groups"`
+}
+
+// Domain is a custom domain which routes to a web app
+type Domain struct {
+	Host string `json:"host"`
+	App  string `json:"app"`
+}
+
+// Addon is a release of a chart from a Helm repository
+type Addon struct {
+	Name    string                 `json:"name"`
+	Chart   string                 `json:"chart"`
+	Version string                 `json:"version"`
+	RepoURL string                 `json:"repo_url"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+var appTypes = map[string]bool{
+	"web":    true,
+	"worker": true,
+	"job":    true,
+}
+
+var dns1123Regex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+var hostRegex = regexp.MustCompile(`^(\*\.)?([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// IsSpec returns whether a porter.yaml file contains a declarative spec, rather than the
+// resources applied by the preview environment worker
+func IsSpec(data []byte) bool {
+	raw := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return false
+	}
+
+	if _, ok := raw["resources"]; ok {
+		return false
+	}
+
+	for _, key := range []string{"apps", "addons", "env_groups", "domains"} {
+		if _, ok := raw[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse parses and validates a declarative spec
+func Parse(data []byte) (*Spec, error) {
+	spec := &Spec{}
+
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+
+	if spec.Namespace == "" {
+		spec.Namespace = "default"
+	}
+
+	if errs := spec.validate(); len(errs) > 0 {
+		errStrs := make([]string, 0, len(errs))
+
+		for _, err := range errs {
+			errStrs = append(errStrs, "- "+err.Error())
+		}
+
+		return nil, fmt.Errorf("the spec is invalid:\n%s", strings.Join(errStrs, "\n"))
+	}
+
+	return spec, nil
+}
+
+func (s *Spec) validate() []error {
+	errs := make([]error, 0)
+
+	if !dns1123Regex.MatchString(s.Namespace) {
+		errs = append(errs, fmt.Errorf("namespace '%s' is not a valid name", s.Namespace))
+	}
+
+	envGroups := make(map[string]bool)
+
+	for i, group := range s.EnvGroups {
+		if !dns1123Regex.MatchString(group.Name) {
+			errs = append(errs, fmt.Errorf("env_groups[%d]: '%s' is not a valid name", i, group.Name))
+		} else if envGroups[group.Name] {
+			errs = append(errs, fmt.Errorf("env_groups[%d]: duplicate env group '%s'", i, group.Name))
+		}
+
+		envGroups[group.Name] = true
+
+		for key := range group.SecretVariables {
+			if _, ok := group.Variables[key]; ok {
+				errs = append(errs, fmt.Errorf("env group '%s': '%s' is both a variable and a secret variable", group.Name, key))
+			}
+		}
+	}
+
+	// apps and addons are both releases, so their names must be unique across both
+	releases := make(map[string]bool)
+	webApps := make(map[string]bool)
+
+	for i, app := range s.Apps {
+		if !dns1123Regex.MatchString(app.Name) {
+			errs = append(errs, fmt.Errorf("apps[%d]: '%s' is not a valid name", i, app.Name))
+		} else if releases[app.Name] {
+			errs = append(errs, fmt.Errorf("apps[%d]: duplicate release '%s'", i, app.Name))
+		}
+
+		releases[app.Name] = true
+
+		if !appTypes[app.Type] {
+			errs = append(errs, fmt.Errorf("app '%s': type must be one of web, worker or job", app.Name))
+		} else if app.Type == "web" {
+			webApps[app.Name] = true
+		}
+
+		if _, _, err := SplitImage(app.Image); err != nil {
+			errs = append(errs, fmt.Errorf("app '%s': %w", app.Name, err))
+		}
+	}
+
+	for i, addon := range s.Addons {
+		if !dns1123Regex.MatchString(addon.Name) {
+			errs = append(errs, fmt.Errorf("addons[%d]: '%s' is not a valid name", i, addon.Name))
+		} else if releases[addon.Name] {
+			errs = append(errs, fmt.Errorf("addons[%d]: duplicate release '%s'", i, addon.Name))
+		}
+
+		releases[addon.Name] = true
+
+		if addon.Chart == "" || addon.Version == "" || addon.RepoURL == "" {
+			errs = append(errs, fmt.Errorf("addon '%s': chart, version and repo_url must be set", addon.Name))
+		}
+	}
+
+	hosts := make(map[string]bool)
+
+	for i, domain := range s.Domains {
+		if !hostRegex.MatchString(domain.Host) {
+			errs = append(errs, fmt.Errorf("domains[%d]: '%s' is not a valid host", i, domain.Host))
+		} else if hosts[domain.Host] {
+			errs = append(errs, fmt.Errorf("domains[%d]: duplicate host '%s'", i, domain.Host))
+		}
+
+		hosts[domain.Host] = true
+
+		if !webApps[domain.App] {
+			errs = append(errs, fmt.Errorf("domain '%s': '%s' is not a web app of the spec", domain.Host, domain.App))
+		}
+	}
+
+	return errs
+}
+
+// SplitImage splits an image into its repository and tag
+func SplitImage(image string) (string, string, error) {
+	// the tag follows the last colon, unless that colon is part of a registry port
+	i := strings.LastIndex(image, ":")
+
+	if i <= 0 || i == len(image)-1 || strings.Contains(image[i:], "/") {
+		return "", "", fmt.Errorf("image '%s' must be in the form repository:tag", image)
+	}
+
+	return image[:i], image[i+1:], nil
+}
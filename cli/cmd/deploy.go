@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
@@ -72,7 +71,7 @@ specify it as follows:
 		err := checkLoginAndRun(args, updateFull)
 
 		if err != nil {
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 	},
 }
@@ -144,7 +143,7 @@ for the application:
 		err := checkLoginAndRun(args, updateBuild)
 
 		if err != nil {
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 	},
 }
@@ -185,7 +184,7 @@ linked it via "porter connect".
 		err := checkLoginAndRun(args, updatePush)
 
 		if err != nil {
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 	},
 }
@@ -216,7 +215,7 @@ the image that the application uses if no --values file is specified:
 		err := checkLoginAndRun(args, updateUpgrade)
 
 		if err != nil {
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 	},
 }
@@ -452,7 +451,7 @@ func updateFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		return err
 	}
 
-	if os.Getenv("GITHUB_ACTIONS") == "" && source == "local" && fullPath == homedir.HomeDir() {
+	if os.Getenv("GITHUB_ACTIONS") == "" && !ci && source == "local" && fullPath == homedir.HomeDir() {
 		proceed, err := utils.PromptConfirm("You are deploying your home directory. Do you want to continue?", false)
 
 		if err != nil {
@@ -472,26 +471,26 @@ func updateFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		return err
 	}
 
-	err = updateBuildWithAgent(updateAgent)
+	err = runStep(stepBuild, func() error { return updateBuildWithAgent(updateAgent) }, "app", app)
 
 	if err != nil {
 		return err
 	}
 
-	err = updatePushWithAgent(updateAgent)
+	err = runStep(stepPush, func() error { return updatePushWithAgent(updateAgent) }, "app", app)
 
 	if err != nil {
 		return err
 	}
 
-	err = updateUpgradeWithAgent(updateAgent)
+	err = runStep(stepDeploy, func() error { return updateUpgradeWithAgent(updateAgent) }, "app", app)
 
 	if err != nil {
 		return err
 	}
 
 	if waitForSuccessfulDeploy {
-		err := checkDeploymentStatus(client)
+		err := runStep(stepHealthCheck, func() error { return checkDeploymentStatus(client) }, "app", app)
 
 		if err != nil {
 			return err
@@ -534,7 +533,7 @@ func updateBuild(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		return err
 	}
 
-	return updateBuildWithAgent(updateAgent)
+	return runStep(stepBuild, func() error { return updateBuildWithAgent(updateAgent) }, "app", app)
 }
 
 func updatePush(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -596,7 +595,7 @@ func updatePush(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		return err
 	}
 
-	return updatePushWithAgent(updateAgent)
+	return runStep(stepPush, func() error { return updatePushWithAgent(updateAgent) }, "app", app)
 }
 
 func updateUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -606,14 +605,14 @@ func updateUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, ar
 		return err
 	}
 
-	err = updateUpgradeWithAgent(updateAgent)
+	err = runStep(stepDeploy, func() error { return updateUpgradeWithAgent(updateAgent) }, "app", app)
 
 	if err != nil {
 		return err
 	}
 
 	if waitForSuccessfulDeploy {
-		err := checkDeploymentStatus(client)
+		err := runStep(stepHealthCheck, func() error { return checkDeploymentStatus(client) }, "app", app)
 
 		if err != nil {
 			return err
@@ -628,8 +627,7 @@ func updateSetEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client
 		return fmt.Errorf("please provide one or more variables to update")
 	}
 
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Color("cyan")
+	s := utils.NewSpinner()
 
 	s.Suffix = fmt.Sprintf(" Fetching env group '%s' in namespace '%s'", name, namespace)
 	s.Start()
@@ -735,8 +733,7 @@ func updateUnsetEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Clie
 		return fmt.Errorf("required variable name")
 	}
 
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Color("cyan")
+	s := utils.NewSpinner()
 
 	s.Suffix = fmt.Sprintf(" Fetching env group '%s' in namespace '%s'", name, namespace)
 	s.Start()
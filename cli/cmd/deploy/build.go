@@ -20,6 +20,9 @@ type BuildAgent struct {
 	ImageRepo   string
 	Env         map[string]string
 	ImageExists bool
+
+	// The stage of a multi-stage Dockerfile to build
+	DockerTarget string
 }
 
 // BuildDocker uses the local Docker daemon to build the image
@@ -50,6 +53,7 @@ func (b *BuildAgent) BuildDocker(
 		DockerfilePath:    dockerfilePath,
 		IsDockerfileInCtx: isDockerfileInCtx,
 		UseCache:          b.UseCache,
+		Target:            b.DockerTarget,
 	}
 
 	return dockerAgent.BuildLocal(
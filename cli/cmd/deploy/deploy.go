@@ -42,6 +42,7 @@ type DeployAgent struct {
 	imageExists    bool
 	imageRepo      string
 	dockerfilePath string
+	dockerTarget   string
 }
 
 // DeployOpts are the options for creating a new DeployAgent
@@ -104,6 +105,7 @@ func NewDeployAgent(client *client.Client, app string, opts *DeployOpts) (*Deplo
 	if deployAgent.Opts.Method == DeployBuildTypeDocker {
 		if release.GitActionConfig != nil {
 			deployAgent.dockerfilePath = release.GitActionConfig.DockerfilePath
+			deployAgent.dockerTarget = release.GitActionConfig.DockerTarget
 		}
 
 		if deployAgent.Opts.LocalDockerfile != "" {
@@ -156,7 +158,8 @@ type GetBuildEnvOpts struct {
 //    1. container.env.normal from the release config
 //    2. container.env.build from the release config
 //    3. container.env.synced from the release config
-//    4. any additional env var that was passed into the DeployAgent as opts.SharedOpts.AdditionalEnv
+//    4. the env groups configured as build args in the git action config, for Dockerfile builds
+//    5. any additional env var that was passed into the DeployAgent as opts.SharedOpts.AdditionalEnv
 func (d *DeployAgent) GetBuildEnv(opts *GetBuildEnvOpts) (map[string]string, error) {
 	conf := d.Release.Config
 
@@ -172,6 +175,21 @@ func (d *DeployAgent) GetBuildEnv(opts *GetBuildEnvOpts) (map[string]string, err
 		return nil, err
 	}
 
+	if d.Opts.Method == DeployBuildTypeDocker && d.Release.GitActionConfig != nil {
+		buildArgs, err := GetBuildArgsFromEnvGroups(
+			d.Client, d.Opts.ProjectID, d.Opts.ClusterID, d.Opts.Namespace,
+			d.Release.GitActionConfig.BuildArgsEnvGroups,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for key, val := range buildArgs {
+			env[key] = val
+		}
+	}
+
 	envConfig, err := GetNestedMap(conf, "container", "env")
 
 	if err == nil {
@@ -308,11 +326,12 @@ func (d *DeployAgent) Build(overrideBuildConfig *types.BuildConfig) error {
 	}
 
 	buildAgent := &BuildAgent{
-		SharedOpts:  d.Opts.SharedOpts,
-		APIClient:   d.Client,
-		ImageRepo:   d.imageRepo,
-		Env:         d.env,
-		ImageExists: d.imageExists,
+		SharedOpts:   d.Opts.SharedOpts,
+		APIClient:    d.Client,
+		ImageRepo:    d.imageRepo,
+		Env:          d.env,
+		ImageExists:  d.imageExists,
+		DockerTarget: d.dockerTarget,
 	}
 
 	if d.Opts.Method == DeployBuildTypeDocker {
@@ -626,6 +645,39 @@ func GetSyncedEnv(
 	return res, nil
 }
 
+// GetBuildArgsFromEnvGroups returns the variables of the given env groups, to be passed to a
+// Dockerfile build as build args. Secret variables are never passed as build args.
+func GetBuildArgsFromEnvGroups(
+	client *client.Client,
+	projID, clusterID uint,
+	namespace string,
+	envGroups []string,
+) (map[string]string, error) {
+	res := make(map[string]string)
+
+	for _, name := range envGroups {
+		eg, err := client.GetEnvGroup(context.Background(), projID, clusterID, namespace,
+			&types.GetEnvGroupRequest{
+				Name: name,
+			},
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not get build args from env group %s: %w", name, err)
+		}
+
+		for key, val := range eg.Variables {
+			if strings.Contains(val, "PORTERSECRET") {
+				continue
+			}
+
+			res[key] = val
+		}
+	}
+
+	return res, nil
+}
+
 func (d *DeployAgent) getReleaseImage() (string, error) {
 	if d.Release.ImageRepoURI != "" {
 		return d.Release.ImageRepoURI, nil
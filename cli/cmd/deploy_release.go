@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	templaterUtils "github.com/porter-dev/porter/internal/templater/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/term"
+	"sigs.k8s.io/yaml"
+)
+
+// deployCmd represents the "porter deploy" base command when called
+// without any subcommands
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploys new values, an image tag or a chart version to an application after showing a diff.",
+	Long: fmt.Sprintf(`
+%s
+
+Deploys new values, an image tag or a chart version to the application given by the --app flag.
+Before the application is upgraded, the changes to its values and to the Kubernetes manifests
+which the upgrade renders are computed by the Porter API and shown as a diff. For example:
+
+  %s
+
+The values file is merged into the current values of the application. To only show the diff,
+without deploying:
+
+  %s
+
+When the diff is shown in a terminal, you are asked to confirm the deploy. In CI pipelines, pass
+the --yes flag to deploy without confirmation:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter deploy\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter deploy --app web --values values.yaml --tag v1.2.0"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter deploy --app web --version 0.60.0 --dry-run"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter deploy --app web --tag $GITHUB_SHA --yes"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, deployWithDiff)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var deployYes bool
+var deployDryRun bool
+var deployChartVersion string
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+
+	// flags are local to "porter deploy", since its subcommands define their own flags
+	deployCmd.Flags().StringVar(
+		&app,
+		"app",
+		"",
+		"Application in the Porter dashboard",
+	)
+
+	deployCmd.Flags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"Namespace of the application",
+	)
+
+	deployCmd.Flags().StringVarP(
+		&values,
+		"values",
+		"v",
+		"",
+		"Filepath to a values.yaml file, which is merged into the current values",
+	)
+
+	deployCmd.Flags().StringVar(
+		&tag,
+		"tag",
+		"",
+		"The image tag to deploy",
+	)
+
+	deployCmd.Flags().StringVar(
+		&deployChartVersion,
+		"version",
+		"",
+		"The chart version to upgrade the application to",
+	)
+
+	deployCmd.Flags().BoolVarP(
+		&deployYes,
+		"yes",
+		"y",
+		false,
+		"Deploy without asking for confirmation",
+	)
+
+	deployCmd.Flags().BoolVar(
+		&deployDryRun,
+		"dry-run",
+		false,
+		"Only show the diff of the changes, without deploying them",
+	)
+}
+
+func deployWithDiff(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if app == "" {
+		return fmt.Errorf("the --app flag must be set")
+	}
+
+	rel, err := client.GetRelease(context.Background(), cliConf.Project, cliConf.Cluster, namespace, app)
+
+	if err != nil {
+		return fmt.Errorf("could not read application %s: %w", app, err)
+	}
+
+	overrideValues, err := readValuesFile()
+
+	if err != nil {
+		return err
+	}
+
+	if tag != "" {
+		overrideValues = templaterUtils.CoalesceValues(overrideValues, map[string]interface{}{
+			"image": map[string]interface{}{
+				"tag": tag,
+			},
+		})
+	}
+
+	valuesBytes, err := yaml.Marshal(templaterUtils.CoalesceValues(rel.Config, overrideValues))
+
+	if err != nil {
+		return err
+	}
+
+	req := &types.UpgradeReleaseRequest{
+		Values:       string(valuesBytes),
+		ChartVersion: deployChartVersion,
+	}
+
+	diff, err := client.DiffUpgrade(context.Background(), cliConf.Project, cliConf.Cluster, namespace, app, req)
+
+	if err != nil {
+		return fmt.Errorf("could not compute the diff of the deploy: %w", err)
+	}
+
+	if diff.ValuesDiff == "" && diff.ManifestDiff == "" && diff.FromChartVersion == diff.ToChartVersion {
+		color.New(color.FgGreen).Printf("No changes: %s is up to date\n", app)
+		return nil
+	}
+
+	if diff.FromChartVersion != diff.ToChartVersion {
+		color.New(color.Bold).Printf("Chart version: %s -> %s\n\n", diff.FromChartVersion, diff.ToChartVersion)
+	}
+
+	if diff.ValuesDiff != "" {
+		color.New(color.Bold).Println("Values:")
+		printUnifiedDiff(diff.ValuesDiff)
+		fmt.Println()
+	}
+
+	if diff.ManifestDiff != "" {
+		color.New(color.Bold).Println("Manifests:")
+		printUnifiedDiff(diff.ManifestDiff)
+		fmt.Println()
+	}
+
+	if deployDryRun {
+		return nil
+	}
+
+	if !deployYes {
+		if !term.IsTerminal(os.Stdin) {
+			return fmt.Errorf("pass the --yes flag to deploy without confirmation from a non-interactive shell")
+		}
+
+		proceed, err := utils.PromptConfirm(fmt.Sprintf("Deploy these changes to %s?", app), false)
+
+		if err != nil {
+			return err
+		}
+
+		if !proceed {
+			return nil
+		}
+	}
+
+	err = client.UpgradeRelease(context.Background(), cliConf.Project, cliConf.Cluster, namespace, app, req)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deployed the changes to %s\n", app)
+
+	return nil
+}
+
+// printUnifiedDiff prints a unified diff with colored additions and deletions
+func printUnifiedDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---"):
+			color.New(color.Bold).Println(line)
+		case strings.HasPrefix(line, "@@"):
+			color.New(color.FgCyan).Println(line)
+		case strings.HasPrefix(line, "+"):
+			color.New(color.FgGreen).Println(line)
+		case strings.HasPrefix(line, "-"):
+			color.New(color.FgRed).Println(line)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
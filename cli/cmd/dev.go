@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/devsync"
+	"github.com/spf13/cobra"
+)
+
+// devCmd represents the "porter dev" base command when called
+// without any subcommands
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Commands for iterating on an application running in a cluster",
+}
+
+var devSyncCmd = &cobra.Command{
+	Use:   "sync [release]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Syncs local files into the containers of a running application as they change.",
+	Long: fmt.Sprintf(`
+%s
+
+Copies the files in a local directory into each running container of an application, then
+watches the directory and copies files into the containers as they are changed or deleted. For
+example, to sync the current directory into the /app directory of a web application in a preview
+environment:
+
+  %s
+
+Files are copied to the working directory of the container unless --dest is set, and the .git
+directory is never synced. Exclude other files with the --exclude flag, which takes glob patterns:
+
+  %s
+
+Use --exec to run a command in each container after files are synced, which can restart or reload
+the application's process:
+
+  %s
+
+Syncing requires tar to be installed in the container. Files which are synced are lost when the
+container restarts, and are synced again to containers started while this command is running.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter dev sync\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter dev sync web --namespace pr-42-my-app --dest /app"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter dev sync web --exclude node_modules --exclude \"*.log\""),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter dev sync web --exec \"kill -HUP 1\""),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, devSync)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var devSyncSrc string
+var devSyncDest string
+var devSyncContainer string
+var devSyncExclude []string
+var devSyncExec string
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.AddCommand(devSyncCmd)
+
+	devSyncCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"namespace of the release",
+	)
+
+	devSyncCmd.PersistentFlags().StringVar(
+		&devSyncSrc,
+		"src",
+		"./",
+		"local directory to sync",
+	)
+
+	devSyncCmd.PersistentFlags().StringVar(
+		&devSyncDest,
+		"dest",
+		"",
+		"directory in the container to sync files to, which defaults to the container's working directory",
+	)
+
+	devSyncCmd.PersistentFlags().StringVarP(
+		&devSyncContainer,
+		"container",
+		"c",
+		"",
+		"name of the container to sync files to, which defaults to the first container of each pod",
+	)
+
+	devSyncCmd.PersistentFlags().StringArrayVar(
+		&devSyncExclude,
+		"exclude",
+		nil,
+		"glob pattern of files which are not synced, which can be repeated",
+	)
+
+	devSyncCmd.PersistentFlags().StringVar(
+		&devSyncExec,
+		"exec",
+		"",
+		"shell command to run in each container after files are synced",
+	)
+}
+
+// devSyncer syncs local files to the running pods of a release
+type devSyncer struct {
+	client   *api.Client
+	release  string
+	src      string
+	excluder *devsync.Excluder
+
+	// the pods which all files have been synced to
+	synced map[string]bool
+}
+
+func devSync(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	args, err := withReleaseArg(client, args)
+
+	if err != nil {
+		return err
+	}
+
+	src, err := filepath.Abs(devSyncSrc)
+
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(src); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", src)
+	}
+
+	s := &devSyncer{
+		client:   client,
+		release:  args[0],
+		src:      src,
+		excluder: devsync.NewExcluder(append([]string{".git"}, devSyncExclude...)),
+		synced:   make(map[string]bool),
+	}
+
+	if err := s.sync(nil); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	termChan := make(chan os.Signal, 1)
+	signal.Notify(termChan, os.Interrupt)
+
+	go func() {
+		<-termChan
+		cancel()
+	}()
+
+	color.New(color.FgGreen).Printf("Watching %s for changes, press Ctrl+C to stop\n", src)
+
+	return devsync.Watch(ctx, src, s.excluder, 300*time.Millisecond, func(changes *devsync.Changes) {
+		if err := s.sync(changes); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "Error syncing files: %v\n", err)
+		}
+	})
+}
+
+// sync syncs changes to the running pods of the release, or all files if changes is nil. All
+// files are synced to pods which haven't been synced to before, such as pods which were started
+// after the previous sync.
+func (s *devSyncer) sync(changes *devsync.Changes) error {
+	pods, err := getPods(s.client, namespace, s.release)
+
+	if err != nil {
+		return fmt.Errorf("could not retrieve list of pods: %w", err)
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("no running pods were found for release %s", s.release)
+	}
+
+	for _, pod := range pods {
+		podChanges := changes
+
+		if !s.synced[pod.Name] {
+			podChanges = nil
+		}
+
+		if err := s.syncPod(pod, podChanges); err != nil {
+			return fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+
+		s.synced[pod.Name] = true
+	}
+
+	return nil
+}
+
+func (s *devSyncer) syncPod(pod podSimple, changes *devsync.Changes) error {
+	container := devSyncContainer
+
+	if container == "" && len(pod.ContainerNames) > 0 {
+		container = pod.ContainerNames[0]
+	}
+
+	dest := devSyncDest
+
+	if dest == "" {
+		dest = "."
+	}
+
+	if changes == nil || len(changes.Updated) > 0 {
+		paths := []string{"."}
+
+		if changes != nil {
+			paths = changes.Updated
+		}
+
+		pr, pw := io.Pipe()
+
+		type tarResult struct {
+			count int
+			err   error
+		}
+
+		tarChan := make(chan tarResult, 1)
+
+		go func() {
+			count, err := devsync.WriteTar(pw, s.src, paths, s.excluder)
+
+			pw.CloseWithError(err)
+			tarChan <- tarResult{count, err}
+		}()
+
+		err := s.exec(pod.Name, container, []string{"tar", "-xof", "-", "-C", dest}, pr)
+
+		// the command can exit before reading all of its input
+		pr.Close()
+
+		res := <-tarChan
+
+		if res.err != nil && err == nil {
+			err = res.err
+		}
+
+		if err != nil {
+			return fmt.Errorf("error copying files: %w", err)
+		}
+
+		color.New(color.FgGreen).Printf("Synced %d files to %s\n", res.count, pod.Name)
+	}
+
+	if changes != nil && len(changes.Deleted) > 0 {
+		cmd := append([]string{"sh", "-c", `cd "$1" && shift && rm -rf -- "$@"`, "sh", dest}, changes.Deleted...)
+
+		if err := s.exec(pod.Name, container, cmd, nil); err != nil {
+			return fmt.Errorf("error deleting files: %w", err)
+		}
+
+		color.New(color.FgGreen).Printf("Deleted %s from %s\n", strings.Join(changes.Deleted, ", "), pod.Name)
+	}
+
+	if devSyncExec != "" {
+		if err := s.exec(pod.Name, container, []string{"sh", "-c", devSyncExec}, nil); err != nil {
+			return fmt.Errorf("error running %s: %w", devSyncExec, err)
+		}
+	}
+
+	return nil
+}
+
+// exec runs a command in a container, and returns an error containing the command's error output
+// if it doesn't succeed
+func (s *devSyncer) exec(podName, container string, command []string, stdin io.Reader) error {
+	stderr := &bytes.Buffer{}
+
+	status, err := s.client.ExecPod(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, podName,
+		&types.ExecRequest{
+			Container: container,
+			Command:   command,
+		},
+		&api.ExecStreams{
+			Stdin:  stdin,
+			Stdout: os.Stdout,
+			Stderr: stderr,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if status.CommandNotFound {
+		return fmt.Errorf("%s is not installed in container %s", command[0], container)
+	}
+
+	if status.Error != "" || status.ExitCode != 0 {
+		msg := status.Error
+
+		if msg == "" {
+			msg = fmt.Sprintf("exit code %d", status.ExitCode)
+		}
+
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			msg = fmt.Sprintf("%s: %s", msg, output)
+		}
+
+		return fmt.Errorf("%s", msg)
+	}
+
+	return nil
+}
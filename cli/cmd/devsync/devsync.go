@@ -0,0 +1,186 @@
+// Package devsync writes local files to tar archives and watches them for changes, so that
+// "porter dev sync" can copy them into the containers of a running application.
+package devsync
+
+import (
+	"archive/tar"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Changes are the paths, relative to the synced directory, which were updated or deleted
+type Changes struct {
+	Updated []string
+	Deleted []string
+}
+
+// Excluder decides which paths are not synced
+type Excluder struct {
+	patterns []string
+}
+
+// NewExcluder returns an Excluder for a list of glob patterns. Patterns which contain a slash are
+// matched against the path relative to the synced directory, and other patterns are matched
+// against the name of each file and directory. Excluding a directory excludes its contents.
+func NewExcluder(patterns []string) *Excluder {
+	res := &Excluder{}
+
+	for _, pattern := range patterns {
+		pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+
+		if pattern != "" {
+			res.patterns = append(res.patterns, pattern)
+		}
+	}
+
+	return res
+}
+
+// Excluded returns whether a path relative to the synced directory is excluded
+func (e *Excluder) Excluded(relPath string) bool {
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+
+	for _, pattern := range e.patterns {
+		if !strings.Contains(pattern, "/") {
+			for _, part := range parts {
+				if ok, _ := path.Match(pattern, part); ok {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		for i := 1; i <= len(parts); i++ {
+			if ok, _ := path.Match(pattern, strings.Join(parts[:i], "/")); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// WriteTar writes the files at the given paths under root to a tar archive, with names
+// relative to root. Directories are written along with the files they contain, and paths
+// which are excluded or no longer exist are skipped. It returns the number of files written.
+func WriteTar(w io.Writer, root string, paths []string, ex *Excluder) (int, error) {
+	tw := tar.NewWriter(w)
+	count := 0
+
+	for _, p := range paths {
+		err := filepath.WalkDir(filepath.Join(root, p), func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// files can be deleted between being changed and being synced
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			relPath, err := filepath.Rel(root, filePath)
+
+			if err != nil {
+				return err
+			}
+
+			if relPath == "." {
+				return nil
+			}
+
+			if ex.Excluded(relPath) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			written, err := writeTarEntry(tw, filePath, filepath.ToSlash(relPath))
+
+			if err != nil {
+				return err
+			}
+
+			if written {
+				count++
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, tw.Close()
+}
+
+// writeTarEntry writes a file, directory or symlink to the archive, and returns whether a file
+// was written
+func writeTarEntry(tw *tar.Writer, filePath, name string) (bool, error) {
+	info, err := os.Lstat(filePath)
+
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	link := ""
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(filePath); err != nil {
+			return false, err
+		}
+	} else if !info.Mode().IsRegular() && !info.IsDir() {
+		// sockets, pipes and devices can't be synced
+		return false, nil
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+
+	if err != nil {
+		return false, err
+	}
+
+	header.Name = name
+
+	// the files are owned by the user which extracts them in the container
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
+
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return false, err
+	}
+
+	if !info.Mode().IsRegular() {
+		return false, nil
+	}
+
+	f, err := os.Open(filePath)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer f.Close()
+
+	// the size of the file was written to the header, so it can't be copied past that size if
+	// the file is appended to while it's being written
+	if _, err := io.Copy(tw, io.LimitReader(f, header.Size)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,146 @@
+package devsync_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/cli/cmd/devsync"
+)
+
+func TestExcluded(t *testing.T) {
+	ex := devsync.NewExcluder([]string{".git", "*.log", "/tmp/cache/", "build/*.o"})
+
+	tests := map[string]bool{
+		".git":               true,
+		".git/HEAD":          true,
+		"src/.git/config":    true,
+		"app.log":            true,
+		"logs/app.log":       true,
+		"tmp/cache":          true,
+		"tmp/cache/a/b":      true,
+		"tmp/other":          false,
+		"src/tmp/cache":      false,
+		"build/main.o":       true,
+		"build/sub/main.o":   false,
+		"main.go":            false,
+		"src/gitignore.go":   false,
+		"src/app.log.backup": false,
+	}
+
+	for relPath, expected := range tests {
+		if excluded := ex.Excluded(relPath); excluded != expected {
+			t.Errorf("%s: expected excluded to be %t, got %t", relPath, expected, excluded)
+		}
+	}
+}
+
+func TestWriteTar(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"main.go":          "package main",
+		"src/app.go":       "package src",
+		"src/app.log":      "log",
+		"src/lib/util.go":  "package lib",
+		".git/HEAD":        "ref",
+		"public/index.htm": "<html></html>",
+	}
+
+	for name, contents := range files {
+		filePath := filepath.Join(root, name)
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if err := os.WriteFile(filePath, []byte(contents), 0644); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	ex := devsync.NewExcluder([]string{".git", "*.log"})
+
+	tests := []struct {
+		paths    []string
+		expected map[string]string
+	}{
+		{
+			paths: []string{"."},
+			expected: map[string]string{
+				"main.go":          "package main",
+				"src/":             "",
+				"src/app.go":       "package src",
+				"src/lib/":         "",
+				"src/lib/util.go":  "package lib",
+				"public/":          "",
+				"public/index.htm": "<html></html>",
+			},
+		},
+		{
+			// deleted paths are skipped
+			paths: []string{"src/lib", "deleted.go"},
+			expected: map[string]string{
+				"src/lib/":        "",
+				"src/lib/util.go": "package lib",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+
+		count, err := devsync.WriteTar(buf, root, test.paths, ex)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		entries := readTar(t, buf)
+
+		if !reflect.DeepEqual(entries, test.expected) {
+			t.Errorf("%v: expected entries %v, got %v", test.paths, test.expected, entries)
+		}
+
+		expectedCount := 0
+
+		for name := range test.expected {
+			if name[len(name)-1] != '/' {
+				expectedCount++
+			}
+		}
+
+		if count != expectedCount {
+			t.Errorf("%v: expected %d files to be written, got %d", test.paths, expectedCount, count)
+		}
+	}
+}
+
+func readTar(t *testing.T, r io.Reader) map[string]string {
+	res := make(map[string]string)
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		contents, err := io.ReadAll(tr)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		res[header.Name] = string(contents)
+	}
+
+	return res
+}
@@ -0,0 +1,114 @@
+package devsync
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch watches the files under root, and calls onChange with the changes made to them once no
+// further change has been made for the debounce interval. Watch returns when ctx is done, or
+// with an error if the files can no longer be watched.
+func Watch(ctx context.Context, root string, ex *Excluder, debounce time.Duration, onChange func(*Changes)) error {
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		return err
+	}
+
+	defer watcher.Close()
+
+	if err := addWatches(watcher, root, root, ex); err != nil {
+		return err
+	}
+
+	changed := make(map[string]bool)
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return err
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(root, event.Name)
+
+			if err != nil || relPath == "." || ex.Excluded(relPath) {
+				continue
+			}
+
+			// new directories are watched along with any directories created inside them
+			// before the watch was added
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+					if err := addWatches(watcher, root, event.Name, ex); err != nil {
+						return err
+					}
+				}
+			}
+
+			changed[relPath] = true
+			timer.Reset(debounce)
+		case <-timer.C:
+			onChange(getChanges(root, changed))
+			changed = make(map[string]bool)
+		}
+	}
+}
+
+func addWatches(watcher *fsnotify.Watcher, root, dir string, ex *Excluder) error {
+	return filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if relPath, err := filepath.Rel(root, filePath); err == nil && relPath != "." && ex.Excluded(relPath) {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(filePath)
+	})
+}
+
+// getChanges sorts changed paths into the paths which were updated and the paths which were
+// deleted, depending on whether they exist once the changes have settled
+func getChanges(root string, changed map[string]bool) *Changes {
+	res := &Changes{}
+
+	for relPath := range changed {
+		if _, err := os.Lstat(filepath.Join(root, relPath)); err == nil {
+			res.Updated = append(res.Updated, relPath)
+		} else {
+			res.Deleted = append(res.Deleted, relPath)
+		}
+	}
+
+	sort.Strings(res.Updated)
+	sort.Strings(res.Deleted)
+
+	return res
+}
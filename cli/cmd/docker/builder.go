@@ -29,6 +29,9 @@ type BuildOpts struct {
 	IsDockerfileInCtx bool
 	UseCache          bool
 
+	// The stage of a multi-stage Dockerfile to build
+	Target string
+
 	Env map[string]string
 }
 
@@ -88,6 +91,7 @@ func (a *Agent) BuildLocal(opts *BuildOpts) (err error) {
 
 	out, err := a.ImageBuild(context.Background(), tar, types.ImageBuildOptions{
 		Dockerfile: dockerfilePath,
+		Target:     opts.Target,
 		BuildArgs:  buildArgs,
 		Tags: []string{
 			fmt.Sprintf("%s:%s", opts.ImageRepo, opts.Tag),
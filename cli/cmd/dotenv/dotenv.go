@@ -0,0 +1,154 @@
+package dotenv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var keyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// Parse reads the variables of a .env file. Each line is of the form KEY=VALUE, and may be
+// prefixed with "export". Values can be wrapped in single quotes, which are read literally, or in
+// double quotes, which can span multiple lines and contain the escape sequences \n, \t, \" and \\.
+// Blank lines and lines starting with # are ignored, as are comments after unquoted values.
+func Parse(r io.Reader) (map[string]string, error) {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, val, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || !keyRegex.MatchString(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		val = strings.TrimSpace(val)
+		startLine := lineNum
+
+		switch {
+		case strings.HasPrefix(val, "'"):
+			end := strings.Index(val[1:], "'")
+
+			if end == -1 {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value for %s", startLine, key)
+			}
+
+			val = val[1 : end+1]
+		case strings.HasPrefix(val, `"`):
+			// read lines until the closing quote, since double-quoted values can span lines
+			quoted := val[1:]
+
+			for closingQuote(quoted) == -1 {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated double-quoted value for %s", startLine, key)
+				}
+
+				lineNum++
+				quoted += "\n" + scanner.Text()
+			}
+
+			val = unescape(quoted[:closingQuote(quoted)])
+		default:
+			if i := strings.Index(val, " #"); i != -1 {
+				val = strings.TrimSpace(val[:i])
+			}
+		}
+
+		res[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Write writes variables in the .env format, sorted by key, quoting values when needed so that
+// they are read back by Parse unchanged
+func Write(w io.Writer, vars map[string]string) error {
+	keys := make([]string, 0, len(vars))
+
+	for key := range vars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, quote(vars[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// closingQuote returns the index of the first double quote which isn't escaped, or -1
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescape(s string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case '"', '\\':
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String()
+}
+
+func quote(val string) string {
+	if val != "" && !strings.ContainsAny(val, " \t\r\n\"'\\#$") {
+		return val
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+	return `"` + replacer.Replace(val) + `"`
+}
@@ -0,0 +1,94 @@
+package dotenv_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/cli/cmd/dotenv"
+)
+
+func TestParse(t *testing.T) {
+	input := `
+# database settings
+DB_HOST=localhost
+export DB_PORT = 5432
+DB_PASSWORD='pa$$ #word'
+EMPTY=
+GREETING="hello \"world\"\nbye"
+CERT="-----BEGIN-----
+abc
+-----END-----"
+LOG_LEVEL=debug # overridden in production
+URL=http://example.com/#anchor
+`
+
+	vars, err := dotenv.Parse(strings.NewReader(input))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expected := map[string]string{
+		"DB_HOST":     "localhost",
+		"DB_PORT":     "5432",
+		"DB_PASSWORD": "pa$$ #word",
+		"EMPTY":       "",
+		"GREETING":    "hello \"world\"\nbye",
+		"CERT":        "-----BEGIN-----\nabc\n-----END-----",
+		"LOG_LEVEL":   "debug",
+		"URL":         "http://example.com/#anchor",
+	}
+
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("expected %v, got %v", expected, vars)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"NO_VALUE",
+		"1KEY=value",
+		"KEY='unterminated",
+		"KEY=\"unterminated\nvalue",
+	}
+
+	for _, input := range tests {
+		if _, err := dotenv.Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestWriteParse(t *testing.T) {
+	vars := map[string]string{
+		"PLAIN":     "value",
+		"EMPTY":     "",
+		"SPACES":    "a b",
+		"QUOTES":    `say "hi" it's`,
+		"MULTILINE": "line1\nline2\r\n\tline3",
+		"BACKSLASH": `C:\path\n`,
+		"COMMENT":   "value #not a comment",
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := dotenv.Write(buf, vars); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "BACKSLASH=") {
+		t.Errorf("expected the variables to be sorted, got:\n%s", buf.String())
+	}
+
+	parsed, err := dotenv.Parse(buf)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !reflect.DeepEqual(parsed, vars) {
+		t.Errorf("expected %v, got %v", vars, parsed)
+	}
+}
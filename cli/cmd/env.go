@@ -0,0 +1,893 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/dotenv"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+// envCmd represents the "porter env" base command when called
+// without any subcommands
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Commands that read and update the variables of env groups",
+	Long: fmt.Sprintf(`
+%s
+
+Reads and updates the variables of env groups. Updating an env group creates a new version of it
+and redeploys the applications which are synced to it. For example:
+
+  %s
+
+Values are masked when variables are printed, unless --show-values is set. The values of secret
+variables can't be read back, so they are always masked, and are not written by "porter env pull".
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env set my-env-group LOG_LEVEL=debug --namespace staging"),
+	),
+}
+
+var envSetCmd = &cobra.Command{
+	Use:   "set [env-group] [KEY=VALUE...]",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Sets variables in an env group, creating the env group if it doesn't exist.",
+	Long: fmt.Sprintf(`
+%s
+
+Sets variables in an env group, creating the env group if it doesn't exist. Other variables of the
+env group are kept. For example:
+
+  %s
+
+Use --secret to store the variables as secrets, and --from-file to set the variables of a .env file:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env set\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env set my-env-group LOG_LEVEL=debug PORT=8080"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env set my-env-group --secret --from-file .env.secrets"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envSet)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envGetCmd = &cobra.Command{
+	Use:   "get [env-group] [KEY...]",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Prints the variables of an env group, or only the given variables.",
+	Long: fmt.Sprintf(`
+%s
+
+Prints the variables of an env group, or only the given variables. Values are masked unless
+--show-values is set, which prints the values of variables which aren't secrets. Use --reveal to
+print the values of secret variables as well; each reveal is recorded in the project's audit
+logs, and can be reviewed with "porter env access". For example:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env get\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env get my-env-group DATABASE_URL --reveal"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envGet)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envUnsetCmd = &cobra.Command{
+	Use:   "unset [env-group] [KEY...]",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Removes variables from an env group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envUnset)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envPullCmd = &cobra.Command{
+	Use:   "pull [env-group]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Writes the variables of an env group to a .env file.",
+	Long: fmt.Sprintf(`
+%s
+
+Writes the variables of an env group to a .env file, which is .env in the current directory unless
+--file is set. Use "--file -" to write the variables to stdout. Secret variables are not written,
+since their values can't be read back. For example:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env pull\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env pull my-env-group --file .env.staging"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envPull)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envPushCmd = &cobra.Command{
+	Use:   "push [env-group]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Replaces the variables of an env group with the variables of a .env file.",
+	Long: fmt.Sprintf(`
+%s
+
+Replaces the variables of an env group with the variables of a .env file, which is .env in the
+current directory unless --file is set, creating the env group if it doesn't exist. Variables
+which aren't in the file are removed from the env group, except for secret variables, since
+"porter env pull" doesn't write them. Use --prune-secrets to remove those as well. For example:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env push\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env push my-env-group --file .env.staging"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envPush)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envHistoryCmd = &cobra.Command{
+	Use:   "history [env-group]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Lists the versions of an env group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envHistory)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envAccessCmd = &cobra.Command{
+	Use:   "access [env-group]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Lists the reads of the secret values of an env group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envAccess)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envRollbackCmd = &cobra.Command{
+	Use:   "rollback [env-group] [version]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Rolls an env group back to a previous version.",
+	Long: fmt.Sprintf(`
+%s
+
+Rolls an env group back to a previous version, by creating a new version with the variables of
+that version. The applications which are synced to the env group keep using the version they were
+deployed with, unless --apps is set, in which case they are redeployed with the new version. For
+example:
+
+  %s
+
+Use "porter env history" to list the versions of an env group.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env rollback\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env rollback my-env-group 3 --apps"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envRollback)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSealCmd = &cobra.Command{
+	Use:   "seal [env-group]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Writes the variables of an env group as a SealedSecret manifest.",
+	Long: fmt.Sprintf(`
+%s
+
+Encrypts the variables and secret variables of an env group with the public key of the cluster's
+sealed-secrets controller, and writes them as a SealedSecret manifest, which can be committed to
+git. The manifest is written to stdout unless --file is set. For example:
+
+  %s
+
+The secret is named after the env group unless --secret-name is set. Sealed secrets can't be
+renamed or moved to another namespace, unless --scope is namespace-wide or cluster-wide.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env seal\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env seal my-env-group --namespace staging --file k8s/my-env-group.yaml"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, envSeal)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSecret bool
+var envFromFile string
+var envShowValues bool
+var envReveal bool
+var envAccessLimit int
+var envFile string
+var envPruneSecrets bool
+var envRollbackApps bool
+var envSealSecretName string
+var envSealScope string
+var envSealVersion uint
+
+// maskedEnvValue is printed in place of the values of variables
+const maskedEnvValue = "********"
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	envCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"namespace of the env group",
+	)
+
+	envSetCmd.PersistentFlags().BoolVar(
+		&envSecret,
+		"secret",
+		false,
+		"whether to store the variables as secrets",
+	)
+
+	envSetCmd.PersistentFlags().StringVar(
+		&envFromFile,
+		"from-file",
+		"",
+		"path to a .env file of variables to set",
+	)
+
+	envGetCmd.PersistentFlags().BoolVar(
+		&envShowValues,
+		"show-values",
+		false,
+		"whether to print the values of variables which aren't secrets",
+	)
+
+	envGetCmd.PersistentFlags().BoolVar(
+		&envReveal,
+		"reveal",
+		false,
+		"whether to print the values of all variables, including secrets",
+	)
+
+	envAccessCmd.PersistentFlags().IntVar(
+		&envAccessLimit,
+		"limit",
+		50,
+		"maximum number of reads to list",
+	)
+
+	envPullCmd.PersistentFlags().StringVarP(
+		&envFile,
+		"file",
+		"f",
+		".env",
+		"path of the .env file to write, or - for stdout",
+	)
+
+	envPushCmd.PersistentFlags().StringVarP(
+		&envFile,
+		"file",
+		"f",
+		".env",
+		"path of the .env file to read",
+	)
+
+	envPushCmd.PersistentFlags().BoolVar(
+		&envSecret,
+		"secret",
+		false,
+		"whether to store the variables as secrets",
+	)
+
+	envPushCmd.PersistentFlags().BoolVar(
+		&envPruneSecrets,
+		"prune-secrets",
+		false,
+		"whether to remove secret variables which aren't in the file",
+	)
+
+	envRollbackCmd.PersistentFlags().BoolVar(
+		&envRollbackApps,
+		"apps",
+		false,
+		"whether to redeploy the applications synced to the env group with the new version",
+	)
+
+	envSealCmd.PersistentFlags().StringVarP(
+		&envFile,
+		"file",
+		"f",
+		"-",
+		"path of the manifest to write, or - for stdout",
+	)
+
+	envSealCmd.PersistentFlags().StringVar(
+		&envSealSecretName,
+		"secret-name",
+		"",
+		"name of the secret created from the manifest, which defaults to the name of the env group",
+	)
+
+	envSealCmd.PersistentFlags().StringVar(
+		&envSealScope,
+		"scope",
+		string(types.SealedSecretScopeStrict),
+		"scope of the sealed secret: strict, namespace-wide or cluster-wide",
+	)
+
+	envSealCmd.PersistentFlags().UintVar(
+		&envSealVersion,
+		"version",
+		0,
+		"version of the env group to seal, which defaults to the latest version",
+	)
+
+	envCmd.AddCommand(envSetCmd)
+	envCmd.AddCommand(envGetCmd)
+	envCmd.AddCommand(envUnsetCmd)
+	envCmd.AddCommand(envPullCmd)
+	envCmd.AddCommand(envPushCmd)
+	envCmd.AddCommand(envHistoryCmd)
+	envCmd.AddCommand(envAccessCmd)
+	envCmd.AddCommand(envRollbackCmd)
+	envCmd.AddCommand(envSealCmd)
+}
+
+func envSet(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	vars := make(map[string]string)
+
+	if envFromFile != "" {
+		fileVars, err := readEnvFile(envFromFile)
+
+		if err != nil {
+			return err
+		}
+
+		vars = fileVars
+	}
+
+	for _, arg := range args[1:] {
+		key, value, err := validateVarValue(arg)
+
+		if err != nil {
+			return err
+		}
+
+		vars[key] = value
+	}
+
+	if len(vars) == 0 {
+		return fmt.Errorf("please provide one or more variables to set, in the form KEY=VALUE or with --from-file")
+	}
+
+	variables, err := getEnvGroupVariables(client, args[0], true)
+
+	if err != nil {
+		return err
+	}
+
+	secretVariables := make(map[string]string)
+
+	for key, value := range vars {
+		delete(variables, key)
+
+		if envSecret {
+			secretVariables[key] = value
+		} else {
+			variables[key] = value
+		}
+	}
+
+	if err := updateEnvGroupVariables(client, args[0], variables, secretVariables); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Set %s in env group %s\n", strings.Join(sortedKeys(vars), ", "), args[0])
+
+	return nil
+}
+
+func envGet(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	variables, err := getEnvGroupVariables(client, args[0], false)
+
+	if err != nil {
+		return err
+	}
+
+	keys := args[1:]
+
+	if len(keys) == 0 {
+		keys = sortedKeys(variables)
+	}
+
+	secretValues := make(map[string]string)
+
+	if envReveal {
+		secretKeys := make([]string, 0)
+
+		for _, key := range keys {
+			if isSecretEnvValue(variables[key]) {
+				secretKeys = append(secretKeys, key)
+			}
+		}
+
+		if len(secretKeys) > 0 {
+			resp, err := client.GetEnvGroupSecrets(
+				context.Background(), cliConf.Project, cliConf.Cluster, namespace, &types.GetEnvGroupSecretsRequest{
+					Name: args[0],
+					Keys: secretKeys,
+				},
+			)
+
+			if err != nil {
+				return err
+			}
+
+			secretValues = resp.SecretVariables
+		}
+	}
+
+	res := make([]envVarOutput, 0, len(keys))
+
+	for _, key := range keys {
+		value, ok := variables[key]
+
+		if !ok {
+			return fmt.Errorf("variable %s is not set in env group %s", key, args[0])
+		}
+
+		secret := isSecretEnvValue(value)
+
+		if secret && envReveal {
+			value = secretValues[key]
+		} else if secret || !(envShowValues || envReveal) {
+			value = maskedEnvValue
+		}
+
+		res = append(res, envVarOutput{
+			Name:   key,
+			Value:  value,
+			Secret: secret,
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", "NAME", "VALUE", "TYPE")
+
+		for _, v := range res {
+			varType := "normal"
+
+			if v.Secret {
+				varType = "secret"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\n", v.Name, v.Value, varType)
+		}
+	})
+}
+
+func envUnset(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	variables, err := getEnvGroupVariables(client, args[0], false)
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range args[1:] {
+		if _, ok := variables[key]; !ok {
+			return fmt.Errorf("variable %s is not set in env group %s", key, args[0])
+		}
+
+		delete(variables, key)
+	}
+
+	if err := updateEnvGroupVariables(client, args[0], variables, nil); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Removed %s from env group %s\n", strings.Join(args[1:], ", "), args[0])
+
+	return nil
+}
+
+func envPull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	variables, err := getEnvGroupVariables(client, args[0], false)
+
+	if err != nil {
+		return err
+	}
+
+	secrets := make([]string, 0)
+
+	for _, key := range sortedKeys(variables) {
+		if isSecretEnvValue(variables[key]) {
+			secrets = append(secrets, key)
+			delete(variables, key)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+
+	if envFile != "-" {
+		f, err := os.OpenFile(envFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := dotenv.Write(w, variables); err != nil {
+		return err
+	}
+
+	if len(secrets) > 0 {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "Skipped secret variables %s, whose values can't be read\n",
+			strings.Join(secrets, ", "))
+	}
+
+	if envFile != "-" {
+		color.New(color.FgGreen).Fprintf(os.Stderr, "Wrote %d variables to %s\n", len(variables), envFile)
+	}
+
+	return nil
+}
+
+func envPush(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	fileVars, err := readEnvFile(envFile)
+
+	if err != nil {
+		return err
+	}
+
+	oldVariables, err := getEnvGroupVariables(client, args[0], true)
+
+	if err != nil {
+		return err
+	}
+
+	variables := make(map[string]string)
+	secretVariables := make(map[string]string)
+
+	// secret variables which aren't in the file are kept by passing their references
+	if !envPruneSecrets {
+		for key, value := range oldVariables {
+			if _, ok := fileVars[key]; !ok && isSecretEnvValue(value) {
+				variables[key] = value
+			}
+		}
+	}
+
+	for key, value := range fileVars {
+		if envSecret {
+			secretVariables[key] = value
+		} else {
+			variables[key] = value
+		}
+	}
+
+	var added, updated, removed int
+
+	for key, value := range fileVars {
+		if oldValue, ok := oldVariables[key]; !ok {
+			added++
+		} else if envSecret || oldValue != value {
+			updated++
+		}
+	}
+
+	for key := range oldVariables {
+		if _, ok := variables[key]; !ok {
+			if _, ok := secretVariables[key]; !ok {
+				removed++
+			}
+		}
+	}
+
+	if err := updateEnvGroupVariables(client, args[0], variables, secretVariables); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Pushed %s to env group %s: %d added, %d updated, %d removed\n",
+		envFile, args[0], added, updated, removed)
+
+	return nil
+}
+
+func envHistory(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListEnvGroupVersions(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, &types.GetEnvGroupAllRequest{
+			Name: args[0],
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	versions := *resp
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+
+	res := make([]envGroupVersionOutput, 0, len(versions))
+
+	for i, version := range versions {
+		res = append(res, envGroupVersionOutput{
+			Version:   version.Version,
+			CreatedAt: formatTime(version.CreatedAt),
+			Latest:    i == 0,
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", "VERSION", "CREATED", "")
+
+		for _, v := range res {
+			latest := ""
+
+			if v.Latest {
+				latest = "latest"
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", v.Version, v.CreatedAt, latest)
+		}
+	})
+}
+
+func envAccess(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListEnvGroupSecretAccess(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, &types.ListEnvGroupSecretAccessRequest{
+			Name:  args[0],
+			Limit: envAccessLimit,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	res := make([]envSecretAccessOutput, 0, len(resp.AuditLogs))
+
+	for _, auditLog := range resp.AuditLogs {
+		access := envSecretAccessOutput{
+			Time:   formatTime(auditLog.CreatedAt),
+			UserID: auditLog.UserID,
+			Keys:   []string{},
+		}
+
+		// metadata is decoded from JSON, so numbers are float64
+		if version, ok := auditLog.Metadata["version"].(float64); ok {
+			access.Version = uint(version)
+		}
+
+		if keys, ok := auditLog.Metadata["keys"].([]interface{}); ok {
+			for _, key := range keys {
+				if keyStr, ok := key.(string); ok {
+					access.Keys = append(access.Keys, keyStr)
+				}
+			}
+		}
+
+		res = append(res, access)
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "TIME", "USER", "VERSION", "KEYS")
+
+		for _, v := range res {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", v.Time, v.UserID, v.Version, strings.Join(v.Keys, ","))
+		}
+	})
+}
+
+func envRollback(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	version, err := strconv.ParseUint(args[1], 10, 64)
+
+	if err != nil || version == 0 {
+		return fmt.Errorf("invalid version %s: must be a positive integer", args[1])
+	}
+
+	s := utils.NewSpinner()
+
+	s.Suffix = fmt.Sprintf(" Rolling back env group '%s' in namespace '%s' to version %d", args[0], namespace, version)
+	s.Start()
+
+	envGroup, err := client.RollbackEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, &types.RollbackEnvGroupRequest{
+			Name:                 args[0],
+			Version:              uint(version),
+			RollbackApplications: envRollbackApps,
+		},
+	)
+
+	s.Stop()
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Rolled back env group %s to version %d, as version %d\n", args[0], version, envGroup.Version)
+
+	if !envRollbackApps && len(envGroup.Applications) > 0 {
+		fmt.Printf("The applications synced to the env group were not redeployed: %s\n", strings.Join(envGroup.Applications, ", "))
+	}
+
+	return nil
+}
+
+func envSeal(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	s := utils.NewSpinner()
+
+	// the spinner is written to stderr, so that it isn't mixed into a manifest written to stdout
+	if !utils.IsCIMode() {
+		s.Writer = os.Stderr
+	}
+
+	s.Suffix = fmt.Sprintf(" Sealing env group '%s' in namespace '%s'", args[0], namespace)
+	s.Start()
+
+	resp, err := client.SealEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, &types.SealEnvGroupRequest{
+			Name:       args[0],
+			Version:    envSealVersion,
+			SecretName: envSealSecretName,
+			Scope:      types.SealedSecretScope(envSealScope),
+		},
+	)
+
+	s.Stop()
+
+	if err != nil {
+		return err
+	}
+
+	if envFile == "-" {
+		fmt.Print(resp.Manifest)
+		return nil
+	}
+
+	if err := os.WriteFile(envFile, []byte(resp.Manifest), 0600); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Fprintf(os.Stderr, "Wrote version %d of env group %s to %s\n", resp.Version, args[0], envFile)
+
+	return nil
+}
+
+// getEnvGroupVariables returns the variables of the latest version of an env group, where the
+// values of secret variables are references to the env group's secret. If allowNotFound is set,
+// no variables are returned for an env group which doesn't exist.
+func getEnvGroupVariables(client *api.Client, name string, allowNotFound bool) (map[string]string, error) {
+	s := utils.NewSpinner()
+
+	s.Suffix = fmt.Sprintf(" Fetching env group '%s' in namespace '%s'", name, namespace)
+	s.Start()
+
+	envGroupResp, err := client.GetEnvGroup(context.Background(), cliConf.Project, cliConf.Cluster, namespace,
+		&types.GetEnvGroupRequest{
+			Name: name,
+		},
+	)
+
+	s.Stop()
+
+	if err != nil && allowNotFound && err.Error() == "env group not found" {
+		return make(map[string]string), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]string)
+
+	for key, value := range envGroupResp.Variables {
+		variables[key] = value
+	}
+
+	return variables, nil
+}
+
+// updateEnvGroupVariables creates a new version of an env group with the given variables
+func updateEnvGroupVariables(client *api.Client, name string, variables, secretVariables map[string]string) error {
+	s := utils.NewSpinner()
+
+	s.Suffix = fmt.Sprintf(" Updating env group '%s' in namespace '%s'", name, namespace)
+	s.Start()
+
+	_, err := client.CreateEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, &types.CreateEnvGroupRequest{
+			Name:            name,
+			Variables:       variables,
+			SecretVariables: secretVariables,
+		},
+	)
+
+	s.Stop()
+
+	return err
+}
+
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	vars, err := dotenv.Parse(f)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return vars, nil
+}
+
+// isSecretEnvValue returns whether the value of an env group's variable is a reference to a
+// secret variable
+func isSecretEnvValue(value string) bool {
+	return strings.Contains(value, "PORTERSECRET")
+}
+
+func sortedKeys(vars map[string]string) []string {
+	keys := make([]string, 0, len(vars))
+
+	for key := range vars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
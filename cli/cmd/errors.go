@@ -24,6 +24,11 @@ func checkLoginAndRun(args []string, runner func(user *types.GetAuthenticatedUse
 		red := color.New(color.FgRed)
 
 		if strings.Contains(err.Error(), "Forbidden") {
+			if ci {
+				red.Fprint(os.Stderr, "You are not logged in. In CI mode, pass an API token with --token or PORTER_TOKEN\n")
+				return ErrNotLoggedIn
+			}
+
 			red.Print("You are not logged in. Log in using \"porter auth login\"\n")
 			return ErrNotLoggedIn
 		} else if strings.Contains(err.Error(), "connection refused") {
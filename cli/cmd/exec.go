@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+// execCmd represents the "porter exec" base command when called
+// without any subcommands
+var execCmd = &cobra.Command{
+	Use:   "exec [release] -- COMMAND [args...]",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Runs a command in a running container of an application.",
+	Long: fmt.Sprintf(`
+%s
+
+Runs a command in a running container of an application, through the Porter API. Unlike "porter run",
+no kubeconfig access to the cluster is required. For example:
+
+  %s
+
+A TTY is allocated when the command is run from a terminal. If the command's executable does not
+exist in the container, which is the case for shells in containers built from distroless images,
+an ephemeral debug container which shares the container's processes is started instead:
+
+  %s
+
+The command exits with the exit code of the command run in the container.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter exec\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter exec web -- sh"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter exec web --debug-image busybox:1.35 -- sh"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, execInApp)
+
+		if err != nil {
+			os.Exit(1)
+		}
+
+		os.Exit(execExitCode)
+	},
+}
+
+var execContainer string
+var execDebugImage string
+var execNoDebugFallback bool
+
+// execExitCode is the exit code of the command run by "porter exec"
+var execExitCode int
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"namespace of release to connect to",
+	)
+
+	execCmd.PersistentFlags().StringVarP(
+		&execContainer,
+		"container",
+		"c",
+		"",
+		"name of the container inside pod to run the command in",
+	)
+
+	execCmd.PersistentFlags().StringVar(
+		&execDebugImage,
+		"debug-image",
+		"",
+		"image of the debug container started when the command is not found in the container",
+	)
+
+	execCmd.PersistentFlags().BoolVar(
+		&execNoDebugFallback,
+		"no-debug-fallback",
+		false,
+		"do not start a debug container when the command is not found in the container",
+	)
+}
+
+func execInApp(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	execArgs := args[1:]
+
+	t := term.TTY{
+		In:  os.Stdin,
+		Out: os.Stdout,
+		Raw: true,
+	}
+
+	interactive := t.IsTerminalIn()
+
+	pods, err := getPods(client, namespace, args[0])
+
+	if err != nil {
+		return fmt.Errorf("could not retrieve list of pods: %w", err)
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("no running pods were found for release %s", args[0])
+	}
+
+	pod := pods[0]
+
+	if len(pods) > 1 && interactive {
+		podNames := make([]string, 0, len(pods))
+
+		for _, p := range pods {
+			podNames = append(podNames, p.Name)
+		}
+
+		selected, err := utils.PromptSelect("Select the pod:", podNames)
+
+		if err != nil {
+			return err
+		}
+
+		for _, p := range pods {
+			if p.Name == selected {
+				pod = p
+			}
+		}
+	}
+
+	container := execContainer
+
+	if container == "" && len(pod.ContainerNames) > 1 && interactive {
+		container, err = utils.PromptSelect("Select the container:", pod.ContainerNames)
+
+		if err != nil {
+			return err
+		}
+	} else if container == "" && len(pod.ContainerNames) > 0 {
+		container = pod.ContainerNames[0]
+	}
+
+	req := &types.ExecRequest{
+		Container: container,
+		Command:   execArgs,
+		TTY:       interactive,
+	}
+
+	streams := &api.ExecStreams{
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+
+	var status *types.ExecStatus
+
+	runExec := func() error {
+		status, err = client.ExecPod(context.Background(), cliConf.Project, cliConf.Cluster, namespace, pod.Name, req, streams)
+
+		return err
+	}
+
+	if interactive {
+		resize := make(chan types.ExecResize)
+		streams.Resize = resize
+
+		sizeQueue := t.MonitorSize(t.GetSize())
+
+		go func() {
+			for {
+				size := sizeQueue.Next()
+
+				if size == nil {
+					return
+				}
+
+				resize <- types.ExecResize{Width: size.Width, Height: size.Height}
+			}
+		}()
+
+		err = t.Safe(runExec)
+	} else {
+		err = runExec()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if status.CommandNotFound && !execNoDebugFallback {
+		color.New(color.FgYellow).Fprintf(
+			os.Stderr, "%s was not found in container %s, starting a debug container instead\n", execArgs[0], container,
+		)
+
+		return execInDebugContainer(client, &t, pod.Name, container, execArgs)
+	}
+
+	if status.Error != "" {
+		return fmt.Errorf("%s", status.Error)
+	}
+
+	execExitCode = status.ExitCode
+
+	return nil
+}
+
+// execInDebugContainer runs a command in an ephemeral container which shares the process
+// namespace of the target container
+func execInDebugContainer(client *api.Client, t *term.TTY, podName, container string, args []string) error {
+	debugContainer, err := client.CreateDebugContainer(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, podName,
+		&types.CreateDebugContainerRequest{
+			Image:           execDebugImage,
+			TargetContainer: container,
+			Command:         args,
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("could not start a debug container: %w", err)
+	}
+
+	color.New(color.FgYellow).Fprintf(
+		os.Stderr, "Attaching to debug container %s (%s). If you don't see a command prompt, try pressing enter.\n",
+		debugContainer.Name, debugContainer.Image,
+	)
+
+	attach := func() error {
+		return client.AttachDebugContainer(
+			context.Background(), cliConf.Project, cliConf.Cluster, namespace, podName, debugContainer.Name,
+			os.Stdin, os.Stdout,
+		)
+	}
+
+	if t.IsTerminalIn() {
+		return t.Safe(attach)
+	}
+
+	return attach()
+}
@@ -5,19 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"text/tabwriter"
 
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
-	"helm.sh/helm/v3/pkg/time"
+	"sigs.k8s.io/yaml"
 )
 
 // getCmd represents the "porter get" base command when called
 // without any subcommands
 var getCmd = &cobra.Command{
 	Use:   "get [release]",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	Short: "Fetches a release.",
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, get)
@@ -31,7 +31,7 @@ var getCmd = &cobra.Command{
 // getValuesCmd represents the "porter get values" command
 var getValuesCmd = &cobra.Command{
 	Use:   "values [release]",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	Short: "Fetches the Helm values for a release.",
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, getValues)
@@ -42,8 +42,6 @@ var getValuesCmd = &cobra.Command{
 	},
 }
 
-var output string
-
 func init() {
 	getCmd.PersistentFlags().StringVar(
 		&namespace,
@@ -52,27 +50,28 @@ func init() {
 		"the namespace of the release",
 	)
 
-	getCmd.PersistentFlags().StringVar(
-		&output,
-		"output",
-		"",
-		"the output format to use (\"yaml\" or \"json\")",
-	)
-
 	getCmd.AddCommand(getValuesCmd)
 
 	rootCmd.AddCommand(getCmd)
 }
 
 type getReleaseInfo struct {
-	Name         string    `json:"name" yaml:"name"`
-	Namespace    string    `json:"namespace" yaml:"namespace"`
-	LastDeployed time.Time `json:"last_deployed" yaml:"last_deployed"`
-	ReleaseType  string    `json:"release_type" yaml:"release_type"`
-	RevisionID   int       `json:"revision_id" yaml:"revision_id"`
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	LastDeployed string `json:"last_deployed"`
+	ReleaseType  string `json:"release_type"`
+	ChartVersion string `json:"chart_version"`
+	RevisionID   int    `json:"revision_id"`
+	Status       string `json:"status"`
 }
 
 func get(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	args, err := withReleaseArg(client, args)
+
+	if err != nil {
+		return err
+	}
+
 	rel, err := client.GetRelease(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0])
 
 	if err != nil {
@@ -82,39 +81,34 @@ func get(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 	relInfo := &getReleaseInfo{
 		Name:         rel.Name,
 		Namespace:    rel.Namespace,
-		LastDeployed: rel.Info.LastDeployed,
+		LastDeployed: formatTime(rel.Info.LastDeployed.Time),
 		ReleaseType:  rel.Chart.Metadata.Name,
+		ChartVersion: rel.Chart.Metadata.Version,
 		RevisionID:   rel.Release.Version,
+		Status:       rel.Info.Status.String(),
 	}
 
-	if output == "yaml" {
-		bytes, err := yaml.Marshal(relInfo)
+	return writeOutput(relInfo, func(w *tabwriter.Writer, wide bool) {
+		fmt.Fprintf(w, "Name:\t%s\n", relInfo.Name)
+		fmt.Fprintf(w, "Namespace:\t%s\n", relInfo.Namespace)
+		fmt.Fprintf(w, "Last deployed:\t%s\n", relInfo.LastDeployed)
+		fmt.Fprintf(w, "Release type:\t%s\n", relInfo.ReleaseType)
+		fmt.Fprintf(w, "Revision ID:\t%d\n", relInfo.RevisionID)
 
-		if err != nil {
-			return err
+		if wide {
+			fmt.Fprintf(w, "Chart version:\t%s\n", relInfo.ChartVersion)
+			fmt.Fprintf(w, "Status:\t%s\n", relInfo.Status)
 		}
+	})
+}
 
-		fmt.Println(string(bytes))
-	} else if output == "json" {
-		bytes, err := json.Marshal(relInfo)
-
-		if err != nil {
-			return err
-		}
+func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	args, err := withReleaseArg(client, args)
 
-		fmt.Println(string(bytes))
-	} else {
-		fmt.Printf("Name:          %s\n", relInfo.Name)
-		fmt.Printf("Namespace:     %s\n", relInfo.Namespace)
-		fmt.Printf("Last deployed: %s\n", relInfo.LastDeployed)
-		fmt.Printf("Release type:  %s\n", relInfo.ReleaseType)
-		fmt.Printf("Revision ID:   %d\n", relInfo.RevisionID)
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
-
-func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
 	rel, err := client.GetRelease(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0])
 
 	if err != nil {
@@ -123,22 +117,29 @@ func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args [
 
 	values := rel.Config
 
-	if output == "json" {
-		bytes, err := json.Marshal(values)
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	switch output {
+	case outputJSON:
+		bytes, err := json.MarshalIndent(values, "", "  ")
 
 		if err != nil {
 			return err
 		}
 
 		fmt.Println(string(bytes))
-	} else { // yaml is the default
+	case "", outputYAML: // yaml is the default
 		bytes, err := yaml.Marshal(values)
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(string(bytes))
+		fmt.Print(string(bytes))
+	default:
+		return fmt.Errorf("invalid output format %q: values can only be written as json or yaml", output)
 	}
 
 	return nil
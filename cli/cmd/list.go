@@ -179,26 +179,49 @@ func writeReleases(client *api.Client, kind string) error {
 		releases = append(releases, resp...)
 	}
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "NAMESPACE", "STATUS", "KIND")
+	res := make([]*releaseOutput, 0)
 
 	for _, rel := range releases {
 		chartName := rel.Chart.Name()
 
-		if kind == "all" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
-		} else if kind == "application" && (chartName == "web" || chartName == "worker") {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
-		} else if kind == "job" && chartName == "job" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
-		} else if kind == "addon" && chartName != "web" && chartName != "worker" && chartName != "job" {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Info.Status, chartName)
+		isApp := chartName == "web" || chartName == "worker"
+		isJob := chartName == "job"
+
+		if (kind == "application" && !isApp) || (kind == "job" && !isJob) || (kind == "addon" && (isApp || isJob)) {
+			continue
+		}
+
+		chartVersion := ""
+
+		if rel.Chart.Metadata != nil {
+			chartVersion = rel.Chart.Metadata.Version
 		}
+
+		res = append(res, &releaseOutput{
+			Name:         rel.Name,
+			Namespace:    rel.Namespace,
+			Status:       rel.Info.Status.String(),
+			Kind:         chartName,
+			ChartVersion: chartVersion,
+			Revision:     rel.Version,
+			LastDeployed: formatTime(rel.Info.LastDeployed.Time),
+		})
 	}
 
-	w.Flush()
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "NAME", "NAMESPACE", "STATUS", "KIND", "VERSION", "REVISION", "LAST DEPLOYED")
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "NAMESPACE", "STATUS", "KIND")
+		}
 
-	return nil
+		for _, rel := range res {
+			if wide {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", rel.Name, rel.Namespace, rel.Status, rel.Kind,
+					rel.ChartVersion, rel.Revision, rel.LastDeployed)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rel.Name, rel.Namespace, rel.Status, rel.Kind)
+			}
+		}
+	})
 }
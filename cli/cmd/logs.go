@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
-	"github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -14,8 +19,35 @@ import (
 // without any subcommands
 var logsCmd = &cobra.Command{
 	Use:   "logs [release]",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	Short: "Logs the output from a given application.",
+	Long: fmt.Sprintf(`
+%s
+
+Streams the aggregated logs of every pod of an application, with each line prefixed by the pod
+and container it was written by. For example:
+
+  %s
+
+To only show the logs of a single container, or written in the last 10 minutes:
+
+  %s
+
+Use --follow to keep streaming new logs. The stream reconnects automatically if the connection
+is lost, or once the pods are replaced by a new deploy.
+
+  %s
+
+The --selector flag narrows the pods with an additional label selector:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter logs\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter logs web --namespace default"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter logs web --container web --since 10m"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter logs web -f"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter logs web --selector tier=worker"),
+	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, logs)
 
@@ -26,6 +58,12 @@ var logsCmd = &cobra.Command{
 }
 
 var follow bool
+var logsSince time.Duration
+var logsContainer string
+var logsSelector string
+
+// the maximum time to wait between attempts to reconnect to a log stream
+const maxLogsReconnectBackoff = 30 * time.Second
 
 func init() {
 	rootCmd.AddCommand(logsCmd)
@@ -44,71 +82,130 @@ func init() {
 		false,
 		"specify if the logs should be streamed",
 	)
+
+	logsCmd.PersistentFlags().DurationVar(
+		&logsSince,
+		"since",
+		0,
+		"only show logs newer than a relative duration like 30s, 5m, or 1h",
+	)
+
+	logsCmd.PersistentFlags().StringVarP(
+		&logsContainer,
+		"container",
+		"c",
+		"",
+		"only show the logs of the container with this name",
+	)
+
+	logsCmd.PersistentFlags().StringVarP(
+		&logsSelector,
+		"selector",
+		"l",
+		"",
+		"an additional label selector to filter the application's pods",
+	)
 }
 
 func logs(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
-	podsSimple, err := getPods(client, namespace, args[0])
+	if logsSince < 0 {
+		return fmt.Errorf("--since must be a positive duration")
+	}
+
+	args, err := withReleaseArg(client, args)
 
 	if err != nil {
-		return fmt.Errorf("Could not retrieve list of pods: %s", err.Error())
+		return err
 	}
 
-	// if length of pods is 0, throw error
-	var selectedPod podSimple
+	selector := fmt.Sprintf("app.kubernetes.io/instance=%s", args[0])
 
-	if len(podsSimple) == 0 {
-		return fmt.Errorf("At least one pod must exist in this deployment.")
-	} else if len(podsSimple) == 1 {
-		selectedPod = podsSimple[0]
-	} else {
-		podNames := make([]string, 0)
+	if logsSelector != "" {
+		selector = fmt.Sprintf("%s,%s", selector, logsSelector)
+	}
 
-		for _, podSimple := range podsSimple {
-			podNames = append(podNames, podSimple.Name)
-		}
+	req := &types.StreamLogsRequest{
+		Selector:  selector,
+		Container: logsContainer,
+		Follow:    follow,
+	}
 
-		selectedPodName, err := utils.PromptSelect("Select the pod:", podNames)
+	if logsSince > 0 {
+		sinceTime := time.Now().Add(-logsSince)
+		req.SinceTime = &sinceTime
+	}
 
-		if err != nil {
-			return err
-		}
+	return streamLogs(client, namespace, req)
+}
 
-		// find selected pod
-		for _, podSimple := range podsSimple {
-			if selectedPodName == podSimple.Name {
-				selectedPod = podSimple
-			}
+// streamLogs prints the logs of the pods matched by a request. When following the logs, the
+// stream reconnects with a backoff until it is interrupted.
+func streamLogs(client *api.Client, namespace string, req *types.StreamLogsRequest) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
 		}
-	}
+	}()
 
-	var selectedContainerName string
+	var lastTimestamp *time.Time
+	backoff := time.Second
 
-	// if the selected pod has multiple container, spawn selector
-	if len(selectedPod.ContainerNames) == 0 {
-		return fmt.Errorf("At least one pod must exist in this deployment.")
-	} else if len(selectedPod.ContainerNames) == 1 {
-		selectedContainerName = selectedPod.ContainerNames[0]
-	} else {
-		selectedContainer, err := utils.PromptSelect("Select the container:", selectedPod.ContainerNames)
+	for attempt := 0; ; attempt++ {
+		received := false
 
-		if err != nil {
+		err := client.StreamLogs(ctx, cliConf.Project, cliConf.Cluster, namespace, req, func(line *types.PodLogLine) {
+			received = true
+
+			if line.Timestamp != nil {
+				lastTimestamp = line.Timestamp
+			}
+
+			printLogLine(line)
+		})
+
+		// errors opening the first stream, such as a missing application, are not retried
+		if ctx.Err() != nil || !req.Follow || (err != nil && attempt == 0) {
 			return err
 		}
 
-		selectedContainerName = selectedContainer
-	}
+		// the stream is resumed from the last line that was received. Kubernetes only filters logs
+		// by whole seconds, so lines written in the same second as that line may be repeated.
+		if lastTimestamp != nil {
+			sinceTime := lastTimestamp.Add(time.Nanosecond)
+			req.SinceTime = &sinceTime
+		}
 
-	config := &PorterRunSharedConfig{
-		Client: client,
-	}
+		if received {
+			backoff = time.Second
+		}
+
+		if err != nil {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "Log stream disconnected: %s. Reconnecting in %s...\n", err.Error(), backoff)
+		}
 
-	err = config.setSharedConfig()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
 
-	if err != nil {
-		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+		if backoff *= 2; backoff > maxLogsReconnectBackoff {
+			backoff = maxLogsReconnectBackoff
+		}
 	}
+}
 
-	_, err = pipePodLogsToStdout(config, namespace, selectedPod.Name, selectedContainerName, follow)
+func printLogLine(line *types.PodLogLine) {
+	prefix := color.New(color.FgCyan).Sprintf("[%s/%s]", line.Pod, line.Container)
 
-	return err
+	fmt.Printf("%s %s\n", prefix, strings.TrimRight(line.Line, "\r\n"))
 }
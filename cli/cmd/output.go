@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// output is the format which list and get commands write their results in
+var output string
+
+const (
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputWide = "wide"
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"the output format of list and get commands (\"json\", \"yaml\" or \"wide\")",
+	)
+}
+
+// writeOutput writes data as JSON or YAML if one of those output formats is set, and otherwise
+// calls writeTable to write a table of the data, with extra columns if the wide format is set.
+// The data written as JSON or YAML should be one of the output structs below, so that the
+// fields which scripts depend on don't change along with the API types.
+func writeOutput(data interface{}, writeTable func(w *tabwriter.Writer, wide bool)) error {
+	switch output {
+	case outputJSON:
+		bytes, err := json.MarshalIndent(data, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(bytes))
+	case outputYAML:
+		bytes, err := yaml.Marshal(data)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(bytes))
+	case "", outputWide:
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 2, '\t', tabwriter.AlignRight)
+
+		writeTable(w, output == outputWide)
+
+		w.Flush()
+	default:
+		return fmt.Errorf("invalid output format %q: must be one of json, yaml or wide", output)
+	}
+
+	return nil
+}
+
+// formatTime formats a timestamp of the output structs, which is empty if the time is not set
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+type releaseOutput struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	Status       string `json:"status"`
+	Kind         string `json:"kind"`
+	ChartVersion string `json:"chart_version"`
+	Revision     int    `json:"revision"`
+	LastDeployed string `json:"last_deployed"`
+}
+
+type projectOutput struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+type clusterOutput struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Server  string `json:"server"`
+	Service string `json:"service"`
+	Current bool   `json:"current"`
+}
+
+type namespaceOutput struct {
+	Name              string `json:"name"`
+	Status            string `json:"status"`
+	CreationTimestamp string `json:"creation_timestamp"`
+}
+
+type registryOutput struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Service string `json:"service"`
+	Current bool   `json:"current"`
+}
+
+type repositoryOutput struct {
+	Name      string `json:"name"`
+	URI       string `json:"uri"`
+	CreatedAt string `json:"created_at"`
+}
+
+type imageOutput struct {
+	Image         string   `json:"image"`
+	Tag           string   `json:"tag"`
+	Digest        string   `json:"digest"`
+	PushedAt      string   `json:"pushed_at"`
+	Size          int64    `json:"size"`
+	Architectures []string `json:"architectures"`
+}
+
+type previewOutput struct {
+	PullRequest    uint   `json:"pull_request"`
+	Repository     string `json:"repository"`
+	Branch         string `json:"branch"`
+	Status         string `json:"status"`
+	Namespace      string `json:"namespace"`
+	URL            string `json:"url"`
+	WorkflowRunURL string `json:"workflow_run_url"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+type pluginOutput struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Warnings []string `json:"warnings"`
+}
+
+type contextOutput struct {
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	Project  uint   `json:"project"`
+	Cluster  uint   `json:"cluster"`
+	Registry uint   `json:"registry"`
+	Current  bool   `json:"current"`
+}
+
+type envVarOutput struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
+}
+
+type envGroupVersionOutput struct {
+	Version   uint   `json:"version"`
+	CreatedAt string `json:"created_at"`
+	Latest    bool   `json:"latest"`
+}
+
+type envSecretAccessOutput struct {
+	Time    string   `json:"time"`
+	UserID  uint     `json:"user_id"`
+	Version uint     `json:"version"`
+	Keys    []string `json:"keys"`
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/cli/cmd/plugin"
+	"github.com/spf13/cobra"
+)
+
+var pluginInstallName string
+
+// pluginCmd represents the "porter plugin" base command when called
+// without any subcommands
+var pluginCmd = &cobra.Command{
+	Use:     "plugin",
+	Aliases: []string{"plugins"},
+	Short:   "Commands that manage CLI plugins",
+	Long: fmt.Sprintf(`
+%s
+
+Plugins extend the CLI with new commands. A plugin is any executable whose name starts with
+"porter-", which is found in %s or on your PATH. For example, an executable named
+porter-deploy-all is run by:
+
+  %s
+
+Any arguments and flags following the plugin's name are passed to the plugin. The plugin is run
+with the PORTER_HOST, PORTER_PROJECT, PORTER_CLUSTER and PORTER_TOKEN environment variables set
+from the current configuration, and with PORTER_BIN set to the path of the porter executable, so
+that plugins can call the Porter API or run porter commands. Built-in commands take precedence
+over plugins with the same name.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter plugin\":"),
+		filepath.Join("~", ".porter", "plugins"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter deploy-all --namespace staging"),
+	),
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the plugins found in the plugin directory and on your PATH",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listPlugins(); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "%s\n", err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install [path or url]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Installs a plugin from a local file or an http(s) URL",
+	Long: fmt.Sprintf(`
+%s
+
+Installs a plugin executable to %s, from a local file or an http(s) URL. The name
+of the plugin is read from the name of the file, without its "porter-" prefix, unless it is set
+with the --name flag:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter plugin install\":"),
+		filepath.Join("~", ".porter", "plugins"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter plugin install https://example.com/releases/porter-db-shell-linux-amd64 --name db-shell"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := installPlugin(args[0]); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "%s\n", err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+
+	pluginInstallCmd.PersistentFlags().StringVar(
+		&pluginInstallName,
+		"name",
+		"",
+		"the name of the plugin, which is read from the name of the file if not set",
+	)
+}
+
+func pluginDir() string {
+	return filepath.Join(home, ".porter", "plugins")
+}
+
+// builtinCommands returns the names and aliases of the built-in top-level commands
+func builtinCommands() map[string]bool {
+	res := map[string]bool{
+		"help":       true,
+		"completion": true,
+	}
+
+	for _, cmd := range rootCmd.Commands() {
+		res[cmd.Name()] = true
+
+		for _, alias := range cmd.Aliases {
+			res[alias] = true
+		}
+	}
+
+	return res
+}
+
+func listPlugins() error {
+	plugins := plugin.List(plugin.Dirs(pluginDir()), builtinCommands())
+
+	if len(plugins) == 0 && (output == "" || output == outputWide) {
+		fmt.Printf("No plugins were found in %s or on your PATH\n", pluginDir())
+		return nil
+	}
+
+	res := make([]*pluginOutput, 0, len(plugins))
+
+	for _, p := range plugins {
+		res = append(res, &pluginOutput{
+			Name:     p.Name,
+			Path:     p.Path,
+			Warnings: p.Warnings,
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		for _, p := range res {
+			fmt.Fprintf(w, "%s\t%s\n", color.New(color.Bold).Sprint(p.Name), p.Path)
+
+			for _, warning := range p.Warnings {
+				color.New(color.FgYellow).Fprintf(w, "  - warning: %s\n", warning)
+			}
+		}
+	})
+}
+
+func installPlugin(src string) error {
+	path, err := plugin.Install(pluginDir(), src, pluginInstallName)
+
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimPrefix(filepath.Base(path), plugin.Prefix)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	color.New(color.FgGreen).Printf("Installed the plugin to %s\n", path)
+
+	if builtinCommands()[name] {
+		color.New(color.FgYellow).Printf("The plugin is shadowed by the built-in command \"porter %s\", so it can't be run\n", name)
+		return nil
+	}
+
+	fmt.Printf("Run it with \"porter %s\"\n", name)
+
+	return nil
+}
+
+// runPlugin runs the plugin named by the first argument if it isn't a built-in command, and
+// returns the plugin's exit code and whether a plugin was run
+func runPlugin(args []string) (int, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || isCompletionRequest(args) {
+		return 0, false
+	}
+
+	if cmd, _, err := rootCmd.Find(args); err == nil && cmd != rootCmd {
+		return 0, false
+	}
+
+	if builtinCommands()[args[0]] {
+		return 0, false
+	}
+
+	path, ok := plugin.Find(plugin.Dirs(pluginDir()), args[0])
+
+	if !ok {
+		return 0, false
+	}
+
+	env := []string{
+		fmt.Sprintf("PORTER_HOST=%s", cliConf.Host),
+		fmt.Sprintf("PORTER_PROJECT=%d", cliConf.Project),
+		fmt.Sprintf("PORTER_CLUSTER=%d", cliConf.Cluster),
+	}
+
+	if cliConf.Token != "" {
+		env = append(env, fmt.Sprintf("PORTER_TOKEN=%s", cliConf.Token))
+	}
+
+	if bin, err := os.Executable(); err == nil {
+		env = append(env, fmt.Sprintf("PORTER_BIN=%s", bin))
+	}
+
+	// interrupts are handled by the plugin, which receives them from the terminal as well. They
+	// are caught rather than ignored, since ignored signals would be inherited by the plugin.
+	signal.Notify(make(chan os.Signal, 1), os.Interrupt)
+
+	code, err := plugin.Run(path, args[1:], env)
+
+	if err != nil {
+		color.New(color.FgRed).Fprintf(os.Stderr, "error running plugin %s: %s\n", path, err.Error())
+	}
+
+	return code, true
+}
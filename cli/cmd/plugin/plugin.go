@@ -0,0 +1,239 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// Prefix is the prefix of the names of plugin executables. A plugin named "deploy-all" is an
+// executable named porter-deploy-all, which is run by "porter deploy-all".
+const Prefix = "porter-"
+
+// Plugin is a plugin executable found in the plugin directory or on the PATH
+type Plugin struct {
+	Name string
+	Path string
+
+	// Warnings are the reasons why the plugin can't be run, if any
+	Warnings []string
+}
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Dirs returns the directories which are searched for plugins, in order of precedence: the
+// plugin directory which plugins are installed to, followed by the directories of the PATH
+func Dirs(pluginDir string) []string {
+	dirs := []string{pluginDir}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// Find returns the path of the plugin with the given name, if it exists
+func Find(dirs []string, name string) (string, bool) {
+	if !nameRegex.MatchString(name) {
+		return "", false
+	}
+
+	for _, dir := range dirs {
+		for _, fileName := range executableNames(name) {
+			path := filepath.Join(dir, fileName)
+
+			if isExecutable(path) {
+				return path, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// List returns the plugins in the given directories. Plugins which are shadowed by a plugin of
+// the same name earlier in the directories, or by a built-in command, are returned with warnings.
+func List(dirs []string, builtins map[string]bool) []*Plugin {
+	res := make([]*Plugin, 0)
+	seen := make(map[string]string)
+
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			if file.IsDir() || !strings.HasPrefix(file.Name(), Prefix) {
+				continue
+			}
+
+			path := filepath.Join(dir, file.Name())
+
+			if !isExecutable(path) {
+				continue
+			}
+
+			name := strings.TrimPrefix(file.Name(), Prefix)
+
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+
+			p := &Plugin{
+				Name: name,
+				Path: path,
+			}
+
+			if !nameRegex.MatchString(name) {
+				p.Warnings = append(p.Warnings, "the name of the plugin must only contain lowercase letters, numbers and dashes")
+			}
+
+			if builtins[name] {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("the plugin is shadowed by the built-in command \"porter %s\"", name))
+			}
+
+			if otherPath, ok := seen[name]; ok {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("the plugin is shadowed by %s", otherPath))
+			} else {
+				seen[name] = path
+			}
+
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+// Install installs a plugin to the plugin directory from a local file or an http(s) URL, and
+// returns the path it was installed to. If name is empty, the name of the plugin is read from
+// the name of the source file.
+func Install(pluginDir, src, name string) (string, error) {
+	if name == "" {
+		name = strings.TrimPrefix(filepath.Base(src), Prefix)
+
+		if runtime.GOOS == "windows" {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+	}
+
+	if !nameRegex.MatchString(name) {
+		return "", fmt.Errorf("%s is not a valid plugin name: plugin names must only contain lowercase letters, numbers and dashes", name)
+	}
+
+	var reader io.ReadCloser
+
+	if strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "http://") {
+		resp, err := http.Get(src)
+
+		if err != nil {
+			return "", fmt.Errorf("error downloading plugin: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", fmt.Errorf("error downloading plugin: %s returned status %d", src, resp.StatusCode)
+		}
+
+		reader = resp.Body
+	} else {
+		file, err := os.Open(src)
+
+		if err != nil {
+			return "", fmt.Errorf("error reading plugin: %w", err)
+		}
+
+		reader = file
+	}
+
+	defer reader.Close()
+
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		return "", err
+	}
+
+	// the plugin is written to a temporary file first, so that a failed download doesn't replace
+	// an installed version of the plugin
+	tmpFile, err := ioutil.TempFile(pluginDir, ".install-*")
+
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, reader); err != nil {
+		tmpFile.Close()
+		return "", fmt.Errorf("error writing plugin: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Chmod(tmpFile.Name(), 0755); err != nil {
+		return "", err
+	}
+
+	dest := filepath.Join(pluginDir, executableNames(name)[0])
+
+	if err := os.Rename(tmpFile.Name(), dest); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// Run runs a plugin with the given arguments and additional environment variables, connected to
+// the streams of the CLI, and returns the plugin's exit code
+func Run(path string, args []string, env []string) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), env...)
+
+	err := cmd.Run()
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	} else if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+func executableNames(name string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{Prefix + name + ".exe", Prefix + name + ".bat", Prefix + name + ".cmd"}
+	}
+
+	return []string{Prefix + name}
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		return true
+	}
+
+	return info.Mode()&0111 != 0
+}
@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeExecutable(t *testing.T, dir, name string) string {
+	path := filepath.Join(dir, name)
+
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\necho hello\n"), 0755); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return path
+}
+
+func TestFindAndList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are detected by file extension on windows")
+	}
+
+	pluginDir := t.TempDir()
+	pathDir := t.TempDir()
+
+	installed := writeExecutable(t, pluginDir, "porter-deploy-all")
+	writeExecutable(t, pathDir, "porter-deploy-all")
+	writeExecutable(t, pathDir, "porter-logs")
+	writeExecutable(t, pathDir, "kubectl")
+
+	// files which aren't executable are not plugins
+	if err := ioutil.WriteFile(filepath.Join(pathDir, "porter-notes"), []byte("notes"), 0644); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	dirs := []string{pluginDir, pathDir}
+
+	path, ok := Find(dirs, "deploy-all")
+
+	if !ok || path != installed {
+		t.Errorf("expected the installed plugin to take precedence, got %s", path)
+	}
+
+	if _, ok := Find(dirs, "notes"); ok {
+		t.Errorf("expected a file which isn't executable not to be found")
+	}
+
+	plugins := List(dirs, map[string]bool{"logs": true})
+
+	if len(plugins) != 3 {
+		t.Fatalf("expected 3 plugins, got %d", len(plugins))
+	}
+
+	warnings := make(map[string]int)
+
+	for _, p := range plugins {
+		warnings[p.Path] = len(p.Warnings)
+	}
+
+	expected := map[string]int{
+		installed: 0,
+		filepath.Join(pathDir, "porter-deploy-all"): 1,
+		filepath.Join(pathDir, "porter-logs"):       1,
+	}
+
+	for path, count := range expected {
+		if warnings[path] != count {
+			t.Errorf("expected %d warnings for %s, got %d", count, path, warnings[path])
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are detected by file extension on windows")
+	}
+
+	srcDir := t.TempDir()
+	pluginDir := filepath.Join(t.TempDir(), "plugins")
+
+	src := writeExecutable(t, srcDir, "porter-db-shell")
+
+	if err := os.Chmod(src, 0644); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	path, err := Install(pluginDir, src, "")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if found, ok := Find([]string{pluginDir}, "db-shell"); !ok || found != path {
+		t.Errorf("expected the installed plugin to be found, got %s", found)
+	}
+
+	if _, err := Install(pluginDir, src, "Invalid_Name"); err == nil {
+		t.Errorf("expected an invalid name to be rejected")
+	}
+}
@@ -3,28 +3,20 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"net/url"
+	"net"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/tools/portforward"
-	"k8s.io/client-go/transport/spdy"
 	"k8s.io/kubectl/pkg/util"
 )
 
@@ -34,6 +26,24 @@ var portForwardCmd = &cobra.Command{
 	Use:   "port-forward [release] [LOCAL_PORT:]REMOTE_PORT [...[LOCAL_PORT_N:]REMOTE_PORT_N]",
 	Short: "Forward one or more local ports to a pod of a release",
 	Args:  cobra.MinimumNArgs(2),
+	Long: fmt.Sprintf(`
+%s
+
+Forwards one or more local ports to a pod of a release. Connections are tunneled through the
+Porter API, so no kubeconfig access to the cluster is required. For example, to forward local
+port 8080 to port 80 of a pod of the release "web":
+
+  %s
+
+If the local port is omitted, a random local port is chosen. Remote ports can also be given by
+the name of a container port:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter port-forward\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter port-forward web 8080:80"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter port-forward web :http"),
+	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, portForward)
 
@@ -56,37 +66,12 @@ func init() {
 		"address",
 		[]string{"localhost"},
 		"Addresses to listen on (comma separated). Only accepts IP addresses or localhost as a value. "+
-			"When localhost is supplied, kubectl will try  to bind on both 127.0.0.1 and ::1 and will fail "+
+			"When localhost is supplied, porter will try to bind on both 127.0.0.1 and ::1 and will fail "+
 			"if neither of these addresses are available to bind.")
 
 	rootCmd.AddCommand(portForwardCmd)
 }
 
-func forwardPorts(
-	method string,
-	url *url.URL,
-	kubeConfig *rest.Config,
-	address, ports []string,
-	stopChan <-chan struct{},
-	readyChan chan struct{},
-) error {
-	transport, upgrader, err := spdy.RoundTripperFor(kubeConfig)
-
-	if err != nil {
-		return err
-	}
-
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, method, url)
-	fw, err := portforward.NewOnAddresses(
-		dialer, address, ports, stopChan, readyChan, os.Stdout, os.Stderr)
-
-	if err != nil {
-		return err
-	}
-
-	return fw.ForwardPorts()
-}
-
 // splitPort splits port string which is in form of [LOCAL PORT]:REMOTE PORT
 // and returns local and remote ports separately
 func splitPort(port string) (local, remote string) {
@@ -102,8 +87,7 @@ func portForward(user *types.GetAuthenticatedUserResponse, client *api.Client, a
 	var err error
 	var pod corev1.Pod
 
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Color("cyan")
+	s := utils.NewSpinner()
 	s.Suffix = fmt.Sprintf(" Loading list of pods for %s", args[0])
 	s.Start()
 
@@ -115,7 +99,17 @@ func portForward(user *types.GetAuthenticatedUserResponse, client *api.Client, a
 		return err
 	}
 
-	pods := *podsResp
+	pods := make([]corev1.Pod, 0)
+
+	for _, p := range *podsResp {
+		if p.Status.Phase == corev1.PodRunning {
+			pods = append(pods, p)
+		}
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("no running pods were found for release %s", args[0])
+	}
 
 	if len(pods) > 1 {
 		selectedPod, err := utils.PromptSelect("Select a pod to port-forward", func() []string {
@@ -145,72 +139,121 @@ func portForward(user *types.GetAuthenticatedUserResponse, client *api.Client, a
 		pod = pods[0]
 	}
 
-	kubeResp, err := client.GetKubeconfig(context.Background(), cliConf.Project, cliConf.Cluster, cliConf.Kubeconfig)
+	err = checkUDPPortInPod(args[1:], &pod)
 
 	if err != nil {
 		return err
 	}
 
-	kubeBytes := kubeResp.Kubeconfig
-
-	cmdConf, err := clientcmd.NewClientConfigFromBytes(kubeBytes)
+	ports, err := convertPodNamedPortToNumber(args[1:], pod)
 
 	if err != nil {
 		return err
 	}
 
-	restConf, err := cmdConf.ClientConfig()
+	listeners := make([]net.Listener, 0)
 
-	if err != nil {
-		return err
-	}
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
 
-	restConf.GroupVersion = &schema.GroupVersion{
-		Group:   "api",
-		Version: "v1",
-	}
+	for _, port := range ports {
+		localPort, remotePort := splitPort(port)
 
-	restConf.NegotiatedSerializer = runtime.NewSimpleNegotiatedSerializer(runtime.SerializerInfo{})
+		remote, err := strconv.ParseUint(remotePort, 10, 16)
 
-	restClient, err := rest.RESTClientFor(restConf)
+		if err != nil {
+			return fmt.Errorf("invalid remote port %s", remotePort)
+		}
 
-	if err != nil {
-		return err
-	}
+		if localPort == "" {
+			localPort = "0"
+		}
 
-	err = checkUDPPortInPod(args[1:], &pod)
+		portListeners, err := listenOnAddresses(address, localPort)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	ports, err := convertPodNamedPortToNumber(args[1:], pod)
+		listeners = append(listeners, portListeners...)
 
-	if err != nil {
-		return err
-	}
+		for _, l := range portListeners {
+			fmt.Printf("Forwarding from %s -> %d\n", l.Addr().String(), remote)
 
-	stopChannel := make(chan struct{}, 1)
-	readyChannel := make(chan struct{})
+			go acceptPortForwardConnections(client, pod.Name, l, uint16(remote))
+		}
+	}
 
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt)
 	defer signal.Stop(signals)
 
-	go func() {
-		<-signals
-		if stopChannel != nil {
-			close(stopChannel)
+	<-signals
+
+	return nil
+}
+
+// listenOnAddresses listens on a local port of each address, where localhost is bound on both
+// 127.0.0.1 and ::1 if available
+func listenOnAddresses(addresses []string, port string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0)
+
+	for _, addr := range addresses {
+		if addr == "localhost" {
+			for _, ip := range []string{"127.0.0.1", "::1"} {
+				if l, err := net.Listen("tcp", net.JoinHostPort(ip, port)); err == nil {
+					listeners = append(listeners, l)
+				}
+			}
+
+			continue
+		}
+
+		if net.ParseIP(addr) == nil {
+			return nil, fmt.Errorf("%s is not a valid IP address", addr)
+		}
+
+		l, err := net.Listen("tcp", net.JoinHostPort(addr, port))
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on %s: %w", net.JoinHostPort(addr, port), err)
+		}
+
+		listeners = append(listeners, l)
+	}
+
+	if len(listeners) == 0 {
+		return nil, fmt.Errorf("unable to listen on port %s on any of the requested addresses", port)
+	}
+
+	return listeners, nil
+}
+
+// acceptPortForwardConnections tunnels each connection accepted by the listener to a port of the
+// pod through the Porter API, until the listener is closed
+func acceptPortForwardConnections(client *api.Client, podName string, l net.Listener, port uint16) {
+	for {
+		conn, err := l.Accept()
+
+		if err != nil {
+			return
 		}
-	}()
 
-	req := restClient.Post().
-		Resource("pods").
-		Namespace(namespace).
-		Name(pod.Name).
-		SubResource("portforward")
+		go func() {
+			defer conn.Close()
 
-	return forwardPorts("POST", req.URL(), restConf, address, ports, stopChannel, readyChannel)
+			fmt.Printf("Handling connection for %d\n", port)
+
+			err := client.PortForwardPod(context.Background(), cliConf.Project, cliConf.Cluster, namespace, podName, port, conn)
+
+			if err != nil {
+				color.New(color.FgRed).Fprintf(os.Stderr, "error forwarding port %d: %s\n", port, err.Error())
+			}
+		}()
+	}
 }
 
 func checkUDPPortInPod(ports []string, pod *corev1.Pod) error {
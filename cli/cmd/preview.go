@@ -0,0 +1,460 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var previewRepo string
+var previewYes bool
+var previewLogsApp string
+
+// previewCmd represents the "porter preview" base command when called
+// without any subcommands
+var previewCmd = &cobra.Command{
+	Use:     "preview",
+	Aliases: []string{"previews"},
+	Short:   "Commands that manage the preview environments of pull requests",
+	Long: fmt.Sprintf(`
+%s
+
+Manages the preview deployments of pull requests in the current cluster. Preview deployments are
+identified by the number of their pull request. If pull requests with the same number exist in
+more than one repository, the repository is chosen with --repo:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter preview\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter preview retry 42 --repo porter-dev/porter"),
+	),
+}
+
+var previewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the preview deployments, and the open pull requests which can be deployed",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listPreviews)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var previewCreateCmd = &cobra.Command{
+	Use:   "create [pr-number]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Creates a preview deployment for an open pull request",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, createPreview)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var previewDeleteCmd = &cobra.Command{
+	Use:   "delete [pr-number]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Deletes the preview deployment of a pull request, along with its namespace",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, deletePreview)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var previewRetryCmd = &cobra.Command{
+	Use:   "retry [pr-number]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Re-runs the workflow which deploys the preview deployment of a pull request",
+	Long: fmt.Sprintf(`
+%s
+
+Re-runs the GitHub workflow which deploys the preview deployment of a pull request. If the
+deployment is inactive, it is re-enabled instead.
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter preview retry\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter preview retry 42"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, retryPreview)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var previewLogsCmd = &cobra.Command{
+	Use:   "logs [pr-number]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Logs the output of the applications of a preview deployment",
+	Long: fmt.Sprintf(`
+%s
+
+Streams the aggregated logs of every application in the namespace of a preview deployment. To
+only show the logs of a single application, and keep streaming new logs:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter preview logs\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter preview logs 42 --app web -f"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, previewLogs)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.PersistentFlags().StringVar(
+		&previewRepo,
+		"repo",
+		"",
+		"the repository of the pull request, in the form owner/name",
+	)
+
+	previewCmd.AddCommand(previewListCmd)
+	previewCmd.AddCommand(previewCreateCmd)
+	previewCmd.AddCommand(previewDeleteCmd)
+	previewCmd.AddCommand(previewRetryCmd)
+	previewCmd.AddCommand(previewLogsCmd)
+
+	previewDeleteCmd.PersistentFlags().BoolVarP(
+		&previewYes,
+		"yes",
+		"y",
+		false,
+		"delete the preview deployment without asking for confirmation",
+	)
+
+	previewLogsCmd.PersistentFlags().StringVar(
+		&previewLogsApp,
+		"app",
+		"",
+		"only show the logs of the application with this name",
+	)
+
+	previewLogsCmd.PersistentFlags().BoolVarP(
+		&follow,
+		"follow",
+		"f",
+		false,
+		"specify if the logs should be streamed",
+	)
+
+	previewLogsCmd.PersistentFlags().DurationVar(
+		&logsSince,
+		"since",
+		0,
+		"only show logs newer than a relative duration like 30s, 5m, or 1h",
+	)
+
+	previewLogsCmd.PersistentFlags().StringVarP(
+		&logsContainer,
+		"container",
+		"c",
+		"",
+		"only show the logs of the containers with this name",
+	)
+}
+
+func listPreviews(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListDeployments(context.Background(), cliConf.Project, cliConf.Cluster, &types.ListDeploymentRequest{})
+
+	if err != nil {
+		return err
+	}
+
+	res := make([]*previewOutput, 0)
+
+	for _, depl := range resp.Deployments {
+		if previewRepo != "" && previewRepo != deploymentRepo(depl) {
+			continue
+		}
+
+		branch := ""
+
+		if depl.GitHubMetadata != nil {
+			branch = depl.PRBranchFrom
+		}
+
+		res = append(res, &previewOutput{
+			PullRequest:    depl.PullRequestID,
+			Repository:     deploymentRepo(depl),
+			Branch:         branch,
+			Status:         string(depl.Status),
+			Namespace:      depl.Namespace,
+			URL:            depl.Subdomain,
+			WorkflowRunURL: depl.LastWorkflowRunURL,
+			UpdatedAt:      formatTime(depl.UpdatedAt),
+		})
+	}
+
+	for _, pr := range resp.PullRequests {
+		repo := fmt.Sprintf("%s/%s", pr.RepoOwner, pr.RepoName)
+
+		if previewRepo != "" && previewRepo != repo {
+			continue
+		}
+
+		res = append(res, &previewOutput{
+			PullRequest: pr.Number,
+			Repository:  repo,
+			Branch:      pr.BranchFrom,
+			Status:      "not deployed",
+			UpdatedAt:   formatTime(pr.UpdatedAt),
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "PR", "REPOSITORY", "BRANCH", "STATUS", "NAMESPACE", "URL",
+				"UPDATED", "WORKFLOW RUN")
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "PR", "REPOSITORY", "BRANCH", "STATUS", "NAMESPACE", "URL")
+		}
+
+		for _, preview := range res {
+			pr := "-"
+
+			if preview.PullRequest != 0 {
+				pr = fmt.Sprintf("#%d", preview.PullRequest)
+			}
+
+			if wide {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", pr, preview.Repository, preview.Branch, preview.Status,
+					preview.Namespace, preview.URL, preview.UpdatedAt, preview.WorkflowRunURL)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", pr, preview.Repository, preview.Branch, preview.Status,
+					preview.Namespace, preview.URL)
+			}
+		}
+	})
+}
+
+func createPreview(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	prNumber, err := parsePRNumber(args[0])
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListDeployments(context.Background(), cliConf.Project, cliConf.Cluster, &types.ListDeploymentRequest{})
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := findPreviewDeployment(resp, prNumber); err == nil {
+		return fmt.Errorf("pull request #%d already has a preview deployment, use \"porter preview retry\" to redeploy it", prNumber)
+	}
+
+	var pr *types.PullRequest
+
+	for _, p := range resp.PullRequests {
+		if p.Number != prNumber || (previewRepo != "" && previewRepo != fmt.Sprintf("%s/%s", p.RepoOwner, p.RepoName)) {
+			continue
+		}
+
+		if pr != nil {
+			return fmt.Errorf("pull request #%d exists in more than one repository, select one with --repo", prNumber)
+		}
+
+		pr = p
+	}
+
+	if pr == nil {
+		return fmt.Errorf("no open pull request #%d was found in the repositories with preview environments", prNumber)
+	}
+
+	depl, err := client.EnablePullRequest(context.Background(), cliConf.Project, cliConf.Cluster, pr)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf(
+		"Creating the preview deployment of pull request #%d in namespace %s\n", prNumber, depl.Namespace,
+	)
+
+	return nil
+}
+
+func deletePreview(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	depl, err := getPreviewDeployment(client, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if !previewYes {
+		proceed, err := utils.PromptConfirm(
+			fmt.Sprintf("Delete the preview deployment of pull request #%d and its namespace %s?", depl.PullRequestID, depl.Namespace),
+			false,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if !proceed {
+			return nil
+		}
+	}
+
+	err = client.DeleteDeployment(context.Background(), cliConf.Project, cliConf.Cluster, depl.ID)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deleted the preview deployment of pull request #%d\n", depl.PullRequestID)
+
+	return nil
+}
+
+func retryPreview(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	depl, err := getPreviewDeployment(client, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if depl.Status == types.DeploymentStatusInactive {
+		err = client.ReenableDeployment(context.Background(), cliConf.Project, cliConf.Cluster, depl.ID)
+
+		if err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Printf("Re-enabled the preview deployment of pull request #%d\n", depl.PullRequestID)
+
+		return nil
+	}
+
+	err = client.TriggerDeploymentWorkflow(context.Background(), cliConf.Project, cliConf.Cluster, depl.ID)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Re-running the deployment workflow of pull request #%d\n", depl.PullRequestID)
+
+	if depl.LastWorkflowRunURL != "" {
+		fmt.Printf("Previous workflow run: %s\n", depl.LastWorkflowRunURL)
+	}
+
+	return nil
+}
+
+func previewLogs(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if logsSince < 0 {
+		return fmt.Errorf("--since must be a positive duration")
+	}
+
+	depl, err := getPreviewDeployment(client, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	// every application deployed by Porter is labeled with the name of its release
+	selector := "app.kubernetes.io/instance"
+
+	if previewLogsApp != "" {
+		selector = fmt.Sprintf("app.kubernetes.io/instance=%s", previewLogsApp)
+	}
+
+	req := &types.StreamLogsRequest{
+		Selector:  selector,
+		Container: logsContainer,
+		Follow:    follow,
+	}
+
+	if logsSince > 0 {
+		sinceTime := time.Now().Add(-logsSince)
+		req.SinceTime = &sinceTime
+	}
+
+	return streamLogs(client, depl.Namespace, req)
+}
+
+// getPreviewDeployment returns the preview deployment of the pull request with the given number
+func getPreviewDeployment(client *api.Client, prNumberStr string) (*types.Deployment, error) {
+	prNumber, err := parsePRNumber(prNumberStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.ListDeployments(context.Background(), cliConf.Project, cliConf.Cluster, &types.ListDeploymentRequest{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return findPreviewDeployment(resp, prNumber)
+}
+
+func findPreviewDeployment(resp *types.ListDeploymentsResponse, prNumber uint) (*types.Deployment, error) {
+	var res *types.Deployment
+
+	for _, depl := range resp.Deployments {
+		if depl.PullRequestID != prNumber || (previewRepo != "" && previewRepo != deploymentRepo(depl)) {
+			continue
+		}
+
+		if res != nil {
+			return nil, fmt.Errorf("pull request #%d has preview deployments in more than one repository, select one with --repo", prNumber)
+		}
+
+		res = depl
+	}
+
+	if res == nil {
+		return nil, fmt.Errorf("no preview deployment was found for pull request #%d", prNumber)
+	}
+
+	return res, nil
+}
+
+func parsePRNumber(prNumberStr string) (uint, error) {
+	prNumber, err := strconv.ParseUint(strings.TrimPrefix(prNumberStr, "#"), 10, 64)
+
+	if err != nil || prNumber == 0 {
+		return 0, fmt.Errorf("%s is not a valid pull request number", prNumberStr)
+	}
+
+	return uint(prNumber), nil
+}
+
+func deploymentRepo(depl *types.Deployment) string {
+	if depl.GitHubMetadata == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", depl.RepoOwner, depl.RepoName)
+}
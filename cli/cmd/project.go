@@ -92,24 +92,27 @@ func listProjects(user *types.GetAuthenticatedUserResponse, client *api.Client,
 
 	projects := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\n", "ID", "NAME")
-
-	currProjectID := cliConf.Project
+	res := make([]*projectOutput, 0, len(projects))
 
 	for _, project := range projects {
-		if currProjectID == project.ID {
-			color.New(color.FgGreen).Fprintf(w, "%d\t%s (current project)\n", project.ID, project.Name)
-		} else {
-			fmt.Fprintf(w, "%d\t%s\n", project.ID, project.Name)
-		}
+		res = append(res, &projectOutput{
+			ID:      project.ID,
+			Name:    project.Name,
+			Current: project.ID == cliConf.Project,
+		})
 	}
 
-	w.Flush()
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		fmt.Fprintf(w, "%s\t%s\n", "ID", "NAME")
 
-	return nil
+		for _, project := range res {
+			if project.Current {
+				color.New(color.FgGreen).Fprintf(w, "%d\t%s (current project)\n", project.ID, project.Name)
+			} else {
+				fmt.Fprintf(w, "%d\t%s\n", project.ID, project.Name)
+			}
+		}
+	})
 }
 
 func deleteProject(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
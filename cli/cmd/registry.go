@@ -115,24 +115,39 @@ func listRegistries(user *types.GetAuthenticatedUserResponse, client *api.Client
 
 	registries := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "URL", "SERVICE")
-
-	currRegistryID := cliConf.Registry
+	res := make([]*registryOutput, 0, len(registries))
 
 	for _, registry := range registries {
-		if currRegistryID == registry.ID {
-			color.New(color.FgGreen).Fprintf(w, "%d\t%s\t%s (current registry)\n", registry.ID, registry.URL, registry.Service)
+		res = append(res, &registryOutput{
+			ID:      registry.ID,
+			Name:    registry.Name,
+			URL:     registry.URL,
+			Service: registry.Service,
+			Current: registry.ID == cliConf.Registry,
+		})
+	}
+
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "ID", "NAME", "URL", "SERVICE")
 		} else {
-			fmt.Fprintf(w, "%d\t%s\t%s\n", registry.ID, registry.URL, registry.Service)
+			fmt.Fprintf(w, "%s\t%s\t%s\n", "ID", "URL", "SERVICE")
 		}
-	}
 
-	w.Flush()
+		for _, registry := range res {
+			row := fmt.Sprintf("%d\t%s\t%s", registry.ID, registry.URL, registry.Service)
 
-	return nil
+			if wide {
+				row = fmt.Sprintf("%d\t%s\t%s\t%s", registry.ID, registry.Name, registry.URL, registry.Service)
+			}
+
+			if registry.Current {
+				color.New(color.FgGreen).Fprintf(w, "%s (current registry)\n", row)
+			} else {
+				fmt.Fprintln(w, row)
+			}
+		}
+	})
 }
 
 func deleteRegistry(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -184,18 +199,31 @@ func listRepos(user *types.GetAuthenticatedUserResponse, client *api.Client, arg
 
 	repos := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\n", "NAME", "CREATED_AT")
+	res := make([]*repositoryOutput, 0, len(repos))
 
 	for _, repo := range repos {
-		fmt.Fprintf(w, "%s\t%s\n", repo.Name, repo.CreatedAt.String())
+		res = append(res, &repositoryOutput{
+			Name:      repo.Name,
+			URI:       repo.URI,
+			CreatedAt: formatTime(repo.CreatedAt),
+		})
 	}
 
-	w.Flush()
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", "NAME", "URI", "CREATED_AT")
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", "NAME", "CREATED_AT")
+		}
 
-	return nil
+		for _, repo := range res {
+			if wide {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", repo.Name, repo.URI, repo.CreatedAt)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", repo.Name, repo.CreatedAt)
+			}
+		}
+	})
 }
 
 func listImages(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -217,16 +245,39 @@ func listImages(user *types.GetAuthenticatedUserResponse, client *api.Client, ar
 
 	imgs := *resp
 
-	w := new(tabwriter.Writer)
-	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
-
-	fmt.Fprintf(w, "%s\t%s\n", "IMAGE", "DIGEST")
+	res := make([]*imageOutput, 0, len(imgs))
 
 	for _, img := range imgs {
-		fmt.Fprintf(w, "%s\t%s\n", repoName+":"+img.Tag, img.Digest)
+		pushedAt := ""
+
+		if img.PushedAt != nil {
+			pushedAt = formatTime(*img.PushedAt)
+		}
+
+		res = append(res, &imageOutput{
+			Image:         repoName + ":" + img.Tag,
+			Tag:           img.Tag,
+			Digest:        img.Digest,
+			PushedAt:      pushedAt,
+			Size:          img.Size,
+			Architectures: img.Architectures,
+		})
 	}
 
-	w.Flush()
+	return writeOutput(res, func(w *tabwriter.Writer, wide bool) {
+		if wide {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "IMAGE", "DIGEST", "PUSHED_AT", "SIZE", "ARCHITECTURES")
+		} else {
+			fmt.Fprintf(w, "%s\t%s\n", "IMAGE", "DIGEST")
+		}
 
-	return nil
+		for _, img := range res {
+			if wide {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", img.Image, img.Digest, img.PushedAt, img.Size,
+					strings.Join(img.Architectures, ","))
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", img.Image, img.Digest)
+			}
+		}
+	})
 }
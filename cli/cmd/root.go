@@ -33,7 +33,15 @@ func Execute() {
 
 	rootCmd.PersistentFlags().AddFlagSet(utils.DefaultFlagSet)
 
-	if config.Version != "dev" {
+	registerCompletions()
+
+	// commands which aren't built in are run by the plugin of the same name, if one is installed
+	if code, ok := runPlugin(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
+	// the version check is skipped when completing a command, since it would delay the shell
+	if config.Version != "dev" && !isCompletionRequest(os.Args[1:]) {
 		ghClient := github.NewClient(nil)
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
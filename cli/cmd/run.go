@@ -119,23 +119,10 @@ func run(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 		color.New(color.FgBlue).Println("Using non-interactive mode. The first available pod will be used to run the command.")
 	}
 
-	if len(execArgs) > 0 {
-		release, err := client.GetRelease(
-			context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0],
-		)
+	execArgs, err := withBuildpacksLauncher(client, args[0], execArgs)
 
-		if err != nil {
-			return fmt.Errorf("error fetching release %s: %w", args[0], err)
-		}
-
-		if release.BuildConfig != nil &&
-			(strings.Contains(release.BuildConfig.Builder, "heroku") ||
-				strings.Contains(release.BuildConfig.Builder, "paketo")) &&
-			execArgs[0] != "/cnb/lifecycle/launcher" &&
-			execArgs[0] != "launcher" {
-			// this is a buildpacks release using a heroku builder, prepend the launcher
-			execArgs = append([]string{"/cnb/lifecycle/launcher"}, execArgs...)
-		}
+	if err != nil {
+		return err
 	}
 
 	podsSimple, err := getPods(client, namespace, args[0])
@@ -229,6 +216,34 @@ func run(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []strin
 	return executeRunEphemeral(config, namespace, selectedPod.Name, selectedContainerName, execArgs)
 }
 
+// withBuildpacksLauncher prepends the buildpacks launcher to a command run in a container of a
+// release built with a heroku or paketo builder, so that the command is run in the environment
+// set up by the buildpacks
+func withBuildpacksLauncher(client *api.Client, releaseName string, execArgs []string) ([]string, error) {
+	if len(execArgs) == 0 {
+		return execArgs, nil
+	}
+
+	release, err := client.GetRelease(
+		context.Background(), cliConf.Project, cliConf.Cluster, namespace, releaseName,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("error fetching release %s: %w", releaseName, err)
+	}
+
+	if release.BuildConfig != nil &&
+		(strings.Contains(release.BuildConfig.Builder, "heroku") ||
+			strings.Contains(release.BuildConfig.Builder, "paketo")) &&
+		execArgs[0] != "/cnb/lifecycle/launcher" &&
+		execArgs[0] != "launcher" {
+		// this is a buildpacks release using a heroku builder, prepend the launcher
+		return append([]string{"/cnb/lifecycle/launcher"}, execArgs...), nil
+	}
+
+	return execArgs, nil
+}
+
 func cleanup(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string) error {
 	config := &PorterRunSharedConfig{
 		Client: client,
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+)
+
+// runJobCmd represents the "porter run job" subcommand
+var runJobCmd = &cobra.Command{
+	Use:   "job [release] -- COMMAND [args...]",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Runs a command in a one-off job created from the pod template of a release.",
+	Long: fmt.Sprintf(`
+%s
+
+Runs a command in a Kubernetes job which is created from the pod template of a release, with the
+same image, environment and resources as the release's containers. The job's logs are streamed
+until it completes, and the command exits with the exit code of the job's container, so that it
+can be used to run tasks such as database migrations in CI. For example:
+
+  %s
+
+Use the --env flag to set environment variables in addition to the release's variables:
+
+  %s
+
+If the command is interrupted or the --timeout is reached, the job is deleted. Finished jobs are
+deleted after an hour.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter run job\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter run job web -- npm run migrate"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter run job web --env DRY_RUN=true --timeout 10m -- npm run migrate"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, runJob)
+
+		if err != nil {
+			os.Exit(1)
+		}
+
+		os.Exit(runJobExitCode)
+	},
+}
+
+var runJobEnv []string
+var runJobTimeout time.Duration
+
+// runJobExitCode is the exit code of the job run by "porter run job"
+var runJobExitCode int
+
+// runJobPollInterval is the interval at which the status of a job's pod is checked
+const runJobPollInterval = 2 * time.Second
+
+func init() {
+	runCmd.AddCommand(runJobCmd)
+
+	runJobCmd.PersistentFlags().StringArrayVar(
+		&runJobEnv,
+		"env",
+		nil,
+		"environment variable to set in the job, in the form KEY=VALUE, which can be repeated",
+	)
+
+	runJobCmd.PersistentFlags().DurationVar(
+		&runJobTimeout,
+		"timeout",
+		0,
+		"time after which the job is deleted if it hasn't completed, such as 10m",
+	)
+}
+
+func runJob(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	env := make(map[string]string)
+
+	for _, envVar := range runJobEnv {
+		key, val, ok := strings.Cut(envVar, "=")
+
+		if !ok || key == "" {
+			return fmt.Errorf("invalid environment variable %s: must be in the form KEY=VALUE", envVar)
+		}
+
+		env[key] = val
+	}
+
+	execArgs, err := withBuildpacksLauncher(client, args[0], args[1:])
+
+	if err != nil {
+		return err
+	}
+
+	job, err := client.RunJob(context.Background(), cliConf.Project, cliConf.Cluster, namespace, args[0], &types.RunJobRequest{
+		Command:   execArgs,
+		Env:       env,
+		Container: containerName,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating job: %w", err)
+	}
+
+	color.New(color.FgGreen).Printf("Created job %s, waiting for it to start\n", job.Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if runJobTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, runJobTimeout)
+		defer cancel()
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	pod, err := waitForJobPod(ctx, client, job, func(pod *v1.Pod) bool {
+		return pod.Status.Phase != v1.PodPending
+	})
+
+	if err == nil {
+		pod, err = streamJobLogs(ctx, client, job, pod)
+	}
+
+	if ctx.Err() != nil {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "Deleting job %s\n", job.Name)
+
+		if err := client.DeleteJob(context.Background(), cliConf.Project, cliConf.Cluster, namespace, job.Name); err != nil {
+			return fmt.Errorf("error deleting job %s: %w", job.Name, err)
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("job %s did not complete within %s", job.Name, runJobTimeout)
+		}
+
+		return fmt.Errorf("interrupted")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	runJobExitCode = getJobExitCode(pod, job.Container)
+
+	if runJobExitCode == 0 {
+		color.New(color.FgGreen).Printf("Job %s succeeded\n", job.Name)
+	} else {
+		color.New(color.FgRed).Fprintf(os.Stderr, "Job %s failed with exit code %d\n", job.Name, runJobExitCode)
+	}
+
+	return nil
+}
+
+// streamJobLogs streams the logs of a job's pod until the pod completes, and returns the
+// completed pod
+func streamJobLogs(ctx context.Context, client *api.Client, job *types.RunJobResponse, pod *v1.Pod) (*v1.Pod, error) {
+	logCtx, cancelLogs := context.WithCancel(ctx)
+	defer cancelLogs()
+
+	logsDone := make(chan error, 1)
+
+	go func() {
+		logsDone <- client.StreamLogs(logCtx, cliConf.Project, cliConf.Cluster, namespace, &types.StreamLogsRequest{
+			Selector:  "job-name=" + job.Name,
+			Container: job.Container,
+			Follow:    !isPodExited(pod),
+			TailLines: 10000,
+		}, printLogLine)
+	}()
+
+	pod, err := waitForJobPod(ctx, client, job, isPodExited)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the last lines of the logs can arrive after the pod has exited
+	select {
+	case <-logsDone:
+	case <-time.After(runJobPollInterval):
+	}
+
+	return pod, nil
+}
+
+// waitForJobPod polls the pod of a job until done returns true for it, printing the reasons
+// that the pod is waiting to start
+func waitForJobPod(
+	ctx context.Context,
+	client *api.Client,
+	job *types.RunJobResponse,
+	done func(pod *v1.Pod) bool,
+) (*v1.Pod, error) {
+	lastReason := ""
+
+	for {
+		pods, err := client.GetJobPods(ctx, cliConf.Project, cliConf.Cluster, namespace, job.Name)
+
+		if err != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("error getting the pods of job %s: %w", job.Name, err)
+		}
+
+		if len(pods) > 0 {
+			pod := &pods[0]
+
+			if done(pod) {
+				return pod, nil
+			}
+
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.State.Waiting != nil && status.State.Waiting.Reason != lastReason {
+					lastReason = status.State.Waiting.Reason
+
+					color.New(color.FgYellow).Fprintf(os.Stderr, "Waiting for the job to start: %s %s\n",
+						status.State.Waiting.Reason, status.State.Waiting.Message)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(runJobPollInterval):
+		}
+	}
+}
+
+// getJobExitCode returns the exit code of a container of a job's completed pod
+func getJobExitCode(pod *v1.Pod, container string) int {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode)
+		}
+	}
+
+	// the pod can fail without its container exiting, such as when it's evicted
+	if pod.Status.Phase == v1.PodFailed {
+		return 1
+	}
+
+	return 0
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/stackspec"
 	"github.com/spf13/cobra"
 )
 
@@ -215,3 +216,65 @@ func stackRemoveEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Clie
 
 	return nil
 }
+
+// applyStackSpec deploys a porter.yaml v2 file as a new revision of its stack, creating the stack
+// if it doesn't exist
+func applyStackSpec(client *api.Client, fileBytes []byte) error {
+	spec, err := stackspec.Parse(fileBytes)
+
+	if err != nil {
+		return err
+	}
+
+	if tag := os.Getenv("PORTER_TAG"); tag != "" {
+		spec.SetImageTag(tag)
+	}
+
+	listStacks, err := client.ListStacks(context.Background(), cliConf.Project, cliConf.Cluster, spec.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	var stackID string
+
+	for _, stk := range *listStacks {
+		if stk.Name == spec.Name {
+			stackID = stk.ID
+		}
+	}
+
+	if applyDryRun {
+		if stackID == "" {
+			fmt.Printf("stack %s would be created in namespace %s with %d service(s)\n", spec.Name, spec.Namespace, len(spec.Services))
+		} else {
+			fmt.Printf("stack %s would be deployed as a new revision with %d service(s)\n", spec.Name, len(spec.Services))
+		}
+
+		return nil
+	}
+
+	var stack *types.Stack
+
+	if stackID == "" {
+		stack, err = client.CreateStack(
+			context.Background(), cliConf.Project, cliConf.Cluster, spec.Namespace, spec.ToCreateStackRequest(),
+		)
+	} else {
+		stack, err = client.PutStackSpec(
+			context.Background(), cliConf.Project, cliConf.Cluster, spec.Namespace, stackID, spec.ToPutStackSpecRequest(),
+		)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if revision := stack.LatestRevision; revision != nil && revision.Status == types.StackRevisionStatusFailed {
+		return fmt.Errorf("revision %d of stack %s failed to deploy: %s", revision.ID, stack.Name, revision.Message)
+	}
+
+	color.New(color.FgGreen).Printf("successfully deployed stack %s\n", stack.Name)
+
+	return nil
+}
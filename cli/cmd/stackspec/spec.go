@@ -0,0 +1,440 @@
+package stackspec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// SourceConfigName is the name of the single source config of a stack which is deployed from
+// a porter.yaml v2 file. All services of the stack are deployed from the same image.
+const SourceConfigName = "default"
+
+// Spec is a porter.yaml v2 file, which describes the services of a stack which are built from
+// a single image and deployed together as one revision of the stack
+type Spec struct {
+	Version string `json:"version"`
+
+	// the name of the stack
+	Name string `json:"name"`
+
+	// the namespace which the stack is deployed in, "default" if not set
+	Namespace string `json:"namespace"`
+
+	Image     *Image              `json:"image"`
+	Build     *Build              `json:"build"`
+	EnvGroups []*EnvGroup         `json:"env_groups"`
+	Services  map[string]*Service `json:"services"`
+}
+
+// Image is the image which the services of the stack are deployed from
+type Image struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+}
+
+// Build describes how the image of the stack is built
+type Build struct {
+	// the build method, either docker or pack
+	Method string `json:"method"`
+
+	// the path to the build context, "." if not set
+	Context string `json:"context"`
+
+	// the path to the Dockerfile, if method is docker
+	Dockerfile string `json:"dockerfile"`
+
+	// the builder and buildpacks, if method is pack
+	Builder    string   `json:"builder"`
+	Buildpacks []string `json:"buildpacks"`
+
+	Git *Git `json:"git"`
+}
+
+// Git is the remote repository which the image of the stack is built from
+type Git struct {
+	IntegrationKind string `json:"integration_kind"`
+	IntegrationID   uint   `json:"integration_id"`
+
+	// the repository in owner/repo form
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+}
+
+// EnvGroup is an env group of the stack
+type EnvGroup struct {
+	Name            string            `json:"name"`
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
+// Service is an application of the stack, deployed from one of the web, worker or job charts
+type Service struct {
+	// the type of the service: web, worker or cron
+	Type string `json:"type"`
+
+	// the version of the chart, "latest" if not set
+	Version string `json:"version"`
+
+	// the command which the service runs, which defaults to the command of the image
+	Run string `json:"run"`
+
+	// the port which a web service listens on
+	Port int `json:"port"`
+
+	// the cron schedule of a cron service
+	Schedule string `json:"schedule"`
+
+	// the names of the env groups of the stack which are synced to the service
+	EnvGroups []string `json:"env_groups"`
+
+	Values map[string]interface{} `json:"values"`
+}
+
+// serviceCharts maps the type of a service to the chart it's deployed from
+var serviceCharts = map[string]string{
+	"web":    "web",
+	"worker": "worker",
+	"cron":   "job",
+}
+
+var buildMethods = map[string]bool{
+	"docker": true,
+	"pack":   true,
+}
+
+var dns1123Regex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// IsSpec returns whether a porter.yaml file is a v2 file which describes a stack
+func IsSpec(data []byte) bool {
+	raw := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return false
+	}
+
+	if version, _ := raw["version"].(string); version != "v2" {
+		return false
+	}
+
+	_, ok := raw["services"]
+
+	return ok
+}
+
+// Parse parses and validates a porter.yaml v2 file
+func Parse(data []byte) (*Spec, error) {
+	spec := &Spec{}
+
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("error parsing porter.yaml: %w", err)
+	}
+
+	if spec.Namespace == "" {
+		spec.Namespace = "default"
+	}
+
+	if spec.Build != nil && spec.Build.Context == "" {
+		spec.Build.Context = "."
+	}
+
+	if errs := spec.validate(); len(errs) > 0 {
+		errStrs := make([]string, 0, len(errs))
+
+		for _, err := range errs {
+			errStrs = append(errStrs, "- "+err.Error())
+		}
+
+		return nil, fmt.Errorf("porter.yaml is invalid:\n%s", strings.Join(errStrs, "\n"))
+	}
+
+	return spec, nil
+}
+
+func (s *Spec) validate() []error {
+	errs := make([]error, 0)
+
+	if s.Version != "v2" {
+		errs = append(errs, fmt.Errorf("version must be v2"))
+	}
+
+	if !dns1123Regex.MatchString(s.Name) {
+		errs = append(errs, fmt.Errorf("name '%s' is not a valid stack name", s.Name))
+	}
+
+	if !dns1123Regex.MatchString(s.Namespace) {
+		errs = append(errs, fmt.Errorf("namespace '%s' is not a valid name", s.Namespace))
+	}
+
+	if s.Image == nil || s.Image.Repository == "" || s.Image.Tag == "" {
+		errs = append(errs, fmt.Errorf("image: repository and tag must be set"))
+	}
+
+	if s.Build != nil {
+		if !buildMethods[s.Build.Method] {
+			errs = append(errs, fmt.Errorf("build: method must be one of docker or pack"))
+		} else if s.Build.Method == "pack" && s.Build.Builder == "" {
+			errs = append(errs, fmt.Errorf("build: builder must be set if method is pack"))
+		}
+
+		if git := s.Build.Git; git != nil && (git.Repo == "" || git.Branch == "") {
+			errs = append(errs, fmt.Errorf("build: git repo and branch must be set"))
+		}
+	}
+
+	envGroups := make(map[string]bool)
+
+	for i, group := range s.EnvGroups {
+		if !dns1123Regex.MatchString(group.Name) {
+			errs = append(errs, fmt.Errorf("env_groups[%d]: '%s' is not a valid name", i, group.Name))
+		} else if envGroups[group.Name] {
+			errs = append(errs, fmt.Errorf("env_groups[%d]: duplicate env group '%s'", i, group.Name))
+		}
+
+		envGroups[group.Name] = true
+
+		for key := range group.SecretVariables {
+			if _, ok := group.Variables[key]; ok {
+				errs = append(errs, fmt.Errorf("env group '%s': '%s' is both a variable and a secret variable", group.Name, key))
+			}
+		}
+	}
+
+	if len(s.Services) == 0 {
+		errs = append(errs, fmt.Errorf("services: at least one service must be set"))
+	}
+
+	for _, name := range s.serviceNames() {
+		svc := s.Services[name]
+
+		if !dns1123Regex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("services: '%s' is not a valid name", name))
+		}
+
+		if svc == nil {
+			errs = append(errs, fmt.Errorf("service '%s': type must be set", name))
+			continue
+		}
+
+		if _, ok := serviceCharts[svc.Type]; !ok {
+			errs = append(errs, fmt.Errorf("service '%s': type must be one of web, worker or cron", name))
+		}
+
+		if svc.Type == "cron" && svc.Schedule == "" {
+			errs = append(errs, fmt.Errorf("service '%s': schedule must be set for a cron service", name))
+		} else if svc.Type != "cron" && svc.Schedule != "" {
+			errs = append(errs, fmt.Errorf("service '%s': schedule can only be set for a cron service", name))
+		}
+
+		if svc.Port != 0 && svc.Type != "web" {
+			errs = append(errs, fmt.Errorf("service '%s': port can only be set for a web service", name))
+		}
+
+		for _, group := range svc.EnvGroups {
+			if !envGroups[group] {
+				errs = append(errs, fmt.Errorf("service '%s': env group '%s' is not in env_groups", name, group))
+			}
+		}
+	}
+
+	return errs
+}
+
+// SetImageTag overrides the tag of the image which the services are deployed from
+func (s *Spec) SetImageTag(tag string) {
+	if s.Image == nil {
+		s.Image = &Image{}
+	}
+
+	s.Image.Tag = tag
+}
+
+// ToCreateStackRequest returns the request which creates the stack of the spec
+func (s *Spec) ToCreateStackRequest() *types.CreateStackRequest {
+	return &types.CreateStackRequest{
+		Name:          s.Name,
+		AppResources:  s.getAppResources(),
+		SourceConfigs: s.getSourceConfigs(),
+		EnvGroups:     s.getEnvGroups(),
+	}
+}
+
+// ToPutStackSpecRequest returns the request which deploys the spec as a new revision of an
+// existing stack
+func (s *Spec) ToPutStackSpecRequest() *types.PutStackSpecRequest {
+	return &types.PutStackSpecRequest{
+		AppResources:  s.getAppResources(),
+		SourceConfigs: s.getSourceConfigs(),
+		EnvGroups:     s.getEnvGroups(),
+	}
+}
+
+func (s *Spec) getSourceConfigs() []*types.CreateStackSourceConfigRequest {
+	sourceConfig := &types.CreateStackSourceConfigRequest{
+		Name:         SourceConfigName,
+		DisplayName:  s.Name,
+		ImageRepoURI: s.Image.Repository,
+		ImageTag:     s.Image.Tag,
+	}
+
+	if s.Build != nil {
+		build := &types.StackSourceConfigBuild{
+			Method:     s.Build.Method,
+			FolderPath: s.Build.Context,
+		}
+
+		if s.Build.Method == "docker" {
+			dockerfile := s.Build.Dockerfile
+
+			if dockerfile == "" {
+				dockerfile = "./Dockerfile"
+			}
+
+			build.StackSourceConfigBuildDockerfile = &types.StackSourceConfigBuildDockerfile{
+				DockerfilePath: dockerfile,
+			}
+		} else {
+			build.StackSourceConfigBuildPack = &types.StackSourceConfigBuildPack{
+				Builder:    s.Build.Builder,
+				Buildpacks: s.Build.Buildpacks,
+			}
+		}
+
+		if git := s.Build.Git; git != nil {
+			build.StackSourceConfigBuildGit = &types.StackSourceConfigBuildGit{
+				GitIntegrationKind: git.IntegrationKind,
+				GitIntegrationID:   git.IntegrationID,
+				GitRepo:            git.Repo,
+				GitBranch:          git.Branch,
+			}
+		}
+
+		sourceConfig.StackSourceConfigBuild = build
+	}
+
+	return []*types.CreateStackSourceConfigRequest{sourceConfig}
+}
+
+func (s *Spec) getAppResources() []*types.CreateStackAppResourceRequest {
+	res := make([]*types.CreateStackAppResourceRequest, 0, len(s.Services))
+
+	for _, name := range s.serviceNames() {
+		svc := s.Services[name]
+
+		version := svc.Version
+
+		if version == "" {
+			version = "latest"
+		}
+
+		res = append(res, &types.CreateStackAppResourceRequest{
+			Name:             name,
+			TemplateName:     serviceCharts[svc.Type],
+			TemplateVersion:  version,
+			SourceConfigName: SourceConfigName,
+			Values:           svc.getValues(s.Image),
+		})
+	}
+
+	return res
+}
+
+func (s *Spec) getEnvGroups() []*types.CreateStackEnvGroupRequest {
+	res := make([]*types.CreateStackEnvGroupRequest, 0, len(s.EnvGroups))
+
+	for _, group := range s.EnvGroups {
+		linkedApps := make([]string, 0)
+
+		for _, name := range s.serviceNames() {
+			for _, svcGroup := range s.Services[name].EnvGroups {
+				if svcGroup == group.Name {
+					linkedApps = append(linkedApps, name)
+				}
+			}
+		}
+
+		variables := group.Variables
+
+		if variables == nil {
+			variables = make(map[string]string)
+		}
+
+		secretVariables := group.SecretVariables
+
+		if secretVariables == nil {
+			secretVariables = make(map[string]string)
+		}
+
+		res = append(res, &types.CreateStackEnvGroupRequest{
+			Name:               group.Name,
+			Variables:          variables,
+			SecretVariables:    secretVariables,
+			LinkedApplications: linkedApps,
+		})
+	}
+
+	return res
+}
+
+// getValues returns the values of the service, with the image, command, port and schedule of the
+// service set over its own values
+func (svc *Service) getValues(image *Image) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	for key, val := range svc.Values {
+		values[key] = val
+	}
+
+	setValue(values, image.Repository, "image", "repository")
+	setValue(values, image.Tag, "image", "tag")
+
+	if svc.Run != "" {
+		setValue(values, svc.Run, "container", "command")
+	}
+
+	if svc.Port != 0 {
+		setValue(values, svc.Port, "container", "port")
+	}
+
+	if svc.Schedule != "" {
+		setValue(values, true, "schedule", "enabled")
+		setValue(values, svc.Schedule, "schedule", "value")
+	}
+
+	return values
+}
+
+// setValue sets a nested value, copying the maps along its path so that the values of the spec
+// are left unchanged
+func setValue(values map[string]interface{}, val interface{}, path ...string) {
+	for _, key := range path[:len(path)-1] {
+		next := make(map[string]interface{})
+
+		if existing, ok := values[key].(map[string]interface{}); ok {
+			for k, v := range existing {
+				next[k] = v
+			}
+		}
+
+		values[key] = next
+		values = next
+	}
+
+	values[path[len(path)-1]] = val
+}
+
+func (s *Spec) serviceNames() []string {
+	names := make([]string, 0, len(s.Services))
+
+	for name := range s.Services {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
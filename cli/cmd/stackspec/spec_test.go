@@ -0,0 +1,155 @@
+package stackspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSpec = `
+version: v2
+name: shop
+namespace: staging
+image:
+  repository: registry.example.com/shop
+  tag: v1
+build:
+  method: docker
+  git:
+    repo: acme/shop
+    branch: main
+env_groups:
+- name: shared
+  variables:
+    LOG_LEVEL: info
+  secret_variables:
+    DATABASE_URL: postgres://db
+services:
+  web:
+    type: web
+    run: bundle exec puma
+    port: 3000
+    env_groups:
+    - shared
+    values:
+      container:
+        port: 8080
+  cleanup:
+    type: cron
+    version: 0.40.0
+    run: rake cleanup
+    schedule: "0 * * * *"
+    env_groups:
+    - shared
+  worker:
+    type: worker
+`
+
+func TestIsSpec(t *testing.T) {
+	if !IsSpec([]byte(testSpec)) {
+		t.Errorf("expected the spec to be detected")
+	}
+
+	if IsSpec([]byte("version: v1\nresources:\n- name: web\n")) {
+		t.Errorf("expected a preview environment porter.yaml not to be detected as a spec")
+	}
+
+	if IsSpec([]byte("apps:\n- name: web\n")) {
+		t.Errorf("expected a declarative spec not to be detected as a stack spec")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse([]byte(`
+version: v2
+name: Shop
+build:
+  method: nixpacks
+services:
+  web:
+    type: api
+  cleanup:
+    type: cron
+    env_groups:
+    - missing
+`))
+
+	if err == nil {
+		t.Fatalf("expected the spec to be invalid")
+	}
+
+	for _, expected := range []string{
+		"not a valid stack name", "repository and tag", "method must be one of",
+		"type must be one of", "schedule must be set", "'missing' is not in env_groups",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %s", expected, err.Error())
+		}
+	}
+}
+
+func TestToCreateStackRequest(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	spec.SetImageTag("v2")
+
+	req := spec.ToCreateStackRequest()
+
+	if len(req.SourceConfigs) != 1 || req.SourceConfigs[0].ImageTag != "v2" {
+		t.Fatalf("expected a single source config with tag v2")
+	}
+
+	build := req.SourceConfigs[0].StackSourceConfigBuild
+
+	if build == nil || build.FolderPath != "." || build.StackSourceConfigBuildDockerfile.DockerfilePath != "./Dockerfile" ||
+		build.StackSourceConfigBuildGit.GitRepo != "acme/shop" {
+		t.Errorf("expected the build to default to ./Dockerfile in . from acme/shop, got %v", build)
+	}
+
+	names := make([]string, 0)
+
+	for _, res := range req.AppResources {
+		names = append(names, res.Name+":"+res.TemplateName+":"+res.TemplateVersion)
+	}
+
+	expectedNames := []string{"cleanup:job:0.40.0", "web:web:latest", "worker:worker:latest"}
+
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("expected app resources %v, got %v", expectedNames, names)
+	}
+
+	cleanup := req.AppResources[0].Values
+
+	expectedSchedule := map[string]interface{}{"enabled": true, "value": "0 * * * *"}
+
+	if !reflect.DeepEqual(cleanup["schedule"], expectedSchedule) {
+		t.Errorf("expected schedule %v, got %v", expectedSchedule, cleanup["schedule"])
+	}
+
+	web := req.AppResources[1].Values
+
+	expectedContainer := map[string]interface{}{"command": "bundle exec puma", "port": 3000}
+
+	if !reflect.DeepEqual(web["container"], expectedContainer) {
+		t.Errorf("expected container %v, got %v", expectedContainer, web["container"])
+	}
+
+	expectedImage := map[string]interface{}{"repository": "registry.example.com/shop", "tag": "v2"}
+
+	if !reflect.DeepEqual(web["image"], expectedImage) {
+		t.Errorf("expected image %v, got %v", expectedImage, web["image"])
+	}
+
+	// the values of the spec are left unchanged
+	if port := spec.Services["web"].Values["container"].(map[string]interface{})["port"]; port != float64(8080) {
+		t.Errorf("expected the port of the spec to be unchanged, got %v", port)
+	}
+
+	if len(req.EnvGroups) != 1 || !reflect.DeepEqual(req.EnvGroups[0].LinkedApplications, []string{"cleanup", "web"}) {
+		t.Errorf("expected the env group to be linked to cleanup and web, got %v", req.EnvGroups)
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/briandowns/spinner"
+)
+
+// ErrPromptInCI is returned by prompts when the CLI is running in CI mode, since there is nobody
+// to answer them
+var ErrPromptInCI = errors.New("cannot prompt for input in CI mode, pass the value as a flag instead")
+
+var ciMode bool
+
+// SetCIMode enables or disables CI mode, which disables prompts and spinners
+func SetCIMode(enabled bool) {
+	ciMode = enabled
+}
+
+// IsCIMode returns whether the CLI is running in CI mode
+func IsCIMode() bool {
+	return ciMode
+}
+
+// NewSpinner returns a spinner to show while waiting on a request. In CI mode, the spinner
+// doesn't write anything, since its animation fills logs with control characters.
+func NewSpinner() *spinner.Spinner {
+	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	s.Color("cyan")
+
+	if ciMode {
+		s.Writer = io.Discard
+	}
+
+	return s
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// FuzzyMatch returns whether the characters of a filter appear in a value in the same order,
+// ignoring case, so that "wbp" matches "web-production"
+func FuzzyMatch(filter, value string) bool {
+	value = strings.ToLower(value)
+
+	for _, r := range strings.ToLower(filter) {
+		i := strings.IndexRune(value, r)
+
+		if i == -1 {
+			return false
+		}
+
+		value = value[i+utf8.RuneLen(r):]
+	}
+
+	return true
+}
@@ -0,0 +1,31 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/cli/cmd/utils"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		filter   string
+		value    string
+		expected bool
+	}{
+		{"", "web", true},
+		{"web", "web", true},
+		{"wbp", "web-production", true},
+		{"WEB", "web-production", true},
+		{"prod", "web-production", true},
+		{"pw", "web-production", false},
+		{"webb", "web-production", false},
+		{"é", "café - 3", true},
+		{"3", "my-cluster - 3", true},
+	}
+
+	for _, test := range tests {
+		if res := utils.FuzzyMatch(test.filter, test.value); res != test.expected {
+			t.Errorf("%q matching %q: expected %t, got %t", test.filter, test.value, test.expected, res)
+		}
+	}
+}
@@ -13,6 +13,10 @@ import (
 
 // PromptPlaintext prompts a user to input plain text
 func PromptPlaintext(prompt string) (string, error) {
+	if ciMode {
+		return "", ErrPromptInCI
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Print(prompt)
@@ -27,6 +31,10 @@ func PromptPlaintext(prompt string) (string, error) {
 
 // PromptPassword prompts a user to input a hidden field
 func PromptPassword(prompt string) (string, error) {
+	if ciMode {
+		return "", ErrPromptInCI
+	}
+
 	fmt.Print(prompt)
 	pw, err := terminal.ReadPassword(0)
 	fmt.Print("\r")
@@ -57,11 +65,20 @@ func PromptPasswordWithConfirmation() (string, error) {
 	return pw, nil
 }
 
+// fuzzyFilter filters the options of select prompts as the user types
+func fuzzyFilter(filter, value string, _ int) bool {
+	return FuzzyMatch(filter, value)
+}
+
 type selectAnswer struct {
 	Response string `survey:"response"`
 }
 
 func PromptSelect(prompt string, options []string) (string, error) {
+	if ciMode {
+		return "", ErrPromptInCI
+	}
+
 	var qs = []*survey.Question{
 		{
 			Name: "response",
@@ -69,6 +86,7 @@ func PromptSelect(prompt string, options []string) (string, error) {
 				Message: prompt,
 				Options: options,
 				Default: options[0],
+				Filter:  fuzzyFilter,
 			},
 		},
 	}
@@ -81,9 +99,14 @@ func PromptSelect(prompt string, options []string) (string, error) {
 }
 
 func PromptMultiselect(prompt string, options []string) ([]string, error) {
+	if ciMode {
+		return nil, ErrPromptInCI
+	}
+
 	query := &survey.MultiSelect{
 		Message: prompt,
 		Options: options,
+		Filter:  fuzzyFilter,
 	}
 
 	var ans []string
@@ -94,6 +117,10 @@ func PromptMultiselect(prompt string, options []string) ([]string, error) {
 }
 
 func PromptConfirm(message string, defaultVal bool) (bool, error) {
+	if ciMode {
+		return false, ErrPromptInCI
+	}
+
 	value := false
 
 	prompt := &survey.Confirm{
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,10 +11,19 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/porter-dev/porter/api/server/handlers/environment"
+	"github.com/porter-dev/porter/api/server/handlers/namespace"
+	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/router"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/loader"
+	"github.com/porter-dev/porter/internal/cost"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/leader"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/usage"
+	"github.com/porter-dev/porter/internal/wildcardcert"
 	"gorm.io/gorm"
 )
 
@@ -45,6 +55,47 @@ func main() {
 		log.Fatal("Data initialization failed: ", err)
 	}
 
+	// run the side effects of requests which were enqueued as background jobs. Jobs are claimed
+	// in the database, so every replica runs workers without running a job twice.
+	environment.RegisterJobHandlers(config)
+	release.RegisterJobHandlers(config)
+	namespace.RegisterJobHandlers(config)
+	webhook.RegisterJobHandlers(config.JobQueue, config.Repo)
+	config.JobQueue.Start(context.Background())
+
+	// the periodic workers below are run by a single replica, or split across the replicas, so
+	// that Porter can run with several replicas
+	elector, err := leader.NewElector(config.Repo, config.Logger, config.ServerConf.LeaderLeaseDuration)
+
+	if err != nil {
+		log.Fatal("Leader election initialization failed: ", err)
+	}
+
+	if err := elector.Join(context.Background()); err != nil {
+		log.Fatal("Leader election initialization failed: ", err)
+	}
+
+	// meter the usage of every project each hour, so that usage which accumulates over time
+	// can be enforced against the limits of a project
+	if config.ServerConf.UsageTrackingEnabled {
+		elector.Lead(context.Background(), "usage-meter", usage.NewMeter(config.Repo, config.Logger).Start)
+	}
+
+	// record the cost of every cluster each hour, which is attributed to namespaces and releases
+	// based on the resources which their pods request
+	if config.ServerConf.CostMonitoringEnabled {
+		elector.Lead(context.Background(), "cost-recorder", cost.NewRecorder(config.Repo, config.DOConf, config.Logger).Start)
+	}
+
+	// mark the DNS records of custom domains as active once they have propagated
+	elector.Lead(context.Background(), "dns-verifier", dns.NewVerifier(config.Repo, config.Logger).Start)
+
+	// copy the wildcard certificates of every cluster to their namespaces, so that renewed
+	// certificates and new namespaces are picked up. The clusters are split across the replicas.
+	wildcardcert.NewDistributor(config.Repo, config.DOConf, config.Logger).
+		PartitionBy(elector.Owns).
+		Start(context.Background())
+
 	appRouter := router.NewAPIRouter(config)
 
 	address := fmt.Sprintf(":%d", config.ServerConf.Port)
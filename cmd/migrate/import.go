@@ -0,0 +1,79 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sort"
+
+	"github.com/joeshaw/envdecode"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/repository/gorm"
+	lr "github.com/porter-dev/porter/pkg/logger"
+
+	pgorm "gorm.io/gorm"
+)
+
+type ImportConf struct {
+	// we add a dummy field to avoid empty struct issue with envdecode
+	DummyField string `env:"ASDF,default=asdf"`
+
+	// the passphrase which the export was encrypted with
+	ExportPassphrase string `env:"EXPORT_PASSPHRASE"`
+}
+
+// runImport imports an export written by the admin export endpoint. The passphrase is read
+// from the environment, so that it does not end up in the shell history.
+func runImport(db *pgorm.DB, dbConf *env.DBConf, logger *lr.Logger, path string) {
+	var c ImportConf
+
+	if err := envdecode.StrictDecode(&c); err != nil {
+		log.Fatalf("Failed to decode import conf: %s", err)
+		return
+	}
+
+	if c.ExportPassphrase == "" {
+		logger.Fatal().Msg("EXPORT_PASSPHRASE must be set to import an export")
+		return
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		logger.Fatal().Err(err).Msg("could not read export")
+		return
+	}
+
+	export, err := gorm.DecodeExport(data, c.ExportPassphrase)
+
+	if err != nil {
+		logger.Fatal().Err(err).Msg("could not decode export")
+		return
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(dbConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	counts, err := gorm.ImportDatabase(db, &key, export)
+
+	if err != nil {
+		logger.Fatal().Err(err).Msg("import failed")
+		return
+	}
+
+	tables := make([]string, 0, len(counts))
+
+	for table := range counts {
+		tables = append(tables, table)
+	}
+
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		logger.Info().Msgf("imported %d rows into %s", counts[table], table)
+	}
+
+	logger.Info().Msgf("imported export created at %s", export.CreatedAt.UTC().Format("2006-01-02 15:04:05"))
+}
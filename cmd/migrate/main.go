@@ -2,7 +2,9 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/porter-dev/porter/api/server/shared/config/envloader"
 	"github.com/porter-dev/porter/cmd/migrate/keyrotate"
@@ -11,7 +13,6 @@ import (
 
 	adapter "github.com/porter-dev/porter/internal/adapter"
 	"github.com/porter-dev/porter/internal/models"
-	"github.com/porter-dev/porter/internal/repository/gorm"
 	lr "github.com/porter-dev/porter/pkg/logger"
 
 	"github.com/joeshaw/envdecode"
@@ -36,10 +37,26 @@ func main() {
 		return
 	}
 
-	err = gorm.AutoMigrate(db, envConf.ServerConf.Debug)
+	migrationDB := db
 
-	if err != nil {
-		logger.Fatal().Err(err).Msg("gorm auto-migration failed")
+	if envConf.ServerConf.Debug {
+		migrationDB = db.Debug()
+	}
+
+	// "migrate import" imports an instance export into a database which has already been migrated
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+
+		runImport(migrationDB, envConf.DBConf, logger, os.Args[2])
+		return
+	}
+
+	// "migrate status" and "migrate down" only run versioned migrations, while "migrate" and
+	// "migrate up" run every migration step
+	if done := runVersionedMigrations(migrationDB, logger, os.Args[1:]); done {
 		return
 	}
 
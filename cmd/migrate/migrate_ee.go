@@ -1,3 +1,4 @@
+//go:build ee
 // +build ee
 
 package main
@@ -18,6 +19,12 @@ func InstanceMigrate(db *gorm.DB, dbConf *env.DBConf) error {
 		}
 	}
 
+	if shouldMigrateEnvGroups() {
+		if err := migrate.MigrateEnvGroupSecrets(db, dbConf); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -38,3 +45,20 @@ func shouldVaultRotate() (bool, bool) {
 
 	return c.VaultMigrateInit, c.VaultMigrateFinalize
 }
+
+type VaultMigrateEnvGroupsConf struct {
+	// we add a dummy field to avoid empty struct issue with envdecode
+	DummyField            string `env:"ASDF,default=asdf"`
+	VaultMigrateEnvGroups bool   `env:"VAULT_MIGRATE_ENV_GROUPS"`
+}
+
+func shouldMigrateEnvGroups() bool {
+	var c VaultMigrateEnvGroupsConf
+
+	if err := envdecode.StrictDecode(&c); err != nil {
+		log.Fatalf("Failed to decode Vault env group migration conf: %s", err)
+		return false
+	}
+
+	return c.VaultMigrateEnvGroups
+}
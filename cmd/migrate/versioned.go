@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/porter-dev/porter/internal/repository/gorm/migrations"
+	lr "github.com/porter-dev/porter/pkg/logger"
+
+	pgorm "gorm.io/gorm"
+)
+
+const usage = `usage: migrate [command]
+
+commands:
+  up [version]     apply pending migrations up to the version, or all pending migrations
+  down <version>   revert applied migrations newer than the version
+  status           list migrations and whether they have been applied
+  import <file>    import an instance export into an empty database, with the passphrase
+                   of the export set in EXPORT_PASSPHRASE`
+
+// runVersionedMigrations runs the versioned migration command given by the arguments, and
+// returns true if no further migration steps should be run
+func runVersionedMigrations(db *pgorm.DB, logger *lr.Logger, args []string) bool {
+	migrator, err := migrations.NewMigrator(db, migrations.All())
+
+	if err != nil {
+		logger.Fatal().Err(err).Msg("could not load migrations")
+		return true
+	}
+
+	cmd := "up"
+
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+
+	switch cmd {
+	case "up":
+		var target uint
+
+		if len(args) > 1 {
+			target = parseVersion(logger, args[1])
+		}
+
+		applied, err := migrator.Up(target)
+
+		if err != nil {
+			logger.Fatal().Err(err).Msg("migration failed")
+			return true
+		}
+
+		for _, migration := range applied {
+			logger.Info().Msgf("applied migration %d_%s", migration.Version, migration.Name)
+		}
+
+		return false
+	case "down":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(1)
+		}
+
+		reverted, err := migrator.Down(parseVersion(logger, args[1]))
+
+		if err != nil {
+			logger.Fatal().Err(err).Msg("reverting migrations failed")
+			return true
+		}
+
+		for _, migration := range reverted {
+			logger.Info().Msgf("reverted migration %d_%s", migration.Version, migration.Name)
+		}
+
+		return true
+	case "status":
+		statuses, err := migrator.Status()
+
+		if err != nil {
+			logger.Fatal().Err(err).Msg("could not read migration status")
+			return true
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		w.Flush()
+
+		return true
+	}
+
+	fmt.Fprintln(os.Stderr, usage)
+	os.Exit(1)
+
+	return true
+}
+
+func parseVersion(logger *lr.Logger, version string) uint {
+	res, err := strconv.ParseUint(version, 10, 32)
+
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("invalid migration version %s", version)
+	}
+
+	return uint(res)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joeshaw/envdecode"
+	"github.com/porter-dev/porter/internal/relay"
+	lr "github.com/porter-dev/porter/pkg/logger"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Version will be linked by an ldflag during build
+var Version string = "dev-ce"
+
+// AgentConf is the configuration of the relay agent, which runs in a cluster and pushes the
+// incidents, events and metrics of the cluster to Porter
+type AgentConf struct {
+	// the url of the Porter server
+	ServerURL string `env:"PORTER_SERVER_URL,required"`
+
+	// the token returned when the relay of the cluster was created
+	Token string `env:"PORTER_RELAY_TOKEN,required"`
+
+	// the interval which reports are sent on
+	ReportInterval time.Duration `env:"PORTER_RELAY_REPORT_INTERVAL,default=30s"`
+
+	Debug bool `env:"DEBUG,default=false"`
+}
+
+func main() {
+	var versionFlag bool
+	flag.BoolVar(&versionFlag, "version", false, "print version and exit")
+	flag.Parse()
+
+	if versionFlag {
+		fmt.Println(Version)
+		os.Exit(0)
+	}
+
+	var conf AgentConf
+
+	if err := envdecode.StrictDecode(&conf); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode relay agent conf: %s\n", err)
+		os.Exit(1)
+	}
+
+	logger := lr.NewConsole(conf.Debug)
+
+	restConf, err := rest.InClusterConfig()
+
+	if err != nil {
+		logger.Fatal().Err(err).Msg("the relay agent must run in a cluster")
+		return
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConf)
+
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error creating kubernetes client")
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info().Msgf("sending relay reports to %s every %s", conf.ServerURL, conf.ReportInterval)
+
+	if err := relay.RunAgent(ctx, &relay.AgentOpts{
+		ServerURL: conf.ServerURL,
+		Token:     conf.Token,
+		Version:   Version,
+		Clientset: clientset,
+		Interval:  conf.ReportInterval,
+		Logger:    logger,
+	}); err != nil {
+		logger.Fatal().Err(err).Msg("relay agent failed")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joeshaw/envdecode"
+	"github.com/porter-dev/porter/internal/tunnel"
+	lr "github.com/porter-dev/porter/pkg/logger"
+)
+
+// Version will be linked by an ldflag during build
+var Version string = "dev-ce"
+
+// AgentConf is the configuration of the tunnel agent, which runs in a cluster without inbound
+// access and connects the cluster to Porter
+type AgentConf struct {
+	// the url of the Porter server
+	ServerURL string `env:"PORTER_SERVER_URL,required"`
+
+	// the token returned when the tunnel of the cluster was created
+	Token string `env:"PORTER_TUNNEL_TOKEN,required"`
+
+	// the addresses which Porter can connect to through the tunnel, separated by semicolons.
+	// Defaults to the address of the Kubernetes API server of the cluster.
+	AllowedTargets []string `env:"PORTER_TUNNEL_ALLOWED_TARGETS"`
+
+	Debug bool `env:"DEBUG,default=false"`
+}
+
+func main() {
+	var versionFlag bool
+	flag.BoolVar(&versionFlag, "version", false, "print version and exit")
+	flag.Parse()
+
+	if versionFlag {
+		fmt.Println(Version)
+		os.Exit(0)
+	}
+
+	var conf AgentConf
+
+	if err := envdecode.StrictDecode(&conf); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode tunnel agent conf: %s\n", err)
+		os.Exit(1)
+	}
+
+	logger := lr.NewConsole(conf.Debug)
+
+	if len(conf.AllowedTargets) == 0 {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+
+		if host == "" || port == "" {
+			logger.Fatal().Msg("PORTER_TUNNEL_ALLOWED_TARGETS must be set when the agent runs outside of a cluster")
+			return
+		}
+
+		conf.AllowedTargets = []string{net.JoinHostPort(host, port), "kubernetes.default.svc:443"}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info().Msgf("connecting tunnel to %s, allowing connections to %v", conf.ServerURL, conf.AllowedTargets)
+
+	if err := tunnel.RunAgent(ctx, &tunnel.AgentOpts{
+		ServerURL:      conf.ServerURL,
+		Token:          conf.Token,
+		AllowedTargets: conf.AllowedTargets,
+		Logger:         logger,
+	}); err != nil {
+		logger.Fatal().Err(err).Msg("tunnel agent failed")
+	}
+}
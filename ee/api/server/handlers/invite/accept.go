@@ -11,6 +11,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
@@ -97,6 +98,13 @@ func (c *InviteAcceptHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	commonutils.RecordAccessChange(c.Config(), proj.ID, user.ID, types.AuditLogActionRoleCreate, "Role",
+		fmt.Sprintf("%d", user.ID), map[string]interface{}{
+			"user_id":   user.ID,
+			"kind":      role.Kind,
+			"invite_id": invite.ID,
+		})
+
 	// update the invite
 	invite.UserID = user.ID
 
@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -57,7 +58,11 @@ func (c *InviteCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// app.Logger.Info().Msgf("New invite created: %d", invite.ID)
+	commonutils.RecordAccessChange(c.Config(), project.ID, user.ID, types.AuditLogActionInviteCreate, "Invite",
+		request.Email, map[string]interface{}{
+			"invite_id": invite.ID,
+			"kind":      invite.Kind,
+		})
 
 	if err := c.Config().UserNotifier.SendProjectInviteEmail(
 		&notifier.SendProjectInviteEmailOpts{
@@ -0,0 +1,104 @@
+//go:build ee
+// +build ee
+
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/repository/credentials"
+)
+
+// WriteEnvGroupSecrets writes the values of the secret variables of a version of an env group
+func (c *Client) WriteEnvGroupSecrets(path *credentials.EnvGroupSecretPath, data map[string]string) error {
+	reqData := &CreateVaultSecretRequest{
+		Data: data,
+	}
+
+	return c.postRequest(fmt.Sprintf("/v1/%s", c.getEnvGroupSecretsPath("data", path)), reqData, nil)
+}
+
+// GetEnvGroupSecrets returns the values of the secret variables of a version of an env group. It
+// returns credentials.ErrNotFound if the version has not been written.
+func (c *Client) GetEnvGroupSecrets(path *credentials.EnvGroupSecretPath) (map[string]string, error) {
+	resp := &GetEnvGroupSecretsResponse{}
+
+	err := c.getRequest(fmt.Sprintf("/v1/%s", c.getEnvGroupSecretsPath("data", path)), resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Data == nil || resp.Data.Data == nil {
+		return nil, credentials.ErrNotFound
+	}
+
+	return resp.Data.Data, nil
+}
+
+// DeleteEnvGroupSecrets deletes every version of the secret variables of an env group, along with
+// their KV history. The version of the path is ignored.
+func (c *Client) DeleteEnvGroupSecrets(path *credentials.EnvGroupSecretPath) error {
+	resp := &ListSecretsResponse{}
+
+	envGroupPath := &credentials.EnvGroupSecretPath{
+		ProjectID: path.ProjectID,
+		ClusterID: path.ClusterID,
+		Namespace: path.Namespace,
+		Name:      path.Name,
+	}
+
+	err := c.writeRequest("LIST", fmt.Sprintf("/v1/%s", c.getEnvGroupSecretsPath("metadata", envGroupPath)), nil, resp)
+
+	if err != nil && errors.Is(err, credentials.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if resp.Data == nil {
+		return nil
+	}
+
+	for _, key := range resp.Data.Keys {
+		// folders are listed with a trailing slash, and there are none under an env group
+		if strings.HasSuffix(key, "/") {
+			continue
+		}
+
+		err := c.deleteRequest(
+			fmt.Sprintf("/v1/%s/%s", c.getEnvGroupSecretsPath("metadata", envGroupPath), key),
+			nil,
+			nil,
+		)
+
+		if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getEnvGroupSecretsPath returns the path of the secret variables of a version of an env group in
+// the KV v2 engine, under the "data" or "metadata" endpoint of the engine. Paths with a version of
+// 0 are the folder of every version of the env group.
+func (c *Client) getEnvGroupSecretsPath(endpoint string, path *credentials.EnvGroupSecretPath) string {
+	res := fmt.Sprintf(
+		"kv/%s/secret/%s/%d/envgroup/%d/%s/%s",
+		endpoint,
+		c.secretPrefix,
+		path.ProjectID,
+		path.ClusterID,
+		path.Namespace,
+		path.Name,
+	)
+
+	if path.Version != 0 {
+		res = fmt.Sprintf("%s/%d", res, path.Version)
+	}
+
+	return res
+}
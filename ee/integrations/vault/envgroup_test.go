@@ -0,0 +1,210 @@
+//go:build ee
+// +build ee
+
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/repository/credentials"
+)
+
+// kvServer serves the data and metadata endpoints of a KV v2 engine mounted at kv
+type kvServer struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	data map[string]map[string]string
+}
+
+func newKVServer(t *testing.T) *kvServer {
+	server := &kvServer{data: make(map[string]map[string]string)}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/kv/data/"):
+			path := strings.TrimPrefix(r.URL.Path, "/v1/kv/data/")
+
+			switch r.Method {
+			case http.MethodPost:
+				req := &struct {
+					Data map[string]string `json:"data"`
+				}{}
+
+				if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				server.data[path] = req.Data
+			case http.MethodGet:
+				data, ok := server.data[path]
+
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				json.NewEncoder(w).Encode(&GetEnvGroupSecretsResponse{
+					Data: &GetEnvGroupSecretsData{Data: data},
+				})
+			}
+		case strings.HasPrefix(r.URL.Path, "/v1/kv/metadata/"):
+			path := strings.TrimPrefix(r.URL.Path, "/v1/kv/metadata/")
+
+			switch r.Method {
+			case "LIST":
+				keys := make([]string, 0)
+
+				for key := range server.data {
+					if strings.HasPrefix(key, path+"/") {
+						keys = append(keys, strings.TrimPrefix(key, path+"/"))
+					}
+				}
+
+				if len(keys) == 0 {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				json.NewEncoder(w).Encode(&ListSecretsResponse{
+					Data: &ListSecretsData{Keys: keys},
+				})
+			case http.MethodDelete:
+				delete(server.data, path)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *kvServer) paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]string, 0, len(s.data))
+
+	for path := range s.data {
+		res = append(res, path)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func TestEnvGroupSecrets(t *testing.T) {
+	server := newKVServer(t)
+	client := NewClient(server.URL, "token", "test")
+
+	path := &credentials.EnvGroupSecretPath{
+		ProjectID: 1,
+		ClusterID: 2,
+		Namespace: "default",
+		Name:      "web",
+		Version:   3,
+	}
+
+	if err := client.WriteEnvGroupSecrets(path, map[string]string{"API_KEY": "secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the secret variables are stored under the project of the env group
+	if paths := server.paths(); len(paths) != 1 || paths[0] != "secret/test/1/envgroup/2/default/web/3" {
+		t.Fatalf("expected the secret variables to be written to the env group's path, got %v", paths)
+	}
+
+	data, err := client.GetEnvGroupSecrets(path)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data["API_KEY"] != "secret" {
+		t.Errorf("expected API_KEY to be read, got %v", data)
+	}
+
+	// versions which weren't written aren't found
+	_, err = client.GetEnvGroupSecrets(&credentials.EnvGroupSecretPath{
+		ProjectID: 1,
+		ClusterID: 2,
+		Namespace: "default",
+		Name:      "web",
+		Version:   4,
+	})
+
+	if !errors.Is(err, credentials.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteEnvGroupSecrets(t *testing.T) {
+	server := newKVServer(t)
+	client := NewClient(server.URL, "token", "test")
+
+	for _, name := range []string{"web", "worker"} {
+		for version := uint(1); version <= 2; version++ {
+			err := client.WriteEnvGroupSecrets(&credentials.EnvGroupSecretPath{
+				ProjectID: 1,
+				ClusterID: 2,
+				Namespace: "default",
+				Name:      name,
+				Version:   version,
+			}, map[string]string{"API_KEY": "secret"})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if err := client.DeleteEnvGroupSecrets(&credentials.EnvGroupSecretPath{
+		ProjectID: 1,
+		ClusterID: 2,
+		Namespace: "default",
+		Name:      "web",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// every version of the env group is deleted, and other env groups are kept
+	expected := []string{
+		"secret/test/1/envgroup/2/default/worker/1",
+		"secret/test/1/envgroup/2/default/worker/2",
+	}
+
+	if paths := server.paths(); strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v to be kept, got %v", expected, paths)
+	}
+
+	// deleting an env group which was never written does nothing
+	if err := client.DeleteEnvGroupSecrets(&credentials.EnvGroupSecretPath{
+		ProjectID: 1,
+		ClusterID: 2,
+		Namespace: "default",
+		Name:      "api",
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
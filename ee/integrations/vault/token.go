@@ -0,0 +1,126 @@
+//go:build ee
+// +build ee
+
+package vault
+
+import (
+	"sync"
+	"time"
+)
+
+// minRenewInterval is the shortest time between renewals of the token, which is also the time
+// before a failed renewal is retried
+var minRenewInterval = 30 * time.Second
+
+// LookupToken returns the time until the token which the client authenticates with expires, and
+// whether the token can be renewed. A TTL of 0 means that the token doesn't expire.
+func (c *Client) LookupToken() (time.Duration, bool, error) {
+	resp := &LookupTokenResponse{}
+
+	err := c.getRequest("/v1/auth/token/lookup-self", resp)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return time.Duration(resp.Data.TTL) * time.Second, resp.Data.Renewable, nil
+}
+
+// RenewToken renews the token which the client authenticates with, and returns the time until the
+// renewed token expires
+func (c *Client) RenewToken() (time.Duration, error) {
+	resp := &RenewTokenResponse{}
+
+	err := c.postRequest("/v1/auth/token/renew-self", struct{}{}, resp)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(resp.Auth.LeaseDuration) * time.Second, nil
+}
+
+// StartTokenRenewal renews the token which the client authenticates with in the background, at
+// half of its TTL and at least once per maxInterval, so that the token doesn't expire while the
+// server is running. Tokens which don't expire or can't be renewed are left as they are. onError
+// is called when a renewal fails, after which the renewal is retried. The returned function stops
+// the renewal, and returns once it has stopped.
+func (c *Client) StartTokenRenewal(maxInterval time.Duration, onError func(err error)) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	var once sync.Once
+
+	go func() {
+		defer close(stopped)
+
+		ttl, renewable, err := c.LookupToken()
+
+		for err != nil {
+			onError(err)
+
+			if !sleepUntil(done, minRenewInterval) {
+				return
+			}
+
+			ttl, renewable, err = c.LookupToken()
+		}
+
+		if !renewable || ttl == 0 {
+			return
+		}
+
+		for {
+			if !sleepUntil(done, renewInterval(ttl, maxInterval)) {
+				return
+			}
+
+			renewedTTL, err := c.RenewToken()
+
+			if err != nil {
+				onError(err)
+
+				// retry the renewal after minRenewInterval
+				ttl = 2 * minRenewInterval
+				continue
+			}
+
+			ttl = renewedTTL
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+
+		<-stopped
+	}
+}
+
+// sleepUntil waits for the duration, and returns false if done is closed before then
+func sleepUntil(done <-chan struct{}, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func renewInterval(ttl, maxInterval time.Duration) time.Duration {
+	interval := ttl / 2
+
+	if maxInterval > 0 && interval > maxInterval {
+		interval = maxInterval
+	}
+
+	if interval < minRenewInterval {
+		interval = minRenewInterval
+	}
+
+	return interval
+}
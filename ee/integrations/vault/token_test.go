@@ -0,0 +1,176 @@
+//go:build ee
+// +build ee
+
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRenewInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		maxInterval time.Duration
+		expected    time.Duration
+	}{
+		{"half of the ttl", 20 * time.Minute, time.Hour, 10 * time.Minute},
+		{"capped at the max interval", 4 * time.Hour, time.Hour, time.Hour},
+		{"no max interval", 4 * time.Hour, 0, 2 * time.Hour},
+		{"at least the min interval", 20 * time.Second, time.Hour, minRenewInterval},
+		{"max interval below the min interval", time.Hour, time.Second, minRenewInterval},
+	}
+
+	for _, test := range tests {
+		if interval := renewInterval(test.ttl, test.maxInterval); interval != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, interval)
+		}
+	}
+}
+
+// tokenServer serves the token lookup and renewal endpoints of Vault, and counts the requests to
+// them
+type tokenServer struct {
+	*httptest.Server
+
+	lookups  int32
+	renewals int32
+
+	// the number of lookups which fail before a lookup succeeds
+	failedLookups int32
+	renewable     bool
+}
+
+func newTokenServer(t *testing.T, renewable bool, failedLookups int32) *tokenServer {
+	server := &tokenServer{renewable: renewable, failedLookups: failedLookups}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/auth/token/lookup-self":
+			if atomic.AddInt32(&server.lookups, 1) <= server.failedLookups {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			json.NewEncoder(w).Encode(&LookupTokenResponse{
+				Data: &LookupTokenData{TTL: 60, Renewable: server.renewable},
+			})
+		case "/v1/auth/token/renew-self":
+			atomic.AddInt32(&server.renewals, 1)
+
+			json.NewEncoder(w).Encode(&RenewTokenResponse{
+				Auth: &TokenAuth{LeaseDuration: 60, Renewable: true},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// setMinRenewInterval shortens the time between renewals for a test
+func setMinRenewInterval(t *testing.T, interval time.Duration) {
+	prev := minRenewInterval
+	minRenewInterval = interval
+
+	t.Cleanup(func() {
+		minRenewInterval = prev
+	})
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for condition")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStartTokenRenewal(t *testing.T) {
+	setMinRenewInterval(t, 10*time.Millisecond)
+
+	server := newTokenServer(t, true, 0)
+	client := NewClient(server.URL, "token", "test")
+
+	stop := client.StartTokenRenewal(20*time.Millisecond, func(err error) {
+		t.Errorf("unexpected error: %v", err)
+	})
+
+	waitFor(t, func() bool {
+		return atomic.LoadInt32(&server.renewals) >= 2
+	})
+
+	stop()
+
+	// the renewal stops once the stop function is called
+	renewals := atomic.LoadInt32(&server.renewals)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if after := atomic.LoadInt32(&server.renewals); after != renewals {
+		t.Errorf("expected the renewal to stop, got %d renewals after stopping", after-renewals)
+	}
+}
+
+func TestStartTokenRenewalNotRenewable(t *testing.T) {
+	setMinRenewInterval(t, 10*time.Millisecond)
+
+	server := newTokenServer(t, false, 0)
+	client := NewClient(server.URL, "token", "test")
+
+	stop := client.StartTokenRenewal(20*time.Millisecond, func(err error) {
+		t.Errorf("unexpected error: %v", err)
+	})
+
+	defer stop()
+
+	waitFor(t, func() bool {
+		return atomic.LoadInt32(&server.lookups) == 1
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	if renewals := atomic.LoadInt32(&server.renewals); renewals != 0 {
+		t.Errorf("expected a token which can't be renewed not to be renewed, got %d renewals", renewals)
+	}
+}
+
+func TestStartTokenRenewalRetriesLookup(t *testing.T) {
+	setMinRenewInterval(t, 10*time.Millisecond)
+
+	server := newTokenServer(t, true, 2)
+	client := NewClient(server.URL, "token", "test")
+
+	var errs int32
+
+	stop := client.StartTokenRenewal(20*time.Millisecond, func(err error) {
+		atomic.AddInt32(&errs, 1)
+	})
+
+	defer stop()
+
+	waitFor(t, func() bool {
+		return atomic.LoadInt32(&server.renewals) >= 1
+	})
+
+	if n := atomic.LoadInt32(&errs); n != 2 {
+		t.Errorf("expected 2 errors for the failed lookups, got %d", n)
+	}
+}
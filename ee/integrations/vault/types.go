@@ -69,6 +69,25 @@ type GetGitlabCredentialData struct {
 	Data     *credentials.GitlabCredential `json:"data"`
 }
 
+type GetEnvGroupSecretsResponse struct {
+	*VaultGetResponse
+	Data *GetEnvGroupSecretsData `json:"data"`
+}
+
+type GetEnvGroupSecretsData struct {
+	Metadata *VaultMetadata    `json:"metadata"`
+	Data     map[string]string `json:"data"`
+}
+
+type ListSecretsResponse struct {
+	*VaultGetResponse
+	Data *ListSecretsData `json:"data"`
+}
+
+type ListSecretsData struct {
+	Keys []string `json:"keys"`
+}
+
 type CreatePolicyRequest struct {
 	Policy string `json:"policy"`
 }
@@ -89,5 +108,22 @@ type CreateTokenResponse struct {
 }
 
 type TokenAuth struct {
-	Token string `json:"client_token"`
+	Token         string `json:"client_token"`
+	LeaseDuration int64  `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+type RenewTokenResponse struct {
+	*VaultGetResponse
+	Auth *TokenAuth `json:"auth"`
+}
+
+type LookupTokenResponse struct {
+	*VaultGetResponse
+	Data *LookupTokenData `json:"data"`
+}
+
+type LookupTokenData struct {
+	TTL       int64 `json:"ttl"`
+	Renewable bool  `json:"renewable"`
 }
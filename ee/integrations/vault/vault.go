@@ -300,6 +300,10 @@ func (c *Client) getRequest(path string, dst interface{}) error {
 
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", credentials.ErrNotFound, path)
+	}
+
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
 		resBytes, err := ioutil.ReadAll(res.Body)
 
@@ -358,6 +362,10 @@ func (c *Client) writeRequest(method, path string, data interface{}, dst interfa
 
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", credentials.ErrNotFound, path)
+	}
+
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
 		resBytes, err := ioutil.ReadAll(res.Body)
 
@@ -0,0 +1,146 @@
+//go:build ee
+// +build ee
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MigrateEnvGroupSecrets writes the values of the secret variables of every version of the env
+// groups of every cluster to Vault. Versions which were already written are skipped, so the
+// migration can be run again after a failure. The secrets of the versions are kept, since the pods
+// of the env groups' applications read their values from them.
+func MigrateEnvGroupSecrets(db *gorm.DB, dbConf *env.DBConf) error {
+	var vaultClient *vault.Client
+
+	if dbConf.VaultAPIKey != "" && dbConf.VaultServerURL != "" && dbConf.VaultPrefix != "" {
+		vaultClient = vault.NewClient(
+			dbConf.VaultServerURL,
+			dbConf.VaultAPIKey,
+			dbConf.VaultPrefix,
+		)
+	} else {
+		return fmt.Errorf("env variables not properly set for vault migration")
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(dbConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, vaultClient)
+
+	// get count of model
+	var count int64
+
+	if err := db.Model(&models.Cluster{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	// make a map of cluster ids to errors
+	errors := make(map[uint]error)
+	var migrated int
+
+	// iterate (count / stepSize) + 1 times using Limit and Offset
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		clusters := []*models.Cluster{}
+
+		if err := db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&clusters).Error; err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters {
+			agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+				Repo:    repo,
+				Cluster: cluster,
+			})
+
+			if err != nil {
+				errors[cluster.ID] = err
+				fmt.Printf("env group migration error on cluster ID %d: %v\n", cluster.ID, err)
+				continue
+			}
+
+			n, err := migrateClusterEnvGroupSecrets(agent, vaultClient, cluster)
+
+			migrated += n
+
+			if err != nil {
+				errors[cluster.ID] = err
+				fmt.Printf("env group migration error on cluster ID %d: %v\n", cluster.ID, err)
+			}
+		}
+	}
+
+	fmt.Printf("migrated %d env group versions of %d clusters with %d errors\n", migrated, count, len(errors))
+
+	return nil
+}
+
+// migrateClusterEnvGroupSecrets writes the values of the secret variables of every version of the
+// env groups of a cluster to Vault, and returns the number of versions which were written
+func migrateClusterEnvGroupSecrets(agent *kubernetes.Agent, client *vault.Client, cluster *models.Cluster) (int, error) {
+	listResp, err := agent.Clientset.CoreV1().ConfigMaps("").List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: "envgroup",
+		},
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	storage := envgroup.NewSecretStorage(client, cluster.ProjectID, cluster.ID)
+	var migrated int
+
+	for i := range listResp.Items {
+		cm := &listResp.Items[i]
+
+		envGroup, err := envgroup.ToEnvGroup(cm)
+
+		// the secret variables of env groups synced from an external secret store are references
+		// to secrets in the store, which aren't migrated
+		if err != nil || envGroup.ExternalSecretStore != nil {
+			continue
+		}
+
+		// Check if the version already exists in vault. If so, we don't write anything to vault,
+		// since we don't want to overwrite any data that's been written.
+		_, err = client.GetEnvGroupSecrets(&credentials.EnvGroupSecretPath{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			Namespace: envGroup.Namespace,
+			Name:      envGroup.Name,
+			Version:   envGroup.Version,
+		})
+
+		if err == nil {
+			continue
+		} else if !errors.Is(err, credentials.ErrNotFound) {
+			return migrated, err
+		}
+
+		if err := storage.WriteSecrets(agent, cm); err != nil {
+			return migrated, err
+		}
+
+		migrated++
+	}
+
+	return migrated, nil
+}
@@ -6,9 +6,15 @@ import (
 	"github.com/porter-dev/porter/ee/models"
 	"github.com/porter-dev/porter/ee/repository"
 	"github.com/porter-dev/porter/internal/encryption"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
 	"gorm.io/gorm"
 )
 
+func init() {
+	// the billing token is encrypted by EncryptUserBillingData
+	rgorm.RegisterEncryptedFields(&models.UserBilling{}, "Token")
+}
+
 // UserBillingRepository uses gorm.DB for querying the database
 type UserBillingRepository struct {
 	db  *gorm.DB
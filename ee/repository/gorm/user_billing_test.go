@@ -0,0 +1,89 @@
+//go:build ee
+// +build ee
+
+package gorm_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/models"
+	"github.com/porter-dev/porter/ee/repository/gorm"
+	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/encryption"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+)
+
+func TestReEncryptUserBilling(t *testing.T) {
+	dbFileName := "./porter_user_billing.db"
+
+	db, err := adapter.New(&env.DBConf{
+		SQLLite:     true,
+		SQLLitePath: dbFileName,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	defer os.Remove(dbFileName)
+
+	if err := db.AutoMigrate(&models.UserBilling{}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	var oldKey, newKey [32]byte
+
+	for i, b := range []byte("__random_strong_encryption_key__") {
+		oldKey[i] = b
+	}
+
+	for i, b := range []byte("__new_strong_encryption_key_____") {
+		newKey[i] = b
+	}
+
+	userBilling, err := gorm.NewUserBillingRepository(db, &oldKey).CreateUserBilling(&models.UserBilling{
+		ProjectID:  1,
+		UserID:     1,
+		TeammateID: "teammate",
+		Token:      []byte("billing-token"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the billing token was encrypted with the previous key
+	encryption.AddDecryptionKeys(&oldKey)
+
+	res, err := rgorm.ReEncrypt(db, &newKey)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if res.ReEncrypted != 1 || res.Current != 0 || res.Failed != 0 {
+		t.Errorf("incorrect re-encryption result: expected 1 re-encrypted, got %+v\n", res)
+	}
+
+	stored := &models.UserBilling{}
+
+	if err := db.First(stored, userBilling.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !encryption.HasKeyID(stored.Token, &newKey) {
+		t.Errorf("billing token was not encrypted with the new key\n")
+	}
+
+	userBilling, err = gorm.NewUserBillingRepository(db, &newKey).ReadUserBilling(1, 1)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(userBilling.Token) != "billing-token" {
+		t.Errorf("incorrect billing token: expected %s, got %s\n", "billing-token", userBilling.Token)
+	}
+}
@@ -12,8 +12,14 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
+// ReadReplicaResolver is the name of the resolver which routes queries to the read replica.
+// Queries are only routed to the replica when they use this resolver explicitly, since most
+// reads are followed by writes which cannot tolerate replication lag.
+const ReadReplicaResolver = "read_replica"
+
 // New returns a new gorm database instance
 func New(conf *env.DBConf) (*gorm.DB, error) {
 	logger := logger.New(
@@ -86,12 +92,37 @@ func New(conf *env.DBConf) (*gorm.DB, error) {
 			}
 
 			if err == nil {
-				return res, nil
+				break
 			}
 
 			retryCount++
 		}
 	}
 
-	return res, err
+	sqlDB, err := res.DB()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB.SetMaxOpenConns(conf.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(conf.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(conf.ConnMaxLifetime)
+
+	if conf.ReadReplicaDSN != "" {
+		resolver := dbresolver.Register(dbresolver.Config{
+			Replicas: []gorm.Dialector{postgres.Open(conf.ReadReplicaDSN)},
+		}, ReadReplicaResolver).
+			SetMaxOpenConns(conf.MaxOpenConns).
+			SetMaxIdleConns(conf.MaxIdleConns).
+			SetConnMaxLifetime(conf.ConnMaxLifetime)
+
+		err = res.Use(resolver)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not register read replica: %w", err)
+		}
+	}
+
+	return res, nil
 }
@@ -0,0 +1,350 @@
+package compliance
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+const (
+	// ManifestFile is the name of the manifest of a bundle
+	ManifestFile = "manifest.json"
+
+	// SignatureFile is the name of the file which holds the base64-encoded signature of the
+	// manifest of a bundle
+	SignatureFile = "manifest.json.sig"
+)
+
+// ErrInvalidBundle is returned when a bundle does not match its signed manifest
+var ErrInvalidBundle = errors.New("invalid compliance bundle")
+
+// Signer signs the manifests of compliance bundles with an ed25519 key
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewSigner returns a signer for a base64-encoded ed25519 seed of 32 bytes
+func NewSigner(seed string) (*Signer, error) {
+	raw, err := base64.StdEncoding.DecodeString(seed)
+
+	if err != nil || len(raw) != ed25519.SeedSize {
+		return nil, fmt.Errorf("the compliance signing key must be a base64-encoded seed of %d bytes", ed25519.SeedSize)
+	}
+
+	return &Signer{ed25519.NewKeyFromSeed(raw)}, nil
+}
+
+// PublicKey returns the base64-encoded public key which verifies the signatures of the signer
+func (s *Signer) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
+
+// WriteBundle writes the evidence as a zip archive, which contains a file of records for each
+// kind of evidence, a manifest with the digest of each file, and the signature of the manifest
+func WriteBundle(
+	w io.Writer,
+	evidence *Evidence,
+	format types.ComplianceExportFormat,
+	generatedBy string,
+	signer *Signer,
+	now time.Time,
+) error {
+	files := []struct {
+		name    string
+		records int
+		write   func(io.Writer) error
+	}{
+		{"audit_logs", len(evidence.AuditLogs), func(w io.Writer) error {
+			return writeAuditLogs(w, format, evidence.AuditLogs, evidence.UserEmails)
+		}},
+		{"access_changes", len(evidence.AccessChanges()), func(w io.Writer) error {
+			return writeAuditLogs(w, format, evidence.AccessChanges(), evidence.UserEmails)
+		}},
+		{"secret_access", len(evidence.SecretAccess()), func(w io.Writer) error {
+			return writeAuditLogs(w, format, evidence.SecretAccess(), evidence.UserEmails)
+		}},
+		{"approvals", len(evidence.Approvals), func(w io.Writer) error {
+			return writeApprovals(w, format, evidence.Approvals, evidence.UserEmails)
+		}},
+	}
+
+	manifest := &types.ComplianceExportManifest{
+		ProjectID:   evidence.ProjectID,
+		ProjectName: evidence.ProjectName,
+		From:        evidence.From,
+		To:          evidence.To,
+		Format:      format,
+		GeneratedAt: now,
+		GeneratedBy: generatedBy,
+		PublicKey:   signer.PublicKey(),
+		Files:       make([]*types.ComplianceExportFile, 0, len(files)),
+	}
+
+	archive := zip.NewWriter(w)
+
+	for _, file := range files {
+		buf := &bytes.Buffer{}
+
+		if err := file.write(buf); err != nil {
+			return err
+		}
+
+		name := fmt.Sprintf("%s.%s", file.name, format)
+
+		if err := writeZipFile(archive, name, buf.Bytes(), now); err != nil {
+			return err
+		}
+
+		digest := sha256.Sum256(buf.Bytes())
+
+		manifest.Files = append(manifest.Files, &types.ComplianceExportFile{
+			Name:    name,
+			SHA256:  hex.EncodeToString(digest[:]),
+			Records: file.records,
+		})
+	}
+
+	rawManifest, err := json.MarshalIndent(manifest, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := writeZipFile(archive, ManifestFile, rawManifest, now); err != nil {
+		return err
+	}
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(signer.key, rawManifest))
+
+	if err := writeZipFile(archive, SignatureFile, []byte(signature), now); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// VerifyBundle checks the signature of the manifest of a bundle with a base64-encoded public
+// key, and checks that the files of the bundle match the manifest
+func VerifyBundle(r io.ReaderAt, size int64, publicKey string) (*types.ComplianceExportManifest, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(publicKey)
+
+	if err != nil || len(rawKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key")
+	}
+
+	archive, err := zip.NewReader(r, size)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+	}
+
+	contents := make(map[string][]byte)
+
+	for _, file := range archive.File {
+		rc, err := file.Open()
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+		}
+
+		data, err := io.ReadAll(rc)
+		rc.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+		}
+
+		contents[file.Name] = data
+	}
+
+	rawManifest, ok := contents[ManifestFile]
+
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, ManifestFile)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(contents[SignatureFile])))
+
+	if err != nil || !ed25519.Verify(ed25519.PublicKey(rawKey), rawManifest, signature) {
+		return nil, fmt.Errorf("%w: the signature of the manifest is invalid", ErrInvalidBundle)
+	}
+
+	manifest := &types.ComplianceExportManifest{}
+
+	if err := json.Unmarshal(rawManifest, manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+	}
+
+	// every file other than the manifest and its signature must be listed in the manifest
+	if len(contents) != len(manifest.Files)+2 {
+		return nil, fmt.Errorf("%w: the bundle contains files which are not in the manifest", ErrInvalidBundle)
+	}
+
+	for _, file := range manifest.Files {
+		data, ok := contents[file.Name]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, file.Name)
+		}
+
+		digest := sha256.Sum256(data)
+
+		if hex.EncodeToString(digest[:]) != file.SHA256 {
+			return nil, fmt.Errorf("%w: the digest of %s does not match the manifest", ErrInvalidBundle, file.Name)
+		}
+	}
+
+	return manifest, nil
+}
+
+func writeZipFile(archive *zip.Writer, name string, data []byte, modified time.Time) error {
+	w, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// auditLogRecord is an audit log with the email of its user
+type auditLogRecord struct {
+	*types.AuditLog
+
+	UserEmail string `json:"user_email"`
+}
+
+func writeAuditLogs(w io.Writer, format types.ComplianceExportFormat, auditLogs []*types.AuditLog, emails map[uint]string) error {
+	if format == types.ComplianceExportFormatJSON {
+		records := make([]*auditLogRecord, 0, len(auditLogs))
+
+		for _, auditLog := range auditLogs {
+			records = append(records, &auditLogRecord{auditLog, emails[auditLog.UserID]})
+		}
+
+		return writeJSON(w, records)
+	}
+
+	rows := [][]string{{
+		"id", "created_at", "user_id", "user_email", "action", "cluster_id",
+		"resource_kind", "resource_name", "namespace", "release_name", "metadata",
+	}}
+
+	for _, auditLog := range auditLogs {
+		metadata := ""
+
+		if len(auditLog.Metadata) > 0 {
+			raw, _ := json.Marshal(auditLog.Metadata)
+			metadata = string(raw)
+		}
+
+		rows = append(rows, []string{
+			formatUint(auditLog.ID),
+			auditLog.CreatedAt.UTC().Format(time.RFC3339),
+			formatUint(auditLog.UserID),
+			emails[auditLog.UserID],
+			string(auditLog.Action),
+			formatUint(auditLog.ClusterID),
+			auditLog.ResourceKind,
+			auditLog.ResourceName,
+			auditLog.Namespace,
+			auditLog.ReleaseName,
+			metadata,
+		})
+	}
+
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// approvalRecord is an approval with the emails of the users who requested and reviewed it
+type approvalRecord struct {
+	*types.Approval
+
+	RequestedByEmail string `json:"requested_by_email"`
+	ReviewedByEmail  string `json:"reviewed_by_email,omitempty"`
+}
+
+func writeApprovals(w io.Writer, format types.ComplianceExportFormat, approvals []*types.Approval, emails map[uint]string) error {
+	if format == types.ComplianceExportFormatJSON {
+		records := make([]*approvalRecord, 0, len(approvals))
+
+		for _, approval := range approvals {
+			records = append(records, &approvalRecord{
+				approval, emails[approval.RequestedByUserID], emails[approval.ReviewedByUserID],
+			})
+		}
+
+		return writeJSON(w, records)
+	}
+
+	rows := [][]string{{
+		"id", "created_at", "cluster_id", "namespace", "name", "chart_version",
+		"requested_by_user_id", "requested_by_email", "approver_ids", "status",
+		"reviewed_by_user_id", "reviewed_by_email", "reviewed_at", "comment", "revision", "error",
+	}}
+
+	for _, approval := range approvals {
+		approverIDs := make([]string, 0, len(approval.ApproverIDs))
+
+		for _, id := range approval.ApproverIDs {
+			approverIDs = append(approverIDs, formatUint(id))
+		}
+
+		reviewedAt := ""
+
+		if approval.ReviewedAt != nil {
+			reviewedAt = approval.ReviewedAt.UTC().Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{
+			formatUint(approval.ID),
+			approval.CreatedAt.UTC().Format(time.RFC3339),
+			formatUint(approval.ClusterID),
+			approval.Namespace,
+			approval.Name,
+			approval.ChartVersion,
+			formatUint(approval.RequestedByUserID),
+			emails[approval.RequestedByUserID],
+			strings.Join(approverIDs, ";"),
+			string(approval.Status),
+			formatUint(approval.ReviewedByUserID),
+			emails[approval.ReviewedByUserID],
+			reviewedAt,
+			approval.Comment,
+			strconv.Itoa(approval.Revision),
+			approval.Error,
+		})
+	}
+
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func formatUint(i uint) string {
+	return strconv.FormatUint(uint64(i), 10)
+}
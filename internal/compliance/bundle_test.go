@@ -0,0 +1,172 @@
+package compliance_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/compliance"
+)
+
+func testEvidence() *compliance.Evidence {
+	reviewedAt := time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	return &compliance.Evidence{
+		ProjectID:   1,
+		ProjectName: "project",
+		From:        time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+		AuditLogs: []*types.AuditLog{
+			{ID: 1, UserID: 1, Action: types.AuditLogActionRoleUpdate, ResourceKind: "Role", ResourceName: "2"},
+			{ID: 2, UserID: 2, Action: types.AuditLogActionEnvGroupSecretsRead, ResourceName: "env", Namespace: "default"},
+			{ID: 3, UserID: 2, Action: types.AuditLogActionResourceApply, Metadata: map[string]interface{}{"kind": "Deployment"}},
+		},
+		Approvals: []*types.Approval{
+			{
+				ID: 1, Name: "web", Namespace: "default", RequestedByUserID: 2, ApproverIDs: []uint{1, 3},
+				Status: types.ApprovalStatusApproved, ReviewedByUserID: 1, ReviewedAt: &reviewedAt,
+			},
+		},
+		UserEmails: map[uint]string{1: "admin@porter.run", 2: "dev@porter.run"},
+	}
+}
+
+func testSigner(t *testing.T, b byte) *compliance.Signer {
+	signer, err := compliance.NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32)))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return signer
+}
+
+func readFile(t *testing.T, bundle []byte, name string) []byte {
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	rc, err := archive.Open(name)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	defer rc.Close()
+
+	data, _ := io.ReadAll(rc)
+
+	return data
+}
+
+func TestWriteAndVerifyBundle(t *testing.T) {
+	signer := testSigner(t, 1)
+
+	for _, format := range []types.ComplianceExportFormat{types.ComplianceExportFormatCSV, types.ComplianceExportFormatJSON} {
+		buf := &bytes.Buffer{}
+
+		if err := compliance.WriteBundle(buf, testEvidence(), format, "admin@porter.run", signer, time.Now()); err != nil {
+			t.Fatalf("%s: unexpected error: %v\n", format, err)
+		}
+
+		manifest, err := compliance.VerifyBundle(bytes.NewReader(buf.Bytes()), int64(buf.Len()), signer.PublicKey())
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error verifying bundle: %v\n", format, err)
+		}
+
+		records := make(map[string]int)
+
+		for _, file := range manifest.Files {
+			records[file.Name] = file.Records
+		}
+
+		expected := map[string]int{
+			"audit_logs." + string(format):     3,
+			"access_changes." + string(format): 1,
+			"secret_access." + string(format):  1,
+			"approvals." + string(format):      1,
+		}
+
+		for name, count := range expected {
+			if records[name] != count {
+				t.Errorf("%s: expected %d records in %s, got %d\n", format, count, name, records[name])
+			}
+		}
+
+		// bundles don't verify with the key of another instance
+		if _, err := compliance.VerifyBundle(bytes.NewReader(buf.Bytes()), int64(buf.Len()), testSigner(t, 2).PublicKey()); !errors.Is(err, compliance.ErrInvalidBundle) {
+			t.Errorf("%s: expected invalid bundle error for another key, got %v\n", format, err)
+		}
+	}
+}
+
+func TestCSVRecords(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := compliance.WriteBundle(buf, testEvidence(), types.ComplianceExportFormatCSV, "admin@porter.run", testSigner(t, 1), time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(readFile(t, buf.Bytes(), "approvals.csv"))).ReadAll()
+
+	if err != nil {
+		t.Fatalf("unexpected error parsing approvals: %v\n", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected header and one approval, got %d rows\n", len(rows))
+	}
+
+	approval := make(map[string]string)
+
+	for i, column := range rows[0] {
+		approval[column] = rows[1][i]
+	}
+
+	if approval["requested_by_email"] != "dev@porter.run" || approval["reviewed_by_email"] != "admin@porter.run" ||
+		approval["approver_ids"] != "1;3" || approval["reviewed_at"] != "2022-06-02T00:00:00Z" {
+		t.Errorf("unexpected approval record %v\n", approval)
+	}
+}
+
+func TestVerifyTamperedBundle(t *testing.T) {
+	signer := testSigner(t, 1)
+	buf := &bytes.Buffer{}
+
+	if err := compliance.WriteBundle(buf, testEvidence(), types.ComplianceExportFormatJSON, "admin@porter.run", signer, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	// rewrite the bundle with the action of an audit log changed
+	archive, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	tampered := &bytes.Buffer{}
+	w := zip.NewWriter(tampered)
+
+	for _, file := range archive.File {
+		data := readFile(t, buf.Bytes(), file.Name)
+
+		if file.Name == "audit_logs.json" {
+			data = bytes.Replace(data, []byte(`"envgroup.secrets.read"`), []byte(`"resource.apply"`), 1)
+		}
+
+		fw, _ := w.Create(file.Name)
+		fw.Write(data)
+	}
+
+	w.Close()
+
+	_, err := compliance.VerifyBundle(bytes.NewReader(tampered.Bytes()), int64(tampered.Len()), signer.PublicKey())
+
+	if !errors.Is(err, compliance.ErrInvalidBundle) {
+		t.Errorf("expected invalid bundle error for tampered bundle, got %v\n", err)
+	}
+}
@@ -0,0 +1,115 @@
+package compliance
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Evidence is the compliance evidence of a project over a period
+type Evidence struct {
+	ProjectID   uint
+	ProjectName string
+	From        time.Time
+	To          time.Time
+
+	AuditLogs []*types.AuditLog
+	Approvals []*types.Approval
+
+	// the emails of the users which the records reference, by id
+	UserEmails map[uint]string
+}
+
+// Collect reads the compliance evidence of a project in [from, to)
+func Collect(repo repository.Repository, project *models.Project, from, to time.Time) (*Evidence, error) {
+	auditLogs, err := repo.AuditLog().ListAuditLogsByDateRange(project.ID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := repo.Approval().ListApprovalsByDateRange(project.ID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	evidence := &Evidence{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		From:        from,
+		To:          to,
+		AuditLogs:   make([]*types.AuditLog, 0, len(auditLogs)),
+		Approvals:   make([]*types.Approval, 0, len(approvals)),
+		UserEmails:  make(map[uint]string),
+	}
+
+	userIDs := make([]uint, 0)
+
+	for _, auditLog := range auditLogs {
+		evidence.AuditLogs = append(evidence.AuditLogs, auditLog.ToAuditLogType())
+		userIDs = append(userIDs, auditLog.UserID)
+	}
+
+	for _, approval := range approvals {
+		evidence.Approvals = append(evidence.Approvals, approval.ToApprovalType())
+		userIDs = append(userIDs, approval.RequestedByUserID, approval.ReviewedByUserID)
+	}
+
+	for _, id := range userIDs {
+		if _, ok := evidence.UserEmails[id]; ok || id == 0 {
+			continue
+		}
+
+		user, err := repo.User().ReadUser(id)
+
+		// records of deleted users are kept, without their email
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		} else if err != nil {
+			evidence.UserEmails[id] = ""
+			continue
+		}
+
+		evidence.UserEmails[id] = user.Email
+	}
+
+	return evidence, nil
+}
+
+// AccessChanges returns the audit logs which changed the access of users and API tokens to the
+// project
+func (e *Evidence) AccessChanges() []*types.AuditLog {
+	actions := make(map[types.AuditLogAction]bool)
+
+	for _, action := range types.AccessAuditLogActions {
+		actions[action] = true
+	}
+
+	res := make([]*types.AuditLog, 0)
+
+	for _, auditLog := range e.AuditLogs {
+		if actions[auditLog.Action] {
+			res = append(res, auditLog)
+		}
+	}
+
+	return res
+}
+
+// SecretAccess returns the audit logs which recorded reads of secret values
+func (e *Evidence) SecretAccess() []*types.AuditLog {
+	res := make([]*types.AuditLog, 0)
+
+	for _, auditLog := range e.AuditLogs {
+		if auditLog.Action == types.AuditLogActionEnvGroupSecretsRead {
+			res = append(res, auditLog)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,76 @@
+package cost
+
+import (
+	"sort"
+
+	"github.com/porter-dev/porter/internal/kubernetes/nodes"
+	"github.com/porter-dev/porter/internal/models"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const releaseLabel = "app.kubernetes.io/instance"
+
+// Allocate attributes the cost of an hour to the releases whose pods are scheduled on the
+// nodes, based on the resources which the pods request. Pods which don't belong to a release
+// are attributed to their namespace, with an empty release name. Resources which are not
+// requested by any pod are not attributed.
+func Allocate(nodeList []corev1.Node, pods []corev1.Pod) []*models.CostRecord {
+	rates := make(map[string]*NodeRates)
+
+	for i := range nodeList {
+		rates[nodeList[i].Name] = GetNodeRates(&nodeList[i])
+	}
+
+	type key struct {
+		namespace, releaseName string
+	}
+
+	records := make(map[key]*models.CostRecord)
+
+	for i := range pods {
+		pod := &pods[i]
+
+		nodeRates, ok := rates[pod.Spec.NodeName]
+
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		reqs := nodes.GetPodRequests(pod)
+		cores := toCores(reqs)
+		gib := toGiB(reqs)
+
+		k := key{pod.Namespace, pod.Labels[releaseLabel]}
+
+		record, ok := records[k]
+
+		if !ok {
+			record = &models.CostRecord{
+				Namespace:   k.namespace,
+				ReleaseName: k.releaseName,
+			}
+
+			records[k] = record
+		}
+
+		record.CPUCores += cores
+		record.MemoryGiB += gib
+		record.Cost += cores*nodeRates.CPUHourlyRate + gib*nodeRates.GiBHourlyRate
+	}
+
+	res := make([]*models.CostRecord, 0, len(records))
+
+	for _, record := range records {
+		res = append(res, record)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+
+		return res[i].ReleaseName < res[j].ReleaseName
+	})
+
+	return res
+}
@@ -0,0 +1,100 @@
+package cost
+
+import (
+	"math"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name, instanceType, cpu, memory string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{instanceTypeLabel: instanceType},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func testPod(namespace, release, nodeName, cpu, memory string, phase corev1.PodPhase) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+
+	if release != "" {
+		pod.Labels[releaseLabel] = release
+	}
+
+	return pod
+}
+
+func TestGetNodeRates(t *testing.T) {
+	node := testNode("a", "m5.large", "2", "8Gi")
+	rates := GetNodeRates(&node)
+
+	// a fully requested node costs the price of its instance type
+	if price := 2*rates.CPUHourlyRate + 8*rates.GiBHourlyRate; math.Abs(price-0.096) > 1e-9 {
+		t.Errorf("expected the node to cost 0.096 per hour, got %f", price)
+	}
+
+	unknown := testNode("b", "custom", "2", "8Gi")
+	rates = GetNodeRates(&unknown)
+
+	if rates.CPUHourlyRate != fallbackCPUHourlyRate || rates.GiBHourlyRate != fallbackGiBHourlyRate {
+		t.Errorf("expected the fallback rates for an unknown instance type, got %v", rates)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	nodeList := []corev1.Node{testNode("a", "custom", "4", "16Gi")}
+
+	pods := []corev1.Pod{
+		testPod("default", "web", "a", "500m", "1Gi", corev1.PodRunning),
+		testPod("default", "web", "a", "500m", "1Gi", corev1.PodRunning),
+		testPod("default", "", "a", "1", "2Gi", corev1.PodRunning),
+		// pods which finished or aren't scheduled are not attributed
+		testPod("default", "job", "a", "1", "2Gi", corev1.PodSucceeded),
+		testPod("default", "pending", "", "1", "2Gi", corev1.PodPending),
+	}
+
+	records := Allocate(nodeList, pods)
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	if records[0].ReleaseName != "" || records[1].ReleaseName != "web" {
+		t.Fatalf("expected records for the namespace and web, got %q and %q", records[0].ReleaseName, records[1].ReleaseName)
+	}
+
+	web := records[1]
+	expectedCost := 1*fallbackCPUHourlyRate + 2*fallbackGiBHourlyRate
+
+	if web.CPUCores != 1 || web.MemoryGiB != 2 || math.Abs(web.Cost-expectedCost) > 1e-9 {
+		t.Errorf("expected web to request 1 core and 2 GiB costing %f, got %f cores, %f GiB costing %f",
+			expectedCost, web.CPUCores, web.MemoryGiB, web.Cost)
+	}
+}
@@ -0,0 +1,119 @@
+package cost
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// fallbackCPUHourlyRate and fallbackGiBHourlyRate are the on-demand rates of a vCPU and a
+	// GiB of memory, used for nodes whose instance type is not known
+	fallbackCPUHourlyRate = 0.031611
+	fallbackGiBHourlyRate = 0.004237
+
+	instanceTypeLabel       = "node.kubernetes.io/instance-type"
+	legacyInstanceTypeLabel = "beta.kubernetes.io/instance-type"
+)
+
+// instanceHourlyPrices are the on-demand hourly prices of common instance types, in US dollars
+var instanceHourlyPrices = map[string]float64{
+	// AWS
+	"t3.micro":   0.0104,
+	"t3.small":   0.0208,
+	"t3.medium":  0.0416,
+	"t3.large":   0.0832,
+	"t3.xlarge":  0.1664,
+	"t3.2xlarge": 0.3328,
+	"m5.large":   0.096,
+	"m5.xlarge":  0.192,
+	"m5.2xlarge": 0.384,
+	"m5.4xlarge": 0.768,
+	"c5.large":   0.085,
+	"c5.xlarge":  0.17,
+	"c5.2xlarge": 0.34,
+	"c5.4xlarge": 0.68,
+	"r5.large":   0.126,
+	"r5.xlarge":  0.252,
+	"r5.2xlarge": 0.504,
+	"r5.4xlarge": 1.008,
+
+	// GCP
+	"e2-small":      0.016751,
+	"e2-medium":     0.033503,
+	"e2-standard-2": 0.067006,
+	"e2-standard-4": 0.134012,
+	"e2-standard-8": 0.268024,
+	"n1-standard-1": 0.0475,
+	"n1-standard-2": 0.095,
+	"n1-standard-4": 0.19,
+	"n1-standard-8": 0.38,
+	"n2-standard-2": 0.097118,
+	"n2-standard-4": 0.194236,
+	"n2-standard-8": 0.388472,
+
+	// DigitalOcean
+	"s-1vcpu-2gb":  0.01786,
+	"s-2vcpu-2gb":  0.02679,
+	"s-2vcpu-4gb":  0.03571,
+	"s-4vcpu-8gb":  0.07143,
+	"s-8vcpu-16gb": 0.14286,
+}
+
+// NodeRates are the hourly rates of a core and a GiB of memory on a node, in US dollars
+type NodeRates struct {
+	CPUHourlyRate float64
+	GiBHourlyRate float64
+}
+
+// GetNodeRates returns the hourly rates of a node. The price of a node of a known instance type
+// is split between its cores and memory in the same ratio as the fallback rates, so that the
+// requested resources of a node which is fully requested add up to the price of the node.
+func GetNodeRates(node *corev1.Node) *NodeRates {
+	fallback := &NodeRates{
+		CPUHourlyRate: fallbackCPUHourlyRate,
+		GiBHourlyRate: fallbackGiBHourlyRate,
+	}
+
+	price, ok := instanceHourlyPrices[getInstanceType(node)]
+
+	if !ok {
+		return fallback
+	}
+
+	capacity := node.Status.Capacity
+
+	if len(node.Status.Allocatable) > 0 {
+		capacity = node.Status.Allocatable
+	}
+
+	cores := toCores(capacity)
+	gib := toGiB(capacity)
+
+	fallbackPrice := cores*fallbackCPUHourlyRate + gib*fallbackGiBHourlyRate
+
+	if fallbackPrice == 0 {
+		return fallback
+	}
+
+	scale := price / fallbackPrice
+
+	return &NodeRates{
+		CPUHourlyRate: fallbackCPUHourlyRate * scale,
+		GiBHourlyRate: fallbackGiBHourlyRate * scale,
+	}
+}
+
+func getInstanceType(node *corev1.Node) string {
+	if instanceType, ok := node.Labels[instanceTypeLabel]; ok {
+		return instanceType
+	}
+
+	return node.Labels[legacyInstanceTypeLabel]
+}
+
+func toCores(resources corev1.ResourceList) float64 {
+	return float64(resources.Cpu().MilliValue()) / 1000
+}
+
+func toGiB(resources corev1.ResourceList) float64 {
+	return float64(resources.Memory().Value()) / (1 << 30)
+}
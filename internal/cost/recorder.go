@@ -0,0 +1,119 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+	"golang.org/x/oauth2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Recorder records the cost of every cluster at the end of each hour
+type Recorder struct {
+	repo   repository.Repository
+	doConf *oauth2.Config
+	logger *logger.Logger
+}
+
+func NewRecorder(repo repository.Repository, doConf *oauth2.Config, logger *logger.Logger) *Recorder {
+	return &Recorder{repo, doConf, logger}
+}
+
+// Start records each hour as it ends, until the context is cancelled
+func (r *Recorder) Start(ctx context.Context) {
+	go func() {
+		for {
+			next := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+
+			hour := next.Add(-time.Hour)
+
+			if err := r.RecordHour(ctx, hour); err != nil {
+				r.logger.Error().Err(err).Msgf("error recording costs for %s", hour.Format(time.RFC3339))
+			}
+		}
+	}()
+}
+
+// RecordHour records the cost of every cluster for the hour which starts at hour, based on the
+// resources which are requested when it is called. Clusters which were already recorded for the
+// hour are left unchanged, so that the hour is only recorded once by servers which run
+// concurrently.
+func (r *Recorder) RecordHour(ctx context.Context, hour time.Time) error {
+	projIDs, err := r.repo.Project().ListProjectIDs()
+
+	if err != nil {
+		return fmt.Errorf("error listing projects: %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for _, projID := range projIDs {
+		clusters, err := r.repo.Cluster().ListClustersByProjectID(projID)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("project %d: %w", projID, err))
+			continue
+		}
+
+		for _, cluster := range clusters {
+			if err := r.recordCluster(ctx, cluster, hour); err != nil {
+				errs = append(errs, fmt.Errorf("cluster %d: %w", cluster.ID, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error recording costs of %d clusters: %v", len(errs), errs)
+	}
+
+	return nil
+}
+
+func (r *Recorder) recordCluster(ctx context.Context, cluster *models.Cluster, hour time.Time) error {
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Cluster:           cluster,
+		Repo:              r.repo,
+		DigitalOceanOAuth: r.doConf,
+		Timeout:           30 * time.Second,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	nodeList, err := agent.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	podList, err := agent.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+
+	if err != nil {
+		return fmt.Errorf("error listing pods: %w", err)
+	}
+
+	records := Allocate(nodeList.Items, podList.Items)
+
+	for _, record := range records {
+		record.ProjectID = cluster.ProjectID
+		record.ClusterID = cluster.ID
+		record.Hour = hour
+	}
+
+	return r.repo.CostRecord().CreateCostRecords(records)
+}
@@ -0,0 +1,163 @@
+package cost
+
+import (
+	"sort"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/usage"
+)
+
+// Summarize adds up the recorded costs of each namespace, or of each release if grouped by
+// release, from the most to the least expensive
+func Summarize(records []*models.CostRecord, groupBy types.CostGroupBy) *types.ListCostsResponse {
+	type key struct {
+		namespace, releaseName string
+	}
+
+	costs := make(map[key]*types.Cost)
+	res := &types.ListCostsResponse{
+		Costs: make([]*types.Cost, 0),
+	}
+
+	for _, record := range records {
+		k := key{namespace: record.Namespace}
+
+		if groupBy == types.CostGroupByRelease {
+			k.releaseName = record.ReleaseName
+		}
+
+		cost, ok := costs[k]
+
+		if !ok {
+			cost = &types.Cost{
+				Namespace:   k.namespace,
+				ReleaseName: k.releaseName,
+			}
+
+			costs[k] = cost
+			res.Costs = append(res.Costs, cost)
+		}
+
+		cost.Cost += record.Cost
+		cost.CPUCoreHours += record.CPUCores
+		cost.MemoryGiBHours += record.MemoryGiB
+		res.Total += record.Cost
+	}
+
+	sort.SliceStable(res.Costs, func(i, j int) bool {
+		if res.Costs[i].Cost != res.Costs[j].Cost {
+			return res.Costs[i].Cost > res.Costs[j].Cost
+		}
+
+		if res.Costs[i].Namespace != res.Costs[j].Namespace {
+			return res.Costs[i].Namespace < res.Costs[j].Namespace
+		}
+
+		return res.Costs[i].ReleaseName < res.Costs[j].ReleaseName
+	})
+
+	return res
+}
+
+// GetHistory adds up the recorded costs of each hour, optionally only for a namespace or a
+// release in a namespace. Records are expected to be ordered by hour.
+func GetHistory(records []*models.CostRecord, namespace, releaseName string) *types.GetCostHistoryResponse {
+	res := &types.GetCostHistoryResponse{
+		Points: make([]*types.CostHistoryPoint, 0),
+	}
+
+	var last *types.CostHistoryPoint
+
+	for _, record := range records {
+		if namespace != "" && record.Namespace != namespace {
+			continue
+		}
+
+		if releaseName != "" && record.ReleaseName != releaseName {
+			continue
+		}
+
+		if last == nil || !last.Hour.Equal(record.Hour) {
+			last = &types.CostHistoryPoint{Hour: record.Hour}
+			res.Points = append(res.Points, last)
+		}
+
+		last.Cost += record.Cost
+		res.Total += record.Cost
+	}
+
+	return res
+}
+
+// RankPreviewEnvironments returns the most expensive deployments of preview environments, based
+// on the recorded costs of their namespaces
+func RankPreviewEnvironments(
+	records []*models.CostRecord,
+	depls []*models.Deployment,
+	envs map[uint]*models.Environment,
+	limit uint,
+) types.ListPreviewEnvironmentCostsResponse {
+	namespaceCosts := make(map[string]float64)
+
+	for _, record := range records {
+		namespaceCosts[record.Namespace] += record.Cost
+	}
+
+	res := make(types.ListPreviewEnvironmentCostsResponse, 0)
+
+	for _, depl := range depls {
+		cost, ok := namespaceCosts[depl.Namespace]
+
+		if !ok {
+			continue
+		}
+
+		previewCost := &types.PreviewEnvironmentCost{
+			DeploymentID:  depl.ID,
+			EnvironmentID: depl.EnvironmentID,
+			Namespace:     depl.Namespace,
+			RepoOwner:     depl.RepoOwner,
+			RepoName:      depl.RepoName,
+			PRName:        depl.PRName,
+			PullRequestID: depl.PullRequestID,
+			Status:        depl.Status,
+			Cost:          cost,
+		}
+
+		if env, ok := envs[depl.EnvironmentID]; ok && previewCost.RepoOwner == "" {
+			previewCost.RepoOwner = env.GitRepoOwner
+			previewCost.RepoName = env.GitRepoName
+		}
+
+		res = append(res, previewCost)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Cost > res[j].Cost
+	})
+
+	if limit > 0 && uint(len(res)) > limit {
+		res = res[:limit]
+	}
+
+	return res
+}
+
+// GetPeriod returns the period of a request, which defaults to the start of the current
+// billing period until now
+func GetPeriod(from, to *time.Time, now time.Time) (time.Time, time.Time) {
+	start := usage.GetBillingPeriod(now)
+	end := now
+
+	if from != nil {
+		start = *from
+	}
+
+	if to != nil {
+		end = *to
+	}
+
+	return start, end
+}
@@ -0,0 +1,64 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+var testHour = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+var testRecords = []*models.CostRecord{
+	{Namespace: "default", ReleaseName: "web", Hour: testHour, Cost: 1},
+	{Namespace: "default", ReleaseName: "worker", Hour: testHour, Cost: 2},
+	{Namespace: "pr-1", ReleaseName: "web", Hour: testHour, Cost: 5},
+	{Namespace: "default", ReleaseName: "web", Hour: testHour.Add(time.Hour), Cost: 1},
+	{Namespace: "pr-2", ReleaseName: "web", Hour: testHour.Add(time.Hour), Cost: 0.5},
+}
+
+func TestSummarize(t *testing.T) {
+	res := Summarize(testRecords, types.CostGroupByNamespace)
+
+	if res.Total != 9.5 || len(res.Costs) != 3 {
+		t.Fatalf("expected 3 namespaces costing 9.5, got %d costing %f", len(res.Costs), res.Total)
+	}
+
+	if res.Costs[0].Namespace != "pr-1" || res.Costs[1].Namespace != "default" || res.Costs[1].Cost != 4 {
+		t.Errorf("expected pr-1 and then default costing 4, got %s and %s costing %f",
+			res.Costs[0].Namespace, res.Costs[1].Namespace, res.Costs[1].Cost)
+	}
+
+	res = Summarize(testRecords, types.CostGroupByRelease)
+
+	if len(res.Costs) != 4 || res.Costs[1].ReleaseName != "web" || res.Costs[1].Cost != 2 {
+		t.Errorf("expected 4 releases with default/web costing 2, got %d releases", len(res.Costs))
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	res := GetHistory(testRecords, "default", "")
+
+	if len(res.Points) != 2 || res.Points[0].Cost != 3 || res.Points[1].Cost != 1 || res.Total != 4 {
+		t.Errorf("expected hours costing 3 and 1, got %v", res.Points)
+	}
+}
+
+func TestRankPreviewEnvironments(t *testing.T) {
+	depls := []*models.Deployment{
+		{EnvironmentID: 1, Namespace: "pr-1", PullRequestID: 1},
+		{EnvironmentID: 1, Namespace: "pr-2", PullRequestID: 2},
+		{EnvironmentID: 1, Namespace: "pr-3", PullRequestID: 3},
+	}
+
+	envs := map[uint]*models.Environment{
+		1: {GitRepoOwner: "porter-dev", GitRepoName: "porter"},
+	}
+
+	res := RankPreviewEnvironments(testRecords, depls, envs, 1)
+
+	if len(res) != 1 || res[0].Namespace != "pr-1" || res[0].Cost != 5 || res[0].RepoOwner != "porter-dev" {
+		t.Errorf("expected pr-1 of porter-dev costing 5, got %v", res)
+	}
+}
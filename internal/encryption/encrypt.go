@@ -37,8 +37,50 @@ func GenerateRandomBytes(n int) (string, error) {
 
 // Encrypt encrypts data using 256-bit AES-GCM.  This both hides the content of
 // the data and provides a check that it hasn't been altered. Output takes the
-// form nonce|ciphertext|tag where '|' indicates concatenation.
+// form header|nonce|ciphertext|tag where '|' indicates concatenation, and the
+// header contains the ID of the key.
 func Encrypt(plaintext []byte, key *[32]byte) (ciphertext []byte, err error) {
+	ciphertext, err = encrypt(plaintext, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keyHeader(key), ciphertext...), nil
+}
+
+// Decrypt decrypts data using 256-bit AES-GCM.  This both hides the content of
+// the data and provides a check that it hasn't been altered. Expects input
+// form header|nonce|ciphertext|tag, or nonce|ciphertext|tag for data encrypted
+// before key IDs were added.
+//
+// If the data was encrypted with a different key, it is decrypted with the
+// matching key registered through AddDecryptionKeys.
+func Decrypt(ciphertext []byte, key *[32]byte) (plaintext []byte, err error) {
+	if id, body, ok := parseKeyHeader(ciphertext); ok {
+		if k := lookupKey(id, key); k != nil {
+			if plaintext, err := decrypt(body, k); err == nil {
+				return plaintext, nil
+			}
+		}
+	}
+
+	// data without a key header is tried with every known key
+	plaintext, err = decrypt(ciphertext, key)
+
+	if err == nil {
+		return plaintext, nil
+	}
+
+	for _, k := range decryptionKeys() {
+		if plaintext, err := decrypt(ciphertext, k); err == nil {
+			return plaintext, nil
+		}
+	}
+
+	return nil, err
+}
+
+func encrypt(plaintext []byte, key *[32]byte) (ciphertext []byte, err error) {
 	block, err := aes.NewCipher(key[:])
 	if err != nil {
 		return nil, err
@@ -58,10 +100,7 @@ func Encrypt(plaintext []byte, key *[32]byte) (ciphertext []byte, err error) {
 	return gcm.Seal(nonce, nonce, plaintext, nil), nil
 }
 
-// Decrypt decrypts data using 256-bit AES-GCM.  This both hides the content of
-// the data and provides a check that it hasn't been altered. Expects input
-// form nonce|ciphertext|tag where '|' indicates concatenation.
-func Decrypt(ciphertext []byte, key *[32]byte) (plaintext []byte, err error) {
+func decrypt(ciphertext []byte, key *[32]byte) (plaintext []byte, err error) {
 	block, err := aes.NewCipher(key[:])
 	if err != nil {
 		return nil, err
@@ -0,0 +1,91 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// keyHeaderMagic prefixes the key ID of a ciphertext
+var keyHeaderMagic = []byte{0x00, 'p', 'k', 0x01}
+
+const keyIDSize = 8
+
+var (
+	keysMu sync.RWMutex
+	keys   = make(map[string]*[32]byte)
+)
+
+// KeyID returns the ID of a key, which is stored alongside the data encrypted with it. The
+// ID is derived from a hash of the key, so it does not reveal the key.
+func KeyID(key *[32]byte) string {
+	return hex.EncodeToString(keyID(key))
+}
+
+// AddDecryptionKeys registers keys which may be used to decrypt data, but which are never
+// used to encrypt it. This allows the encryption key to be rotated without downtime: data
+// encrypted with a previous key can be read until it is re-encrypted with the new key.
+func AddDecryptionKeys(decryptionKeys ...*[32]byte) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	for _, key := range decryptionKeys {
+		keys[string(keyID(key))] = key
+	}
+}
+
+// HasKeyID returns true if the data was encrypted with the given key
+func HasKeyID(ciphertext []byte, key *[32]byte) bool {
+	id, _, ok := parseKeyHeader(ciphertext)
+
+	return ok && bytes.Equal(id, keyID(key))
+}
+
+func keyID(key *[32]byte) []byte {
+	sum := sha256.Sum256(key[:])
+
+	return sum[:keyIDSize]
+}
+
+func keyHeader(key *[32]byte) []byte {
+	header := make([]byte, 0, len(keyHeaderMagic)+keyIDSize)
+	header = append(header, keyHeaderMagic...)
+
+	return append(header, keyID(key)...)
+}
+
+func parseKeyHeader(ciphertext []byte) (id []byte, body []byte, ok bool) {
+	headerLen := len(keyHeaderMagic) + keyIDSize
+
+	if len(ciphertext) < headerLen || !bytes.Equal(ciphertext[:len(keyHeaderMagic)], keyHeaderMagic) {
+		return nil, nil, false
+	}
+
+	return ciphertext[len(keyHeaderMagic):headerLen], ciphertext[headerLen:], true
+}
+
+// lookupKey returns the key with the given ID, preferring the current key
+func lookupKey(id []byte, current *[32]byte) *[32]byte {
+	if bytes.Equal(id, keyID(current)) {
+		return current
+	}
+
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+
+	return keys[string(id)]
+}
+
+func decryptionKeys() []*[32]byte {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+
+	res := make([]*[32]byte, 0, len(keys))
+
+	for _, key := range keys {
+		res = append(res, key)
+	}
+
+	return res
+}
@@ -0,0 +1,70 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const passphraseSaltSize = 16
+
+// EncryptWithPassphrase encrypts data with a key derived from a passphrase, for data which
+// leaves the instance and so cannot be encrypted with the encryption key. Output takes the
+// form salt|ciphertext, where the ciphertext is the output of Encrypt.
+func EncryptWithPassphrase(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, passphraseSaltSize)
+
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	key, err := passphraseKey(passphrase, salt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := Encrypt(plaintext, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(salt, ciphertext...), nil
+}
+
+// DecryptWithPassphrase decrypts data encrypted with EncryptWithPassphrase
+func DecryptWithPassphrase(ciphertext []byte, passphrase string) ([]byte, error) {
+	if len(ciphertext) < passphraseSaltSize {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	key, err := passphraseKey(passphrase, ciphertext[:passphraseSaltSize])
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, body, ok := parseKeyHeader(ciphertext[passphraseSaltSize:])
+
+	if !ok {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	return decrypt(body, key)
+}
+
+func passphraseKey(passphrase string, salt []byte) (*[32]byte, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := [32]byte{}
+	copy(key[:], derived)
+
+	return &key, nil
+}
@@ -0,0 +1,122 @@
+package freeze
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// MaxDuration is the longest duration of the occurrences of a freeze window with a cron
+// schedule. Longer freezes can be defined as date ranges.
+const MaxDuration = 7 * 24 * time.Hour
+
+// Validate returns an error if the freeze window has neither a valid cron schedule and
+// duration, nor a valid date range
+func Validate(window *models.FreezeWindow) error {
+	if window.Schedule != "" {
+		if window.StartsAt != nil || window.EndsAt != nil {
+			return fmt.Errorf("a freeze window cannot have both a schedule and a date range")
+		}
+
+		if _, err := ParseSchedule(window.Schedule); err != nil {
+			return err
+		}
+
+		duration := time.Duration(window.DurationMinutes) * time.Minute
+
+		if duration <= 0 || duration > MaxDuration {
+			return fmt.Errorf("the duration of a scheduled freeze window must be between 1 minute and %s", MaxDuration)
+		}
+
+		if _, err := time.LoadLocation(window.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", window.Timezone, err)
+		}
+
+		return nil
+	}
+
+	if window.StartsAt == nil || window.EndsAt == nil {
+		return fmt.Errorf("a freeze window must have either a schedule or a start and an end")
+	}
+
+	if !window.EndsAt.After(*window.StartsAt) {
+		return fmt.Errorf("the end of a freeze window must be after its start")
+	}
+
+	return nil
+}
+
+// Active returns true if the freeze window is active at t, and the time at which the current
+// freeze ends
+func Active(window *models.FreezeWindow, t time.Time) (bool, time.Time, error) {
+	if window.Schedule == "" {
+		if window.StartsAt == nil || window.EndsAt == nil {
+			return false, time.Time{}, nil
+		}
+
+		return !t.Before(*window.StartsAt) && t.Before(*window.EndsAt), *window.EndsAt, nil
+	}
+
+	schedule, err := ParseSchedule(window.Schedule)
+
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	loc, err := time.LoadLocation(window.Timezone)
+
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	duration := time.Duration(window.DurationMinutes) * time.Minute
+
+	if duration > MaxDuration {
+		duration = MaxDuration
+	}
+
+	// the window is active if the schedule fired within the last duration. The latest firing
+	// is found first, since it ends the latest.
+	t = t.In(loc)
+
+	for start := t.Truncate(time.Minute); t.Sub(start) < duration; start = start.Add(-time.Minute) {
+		if schedule.Matches(start) {
+			return true, start.Add(duration), nil
+		}
+	}
+
+	return false, time.Time{}, nil
+}
+
+// ActiveWindow returns the freeze window which applies to the namespace of the cluster and is
+// active at t, and the time at which it ends. If several windows are active, the window which
+// ends the latest is returned. It returns nil if no window is active.
+func ActiveWindow(
+	windows []*models.FreezeWindow,
+	clusterID uint,
+	namespace string,
+	t time.Time,
+) (*models.FreezeWindow, time.Time, error) {
+	var res *models.FreezeWindow
+	var resEnd time.Time
+
+	for _, window := range windows {
+		if !window.Applies(clusterID, namespace) {
+			continue
+		}
+
+		active, end, err := Active(window, t)
+
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("error evaluating freeze window %d: %w", window.ID, err)
+		}
+
+		if active && (res == nil || end.After(resEnd)) {
+			res = window
+			resEnd = end
+		}
+	}
+
+	return res, resEnd, nil
+}
@@ -0,0 +1,134 @@
+package freeze_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/freeze"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestScheduleMatches(t *testing.T) {
+	tests := []struct {
+		expr    string
+		t       time.Time
+		matches bool
+	}{
+		// 2022-12-02 is a Friday
+		{"0 18 * * FRI", time.Date(2022, 12, 2, 18, 0, 0, 0, time.UTC), true},
+		{"0 18 * * FRI", time.Date(2022, 12, 2, 18, 1, 0, 0, time.UTC), false},
+		{"0 18 * * FRI", time.Date(2022, 12, 1, 18, 0, 0, 0, time.UTC), false},
+		{"*/15 9-17 * * 1-5", time.Date(2022, 12, 1, 9, 45, 0, 0, time.UTC), true},
+		{"*/15 9-17 * * 1-5", time.Date(2022, 12, 3, 9, 45, 0, 0, time.UTC), false},
+		{"0 0 24-31 dec *", time.Date(2022, 12, 25, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 24-31 dec *", time.Date(2022, 11, 25, 0, 0, 0, 0, time.UTC), false},
+		// 7 is Sunday
+		{"30 12 * * 7", time.Date(2022, 12, 4, 12, 30, 0, 0, time.UTC), true},
+		// either the day of month or the day of week matches when both are restricted
+		{"0 0 1 * MON", time.Date(2022, 12, 5, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 1 * MON", time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 1 * MON", time.Date(2022, 12, 2, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, test := range tests {
+		schedule, err := freeze.ParseSchedule(test.expr)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.expr, err)
+		}
+
+		if matches := schedule.Matches(test.t); matches != test.matches {
+			t.Errorf("%s at %s: expected %t, got %t", test.expr, test.t, test.matches, matches)
+		}
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "* * * foo *"} {
+		if _, err := freeze.ParseSchedule(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+func TestActiveWindow(t *testing.T) {
+	date := func(day, hour int) *time.Time {
+		res := time.Date(2022, 12, day, hour, 0, 0, 0, time.UTC)
+		return &res
+	}
+
+	windows := []*models.FreezeWindow{
+		// every weekend, from Friday 18:00 to Monday 08:00
+		{Model: gorm.Model{ID: 1}, ClusterID: 1, Schedule: "0 18 * * FRI", DurationMinutes: 62 * 60},
+		{Model: gorm.Model{ID: 2}, ClusterID: 1, Namespaces: "production", StartsAt: date(20, 0), EndsAt: date(27, 0)},
+		{Model: gorm.Model{ID: 3}, ClusterID: 2, StartsAt: date(1, 0), EndsAt: date(31, 0)},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		t         time.Time
+		windowID  uint
+		end       *time.Time
+	}{
+		{"weekday", "default", *date(1, 12), 0, nil},
+		{"friday evening", "default", *date(2, 19), 1, date(5, 8)},
+		{"sunday", "default", *date(4, 12), 1, date(5, 8)},
+		{"monday morning", "default", *date(5, 8), 0, nil},
+		{"date range in another namespace", "default", *date(21, 12), 0, nil},
+		{"date range", "production", *date(21, 12), 2, date(27, 0)},
+		// the window which ends the latest is returned
+		{"overlapping windows", "production", *date(23, 19), 2, date(27, 0)},
+	}
+
+	for _, test := range tests {
+		window, end, err := freeze.ActiveWindow(windows, 1, test.namespace, test.t)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if test.windowID == 0 {
+			if window != nil {
+				t.Errorf("%s: expected no active window, got %d", test.name, window.ID)
+			}
+
+			continue
+		}
+
+		if window == nil || window.ID != test.windowID {
+			t.Errorf("%s: expected window %d to be active, got %v", test.name, test.windowID, window)
+			continue
+		}
+
+		if !end.Equal(*test.end) {
+			t.Errorf("%s: expected the window to end at %s, got %s", test.name, test.end, end)
+		}
+	}
+}
+
+func TestActiveTimezone(t *testing.T) {
+	window := &models.FreezeWindow{Schedule: "0 9 * * *", DurationMinutes: 60, Timezone: "America/New_York"}
+
+	// 9:30 in New York is 14:30 UTC in December
+	active, _, err := freeze.Active(window, time.Date(2022, 12, 1, 14, 30, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !active {
+		t.Errorf("expected the window to be active")
+	}
+
+	active, _, err = freeze.Active(window, time.Date(2022, 12, 1, 9, 30, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if active {
+		t.Errorf("expected the window to be inactive")
+	}
+}
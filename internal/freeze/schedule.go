@@ -0,0 +1,169 @@
+package freeze
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression with the standard five fields: minute, hour, day of
+// month, month and day of week
+type Schedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+
+	// cron matches a day if either the day of month or the day of week matches, when both
+	// fields are restricted
+	domRestricted bool
+	dowRestricted bool
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// both 0 and 7 are Sunday
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseSchedule parses a cron expression such as "0 18 * * FRI". Each field accepts "*",
+// numbers, ranges ("1-5"), steps ("*/15", "0-30/10") and comma-separated lists of these.
+func ParseSchedule(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, found %d", expr, len(fields))
+	}
+
+	res := &Schedule{
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+	}
+
+	var err error
+
+	if res.minutes, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+
+	if res.hours, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+
+	if res.daysOfMonth, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+
+	if res.months, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+
+	if res.daysOfWeek, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+
+	if res.daysOfWeek[7] {
+		res.daysOfWeek[0] = true
+	}
+
+	return res, nil
+}
+
+// Matches returns true if the schedule fires at the minute of t, in the location of t
+func (s *Schedule) Matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[int(t.Month())] {
+		return false
+	}
+
+	domMatches := s.daysOfMonth[t.Day()]
+	dowMatches := s.daysOfWeek[int(t.Weekday())]
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
+
+func parseField(expr string, f field) (map[int]bool, error) {
+	res := make(map[int]bool)
+
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %s field %q", f.name, part)
+			}
+		}
+
+		start, end := f.min, f.max
+
+		if rangeExpr != "*" {
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+
+			var err error
+
+			if start, err = f.parseValue(bounds[0]); err != nil {
+				return nil, err
+			}
+
+			end = start
+
+			if len(bounds) == 2 {
+				if end, err = f.parseValue(bounds[1]); err != nil {
+					return nil, err
+				}
+			} else if step != 1 {
+				// "5/15" means every 15 from 5
+				end = f.max
+			}
+
+			if end < start {
+				return nil, fmt.Errorf("invalid range in %s field %q", f.name, part)
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			res[i] = true
+		}
+	}
+
+	return res, nil
+}
+
+func (f field) parseValue(str string) (int, error) {
+	if val, ok := f.names[strings.ToLower(str)]; ok {
+		return val, nil
+	}
+
+	val, err := strconv.Atoi(str)
+
+	if err != nil || val < f.min || val > f.max {
+		return 0, fmt.Errorf("invalid %s %q, must be between %d and %d", f.name, str, f.min, f.max)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,239 @@
+package gitops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+)
+
+// Target is the location in a git repository which manifests are exported to
+type Target struct {
+	Owner  string
+	Name   string
+	Branch string
+
+	// the directory which the manifests are written to, which is fully managed by the export:
+	// files in the directory which aren't exported are deleted
+	Path string
+
+	// if set, the manifests are committed to a new branch and a pull request is opened against
+	// Branch, instead of committing to Branch directly
+	PullRequest bool
+}
+
+// Commit describes a commit of exported manifests
+type Commit struct {
+	Message string
+
+	// the branch which a pull request is opened from, if the target uses pull requests
+	HeadBranch string
+
+	PullRequestTitle string
+	PullRequestBody  string
+}
+
+// Result is the result of an export
+type Result struct {
+	// the sha of the commit, which is empty if the exported manifests were already committed
+	CommitSHA string
+
+	// the url of the pull request which the commit was opened in, if the target uses pull
+	// requests
+	PullRequestURL string
+}
+
+// ManifestFiles returns the files which the manifests of a set of releases are exported to,
+// keyed by their path in the repository. The manifests of each release are written to a
+// single file named after the release.
+func ManifestFiles(dir string, manifests map[string]string) map[string]string {
+	res := make(map[string]string)
+
+	for name, manifest := range manifests {
+		res[path.Join(cleanDir(dir), name+".yaml")] = manifest
+	}
+
+	return res
+}
+
+// Export commits a set of files to the directory of a target, replacing the files which are
+// already in the directory
+func Export(client *github.Client, target *Target, files map[string]string, commit *Commit) (*Result, error) {
+	ctx := context.Background()
+
+	baseRef, _, err := client.Git.GetRef(ctx, target.Owner, target.Name, "heads/"+target.Branch)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading branch %s: %w", target.Branch, err)
+	}
+
+	baseCommit, _, err := client.Git.GetCommit(ctx, target.Owner, target.Name, baseRef.GetObject().GetSHA())
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading the latest commit of branch %s: %w", target.Branch, err)
+	}
+
+	baseTree, _, err := client.Git.GetTree(ctx, target.Owner, target.Name, baseCommit.GetTree().GetSHA(), true)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading the files of branch %s: %w", target.Branch, err)
+	}
+
+	if baseTree.GetTruncated() {
+		return nil, fmt.Errorf("the repository has too many files to be read by the github api")
+	}
+
+	tree, _, err := client.Git.CreateTree(
+		ctx,
+		target.Owner,
+		target.Name,
+		baseCommit.GetTree().GetSHA(),
+		treeEntries(target.Path, baseTree.Entries, files),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("error writing the exported files: %w", err)
+	}
+
+	// the manifests haven't changed since the last export
+	if tree.GetSHA() == baseCommit.GetTree().GetSHA() {
+		return &Result{}, nil
+	}
+
+	newCommit, _, err := client.Git.CreateCommit(ctx, target.Owner, target.Name, &github.Commit{
+		Message: github.String(commit.Message),
+		Tree:    tree,
+		Parents: []*github.Commit{baseCommit},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating commit: %w", err)
+	}
+
+	res := &Result{
+		CommitSHA: newCommit.GetSHA(),
+	}
+
+	if !target.PullRequest {
+		_, _, err = client.Git.UpdateRef(ctx, target.Owner, target.Name, &github.Reference{
+			Ref:    github.String("refs/heads/" + target.Branch),
+			Object: &github.GitObject{SHA: newCommit.SHA},
+		}, false)
+
+		if err != nil {
+			return nil, fmt.Errorf("error updating branch %s: %w", target.Branch, err)
+		}
+
+		return res, nil
+	}
+
+	res.PullRequestURL, err = openPullRequest(ctx, client, target, commit, newCommit.GetSHA())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// openPullRequest points the head branch of a commit to the commit, and opens a pull request
+// from the head branch if one isn't open already
+func openPullRequest(ctx context.Context, client *github.Client, target *Target, commit *Commit, sha string) (string, error) {
+	headRef := &github.Reference{
+		Ref:    github.String("refs/heads/" + commit.HeadBranch),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	}
+
+	if _, resp, err := client.Git.GetRef(ctx, target.Owner, target.Name, "heads/"+commit.HeadBranch); err == nil {
+		// the head branch belongs to an earlier export, whose commit is replaced
+		if _, _, err := client.Git.UpdateRef(ctx, target.Owner, target.Name, headRef, true); err != nil {
+			return "", fmt.Errorf("error updating branch %s: %w", commit.HeadBranch, err)
+		}
+	} else if resp != nil && resp.StatusCode == http.StatusNotFound {
+		if _, _, err := client.Git.CreateRef(ctx, target.Owner, target.Name, headRef); err != nil {
+			return "", fmt.Errorf("error creating branch %s: %w", commit.HeadBranch, err)
+		}
+	} else {
+		return "", fmt.Errorf("error reading branch %s: %w", commit.HeadBranch, err)
+	}
+
+	prs, _, err := client.PullRequests.List(ctx, target.Owner, target.Name, &github.PullRequestListOptions{
+		State: "open",
+		Head:  target.Owner + ":" + commit.HeadBranch,
+		Base:  target.Branch,
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error listing pull requests: %w", err)
+	}
+
+	if len(prs) > 0 {
+		return prs[0].GetHTMLURL(), nil
+	}
+
+	pr, _, err := client.PullRequests.Create(ctx, target.Owner, target.Name, &github.NewPullRequest{
+		Title: github.String(commit.PullRequestTitle),
+		Body:  github.String(commit.PullRequestBody),
+		Base:  github.String(target.Branch),
+		Head:  github.String(commit.HeadBranch),
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error opening pull request: %w", err)
+	}
+
+	return pr.GetHTMLURL(), nil
+}
+
+// treeEntries returns the entries of a tree which writes the files to the directory, and
+// deletes the files of the directory which aren't written
+func treeEntries(dir string, existing []*github.TreeEntry, files map[string]string) []*github.TreeEntry {
+	prefix := cleanDir(dir)
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	res := make([]*github.TreeEntry, 0)
+
+	for _, entry := range existing {
+		if entry.GetType() != "blob" || !strings.HasPrefix(entry.GetPath(), prefix) {
+			continue
+		}
+
+		if _, ok := files[entry.GetPath()]; !ok {
+			res = append(res, &github.TreeEntry{
+				Path: entry.Path,
+				Mode: entry.Mode,
+				Type: github.String("blob"),
+			})
+		}
+	}
+
+	paths := make([]string, 0, len(files))
+
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+
+	sort.Strings(paths)
+
+	for _, filePath := range paths {
+		res = append(res, &github.TreeEntry{
+			Path:    github.String(filePath),
+			Mode:    github.String("100644"),
+			Type:    github.String("blob"),
+			Content: github.String(files[filePath]),
+		})
+	}
+
+	return res
+}
+
+func cleanDir(dir string) string {
+	return strings.Trim(path.Clean("/"+dir), "/")
+}
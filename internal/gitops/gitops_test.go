@@ -0,0 +1,40 @@
+package gitops
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+)
+
+func TestManifestFiles(t *testing.T) {
+	files := ManifestFiles("/stacks/web/", map[string]string{"api": "kind: Deployment"})
+
+	if files["stacks/web/api.yaml"] != "kind: Deployment" || len(files) != 1 {
+		t.Errorf("expected the manifest of api to be written to stacks/web/api.yaml, got %v", files)
+	}
+}
+
+func TestTreeEntries(t *testing.T) {
+	existing := []*github.TreeEntry{
+		{Path: github.String("README.md"), Type: github.String("blob"), Mode: github.String("100644")},
+		{Path: github.String("stacks/web"), Type: github.String("tree"), Mode: github.String("040000")},
+		{Path: github.String("stacks/web/api.yaml"), Type: github.String("blob"), Mode: github.String("100644")},
+		{Path: github.String("stacks/web/worker.yaml"), Type: github.String("blob"), Mode: github.String("100644")},
+		{Path: github.String("stacks/web-staging/api.yaml"), Type: github.String("blob"), Mode: github.String("100644")},
+	}
+
+	entries := treeEntries("stacks/web", existing, map[string]string{"stacks/web/api.yaml": "kind: Deployment"})
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 tree entries, got %v", entries)
+	}
+
+	// files of the directory which aren't exported anymore are deleted
+	if entries[0].GetPath() != "stacks/web/worker.yaml" || entries[0].SHA != nil || entries[0].Content != nil {
+		t.Errorf("expected stacks/web/worker.yaml to be deleted, got %v", entries[0])
+	}
+
+	if entries[1].GetPath() != "stacks/web/api.yaml" || entries[1].GetContent() != "kind: Deployment" {
+		t.Errorf("expected stacks/web/api.yaml to be written, got %v", entries[1])
+	}
+}
@@ -23,12 +23,33 @@ import (
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Agent is a Helm agent for performing helm operations
 type Agent struct {
 	ActionConfig *action.Configuration
 	K8sAgent     *kubernetes.Agent
+
+	// the context which helm operations are traced under
+	ctx context.Context
+}
+
+// WithContext returns a copy of the agent whose helm operations are traced as children of the
+// span in the context, such as the span of the request which the agent was created for
+func (a *Agent) WithContext(ctx context.Context) *Agent {
+	res := *a
+	res.ctx = ctx
+
+	return &res
+}
+
+func (a *Agent) startSpan(name string, attrs ...attribute.KeyValue) trace.Span {
+	_, span := tracing.StartSpan(a.ctx, name, attrs...)
+
+	return span
 }
 
 // ListReleases lists releases based on a ListFilter
@@ -198,6 +219,95 @@ func (a *Agent) UpgradeReleaseByValues(
 	conf *UpgradeReleaseConfig,
 	doAuth *oauth2.Config,
 	disablePullSecretsInjection bool,
+) (*release.Release, error) {
+	span := a.startSpan("helm.upgrade", attribute.String("helm.release", conf.Name))
+
+	res, err := a.upgradeReleaseByValues(conf, doAuth, disablePullSecretsInjection)
+
+	if res != nil {
+		span.SetAttributes(attribute.Int("helm.revision", res.Version))
+	}
+
+	tracing.EndSpan(span, err)
+
+	return res, err
+}
+
+// TemplateUpgrade renders the release which an upgrade by values would produce, equivalent to
+// `helm upgrade --dry-run`, without changing the release
+func (a *Agent) TemplateUpgrade(
+	conf *UpgradeReleaseConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
+) (*release.Release, error) {
+	span := a.startSpan("helm.template_upgrade", attribute.String("helm.release", conf.Name))
+
+	res, err := a.templateUpgrade(conf, doAuth, disablePullSecretsInjection)
+
+	tracing.EndSpan(span, err)
+
+	return res, err
+}
+
+func (a *Agent) templateUpgrade(
+	conf *UpgradeReleaseConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
+) (*release.Release, error) {
+	rel, err := a.GetRelease(conf.Name, 0, true)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get release to be upgraded: %v", err)
+	}
+
+	ch := rel.Chart
+
+	if conf.Chart != nil {
+		ch = conf.Chart
+	}
+
+	cmd := action.NewUpgrade(a.ActionConfig)
+	cmd.Namespace = rel.Namespace
+	cmd.DryRun = true
+
+	// the manifests are post-rendered in the same way as an upgrade, so that they can be
+	// compared with the manifest of the current release
+	cmd.PostRenderer, err = NewPorterPostrenderer(
+		conf.Cluster,
+		conf.Repo,
+		a.K8sAgent,
+		rel.Namespace,
+		conf.Registries,
+		doAuth,
+		disablePullSecretsInjection,
+		conf.Values,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.StackName != "" && conf.StackRevision > 0 {
+		conf.Values["stack"] = map[string]interface{}{
+			"enabled":  true,
+			"name":     conf.StackName,
+			"revision": conf.StackRevision,
+		}
+	}
+
+	res, err := cmd.Run(conf.Name, ch, conf.Values)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not render upgrade: %w", err)
+	}
+
+	return res, nil
+}
+
+func (a *Agent) upgradeReleaseByValues(
+	conf *UpgradeReleaseConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
 ) (*release.Release, error) {
 	// grab the latest release
 	rel, err := a.GetRelease(conf.Name, 0, true)
@@ -223,6 +333,7 @@ func (a *Agent) UpgradeReleaseByValues(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.Values,
 	)
 
 	if err != nil {
@@ -404,6 +515,24 @@ func (a *Agent) InstallChart(
 	conf *InstallChartConfig,
 	doAuth *oauth2.Config,
 	disablePullSecretsInjection bool,
+) (*release.Release, error) {
+	span := a.startSpan(
+		"helm.install",
+		attribute.String("helm.release", conf.Name),
+		attribute.String("helm.namespace", conf.Namespace),
+	)
+
+	res, err := a.installChart(conf, doAuth, disablePullSecretsInjection)
+
+	tracing.EndSpan(span, err)
+
+	return res, err
+}
+
+func (a *Agent) installChart(
+	conf *InstallChartConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
 ) (*release.Release, error) {
 	cmd := action.NewInstall(a.ActionConfig)
 
@@ -429,6 +558,7 @@ func (a *Agent) InstallChart(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.Values,
 	)
 
 	if err != nil {
@@ -445,12 +575,80 @@ func (a *Agent) InstallChart(
 	return cmd.Run(conf.Chart, conf.Values)
 }
 
+// TemplateInstall renders the release which installing a chart would produce, equivalent to
+// `helm install --dry-run`, without installing the chart. The release may already exist.
+func (a *Agent) TemplateInstall(
+	conf *InstallChartConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
+) (*release.Release, error) {
+	span := a.startSpan(
+		"helm.template_install",
+		attribute.String("helm.release", conf.Name),
+		attribute.String("helm.namespace", conf.Namespace),
+	)
+
+	res, err := a.templateInstall(conf, doAuth, disablePullSecretsInjection)
+
+	tracing.EndSpan(span, err)
+
+	return res, err
+}
+
+func (a *Agent) templateInstall(
+	conf *InstallChartConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
+) (*release.Release, error) {
+	cmd := action.NewInstall(a.ActionConfig)
+
+	cmd.ReleaseName = conf.Name
+	cmd.Namespace = conf.Namespace
+	cmd.DryRun = true
+	cmd.Replace = true
+
+	if err := checkIfInstallable(conf.Chart); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	cmd.PostRenderer, err = NewPorterPostrenderer(
+		conf.Cluster,
+		conf.Repo,
+		a.K8sAgent,
+		conf.Namespace,
+		conf.Registries,
+		doAuth,
+		disablePullSecretsInjection,
+		conf.Values,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cmd.Run(conf.Chart, conf.Values)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not render install: %w", err)
+	}
+
+	return res, nil
+}
+
 // UninstallChart uninstalls a chart
 func (a *Agent) UninstallChart(
 	name string,
 ) (*release.UninstallReleaseResponse, error) {
+	span := a.startSpan("helm.uninstall", attribute.String("helm.release", name))
+
 	cmd := action.NewUninstall(a.ActionConfig)
-	return cmd.Run(name)
+	res, err := cmd.Run(name)
+
+	tracing.EndSpan(span, err)
+
+	return res, err
 }
 
 // RollbackRelease rolls a release back to a specified revision/version
@@ -458,9 +656,19 @@ func (a *Agent) RollbackRelease(
 	name string,
 	version int,
 ) error {
+	span := a.startSpan(
+		"helm.rollback",
+		attribute.String("helm.release", name),
+		attribute.Int("helm.revision", version),
+	)
+
 	cmd := action.NewRollback(a.ActionConfig)
 	cmd.Version = version
-	return cmd.Run(name)
+	err := cmd.Run(name)
+
+	tracing.EndSpan(span, err)
+
+	return err
 }
 
 // ------------------------ Helm agent helper functions ------------------------ //
@@ -0,0 +1,52 @@
+package helm
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// DiffValues returns a unified diff of the YAML encoding of two sets of values, which is empty
+// if the values are equal
+func DiffValues(from, to map[string]interface{}) (string, error) {
+	fromBytes, err := yaml.Marshal(nonNilValues(from))
+
+	if err != nil {
+		return "", err
+	}
+
+	toBytes, err := yaml.Marshal(nonNilValues(to))
+
+	if err != nil {
+		return "", err
+	}
+
+	return diffText(string(fromBytes), string(toBytes), "values")
+}
+
+// DiffManifests returns a unified diff of two rendered release manifests, which is empty if the
+// manifests are equal
+func DiffManifests(from, to string) (string, error) {
+	return diffText(from, to, "manifest")
+}
+
+func diffText(from, to, name string) (string, error) {
+	if from == to {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "current/" + name,
+		ToFile:   "upgraded/" + name,
+		Context:  3,
+	})
+}
+
+func nonNilValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return make(map[string]interface{})
+	}
+
+	return values
+}
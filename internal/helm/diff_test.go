@@ -0,0 +1,52 @@
+package helm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+)
+
+func TestDiffValues(t *testing.T) {
+	from := map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "nginx",
+			"tag":        "1.22",
+		},
+		"replicaCount": 1,
+	}
+
+	to := map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "nginx",
+			"tag":        "1.23",
+		},
+		"replicaCount": 1,
+	}
+
+	diff, err := helm.DiffValues(from, to)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !strings.Contains(diff, "-  tag: \"1.22\"\n+  tag: \"1.23\"\n") {
+		t.Errorf("unexpected values diff:\n%s", diff)
+	}
+
+	if diff, err := helm.DiffValues(from, from); err != nil || diff != "" {
+		t.Errorf("expected no diff of equal values, got %q", diff)
+	}
+}
+
+func TestDiffManifests(t *testing.T) {
+	diff, err := helm.DiffManifests("kind: Service\nport: 80\n", "kind: Service\nport: 8080\n")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !strings.Contains(diff, "-port: 80\n+port: 8080\n") {
+		t.Errorf("unexpected manifest diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,207 @@
+package helm
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/porter-dev/porter/internal/models"
+	"gopkg.in/yaml.v2"
+)
+
+// ImageMirrorPostRenderer is a Helm post-renderer that rewrites the images of pod specs
+// to be pulled through the pull through caches of a cluster.
+type ImageMirrorPostRenderer struct {
+	// mirrors maps an upstream registry host to the prefix which replaces it
+	mirrors map[string]string
+
+	podSpecs  []resource
+	resources []resource
+}
+
+func NewImageMirrorPostRenderer(rules []*models.PullThroughCacheRule) *ImageMirrorPostRenderer {
+	mirrors := make(map[string]string)
+
+	for _, rule := range rules {
+		mirrors[NormalizeRegistryHost(rule.UpstreamRegistry)] = strings.TrimSuffix(rule.MirrorPrefix, "/")
+	}
+
+	return &ImageMirrorPostRenderer{
+		mirrors:   mirrors,
+		podSpecs:  make([]resource, 0),
+		resources: make([]resource, 0),
+	}
+}
+
+func (m *ImageMirrorPostRenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	m.resources, err = decodeRenderedManifests(renderedManifests)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// rewrite the images of manifests stored in porter configmaps as well, so that the
+	// images are consistent with the deployed resources
+	for i, res := range m.resources {
+		if kind, _ := res["kind"].(string); kind != "ConfigMap" {
+			continue
+		}
+
+		labelVal := getNestedResource(res, "metadata", "labels")
+
+		if labelVal == nil {
+			continue
+		}
+
+		if labelValStr, ok := labelVal["getporter.dev/manifest"].(string); !ok || labelValStr != "true" {
+			continue
+		}
+
+		data := getNestedResource(res, "data")
+		manifestDataStr, ok := data["manifest"].(string)
+
+		if !ok {
+			continue
+		}
+
+		mCopy := &ImageMirrorPostRenderer{
+			mirrors:   m.mirrors,
+			podSpecs:  make([]resource, 0),
+			resources: make([]resource, 0),
+		}
+
+		newData, err := mCopy.Run(bytes.NewBufferString(manifestDataStr))
+
+		if err != nil {
+			continue
+		}
+
+		data["manifest"] = string(newData.Bytes())
+
+		m.resources[i] = res
+	}
+
+	m.getPodSpecs(m.resources)
+	m.updatePodSpecs()
+
+	modifiedManifests = bytes.NewBuffer([]byte{})
+	encoder := yaml.NewEncoder(modifiedManifests)
+	defer encoder.Close()
+
+	for _, resource := range m.resources {
+		err = encoder.Encode(resource)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedManifests, nil
+}
+
+func (m *ImageMirrorPostRenderer) getPodSpecs(resources []resource) {
+	for _, res := range resources {
+		kind, ok := res["kind"].(string)
+
+		if !ok {
+			continue
+		}
+
+		// manifests of list type will have an items field, items should
+		// be recursively parsed
+		if itemsVal, isList := res["items"]; isList {
+			if items, ok := itemsVal.([]interface{}); ok {
+				resArr := make([]resource, 0)
+
+				for _, item := range items {
+					if arrVal, ok := item.(resource); ok {
+						resArr = append(resArr, arrVal)
+					}
+				}
+
+				m.getPodSpecs(resArr)
+			}
+
+			continue
+		}
+
+		if podSpec := getPodSpecFromResource(kind, res); podSpec != nil {
+			m.podSpecs = append(m.podSpecs, podSpec)
+		}
+	}
+}
+
+func (m *ImageMirrorPostRenderer) updatePodSpecs() {
+	for _, podSpec := range m.podSpecs {
+		for _, key := range []string{"initContainers", "containers"} {
+			containers, ok := podSpec[key].([]interface{})
+
+			if !ok {
+				continue
+			}
+
+			for _, container := range containers {
+				_container, ok := container.(resource)
+
+				if !ok {
+					continue
+				}
+
+				image, ok := _container["image"].(string)
+
+				if !ok {
+					continue
+				}
+
+				if newImage, rewritten := RewriteImage(image, m.mirrors); rewritten {
+					_container["image"] = newImage
+				}
+			}
+		}
+	}
+}
+
+// RewriteImage replaces the registry host of an image with its mirror prefix, keeping the
+// image path and its tag or digest. Images of registries without a mirror, or which cannot
+// be parsed, are returned unchanged.
+func RewriteImage(image string, mirrors map[string]string) (string, bool) {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return image, false
+	}
+
+	prefix, exists := mirrors[NormalizeRegistryHost(reference.Domain(named))]
+
+	if !exists || prefix == "" {
+		return image, false
+	}
+
+	res := prefix + "/" + reference.Path(named)
+
+	if tagged, ok := named.(reference.Tagged); ok {
+		res += ":" + tagged.Tag()
+	}
+
+	if digested, ok := named.(reference.Digested); ok {
+		res += "@" + digested.Digest().String()
+	}
+
+	return res, true
+}
+
+// NormalizeRegistryHost returns the canonical host of a registry, so that the aliases of
+// Docker Hub all refer to docker.io
+func NormalizeRegistryHost(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "/"))
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return "docker.io"
+	}
+
+	return host
+}
@@ -0,0 +1,81 @@
+package helm_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type rewriteImageTest struct {
+	image     string
+	expImage  string
+	rewritten bool
+}
+
+var mirrors = map[string]string{
+	"docker.io": "123456789012.dkr.ecr.us-east-1.amazonaws.com/docker-hub",
+	"quay.io":   "mirror.example.com/quay",
+}
+
+var rewriteImageTests = []rewriteImageTest{
+	{"nginx", "123456789012.dkr.ecr.us-east-1.amazonaws.com/docker-hub/library/nginx", true},
+	{"nginx:1.23", "123456789012.dkr.ecr.us-east-1.amazonaws.com/docker-hub/library/nginx:1.23", true},
+	{"index.docker.io/bitnami/redis:7.0", "123456789012.dkr.ecr.us-east-1.amazonaws.com/docker-hub/bitnami/redis:7.0", true},
+	{
+		"quay.io/jetstack/cert-manager-controller:v1.9.1@sha256:cd9bf3d48b6b8402a2a8b11953f9dc0275ba4beec14da47e31823a0515cde7e2",
+		"mirror.example.com/quay/jetstack/cert-manager-controller:v1.9.1@sha256:cd9bf3d48b6b8402a2a8b11953f9dc0275ba4beec14da47e31823a0515cde7e2",
+		true,
+	},
+	{"ghcr.io/porter-dev/porter:latest", "ghcr.io/porter-dev/porter:latest", false},
+	{"Invalid Image", "Invalid Image", false},
+}
+
+func TestRewriteImage(t *testing.T) {
+	for _, test := range rewriteImageTests {
+		image, rewritten := helm.RewriteImage(test.image, mirrors)
+
+		if image != test.expImage || rewritten != test.rewritten {
+			t.Errorf("rewriting %s: expected (%s, %t), got (%s, %t)",
+				test.image, test.expImage, test.rewritten, image, rewritten,
+			)
+		}
+	}
+}
+
+const mirrorManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: migrate
+        image: busybox:1.35
+      containers:
+      - name: web
+        image: ghcr.io/porter-dev/porter:latest
+`
+
+func TestImageMirrorPostRenderer(t *testing.T) {
+	renderer := helm.NewImageMirrorPostRenderer([]*models.PullThroughCacheRule{
+		{UpstreamRegistry: "registry-1.docker.io", MirrorPrefix: "mirror.example.com/docker-hub/"},
+	})
+
+	res, err := renderer.Run(bytes.NewBufferString(mirrorManifest))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !strings.Contains(res.String(), "image: mirror.example.com/docker-hub/library/busybox:1.35") {
+		t.Errorf("expected init container image to be rewritten, got:\n%s", res.String())
+	}
+
+	if !strings.Contains(res.String(), "image: ghcr.io/porter-dev/porter:latest") {
+		t.Errorf("expected container image to be unchanged, got:\n%s", res.String())
+	}
+}
@@ -22,6 +22,8 @@ import (
 )
 
 type PorterPostrenderer struct {
+	RolloutPostRenderer             *RolloutPostRenderer
+	ImageMirrorPostRenderer         *ImageMirrorPostRenderer
 	DockerSecretsPostRenderer       *DockerSecretsPostRenderer
 	EnvironmentVariablePostrenderer *EnvironmentVariablePostrenderer
 }
@@ -34,10 +36,24 @@ func NewPorterPostrenderer(
 	regs []*models.Registry,
 	doAuth *oauth2.Config,
 	disablePullSecretsInjection bool,
+	values map[string]interface{},
 ) (postrender.PostRenderer, error) {
+	var imageMirrorPostrenderer *ImageMirrorPostRenderer
 	var dockerSecretsPostrenderer *DockerSecretsPostRenderer
 	var err error
 
+	if cluster != nil && repo != nil {
+		rules, err := repo.PullThroughCacheRule().ListPullThroughCacheRulesByClusterID(cluster.ProjectID, cluster.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rules) > 0 {
+			imageMirrorPostrenderer = NewImageMirrorPostRenderer(rules)
+		}
+	}
+
 	if !disablePullSecretsInjection && cluster != nil && agent != nil && regs != nil && len(regs) > 0 {
 		dockerSecretsPostrenderer, err = NewDockerSecretsPostRenderer(cluster, repo, agent, namespace, regs, doAuth)
 
@@ -53,6 +69,8 @@ func NewPorterPostrenderer(
 	}
 
 	return &PorterPostrenderer{
+		RolloutPostRenderer:             NewRolloutPostRenderer(values),
+		ImageMirrorPostRenderer:         imageMirrorPostrenderer,
 		DockerSecretsPostRenderer:       dockerSecretsPostrenderer,
 		EnvironmentVariablePostrenderer: envVarPostrenderer,
 	}, nil
@@ -61,6 +79,25 @@ func NewPorterPostrenderer(
 func (p *PorterPostrenderer) Run(
 	renderedManifests *bytes.Buffer,
 ) (modifiedManifests *bytes.Buffer, err error) {
+	// deployments are converted to rollouts first, so that the pod specs of the rollouts are
+	// updated by the other post-renderers
+	if p.RolloutPostRenderer != nil {
+		renderedManifests, err = p.RolloutPostRenderer.Run(renderedManifests)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// images are rewritten first, so that pull secrets are added for the mirror registries
+	if p.ImageMirrorPostRenderer != nil {
+		renderedManifests, err = p.ImageMirrorPostRenderer.Run(renderedManifests)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if p.DockerSecretsPostRenderer != nil {
 		renderedManifests, err = p.DockerSecretsPostRenderer.Run(renderedManifests)
 
@@ -291,6 +328,14 @@ func (d *DockerSecretsPostRenderer) getRegistriesToLink(renderedManifests *bytes
 
 			reg, exists := d.registries[regName]
 
+			// docker hub registries may be linked to an entire namespace rather than a
+			// single repository
+			if !exists && strings.HasPrefix(regName, "index.docker.io/") {
+				if pathArr := strings.Split(regName, "/"); len(pathArr) > 2 {
+					reg, exists = d.registries[strings.Join(pathArr[:2], "/")]
+				}
+			}
+
 			if !exists {
 				continue
 			}
@@ -775,7 +820,7 @@ func getPodSpecFromResource(kind string, res resource) resource {
 	switch kind {
 	case "Pod":
 		return getNestedResource(res, "spec")
-	case "DaemonSet", "Deployment", "Job", "ReplicaSet", "ReplicationController", "StatefulSet":
+	case "DaemonSet", "Deployment", "Job", "ReplicaSet", "ReplicationController", "StatefulSet", "Rollout":
 		return getNestedResource(res, "spec", "template", "spec")
 	case "PodTemplate":
 		return getNestedResource(res, "template", "spec")
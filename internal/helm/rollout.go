@@ -0,0 +1,195 @@
+package helm
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v2"
+)
+
+const rolloutAPIVersion = "argoproj.io/v1alpha1"
+
+// RolloutPostRenderer is a Helm post-renderer that renders the Deployments of a release as
+// Argo Rollouts, so that the release is rolled out progressively. The Horizontal Pod
+// Autoscalers which target the Deployments are updated to target the Rollouts.
+//
+// The post-renderer is enabled by the rollout values of a release:
+//
+//	rollout:
+//	  enabled: true
+//	  # (optional) the strategy of the Rollouts, which is a canary with pauses by default
+//	  strategy:
+//	    blueGreen:
+//	      activeService: web
+type RolloutPostRenderer struct {
+	strategy map[string]interface{}
+}
+
+// NewRolloutPostRenderer returns a RolloutPostRenderer if the values of a release enable
+// Rollouts, and nil otherwise
+func NewRolloutPostRenderer(values map[string]interface{}) *RolloutPostRenderer {
+	rolloutValues, ok := values["rollout"].(map[string]interface{})
+
+	if !ok {
+		return nil
+	}
+
+	if enabled, _ := rolloutValues["enabled"].(bool); !enabled {
+		return nil
+	}
+
+	strategy, ok := rolloutValues["strategy"].(map[string]interface{})
+
+	if !ok || len(strategy) == 0 {
+		strategy = defaultRolloutStrategy()
+	}
+
+	return &RolloutPostRenderer{strategy}
+}
+
+// defaultRolloutStrategy shifts traffic to a new version in steps, waiting for the new version
+// to be promoted before the first step
+func defaultRolloutStrategy() map[string]interface{} {
+	return map[string]interface{}{
+		"canary": map[string]interface{}{
+			"steps": []interface{}{
+				map[string]interface{}{"setWeight": 20},
+				map[string]interface{}{"pause": map[string]interface{}{}},
+				map[string]interface{}{"setWeight": 50},
+				map[string]interface{}{"pause": map[string]interface{}{"duration": "5m"}},
+				map[string]interface{}{"setWeight": 80},
+				map[string]interface{}{"pause": map[string]interface{}{"duration": "5m"}},
+			},
+		},
+	}
+}
+
+func (p *RolloutPostRenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	resources, err := decodeRenderedManifests(renderedManifests)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts := make(map[string]bool)
+
+	for _, res := range resources {
+		kind, apiVersion, ok := getKindAndAPIVersion(res)
+
+		if !ok || kind != "Deployment" || apiVersion != "apps/v1" {
+			continue
+		}
+
+		name, _ := getResourceName(res)
+		rollouts[name] = true
+
+		p.toRollout(res, resources)
+	}
+
+	for _, res := range resources {
+		if kind, _, _ := getKindAndAPIVersion(res); kind != "HorizontalPodAutoscaler" {
+			continue
+		}
+
+		target := getNestedResource(res, "spec", "scaleTargetRef")
+
+		if target == nil {
+			continue
+		}
+
+		if name, _ := target["name"].(string); target["kind"] == "Deployment" && rollouts[name] {
+			target["apiVersion"] = rolloutAPIVersion
+			target["kind"] = "Rollout"
+		}
+	}
+
+	modifiedManifests = bytes.NewBuffer([]byte{})
+	encoder := yaml.NewEncoder(modifiedManifests)
+	defer encoder.Close()
+
+	for _, resource := range resources {
+		err = encoder.Encode(resource)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedManifests, nil
+}
+
+// toRollout converts a Deployment to a Rollout with the same pod template. The strategy of the
+// Deployment is replaced by the strategy of the post-renderer.
+func (p *RolloutPostRenderer) toRollout(res resource, resources []resource) {
+	res["apiVersion"] = rolloutAPIVersion
+	res["kind"] = "Rollout"
+
+	spec := getNestedResource(res, "spec")
+
+	if spec == nil {
+		return
+	}
+
+	delete(spec, "progressDeadlineSeconds")
+
+	strategy := make(resource)
+
+	for key, val := range p.strategy {
+		strategy[key] = val
+	}
+
+	// a blue-green rollout requires an active service, which defaults to the service which
+	// selects the pods of the Deployment
+	if blueGreen, ok := strategy["blueGreen"].(map[string]interface{}); ok && blueGreen["activeService"] == nil {
+		blueGreenCopy := make(map[string]interface{})
+
+		for key, val := range blueGreen {
+			blueGreenCopy[key] = val
+		}
+
+		if svcName := findSelectingService(getNestedResource(res, "spec", "template", "metadata", "labels"), resources); svcName != "" {
+			blueGreenCopy["activeService"] = svcName
+		}
+
+		strategy["blueGreen"] = blueGreenCopy
+	}
+
+	spec["strategy"] = strategy
+}
+
+// findSelectingService returns the name of the first service whose selector matches the pod
+// labels
+func findSelectingService(podLabels resource, resources []resource) string {
+	if podLabels == nil {
+		return ""
+	}
+
+	for _, res := range resources {
+		if kind, _, _ := getKindAndAPIVersion(res); kind != "Service" {
+			continue
+		}
+
+		selector := getNestedResource(res, "spec", "selector")
+
+		if len(selector) == 0 {
+			continue
+		}
+
+		matches := true
+
+		for key, val := range selector {
+			if podLabels[key] != val {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			name, _ := getResourceName(res)
+			return name
+		}
+	}
+
+	return ""
+}
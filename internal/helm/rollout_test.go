@@ -0,0 +1,109 @@
+package helm_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+)
+
+const rolloutManifest = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app.kubernetes.io/name: web
+  ports:
+  - port: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  strategy:
+    type: RollingUpdate
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: web
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.23
+---
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: web
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+`
+
+func TestRolloutPostRendererDisabled(t *testing.T) {
+	for _, values := range []map[string]interface{}{
+		nil,
+		{"rollout": map[string]interface{}{"enabled": false}},
+	} {
+		if renderer := helm.NewRolloutPostRenderer(values); renderer != nil {
+			t.Errorf("expected no renderer for values %v", values)
+		}
+	}
+}
+
+func TestRolloutPostRenderer(t *testing.T) {
+	renderer := helm.NewRolloutPostRenderer(map[string]interface{}{
+		"rollout": map[string]interface{}{"enabled": true},
+	})
+
+	res, err := renderer.Run(bytes.NewBufferString(rolloutManifest))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	out := res.String()
+
+	for _, expected := range []string{"kind: Rollout", "apiVersion: argoproj.io/v1alpha1", "canary:", "setWeight: 20"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in rendered manifests, got:\n%s", expected, out)
+		}
+	}
+
+	for _, unexpected := range []string{"kind: Deployment", "RollingUpdate", "progressDeadlineSeconds"} {
+		if strings.Contains(out, unexpected) {
+			t.Errorf("expected %q not to be in rendered manifests, got:\n%s", unexpected, out)
+		}
+	}
+}
+
+func TestRolloutPostRendererBlueGreen(t *testing.T) {
+	renderer := helm.NewRolloutPostRenderer(map[string]interface{}{
+		"rollout": map[string]interface{}{
+			"enabled": true,
+			"strategy": map[string]interface{}{
+				"blueGreen": map[string]interface{}{"autoPromotionEnabled": false},
+			},
+		},
+	})
+
+	res, err := renderer.Run(bytes.NewBufferString(rolloutManifest))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if out := res.String(); !strings.Contains(out, "activeService: web") || !strings.Contains(out, "autoPromotionEnabled: false") {
+		t.Errorf("expected the blue-green strategy to use the web service, got:\n%s", out)
+	}
+}
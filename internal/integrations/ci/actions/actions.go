@@ -10,9 +10,11 @@ import (
 	"github.com/Masterminds/semver/v3"
 	ghinstallation "github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/tracing"
 	"golang.org/x/crypto/nacl/box"
 	"golang.org/x/oauth2"
 
@@ -57,6 +59,9 @@ type GithubActions struct {
 
 	DryRun               bool
 	ShouldCreateWorkflow bool
+
+	// the workflow template of the project, which customizes the generated workflow
+	WorkflowTemplate *types.WorkflowTemplateSpec
 }
 
 var (
@@ -219,6 +224,7 @@ func (g *GithubActions) Cleanup() error {
 type GithubActionYAMLStep struct {
 	Name    string            `yaml:"name,omitempty"`
 	ID      string            `yaml:"id,omitempty"`
+	If      string            `yaml:"if,omitempty"`
 	Timeout uint64            `yaml:"timeout-minutes,omitempty"`
 	Uses    string            `yaml:"uses,omitempty"`
 	Run     string            `yaml:"run,omitempty"`
@@ -235,7 +241,10 @@ type GithubActionYAMLOnPush struct {
 }
 
 type GithubActionYAMLJob struct {
-	RunsOn      string                 `yaml:"runs-on,omitempty"`
+	// the label of the runner, or a list of labels
+	RunsOn      interface{}            `yaml:"runs-on,omitempty"`
+	Timeout     uint64                 `yaml:"timeout-minutes,omitempty"`
+	Env         map[string]string      `yaml:"env,omitempty"`
 	Steps       []GithubActionYAMLStep `yaml:"steps,omitempty"`
 	Concurrency map[string]string      `yaml:"concurrency,omitempty"`
 }
@@ -271,10 +280,10 @@ func (g *GithubActions) GetGithubActionYAML() ([]byte, error) {
 		},
 		Name: "Deploy to Porter",
 		Jobs: map[string]GithubActionYAMLJob{
-			"porter-deploy": {
+			"porter-deploy": applyWorkflowTemplate(GithubActionYAMLJob{
 				RunsOn: "ubuntu-latest",
 				Steps:  gaSteps,
-			},
+			}, g.WorkflowTemplate),
 		},
 	}
 
@@ -311,7 +320,7 @@ func (g *GithubActions) getClient() (*github.Client, error) {
 
 	// authenticate as github app installation
 	itr, err := ghinstallation.NewKeyFromFile(
-		http.DefaultTransport,
+		tracing.NewTransport(http.DefaultTransport),
 		g.GithubAppID,
 		int64(g.GithubInstallationID),
 		g.GithubAppSecretPath)
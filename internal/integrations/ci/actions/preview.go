@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/types"
 
 	"gopkg.in/yaml.v2"
 )
@@ -19,6 +20,9 @@ type EnvOpts struct {
 	EnvironmentName                         string
 	InstanceName                            string
 	ProjectID, ClusterID, GitInstallationID uint
+
+	// the workflow template of the project, which customizes the generated workflow
+	WorkflowTemplate *types.WorkflowTemplateSpec
 }
 
 func SetupEnv(opts *EnvOpts) error {
@@ -264,13 +268,13 @@ func getPreviewApplyActionYAML(opts *EnvOpts) ([]byte, error) {
 		},
 		Name: "Porter Preview Environment",
 		Jobs: map[string]GithubActionYAMLJob{
-			"porter-preview": {
+			"porter-preview": applyWorkflowTemplate(GithubActionYAMLJob{
 				RunsOn: "ubuntu-latest",
 				Concurrency: map[string]string{
 					"group": "${{ github.workflow }}-${{ github.event.inputs.pr_number }}",
 				},
 				Steps: gaSteps,
-			},
+			}, opts.WorkflowTemplate),
 		},
 	}
 
@@ -0,0 +1,242 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+const cacheActionName = "actions/cache@v3"
+
+// the format of the uses field of a step: a docker image, a local action, or a versioned
+// action in a repository
+var usesRegex = regexp.MustCompile(`^(docker://\S+|\./\S*|[\w.-]+/[\w./-]+@[\w.-]+)$`)
+
+// the format of the ids of steps, which must be valid expression identifiers
+var stepIDRegex = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+
+// GetWorkflowTemplateSpec returns the spec of the workflow template of the given kind for a
+// project, or nil if the project doesn't customize its workflows
+func GetWorkflowTemplateSpec(
+	repo repository.Repository,
+	projectID uint,
+	kind types.WorkflowTemplateKind,
+) (*types.WorkflowTemplateSpec, error) {
+	template, err := repo.WorkflowTemplate().ReadWorkflowTemplate(projectID, string(kind))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return template.GetSpec()
+}
+
+// ValidateWorkflowTemplate returns an error if a workflow rendered with the template would not
+// be a valid Github Actions workflow, or would break the steps which Porter generates
+func ValidateWorkflowTemplate(spec *types.WorkflowTemplateSpec) error {
+	if spec == nil {
+		return fmt.Errorf("the workflow template spec is empty")
+	}
+
+	for _, label := range spec.RunsOn {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("runner labels cannot be empty")
+		}
+	}
+
+	for key := range spec.Env {
+		if key == "" || strings.HasPrefix(strings.ToUpper(key), "GITHUB_") {
+			return fmt.Errorf("invalid environment variable name %q", key)
+		}
+	}
+
+	if spec.Cache != nil {
+		if len(spec.Cache.Paths) == 0 {
+			return fmt.Errorf("the cache must have at least one path")
+		}
+
+		if strings.TrimSpace(spec.Cache.Key) == "" {
+			return fmt.Errorf("the cache must have a key")
+		}
+	}
+
+	// the id of the step which sets the image tag is referenced by the update step
+	stepIDs := map[string]bool{
+		getSetTagStep().ID: true,
+	}
+
+	steps := append(append([]*types.WorkflowStep{}, spec.PreSteps...), spec.PostSteps...)
+
+	for i, step := range steps {
+		if err := validateWorkflowStep(step, stepIDs); err != nil {
+			if step != nil && step.Name != "" {
+				return fmt.Errorf("invalid step %q: %w", step.Name, err)
+			}
+
+			return fmt.Errorf("invalid step %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func validateWorkflowStep(step *types.WorkflowStep, stepIDs map[string]bool) error {
+	if step == nil {
+		return fmt.Errorf("the step is empty")
+	}
+
+	if strings.TrimSpace(step.Name) == "" {
+		return fmt.Errorf("the step must have a name")
+	}
+
+	if (step.Uses == "") == (step.Run == "") {
+		return fmt.Errorf("the step must set exactly one of uses or run")
+	}
+
+	if step.Uses != "" && !usesRegex.MatchString(step.Uses) {
+		return fmt.Errorf("uses must reference an action as owner/repo@ref, a local action or a docker image")
+	}
+
+	if step.Run != "" && len(step.With) > 0 {
+		return fmt.Errorf("with can only be set for steps which use an action")
+	}
+
+	if step.ID != "" {
+		if !stepIDRegex.MatchString(step.ID) {
+			return fmt.Errorf("invalid id %q", step.ID)
+		}
+
+		if stepIDs[step.ID] {
+			return fmt.Errorf("the id %q is already used by another step", step.ID)
+		}
+
+		stepIDs[step.ID] = true
+	}
+
+	return nil
+}
+
+// applyWorkflowTemplate applies a workflow template to a generated job. The steps of the
+// template are placed around the steps which deploy with Porter: the checkout step always
+// runs first, followed by the cache step and the pre-steps, and the post-steps run last.
+func applyWorkflowTemplate(job GithubActionYAMLJob, spec *types.WorkflowTemplateSpec) GithubActionYAMLJob {
+	if spec == nil {
+		return job
+	}
+
+	if len(spec.RunsOn) == 1 {
+		job.RunsOn = spec.RunsOn[0]
+	} else if len(spec.RunsOn) > 1 {
+		job.RunsOn = spec.RunsOn
+	}
+
+	job.Timeout = spec.TimeoutMinutes
+	job.Env = spec.Env
+
+	steps := make([]GithubActionYAMLStep, 0)
+	porterSteps := job.Steps
+
+	if len(porterSteps) > 0 && porterSteps[0].Uses == getCheckoutCodeStep().Uses {
+		steps = append(steps, porterSteps[0])
+		porterSteps = porterSteps[1:]
+	}
+
+	if spec.Cache != nil {
+		steps = append(steps, getCacheStep(spec.Cache))
+	}
+
+	for _, step := range spec.PreSteps {
+		steps = append(steps, toGithubActionYAMLStep(step))
+	}
+
+	steps = append(steps, porterSteps...)
+
+	for _, step := range spec.PostSteps {
+		steps = append(steps, toGithubActionYAMLStep(step))
+	}
+
+	job.Steps = steps
+
+	return job
+}
+
+func getCacheStep(cache *types.WorkflowCacheSpec) GithubActionYAMLStep {
+	with := map[string]string{
+		"path": strings.Join(cache.Paths, "\n"),
+		"key":  cache.Key,
+	}
+
+	if len(cache.RestoreKeys) > 0 {
+		with["restore-keys"] = strings.Join(cache.RestoreKeys, "\n")
+	}
+
+	return GithubActionYAMLStep{
+		Name: "Cache dependencies",
+		Uses: cacheActionName,
+		With: with,
+	}
+}
+
+func toGithubActionYAMLStep(step *types.WorkflowStep) GithubActionYAMLStep {
+	return GithubActionYAMLStep{
+		Name:    step.Name,
+		ID:      step.ID,
+		If:      step.If,
+		Timeout: step.TimeoutMinutes,
+		Uses:    step.Uses,
+		Run:     step.Run,
+		With:    step.With,
+		Env:     step.Env,
+	}
+}
+
+// RenderWorkflowTemplate validates a workflow template, and renders the workflow of the given
+// kind for an example application or preview environment with the template
+func RenderWorkflowTemplate(
+	kind types.WorkflowTemplateKind,
+	spec *types.WorkflowTemplateSpec,
+	serverURL string,
+	projectID uint,
+) ([]byte, error) {
+	if err := ValidateWorkflowTemplate(spec); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case types.WorkflowTemplateKindDeploy:
+		g := &GithubActions{
+			ServerURL:        serverURL,
+			ProjectID:        projectID,
+			ClusterID:        1,
+			ReleaseName:      "my-app",
+			ReleaseNamespace: "default",
+			GitBranch:        "main",
+			Version:          "v0.1.0",
+			WorkflowTemplate: spec,
+		}
+
+		return g.GetGithubActionYAML()
+	case types.WorkflowTemplateKindPreview:
+		return getPreviewApplyActionYAML(&EnvOpts{
+			ServerURL:         serverURL,
+			GitRepoOwner:      "my-org",
+			GitRepoName:       "my-repo",
+			EnvironmentName:   "Preview",
+			ProjectID:         projectID,
+			ClusterID:         1,
+			GitInstallationID: 1,
+			WorkflowTemplate:  spec,
+		})
+	}
+
+	return nil, fmt.Errorf("unknown workflow template kind %s", kind)
+}
@@ -0,0 +1,142 @@
+package actions_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/ci/actions"
+	"gopkg.in/yaml.v2"
+)
+
+type renderedWorkflow struct {
+	Jobs map[string]struct {
+		RunsOn  interface{}       `yaml:"runs-on"`
+		Timeout uint64            `yaml:"timeout-minutes"`
+		Env     map[string]string `yaml:"env"`
+		Steps   []struct {
+			Name string `yaml:"name"`
+			Uses string `yaml:"uses"`
+		} `yaml:"steps"`
+	} `yaml:"jobs"`
+}
+
+func TestRenderWorkflowTemplate(t *testing.T) {
+	spec := &types.WorkflowTemplateSpec{
+		RunsOn:         []string{"self-hosted", "linux"},
+		TimeoutMinutes: 45,
+		Env:            map[string]string{"NODE_ENV": "production"},
+		Cache: &types.WorkflowCacheSpec{
+			Paths: []string{"node_modules"},
+			Key:   "npm-${{ hashFiles('package-lock.json') }}",
+		},
+		PreSteps: []*types.WorkflowStep{
+			{Name: "Run tests", Run: "npm test"},
+		},
+		PostSteps: []*types.WorkflowStep{
+			{Name: "Notify", Uses: "acme/notify-action@v1", With: map[string]string{"channel": "deploys"}},
+		},
+	}
+
+	raw, err := actions.RenderWorkflowTemplate(types.WorkflowTemplateKindDeploy, spec, "https://porter.run", 1)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	workflow := &renderedWorkflow{}
+
+	if err := yaml.Unmarshal(raw, workflow); err != nil {
+		t.Fatalf("error parsing rendered workflow: %v\n", err)
+	}
+
+	job, ok := workflow.Jobs["porter-deploy"]
+
+	if !ok {
+		t.Fatalf("expected porter-deploy job in rendered workflow\n")
+	}
+
+	if !reflect.DeepEqual(job.RunsOn, []interface{}{"self-hosted", "linux"}) {
+		t.Errorf("expected runner labels to be set, got %v\n", job.RunsOn)
+	}
+
+	if job.Timeout != 45 || job.Env["NODE_ENV"] != "production" {
+		t.Errorf("expected timeout and env to be set, got %d and %v\n", job.Timeout, job.Env)
+	}
+
+	names := make([]string, 0)
+
+	for _, step := range job.Steps {
+		names = append(names, step.Name)
+	}
+
+	expected := []string{"Checkout code", "Cache dependencies", "Run tests", "Set Github tag", "Update Porter App", "Notify"}
+
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected steps %v, got %v\n", expected, names)
+	}
+
+	// a template with a single runner label renders a string
+	raw, err = actions.RenderWorkflowTemplate(types.WorkflowTemplateKindPreview, &types.WorkflowTemplateSpec{
+		RunsOn: []string{"self-hosted"},
+	}, "https://porter.run", 1)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	workflow = &renderedWorkflow{}
+
+	if err := yaml.Unmarshal(raw, workflow); err != nil {
+		t.Fatalf("error parsing rendered workflow: %v\n", err)
+	}
+
+	if runsOn := workflow.Jobs["porter-preview"].RunsOn; runsOn != "self-hosted" {
+		t.Errorf("expected runner label self-hosted, got %v\n", runsOn)
+	}
+}
+
+func TestValidateWorkflowTemplate(t *testing.T) {
+	invalid := map[string]*types.WorkflowTemplateSpec{
+		"uses and run": {
+			PreSteps: []*types.WorkflowStep{{Name: "step", Uses: "actions/setup-go@v3", Run: "go test"}},
+		},
+		"no name": {
+			PreSteps: []*types.WorkflowStep{{Run: "go test"}},
+		},
+		"invalid uses": {
+			PreSteps: []*types.WorkflowStep{{Name: "step", Uses: "setup-go"}},
+		},
+		"reserved id": {
+			PostSteps: []*types.WorkflowStep{{Name: "step", ID: "vars", Run: "echo"}},
+		},
+		"duplicate id": {
+			PreSteps:  []*types.WorkflowStep{{Name: "a", ID: "build", Run: "echo"}},
+			PostSteps: []*types.WorkflowStep{{Name: "b", ID: "build", Run: "echo"}},
+		},
+		"empty runner label": {
+			RunsOn: []string{""},
+		},
+		"cache without key": {
+			Cache: &types.WorkflowCacheSpec{Paths: []string{"~/.cache"}},
+		},
+	}
+
+	for name, spec := range invalid {
+		if err := actions.ValidateWorkflowTemplate(spec); err == nil {
+			t.Errorf("%s: expected validation error\n", name)
+		}
+	}
+
+	valid := &types.WorkflowTemplateSpec{
+		PreSteps: []*types.WorkflowStep{
+			{Name: "Setup go", Uses: "actions/setup-go@v3", With: map[string]string{"go-version": "1.18"}},
+			{Name: "Local action", Uses: "./.github/actions/lint"},
+			{Name: "Container", Uses: "docker://alpine:3.16"},
+		},
+	}
+
+	if err := actions.ValidateWorkflowTemplate(valid); err != nil {
+		t.Errorf("unexpected validation error: %v\n", err)
+	}
+}
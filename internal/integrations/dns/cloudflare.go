@@ -0,0 +1,236 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// CloudflareAPIURL is the URL of the Cloudflare API
+var CloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+
+// CloudflareProvider manages records in the zones which the API token of an integration can
+// access. Records are created without the Cloudflare proxy, so that they resolve to the
+// ingress controller.
+type CloudflareProvider struct {
+	dnsInt     *ints.DNSProviderIntegration
+	httpClient *http.Client
+}
+
+func NewCloudflareProvider(dnsInt *ints.DNSProviderIntegration) *CloudflareProvider {
+	return &CloudflareProvider{
+		dnsInt: dnsInt,
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+type cloudflareZone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type cloudflareRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+	Proxied bool   `json:"proxied"`
+}
+
+// ValidateAPIToken returns an error if the API token of the integration is not active
+func (p *CloudflareProvider) ValidateAPIToken(ctx context.Context) error {
+	res := &struct {
+		Status string `json:"status"`
+	}{}
+
+	if err := p.do(ctx, http.MethodGet, "/user/tokens/verify", nil, nil, res); err != nil {
+		return err
+	}
+
+	if res.Status != "active" {
+		return fmt.Errorf("the api token is %s", res.Status)
+	}
+
+	return nil
+}
+
+func (p *CloudflareProvider) UpsertRecord(ctx context.Context, host string, record *Record) error {
+	zoneID, err := p.getZoneID(ctx, host)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := p.listAddressRecords(ctx, zoneID, host)
+
+	if err != nil {
+		return err
+	}
+
+	body := &cloudflareRecord{
+		Type:    record.Type,
+		Name:    host,
+		Content: record.Value,
+		TTL:     recordTTL,
+	}
+
+	if len(existing) == 0 {
+		return p.do(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", nil, body, nil)
+	}
+
+	// the first record is replaced, and the others are deleted, since a CNAME record can't
+	// coexist with other records of the host
+	for _, other := range existing[1:] {
+		if err := p.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+other.ID, nil, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return p.do(ctx, http.MethodPut, "/zones/"+zoneID+"/dns_records/"+existing[0].ID, nil, body, nil)
+}
+
+func (p *CloudflareProvider) DeleteRecord(ctx context.Context, host string) error {
+	zoneID, err := p.getZoneID(ctx, host)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := p.listAddressRecords(ctx, zoneID, host)
+
+	if err != nil {
+		return err
+	}
+
+	for _, record := range existing {
+		if err := p.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+record.ID, nil, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getZoneID returns the id of the most specific zone which the host belongs to
+func (p *CloudflareProvider) getZoneID(ctx context.Context, host string) (string, error) {
+	for _, candidate := range zoneCandidates(host) {
+		zones := make([]*cloudflareZone, 0)
+
+		if err := p.do(ctx, http.MethodGet, "/zones", url.Values{"name": []string{candidate}}, nil, &zones); err != nil {
+			return "", err
+		}
+
+		if len(zones) > 0 {
+			return zones[0].ID, nil
+		}
+	}
+
+	return "", ErrZoneNotFound
+}
+
+// listAddressRecords returns the A, AAAA and CNAME records of a host
+func (p *CloudflareProvider) listAddressRecords(ctx context.Context, zoneID, host string) ([]*cloudflareRecord, error) {
+	records := make([]*cloudflareRecord, 0)
+
+	if err := p.do(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records", url.Values{"name": []string{host}}, nil, &records); err != nil {
+		return nil, err
+	}
+
+	res := make([]*cloudflareRecord, 0, len(records))
+
+	for _, record := range records {
+		if strings.EqualFold(record.Name, host) && isAddressRecord(record.Type) {
+			res = append(res, record)
+		}
+	}
+
+	return res, nil
+}
+
+// do calls the Cloudflare API and decodes the result of the response into result, if it's
+// not nil
+func (p *CloudflareProvider) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body interface{},
+	result interface{},
+) error {
+	reqURL := CloudflareAPIURL + path
+
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(p.dnsInt.CloudflareAPIToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid api token")
+	}
+
+	res := &struct {
+		Success bool            `json:"success"`
+		Result  json.RawMessage `json:"result"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("cloudflare api returned status code %d", resp.StatusCode)
+	}
+
+	if !res.Success {
+		messages := make([]string, 0, len(res.Errors))
+
+		for _, apiErr := range res.Errors {
+			messages = append(messages, apiErr.Message)
+		}
+
+		return fmt.Errorf("cloudflare api returned an error: %s", strings.Join(messages, ", "))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(res.Result, result)
+}
@@ -0,0 +1,157 @@
+package dns_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type testRecord struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	Proxied bool   `json:"proxied"`
+}
+
+// newTestCloudflareServer serves the zone example.com, whose records are stored in records
+func newTestCloudflareServer(records map[string]*testRecord) *httptest.Server {
+	nextID := len(records)
+
+	writeResult := func(w http.ResponseWriter, result interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "result": result})
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/zones":
+			zones := make([]interface{}, 0)
+
+			if r.URL.Query().Get("name") == "example.com" {
+				zones = append(zones, map[string]string{"id": "zone", "name": "example.com"})
+			}
+
+			writeResult(w, zones)
+		case r.URL.Path == "/zones/zone/dns_records" && r.Method == http.MethodGet:
+			res := make([]*testRecord, 0)
+
+			for _, record := range records {
+				if record.Name == r.URL.Query().Get("name") {
+					res = append(res, record)
+				}
+			}
+
+			writeResult(w, res)
+		case strings.HasPrefix(r.URL.Path, "/zones/zone/dns_records"):
+			record := &testRecord{}
+
+			if r.Method != http.MethodDelete {
+				json.NewDecoder(r.Body).Decode(record)
+			}
+
+			switch r.Method {
+			case http.MethodPost:
+				nextID++
+				record.ID = fmt.Sprintf("%d", nextID)
+				records[record.ID] = record
+			case http.MethodPut:
+				record.ID = strings.TrimPrefix(r.URL.Path, "/zones/zone/dns_records/")
+				records[record.ID] = record
+			case http.MethodDelete:
+				delete(records, strings.TrimPrefix(r.URL.Path, "/zones/zone/dns_records/"))
+			}
+
+			writeResult(w, record)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"errors":[{"code":7003,"message":"Could not route to this path"}]}`))
+		}
+	}))
+}
+
+func TestGetRecord(t *testing.T) {
+	if record := dns.GetRecord("1.2.3.4"); record.Type != "A" {
+		t.Errorf("expected an A record for an ip address, got %s\n", record.Type)
+	}
+
+	if record := dns.GetRecord("lb.elb.amazonaws.com"); record.Type != "CNAME" {
+		t.Errorf("expected a CNAME record for a hostname, got %s\n", record.Type)
+	}
+}
+
+func TestCloudflareUpsertRecord(t *testing.T) {
+	records := map[string]*testRecord{
+		"1": {ID: "1", Type: "A", Name: "app.example.com", Content: "1.1.1.1"},
+		"2": {ID: "2", Type: "AAAA", Name: "app.example.com", Content: "::1"},
+		"3": {ID: "3", Type: "TXT", Name: "app.example.com", Content: "verification"},
+	}
+
+	server := newTestCloudflareServer(records)
+	defer server.Close()
+
+	dns.CloudflareAPIURL = server.URL
+
+	provider := dns.NewCloudflareProvider(&integrations.DNSProviderIntegration{
+		CloudflareAPIToken: []byte("token"),
+	})
+
+	err := provider.UpsertRecord(context.Background(), "app.example.com", dns.GetRecord("lb.elb.amazonaws.com"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v\n", err)
+	}
+
+	// the address records are replaced by the CNAME record, and the other records are kept
+	if len(records) != 2 || records["3"] == nil {
+		t.Fatalf("expected the CNAME and TXT records, got %v\n", records)
+	}
+
+	for id, record := range records {
+		if id != "3" && (record.Type != "CNAME" || record.Content != "lb.elb.amazonaws.com" || record.Proxied) {
+			t.Errorf("expected an unproxied CNAME record to lb.elb.amazonaws.com, got %v\n", record)
+		}
+	}
+
+	if err := provider.DeleteRecord(context.Background(), "app.example.com"); err != nil {
+		t.Fatalf("expected no error, got %v\n", err)
+	}
+
+	if len(records) != 1 || records["3"] == nil {
+		t.Errorf("expected only the TXT record, got %v\n", records)
+	}
+
+	err = provider.UpsertRecord(context.Background(), "app.example.org", dns.GetRecord("1.2.3.4"))
+
+	if !errors.Is(err, dns.ErrZoneNotFound) {
+		t.Errorf("expected ErrZoneNotFound, got %v\n", err)
+	}
+}
+
+func TestCloudflareInvalidToken(t *testing.T) {
+	server := newTestCloudflareServer(map[string]*testRecord{})
+	defer server.Close()
+
+	dns.CloudflareAPIURL = server.URL
+
+	provider := dns.NewCloudflareProvider(&integrations.DNSProviderIntegration{
+		CloudflareAPIToken: []byte("invalid"),
+	})
+
+	if err := provider.ValidateAPIToken(context.Background()); err == nil {
+		t.Errorf("expected an error for an invalid token\n")
+	}
+}
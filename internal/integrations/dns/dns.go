@@ -0,0 +1,85 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// ErrZoneNotFound is returned when the DNS provider has no zone which the host belongs to
+var ErrZoneNotFound = errors.New("the dns provider has no zone for this host")
+
+// recordTTL is the TTL, in seconds, of the records which are created
+const recordTTL = 300
+
+// Record is a DNS record which points a custom domain to the ingress controller of a cluster
+type Record struct {
+	Type  string
+	Value string
+}
+
+// GetRecord returns the record which points a host to an ingress endpoint: an A record if
+// the endpoint is an IP address, and a CNAME record if it's a load balancer hostname
+func GetRecord(endpoint string) *Record {
+	if net.ParseIP(endpoint) != nil {
+		return &Record{Type: "A", Value: endpoint}
+	}
+
+	return &Record{Type: "CNAME", Value: endpoint}
+}
+
+// Provider manages the DNS records of custom domains in the zones of a DNS provider account
+type Provider interface {
+	// UpsertRecord creates the record of a host, replacing any A, AAAA or CNAME records which
+	// the host already has
+	UpsertRecord(ctx context.Context, host string, record *Record) error
+
+	// DeleteRecord deletes the A, AAAA and CNAME records of a host
+	DeleteRecord(ctx context.Context, host string) error
+}
+
+// NewProvider returns the provider of a DNS provider integration. Route53 integrations are
+// authenticated with the credentials of their AWS integration, which must be passed.
+func NewProvider(dnsInt *ints.DNSProviderIntegration, awsInt *ints.AWSIntegration) (Provider, error) {
+	switch dnsInt.Provider {
+	case types.DNSProviderRoute53:
+		if awsInt == nil {
+			return nil, fmt.Errorf("route53 integrations require an aws integration")
+		}
+
+		return NewRoute53Provider(awsInt)
+	case types.DNSProviderCloudflare:
+		return NewCloudflareProvider(dnsInt), nil
+	}
+
+	return nil, fmt.Errorf("unsupported dns provider %s", dnsInt.Provider)
+}
+
+// zoneCandidates returns the names of the zones which a host can belong to, from the most to
+// the least specific. For api.example.com, these are api.example.com and example.com.
+func zoneCandidates(host string) []string {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(host, ".")), ".")
+	res := make([]string, 0, len(labels))
+
+	for i := 0; i < len(labels)-1; i++ {
+		res = append(res, strings.Join(labels[i:], "."))
+	}
+
+	return res
+}
+
+// isAddressRecord returns true for the types of records which route a host, and which are
+// replaced when the record of a custom domain is created
+func isAddressRecord(recordType string) bool {
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA", "CNAME":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// Route53Provider manages records in the public hosted zones of an AWS account
+type Route53Provider struct {
+	client *route53.Route53
+}
+
+func NewRoute53Provider(awsInt *ints.AWSIntegration) (*Route53Provider, error) {
+	// route53 is a global service, which is served from us-east-1
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials(
+			string(awsInt.AWSAccessKeyID),
+			string(awsInt.AWSSecretAccessKey),
+			string(awsInt.AWSSessionToken),
+		),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Route53Provider{route53.New(sess)}, nil
+}
+
+func (p *Route53Provider) UpsertRecord(ctx context.Context, host string, record *Record) error {
+	zoneID, err := p.getZoneID(ctx, host)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := p.listAddressRecordSets(ctx, zoneID, host)
+
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*route53.Change, 0)
+
+	// a CNAME record can't coexist with other records of the host, so the records of other
+	// types are deleted in the same change
+	for _, recordSet := range existing {
+		if aws.StringValue(recordSet.Type) != record.Type {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: recordSet,
+			})
+		}
+	}
+
+	changes = append(changes, &route53.Change{
+		Action: aws.String(route53.ChangeActionUpsert),
+		ResourceRecordSet: &route53.ResourceRecordSet{
+			Name: aws.String(host),
+			Type: aws.String(record.Type),
+			TTL:  aws.Int64(recordTTL),
+			ResourceRecords: []*route53.ResourceRecord{
+				{Value: aws.String(record.Value)},
+			},
+		},
+	})
+
+	return p.changeRecordSets(ctx, zoneID, changes)
+}
+
+func (p *Route53Provider) DeleteRecord(ctx context.Context, host string) error {
+	zoneID, err := p.getZoneID(ctx, host)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := p.listAddressRecordSets(ctx, zoneID, host)
+
+	if err != nil || len(existing) == 0 {
+		return err
+	}
+
+	changes := make([]*route53.Change, 0, len(existing))
+
+	for _, recordSet := range existing {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: recordSet,
+		})
+	}
+
+	return p.changeRecordSets(ctx, zoneID, changes)
+}
+
+// getZoneID returns the id of the most specific public hosted zone which the host belongs to
+func (p *Route53Provider) getZoneID(ctx context.Context, host string) (string, error) {
+	zoneIDs := make(map[string]string)
+
+	err := p.client.ListHostedZonesPagesWithContext(
+		ctx,
+		&route53.ListHostedZonesInput{},
+		func(page *route53.ListHostedZonesOutput, lastPage bool) bool {
+			for _, zone := range page.HostedZones {
+				if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) {
+					continue
+				}
+
+				zoneIDs[strings.ToLower(strings.TrimSuffix(aws.StringValue(zone.Name), "."))] = aws.StringValue(zone.Id)
+			}
+
+			return true
+		},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, candidate := range zoneCandidates(host) {
+		if zoneID, ok := zoneIDs[candidate]; ok {
+			return zoneID, nil
+		}
+	}
+
+	return "", ErrZoneNotFound
+}
+
+// listAddressRecordSets returns the A, AAAA and CNAME record sets of a host
+func (p *Route53Provider) listAddressRecordSets(
+	ctx context.Context,
+	zoneID, host string,
+) ([]*route53.ResourceRecordSet, error) {
+	// record sets are listed in order of their names, starting at the host
+	out, err := p.client.ListResourceRecordSetsWithContext(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(host),
+		MaxItems:        aws.String("10"),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*route53.ResourceRecordSet, 0)
+
+	for _, recordSet := range out.ResourceRecordSets {
+		// route53 returns the * of wildcard records in its octal escape
+		name := strings.ReplaceAll(strings.TrimSuffix(aws.StringValue(recordSet.Name), "."), `\052`, "*")
+
+		if strings.EqualFold(name, strings.TrimSuffix(host, ".")) && isAddressRecord(aws.StringValue(recordSet.Type)) {
+			res = append(res, recordSet)
+		}
+	}
+
+	return res, nil
+}
+
+func (p *Route53Provider) changeRecordSets(ctx context.Context, zoneID string, changes []*route53.Change) error {
+	_, err := p.client.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("managed by porter"),
+			Changes: changes,
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,84 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// propagationTimeout is how long a record can take to resolve to the ingress controller
+// before it's marked as failed
+const propagationTimeout = time.Hour
+
+// Verifier marks the pending records of custom domains as active once they have propagated
+type Verifier struct {
+	repo   repository.Repository
+	logger *logger.Logger
+}
+
+func NewVerifier(repo repository.Repository, logger *logger.Logger) *Verifier {
+	return &Verifier{repo, logger}
+}
+
+// Start verifies the pending records every minute, until the context is cancelled
+func (v *Verifier) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := VerifyPendingRecords(v.repo, time.Now()); err != nil {
+					v.logger.Error().Err(err).Msg("error verifying custom domain dns records")
+				}
+			}
+		}
+	}()
+}
+
+// VerifyPendingRecords checks whether the pending records resolve to the ingress controller.
+// Records which resolve become active, and records which don't resolve within the propagation
+// timeout fail.
+func VerifyPendingRecords(repo repository.Repository, now time.Time) error {
+	records, err := repo.CustomDomainDNSRecord().ListPendingCustomDomainDNSRecords()
+
+	if err != nil {
+		return fmt.Errorf("error listing pending records: %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for _, record := range records {
+		res := domain.VerifyCustomDomain(record.Host, record.Value)
+
+		switch {
+		case res.Verified:
+			record.Status = types.CustomDomainDNSActive
+			record.Message = ""
+			record.VerifiedAt = &now
+		case now.Sub(record.CreatedAt) > propagationTimeout:
+			record.Status = types.CustomDomainDNSFailed
+			record.Message = fmt.Sprintf("the record did not propagate within %s: %s", propagationTimeout, res.Message)
+		default:
+			record.Message = res.Message
+		}
+
+		if _, err := repo.CustomDomainDNSRecord().UpdateCustomDomainDNSRecord(record); err != nil {
+			errs = append(errs, fmt.Errorf("record %d: %w", record.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error updating %d of %d records: %v", len(errs), len(records), errs)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package doppler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// ErrInvalidConfigID is returned when a config is not referenced as project/config
+var ErrInvalidConfigID = errors.New("doppler configs must be referenced as project/config")
+
+// APIURL is the URL of the Doppler API
+var APIURL = "https://api.doppler.com"
+
+// Config is the current value of the secrets of a Doppler config
+type Config struct {
+	// VersionID is a digest of the secrets, which changes whenever a secret is added, removed or
+	// changed. Doppler doesn't return the version of a config with its secrets.
+	VersionID string
+	Values    map[string]string
+}
+
+// ParseConfigID splits a config referenced as project/config, such as backend/prd, into the
+// name of the project and the name of the config
+func ParseConfigID(configID string) (string, string, error) {
+	parts := strings.Split(configID, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", ErrInvalidConfigID
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// Client calls the Doppler API with the API token of an integration
+type Client struct {
+	dopplerInt *integrations.DopplerIntegration
+	httpClient *http.Client
+}
+
+func NewClient(dopplerInt *integrations.DopplerIntegration) *Client {
+	return &Client{
+		dopplerInt: dopplerInt,
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+// ValidateAPIToken returns an error if the API token of the integration cannot authenticate
+// with Doppler
+func (c *Client) ValidateAPIToken(ctx context.Context) error {
+	return c.get(ctx, "/v3/me", nil, &struct{}{})
+}
+
+// GetConfig reads the secrets of a config, referenced as project/config. Doppler's own
+// secrets, such as DOPPLER_PROJECT, are included.
+func (c *Client) GetConfig(ctx context.Context, configID string) (*Config, error) {
+	project, config, err := ParseConfigID(configID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+
+	query := url.Values{}
+	query.Set("project", project)
+	query.Set("config", config)
+	query.Set("format", "json")
+
+	if err := c.get(ctx, "/v3/configs/config/secrets/download", query, &values); err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		VersionID: getVersionID(values),
+		Values:    values,
+	}, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, data interface{}) error {
+	reqURL := APIURL + path
+
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.dopplerInt.APIToken))
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid api token")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		res := &struct {
+			Messages []string `json:"messages"`
+		}{}
+
+		if err := json.NewDecoder(resp.Body).Decode(res); err == nil && len(res.Messages) > 0 {
+			return fmt.Errorf("doppler api returned an error: %s", strings.Join(res.Messages, ", "))
+		}
+
+		return fmt.Errorf("doppler api returned status code %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(data)
+}
+
+// getVersionID returns a digest of the keys and values of a config, in order of their keys
+func getVersionID(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	hash := sha256.New()
+
+	for _, key := range keys {
+		// the lengths are written with the keys and values, so that they can't run together
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(key), key, len(values[key]), values[key])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
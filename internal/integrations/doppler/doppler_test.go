@@ -0,0 +1,117 @@
+package doppler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/doppler"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestParseConfigID(t *testing.T) {
+	project, config, err := doppler.ParseConfigID("backend/prd")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v\n", err)
+	}
+
+	if project != "backend" || config != "prd" {
+		t.Errorf("expected backend and prd, got %s and %s\n", project, config)
+	}
+
+	for _, configID := range []string{"", "backend", "backend/", "/prd", "backend/prd/extra"} {
+		if _, _, err := doppler.ParseConfigID(configID); !errors.Is(err, doppler.ErrInvalidConfigID) {
+			t.Errorf("expected ErrInvalidConfigID for %q, got %v\n", configID, err)
+		}
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	secrets := `{"DATABASE_URL":"postgres://db","DOPPLER_CONFIG":"prd"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		query := r.URL.Query()
+
+		if r.URL.Path != "/v3/configs/config/secrets/download" || query.Get("format") != "json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if query.Get("project") != "backend" || query.Get("config") != "prd" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"messages":["Could not find requested config"],"success":false}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(secrets))
+	}))
+
+	defer server.Close()
+
+	prevURL := doppler.APIURL
+	doppler.APIURL = server.URL
+
+	defer func() {
+		doppler.APIURL = prevURL
+	}()
+
+	client := doppler.NewClient(&integrations.DopplerIntegration{APIToken: []byte("token")})
+
+	config, err := client.GetConfig(context.Background(), "backend/prd")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v\n", err)
+	}
+
+	expected := map[string]string{
+		"DATABASE_URL":   "postgres://db",
+		"DOPPLER_CONFIG": "prd",
+	}
+
+	if !reflect.DeepEqual(config.Values, expected) {
+		t.Errorf("expected values %v, got %v\n", expected, config.Values)
+	}
+
+	// the version only changes with the values of the config
+	unchanged, err := client.GetConfig(context.Background(), "backend/prd")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v\n", err)
+	}
+
+	if unchanged.VersionID != config.VersionID {
+		t.Errorf("expected version %s, got %s\n", config.VersionID, unchanged.VersionID)
+	}
+
+	secrets = `{"DATABASE_URL":"postgres://replica","DOPPLER_CONFIG":"prd"}`
+
+	changed, err := client.GetConfig(context.Background(), "backend/prd")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v\n", err)
+	}
+
+	if changed.VersionID == config.VersionID {
+		t.Errorf("expected the version to change with the values of the config\n")
+	}
+
+	if _, err := client.GetConfig(context.Background(), "backend/dev"); err == nil {
+		t.Errorf("expected an error for a config which doesn't exist\n")
+	}
+
+	invalidClient := doppler.NewClient(&integrations.DopplerIntegration{APIToken: []byte("invalid")})
+
+	if _, err := invalidClient.GetConfig(context.Background(), "backend/prd"); err == nil {
+		t.Errorf("expected an error for an invalid token\n")
+	}
+}
@@ -0,0 +1,110 @@
+package featureflags
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// ErrFlagNotFound is returned when a flag or environment doesn't exist in the project of an
+// integration
+var ErrFlagNotFound = errors.New("the feature flag was not found")
+
+// Client reads and changes the flags of a feature flag integration's project
+type Client interface {
+	// Validate returns an error if the project can't be read with the API token of the
+	// integration
+	Validate(ctx context.Context) error
+
+	// GetFlag returns true if a flag is on in an environment
+	GetFlag(ctx context.Context, environment, key string) (bool, error)
+
+	// SetFlag turns a flag on or off in an environment
+	SetFlag(ctx context.Context, environment, key string, enabled bool) error
+}
+
+// NewClient returns the client of a feature flag integration. The URL of the integration is
+// set by users, so the client refuses to connect to addresses which are not public.
+func NewClient(ffInt *ints.FeatureFlagIntegration) (Client, error) {
+	httpClient := netguard.NewHTTPClient(time.Second * 10)
+
+	switch ffInt.Provider {
+	case types.FeatureFlagProviderLaunchDarkly:
+		return &launchDarklyClient{ffInt, httpClient}, nil
+	case types.FeatureFlagProviderUnleash:
+		return &unleashClient{ffInt, httpClient}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported feature flag provider %s", ffInt.Provider)
+}
+
+// do calls the API of a feature flag provider, which both authenticate with the API token in
+// the Authorization header, and decodes the response into result if it's not nil
+func do(
+	ctx context.Context,
+	httpClient *http.Client,
+	ffInt *ints.FeatureFlagIntegration,
+	method, path, contentType string,
+	body interface{},
+	result interface{},
+) error {
+	var reqBody io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(ffInt.URL, "/")+path, reqBody)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", string(ffInt.APIToken))
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("invalid api token")
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrFlagNotFound
+	case resp.StatusCode >= 300:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("%s api returned status code %d: %s", ffInt.Provider, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
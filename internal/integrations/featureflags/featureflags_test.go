@@ -0,0 +1,183 @@
+package featureflags_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/featureflags"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// newTestLaunchDarklyServer serves the project app, whose flags in the production environment
+// are stored in flags
+func newTestLaunchDarklyServer(t *testing.T, flags map[string]bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path == "/api/v2/projects/app" {
+			w.Write([]byte(`{"key":"app"}`))
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/api/v2/flags/app/")
+		on, ok := flags[key]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"environments": map[string]interface{}{"production": map[string]bool{"on": on}},
+			})
+		case http.MethodPatch:
+			if !strings.Contains(r.Header.Get("Content-Type"), "domain-model=launchdarkly.semanticpatch") {
+				t.Errorf("expected semantic patch, got content type %s\n", r.Header.Get("Content-Type"))
+			}
+
+			patch := &struct {
+				EnvironmentKey string `json:"environmentKey"`
+				Instructions   []struct {
+					Kind string `json:"kind"`
+				} `json:"instructions"`
+			}{}
+
+			json.NewDecoder(r.Body).Decode(patch)
+
+			if patch.EnvironmentKey != "production" || len(patch.Instructions) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			flags[key] = patch.Instructions[0].Kind == "turnFlagOn"
+			w.Write([]byte(`{}`))
+		}
+	}))
+}
+
+// newTestUnleashServer serves the project default, whose features in the production
+// environment are stored in flags
+func newTestUnleashServer(flags map[string]bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path == "/api/admin/projects/default" {
+			w.Write([]byte(`{"name":"Default"}`))
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/admin/projects/default/features/"), "/")
+		on, ok := flags[parts[0]]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && len(parts) == 1:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"environments": []interface{}{map[string]interface{}{"name": "production", "enabled": on}},
+			})
+		case r.Method == http.MethodPost && len(parts) == 4 && parts[1] == "environments" && parts[2] == "production":
+			flags[parts[0]] = parts[3] == "on"
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClients(t *testing.T) {
+	// the test servers listen on the loopback address
+	t.Cleanup(netguard.AllowLocalTargets())
+
+	ldFlags := map[string]bool{"new-checkout": false}
+	ldServer := newTestLaunchDarklyServer(t, ldFlags)
+	defer ldServer.Close()
+
+	unleashFlags := map[string]bool{"new-checkout": false}
+	unleashServer := newTestUnleashServer(unleashFlags)
+	defer unleashServer.Close()
+
+	tests := []struct {
+		ffInt *integrations.FeatureFlagIntegration
+		flags map[string]bool
+	}{
+		{
+			ffInt: &integrations.FeatureFlagIntegration{
+				Provider:    types.FeatureFlagProviderLaunchDarkly,
+				URL:         ldServer.URL,
+				FlagProject: "app",
+				APIToken:    []byte("token"),
+			},
+			flags: ldFlags,
+		},
+		{
+			ffInt: &integrations.FeatureFlagIntegration{
+				Provider:    types.FeatureFlagProviderUnleash,
+				URL:         unleashServer.URL + "/",
+				FlagProject: "default",
+				APIToken:    []byte("token"),
+			},
+			flags: unleashFlags,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		client, err := featureflags.NewClient(test.ffInt)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v\n", test.ffInt.Provider, err)
+		}
+
+		if err := client.Validate(ctx); err != nil {
+			t.Errorf("%s: unexpected validation error: %v\n", test.ffInt.Provider, err)
+		}
+
+		if err := client.SetFlag(ctx, "production", "new-checkout", true); err != nil {
+			t.Fatalf("%s: unexpected error: %v\n", test.ffInt.Provider, err)
+		}
+
+		if !test.flags["new-checkout"] {
+			t.Errorf("%s: expected flag to be turned on\n", test.ffInt.Provider)
+		}
+
+		on, err := client.GetFlag(ctx, "production", "new-checkout")
+
+		if err != nil || !on {
+			t.Errorf("%s: expected flag to be on, got %t, %v\n", test.ffInt.Provider, on, err)
+		}
+
+		if _, err := client.GetFlag(ctx, "staging", "new-checkout"); !errors.Is(err, featureflags.ErrFlagNotFound) {
+			t.Errorf("%s: expected not found error for unknown environment, got %v\n", test.ffInt.Provider, err)
+		}
+
+		if err := client.SetFlag(ctx, "production", "missing", true); !errors.Is(err, featureflags.ErrFlagNotFound) {
+			t.Errorf("%s: expected not found error for unknown flag, got %v\n", test.ffInt.Provider, err)
+		}
+
+		test.ffInt.APIToken = []byte("invalid")
+
+		if err := client.Validate(ctx); err == nil || !strings.Contains(err.Error(), "invalid api token") {
+			t.Errorf("%s: expected invalid api token error, got %v\n", test.ffInt.Provider, err)
+		}
+	}
+}
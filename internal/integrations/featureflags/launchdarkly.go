@@ -0,0 +1,68 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// LaunchDarklyURL is the URL of the LaunchDarkly API, which integrations use by default
+const LaunchDarklyURL = "https://app.launchdarkly.com"
+
+// launchDarklySemanticPatch is the content type of the patches which turn flags on and off,
+// which change a flag without a json patch of its full configuration
+const launchDarklySemanticPatch = "application/json; domain-model=launchdarkly.semanticpatch"
+
+type launchDarklyClient struct {
+	ffInt      *ints.FeatureFlagIntegration
+	httpClient *http.Client
+}
+
+func (c *launchDarklyClient) Validate(ctx context.Context) error {
+	return do(ctx, c.httpClient, c.ffInt, http.MethodGet, "/api/v2/projects/"+url.PathEscape(c.ffInt.FlagProject), "", nil, nil)
+}
+
+func (c *launchDarklyClient) GetFlag(ctx context.Context, environment, key string) (bool, error) {
+	res := &struct {
+		Environments map[string]struct {
+			On bool `json:"on"`
+		} `json:"environments"`
+	}{}
+
+	path := c.flagPath(key) + "?env=" + url.QueryEscape(environment)
+
+	if err := do(ctx, c.httpClient, c.ffInt, http.MethodGet, path, "", nil, res); err != nil {
+		return false, err
+	}
+
+	env, ok := res.Environments[environment]
+
+	if !ok {
+		return false, fmt.Errorf("environment %s: %w", environment, ErrFlagNotFound)
+	}
+
+	return env.On, nil
+}
+
+func (c *launchDarklyClient) SetFlag(ctx context.Context, environment, key string, enabled bool) error {
+	kind := "turnFlagOff"
+
+	if enabled {
+		kind = "turnFlagOn"
+	}
+
+	body := map[string]interface{}{
+		"environmentKey": environment,
+		"comment":        "Changed by a Porter deploy",
+		"instructions":   []map[string]string{{"kind": kind}},
+	}
+
+	return do(ctx, c.httpClient, c.ffInt, http.MethodPatch, c.flagPath(key), launchDarklySemanticPatch, body, nil)
+}
+
+func (c *launchDarklyClient) flagPath(key string) string {
+	return fmt.Sprintf("/api/v2/flags/%s/%s", url.PathEscape(c.ffInt.FlagProject), url.PathEscape(key))
+}
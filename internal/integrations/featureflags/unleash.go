@@ -0,0 +1,56 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type unleashClient struct {
+	ffInt      *ints.FeatureFlagIntegration
+	httpClient *http.Client
+}
+
+func (c *unleashClient) Validate(ctx context.Context) error {
+	return do(ctx, c.httpClient, c.ffInt, http.MethodGet, "/api/admin/projects/"+url.PathEscape(c.ffInt.FlagProject), "", nil, nil)
+}
+
+func (c *unleashClient) GetFlag(ctx context.Context, environment, key string) (bool, error) {
+	res := &struct {
+		Environments []struct {
+			Name    string `json:"name"`
+			Enabled bool   `json:"enabled"`
+		} `json:"environments"`
+	}{}
+
+	if err := do(ctx, c.httpClient, c.ffInt, http.MethodGet, c.featurePath(key), "", nil, res); err != nil {
+		return false, err
+	}
+
+	for _, env := range res.Environments {
+		if env.Name == environment {
+			return env.Enabled, nil
+		}
+	}
+
+	return false, fmt.Errorf("environment %s: %w", environment, ErrFlagNotFound)
+}
+
+func (c *unleashClient) SetFlag(ctx context.Context, environment, key string, enabled bool) error {
+	state := "off"
+
+	if enabled {
+		state = "on"
+	}
+
+	path := fmt.Sprintf("%s/environments/%s/%s", c.featurePath(key), url.PathEscape(environment), state)
+
+	return do(ctx, c.httpClient, c.ffInt, http.MethodPost, path, "", nil, nil)
+}
+
+func (c *unleashClient) featurePath(key string) string {
+	return fmt.Sprintf("/api/admin/projects/%s/features/%s", url.PathEscape(c.ffInt.FlagProject), url.PathEscape(key))
+}
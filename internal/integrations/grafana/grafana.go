@@ -0,0 +1,221 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// DashboardOpts are the options of the dashboard of a release
+type DashboardOpts struct {
+	// The uid of the dashboard. Provisioning a dashboard with the same uid overwrites the
+	// existing dashboard.
+	UID string
+
+	ClusterName string
+	Namespace   string
+	Name        string
+}
+
+// GetDashboardUID returns the uid of the dashboard of a release, which is stable so that the
+// dashboard is overwritten rather than duplicated when it is provisioned again
+func GetDashboardUID(releaseID uint) string {
+	return fmt.Sprintf("porter-release-%d", releaseID)
+}
+
+// GetDashboard returns the model of a dashboard which graphs the CPU, memory and request
+// metrics of a release's workloads
+func GetDashboard(opts *DashboardOpts, datasourceUID string) map[string]interface{} {
+	podSelector := fmt.Sprintf(`namespace="%s",pod=~"%s-.*",container!="POD",container!=""`, opts.Namespace, opts.Name)
+	ingressSelector := fmt.Sprintf(`exported_namespace="%s",ingress=~"%s(-.*)?"`, opts.Namespace, opts.Name)
+
+	var datasource interface{}
+
+	if datasourceUID != "" {
+		datasource = map[string]string{
+			"type": "prometheus",
+			"uid":  datasourceUID,
+		}
+	}
+
+	panels := []map[string]interface{}{
+		getPanel(1, "CPU usage (cores)", "short", datasource, 0, 0,
+			fmt.Sprintf(`sum(rate(container_cpu_usage_seconds_total{%s}[5m])) by (pod)`, podSelector),
+		),
+		getPanel(2, "Memory usage", "bytes", datasource, 12, 0,
+			fmt.Sprintf(`sum(container_memory_usage_bytes{%s}) by (pod)`, podSelector),
+		),
+		getPanel(3, "Requests per second", "reqps", datasource, 0, 8,
+			fmt.Sprintf(`sum(rate(nginx_ingress_controller_requests{%s}[5m])) by (status)`, ingressSelector),
+		),
+		getPanel(4, "Average request latency", "s", datasource, 12, 8,
+			fmt.Sprintf(
+				`sum(rate(nginx_ingress_controller_request_duration_seconds_sum{%s}[5m])) / sum(rate(nginx_ingress_controller_request_duration_seconds_count{%s}[5m]))`,
+				ingressSelector,
+				ingressSelector,
+			),
+		),
+	}
+
+	return map[string]interface{}{
+		"uid":           opts.UID,
+		"title":         fmt.Sprintf("%s (%s/%s)", opts.Name, opts.ClusterName, opts.Namespace),
+		"tags":          []string{"porter", opts.ClusterName, opts.Namespace},
+		"timezone":      "browser",
+		"schemaVersion": 36,
+		"refresh":       "1m",
+		"time": map[string]string{
+			"from": "now-6h",
+			"to":   "now",
+		},
+		"panels": panels,
+	}
+}
+
+func getPanel(id int, title, unit string, datasource interface{}, x, y int, query string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "timeseries",
+		"title":      title,
+		"datasource": datasource,
+		"gridPos": map[string]int{
+			"h": 8,
+			"w": 12,
+			"x": x,
+			"y": y,
+		},
+		"fieldConfig": map[string]interface{}{
+			"defaults": map[string]string{
+				"unit": unit,
+			},
+		},
+		"targets": []map[string]interface{}{
+			{
+				"refId":        "A",
+				"datasource":   datasource,
+				"expr":         query,
+				"legendFormat": "__auto",
+			},
+		},
+	}
+}
+
+// Client calls the Grafana HTTP API with the API key of an integration
+type Client struct {
+	grafanaInt *integrations.GrafanaIntegration
+	httpClient *http.Client
+}
+
+// NewClient returns the client of an integration. The URL of the Grafana instance is set by
+// users, so the client refuses to connect to addresses which are not public.
+func NewClient(grafanaInt *integrations.GrafanaIntegration) *Client {
+	return &Client{
+		grafanaInt: grafanaInt,
+		httpClient: netguard.NewHTTPClient(time.Second * 10),
+	}
+}
+
+// ValidateAPIKey returns an error if the API key of the integration cannot authenticate with
+// the Grafana instance
+func (c *Client) ValidateAPIKey() error {
+	statusCode, err := c.do(http.MethodGet, "/api/search?limit=1", nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid api key for %s", c.grafanaInt.URL)
+	} else if statusCode != http.StatusOK {
+		return fmt.Errorf("grafana api returned status code %d", statusCode)
+	}
+
+	return nil
+}
+
+// ProvisionDashboard creates or overwrites the dashboard of a release, and returns the URL of
+// the dashboard
+func (c *Client) ProvisionDashboard(opts *DashboardOpts) (string, error) {
+	res := &struct {
+		URL string `json:"url"`
+	}{}
+
+	statusCode, err := c.do(
+		http.MethodPost,
+		"/api/dashboards/db",
+		map[string]interface{}{
+			"dashboard": GetDashboard(opts, c.grafanaInt.DatasourceUID),
+			"folderUid": c.grafanaInt.FolderUID,
+			"overwrite": true,
+			"message":   "Provisioned by Porter",
+		},
+		res,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("grafana dashboards api returned status code %d", statusCode)
+	}
+
+	baseURL, err := url.Parse(c.grafanaInt.URL)
+
+	if err != nil {
+		return "", err
+	}
+
+	// the returned path includes the sub path which Grafana is served from, if any
+	return fmt.Sprintf("%s://%s%s", baseURL.Scheme, baseURL.Host, res.URL), nil
+}
+
+func (c *Client) do(method, path string, body, res interface{}) (int, error) {
+	reqBody := &bytes.Buffer{}
+
+	if body != nil {
+		data, err := json.Marshal(body)
+
+		if err != nil {
+			return 0, err
+		}
+
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.grafanaInt.URL, "/")+path, reqBody)
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", string(c.grafanaInt.APIKey)))
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if res != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+			return 0, err
+		}
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,144 @@
+package grafana_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/grafana"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+func TestGetDashboard(t *testing.T) {
+	dashboard := grafana.GetDashboard(&grafana.DashboardOpts{
+		UID:         grafana.GetDashboardUID(4),
+		ClusterName: "production",
+		Namespace:   "default",
+		Name:        "web",
+	}, "prometheus")
+
+	if dashboard["uid"] != "porter-release-4" {
+		t.Errorf("expected uid porter-release-4, got %v\n", dashboard["uid"])
+	}
+
+	data, err := json.Marshal(dashboard)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, expected := range []string{
+		`container_cpu_usage_seconds_total{namespace=\"default\",pod=~\"web-.*\"`,
+		`container_memory_usage_bytes{namespace=\"default\",pod=~\"web-.*\"`,
+		`nginx_ingress_controller_requests{exported_namespace=\"default\",ingress=~\"web(-.*)?\"}`,
+		`"uid":"prometheus"`,
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("expected dashboard to contain %s\n", expected)
+		}
+	}
+}
+
+func TestProvisionDashboard(t *testing.T) {
+	// the test server listens on the loopback address
+	t.Cleanup(netguard.AllowLocalTargets())
+
+	var folderUID string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/grafana/api/search":
+			w.Write([]byte(`[]`))
+		case "/grafana/api/dashboards/db":
+			body := &struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+				FolderUID string                 `json:"folderUid"`
+				Overwrite bool                   `json:"overwrite"`
+			}{}
+
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil || !body.Overwrite {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			folderUID = body.FolderUID
+
+			w.Write([]byte(`{"status":"success","url":"/grafana/d/` + body.Dashboard["uid"].(string) + `/web"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	client := grafana.NewClient(&integrations.GrafanaIntegration{
+		URL:       server.URL + "/grafana/",
+		FolderUID: "porter",
+		APIKey:    []byte("key"),
+	})
+
+	if err := client.ValidateAPIKey(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	dashboardURL, err := client.ProvisionDashboard(&grafana.DashboardOpts{
+		UID:         grafana.GetDashboardUID(4),
+		ClusterName: "production",
+		Namespace:   "default",
+		Name:        "web",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if expected := server.URL + "/grafana/d/porter-release-4/web"; dashboardURL != expected {
+		t.Errorf("expected dashboard url %s, got %s\n", expected, dashboardURL)
+	}
+
+	if folderUID != "porter" {
+		t.Errorf("expected dashboard to be created in folder porter, got %s\n", folderUID)
+	}
+
+	invalidClient := grafana.NewClient(&integrations.GrafanaIntegration{
+		URL:    server.URL + "/grafana",
+		APIKey: []byte("invalid"),
+	})
+
+	if err := invalidClient.ValidateAPIKey(); err == nil {
+		t.Errorf("expected an invalid api key to fail validation\n")
+	}
+}
+
+func TestClientRejectsNonPublicTargets(t *testing.T) {
+	var received bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+
+	defer server.Close()
+
+	client := grafana.NewClient(&integrations.GrafanaIntegration{
+		URL:    server.URL,
+		APIKey: []byte("key"),
+	})
+
+	// the test server listens on the loopback address, which is checked when dialing
+	if err := client.ValidateAPIKey(); !errors.Is(err, netguard.ErrNonPublicTarget) {
+		t.Errorf("expected ErrNonPublicTarget, got %v\n", err)
+	}
+
+	if received {
+		t.Errorf("expected the request not to reach the server\n")
+	}
+}
@@ -0,0 +1,185 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// ErrIssueNotFound is returned when an issue does not exist or cannot be viewed by the user of
+// an integration
+var ErrIssueNotFound = errors.New("jira issue not found")
+
+// issueKeyRegex matches Jira issue keys, which are the key of a Jira project followed by the
+// number of the issue, such as PORTER-123
+var issueKeyRegex = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// ParseIssueKeys returns the unique Jira issue keys referenced in the given texts, in the
+// order they first appear
+func ParseIssueKeys(texts ...string) []string {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, text := range texts {
+		for _, key := range issueKeyRegex.FindAllString(text, -1) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// MatchesProject returns true if issues with the given key are linked by an integration
+func MatchesProject(jiraInt *integrations.JiraIntegration, key string) bool {
+	projectKeys := jiraInt.GetJiraProjectKeys()
+
+	if len(projectKeys) == 0 {
+		return true
+	}
+
+	projectKey := key[:strings.LastIndex(key, "-")]
+
+	for _, k := range projectKeys {
+		if strings.EqualFold(k, projectKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetIssueURL returns the URL of an issue on a Jira site
+func GetIssueURL(baseURL, key string) string {
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(baseURL, "/"), key)
+}
+
+// GetIssues returns the issues with the given keys, along with their URLs on the Jira site of
+// the first integration which links issues of their project
+func GetIssues(jiraInts []*integrations.JiraIntegration, keys []string) []*types.JiraIssue {
+	res := make([]*types.JiraIssue, 0, len(keys))
+
+	for _, key := range keys {
+		issue := &types.JiraIssue{
+			Key: key,
+		}
+
+		for _, jiraInt := range jiraInts {
+			if MatchesProject(jiraInt, key) {
+				issue.URL = GetIssueURL(jiraInt.BaseURL, key)
+				break
+			}
+		}
+
+		res = append(res, issue)
+	}
+
+	return res
+}
+
+// Client calls the Jira REST API with the credentials of an integration
+type Client struct {
+	jiraInt    *integrations.JiraIntegration
+	httpClient *http.Client
+}
+
+// NewClient returns the client of an integration. The URL of the Jira site is set by users, so
+// the client refuses to connect to addresses which are not public.
+func NewClient(jiraInt *integrations.JiraIntegration) *Client {
+	return &Client{
+		jiraInt:    jiraInt,
+		httpClient: netguard.NewHTTPClient(time.Second * 10),
+	}
+}
+
+// ValidateCredentials returns an error if the email and API token of the integration cannot
+// authenticate with the Jira site
+func (c *Client) ValidateCredentials() error {
+	statusCode, err := c.do(http.MethodGet, "/rest/api/2/myself", nil)
+
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid email or api token for %s", c.jiraInt.BaseURL)
+	} else if statusCode != http.StatusOK {
+		return fmt.Errorf("jira api returned status code %d", statusCode)
+	}
+
+	return nil
+}
+
+// AddComment adds a comment, written in Jira's wiki markup, to an issue. ErrIssueNotFound is
+// returned if the issue does not exist, which is common since not every string which looks
+// like an issue key refers to an issue.
+func (c *Client) AddComment(key, body string) error {
+	statusCode, err := c.do(
+		http.MethodPost,
+		fmt.Sprintf("/rest/api/2/issue/%s/comment", url.PathEscape(key)),
+		map[string]string{
+			"body": body,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ErrIssueNotFound
+	} else if statusCode != http.StatusCreated {
+		return fmt.Errorf("jira comments api returned status code %d for issue %s", statusCode, key)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, path string, body interface{}) (int, error) {
+	reqBody := &bytes.Buffer{}
+
+	if body != nil {
+		data, err := json.Marshal(body)
+
+		if err != nil {
+			return 0, err
+		}
+
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.jiraInt.BaseURL, "/")+path, reqBody)
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.SetBasicAuth(c.jiraInt.Email, string(c.jiraInt.APIToken))
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,130 @@
+package jira_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/jira"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+func TestParseIssueKeys(t *testing.T) {
+	keys := jira.ParseIssueKeys(
+		"WEB-12: fix the login page",
+		"feature/WEB-12-API-7-login",
+		"Merge branch 'main' into feature/WEB-12\n\nAlso fixes API_V2-3 and not web-4 or WEB-0",
+	)
+
+	expected := []string{"WEB-12", "API-7", "API_V2-3"}
+
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected keys %v, got %v\n", expected, keys)
+	}
+}
+
+func TestMatchesProject(t *testing.T) {
+	jiraInt := &integrations.JiraIntegration{
+		JiraProjectKeys: "WEB,API_V2",
+	}
+
+	if !jira.MatchesProject(jiraInt, "WEB-12") || !jira.MatchesProject(jiraInt, "API_V2-3") {
+		t.Errorf("expected issues of the integration's projects to match\n")
+	}
+
+	if jira.MatchesProject(jiraInt, "UTF-8") {
+		t.Errorf("expected issues of other projects not to match\n")
+	}
+
+	if !jira.MatchesProject(&integrations.JiraIntegration{}, "UTF-8") {
+		t.Errorf("expected integrations without project keys to match every issue\n")
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	// the test server listens on the loopback address
+	t.Cleanup(netguard.AllowLocalTargets())
+
+	comments := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if email, token, ok := r.BasicAuth(); !ok || email != "deploys@acme.com" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/rest/api/2/issue/WEB-12/comment":
+			body := make(map[string]string)
+
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			comments["WEB-12"] = body["body"]
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	client := jira.NewClient(&integrations.JiraIntegration{
+		BaseURL:  server.URL,
+		Email:    "deploys@acme.com",
+		APIToken: []byte("token"),
+	})
+
+	if err := client.AddComment("WEB-12", "Deployed"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if comments["WEB-12"] != "Deployed" {
+		t.Errorf("expected comment to be posted, got %q\n", comments["WEB-12"])
+	}
+
+	if err := client.AddComment("UTF-8", "Deployed"); !errors.Is(err, jira.ErrIssueNotFound) {
+		t.Errorf("expected issue not found error, got %v\n", err)
+	}
+
+	invalidClient := jira.NewClient(&integrations.JiraIntegration{
+		BaseURL:  server.URL,
+		Email:    "deploys@acme.com",
+		APIToken: []byte("invalid"),
+	})
+
+	if err := invalidClient.ValidateCredentials(); err == nil {
+		t.Errorf("expected invalid credentials to fail validation\n")
+	}
+}
+
+func TestClientRejectsNonPublicTargets(t *testing.T) {
+	var received bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+
+	defer server.Close()
+
+	client := jira.NewClient(&integrations.JiraIntegration{
+		BaseURL:  server.URL,
+		Email:    "deploys@acme.com",
+		APIToken: []byte("token"),
+	})
+
+	// the test server listens on the loopback address, which is checked when dialing
+	if err := client.ValidateCredentials(); !errors.Is(err, netguard.ErrNonPublicTarget) {
+		t.Errorf("expected ErrNonPublicTarget, got %v\n", err)
+	}
+
+	if received {
+		t.Errorf("expected the request not to reach the server\n")
+	}
+}
@@ -0,0 +1,225 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// maxIssueIdentifiers is the maximum number of issue identifiers which are parsed from a
+// branch, since each identifier is looked up in Linear
+const maxIssueIdentifiers = 5
+
+// ErrIssueNotFound is returned when an issue does not exist or cannot be viewed with the API
+// key of an integration
+var ErrIssueNotFound = errors.New("linear issue not found")
+
+// APIURL is the URL of the Linear GraphQL API
+var APIURL = "https://api.linear.app/graphql"
+
+// issueIdentifierRegex matches Linear issue identifiers, which are the key of a team followed
+// by the number of the issue. Branches created from Linear use lowercase identifiers, such as
+// alice/eng-123-fix-login.
+var issueIdentifierRegex = regexp.MustCompile(`(?i)\b[a-z][a-z0-9]{0,6}-[1-9][0-9]*\b`)
+
+// ParseIssueIdentifiers returns the unique, uppercase Linear issue identifiers referenced in a
+// branch name, in the order they appear
+func ParseIssueIdentifiers(branch string) []string {
+	identifiers := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range issueIdentifierRegex.FindAllString(branch, -1) {
+		identifier := strings.ToUpper(match)
+
+		if seen[identifier] {
+			continue
+		}
+
+		seen[identifier] = true
+		identifiers = append(identifiers, identifier)
+
+		if len(identifiers) == maxIssueIdentifiers {
+			break
+		}
+	}
+
+	return identifiers
+}
+
+// Attachment links a URL to a Linear issue. Linear updates the existing attachment of an issue
+// with the same URL, so attachments can be created repeatedly to update their subtitle.
+type Attachment struct {
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// Client calls the Linear GraphQL API with the API key of an integration
+type Client struct {
+	linearInt  *integrations.LinearIntegration
+	httpClient *http.Client
+}
+
+func NewClient(linearInt *integrations.LinearIntegration) *Client {
+	return &Client{
+		linearInt: linearInt,
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+// ValidateAPIKey returns an error if the API key of the integration cannot authenticate with
+// Linear
+func (c *Client) ValidateAPIKey() error {
+	data := &struct {
+		Viewer struct {
+			ID string `json:"id"`
+		} `json:"viewer"`
+	}{}
+
+	if err := c.query(`query { viewer { id } }`, nil, data); err != nil {
+		return err
+	}
+
+	if data.Viewer.ID == "" {
+		return fmt.Errorf("invalid api key")
+	}
+
+	return nil
+}
+
+// GetIssueID returns the ID of the issue with the given identifier, or ErrIssueNotFound if the
+// issue does not exist
+func (c *Client) GetIssueID(identifier string) (string, error) {
+	data := &struct {
+		Issue *struct {
+			ID string `json:"id"`
+		} `json:"issue"`
+	}{}
+
+	err := c.query(
+		`query Issue($id: String!) { issue(id: $id) { id } }`,
+		map[string]interface{}{"id": identifier},
+		data,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	if data.Issue == nil {
+		return "", ErrIssueNotFound
+	}
+
+	return data.Issue.ID, nil
+}
+
+// CreateAttachment creates or updates an attachment on an issue
+func (c *Client) CreateAttachment(issueID string, attachment *Attachment) error {
+	data := &struct {
+		AttachmentCreate struct {
+			Success bool `json:"success"`
+		} `json:"attachmentCreate"`
+	}{}
+
+	err := c.query(
+		`mutation AttachmentCreate($input: AttachmentCreateInput!) { attachmentCreate(input: $input) { success } }`,
+		map[string]interface{}{
+			"input": map[string]interface{}{
+				"issueId":  issueID,
+				"url":      attachment.URL,
+				"title":    attachment.Title,
+				"subtitle": attachment.Subtitle,
+			},
+		},
+		data,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !data.AttachmentCreate.Success {
+		return fmt.Errorf("linear did not create the attachment")
+	}
+
+	return nil
+}
+
+func (c *Client) query(query string, variables map[string]interface{}, data interface{}) error {
+	body, err := json.Marshal(&graphQLRequest{
+		Query:     query,
+		Variables: variables,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, APIURL, bytes.NewBuffer(body))
+
+	if err != nil {
+		return err
+	}
+
+	// personal API keys are sent without a token type
+	req.Header.Set("Authorization", string(c.linearInt.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid api key")
+	}
+
+	res := &graphQLResponse{}
+
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("linear api returned status code %d", resp.StatusCode)
+	}
+
+	for _, gqlErr := range res.Errors {
+		// lookups of issues which do not exist return an error instead of an empty result
+		if strings.Contains(strings.ToLower(gqlErr.Message), "entity not found") {
+			return ErrIssueNotFound
+		}
+	}
+
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("linear api returned an error: %s", res.Errors[0].Message)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("linear api returned status code %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(res.Data, data)
+}
@@ -0,0 +1,113 @@
+package linear_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/linear"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestParseIssueIdentifiers(t *testing.T) {
+	identifiers := linear.ParseIssueIdentifiers("alice/eng-123-fix-login-ENG-123-and-web2-7-not-eng-0")
+
+	expected := []string{"ENG-123", "WEB2-7"}
+
+	if !reflect.DeepEqual(identifiers, expected) {
+		t.Errorf("expected identifiers %v, got %v\n", expected, identifiers)
+	}
+
+	identifiers = linear.ParseIssueIdentifiers("a-1-b-2-c-3-d-4-e-5-f-6")
+
+	if len(identifiers) != 5 {
+		t.Errorf("expected at most 5 identifiers, got %v\n", identifiers)
+	}
+}
+
+func TestCreateAttachment(t *testing.T) {
+	attachments := make(map[string]map[string]interface{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		req := &struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}{}
+
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if input, ok := req.Variables["input"].(map[string]interface{}); ok {
+			attachments[input["url"].(string)] = input
+			w.Write([]byte(`{"data":{"attachmentCreate":{"success":true}}}`))
+			return
+		}
+
+		if req.Variables["id"] == "ENG-123" {
+			w.Write([]byte(`{"data":{"issue":{"id":"issue-1"}}}`))
+			return
+		}
+
+		w.Write([]byte(`{"data":null,"errors":[{"message":"Entity not found: Issue"}]}`))
+	}))
+
+	defer server.Close()
+
+	prevURL := linear.APIURL
+	linear.APIURL = server.URL
+	defer func() { linear.APIURL = prevURL }()
+
+	client := linear.NewClient(&integrations.LinearIntegration{
+		APIKey: []byte("key"),
+	})
+
+	issueID, err := client.GetIssueID("ENG-123")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if issueID != "issue-1" {
+		t.Errorf("expected issue id issue-1, got %s\n", issueID)
+	}
+
+	if _, err := client.GetIssueID("ENG-4"); !errors.Is(err, linear.ErrIssueNotFound) {
+		t.Errorf("expected issue not found error, got %v\n", err)
+	}
+
+	err = client.CreateAttachment(issueID, &linear.Attachment{
+		URL:      "https://dashboard.porter.run/preview-environments/details/1",
+		Title:    "Porter preview: web",
+		Subtitle: "Deployed",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	attachment, ok := attachments["https://dashboard.porter.run/preview-environments/details/1"]
+
+	if !ok || attachment["issueId"] != "issue-1" || attachment["subtitle"] != "Deployed" {
+		t.Errorf("expected an attachment on issue-1, got %v\n", attachments)
+	}
+
+	invalidClient := linear.NewClient(&integrations.LinearIntegration{
+		APIKey: []byte("invalid"),
+	})
+
+	if err := invalidClient.ValidateAPIKey(); err == nil {
+		t.Errorf("expected an invalid api key to fail validation\n")
+	}
+}
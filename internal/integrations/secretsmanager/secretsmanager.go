@@ -0,0 +1,104 @@
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// Secret is the current version of a secret in AWS Secrets Manager
+type Secret struct {
+	VersionID string
+	Values    map[string]string
+}
+
+// GetSecret reads the current version of a secret with the credentials of an AWS integration.
+// The secret can be referenced by name or by ARN, in which case it's read from the region of
+// the ARN.
+func GetSecret(ctx context.Context, awsInt *ints.AWSIntegration, secretID string) (*Secret, error) {
+	region := awsInt.AWSRegion
+
+	if parsed, err := arn.Parse(secretID); err == nil {
+		region = parsed.Region
+	}
+
+	if region == "" {
+		return nil, fmt.Errorf("the region of secret %s is unknown: use the ARN of the secret or set the region of the AWS integration", secretID)
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+		Credentials: credentials.NewStaticCredentials(
+			string(awsInt.AWSAccessKeyID),
+			string(awsInt.AWSSecretAccessKey),
+			string(awsInt.AWSSessionToken),
+		),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := secretsmanager.New(sess).GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.SecretString == nil {
+		return nil, fmt.Errorf("secret %s has no string value", secretID)
+	}
+
+	values, err := ParseSecretValues(*out.SecretString)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse secret %s: %w", secretID, err)
+	}
+
+	return &Secret{
+		VersionID: aws.StringValue(out.VersionId),
+		Values:    values,
+	}, nil
+}
+
+// ParseSecretValues parses the value of a secret, which must be a JSON object, into a map of keys
+// and values. Null values are stored as empty strings, and other values which aren't strings as
+// their JSON encoding.
+func ParseSecretValues(secretString string) (map[string]string, error) {
+	raw := make(map[string]interface{})
+
+	if err := json.Unmarshal([]byte(secretString), &raw); err != nil {
+		return nil, fmt.Errorf("the value of the secret must be a JSON object of keys and values")
+	}
+
+	values := make(map[string]string)
+
+	for key, val := range raw {
+		switch v := val.(type) {
+		case string:
+			values[key] = v
+		case nil:
+			values[key] = ""
+		default:
+			// numbers, booleans and nested objects are stored as their JSON encoding
+			encoded, err := json.Marshal(v)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[key] = string(encoded)
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,31 @@
+package secretsmanager_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/secretsmanager"
+)
+
+func TestParseSecretValues(t *testing.T) {
+	values, err := secretsmanager.ParseSecretValues(`{"DB_USER":"admin","DB_PORT":5432,"DEBUG":false,"EMPTY":null}`)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	expected := map[string]string{
+		"DB_USER": "admin",
+		"DB_PORT": "5432",
+		"DEBUG":   "false",
+		"EMPTY":   "",
+	}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v\n", expected, values)
+	}
+
+	if _, err := secretsmanager.ParseSecretValues("plaintext-password"); err == nil {
+		t.Errorf("expected error for a secret which isn't a JSON object\n")
+	}
+}
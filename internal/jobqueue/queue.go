@@ -0,0 +1,223 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Handler runs a single job. Returning an error fails the attempt, and the job is retried
+// with a backoff until it runs out of attempts.
+type Handler func(ctx context.Context, job *models.BackgroundJob) error
+
+type QueueOpts struct {
+	// The number of jobs which run at the same time
+	Workers int
+
+	// How often idle workers check for jobs which are ready to run
+	PollInterval time.Duration
+
+	// The number of times a job is attempted before it is marked as dead
+	MaxAttempts uint
+
+	// The maximum duration of an attempt. Jobs which have been running for longer are
+	// assumed to belong to a worker which has stopped, and are claimed again.
+	Timeout time.Duration
+}
+
+// Queue is a job queue which stores jobs in the database, so that jobs survive restarts
+// and can be run by any server instance
+type Queue struct {
+	repo   repository.Repository
+	logger *logger.Logger
+	opts   *QueueOpts
+
+	handlersMu sync.RWMutex
+	handlers   map[string]Handler
+}
+
+func NewQueue(repo repository.Repository, logger *logger.Logger, opts *QueueOpts) *Queue {
+	return &Queue{
+		repo:     repo,
+		logger:   logger,
+		opts:     opts,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register sets the handler which runs the jobs of a kind
+func (q *Queue) Register(kind string, handler Handler) {
+	q.handlersMu.Lock()
+	defer q.handlersMu.Unlock()
+
+	q.handlers[kind] = handler
+}
+
+// Enqueue stores a job with a JSON-encoded payload, which runs as soon as a worker is free
+func (q *Queue) Enqueue(projectID uint, kind string, payload interface{}) (*models.BackgroundJob, error) {
+	job, err := q.NewJob(projectID, kind, payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return q.repo.BackgroundJob().CreateBackgroundJob(job)
+}
+
+// NewJob returns a job with a JSON-encoded payload without storing it, so that it can be
+// stored in the same transaction as the change which caused it
+func (q *Queue) NewJob(projectID uint, kind string, payload interface{}) (*models.BackgroundJob, error) {
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not encode payload of %s job: %w", kind, err)
+	}
+
+	return &models.BackgroundJob{
+		ProjectID:   projectID,
+		Kind:        kind,
+		Payload:     data,
+		Status:      types.BackgroundJobQueued,
+		MaxAttempts: q.opts.MaxAttempts,
+		RunAfter:    time.Now().UTC(),
+	}, nil
+}
+
+// Requeue resets the attempts of a job, so that a dead job runs again
+func (q *Queue) Requeue(job *models.BackgroundJob) (*models.BackgroundJob, error) {
+	job.Status = types.BackgroundJobQueued
+	job.Attempts = 0
+	job.RunAfter = time.Now().UTC()
+	job.LockedAt = nil
+
+	return q.repo.BackgroundJob().UpdateBackgroundJob(job)
+}
+
+// Start runs the workers of the queue until the context is cancelled
+func (q *Queue) Start(ctx context.Context) {
+	for i := 0; i < q.opts.Workers; i++ {
+		go q.work(ctx)
+	}
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		ran, err := q.runNext(ctx)
+
+		if err != nil {
+			q.logger.Error().Err(err).Msg("error running background job")
+		}
+
+		// only wait for the next poll when there are no jobs which are ready to run
+		if ran && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(q.opts.PollInterval):
+		}
+	}
+}
+
+// runNext claims the next job which is ready to run and runs it. It returns false if there
+// were no jobs which were ready to run.
+func (q *Queue) runNext(ctx context.Context) (bool, error) {
+	now := time.Now().UTC()
+
+	job, err := q.repo.BackgroundJob().ClaimBackgroundJob(now, now.Add(-q.opts.Timeout))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	runErr := q.run(ctx, job)
+
+	if runErr == nil {
+		job.Status = types.BackgroundJobSucceeded
+		job.LastError = ""
+	} else if job.Attempts >= job.MaxAttempts {
+		job.Status = types.BackgroundJobDead
+		job.LastError = runErr.Error()
+
+		q.logger.Error().Err(runErr).Msgf("%s job %d failed %d times and will not be retried", job.Kind, job.ID, job.Attempts)
+	} else {
+		job.Status = types.BackgroundJobQueued
+		job.LastError = runErr.Error()
+		job.RunAfter = time.Now().UTC().Add(backoff(job.Attempts))
+	}
+
+	job.LockedAt = nil
+
+	if _, err := q.repo.BackgroundJob().UpdateBackgroundJob(job); err != nil {
+		return true, fmt.Errorf("could not update %s job %d: %w", job.Kind, job.ID, err)
+	}
+
+	return true, nil
+}
+
+func (q *Queue) run(ctx context.Context, job *models.BackgroundJob) (err error) {
+	q.handlersMu.RLock()
+	handler, ok := q.handlers[job.Kind]
+	q.handlersMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no handler registered for job kind %s", job.Kind)
+	}
+
+	// a panicking handler fails the attempt rather than stopping the worker
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, q.opts.Timeout)
+	defer cancel()
+
+	return handler(ctx, job)
+}
+
+// DecodePayload decodes the JSON-encoded payload of a job
+func DecodePayload(job *models.BackgroundJob, payload interface{}) error {
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return fmt.Errorf("could not decode payload of %s job %d: %w", job.Kind, job.ID, err)
+	}
+
+	return nil
+}
+
+const (
+	minBackoff = 30 * time.Second
+	maxBackoff = time.Hour
+)
+
+// backoff returns the time to wait before the next attempt of a job, which doubles
+// after every failed attempt
+func backoff(attempts uint) time.Duration {
+	wait := minBackoff
+
+	for i := uint(1); i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+
+	return wait
+}
@@ -0,0 +1,25 @@
+package jobqueue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts uint
+		expected time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{4, 4 * time.Minute},
+		{8, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, test := range tests {
+		if got := backoff(test.attempts); got != test.expected {
+			t.Errorf("incorrect backoff after %d attempts: expected %s, got %s\n", test.attempts, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// UserIDLabel is set on service accounts and bindings created for a Porter user
+	UserIDLabel = "porter.run/user-id"
+
+	// ExpiresAtAnnotation is set on service accounts created for a Porter user, and is
+	// refreshed each time a kubeconfig is issued for the service account
+	ExpiresAtAnnotation = "porter.run/expires-at"
+
+	// defaultServiceAccountNamespace is the namespace of service accounts which are
+	// granted access to all namespaces
+	defaultServiceAccountNamespace = "default"
+
+	DefaultExpirySeconds int64 = 3600
+	MinExpirySeconds     int64 = 600
+	MaxExpirySeconds     int64 = 86400
+)
+
+var ErrRoleNotSupported = fmt.Errorf("kubeconfigs cannot be issued for this role")
+
+// ClusterRoleForPorterRole maps a Porter project role to one of the default user-facing
+// Kubernetes cluster roles
+func ClusterRoleForPorterRole(kind types.RoleKind) (string, error) {
+	switch kind {
+	case types.RoleAdmin:
+		return "admin", nil
+	case types.RoleDeveloper:
+		return "edit", nil
+	case types.RoleViewer:
+		return "view", nil
+	default:
+		return "", ErrRoleNotSupported
+	}
+}
+
+type ScopedKubeconfigOpts struct {
+	UserID   uint
+	RoleKind types.RoleKind
+
+	// if set, access is limited to this namespace. Otherwise, the role is granted in all
+	// namespaces.
+	Namespace string
+
+	ExpirySeconds int64
+}
+
+// CreateScopedKubeconfig creates (or reuses) a service account for a Porter user, binds it to
+// the cluster role matching the user's Porter role, and returns a kubeconfig which uses a
+// time-limited token for the service account.
+func CreateScopedKubeconfig(
+	clientset kubernetes.Interface,
+	cluster *models.Cluster,
+	opts *ScopedKubeconfigOpts,
+) (*types.GetTemporaryKubeconfigResponse, error) {
+	clusterRole, err := ClusterRoleForPorterRole(opts.RoleKind)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expirySeconds := opts.ExpirySeconds
+
+	if expirySeconds == 0 {
+		expirySeconds = DefaultExpirySeconds
+	} else if expirySeconds < MinExpirySeconds || expirySeconds > MaxExpirySeconds {
+		return nil, fmt.Errorf("expiry must be between %d and %d seconds", MinExpirySeconds, MaxExpirySeconds)
+	}
+
+	saNamespace := opts.Namespace
+
+	if saNamespace == "" {
+		saNamespace = defaultServiceAccountNamespace
+	}
+
+	name := fmt.Sprintf("porter-user-%d", opts.UserID)
+
+	// service accounts with access to all namespaces are named separately, so that a
+	// namespaced kubeconfig never reuses a cluster-wide binding
+	if opts.Namespace == "" {
+		name = fmt.Sprintf("porter-user-%d-cluster", opts.UserID)
+	}
+	expiresAt := time.Now().Add(time.Duration(expirySeconds) * time.Second)
+
+	// expired service accounts of other users are removed on a best-effort basis
+	deleteExpiredServiceAccounts(clientset, saNamespace)
+
+	if err := upsertServiceAccount(clientset, saNamespace, name, opts.UserID, expiresAt); err != nil {
+		return nil, err
+	}
+
+	if opts.Namespace != "" {
+		err = upsertRoleBinding(clientset, saNamespace, name, opts.UserID, clusterRole)
+	} else {
+		err = upsertClusterRoleBinding(clientset, saNamespace, name, opts.UserID, clusterRole)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenReq, err := clientset.CoreV1().ServiceAccounts(saNamespace).CreateToken(
+		context.Background(),
+		name,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				ExpirationSeconds: &expirySeconds,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := clientcmd.Write(*buildKubeconfig(cluster, name, saNamespace, tokenReq.Status.Token))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetTemporaryKubeconfigResponse{
+		Kubeconfig: kubeconfig,
+		Namespace:  opts.Namespace,
+		ExpiresAt:  tokenReq.Status.ExpirationTimestamp.Time,
+	}, nil
+}
+
+func buildKubeconfig(cluster *models.Cluster, saName, namespace, token string) *api.Config {
+	contextName := fmt.Sprintf("%s-%s", cluster.Name, saName)
+
+	return &api.Config{
+		Clusters: map[string]*api.Cluster{
+			cluster.Name: {
+				Server:                   cluster.Server,
+				TLSServerName:            cluster.TLSServerName,
+				InsecureSkipTLSVerify:    cluster.InsecureSkipTLSVerify,
+				CertificateAuthorityData: cluster.CertificateAuthorityData,
+			},
+		},
+		AuthInfos: map[string]*api.AuthInfo{
+			saName: {
+				Token: token,
+			},
+		},
+		Contexts: map[string]*api.Context{
+			contextName: {
+				Cluster:   cluster.Name,
+				AuthInfo:  saName,
+				Namespace: namespace,
+			},
+		},
+		CurrentContext: contextName,
+	}
+}
+
+func upsertServiceAccount(clientset kubernetes.Interface, namespace, name string, userID uint, expiresAt time.Time) error {
+	sas := clientset.CoreV1().ServiceAccounts(namespace)
+
+	sa, err := sas.Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		_, err = sas.Create(context.Background(), &v1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					UserIDLabel: fmt.Sprintf("%d", userID),
+				},
+				Annotations: map[string]string{
+					ExpiresAtAnnotation: expiresAt.UTC().Format(time.RFC3339),
+				},
+			},
+		}, metav1.CreateOptions{})
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if sa.Annotations == nil {
+		sa.Annotations = make(map[string]string)
+	}
+
+	// only extend the expiry, since an earlier kubeconfig may still be valid for longer
+	if curr, err := time.Parse(time.RFC3339, sa.Annotations[ExpiresAtAnnotation]); err == nil && curr.After(expiresAt) {
+		return nil
+	}
+
+	sa.Annotations[ExpiresAtAnnotation] = expiresAt.UTC().Format(time.RFC3339)
+
+	_, err = sas.Update(context.Background(), sa, metav1.UpdateOptions{})
+
+	return err
+}
+
+func upsertRoleBinding(clientset kubernetes.Interface, namespace, name string, userID uint, clusterRole string) error {
+	bindings := clientset.RbacV1().RoleBindings(namespace)
+
+	binding, err := bindings.Get(context.Background(), name, metav1.GetOptions{})
+
+	if err == nil && binding.RoleRef.Name == clusterRole {
+		return nil
+	} else if err == nil {
+		// the role ref of a binding cannot be updated, so the binding is recreated when the
+		// user's role changes
+		if err := bindings.Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	_, err = bindings.Create(context.Background(), &rbacv1.RoleBinding{
+		ObjectMeta: bindingMeta(name, namespace, userID),
+		Subjects:   bindingSubjects(name, namespace),
+		RoleRef:    clusterRoleRef(clusterRole),
+	}, metav1.CreateOptions{})
+
+	return err
+}
+
+func upsertClusterRoleBinding(clientset kubernetes.Interface, saNamespace, name string, userID uint, clusterRole string) error {
+	bindings := clientset.RbacV1().ClusterRoleBindings()
+
+	binding, err := bindings.Get(context.Background(), name, metav1.GetOptions{})
+
+	if err == nil && binding.RoleRef.Name == clusterRole {
+		return nil
+	} else if err == nil {
+		if err := bindings.Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	_, err = bindings.Create(context.Background(), &rbacv1.ClusterRoleBinding{
+		ObjectMeta: bindingMeta(name, "", userID),
+		Subjects:   bindingSubjects(name, saNamespace),
+		RoleRef:    clusterRoleRef(clusterRole),
+	}, metav1.CreateOptions{})
+
+	return err
+}
+
+// deleteExpiredServiceAccounts removes Porter user service accounts in a namespace whose
+// kubeconfigs have all expired, along with their bindings
+func deleteExpiredServiceAccounts(clientset kubernetes.Interface, namespace string) {
+	saList, err := clientset.CoreV1().ServiceAccounts(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: UserIDLabel,
+	})
+
+	if err != nil {
+		return
+	}
+
+	for _, sa := range saList.Items {
+		expiresAt, err := time.Parse(time.RFC3339, sa.Annotations[ExpiresAtAnnotation])
+
+		if err != nil || time.Now().Before(expiresAt) {
+			continue
+		}
+
+		// only one of the bindings exists, depending on whether the service account was
+		// granted access to all namespaces
+		clientset.RbacV1().RoleBindings(namespace).Delete(context.Background(), sa.Name, metav1.DeleteOptions{})
+		clientset.RbacV1().ClusterRoleBindings().Delete(context.Background(), sa.Name, metav1.DeleteOptions{})
+
+		clientset.CoreV1().ServiceAccounts(namespace).Delete(context.Background(), sa.Name, metav1.DeleteOptions{})
+	}
+}
+
+func bindingMeta(name, namespace string, userID uint) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			UserIDLabel: fmt.Sprintf("%d", userID),
+		},
+	}
+}
+
+func bindingSubjects(saName, saNamespace string) []rbacv1.Subject {
+	return []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      saName,
+			Namespace: saNamespace,
+		},
+	}
+}
+
+func clusterRoleRef(clusterRole string) rbacv1.RoleRef {
+	return rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     clusterRole,
+	}
+}
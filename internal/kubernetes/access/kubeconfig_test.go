@@ -0,0 +1,109 @@
+package access_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/access"
+	"github.com/porter-dev/porter/internal/models"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func newFakeClientset() *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+
+		return true, &authenticationv1.TokenRequest{
+			Status: authenticationv1.TokenRequestStatus{
+				Token:               "test-token",
+				ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+			},
+		}, nil
+	})
+
+	return clientset
+}
+
+func TestCreateScopedKubeconfigNamespaced(t *testing.T) {
+	clientset := newFakeClientset()
+	cluster := &models.Cluster{
+		Name:   "cluster-test",
+		Server: "https://localhost",
+	}
+
+	res, err := access.CreateScopedKubeconfig(clientset, cluster, &access.ScopedKubeconfigOpts{
+		UserID:    1,
+		RoleKind:  types.RoleDeveloper,
+		Namespace: "staging",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	binding, err := clientset.RbacV1().RoleBindings("staging").Get(context.Background(), "porter-user-1", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("expected role binding to be created: %v", err)
+	}
+
+	if binding.RoleRef.Name != "edit" {
+		t.Errorf("expected developer to be bound to edit, got %s", binding.RoleRef.Name)
+	}
+
+	conf, err := clientcmd.Load(res.Kubeconfig)
+
+	if err != nil {
+		t.Fatalf("could not load kubeconfig: %v", err)
+	}
+
+	currCtx := conf.Contexts[conf.CurrentContext]
+
+	if currCtx == nil || currCtx.Namespace != "staging" {
+		t.Fatalf("expected current context in namespace staging, got %v", currCtx)
+	}
+
+	if conf.AuthInfos[currCtx.AuthInfo].Token != "test-token" {
+		t.Errorf("expected kubeconfig to use service account token")
+	}
+
+	// a role change should recreate the binding with the new role
+	_, err = access.CreateScopedKubeconfig(clientset, cluster, &access.ScopedKubeconfigOpts{
+		UserID:    1,
+		RoleKind:  types.RoleViewer,
+		Namespace: "staging",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	binding, _ = clientset.RbacV1().RoleBindings("staging").Get(context.Background(), "porter-user-1", metav1.GetOptions{})
+
+	if binding.RoleRef.Name != "view" {
+		t.Errorf("expected viewer to be bound to view, got %s", binding.RoleRef.Name)
+	}
+}
+
+func TestCreateScopedKubeconfigInvalidExpiry(t *testing.T) {
+	_, err := access.CreateScopedKubeconfig(newFakeClientset(), &models.Cluster{}, &access.ScopedKubeconfigOpts{
+		UserID:        1,
+		RoleKind:      types.RoleAdmin,
+		ExpirySeconds: 60,
+	})
+
+	if err == nil {
+		t.Errorf("expected error for expiry below minimum")
+	}
+}
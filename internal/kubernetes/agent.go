@@ -39,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -57,6 +58,10 @@ import (
 type Agent struct {
 	RESTClientGetter genericclioptions.RESTClientGetter
 	Clientset        kubernetes.Interface
+
+	// DynamicClient is returned by GetDynamicClient when set, instead of a client created
+	// from the RESTClientGetter
+	DynamicClient dynamic.Interface
 }
 
 type Message struct {
@@ -94,6 +99,22 @@ func (a *Agent) UpdateClientset() error {
 	return nil
 }
 
+// GetDynamicClient returns a dynamic client for the cluster of the Agent, for resources which
+// the Clientset has no typed client for
+func (a *Agent) GetDynamicClient() (dynamic.Interface, error) {
+	if a.DynamicClient != nil {
+		return a.DynamicClient, nil
+	}
+
+	restConf, err := a.RESTClientGetter.ToRESTConfig()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.NewForConfig(restConf)
+}
+
 // CreateConfigMap creates the configmap given the key-value pairs and namespace
 func (a *Agent) CreateConfigMap(name string, namespace string, configMap map[string]string) (*v1.ConfigMap, error) {
 	return a.Clientset.CoreV1().ConfigMaps(namespace).Create(
@@ -579,6 +600,32 @@ func (a *Agent) GetLatestVersionedSecret(name, namespace string) (*v1.Secret, ui
 	return res, latestVersion, nil
 }
 
+// GetVersionedSecret retrieves the secret which holds the secret variables of a version of an
+// env group
+func (a *Agent) GetVersionedSecret(name, namespace string, version uint) (*v1.Secret, error) {
+	listResp, err := a.Clientset.CoreV1().Secrets(namespace).List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("envgroup=%s,version=%d", name, version),
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if listResp.Items == nil || len(listResp.Items) == 0 {
+		return nil, IsNotFoundError
+	}
+
+	// if the length of the list is greater than 1, return an error -- this shouldn't happen
+	if len(listResp.Items) > 1 {
+		return nil, fmt.Errorf("multiple secrets found while searching for %s/%s and version %d", namespace, name, version)
+	}
+
+	return &listResp.Items[0], nil
+}
+
 // GetSecret retrieves the secret given its name and namespace
 func (a *Agent) GetSecret(name string, namespace string) (*v1.Secret, error) {
 	return a.Clientset.CoreV1().Secrets(namespace).Get(
@@ -878,10 +925,15 @@ func (a *Agent) StreamJobs(namespace string, selectors string, rw *websocket.Web
 
 // DeleteJob deletes the job in the given name and namespace.
 func (a *Agent) DeleteJob(name, namespace string) error {
+	// jobs orphan their pods by default, which would leave a running job's pod running
+	propagation := metav1.DeletePropagationBackground
+
 	return a.Clientset.BatchV1().Jobs(namespace).Delete(
 		context.TODO(),
 		name,
-		metav1.DeleteOptions{},
+		metav1.DeleteOptions{
+			PropagationPolicy: &propagation,
+		},
 	)
 }
 
@@ -1144,8 +1196,10 @@ func (a *Agent) DeletePod(namespace string, name string) error {
 	return err
 }
 
-// GetPodLogs streams real-time logs from a given pod.
-func (a *Agent) GetPodLogs(namespace string, name string, selectedContainer string, rw *websocket.WebsocketSafeReadWriter) error {
+// GetPodLogs streams real-time logs from a given pod. Any container in the pod can be selected,
+// including init containers and ephemeral containers. If previous is set, the logs of the
+// previous instance of the container are streamed instead.
+func (a *Agent) GetPodLogs(namespace string, name string, selectedContainer string, previous bool, rw *websocket.WebsocketSafeReadWriter) error {
 	// get the pod to read in the list of contains
 	pod, err := a.Clientset.CoreV1().Pods(namespace).Get(
 		context.Background(),
@@ -1159,29 +1213,39 @@ func (a *Agent) GetPodLogs(namespace string, name string, selectedContainer stri
 		return fmt.Errorf("Cannot get logs from pod %s: %s", name, err.Error())
 	}
 
-	// see if container is ready and able to open a stream. If not, wait for container
-	// to be ready.
-	err, _ = a.waitForPod(pod)
-
-	if err != nil && goerrors.Is(err, IsNotFoundError) {
-		return IsNotFoundError
-	} else if err != nil {
-		return fmt.Errorf("Cannot get logs from pod %s: %s", name, err.Error())
-	}
-
 	container := pod.Spec.Containers[0].Name
+	isAppContainer := true
 
 	if len(selectedContainer) > 0 {
 		container = selectedContainer
+		isAppContainer, err = findPodContainer(pod, selectedContainer)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// see if container is ready and able to open a stream. If not, wait for container
+	// to be ready. Init and ephemeral containers run before the pod is ready or independently
+	// of it, and previous logs are available regardless of the pod's state, so we don't wait.
+	if isAppContainer && !previous {
+		err, _ = a.waitForPod(pod)
+
+		if err != nil && goerrors.Is(err, IsNotFoundError) {
+			return IsNotFoundError
+		} else if err != nil {
+			return fmt.Errorf("Cannot get logs from pod %s: %s", name, err.Error())
+		}
 	}
 
 	tails := int64(400)
 
 	// follow logs
 	podLogOpts := v1.PodLogOptions{
-		Follow:    true,
+		Follow:    !previous,
 		TailLines: &tails,
 		Container: container,
+		Previous:  previous,
 	}
 
 	req := a.Clientset.CoreV1().Pods(namespace).GetLogs(name, &podLogOpts)
@@ -1263,6 +1327,30 @@ func (a *Agent) GetPodLogs(namespace string, name string, selectedContainer stri
 	return err
 }
 
+// findPodContainer checks that a container exists in a pod, and returns whether it is one of
+// the pod's app containers
+func findPodContainer(pod *v1.Pod, name string) (bool, error) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true, nil
+		}
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == name {
+			return false, nil
+		}
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return false, nil
+		}
+	}
+
+	return false, &BadRequestError{fmt.Sprintf("container %s does not exist in pod %s", name, pod.Name)}
+}
+
 // GetPodLogs streams real-time logs from a given pod.
 func (a *Agent) GetPreviousPodLogs(namespace string, name string, selectedContainer string) ([]string, error) {
 	// get the pod to read in the list of contains
@@ -1943,7 +2031,7 @@ func (a *Agent) CreateImagePullSecrets(
 			return nil, err
 		}
 
-		secretName := fmt.Sprintf("porter-%s-%d", val.ToRegistryType().Service, val.ID)
+		secretName := GetImagePullSecretName(val)
 
 		secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(
 			context.TODO(),
@@ -2008,6 +2096,171 @@ func (a *Agent) CreateImagePullSecrets(
 	return res, nil
 }
 
+// GetImagePullSecretName returns the name of the image pull secret which Porter creates for a
+// registry in each namespace that pulls from it
+func GetImagePullSecretName(reg *models.Registry) string {
+	return fmt.Sprintf("porter-%s-%d", reg.ToRegistryType().Service, reg.ID)
+}
+
+// RefreshImagePullSecrets regenerates the image pull secrets of a registry in every namespace
+// which contains one, and returns the number of namespaces whose secrets were updated. This
+// is used for registries whose credentials expire, such as ECR.
+func (a *Agent) RefreshImagePullSecrets(
+	repo repository.Repository,
+	reg *models.Registry,
+	doAuth *oauth2.Config,
+) (uint, error) {
+	secrets, err := a.Clientset.CoreV1().Secrets(metav1.NamespaceAll).List(
+		context.TODO(),
+		metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", GetImagePullSecretName(reg)).String(),
+		},
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(secrets.Items) == 0 {
+		return 0, nil
+	}
+
+	_reg := registry.Registry(*reg)
+
+	data, err := _reg.GetDockerConfigJSON(repo, doAuth)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var refreshed uint
+
+	for _, secret := range secrets.Items {
+		if secret.Type != v1.SecretTypeDockerConfigJson || bytes.Equal(secret.Data[v1.DockerConfigJsonKey], data) {
+			continue
+		}
+
+		secret.Data = map[string][]byte{
+			string(v1.DockerConfigJsonKey): data,
+		}
+
+		_, err := a.Clientset.CoreV1().Secrets(secret.Namespace).Update(
+			context.TODO(),
+			&secret,
+			metav1.UpdateOptions{},
+		)
+
+		if err != nil {
+			return refreshed, fmt.Errorf("error updating pull secret in namespace %s: %w", secret.Namespace, err)
+		}
+
+		refreshed++
+	}
+
+	return refreshed, nil
+}
+
+// PropagateImagePullSecrets creates or refreshes the image pull secrets of each registry in a
+// namespace, and adds them to the default service account of the namespace, so that pods
+// in the namespace can pull from the registries without setting imagePullSecrets. Secrets
+// are created for as many registries as possible, and the names of the secrets which were
+// created are returned along with any error.
+func (a *Agent) PropagateImagePullSecrets(
+	repo repository.Repository,
+	namespace string,
+	registries []*models.Registry,
+	doAuth *oauth2.Config,
+) ([]string, error) {
+	secretNames := make([]string, 0)
+	errs := make([]string, 0)
+
+	for _, reg := range registries {
+		secrets, err := a.CreateImagePullSecrets(repo, namespace, map[string]*models.Registry{
+			reg.Name: reg,
+		}, doAuth)
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("registry %s: %s", reg.Name, err.Error()))
+			continue
+		}
+
+		for _, secretName := range secrets {
+			secretNames = append(secretNames, secretName)
+		}
+	}
+
+	if err := a.AddImagePullSecretsToServiceAccount(namespace, "default", secretNames); err != nil {
+		errs = append(errs, fmt.Sprintf("service account: %s", err.Error()))
+	}
+
+	if len(errs) > 0 {
+		return secretNames, fmt.Errorf("error propagating image pull secrets to namespace %s: %s", namespace, strings.Join(errs, "; "))
+	}
+
+	return secretNames, nil
+}
+
+// AddImagePullSecretsToServiceAccount adds image pull secrets to a service account, if the
+// service account doesn't already reference them. If the service account doesn't exist, it
+// is created, since the default service account of a new namespace is created asynchronously.
+func (a *Agent) AddImagePullSecretsToServiceAccount(namespace, name string, secretNames []string) error {
+	if len(secretNames) == 0 {
+		return nil
+	}
+
+	sa, err := a.Clientset.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		sa = &v1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+
+		for _, secretName := range secretNames {
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, v1.LocalObjectReference{Name: secretName})
+		}
+
+		_, err = a.Clientset.CoreV1().ServiceAccounts(namespace).Create(context.TODO(), sa, metav1.CreateOptions{})
+
+		// the service account may have been created in the meantime, in which case it is updated
+		if err == nil || !errors.IsAlreadyExists(err) {
+			return err
+		}
+
+		sa, err = a.Clientset.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+
+	for _, ref := range sa.ImagePullSecrets {
+		existing[ref.Name] = true
+	}
+
+	updated := false
+
+	for _, secretName := range secretNames {
+		if !existing[secretName] {
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, v1.LocalObjectReference{Name: secretName})
+			existing[secretName] = true
+			updated = true
+		}
+	}
+
+	if !updated {
+		return nil
+	}
+
+	_, err = a.Clientset.CoreV1().ServiceAccounts(namespace).Update(context.TODO(), sa, metav1.UpdateOptions{})
+
+	return err
+}
+
 // helper that waits for pod to be ready
 func (a *Agent) waitForPod(pod *v1.Pod) (error, bool) {
 	var (
@@ -1,6 +1,7 @@
 package kubernetes_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/porter-dev/porter/internal/kubernetes"
@@ -63,3 +64,34 @@ func TestOutOfClusterConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestAddImagePullSecretsToServiceAccount(t *testing.T) {
+	k8sAgent := newAgentFixture(t, &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: "existing",
+		},
+		ImagePullSecrets: []v1.LocalObjectReference{
+			{Name: "porter-ecr-1"},
+		},
+	})
+
+	for _, namespace := range []string{"existing", "new"} {
+		err := k8sAgent.AddImagePullSecretsToServiceAccount(namespace, "default", []string{"porter-ecr-1", "porter-gcr-2"})
+
+		if err != nil {
+			t.Fatalf("namespace %s: %v", namespace, err)
+		}
+
+		sa, err := k8sAgent.Clientset.CoreV1().ServiceAccounts(namespace).Get(context.Background(), "default", metav1.GetOptions{})
+
+		if err != nil {
+			t.Fatalf("namespace %s: %v", namespace, err)
+		}
+
+		if len(sa.ImagePullSecrets) != 2 || sa.ImagePullSecrets[0].Name != "porter-ecr-1" ||
+			sa.ImagePullSecrets[1].Name != "porter-gcr-2" {
+			t.Errorf("namespace %s: unexpected image pull secrets %v", namespace, sa.ImagePullSecrets)
+		}
+	}
+}
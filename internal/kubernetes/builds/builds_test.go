@@ -0,0 +1,127 @@
+package builds_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/builds"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateBuildJob(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	job, err := builds.CreateBuildJob(clientset, &builds.BuildOpts{
+		Name:             "porter-build-1",
+		Namespace:        "default",
+		GitRepo:          "porter-dev/web",
+		CommitSHA:        "abc123",
+		FolderPath:       "./app",
+		GitToken:         "token",
+		Image:            "registry.example.com/web:abc123",
+		DockerConfigJSON: []byte(`{"auths":{}}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	build := job.Spec.Template.Spec.Containers[0]
+
+	if build.Image != builds.DefaultBuilder {
+		t.Errorf("expected default builder %s, got %s", builds.DefaultBuilder, build.Image)
+	}
+
+	expectedCommand := []string{
+		"/cnb/lifecycle/creator",
+		"-app=/workspace/source/app",
+		"-layers=/layers",
+		"registry.example.com/web:abc123",
+	}
+
+	for i, arg := range expectedCommand {
+		if build.Command[i] != arg {
+			t.Errorf("expected command argument %d to be %s, got %s", i, arg, build.Command[i])
+		}
+	}
+
+	secret, err := clientset.CoreV1().Secrets("default").Get(context.Background(), "porter-build-1", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("expected build secret to be created: %v", err)
+	}
+
+	if string(secret.Data["git-token"]) != "token" || string(secret.Data[v1.DockerConfigJsonKey]) != `{"auths":{}}` {
+		t.Errorf("unexpected build secret data: %v", secret.Data)
+	}
+
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != job.Name {
+		t.Errorf("expected build secret to be owned by the job")
+	}
+}
+
+func TestGetBuildStatus(t *testing.T) {
+	tests := map[string]struct {
+		status   batchv1.JobStatus
+		expected types.BuildStatus
+	}{
+		"queued": {
+			status:   batchv1.JobStatus{},
+			expected: types.BuildStatusQueued,
+		},
+		"running": {
+			status:   batchv1.JobStatus{Active: 1},
+			expected: types.BuildStatusRunning,
+		},
+		"succeeded": {
+			status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}},
+			},
+			expected: types.BuildStatusSucceeded,
+		},
+		"failed": {
+			status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{
+					Type:    batchv1.JobFailed,
+					Status:  v1.ConditionTrue,
+					Reason:  "BackoffLimitExceeded",
+					Message: "Job has reached the specified backoff limit",
+				}},
+			},
+			expected: types.BuildStatusFailed,
+		},
+	}
+
+	for name, test := range tests {
+		clientset := fake.NewSimpleClientset(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "porter-build-1", Namespace: "default"},
+			Status:     test.status,
+		})
+
+		status, message, finishedAt, err := builds.GetBuildStatus(clientset, "default", "porter-build-1")
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if status != test.expected {
+			t.Errorf("%s: expected status %s, got %s", name, test.expected, status)
+		}
+
+		if test.expected == types.BuildStatusFailed && message == "" {
+			t.Errorf("%s: expected a failure message", name)
+		}
+
+		if (finishedAt != nil) != (test.expected == types.BuildStatusSucceeded || test.expected == types.BuildStatusFailed) {
+			t.Errorf("%s: unexpected finish time %v", name, finishedAt)
+		}
+	}
+
+	if _, _, _, err := builds.GetBuildStatus(fake.NewSimpleClientset(), "default", "missing"); err != builds.ErrBuildJobNotFound {
+		t.Errorf("expected build job not found error, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var certificateResource = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+var ErrCertManagerNotInstalled = fmt.Errorf("cert-manager is not installed in this cluster")
+
+// ListCertificates lists the cert-manager certificates in a namespace along with their
+// issuance state
+func ListCertificates(client dynamic.Interface, namespace string) ([]*types.Certificate, error) {
+	certList, err := client.Resource(certificateResource).Namespace(namespace).List(
+		context.Background(),
+		metav1.ListOptions{},
+	)
+
+	// if the certificate CRD is not registered, the API server returns a not found error
+	if err != nil && errors.IsNotFound(err) {
+		return nil, ErrCertManagerNotInstalled
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Certificate, 0)
+
+	for _, cert := range certList.Items {
+		res = append(res, toCertificateType(&cert))
+	}
+
+	return res, nil
+}
+
+func toCertificateType(cert *unstructured.Unstructured) *types.Certificate {
+	res := &types.Certificate{
+		Name:      cert.GetName(),
+		Namespace: cert.GetNamespace(),
+		DNSNames:  make([]string, 0),
+		Status:    types.CertificateStatusUnknown,
+	}
+
+	res.SecretName, _, _ = unstructured.NestedString(cert.Object, "spec", "secretName")
+	res.IssuerKind, _, _ = unstructured.NestedString(cert.Object, "spec", "issuerRef", "kind")
+	res.IssuerName, _, _ = unstructured.NestedString(cert.Object, "spec", "issuerRef", "name")
+
+	if res.IssuerKind == "" {
+		res.IssuerKind = "Issuer"
+	}
+
+	if dnsNames, found, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames"); found {
+		res.DNSNames = dnsNames
+	}
+
+	// certificates rendered by a chart carry the standard instance label
+	res.ReleaseName = cert.GetLabels()["app.kubernetes.io/instance"]
+
+	res.NotBefore = nestedTime(cert.Object, "status", "notBefore")
+	res.NotAfter = nestedTime(cert.Object, "status", "notAfter")
+	res.RenewalTime = nestedTime(cert.Object, "status", "renewalTime")
+	res.LastFailureTime = nestedTime(cert.Object, "status", "lastFailureTime")
+
+	res.FailedIssuanceAttempts, _, _ = unstructured.NestedInt64(cert.Object, "status", "failedIssuanceAttempts")
+
+	conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+
+	var readyStatus, readyReason, readyMessage string
+	var issuing bool
+
+	for _, rawCond := range conditions {
+		cond, ok := rawCond.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		condType, _ := cond["type"].(string)
+		condStatus, _ := cond["status"].(string)
+
+		switch condType {
+		case "Ready":
+			readyStatus = condStatus
+			readyReason, _ = cond["reason"].(string)
+			readyMessage, _ = cond["message"].(string)
+		case "Issuing":
+			issuing = condStatus == "True"
+		}
+	}
+
+	switch {
+	case readyStatus == "True":
+		res.Status = types.CertificateStatusReady
+	case res.LastFailureTime != nil || res.FailedIssuanceAttempts > 0:
+		res.Status = types.CertificateStatusFailed
+	case issuing:
+		res.Status = types.CertificateStatusIssuing
+	case readyStatus == "False":
+		res.Status = types.CertificateStatusFailed
+	}
+
+	if res.Status != types.CertificateStatusReady {
+		res.Reason = readyReason
+		res.Message = readyMessage
+	}
+
+	return res
+}
+
+func nestedTime(obj map[string]interface{}, fields ...string) *time.Time {
+	val, found, err := unstructured.NestedString(obj, fields...)
+
+	if !found || err != nil {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
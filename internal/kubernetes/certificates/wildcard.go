@@ -0,0 +1,449 @@
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// WildcardNamespace is the namespace which wildcard certificates are issued in, which is the
+	// namespace that cert-manager reads the secrets of cluster issuers from
+	WildcardNamespace = "cert-manager"
+
+	// WildcardCertificateLabel is set on the resources of a wildcard certificate, and on the
+	// secrets which the certificate is copied to
+	WildcardCertificateLabel = "porter.run/wildcard-certificate"
+
+	letsEncryptServer = "https://acme-v02.api.letsencrypt.org/directory"
+)
+
+var clusterIssuerResource = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "clusterissuers",
+}
+
+// ErrCertificateNotIssued is returned when a wildcard certificate is copied before it's issued
+var ErrCertificateNotIssued = fmt.Errorf("the certificate has not been issued yet")
+
+// WildcardCertificateOpts are the options of a wildcard certificate, which is issued for a
+// domain and its subdomains through a DNS-01 challenge with Route53 or Cloudflare
+type WildcardCertificateOpts struct {
+	// Name is the name of the cluster issuer and the certificate, and the prefix of their secrets
+	Name string
+
+	// ID identifies the certificate in the label of its resources
+	ID string
+
+	Domain string
+	Email  string
+
+	Provider types.DNSProvider
+
+	// the credentials which cert-manager creates the challenge records with. Route53 requires
+	// an access key, and Cloudflare requires an API token.
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	CloudflareAPIToken string
+}
+
+// ApplyWildcardCertificate creates or updates the cluster issuer and the certificate of a
+// wildcard certificate, along with the secret which stores the credentials of the issuer.
+// cert-manager issues the certificate and renews it before it expires.
+func ApplyWildcardCertificate(
+	clientset kubernetes.Interface,
+	client dynamic.Interface,
+	opts *WildcardCertificateOpts,
+) error {
+	credentials := make(map[string][]byte)
+
+	switch opts.Provider {
+	case types.DNSProviderRoute53:
+		credentials["secret-access-key"] = []byte(opts.AWSSecretAccessKey)
+	case types.DNSProviderCloudflare:
+		credentials["api-token"] = []byte(opts.CloudflareAPIToken)
+	default:
+		return fmt.Errorf("unsupported dns provider %s", opts.Provider)
+	}
+
+	err := applySecret(clientset, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.Name + "-dns",
+			Namespace: WildcardNamespace,
+			Labels:    map[string]string{WildcardCertificateLabel: opts.ID},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: credentials,
+	})
+
+	if err != nil && errors.IsNotFound(err) {
+		// the namespace of cert-manager does not exist
+		return ErrCertManagerNotInstalled
+	} else if err != nil {
+		return err
+	}
+
+	issuer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "ClusterIssuer",
+		"metadata":   getWildcardMetadata(opts, ""),
+		"spec":       getClusterIssuerSpec(opts),
+	}}
+
+	if err := applyUnstructured(client.Resource(clusterIssuerResource), issuer); err != nil {
+		return err
+	}
+
+	cert := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata":   getWildcardMetadata(opts, WildcardNamespace),
+		"spec": map[string]interface{}{
+			"secretName": opts.Name + "-tls",
+			"dnsNames":   []interface{}{"*." + opts.Domain, opts.Domain},
+			"issuerRef": map[string]interface{}{
+				"kind": "ClusterIssuer",
+				"name": opts.Name,
+			},
+		},
+	}}
+
+	return applyUnstructured(client.Resource(certificateResource).Namespace(WildcardNamespace), cert)
+}
+
+// GetWildcardCertificate reads the issuance state of a wildcard certificate
+func GetWildcardCertificate(client dynamic.Interface, name string) (*types.Certificate, error) {
+	cert, err := client.Resource(certificateResource).Namespace(WildcardNamespace).Get(
+		context.Background(),
+		name,
+		metav1.GetOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toCertificateType(cert), nil
+}
+
+// DeleteWildcardCertificate deletes the certificate, the cluster issuer and the secrets of a
+// wildcard certificate, including the secrets which the certificate was copied to
+func DeleteWildcardCertificate(clientset kubernetes.Interface, client dynamic.Interface, name, id string) error {
+	err := client.Resource(certificateResource).Namespace(WildcardNamespace).Delete(
+		context.Background(),
+		name,
+		metav1.DeleteOptions{},
+	)
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	err = client.Resource(clusterIssuerResource).Delete(context.Background(), name, metav1.DeleteOptions{})
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	for _, secretName := range []string{name + "-dns", name + "-tls", name + "-account-key"} {
+		err := clientset.CoreV1().Secrets(WildcardNamespace).Delete(context.Background(), secretName, metav1.DeleteOptions{})
+
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	_, err = removeCopies(clientset, id, "", map[string]bool{})
+
+	return err
+}
+
+// DistributeWildcardCertificate copies the issued certificate of a wildcard certificate to a
+// TLS secret in each of the namespaces, or in every namespace except the system namespaces if
+// no namespaces are given. Copies which are out of date, such as after the certificate was
+// renewed, are updated, and copies in namespaces which are no longer targeted are deleted.
+// The namespaces which the certificate was copied to are returned.
+func DistributeWildcardCertificate(
+	clientset kubernetes.Interface,
+	name, id, secretName string,
+	namespaces []string,
+) ([]string, error) {
+	source, err := clientset.CoreV1().Secrets(WildcardNamespace).Get(context.Background(), name+"-tls", metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, ErrCertificateNotIssued
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(source.Data[v1.TLSCertKey]) == 0 || len(source.Data[v1.TLSPrivateKeyKey]) == 0 {
+		return nil, ErrCertificateNotIssued
+	}
+
+	targets, err := getTargetNamespaces(clientset, namespaces)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(targets))
+	errs := make([]error, 0)
+
+	for _, namespace := range targets {
+		err := copyCertificate(clientset, source, namespace, secretName, id)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", namespace, err))
+			continue
+		}
+
+		res = append(res, namespace)
+	}
+
+	keep := make(map[string]bool)
+
+	for _, namespace := range targets {
+		keep[namespace+"/"+secretName] = true
+	}
+
+	if _, err := removeCopies(clientset, id, secretName, keep); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return res, fmt.Errorf("error copying the certificate to %d namespaces: %v", len(errs), errs)
+	}
+
+	return res, nil
+}
+
+func getWildcardMetadata(opts *WildcardCertificateOpts, namespace string) map[string]interface{} {
+	res := map[string]interface{}{
+		"name": opts.Name,
+		"labels": map[string]interface{}{
+			WildcardCertificateLabel: opts.ID,
+		},
+	}
+
+	if namespace != "" {
+		res["namespace"] = namespace
+	}
+
+	return res
+}
+
+// getClusterIssuerSpec returns the spec of a Let's Encrypt cluster issuer which solves DNS-01
+// challenges with the DNS provider of the certificate
+func getClusterIssuerSpec(opts *WildcardCertificateOpts) map[string]interface{} {
+	dns01 := make(map[string]interface{})
+
+	switch opts.Provider {
+	case types.DNSProviderRoute53:
+		// route53 is a global service, so the region is only used to sign requests
+		dns01["route53"] = map[string]interface{}{
+			"region":      "us-east-1",
+			"accessKeyID": opts.AWSAccessKeyID,
+			"secretAccessKeySecretRef": map[string]interface{}{
+				"name": opts.Name + "-dns",
+				"key":  "secret-access-key",
+			},
+		}
+	case types.DNSProviderCloudflare:
+		dns01["cloudflare"] = map[string]interface{}{
+			"apiTokenSecretRef": map[string]interface{}{
+				"name": opts.Name + "-dns",
+				"key":  "api-token",
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"acme": map[string]interface{}{
+			"server": letsEncryptServer,
+			"email":  opts.Email,
+			"privateKeySecretRef": map[string]interface{}{
+				"name": opts.Name + "-account-key",
+			},
+			"solvers": []interface{}{
+				map[string]interface{}{"dns01": dns01},
+			},
+		},
+	}
+}
+
+// getTargetNamespaces returns the namespaces which a certificate is copied to, in order
+func getTargetNamespaces(clientset kubernetes.Interface, namespaces []string) ([]string, error) {
+	if len(namespaces) > 0 {
+		res := append([]string{}, namespaces...)
+		sort.Strings(res)
+
+		return res, nil
+	}
+
+	nsList, err := clientset.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0)
+
+	for _, ns := range nsList.Items {
+		if !isSystemNamespace(ns.Name) && ns.Status.Phase != v1.NamespaceTerminating {
+			res = append(res, ns.Name)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// copyCertificate copies the certificate of a source secret to a TLS secret in a namespace.
+// Secrets which weren't copied from the same wildcard certificate are not overwritten.
+func copyCertificate(clientset kubernetes.Interface, source *v1.Secret, namespace, secretName, id string) error {
+	secrets := clientset.CoreV1().Secrets(namespace)
+
+	existing, err := secrets.Get(context.Background(), secretName, metav1.GetOptions{})
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	data := map[string][]byte{
+		v1.TLSCertKey:       source.Data[v1.TLSCertKey],
+		v1.TLSPrivateKeyKey: source.Data[v1.TLSPrivateKeyKey],
+	}
+
+	if err != nil {
+		_, err = secrets.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: namespace,
+				Labels:    map[string]string{WildcardCertificateLabel: id},
+			},
+			Type: v1.SecretTypeTLS,
+			Data: data,
+		}, metav1.CreateOptions{})
+
+		return err
+	}
+
+	if existing.Labels[WildcardCertificateLabel] != id {
+		return fmt.Errorf("secret %s already exists and was not created for this certificate", secretName)
+	}
+
+	if bytes.Equal(existing.Data[v1.TLSCertKey], data[v1.TLSCertKey]) &&
+		bytes.Equal(existing.Data[v1.TLSPrivateKeyKey], data[v1.TLSPrivateKeyKey]) {
+		return nil
+	}
+
+	existing.Data = data
+
+	_, err = secrets.Update(context.Background(), existing, metav1.UpdateOptions{})
+
+	return err
+}
+
+// removeCopies deletes the copies of a wildcard certificate, except for the copies in keep,
+// which are keyed by namespace/name. The number of deleted copies is returned.
+func removeCopies(clientset kubernetes.Interface, id, secretName string, keep map[string]bool) (int, error) {
+	secretList, err := clientset.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", WildcardCertificateLabel, id),
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+
+	for _, secret := range secretList.Items {
+		// the resources of the certificate itself are managed by cert-manager and the issuer
+		if secret.Namespace == WildcardNamespace && secret.Name != secretName {
+			continue
+		}
+
+		if keep[secret.Namespace+"/"+secret.Name] {
+			continue
+		}
+
+		err := clientset.CoreV1().Secrets(secret.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{})
+
+		if err != nil && !errors.IsNotFound(err) {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func applySecret(clientset kubernetes.Interface, secret *v1.Secret) error {
+	secrets := clientset.CoreV1().Secrets(secret.Namespace)
+
+	existing, err := secrets.Get(context.Background(), secret.Name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		_, err = secrets.Create(context.Background(), secret, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	existing.Labels = secret.Labels
+	existing.Data = secret.Data
+
+	_, err = secrets.Update(context.Background(), existing, metav1.UpdateOptions{})
+
+	return err
+}
+
+// applyUnstructured creates an object, or replaces the labels and the spec of the object if
+// it exists
+func applyUnstructured(client dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+	existing, err := client.Get(context.Background(), obj.GetName(), metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		_, err = client.Create(context.Background(), obj, metav1.CreateOptions{})
+
+		// if the CRD is not registered, the API server returns a not found error
+		if err != nil && errors.IsNotFound(err) {
+			return ErrCertManagerNotInstalled
+		}
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	existing.SetLabels(obj.GetLabels())
+	existing.Object["spec"] = obj.Object["spec"]
+
+	_, err = client.Update(context.Background(), existing, metav1.UpdateOptions{})
+
+	return err
+}
+
+// isSystemNamespace returns true for the namespaces of the cluster's add-ons. Unlike the
+// namespaces which preview environments skip, the default namespace is not a system namespace,
+// since applications are deployed to it.
+func isSystemNamespace(namespace string) bool {
+	return namespace == "cert-manager" || namespace == "ingress-nginx" ||
+		namespace == "kube-node-lease" || namespace == "kube-public" ||
+		namespace == "kube-system" || namespace == "monitoring" ||
+		namespace == "porter-agent-system" || namespace == "velero" ||
+		namespace == "ingress-nginx-private"
+}
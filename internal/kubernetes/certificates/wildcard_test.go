@@ -0,0 +1,105 @@
+package certificates
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testSecret(namespace, name, id, cert string) *v1.Secret {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte(cert),
+			v1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+
+	if id != "" {
+		secret.Labels = map[string]string{WildcardCertificateLabel: id}
+	}
+
+	return secret
+}
+
+func TestDistributeWildcardCertificate(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pr-1"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pr-2"}},
+		testSecret(WildcardNamespace, "porter-wildcard-tls", "", "cert-1"),
+		// a copy in a namespace which was deleted, and a secret of the same name created by a user
+		testSecret("pr-0", "wildcard-tls", "1", "cert-0"),
+		testSecret("pr-2", "wildcard-tls", "", "user-cert"),
+	)
+
+	namespaces, err := DistributeWildcardCertificate(clientset, "porter-wildcard", "1", "wildcard-tls", nil)
+
+	if err == nil {
+		t.Errorf("expected an error for the secret created by a user")
+	}
+
+	if expected := []string{"default", "pr-1"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("expected the certificate to be copied to %v, got %v", expected, namespaces)
+	}
+
+	if secret, _ := clientset.CoreV1().Secrets("pr-2").Get(context.Background(), "wildcard-tls", metav1.GetOptions{}); string(secret.Data[v1.TLSCertKey]) != "user-cert" {
+		t.Errorf("expected the secret created by a user to be left unchanged")
+	}
+
+	if _, err := clientset.CoreV1().Secrets("pr-0").Get(context.Background(), "wildcard-tls", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected the copy in a namespace which is no longer targeted to be deleted")
+	}
+
+	// a renewed certificate replaces the copies
+	source := testSecret(WildcardNamespace, "porter-wildcard-tls", "", "cert-2")
+	clientset.CoreV1().Secrets(WildcardNamespace).Update(context.Background(), source, metav1.UpdateOptions{})
+
+	if _, err := DistributeWildcardCertificate(clientset, "porter-wildcard", "1", "wildcard-tls", []string{"pr-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if secret, _ := clientset.CoreV1().Secrets("pr-1").Get(context.Background(), "wildcard-tls", metav1.GetOptions{}); string(secret.Data[v1.TLSCertKey]) != "cert-2" {
+		t.Errorf("expected the copy to be updated with the renewed certificate")
+	}
+
+	if _, err := clientset.CoreV1().Secrets("default").Get(context.Background(), "wildcard-tls", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected the copy in the default namespace to be deleted")
+	}
+}
+
+func TestDistributeWildcardCertificateNotIssued(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	if _, err := DistributeWildcardCertificate(clientset, "porter-wildcard", "1", "wildcard-tls", nil); err != ErrCertificateNotIssued {
+		t.Errorf("expected ErrCertificateNotIssued, got %v", err)
+	}
+}
+
+func TestGetClusterIssuerSpec(t *testing.T) {
+	spec := getClusterIssuerSpec(&WildcardCertificateOpts{
+		Name:     "porter-wildcard",
+		Email:    "admin@example.com",
+		Provider: types.DNSProviderCloudflare,
+	})
+
+	solvers, _, _ := unstructured.NestedSlice(spec, "acme", "solvers")
+
+	if len(solvers) != 1 {
+		t.Fatalf("expected 1 solver, got %v", solvers)
+	}
+
+	secretName, _, _ := unstructured.NestedString(solvers[0].(map[string]interface{}), "dns01", "cloudflare", "apiTokenSecretRef", "name")
+
+	if secretName != "porter-wildcard-dns" {
+		t.Errorf("expected the api token to be read from porter-wildcard-dns, got %s", secretName)
+	}
+}
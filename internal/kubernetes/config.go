@@ -12,6 +12,7 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/tunnel"
 	"golang.org/x/oauth2"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -75,7 +76,7 @@ func GetAgentOutOfClusterConfig(conf *OutOfClusterConfig) (*Agent, error) {
 		return nil, err
 	}
 
-	return &Agent{conf, clientset}, nil
+	return &Agent{RESTClientGetter: conf, Clientset: clientset}, nil
 }
 
 // IsInCluster returns true if the process is running in a Kubernetes cluster,
@@ -100,12 +101,12 @@ func GetAgentInClusterConfig(namespace string) (*Agent, error) {
 	restClientGetter := NewRESTClientGetterFromInClusterConfig(conf, namespace)
 	clientset, err := kubernetes.NewForConfig(conf)
 
-	return &Agent{restClientGetter, clientset}, nil
+	return &Agent{RESTClientGetter: restClientGetter, Clientset: clientset}, nil
 }
 
 // GetAgentTesting creates a new Agent using an optional existing storage class
 func GetAgentTesting(objects ...runtime.Object) *Agent {
-	return &Agent{&fakeRESTClientGetter{}, fake.NewSimpleClientset(objects...)}
+	return &Agent{RESTClientGetter: &fakeRESTClientGetter{}, Clientset: fake.NewSimpleClientset(objects...)}
 }
 
 // OutOfClusterConfig is the set of parameters required for an out-of-cluster connection.
@@ -139,6 +140,16 @@ func (conf *OutOfClusterConfig) ToRESTConfig() (*rest.Config, error) {
 
 	restConf.Timeout = conf.Timeout
 
+	// clusters without inbound access are reached through the tunnel of their agent, which
+	// must be connected to this server
+	if conf.Cluster.TunnelEnabled {
+		if !tunnel.DefaultRegistry.IsConnected(conf.Cluster.ID) {
+			return nil, fmt.Errorf("cluster %d: %w", conf.Cluster.ID, tunnel.ErrNotConnected)
+		}
+
+		restConf.Proxy = tunnel.DefaultRegistry.Proxy(conf.Cluster.ID)
+	}
+
 	rest.SetKubernetesDefaults(restConf)
 	return restConf, nil
 }
@@ -0,0 +1,97 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+var ErrPodNotFound = fmt.Errorf("pod not found")
+
+// ListPodContainers returns the init, app and ephemeral containers of a pod, in that order
+func ListPodContainers(clientset k8s.Interface, namespace, name string) ([]*types.PodContainer, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, ErrPodNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return GetPodContainers(pod), nil
+}
+
+// GetPodContainers returns the init, app and ephemeral containers of a pod, in that order
+func GetPodContainers(pod *v1.Pod) []*types.PodContainer {
+	res := make([]*types.PodContainer, 0)
+
+	statuses := make(map[string]v1.ContainerStatus)
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		res = append(res, toPodContainer(container.Name, container.Image, types.PodContainerTypeInit, statuses))
+	}
+
+	for _, container := range pod.Spec.Containers {
+		res = append(res, toPodContainer(container.Name, container.Image, types.PodContainerTypeApp, statuses))
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		res = append(res, toPodContainer(container.Name, container.Image, types.PodContainerTypeEphemeral, statuses))
+	}
+
+	return res
+}
+
+func toPodContainer(
+	name, image string,
+	containerType types.PodContainerType,
+	statuses map[string]v1.ContainerStatus,
+) *types.PodContainer {
+	res := &types.PodContainer{
+		Name:  name,
+		Type:  containerType,
+		Image: image,
+		State: "waiting",
+	}
+
+	status, ok := statuses[name]
+
+	if !ok {
+		return res
+	}
+
+	res.Ready = status.Ready
+	res.RestartCount = status.RestartCount
+
+	// logs of the previous instance are kept by the kubelet until the container restarts again
+	res.HasPreviousLogs = status.LastTerminationState.Terminated != nil
+
+	switch {
+	case status.State.Running != nil:
+		res.State = "running"
+	case status.State.Terminated != nil:
+		res.State = "terminated"
+		res.Reason = status.State.Terminated.Reason
+	case status.State.Waiting != nil:
+		res.Reason = status.State.Waiting.Reason
+	}
+
+	return res
+}
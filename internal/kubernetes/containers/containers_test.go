@@ -0,0 +1,76 @@
+package containers_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/containers"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGetPodContainers(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "migrate", Image: "app:1"}},
+			Containers: []v1.Container{
+				{Name: "web", Image: "app:1"},
+				{Name: "proxy", Image: "envoy:1"},
+			},
+			EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"}},
+			},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "migrate",
+					State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}},
+				},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "web",
+					RestartCount: 3,
+					State:        v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: 1},
+					},
+				},
+				{
+					Name:  "proxy",
+					Ready: true,
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+
+	res := containers.GetPodContainers(pod)
+
+	expected := []struct {
+		name            string
+		containerType   types.PodContainerType
+		state           string
+		hasPreviousLogs bool
+	}{
+		{"migrate", types.PodContainerTypeInit, "terminated", false},
+		{"web", types.PodContainerTypeApp, "waiting", true},
+		{"proxy", types.PodContainerTypeApp, "running", false},
+		{"debugger", types.PodContainerTypeEphemeral, "waiting", false},
+	}
+
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d containers, got %d", len(expected), len(res))
+	}
+
+	for i, exp := range expected {
+		if res[i].Name != exp.name || res[i].Type != exp.containerType ||
+			res[i].State != exp.state || res[i].HasPreviousLogs != exp.hasPreviousLogs {
+			t.Errorf("unexpected container at index %d: %+v", i, res[i])
+		}
+	}
+
+	if res[1].Reason != "CrashLoopBackOff" || res[1].RestartCount != 3 {
+		t.Errorf("expected crash looping web container, got %+v", res[1])
+	}
+}
@@ -0,0 +1,240 @@
+package crds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+var ErrCRDNotFound = fmt.Errorf("custom resource definition not found")
+
+var ErrVersionNotServed = fmt.Errorf("version is not served for this custom resource")
+
+var ErrNamespaceRequired = fmt.Errorf("namespace is required for namespaced custom resources")
+
+// ListCRDs lists the custom resource definitions installed on a cluster
+func ListCRDs(client dynamic.Interface, group string) ([]*types.CustomResourceDefinition, error) {
+	crdList, err := client.Resource(crdGVR).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.CustomResourceDefinition, 0)
+
+	for _, crd := range crdList.Items {
+		def := toCRDType(&crd)
+
+		if group != "" && def.Group != group {
+			continue
+		}
+
+		res = append(res, def)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// GetCRD returns a single custom resource definition by name
+func GetCRD(client dynamic.Interface, name string) (*types.CustomResourceDefinition, error) {
+	crd, err := client.Resource(crdGVR).Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, ErrCRDNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return toCRDType(crd), nil
+}
+
+// ListCustomResources lists the instances of a custom resource
+func ListCustomResources(
+	client dynamic.Interface,
+	crd *types.CustomResourceDefinition,
+	req *types.ListCustomResourcesRequest,
+) (types.ListCustomResourcesResponse, error) {
+	gvr, err := resolveGVR(crd, req.Version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: req.LabelSelector,
+		FieldSelector: req.FieldSelector,
+	}
+
+	var list *unstructured.UnstructuredList
+
+	if crd.Namespaced && req.Namespace != "" {
+		list, err = client.Resource(gvr).Namespace(req.Namespace).List(context.Background(), opts)
+	} else {
+		list, err = client.Resource(gvr).List(context.Background(), opts)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(types.ListCustomResourcesResponse, 0)
+
+	for _, item := range list.Items {
+		// managed fields are noisy and not useful when browsing resources
+		unstructured.RemoveNestedField(item.Object, "metadata", "managedFields")
+
+		res = append(res, filterFields(item.Object, req.Fields))
+	}
+
+	return res, nil
+}
+
+// GetCustomResource returns a single instance of a custom resource
+func GetCustomResource(
+	client dynamic.Interface,
+	crd *types.CustomResourceDefinition,
+	namespace, name, version string,
+) (map[string]interface{}, error) {
+	gvr, err := resolveGVR(crd, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *unstructured.Unstructured
+
+	if crd.Namespaced {
+		if namespace == "" {
+			return nil, ErrNamespaceRequired
+		}
+
+		obj, err = client.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	} else {
+		obj, err = client.Resource(gvr).Get(context.Background(), name, metav1.GetOptions{})
+	}
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, kubernetes.IsNotFoundError
+	} else if err != nil {
+		return nil, err
+	}
+
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+
+	return obj.Object, nil
+}
+
+func resolveGVR(crd *types.CustomResourceDefinition, version string) (schema.GroupVersionResource, error) {
+	if version == "" {
+		version = crd.StorageVersion
+	}
+
+	for _, served := range crd.Versions {
+		if served == version {
+			return schema.GroupVersionResource{
+				Group:    crd.Group,
+				Version:  version,
+				Resource: crd.Plural,
+			}, nil
+		}
+	}
+
+	return schema.GroupVersionResource{}, fmt.Errorf("%w: %s", ErrVersionNotServed, version)
+}
+
+// filterFields returns a copy of an object containing only the given dot-separated field
+// paths, along with the object's identifying fields
+func filterFields(obj map[string]interface{}, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		return obj
+	}
+
+	res := make(map[string]interface{})
+
+	paths := [][]string{
+		{"apiVersion"},
+		{"kind"},
+		{"metadata", "name"},
+		{"metadata", "namespace"},
+	}
+
+	for _, field := range fields {
+		if field = strings.Trim(field, "."); field != "" {
+			paths = append(paths, strings.Split(field, "."))
+		}
+	}
+
+	for _, path := range paths {
+		val, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+
+		if err != nil || !found {
+			continue
+		}
+
+		unstructured.SetNestedField(res, runtime.DeepCopyJSONValue(val), path...)
+	}
+
+	return res
+}
+
+func toCRDType(crd *unstructured.Unstructured) *types.CustomResourceDefinition {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+	singular, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "singular")
+	categories, _, _ := unstructured.NestedStringSlice(crd.Object, "spec", "names", "categories")
+	scope, _, _ := unstructured.NestedString(crd.Object, "spec", "scope")
+
+	res := &types.CustomResourceDefinition{
+		Name:       crd.GetName(),
+		Group:      group,
+		Kind:       kind,
+		Plural:     plural,
+		Singular:   singular,
+		Namespaced: scope == "Namespaced",
+		Versions:   make([]string, 0),
+		Categories: categories,
+	}
+
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		name, _ := version["name"].(string)
+
+		if served, _ := version["served"].(bool); served {
+			res.Versions = append(res.Versions, name)
+		}
+
+		if storage, _ := version["storage"].(bool); storage {
+			res.StorageVersion = name
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,116 @@
+package crds_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/crds"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": "rollouts.argoproj.io",
+		},
+		"spec": map[string]interface{}{
+			"group": "argoproj.io",
+			"scope": "Namespaced",
+			"names": map[string]interface{}{
+				"kind":     "Rollout",
+				"plural":   "rollouts",
+				"singular": "rollout",
+			},
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name":    "v1alpha1",
+					"served":  true,
+					"storage": true,
+				},
+			},
+		},
+	}}
+
+	rollout := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+		"status": map[string]interface{}{
+			"phase": "Healthy",
+		},
+	}}
+
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}: "CustomResourceDefinitionList",
+			{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}:                     "RolloutList",
+		},
+		crd,
+		rollout,
+	)
+}
+
+func TestListCustomResourcesWithFields(t *testing.T) {
+	client := newFakeDynamicClient()
+
+	defs, err := crds.ListCRDs(client, "")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(defs) != 1 || defs[0].Kind != "Rollout" || !defs[0].Namespaced || defs[0].StorageVersion != "v1alpha1" {
+		t.Fatalf("unexpected definitions: %+v", defs)
+	}
+
+	res, err := crds.ListCustomResources(client, defs[0], &types.ListCustomResourcesRequest{
+		Namespace: "default",
+		Fields:    []string{"status.phase"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(res))
+	}
+
+	if phase, _, _ := unstructured.NestedString(res[0], "status", "phase"); phase != "Healthy" {
+		t.Errorf("expected status.phase to be returned, got %q", phase)
+	}
+
+	if _, found, _ := unstructured.NestedFieldNoCopy(res[0], "spec"); found {
+		t.Errorf("expected spec to be filtered out")
+	}
+
+	if name, _, _ := unstructured.NestedString(res[0], "metadata", "name"); name != "web" {
+		t.Errorf("expected name to always be returned, got %q", name)
+	}
+}
+
+func TestGetCustomResourceUnservedVersion(t *testing.T) {
+	client := newFakeDynamicClient()
+
+	crd, err := crds.GetCRD(client, "rollouts.argoproj.io")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := crds.GetCustomResource(client, crd, "default", "web", "v2"); err == nil {
+		t.Errorf("expected error for unserved version")
+	}
+}
@@ -0,0 +1,234 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	defaultDebugImage = "busybox:1.35"
+
+	// debugContainerStartTimeout is the time to wait for the debug image to be pulled and
+	// the container to start
+	debugContainerStartTimeout = 2 * time.Minute
+)
+
+var ErrEphemeralContainersNotSupported = fmt.Errorf("this cluster does not support ephemeral containers (requires Kubernetes 1.23+)")
+
+var ErrDebugContainerNotFound = fmt.Errorf("debug container not found")
+
+// CreateDebugContainer injects an ephemeral container into a running pod. The container shares
+// the process namespace of the target container, so tools in the debug image can inspect
+// containers built from distroless images.
+func CreateDebugContainer(
+	clientset k8s.Interface,
+	namespace, podName string,
+	req *types.CreateDebugContainerRequest,
+) (*types.DebugContainer, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, kubernetes.IsNotFoundError
+	} else if err != nil {
+		return nil, err
+	}
+
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, fmt.Errorf("pod %s/%s is not running", namespace, podName)
+	}
+
+	image := req.Image
+
+	if image == "" {
+		image = defaultDebugImage
+	}
+
+	target := req.TargetContainer
+
+	if target == "" {
+		target = pod.Spec.Containers[0].Name
+	}
+
+	found := false
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name == target {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("container %s not found in pod %s/%s", target, namespace, podName)
+	}
+
+	command := req.Command
+
+	if len(command) == 0 {
+		command = []string{"sh"}
+	}
+
+	name := fmt.Sprintf("porter-debug-%s", utilrand.String(5))
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			Command:                  command,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	})
+
+	pod, err = clientset.CoreV1().Pods(namespace).UpdateEphemeralContainers(
+		context.Background(),
+		podName,
+		pod,
+		metav1.UpdateOptions{},
+	)
+
+	// clusters without the ephemeralcontainers subresource return a not found error
+	if err != nil && errors.IsNotFound(err) {
+		return nil, ErrEphemeralContainersNotSupported
+	} else if err != nil {
+		return nil, err
+	}
+
+	return toDebugContainerType(pod, name)
+}
+
+// WaitForDebugContainer waits until a debug container is running, and returns an error if the
+// container cannot be started
+func WaitForDebugContainer(ctx context.Context, clientset k8s.Interface, namespace, podName, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, debugContainerStartTimeout)
+	defer cancel()
+
+	for {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+
+		if err != nil && errors.IsNotFound(err) {
+			return kubernetes.IsNotFoundError
+		} else if err != nil {
+			return err
+		}
+
+		container, err := toDebugContainerType(pod, name)
+
+		if err != nil {
+			return err
+		}
+
+		switch container.State {
+		case "running":
+			return nil
+		case "terminated":
+			return fmt.Errorf("debug container %s terminated: %s", name, container.Reason)
+		case "waiting":
+			if container.Reason == "ErrImagePull" || container.Reason == "ImagePullBackOff" ||
+				container.Reason == "InvalidImageName" {
+				return fmt.Errorf("could not pull debug image %s: %s", container.Image, container.Reason)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for debug container %s to start: %w", name, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// AttachDebugContainer attaches to the TTY of a running debug container, reading input from
+// stdin and writing output to stdout until the session ends
+func AttachDebugContainer(
+	agent *kubernetes.Agent,
+	namespace, podName, name string,
+	stdin io.Reader,
+	stdout io.Writer,
+) error {
+	restConf, err := agent.RESTClientGetter.ToRESTConfig()
+
+	if err != nil {
+		return err
+	}
+
+	req := agent.Clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("attach")
+
+	req.VersionedParams(
+		&v1.PodAttachOptions{
+			Container: name,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		},
+		scheme.ParameterCodec,
+	)
+
+	exec, err := remotecommand.NewSPDYExecutor(restConf, "POST", req.URL())
+
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Tty:    true,
+	})
+}
+
+func toDebugContainerType(pod *v1.Pod, name string) (*types.DebugContainer, error) {
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name != name {
+			continue
+		}
+
+		res := &types.DebugContainer{
+			Name:            name,
+			Pod:             pod.Name,
+			Namespace:       pod.Namespace,
+			Image:           container.Image,
+			TargetContainer: container.TargetContainerName,
+			State:           "waiting",
+		}
+
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != name {
+				continue
+			}
+
+			if status.State.Running != nil {
+				res.State = "running"
+			} else if status.State.Terminated != nil {
+				res.State = "terminated"
+				res.Reason = status.State.Terminated.Reason
+			} else if status.State.Waiting != nil {
+				res.Reason = status.State.Waiting.Reason
+			}
+		}
+
+		return res, nil
+	}
+
+	return nil, ErrDebugContainerNotFound
+}
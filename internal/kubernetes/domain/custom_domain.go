@@ -0,0 +1,400 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// CustomDomainReleaseLabel is set on the Porter-managed ingress which routes
+	// custom domains to a release
+	CustomDomainReleaseLabel = "porter.run/custom-domain-release"
+
+	defaultClusterIssuer = "letsencrypt-prod"
+)
+
+var ErrCustomDomainExists = fmt.Errorf("custom domain is already attached to a release in this cluster")
+
+var ErrCustomDomainNotFound = fmt.Errorf("custom domain not found")
+
+var ErrServiceNotFound = fmt.Errorf("could not find a service for this release")
+
+// CustomDomainIngressName returns the name of the ingress which stores the custom domains
+// of a release. This ingress is kept separate from the ingress rendered by the release's
+// chart, so that Helm upgrades don't overwrite the custom domains.
+func CustomDomainIngressName(releaseName string) string {
+	return fmt.Sprintf("%s-custom-domains", releaseName)
+}
+
+// ListCustomDomains returns the custom domains attached to a release
+func ListCustomDomains(clientset kubernetes.Interface, namespace, releaseName string) ([]*types.CustomDomain, error) {
+	ingress, err := clientset.NetworkingV1().Ingresses(namespace).Get(
+		context.Background(),
+		CustomDomainIngressName(releaseName),
+		metav1.GetOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return make([]*types.CustomDomain, 0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return customDomainsFromIngress(ingress), nil
+}
+
+// AddCustomDomain routes a custom domain to a release by adding a rule to the release's custom
+// domain ingress, creating the ingress if it does not exist
+func AddCustomDomain(
+	clientset kubernetes.Interface,
+	namespace, releaseName string,
+	req *types.CreateCustomDomainRequest,
+) (*types.CustomDomain, error) {
+	host := strings.ToLower(req.Host)
+
+	// hosts must be unique across the cluster, otherwise the ingress controller will
+	// route traffic unpredictably
+	hostnames, err := ListIngressHostnames(clientset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hostname := range hostnames {
+		if hostname.Host == host {
+			return nil, ErrCustomDomainExists
+		}
+	}
+
+	svcName, svcPort, err := getReleaseServiceBackend(clientset, namespace, releaseName, req.ServiceName, req.ServicePort)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ingresses := clientset.NetworkingV1().Ingresses(namespace)
+	ingressName := CustomDomainIngressName(releaseName)
+
+	ingress, err := ingresses.Get(context.Background(), ingressName, metav1.GetOptions{})
+	exists := true
+
+	if err != nil && errors.IsNotFound(err) {
+		exists = false
+
+		ingress = &netv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ingressName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					CustomDomainReleaseLabel: releaseName,
+					"porter":                 "true",
+				},
+				Annotations: map[string]string{
+					"kubernetes.io/ingress.class": "nginx",
+				},
+			},
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	pathType := netv1.PathTypeImplementationSpecific
+
+	ingress.Spec.Rules = append(ingress.Spec.Rules, netv1.IngressRule{
+		Host: host,
+		IngressRuleValue: netv1.IngressRuleValue{
+			HTTP: &netv1.HTTPIngressRuleValue{
+				Paths: []netv1.HTTPIngressPath{
+					{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: netv1.IngressBackend{
+							Service: &netv1.IngressServiceBackend{
+								Name: svcName,
+								Port: netv1.ServiceBackendPort{
+									Number: svcPort,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	if req.TLSEnabled {
+		issuer := req.ClusterIssuer
+
+		if issuer == "" {
+			issuer = defaultClusterIssuer
+		}
+
+		if ingress.Annotations == nil {
+			ingress.Annotations = make(map[string]string)
+		}
+
+		ingress.Annotations["cert-manager.io/cluster-issuer"] = issuer
+
+		ingress.Spec.TLS = append(ingress.Spec.TLS, netv1.IngressTLS{
+			Hosts:      []string{host},
+			SecretName: tlsSecretName(host),
+		})
+	}
+
+	if exists {
+		ingress, err = ingresses.Update(context.Background(), ingress, metav1.UpdateOptions{})
+	} else {
+		ingress, err = ingresses.Create(context.Background(), ingress, metav1.CreateOptions{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, domain := range customDomainsFromIngress(ingress) {
+		if domain.Host == host {
+			return domain, nil
+		}
+	}
+
+	return nil, ErrCustomDomainNotFound
+}
+
+// RemoveCustomDomain removes a custom domain from a release. If no custom domains are left,
+// the custom domain ingress is deleted.
+func RemoveCustomDomain(clientset kubernetes.Interface, namespace, releaseName, host string) error {
+	host = strings.ToLower(host)
+	ingresses := clientset.NetworkingV1().Ingresses(namespace)
+
+	ingress, err := ingresses.Get(context.Background(), CustomDomainIngressName(releaseName), metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return ErrCustomDomainNotFound
+	} else if err != nil {
+		return err
+	}
+
+	rules := make([]netv1.IngressRule, 0)
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != host {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == len(ingress.Spec.Rules) {
+		return ErrCustomDomainNotFound
+	}
+
+	if len(rules) == 0 {
+		return ingresses.Delete(context.Background(), ingress.Name, metav1.DeleteOptions{})
+	}
+
+	tls := make([]netv1.IngressTLS, 0)
+
+	for _, tlsEntry := range ingress.Spec.TLS {
+		if len(tlsEntry.Hosts) != 1 || tlsEntry.Hosts[0] != host {
+			tls = append(tls, tlsEntry)
+		}
+	}
+
+	ingress.Spec.Rules = rules
+	ingress.Spec.TLS = tls
+
+	_, err = ingresses.Update(context.Background(), ingress, metav1.UpdateOptions{})
+
+	return err
+}
+
+// VerifyCustomDomain checks that the DNS records of a host point to the given ingress endpoint,
+// which is either an IP address or a load balancer hostname
+func VerifyCustomDomain(host, endpoint string) *types.VerifyCustomDomainResponse {
+	res := &types.VerifyCustomDomainResponse{
+		Host:      host,
+		Endpoint:  endpoint,
+		Addresses: make([]string, 0),
+	}
+
+	if cname, err := net.LookupCNAME(host); err == nil && strings.TrimSuffix(cname, ".") != host {
+		res.CNAME = strings.TrimSuffix(cname, ".")
+
+		if strings.EqualFold(res.CNAME, endpoint) {
+			res.Verified = true
+			return res
+		}
+	}
+
+	addrs, err := net.LookupHost(host)
+
+	if err != nil {
+		res.Message = fmt.Sprintf("could not resolve %s: %s", host, err.Error())
+		return res
+	}
+
+	res.Addresses = addrs
+
+	// the endpoint may be a load balancer hostname, in which case we compare the
+	// resolved addresses of both hosts
+	endpointAddrs := []string{endpoint}
+
+	if net.ParseIP(endpoint) == nil {
+		endpointAddrs, err = net.LookupHost(endpoint)
+
+		if err != nil {
+			res.Message = fmt.Sprintf("could not resolve ingress endpoint %s: %s", endpoint, err.Error())
+			return res
+		}
+	}
+
+	for _, addr := range addrs {
+		for _, endpointAddr := range endpointAddrs {
+			if addr == endpointAddr {
+				res.Verified = true
+				return res
+			}
+		}
+	}
+
+	if net.ParseIP(endpoint) != nil {
+		res.Message = fmt.Sprintf("%s does not resolve to %s: create an A record pointing to %s", host, endpoint, endpoint)
+	} else {
+		res.Message = fmt.Sprintf("%s does not resolve to %s: create a CNAME record pointing to %s", host, endpoint, endpoint)
+	}
+
+	return res
+}
+
+// ListIngressHostnames lists all hostnames routed by networking/v1 ingresses in the cluster
+func ListIngressHostnames(clientset kubernetes.Interface) ([]*types.IngressHostname, error) {
+	ingressList, err := clientset.NetworkingV1().Ingresses("").List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.IngressHostname, 0)
+
+	for _, ingress := range ingressList.Items {
+		tlsHosts := make(map[string]bool)
+
+		for _, tlsEntry := range ingress.Spec.TLS {
+			for _, host := range tlsEntry.Hosts {
+				tlsHosts[host] = true
+			}
+		}
+
+		releaseName, isCustomDomain := ingress.Labels[CustomDomainReleaseLabel]
+
+		if !isCustomDomain {
+			releaseName = ingress.Annotations["meta.helm.sh/release-name"]
+		}
+
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host == "" {
+				continue
+			}
+
+			res = append(res, &types.IngressHostname{
+				Host:           rule.Host,
+				Namespace:      ingress.Namespace,
+				IngressName:    ingress.Name,
+				ReleaseName:    releaseName,
+				TLSEnabled:     tlsHosts[rule.Host],
+				IsCustomDomain: isCustomDomain,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func customDomainsFromIngress(ingress *netv1.Ingress) []*types.CustomDomain {
+	tlsSecrets := make(map[string]string)
+
+	for _, tlsEntry := range ingress.Spec.TLS {
+		for _, host := range tlsEntry.Hosts {
+			tlsSecrets[host] = tlsEntry.SecretName
+		}
+	}
+
+	res := make([]*types.CustomDomain, 0)
+
+	for _, rule := range ingress.Spec.Rules {
+		domain := &types.CustomDomain{
+			Host: rule.Host,
+		}
+
+		if secretName, ok := tlsSecrets[rule.Host]; ok {
+			domain.TLSEnabled = true
+			domain.TLSSecretName = secretName
+		}
+
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 && rule.HTTP.Paths[0].Backend.Service != nil {
+			domain.ServiceName = rule.HTTP.Paths[0].Backend.Service.Name
+			domain.ServicePort = rule.HTTP.Paths[0].Backend.Service.Port.Number
+		}
+
+		res = append(res, domain)
+	}
+
+	return res
+}
+
+// getReleaseServiceBackend finds the service and port that custom domain traffic should be routed
+// to. If a service name is not given, the service belonging to the release is used.
+func getReleaseServiceBackend(
+	clientset kubernetes.Interface,
+	namespace, releaseName, svcName string,
+	svcPort int32,
+) (string, int32, error) {
+	var svc *v1.Service
+
+	if svcName != "" {
+		found, err := clientset.CoreV1().Services(namespace).Get(context.Background(), svcName, metav1.GetOptions{})
+
+		if err != nil && errors.IsNotFound(err) {
+			return "", 0, ErrServiceNotFound
+		} else if err != nil {
+			return "", 0, err
+		}
+
+		svc = found
+	} else {
+		svcList, err := clientset.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName),
+		})
+
+		if err != nil {
+			return "", 0, err
+		}
+
+		if len(svcList.Items) == 0 {
+			return "", 0, ErrServiceNotFound
+		}
+
+		svc = &svcList.Items[0]
+	}
+
+	if svcPort != 0 {
+		return svc.Name, svcPort, nil
+	}
+
+	if len(svc.Spec.Ports) == 0 {
+		return "", 0, fmt.Errorf("service %s/%s does not expose any ports", namespace, svc.Name)
+	}
+
+	return svc.Name, svc.Spec.Ports[0].Port, nil
+}
+
+func tlsSecretName(host string) string {
+	return fmt.Sprintf("%s-tls", strings.ReplaceAll(host, ".", "-"))
+}
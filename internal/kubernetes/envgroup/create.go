@@ -1,6 +1,7 @@
 package envgroup
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"helm.sh/helm/v3/pkg/release"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func ConvertV1ToV2EnvGroup(agent *kubernetes.Agent, name, namespace string) (*v1.ConfigMap, error) {
@@ -41,11 +43,12 @@ func ConvertV1ToV2EnvGroup(agent *kubernetes.Agent, name, namespace string) (*v1
 		secretVariables[key] = string(val)
 	}
 
+	// the values of V1 env groups aren't templates
 	envGroup, err := CreateEnvGroup(agent, types.ConfigMapInput{
 		Name:            name,
 		Namespace:       namespace,
-		Variables:       variables,
-		SecretVariables: secretVariables,
+		Variables:       EscapeTemplates(variables),
+		SecretVariables: EscapeTemplates(secretVariables),
 	})
 
 	if err != nil {
@@ -83,48 +86,204 @@ func CreateEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.Co
 		}
 	}
 
+	if input.SecretVariables == nil {
+		input.SecretVariables = make(map[string]string)
+	}
+
+	store := input.ExternalSecretStore
+
+	// existing env groups keep the store they were created with, since the references of their
+	// secret variables are references to secrets in that store
+	if oldCM != nil {
+		store, err = GetExternalSecretStore(oldCM)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if store != nil {
+		return createExternalEnvGroup(agent, input, store, oldCM, latestVersion, apps)
+	}
+
 	oldSecret, _, err := agent.GetLatestVersionedSecret(input.Name, input.Namespace)
 
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return nil, err
+	}
+
+	carryOverSecrets(input, oldSecret)
+
+	resolved, err := resolveInput(input, oldCM, oldSecret, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// add all secret env variables to configmap with value PORTERSECRET_${configmap_name}
+	for key := range resolved.SecretVariables {
+		resolved.Variables[key] = fmt.Sprintf("PORTERSECRET_%s.v%d", input.Name, latestVersion)
+	}
+
+	cm, err := agent.CreateVersionedConfigMap(input.Name, input.Namespace, latestVersion, resolved.Variables, apps...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secretData := EncodeSecrets(resolved.SecretVariables)
+
+	secret, err := agent.CreateLinkedVersionedSecret(input.Name, input.Namespace, cm.ObjectMeta.Name, latestVersion, secretData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return storeTemplates(agent, cm, secret, resolved)
+}
+
+// PreviewEnvGroup resolves the templates of a new version of an env group, without creating the
+// version
+func PreviewEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*ResolvedVariables, error) {
+	oldCM, _, err := agent.GetLatestVersionedConfigMap(input.Name, input.Namespace)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return nil, err
+	}
+
 	if input.SecretVariables == nil {
 		input.SecretVariables = make(map[string]string)
 	}
 
+	store := input.ExternalSecretStore
+
+	if oldCM != nil {
+		store, err = GetExternalSecretStore(oldCM)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the secret variables of env groups synced from an external secret store are references
+	// to secrets in the store, which aren't resolved
+	if store != nil {
+		return resolveInput(input, oldCM, nil, false)
+	}
+
+	oldSecret, _, err := agent.GetLatestVersionedSecret(input.Name, input.Namespace)
+
 	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
 		return nil, err
-	} else if err == nil && oldSecret != nil {
-		// In this case, we find all old variables referencing a secret value, and add those
-		// values to the new secret variables. The frontend will only send **new** secret values.
-		for key1, val1 := range input.Variables {
-			if strings.Contains(val1, "PORTERSECRET") {
-				// get that value from the secret
-				for key2, val2 := range oldSecret.Data {
-					if key2 == key1 {
-						input.SecretVariables[key1] = string(val2)
-					}
+	}
+
+	carryOverSecrets(input, oldSecret)
+
+	return resolveInput(input, oldCM, oldSecret, true)
+}
+
+// carryOverSecrets adds the values of the secret variables of the previous version of an env
+// group which are referenced by the variables of a new version to its secret variables
+func carryOverSecrets(input types.ConfigMapInput, oldSecret *v1.Secret) {
+	if oldSecret == nil {
+		return
+	}
+
+	// In this case, we find all old variables referencing a secret value, and add those
+	// values to the new secret variables. The frontend will only send **new** secret values.
+	for key1, val1 := range input.Variables {
+		if strings.Contains(val1, "PORTERSECRET") {
+			// get that value from the secret
+			for key2, val2 := range oldSecret.Data {
+				if key2 == key1 {
+					input.SecretVariables[key1] = string(val2)
 				}
 			}
 		}
 	}
+}
 
-	// add all secret env variables to configmap with value PORTERSECRET_${configmap_name}
-	for key := range input.SecretVariables {
-		input.Variables[key] = fmt.Sprintf("PORTERSECRET_%s.v%d", input.Name, latestVersion)
+// resolveInput resolves the templates of the variables of a new version of an env group, and of
+// its secret variables if resolveSecrets is set. The values which are unchanged from the
+// previous version are resolved from the templates of the previous version.
+func resolveInput(
+	input types.ConfigMapInput,
+	oldCM *v1.ConfigMap,
+	oldSecret *v1.Secret,
+	resolveSecrets bool,
+) (*ResolvedVariables, error) {
+	variables := make(map[string]string)
+
+	for key, val := range input.Variables {
+		if _, isSecret := input.SecretVariables[key]; !isSecret {
+			variables[key] = val
+		}
 	}
 
-	cm, err := agent.CreateVersionedConfigMap(input.Name, input.Namespace, latestVersion, input.Variables, apps...)
+	secretVariables := make(map[string]string)
 
-	if err != nil {
+	if resolveSecrets {
+		secretVariables = input.SecretVariables
+	}
+
+	if oldCM != nil {
+		variables = carryOverTemplates(variables, oldCM.Data, GetTemplates(oldCM.Annotations))
+	}
+
+	if oldSecret != nil && resolveSecrets {
+		oldSecretVariables := make(map[string]string)
+
+		for key, val := range oldSecret.Data {
+			oldSecretVariables[key] = string(val)
+		}
+
+		secretVariables = carryOverTemplates(secretVariables, oldSecretVariables, GetTemplates(oldSecret.Annotations))
+	}
+
+	return ResolveTemplates(variables, secretVariables, input.ResolveOutput)
+}
+
+// storeTemplates stores the templates of a new version of an env group in the annotations of its
+// configmap and secret
+func storeTemplates(
+	agent *kubernetes.Agent,
+	cm *v1.ConfigMap,
+	secret *v1.Secret,
+	resolved *ResolvedVariables,
+) (*v1.ConfigMap, error) {
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+
+	if changed, err := setTemplates(secret.Annotations, resolved.SecretTemplates); err != nil {
 		return nil, err
+	} else if changed {
+		_, err := agent.Clientset.CoreV1().Secrets(secret.Namespace).Update(
+			context.TODO(),
+			secret,
+			metav1.UpdateOptions{},
+		)
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	secretData := EncodeSecrets(input.SecretVariables)
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string)
+	}
 
-	// create secret first
-	if _, err := agent.CreateLinkedVersionedSecret(input.Name, input.Namespace, cm.ObjectMeta.Name, latestVersion, secretData); err != nil {
+	if changed, err := setTemplates(cm.Annotations, resolved.Templates); err != nil {
 		return nil, err
+	} else if changed {
+		return agent.Clientset.CoreV1().ConfigMaps(cm.Namespace).Update(
+			context.TODO(),
+			cm,
+			metav1.UpdateOptions{},
+		)
 	}
 
-	return cm, err
+	return cm, nil
 }
 
 func ToEnvGroup(configMap *v1.ConfigMap) (*types.EnvGroup, error) {
@@ -169,6 +328,17 @@ func ToEnvGroup(configMap *v1.ConfigMap) (*types.EnvGroup, error) {
 	}
 
 	res.Version = uint(versionInt)
+	res.LinkedFrom = configMap.Labels[LinkedFromLabel]
+
+	res.ExternalSecretStore, err = GetExternalSecretStore(configMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if templates := GetTemplates(configMap.Annotations); len(templates) > 0 {
+		res.Templates = templates
+	}
 
 	// get applications, if they exist
 	appStr, appAnnonExists := configMap.Annotations[kubernetes.PorterAppAnnotationName]
@@ -183,6 +353,12 @@ func ToEnvGroup(configMap *v1.ConfigMap) (*types.EnvGroup, error) {
 }
 
 func GetSyncedReleases(helmAgent *helm.Agent, configMap *v1.ConfigMap) ([]*release.Release, error) {
+	return GetLinkedReleases(helmAgent, configMap, configMap.Namespace)
+}
+
+// GetLinkedReleases returns the releases in a namespace which are linked to the env group of the
+// configmap. The helm agent must be scoped to that namespace.
+func GetLinkedReleases(helmAgent *helm.Agent, configMap *v1.ConfigMap, namespace string) ([]*release.Release, error) {
 	res := make([]*release.Release, 0)
 
 	// get applications, if they exist
@@ -192,10 +368,20 @@ func GetSyncedReleases(helmAgent *helm.Agent, configMap *v1.ConfigMap) ([]*relea
 		return res, nil
 	}
 
-	appStrArr := strings.Split(appStr, ",")
+	appStrArr := make([]string, 0)
+
+	for _, app := range strings.Split(appStr, ",") {
+		if appNamespace, appName := ParseApplication(configMap.Namespace, app); appNamespace == namespace {
+			appStrArr = append(appStrArr, appName)
+		}
+	}
+
+	if len(appStrArr) == 0 {
+		return res, nil
+	}
 
 	// list all latest helm releases and check them against app string
-	releases, err := helmAgent.ListReleases(configMap.Namespace, &types.ReleaseListFilter{
+	releases, err := helmAgent.ListReleases(namespace, &types.ReleaseListFilter{
 		StatusFilter: []string{
 			"deployed",
 			"uninstalled",
@@ -1,8 +1,22 @@
 package envgroup
 
-import "github.com/porter-dev/porter/internal/kubernetes"
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+)
 
 func DeleteEnvGroup(agent *kubernetes.Agent, name, namespace string) error {
+	cm, _, err := agent.GetLatestVersionedConfigMap(name, namespace)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return err
+	} else if err == nil {
+		if err := deleteExternalSecrets(agent, cm); err != nil {
+			return err
+		}
+	}
+
 	if err := agent.DeleteVersionedSecret(name, namespace); err != nil {
 		return err
 	}
@@ -0,0 +1,203 @@
+package envgroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/externalsecrets"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalSecretStoreAnnotation is set on the configmaps of env groups whose secret variables
+// are synced from an external secret store, and holds the store
+const ExternalSecretStoreAnnotation = "porter.run/external-secret-store"
+
+// GetExternalSecretStore returns the store which the secret variables of the env group of a
+// configmap are synced from, or nil if the secret variables are stored in a Secret
+func GetExternalSecretStore(configMap *v1.ConfigMap) (*types.ExternalSecretStore, error) {
+	storeStr, storeExists := configMap.Annotations[ExternalSecretStoreAnnotation]
+
+	if !storeExists || storeStr == "" {
+		return nil, nil
+	}
+
+	store := &types.ExternalSecretStore{}
+
+	if err := json.Unmarshal([]byte(storeStr), store); err != nil {
+		return nil, fmt.Errorf("not a valid configmap: invalid external secret store annotation: %v", err)
+	}
+
+	return store, nil
+}
+
+// createExternalEnvGroup creates a version of an env group whose secret variables are synced from
+// an external secret store. The values of the secret variables are references to secrets in the
+// store, and an ExternalSecret creates the secret of the version from them.
+func createExternalEnvGroup(
+	agent *kubernetes.Agent,
+	input types.ConfigMapInput,
+	store *types.ExternalSecretStore,
+	oldCM *v1.ConfigMap,
+	version uint,
+	apps []string,
+) (*v1.ConfigMap, error) {
+	client, err := agent.GetDynamicClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if oldCM != nil {
+		// as with secrets, the frontend only sends the references of new secret variables, so
+		// the references of the existing ones are read from the previous ExternalSecret
+		oldRefs, err := GetRemoteRefs(agent, oldCM)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for key, val := range input.Variables {
+			if oldRef, ok := oldRefs[key]; ok && strings.Contains(val, "PORTERSECRET") {
+				input.SecretVariables[key] = oldRef
+			}
+		}
+	}
+
+	// the references of secret variables aren't templates, so only the variables are resolved
+	resolved, err := resolveInput(input, oldCM, nil, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]*externalsecrets.RemoteRef)
+
+	for key, val := range input.SecretVariables {
+		ref, err := externalsecrets.ParseRemoteRef(val)
+
+		if err != nil {
+			return nil, fmt.Errorf("secret variable %s: %w", key, err)
+		}
+
+		refs[key] = ref
+		resolved.Variables[key] = fmt.Sprintf("PORTERSECRET_%s.v%d", input.Name, version)
+	}
+
+	name := fmt.Sprintf("%s.v%d", input.Name, version)
+
+	labels := map[string]string{
+		"owner":    "porter",
+		"envgroup": input.Name,
+		"version":  fmt.Sprintf("%d", version),
+	}
+
+	// the ExternalSecret is created before the configmap, so that no version is created when
+	// the operator isn't installed. The labels of the secret created by the operator are the
+	// labels of the secrets created by Porter, so that the secret is read as the secret of the
+	// version.
+	if len(refs) > 0 {
+		targetLabels := map[string]string{"configmap": name}
+
+		for key, val := range labels {
+			targetLabels[key] = val
+		}
+
+		err = externalsecrets.CreateExternalSecret(client, &externalsecrets.ExternalSecret{
+			Name:         name,
+			Namespace:    input.Namespace,
+			Labels:       labels,
+			Store:        store,
+			TargetName:   name,
+			TargetLabels: targetLabels,
+			Data:         refs,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cm, err := agent.CreateVersionedConfigMap(input.Name, input.Namespace, version, resolved.Variables, apps...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	storeBytes, err := json.Marshal(&types.ExternalSecretStore{
+		Kind:            store.Kind,
+		Name:            store.Name,
+		RefreshInterval: store.RefreshInterval,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	cm.Annotations[ExternalSecretStoreAnnotation] = string(storeBytes)
+
+	if _, err := setTemplates(cm.Annotations, resolved.Templates); err != nil {
+		return nil, err
+	}
+
+	return agent.Clientset.CoreV1().ConfigMaps(cm.Namespace).Update(
+		context.TODO(),
+		cm,
+		metav1.UpdateOptions{},
+	)
+}
+
+// GetRemoteRefs returns the references of the secret variables of the version of an env group
+// in a configmap, read from the ExternalSecret of the version
+func GetRemoteRefs(agent *kubernetes.Agent, configMap *v1.ConfigMap) (map[string]string, error) {
+	client, err := agent.GetDynamicClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string)
+
+	refs, err := externalsecrets.GetRemoteRefs(client, configMap.Namespace, configMap.Name)
+
+	// versions without secret variables don't have an ExternalSecret
+	if err != nil && k8sErrors.IsNotFound(err) {
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for key, ref := range refs {
+		res[key] = ref.String()
+	}
+
+	return res, nil
+}
+
+// deleteExternalSecrets deletes the ExternalSecrets of the versions of the env group of a
+// configmap, if its secret variables are synced from an external secret store, so that the
+// operator doesn't recreate their secrets
+func deleteExternalSecrets(agent *kubernetes.Agent, configMap *v1.ConfigMap) error {
+	store, err := GetExternalSecretStore(configMap)
+
+	if err != nil || store == nil {
+		return err
+	}
+
+	client, err := agent.GetDynamicClient()
+
+	if err != nil {
+		return err
+	}
+
+	return externalsecrets.DeleteExternalSecrets(
+		client,
+		configMap.Namespace,
+		fmt.Sprintf("envgroup=%s", configMap.Labels["envgroup"]),
+	)
+}
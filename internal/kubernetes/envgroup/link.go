@@ -0,0 +1,181 @@
+package envgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LinkedFromLabel is set on the copies of an env group which are created in the namespaces of
+// applications linked to the env group from another namespace, and holds the namespace of the
+// env group
+const LinkedFromLabel = "porter.run/linked-from"
+
+// ErrLinkConflict is returned when an env group can't be copied to a namespace, because the
+// namespace already has an env group with the same name
+var ErrLinkConflict = errors.New("namespace already has an env group with that name")
+
+// FormatApplication returns the entry of an application in the applications of an env group in
+// namespace. Applications in the namespace of the env group are stored by name, and applications
+// in other namespaces as namespace/name.
+func FormatApplication(namespace, appNamespace, appName string) string {
+	if appNamespace == "" || appNamespace == namespace {
+		return appName
+	}
+
+	return fmt.Sprintf("%s/%s", appNamespace, appName)
+}
+
+// ParseApplication returns the namespace and name of an entry in the applications of an env
+// group in namespace
+func ParseApplication(namespace, app string) (string, string) {
+	if strs := strings.SplitN(app, "/", 2); len(strs) == 2 {
+		return strs[0], strs[1]
+	}
+
+	return namespace, app
+}
+
+// GetLinkedNamespaces returns the namespaces of the applications linked to the env group of a
+// configmap, other than the namespace of the env group
+func GetLinkedNamespaces(configMap *v1.ConfigMap) []string {
+	appStr := configMap.Annotations[kubernetes.PorterAppAnnotationName]
+
+	if appStr == "" {
+		return []string{}
+	}
+
+	namespaces := make(map[string]bool)
+
+	for _, app := range strings.Split(appStr, ",") {
+		if namespace, _ := ParseApplication(configMap.Namespace, app); namespace != configMap.Namespace {
+			namespaces[namespace] = true
+		}
+	}
+
+	res := make([]string, 0, len(namespaces))
+
+	for namespace := range namespaces {
+		res = append(res, namespace)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// CopyEnvGroup creates the version of an env group in the configmap in another namespace, along
+// with its secret, so that applications in that namespace can use it. Versions which were
+// already copied are left as they are.
+func CopyEnvGroup(agent *kubernetes.Agent, configMap *v1.ConfigMap, namespace string) error {
+	envGroup, err := ToEnvGroup(configMap)
+
+	if err != nil {
+		return err
+	}
+
+	existing, _, err := agent.GetLatestVersionedConfigMap(envGroup.Name, namespace)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return err
+	} else if err == nil && existing.Labels[LinkedFromLabel] != configMap.Namespace {
+		return fmt.Errorf("%w: %s/%s", ErrLinkConflict, namespace, envGroup.Name)
+	}
+
+	if _, err := agent.GetVersionedConfigMap(envGroup.Name, namespace, envGroup.Version); err == nil {
+		return nil
+	} else if !errors.Is(err, kubernetes.IsNotFoundError) {
+		return err
+	}
+
+	labels := map[string]string{
+		"owner":         "porter",
+		"envgroup":      envGroup.Name,
+		"version":       fmt.Sprintf("%d", envGroup.Version),
+		LinkedFromLabel: configMap.Namespace,
+	}
+
+	secret, err := agent.GetVersionedSecret(envGroup.Name, configMap.Namespace, envGroup.Version)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return err
+	} else if err == nil {
+		secretLabels := map[string]string{"configmap": configMap.Name}
+
+		for key, val := range labels {
+			secretLabels[key] = val
+		}
+
+		_, err = agent.Clientset.CoreV1().Secrets(namespace).Create(
+			context.TODO(),
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secret.Name,
+					Namespace: namespace,
+					Labels:    secretLabels,
+				},
+				Data: secret.Data,
+			},
+			metav1.CreateOptions{},
+		)
+
+		// the secret is created first, so it already exists if creating the configmap failed
+		// the last time the version was copied
+		if err != nil && !k8sErrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	_, err = agent.Clientset.CoreV1().ConfigMaps(namespace).Create(
+		context.TODO(),
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMap.Name,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Data: configMap.Data,
+		},
+		metav1.CreateOptions{},
+	)
+
+	return err
+}
+
+// DeleteEnvGroupCopies deletes the copies of an env group which were created in other namespaces
+// for the applications linked to it
+func DeleteEnvGroupCopies(agent *kubernetes.Agent, name, namespace string) error {
+	listResp, err := agent.Clientset.CoreV1().ConfigMaps("").List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("envgroup=%s,%s=%s", name, LinkedFromLabel, namespace),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	deleted := make(map[string]bool)
+
+	for _, cm := range listResp.Items {
+		if deleted[cm.Namespace] {
+			continue
+		}
+
+		if err := DeleteEnvGroup(agent, name, cm.Namespace); err != nil {
+			return err
+		}
+
+		deleted[cm.Namespace] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package envgroup_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseApplication(t *testing.T) {
+	app := envgroup.FormatApplication("default", "staging", "web")
+
+	if app != "staging/web" {
+		t.Errorf("expected staging/web, got %s", app)
+	}
+
+	if namespace, name := envgroup.ParseApplication("default", app); namespace != "staging" || name != "web" {
+		t.Errorf("expected staging and web, got %s and %s", namespace, name)
+	}
+
+	if app := envgroup.FormatApplication("default", "default", "web"); app != "web" {
+		t.Errorf("expected web, got %s", app)
+	}
+
+	if namespace, name := envgroup.ParseApplication("default", "web"); namespace != "default" || name != "web" {
+		t.Errorf("expected default and web, got %s and %s", namespace, name)
+	}
+}
+
+func TestCopyEnvGroup(t *testing.T) {
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+
+	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "shared",
+		Namespace:       "default",
+		Variables:       map[string]string{"LOG_LEVEL": "info"},
+		SecretVariables: map[string]string{"API_KEY": "secret"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configMap, err = agent.AddApplicationToVersionedConfigMap(configMap, envgroup.FormatApplication("default", "staging", "web"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if namespaces := envgroup.GetLinkedNamespaces(configMap); len(namespaces) != 1 || namespaces[0] != "staging" {
+		t.Fatalf("expected linked namespace staging, got %v", namespaces)
+	}
+
+	if err := envgroup.CopyEnvGroup(agent, configMap, "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// copying a version which was already copied does nothing
+	if err := envgroup.CopyEnvGroup(agent, configMap, "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	envGroup, err := envgroup.GetEnvGroup(agent, "shared", "staging", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if envGroup.LinkedFrom != "default" || envGroup.Version != 1 {
+		t.Errorf("expected version 1 linked from default, got %+v", envGroup)
+	}
+
+	_, _, secretVariables, err := envgroup.GetVariables(agent, "shared", "staging", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if secretVariables["API_KEY"] != "secret" {
+		t.Errorf("expected the secret variables to be copied, got %v", secretVariables)
+	}
+
+	// env groups which weren't copied from the namespace aren't overwritten
+	_, err = envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:      "shared",
+		Namespace: "production",
+		Variables: map[string]string{"LOG_LEVEL": "warn"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := envgroup.CopyEnvGroup(agent, configMap, "production"); !errors.Is(err, envgroup.ErrLinkConflict) {
+		t.Errorf("expected ErrLinkConflict, got %v", err)
+	}
+}
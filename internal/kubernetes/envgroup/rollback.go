@@ -0,0 +1,58 @@
+package envgroup
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// RollbackEnvGroup creates a new version of an env group with the variables and secret variables of
+// a previous version. The applications which are linked to the env group stay linked. The
+// templates of the previous version are resolved again, with resolveOutput resolving references
+// to infra outputs.
+func RollbackEnvGroup(
+	agent *kubernetes.Agent,
+	name, namespace string,
+	version uint,
+	resolveOutput OutputResolver,
+) (*v1.ConfigMap, error) {
+	cm, err := agent.GetVersionedConfigMap(name, namespace, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	variables, secretVariables, err := getVariables(agent, cm, name, namespace, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	variables = RestoreTemplates(variables, GetTemplates(cm.Annotations))
+
+	// the secret of a version synced from an external secret store holds the values of the
+	// secrets in the store, so the references to the secrets are rolled back instead
+	if store, err := GetExternalSecretStore(cm); err != nil {
+		return nil, err
+	} else if store != nil {
+		secretVariables, err = GetRemoteRefs(agent, cm)
+
+		if err != nil {
+			return nil, err
+		}
+	} else if secret, err := agent.GetVersionedSecret(name, namespace, version); err == nil {
+		secretVariables = RestoreTemplates(secretVariables, GetTemplates(secret.Annotations))
+	} else if !errors.Is(err, kubernetes.IsNotFoundError) {
+		return nil, err
+	}
+
+	return CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            name,
+		Namespace:       namespace,
+		Variables:       variables,
+		SecretVariables: secretVariables,
+		ResolveOutput:   resolveOutput,
+	})
+}
@@ -0,0 +1,250 @@
+package envgroup_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func assertValues(t *testing.T, kind string, expected, actual map[string]string) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Errorf("expected %s %v, got %v", kind, expected, actual)
+		return
+	}
+
+	for key, val := range expected {
+		if actual[key] != val {
+			t.Errorf("expected %s %s to be %s, got %s", kind, key, val, actual[key])
+		}
+	}
+}
+
+func TestRollbackEnvGroup(t *testing.T) {
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+
+	_, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "shared",
+		Namespace:       "default",
+		Variables:       map[string]string{"LOG_LEVEL": "info", "PORT": "8080"},
+		SecretVariables: map[string]string{"API_KEY": "old-secret"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "shared",
+		Namespace:       "default",
+		Variables:       map[string]string{"LOG_LEVEL": "debug"},
+		SecretVariables: map[string]string{"API_KEY": "new-secret", "TOKEN": "token"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := agent.AddApplicationToVersionedConfigMap(configMap, "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := envgroup.RollbackEnvGroup(agent, "shared", "default", 1, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the rollback creates a new version with the variables of the first version
+	version, variables, secretVariables, err := envgroup.GetVariables(agent, "shared", "default", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if version != 3 {
+		t.Errorf("expected version 3, got %d", version)
+	}
+
+	assertValues(t, "variable", map[string]string{"LOG_LEVEL": "info", "PORT": "8080"}, variables)
+	assertValues(t, "secret variable", map[string]string{"API_KEY": "old-secret"}, secretVariables)
+
+	envGroup, err := envgroup.GetEnvGroup(agent, "shared", "default", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if envGroup.Variables["API_KEY"] != "PORTERSECRET_shared.v3" {
+		t.Errorf("expected API_KEY to reference the secret of version 3, got %s", envGroup.Variables["API_KEY"])
+	}
+
+	if len(envGroup.Applications) != 1 || envGroup.Applications[0] != "web" {
+		t.Errorf("expected web to stay linked, got %v", envGroup.Applications)
+	}
+
+	// the previous versions are kept
+	_, variables, secretVariables, err = envgroup.GetVariables(agent, "shared", "default", 2)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertValues(t, "variable", map[string]string{"LOG_LEVEL": "debug"}, variables)
+	assertValues(t, "secret variable", map[string]string{"API_KEY": "new-secret", "TOKEN": "token"}, secretVariables)
+
+	if _, err := envgroup.RollbackEnvGroup(agent, "shared", "default", 5, nil); err == nil {
+		t.Errorf("expected an error when rolling back to a version which doesn't exist")
+	}
+}
+
+func TestRollbackEnvGroupRestoresTemplates(t *testing.T) {
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+
+	host := "db.internal"
+
+	resolveOutput := func(ref string) (string, bool, error) {
+		if ref == "3.host" {
+			return host, false, nil
+		}
+
+		return "", false, fmt.Errorf("unknown output %s", ref)
+	}
+
+	_, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:      "shared",
+		Namespace: "default",
+		Variables: map[string]string{
+			"DB_HOST": "${infra.3.host}",
+			"LITERAL": "$${DB_HOST}",
+		},
+		SecretVariables: map[string]string{
+			"DATABASE_URL": "postgres://${DB_HOST}/app",
+		},
+		ResolveOutput: resolveOutput,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "shared",
+		Namespace:       "default",
+		Variables:       map[string]string{"DB_HOST": "localhost"},
+		SecretVariables: map[string]string{"DATABASE_URL": "postgres://localhost/app"},
+		ResolveOutput:   resolveOutput,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the output changed since the first version, so the templates of the first version resolve
+	// to the new value
+	host = "db-2.internal"
+
+	configMap, err := envgroup.RollbackEnvGroup(agent, "shared", "default", 1, resolveOutput)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, variables, secretVariables, err := envgroup.GetVariables(agent, "shared", "default", 3)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertValues(t, "variable", map[string]string{"DB_HOST": "db-2.internal", "LITERAL": "${DB_HOST}"}, variables)
+	assertValues(t, "secret variable", map[string]string{"DATABASE_URL": "postgres://db-2.internal/app"}, secretVariables)
+
+	// the templates are stored again, so that later versions keep resolving them
+	assertValues(t, "template", map[string]string{
+		"DB_HOST": "${infra.3.host}",
+		"LITERAL": "$${DB_HOST}",
+	}, envgroup.GetTemplates(configMap.Annotations))
+
+	secret, err := agent.GetVersionedSecret("shared", "default", 3)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertValues(t, "secret template", map[string]string{"DATABASE_URL": "postgres://${DB_HOST}/app"}, envgroup.GetTemplates(secret.Annotations))
+}
+
+func TestRollbackExternalEnvGroup(t *testing.T) {
+	agent := &kubernetes.Agent{
+		Clientset: fake.NewSimpleClientset(),
+		DynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{
+				{Group: "external-secrets.io", Version: "v1beta1", Resource: "externalsecrets"}: "ExternalSecretList",
+			},
+		),
+	}
+
+	_, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "shared",
+		Namespace:       "default",
+		Variables:       map[string]string{"LOG_LEVEL": "info"},
+		SecretVariables: map[string]string{"DB_PASSWORD": "prod/db#password"},
+		ExternalSecretStore: &types.ExternalSecretStore{
+			Kind:            types.ExternalSecretStoreKindClusterSecretStore,
+			Name:            "vault",
+			RefreshInterval: types.DefaultExternalSecretRefreshInterval,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "shared",
+		Namespace:       "default",
+		Variables:       map[string]string{"LOG_LEVEL": "debug"},
+		SecretVariables: map[string]string{"DB_PASSWORD": "prod/db-2#password", "API_KEY": "prod/api"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configMap, err := envgroup.RollbackEnvGroup(agent, "shared", "default", 1, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the references to the secrets in the store are rolled back, rather than their values
+	refs, err := envgroup.GetRemoteRefs(agent, configMap)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertValues(t, "reference", map[string]string{"DB_PASSWORD": "prod/db#password"}, refs)
+
+	envGroup, err := envgroup.ToEnvGroup(configMap)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertValues(t, "variable", map[string]string{
+		"LOG_LEVEL":   "info",
+		"DB_PASSWORD": "PORTERSECRET_shared.v3",
+	}, envGroup.Variables)
+
+	if store, err := envgroup.GetExternalSecretStore(configMap); err != nil || store == nil || store.Name != "vault" {
+		t.Errorf("expected the rolled back version to keep the store vault, got %v, %v", store, err)
+	}
+}
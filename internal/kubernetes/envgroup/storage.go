@@ -0,0 +1,135 @@
+package envgroup
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/repository/credentials"
+	v1 "k8s.io/api/core/v1"
+)
+
+// SecretStorage stores the values of the secret variables of the env groups of a cluster in the
+// external credential storage of the server, such as Vault. The storage is the source of the
+// values which are read through the API, while the secret of each version keeps a copy of the
+// values for the pods of the env group's applications.
+type SecretStorage struct {
+	backend   credentials.CredentialStorage
+	projectID uint
+	clusterID uint
+}
+
+// NewSecretStorage returns the secret storage of the env groups of a cluster, which is nil if the
+// server has no external credential storage
+func NewSecretStorage(backend credentials.CredentialStorage, projectID, clusterID uint) *SecretStorage {
+	if backend == nil {
+		return nil
+	}
+
+	return &SecretStorage{backend, projectID, clusterID}
+}
+
+func (s *SecretStorage) path(name, namespace string, version uint) *credentials.EnvGroupSecretPath {
+	return &credentials.EnvGroupSecretPath{
+		ProjectID: s.projectID,
+		ClusterID: s.clusterID,
+		Namespace: namespace,
+		Name:      name,
+		Version:   version,
+	}
+}
+
+// WriteSecrets writes the values of the secret variables of a version of an env group, which are
+// read from the secret of the version. Env groups which are synced from an external secret store
+// only hold references to secrets in the store, so their values are not written.
+func (s *SecretStorage) WriteSecrets(agent *kubernetes.Agent, cm *v1.ConfigMap) error {
+	if s == nil {
+		return nil
+	}
+
+	envGroup, err := ToEnvGroup(cm)
+
+	if err != nil {
+		return err
+	}
+
+	if envGroup.MetaVersion != 2 || envGroup.ExternalSecretStore != nil {
+		return nil
+	}
+
+	secretVariables := make(map[string]string)
+
+	secret, err := agent.GetVersionedSecret(envGroup.Name, envGroup.Namespace, envGroup.Version)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return err
+	} else if err == nil {
+		for key, val := range secret.Data {
+			secretVariables[key] = string(val)
+		}
+	}
+
+	return s.backend.WriteEnvGroupSecrets(s.path(envGroup.Name, envGroup.Namespace, envGroup.Version), secretVariables)
+}
+
+// DeleteSecrets deletes the values of the secret variables of every version of an env group
+func (s *SecretStorage) DeleteSecrets(name, namespace string) error {
+	if s == nil {
+		return nil
+	}
+
+	return s.backend.DeleteEnvGroupSecrets(s.path(name, namespace, 0))
+}
+
+// CreateStoredEnvGroup creates a new version of an env group, and writes the values of its secret
+// variables to the secret storage, if there is one
+func CreateStoredEnvGroup(agent *kubernetes.Agent, storage *SecretStorage, input types.ConfigMapInput) (*v1.ConfigMap, error) {
+	cm, err := CreateEnvGroup(agent, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.WriteSecrets(agent, cm); err != nil {
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+// GetStoredVariables returns a version of an env group like GetVariables, reading the values of
+// its secret variables from the secret storage, if there is one. Versions which were created
+// before the secret storage was configured, and were not migrated to it, are read from their
+// secret.
+func GetStoredVariables(
+	agent *kubernetes.Agent,
+	storage *SecretStorage,
+	name, namespace string,
+	version uint,
+) (uint, map[string]string, map[string]string, error) {
+	version, variables, secretVariables, err := GetVariables(agent, name, namespace, version)
+
+	if err != nil || storage == nil {
+		return version, variables, secretVariables, err
+	}
+
+	storedSecretVariables, err := storage.backend.GetEnvGroupSecrets(storage.path(name, namespace, version))
+
+	if err != nil && errors.Is(err, credentials.ErrNotFound) {
+		return version, variables, secretVariables, nil
+	} else if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return version, variables, storedSecretVariables, nil
+}
+
+// DeleteStoredEnvGroup deletes an env group, along with the values of its secret variables in the
+// secret storage, if there is one
+func DeleteStoredEnvGroup(agent *kubernetes.Agent, storage *SecretStorage, name, namespace string) error {
+	if err := DeleteEnvGroup(agent, name, namespace); err != nil {
+		return err
+	}
+
+	return storage.DeleteSecrets(name, namespace)
+}
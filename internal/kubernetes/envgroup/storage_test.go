@@ -0,0 +1,139 @@
+package envgroup_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/repository/credentials"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// memoryCredentialStorage stores the secret variables of env groups in memory. The other methods
+// of the credential storage are not used by env groups.
+type memoryCredentialStorage struct {
+	credentials.CredentialStorage
+
+	secrets map[string]map[string]string
+}
+
+func newMemoryCredentialStorage() *memoryCredentialStorage {
+	return &memoryCredentialStorage{secrets: make(map[string]map[string]string)}
+}
+
+func envGroupKey(path *credentials.EnvGroupSecretPath) string {
+	return fmt.Sprintf("%d/%d/%s/%s", path.ProjectID, path.ClusterID, path.Namespace, path.Name)
+}
+
+func (s *memoryCredentialStorage) WriteEnvGroupSecrets(path *credentials.EnvGroupSecretPath, data map[string]string) error {
+	s.secrets[fmt.Sprintf("%s/%d", envGroupKey(path), path.Version)] = data
+	return nil
+}
+
+func (s *memoryCredentialStorage) GetEnvGroupSecrets(path *credentials.EnvGroupSecretPath) (map[string]string, error) {
+	data, ok := s.secrets[fmt.Sprintf("%s/%d", envGroupKey(path), path.Version)]
+
+	if !ok {
+		return nil, credentials.ErrNotFound
+	}
+
+	return data, nil
+}
+
+func (s *memoryCredentialStorage) DeleteEnvGroupSecrets(path *credentials.EnvGroupSecretPath) error {
+	for version := uint(1); version <= 10; version++ {
+		delete(s.secrets, fmt.Sprintf("%s/%d", envGroupKey(path), version))
+	}
+
+	return nil
+}
+
+func TestNewSecretStorageWithoutBackend(t *testing.T) {
+	if storage := envgroup.NewSecretStorage(nil, 1, 1); storage != nil {
+		t.Errorf("expected no secret storage, got %v", storage)
+	}
+}
+
+func TestStoredEnvGroupSecrets(t *testing.T) {
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+	backend := newMemoryCredentialStorage()
+	storage := envgroup.NewSecretStorage(backend, 1, 2)
+
+	_, err := envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
+		Name:            "web",
+		Namespace:       "default",
+		Variables:       map[string]string{"LOG_LEVEL": "info"},
+		SecretVariables: map[string]string{"API_KEY": "secret"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stored, err := backend.GetEnvGroupSecrets(&credentials.EnvGroupSecretPath{
+		ProjectID: 1,
+		ClusterID: 2,
+		Namespace: "default",
+		Name:      "web",
+		Version:   1,
+	})
+
+	if err != nil {
+		t.Fatalf("expected the secret variables to be written, got error: %v", err)
+	}
+
+	if stored["API_KEY"] != "secret" {
+		t.Errorf("expected API_KEY to be written, got %v", stored)
+	}
+
+	// the values are read from the storage rather than from the secret of the version
+	stored["API_KEY"] = "stored"
+
+	version, variables, secretVariables, err := envgroup.GetStoredVariables(agent, storage, "web", "default", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if version != 1 || variables["LOG_LEVEL"] != "info" || secretVariables["API_KEY"] != "stored" {
+		t.Errorf("expected the stored values of version 1, got version %d, %v and %v", version, variables, secretVariables)
+	}
+
+	if err := envgroup.DeleteStoredEnvGroup(agent, storage, "web", "default"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(backend.secrets) != 0 {
+		t.Errorf("expected the stored secret variables to be deleted, got %v", backend.secrets)
+	}
+}
+
+func TestGetStoredVariablesNotMigrated(t *testing.T) {
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+
+	// the version is created before the secret storage is configured
+	_, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:            "web",
+		Namespace:       "default",
+		Variables:       map[string]string{},
+		SecretVariables: map[string]string{"API_KEY": "secret"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	storage := envgroup.NewSecretStorage(newMemoryCredentialStorage(), 1, 2)
+
+	_, _, secretVariables, err := envgroup.GetStoredVariables(agent, storage, "web", "default", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if secretVariables["API_KEY"] != "secret" {
+		t.Errorf("expected the values of the secret of the version, got %v", secretVariables)
+	}
+}
@@ -0,0 +1,290 @@
+package envgroup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TemplatesAnnotation is set on the configmap and the secret of a version of an env group, and
+// holds the templates which the values of its variables and secret variables were resolved from
+const TemplatesAnnotation = "porter.run/templates"
+
+// ErrInvalidTemplate is returned when the templates of an env group can't be resolved, because
+// they reference unknown variables or outputs, or reference each other in a cycle
+var ErrInvalidTemplate = errors.New("invalid env group template")
+
+// a reference is either ${KEY}, which is replaced with the value of another variable of the env
+// group, or ${infra.ID.OUTPUT}, which is replaced with an output of provisioned infra. $${ is an
+// escaped ${.
+var templateRefRegex = regexp.MustCompile(`\$\$\{|\$\{([^{}]*)\}`)
+
+const infraRefPrefix = "infra."
+
+// OutputResolver returns the value of an output of provisioned infra, referenced as ID.OUTPUT,
+// and whether the output is secret. Secret outputs can only be referenced by secret variables.
+type OutputResolver func(ref string) (value string, secret bool, err error)
+
+// ResolvedVariables are the variables and secret variables of an env group, with their templates
+// resolved
+type ResolvedVariables struct {
+	Variables       map[string]string
+	SecretVariables map[string]string
+
+	// Templates and SecretTemplates are the templates of the variables and secret variables
+	// which were resolved from templates
+	Templates       map[string]string
+	SecretTemplates map[string]string
+}
+
+// IsTemplate returns whether a value contains references or escaped references
+func IsTemplate(val string) bool {
+	return templateRefRegex.MatchString(val)
+}
+
+// EscapeTemplate escapes the references in a value, so that it resolves to itself
+func EscapeTemplate(val string) string {
+	return strings.ReplaceAll(val, "${", "$${")
+}
+
+// EscapeTemplates escapes the references in values, such as values read from an external system,
+// so that they resolve to themselves
+func EscapeTemplates(values map[string]string) map[string]string {
+	res := make(map[string]string)
+
+	for key, val := range values {
+		res[key] = EscapeTemplate(val)
+	}
+
+	return res
+}
+
+// ResolveTemplates replaces the references in the values of the variables and secret variables of
+// an env group. Variables can't reference secret variables or secret outputs, so that secret
+// values aren't stored in the configmap of the env group. If resolveOutput is nil, references to
+// infra outputs can't be resolved.
+func ResolveTemplates(variables, secretVariables map[string]string, resolveOutput OutputResolver) (*ResolvedVariables, error) {
+	r := &templateResolver{
+		variables:       variables,
+		secretVariables: secretVariables,
+		resolveOutput:   resolveOutput,
+		resolved:        make(map[string]string),
+		visiting:        make(map[string]bool),
+	}
+
+	res := &ResolvedVariables{
+		Variables:       make(map[string]string),
+		SecretVariables: make(map[string]string),
+		Templates:       make(map[string]string),
+		SecretTemplates: make(map[string]string),
+	}
+
+	for key, val := range variables {
+		if _, isSecret := secretVariables[key]; isSecret {
+			continue
+		}
+
+		resolved, err := r.resolve(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.Variables[key] = resolved
+
+		if IsTemplate(val) {
+			res.Templates[key] = val
+		}
+	}
+
+	for key, val := range secretVariables {
+		resolved, err := r.resolve(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.SecretVariables[key] = resolved
+
+		if IsTemplate(val) {
+			res.SecretTemplates[key] = val
+		}
+	}
+
+	return res, nil
+}
+
+type templateResolver struct {
+	variables       map[string]string
+	secretVariables map[string]string
+	resolveOutput   OutputResolver
+
+	resolved map[string]string
+	visiting map[string]bool
+
+	// path is the chain of references which is being resolved, to report cycles
+	path []string
+}
+
+func (r *templateResolver) resolve(key string) (string, error) {
+	if val, ok := r.resolved[key]; ok {
+		return val, nil
+	}
+
+	if r.visiting[key] {
+		cycle := []string{key}
+
+		for i := len(r.path) - 1; i >= 0 && r.path[i] != key; i-- {
+			cycle = append([]string{r.path[i]}, cycle...)
+		}
+
+		cycle = append([]string{key}, cycle...)
+
+		return "", fmt.Errorf("%w: variables reference each other in a cycle: %s", ErrInvalidTemplate, strings.Join(cycle, " -> "))
+	}
+
+	raw, secret := r.variables[key], false
+
+	if val, ok := r.secretVariables[key]; ok {
+		raw, secret = val, true
+	}
+
+	r.visiting[key] = true
+	r.path = append(r.path, key)
+
+	defer func() {
+		delete(r.visiting, key)
+		r.path = r.path[:len(r.path)-1]
+	}()
+
+	var resolveErr error
+
+	res := templateRefRegex.ReplaceAllStringFunc(raw, func(match string) string {
+		if resolveErr != nil {
+			return ""
+		}
+
+		if match == "$${" {
+			return "${"
+		}
+
+		val, err := r.resolveRef(key, secret, match[2:len(match)-1])
+
+		if err != nil {
+			resolveErr = err
+		}
+
+		return val
+	})
+
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	r.resolved[key] = res
+
+	return res, nil
+}
+
+func (r *templateResolver) resolveRef(key string, secret bool, ref string) (string, error) {
+	if strings.HasPrefix(ref, infraRefPrefix) {
+		if r.resolveOutput == nil {
+			return "", fmt.Errorf("%w: %s references %s, but infra outputs can't be referenced here", ErrInvalidTemplate, key, ref)
+		}
+
+		val, refSecret, err := r.resolveOutput(strings.TrimPrefix(ref, infraRefPrefix))
+
+		if err != nil {
+			return "", fmt.Errorf("%w: %s references %s: %v", ErrInvalidTemplate, key, ref, err)
+		}
+
+		if refSecret && !secret {
+			return "", fmt.Errorf("%w: %s references the secret output %s, so it must be a secret variable", ErrInvalidTemplate, key, ref)
+		}
+
+		return val, nil
+	}
+
+	_, isVariable := r.variables[ref]
+	_, isSecret := r.secretVariables[ref]
+
+	if !isVariable && !isSecret {
+		return "", fmt.Errorf("%w: %s references %s, which is not a variable of the env group", ErrInvalidTemplate, key, ref)
+	}
+
+	if isSecret && !secret {
+		return "", fmt.Errorf("%w: %s references the secret variable %s, so it must be a secret variable", ErrInvalidTemplate, key, ref)
+	}
+
+	return r.resolve(ref)
+}
+
+// GetTemplates returns the templates in the annotations of the configmap or the secret of a
+// version of an env group
+func GetTemplates(annotations map[string]string) map[string]string {
+	res := make(map[string]string)
+
+	if templatesStr, ok := annotations[TemplatesAnnotation]; ok && templatesStr != "" {
+		// versions with invalid annotations are treated as versions without templates
+		json.Unmarshal([]byte(templatesStr), &res)
+	}
+
+	return res
+}
+
+// setTemplates stores templates in annotations, and returns whether the annotations changed
+func setTemplates(annotations map[string]string, templates map[string]string) (bool, error) {
+	if len(templates) == 0 {
+		return false, nil
+	}
+
+	templatesBytes, err := json.Marshal(templates)
+
+	if err != nil {
+		return false, err
+	}
+
+	annotations[TemplatesAnnotation] = string(templatesBytes)
+
+	return true, nil
+}
+
+// carryOverTemplates replaces the values which are unchanged from the previous version of an env
+// group with their templates, so that they're resolved again. Unchanged values without templates
+// are escaped, so that values created before templates were supported aren't resolved.
+func carryOverTemplates(values, oldValues, oldTemplates map[string]string) map[string]string {
+	res := make(map[string]string)
+	unchanged := make(map[string]string)
+
+	for key, val := range values {
+		if oldVal, ok := oldValues[key]; ok && oldVal == val {
+			unchanged[key] = val
+		} else {
+			res[key] = val
+		}
+	}
+
+	for key, val := range RestoreTemplates(unchanged, oldTemplates) {
+		res[key] = val
+	}
+
+	return res
+}
+
+// RestoreTemplates replaces the values of a version of an env group with the templates they were
+// resolved from, and escapes the values without templates
+func RestoreTemplates(values, templates map[string]string) map[string]string {
+	res := make(map[string]string)
+
+	for key, val := range values {
+		if template, ok := templates[key]; ok {
+			res[key] = template
+		} else {
+			res[key] = EscapeTemplate(val)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,64 @@
+package envgroup
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// GetVariables returns a version of an env group, or the latest version if version is 0, along
+// with its variables and the decoded values of its secret variables
+func GetVariables(agent *kubernetes.Agent, name, namespace string, version uint) (uint, map[string]string, map[string]string, error) {
+	var cm *v1.ConfigMap
+	var err error
+
+	if version == 0 {
+		cm, version, err = agent.GetLatestVersionedConfigMap(name, namespace)
+	} else {
+		cm, err = agent.GetVersionedConfigMap(name, namespace, version)
+	}
+
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	variables, secretVariables, err := getVariables(agent, cm, name, namespace, version)
+
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return version, variables, secretVariables, nil
+}
+
+// getVariables returns the variables of a version of an env group, and the values of its secret
+// variables read from the secret of that version
+func getVariables(
+	agent *kubernetes.Agent,
+	cm *v1.ConfigMap,
+	name, namespace string,
+	version uint,
+) (map[string]string, map[string]string, error) {
+	variables := make(map[string]string)
+	secretVariables := make(map[string]string)
+
+	for key, val := range cm.Data {
+		if !strings.Contains(val, "PORTERSECRET") {
+			variables[key] = val
+		}
+	}
+
+	secret, err := agent.GetVersionedSecret(name, namespace, version)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return nil, nil, err
+	} else if err == nil {
+		for key, val := range secret.Data {
+			secretVariables[key] = string(val)
+		}
+	}
+
+	return variables, secretVariables, nil
+}
@@ -0,0 +1,233 @@
+package externalsecrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var externalSecretResource = schema.GroupVersionResource{
+	Group:    "external-secrets.io",
+	Version:  "v1beta1",
+	Resource: "externalsecrets",
+}
+
+var ErrNotInstalled = fmt.Errorf("the external secrets operator is not installed in this cluster")
+
+// ErrInvalidRemoteRef is returned when the value of a secret variable is not a reference to a
+// secret in the store
+var ErrInvalidRemoteRef = errors.New("invalid reference to a secret in the external secret store")
+
+// RemoteRef is a reference to a secret in an external secret store. The value of a secret
+// variable of an env group which is synced from a store is a reference of the form key, or
+// key#property to read a property of a structured secret.
+type RemoteRef struct {
+	Key      string
+	Property string
+}
+
+// ParseRemoteRef parses the reference in the value of a secret variable
+func ParseRemoteRef(val string) (*RemoteRef, error) {
+	strs := strings.SplitN(strings.TrimSpace(val), "#", 2)
+
+	res := &RemoteRef{
+		Key: strs[0],
+	}
+
+	if len(strs) == 2 {
+		res.Property = strs[1]
+
+		if res.Property == "" {
+			return nil, fmt.Errorf("%w: %s has an empty property", ErrInvalidRemoteRef, val)
+		}
+	}
+
+	if res.Key == "" {
+		return nil, fmt.Errorf("%w: the key must not be empty", ErrInvalidRemoteRef)
+	}
+
+	return res, nil
+}
+
+func (r *RemoteRef) String() string {
+	if r.Property == "" {
+		return r.Key
+	}
+
+	return fmt.Sprintf("%s#%s", r.Key, r.Property)
+}
+
+// ExternalSecret is an ExternalSecret resource, which the External Secrets Operator reconciles
+// into a Secret with the values of the referenced secrets in the store
+type ExternalSecret struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+
+	Store *types.ExternalSecretStore
+
+	// TargetName and TargetLabels are the name and the labels of the Secret created by the
+	// operator
+	TargetName   string
+	TargetLabels map[string]string
+
+	// Data maps the keys of the Secret to the references of their values in the store
+	Data map[string]*RemoteRef
+}
+
+// CreateExternalSecret creates an ExternalSecret resource. The Secret it targets is owned by
+// the ExternalSecret, so it's deleted along with the ExternalSecret.
+func CreateExternalSecret(client dynamic.Interface, es *ExternalSecret) error {
+	_, err := client.Resource(externalSecretResource).Namespace(es.Namespace).Create(
+		context.Background(),
+		toUnstructured(es),
+		metav1.CreateOptions{},
+	)
+
+	// if the ExternalSecret CRD is not registered, the API server returns a not found error
+	if err != nil && k8sErrors.IsNotFound(err) {
+		return ErrNotInstalled
+	}
+
+	return err
+}
+
+// GetRemoteRefs returns the references of the keys of an ExternalSecret
+func GetRemoteRefs(client dynamic.Interface, namespace, name string) (map[string]*RemoteRef, error) {
+	es, err := client.Resource(externalSecretResource).Namespace(namespace).Get(
+		context.Background(),
+		name,
+		metav1.GetOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := unstructured.NestedSlice(es.Object, "spec", "data")
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*RemoteRef)
+
+	for _, item := range data {
+		itemMap, ok := item.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		secretKey, _, _ := unstructured.NestedString(itemMap, "secretKey")
+		key, _, _ := unstructured.NestedString(itemMap, "remoteRef", "key")
+		property, _, _ := unstructured.NestedString(itemMap, "remoteRef", "property")
+
+		if secretKey != "" {
+			res[secretKey] = &RemoteRef{
+				Key:      key,
+				Property: property,
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteExternalSecrets deletes the ExternalSecrets in a namespace which match a label selector,
+// along with the Secrets they own
+func DeleteExternalSecrets(client dynamic.Interface, namespace, labelSelector string) error {
+	err := client.Resource(externalSecretResource).Namespace(namespace).DeleteCollection(
+		context.Background(),
+		metav1.DeleteOptions{},
+		metav1.ListOptions{
+			LabelSelector: labelSelector,
+		},
+	)
+
+	if err != nil && k8sErrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func toUnstructured(es *ExternalSecret) *unstructured.Unstructured {
+	keys := make([]string, 0, len(es.Data))
+
+	for key := range es.Data {
+		keys = append(keys, key)
+	}
+
+	// sort the keys so that the resource doesn't depend on the order of the map
+	sort.Strings(keys)
+
+	data := make([]interface{}, 0, len(keys))
+
+	for _, key := range keys {
+		remoteRef := map[string]interface{}{
+			"key": es.Data[key].Key,
+		}
+
+		if es.Data[key].Property != "" {
+			remoteRef["property"] = es.Data[key].Property
+		}
+
+		data = append(data, map[string]interface{}{
+			"secretKey": key,
+			"remoteRef": remoteRef,
+		})
+	}
+
+	refreshInterval := es.Store.RefreshInterval
+
+	if refreshInterval == "" {
+		refreshInterval = types.DefaultExternalSecretRefreshInterval
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "external-secrets.io/v1beta1",
+		"kind":       "ExternalSecret",
+		"metadata": map[string]interface{}{
+			"name":      es.Name,
+			"namespace": es.Namespace,
+			"labels":    toInterfaceMap(es.Labels),
+		},
+		"spec": map[string]interface{}{
+			"refreshInterval": refreshInterval,
+			"secretStoreRef": map[string]interface{}{
+				"kind": string(es.Store.Kind),
+				"name": es.Store.Name,
+			},
+			"target": map[string]interface{}{
+				"name":           es.TargetName,
+				"creationPolicy": "Owner",
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": toInterfaceMap(es.TargetLabels),
+					},
+				},
+			},
+			"data": data,
+		},
+	}}
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	for key, val := range m {
+		res[key] = val
+	}
+
+	return res
+}
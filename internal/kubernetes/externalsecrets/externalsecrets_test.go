@@ -0,0 +1,101 @@
+package externalsecrets_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/externalsecrets"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "external-secrets.io", Version: "v1beta1", Resource: "externalsecrets"}: "ExternalSecretList",
+		},
+	)
+}
+
+func TestParseRemoteRef(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected *externalsecrets.RemoteRef
+	}{
+		{"prod/db", &externalsecrets.RemoteRef{Key: "prod/db"}},
+		{"prod/db#password", &externalsecrets.RemoteRef{Key: "prod/db", Property: "password"}},
+		{" prod/db ", &externalsecrets.RemoteRef{Key: "prod/db"}},
+		{"", nil},
+		{"#password", nil},
+		{"prod/db#", nil},
+	}
+
+	for _, test := range tests {
+		ref, err := externalsecrets.ParseRemoteRef(test.val)
+
+		if test.expected == nil {
+			if !errors.Is(err, externalsecrets.ErrInvalidRemoteRef) {
+				t.Errorf("%q: expected ErrInvalidRemoteRef, got %v", test.val, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%q: %v", test.val, err)
+		}
+
+		if *ref != *test.expected {
+			t.Errorf("%q: expected %+v, got %+v", test.val, test.expected, ref)
+		}
+
+		if ref.String() != test.expected.String() {
+			t.Errorf("%q: expected %s, got %s", test.val, test.expected.String(), ref.String())
+		}
+	}
+}
+
+func TestCreateExternalSecret(t *testing.T) {
+	client := newFakeDynamicClient()
+
+	err := externalsecrets.CreateExternalSecret(client, &externalsecrets.ExternalSecret{
+		Name:      "web.v2",
+		Namespace: "default",
+		Labels:    map[string]string{"envgroup": "web", "version": "2"},
+		Store: &types.ExternalSecretStore{
+			Kind: types.ExternalSecretStoreKindClusterSecretStore,
+			Name: "vault",
+		},
+		TargetName:   "web.v2",
+		TargetLabels: map[string]string{"envgroup": "web", "version": "2", "configmap": "web.v2"},
+		Data: map[string]*externalsecrets.RemoteRef{
+			"DB_PASSWORD": {Key: "prod/db", Property: "password"},
+			"API_KEY":     {Key: "prod/api-key"},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := externalsecrets.GetRemoteRefs(client, "default", "web.v2")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 references, got %d", len(refs))
+	}
+
+	if refs["DB_PASSWORD"].String() != "prod/db#password" {
+		t.Errorf("expected prod/db#password, got %s", refs["DB_PASSWORD"].String())
+	}
+
+	if refs["API_KEY"].String() != "prod/api-key" {
+		t.Errorf("expected prod/api-key, got %s", refs["API_KEY"].String())
+	}
+}
@@ -0,0 +1,218 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// credentialsExpiryWarning is how long before credentials expire that the credentials check
+// starts warning about the expiry
+const credentialsExpiryWarning = 7 * 24 * time.Hour
+
+// CheckCluster runs connectivity checks against a cluster. The expiry of the credentials
+// used to connect to the cluster should be passed if known.
+func CheckCluster(ctx context.Context, clientset k8s.Interface, credsExpiry *time.Time, now time.Time) *types.ClusterHealth {
+	res := &types.ClusterHealth{
+		CheckedAt:           now,
+		CredentialsExpireAt: credsExpiry,
+	}
+
+	apiServerCheck := &types.ClusterHealthCheck{
+		Name:     types.ClusterHealthCheckAPIServer,
+		Critical: true,
+	}
+
+	credsCheck := checkCredentialsExpiry(credsExpiry, now)
+
+	start := time.Now()
+	version, err := clientset.Discovery().ServerVersion()
+
+	if err != nil && (errors.IsUnauthorized(err) || errors.IsForbidden(err)) {
+		// the API server is reachable, but rejected the credentials
+		apiServerCheck.Healthy = true
+		credsCheck.Healthy = false
+		credsCheck.Message = fmt.Sprintf("the cluster rejected Porter's credentials: %s", err.Error())
+	} else if err != nil {
+		apiServerCheck.Message = fmt.Sprintf("could not reach the API server: %s", err.Error())
+		credsCheck.Healthy = false
+		credsCheck.Message = "could not validate credentials since the API server is unreachable"
+	} else {
+		apiServerCheck.Healthy = true
+		apiServerCheck.Message = fmt.Sprintf("responded in %dms", time.Since(start).Milliseconds())
+		res.ServerVersion = version.GitVersion
+	}
+
+	res.Checks = append(res.Checks, apiServerCheck, credsCheck)
+
+	// the remaining checks require a working connection
+	if apiServerCheck.Healthy && credsCheck.Healthy {
+		res.Checks = append(res.Checks, checkMetrics(clientset))
+
+		agentCheck, agentVersion := checkAgent(ctx, clientset)
+		res.Checks = append(res.Checks, agentCheck)
+		res.AgentVersion = agentVersion
+	}
+
+	res.Healthy = true
+
+	for _, check := range res.Checks {
+		if check.Critical && !check.Healthy {
+			res.Healthy = false
+		}
+	}
+
+	return res
+}
+
+// ConnectionFailure returns the health of a cluster that Porter could not create a client for,
+// which is typically caused by invalid or expired credentials
+func ConnectionFailure(err error, credsExpiry *time.Time, now time.Time) *types.ClusterHealth {
+	return &types.ClusterHealth{
+		Healthy:             false,
+		CheckedAt:           now,
+		CredentialsExpireAt: credsExpiry,
+		Checks: []*types.ClusterHealthCheck{
+			{
+				Name:     types.ClusterHealthCheckCredentials,
+				Critical: true,
+				Message:  fmt.Sprintf("could not connect to the cluster: %s", err.Error()),
+			},
+		},
+	}
+}
+
+// GetCredentialsExpiry returns the expiry of the certificate or token used to connect to a
+// cluster. Credentials for cloud providers are refreshed automatically, so only credentials
+// from kubeconfigs are checked.
+func GetCredentialsExpiry(cluster *models.Cluster, kubeInt *ints.KubeIntegration) *time.Time {
+	if kubeInt == nil {
+		return nil
+	}
+
+	switch cluster.AuthMechanism {
+	case models.X509:
+		block, _ := pem.Decode(kubeInt.ClientCertificateData)
+
+		if block == nil {
+			return nil
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+
+		if err != nil {
+			return nil
+		}
+
+		return &cert.NotAfter
+	case models.Bearer:
+		claims := jwt.MapClaims{}
+
+		// the token is only inspected for its expiry, it is verified by the API server
+		if _, _, err := new(jwt.Parser).ParseUnverified(string(kubeInt.Token), claims); err != nil {
+			return nil
+		}
+
+		exp, ok := claims["exp"].(float64)
+
+		if !ok {
+			return nil
+		}
+
+		expiry := time.Unix(int64(exp), 0).UTC()
+
+		return &expiry
+	}
+
+	return nil
+}
+
+func checkCredentialsExpiry(credsExpiry *time.Time, now time.Time) *types.ClusterHealthCheck {
+	check := &types.ClusterHealthCheck{
+		Name:     types.ClusterHealthCheckCredentials,
+		Critical: true,
+		Healthy:  true,
+	}
+
+	if credsExpiry == nil {
+		return check
+	}
+
+	if credsExpiry.Before(now) {
+		check.Healthy = false
+		check.Message = fmt.Sprintf("credentials expired at %s", credsExpiry.Format(time.RFC3339))
+	} else if credsExpiry.Sub(now) < credentialsExpiryWarning {
+		check.Message = fmt.Sprintf("credentials expire at %s", credsExpiry.Format(time.RFC3339))
+	}
+
+	return check
+}
+
+func checkMetrics(clientset k8s.Interface) *types.ClusterHealthCheck {
+	check := &types.ClusterHealthCheck{
+		Name: types.ClusterHealthCheckMetrics,
+	}
+
+	_, found, err := prometheus.GetPrometheusService(clientset)
+
+	if err != nil {
+		check.Message = fmt.Sprintf("could not look up the prometheus service: %s", err.Error())
+	} else if !found {
+		check.Message = "prometheus is not installed, so metrics and autoscaling recommendations are unavailable"
+	} else {
+		check.Healthy = true
+	}
+
+	return check
+}
+
+func checkAgent(ctx context.Context, clientset k8s.Interface) (*types.ClusterHealthCheck, string) {
+	check := &types.ClusterHealthCheck{
+		Name: types.ClusterHealthCheckAgent,
+	}
+
+	depl, err := clientset.AppsV1().Deployments("porter-agent-system").Get(
+		ctx,
+		"porter-agent-controller-manager",
+		metav1.GetOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		check.Message = "the porter agent is not installed"
+		return check, ""
+	} else if err != nil {
+		check.Message = fmt.Sprintf("could not look up the porter agent: %s", err.Error())
+		return check, ""
+	}
+
+	version := depl.Annotations["porter.run/agent-major-version"]
+
+	if version == "" {
+		version = "v1"
+	}
+
+	version = "v" + strings.TrimPrefix(version, "v")
+
+	if depl.Status.AvailableReplicas == 0 {
+		check.Message = "the porter agent has no available replicas"
+	} else if version != "v3" {
+		check.Healthy = true
+		check.Message = fmt.Sprintf("the porter agent is outdated (%s), upgrade to v3", version)
+	} else {
+		check.Healthy = true
+	}
+
+	return check, version
+}
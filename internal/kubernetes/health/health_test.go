@@ -0,0 +1,82 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/health"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func getCheck(res *types.ClusterHealth, name types.ClusterHealthCheckName) *types.ClusterHealthCheck {
+	for _, check := range res.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+
+	return nil
+}
+
+func TestCheckClusterWithoutAddons(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	res := health.CheckCluster(context.Background(), clientset, nil, time.Now())
+
+	// missing metrics and a missing agent should not mark the cluster as unhealthy
+	if !res.Healthy {
+		t.Errorf("expected cluster to be healthy, got %+v", res.Checks)
+	}
+
+	if check := getCheck(res, types.ClusterHealthCheckMetrics); check == nil || check.Healthy {
+		t.Errorf("expected unhealthy metrics check, got %+v", check)
+	}
+
+	if check := getCheck(res, types.ClusterHealthCheckAgent); check == nil || check.Healthy {
+		t.Errorf("expected unhealthy agent check, got %+v", check)
+	}
+}
+
+func TestCheckClusterAgentVersion(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "porter-agent-controller-manager",
+			Namespace: "porter-agent-system",
+			Annotations: map[string]string{
+				"porter.run/agent-major-version": "v3",
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			AvailableReplicas: 1,
+		},
+	})
+
+	res := health.CheckCluster(context.Background(), clientset, nil, time.Now())
+
+	if res.AgentVersion != "v3" {
+		t.Errorf("expected agent version v3, got %s", res.AgentVersion)
+	}
+
+	if check := getCheck(res, types.ClusterHealthCheckAgent); check == nil || !check.Healthy {
+		t.Errorf("expected healthy agent check, got %+v", check)
+	}
+}
+
+func TestCheckClusterExpiredCredentials(t *testing.T) {
+	now := time.Now()
+	expiry := now.Add(-time.Hour)
+
+	res := health.CheckCluster(context.Background(), fake.NewSimpleClientset(), &expiry, now)
+
+	if res.Healthy {
+		t.Errorf("expected cluster with expired credentials to be unhealthy")
+	}
+
+	if check := getCheck(res, types.ClusterHealthCheckCredentials); check == nil || check.Healthy {
+		t.Errorf("expected unhealthy credentials check, got %+v", check)
+	}
+}
@@ -0,0 +1,209 @@
+package incidents
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// recentWindow is how far back OOM kills and failed probes are considered to be part of an
+// ongoing incident
+const recentWindow = 10 * time.Minute
+
+// DetectedIncident is a failure detected in the cluster, aggregated across all pods of the
+// owning workload
+type DetectedIncident struct {
+	Key     string
+	Reason  types.ClusterIncidentReason
+	Message string
+
+	Namespace          string
+	ReleaseName        string
+	InvolvedObjectKind string
+	InvolvedObjectName string
+
+	Pods []string
+}
+
+// Detector detects incidents from the pod states and events of a cluster
+type Detector struct {
+	clientset kubernetes.Interface
+}
+
+func NewDetector(clientset kubernetes.Interface) *Detector {
+	return &Detector{
+		clientset: clientset,
+	}
+}
+
+// Detect returns all incidents which are currently ongoing in the cluster
+func (d *Detector) Detect(ctx context.Context) ([]*DetectedIncident, error) {
+	podList, err := d.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := make(map[string]*DetectedIncident)
+	podsByKey := make(map[string]*v1.Pod)
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		podsByKey[pod.Namespace+"/"+pod.Name] = pod
+
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			reason, message := d.containerFailure(&status)
+
+			if reason != "" {
+				addIncident(incidents, pod, reason, message)
+			}
+		}
+	}
+
+	eventList, err := d.clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: "reason=Unhealthy,involvedObject.kind=Pod",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range eventList.Items {
+		if time.Now().Sub(lastEventTime(&event)) > recentWindow {
+			continue
+		}
+
+		pod, ok := podsByKey[event.InvolvedObject.Namespace+"/"+event.InvolvedObject.Name]
+
+		// events for deleted pods are ignored, since the probe failure no longer affects the workload
+		if !ok {
+			continue
+		}
+
+		addIncident(incidents, pod, types.ClusterIncidentReasonProbeFailed, event.Message)
+	}
+
+	res := make([]*DetectedIncident, 0, len(incidents))
+
+	for _, incident := range incidents {
+		sort.Strings(incident.Pods)
+		res = append(res, incident)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res, nil
+}
+
+func (d *Detector) containerFailure(status *v1.ContainerStatus) (types.ClusterIncidentReason, string) {
+	if waiting := status.State.Waiting; waiting != nil {
+		switch waiting.Reason {
+		case "CrashLoopBackOff":
+			message := fmt.Sprintf("container %s is crash looping", status.Name)
+
+			if term := status.LastTerminationState.Terminated; term != nil {
+				// a container which was OOM killed before restarting is reported as an OOM kill,
+				// since that is the underlying cause of the crash loop
+				if term.Reason == "OOMKilled" {
+					return types.ClusterIncidentReasonOOMKilled, fmt.Sprintf("container %s was killed after running out of memory", status.Name)
+				}
+
+				message = fmt.Sprintf("%s: last exited with code %d", message, term.ExitCode)
+			}
+
+			return types.ClusterIncidentReasonCrashLoop, message
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+			return types.ClusterIncidentReasonImagePull, fmt.Sprintf("container %s cannot pull image %s: %s", status.Name, status.Image, waiting.Message)
+		}
+	}
+
+	for _, term := range []*v1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+		if term != nil && term.Reason == "OOMKilled" && time.Now().Sub(term.FinishedAt.Time) <= recentWindow {
+			return types.ClusterIncidentReasonOOMKilled, fmt.Sprintf("container %s was killed after running out of memory", status.Name)
+		}
+	}
+
+	return "", ""
+}
+
+func addIncident(incidents map[string]*DetectedIncident, pod *v1.Pod, reason types.ClusterIncidentReason, message string) {
+	ownerKind, ownerName := getPodOwner(pod)
+	key := Key(pod.Namespace, ownerKind, ownerName, reason)
+
+	incident, ok := incidents[key]
+
+	if !ok {
+		incident = &DetectedIncident{
+			Key:                key,
+			Reason:             reason,
+			Message:            message,
+			Namespace:          pod.Namespace,
+			ReleaseName:        getReleaseName(pod),
+			InvolvedObjectKind: ownerKind,
+			InvolvedObjectName: ownerName,
+			Pods:               make([]string, 0),
+		}
+
+		incidents[key] = incident
+	}
+
+	for _, name := range incident.Pods {
+		if name == pod.Name {
+			return
+		}
+	}
+
+	incident.Pods = append(incident.Pods, pod.Name)
+}
+
+// Key returns the key of the incident of a workload which failed with a reason
+func Key(namespace, kind, name string, reason types.ClusterIncidentReason) string {
+	return strings.Join([]string{namespace, kind, name, string(reason)}, "/")
+}
+
+// getPodOwner returns the workload which owns a pod. Pods owned by a ReplicaSet are attributed
+// to the ReplicaSet's deployment.
+func getPodOwner(pod *v1.Pod) (string, string) {
+	controller := metav1.GetControllerOf(pod)
+
+	if controller == nil {
+		return "Pod", pod.Name
+	}
+
+	if controller.Kind == "ReplicaSet" {
+		if hash, ok := pod.Labels["pod-template-hash"]; ok && strings.HasSuffix(controller.Name, "-"+hash) {
+			return "Deployment", strings.TrimSuffix(controller.Name, "-"+hash)
+		}
+	}
+
+	return controller.Kind, controller.Name
+}
+
+func getReleaseName(pod *v1.Pod) string {
+	if name, ok := pod.Labels["app.kubernetes.io/instance"]; ok {
+		return name
+	}
+
+	return pod.Labels["release"]
+}
+
+func lastEventTime(event *v1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+
+	if event.Series != nil {
+		return event.Series.LastObservedTime.Time
+	}
+
+	return event.EventTime.Time
+}
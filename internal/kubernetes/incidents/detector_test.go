@@ -0,0 +1,142 @@
+package incidents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/incidents"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func deploymentPod(name string, statuses ...v1.ContainerStatus) *v1.Pod {
+	controller := true
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "web",
+				"pod-template-hash":          "abc123",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind:       "ReplicaSet",
+					Name:       "web-abc123",
+					Controller: &controller,
+				},
+			},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: statuses,
+		},
+	}
+}
+
+func TestDetectAggregatesPodsByWorkload(t *testing.T) {
+	crashLooping := v1.ContainerStatus{
+		Name: "web",
+		State: v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+		LastTerminationState: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{ExitCode: 1},
+		},
+	}
+
+	clientset := fake.NewSimpleClientset(
+		deploymentPod("web-abc123-1", crashLooping),
+		deploymentPod("web-abc123-2", crashLooping),
+	)
+
+	detected, err := incidents.NewDetector(clientset).Detect(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(detected) != 1 {
+		t.Fatalf("expected 1 incident, got %d", len(detected))
+	}
+
+	incident := detected[0]
+
+	if incident.Reason != types.ClusterIncidentReasonCrashLoop {
+		t.Errorf("expected reason %s, got %s", types.ClusterIncidentReasonCrashLoop, incident.Reason)
+	}
+
+	if incident.InvolvedObjectKind != "Deployment" || incident.InvolvedObjectName != "web" {
+		t.Errorf("expected incident on Deployment web, got %s %s", incident.InvolvedObjectKind, incident.InvolvedObjectName)
+	}
+
+	if incident.ReleaseName != "web" {
+		t.Errorf("expected release web, got %s", incident.ReleaseName)
+	}
+
+	if len(incident.Pods) != 2 {
+		t.Errorf("expected 2 pods, got %v", incident.Pods)
+	}
+}
+
+func TestDetectOOMKilled(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		deploymentPod("web-abc123-1", v1.ContainerStatus{
+			Name:  "web",
+			State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			LastTerminationState: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					Reason:     "OOMKilled",
+					FinishedAt: metav1.NewTime(time.Now().Add(-time.Minute)),
+				},
+			},
+		}),
+		// OOM kills outside of the detection window are ignored
+		deploymentPod("web-abc123-2", v1.ContainerStatus{
+			Name:  "web",
+			State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			LastTerminationState: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					Reason:     "OOMKilled",
+					FinishedAt: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			},
+		}),
+	)
+
+	detected, err := incidents.NewDetector(clientset).Detect(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(detected) != 1 || detected[0].Reason != types.ClusterIncidentReasonOOMKilled {
+		t.Fatalf("expected a single OOM incident, got %v", detected)
+	}
+
+	if len(detected[0].Pods) != 1 || detected[0].Pods[0] != "web-abc123-1" {
+		t.Errorf("expected only web-abc123-1 to be affected, got %v", detected[0].Pods)
+	}
+}
+
+func TestDetectNoIncidents(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		deploymentPod("web-abc123-1", v1.ContainerStatus{
+			Name:  "web",
+			State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+		}),
+	)
+
+	detected, err := incidents.NewDetector(clientset).Detect(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(detected) != 0 {
+		t.Errorf("expected no incidents, got %v", detected)
+	}
+}
@@ -0,0 +1,96 @@
+package incidents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultExcerptLines is the number of log lines attached to incident notifications
+const DefaultExcerptLines = 50
+
+// GetExcerpt returns a short excerpt which helps explain an incident. For crash loops, this
+// is the tail of the logs of the last terminated container. Containers which cannot pull
+// their image never ran, so the recent events of the pod are returned instead.
+func GetExcerpt(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	incident *types.ClusterIncident,
+	tailLines int64,
+) (string, error) {
+	if len(incident.Pods) == 0 {
+		return "", nil
+	}
+
+	if tailLines == 0 {
+		tailLines = DefaultExcerptLines
+	}
+
+	pod, err := clientset.CoreV1().Pods(incident.Namespace).Get(ctx, incident.Pods[0], metav1.GetOptions{})
+
+	if err != nil {
+		return "", err
+	}
+
+	switch incident.Reason {
+	case types.ClusterIncidentReasonCrashLoop:
+		return getCrashLogs(ctx, clientset, pod, tailLines)
+	case types.ClusterIncidentReasonImagePull:
+		return getPodEvents(ctx, clientset, pod, tailLines)
+	}
+
+	return "", nil
+}
+
+func getCrashLogs(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod, tailLines int64) (string, error) {
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" {
+			continue
+		}
+
+		raw, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+			Container: status.Name,
+			Previous:  true,
+			TailLines: &tailLines,
+		}).DoRaw(ctx)
+
+		if err != nil {
+			return "", fmt.Errorf("could not get logs of container %s: %w", status.Name, err)
+		}
+
+		return strings.TrimSpace(string(raw)), nil
+	}
+
+	return "", nil
+}
+
+func getPodEvents(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod, limit int64) (string, error) {
+	eventList, err := clientset.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", pod.Name),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0)
+
+	for _, event := range eventList.Items {
+		if event.Type != v1.EventTypeWarning {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s", event.Reason, event.Message))
+	}
+
+	if int64(len(lines)) > limit {
+		lines = lines[int64(len(lines))-limit:]
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
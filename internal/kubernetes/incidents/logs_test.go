@@ -0,0 +1,71 @@
+package incidents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/incidents"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetExcerptCrashLoopLogs(t *testing.T) {
+	clientset := fake.NewSimpleClientset(deploymentPod("web-abc123-1", v1.ContainerStatus{
+		Name: "web",
+		State: v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+	}))
+
+	excerpt, err := incidents.GetExcerpt(context.Background(), clientset, &types.ClusterIncident{
+		Reason:    types.ClusterIncidentReasonCrashLoop,
+		Namespace: "default",
+		Pods:      []string{"web-abc123-1"},
+	}, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fake clientset returns a fixed body for all log requests
+	if excerpt != "fake logs" {
+		t.Errorf("expected container logs, got %q", excerpt)
+	}
+}
+
+func TestGetExcerptImagePullEvents(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		deploymentPod("web-abc123-1", v1.ContainerStatus{
+			Name: "web",
+			State: v1.ContainerState{
+				Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+			},
+		}),
+		&v1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-abc123-1.1", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{
+				Kind: "Pod",
+				Name: "web-abc123-1",
+			},
+			Type:    v1.EventTypeWarning,
+			Reason:  "Failed",
+			Message: "Failed to pull image \"web:latest\"",
+		},
+	)
+
+	excerpt, err := incidents.GetExcerpt(context.Background(), clientset, &types.ClusterIncident{
+		Reason:    types.ClusterIncidentReasonImagePull,
+		Namespace: "default",
+		Pods:      []string{"web-abc123-1"},
+	}, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if excerpt != "Failed: Failed to pull image \"web:latest\"" {
+		t.Errorf("unexpected excerpt %q", excerpt)
+	}
+}
@@ -0,0 +1,106 @@
+package incidents
+
+import (
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// Reconcile stores the detected incidents for a cluster. New incidents are opened, ongoing
+// incidents are updated, and open incidents which were not detected are resolved. The
+// resulting changes are returned as events.
+func Reconcile(
+	repo repository.ClusterIncidentRepository,
+	cluster *models.Cluster,
+	detected []*DetectedIncident,
+	now time.Time,
+) ([]*types.ClusterIncidentEvent, error) {
+	active, err := repo.ListActiveClusterIncidents(cluster.ProjectID, cluster.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	activeByKey := make(map[string]*models.ClusterIncident)
+
+	for _, incident := range active {
+		activeByKey[incident.IncidentKey] = incident
+	}
+
+	res := make([]*types.ClusterIncidentEvent, 0)
+
+	for _, d := range detected {
+		pods := strings.Join(d.Pods, ",")
+
+		if incident, ok := activeByKey[d.Key]; ok {
+			delete(activeByKey, d.Key)
+
+			changed := incident.Pods != pods || incident.Message != d.Message
+
+			incident.Pods = pods
+			incident.Message = d.Message
+			incident.LastSeenAt = now
+
+			incident, err = repo.UpdateClusterIncident(incident)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if changed {
+				res = append(res, &types.ClusterIncidentEvent{
+					Type:     types.ClusterIncidentEventUpdated,
+					Incident: incident.ToClusterIncidentType(),
+				})
+			}
+
+			continue
+		}
+
+		incident, err := repo.CreateClusterIncident(&models.ClusterIncident{
+			ProjectID:          cluster.ProjectID,
+			ClusterID:          cluster.ID,
+			IncidentKey:        d.Key,
+			Reason:             string(d.Reason),
+			Message:            d.Message,
+			Namespace:          d.Namespace,
+			ReleaseName:        d.ReleaseName,
+			InvolvedObjectKind: d.InvolvedObjectKind,
+			InvolvedObjectName: d.InvolvedObjectName,
+			Pods:               pods,
+			StartedAt:          now,
+			LastSeenAt:         now,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &types.ClusterIncidentEvent{
+			Type:     types.ClusterIncidentEventOpened,
+			Incident: incident.ToClusterIncidentType(),
+		})
+	}
+
+	// any incidents which are still open but were not detected have been resolved
+	for _, incident := range activeByKey {
+		resolvedAt := now
+		incident.ResolvedAt = &resolvedAt
+
+		incident, err = repo.UpdateClusterIncident(incident)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &types.ClusterIncidentEvent{
+			Type:     types.ClusterIncidentEventResolved,
+			Incident: incident.ToClusterIncidentType(),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,188 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// DefaultTailLines is the number of lines from the end of each container's logs which are
+// streamed if neither a tail nor a start time is given
+const DefaultTailLines int64 = 100
+
+// maxStreams is the maximum number of container log streams which are opened at once, so that
+// broad selectors don't open a stream per container of a large namespace
+const maxStreams = 50
+
+var ErrNoPods = errors.New("no pods match the selector")
+
+// StreamOpts are the options of an aggregated log stream
+type StreamOpts struct {
+	Selector  string
+	Container string
+	Follow    bool
+
+	SinceTime    *time.Time
+	SinceSeconds int64
+	TailLines    int64
+}
+
+// WriteFunc writes a log line to the client of a stream. Lines of different containers are
+// written concurrently, so implementations must be safe for concurrent use.
+type WriteFunc func(line *types.PodLogLine) error
+
+type container struct {
+	pod  string
+	name string
+}
+
+// Stream streams the logs of the containers of every pod which matches a selector, until each
+// container's stream has ended or the context is cancelled
+func Stream(ctx context.Context, clientset k8s.Interface, namespace string, opts *StreamOpts, write WriteFunc) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: opts.Selector,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	containers := getContainers(pods.Items, opts.Container)
+
+	if len(containers) == 0 {
+		return ErrNoPods
+	}
+
+	if len(containers) > maxStreams {
+		return fmt.Errorf("the selector matches %d containers, but logs can be streamed from at most %d", len(containers), maxStreams)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var streamErr error
+
+	for _, c := range containers {
+		wg.Add(1)
+
+		go func(c container) {
+			defer wg.Done()
+
+			if err := streamContainer(ctx, clientset, namespace, c, opts, write); err != nil {
+				// a failed write means the client has gone away, so the other streams are stopped
+				once.Do(func() {
+					streamErr = err
+					cancel()
+				})
+			}
+		}(c)
+	}
+
+	wg.Wait()
+
+	return streamErr
+}
+
+// getContainers returns the containers of the pods whose logs are streamed. Pods which have not
+// been scheduled have no logs, so they are skipped.
+func getContainers(pods []v1.Pod, name string) []container {
+	res := make([]container, 0)
+
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodPending {
+			continue
+		}
+
+		for _, c := range pod.Spec.Containers {
+			if name == "" || c.Name == name {
+				res = append(res, container{pod.Name, c.Name})
+			}
+		}
+	}
+
+	return res
+}
+
+func streamContainer(
+	ctx context.Context,
+	clientset k8s.Interface,
+	namespace string,
+	c container,
+	opts *StreamOpts,
+	write WriteFunc,
+) error {
+	logOpts := &v1.PodLogOptions{
+		Container:  c.name,
+		Follow:     opts.Follow,
+		Timestamps: true,
+	}
+
+	if opts.SinceTime != nil {
+		sinceTime := metav1.NewTime(*opts.SinceTime)
+		logOpts.SinceTime = &sinceTime
+	} else if opts.SinceSeconds > 0 {
+		logOpts.SinceSeconds = &opts.SinceSeconds
+	}
+
+	if opts.TailLines > 0 {
+		logOpts.TailLines = &opts.TailLines
+	} else if logOpts.SinceTime == nil && logOpts.SinceSeconds == nil {
+		tailLines := DefaultTailLines
+		logOpts.TailLines = &tailLines
+	}
+
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(c.pod, logOpts).Stream(ctx)
+
+	if err != nil {
+		// containers which are not running yet, or have been removed, are skipped rather than
+		// ending the other streams
+		return write(&types.PodLogLine{
+			Pod:       c.pod,
+			Container: c.name,
+			Line:      fmt.Sprintf("unable to stream logs: %s", err.Error()),
+		})
+	}
+
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if err := write(ParseLine(c.pod, c.name, scanner.Text())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseLine parses a line of logs which were read with timestamps, which are prefixed with an
+// RFC 3339 timestamp and a space
+func ParseLine(pod, container, raw string) *types.PodLogLine {
+	res := &types.PodLogLine{
+		Pod:       pod,
+		Container: container,
+		Line:      raw,
+	}
+
+	if prefix, line, found := strings.Cut(raw, " "); found {
+		if timestamp, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			res.Timestamp = &timestamp
+			res.Line = line
+		}
+	}
+
+	return res
+}
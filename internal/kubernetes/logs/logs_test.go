@@ -0,0 +1,104 @@
+package logs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/logs"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func getPod(name string, phase v1.PodPhase, containers ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": "web",
+			},
+		},
+		Status: v1.PodStatus{
+			Phase: phase,
+		},
+	}
+
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: container})
+	}
+
+	return pod
+}
+
+func TestParseLine(t *testing.T) {
+	line := logs.ParseLine("web-1", "web", "2022-06-01T10:00:00.123456789Z GET / 200")
+
+	if line.Timestamp == nil || line.Timestamp.Nanosecond() != 123456789 {
+		t.Errorf("expected the timestamp to be parsed, got %v\n", line.Timestamp)
+	}
+
+	if line.Line != "GET / 200" {
+		t.Errorf("expected the timestamp to be removed from the line, got %s\n", line.Line)
+	}
+
+	line = logs.ParseLine("web-1", "web", "starting server")
+
+	if line.Timestamp != nil || line.Line != "starting server" {
+		t.Errorf("expected lines without timestamps to be unchanged, got %v\n", line)
+	}
+}
+
+func TestStream(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		getPod("web-1", v1.PodRunning, "web", "sidecar"),
+		getPod("web-2", v1.PodRunning, "web", "sidecar"),
+		getPod("web-3", v1.PodPending, "web", "sidecar"),
+	)
+
+	var mu sync.Mutex
+	lines := make([]*types.PodLogLine, 0)
+
+	err := logs.Stream(context.Background(), clientset, "default", &logs.StreamOpts{
+		Selector:  "app.kubernetes.io/instance=web",
+		Container: "web",
+	}, func(line *types.PodLogLine) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lines = append(lines, line)
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	pods := make(map[string]bool)
+
+	for _, line := range lines {
+		if line.Container != "web" {
+			t.Errorf("expected only logs of the web container, got %s\n", line.Container)
+		}
+
+		pods[line.Pod] = true
+	}
+
+	if len(pods) != 2 || !pods["web-1"] || !pods["web-2"] {
+		t.Errorf("expected logs of the running pods, got %v\n", pods)
+	}
+
+	err = logs.Stream(context.Background(), clientset, "default", &logs.StreamOpts{
+		Selector: "app.kubernetes.io/instance=api",
+	}, func(line *types.PodLogLine) error {
+		return nil
+	})
+
+	if !errors.Is(err, logs.ErrNoPods) {
+		t.Errorf("expected no pods error, got %v\n", err)
+	}
+}
@@ -0,0 +1,405 @@
+package maintenance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OriginalBackendsAnnotation stores the backends of an ingress's paths before they were
+	// routed to the maintenance page, keyed by host and path
+	OriginalBackendsAnnotation = "porter.run/maintenance-original-backends"
+
+	// ReleaseLabel is set on the resources which serve the maintenance page of a release
+	ReleaseLabel = "porter.run/maintenance-release"
+
+	pageChecksumAnnotation = "porter.run/maintenance-page-checksum"
+
+	image       = "nginxinc/nginx-unprivileged:1.23-alpine"
+	servicePort = 80
+	nginxPort   = 8080
+
+	// defaultBackendKey is the key of an ingress's default backend in the original backends
+	defaultBackendKey = "*"
+)
+
+// DefaultPageHTML is the maintenance page which is served if no page is configured
+const DefaultPageHTML = `<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <title>Down for maintenance</title>
+  </head>
+  <body style="font-family: sans-serif; text-align: center; padding-top: 15vh;">
+    <h1>We'll be back soon</h1>
+    <p>This application is undergoing maintenance. Please check back shortly.</p>
+  </body>
+</html>
+`
+
+// every request is answered with a 503, so that clients and crawlers don't cache the page
+const nginxConf = `server {
+  listen 8080;
+
+  error_page 503 /maintenance.html;
+
+  location / {
+    add_header Retry-After 300 always;
+    return 503;
+  }
+
+  location = /maintenance.html {
+    root /usr/share/nginx/html;
+    add_header Retry-After 300 always;
+    internal;
+  }
+}
+`
+
+// ResourceName returns the name of the deployment, service and config map which serve the
+// maintenance page of a release
+func ResourceName(releaseName string) string {
+	name := fmt.Sprintf("%s-maintenance", releaseName)
+
+	// service names must be valid DNS labels
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+
+	return name
+}
+
+// Enable serves a maintenance page for a release and routes every path of the release's
+// ingresses to it. The original backends are stored on each ingress, so that routing can be
+// restored by Disable. Enable can be called again to update the page.
+func Enable(clientset kubernetes.Interface, namespace, releaseName, pageHTML string) error {
+	if pageHTML == "" {
+		pageHTML = DefaultPageHTML
+	}
+
+	if err := applyPage(clientset, namespace, releaseName, pageHTML); err != nil {
+		return fmt.Errorf("could not create the maintenance page: %w", err)
+	}
+
+	return Reapply(clientset, namespace, releaseName)
+}
+
+// Reapply routes the paths of the release's ingresses which are not routed to the maintenance
+// page, such as paths which were re-rendered by an upgrade, to the maintenance page, keeping the
+// page which is currently served
+func Reapply(clientset kubernetes.Interface, namespace, releaseName string) error {
+	ingresses, err := listReleaseIngresses(clientset, namespace, releaseName)
+
+	if err != nil {
+		return err
+	}
+
+	svcName := ResourceName(releaseName)
+
+	for _, ingress := range ingresses {
+		original := make(map[string]netv1.IngressBackend)
+
+		if data, ok := ingress.Annotations[OriginalBackendsAnnotation]; ok {
+			if err := json.Unmarshal([]byte(data), &original); err != nil {
+				return fmt.Errorf("could not parse the original backends of ingress %s: %w", ingress.Name, err)
+			}
+		}
+
+		changed := false
+
+		forEachBackend(ingress, func(key string, backend *netv1.IngressBackend) {
+			if isMaintenanceBackend(backend, svcName) {
+				return
+			}
+
+			original[key] = *backend
+			*backend = maintenanceBackend(svcName)
+			changed = true
+		})
+
+		if !changed {
+			continue
+		}
+
+		data, err := json.Marshal(original)
+
+		if err != nil {
+			return err
+		}
+
+		if ingress.Annotations == nil {
+			ingress.Annotations = make(map[string]string)
+		}
+
+		ingress.Annotations[OriginalBackendsAnnotation] = string(data)
+
+		_, err = clientset.NetworkingV1().Ingresses(namespace).Update(context.Background(), &ingress, metav1.UpdateOptions{})
+
+		if err != nil {
+			return fmt.Errorf("could not route ingress %s to the maintenance page: %w", ingress.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Disable restores the original routing of the release's ingresses and deletes the resources
+// which serve the maintenance page
+func Disable(clientset kubernetes.Interface, namespace, releaseName string) error {
+	ingresses, err := listReleaseIngresses(clientset, namespace, releaseName)
+
+	if err != nil {
+		return err
+	}
+
+	svcName := ResourceName(releaseName)
+
+	for _, ingress := range ingresses {
+		data, ok := ingress.Annotations[OriginalBackendsAnnotation]
+
+		if !ok {
+			continue
+		}
+
+		original := make(map[string]netv1.IngressBackend)
+
+		if err := json.Unmarshal([]byte(data), &original); err != nil {
+			return fmt.Errorf("could not parse the original backends of ingress %s: %w", ingress.Name, err)
+		}
+
+		forEachBackend(ingress, func(key string, backend *netv1.IngressBackend) {
+			if originalBackend, ok := original[key]; ok && isMaintenanceBackend(backend, svcName) {
+				*backend = originalBackend
+			}
+		})
+
+		delete(ingress.Annotations, OriginalBackendsAnnotation)
+
+		_, err = clientset.NetworkingV1().Ingresses(namespace).Update(context.Background(), &ingress, metav1.UpdateOptions{})
+
+		if err != nil {
+			return fmt.Errorf("could not restore the routing of ingress %s: %w", ingress.Name, err)
+		}
+	}
+
+	return deletePage(clientset, namespace, releaseName)
+}
+
+// listReleaseIngresses returns the ingresses rendered by the release's chart, and the ingress
+// which routes the release's custom domains
+func listReleaseIngresses(clientset kubernetes.Interface, namespace, releaseName string) ([]netv1.Ingress, error) {
+	ingresses, err := clientset.NetworkingV1().Ingresses(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]netv1.Ingress, 0)
+
+	for _, ingress := range ingresses.Items {
+		if ingress.Annotations["meta.helm.sh/release-name"] == releaseName ||
+			ingress.Labels[domain.CustomDomainReleaseLabel] == releaseName {
+			res = append(res, ingress)
+		}
+	}
+
+	return res, nil
+}
+
+// forEachBackend calls f with every service backend of an ingress, keyed by host and path
+func forEachBackend(ingress netv1.Ingress, f func(key string, backend *netv1.IngressBackend)) {
+	if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil {
+		f(defaultBackendKey, ingress.Spec.DefaultBackend)
+	}
+
+	for i := range ingress.Spec.Rules {
+		rule := &ingress.Spec.Rules[i]
+
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for j := range rule.HTTP.Paths {
+			path := &rule.HTTP.Paths[j]
+
+			if path.Backend.Service != nil {
+				f(rule.Host+path.Path, &path.Backend)
+			}
+		}
+	}
+}
+
+func maintenanceBackend(svcName string) netv1.IngressBackend {
+	return netv1.IngressBackend{
+		Service: &netv1.IngressServiceBackend{
+			Name: svcName,
+			Port: netv1.ServiceBackendPort{Number: servicePort},
+		},
+	}
+}
+
+func isMaintenanceBackend(backend *netv1.IngressBackend, svcName string) bool {
+	return backend.Service != nil && backend.Service.Name == svcName
+}
+
+// applyPage creates or updates the config map, deployment and service which serve the
+// maintenance page
+func applyPage(clientset kubernetes.Interface, namespace, releaseName, pageHTML string) error {
+	name := ResourceName(releaseName)
+	labels := map[string]string{ReleaseLabel: releaseName}
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			"default.conf":     nginxConf,
+			"maintenance.html": pageHTML,
+		},
+	}
+
+	configMaps := clientset.CoreV1().ConfigMaps(namespace)
+
+	if _, err := configMaps.Create(context.Background(), configMap, metav1.CreateOptions{}); errors.IsAlreadyExists(err) {
+		_, err = configMaps.Update(context.Background(), configMap, metav1.UpdateOptions{})
+
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	replicas := int32(1)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					// config maps mounted with a sub path are not updated in running pods,
+					// so the pods are replaced when the page changes
+					Annotations: map[string]string{
+						pageChecksumAnnotation: fmt.Sprintf("%x", sha256.Sum256([]byte(pageHTML))),
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "nginx",
+							Image: image,
+							Ports: []v1.ContainerPort{{ContainerPort: nginxPort}},
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{
+									v1.ResourceCPU:    resource.MustParse("10m"),
+									v1.ResourceMemory: resource.MustParse("16Mi"),
+								},
+								Limits: v1.ResourceList{
+									v1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+							},
+							VolumeMounts: []v1.VolumeMount{
+								{
+									Name:      "page",
+									MountPath: "/etc/nginx/conf.d/default.conf",
+									SubPath:   "default.conf",
+								},
+								{
+									Name:      "page",
+									MountPath: "/usr/share/nginx/html/maintenance.html",
+									SubPath:   "maintenance.html",
+								},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: "page",
+							VolumeSource: v1.VolumeSource{
+								ConfigMap: &v1.ConfigMapVolumeSource{
+									LocalObjectReference: v1.LocalObjectReference{Name: name},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	deployments := clientset.AppsV1().Deployments(namespace)
+
+	if _, err := deployments.Create(context.Background(), deployment, metav1.CreateOptions{}); errors.IsAlreadyExists(err) {
+		_, err = deployments.Update(context.Background(), deployment, metav1.UpdateOptions{})
+
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: v1.ServiceSpec{
+			Selector: labels,
+			Ports: []v1.ServicePort{
+				{
+					Name:       "http",
+					Port:       servicePort,
+					TargetPort: intstr.FromInt(nginxPort),
+				},
+			},
+		},
+	}
+
+	if _, err := clientset.CoreV1().Services(namespace).Create(context.Background(), service, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+func deletePage(clientset kubernetes.Interface, namespace, releaseName string) error {
+	name := ResourceName(releaseName)
+
+	if err := clientset.CoreV1().Services(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err := clientset.AppsV1().Deployments(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err := clientset.CoreV1().ConfigMaps(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testIngress(name string, labels, annotations map[string]string, hosts ...string) *netv1.Ingress {
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+
+	for _, host := range hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, netv1.IngressRule{
+			Host: host,
+			IngressRuleValue: netv1.IngressRuleValue{
+				HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{
+						{
+							Path: "/",
+							Backend: netv1.IngressBackend{
+								Service: &netv1.IngressServiceBackend{
+									Name: "web",
+									Port: netv1.ServiceBackendPort{Number: 80},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return ingress
+}
+
+func getBackend(t *testing.T, clientset *fake.Clientset, name string, rule int) string {
+	ingress, err := clientset.NetworkingV1().Ingresses("default").Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return ingress.Spec.Rules[rule].HTTP.Paths[0].Backend.Service.Name
+}
+
+func TestEnableDisable(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		testIngress("web", nil, map[string]string{"meta.helm.sh/release-name": "web"}, "web.porter.run"),
+		testIngress("web-custom-domains", map[string]string{domain.CustomDomainReleaseLabel: "web"}, nil, "example.com"),
+		testIngress("other", nil, map[string]string{"meta.helm.sh/release-name": "other"}, "other.porter.run"),
+	)
+
+	if err := Enable(clientset, "default", "web", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"web", "web-custom-domains"} {
+		if backend := getBackend(t, clientset, name, 0); backend != "web-maintenance" {
+			t.Errorf("expected ingress %s to be routed to the maintenance page, got %s", name, backend)
+		}
+	}
+
+	if backend := getBackend(t, clientset, "other", 0); backend != "web" {
+		t.Errorf("expected the ingress of another release to be left unchanged, got %s", backend)
+	}
+
+	if _, err := clientset.AppsV1().Deployments("default").Get(context.Background(), "web-maintenance", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the maintenance deployment to be created: %v", err)
+	}
+
+	// a custom domain which is added while in maintenance mode is routed to the maintenance page
+	// when maintenance mode is enabled again, without losing the original backends
+	ingress, _ := clientset.NetworkingV1().Ingresses("default").Get(context.Background(), "web-custom-domains", metav1.GetOptions{})
+	ingress.Spec.Rules = append(ingress.Spec.Rules, testIngress("", nil, nil, "example.org").Spec.Rules...)
+	clientset.NetworkingV1().Ingresses("default").Update(context.Background(), ingress, metav1.UpdateOptions{})
+
+	if err := Enable(clientset, "default", "web", "<h1>Back soon</h1>"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if backend := getBackend(t, clientset, "web-custom-domains", 1); backend != "web-maintenance" {
+		t.Errorf("expected the new custom domain to be routed to the maintenance page, got %s", backend)
+	}
+
+	configMap, _ := clientset.CoreV1().ConfigMaps("default").Get(context.Background(), "web-maintenance", metav1.GetOptions{})
+
+	if configMap.Data["maintenance.html"] != "<h1>Back soon</h1>" {
+		t.Errorf("expected the maintenance page to be updated, got %s", configMap.Data["maintenance.html"])
+	}
+
+	if err := Disable(clientset, "default", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, rules := range map[string]int{"web": 1, "web-custom-domains": 2} {
+		for i := 0; i < rules; i++ {
+			if backend := getBackend(t, clientset, name, i); backend != "web" {
+				t.Errorf("expected the routing of ingress %s to be restored, got %s", name, backend)
+			}
+		}
+
+		ingress, _ := clientset.NetworkingV1().Ingresses("default").Get(context.Background(), name, metav1.GetOptions{})
+
+		if _, ok := ingress.Annotations[OriginalBackendsAnnotation]; ok {
+			t.Errorf("expected the original backends annotation to be removed from ingress %s", name)
+		}
+	}
+
+	if _, err := clientset.CoreV1().Services("default").Get(context.Background(), "web-maintenance", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected the maintenance service to be deleted")
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	name := ResourceName("a-release-name-which-is-fifty-three-characters-long-xx")
+
+	if len(name) > 63 {
+		t.Errorf("expected the name to be a valid DNS label, got %s", name)
+	}
+}
@@ -0,0 +1,164 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// PresetLabel is set on network policies created from a preset profile
+const PresetLabel = "porter.run/network-policy-preset"
+
+var ErrInvalidPolicy = fmt.Errorf("either a preset or a name and spec must be set")
+
+// ingressNamespaceNames are the namespaces that ingress controllers installed by Porter run in
+var ingressNamespaceNames = []string{"ingress-nginx", "nginx-ingress"}
+
+// BuildNetworkPolicy constructs the network policy described by the request, without applying it
+func BuildNetworkPolicy(namespace string, req *types.ApplyNetworkPolicyRequest) (*netv1.NetworkPolicy, error) {
+	if req.Preset != "" {
+		return buildPreset(namespace, req.Preset)
+	}
+
+	if req.Name == "" || req.Spec == nil {
+		return nil, ErrInvalidPolicy
+	}
+
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: namespace,
+		},
+		Spec: *req.Spec,
+	}, nil
+}
+
+// ListNetworkPolicies lists the network policies in a namespace
+func ListNetworkPolicies(clientset k8s.Interface, namespace string) ([]*types.NetworkPolicy, error) {
+	policyList, err := clientset.NetworkingV1().NetworkPolicies(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.NetworkPolicy, 0)
+
+	for _, policy := range policyList.Items {
+		res = append(res, ToNetworkPolicyType(&policy))
+	}
+
+	return res, nil
+}
+
+// ApplyNetworkPolicy creates the network policy, or updates it if a policy with the same
+// name already exists
+func ApplyNetworkPolicy(clientset k8s.Interface, policy *netv1.NetworkPolicy) (*types.NetworkPolicy, error) {
+	policies := clientset.NetworkingV1().NetworkPolicies(policy.Namespace)
+
+	existing, err := policies.Get(context.Background(), policy.Name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		created, err := policies.Create(context.Background(), policy, metav1.CreateOptions{})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return ToNetworkPolicyType(created), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	existing.Labels = policy.Labels
+	existing.Spec = policy.Spec
+
+	updated, err := policies.Update(context.Background(), existing, metav1.UpdateOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ToNetworkPolicyType(updated), nil
+}
+
+// DeleteNetworkPolicy deletes a network policy
+func DeleteNetworkPolicy(clientset k8s.Interface, namespace, name string) error {
+	err := clientset.NetworkingV1().NetworkPolicies(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return kubernetes.IsNotFoundError
+	}
+
+	return err
+}
+
+func ToNetworkPolicyType(policy *netv1.NetworkPolicy) *types.NetworkPolicy {
+	return &types.NetworkPolicy{
+		Name:      policy.Name,
+		Namespace: policy.Namespace,
+		Preset:    types.NetworkPolicyPreset(policy.Labels[PresetLabel]),
+		Spec:      policy.Spec,
+		CreatedAt: policy.CreationTimestamp.Time,
+	}
+}
+
+func buildPreset(namespace string, preset types.NetworkPolicyPreset) (*netv1.NetworkPolicy, error) {
+	policy := &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("porter-%s", preset),
+			Namespace: namespace,
+			Labels: map[string]string{
+				PresetLabel: string(preset),
+			},
+		},
+		Spec: netv1.NetworkPolicySpec{
+			// an empty pod selector selects all pods in the namespace
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+		},
+	}
+
+	sameNamespacePeer := netv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{},
+	}
+
+	switch preset {
+	case types.NetworkPolicyPresetDenyAll:
+		// no ingress rules, so all ingress traffic is denied
+	case types.NetworkPolicyPresetNamespaceIsolated:
+		policy.Spec.Ingress = []netv1.NetworkPolicyIngressRule{
+			{
+				From: []netv1.NetworkPolicyPeer{sameNamespacePeer},
+			},
+		}
+	case types.NetworkPolicyPresetAllowFromIngress:
+		policy.Spec.Ingress = []netv1.NetworkPolicyIngressRule{
+			{
+				From: []netv1.NetworkPolicyPeer{
+					sameNamespacePeer,
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{
+									Key:      "kubernetes.io/metadata.name",
+									Operator: metav1.LabelSelectorOpIn,
+									Values:   ingressNamespaceNames,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unknown network policy preset %s", preset)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,327 @@
+package networkpolicy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// workload is a group of pods which are treated as a single connection source, so that
+// a preview doesn't contain one entry per replica
+type workload struct {
+	name string
+	pod  *v1.Pod
+}
+
+// PreviewNetworkPolicy computes which connections to the services in the policy's namespace
+// are allowed by the current network policies, but would be blocked if the policy was applied.
+//
+// Network policies don't expose observed traffic, so every workload in the cluster is treated
+// as a potential client of every service in the namespace.
+func PreviewNetworkPolicy(clientset k8s.Interface, policy *netv1.NetworkPolicy) ([]*types.BlockedConnection, error) {
+	ctx := context.Background()
+
+	nsList, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	nsLabels := make(map[string]map[string]string)
+
+	for _, ns := range nsList.Items {
+		nsLabels[ns.Name] = ns.Labels
+	}
+
+	podList, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	policyList, err := clientset.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	svcList, err := clientset.CoreV1().Services(policy.Namespace).List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]netv1.NetworkPolicy, 0)
+
+	for _, p := range policyList.Items {
+		// an applied policy with the same name replaces the existing one
+		if p.Namespace == policy.Namespace && p.Name == policy.Name {
+			continue
+		}
+
+		current = append(current, p)
+	}
+
+	proposed := append(append([]netv1.NetworkPolicy{}, current...), *policy)
+
+	currEval := &evaluator{policies: policyList.Items, nsLabels: nsLabels}
+	nextEval := &evaluator{policies: proposed, nsLabels: nsLabels}
+
+	sources := getWorkloads(podList.Items)
+	res := make([]*types.BlockedConnection, 0)
+
+	for _, svc := range svcList.Items {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		dst := getServicePod(podList.Items, &svc)
+
+		if dst == nil {
+			continue
+		}
+
+		for _, port := range svc.Spec.Ports {
+			for _, src := range sources {
+				if src.pod.UID == dst.UID {
+					continue
+				}
+
+				if !currEval.allowed(src.pod, dst, &port) {
+					continue
+				}
+
+				direction := ""
+
+				if !nextEval.ingressAllowed(src.pod, dst, &port) {
+					direction = string(netv1.PolicyTypeIngress)
+				} else if !nextEval.egressAllowed(src.pod, dst, &port) {
+					direction = string(netv1.PolicyTypeEgress)
+				}
+
+				if direction != "" {
+					res = append(res, &types.BlockedConnection{
+						SourceNamespace:      src.pod.Namespace,
+						SourceWorkload:       src.name,
+						DestinationNamespace: svc.Namespace,
+						DestinationService:   svc.Name,
+						DestinationPort:      port.Port,
+						Direction:            direction,
+					})
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+type evaluator struct {
+	policies []netv1.NetworkPolicy
+	nsLabels map[string]map[string]string
+}
+
+func (e *evaluator) allowed(src, dst *v1.Pod, port *v1.ServicePort) bool {
+	return e.ingressAllowed(src, dst, port) && e.egressAllowed(src, dst, port)
+}
+
+func (e *evaluator) ingressAllowed(src, dst *v1.Pod, port *v1.ServicePort) bool {
+	isolated := false
+
+	for _, policy := range e.policies {
+		if !e.selects(&policy, dst, netv1.PolicyTypeIngress) {
+			continue
+		}
+
+		isolated = true
+
+		for _, rule := range policy.Spec.Ingress {
+			if e.peersMatch(&policy, rule.From, src) && portsMatch(rule.Ports, port) {
+				return true
+			}
+		}
+	}
+
+	return !isolated
+}
+
+func (e *evaluator) egressAllowed(src, dst *v1.Pod, port *v1.ServicePort) bool {
+	isolated := false
+
+	for _, policy := range e.policies {
+		if !e.selects(&policy, src, netv1.PolicyTypeEgress) {
+			continue
+		}
+
+		isolated = true
+
+		for _, rule := range policy.Spec.Egress {
+			if e.peersMatch(&policy, rule.To, dst) && portsMatch(rule.Ports, port) {
+				return true
+			}
+		}
+	}
+
+	return !isolated
+}
+
+// selects returns true if the policy isolates the pod for the given direction
+func (e *evaluator) selects(policy *netv1.NetworkPolicy, pod *v1.Pod, policyType netv1.PolicyType) bool {
+	if policy.Namespace != pod.Namespace || !hasPolicyType(policy, policyType) {
+		return false
+	}
+
+	return selectorMatches(&policy.Spec.PodSelector, pod.Labels)
+}
+
+func (e *evaluator) peersMatch(policy *netv1.NetworkPolicy, peers []netv1.NetworkPolicyPeer, pod *v1.Pod) bool {
+	// a rule without peers matches all sources or destinations
+	if len(peers) == 0 {
+		return true
+	}
+
+	for _, peer := range peers {
+		// ip blocks are meant for traffic from outside the cluster, so they never match a pod
+		if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+			continue
+		}
+
+		if peer.NamespaceSelector == nil {
+			if pod.Namespace == policy.Namespace && selectorMatches(peer.PodSelector, pod.Labels) {
+				return true
+			}
+
+			continue
+		}
+
+		if !selectorMatches(peer.NamespaceSelector, e.nsLabels[pod.Namespace]) {
+			continue
+		}
+
+		if peer.PodSelector == nil || selectorMatches(peer.PodSelector, pod.Labels) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasPolicyType(policy *netv1.NetworkPolicy, policyType netv1.PolicyType) bool {
+	// if policy types are not set, ingress is always implied and egress is implied when
+	// the policy has egress rules
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == netv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func portsMatch(ports []netv1.NetworkPolicyPort, svcPort *v1.ServicePort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	targetPort := svcPort.TargetPort
+
+	if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+		targetPort = intstr.FromInt(int(svcPort.Port))
+	}
+
+	for _, port := range ports {
+		if port.Protocol != nil && svcPort.Protocol != "" && *port.Protocol != svcPort.Protocol {
+			continue
+		}
+
+		if port.Port == nil {
+			return true
+		}
+
+		if port.Port.Type == targetPort.Type && port.Port.String() == targetPort.String() {
+			return true
+		}
+
+		if port.Port.Type == intstr.Int && targetPort.Type == intstr.Int && port.EndPort != nil &&
+			targetPort.IntVal >= port.Port.IntVal && targetPort.IntVal <= *port.EndPort {
+			return true
+		}
+	}
+
+	return false
+}
+
+func selectorMatches(selector *metav1.LabelSelector, podLabels map[string]string) bool {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+
+	if err != nil {
+		return false
+	}
+
+	return sel.Matches(labels.Set(podLabels))
+}
+
+func getServicePod(pods []v1.Pod, svc *v1.Service) *v1.Pod {
+	sel := labels.SelectorFromSet(svc.Spec.Selector)
+
+	for i, pod := range pods {
+		if pod.Namespace == svc.Namespace && pod.Status.Phase == v1.PodRunning && sel.Matches(labels.Set(pod.Labels)) {
+			return &pods[i]
+		}
+	}
+
+	return nil
+}
+
+// getWorkloads groups running pods by namespace and release
+func getWorkloads(pods []v1.Pod) []*workload {
+	seen := make(map[string]*workload)
+
+	for i, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning || pod.Spec.HostNetwork {
+			continue
+		}
+
+		name := pod.Labels["app.kubernetes.io/instance"]
+
+		if name == "" {
+			name = pod.Name
+		}
+
+		key := pod.Namespace + "/" + name
+
+		if _, ok := seen[key]; !ok {
+			seen[key] = &workload{
+				name: name,
+				pod:  &pods[i],
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+
+	for key := range seen {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]*workload, 0, len(keys))
+
+	for _, key := range keys {
+		res = append(res, seen[key])
+	}
+
+	return res
+}
@@ -0,0 +1,130 @@
+package networkpolicy_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/networkpolicy"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func namespace(name string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"kubernetes.io/metadata.name": name,
+			},
+		},
+	}
+}
+
+func pod(namespace, release string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      release + "-pod",
+			Namespace: namespace,
+			UID:       k8stypes.UID(namespace + "-" + release),
+			Labels: map[string]string{
+				"app.kubernetes.io/instance": release,
+			},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+		},
+	}
+}
+
+func getTestClientset() *fake.Clientset {
+	objects := []runtime.Object{
+		namespace("default"),
+		namespace("other"),
+		namespace("ingress-nginx"),
+		pod("default", "web"),
+		pod("default", "worker"),
+		pod("other", "api"),
+		pod("ingress-nginx", "nginx"),
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: "default",
+			},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{
+					"app.kubernetes.io/instance": "web",
+				},
+				Ports: []v1.ServicePort{
+					{
+						Port:       80,
+						TargetPort: intstr.FromInt(8080),
+					},
+				},
+			},
+		},
+	}
+
+	return fake.NewSimpleClientset(objects...)
+}
+
+func previewPreset(t *testing.T, preset types.NetworkPolicyPreset) map[string]bool {
+	t.Helper()
+
+	policy, err := networkpolicy.BuildNetworkPolicy("default", &types.ApplyNetworkPolicyRequest{
+		Preset: preset,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocked, err := networkpolicy.PreviewNetworkPolicy(getTestClientset(), policy)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make(map[string]bool)
+
+	for _, conn := range blocked {
+		res[conn.SourceNamespace+"/"+conn.SourceWorkload] = true
+	}
+
+	return res
+}
+
+func TestPreviewDenyAll(t *testing.T) {
+	blocked := previewPreset(t, types.NetworkPolicyPresetDenyAll)
+
+	for _, src := range []string{"default/worker", "other/api", "ingress-nginx/nginx"} {
+		if !blocked[src] {
+			t.Errorf("expected connection from %s to be blocked", src)
+		}
+	}
+}
+
+func TestPreviewNamespaceIsolated(t *testing.T) {
+	blocked := previewPreset(t, types.NetworkPolicyPresetNamespaceIsolated)
+
+	if blocked["default/worker"] {
+		t.Errorf("expected connection from default/worker to be allowed")
+	}
+
+	for _, src := range []string{"other/api", "ingress-nginx/nginx"} {
+		if !blocked[src] {
+			t.Errorf("expected connection from %s to be blocked", src)
+		}
+	}
+}
+
+func TestPreviewAllowFromIngress(t *testing.T) {
+	blocked := previewPreset(t, types.NetworkPolicyPresetAllowFromIngress)
+
+	if len(blocked) != 1 || !blocked["other/api"] {
+		t.Errorf("expected only other/api to be blocked, got %v", blocked)
+	}
+}
@@ -0,0 +1,253 @@
+package nodes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultDrainTimeout = 10 * time.Minute
+
+// evictionRetryInterval is the time to wait before retrying an eviction which was
+// blocked by a pod disruption budget
+const evictionRetryInterval = 5 * time.Second
+
+var ErrNodeNotFound = fmt.Errorf("node not found")
+
+// SetUnschedulable cordons or uncordons a node
+func SetUnschedulable(clientset kubernetes.Interface, nodeName string, unschedulable bool) (*v1.Node, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": unschedulable,
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := clientset.CoreV1().Nodes().Patch(
+		context.Background(),
+		nodeName,
+		k8stypes.StrategicMergePatchType,
+		patch,
+		metav1.PatchOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, ErrNodeNotFound
+	}
+
+	return node, err
+}
+
+// DrainNode cordons a node and evicts all pods running on it, except for DaemonSet pods and
+// mirror pods. Each step of the drain is reported via onEvent.
+func DrainNode(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	nodeName string,
+	opts *types.DrainNodeRequest,
+	onEvent func(event *types.NodeDrainEvent) error,
+) error {
+	timeout := defaultDrainTimeout
+
+	if opts.TimeoutSeconds != 0 {
+		timeout = time.Duration(opts.TimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := SetUnschedulable(clientset, nodeName, true); err != nil {
+		return err
+	}
+
+	if err := onEvent(&types.NodeDrainEvent{
+		Type: types.NodeDrainEventCordoned,
+		Node: nodeName,
+	}); err != nil {
+		return err
+	}
+
+	podList, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	toEvict := make([]v1.Pod, 0)
+
+	for _, pod := range podList.Items {
+		skip, reason := shouldSkipEviction(&pod, opts)
+
+		if skip {
+			continue
+		}
+
+		if reason != "" {
+			// the pod cannot be evicted safely, so the drain is aborted before any
+			// pods are evicted
+			onEvent(&types.NodeDrainEvent{
+				Type:      types.NodeDrainEventFailed,
+				Node:      nodeName,
+				Pod:       pod.Name,
+				Namespace: pod.Namespace,
+				Message:   reason,
+			})
+
+			return fmt.Errorf("cannot drain node %s: pod %s/%s %s", nodeName, pod.Namespace, pod.Name, reason)
+		}
+
+		toEvict = append(toEvict, pod)
+	}
+
+	for i, pod := range toEvict {
+		if err := onEvent(&types.NodeDrainEvent{
+			Type:      types.NodeDrainEventEvicting,
+			Node:      nodeName,
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Remaining: len(toEvict) - i,
+		}); err != nil {
+			return err
+		}
+
+		if err := evictPod(ctx, clientset, &pod, opts.GracePeriodSeconds, nodeName, onEvent); err != nil {
+			onEvent(&types.NodeDrainEvent{
+				Type:      types.NodeDrainEventFailed,
+				Node:      nodeName,
+				Pod:       pod.Name,
+				Namespace: pod.Namespace,
+				Message:   err.Error(),
+			})
+
+			return err
+		}
+
+		if err := waitForPodDeletion(ctx, clientset, &pod); err != nil {
+			return err
+		}
+
+		if err := onEvent(&types.NodeDrainEvent{
+			Type:      types.NodeDrainEventEvicted,
+			Node:      nodeName,
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Remaining: len(toEvict) - i - 1,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return onEvent(&types.NodeDrainEvent{
+		Type: types.NodeDrainEventCompleted,
+		Node: nodeName,
+	})
+}
+
+// shouldSkipEviction returns true if the pod should be left on the node. If the pod should
+// not be skipped but cannot be evicted with the given options, a reason is returned.
+func shouldSkipEviction(pod *v1.Pod, opts *types.DrainNodeRequest) (bool, string) {
+	// mirror pods are managed by the kubelet and cannot be evicted
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return true, ""
+	}
+
+	// pods which have already terminated don't need to be evicted
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return true, ""
+	}
+
+	controller := metav1.GetControllerOf(pod)
+
+	if controller != nil && controller.Kind == "DaemonSet" {
+		return true, ""
+	}
+
+	if controller == nil && !opts.Force {
+		return false, "is not managed by a controller and would not be rescheduled (set force to evict it)"
+	}
+
+	for _, vol := range pod.Spec.Volumes {
+		if vol.EmptyDir != nil && !opts.DeleteEmptyDirData {
+			return false, "uses an emptyDir volume whose data would be lost (set delete_emptydir_data to evict it)"
+		}
+	}
+
+	return false, ""
+}
+
+func evictPod(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	pod *v1.Pod,
+	gracePeriodSeconds *int64,
+	nodeName string,
+	onEvent func(event *types.NodeDrainEvent) error,
+) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriodSeconds,
+		},
+	}
+
+	for {
+		err := clientset.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+
+		if err == nil || errors.IsNotFound(err) {
+			return nil
+		} else if !errors.IsTooManyRequests(err) {
+			return err
+		}
+
+		// the eviction was blocked by a pod disruption budget, so we wait and retry
+		onEvent(&types.NodeDrainEvent{
+			Type:      types.NodeDrainEventBlocked,
+			Node:      nodeName,
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Message:   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out evicting pod %s/%s: %w", pod.Namespace, pod.Name, ctx.Err())
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+}
+
+func waitForPodDeletion(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod) error {
+	for {
+		currPod, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+
+		// if the pod was not found or was replaced by a pod with the same name, it has been deleted
+		if (err != nil && errors.IsNotFound(err)) || (err == nil && currPod.UID != pod.UID) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod %s/%s to be deleted: %w", pod.Namespace, pod.Name, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -29,6 +29,14 @@ func getPodsTotalRequestsAndLimits(podList *corev1.PodList) (reqs map[corev1.Res
 	return
 }
 
+// GetPodRequests returns the resources which a pod requests, which are the greater of the sum of
+// the requests of its containers and the requests of any of its init containers
+func GetPodRequests(pod *corev1.Pod) corev1.ResourceList {
+	reqs, _ := podRequestsAndLimits(pod)
+
+	return reqs
+}
+
 func podRequestsAndLimits(pod *corev1.Pod) (reqs, limits corev1.ResourceList) {
 	reqs, limits = corev1.ResourceList{}, corev1.ResourceList{}
 	for _, container := range pod.Spec.Containers {
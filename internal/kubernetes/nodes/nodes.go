@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
@@ -63,6 +64,9 @@ type NodeWithUsageData struct {
 	FractionEphemeralStorageReqs   float64            `json:"fraction_ephemeral_storage_reqs"`
 	FractionEphemeralStorageLimits float64            `json:"fraction_ephemeral_storage_limits"`
 	Condition                      []v1.NodeCondition `json:"node_conditions"`
+	Capacity                       v1.ResourceList    `json:"capacity"`
+	Allocatable                    v1.ResourceList    `json:"allocatable"`
+	Unschedulable                  bool               `json:"unschedulable"`
 }
 
 func (nu *NodeUsage) Externalize(node v1.Node) *NodeWithUsageData {
@@ -79,6 +83,9 @@ func (nu *NodeUsage) Externalize(node v1.Node) *NodeWithUsageData {
 		FractionEphemeralStorageReqs:   nu.fractionEphemeralStorageReqs,
 		FractionEphemeralStorageLimits: nu.fractionEphemeralStorageLimits,
 		Condition:                      node.Status.Conditions,
+		Capacity:                       node.Status.Capacity,
+		Allocatable:                    node.Status.Allocatable,
+		Unschedulable:                  node.Spec.Unschedulable,
 	}
 }
 
@@ -142,3 +149,33 @@ func ListNodesByLabels(clientset kubernetes.Interface, labelSelector string) ([]
 
 	return nodes.Items, nil
 }
+
+// GetNodeArchitectures returns the distinct CPU architectures of the nodes of a cluster,
+// such as "amd64" and "arm64"
+func GetNodeArchitectures(clientset kubernetes.Interface) ([]string, error) {
+	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	res := make([]string, 0)
+
+	for _, node := range nodeList.Items {
+		arch := node.Status.NodeInfo.Architecture
+
+		if arch == "" {
+			arch = node.Labels[v1.LabelArchStable]
+		}
+
+		if arch != "" && !seen[arch] {
+			seen[arch] = true
+			res = append(res, arch)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
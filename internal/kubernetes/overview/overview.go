@@ -0,0 +1,129 @@
+package overview
+
+import (
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/nodes"
+	"helm.sh/helm/v3/pkg/release"
+	v1 "k8s.io/api/core/v1"
+)
+
+// SummarizeNodes returns the capacity of the nodes of a cluster, and the resources which are
+// requested by the pods which are scheduled on the nodes and have not terminated
+func SummarizeNodes(nodeList []v1.Node, pods []v1.Pod) *types.ClusterOverviewNodes {
+	res := &types.ClusterOverviewNodes{
+		Count: len(nodeList),
+	}
+
+	nodeNames := make(map[string]bool)
+
+	for _, node := range nodeList {
+		nodeNames[node.Name] = true
+
+		if isNodeReady(node) {
+			res.Ready++
+		}
+
+		if node.Spec.Unschedulable {
+			res.Unschedulable++
+		}
+
+		res.CPUCapacity += node.Status.Capacity.Cpu().MilliValue()
+		res.CPUAllocatable += node.Status.Allocatable.Cpu().MilliValue()
+		res.MemoryCapacity += node.Status.Capacity.Memory().Value()
+		res.MemoryAllocatable += node.Status.Allocatable.Memory().Value()
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+
+		if !nodeNames[pod.Spec.NodeName] || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		reqs := nodes.GetPodRequests(pod)
+
+		res.CPURequests += reqs.Cpu().MilliValue()
+		res.MemoryRequests += reqs.Memory().Value()
+	}
+
+	return res
+}
+
+func SummarizeNamespaces(namespaces []v1.Namespace) *types.ClusterOverviewNamespaces {
+	res := &types.ClusterOverviewNamespaces{
+		Count: len(namespaces),
+	}
+
+	for _, ns := range namespaces {
+		switch ns.Status.Phase {
+		case v1.NamespaceTerminating:
+			res.Terminating++
+		default:
+			res.Active++
+		}
+	}
+
+	return res
+}
+
+// CountPodsByPhase returns the number of pods in each phase. Every phase is included, so that
+// phases without pods are returned as zero.
+func CountPodsByPhase(pods []v1.Pod) map[string]int {
+	res := map[string]int{
+		string(v1.PodPending):   0,
+		string(v1.PodRunning):   0,
+		string(v1.PodSucceeded): 0,
+		string(v1.PodFailed):    0,
+		string(v1.PodUnknown):   0,
+	}
+
+	for _, pod := range pods {
+		phase := pod.Status.Phase
+
+		if phase == "" {
+			phase = v1.PodUnknown
+		}
+
+		res[string(phase)]++
+	}
+
+	return res
+}
+
+// GetPendingUpgrades returns the releases whose latest revision is still being installed,
+// upgraded or rolled back, oldest first
+func GetPendingUpgrades(releases []*release.Release) []*types.ClusterOverviewPendingUpgrade {
+	res := make([]*types.ClusterOverviewPendingUpgrade, 0)
+
+	for _, rel := range releases {
+		if rel.Info == nil || !rel.Info.Status.IsPending() {
+			continue
+		}
+
+		res = append(res, &types.ClusterOverviewPendingUpgrade{
+			Name:      rel.Name,
+			Namespace: rel.Namespace,
+			Status:    rel.Info.Status.String(),
+			Revision:  rel.Version,
+			Since:     rel.Info.LastDeployed.Time,
+		})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Since.Before(res[j].Since)
+	})
+
+	return res
+}
+
+func isNodeReady(node v1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package overview
+
+import (
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name string, ready bool, cpu, memory string) v1.Node {
+	status := v1.ConditionFalse
+
+	if ready {
+		status = v1.ConditionTrue
+	}
+
+	resources := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}
+
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Capacity:    resources,
+			Allocatable: resources,
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func testPod(nodeName string, phase v1.PodPhase, cpu, memory string) v1.Pod {
+	return v1.Pod{
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestSummarizeNodes(t *testing.T) {
+	nodeList := []v1.Node{
+		testNode("node-1", true, "2", "4Gi"),
+		testNode("node-2", false, "2", "4Gi"),
+	}
+
+	nodeList[1].Spec.Unschedulable = true
+
+	// only the pods which are scheduled and have not terminated are counted
+	pods := []v1.Pod{
+		testPod("node-1", v1.PodRunning, "500m", "1Gi"),
+		testPod("node-2", v1.PodRunning, "250m", "512Mi"),
+		testPod("node-1", v1.PodSucceeded, "1", "1Gi"),
+		testPod("", v1.PodPending, "1", "1Gi"),
+	}
+
+	res := SummarizeNodes(nodeList, pods)
+
+	if res.Count != 2 || res.Ready != 1 || res.Unschedulable != 1 {
+		t.Errorf("expected 2 nodes with 1 ready and 1 unschedulable, got %d nodes with %d ready and %d unschedulable",
+			res.Count, res.Ready, res.Unschedulable)
+	}
+
+	if res.CPUCapacity != 4000 || res.CPURequests != 750 {
+		t.Errorf("expected 4000m cpu capacity with 750m requested, got %dm with %dm requested", res.CPUCapacity, res.CPURequests)
+	}
+
+	if res.MemoryAllocatable != 8<<30 || res.MemoryRequests != 3<<29 {
+		t.Errorf("expected 8Gi memory allocatable with 1.5Gi requested, got %d with %d requested",
+			res.MemoryAllocatable, res.MemoryRequests)
+	}
+}
+
+func TestCountPodsByPhase(t *testing.T) {
+	res := CountPodsByPhase([]v1.Pod{
+		testPod("node-1", v1.PodRunning, "1", "1Gi"),
+		testPod("node-1", v1.PodRunning, "1", "1Gi"),
+		testPod("", "", "1", "1Gi"),
+	})
+
+	if res["Running"] != 2 || res["Unknown"] != 1 || res["Pending"] != 0 || len(res) != 5 {
+		t.Errorf("expected 2 running and 1 unknown pod in 5 phases, got %v", res)
+	}
+}
+
+func TestGetPendingUpgrades(t *testing.T) {
+	now := time.Now()
+
+	releases := []*release.Release{
+		{
+			Name: "web", Namespace: "default", Version: 3,
+			Info: &release.Info{Status: release.StatusPendingUpgrade, LastDeployed: helmtime.Time{Time: now}},
+		},
+		{
+			Name: "worker", Namespace: "default", Version: 1,
+			Info: &release.Info{Status: release.StatusDeployed, LastDeployed: helmtime.Time{Time: now}},
+		},
+		{
+			Name: "api", Namespace: "staging", Version: 2,
+			Info: &release.Info{Status: release.StatusPendingRollback, LastDeployed: helmtime.Time{Time: now.Add(-time.Hour)}},
+		},
+	}
+
+	res := GetPendingUpgrades(releases)
+
+	if len(res) != 2 || res[0].Name != "api" || res[0].Status != "pending-rollback" || res[1].Name != "web" {
+		t.Fatalf("expected pending releases api and web, oldest first, got %v", res)
+	}
+}
@@ -0,0 +1,160 @@
+package podexec
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// Opts are the options of a command run in a container
+type Opts struct {
+	Container string
+	Command   []string
+	TTY       bool
+
+	Stdin  io.Reader
+	Stdout io.Writer
+
+	// Stderr is ignored when a TTY is allocated, since the TTY writes errors to stdout
+	Stderr io.Writer
+
+	SizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Exec runs a command in a container, equivalent to `kubectl exec`, until the command exits
+func Exec(agent *kubernetes.Agent, namespace, podName string, opts *Opts) error {
+	restConf, err := agent.RESTClientGetter.ToRESTConfig()
+
+	if err != nil {
+		return err
+	}
+
+	req := agent.Clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec")
+
+	req.VersionedParams(
+		&v1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    true,
+			Stderr:    !opts.TTY,
+			TTY:       opts.TTY,
+		},
+		scheme.ParameterCodec,
+	)
+
+	exec, err := remotecommand.NewSPDYExecutor(restConf, "POST", req.URL())
+
+	if err != nil {
+		return err
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.SizeQueue,
+	}
+
+	if !opts.TTY {
+		streamOpts.Stderr = opts.Stderr
+	}
+
+	return exec.Stream(streamOpts)
+}
+
+// GetStatus returns the status of a command from the error returned by Exec
+func GetStatus(err error) *types.ExecStatus {
+	if err == nil {
+		return &types.ExecStatus{}
+	}
+
+	var exitErr utilexec.ExitError
+
+	if errors.As(err, &exitErr) {
+		return &types.ExecStatus{
+			ExitCode: exitErr.ExitStatus(),
+		}
+	}
+
+	// the container runtime reports an executable which does not exist as a failure to start
+	// the command, rather than as an exit code
+	msg := err.Error()
+
+	return &types.ExecStatus{
+		ExitCode: 1,
+		Error:    msg,
+		CommandNotFound: strings.Contains(msg, "executable file not found") ||
+			strings.Contains(msg, "no such file or directory"),
+	}
+}
+
+// SizeQueue is a queue of terminal sizes of a TTY, which are sent to the container as the
+// client's terminal is resized
+type SizeQueue struct {
+	sizes  chan remotecommand.TerminalSize
+	closed chan struct{}
+	once   sync.Once
+}
+
+func NewSizeQueue() *SizeQueue {
+	return &SizeQueue{
+		sizes:  make(chan remotecommand.TerminalSize, 1),
+		closed: make(chan struct{}),
+	}
+}
+
+// Push adds a terminal size to the queue, replacing a size which hasn't been sent yet
+func (q *SizeQueue) Push(width, height uint16) {
+	size := remotecommand.TerminalSize{Width: width, Height: height}
+
+	for {
+		select {
+		case <-q.closed:
+			return
+		default:
+		}
+
+		select {
+		case q.sizes <- size:
+			return
+		default:
+		}
+
+		// drop the pending size, since only the latest size matters
+		select {
+		case <-q.sizes:
+		default:
+		}
+	}
+}
+
+// Next returns the next terminal size, or nil once the queue is closed
+func (q *SizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.closed:
+		return nil
+	}
+}
+
+// Close stops the queue, so that Next returns nil
+func (q *SizeQueue) Close() {
+	q.once.Do(func() {
+		close(q.closed)
+	})
+}
@@ -0,0 +1,46 @@
+package podexec_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes/podexec"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+func TestGetStatus(t *testing.T) {
+	if status := podexec.GetStatus(nil); status.ExitCode != 0 || status.Error != "" {
+		t.Errorf("expected a successful status, got %+v", status)
+	}
+
+	status := podexec.GetStatus(utilexec.CodeExitError{Err: errors.New("command terminated with exit code 3"), Code: 3})
+
+	if status.ExitCode != 3 || status.Error != "" || status.CommandNotFound {
+		t.Errorf("expected exit code 3, got %+v", status)
+	}
+
+	status = podexec.GetStatus(errors.New(`OCI runtime exec failed: exec failed: unable to start container process: exec: "sh": executable file not found in $PATH: unknown`))
+
+	if status.ExitCode == 0 || !status.CommandNotFound {
+		t.Errorf("expected the command not to be found, got %+v", status)
+	}
+}
+
+func TestSizeQueue(t *testing.T) {
+	q := podexec.NewSizeQueue()
+
+	// only the latest pending size is sent
+	q.Push(80, 24)
+	q.Push(120, 40)
+
+	if size := q.Next(); size == nil || size.Width != 120 || size.Height != 40 {
+		t.Errorf("expected the latest size, got %+v", size)
+	}
+
+	q.Close()
+	q.Push(100, 30)
+
+	if size := q.Next(); size != nil {
+		t.Errorf("expected no size after the queue is closed, got %+v", size)
+	}
+}
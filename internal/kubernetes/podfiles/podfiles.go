@@ -0,0 +1,185 @@
+package podfiles
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// MaxArchiveSize is the maximum size of a tar archive copied to or from a pod
+const MaxArchiveSize int64 = 50 << 20
+
+var ErrArchiveTooLarge = fmt.Errorf("archive exceeds the maximum size of %d MB", MaxArchiveSize>>20)
+
+var ErrInvalidPath = fmt.Errorf("path must be an absolute path")
+
+var ErrInvalidArchive = fmt.Errorf("archive is not a valid tar archive")
+
+// DownloadFiles copies a file or directory out of a container, equivalent to `kubectl cp`.
+// The contents are written to w as a tar archive, whose entries are relative to the parent
+// directory of the path. The tar binary must be present in the container.
+func DownloadFiles(
+	agent *kubernetes.Agent,
+	namespace, podName, container, filePath string,
+	w io.Writer,
+) (int64, error) {
+	if !path.IsAbs(filePath) {
+		return 0, ErrInvalidPath
+	}
+
+	filePath = path.Clean(filePath)
+
+	lw := &limitedWriter{w: w, remaining: MaxArchiveSize}
+
+	err := execInContainer(
+		agent, namespace, podName, container,
+		[]string{"tar", "cf", "-", "-C", path.Dir(filePath), path.Base(filePath)},
+		nil, lw,
+	)
+
+	if lw.exceeded {
+		return MaxArchiveSize - lw.remaining, ErrArchiveTooLarge
+	}
+
+	return MaxArchiveSize - lw.remaining, err
+}
+
+// UploadFiles extracts a tar archive into a directory of a container, creating the directory
+// if it does not exist. The tar binary and a shell must be present in the container.
+func UploadFiles(
+	agent *kubernetes.Agent,
+	namespace, podName, container, dirPath string,
+	archive []byte,
+) error {
+	if !path.IsAbs(dirPath) {
+		return ErrInvalidPath
+	}
+
+	if int64(len(archive)) > MaxArchiveSize {
+		return ErrArchiveTooLarge
+	}
+
+	// the path is passed as a positional argument rather than interpolated into the script,
+	// so that it cannot be used to inject shell commands
+	return execInContainer(
+		agent, namespace, podName, container,
+		[]string{"sh", "-c", `mkdir -p "$0" && tar xmf - -C "$0"`, path.Clean(dirPath)},
+		bytes.NewReader(archive), io.Discard,
+	)
+}
+
+// ValidateArchive checks that an archive is a valid tar archive whose entries cannot be
+// extracted outside of the target directory, and returns the number of files and total
+// size of the files in the archive
+func ValidateArchive(archive []byte) (int, int64, error) {
+	tr := tar.NewReader(bytes.NewReader(archive))
+
+	files := 0
+	var size int64
+
+	for {
+		header, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, 0, ErrInvalidArchive
+		}
+
+		name := path.Clean(header.Name)
+
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return 0, 0, fmt.Errorf("%w: entry %s is outside of the target directory", ErrInvalidArchive, header.Name)
+		}
+
+		if header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink {
+			return 0, 0, fmt.Errorf("%w: entry %s is a link, which is not supported", ErrInvalidArchive, header.Name)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			files++
+			size += header.Size
+		}
+	}
+
+	return files, size, nil
+}
+
+func execInContainer(
+	agent *kubernetes.Agent,
+	namespace, podName, container string,
+	command []string,
+	stdin io.Reader,
+	stdout io.Writer,
+) error {
+	restConf, err := agent.RESTClientGetter.ToRESTConfig()
+
+	if err != nil {
+		return err
+	}
+
+	req := agent.Clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec")
+
+	req.VersionedParams(
+		&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		},
+		scheme.ParameterCodec,
+	)
+
+	exec, err := remotecommand.NewSPDYExecutor(restConf, "POST", req.URL())
+
+	if err != nil {
+		return err
+	}
+
+	stderr := &bytes.Buffer{}
+
+	err = exec.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return err
+}
+
+// limitedWriter writes to w until the limit is reached, after which all writes fail
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		l.exceeded = true
+		return 0, ErrArchiveTooLarge
+	}
+
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+
+	return n, err
+}
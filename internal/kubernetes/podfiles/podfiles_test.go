@@ -0,0 +1,73 @@
+package podfiles_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes/podfiles"
+)
+
+func buildArchive(t *testing.T, headers ...*tar.Header) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	for _, header := range headers {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Size > 0 {
+			if _, err := tw.Write(bytes.Repeat([]byte("a"), int(header.Size))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestValidateArchive(t *testing.T) {
+	archive := buildArchive(t,
+		&tar.Header{Name: "fixtures/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "fixtures/a.json", Typeflag: tar.TypeReg, Mode: 0644, Size: 10},
+		&tar.Header{Name: "fixtures/b.json", Typeflag: tar.TypeReg, Mode: 0644, Size: 5},
+	)
+
+	files, size, err := podfiles.ValidateArchive(archive)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if files != 2 || size != 15 {
+		t.Errorf("expected 2 files of 15 bytes, got %d files of %d bytes", files, size)
+	}
+}
+
+func TestValidateArchiveRejectsUnsafeEntries(t *testing.T) {
+	tests := map[string]*tar.Header{
+		"parent directory": {Name: "../etc/passwd", Typeflag: tar.TypeReg, Mode: 0644, Size: 1},
+		"absolute path":    {Name: "/etc/passwd", Typeflag: tar.TypeReg, Mode: 0644, Size: 1},
+		"symlink":          {Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+	}
+
+	for name, header := range tests {
+		_, _, err := podfiles.ValidateArchive(buildArchive(t, header))
+
+		if !errors.Is(err, podfiles.ErrInvalidArchive) {
+			t.Errorf("%s: expected ErrInvalidArchive, got %v", name, err)
+		}
+	}
+}
+
+func TestValidateArchiveRejectsInvalidData(t *testing.T) {
+	if _, _, err := podfiles.ValidateArchive([]byte("not a tar archive")); !errors.Is(err, podfiles.ErrInvalidArchive) {
+		t.Errorf("expected ErrInvalidArchive, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package podportforward
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// Forward forwards a single connection to a port of a pod, equivalent to a connection accepted by
+// `kubectl port-forward`. Data read from in is written to the port, and data read from the port is
+// written to out, until the pod closes the connection.
+func Forward(agent *kubernetes.Agent, namespace, podName string, port uint16, in io.Reader, out io.Writer) error {
+	restConf, err := agent.RESTClientGetter.ToRESTConfig()
+
+	if err != nil {
+		return err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConf)
+
+	if err != nil {
+		return err
+	}
+
+	req := agent.Clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+
+	if err != nil {
+		return fmt.Errorf("error upgrading connection: %w", err)
+	}
+
+	defer streamConn.Close()
+
+	// a port-forward is made of an error stream and a data stream, which are paired by their
+	// request ID
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(int(port)))
+	headers.Set(v1.PortForwardRequestIDHeader, "0")
+
+	errorStream, err := streamConn.CreateStream(headers)
+
+	if err != nil {
+		return fmt.Errorf("error creating error stream: %w", err)
+	}
+
+	// nothing is written to the error stream
+	errorStream.Close()
+
+	errorChan := make(chan error, 1)
+
+	go func() {
+		message, err := ioutil.ReadAll(errorStream)
+
+		switch {
+		case err != nil:
+			errorChan <- fmt.Errorf("error reading from error stream for port %d: %w", port, err)
+		case len(message) > 0:
+			errorChan <- fmt.Errorf("an error occurred forwarding port %d: %s", port, string(message))
+		}
+
+		close(errorChan)
+	}()
+
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+
+	dataStream, err := streamConn.CreateStream(headers)
+
+	if err != nil {
+		return fmt.Errorf("error creating data stream: %w", err)
+	}
+
+	localError := make(chan error, 1)
+	remoteDone := make(chan struct{})
+
+	go func() {
+		// the pod closes the data stream once the connection to the port is closed
+		io.Copy(out, dataStream)
+		close(remoteDone)
+	}()
+
+	go func() {
+		// closing the data stream once the input ends signals the pod to close the connection
+		defer dataStream.Close()
+
+		if _, err := io.Copy(dataStream, in); err != nil {
+			localError <- err
+		}
+	}()
+
+	select {
+	case <-remoteDone:
+	case err := <-localError:
+		return fmt.Errorf("error forwarding port %d: %w", port, err)
+	}
+
+	// the error stream is always closed, with or without an error message
+	return <-errorChan
+}
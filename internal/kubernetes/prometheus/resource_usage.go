@@ -0,0 +1,151 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Controller is a controller whose pods are queried, such as a deployment or a cronjob
+type Controller struct {
+	Kind string
+	Name string
+}
+
+// ResourceUsage is the usage of the pods of a release over a window, where the usage of
+// each pod is the sum of the usage of its containers
+type ResourceUsage struct {
+	CPUCoresP95    float64 `json:"cpu_cores_p95"`
+	CPUCoresMax    float64 `json:"cpu_cores_max"`
+	MemoryBytesP95 float64 `json:"memory_bytes_p95"`
+	MemoryBytesMax float64 `json:"memory_bytes_max"`
+}
+
+// ErrNoUsageData is returned when prometheus has no usage data for the pods of the controllers
+var ErrNoUsageData = fmt.Errorf("no usage data found")
+
+// QueryResourceUsage returns the 95th percentile and peak CPU and memory usage of the pods of
+// the controllers over the last windowDays days. The usage of a pod is the sum of the usage of
+// its containers, and the usage of the controllers is the usage of their busiest pod.
+func QueryResourceUsage(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	namespace string,
+	controllers []Controller,
+	windowDays uint,
+) (*ResourceUsage, error) {
+	if len(service.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("prometheus service has no exposed ports to query")
+	}
+
+	selectionRegexes := make([]string, 0)
+
+	for _, controller := range controllers {
+		// controllers which can't be queried for metrics, such as replicasets owned by a
+		// deployment, are skipped
+		if selectionRegex, err := getSelectionRegex(controller.Kind, controller.Name); err == nil {
+			selectionRegexes = append(selectionRegexes, selectionRegex)
+		}
+	}
+
+	if len(selectionRegexes) == 0 {
+		return nil, ErrNoUsageData
+	}
+
+	podSelector := fmt.Sprintf(
+		`namespace="%s",pod=~"%s",container!="POD",container!=""`,
+		namespace, strings.Join(selectionRegexes, "|"),
+	)
+
+	cpu := fmt.Sprintf("sum by (pod) (rate(container_cpu_usage_seconds_total{%s}[5m]))", podSelector)
+	memory := fmt.Sprintf("sum by (pod) (container_memory_working_set_bytes{%s})", podSelector)
+
+	res := &ResourceUsage{}
+
+	queries := []struct {
+		query string
+		dest  *float64
+	}{
+		{fmt.Sprintf("max(quantile_over_time(0.95, %s[%dd:5m]))", cpu, windowDays), &res.CPUCoresP95},
+		{fmt.Sprintf("max(max_over_time(%s[%dd:5m]))", cpu, windowDays), &res.CPUCoresMax},
+		{fmt.Sprintf("max(quantile_over_time(0.95, %s[%dd:5m]))", memory, windowDays), &res.MemoryBytesP95},
+		{fmt.Sprintf("max(max_over_time(%s[%dd:5m]))", memory, windowDays), &res.MemoryBytesMax},
+	}
+
+	for _, q := range queries {
+		val, found, err := queryInstant(clientset, service, q.query)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, ErrNoUsageData
+		}
+
+		*q.dest = val
+	}
+
+	return res, nil
+}
+
+type promRawInstantQuery struct {
+	Data struct {
+		Result []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// queryInstant runs a query which returns a single value, and returns false if the query
+// returned no value
+func queryInstant(clientset kubernetes.Interface, service *v1.Service, query string) (float64, bool, error) {
+	resp := clientset.CoreV1().Services(service.Namespace).ProxyGet(
+		"http",
+		service.Name,
+		fmt.Sprintf("%d", service.Spec.Ports[0].Port),
+		"/api/v1/query",
+		map[string]string{
+			"query": query,
+		},
+	)
+
+	rawQuery, err := resp.DoRaw(context.TODO())
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return parseInstantQuery(rawQuery)
+}
+
+func parseInstantQuery(rawQuery []byte) (float64, bool, error) {
+	rawQueryObj := &promRawInstantQuery{}
+
+	if err := json.Unmarshal(rawQuery, rawQueryObj); err != nil {
+		return 0, false, err
+	}
+
+	if len(rawQueryObj.Data.Result) == 0 || len(rawQueryObj.Data.Result[0].Value) != 2 {
+		return 0, false, nil
+	}
+
+	strVal, ok := rawQueryObj.Data.Result[0].Value[1].(string)
+
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected value in prometheus response")
+	}
+
+	val, err := strconv.ParseFloat(strVal, 64)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return val, true, nil
+}
@@ -0,0 +1,245 @@
+package quotas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ManagedResourceQuotaName is the name of the resource quota managed through Porter
+	ManagedResourceQuotaName = "porter-quota"
+
+	// ManagedLimitRangeName is the name of the limit range managed through Porter
+	ManagedLimitRangeName = "porter-limits"
+)
+
+var ErrInvalidQuantity = fmt.Errorf("invalid resource quantity")
+
+// GetNamespaceQuotas lists the resource quotas and limit ranges in a namespace
+func GetNamespaceQuotas(clientset k8s.Interface, namespace string) (*types.GetNamespaceQuotasResponse, error) {
+	quotaList, err := clientset.CoreV1().ResourceQuotas(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	limitRangeList, err := clientset.CoreV1().LimitRanges(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.GetNamespaceQuotasResponse{
+		ResourceQuotas: make([]*types.ResourceQuota, 0),
+		LimitRanges:    make([]*types.LimitRange, 0),
+	}
+
+	for _, quota := range quotaList.Items {
+		res.ResourceQuotas = append(res.ResourceQuotas, toResourceQuotaType(&quota))
+	}
+
+	for _, limitRange := range limitRangeList.Items {
+		res.LimitRanges = append(res.LimitRanges, toLimitRangeType(&limitRange))
+	}
+
+	return res, nil
+}
+
+// SetResourceQuota creates or replaces the Porter-managed resource quota in a namespace
+func SetResourceQuota(
+	clientset k8s.Interface,
+	namespace string,
+	req *types.UpdateResourceQuotaRequest,
+) (*types.ResourceQuota, error) {
+	hard, err := toResourceList(req.Hard)
+
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := clientset.CoreV1().ResourceQuotas(namespace)
+
+	quota, err := quotas.Get(context.Background(), ManagedResourceQuotaName, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		quota, err = quotas.Create(context.Background(), &v1.ResourceQuota{
+			ObjectMeta: managedObjectMeta(ManagedResourceQuotaName, namespace),
+			Spec: v1.ResourceQuotaSpec{
+				Hard: hard,
+			},
+		}, metav1.CreateOptions{})
+	} else if err == nil {
+		quota.Spec.Hard = hard
+		quota, err = quotas.Update(context.Background(), quota, metav1.UpdateOptions{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toResourceQuotaType(quota), nil
+}
+
+// SetLimitRange creates or replaces the Porter-managed limit range in a namespace
+func SetLimitRange(
+	clientset k8s.Interface,
+	namespace string,
+	req *types.UpdateLimitRangeRequest,
+) (*types.LimitRange, error) {
+	limits := make([]v1.LimitRangeItem, 0)
+
+	for _, item := range req.Limits {
+		limit := v1.LimitRangeItem{
+			Type: v1.LimitType(item.Type),
+		}
+
+		var err error
+
+		if limit.Max, err = toResourceList(item.Max); err != nil {
+			return nil, err
+		}
+
+		if limit.Min, err = toResourceList(item.Min); err != nil {
+			return nil, err
+		}
+
+		if limit.Default, err = toResourceList(item.Default); err != nil {
+			return nil, err
+		}
+
+		if limit.DefaultRequest, err = toResourceList(item.DefaultRequest); err != nil {
+			return nil, err
+		}
+
+		limits = append(limits, limit)
+	}
+
+	limitRanges := clientset.CoreV1().LimitRanges(namespace)
+
+	limitRange, err := limitRanges.Get(context.Background(), ManagedLimitRangeName, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		limitRange, err = limitRanges.Create(context.Background(), &v1.LimitRange{
+			ObjectMeta: managedObjectMeta(ManagedLimitRangeName, namespace),
+			Spec: v1.LimitRangeSpec{
+				Limits: limits,
+			},
+		}, metav1.CreateOptions{})
+	} else if err == nil {
+		limitRange.Spec.Limits = limits
+		limitRange, err = limitRanges.Update(context.Background(), limitRange, metav1.UpdateOptions{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toLimitRangeType(limitRange), nil
+}
+
+// DeleteResourceQuota removes the Porter-managed resource quota from a namespace
+func DeleteResourceQuota(clientset k8s.Interface, namespace string) error {
+	err := clientset.CoreV1().ResourceQuotas(namespace).Delete(
+		context.Background(),
+		ManagedResourceQuotaName,
+		metav1.DeleteOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return kubernetes.IsNotFoundError
+	}
+
+	return err
+}
+
+// DeleteLimitRange removes the Porter-managed limit range from a namespace
+func DeleteLimitRange(clientset k8s.Interface, namespace string) error {
+	err := clientset.CoreV1().LimitRanges(namespace).Delete(
+		context.Background(),
+		ManagedLimitRangeName,
+		metav1.DeleteOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return kubernetes.IsNotFoundError
+	}
+
+	return err
+}
+
+func managedObjectMeta(name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			"porter": "true",
+		},
+	}
+}
+
+func toResourceList(vals map[string]string) (v1.ResourceList, error) {
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	res := make(v1.ResourceList)
+
+	for name, val := range vals {
+		quantity, err := resource.ParseQuantity(val)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w for %s: %s", ErrInvalidQuantity, name, val)
+		}
+
+		res[v1.ResourceName(name)] = quantity
+	}
+
+	return res, nil
+}
+
+func fromResourceList(list v1.ResourceList) map[string]string {
+	res := make(map[string]string)
+
+	for name, quantity := range list {
+		res[string(name)] = quantity.String()
+	}
+
+	return res
+}
+
+func toResourceQuotaType(quota *v1.ResourceQuota) *types.ResourceQuota {
+	return &types.ResourceQuota{
+		Name:    quota.Name,
+		Managed: quota.Name == ManagedResourceQuotaName,
+		Hard:    fromResourceList(quota.Spec.Hard),
+		Used:    fromResourceList(quota.Status.Used),
+	}
+}
+
+func toLimitRangeType(limitRange *v1.LimitRange) *types.LimitRange {
+	res := &types.LimitRange{
+		Name:    limitRange.Name,
+		Managed: limitRange.Name == ManagedLimitRangeName,
+		Limits:  make([]types.LimitRangeItem, 0),
+	}
+
+	for _, limit := range limitRange.Spec.Limits {
+		res.Limits = append(res.Limits, types.LimitRangeItem{
+			Type:           string(limit.Type),
+			Max:            fromResourceList(limit.Max),
+			Min:            fromResourceList(limit.Min),
+			Default:        fromResourceList(limit.Default),
+			DefaultRequest: fromResourceList(limit.DefaultRequest),
+		})
+	}
+
+	return res
+}
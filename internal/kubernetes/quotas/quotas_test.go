@@ -0,0 +1,60 @@
+package quotas_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/quotas"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetResourceQuota(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	_, err := quotas.SetResourceQuota(clientset, "default", &types.UpdateResourceQuotaRequest{
+		Hard: map[string]string{"requests.cpu": "2", "pods": "10"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// setting the quota again should replace the existing limits
+	quota, err := quotas.SetResourceQuota(clientset, "default", &types.UpdateResourceQuotaRequest{
+		Hard: map[string]string{"requests.cpu": "4"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !quota.Managed || len(quota.Hard) != 1 || quota.Hard["requests.cpu"] != "4" {
+		t.Errorf("unexpected quota: %+v", quota)
+	}
+
+	res, err := quotas.GetNamespaceQuotas(clientset, "default")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.ResourceQuotas) != 1 {
+		t.Errorf("expected 1 resource quota, got %d", len(res.ResourceQuotas))
+	}
+}
+
+func TestSetLimitRangeInvalidQuantity(t *testing.T) {
+	_, err := quotas.SetLimitRange(fake.NewSimpleClientset(), "default", &types.UpdateLimitRangeRequest{
+		Limits: []types.LimitRangeItem{
+			{
+				Type:    "Container",
+				Default: map[string]string{"memory": "lots"},
+			},
+		},
+	})
+
+	if !errors.Is(err, quotas.ErrInvalidQuantity) {
+		t.Errorf("expected ErrInvalidQuantity, got %v", err)
+	}
+}
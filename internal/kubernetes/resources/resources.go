@@ -0,0 +1,188 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// FieldManager is the field manager used for server-side applies made through Porter
+const FieldManager = "porter"
+
+var ErrResourceNotInRelease = fmt.Errorf("resource is not owned by this release")
+
+var ErrInvalidResource = fmt.Errorf("invalid resource")
+
+// Client reads and applies resources which are owned by a release
+type Client struct {
+	mapper    meta.RESTMapper
+	dynClient dynamic.Interface
+}
+
+func NewClient(agent *kubernetes.Agent, dynClient dynamic.Interface) (*Client, error) {
+	mapper, err := agent.RESTClientGetter.ToRESTMapper()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{mapper, dynClient}, nil
+}
+
+// FindReleaseResource finds a resource in a release manifest by kind and name, and returns its
+// group version kind and namespace
+func FindReleaseResource(manifest, releaseNamespace, kind, name string) (schema.GroupVersionKind, string, error) {
+	for _, obj := range grapher.ImportMultiDocYAML([]byte(manifest)) {
+		u := &unstructured.Unstructured{Object: obj}
+
+		if !strings.EqualFold(u.GetKind(), kind) || u.GetName() != name {
+			continue
+		}
+
+		ns := u.GetNamespace()
+
+		if ns == "" {
+			ns = releaseNamespace
+		}
+
+		return u.GroupVersionKind(), ns, nil
+	}
+
+	return schema.GroupVersionKind{}, "", ErrResourceNotInRelease
+}
+
+// GetYAML returns the live YAML of a resource, without managed fields
+func (c *Client) GetYAML(gvk schema.GroupVersionKind, namespace, name string) (*types.ResourceYAML, error) {
+	resource, err := c.resourceFor(gvk, namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := resource.Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, kubernetes.IsNotFoundError
+	} else if err != nil {
+		return nil, err
+	}
+
+	return toResourceYAML(obj)
+}
+
+// Apply performs a server-side apply of the given YAML. The apply is always run with dry-run
+// first, so that invalid resources are rejected before any changes are persisted.
+func (c *Client) Apply(gvk schema.GroupVersionKind, namespace, name string, rawYAML []byte, dryRun bool) (*types.ResourceYAML, error) {
+	obj := &unstructured.Unstructured{}
+
+	if err := yaml.Unmarshal(rawYAML, &obj.Object); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidResource, err.Error())
+	}
+
+	// the edited resource must be the same resource that is owned by the release, otherwise
+	// this endpoint could be used to write arbitrary resources
+	if obj.GroupVersionKind().GroupKind() != gvk.GroupKind() || obj.GetName() != name {
+		return nil, fmt.Errorf("%w: the kind and name of the resource cannot be changed", ErrInvalidResource)
+	}
+
+	if obj.GetNamespace() != "" && obj.GetNamespace() != namespace {
+		return nil, fmt.Errorf("%w: the namespace of the resource cannot be changed", ErrInvalidResource)
+	}
+
+	// managed fields and the resource version cannot be set in an apply request
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	data, err := obj.MarshalJSON()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := c.resourceFor(obj.GroupVersionKind(), namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	force := true
+
+	opts := metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+		DryRun:       []string{metav1.DryRunAll},
+	}
+
+	res, err := resource.Patch(context.Background(), name, k8stypes.ApplyPatchType, data, opts)
+
+	if err != nil {
+		return nil, wrapApplyError(err)
+	}
+
+	if !dryRun {
+		opts.DryRun = nil
+
+		res, err = resource.Patch(context.Background(), name, k8stypes.ApplyPatchType, data, opts)
+
+		if err != nil {
+			return nil, wrapApplyError(err)
+		}
+	}
+
+	return toResourceYAML(res)
+}
+
+func (c *Client) resourceFor(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.dynClient.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+
+	return c.dynClient.Resource(mapping.Resource), nil
+}
+
+// wrapApplyError marks errors caused by an invalid resource, so that they can be returned
+// to the client
+func wrapApplyError(err error) error {
+	if errors.IsInvalid(err) || errors.IsBadRequest(err) || errors.IsConflict(err) {
+		return fmt.Errorf("%w: %s", ErrInvalidResource, err.Error())
+	}
+
+	return err
+}
+
+func toResourceYAML(obj *unstructured.Unstructured) (*types.ResourceYAML, error) {
+	obj.SetManagedFields(nil)
+
+	data, err := yaml.Marshal(obj.Object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ResourceYAML{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		YAML:       string(data),
+	}, nil
+}
@@ -0,0 +1,313 @@
+package restarts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// checksumAnnotationPrefix prefixes the pod template annotations which store the hash of
+// each configmap or secret that a workload was last restarted with. Changing the annotation
+// triggers a rolling restart of the workload.
+const checksumAnnotationPrefix = "checksum.porter.run/"
+
+// PreviewRestart lists the workloads in a namespace which reference a configmap or secret,
+// and whether each one needs to be restarted to pick up the current data
+func PreviewRestart(
+	clientset k8s.Interface,
+	namespace string,
+	req *types.WorkloadRestartRequest,
+) (*types.WorkloadRestartPreview, error) {
+	hash, err := getDataHash(clientset, namespace, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := findDependentWorkloads(clientset, namespace, req, hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.WorkloadRestartPreview{
+		Kind:      req.Kind,
+		Name:      req.Name,
+		Hash:      hash,
+		Workloads: make([]*types.DependentWorkload, 0),
+	}
+
+	for _, workload := range workloads {
+		res.Workloads = append(res.Workloads, workload.DependentWorkload)
+	}
+
+	return res, nil
+}
+
+// RestartWorkloads triggers a rolling restart of every workload which references a configmap
+// or secret and was not yet restarted with its current data
+func RestartWorkloads(
+	clientset k8s.Interface,
+	namespace string,
+	req *types.WorkloadRestartRequest,
+) (*types.RestartWorkloadsResponse, error) {
+	hash, err := getDataHash(clientset, namespace, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := findDependentWorkloads(clientset, namespace, req, hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.RestartWorkloadsResponse{
+		Hash:      hash,
+		Restarted: make([]*types.DependentWorkload, 0),
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						checksumAnnotation(req): hash,
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, workload := range workloads {
+		if !workload.NeedsRestart {
+			continue
+		}
+
+		if err := workload.patch(clientset, namespace, patch); err != nil {
+			return nil, fmt.Errorf("could not restart %s %s: %w", workload.Kind, workload.Name, err)
+		}
+
+		workload.CurrentHash = hash
+		workload.NeedsRestart = false
+
+		res.Restarted = append(res.Restarted, workload.DependentWorkload)
+	}
+
+	return res, nil
+}
+
+type dependentWorkload struct {
+	*types.DependentWorkload
+
+	patch func(clientset k8s.Interface, namespace string, patch []byte) error
+}
+
+func findDependentWorkloads(
+	clientset k8s.Interface,
+	namespace string,
+	req *types.WorkloadRestartRequest,
+	hash string,
+) ([]*dependentWorkload, error) {
+	ctx := context.Background()
+	res := make([]*dependentWorkload, 0)
+	annotation := checksumAnnotation(req)
+
+	add := func(kind string, meta metav1.ObjectMeta, template *v1.PodTemplateSpec, patch func(k8s.Interface, string, []byte) error) {
+		if !referencesSource(&template.Spec, req) {
+			return
+		}
+
+		currHash := template.Annotations[annotation]
+
+		res = append(res, &dependentWorkload{
+			DependentWorkload: &types.DependentWorkload{
+				Kind:         kind,
+				Name:         meta.Name,
+				ReleaseName:  meta.Annotations["meta.helm.sh/release-name"],
+				CurrentHash:  currHash,
+				NeedsRestart: currHash != hash,
+			},
+			patch: patch,
+		})
+	}
+
+	depls, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, depl := range depls.Items {
+		name := depl.Name
+
+		add("Deployment", depl.ObjectMeta, &depl.Spec.Template, func(clientset k8s.Interface, ns string, patch []byte) error {
+			_, err := clientset.AppsV1().Deployments(ns).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+			return err
+		})
+	}
+
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ss := range statefulSets.Items {
+		name := ss.Name
+
+		add("StatefulSet", ss.ObjectMeta, &ss.Spec.Template, func(clientset k8s.Interface, ns string, patch []byte) error {
+			_, err := clientset.AppsV1().StatefulSets(ns).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+			return err
+		})
+	}
+
+	daemonSets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ds := range daemonSets.Items {
+		name := ds.Name
+
+		add("DaemonSet", ds.ObjectMeta, &ds.Spec.Template, func(clientset k8s.Interface, ns string, patch []byte) error {
+			_, err := clientset.AppsV1().DaemonSets(ns).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+			return err
+		})
+	}
+
+	return res, nil
+}
+
+// referencesSource returns true if a pod spec references the configmap or secret through
+// env, envFrom or a volume
+func referencesSource(spec *v1.PodSpec, req *types.WorkloadRestartRequest) bool {
+	isConfigMap := req.Kind == types.WorkloadRestartSourceConfigMap
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if isConfigMap && envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == req.Name {
+				return true
+			} else if !isConfigMap && envFrom.SecretRef != nil && envFrom.SecretRef.Name == req.Name {
+				return true
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+
+			if isConfigMap && env.ValueFrom.ConfigMapKeyRef != nil && env.ValueFrom.ConfigMapKeyRef.Name == req.Name {
+				return true
+			} else if !isConfigMap && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == req.Name {
+				return true
+			}
+		}
+	}
+
+	for _, vol := range spec.Volumes {
+		if isConfigMap && vol.ConfigMap != nil && vol.ConfigMap.Name == req.Name {
+			return true
+		} else if !isConfigMap && vol.Secret != nil && vol.Secret.SecretName == req.Name {
+			return true
+		}
+
+		if vol.Projected == nil {
+			continue
+		}
+
+		for _, source := range vol.Projected.Sources {
+			if isConfigMap && source.ConfigMap != nil && source.ConfigMap.Name == req.Name {
+				return true
+			} else if !isConfigMap && source.Secret != nil && source.Secret.Name == req.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getDataHash returns a hash of the data stored in a configmap or secret
+func getDataHash(clientset k8s.Interface, namespace string, req *types.WorkloadRestartRequest) (string, error) {
+	data := make(map[string][]byte)
+
+	if req.Kind == types.WorkloadRestartSourceConfigMap {
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(context.Background(), req.Name, metav1.GetOptions{})
+
+		if err != nil && errors.IsNotFound(err) {
+			return "", kubernetes.IsNotFoundError
+		} else if err != nil {
+			return "", err
+		}
+
+		for key, val := range cm.Data {
+			data[key] = []byte(val)
+		}
+
+		for key, val := range cm.BinaryData {
+			data[key] = val
+		}
+	} else {
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(context.Background(), req.Name, metav1.GetOptions{})
+
+		if err != nil && errors.IsNotFound(err) {
+			return "", kubernetes.IsNotFoundError
+		} else if err != nil {
+			return "", err
+		}
+
+		data = secret.Data
+	}
+
+	keys := make([]string, 0)
+
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	hasher := sha256.New()
+
+	for _, key := range keys {
+		hasher.Write([]byte(key))
+		hasher.Write([]byte{0})
+		hasher.Write(data[key])
+		hasher.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func checksumAnnotation(req *types.WorkloadRestartRequest) string {
+	name := fmt.Sprintf("%s-%s", req.Kind, req.Name)
+
+	// the name segment of an annotation key is limited to 63 characters
+	if len(name) > 63 {
+		sum := sha256.Sum256([]byte(name))
+		name = fmt.Sprintf("%s-%s", req.Kind, hex.EncodeToString(sum[:])[:16])
+	}
+
+	return checksumAnnotationPrefix + name
+}
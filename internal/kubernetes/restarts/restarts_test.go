@@ -0,0 +1,83 @@
+package restarts_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/restarts"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDeployment(name string, envFrom []v1.EnvFromSource) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:    "app",
+							Image:   "nginx",
+							EnvFrom: envFrom,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRestartWorkloads(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-env", Namespace: "default"},
+			Data:       map[string][]byte{"KEY": []byte("value")},
+		},
+		newDeployment("web", []v1.EnvFromSource{
+			{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-env"}}},
+		}),
+		newDeployment("other", nil),
+	)
+
+	req := &types.WorkloadRestartRequest{
+		Kind: types.WorkloadRestartSourceSecret,
+		Name: "app-env",
+	}
+
+	preview, err := restarts.PreviewRestart(clientset, "default", req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(preview.Workloads) != 1 || preview.Workloads[0].Name != "web" || !preview.Workloads[0].NeedsRestart {
+		t.Fatalf("unexpected preview: %+v", preview.Workloads)
+	}
+
+	res, err := restarts.RestartWorkloads(clientset, "default", req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Restarted) != 1 {
+		t.Fatalf("expected 1 restarted workload, got %d", len(res.Restarted))
+	}
+
+	// once restarted with the current data, the workload should not be restarted again
+	preview, err = restarts.PreviewRestart(clientset, "default", req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.Workloads[0].NeedsRestart {
+		t.Errorf("expected workload to be up to date after restart")
+	}
+}
@@ -0,0 +1,206 @@
+package rightsizing
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultWindowDays is the number of days of usage which is analyzed by default
+const DefaultWindowDays = 7
+
+const (
+	// requests leave headroom above the 95th percentile of usage, and limits leave headroom
+	// above the peak usage, so that pods are neither throttled nor OOM killed by normal spikes
+	cpuRequestHeadroom    = 1.2
+	cpuLimitHeadroom      = 1.5
+	memoryRequestHeadroom = 1.2
+	memoryLimitHeadroom   = 1.3
+
+	minCPUMillis = 10
+	minMemoryMiB = 32
+
+	// values which differ from the current values by less than this fraction are not
+	// considered a change, so that recommendations don't churn with small changes in usage
+	changeThreshold = 0.1
+)
+
+var ErrPrometheusNotInstalled = fmt.Errorf("prometheus is not installed in the cluster")
+
+// GetRecommendation analyzes the usage of the pods of a release over the last windowDays days,
+// and recommends the resources values of the release
+func GetRecommendation(
+	clientset kubernetes.Interface,
+	helmRelease *release.Release,
+	windowDays uint,
+) (*types.ResourceRecommendation, error) {
+	if windowDays == 0 {
+		windowDays = DefaultWindowDays
+	}
+
+	promSvc, found, err := prometheus.GetPrometheusService(clientset)
+
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, ErrPrometheusNotInstalled
+	}
+
+	controllers := make([]prometheus.Controller, 0)
+
+	for _, obj := range grapher.ParseControllers(grapher.ImportMultiDocYAML([]byte(helmRelease.Manifest))) {
+		controllers = append(controllers, prometheus.Controller{Kind: obj.Kind, Name: obj.Name})
+	}
+
+	usage, err := prometheus.QueryResourceUsage(clientset, promSvc, helmRelease.Namespace, controllers, windowDays)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Recommend(usage, GetCurrentValues(helmRelease.Config), windowDays), nil
+}
+
+// Recommend returns the resources values which fit the usage of a release
+func Recommend(usage *prometheus.ResourceUsage, current *types.ResourceValues, windowDays uint) *types.ResourceRecommendation {
+	recommended := &types.ResourceValues{
+		CPURequest:    toCPUQuantity(usage.CPUCoresP95 * cpuRequestHeadroom),
+		CPULimit:      toCPUQuantity(usage.CPUCoresMax * cpuLimitHeadroom),
+		MemoryRequest: toMemoryQuantity(usage.MemoryBytesP95 * memoryRequestHeadroom),
+		MemoryLimit:   toMemoryQuantity(usage.MemoryBytesMax * memoryLimitHeadroom),
+	}
+
+	// a limit must be at least the request
+	if isLess(recommended.CPULimit, recommended.CPURequest) {
+		recommended.CPULimit = recommended.CPURequest
+	}
+
+	if isLess(recommended.MemoryLimit, recommended.MemoryRequest) {
+		recommended.MemoryLimit = recommended.MemoryRequest
+	}
+
+	return &types.ResourceRecommendation{
+		WindowDays:  windowDays,
+		Usage:       usage,
+		Current:     current,
+		Recommended: recommended,
+		Changed: isChanged(current.CPURequest, recommended.CPURequest) ||
+			isChanged(current.CPULimit, recommended.CPULimit) ||
+			isChanged(current.MemoryRequest, recommended.MemoryRequest) ||
+			isChanged(current.MemoryLimit, recommended.MemoryLimit),
+	}
+}
+
+// GetCurrentValues reads the resources values of a release from the resources field of its
+// values, which is used by Porter's charts
+func GetCurrentValues(values map[string]interface{}) *types.ResourceValues {
+	res := &types.ResourceValues{}
+
+	resources, _ := values["resources"].(map[string]interface{})
+	requests, _ := resources["requests"].(map[string]interface{})
+	limits, _ := resources["limits"].(map[string]interface{})
+
+	res.CPURequest = toString(requests["cpu"])
+	res.MemoryRequest = toString(requests["memory"])
+	res.CPULimit = toString(limits["cpu"])
+	res.MemoryLimit = toString(limits["memory"])
+
+	return res
+}
+
+// ApplyToValues returns a copy of the values of a release with the recommended resources
+// values, leaving any other resources values unchanged
+func ApplyToValues(values map[string]interface{}, recommended *types.ResourceValues) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	for key, val := range values {
+		res[key] = val
+	}
+
+	resources := copyMap(res["resources"])
+	requests := copyMap(resources["requests"])
+	limits := copyMap(resources["limits"])
+
+	requests["cpu"] = recommended.CPURequest
+	requests["memory"] = recommended.MemoryRequest
+	limits["cpu"] = recommended.CPULimit
+	limits["memory"] = recommended.MemoryLimit
+
+	resources["requests"] = requests
+	resources["limits"] = limits
+	res["resources"] = resources
+
+	return res
+}
+
+// toCPUQuantity rounds cores up to the nearest 5 millicores
+func toCPUQuantity(cores float64) string {
+	millis := int64(math.Ceil(cores*1000/5-1e-9) * 5)
+
+	if millis < minCPUMillis {
+		millis = minCPUMillis
+	}
+
+	return fmt.Sprintf("%dm", millis)
+}
+
+// toMemoryQuantity rounds bytes up to the nearest MiB
+func toMemoryQuantity(bytes float64) string {
+	mib := int64(math.Ceil(bytes/(1<<20) - 1e-9))
+
+	if mib < minMemoryMiB {
+		mib = minMemoryMiB
+	}
+
+	return fmt.Sprintf("%dMi", mib)
+}
+
+func isLess(a, b string) bool {
+	aQuantity := resource.MustParse(a)
+
+	return aQuantity.Cmp(resource.MustParse(b)) < 0
+}
+
+func isChanged(current, recommended string) bool {
+	currQuantity, err := resource.ParseQuantity(current)
+
+	if err != nil || currQuantity.IsZero() {
+		return true
+	}
+
+	recQuantity := resource.MustParse(recommended)
+
+	curr := currQuantity.AsApproximateFloat64()
+	rec := recQuantity.AsApproximateFloat64()
+
+	return math.Abs(rec-curr)/curr > changeThreshold
+}
+
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func copyMap(val interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	if m, ok := val.(map[string]interface{}); ok {
+		for key, v := range m {
+			res[key] = v
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,93 @@
+package rightsizing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+)
+
+func TestRecommend(t *testing.T) {
+	usage := &prometheus.ResourceUsage{
+		CPUCoresP95:    0.1,
+		CPUCoresMax:    0.3,
+		MemoryBytesP95: 200 << 20,
+		MemoryBytesMax: 10 << 20,
+	}
+
+	rec := Recommend(usage, &types.ResourceValues{CPURequest: "120m", MemoryRequest: "256Mi"}, 7)
+
+	expected := &types.ResourceValues{
+		CPURequest:    "120m",
+		CPULimit:      "450m",
+		MemoryRequest: "240Mi",
+		// the limit is raised to the request
+		MemoryLimit: "240Mi",
+	}
+
+	if !reflect.DeepEqual(rec.Recommended, expected) {
+		t.Errorf("expected %v, got %v", expected, rec.Recommended)
+	}
+
+	// the limits aren't set, so the recommendation is a change
+	if !rec.Changed {
+		t.Errorf("expected the recommendation to be a change")
+	}
+
+	rec = Recommend(usage, &types.ResourceValues{
+		CPURequest: "125m", CPULimit: "0.45", MemoryRequest: "250Mi", MemoryLimit: "256Mi",
+	}, 7)
+
+	if rec.Changed {
+		t.Errorf("expected values within the threshold not to be a change")
+	}
+}
+
+func TestRecommendMinimum(t *testing.T) {
+	rec := Recommend(&prometheus.ResourceUsage{}, &types.ResourceValues{}, 7)
+
+	if rec.Recommended.CPURequest != "10m" || rec.Recommended.MemoryRequest != "32Mi" {
+		t.Errorf("expected the minimum values, got %v", rec.Recommended)
+	}
+}
+
+func TestApplyToValues(t *testing.T) {
+	values := map[string]interface{}{
+		"replicaCount": 2,
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{
+				"cpu":               "100m",
+				"ephemeral-storage": "1Gi",
+			},
+		},
+	}
+
+	res := ApplyToValues(values, &types.ResourceValues{
+		CPURequest: "50m", CPULimit: "100m", MemoryRequest: "64Mi", MemoryLimit: "128Mi",
+	})
+
+	expected := map[string]interface{}{
+		"replicaCount": 2,
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{
+				"cpu":               "50m",
+				"memory":            "64Mi",
+				"ephemeral-storage": "1Gi",
+			},
+			"limits": map[string]interface{}{
+				"cpu":    "100m",
+				"memory": "128Mi",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("expected %v, got %v", expected, res)
+	}
+
+	// the values of the release are left unchanged
+	if cpu := GetCurrentValues(values).CPURequest; cpu != "100m" {
+		t.Errorf("expected the values to be unchanged, got cpu %s", cpu)
+	}
+}
@@ -0,0 +1,378 @@
+package rollouts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const groupVersion = "argoproj.io/v1alpha1"
+
+var (
+	rolloutGVR = schema.GroupVersionResource{
+		Group:    "argoproj.io",
+		Version:  "v1alpha1",
+		Resource: "rollouts",
+	}
+
+	analysisRunGVR = schema.GroupVersionResource{
+		Group:    "argoproj.io",
+		Version:  "v1alpha1",
+		Resource: "analysisruns",
+	}
+)
+
+var (
+	ErrNotInstalled = fmt.Errorf("argo rollouts is not installed in this cluster")
+	ErrNotFound     = fmt.Errorf("rollout not found")
+)
+
+// Detect returns whether the Argo Rollouts CRDs are registered in a cluster, and the version of
+// the controller if its deployment can be found
+func Detect(clientset kubernetes.Interface) (*types.ArgoRolloutsStatus, error) {
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return &types.ArgoRolloutsStatus{}, nil
+		}
+
+		return nil, err
+	}
+
+	res := &types.ArgoRolloutsStatus{}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == rolloutGVR.Resource {
+			res.Installed = true
+		}
+	}
+
+	if !res.Installed {
+		return res, nil
+	}
+
+	// the version is informational, so the controller is detected even if it can't be read
+	depls, err := clientset.AppsV1().Deployments("").List(context.Background(), metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=argo-rollouts",
+	})
+
+	if err == nil && len(depls.Items) > 0 {
+		for _, container := range depls.Items[0].Spec.Template.Spec.Containers {
+			if i := strings.LastIndex(container.Image, ":"); i != -1 && strings.Contains(container.Image, "argo-rollouts") {
+				res.Version = container.Image[i+1:]
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// ListRollouts lists the rollouts which are rendered by a release
+func ListRollouts(client dynamic.Interface, namespace, releaseName string) ([]*types.Rollout, error) {
+	list, err := client.Resource(rolloutGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	res := make([]*types.Rollout, 0)
+
+	for i := range list.Items {
+		if isReleaseRollout(&list.Items[i], releaseName) {
+			res = append(res, toRollout(&list.Items[i]))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// GetRollout returns a rollout which is rendered by a release, or ErrNotFound if the release
+// does not render the rollout
+func GetRollout(client dynamic.Interface, namespace, releaseName, name string) (*types.Rollout, error) {
+	obj, err := getReleaseRollout(client, namespace, releaseName, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toRollout(obj), nil
+}
+
+// Pause pauses a rollout at its current step
+func Pause(client dynamic.Interface, namespace, releaseName, name string) (*types.Rollout, error) {
+	if _, err := getReleaseRollout(client, namespace, releaseName, name); err != nil {
+		return nil, err
+	}
+
+	return patch(client, namespace, name, `{"spec":{"paused":true}}`, false)
+}
+
+// Promote resumes a paused rollout, or skips its current step. If full is set, the remaining
+// steps and analyses are skipped and the new version is fully promoted.
+func Promote(client dynamic.Interface, namespace, releaseName, name string, full bool) (*types.Rollout, error) {
+	obj, err := getReleaseRollout(client, namespace, releaseName, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rollout *types.Rollout
+
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		if rollout, err = patch(client, namespace, name, `{"spec":{"paused":false}}`, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if full {
+		return patch(client, namespace, name, `{"status":{"promoteFull":true}}`, true)
+	}
+
+	pauseConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "pauseConditions")
+
+	// a rollout which is paused by a step or an analysis resumes once its pause conditions
+	// are cleared
+	if len(pauseConditions) > 0 {
+		return patch(client, namespace, name, `{"status":{"pauseConditions":null,"controllerPause":false}}`, true)
+	}
+
+	// a rollout which was only paused by a user resumes at its current step
+	if rollout != nil {
+		return rollout, nil
+	}
+
+	// a rollout which is not paused skips its current step
+	if current := toRollout(obj); current.CurrentStepIndex != nil && *current.CurrentStepIndex < current.TotalSteps {
+		return patch(client, namespace, name, fmt.Sprintf(
+			`{"status":{"currentStepIndex":%d}}`,
+			*current.CurrentStepIndex+1,
+		), true)
+	}
+
+	return toRollout(obj), nil
+}
+
+// Abort aborts a rollout, which scales down the new version and routes all traffic to the
+// stable version
+func Abort(client dynamic.Interface, namespace, releaseName, name string) (*types.Rollout, error) {
+	if _, err := getReleaseRollout(client, namespace, releaseName, name); err != nil {
+		return nil, err
+	}
+
+	return patch(client, namespace, name, `{"status":{"abort":true}}`, true)
+}
+
+// ListAnalysisRuns lists the analysis runs of a rollout, most recent first
+func ListAnalysisRuns(client dynamic.Interface, namespace, releaseName, name string) ([]*types.AnalysisRun, error) {
+	rollout, err := getReleaseRollout(client, namespace, releaseName, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Resource(analysisRunGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	res := make([]*types.AnalysisRun, 0)
+
+	for i := range list.Items {
+		for _, owner := range list.Items[i].GetOwnerReferences() {
+			if owner.Kind == "Rollout" && owner.UID == rollout.GetUID() {
+				res = append(res, toAnalysisRun(&list.Items[i]))
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt != nil && (res[j].CreatedAt == nil || res[i].CreatedAt.After(*res[j].CreatedAt))
+	})
+
+	return res, nil
+}
+
+func getReleaseRollout(client dynamic.Interface, namespace, releaseName, name string) (*unstructured.Unstructured, error) {
+	obj, err := client.Resource(rolloutGVR).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			// the API server returns a not found error for the resource if the CRDs are not
+			// registered, which is distinguished from a missing rollout by the error details
+			var statusErr *k8sErrors.StatusError
+
+			if errors.As(err, &statusErr) && statusErr.ErrStatus.Details != nil && statusErr.ErrStatus.Details.Name == name {
+				return nil, ErrNotFound
+			}
+		}
+
+		return nil, wrapErr(err)
+	}
+
+	if !isReleaseRollout(obj, releaseName) {
+		return nil, ErrNotFound
+	}
+
+	return obj, nil
+}
+
+func isReleaseRollout(obj *unstructured.Unstructured, releaseName string) bool {
+	return obj.GetAnnotations()["meta.helm.sh/release-name"] == releaseName
+}
+
+func patch(client dynamic.Interface, namespace, name, data string, status bool) (*types.Rollout, error) {
+	subresources := []string{}
+
+	if status {
+		subresources = append(subresources, "status")
+	}
+
+	obj, err := client.Resource(rolloutGVR).Namespace(namespace).Patch(
+		context.Background(),
+		name,
+		k8stypes.MergePatchType,
+		[]byte(data),
+		metav1.PatchOptions{},
+		subresources...,
+	)
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return toRollout(obj), nil
+}
+
+func toRollout(obj *unstructured.Unstructured) *types.Rollout {
+	res := &types.Rollout{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+
+	if _, ok, _ := unstructured.NestedMap(obj.Object, "spec", "strategy", "blueGreen"); ok {
+		res.Strategy = "blueGreen"
+	} else {
+		res.Strategy = "canary"
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	res.Message, _, _ = unstructured.NestedString(obj.Object, "status", "message")
+	res.Aborted, _, _ = unstructured.NestedBool(obj.Object, "status", "abort")
+
+	paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+	pauseConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "pauseConditions")
+	res.Paused = paused || len(pauseConditions) > 0
+
+	steps, _, _ := unstructured.NestedSlice(obj.Object, "spec", "strategy", "canary", "steps")
+	res.TotalSteps = len(steps)
+
+	if idx, ok, _ := unstructured.NestedInt64(obj.Object, "status", "currentStepIndex"); ok {
+		currentStepIndex := int(idx)
+		res.CurrentStepIndex = &currentStepIndex
+	}
+
+	res.Replicas = getInt(obj, "status", "replicas")
+	res.UpdatedReplicas = getInt(obj, "status", "updatedReplicas")
+	res.ReadyReplicas = getInt(obj, "status", "readyReplicas")
+	res.AvailableReplicas = getInt(obj, "status", "availableReplicas")
+
+	res.StableRevision, _, _ = unstructured.NestedString(obj.Object, "status", "stableRS")
+	res.CurrentRevision, _, _ = unstructured.NestedString(obj.Object, "status", "currentPodHash")
+
+	return res
+}
+
+func toAnalysisRun(obj *unstructured.Unstructured) *types.AnalysisRun {
+	createdAt := obj.GetCreationTimestamp().Time
+
+	res := &types.AnalysisRun{
+		Name:      obj.GetName(),
+		CreatedAt: &createdAt,
+		Metrics:   make([]*types.AnalysisRunMetric, 0),
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	res.Message, _, _ = unstructured.NestedString(obj.Object, "status", "message")
+
+	metrics, _, _ := unstructured.NestedSlice(obj.Object, "status", "metricResults")
+
+	for _, metricVal := range metrics {
+		metric, ok := metricVal.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		m := &unstructured.Unstructured{Object: metric}
+
+		res.Metrics = append(res.Metrics, &types.AnalysisRunMetric{
+			Name:         getString(m, "name"),
+			Phase:        getString(m, "phase"),
+			Message:      getString(m, "message"),
+			Count:        getInt(m, "count"),
+			Successful:   getInt(m, "successful"),
+			Failed:       getInt(m, "failed"),
+			Inconclusive: getInt(m, "inconclusive"),
+			Error:        getInt(m, "error"),
+			LatestValue:  getLatestValue(metric),
+		})
+	}
+
+	return res
+}
+
+func getLatestValue(metric map[string]interface{}) string {
+	measurements, _, _ := unstructured.NestedSlice(metric, "measurements")
+
+	if len(measurements) == 0 {
+		return ""
+	}
+
+	latest, ok := measurements[len(measurements)-1].(map[string]interface{})
+
+	if !ok {
+		return ""
+	}
+
+	value, _, _ := unstructured.NestedString(latest, "value")
+
+	return value
+}
+
+func getString(obj *unstructured.Unstructured, fields ...string) string {
+	res, _, _ := unstructured.NestedString(obj.Object, fields...)
+	return res
+}
+
+func getInt(obj *unstructured.Unstructured, fields ...string) int {
+	res, _, _ := unstructured.NestedInt64(obj.Object, fields...)
+	return int(res)
+}
+
+// wrapErr returns ErrNotInstalled if the Argo Rollouts CRDs are not registered, in which case
+// the API server returns a not found error
+func wrapErr(err error) error {
+	if k8sErrors.IsNotFound(err) {
+		return ErrNotInstalled
+	}
+
+	return err
+}
@@ -0,0 +1,131 @@
+package rollouts
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func testRollout(name, releaseName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": groupVersion,
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"namespace":   "default",
+			"uid":         name + "-uid",
+			"annotations": map[string]interface{}{"meta.helm.sh/release-name": releaseName},
+		},
+		"spec": map[string]interface{}{
+			"strategy": map[string]interface{}{
+				"canary": map[string]interface{}{
+					"steps": []interface{}{
+						map[string]interface{}{"setWeight": int64(20)},
+						map[string]interface{}{"pause": map[string]interface{}{}},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"phase":            "Paused",
+			"currentStepIndex": int64(1),
+			"replicas":         int64(2),
+		},
+	}}
+}
+
+func testAnalysisRun(name, ownerUID string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": groupVersion,
+		"kind":       "AnalysisRun",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+			"ownerReferences": []interface{}{
+				map[string]interface{}{
+					"apiVersion": groupVersion,
+					"kind":       "Rollout",
+					"name":       "owner",
+					"uid":        ownerUID,
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"phase": "Successful",
+			"metricResults": []interface{}{
+				map[string]interface{}{
+					"name":       "success-rate",
+					"phase":      "Successful",
+					"count":      int64(3),
+					"successful": int64(3),
+					"measurements": []interface{}{
+						map[string]interface{}{"value": "0.98"},
+						map[string]interface{}{"value": "0.99"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func testClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			rolloutGVR:     "RolloutList",
+			analysisRunGVR: "AnalysisRunList",
+		},
+		testRollout("web", "web"),
+		testRollout("worker", "worker"),
+		testAnalysisRun("web-1", "web-uid"),
+		testAnalysisRun("worker-1", "worker-uid"),
+	)
+}
+
+func TestListRollouts(t *testing.T) {
+	res, err := ListRollouts(testClient(), "default", "web")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 1 || res[0].Name != "web" {
+		t.Fatalf("expected only the rollout of the release, got %v", res)
+	}
+
+	if res[0].Strategy != "canary" || res[0].TotalSteps != 2 || res[0].CurrentStepIndex == nil || *res[0].CurrentStepIndex != 1 {
+		t.Errorf("expected a canary rollout at step 1 of 2, got %+v", res[0])
+	}
+}
+
+func TestGetRolloutOfOtherRelease(t *testing.T) {
+	client := testClient()
+
+	if _, err := GetRollout(client, "default", "web", "worker"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a rollout of another release not to be found, got %v", err)
+	}
+
+	if _, err := GetRollout(client, "default", "web", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a missing rollout not to be found, got %v", err)
+	}
+}
+
+func TestListAnalysisRuns(t *testing.T) {
+	res, err := ListAnalysisRuns(testClient(), "default", "web", "web")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 1 || res[0].Name != "web-1" {
+		t.Fatalf("expected only the analysis run of the rollout, got %v", res)
+	}
+
+	if len(res[0].Metrics) != 1 || res[0].Metrics[0].Successful != 3 || res[0].Metrics[0].LatestValue != "0.99" {
+		t.Errorf("expected the success rate metric with its latest value, got %+v", res[0].Metrics)
+	}
+}
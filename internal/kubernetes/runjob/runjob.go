@@ -0,0 +1,158 @@
+package runjob
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// LabelRelease is the label of the jobs run from the pod template of a release, which is set to
+// the name of the release
+const LabelRelease = "porter.run/run-job-release"
+
+// finishedJobTTLSeconds is the time for which a job is kept after it finishes, so that its
+// status and logs can be read
+const finishedJobTTLSeconds int32 = 3600
+
+// maxReleaseNameLength is the length which the release name is truncated to in the name of a
+// job, since the job's name is also set as a label of its pods and labels are limited to 63
+// characters
+const maxReleaseNameLength = 52
+
+// Run creates a job which runs a command in a container of a release's pod template. Only the
+// selected container is run, without its probes or ports. The job's pods don't have the labels
+// or annotations of the pod template, so that they don't receive traffic from the release's
+// services and don't have sidecars injected which would keep the job from completing.
+func Run(
+	clientset k8s.Interface,
+	namespace, releaseName string,
+	template *v1.PodTemplateSpec,
+	req *types.RunJobRequest,
+) (*types.RunJobResponse, error) {
+	job, err := NewJob(namespace, releaseName, template, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	job, err = clientset.BatchV1().Jobs(namespace).Create(context.Background(), job, metav1.CreateOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RunJobResponse{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Container: job.Spec.Template.Spec.Containers[0].Name,
+	}, nil
+}
+
+// NewJob returns the job which Run creates
+func NewJob(namespace, releaseName string, template *v1.PodTemplateSpec, req *types.RunJobRequest) (*batchv1.Job, error) {
+	if len(template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("the pod template of %s has no containers", releaseName)
+	}
+
+	var container *v1.Container
+
+	if req.Container == "" {
+		container = template.Spec.Containers[0].DeepCopy()
+	} else {
+		for _, c := range template.Spec.Containers {
+			if c.Name == req.Container {
+				container = c.DeepCopy()
+				break
+			}
+		}
+
+		if container == nil {
+			return nil, fmt.Errorf("container %s does not exist in the pod template of %s", req.Container, releaseName)
+		}
+	}
+
+	container.Command = req.Command
+	container.Args = nil
+	container.Ports = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	container.Lifecycle = nil
+	container.Stdin = false
+	container.StdinOnce = false
+	container.TTY = false
+	container.Env = mergeEnv(container.Env, req.Env)
+
+	spec := template.Spec.DeepCopy()
+
+	// init containers are kept, since the command may depend on them, but the pod template's
+	// sidecars would keep the job from completing
+	spec.Containers = []v1.Container{*container}
+	spec.RestartPolicy = v1.RestartPolicyNever
+	spec.NodeName = ""
+	spec.Hostname = ""
+
+	name := releaseName
+
+	if len(name) > maxReleaseNameLength {
+		name = name[:maxReleaseNameLength]
+	}
+
+	labels := map[string]string{
+		LabelRelease: releaseName,
+	}
+
+	backoffLimit := int32(0)
+	ttl := finishedJobTTLSeconds
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-run-%s", name, rand.String(5)),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: *spec,
+			},
+		},
+	}, nil
+}
+
+// mergeEnv sets environment variables on top of a container's variables, replacing variables
+// with the same name
+func mergeEnv(env []v1.EnvVar, vars map[string]string) []v1.EnvVar {
+	res := make([]v1.EnvVar, 0, len(env)+len(vars))
+
+	for _, envVar := range env {
+		if _, ok := vars[envVar.Name]; !ok {
+			res = append(res, envVar)
+		}
+	}
+
+	keys := make([]string, 0, len(vars))
+
+	for key := range vars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		res = append(res, v1.EnvVar{Name: key, Value: vars[key]})
+	}
+
+	return res
+}
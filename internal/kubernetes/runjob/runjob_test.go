@@ -0,0 +1,125 @@
+package runjob_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/runjob"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTemplate() *v1.PodTemplateSpec {
+	return &v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"app.kubernetes.io/instance": "web"},
+			Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:    "web",
+					Image:   "web:v1",
+					Command: []string{"npm", "start"},
+					Ports:   []v1.ContainerPort{{ContainerPort: 8080}},
+					Env: []v1.EnvVar{
+						{Name: "NODE_ENV", Value: "production"},
+						{Name: "LOG_LEVEL", Value: "info"},
+					},
+					ReadinessProbe: &v1.Probe{},
+				},
+				{
+					Name:  "proxy",
+					Image: "proxy:v1",
+				},
+			},
+			RestartPolicy: v1.RestartPolicyAlways,
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	res, err := runjob.Run(clientset, "default", "web", newTemplate(), &types.RunJobRequest{
+		Command: []string{"npm", "run", "migrate"},
+		Env:     map[string]string{"LOG_LEVEL": "debug", "DRY_RUN": "false"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !strings.HasPrefix(res.Name, "web-run-") || res.Container != "web" {
+		t.Errorf("unexpected response %+v", res)
+	}
+
+	job, err := clientset.BatchV1().Jobs("default").Get(context.Background(), res.Name, metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if job.Labels[runjob.LabelRelease] != "web" {
+		t.Errorf("expected the job to be labeled with the release, got %v", job.Labels)
+	}
+
+	podTemplate := job.Spec.Template
+
+	if _, ok := podTemplate.Labels["app.kubernetes.io/instance"]; ok || len(podTemplate.Annotations) != 0 {
+		t.Errorf("expected the labels and annotations of the pod template not to be copied")
+	}
+
+	if podTemplate.Spec.RestartPolicy != v1.RestartPolicyNever || *job.Spec.BackoffLimit != 0 {
+		t.Errorf("expected the job not to be retried")
+	}
+
+	if len(podTemplate.Spec.Containers) != 1 {
+		t.Fatalf("expected only the web container to be run, got %d containers", len(podTemplate.Spec.Containers))
+	}
+
+	container := podTemplate.Spec.Containers[0]
+
+	if !reflect.DeepEqual(container.Command, []string{"npm", "run", "migrate"}) ||
+		container.ReadinessProbe != nil || len(container.Ports) != 0 {
+		t.Errorf("unexpected container %+v", container)
+	}
+
+	expectedEnv := []v1.EnvVar{
+		{Name: "NODE_ENV", Value: "production"},
+		{Name: "DRY_RUN", Value: "false"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}
+
+	if !reflect.DeepEqual(container.Env, expectedEnv) {
+		t.Errorf("expected env %v, got %v", expectedEnv, container.Env)
+	}
+}
+
+func TestRunContainer(t *testing.T) {
+	job, err := runjob.NewJob("default", "web", newTemplate(), &types.RunJobRequest{
+		Command:   []string{"sh"},
+		Container: "proxy",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if containers := job.Spec.Template.Spec.Containers; len(containers) != 1 || containers[0].Name != "proxy" {
+		t.Errorf("expected only the proxy container to be run")
+	}
+
+	_, err = runjob.NewJob("default", "web", newTemplate(), &types.RunJobRequest{
+		Command:   []string{"sh"},
+		Container: "worker",
+	})
+
+	if err == nil {
+		t.Errorf("expected an error for a container which does not exist")
+	}
+}
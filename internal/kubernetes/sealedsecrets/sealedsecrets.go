@@ -0,0 +1,204 @@
+package sealedsecrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/porter-dev/porter/api/types"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultControllerNamespace and DefaultControllerName are where the Helm chart of the
+	// sealed-secrets controller installs the controller by default
+	DefaultControllerNamespace = "kube-system"
+	DefaultControllerName      = "sealed-secrets-controller"
+
+	annotationNamespaceWide = "sealedsecrets.bitnami.com/namespace-wide"
+	annotationClusterWide   = "sealedsecrets.bitnami.com/cluster-wide"
+
+	// sessionKeyBytes is the length of the AES key which encrypts each value
+	sessionKeyBytes = 32
+)
+
+// GetPublicKey fetches the public key which the sealed-secrets controller decrypts sealed
+// secrets with, through the API server's proxy to the controller's service
+func GetPublicKey(clientset k8s.Interface, controllerNamespace, controllerName string) (*rsa.PublicKey, error) {
+	certPEM, err := clientset.CoreV1().Services(controllerNamespace).ProxyGet(
+		"http",
+		controllerName,
+		"",
+		"/v1/cert.pem",
+		nil,
+	).DoRaw(context.Background())
+
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the certificate of sealed-secrets controller %s/%s: %w",
+			controllerNamespace, controllerName, err)
+	}
+
+	return ParsePublicKey(certPEM)
+}
+
+// ParsePublicKey reads the RSA public key of a PEM-encoded certificate of the sealed-secrets
+// controller
+func ParsePublicKey(certPEM []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(certPEM)
+
+	if block == nil {
+		return nil, fmt.Errorf("the certificate of the sealed-secrets controller is not PEM-encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+
+	if !ok {
+		return nil, fmt.Errorf("the certificate of the sealed-secrets controller does not have an RSA public key")
+	}
+
+	return pubKey, nil
+}
+
+// SealedSecret is a SealedSecret resource of the sealed-secrets controller, which contains the
+// values of a secret encrypted with the controller's public key
+type SealedSecret struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   SealedSecretMeta `json:"metadata"`
+	Spec       SealedSecretSpec `json:"spec"`
+}
+
+type SealedSecretMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type SealedSecretSpec struct {
+	EncryptedData map[string]string        `json:"encryptedData"`
+	Template      SealedSecretSpecTemplate `json:"template"`
+}
+
+// SealedSecretSpecTemplate is the template of the secret which the controller creates from a
+// sealed secret
+type SealedSecretSpecTemplate struct {
+	Metadata SealedSecretMeta `json:"metadata"`
+	Type     string           `json:"type,omitempty"`
+}
+
+// Seal encrypts the values of a secret with the public key of the sealed-secrets controller. The
+// scope determines whether the sealed secret can be renamed or moved to another namespace after
+// it's sealed.
+func Seal(
+	pubKey *rsa.PublicKey,
+	name, namespace string,
+	scope types.SealedSecretScope,
+	data map[string]string,
+) (*SealedSecret, error) {
+	annotations := make(map[string]string)
+
+	switch scope {
+	case types.SealedSecretScopeNamespaceWide:
+		annotations[annotationNamespaceWide] = "true"
+	case types.SealedSecretScopeClusterWide:
+		annotations[annotationClusterWide] = "true"
+	}
+
+	label := sealingLabel(name, namespace, scope)
+	encryptedData := make(map[string]string)
+
+	for key, val := range data {
+		ciphertext, err := hybridEncrypt(rand.Reader, pubKey, []byte(val), label)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not encrypt %s: %w", key, err)
+		}
+
+		encryptedData[key] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	meta := SealedSecretMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Annotations: annotations,
+	}
+
+	return &SealedSecret{
+		APIVersion: "bitnami.com/v1alpha1",
+		Kind:       "SealedSecret",
+		Metadata:   meta,
+		Spec: SealedSecretSpec{
+			EncryptedData: encryptedData,
+			Template: SealedSecretSpecTemplate{
+				Metadata: meta,
+				Type:     "Opaque",
+			},
+		},
+	}, nil
+}
+
+// sealingLabel returns the label which binds the encrypted values to the name and namespace of
+// the secret, as the controller expects for the scope
+func sealingLabel(name, namespace string, scope types.SealedSecretScope) []byte {
+	switch scope {
+	case types.SealedSecretScopeClusterWide:
+		return []byte{}
+	case types.SealedSecretScopeNamespaceWide:
+		return []byte(namespace)
+	default:
+		return []byte(fmt.Sprintf("%s/%s", namespace, name))
+	}
+}
+
+// hybridEncrypt encrypts a value in the format which the sealed-secrets controller decrypts: a
+// random AES-256 session key encrypted with RSA-OAEP, prefixed with its length as 2 big-endian
+// bytes, followed by the value encrypted with AES-GCM. The nonce is always zero, since each
+// session key is only used once.
+func hybridEncrypt(rnd io.Reader, pubKey *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	sessionKey := make([]byte, sessionKeyBytes)
+
+	if _, err := io.ReadFull(rnd, sessionKey); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(sessionKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rsaCiphertext, err := rsa.EncryptOAEP(sha256.New(), rnd, pubKey, sessionKey, label)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := make([]byte, 2)
+	binary.BigEndian.PutUint16(ciphertext, uint16(len(rsaCiphertext)))
+	ciphertext = append(ciphertext, rsaCiphertext...)
+
+	zeroNonce := make([]byte, aead.NonceSize())
+
+	return aead.Seal(ciphertext, zeroNonce, plaintext, nil), nil
+}
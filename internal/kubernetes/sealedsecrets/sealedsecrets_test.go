@@ -0,0 +1,138 @@
+package sealedsecrets_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/sealedsecrets"
+)
+
+func TestSeal(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sealed, err := sealedsecrets.Seal(&privKey.PublicKey, "web", "staging", types.SealedSecretScopeStrict, map[string]string{
+		"DB_PASSWORD": "hunter2",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sealed.Kind != "SealedSecret" || sealed.Metadata.Name != "web" || sealed.Metadata.Namespace != "staging" {
+		t.Errorf("unexpected metadata: %v %v", sealed.Kind, sealed.Metadata)
+	}
+
+	if len(sealed.Metadata.Annotations) != 0 {
+		t.Errorf("expected no annotations for a strict sealed secret, got %v", sealed.Metadata.Annotations)
+	}
+
+	// the controller decrypts with the namespace and name of the secret as the label
+	if val := decrypt(t, privKey, sealed.Spec.EncryptedData["DB_PASSWORD"], "staging/web"); val != "hunter2" {
+		t.Errorf("expected decrypted value hunter2, got %s", val)
+	}
+
+	sealed, err = sealedsecrets.Seal(&privKey.PublicKey, "web", "staging", types.SealedSecretScopeClusterWide, map[string]string{
+		"DB_PASSWORD": "hunter2",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sealed.Metadata.Annotations["sealedsecrets.bitnami.com/cluster-wide"] != "true" {
+		t.Errorf("expected cluster-wide annotation, got %v", sealed.Metadata.Annotations)
+	}
+
+	if val := decrypt(t, privKey, sealed.Spec.EncryptedData["DB_PASSWORD"], ""); val != "hunter2" {
+		t.Errorf("expected decrypted value hunter2, got %s", val)
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sealed-secret"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, privKey)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pubKey, err := sealedsecrets.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !pubKey.Equal(&privKey.PublicKey) {
+		t.Errorf("expected the public key of the certificate")
+	}
+
+	if _, err := sealedsecrets.ParsePublicKey([]byte("not a certificate")); err == nil {
+		t.Errorf("expected error for a value which isn't PEM-encoded")
+	}
+}
+
+// decrypt decrypts a sealed value the way the sealed-secrets controller does
+func decrypt(t *testing.T, privKey *rsa.PrivateKey, encoded, label string) string {
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rsaLen := int(binary.BigEndian.Uint16(ciphertext))
+	rsaCiphertext := ciphertext[2 : 2+rsaLen]
+
+	sessionKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privKey, rsaCiphertext, []byte(label))
+
+	if err != nil {
+		t.Fatalf("could not decrypt session key: %v", err)
+	}
+
+	block, err := aes.NewCipher(sessionKey)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plaintext, err := aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[2+rsaLen:], nil)
+
+	if err != nil {
+		t.Fatalf("could not decrypt value: %v", err)
+	}
+
+	return string(plaintext)
+}
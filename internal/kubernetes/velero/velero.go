@@ -0,0 +1,415 @@
+package velero
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// Namespace is the namespace which Velero is installed in, and which its resources are created in
+const Namespace = "velero"
+
+const (
+	namespaceLabel    = "porter.run/backup-namespace"
+	releaseLabel      = "porter.run/backup-release"
+	scheduleNameLabel = "velero.io/schedule-name"
+)
+
+var (
+	backupGVR = schema.GroupVersionResource{
+		Group:    "velero.io",
+		Version:  "v1",
+		Resource: "backups",
+	}
+
+	scheduleGVR = schema.GroupVersionResource{
+		Group:    "velero.io",
+		Version:  "v1",
+		Resource: "schedules",
+	}
+
+	restoreGVR = schema.GroupVersionResource{
+		Group:    "velero.io",
+		Version:  "v1",
+		Resource: "restores",
+	}
+)
+
+var ErrNotInstalled = fmt.Errorf("velero is not installed in this cluster")
+
+// CreateBackup starts a backup of the manifests and the persistent volumes of a namespace or a
+// release. A ttlHours of 0 keeps the backup for the Velero default.
+func CreateBackup(client dynamic.Interface, scope *types.BackupScope, ttlHours uint) (*types.Backup, error) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Backup",
+		"metadata":   getMetadata(scope),
+		"spec":       getBackupSpec(scope, ttlHours),
+	}}
+
+	res, err := client.Resource(backupGVR).Namespace(Namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return toBackup(res), nil
+}
+
+// GetBackup reads a backup. The error is a not found error if the backup does not exist.
+func GetBackup(client dynamic.Interface, name string) (*types.Backup, error) {
+	res, err := client.Resource(backupGVR).Namespace(Namespace).Get(context.Background(), name, metav1.GetOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toBackup(res), nil
+}
+
+// ListBackups lists the backups of a namespace or a release, most recent first. If the namespace
+// is empty, every backup is listed, including the backups which were not created by Porter.
+func ListBackups(client dynamic.Interface, namespace, releaseName string) ([]*types.Backup, error) {
+	list, err := client.Resource(backupGVR).Namespace(Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: getLabelSelector(namespace, releaseName),
+	})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	res := make([]*types.Backup, 0, len(list.Items))
+
+	for i := range list.Items {
+		res = append(res, toBackup(&list.Items[i]))
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return startedAfter(res[i].StartedAt, res[j].StartedAt)
+	})
+
+	return res, nil
+}
+
+// CreateSchedule creates a schedule which backs up a namespace or a release on a cron schedule
+func CreateSchedule(
+	client dynamic.Interface,
+	scope *types.BackupScope,
+	cronSchedule string,
+	ttlHours uint,
+) (*types.BackupSchedule, error) {
+	// Velero copies the labels of a schedule to the backups it creates, so that the backups of
+	// a schedule are listed with the backups of its namespace or release
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Schedule",
+		"metadata":   getMetadata(scope),
+		"spec": map[string]interface{}{
+			"schedule": cronSchedule,
+			"template": getBackupSpec(scope, ttlHours),
+		},
+	}}
+
+	res, err := client.Resource(scheduleGVR).Namespace(Namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return toSchedule(res), nil
+}
+
+// ListSchedules lists the schedules of a namespace or a release, in the same way as ListBackups
+func ListSchedules(client dynamic.Interface, namespace, releaseName string) ([]*types.BackupSchedule, error) {
+	list, err := client.Resource(scheduleGVR).Namespace(Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: getLabelSelector(namespace, releaseName),
+	})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	res := make([]*types.BackupSchedule, 0, len(list.Items))
+
+	for i := range list.Items {
+		res = append(res, toSchedule(&list.Items[i]))
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// DeleteSchedule deletes a schedule. The backups which the schedule created are kept until they
+// expire.
+func DeleteSchedule(client dynamic.Interface, name string) error {
+	return client.Resource(scheduleGVR).Namespace(Namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+}
+
+// CreateRestore starts a restore of a backup. If targetNamespace is set, the resources of the
+// namespace of the backup are restored into targetNamespace. Persistent volumes are restored
+// from their snapshots.
+func CreateRestore(client dynamic.Interface, backup *types.Backup, targetNamespace string) (*types.Restore, error) {
+	spec := map[string]interface{}{
+		"backupName":         backup.Name,
+		"includedNamespaces": []interface{}{backup.Namespace},
+		"restorePVs":         true,
+	}
+
+	if targetNamespace != "" && targetNamespace != backup.Namespace {
+		spec["namespaceMapping"] = map[string]interface{}{
+			backup.Namespace: targetNamespace,
+		}
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Restore",
+		"metadata": map[string]interface{}{
+			"generateName": truncate(backup.Name, 52) + "-",
+			"namespace":    Namespace,
+		},
+		"spec": spec,
+	}}
+
+	res, err := client.Resource(restoreGVR).Namespace(Namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return toRestore(res), nil
+}
+
+// ListRestores lists the restores of a cluster, most recent first
+func ListRestores(client dynamic.Interface) ([]*types.Restore, error) {
+	list, err := client.Resource(restoreGVR).Namespace(Namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	res := make([]*types.Restore, 0, len(list.Items))
+
+	for i := range list.Items {
+		res = append(res, toRestore(&list.Items[i]))
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return startedAfter(res[i].StartedAt, res[j].StartedAt)
+	})
+
+	return res, nil
+}
+
+func getMetadata(scope *types.BackupScope) map[string]interface{} {
+	name := scope.Namespace
+	labels := map[string]interface{}{
+		namespaceLabel: scope.Namespace,
+	}
+
+	if scope.ReleaseName != "" {
+		name = fmt.Sprintf("%s-%s", scope.Namespace, scope.ReleaseName)
+		labels[releaseLabel] = scope.ReleaseName
+	}
+
+	return map[string]interface{}{
+		"generateName": truncate(name, 52) + "-",
+		"namespace":    Namespace,
+		"labels":       labels,
+	}
+}
+
+// getBackupSpec returns the spec of a backup of a namespace or a release. The backup of a
+// release contains the resources of its chart and the secrets which store its Helm history,
+// so that the release can be upgraded and rolled back after it's restored.
+func getBackupSpec(scope *types.BackupScope, ttlHours uint) map[string]interface{} {
+	spec := map[string]interface{}{
+		"includedNamespaces": []interface{}{scope.Namespace},
+		"snapshotVolumes":    true,
+	}
+
+	if scope.ReleaseName != "" {
+		spec["orLabelSelectors"] = []interface{}{
+			map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					"app.kubernetes.io/instance": scope.ReleaseName,
+				},
+			},
+			map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					"owner": "helm",
+					"name":  scope.ReleaseName,
+				},
+			},
+		}
+	}
+
+	if ttlHours != 0 {
+		spec["ttl"] = (time.Duration(ttlHours) * time.Hour).String()
+	}
+
+	return spec
+}
+
+func getLabelSelector(namespace, releaseName string) string {
+	if namespace == "" {
+		return ""
+	}
+
+	selectors := []string{fmt.Sprintf("%s=%s", namespaceLabel, namespace)}
+
+	if releaseName != "" {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", releaseLabel, releaseName))
+	}
+
+	return strings.Join(selectors, ",")
+}
+
+func toBackup(obj *unstructured.Unstructured) *types.Backup {
+	labels := obj.GetLabels()
+
+	res := &types.Backup{
+		Name: obj.GetName(),
+		BackupScope: types.BackupScope{
+			Namespace:   labels[namespaceLabel],
+			ReleaseName: labels[releaseLabel],
+		},
+		ScheduleName: labels[scheduleNameLabel],
+	}
+
+	// backups which were not created by Porter are scoped to their first namespace
+	if res.Namespace == "" {
+		namespaces, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "includedNamespaces")
+
+		if len(namespaces) > 0 {
+			res.Namespace = namespaces[0]
+		}
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	res.StartedAt = getTime(obj, "status", "startTimestamp")
+	res.CompletedAt = getTime(obj, "status", "completionTimestamp")
+	res.ExpiresAt = getTime(obj, "status", "expiration")
+	res.Errors = getInt(obj, "status", "errors")
+	res.Warnings = getInt(obj, "status", "warnings")
+	res.VolumeSnapshotsAttempted = getInt(obj, "status", "volumeSnapshotsAttempted")
+	res.VolumeSnapshotsCompleted = getInt(obj, "status", "volumeSnapshotsCompleted")
+	res.ValidationErrors, _, _ = unstructured.NestedStringSlice(obj.Object, "status", "validationErrors")
+
+	return res
+}
+
+func toSchedule(obj *unstructured.Unstructured) *types.BackupSchedule {
+	labels := obj.GetLabels()
+
+	res := &types.BackupSchedule{
+		Name: obj.GetName(),
+		BackupScope: types.BackupScope{
+			Namespace:   labels[namespaceLabel],
+			ReleaseName: labels[releaseLabel],
+		},
+	}
+
+	res.Schedule, _, _ = unstructured.NestedString(obj.Object, "spec", "schedule")
+
+	if ttl, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "ttl"); ttl != "" {
+		if dur, err := time.ParseDuration(ttl); err == nil {
+			res.TTLHours = uint(dur.Hours())
+		}
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	res.LastBackupAt = getTime(obj, "status", "lastBackup")
+	res.ValidationErrors, _, _ = unstructured.NestedStringSlice(obj.Object, "status", "validationErrors")
+
+	return res
+}
+
+func toRestore(obj *unstructured.Unstructured) *types.Restore {
+	res := &types.Restore{
+		Name: obj.GetName(),
+	}
+
+	res.BackupName, _, _ = unstructured.NestedString(obj.Object, "spec", "backupName")
+
+	namespaces, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "includedNamespaces")
+
+	if len(namespaces) > 0 {
+		res.TargetNamespace = namespaces[0]
+
+		if mapped, found, _ := unstructured.NestedString(obj.Object, "spec", "namespaceMapping", namespaces[0]); found {
+			res.TargetNamespace = mapped
+		}
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	res.StartedAt = getTime(obj, "status", "startTimestamp")
+	res.CompletedAt = getTime(obj, "status", "completionTimestamp")
+	res.Errors = getInt(obj, "status", "errors")
+	res.Warnings = getInt(obj, "status", "warnings")
+	res.ValidationErrors, _, _ = unstructured.NestedStringSlice(obj.Object, "status", "validationErrors")
+
+	return res
+}
+
+func getTime(obj *unstructured.Unstructured, fields ...string) *time.Time {
+	val, found, _ := unstructured.NestedString(obj.Object, fields...)
+
+	if !found {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func getInt(obj *unstructured.Unstructured, fields ...string) int {
+	val, _, _ := unstructured.NestedInt64(obj.Object, fields...)
+
+	return int(val)
+}
+
+// startedAfter returns true if a started after b, where backups and restores which have not
+// started are the most recent
+func startedAfter(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	return a.After(*b)
+}
+
+func truncate(str string, length int) string {
+	if len(str) <= length {
+		return str
+	}
+
+	return strings.TrimRight(str[:length], "-")
+}
+
+// wrapErr returns ErrNotInstalled if the Velero CRDs are not registered, in which case the API
+// server returns a not found error
+func wrapErr(err error) error {
+	if k8sErrors.IsNotFound(err) {
+		return ErrNotInstalled
+	}
+
+	return err
+}
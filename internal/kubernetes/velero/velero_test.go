@@ -0,0 +1,100 @@
+package velero
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func testBackup(name string, labels map[string]interface{}, startTimestamp string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Backup",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": Namespace,
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{
+			"includedNamespaces": []interface{}{"default"},
+		},
+		"status": map[string]interface{}{
+			"phase": "Completed",
+		},
+	}}
+
+	if startTimestamp != "" {
+		unstructured.SetNestedField(obj.Object, startTimestamp, "status", "startTimestamp")
+	}
+
+	return obj
+}
+
+func TestGetBackupSpec(t *testing.T) {
+	spec := getBackupSpec(&types.BackupScope{Namespace: "default", ReleaseName: "web"}, 48)
+
+	if spec["ttl"] != "48h0m0s" || spec["snapshotVolumes"] != true {
+		t.Errorf("expected a ttl of 48h with volume snapshots, got %v", spec)
+	}
+
+	// the backup of a release contains its resources and its helm history
+	selectors, _ := spec["orLabelSelectors"].([]interface{})
+
+	if len(selectors) != 2 {
+		t.Fatalf("expected 2 label selectors, got %v", spec["orLabelSelectors"])
+	}
+
+	expected := map[string]interface{}{"owner": "helm", "name": "web"}
+
+	if labels := selectors[1].(map[string]interface{})["matchLabels"]; !reflect.DeepEqual(labels, expected) {
+		t.Errorf("expected the helm history selector %v, got %v", expected, labels)
+	}
+
+	if spec := getBackupSpec(&types.BackupScope{Namespace: "default"}, 0); spec["orLabelSelectors"] != nil || spec["ttl"] != nil {
+		t.Errorf("expected the backup of a namespace to have no label selectors or ttl, got %v", spec)
+	}
+}
+
+func TestListBackups(t *testing.T) {
+	dynClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{backupGVR: "BackupList"},
+		testBackup("web-1", map[string]interface{}{namespaceLabel: "default", releaseLabel: "web"}, "2022-06-01T10:00:00Z"),
+		testBackup("web-2", map[string]interface{}{namespaceLabel: "default", releaseLabel: "web"}, "2022-06-02T10:00:00Z"),
+		testBackup("web-3", map[string]interface{}{namespaceLabel: "default", releaseLabel: "web"}, ""),
+		testBackup("default-1", map[string]interface{}{namespaceLabel: "default"}, "2022-06-03T10:00:00Z"),
+		testBackup("manual", nil, "2022-06-04T10:00:00Z"),
+	)
+
+	backups, err := ListBackups(dynClient, "default", "web")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := make([]string, 0)
+
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+
+	// backups which have not started are the most recent
+	if expected := []string{"web-3", "web-2", "web-1"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected backups %v, got %v", expected, names)
+	}
+
+	backups, err = ListBackups(dynClient, "", "")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(backups) != 5 || backups[1].Name != "manual" || backups[1].Namespace != "default" {
+		t.Errorf("expected every backup with the backup not created by Porter scoped to its namespace, got %v", backups)
+	}
+}
@@ -0,0 +1,99 @@
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// kubeletStatsSummary is the subset of the kubelet's /stats/summary response that
+// contains volume usage
+type kubeletStatsSummary struct {
+	Pods []struct {
+		Volumes []struct {
+			PVCRef *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef,omitempty"`
+
+			UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+			AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+			CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+		} `json:"volume,omitempty"`
+	} `json:"pods"`
+}
+
+// getVolumeUsage queries the kubelet on each node running pods in the namespace for
+// volume stats, and returns a map of claim names to usage
+func getVolumeUsage(clientset k8s.Interface, namespace string) (map[string]*types.PersistentVolumeClaimUsage, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	nodeNames := make(map[string]bool)
+
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil {
+				nodeNames[pod.Spec.NodeName] = true
+				break
+			}
+		}
+	}
+
+	res := make(map[string]*types.PersistentVolumeClaimUsage)
+
+	for nodeName := range nodeNames {
+		raw, err := clientset.CoreV1().RESTClient().Get().
+			Resource("nodes").
+			Name(nodeName).
+			SubResource("proxy").
+			Suffix("stats/summary").
+			DoRaw(context.Background())
+
+		if err != nil {
+			return nil, err
+		}
+
+		summary := &kubeletStatsSummary{}
+
+		if err := json.Unmarshal(raw, summary); err != nil {
+			return nil, err
+		}
+
+		for _, pod := range summary.Pods {
+			for _, vol := range pod.Volumes {
+				if vol.PVCRef == nil || vol.PVCRef.Namespace != namespace {
+					continue
+				}
+
+				usage := &types.PersistentVolumeClaimUsage{}
+
+				if vol.UsedBytes != nil {
+					usage.UsedBytes = *vol.UsedBytes
+				}
+
+				if vol.AvailableBytes != nil {
+					usage.AvailableBytes = *vol.AvailableBytes
+				}
+
+				if vol.CapacityBytes != nil {
+					usage.CapacityBytes = *vol.CapacityBytes
+				}
+
+				res[vol.PVCRef.Name] = usage
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,309 @@
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// DeletionProtectionAnnotation is set on persistent volume claims which should not be
+// deleted through Porter
+const DeletionProtectionAnnotation = "porter.run/deletion-protection"
+
+var ErrDeletionProtected = fmt.Errorf("persistent volume claim has deletion protection enabled")
+
+var ErrExpansionNotSupported = fmt.Errorf("the storage class of this persistent volume claim does not support volume expansion")
+
+var ErrInvalidSize = fmt.Errorf("invalid size")
+
+// ListPersistentVolumeClaims lists the persistent volume claims in a namespace. If releaseName
+// is set, only claims owned by that release are returned.
+func ListPersistentVolumeClaims(
+	clientset k8s.Interface,
+	namespace, releaseName string,
+	withUsage bool,
+) ([]*types.PersistentVolumeClaim, error) {
+	pvcList, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(
+		context.Background(),
+		metav1.ListOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expandable, err := getExpandableStorageClasses(clientset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var usage map[string]*types.PersistentVolumeClaimUsage
+
+	if withUsage {
+		// usage is best-effort: the kubelet stats endpoint may be unreachable
+		usage, _ = getVolumeUsage(clientset, namespace)
+	}
+
+	res := make([]*types.PersistentVolumeClaim, 0)
+
+	for _, pvc := range pvcList.Items {
+		if releaseName != "" && getReleaseName(&pvc) != releaseName {
+			continue
+		}
+
+		res = append(res, toPVCType(&pvc, expandable, usage))
+	}
+
+	return res, nil
+}
+
+// GetPersistentVolumeClaim returns a single persistent volume claim, along with its usage
+func GetPersistentVolumeClaim(clientset k8s.Interface, namespace, name string) (*types.PersistentVolumeClaim, error) {
+	pvc, err := getPVC(clientset, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expandable, err := getExpandableStorageClasses(clientset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	usage, _ := getVolumeUsage(clientset, namespace)
+
+	return toPVCType(pvc, expandable, usage), nil
+}
+
+// ResizePersistentVolumeClaim expands a persistent volume claim to the given size. This
+// only succeeds if the storage class of the claim allows volume expansion, and if the new
+// size is larger than the current request.
+func ResizePersistentVolumeClaim(clientset k8s.Interface, namespace, name, size string) (*types.PersistentVolumeClaim, error) {
+	newSize, err := resource.ParseQuantity(size)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidSize, size, err.Error())
+	}
+
+	pvc, err := getPVC(clientset, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expandable, err := getExpandableStorageClasses(clientset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc.Spec.StorageClassName == nil || !expandable[*pvc.Spec.StorageClassName] {
+		return nil, ErrExpansionNotSupported
+	}
+
+	currSize := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+
+	if newSize.Cmp(currSize) <= 0 {
+		return nil, fmt.Errorf("%w: new size %s must be larger than the current size %s", ErrInvalidSize, newSize.String(), currSize.String())
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]string{
+					string(v1.ResourceStorage): newSize.String(),
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	pvc, err = clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(
+		context.Background(),
+		name,
+		k8stypes.MergePatchType,
+		patch,
+		metav1.PatchOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPVCType(pvc, expandable, nil), nil
+}
+
+// SetDeletionProtection toggles the deletion protection annotation on a persistent volume claim
+func SetDeletionProtection(clientset k8s.Interface, namespace, name string, enabled bool) (*types.PersistentVolumeClaim, error) {
+	var annValue interface{}
+
+	if enabled {
+		annValue = "true"
+	}
+
+	// a null value in a merge patch removes the annotation
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				DeletionProtectionAnnotation: annValue,
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(
+		context.Background(),
+		name,
+		k8stypes.MergePatchType,
+		patch,
+		metav1.PatchOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, kubernetes.IsNotFoundError
+	} else if err != nil {
+		return nil, err
+	}
+
+	expandable, err := getExpandableStorageClasses(clientset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPVCType(pvc, expandable, nil), nil
+}
+
+// DeletePersistentVolumeClaim deletes a persistent volume claim, unless deletion protection
+// is enabled for the claim
+func DeletePersistentVolumeClaim(clientset k8s.Interface, namespace, name string) error {
+	pvc, err := getPVC(clientset, namespace, name)
+
+	if err != nil {
+		return err
+	}
+
+	if isDeletionProtected(pvc) {
+		return ErrDeletionProtected
+	}
+
+	return clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(
+		context.Background(),
+		name,
+		metav1.DeleteOptions{},
+	)
+}
+
+func getPVC(clientset k8s.Interface, namespace, name string) (*v1.PersistentVolumeClaim, error) {
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(
+		context.Background(),
+		name,
+		metav1.GetOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, kubernetes.IsNotFoundError
+	}
+
+	return pvc, err
+}
+
+func getExpandableStorageClasses(clientset k8s.Interface) (map[string]bool, error) {
+	scList, err := clientset.StorageV1().StorageClasses().List(
+		context.Background(),
+		metav1.ListOptions{},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool)
+
+	for _, sc := range scList.Items {
+		res[sc.Name] = sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
+	}
+
+	return res, nil
+}
+
+func getReleaseName(pvc *v1.PersistentVolumeClaim) string {
+	if name, ok := pvc.Annotations["meta.helm.sh/release-name"]; ok {
+		return name
+	}
+
+	// claims created from a statefulset's volume claim templates inherit the pod labels
+	if name, ok := pvc.Labels["app.kubernetes.io/instance"]; ok {
+		return name
+	}
+
+	return pvc.Labels["release"]
+}
+
+func isDeletionProtected(pvc *v1.PersistentVolumeClaim) bool {
+	return pvc.Annotations[DeletionProtectionAnnotation] == "true"
+}
+
+func toPVCType(
+	pvc *v1.PersistentVolumeClaim,
+	expandable map[string]bool,
+	usage map[string]*types.PersistentVolumeClaimUsage,
+) *types.PersistentVolumeClaim {
+	res := &types.PersistentVolumeClaim{
+		Name:               pvc.Name,
+		Namespace:          pvc.Namespace,
+		ReleaseName:        getReleaseName(pvc),
+		Status:             string(pvc.Status.Phase),
+		VolumeName:         pvc.Spec.VolumeName,
+		AccessModes:        make([]string, 0),
+		DeletionProtection: isDeletionProtected(pvc),
+		CreatedAt:          pvc.CreationTimestamp.Time,
+	}
+
+	if pvc.Spec.StorageClassName != nil {
+		res.StorageClass = *pvc.Spec.StorageClassName
+		res.CanExpand = expandable[res.StorageClass]
+	}
+
+	for _, mode := range pvc.Spec.AccessModes {
+		res.AccessModes = append(res.AccessModes, string(mode))
+	}
+
+	if req, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		res.RequestedSize = req.String()
+	}
+
+	if capac, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		res.Capacity = capac.String()
+	}
+
+	for _, cond := range pvc.Status.Conditions {
+		if (cond.Type == v1.PersistentVolumeClaimResizing || cond.Type == v1.PersistentVolumeClaimFileSystemResizePending) &&
+			cond.Status == v1.ConditionTrue {
+			res.Resizing = true
+		}
+	}
+
+	if usage != nil {
+		res.Usage = usage[pvc.Name]
+	}
+
+	return res
+}
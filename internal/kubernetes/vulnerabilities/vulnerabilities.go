@@ -0,0 +1,286 @@
+package vulnerabilities
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/repository"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// vulnerabilityReportGVR is the resource written by the Trivy operator for each scanned
+// container image
+var vulnerabilityReportGVR = schema.GroupVersionResource{
+	Group:    "aquasecurity.github.io",
+	Version:  "v1alpha1",
+	Resource: "vulnerabilityreports",
+}
+
+var ErrNoScanResults = fmt.Errorf("no vulnerability scan results found for image")
+
+// Scanner looks up vulnerability scan results for container images. Reports written by
+// the Trivy operator in the cluster are preferred, and scan findings from ECR or Harbor are
+// used as a fallback for images hosted in a connected registry.
+type Scanner struct {
+	dynClient  dynamic.Interface
+	namespace  string
+	registries []*models.Registry
+	repo       repository.Repository
+
+	// trivyReports caches the Trivy reports in the namespace, keyed by normalized image
+	trivyReports map[string]*types.ImageVulnerabilitySummary
+}
+
+func NewScanner(
+	dynClient dynamic.Interface,
+	namespace string,
+	registries []*models.Registry,
+	repo repository.Repository,
+) *Scanner {
+	return &Scanner{
+		dynClient:  dynClient,
+		namespace:  namespace,
+		registries: registries,
+		repo:       repo,
+	}
+}
+
+// ScanRelease returns the vulnerability summaries of every image in a release manifest.
+// Images without scan results are skipped.
+func (s *Scanner) ScanRelease(manifest string) []*types.ImageVulnerabilitySummary {
+	res := make([]*types.ImageVulnerabilitySummary, 0)
+
+	for _, image := range GetManifestImages(manifest) {
+		summary, err := s.Scan(image)
+
+		if err != nil {
+			continue
+		}
+
+		res = append(res, summary)
+	}
+
+	return res
+}
+
+// Scan returns the vulnerability summary of a single image
+func (s *Scanner) Scan(image string) (*types.ImageVulnerabilitySummary, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid image %s: %w", image, err)
+	}
+
+	named = reference.TagNameOnly(named)
+
+	if s.dynClient != nil {
+		if err := s.loadTrivyReports(); err != nil {
+			return nil, err
+		}
+
+		if summary, ok := s.trivyReports[named.String()]; ok {
+			return withImage(summary, image), nil
+		}
+	}
+
+	tagged, ok := named.(reference.Tagged)
+
+	if !ok {
+		return nil, ErrNoScanResults
+	}
+
+	for _, reg := range s.registries {
+		_reg := registry.Registry(*reg)
+
+		if !_reg.SupportsImageScanning() || registryHost(reg.URL) != reference.Domain(named) {
+			continue
+		}
+
+		summary, err := _reg.GetImageScanSummary(s.repo, reference.Path(named), tagged.Tag())
+
+		if err == registry.ErrNoScanFindings {
+			return nil, ErrNoScanResults
+		} else if err != nil {
+			return nil, err
+		}
+
+		return withImage(summary, image), nil
+	}
+
+	return nil, ErrNoScanResults
+}
+
+func (s *Scanner) loadTrivyReports() error {
+	if s.trivyReports != nil {
+		return nil
+	}
+
+	s.trivyReports = make(map[string]*types.ImageVulnerabilitySummary)
+
+	reportList, err := s.dynClient.Resource(vulnerabilityReportGVR).Namespace(s.namespace).List(
+		context.Background(),
+		metav1.ListOptions{},
+	)
+
+	// if the Trivy operator is not installed, there are simply no reports to use
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, report := range reportList.Items {
+		image, summary := parseTrivyReport(&report)
+
+		if image == "" {
+			continue
+		}
+
+		// multiple workloads may run the same image, in which case the most recent report is used
+		if existing, ok := s.trivyReports[image]; ok && existing.ScannedAt != nil &&
+			summary.ScannedAt != nil && existing.ScannedAt.After(*summary.ScannedAt) {
+			continue
+		}
+
+		s.trivyReports[image] = summary
+	}
+
+	return nil
+}
+
+func parseTrivyReport(report *unstructured.Unstructured) (string, *types.ImageVulnerabilitySummary) {
+	server, _, _ := unstructured.NestedString(report.Object, "report", "registry", "server")
+	repoName, _, _ := unstructured.NestedString(report.Object, "report", "artifact", "repository")
+	tag, _, _ := unstructured.NestedString(report.Object, "report", "artifact", "tag")
+
+	if repoName == "" {
+		return "", nil
+	}
+
+	// the Trivy operator reports Docker Hub images under index.docker.io
+	if server == "index.docker.io" {
+		server = "docker.io"
+	}
+
+	ref := repoName
+
+	if server != "" {
+		ref = server + "/" + repoName
+	}
+
+	if tag != "" {
+		ref += ":" + tag
+	}
+
+	named, err := reference.ParseNormalizedNamed(ref)
+
+	if err != nil {
+		return "", nil
+	}
+
+	summary := &types.ImageVulnerabilitySummary{
+		Scanner:  types.VulnerabilityScannerTrivy,
+		Critical: nestedCount(report, "criticalCount"),
+		High:     nestedCount(report, "highCount"),
+		Medium:   nestedCount(report, "mediumCount"),
+		Low:      nestedCount(report, "lowCount"),
+		Unknown:  nestedCount(report, "unknownCount"),
+	}
+
+	if updated, found, _ := unstructured.NestedString(report.Object, "report", "updateTimestamp"); found {
+		if t, err := time.Parse(time.RFC3339, updated); err == nil {
+			summary.ScannedAt = &t
+		}
+	}
+
+	return reference.TagNameOnly(named).String(), summary
+}
+
+func nestedCount(report *unstructured.Unstructured, field string) int {
+	count, _, _ := unstructured.NestedInt64(report.Object, "report", "summary", field)
+
+	return int(count)
+}
+
+// GetManifestImages returns the unique container images referenced by the pod specs in a
+// release manifest
+func GetManifestImages(manifest string) []string {
+	images := make(map[string]bool)
+
+	for _, obj := range grapher.ImportMultiDocYAML([]byte(manifest)) {
+		collectImages(obj, images)
+	}
+
+	res := make([]string, 0)
+
+	for image := range images {
+		res = append(res, image)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// collectImages walks an object and collects the images of all containers, so that pod
+// specs nested in deployments, jobs and cronjobs are all found
+func collectImages(obj interface{}, images map[string]bool) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for key, val := range o {
+			if key == "containers" || key == "initContainers" {
+				if containers, ok := val.([]interface{}); ok {
+					for _, container := range containers {
+						if c, ok := container.(map[string]interface{}); ok {
+							if image, ok := c["image"].(string); ok && image != "" {
+								images[image] = true
+							}
+						}
+					}
+				}
+
+				continue
+			}
+
+			collectImages(val, images)
+		}
+	case []interface{}:
+		for _, val := range o {
+			collectImages(val, images)
+		}
+	}
+}
+
+func registryHost(regURL string) string {
+	if !strings.Contains(regURL, "http") {
+		regURL = "https://" + regURL
+	}
+
+	parsed, err := url.Parse(regURL)
+
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Host
+}
+
+func withImage(summary *types.ImageVulnerabilitySummary, image string) *types.ImageVulnerabilitySummary {
+	res := *summary
+	res.Image = image
+
+	return &res
+}
@@ -0,0 +1,108 @@
+package vulnerabilities_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes/vulnerabilities"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const testManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: migrate
+        image: porter/web:v1
+      containers:
+      - name: web
+        image: porter/web:v1
+      - name: sidecar
+        image: nginx:1.23
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: cleanup
+            image: porter/cleanup:v2
+`
+
+func TestGetManifestImages(t *testing.T) {
+	images := vulnerabilities.GetManifestImages(testManifest)
+	expected := []string{"nginx:1.23", "porter/cleanup:v2", "porter/web:v1"}
+
+	if len(images) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, images)
+	}
+
+	for i := range expected {
+		if images[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, images)
+		}
+	}
+}
+
+func TestScanTrivyReport(t *testing.T) {
+	gvr := schema.GroupVersionResource{
+		Group:    "aquasecurity.github.io",
+		Version:  "v1alpha1",
+		Resource: "vulnerabilityreports",
+	}
+
+	report := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "aquasecurity.github.io/v1alpha1",
+		"kind":       "VulnerabilityReport",
+		"metadata": map[string]interface{}{
+			"name":      "replicaset-web-nginx",
+			"namespace": "default",
+		},
+		"report": map[string]interface{}{
+			"registry": map[string]interface{}{
+				"server": "index.docker.io",
+			},
+			"artifact": map[string]interface{}{
+				"repository": "library/nginx",
+				"tag":        "1.23",
+			},
+			"summary": map[string]interface{}{
+				"criticalCount": int64(2),
+				"highCount":     int64(5),
+			},
+		},
+	}}
+
+	dynClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "VulnerabilityReportList"},
+		report,
+	)
+
+	scanner := vulnerabilities.NewScanner(dynClient, "default", nil, nil)
+
+	summary, err := scanner.Scan("nginx:1.23")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.Image != "nginx:1.23" || summary.Critical != 2 || summary.High != 5 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	if _, err := scanner.Scan("porter/web:v1"); err != vulnerabilities.ErrNoScanResults {
+		t.Errorf("expected ErrNoScanResults, got %v", err)
+	}
+}
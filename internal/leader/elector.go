@@ -0,0 +1,213 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+const (
+	// the prefix of the leases which elect the leader of a worker
+	leaderLeasePrefix = "leader/"
+
+	// the prefix of the leases which register the replicas that workers are partitioned across
+	memberLeasePrefix = "member/"
+)
+
+// Elector coordinates the background workers of the server replicas which share a database,
+// so that Porter can run with several replicas. Workers which must run on a single replica are
+// run by the leader of their lease, and workers which can be split across replicas use the
+// members of the elector to partition their work.
+//
+// Leases are stored in the database, and expire unless their holder renews them. The clocks of
+// the replicas are assumed to be synchronized within a fraction of the lease duration.
+type Elector struct {
+	repo          repository.Repository
+	logger        *logger.Logger
+	id            string
+	leaseDuration time.Duration
+
+	mu      sync.Mutex
+	members []string
+}
+
+func NewElector(repo repository.Repository, logger *logger.Logger, leaseDuration time.Duration) (*Elector, error) {
+	hostname, err := os.Hostname()
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting hostname: %w", err)
+	}
+
+	// the suffix keeps the identities of replicas which share a hostname, such as restarted
+	// containers, distinct
+	suffix, err := encryption.GenerateRandomBytes(8)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Elector{
+		repo:          repo,
+		logger:        logger,
+		id:            hostname + "-" + suffix,
+		leaseDuration: leaseDuration,
+	}, nil
+}
+
+// ID returns the identity of this replica
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// renewInterval is how often leases are renewed, which leaves time for two renewals to fail
+// before a lease expires
+func (e *Elector) renewInterval() time.Duration {
+	return e.leaseDuration / 3
+}
+
+// Lead starts run each time this replica acquires the lease with the given name, with a context
+// which is cancelled once it stops holding the lease. Run must stop when its context is
+// cancelled, since the lease can then be acquired by another replica. Lead returns immediately,
+// and releases the lease when ctx is cancelled.
+func (e *Elector) Lead(ctx context.Context, name string, run func(ctx context.Context)) {
+	leaseName := leaderLeasePrefix + name
+
+	go func() {
+		ticker := time.NewTicker(e.renewInterval())
+		defer ticker.Stop()
+
+		var cancel context.CancelFunc
+		var expiresAt time.Time
+
+		for {
+			now := time.Now()
+
+			held, err := e.repo.LeaderLease().AcquireLeaderLease(leaseName, e.id, now, now.Add(e.leaseDuration))
+
+			if err != nil {
+				e.logger.Error().Err(err).Msgf("error acquiring lease %s", leaseName)
+			}
+
+			switch {
+			case held:
+				expiresAt = now.Add(e.leaseDuration)
+
+				if cancel == nil {
+					e.logger.Info().Msgf("%s acquired lease %s", e.id, leaseName)
+
+					var leaderCtx context.Context
+					leaderCtx, cancel = context.WithCancel(ctx)
+
+					go run(leaderCtx)
+				}
+			// a renewal which fails with an error keeps the lease until the next renewal would
+			// be too late, so that a short database outage doesn't restart the worker
+			case cancel != nil && (err == nil || !now.Add(e.renewInterval()).Before(expiresAt)):
+				e.logger.Info().Msgf("%s lost lease %s", e.id, leaseName)
+
+				cancel()
+				cancel = nil
+			}
+
+			select {
+			case <-ctx.Done():
+				if cancel != nil {
+					cancel()
+
+					if err := e.repo.LeaderLease().ReleaseLeaderLease(leaseName, e.id, time.Now()); err != nil {
+						e.logger.Error().Err(err).Msgf("error releasing lease %s", leaseName)
+					}
+				}
+
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Join registers this replica as a member which workers are partitioned across, until ctx is
+// cancelled. It returns once the members have been listed, so that Owns can be used right away.
+func (e *Elector) Join(ctx context.Context) error {
+	if err := e.heartbeat(); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(e.renewInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				err := e.repo.LeaderLease().ReleaseLeaderLease(memberLeasePrefix+e.id, e.id, time.Now())
+
+				if err != nil {
+					e.logger.Error().Err(err).Msgf("error releasing member lease of %s", e.id)
+				}
+
+				return
+			case <-ticker.C:
+				if err := e.heartbeat(); err != nil {
+					e.logger.Error().Err(err).Msgf("error renewing member lease of %s", e.id)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// heartbeat renews the member lease of this replica, and lists the members whose leases are
+// active
+func (e *Elector) heartbeat() error {
+	now := time.Now()
+
+	if _, err := e.repo.LeaderLease().AcquireLeaderLease(
+		memberLeasePrefix+e.id, e.id, now, now.Add(e.leaseDuration),
+	); err != nil {
+		return err
+	}
+
+	leases, err := e.repo.LeaderLease().ListActiveLeaderLeases(memberLeasePrefix, now)
+
+	if err != nil {
+		return err
+	}
+
+	members := make([]string, 0, len(leases))
+
+	for _, lease := range leases {
+		members = append(members, lease.Holder)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.members = members
+
+	return nil
+}
+
+// Owns returns true if a key, such as the ID of a cluster, belongs to the partition of this
+// replica. Keys are assigned to the members by their remainder, so keys move to other replicas
+// when members join or leave, and can be handled twice or skipped for a renewal interval while
+// the replicas see different members.
+func (e *Elector) Owns(key uint) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, member := range e.members {
+		if member == e.id {
+			return int(key%uint(len(e.members))) == i
+		}
+	}
+
+	return false
+}
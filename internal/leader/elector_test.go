@@ -0,0 +1,33 @@
+package leader
+
+import (
+	"testing"
+)
+
+func TestOwns(t *testing.T) {
+	members := []string{"replica-a", "replica-b", "replica-c"}
+	owners := make(map[uint]int)
+
+	for _, id := range members {
+		e := &Elector{id: id, members: members}
+
+		for key := uint(0); key < 30; key++ {
+			if e.Owns(key) {
+				owners[key]++
+			}
+		}
+	}
+
+	for key := uint(0); key < 30; key++ {
+		if owners[key] != 1 {
+			t.Errorf("expected key %d to be owned by one replica, got %d\n", key, owners[key])
+		}
+	}
+
+	// replicas which aren't members yet don't own any keys
+	e := &Elector{id: "replica-d", members: members}
+
+	if e.Owns(0) {
+		t.Errorf("expected a replica which is not a member not to own keys\n")
+	}
+}
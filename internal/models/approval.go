@@ -0,0 +1,157 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ApprovalPolicy requires upgrades of the releases in a cluster, or in some namespaces of a
+// cluster, to be approved before they are executed
+type ApprovalPolicy struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+
+	// comma-separated list of the namespaces which the policy applies to, or empty if the
+	// policy applies to every namespace of the cluster
+	Namespaces string
+
+	// comma-separated list of the ids of the users who can approve upgrades
+	ApproverIDs string
+}
+
+// Applies returns true if the policy applies to upgrades of releases in the namespace of the
+// cluster
+func (p *ApprovalPolicy) Applies(clusterID uint, namespace string) bool {
+	if p.ClusterID != clusterID {
+		return false
+	}
+
+	if p.Namespaces == "" {
+		return true
+	}
+
+	for _, ns := range strings.Split(p.Namespaces, ",") {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *ApprovalPolicy) ToApprovalPolicyType() *types.ApprovalPolicy {
+	namespaces := []string{}
+
+	if p.Namespaces != "" {
+		namespaces = strings.Split(p.Namespaces, ",")
+	}
+
+	return &types.ApprovalPolicy{
+		ID:          p.ID,
+		CreatedAt:   p.CreatedAt,
+		ProjectID:   p.ProjectID,
+		ClusterID:   p.ClusterID,
+		Namespaces:  namespaces,
+		ApproverIDs: parseIDs(p.ApproverIDs),
+	}
+}
+
+// Approval is an upgrade of a release which must be approved before it is executed. The
+// values of the upgrade are stored so that the upgrade can be executed once it is approved.
+type Approval struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+	Namespace string
+	Name      string
+
+	PolicyID          uint
+	RequestedByUserID uint
+
+	// the values of the upgrade, as yaml
+	Values         []byte
+	ChartVersion   string
+	LatestRevision uint
+
+	// the feature flags which are changed once the upgrade succeeds, as json
+	FeatureFlags []byte
+
+	// comma-separated list of the ids of the users who can approve the upgrade, copied from
+	// the policy when the upgrade is requested
+	ApproverIDs string
+
+	Status types.ApprovalStatus
+
+	ReviewedByUserID uint
+	ReviewedAt       *time.Time
+	Comment          string
+
+	Revision int
+	Error    string
+}
+
+// CanReview returns true if the user is an approver of the upgrade. Users cannot approve
+// upgrades which they requested.
+func (a *Approval) CanReview(userID uint) bool {
+	if userID == a.RequestedByUserID {
+		return false
+	}
+
+	for _, id := range parseIDs(a.ApproverIDs) {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *Approval) ToApprovalType() *types.Approval {
+	return &types.Approval{
+		ID:                a.ID,
+		CreatedAt:         a.CreatedAt,
+		ProjectID:         a.ProjectID,
+		ClusterID:         a.ClusterID,
+		Namespace:         a.Namespace,
+		Name:              a.Name,
+		ChartVersion:      a.ChartVersion,
+		RequestedByUserID: a.RequestedByUserID,
+		ApproverIDs:       parseIDs(a.ApproverIDs),
+		Status:            a.Status,
+		ReviewedByUserID:  a.ReviewedByUserID,
+		ReviewedAt:        a.ReviewedAt,
+		Comment:           a.Comment,
+		Revision:          a.Revision,
+		Error:             a.Error,
+	}
+}
+
+// FormatIDs returns the comma-separated list of ids which is stored for a list of ids
+func FormatIDs(ids []uint) string {
+	strs := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		strs = append(strs, strconv.FormatUint(uint64(id), 10))
+	}
+
+	return strings.Join(strs, ",")
+}
+
+func parseIDs(str string) []uint {
+	res := make([]uint, 0)
+
+	for _, idStr := range strings.Split(str, ",") {
+		if id, err := strconv.ParseUint(idStr, 10, 64); err == nil {
+			res = append(res, uint(id))
+		}
+	}
+
+	return res
+}
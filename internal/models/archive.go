@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ArchivedDeployment is a preview deployment of a closed pull request which was moved out of
+// the deployments table by the archival job. The deployment is stored as JSON, along with the
+// columns that archived deployments are queried by. The time at which the deployment was
+// archived is stored as CreatedAt.
+type ArchivedDeployment struct {
+	gorm.Model
+
+	ProjectID     uint `gorm:"index:idx_archived_deployments_cluster"`
+	ClusterID     uint `gorm:"index:idx_archived_deployments_cluster"`
+	EnvironmentID uint
+	DeploymentID  uint
+
+	Namespace     string
+	RepoOwner     string
+	RepoName      string
+	PullRequestID uint
+
+	DeploymentCreatedAt time.Time
+
+	// Data is the JSON-encoded types.Deployment
+	Data []byte
+}
+
+func (d *ArchivedDeployment) ToArchivedDeploymentType() *types.ArchivedDeployment {
+	depl := &types.Deployment{}
+
+	// archived data is written by the archival job, so it can only fail to decode if the
+	// deployment type changes incompatibly, in which case the stored columns are returned
+	if err := json.Unmarshal(d.Data, depl); err != nil {
+		depl = &types.Deployment{
+			ID:            d.DeploymentID,
+			EnvironmentID: d.EnvironmentID,
+			Namespace:     d.Namespace,
+			PullRequestID: d.PullRequestID,
+		}
+	}
+
+	return &types.ArchivedDeployment{
+		ID:         d.ID,
+		ArchivedAt: d.CreatedAt,
+		ProjectID:  d.ProjectID,
+		ClusterID:  d.ClusterID,
+		Deployment: depl,
+	}
+}
+
+// ArchivedKubeEvent is a kube event, with its sub events, which was moved out of the kube
+// events tables by the archival job. The time at which the event was archived is stored as
+// CreatedAt.
+type ArchivedKubeEvent struct {
+	gorm.Model
+
+	ProjectID   uint `gorm:"index:idx_archived_kube_events_cluster"`
+	ClusterID   uint `gorm:"index:idx_archived_kube_events_cluster"`
+	KubeEventID uint
+
+	Name         string
+	ResourceType string
+	Namespace    string
+
+	// Data is the JSON-encoded types.KubeEvent
+	Data []byte
+}
+
+func (e *ArchivedKubeEvent) ToArchivedKubeEventType() *types.ArchivedKubeEvent {
+	event := &types.KubeEvent{}
+
+	if err := json.Unmarshal(e.Data, event); err != nil {
+		event = &types.KubeEvent{
+			ID:           e.KubeEventID,
+			ProjectID:    e.ProjectID,
+			ClusterID:    e.ClusterID,
+			Name:         e.Name,
+			ResourceType: e.ResourceType,
+			Namespace:    e.Namespace,
+		}
+	}
+
+	return &types.ArchivedKubeEvent{
+		ID:         e.ID,
+		ArchivedAt: e.CreatedAt,
+		KubeEvent:  event,
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditLog records a sensitive action taken by a user in a project
+type AuditLog struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+	UserID    uint
+
+	Action string
+
+	ResourceKind string
+	ResourceName string
+	Namespace    string
+	ReleaseName  string
+
+	// JSON-encoded metadata for the action
+	Metadata []byte
+}
+
+func (a *AuditLog) ToAuditLogType() *types.AuditLog {
+	res := &types.AuditLog{
+		ID:           a.ID,
+		CreatedAt:    a.CreatedAt,
+		ProjectID:    a.ProjectID,
+		ClusterID:    a.ClusterID,
+		UserID:       a.UserID,
+		Action:       types.AuditLogAction(a.Action),
+		ResourceKind: a.ResourceKind,
+		ResourceName: a.ResourceName,
+		Namespace:    a.Namespace,
+		ReleaseName:  a.ReleaseName,
+	}
+
+	if len(a.Metadata) > 0 {
+		metadata := make(map[string]interface{})
+
+		if err := json.Unmarshal(a.Metadata, &metadata); err == nil {
+			res.Metadata = metadata
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// BackgroundJob is a side effect of a request, like a GitHub comment or a namespace teardown,
+// which is stored in the database and run by the job queue with retries
+type BackgroundJob struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	// Kind is the type of the job, which determines the handler that runs it
+	Kind string `gorm:"index"`
+
+	// Payload is the JSON-encoded input of the job
+	Payload []byte
+
+	Status      types.BackgroundJobStatus `gorm:"index"`
+	Attempts    uint
+	MaxAttempts uint
+
+	// RunAfter is the earliest time at which the job can be claimed by a worker
+	RunAfter time.Time `gorm:"index"`
+
+	// LockedAt is the time at which the job was claimed by a worker
+	LockedAt *time.Time
+
+	LastError string
+}
+
+func (j *BackgroundJob) ToBackgroundJobType() *types.BackgroundJob {
+	return &types.BackgroundJob{
+		ID:          j.ID,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		Kind:        j.Kind,
+		Status:      j.Status,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAfter:    j.RunAfter,
+		LastError:   j.LastError,
+	}
+}
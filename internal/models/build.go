@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// Build is an image build run with Cloud Native Buildpacks as a job in a cluster
+type Build struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+	Namespace string
+
+	GitRepo              string
+	GitBranch            string
+	CommitSHA            string
+	GithubInstallationID uint
+	FolderPath           string
+
+	Builder string
+	Image   string
+
+	// The name of the job running the build
+	JobName string
+
+	Status     types.BuildStatus
+	Error      string
+	FinishedAt *time.Time
+}
+
+func (b *Build) ToBuildType() *types.Build {
+	return &types.Build{
+		ID:         b.ID,
+		CreatedAt:  b.CreatedAt,
+		ProjectID:  b.ProjectID,
+		ClusterID:  b.ClusterID,
+		Namespace:  b.Namespace,
+		GitRepo:    b.GitRepo,
+		GitBranch:  b.GitBranch,
+		CommitSHA:  b.CommitSHA,
+		FolderPath: b.FolderPath,
+		Builder:    b.Builder,
+		Image:      b.Image,
+		Status:     b.Status,
+		Error:      b.Error,
+		FinishedAt: b.FinishedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// BulkDeploymentOperation is an action which is run in the background on many preview
+// deployments of a cluster
+type BulkDeploymentOperation struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint `gorm:"index"`
+
+	Action types.BulkDeploymentAction
+	Status types.BulkDeploymentOperationStatus
+
+	Items []BulkDeploymentOperationItem
+}
+
+// BulkDeploymentOperationItem is the result of a bulk deployment operation for a single deployment
+type BulkDeploymentOperationItem struct {
+	gorm.Model
+
+	BulkDeploymentOperationID uint `gorm:"index"`
+
+	DeploymentID uint
+	Namespace    string
+
+	Status  types.BulkDeploymentItemStatus
+	Message string
+}
+
+func (o *BulkDeploymentOperation) ToBulkDeploymentOperationType() *types.BulkDeploymentOperation {
+	items := make([]*types.BulkDeploymentOperationItem, 0, len(o.Items))
+
+	for _, item := range o.Items {
+		items = append(items, &types.BulkDeploymentOperationItem{
+			DeploymentID: item.DeploymentID,
+			Namespace:    item.Namespace,
+			Status:       item.Status,
+			Message:      item.Message,
+		})
+	}
+
+	return &types.BulkDeploymentOperation{
+		ID:        o.ID,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+		Action:    o.Action,
+		Status:    o.Status,
+		Items:     items,
+	}
+}
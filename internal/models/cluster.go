@@ -60,6 +60,10 @@ type Cluster struct {
 
 	PreviewEnvsEnabled bool
 
+	// Whether the cluster is connected through the reverse tunnel of its tunnel agent, instead
+	// of through its server endpoint
+	TunnelEnabled bool
+
 	AWSClusterID string
 
 	// ------------------------------------------------------------------
@@ -110,6 +114,7 @@ func (c *Cluster) ToClusterType() *types.Cluster {
 		AWSIntegrationID:        c.AWSIntegrationID,
 		AWSClusterID:            c.AWSClusterID,
 		PreviewEnvsEnabled:      c.PreviewEnvsEnabled,
+		TunnelEnabled:           c.TunnelEnabled,
 	}
 }
 
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ClusterIncident is an incident detected from the pod states and events of a cluster
+type ClusterIncident struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index:idx_cluster_incidents_cluster"`
+	ClusterID uint `gorm:"index:idx_cluster_incidents_cluster"`
+
+	// IncidentKey uniquely identifies an open incident in a cluster, so that the same failure
+	// is not reported twice
+	IncidentKey string `gorm:"index"`
+
+	Reason  string
+	Message string
+
+	Namespace          string
+	ReleaseName        string
+	InvolvedObjectKind string
+	InvolvedObjectName string
+
+	// comma-separated list of affected pods
+	Pods string
+
+	StartedAt  time.Time
+	LastSeenAt time.Time
+	ResolvedAt *time.Time
+}
+
+func (c *ClusterIncident) ToClusterIncidentType() *types.ClusterIncident {
+	status := types.IncidentStatusActive
+
+	if c.ResolvedAt != nil {
+		status = types.IncidentStatusResolved
+	}
+
+	pods := make([]string, 0)
+
+	if c.Pods != "" {
+		pods = strings.Split(c.Pods, ",")
+	}
+
+	return &types.ClusterIncident{
+		ID:                 c.ID,
+		ProjectID:          c.ProjectID,
+		ClusterID:          c.ClusterID,
+		Reason:             types.ClusterIncidentReason(c.Reason),
+		Status:             status,
+		Message:            c.Message,
+		Namespace:          c.Namespace,
+		ReleaseName:        c.ReleaseName,
+		InvolvedObjectKind: c.InvolvedObjectKind,
+		InvolvedObjectName: c.InvolvedObjectName,
+		Pods:               pods,
+		StartedAt:          c.StartedAt,
+		LastSeenAt:         c.LastSeenAt,
+		ResolvedAt:         c.ResolvedAt,
+	}
+}
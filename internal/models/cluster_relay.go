@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ClusterRelayReportTimeout is how long the last report of a relay is used for. Clusters whose
+// relay hasn't reported within the timeout are polled through the Kubernetes API again.
+const ClusterRelayReportTimeout = 3 * time.Minute
+
+// ClusterRelay is the in-cluster agent of a cluster, which pushes the incidents, events and
+// metrics of the cluster to Porter with the token of the relay
+type ClusterRelay struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint `gorm:"unique"`
+
+	// the bcrypt hash of the token of the agent
+	TokenHash string
+
+	AgentVersion string
+	LastReportAt *time.Time
+
+	// the JSON-encoded incidents and pod metrics of the last report
+	Incidents  []byte
+	PodMetrics []byte
+}
+
+// IsActive returns true if the relay reported within ClusterRelayReportTimeout
+func (c *ClusterRelay) IsActive(now time.Time) bool {
+	return c.LastReportAt != nil && now.Sub(*c.LastReportAt) <= ClusterRelayReportTimeout
+}
+
+func (c *ClusterRelay) GetIncidents() ([]*types.RelayIncident, error) {
+	res := make([]*types.RelayIncident, 0)
+
+	if len(c.Incidents) == 0 {
+		return res, nil
+	}
+
+	if err := json.Unmarshal(c.Incidents, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *ClusterRelay) GetPodMetrics() ([]*types.RelayPodMetrics, error) {
+	res := make([]*types.RelayPodMetrics, 0)
+
+	if len(c.PodMetrics) == 0 {
+		return res, nil
+	}
+
+	if err := json.Unmarshal(c.PodMetrics, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *ClusterRelay) ToClusterRelayType() *types.ClusterRelay {
+	return &types.ClusterRelay{
+		ID:           c.ID,
+		CreatedAt:    c.CreatedAt,
+		ClusterID:    c.ClusterID,
+		AgentVersion: c.AgentVersion,
+		LastReportAt: c.LastReportAt,
+		Active:       c.IsActive(time.Now()),
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ClusterTunnel is the reverse tunnel of a cluster without inbound access. The tunnel agent of
+// the cluster connects to Porter with the token of the tunnel, and the traffic to the
+// Kubernetes API of the cluster is routed through the agent's connection.
+type ClusterTunnel struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint `gorm:"unique"`
+
+	// the bcrypt hash of the token of the tunnel agent
+	TokenHash string
+
+	LastConnectedAt    *time.Time
+	LastDisconnectedAt *time.Time
+}
+
+func (c *ClusterTunnel) ToClusterTunnelType(connected bool) *types.ClusterTunnel {
+	return &types.ClusterTunnel{
+		ID:                 c.ID,
+		CreatedAt:          c.CreatedAt,
+		ClusterID:          c.ClusterID,
+		Connected:          connected,
+		LastConnectedAt:    c.LastConnectedAt,
+		LastDisconnectedAt: c.LastDisconnectedAt,
+	}
+}
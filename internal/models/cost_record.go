@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CostRecord is the cost of the resources which the pods of a release requested during an
+// hour. Pods which don't belong to a release are recorded under their namespace, with an empty
+// release name.
+type CostRecord struct {
+	gorm.Model
+
+	ProjectID   uint      `gorm:"index"`
+	ClusterID   uint      `gorm:"uniqueIndex:idx_cost_records_cluster_release_hour"`
+	Namespace   string    `gorm:"uniqueIndex:idx_cost_records_cluster_release_hour"`
+	ReleaseName string    `gorm:"uniqueIndex:idx_cost_records_cluster_release_hour"`
+	Hour        time.Time `gorm:"uniqueIndex:idx_cost_records_cluster_release_hour"`
+
+	// The requested resources, at the end of the hour
+	CPUCores  float64
+	MemoryGiB float64
+
+	// The cost of the requested resources for the hour, in US dollars
+	Cost float64
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// CustomDomainDNSRecord is a DNS record of a custom domain of a release, which was created
+// through a DNS provider integration and points the domain to the cluster's ingress controller
+type CustomDomainDNSRecord struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint `gorm:"index"`
+
+	Namespace   string
+	ReleaseName string
+	Host        string
+
+	DNSProviderIntegrationID uint
+
+	RecordType string
+	Value      string
+
+	Status     types.CustomDomainDNSStatus
+	Message    string
+	VerifiedAt *time.Time
+}
+
+func (r *CustomDomainDNSRecord) ToCustomDomainDNSType() *types.CustomDomainDNS {
+	return &types.CustomDomainDNS{
+		DNSProviderIntegrationID: r.DNSProviderIntegrationID,
+		RecordType:               r.RecordType,
+		Value:                    r.Value,
+		Status:                   r.Status,
+		Message:                  r.Message,
+		VerifiedAt:               r.VerifiedAt,
+	}
+}
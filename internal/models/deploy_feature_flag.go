@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// DeployFeatureFlag is a feature flag which was changed when an upgrade of a release
+// succeeded. The previous state of the flag is stored so that the change can be reverted when
+// the release is rolled back to a revision before the upgrade.
+type DeployFeatureFlag struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint   `gorm:"index:idx_deploy_feature_flags_release"`
+	Namespace string `gorm:"index:idx_deploy_feature_flags_release"`
+	Name      string `gorm:"index:idx_deploy_feature_flags_release"`
+
+	// the revision of the release which the upgrade created
+	Revision int
+
+	IntegrationID uint
+	Environment   string
+	FlagKey       string
+	Enabled       bool
+
+	PreviousEnabled bool
+
+	Status types.DeployFeatureFlagStatus
+
+	AppliedAt  *time.Time
+	RevertedAt *time.Time
+
+	Error string
+}
+
+func (f *DeployFeatureFlag) ToDeployFeatureFlagType() *types.DeployFeatureFlag {
+	res := &types.DeployFeatureFlag{
+		FeatureFlagChange: types.FeatureFlagChange{
+			IntegrationID: f.IntegrationID,
+			Environment:   f.Environment,
+			Key:           f.FlagKey,
+			Enabled:       f.Enabled,
+		},
+		Status:     f.Status,
+		AppliedAt:  f.AppliedAt,
+		RevertedAt: f.RevertedAt,
+		Error:      f.Error,
+	}
+
+	// the previous state is only known if the flag was read before it was changed
+	if f.AppliedAt != nil {
+		prev := f.PreviousEnabled
+		res.PreviousEnabled = &prev
+	}
+
+	return res
+}
+
+// FormatFeatureFlagChanges returns the json which is stored for the feature flag changes of
+// an upgrade, or nil if the upgrade changes no flags
+func FormatFeatureFlagChanges(changes []*types.FeatureFlagChange) ([]byte, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(changes)
+}
+
+// ParseFeatureFlagChanges returns the feature flag changes stored by FormatFeatureFlagChanges
+func ParseFeatureFlagChanges(data []byte) []*types.FeatureFlagChange {
+	changes := make([]*types.FeatureFlagChange, 0)
+
+	if len(data) > 0 {
+		json.Unmarshal(data, &changes)
+	}
+
+	return changes
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EmailPreference stores the emails which a user has opted in to. Users without a stored
+// preference use the default preference.
+type EmailPreference struct {
+	gorm.Model
+
+	UserID uint `gorm:"uniqueIndex"`
+
+	ImmediateFailures bool
+	DailyDigest       bool
+
+	// LastDigestAt is the time at which the last daily digest was sent to the user
+	LastDigestAt *time.Time
+}
+
+// DefaultEmailPreference returns the preference of a user who has not changed their
+// preferences, which only sends failure alerts
+func DefaultEmailPreference(userID uint) *EmailPreference {
+	return &EmailPreference{
+		UserID:            userID,
+		ImmediateFailures: true,
+		DailyDigest:       false,
+	}
+}
+
+func (e *EmailPreference) ToEmailPreferencesType() *types.EmailPreferences {
+	return &types.EmailPreferences{
+		ImmediateFailures: e.ImmediateFailures,
+		DailyDigest:       e.DailyDigest,
+	}
+}
+
+// DeploymentRecord records a deploy of a release, so that deploys can be summarized in the
+// daily email digests. Records are purged once they are older than a digest.
+type DeploymentRecord struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+
+	Name      string
+	Namespace string
+
+	// Status is either "succeeded" or "failed"
+	Status  string
+	Version int
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupRotationPolicy replaces the value of a secret variable of an env group with a newly
+// generated value on a schedule, and redeploys the applications which are synced to the env group
+type EnvGroupRotationPolicy struct {
+	gorm.Model
+
+	ProjectID uint   `gorm:"index"`
+	ClusterID uint   `gorm:"uniqueIndex:idx_env_group_rotation_policy"`
+	Namespace string `gorm:"uniqueIndex:idx_env_group_rotation_policy"`
+	Name      string `gorm:"uniqueIndex:idx_env_group_rotation_policy"`
+	Key       string `gorm:"uniqueIndex:idx_env_group_rotation_policy"`
+
+	Generator    types.RotationGeneratorKind
+	IntervalDays uint
+
+	// The length and characters of values generated by the random generator
+	Length  uint
+	Charset types.RotationCharset
+
+	// The RDS infra whose master password is rotated by the rds_password generator
+	InfraID uint
+
+	LastRotatedAt  *time.Time
+	NextRotationAt time.Time
+
+	// Error is the error of the last rotation, if it failed
+	Error string
+}
+
+func (p *EnvGroupRotationPolicy) ToEnvGroupRotationPolicyType() *types.EnvGroupRotationPolicy {
+	return &types.EnvGroupRotationPolicy{
+		Name:           p.Name,
+		Namespace:      p.Namespace,
+		Key:            p.Key,
+		Generator:      p.Generator,
+		IntervalDays:   p.IntervalDays,
+		Length:         p.Length,
+		Charset:        p.Charset,
+		InfraID:        p.InfraID,
+		LastRotatedAt:  p.LastRotatedAt,
+		NextRotationAt: p.NextRotationAt,
+		Error:          p.Error,
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSource links an env group to a secret in an external secret store. The values of the
+// secret are synced into the env group, which can't be updated through the API while it's linked.
+type EnvGroupSource struct {
+	gorm.Model
+
+	ProjectID uint   `gorm:"index"`
+	ClusterID uint   `gorm:"uniqueIndex:idx_env_group_source"`
+	Namespace string `gorm:"uniqueIndex:idx_env_group_source"`
+	Name      string `gorm:"uniqueIndex:idx_env_group_source"`
+
+	Kind types.EnvGroupSourceKind
+
+	// The AWS integration used to read secrets from AWS Secrets Manager
+	AWSIntegrationID uint
+
+	// The Doppler integration used to read configs from Doppler
+	DopplerIntegrationID uint
+
+	// The name or ARN of the secret, or the Doppler config as project/config
+	SecretID string
+
+	// WebhookToken authenticates the webhook which triggers a sync when the secret changes. It's
+	// only set for sources whose secret store can call webhooks, such as Doppler.
+	WebhookToken string `gorm:"index"`
+
+	// The version of the secret which was last synced, and the version of the env group it was
+	// synced to
+	SecretVersionID string
+	EnvGroupVersion uint
+
+	LastSyncedAt    *time.Time
+	LastAttemptedAt *time.Time
+
+	// DriftDetectedAt is the last time the env group was found to differ from the secret without
+	// the secret having changed, which means that the env group was changed outside of Porter
+	DriftDetectedAt *time.Time
+
+	Error string
+}
+
+func (s *EnvGroupSource) ToEnvGroupSourceType() *types.EnvGroupSource {
+	return &types.EnvGroupSource{
+		Name:                 s.Name,
+		Namespace:            s.Namespace,
+		Kind:                 s.Kind,
+		AWSIntegrationID:     s.AWSIntegrationID,
+		DopplerIntegrationID: s.DopplerIntegrationID,
+		SecretID:             s.SecretID,
+		EnvGroupVersion:      s.EnvGroupVersion,
+		LastSyncedAt:         s.LastSyncedAt,
+		LastAttemptedAt:      s.LastAttemptedAt,
+		DriftDetectedAt:      s.DriftDetectedAt,
+		Error:                s.Error,
+	}
+}
@@ -27,6 +27,10 @@ type Environment struct {
 	NamespaceAnnotations []byte
 	GitDeployBranches    string
 
+	// LinearIntegrationID is the Linear integration which deployments are attached to, or 0 if
+	// deployments are not attached to Linear issues
+	LinearIntegrationID uint
+
 	// WebhookID uniquely identifies the environment when other fields (project, cluster)
 	// aren't present
 	WebhookID string `gorm:"unique"`
@@ -63,6 +67,7 @@ func (e *Environment) ToEnvironmentType() *types.Environment {
 
 		NewCommentsDisabled: e.NewCommentsDisabled,
 		NamespaceLabels:     make(map[string]string),
+		LinearIntegrationID: e.LinearIntegrationID,
 
 		Name: e.Name,
 		Mode: e.Mode,
@@ -116,6 +121,18 @@ type Deployment struct {
 	CommitSHA      string
 	PRBranchFrom   string
 	PRBranchInto   string
+
+	// comma-separated list of the keys of the Jira issues which the deployment is linked to
+	JiraIssueKeys string
+}
+
+// GetJiraIssueKeys returns the keys of the Jira issues which the deployment is linked to
+func (d *Deployment) GetJiraIssueKeys() []string {
+	if d.JiraIssueKeys == "" {
+		return []string{}
+	}
+
+	return strings.Split(d.JiraIssueKeys, ",")
 }
 
 func (d *Deployment) ToDeploymentType() *types.Deployment {
@@ -129,6 +146,14 @@ func (d *Deployment) ToDeploymentType() *types.Deployment {
 		PRBranchInto: d.PRBranchInto,
 	}
 
+	jiraIssues := make([]*types.JiraIssue, 0)
+
+	for _, key := range d.GetJiraIssueKeys() {
+		jiraIssues = append(jiraIssues, &types.JiraIssue{
+			Key: key,
+		})
+	}
+
 	return &types.Deployment{
 		CreatedAt:      d.CreatedAt,
 		UpdatedAt:      d.UpdatedAt,
@@ -139,6 +164,7 @@ func (d *Deployment) ToDeploymentType() *types.Deployment {
 		Subdomain:      d.Subdomain,
 		PullRequestID:  d.PullRequestID,
 		GitHubMetadata: ghMetadata,
+		JiraIssues:     jiraIssues,
 	}
 }
 
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ExternalSecretStore is the secret store of the External Secrets Operator which the env groups
+// of a project sync their secret variables from
+type ExternalSecretStore struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"uniqueIndex"`
+
+	Kind            types.ExternalSecretStoreKind
+	Name            string
+	RefreshInterval string
+}
+
+func (s *ExternalSecretStore) ToExternalSecretStoreType() *types.ExternalSecretStore {
+	return &types.ExternalSecretStore{
+		ProjectID:       s.ProjectID,
+		Kind:            s.Kind,
+		Name:            s.Name,
+		RefreshInterval: s.RefreshInterval,
+		UpdatedAt:       &s.UpdatedAt,
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// FreezeWindow blocks upgrades and preview deployments in a cluster, or in some namespaces of
+// a cluster, either on a recurring cron schedule or during a date range
+type FreezeWindow struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+
+	// comma-separated list of the namespaces which the window applies to, or empty if the
+	// window applies to every namespace of the cluster
+	Namespaces string
+
+	Name   string
+	Reason string
+
+	// the cron expression of the starts of a recurring window, which lasts DurationMinutes
+	// after each start, evaluated in Timezone
+	Schedule        string
+	DurationMinutes uint
+	Timezone        string
+
+	// the date range of a one-off window
+	StartsAt *time.Time
+	EndsAt   *time.Time
+
+	// comma-separated list of the ids of the users, in addition to the project admins, who can
+	// deploy during the window in an emergency
+	OverrideUserIDs string
+}
+
+// Applies returns true if the window applies to the namespace of the cluster
+func (f *FreezeWindow) Applies(clusterID uint, namespace string) bool {
+	if f.ClusterID != clusterID {
+		return false
+	}
+
+	if f.Namespaces == "" {
+		return true
+	}
+
+	for _, ns := range strings.Split(f.Namespaces, ",") {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanOverride returns true if the user is allowed to override the window. Project admins can
+// override every window.
+func (f *FreezeWindow) CanOverride(userID uint) bool {
+	for _, id := range parseIDs(f.OverrideUserIDs) {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *FreezeWindow) ToFreezeWindowType() *types.FreezeWindow {
+	namespaces := []string{}
+
+	if f.Namespaces != "" {
+		namespaces = strings.Split(f.Namespaces, ",")
+	}
+
+	return &types.FreezeWindow{
+		ID:              f.ID,
+		CreatedAt:       f.CreatedAt,
+		ProjectID:       f.ProjectID,
+		ClusterID:       f.ClusterID,
+		Namespaces:      namespaces,
+		Name:            f.Name,
+		Reason:          f.Reason,
+		Schedule:        f.Schedule,
+		DurationMinutes: f.DurationMinutes,
+		Timezone:        f.Timezone,
+		StartsAt:        f.StartsAt,
+		EndsAt:          f.EndsAt,
+		OverrideUserIDs: parseIDs(f.OverrideUserIDs),
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// GitOpsExport configures a stack to be exported to a git repository: instead of being
+// deployed to its cluster, the manifests of each revision of the stack are committed to the
+// repository, where they are applied by a GitOps controller such as Argo CD or Flux
+type GitOpsExport struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint
+	StackID   uint `gorm:"unique"`
+
+	GitInstallationID uint
+	GitRepoOwner      string
+	GitRepoName       string
+	GitBranch         string
+	Path              string
+
+	// if set, each export opens a pull request against GitBranch
+	PullRequest bool
+
+	// the result of the latest export
+	LastExportedAt     *time.Time
+	LastCommitSHA      string
+	LastPullRequestURL string
+	LastError          string
+}
+
+func (g *GitOpsExport) ToGitOpsExportType() *types.GitOpsExport {
+	return &types.GitOpsExport{
+		ID:                 g.ID,
+		CreatedAt:          g.CreatedAt,
+		GitInstallationID:  g.GitInstallationID,
+		GitRepoOwner:       g.GitRepoOwner,
+		GitRepoName:        g.GitRepoName,
+		GitBranch:          g.GitBranch,
+		Path:               g.Path,
+		PullRequest:        g.PullRequest,
+		LastExportedAt:     g.LastExportedAt,
+		LastCommitSHA:      g.LastCommitSHA,
+		LastPullRequestURL: g.LastPullRequestURL,
+		LastError:          g.LastError,
+	}
+}
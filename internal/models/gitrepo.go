@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -52,6 +54,13 @@ type GitActionConfig struct {
 	// The build context
 	FolderPath string `json:"folder_path"`
 
+	// The stage of a multi-stage Dockerfile to build
+	DockerTarget string `json:"docker_target"`
+
+	// A comma-separated list of env groups whose variables are passed to Dockerfile
+	// builds as build args
+	BuildArgsEnvGroups string `json:"build_args_env_groups"`
+
 	// Determines on how authentication is performed on this action
 	IsInstallation bool `json:"is_installation"`
 
@@ -68,5 +77,17 @@ func (r *GitActionConfig) ToGitActionConfigType() *types.GitActionConfig {
 		GitlabIntegrationID: r.GitlabIntegrationID,
 		DockerfilePath:      r.DockerfilePath,
 		FolderPath:          r.FolderPath,
+		DockerTarget:        r.DockerTarget,
+		BuildArgsEnvGroups:  r.GetBuildArgsEnvGroups(),
 	}
 }
+
+// GetBuildArgsEnvGroups returns the env groups whose variables are passed to Dockerfile
+// builds as build args
+func (r *GitActionConfig) GetBuildArgsEnvGroups() []string {
+	if r.BuildArgsEnvGroups == "" {
+		return nil
+	}
+
+	return strings.Split(r.BuildArgsEnvGroups, ",")
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ImageSignaturePolicy holds the cosign public key which images deployed in a project must
+// be signed with
+type ImageSignaturePolicy struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"uniqueIndex"`
+
+	Enabled bool
+
+	// The PEM-encoded public key used to verify image signatures
+	PublicKey string
+}
+
+func (p *ImageSignaturePolicy) ToImageSignaturePolicyType() *types.ImageSignaturePolicy {
+	return &types.ImageSignaturePolicy{
+		ProjectID: p.ProjectID,
+		Enabled:   p.Enabled,
+		PublicKey: p.PublicKey,
+	}
+}
@@ -0,0 +1,55 @@
+package integrations
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DatadogIntegration sends deployment events of a project to a Datadog organization
+type DatadogIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Datadog organization
+	Name string
+
+	// The Datadog site of the organization, such as datadoghq.com or datadoghq.eu
+	Site string
+
+	// comma-separated list of tags added to every event, such as env:production
+	Tags string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The API key which events are sent with
+	APIKey []byte
+}
+
+// GetTags returns the tags added to every event
+func (d *DatadogIntegration) GetTags() []string {
+	if d.Tags == "" {
+		return []string{}
+	}
+
+	return strings.Split(d.Tags, ",")
+}
+
+func (d *DatadogIntegration) ToDatadogIntegrationType() *types.DatadogIntegration {
+	return &types.DatadogIntegration{
+		ID:        d.ID,
+		ProjectID: d.ProjectID,
+		Name:      d.Name,
+		Site:      d.Site,
+		Tags:      d.GetTags(),
+	}
+}
@@ -0,0 +1,40 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DiscordIntegration is a webhook notifier to a specific channel in a Discord server
+type DiscordIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Discord channel
+	Name string
+
+	// The preview environment that this integration belongs to, or 0 for the whole project
+	EnvironmentID uint
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The webhook to call
+	Webhook []byte
+}
+
+func (d *DiscordIntegration) ToDiscordIntegrationType() *types.DiscordIntegration {
+	return &types.DiscordIntegration{
+		ID:            d.ID,
+		ProjectID:     d.ProjectID,
+		Name:          d.Name,
+		EnvironmentID: d.EnvironmentID,
+	}
+}
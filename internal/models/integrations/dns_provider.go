@@ -0,0 +1,42 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DNSProviderIntegration manages the DNS records of custom domains in Route53, with the
+// credentials of an AWS integration, or in Cloudflare, with an API token
+type DNSProviderIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	Name     string
+	Provider types.DNSProvider
+
+	// The AWS integration whose credentials manage Route53 records
+	AWSIntegrationID uint
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The Cloudflare API token which records are managed with
+	CloudflareAPIToken []byte
+}
+
+func (d *DNSProviderIntegration) ToDNSProviderIntegrationType() *types.DNSProviderIntegration {
+	return &types.DNSProviderIntegration{
+		ID:               d.ID,
+		ProjectID:        d.ProjectID,
+		Name:             d.Name,
+		Provider:         d.Provider,
+		AWSIntegrationID: d.AWSIntegrationID,
+	}
+}
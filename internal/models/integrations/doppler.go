@@ -0,0 +1,37 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DopplerIntegration reads the secrets of Doppler configs, which are synced into env groups
+// by env group sources
+type DopplerIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Doppler workplace
+	Name string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The API token which secrets are read with
+	APIToken []byte
+}
+
+func (l *DopplerIntegration) ToDopplerIntegrationType() *types.DopplerIntegration {
+	return &types.DopplerIntegration{
+		ID:        l.ID,
+		ProjectID: l.ProjectID,
+		Name:      l.Name,
+	}
+}
@@ -0,0 +1,47 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// FeatureFlagIntegration changes the flags of a LaunchDarkly or Unleash project when the
+// upgrades of releases succeed
+type FeatureFlagIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	Name     string
+	Provider types.FeatureFlagProvider
+
+	// The url of the provider's API
+	URL string
+
+	// The key of the LaunchDarkly project, or the id of the Unleash project, which contains
+	// the flags
+	FlagProject string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The API token which flags are changed with
+	APIToken []byte
+}
+
+func (f *FeatureFlagIntegration) ToFeatureFlagIntegrationType() *types.FeatureFlagIntegration {
+	return &types.FeatureFlagIntegration{
+		ID:          f.ID,
+		ProjectID:   f.ProjectID,
+		Name:        f.Name,
+		Provider:    f.Provider,
+		URL:         f.URL,
+		FlagProject: f.FlagProject,
+	}
+}
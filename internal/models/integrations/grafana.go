@@ -0,0 +1,50 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// GrafanaIntegration provisions dashboards for the releases of a project in a Grafana instance
+type GrafanaIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Grafana instance
+	Name string
+
+	// The base URL of the Grafana instance, such as https://acme.grafana.net
+	URL string
+
+	// The uid of the folder which dashboards are created in. Dashboards are created in the
+	// General folder if empty.
+	FolderUID string
+
+	// The uid of the Prometheus data source which dashboards query. Dashboards query the
+	// default data source if empty.
+	DatasourceUID string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The service account token or API key which dashboards are created with
+	APIKey []byte
+}
+
+func (g *GrafanaIntegration) ToGrafanaIntegrationType() *types.GrafanaIntegration {
+	return &types.GrafanaIntegration{
+		ID:            g.ID,
+		ProjectID:     g.ProjectID,
+		Name:          g.Name,
+		URL:           g.URL,
+		FolderUID:     g.FolderUID,
+		DatasourceUID: g.DatasourceUID,
+	}
+}
@@ -0,0 +1,61 @@
+package integrations
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// JiraIntegration comments on the Jira issues referenced by the pull requests and commits of
+// preview deployments
+type JiraIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Jira site
+	Name string
+
+	// The URL of the Jira site, such as https://acme.atlassian.net
+	BaseURL string
+
+	// The email of the Jira user which comments are posted as
+	Email string
+
+	// comma-separated list of the keys of the Jira projects which issues are linked in. If
+	// empty, issues of any project are linked.
+	JiraProjectKeys string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The API token of the Jira user
+	APIToken []byte
+}
+
+// GetJiraProjectKeys returns the keys of the Jira projects which issues are linked in
+func (j *JiraIntegration) GetJiraProjectKeys() []string {
+	if j.JiraProjectKeys == "" {
+		return []string{}
+	}
+
+	return strings.Split(j.JiraProjectKeys, ",")
+}
+
+func (j *JiraIntegration) ToJiraIntegrationType() *types.JiraIntegration {
+	return &types.JiraIntegration{
+		ID:              j.ID,
+		ProjectID:       j.ProjectID,
+		Name:            j.Name,
+		BaseURL:         j.BaseURL,
+		Email:           j.Email,
+		JiraProjectKeys: j.GetJiraProjectKeys(),
+	}
+}
@@ -0,0 +1,37 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// LinearIntegration attaches the preview URLs and statuses of preview deployments to the
+// Linear issues referenced by their branches. Integrations are enabled per environment.
+type LinearIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Linear workspace
+	Name string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The API key which attachments are created with
+	APIKey []byte
+}
+
+func (l *LinearIntegration) ToLinearIntegrationType() *types.LinearIntegration {
+	return &types.LinearIntegration{
+		ID:        l.ID,
+		ProjectID: l.ProjectID,
+		Name:      l.Name,
+	}
+}
@@ -0,0 +1,50 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// PagerDutyIntegration is an Events API v2 integration on a PagerDuty service, which is mapped
+// to the releases of a cluster and namespace
+type PagerDutyIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the PagerDuty service
+	Name string
+
+	// The cluster and namespace of the releases which are mapped to the service. A zero
+	// value matches any cluster or namespace.
+	ClusterID uint
+	Namespace string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The integration key which events are sent with
+	RoutingKey []byte
+}
+
+// Matches returns true if releases in the given cluster and namespace are mapped to the
+// integration's service
+func (p *PagerDutyIntegration) Matches(clusterID uint, namespace string) bool {
+	return (p.ClusterID == 0 || p.ClusterID == clusterID) && (p.Namespace == "" || p.Namespace == namespace)
+}
+
+func (p *PagerDutyIntegration) ToPagerDutyIntegrationType() *types.PagerDutyIntegration {
+	return &types.PagerDutyIntegration{
+		ID:        p.ID,
+		ProjectID: p.ProjectID,
+		Name:      p.Name,
+		ClusterID: p.ClusterID,
+		Namespace: p.Namespace,
+	}
+}
@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// SentryIntegration creates Sentry releases and deploys whenever a release of a project is
+// upgraded
+type SentryIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Sentry organization
+	Name string
+
+	// The URL of the Sentry instance, which is https://sentry.io unless Sentry is self-hosted
+	BaseURL string
+
+	// The slug of the Sentry organization
+	OrganizationSlug string
+
+	// comma-separated list of slugs of the Sentry projects which releases are created in
+	SentryProjects string
+
+	// The environment which deploys are marked in. If empty, deploys are marked in an
+	// environment named after the cluster of the release.
+	Environment string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The auth token which releases are created with
+	AuthToken []byte
+}
+
+// GetSentryProjects returns the slugs of the Sentry projects which releases are created in
+func (s *SentryIntegration) GetSentryProjects() []string {
+	if s.SentryProjects == "" {
+		return []string{}
+	}
+
+	return strings.Split(s.SentryProjects, ",")
+}
+
+func (s *SentryIntegration) ToSentryIntegrationType() *types.SentryIntegration {
+	return &types.SentryIntegration{
+		ID:               s.ID,
+		ProjectID:        s.ProjectID,
+		Name:             s.Name,
+		BaseURL:          s.BaseURL,
+		OrganizationSlug: s.OrganizationSlug,
+		SentryProjects:   s.GetSentryProjects(),
+		Environment:      s.Environment,
+	}
+}
@@ -57,3 +57,38 @@ func (s *SlackIntegration) ToSlackIntegraionType() *types.SlackIntegration {
 		ConfigurationURL: s.ConfigurationURL,
 	}
 }
+
+// SlackRoutingRule routes events of a project to a Slack integration
+type SlackRoutingRule struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	SlackIntegrationID uint
+
+	Event types.SlackEvent
+
+	// The cluster which the rule is restricted to, or 0 for every cluster
+	ClusterID uint
+
+	// The namespace which the rule is restricted to, or empty for every namespace
+	Namespace string
+}
+
+func (s *SlackRoutingRule) ToSlackRoutingRuleType() *types.SlackRoutingRule {
+	return &types.SlackRoutingRule{
+		ID:                 s.ID,
+		ProjectID:          s.ProjectID,
+		SlackIntegrationID: s.SlackIntegrationID,
+		Event:              s.Event,
+		ClusterID:          s.ClusterID,
+		Namespace:          s.Namespace,
+	}
+}
+
+// Matches returns true if an event in the given cluster and namespace matches the rule
+func (s *SlackRoutingRule) Matches(event types.SlackEvent, clusterID uint, namespace string) bool {
+	return s.Event == event &&
+		(s.ClusterID == 0 || s.ClusterID == clusterID) &&
+		(s.Namespace == "" || s.Namespace == namespace)
+}
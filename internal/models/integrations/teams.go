@@ -0,0 +1,36 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// TeamsIntegration is an incoming webhook notifier to a specific channel in Microsoft Teams
+type TeamsIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint
+
+	// The project that this integration belongs to
+	ProjectID uint `gorm:"index"`
+
+	// The name of the integration, such as the name of the Teams channel
+	Name string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The webhook to call
+	Webhook []byte
+}
+
+func (t *TeamsIntegration) ToTeamsIntegrationType() *types.TeamsIntegration {
+	return &types.TeamsIntegration{
+		ID:        t.ID,
+		ProjectID: t.ProjectID,
+		Name:      t.Name,
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LeaderLease is a named lease which at most one server replica holds at a time, so that
+// background workers which must not run concurrently only run on the replica which holds it
+type LeaderLease struct {
+	gorm.Model
+
+	Name string `gorm:"unique"`
+
+	// Holder is the identity of the replica which holds the lease
+	Holder string
+
+	// ExpiresAt is the time at which the lease can be acquired by another replica, unless the
+	// holder renews it
+	ExpiresAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// LogAlertRule opens an incident for a release when the logs of its pods match a pattern at
+// least a threshold number of times within a window
+type LogAlertRule struct {
+	gorm.Model
+
+	ProjectID   uint
+	ClusterID   uint   `gorm:"index:idx_log_alert_rules_release"`
+	Namespace   string `gorm:"index:idx_log_alert_rules_release"`
+	ReleaseName string `gorm:"index:idx_log_alert_rules_release"`
+
+	Name          string
+	Pattern       string
+	Threshold     uint
+	WindowSeconds uint
+}
+
+func (r *LogAlertRule) ToLogAlertRuleType() *types.LogAlertRule {
+	return &types.LogAlertRule{
+		ID:            r.ID,
+		CreatedAt:     r.CreatedAt,
+		ClusterID:     r.ClusterID,
+		Namespace:     r.Namespace,
+		ReleaseName:   r.ReleaseName,
+		Name:          r.Name,
+		Pattern:       r.Pattern,
+		Threshold:     r.Threshold,
+		WindowSeconds: r.WindowSeconds,
+	}
+}
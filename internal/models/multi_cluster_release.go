@@ -0,0 +1,110 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// MultiClusterRelease is a release which is deployed to the same name in several clusters,
+// and whose upgrades fan out to every cluster
+type MultiClusterRelease struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	Name              string
+	Strategy          types.MultiClusterStrategy
+	RollbackOnFailure bool
+
+	Targets []MultiClusterReleaseTarget
+}
+
+// MultiClusterReleaseTarget is a cluster and namespace of a multi-cluster release
+type MultiClusterReleaseTarget struct {
+	gorm.Model
+
+	MultiClusterReleaseID uint `gorm:"index"`
+
+	ClusterID uint
+	Namespace string
+
+	// the position of the target, which is the order of the sequential strategy
+	Position uint
+}
+
+// MultiClusterRollout is an upgrade or a rollback of every target of a multi-cluster release,
+// which runs in the background
+type MultiClusterRollout struct {
+	gorm.Model
+
+	MultiClusterReleaseID uint `gorm:"index"`
+
+	Kind     types.MultiClusterRolloutKind
+	Strategy types.MultiClusterStrategy
+	Status   types.MultiClusterRolloutStatus
+
+	Targets []MultiClusterRolloutTarget
+}
+
+// MultiClusterRolloutTarget is the status of a rollout in a single target
+type MultiClusterRolloutTarget struct {
+	gorm.Model
+
+	MultiClusterRolloutID uint `gorm:"index"`
+
+	ClusterID uint
+	Namespace string
+	Position  uint
+
+	Status           types.MultiClusterRolloutTargetStatus
+	PreviousRevision int
+	Revision         int
+	Message          string
+}
+
+func (m *MultiClusterRelease) ToMultiClusterReleaseType() *types.MultiClusterRelease {
+	targets := make([]*types.MultiClusterTarget, 0, len(m.Targets))
+
+	for _, target := range m.Targets {
+		targets = append(targets, &types.MultiClusterTarget{
+			ClusterID: target.ClusterID,
+			Namespace: target.Namespace,
+		})
+	}
+
+	return &types.MultiClusterRelease{
+		ID:                m.ID,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+		Name:              m.Name,
+		Strategy:          m.Strategy,
+		Targets:           targets,
+		RollbackOnFailure: m.RollbackOnFailure,
+	}
+}
+
+func (r *MultiClusterRollout) ToMultiClusterRolloutType() *types.MultiClusterRollout {
+	targets := make([]*types.MultiClusterRolloutTarget, 0, len(r.Targets))
+
+	for _, target := range r.Targets {
+		targets = append(targets, &types.MultiClusterRolloutTarget{
+			ClusterID:        target.ClusterID,
+			Namespace:        target.Namespace,
+			Status:           target.Status,
+			PreviousRevision: target.PreviousRevision,
+			Revision:         target.Revision,
+			Message:          target.Message,
+		})
+	}
+
+	return &types.MultiClusterRollout{
+		ID:                    r.ID,
+		CreatedAt:             r.CreatedAt,
+		UpdatedAt:             r.UpdatedAt,
+		MultiClusterReleaseID: r.MultiClusterReleaseID,
+		Kind:                  r.Kind,
+		Strategy:              r.Strategy,
+		Status:                r.Status,
+		Targets:               targets,
+	}
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// NotificationPreference configures which events are sent through a notification channel for
+// a project, or for a user of a project if the user id is set. Channels without a stored
+// preference send every event.
+type NotificationPreference struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	// UserID is 0 for the preferences of a project
+	UserID uint
+
+	Channel types.NotificationChannel
+
+	// comma-separated list of the events which are sent, or empty if every event is sent
+	Events string
+
+	MinSeverity types.NotificationSeverity
+
+	// the quiet hours are disabled if the start and end are empty
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
+}
+
+// DefaultNotificationPreference returns the preference of a channel which has not been
+// configured, which sends every event
+func DefaultNotificationPreference(projectID, userID uint, channel types.NotificationChannel) *NotificationPreference {
+	return &NotificationPreference{
+		ProjectID:   projectID,
+		UserID:      userID,
+		Channel:     channel,
+		MinSeverity: types.NotificationSeverityInfo,
+	}
+}
+
+// GetEvents returns the events which are sent, or an empty list if every event is sent
+func (n *NotificationPreference) GetEvents() []types.NotificationEvent {
+	res := make([]types.NotificationEvent, 0)
+
+	if n.Events == "" {
+		return res
+	}
+
+	for _, event := range strings.Split(n.Events, ",") {
+		res = append(res, types.NotificationEvent(event))
+	}
+
+	return res
+}
+
+// Allows returns true if an event should be sent through the channel at the given time
+func (n *NotificationPreference) Allows(event types.NotificationEvent, now time.Time) bool {
+	if events := n.GetEvents(); len(events) != 0 {
+		found := false
+
+		for _, e := range events {
+			if e == event {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	severity := types.NotificationEventSeverities[event]
+
+	if getSeverityRank(severity) < getSeverityRank(n.MinSeverity) {
+		return false
+	}
+
+	return severity == types.NotificationSeverityCritical || !n.IsQuiet(now)
+}
+
+// IsQuiet returns true if the given time is within the quiet hours
+func (n *NotificationPreference) IsQuiet(now time.Time) bool {
+	if n.QuietHoursStart == "" || n.QuietHoursEnd == "" {
+		return false
+	}
+
+	loc, err := time.LoadLocation(n.QuietHoursTimezone)
+
+	if err != nil {
+		loc = time.UTC
+	}
+
+	start, err := ParseTimeOfDay(n.QuietHoursStart)
+
+	if err != nil {
+		return false
+	}
+
+	end, err := ParseTimeOfDay(n.QuietHoursEnd)
+
+	if err != nil {
+		return false
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	if start <= end {
+		return minute >= start && minute < end
+	}
+
+	// the quiet hours span midnight
+	return minute >= start || minute < end
+}
+
+func (n *NotificationPreference) ToNotificationPreferenceType() *types.NotificationPreference {
+	res := &types.NotificationPreference{
+		Channel:     n.Channel,
+		Events:      n.GetEvents(),
+		MinSeverity: n.MinSeverity,
+	}
+
+	if res.MinSeverity == "" {
+		res.MinSeverity = types.NotificationSeverityInfo
+	}
+
+	if n.QuietHoursStart != "" && n.QuietHoursEnd != "" {
+		res.QuietHours = &types.QuietHours{
+			Start:    n.QuietHoursStart,
+			End:      n.QuietHoursEnd,
+			Timezone: n.QuietHoursTimezone,
+		}
+	}
+
+	return res
+}
+
+// ParseTimeOfDay parses a time in HH:MM form, and returns the number of minutes since midnight
+func ParseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %s: expected HH:MM", value)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func getSeverityRank(severity types.NotificationSeverity) int {
+	switch severity {
+	case types.NotificationSeverityWarning:
+		return 1
+	case types.NotificationSeverityCritical:
+		return 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// PullThroughCacheRule rewrites images of an upstream registry which are deployed to a
+// cluster to be pulled through a cache, such as an ECR pull through cache rule or a
+// registry mirror
+type PullThroughCacheRule struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint `gorm:"uniqueIndex:idx_pull_through_cache_rule"`
+
+	// The host of the upstream registry, such as docker.io
+	UpstreamRegistry string `gorm:"uniqueIndex:idx_pull_through_cache_rule"`
+
+	// The prefix which replaces the upstream registry host in image references
+	MirrorPrefix string
+}
+
+func (r *PullThroughCacheRule) ToPullThroughCacheRuleType() *types.PullThroughCacheRule {
+	return &types.PullThroughCacheRule{
+		UpstreamRegistry: r.UpstreamRegistry,
+		MirrorPrefix:     r.MirrorPrefix,
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// QueuedDeploy is an upgrade of a release in the release's deploy queue. The values of the
+// upgrade are stored so that the upgrade can run once the upgrades before it have finished.
+type QueuedDeploy struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint   `gorm:"index:idx_queued_deploys_release"`
+	Namespace string `gorm:"index:idx_queued_deploys_release"`
+	Name      string `gorm:"index:idx_queued_deploys_release"`
+
+	Source            types.DeploySource
+	RequestedByUserID uint
+
+	// the values of an upgrade which sets the values of the release, as yaml
+	Values         []byte
+	ChartVersion   string
+	LatestRevision uint
+
+	// the image of an upgrade triggered by a webhook or a registry push, which is set on the
+	// values of the release when the upgrade runs
+	ImageRepository string
+	ImageTag        string
+
+	// the feature flags which are changed once the upgrade succeeds, as json
+	FeatureFlags []byte
+
+	Status types.DeployStatus `gorm:"index"`
+
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+
+	CancelledByUserID uint
+
+	Revision int
+	Error    string
+}
+
+func (d *QueuedDeploy) ToQueuedDeployType() *types.QueuedDeploy {
+	return &types.QueuedDeploy{
+		ID:                d.ID,
+		CreatedAt:         d.CreatedAt,
+		ClusterID:         d.ClusterID,
+		Namespace:         d.Namespace,
+		Name:              d.Name,
+		Source:            d.Source,
+		RequestedByUserID: d.RequestedByUserID,
+		ChartVersion:      d.ChartVersion,
+		ImageTag:          d.ImageTag,
+		Status:            d.Status,
+		StartedAt:         d.StartedAt,
+		FinishedAt:        d.FinishedAt,
+		CancelledByUserID: d.CancelledByUserID,
+		Revision:          d.Revision,
+		Error:             d.Error,
+		FeatureFlags:      d.getDeclaredFeatureFlags(),
+	}
+}
+
+// getDeclaredFeatureFlags returns the feature flag changes of the upgrade, which are pending
+// until the upgrade finishes. The flags which were changed by a successful upgrade are stored
+// as DeployFeatureFlags.
+func (d *QueuedDeploy) getDeclaredFeatureFlags() []*types.DeployFeatureFlag {
+	status := types.DeployFeatureFlagStatusPending
+
+	if d.Status == types.DeployStatusFailed || d.Status == types.DeployStatusCancelled {
+		status = types.DeployFeatureFlagStatusSkipped
+	}
+
+	res := make([]*types.DeployFeatureFlag, 0)
+
+	for _, change := range ParseFeatureFlagChanges(d.FeatureFlags) {
+		res = append(res, &types.DeployFeatureFlag{
+			FeatureFlagChange: *change,
+			Status:            status,
+		})
+	}
+
+	return res
+}
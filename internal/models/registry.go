@@ -25,6 +25,13 @@ type Registry struct {
 	// The infra id, if registry was provisioned with Porter
 	InfraID uint `json:"infra_id"`
 
+	// The registry service, which is set for registries connected with basic auth since
+	// the service can't be inferred from the integration
+	Service types.RegistryService `json:"service"`
+
+	// The token used to authenticate push webhooks sent by the registry
+	WebhookToken string `gorm:"index"`
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -54,6 +61,8 @@ func (r *Registry) ToRegistryType() *types.Registry {
 		serv = types.DOCR
 	} else if r.AzureIntegrationID != 0 {
 		serv = types.ACR
+	} else if r.Service != "" {
+		serv = r.Service
 	} else if strings.Contains(r.URL, "index.docker.io") {
 		serv = types.DockerHub
 	}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// RegistryCredentialRefresh holds the result of the last refresh of a registry's image
+// pull secrets in a cluster
+type RegistryCredentialRefresh struct {
+	gorm.Model
+
+	ProjectID  uint `gorm:"index"`
+	ClusterID  uint `gorm:"uniqueIndex:idx_registry_credential_refresh"`
+	RegistryID uint `gorm:"uniqueIndex:idx_registry_credential_refresh"`
+
+	Namespaces      uint
+	LastRefreshedAt *time.Time
+	LastAttemptedAt time.Time
+	Error           string
+}
+
+func (r *RegistryCredentialRefresh) ToRegistryCredentialStatusType(registryName string) *types.RegistryCredentialStatus {
+	return &types.RegistryCredentialStatus{
+		RegistryID:      r.RegistryID,
+		RegistryName:    registryName,
+		Namespaces:      r.Namespaces,
+		LastRefreshedAt: r.LastRefreshedAt,
+		LastAttemptedAt: r.LastAttemptedAt,
+		Error:           r.Error,
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionPolicy holds the image retention rules of a registry
+type RegistryRetentionPolicy struct {
+	gorm.Model
+
+	ProjectID  uint `gorm:"index"`
+	RegistryID uint `gorm:"uniqueIndex"`
+
+	Enabled                 bool
+	KeepLastTags            uint
+	DeleteUntaggedAfterDays uint
+	ProtectedTagPattern     string
+
+	LastRunAt *time.Time
+}
+
+func (p *RegistryRetentionPolicy) ToRegistryRetentionPolicyType() *types.RegistryRetentionPolicy {
+	return &types.RegistryRetentionPolicy{
+		RegistryID:              p.RegistryID,
+		Enabled:                 p.Enabled,
+		KeepLastTags:            p.KeepLastTags,
+		DeleteUntaggedAfterDays: p.DeleteUntaggedAfterDays,
+		ProtectedTagPattern:     p.ProtectedTagPattern,
+		LastRunAt:               p.LastRunAt,
+	}
+}
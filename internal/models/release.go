@@ -31,6 +31,24 @@ type Release struct {
 
 	// A configurable canonical name of a Porter release
 	CanonicalName string
+
+	// Whether upgrades which deploy images with critical vulnerabilities are rejected
+	BlockCriticalVulnerabilities bool
+
+	// Whether the release is upgraded when a new tag of its image repository is pushed to a
+	// registry with a push webhook. If PushDeployTagPattern is set, only matching tags are deployed.
+	PushDeployEnabled    bool
+	PushDeployTagPattern string
+
+	// Whether upgrades which deploy images that support none of the architectures of the
+	// cluster's nodes are rejected. Otherwise, these upgrades are allowed with a warning.
+	BlockArchitectureMismatch bool
+
+	// The URL of the Grafana dashboard which was provisioned for the release, if any
+	GrafanaDashboardURL string
+
+	// Whether the release's ingresses are routed to a maintenance page
+	MaintenanceMode bool
 }
 
 func (r *Release) ToReleaseType() *types.PorterRelease {
@@ -39,6 +57,13 @@ func (r *Release) ToReleaseType() *types.PorterRelease {
 		WebhookToken:  r.WebhookToken,
 		ImageRepoURI:  r.ImageRepoURI,
 		CanonicalName: r.CanonicalName,
+
+		BlockCriticalVulnerabilities: r.BlockCriticalVulnerabilities,
+		PushDeployEnabled:            r.PushDeployEnabled,
+		PushDeployTagPattern:         r.PushDeployTagPattern,
+		BlockArchitectureMismatch:    r.BlockArchitectureMismatch,
+		GrafanaDashboardURL:          r.GrafanaDashboardURL,
+		MaintenanceMode:              r.MaintenanceMode,
 	}
 
 	if r.GitActionConfig != nil {
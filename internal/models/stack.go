@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -172,20 +174,103 @@ type StackSourceConfig struct {
 
 	ImageTag string
 
-	// TODO: add git-specific information
+	// The build settings of the source, which are only set if the image is built from source
+	// rather than deployed directly from the image repo uri
+	BuildMethod         string
+	BuildFolderPath     string
+	BuildDockerfilePath string
+	BuildBuilder        string
+
+	// Comma-separated list of buildpacks
+	BuildBuildpacks string
+
+	GitIntegrationKind string
+	GitIntegrationID   uint
+	GitRepo            string
+	GitBranch          string
 }
 
 func (s StackSourceConfig) ToStackSourceConfigType(stackID string, stackRevisionID uint) *types.StackSourceConfig {
 	return &types.StackSourceConfig{
-		CreatedAt:       s.CreatedAt,
-		UpdatedAt:       s.UpdatedAt,
-		StackID:         stackID,
-		StackRevisionID: stackRevisionID,
-		Name:            s.Name,
-		ID:              s.UID,
-		ImageRepoURI:    s.ImageRepoURI,
-		ImageTag:        s.ImageTag,
-		DisplayName:     s.DisplayName,
+		CreatedAt:              s.CreatedAt,
+		UpdatedAt:              s.UpdatedAt,
+		StackID:                stackID,
+		StackRevisionID:        stackRevisionID,
+		Name:                   s.Name,
+		ID:                     s.UID,
+		ImageRepoURI:           s.ImageRepoURI,
+		ImageTag:               s.ImageTag,
+		DisplayName:            s.DisplayName,
+		StackSourceConfigBuild: s.ToStackSourceConfigBuildType(),
+	}
+}
+
+// ToStackSourceConfigBuildType returns the build settings of the source, or nil if the source
+// is deployed directly from its image
+func (s StackSourceConfig) ToStackSourceConfigBuildType() *types.StackSourceConfigBuild {
+	if s.BuildMethod == "" {
+		return nil
+	}
+
+	build := &types.StackSourceConfigBuild{
+		Method:     s.BuildMethod,
+		FolderPath: s.BuildFolderPath,
+	}
+
+	if s.GitRepo != "" {
+		build.StackSourceConfigBuildGit = &types.StackSourceConfigBuildGit{
+			GitIntegrationKind: s.GitIntegrationKind,
+			GitIntegrationID:   s.GitIntegrationID,
+			GitRepo:            s.GitRepo,
+			GitBranch:          s.GitBranch,
+		}
+	}
+
+	switch s.BuildMethod {
+	case "docker":
+		build.StackSourceConfigBuildDockerfile = &types.StackSourceConfigBuildDockerfile{
+			DockerfilePath: s.BuildDockerfilePath,
+		}
+	case "pack":
+		build.StackSourceConfigBuildPack = &types.StackSourceConfigBuildPack{
+			Builder:    s.BuildBuilder,
+			Buildpacks: make([]string, 0),
+		}
+
+		if s.BuildBuildpacks != "" {
+			build.StackSourceConfigBuildPack.Buildpacks = strings.Split(s.BuildBuildpacks, ",")
+		}
+	}
+
+	return build
+}
+
+// SetBuild sets the build settings of the source, or clears them if build is nil
+func (s *StackSourceConfig) SetBuild(build *types.StackSourceConfigBuild) {
+	s.BuildMethod, s.BuildFolderPath, s.BuildDockerfilePath, s.BuildBuilder, s.BuildBuildpacks = "", "", "", "", ""
+	s.GitIntegrationKind, s.GitIntegrationID, s.GitRepo, s.GitBranch = "", 0, "", ""
+
+	if build == nil {
+		return
+	}
+
+	s.BuildMethod = build.Method
+	s.BuildFolderPath = build.FolderPath
+
+	if git := build.StackSourceConfigBuildGit; git != nil {
+		s.GitIntegrationKind = git.GitIntegrationKind
+		s.GitIntegrationID = git.GitIntegrationID
+		s.GitRepo = git.GitRepo
+		s.GitBranch = git.GitBranch
+	}
+
+	if dockerfile := build.StackSourceConfigBuildDockerfile; dockerfile != nil {
+		s.BuildDockerfilePath = dockerfile.DockerfilePath
+	}
+
+	if pack := build.StackSourceConfigBuildPack; pack != nil {
+		s.BuildBuilder = pack.Builder
+		s.BuildBuildpacks = strings.Join(pack.Buildpacks, ",")
 	}
 }
 
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// StatusPage is a public, unauthenticated page showing the health of selected releases of a
+// project. Each project has at most one status page.
+type StatusPage struct {
+	gorm.Model
+
+	ProjectID uint   `gorm:"uniqueIndex"`
+	Subdomain string `gorm:"uniqueIndex"`
+	Title     string
+
+	Releases []StatusPageRelease
+}
+
+// StatusPageRelease is a release shown on a status page
+type StatusPageRelease struct {
+	gorm.Model
+
+	StatusPageID uint `gorm:"index"`
+
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+	DisplayName string
+}
+
+// GetDisplayName returns the name shown for the release on the status page
+func (r *StatusPageRelease) GetDisplayName() string {
+	if r.DisplayName != "" {
+		return r.DisplayName
+	}
+
+	return r.ReleaseName
+}
+
+func (s *StatusPage) ToStatusPageType(url string) *types.StatusPage {
+	releases := make([]*types.StatusPageRelease, 0, len(s.Releases))
+
+	for _, rel := range s.Releases {
+		releases = append(releases, &types.StatusPageRelease{
+			ClusterID:   rel.ClusterID,
+			Namespace:   rel.Namespace,
+			ReleaseName: rel.ReleaseName,
+			DisplayName: rel.DisplayName,
+		})
+	}
+
+	return &types.StatusPage{
+		ID:        s.ID,
+		ProjectID: s.ProjectID,
+		Subdomain: s.Subdomain,
+		Title:     s.Title,
+		URL:       url,
+		Releases:  releases,
+	}
+}
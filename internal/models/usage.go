@@ -25,15 +25,27 @@ type ProjectUsage struct {
 
 	// The number of users
 	Users uint
+
+	// The number of deployed applications
+	Apps uint
+
+	// The minutes of builds in a month
+	BuildMinutes uint
+
+	// The hours of preview environments in a month
+	PreviewEnvironmentHours uint
 }
 
 // ToProjectUsageType converts the project usage model to a project usage API type
 func (p *ProjectUsage) ToProjectUsageType() *types.ProjectUsage {
 	return &types.ProjectUsage{
-		ResourceCPU:    p.ResourceCPU,
-		ResourceMemory: p.ResourceMemory,
-		Clusters:       p.Clusters,
-		Users:          p.Users,
+		ResourceCPU:             p.ResourceCPU,
+		ResourceMemory:          p.ResourceMemory,
+		Clusters:                p.Clusters,
+		Users:                   p.Users,
+		Apps:                    p.Apps,
+		BuildMinutes:            p.BuildMinutes,
+		PreviewEnvironmentHours: p.PreviewEnvironmentHours,
 	}
 }
 
@@ -57,6 +69,15 @@ type ProjectUsageCache struct {
 	// The number of users
 	Users uint
 
+	// The number of deployed applications
+	Apps uint
+
+	// The minutes of builds in the current month
+	BuildMinutes uint
+
+	// The hours of preview environments in the current month
+	PreviewEnvironmentHours uint
+
 	// Whether the user is exceeding usage
 	Exceeded bool
 
@@ -68,3 +89,30 @@ func (p *ProjectUsageCache) Is1HrOld() bool {
 	timeSince := time.Now().Sub(p.UpdatedAt)
 	return timeSince > 1*time.Hour
 }
+
+// ProjectUsageRecord is the usage of a project which was metered for an hour. Counts are taken
+// at the end of the hour, so each active preview environment adds an hour of usage.
+type ProjectUsageRecord struct {
+	gorm.Model
+
+	ProjectID uint      `gorm:"uniqueIndex:idx_project_usage_records_project_hour"`
+	Hour      time.Time `gorm:"uniqueIndex:idx_project_usage_records_project_hour"`
+
+	Apps     uint
+	Clusters uint
+
+	// The seconds of the builds which finished during the hour
+	BuildSeconds uint
+
+	PreviewEnvironmentHours uint
+}
+
+func (p *ProjectUsageRecord) ToProjectUsageRecordType() *types.ProjectUsageRecord {
+	return &types.ProjectUsageRecord{
+		Hour:                    p.Hour,
+		Apps:                    p.Apps,
+		Clusters:                p.Clusters,
+		BuildSeconds:            p.BuildSeconds,
+		PreviewEnvironmentHours: p.PreviewEnvironmentHours,
+	}
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription is a URL which is sent the events of a project
+type WebhookSubscription struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	// The id of the user that created this subscription
+	UserID uint
+
+	URL string
+
+	// Events is a comma-separated list of the events which are sent to the URL, where an
+	// empty list sends every event
+	Events string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The secret which signs the payloads sent to the URL
+	Secret []byte
+}
+
+// GetEvents returns the events which are sent to the URL
+func (w *WebhookSubscription) GetEvents() []types.WebhookEvent {
+	events := make([]types.WebhookEvent, 0)
+
+	if w.Events == "" {
+		return events
+	}
+
+	for _, event := range strings.Split(w.Events, ",") {
+		events = append(events, types.WebhookEvent(event))
+	}
+
+	return events
+}
+
+// SetEvents sets the events which are sent to the URL
+func (w *WebhookSubscription) SetEvents(events []types.WebhookEvent) {
+	strEvents := make([]string, 0, len(events))
+
+	for _, event := range events {
+		strEvents = append(strEvents, string(event))
+	}
+
+	w.Events = strings.Join(strEvents, ",")
+}
+
+// Subscribes returns true if the event is sent to the URL
+func (w *WebhookSubscription) Subscribes(event types.WebhookEvent) bool {
+	events := w.GetEvents()
+
+	if len(events) == 0 {
+		return true
+	}
+
+	for _, subscribed := range events {
+		if subscribed == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *WebhookSubscription) ToWebhookSubscriptionType() *types.WebhookSubscription {
+	return &types.WebhookSubscription{
+		ID:        w.ID,
+		CreatedAt: w.CreatedAt,
+		ProjectID: w.ProjectID,
+		URL:       w.URL,
+		Events:    w.GetEvents(),
+	}
+}
+
+// WebhookDelivery is an event sent to a webhook subscription. Deliveries are sent by a
+// background job, so failed attempts are retried with a backoff.
+type WebhookDelivery struct {
+	gorm.Model
+
+	ProjectID             uint `gorm:"index"`
+	WebhookSubscriptionID uint `gorm:"index"`
+
+	Event  types.WebhookEvent
+	Status types.WebhookDeliveryStatus `gorm:"index"`
+
+	// Payload is the JSON-encoded body which is sent to the URL
+	Payload []byte
+
+	Attempts uint
+
+	// The status code of the response to the last attempt, and the time it took to respond.
+	// Response bodies are not stored, since they may contain data of the receiving service.
+	ResponseCode int
+	LatencyMs    int64
+
+	LastError string
+}
+
+func (w *WebhookDelivery) ToWebhookDeliveryType() *types.WebhookDelivery {
+	return &types.WebhookDelivery{
+		ID:                    w.ID,
+		CreatedAt:             w.CreatedAt,
+		UpdatedAt:             w.UpdatedAt,
+		WebhookSubscriptionID: w.WebhookSubscriptionID,
+		Event:                 w.Event,
+		Status:                w.Status,
+		Attempts:              w.Attempts,
+		Payload:               string(w.Payload),
+		ResponseCode:          w.ResponseCode,
+		LatencyMs:             w.LatencyMs,
+		LastError:             w.LastError,
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// WildcardCertificate is a wildcard certificate which is issued in a cluster through a DNS
+// provider integration, and copied to the namespaces of the cluster
+type WildcardCertificate struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint `gorm:"index"`
+
+	Domain string
+
+	DNSProviderIntegrationID uint
+
+	// comma-separated list of the namespaces which the certificate is copied to
+	Namespaces string
+
+	DistributedAt     *time.Time
+	DistributionError string
+}
+
+// ResourceName returns the name of the cert-manager resources of the certificate
+func (c *WildcardCertificate) ResourceName() string {
+	return "porter-wildcard-" + strings.ReplaceAll(c.Domain, ".", "-")
+}
+
+// SecretName returns the name of the TLS secret which the certificate is copied to
+func (c *WildcardCertificate) SecretName() string {
+	return "wildcard-" + strings.ReplaceAll(c.Domain, ".", "-") + "-tls"
+}
+
+func (c *WildcardCertificate) GetNamespaces() []string {
+	if c.Namespaces == "" {
+		return []string{}
+	}
+
+	return strings.Split(c.Namespaces, ",")
+}
+
+func (c *WildcardCertificate) ToWildcardCertificateType() *types.WildcardCertificate {
+	return &types.WildcardCertificate{
+		ID:                       c.ID,
+		ProjectID:                c.ProjectID,
+		ClusterID:                c.ClusterID,
+		Domain:                   c.Domain,
+		DNSProviderIntegrationID: c.DNSProviderIntegrationID,
+		SecretName:               c.SecretName(),
+		Namespaces:               c.GetNamespaces(),
+		DistributedAt:            c.DistributedAt,
+		DistributionError:        c.DistributionError,
+	}
+}
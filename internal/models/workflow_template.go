@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// WorkflowTemplate customizes the Github Actions workflows which Porter generates for a
+// project. A project has at most one template of each kind.
+type WorkflowTemplate struct {
+	gorm.Model
+
+	ProjectID uint   `gorm:"uniqueIndex:idx_workflow_template"`
+	Kind      string `gorm:"uniqueIndex:idx_workflow_template"`
+
+	// the JSON-encoded types.WorkflowTemplateSpec of the template
+	Spec []byte
+}
+
+func (w *WorkflowTemplate) ToWorkflowTemplateType() (*types.WorkflowTemplate, error) {
+	spec, err := w.GetSpec()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.WorkflowTemplate{
+		ID:        w.ID,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+		ProjectID: w.ProjectID,
+		Kind:      types.WorkflowTemplateKind(w.Kind),
+		Spec:      spec,
+	}, nil
+}
+
+func (w *WorkflowTemplate) GetSpec() (*types.WorkflowTemplateSpec, error) {
+	spec := &types.WorkflowTemplateSpec{}
+
+	if err := json.Unmarshal(w.Spec, spec); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,209 @@
+package multicluster
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// Deployer changes the release of a multi-cluster release in a single target
+type Deployer interface {
+	// Upgrade upgrades the release in a target, and returns the revisions of the release
+	// before and after the upgrade
+	Upgrade(target *models.MultiClusterRolloutTarget) (prevRevision, revision int, err error)
+
+	// Rollback rolls the release in a target back to a revision, and returns the revision
+	// which the rollback created
+	Rollback(target *models.MultiClusterRolloutTarget, revision int) (int, error)
+}
+
+// Executor runs the rollouts of multi-cluster releases, and records the status of each
+// target as it changes
+type Executor struct {
+	repo     repository.MultiClusterReleaseRepository
+	deployer Deployer
+	logger   *logger.Logger
+}
+
+func NewExecutor(repo repository.MultiClusterReleaseRepository, deployer Deployer, logger *logger.Logger) *Executor {
+	return &Executor{repo, deployer, logger}
+}
+
+// NewUpgradeRollout returns a rollout which upgrades every target of a multi-cluster release
+func NewUpgradeRollout(release *models.MultiClusterRelease) *models.MultiClusterRollout {
+	rollout := &models.MultiClusterRollout{
+		MultiClusterReleaseID: release.ID,
+		Kind:                  types.MultiClusterRolloutUpgrade,
+		Strategy:              release.Strategy,
+		Status:                types.MultiClusterRolloutRunning,
+	}
+
+	for _, target := range release.Targets {
+		rollout.Targets = append(rollout.Targets, models.MultiClusterRolloutTarget{
+			ClusterID: target.ClusterID,
+			Namespace: target.Namespace,
+			Position:  target.Position,
+			Status:    types.MultiClusterTargetPending,
+		})
+	}
+
+	return rollout
+}
+
+// NewRollbackRollout returns a rollout which rolls back every target which an upgrade rollout
+// upgraded, to the revision of the target before the upgrade. Rollbacks run in the reverse
+// order of the upgrade.
+func NewRollbackRollout(upgrade *models.MultiClusterRollout) (*models.MultiClusterRollout, error) {
+	if upgrade.Kind != types.MultiClusterRolloutUpgrade {
+		return nil, fmt.Errorf("only upgrades can be rolled back")
+	}
+
+	if upgrade.Status == types.MultiClusterRolloutRunning {
+		return nil, fmt.Errorf("the upgrade is still running")
+	}
+
+	rollout := &models.MultiClusterRollout{
+		MultiClusterReleaseID: upgrade.MultiClusterReleaseID,
+		Kind:                  types.MultiClusterRolloutRollback,
+		Strategy:              upgrade.Strategy,
+		Status:                types.MultiClusterRolloutRunning,
+	}
+
+	for i := len(upgrade.Targets) - 1; i >= 0; i-- {
+		target := upgrade.Targets[i]
+
+		if target.Status != types.MultiClusterTargetSucceeded || target.PreviousRevision == 0 {
+			continue
+		}
+
+		rollout.Targets = append(rollout.Targets, models.MultiClusterRolloutTarget{
+			ClusterID:        target.ClusterID,
+			Namespace:        target.Namespace,
+			Position:         uint(len(rollout.Targets)),
+			Status:           types.MultiClusterTargetPending,
+			PreviousRevision: target.PreviousRevision,
+		})
+	}
+
+	if len(rollout.Targets) == 0 {
+		return nil, fmt.Errorf("no targets of the upgrade can be rolled back")
+	}
+
+	return rollout, nil
+}
+
+// Run runs a rollout with its strategy. When an upgrade fails in any target and
+// rollbackOnFailure is set, the targets which were upgraded are rolled back.
+func (e *Executor) Run(rollout *models.MultiClusterRollout, rollbackOnFailure bool) {
+	// rollbacks try every target, since a failure in one target doesn't affect the others
+	stopOnFailure := rollout.Kind == types.MultiClusterRolloutUpgrade
+
+	if rollout.Strategy == types.MultiClusterStrategyParallel {
+		var wg sync.WaitGroup
+
+		for i := range rollout.Targets {
+			wg.Add(1)
+
+			go func(target *models.MultiClusterRolloutTarget) {
+				defer wg.Done()
+				e.runTarget(rollout, target)
+			}(&rollout.Targets[i])
+		}
+
+		wg.Wait()
+	} else {
+		failed := false
+
+		for i := range rollout.Targets {
+			target := &rollout.Targets[i]
+
+			if failed && stopOnFailure {
+				target.Status = types.MultiClusterTargetSkipped
+				target.Message = "skipped since a previous target failed"
+				e.updateTarget(rollout, target)
+
+				continue
+			}
+
+			if e.runTarget(rollout, target) != nil {
+				failed = true
+			}
+		}
+	}
+
+	rollout.Status = types.MultiClusterRolloutSucceeded
+
+	for _, target := range rollout.Targets {
+		if target.Status == types.MultiClusterTargetFailed {
+			rollout.Status = types.MultiClusterRolloutFailed
+			break
+		}
+	}
+
+	if rollout.Status == types.MultiClusterRolloutFailed && rollout.Kind == types.MultiClusterRolloutUpgrade && rollbackOnFailure {
+		e.rollbackUpgraded(rollout)
+		rollout.Status = types.MultiClusterRolloutRolledBack
+	}
+
+	if _, err := e.repo.UpdateMultiClusterRollout(rollout); err != nil {
+		e.logger.Error().Err(err).Msgf("error completing multi-cluster rollout %d", rollout.ID)
+	}
+}
+
+func (e *Executor) runTarget(rollout *models.MultiClusterRollout, target *models.MultiClusterRolloutTarget) error {
+	target.Status = types.MultiClusterTargetRunning
+	e.updateTarget(rollout, target)
+
+	var err error
+
+	if rollout.Kind == types.MultiClusterRolloutRollback {
+		target.Revision, err = e.deployer.Rollback(target, target.PreviousRevision)
+	} else {
+		target.PreviousRevision, target.Revision, err = e.deployer.Upgrade(target)
+	}
+
+	if err != nil {
+		target.Status = types.MultiClusterTargetFailed
+		target.Message = err.Error()
+	} else {
+		target.Status = types.MultiClusterTargetSucceeded
+	}
+
+	e.updateTarget(rollout, target)
+
+	return err
+}
+
+// rollbackUpgraded rolls back the targets of a failed upgrade which were upgraded, in the
+// reverse order of the upgrade
+func (e *Executor) rollbackUpgraded(rollout *models.MultiClusterRollout) {
+	for i := len(rollout.Targets) - 1; i >= 0; i-- {
+		target := &rollout.Targets[i]
+
+		if target.Status != types.MultiClusterTargetSucceeded || target.PreviousRevision == 0 {
+			continue
+		}
+
+		revision, err := e.deployer.Rollback(target, target.PreviousRevision)
+
+		if err != nil {
+			target.Message = fmt.Sprintf("error rolling back to revision %d: %s", target.PreviousRevision, err.Error())
+		} else {
+			target.Status = types.MultiClusterTargetRolledBack
+			target.Revision = revision
+		}
+
+		e.updateTarget(rollout, target)
+	}
+}
+
+func (e *Executor) updateTarget(rollout *models.MultiClusterRollout, target *models.MultiClusterRolloutTarget) {
+	if _, err := e.repo.UpdateMultiClusterRolloutTarget(target); err != nil {
+		e.logger.Error().Err(err).Msgf("error updating target cluster %d of multi-cluster rollout %d",
+			target.ClusterID, rollout.ID)
+	}
+}
@@ -0,0 +1,145 @@
+package multicluster
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// fakeRepository implements the queries of the executor, and panics on any other query
+type fakeRepository struct {
+	repository.MultiClusterReleaseRepository
+}
+
+func (repo *fakeRepository) UpdateMultiClusterRollout(rollout *models.MultiClusterRollout) (*models.MultiClusterRollout, error) {
+	return rollout, nil
+}
+
+func (repo *fakeRepository) UpdateMultiClusterRolloutTarget(
+	target *models.MultiClusterRolloutTarget,
+) (*models.MultiClusterRolloutTarget, error) {
+	return target, nil
+}
+
+// fakeDeployer upgrades each cluster from revision 1 to 2, and fails in the failing clusters
+type fakeDeployer struct {
+	failing map[uint]bool
+
+	mu        sync.Mutex
+	rollbacks []uint
+}
+
+func (d *fakeDeployer) Upgrade(target *models.MultiClusterRolloutTarget) (int, int, error) {
+	if d.failing[target.ClusterID] {
+		return 1, 0, fmt.Errorf("upgrade failed")
+	}
+
+	return 1, 2, nil
+}
+
+func (d *fakeDeployer) Rollback(target *models.MultiClusterRolloutTarget, revision int) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rollbacks = append(d.rollbacks, target.ClusterID)
+
+	return 3, nil
+}
+
+func testRelease(strategy types.MultiClusterStrategy) *models.MultiClusterRelease {
+	release := &models.MultiClusterRelease{Strategy: strategy}
+
+	for i, clusterID := range []uint{1, 2, 3} {
+		release.Targets = append(release.Targets, models.MultiClusterReleaseTarget{
+			ClusterID: clusterID,
+			Namespace: "default",
+			Position:  uint(i),
+		})
+	}
+
+	return release
+}
+
+func getStatuses(rollout *models.MultiClusterRollout) []types.MultiClusterRolloutTargetStatus {
+	res := make([]types.MultiClusterRolloutTargetStatus, 0)
+
+	for _, target := range rollout.Targets {
+		res = append(res, target.Status)
+	}
+
+	return res
+}
+
+func TestRunSequential(t *testing.T) {
+	deployer := &fakeDeployer{failing: map[uint]bool{2: true}}
+	rollout := NewUpgradeRollout(testRelease(types.MultiClusterStrategySequential))
+
+	NewExecutor(&fakeRepository{}, deployer, logger.NewConsole(false)).Run(rollout, false)
+
+	expected := []types.MultiClusterRolloutTargetStatus{
+		types.MultiClusterTargetSucceeded, types.MultiClusterTargetFailed, types.MultiClusterTargetSkipped,
+	}
+
+	if rollout.Status != types.MultiClusterRolloutFailed || !reflect.DeepEqual(getStatuses(rollout), expected) {
+		t.Errorf("expected a failed rollout with statuses %v, got %s with %v", expected, rollout.Status, getStatuses(rollout))
+	}
+
+	if len(deployer.rollbacks) != 0 {
+		t.Errorf("expected no rollbacks, got %v", deployer.rollbacks)
+	}
+}
+
+func TestRunParallelWithRollback(t *testing.T) {
+	deployer := &fakeDeployer{failing: map[uint]bool{2: true}}
+	rollout := NewUpgradeRollout(testRelease(types.MultiClusterStrategyParallel))
+
+	NewExecutor(&fakeRepository{}, deployer, logger.NewConsole(false)).Run(rollout, true)
+
+	expected := []types.MultiClusterRolloutTargetStatus{
+		types.MultiClusterTargetRolledBack, types.MultiClusterTargetFailed, types.MultiClusterTargetRolledBack,
+	}
+
+	if rollout.Status != types.MultiClusterRolloutRolledBack || !reflect.DeepEqual(getStatuses(rollout), expected) {
+		t.Errorf("expected a rolled back rollout with statuses %v, got %s with %v", expected, rollout.Status, getStatuses(rollout))
+	}
+
+	// targets are rolled back in the reverse order of the upgrade
+	if !reflect.DeepEqual(deployer.rollbacks, []uint{3, 1}) {
+		t.Errorf("expected clusters 3 and 1 to be rolled back, got %v", deployer.rollbacks)
+	}
+}
+
+func TestNewRollbackRollout(t *testing.T) {
+	upgrade := NewUpgradeRollout(testRelease(types.MultiClusterStrategySequential))
+
+	NewExecutor(&fakeRepository{}, &fakeDeployer{failing: map[uint]bool{3: true}}, logger.NewConsole(false)).Run(upgrade, false)
+
+	rollback, err := NewRollbackRollout(upgrade)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(rollback.Targets) != 2 || rollback.Targets[0].ClusterID != 2 || rollback.Targets[1].ClusterID != 1 ||
+		rollback.Targets[0].PreviousRevision != 1 {
+		t.Fatalf("expected rollbacks of clusters 2 and 1 to revision 1, got %v", rollback.Targets)
+	}
+
+	deployer := &fakeDeployer{}
+
+	NewExecutor(&fakeRepository{}, deployer, logger.NewConsole(false)).Run(rollback, false)
+
+	if rollback.Status != types.MultiClusterRolloutSucceeded || !reflect.DeepEqual(deployer.rollbacks, []uint{2, 1}) {
+		t.Errorf("expected a succeeded rollback of clusters 2 and 1, got %s of %v", rollback.Status, deployer.rollbacks)
+	}
+
+	if _, err := NewRollbackRollout(rollback); err == nil {
+		t.Errorf("expected a rollback not to be rolled back")
+	}
+}
@@ -0,0 +1,135 @@
+// Package netguard guards requests which Porter sends to URLs that are configured by users,
+// such as webhook subscriptions and the self-hosted instances of integrations. These requests
+// are sent from inside the network of Porter, so they must not reach internal addresses such
+// as the metadata endpoint of the cloud provider.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicTarget is returned for URLs which resolve to addresses that are not reachable
+// from the public internet, like the loopback, private and link-local ranges.
+var ErrNonPublicTarget = errors.New("url must resolve to a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which is not covered by
+// net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{
+	IP:   net.IPv4(100, 64, 0, 0),
+	Mask: net.CIDRMask(10, 32),
+}
+
+// isPublicIP returns true if an IP is reachable from the public internet
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip) {
+		return false
+	}
+
+	return true
+}
+
+func checkPublicIP(ip net.IP) error {
+	if !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicTarget, ip)
+	}
+
+	return nil
+}
+
+// checkIP is replaced by AllowLocalTargets
+var checkIP = checkPublicIP
+
+// AllowLocalTargets disables the checks of this package, so that tests can send requests to
+// local test servers. It returns a function which restores the checks.
+func AllowLocalTargets() (restore func()) {
+	checkIP = func(ip net.IP) error { return nil }
+
+	return func() {
+		checkIP = checkPublicIP
+	}
+}
+
+// ValidateURL checks that a URL is an http or https URL, and that every address which its
+// host resolves to is public. Since the host may resolve to a different address by the time
+// a request is sent, the address is checked again by the client of NewHTTPClient when
+// dialing.
+func ValidateURL(ctx context.Context, rawURL string) error {
+	targetURL, err := url.Parse(rawURL)
+
+	if err != nil || (targetURL.Scheme != "https" && targetURL.Scheme != "http") || targetURL.Hostname() == "" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+
+	host := targetURL.Hostname()
+
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(ip)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+
+	if err != nil {
+		return fmt.Errorf("could not resolve host %s", host)
+	}
+
+	for _, addr := range addrs {
+		if err := checkIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dialControl rejects connections to addresses which are not public. It runs after the host
+// has been resolved, so it also covers redirects and hosts whose DNS records changed after
+// the URL was validated.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil {
+		return fmt.Errorf("%w: %s", ErrNonPublicTarget, host)
+	}
+
+	return checkIP(ip)
+}
+
+// NewHTTPClient returns a client which refuses to connect to addresses which are not public.
+// The client does not use the proxy of the environment, since the address would then be
+// checked for the proxy rather than for the URL of the request.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: dialControl,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     30 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://93.184.216.34/hook", true},
+		{"http://[2606:2800:220:1:248:1893:25c8:1946]/hook", true},
+		{"ftp://93.184.216.34/hook", false},
+		{"https:///hook", false},
+		{"http://127.0.0.1:8080/hook", false},
+		{"http://localhost/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://10.0.0.5/hook", false},
+		{"http://172.16.3.4/hook", false},
+		{"http://192.168.1.1/hook", false},
+		{"http://100.64.0.1/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://[fe80::1]/hook", false},
+		{"http://[fd00::1]/hook", false},
+		{"http://0.0.0.0/hook", false},
+	}
+
+	for _, test := range tests {
+		err := ValidateURL(context.Background(), test.url)
+
+		if test.valid && err != nil {
+			t.Errorf("%s: expected the url to be valid, got %v\n", test.url, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expected the url to be rejected\n", test.url)
+		}
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	var received int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+	}))
+
+	defer server.Close()
+
+	client := NewHTTPClient(5 * time.Second)
+
+	// the test server listens on the loopback address, which is checked when dialing
+	_, err := client.Get(server.URL)
+
+	if !errors.Is(err, ErrNonPublicTarget) {
+		t.Errorf("expected ErrNonPublicTarget, got %v\n", err)
+	}
+
+	if atomic.LoadInt32(&received) != 0 {
+		t.Fatalf("expected the request not to reach the server\n")
+	}
+
+	restore := AllowLocalTargets()
+
+	resp, err := client.Get(server.URL)
+
+	restore()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	resp.Body.Close()
+
+	if atomic.LoadInt32(&received) != 1 {
+		t.Errorf("expected the request to reach the server once local targets are allowed\n")
+	}
+
+	if err := ValidateURL(context.Background(), server.URL); !errors.Is(err, ErrNonPublicTarget) {
+		t.Errorf("expected the checks to be restored, got %v\n", err)
+	}
+}
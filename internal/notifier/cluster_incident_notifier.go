@@ -0,0 +1,37 @@
+package notifier
+
+import "github.com/porter-dev/porter/api/types"
+
+// ClusterIncidentNotifier sends alerts for incidents detected by Porter from the state of a
+// cluster. The excerpt contains container logs or events which help explain the incident.
+type ClusterIncidentNotifier interface {
+	NotifyOpened(incident *types.ClusterIncident, excerpt string, url string) error
+}
+
+type MultiClusterIncidentNotifier struct {
+	notifConf *types.NotificationConfig
+	notifiers []ClusterIncidentNotifier
+}
+
+func NewMultiClusterIncidentNotifier(
+	notifConf *types.NotificationConfig,
+	notifiers ...ClusterIncidentNotifier,
+) ClusterIncidentNotifier {
+	return &MultiClusterIncidentNotifier{notifConf, notifiers}
+}
+
+func (m *MultiClusterIncidentNotifier) NotifyOpened(incident *types.ClusterIncident, excerpt string, url string) error {
+	// if notification config exists and notifs are disabled for this release, or failure notifications
+	// are disabled, do not alert
+	if m.notifConf != nil && (!m.notifConf.Enabled || !m.notifConf.Failure) {
+		return nil
+	}
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyOpened(incident, excerpt, url); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
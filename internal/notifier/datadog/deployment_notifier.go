@@ -0,0 +1,92 @@
+package datadog
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// DeploymentNotifier sends a Datadog event whenever a release is upgraded. Events are deployment
+// markers rather than alerts, so the notification config of a release is not respected.
+type DeploymentNotifier struct {
+	ddInts []*integrations.DatadogIntegration
+}
+
+func NewDeploymentNotifier(ddInts ...*integrations.DatadogIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{ddInts}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if len(d.ddInts) == 0 {
+		return nil
+	}
+
+	var event *Event
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		event = &Event{
+			Title:     fmt.Sprintf("Porter deployed %s version %d", opts.Name, opts.Version),
+			Text:      getMarkdown(fmt.Sprintf("%s was upgraded to version %d in namespace %s of cluster %s.", opts.Name, opts.Version, opts.Namespace, opts.ClusterName) + getLink(opts.URL)),
+			AlertType: "success",
+		}
+	case notifier.StatusHelmFailed:
+		event = &Event{
+			Title:     fmt.Sprintf("Porter failed to deploy %s", opts.Name),
+			Text:      getMarkdown(fmt.Sprintf("%s failed to upgrade in namespace %s of cluster %s:\n```\n%s\n```", opts.Name, opts.Namespace, opts.ClusterName, opts.Info) + getLink(opts.URL)),
+			AlertType: "error",
+		}
+	default:
+		return nil
+	}
+
+	event.SourceTypeName = "porter"
+	event.AggregationKey = getAggregationKey(opts.ClusterName, opts.Namespace, opts.Name)
+	event.Tags = append(getReleaseTags(opts.ClusterName, opts.Namespace, opts.Name, opts.Version), "porter_event:deploy")
+
+	return postEvent(d.ddInts, event)
+}
+
+// RollbackOpts describes the rollback of a release to a previous revision
+type RollbackOpts struct {
+	ClusterName string
+	Namespace   string
+	Name        string
+	Revision    int
+	URL         string
+}
+
+// RollbackNotifier sends a Datadog event whenever a release is rolled back
+type RollbackNotifier struct {
+	ddInts []*integrations.DatadogIntegration
+}
+
+func NewRollbackNotifier(ddInts ...*integrations.DatadogIntegration) *RollbackNotifier {
+	return &RollbackNotifier{ddInts}
+}
+
+func (r *RollbackNotifier) Notify(opts *RollbackOpts) error {
+	if len(r.ddInts) == 0 {
+		return nil
+	}
+
+	return postEvent(r.ddInts, &Event{
+		Title:          fmt.Sprintf("Porter rolled back %s to version %d", opts.Name, opts.Revision),
+		Text:           getMarkdown(fmt.Sprintf("%s was rolled back to version %d in namespace %s of cluster %s.", opts.Name, opts.Revision, opts.Namespace, opts.ClusterName) + getLink(opts.URL)),
+		AlertType:      "warning",
+		SourceTypeName: "porter",
+		AggregationKey: getAggregationKey(opts.ClusterName, opts.Namespace, opts.Name),
+		Tags:           append(getReleaseTags(opts.ClusterName, opts.Namespace, opts.Name, opts.Revision), "porter_event:rollback"),
+	})
+}
+
+// getMarkdown wraps text in the delimiters which Datadog uses to render an event's text as
+// markdown, truncating the text so that the delimiters are kept
+func getMarkdown(text string) string {
+	if len(text) > maxTextLength {
+		text = text[0:maxTextLength] + "..."
+	}
+
+	return fmt.Sprintf("%%%%%% \n%s\n %%%%%%", text)
+}
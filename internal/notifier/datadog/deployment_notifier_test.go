@@ -0,0 +1,98 @@
+package datadog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func TestDeploymentNotifier(t *testing.T) {
+	events := make([]*Event, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/events" || r.Header.Get("DD-API-KEY") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		event := &Event{}
+
+		if err := json.NewDecoder(r.Body).Decode(event); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		events = append(events, event)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	defer server.Close()
+
+	prevGetAPIURL := getAPIURL
+	getAPIURL = func(site string) string { return server.URL }
+	defer func() { getAPIURL = prevGetAPIURL }()
+
+	ddInt := &integrations.DatadogIntegration{
+		Site:   "datadoghq.com",
+		Tags:   "env:production",
+		APIKey: []byte("key"),
+	}
+
+	err := NewDeploymentNotifier(ddInt).Notify(&notifier.NotifyOpts{
+		ClusterName: "production",
+		Name:        "web",
+		Namespace:   "default",
+		Status:      notifier.StatusHelmDeployed,
+		Version:     4,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	err = NewRollbackNotifier(ddInt).Notify(&RollbackOpts{
+		ClusterName: "production",
+		Name:        "web",
+		Namespace:   "default",
+		Revision:    3,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d\n", len(events))
+	}
+
+	expTags := map[string]bool{
+		"kube_cluster_name:production": true,
+		"kube_namespace:default":       true,
+		"service:web":                  true,
+		"version:4":                    true,
+		"porter_event:deploy":          true,
+		"env:production":               true,
+	}
+
+	for _, tag := range events[0].Tags {
+		delete(expTags, tag)
+	}
+
+	if len(expTags) != 0 {
+		t.Errorf("deploy event is missing tags %v\n", expTags)
+	}
+
+	if events[0].AlertType != "success" || events[1].AlertType != "warning" {
+		t.Errorf("incorrect alert types: expected success and warning, got %s and %s\n",
+			events[0].AlertType, events[1].AlertType)
+	}
+
+	// events of the same release are aggregated together in the event stream
+	if events[0].AggregationKey != events[1].AggregationKey {
+		t.Errorf("deploy and rollback events should have the same aggregation key\n")
+	}
+}
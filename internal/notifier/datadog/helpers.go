@@ -0,0 +1,149 @@
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// getAPIURL returns the API URL of a Datadog site
+var getAPIURL = func(site string) string {
+	return fmt.Sprintf("https://api.%s", site)
+}
+
+// Datadog truncates event text longer than 4000 characters, so the text is truncated before it is
+// wrapped in markdown delimiters
+const maxTextLength = 3950
+
+type Event struct {
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	AlertType      string   `json:"alert_type"`
+	SourceTypeName string   `json:"source_type_name"`
+	AggregationKey string   `json:"aggregation_key,omitempty"`
+	Tags           []string `json:"tags"`
+}
+
+// ValidateAPIKey returns an error if the API key is not valid for the Datadog site
+func ValidateAPIKey(site, apiKey string) error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/validate", getAPIURL(site)), nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("DD-API-KEY", apiKey)
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid API key for site %s", site)
+	} else if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("datadog api returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// postEvent posts an event to the organization of each Datadog integration, and returns the
+// first error which is encountered after every integration has been posted to. The tags of
+// each integration are added to the event's tags.
+func postEvent(ddInts []*integrations.DatadogIntegration, event *Event) error {
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	eventTags := event.Tags
+
+	var postErr error
+
+	for _, ddInt := range ddInts {
+		event.Tags = append(append([]string{}, eventTags...), ddInt.GetTags()...)
+
+		err := postEventToSite(client, ddInt, event)
+
+		if err != nil && postErr == nil {
+			postErr = err
+		}
+	}
+
+	return postErr
+}
+
+func postEventToSite(client *http.Client, ddInt *integrations.DatadogIntegration, event *Event) error {
+	payload, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("%s/api/v1/events", getAPIURL(ddInt.Site)),
+		bytes.NewReader(payload),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("DD-API-KEY", string(ddInt.APIKey))
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("datadog events api returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// getReleaseTags returns the tags of a release, which match the tags of the Datadog Kubernetes
+// integration so that events are correlated with the release's metrics
+func getReleaseTags(clusterName, namespace, name string, version int) []string {
+	tags := []string{
+		"source:porter",
+		fmt.Sprintf("kube_cluster_name:%s", clusterName),
+		fmt.Sprintf("kube_namespace:%s", namespace),
+		fmt.Sprintf("service:%s", name),
+	}
+
+	if version != 0 {
+		tags = append(tags, fmt.Sprintf("version:%d", version))
+	}
+
+	return tags
+}
+
+func getAggregationKey(clusterName, namespace, name string) string {
+	return fmt.Sprintf("porter-%s-%s-%s", clusterName, namespace, name)
+}
+
+func getLink(url string) string {
+	if url == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\n[View release on Porter](%s)", url)
+}
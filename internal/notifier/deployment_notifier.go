@@ -43,3 +43,26 @@ type NotifyOpts struct {
 
 	Version int
 }
+
+// MultiNotifier sends deployment notifications to several notifiers
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+func NewMultiNotifier(notifiers ...Notifier) Notifier {
+	return &MultiNotifier{notifiers}
+}
+
+// Notify notifies every notifier, and returns the first error which is encountered after
+// every notifier has been notified
+func (m *MultiNotifier) Notify(opts *NotifyOpts) error {
+	var res error
+
+	for _, n := range m.notifiers {
+		if err := n.Notify(opts); err != nil && res == nil {
+			res = err
+		}
+	}
+
+	return res
+}
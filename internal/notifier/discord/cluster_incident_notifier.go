@@ -0,0 +1,54 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type ClusterIncidentNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+}
+
+func NewClusterIncidentNotifier(discordInts ...*integrations.DiscordIntegration) *ClusterIncidentNotifier {
+	return &ClusterIncidentNotifier{
+		discordInts: discordInts,
+	}
+}
+
+func (d *ClusterIncidentNotifier) NotifyOpened(incident *types.ClusterIncident, excerpt string, url string) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	title := fmt.Sprintf("Your application %s is failing on Porter", incident.ReleaseName)
+
+	if incident.Reason == types.ClusterIncidentReasonImagePull {
+		title = fmt.Sprintf("Your application %s cannot pull its image on Porter", incident.ReleaseName)
+	} else if incident.Reason == types.ClusterIncidentReasonLogAlert {
+		title = fmt.Sprintf("Your application %s matched a log alert rule on Porter", incident.ReleaseName)
+	}
+
+	embed := &DiscordEmbed{
+		Title:       title,
+		Description: getCodeBlock(incident.Message, maxDescriptionLength, false),
+		URL:         url,
+		Color:       colorWarning,
+		Fields: []*DiscordEmbedField{
+			getField("Name", "`"+incident.ReleaseName+"`"),
+			getField("Namespace", "`"+incident.Namespace+"`"),
+		},
+		Timestamp: getTimestamp(incident.StartedAt),
+	}
+
+	// the end of the logs is kept, since it is most likely to explain the failure
+	if excerpt != "" {
+		embed.Fields = append(embed.Fields, &DiscordEmbedField{
+			Name:  "Logs",
+			Value: getCodeBlock(excerpt, maxFieldLength, true),
+		})
+	}
+
+	return postEmbed(d.discordInts, embed)
+}
@@ -0,0 +1,76 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type DeploymentNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+	Config      *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, discordInts ...*integrations.DiscordIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		discordInts: discordInts,
+		Config:      conf,
+	}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	if d.Config != nil {
+		if !d.Config.Enabled {
+			return nil
+		}
+		if opts.Status == notifier.StatusHelmDeployed && !d.Config.Success {
+			return nil
+		}
+		if (opts.Status == notifier.StatusPodCrashed || opts.Status == notifier.StatusHelmFailed) && !d.Config.Failure {
+			return nil
+		}
+	}
+
+	embed := &DiscordEmbed{
+		URL: opts.URL,
+		Fields: []*DiscordEmbedField{
+			getField("Name", "`"+opts.Name+"`"),
+			getField("Namespace", "`"+opts.Namespace+"`"),
+		},
+	}
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		embed.Title = fmt.Sprintf("Your application %s was successfully updated on Porter", opts.Name)
+		embed.Color = colorSuccess
+	case notifier.StatusHelmFailed:
+		embed.Title = fmt.Sprintf("Your application %s failed to deploy on Porter", opts.Name)
+		embed.Color = colorFailure
+	case notifier.StatusPodCrashed:
+		embed.Title = fmt.Sprintf("Your application %s crashed on Porter", opts.Name)
+		embed.Color = colorFailure
+	}
+
+	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed {
+		embed.Fields = append(embed.Fields, getField("Version", fmt.Sprintf("%d", opts.Version)))
+	}
+
+	if opts.Status != notifier.StatusHelmDeployed && opts.Info != "" {
+		embed.Description = getCodeBlock(opts.Info, maxDescriptionLength, false)
+	}
+
+	if opts.Timestamp != nil {
+		embed.Timestamp = getTimestamp(*opts.Timestamp)
+	} else {
+		embed.Timestamp = getTimestamp(time.Now())
+	}
+
+	return postEmbed(d.discordInts, embed)
+}
@@ -0,0 +1,106 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// embed colors, as decimal RGB values
+const (
+	colorSuccess = 0x2ecc71
+	colorFailure = 0xe74c3c
+	colorWarning = 0xf1c40f
+)
+
+// Discord limits embed descriptions to 4096 characters and field values to 1024 characters
+const (
+	maxDescriptionLength = 4000
+	maxFieldLength       = 1000
+)
+
+type DiscordPayload struct {
+	Embeds []*DiscordEmbed `json:"embeds"`
+}
+
+type DiscordEmbed struct {
+	Title       string               `json:"title"`
+	Description string               `json:"description,omitempty"`
+	URL         string               `json:"url,omitempty"`
+	Color       int                  `json:"color"`
+	Fields      []*DiscordEmbedField `json:"fields,omitempty"`
+	Timestamp   string               `json:"timestamp,omitempty"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// postEmbed posts an embed to the webhook of each Discord integration, and returns the first
+// error which is encountered after every integration has been posted to
+func postEmbed(discordInts []*integrations.DiscordIntegration, embed *DiscordEmbed) error {
+	payload, err := json.Marshal(&DiscordPayload{
+		Embeds: []*DiscordEmbed{embed},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	var postErr error
+
+	for _, discordInt := range discordInts {
+		resp, err := client.Post(string(discordInt.Webhook), "application/json", bytes.NewReader(payload))
+
+		if err == nil {
+			resp.Body.Close()
+
+			// Discord responds with 204 No Content unless the webhook is called with ?wait=true
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+				err = fmt.Errorf("discord webhook returned status code %d", resp.StatusCode)
+			}
+		}
+
+		if err != nil && postErr == nil {
+			postErr = err
+		}
+	}
+
+	return postErr
+}
+
+func getField(name, value string) *DiscordEmbedField {
+	return &DiscordEmbedField{
+		Name:   name,
+		Value:  value,
+		Inline: true,
+	}
+}
+
+// getCodeBlock formats text as a code block. If the text is longer than maxLength, the
+// beginning of the text is kept if truncateStart is false, and the end of the text otherwise.
+func getCodeBlock(text string, maxLength int, truncateStart bool) string {
+	if len(text) > maxLength {
+		if truncateStart {
+			text = "..." + text[len(text)-maxLength:]
+		} else {
+			text = text[0:maxLength] + "..."
+		}
+	}
+
+	return fmt.Sprintf("```\n%s\n```", text)
+}
+
+func getTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
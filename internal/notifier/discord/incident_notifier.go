@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type IncidentNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+}
+
+func NewIncidentNotifier(discordInts ...*integrations.DiscordIntegration) *IncidentNotifier {
+	return &IncidentNotifier{
+		discordInts: discordInts,
+	}
+}
+
+func (d *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	resourceKind := "application"
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == "job" {
+		resourceKind = "job"
+	}
+
+	return postEmbed(d.discordInts, &DiscordEmbed{
+		Title:       fmt.Sprintf("Your %s %s crashed on Porter", resourceKind, incident.ReleaseName),
+		Description: getCodeBlock(incident.Summary, maxDescriptionLength, false),
+		URL:         url,
+		Color:       colorWarning,
+		Fields: []*DiscordEmbedField{
+			getField("Name", "`"+incident.ReleaseName+"`"),
+			getField("Namespace", "`"+incident.ReleaseNamespace+"`"),
+		},
+		Timestamp: getTimestamp(incident.CreatedAt),
+	})
+}
+
+func (d *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	return postEmbed(d.discordInts, &DiscordEmbed{
+		Title:       fmt.Sprintf("The incident for application %s has been resolved", incident.ReleaseName),
+		Description: getCodeBlock(incident.Summary, maxDescriptionLength, false),
+		URL:         url,
+		Color:       colorSuccess,
+		Fields: []*DiscordEmbedField{
+			getField("Name", "`"+incident.ReleaseName+"`"),
+			getField("Namespace", "`"+incident.ReleaseNamespace+"`"),
+		},
+		Timestamp: getTimestamp(incident.UpdatedAt),
+	})
+}
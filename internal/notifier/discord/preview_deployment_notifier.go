@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type PreviewDeploymentNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+}
+
+func NewPreviewDeploymentNotifier(discordInts ...*integrations.DiscordIntegration) *PreviewDeploymentNotifier {
+	return &PreviewDeploymentNotifier{
+		discordInts: discordInts,
+	}
+}
+
+func (d *PreviewDeploymentNotifier) Notify(opts *notifier.PreviewDeploymentNotifyOpts) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	pr := fmt.Sprintf("%s/%s#%d", opts.RepoOwner, opts.RepoName, opts.PRNumber)
+
+	embed := &DiscordEmbed{
+		Title:     fmt.Sprintf("The preview deployment for %s is ready on Porter", pr),
+		URL:       opts.URL,
+		Color:     colorSuccess,
+		Timestamp: getTimestamp(time.Now()),
+	}
+
+	switch opts.Status {
+	case types.DeploymentStatusCreating:
+		embed.Title = fmt.Sprintf("The preview deployment for %s is being created on Porter", pr)
+		embed.Color = colorWarning
+	case types.DeploymentStatusFailed:
+		embed.Title = fmt.Sprintf("The preview deployment for %s failed on Porter", pr)
+		embed.Color = colorFailure
+
+		if opts.Info != "" {
+			embed.Description = getCodeBlock(opts.Info, maxDescriptionLength, false)
+		}
+	case types.DeploymentStatusInactive:
+		embed.Title = fmt.Sprintf("The preview deployment for %s was deleted from Porter", pr)
+		embed.URL = ""
+		embed.Color = colorWarning
+	}
+
+	if opts.PRName != "" {
+		embed.Fields = append(embed.Fields, getField("Pull request", opts.PRName))
+	}
+
+	embed.Fields = append(embed.Fields, getField("Namespace", "`"+opts.Namespace+"`"))
+
+	if opts.Subdomain != "" {
+		embed.Fields = append(embed.Fields, getField("URL", opts.Subdomain))
+	}
+
+	return postEmbed(d.discordInts, embed)
+}
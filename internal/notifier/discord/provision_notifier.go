@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type ProvisionNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+}
+
+func NewProvisionNotifier(discordInts ...*integrations.DiscordIntegration) *ProvisionNotifier {
+	return &ProvisionNotifier{
+		discordInts: discordInts,
+	}
+}
+
+func (d *ProvisionNotifier) NotifyFailed(opts *notifier.ProvisionNotifyOpts) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	embed := &DiscordEmbed{
+		Title: fmt.Sprintf("Your %s infrastructure failed to %s on Porter", opts.Kind, opts.Operation),
+		URL:   opts.URL,
+		Color: colorFailure,
+		Fields: []*DiscordEmbedField{
+			getField("Infrastructure ID", fmt.Sprintf("%d", opts.InfraID)),
+		},
+		Timestamp: getTimestamp(time.Now()),
+	}
+
+	if opts.Info != "" {
+		embed.Description = getCodeBlock(opts.Info, maxDescriptionLength, false)
+	}
+
+	return postEmbed(d.discordInts, embed)
+}
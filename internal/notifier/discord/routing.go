@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ListRoutedIntegrations lists the Discord integrations of a project which should be notified
+// of an event in the given cluster and namespace. Integrations of the whole project are
+// notified of every event, while integrations of a preview environment are only notified of
+// events in the namespaces of that environment's deployments.
+func ListRoutedIntegrations(
+	repo repository.Repository,
+	projectID, clusterID uint,
+	namespace string,
+) ([]*integrations.DiscordIntegration, error) {
+	discordInts, err := repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*integrations.DiscordIntegration, 0)
+	hasEnvironmentInts := false
+
+	for _, discordInt := range discordInts {
+		if discordInt.EnvironmentID == 0 {
+			res = append(res, discordInt)
+		} else {
+			hasEnvironmentInts = true
+		}
+	}
+
+	// the deployments of the cluster are only read if they can match an integration
+	if !hasEnvironmentInts || clusterID == 0 || namespace == "" {
+		return res, nil
+	}
+
+	depls, err := repo.Environment().ListDeploymentsByCluster(projectID, clusterID, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var environmentID uint
+
+	for _, depl := range depls {
+		if depl.Namespace == namespace {
+			environmentID = depl.EnvironmentID
+			break
+		}
+	}
+
+	if environmentID == 0 {
+		return res, nil
+	}
+
+	for _, discordInt := range discordInts {
+		if discordInt.EnvironmentID == environmentID {
+			res = append(res, discordInt)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,74 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ApprovalRequestOpts describe an upgrade which is waiting for the approval of its approvers
+type ApprovalRequestOpts struct {
+	Approval    *models.Approval
+	ClusterName string
+
+	// the email of the user who requested the upgrade, which is empty for upgrades which were
+	// triggered by a webhook
+	RequestedBy string
+
+	URL string
+}
+
+// SendApprovalRequest emails the approvers of an upgrade which is waiting for their approval
+func SendApprovalRequest(repo repository.Repository, sender Sender, opts *ApprovalRequestOpts) error {
+	if sender == nil {
+		return nil
+	}
+
+	users, err := repo.User().ListUsersByIDs(opts.Approval.ToApprovalType().ApproverIDs)
+
+	if err != nil {
+		return err
+	}
+
+	to := make([]string, 0, len(users))
+
+	for _, user := range users {
+		if user.ID != opts.Approval.RequestedByUserID {
+			to = append(to, user.Email)
+		}
+	}
+
+	if len(to) == 0 {
+		return nil
+	}
+
+	requestedBy := opts.RequestedBy
+
+	if requestedBy == "" {
+		requestedBy = "a deploy webhook"
+	}
+
+	subject := fmt.Sprintf("An upgrade of %s is waiting for your approval on Porter", opts.Approval.Name)
+
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "%s.\n\n", subject)
+	fmt.Fprintf(&text, "Requested by: %s\n", requestedBy)
+	fmt.Fprintf(&text, "Cluster: %s\n", opts.ClusterName)
+	fmt.Fprintf(&text, "Namespace: %s\n", opts.Approval.Namespace)
+
+	if opts.Approval.ChartVersion != "" {
+		fmt.Fprintf(&text, "Chart version: %s\n", opts.Approval.ChartVersion)
+	}
+
+	fmt.Fprintf(&text, "Approval: %d\n", opts.Approval.ID)
+	fmt.Fprintf(&text, "\nView the application: %s\n", opts.URL)
+
+	return sender.Send(&Message{
+		To:      to,
+		Subject: subject,
+		Text:    text.String(),
+	})
+}
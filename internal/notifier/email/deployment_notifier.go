@@ -0,0 +1,115 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// DeploymentNotifier records every deploy for the daily digests, and emails the users of the
+// project which have opted in to failure alerts when a deploy fails
+type DeploymentNotifier struct {
+	repo   repository.Repository
+	sender Sender
+	Config *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, repo repository.Repository, sender Sender) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		repo:   repo,
+		sender: sender,
+		Config: conf,
+	}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	switch opts.Status {
+	case notifier.StatusHelmDeployed, notifier.StatusHelmFailed:
+		status := "succeeded"
+
+		if opts.Status == notifier.StatusHelmFailed {
+			status = "failed"
+		}
+
+		_, err := d.repo.EmailPreference().CreateDeploymentRecord(&models.DeploymentRecord{
+			ProjectID: opts.ProjectID,
+			ClusterID: opts.ClusterID,
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
+			Status:    status,
+			Version:   opts.Version,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.sender == nil || opts.Status == notifier.StatusHelmDeployed {
+		return nil
+	}
+
+	if d.Config != nil && (!d.Config.Enabled || !d.Config.Failure) {
+		return nil
+	}
+
+	prefs, err := d.repo.NotificationPreference().ListNotificationPreferencesByProjectID(opts.ProjectID)
+
+	if err != nil {
+		return err
+	}
+
+	event := notifier.GetDeploymentEvent(opts.Status)
+	now := time.Now()
+
+	// deploys are recorded for the digests even if the project has muted the alert
+	if !notifier.GetNotificationPreference(prefs, opts.ProjectID, 0, types.NotificationChannelEmail).Allows(event, now) {
+		return nil
+	}
+
+	// users who have muted the event in their preferences for the project are not emailed
+	to, err := listRecipients(d.repo, opts.ProjectID, func(pref *models.EmailPreference) bool {
+		userPref := notifier.GetNotificationPreference(prefs, opts.ProjectID, pref.UserID, types.NotificationChannelEmail)
+
+		return wantsImmediateFailures(pref) && userPref.Allows(event, now)
+	})
+
+	if err != nil || len(to) == 0 {
+		return err
+	}
+
+	var subject string
+
+	if opts.Status == notifier.StatusPodCrashed {
+		subject = fmt.Sprintf("Your application %s crashed on Porter", opts.Name)
+	} else {
+		subject = fmt.Sprintf("Your application %s failed to deploy on Porter", opts.Name)
+	}
+
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "%s.\n\n", subject)
+	fmt.Fprintf(&text, "Cluster: %s\n", opts.ClusterName)
+	fmt.Fprintf(&text, "Namespace: %s\n", opts.Namespace)
+
+	if opts.Status == notifier.StatusHelmFailed {
+		fmt.Fprintf(&text, "Version: %d\n", opts.Version)
+	}
+
+	if opts.Info != "" {
+		fmt.Fprintf(&text, "\n%s\n", opts.Info)
+	}
+
+	fmt.Fprintf(&text, "\nView the application: %s\n", opts.URL)
+
+	return d.sender.Send(&Message{
+		To:      to,
+		Subject: subject,
+		Text:    text.String(),
+	})
+}
@@ -0,0 +1,181 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// only the most recent deploys of a project are listed in a digest
+const maxDigestDeployments = 20
+
+// openDeploymentStatuses are the statuses of preview deployments which have not been closed
+var openDeploymentStatuses = []string{
+	string(types.DeploymentStatusCreating),
+	string(types.DeploymentStatusCreated),
+	string(types.DeploymentStatusUpdating),
+	string(types.DeploymentStatusFailed),
+	string(types.DeploymentStatusTimedOut),
+}
+
+type projectDigest struct {
+	project     *models.Project
+	records     []*models.DeploymentRecord
+	previewURLs map[uint]string
+	previews    []*models.Deployment
+}
+
+// SendDigest sends a user the digest of the deploys since the given time and the open preview
+// environments of every project which they belong to. It returns false without sending an
+// email if there is nothing to summarize.
+func SendDigest(
+	repo repository.Repository,
+	sender Sender,
+	serverURL string,
+	user *models.User,
+	since time.Time,
+) (bool, error) {
+	projects, err := repo.Project().ListProjectsByUserID(user.ID)
+
+	if err != nil {
+		return false, err
+	}
+
+	digests := make([]*projectDigest, 0)
+
+	for _, project := range projects {
+		digest, err := getProjectDigest(repo, serverURL, project, since)
+
+		if err != nil {
+			return false, err
+		}
+
+		if len(digest.records) > 0 || len(digest.previews) > 0 {
+			digests = append(digests, digest)
+		}
+	}
+
+	if len(digests) == 0 {
+		return false, nil
+	}
+
+	err = sender.Send(&Message{
+		To:      []string{user.Email},
+		Subject: fmt.Sprintf("Your Porter digest for %s", time.Now().UTC().Format("Jan 2, 2006")),
+		Text:    formatDigest(digests, since),
+	})
+
+	return err == nil, err
+}
+
+func getProjectDigest(
+	repo repository.Repository,
+	serverURL string,
+	project *models.Project,
+	since time.Time,
+) (*projectDigest, error) {
+	records, err := repo.EmailPreference().ListDeploymentRecords(project.ID, since)
+
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := repo.Cluster().ListClustersByProjectID(project.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	digest := &projectDigest{
+		project:     project,
+		records:     records,
+		previewURLs: make(map[uint]string),
+		previews:    make([]*models.Deployment, 0),
+	}
+
+	for _, cluster := range clusters {
+		depls, err := repo.Environment().ListDeploymentsByCluster(project.ID, cluster.ID, &repository.ListOptions{
+			Filters: []repository.Filter{
+				{Field: "status", Values: openDeploymentStatuses},
+			},
+			ReadReplica: true,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, depl := range depls {
+			digest.previewURLs[depl.ID] = fmt.Sprintf(
+				"%s/preview-environments/details/%d?environment_id=%d&project_id=%d",
+				serverURL,
+				depl.ID,
+				depl.EnvironmentID,
+				project.ID,
+			)
+		}
+
+		digest.previews = append(digest.previews, depls...)
+	}
+
+	return digest, nil
+}
+
+func formatDigest(digests []*projectDigest, since time.Time) string {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "Here is what happened on Porter since %s.\n", since.UTC().Format("Jan 2, 2006 at 3:04pm (MST)"))
+
+	for _, digest := range digests {
+		fmt.Fprintf(&text, "\n== %s ==\n", digest.project.Name)
+
+		if len(digest.records) > 0 {
+			failed := 0
+
+			for _, record := range digest.records {
+				if record.Status == "failed" {
+					failed++
+				}
+			}
+
+			fmt.Fprintf(&text, "\nDeployments: %d succeeded, %d failed\n", len(digest.records)-failed, failed)
+
+			records := digest.records
+
+			if len(records) > maxDigestDeployments {
+				records = records[len(records)-maxDigestDeployments:]
+			}
+
+			for _, record := range records {
+				fmt.Fprintf(
+					&text, "  - %s (%s) version %d %s at %s\n",
+					record.Name, record.Namespace, record.Version, record.Status,
+					record.CreatedAt.UTC().Format("3:04pm (MST)"),
+				)
+			}
+
+			if n := len(digest.records) - len(records); n > 0 {
+				fmt.Fprintf(&text, "  ... and %d earlier deployments\n", n)
+			}
+		}
+
+		if len(digest.previews) > 0 {
+			fmt.Fprintf(&text, "\nOpen preview environments: %d\n", len(digest.previews))
+
+			for _, depl := range digest.previews {
+				fmt.Fprintf(
+					&text, "  - %s/%s#%d %s (%s): %s\n",
+					depl.RepoOwner, depl.RepoName, depl.PullRequestID, depl.PRName, depl.Status, digest.previewURLs[depl.ID],
+				)
+			}
+		}
+	}
+
+	text.WriteString("\nYou can change which emails you receive in your Porter account settings.\n")
+
+	return text.String()
+}
@@ -0,0 +1,61 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// PreviewDeploymentNotifier emails the users of the project which have opted in to failure
+// alerts when a preview deployment fails. Other lifecycle events are only included in the
+// daily digests.
+type PreviewDeploymentNotifier struct {
+	repo   repository.Repository
+	sender Sender
+}
+
+func NewPreviewDeploymentNotifier(repo repository.Repository, sender Sender) *PreviewDeploymentNotifier {
+	return &PreviewDeploymentNotifier{
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+func (p *PreviewDeploymentNotifier) Notify(opts *notifier.PreviewDeploymentNotifyOpts) error {
+	if p.sender == nil || opts.Status != types.DeploymentStatusFailed {
+		return nil
+	}
+
+	to, err := listRecipients(p.repo, opts.ProjectID, wantsImmediateFailures)
+
+	if err != nil || len(to) == 0 {
+		return err
+	}
+
+	subject := fmt.Sprintf("The preview deployment for %s/%s#%d failed on Porter", opts.RepoOwner, opts.RepoName, opts.PRNumber)
+
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "%s.\n\n", subject)
+
+	if opts.PRName != "" {
+		fmt.Fprintf(&text, "Pull request: %s\n", opts.PRName)
+	}
+
+	fmt.Fprintf(&text, "Namespace: %s\n", opts.Namespace)
+
+	if opts.Info != "" {
+		fmt.Fprintf(&text, "\n%s\n", opts.Info)
+	}
+
+	fmt.Fprintf(&text, "\nView the deployment: %s\n", opts.URL)
+
+	return p.sender.Send(&Message{
+		To:      to,
+		Subject: subject,
+		Text:    text.String(),
+	})
+}
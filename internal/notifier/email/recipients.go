@@ -0,0 +1,64 @@
+package email
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// listRecipients returns the emails of the users of a project whose preference, or the default
+// preference if they have not stored one, opts in to an email
+func listRecipients(
+	repo repository.Repository,
+	projectID uint,
+	optedIn func(pref *models.EmailPreference) bool,
+) ([]string, error) {
+	roles, err := repo.Project().ListProjectRoles(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, 0, len(roles))
+
+	for _, role := range roles {
+		userIDs = append(userIDs, role.UserID)
+	}
+
+	users, err := repo.User().ListUsersByIDs(userIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := repo.EmailPreference().ListEmailPreferencesByUserIDs(userIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	prefsByUser := make(map[uint]*models.EmailPreference)
+
+	for _, pref := range prefs {
+		prefsByUser[pref.UserID] = pref
+	}
+
+	res := make([]string, 0)
+
+	for _, user := range users {
+		pref, ok := prefsByUser[user.ID]
+
+		if !ok {
+			pref = models.DefaultEmailPreference(user.ID)
+		}
+
+		if optedIn(pref) {
+			res = append(res, user.Email)
+		}
+	}
+
+	return res, nil
+}
+
+func wantsImmediateFailures(pref *models.EmailPreference) bool {
+	return pref.ImmediateFailures
+}
@@ -0,0 +1,114 @@
+package email
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+}
+
+// Sender sends emails through an email provider
+type Sender interface {
+	Send(msg *Message) error
+}
+
+// SenderOpts configure the provider which sends emails. SMTP is used if an SMTP host is set,
+// and SendGrid otherwise.
+type SenderOpts struct {
+	SenderEmail string
+
+	SMTPHost     string
+	SMTPPort     uint
+	SMTPUsername string
+	SMTPPassword string
+
+	SendgridAPIKey string
+}
+
+// NewSender returns the sender which is configured by the options, or nil if no email
+// provider is configured
+func NewSender(opts *SenderOpts) Sender {
+	if opts.SenderEmail == "" {
+		return nil
+	}
+
+	if opts.SMTPHost != "" {
+		return &smtpSender{opts}
+	}
+
+	if opts.SendgridAPIKey != "" {
+		return &sendgridSender{opts}
+	}
+
+	return nil
+}
+
+type smtpSender struct {
+	opts *SenderOpts
+}
+
+func (s *smtpSender) Send(msg *Message) error {
+	var auth smtp.Auth
+
+	if s.opts.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", s.opts.SMTPUsername, s.opts.SMTPPassword, s.opts.SMTPHost)
+	}
+
+	var body strings.Builder
+
+	fmt.Fprintf(&body, "From: Porter Notifications <%s>\r\n", s.opts.SenderEmail)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", msg.Subject)
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+
+	addr := net.JoinHostPort(s.opts.SMTPHost, fmt.Sprintf("%d", s.opts.SMTPPort))
+
+	return smtp.SendMail(addr, auth, s.opts.SenderEmail, msg.To, []byte(body.String()))
+}
+
+type sendgridSender struct {
+	opts *SenderOpts
+}
+
+func (s *sendgridSender) Send(msg *Message) error {
+	request := sendgrid.GetRequest(s.opts.SendgridAPIKey, "/v3/mail/send", "https://api.sendgrid.com")
+	request.Method = "POST"
+
+	personalization := mail.NewPersonalization()
+
+	for _, to := range msg.To {
+		personalization.AddTos(mail.NewEmail("", to))
+	}
+
+	sgMail := mail.NewV3Mail()
+	sgMail.SetFrom(mail.NewEmail("Porter Notifications", s.opts.SenderEmail))
+	sgMail.Subject = msg.Subject
+	sgMail.AddPersonalizations(personalization)
+	sgMail.AddContent(mail.NewContent("text/plain", msg.Text))
+
+	request.Body = mail.GetRequestBody(sgMail)
+
+	resp, err := sendgrid.API(request)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sendgrid returned status code %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
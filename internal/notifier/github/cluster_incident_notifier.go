@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// maxExcerptLength keeps PR comments short enough to be readable
+const maxExcerptLength = 10000
+
+// ClusterIncidentNotifier comments on the pull request of a preview deployment when one of
+// its applications starts failing
+type ClusterIncidentNotifier struct {
+	client   *github.Client
+	owner    string
+	repo     string
+	prNumber int
+}
+
+func NewClusterIncidentNotifier(client *github.Client, owner, repo string, prNumber int) *ClusterIncidentNotifier {
+	return &ClusterIncidentNotifier{
+		client:   client,
+		owner:    owner,
+		repo:     repo,
+		prNumber: prNumber,
+	}
+}
+
+func (g *ClusterIncidentNotifier) NotifyOpened(incident *types.ClusterIncident, excerpt string, url string) error {
+	var body strings.Builder
+
+	if incident.Reason == types.ClusterIncidentReasonImagePull {
+		fmt.Fprintf(&body, "## :warning: `%s` cannot pull its image\n\n", incident.ReleaseName)
+	} else if incident.Reason == types.ClusterIncidentReasonLogAlert {
+		fmt.Fprintf(&body, "## :warning: `%s` matched a log alert rule\n\n", incident.ReleaseName)
+	} else {
+		fmt.Fprintf(&body, "## :warning: `%s` is crash looping\n\n", incident.ReleaseName)
+	}
+
+	fmt.Fprintf(&body, "%s\n\n", incident.Message)
+
+	if excerpt != "" {
+		if len(excerpt) > maxExcerptLength {
+			excerpt = excerpt[len(excerpt)-maxExcerptLength:]
+		}
+
+		fmt.Fprintf(&body, "<details>\n<summary>Logs</summary>\n\n```\n%s\n```\n</details>\n\n", excerpt)
+	}
+
+	fmt.Fprintf(&body, "[View the application on Porter](%s)", url)
+
+	bodyStr := body.String()
+
+	_, _, err := g.client.Issues.CreateComment(
+		context.Background(),
+		g.owner,
+		g.repo,
+		g.prNumber,
+		&github.IssueComment{
+			Body: &bodyStr,
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("error creating github comment for owner: %s repo %s prNumber: %d. Error: %w",
+			g.owner, g.repo, g.prNumber, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package pagerduty
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// SustainedCrashLoopDuration is how long a crash loop must stay open before it is paged, so
+// that restarts which recover on their own do not wake anyone up
+const SustainedCrashLoopDuration = 10 * time.Minute
+
+// IsSustained returns true if a crash loop which started at startedAt has been open for
+// SustainedCrashLoopDuration at the given time
+func IsSustained(startedAt, at time.Time) bool {
+	return !at.Before(startedAt.Add(SustainedCrashLoopDuration))
+}
+
+// CrashLoopNotifier opens PagerDuty incidents for sustained crash loops, and resolves them once
+// the crash loop is no longer detected
+type CrashLoopNotifier struct {
+	pdInts []*integrations.PagerDutyIntegration
+}
+
+func NewCrashLoopNotifier(pdInts ...*integrations.PagerDutyIntegration) *CrashLoopNotifier {
+	return &CrashLoopNotifier{pdInts}
+}
+
+func (c *CrashLoopNotifier) NotifySustained(incident *types.ClusterIncident, clusterName, url string) error {
+	if len(c.pdInts) == 0 {
+		return nil
+	}
+
+	return sendEvent(c.pdInts, &Event{
+		EventAction: actionTrigger,
+		DedupKey:    getIncidentDedupKey(incident.ID),
+		Payload: &EventPayload{
+			Summary: fmt.Sprintf(
+				"%s has been crash looping on Porter for over %d minutes: %s",
+				incident.ReleaseName,
+				int(SustainedCrashLoopDuration.Minutes()),
+				incident.Message,
+			),
+			Source:    clusterName,
+			Severity:  "critical",
+			Component: incident.ReleaseName,
+			Group:     incident.Namespace,
+			Class:     "crash_loop",
+			CustomDetails: map[string]string{
+				"cluster":    clusterName,
+				"namespace":  incident.Namespace,
+				"release":    incident.ReleaseName,
+				"pods":       strings.Join(incident.Pods, ", "),
+				"started_at": incident.StartedAt.Format(time.RFC3339),
+			},
+		},
+		Links: getLinks(url),
+	})
+}
+
+func (c *CrashLoopNotifier) NotifyResolved(incident *types.ClusterIncident) error {
+	if len(c.pdInts) == 0 {
+		return nil
+	}
+
+	return sendEvent(c.pdInts, &Event{
+		EventAction: actionResolve,
+		DedupKey:    getIncidentDedupKey(incident.ID),
+	})
+}
@@ -0,0 +1,57 @@
+package pagerduty
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// DeploymentNotifier opens a PagerDuty incident when the upgrade of a release fails, and resolves
+// it once the release is deployed successfully. Since integrations are explicitly mapped to the
+// releases they page for, the notification config of a release is not respected.
+type DeploymentNotifier struct {
+	pdInts []*integrations.PagerDutyIntegration
+}
+
+func NewDeploymentNotifier(pdInts ...*integrations.PagerDutyIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{pdInts}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if len(d.pdInts) == 0 {
+		return nil
+	}
+
+	dedupKey := getReleaseDedupKey(opts.ClusterID, opts.Namespace, opts.Name)
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		return sendEvent(d.pdInts, &Event{
+			EventAction: actionResolve,
+			DedupKey:    dedupKey,
+		})
+	case notifier.StatusHelmFailed:
+		return sendEvent(d.pdInts, &Event{
+			EventAction: actionTrigger,
+			DedupKey:    dedupKey,
+			Payload: &EventPayload{
+				Summary:   fmt.Sprintf("%s failed to deploy on Porter: %s", opts.Name, opts.Info),
+				Source:    opts.ClusterName,
+				Severity:  "error",
+				Component: opts.Name,
+				Group:     opts.Namespace,
+				Class:     "deployment_failed",
+				CustomDetails: map[string]string{
+					"cluster":   opts.ClusterName,
+					"namespace": opts.Namespace,
+					"release":   opts.Name,
+					"error":     opts.Info,
+				},
+			},
+			Links: getLinks(opts.URL),
+		})
+	}
+
+	return nil
+}
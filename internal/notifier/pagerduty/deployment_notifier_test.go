@@ -0,0 +1,68 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func TestDeploymentNotifier(t *testing.T) {
+	events := make([]*Event, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := &Event{}
+
+		if err := json.NewDecoder(r.Body).Decode(event); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		events = append(events, event)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	defer server.Close()
+
+	prevURL := eventsURL
+	eventsURL = server.URL
+	defer func() { eventsURL = prevURL }()
+
+	n := NewDeploymentNotifier(&integrations.PagerDutyIntegration{RoutingKey: []byte("key")})
+
+	opts := &notifier.NotifyOpts{
+		ClusterID:   1,
+		ClusterName: "production",
+		Name:        "web",
+		Namespace:   "default",
+		Status:      notifier.StatusHelmFailed,
+		Info:        "timed out waiting for the condition",
+		URL:         "https://dashboard.getporter.dev/applications/production/default/web",
+	}
+
+	if err := n.Notify(opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	opts.Status = notifier.StatusHelmDeployed
+
+	if err := n.Notify(opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d\n", len(events))
+	}
+
+	if events[0].EventAction != actionTrigger || events[0].RoutingKey != "key" || len(events[0].Links) != 1 {
+		t.Errorf("incorrect trigger event for failed deployment\n")
+	}
+
+	// the resolve event must use the dedup key of the trigger event to resolve its incident
+	if events[1].EventAction != actionResolve || events[1].DedupKey != events[0].DedupKey {
+		t.Errorf("incorrect resolve event for successful deployment\n")
+	}
+}
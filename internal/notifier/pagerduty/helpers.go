@@ -0,0 +1,131 @@
+package pagerduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// eventsURL is the endpoint of the PagerDuty Events API v2
+var eventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+const (
+	actionTrigger = "trigger"
+	actionResolve = "resolve"
+)
+
+// PagerDuty rejects events with summaries longer than 1024 characters
+const maxSummaryLength = 1024
+
+type Event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *EventPayload `json:"payload,omitempty"`
+	Links       []*EventLink  `json:"links,omitempty"`
+}
+
+type EventPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type EventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// ListRoutedIntegrations returns the PagerDuty integrations of a project which are mapped to
+// releases in the given cluster and namespace
+func ListRoutedIntegrations(
+	repo repository.PagerDutyIntegrationRepository,
+	projectID, clusterID uint,
+	namespace string,
+) ([]*integrations.PagerDutyIntegration, error) {
+	pdInts, err := repo.ListPagerDutyIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*integrations.PagerDutyIntegration, 0)
+
+	for _, pdInt := range pdInts {
+		if pdInt.Matches(clusterID, namespace) {
+			res = append(res, pdInt)
+		}
+	}
+
+	return res, nil
+}
+
+// sendEvent sends an event to the service of each PagerDuty integration, and returns the first
+// error which is encountered after every integration has been sent the event
+func sendEvent(pdInts []*integrations.PagerDutyIntegration, event *Event) error {
+	if event.Payload != nil && len(event.Payload.Summary) > maxSummaryLength {
+		event.Payload.Summary = event.Payload.Summary[0:maxSummaryLength-3] + "..."
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	var sendErr error
+
+	for _, pdInt := range pdInts {
+		event.RoutingKey = string(pdInt.RoutingKey)
+
+		payload, err := json.Marshal(event)
+
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Post(eventsURL, "application/json", bytes.NewReader(payload))
+
+		if err == nil {
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusAccepted {
+				err = fmt.Errorf("pagerduty events api returned status code %d", resp.StatusCode)
+			}
+		}
+
+		if err != nil && sendErr == nil {
+			sendErr = err
+		}
+	}
+
+	return sendErr
+}
+
+func getReleaseDedupKey(clusterID uint, namespace, name string) string {
+	return fmt.Sprintf("porter-release-%d-%s-%s", clusterID, namespace, name)
+}
+
+func getIncidentDedupKey(incidentID uint) string {
+	return fmt.Sprintf("porter-cluster-incident-%d", incidentID)
+}
+
+func getLinks(url string) []*EventLink {
+	if url == "" {
+		return nil
+	}
+
+	return []*EventLink{
+		{
+			Href: url,
+			Text: "View release on Porter",
+		},
+	}
+}
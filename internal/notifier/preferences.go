@@ -0,0 +1,97 @@
+package notifier
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// GetNotificationPreference returns the preference of a channel for a project if the user id
+// is 0, or for a user of the project otherwise. The default preference is returned if the
+// preference is not in the list.
+func GetNotificationPreference(
+	prefs []*models.NotificationPreference,
+	projectID, userID uint,
+	channel types.NotificationChannel,
+) *models.NotificationPreference {
+	for _, pref := range prefs {
+		if pref.UserID == userID && pref.Channel == channel {
+			return pref
+		}
+	}
+
+	return models.DefaultNotificationPreference(projectID, userID, channel)
+}
+
+// GetDeploymentEvent returns the notification event of a deployment status. Crashed pods are
+// reported as incidents.
+func GetDeploymentEvent(status DeploymentStatus) types.NotificationEvent {
+	switch status {
+	case StatusHelmDeployed:
+		return types.NotificationEventDeploySucceeded
+	case StatusHelmFailed:
+		return types.NotificationEventDeployFailed
+	default:
+		return types.NotificationEventIncident
+	}
+}
+
+// PreferenceNotifier only notifies the wrapped notifier of the deployment events which the
+// project's preference for a channel allows
+type PreferenceNotifier struct {
+	pref     *models.NotificationPreference
+	notifier Notifier
+}
+
+func NewPreferenceNotifier(
+	prefs []*models.NotificationPreference,
+	projectID uint,
+	channel types.NotificationChannel,
+	notifier Notifier,
+) Notifier {
+	return &PreferenceNotifier{
+		pref:     GetNotificationPreference(prefs, projectID, 0, channel),
+		notifier: notifier,
+	}
+}
+
+func (p *PreferenceNotifier) Notify(opts *NotifyOpts) error {
+	if !p.pref.Allows(GetDeploymentEvent(opts.Status), time.Now()) {
+		return nil
+	}
+
+	return p.notifier.Notify(opts)
+}
+
+// ProjectAllows returns true if the project's preference for a channel allows an event to be
+// sent now
+func ProjectAllows(
+	prefs []*models.NotificationPreference,
+	projectID uint,
+	channel types.NotificationChannel,
+	event types.NotificationEvent,
+) bool {
+	return GetNotificationPreference(prefs, projectID, 0, channel).Allows(event, time.Now())
+}
+
+// FilterUsers returns the users whose preferences for a channel in the project allow an event
+// to be sent to them now
+func FilterUsers(
+	prefs []*models.NotificationPreference,
+	projectID uint,
+	channel types.NotificationChannel,
+	event types.NotificationEvent,
+	users []*models.User,
+) []*models.User {
+	now := time.Now()
+	res := make([]*models.User, 0)
+
+	for _, user := range users {
+		if GetNotificationPreference(prefs, projectID, user.ID, channel).Allows(event, now) {
+			res = append(res, user)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import "github.com/porter-dev/porter/api/types"
+
+type PreviewDeploymentNotifier interface {
+	Notify(opts *PreviewDeploymentNotifyOpts) error
+}
+
+type PreviewDeploymentNotifyOpts struct {
+	// ProjectID is the id of the Porter project that this deployment belongs to
+	ProjectID uint
+
+	// ClusterID is the id of the Porter cluster that this deployment belongs to
+	ClusterID uint
+
+	// Namespace is the Kubernetes namespace of the preview deployment
+	Namespace string
+
+	// RepoOwner and RepoName identify the Git repository of the pull request
+	RepoOwner string
+	RepoName  string
+
+	// PRNumber and PRName identify the pull request of the preview deployment
+	PRNumber uint
+	PRName   string
+
+	// Status is the status that the preview deployment was finalized with
+	Status types.DeploymentStatus
+
+	// Info is any additional information about the status, such as the errors of a
+	// failed deployment
+	Info string
+
+	// URL links to the preview deployment on Porter
+	URL string
+
+	// Subdomain is the public URL of the preview deployment, if it has one
+	Subdomain string
+}
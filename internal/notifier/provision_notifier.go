@@ -0,0 +1,25 @@
+package notifier
+
+type ProvisionNotifier interface {
+	NotifyFailed(opts *ProvisionNotifyOpts) error
+}
+
+type ProvisionNotifyOpts struct {
+	// ProjectID is the id of the Porter project that the infra belongs to
+	ProjectID uint
+
+	// InfraID is the id of the infra which failed to provision
+	InfraID uint
+
+	// Kind is the kind of infra, such as "eks" or "ecr"
+	Kind string
+
+	// Operation is the kind of operation which failed, such as "create" or "delete"
+	Operation string
+
+	// Info is any additional information about the failure, such as the errored resources
+	Info string
+
+	// URL links to the infra on Porter
+	URL string
+}
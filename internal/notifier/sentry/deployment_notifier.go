@@ -0,0 +1,137 @@
+package sentry
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// CommitRange is the range of commits of a git repository which were deployed by an upgrade
+type CommitRange struct {
+	// The git repo in ${owner}/${repo} form
+	Repository string
+
+	Commit         string
+	PreviousCommit string
+}
+
+// GetCommitRange returns the commits which were deployed by an upgrade of a release from the
+// previous to the new image tag. Images of releases which are built from a git repository are
+// tagged with the commit they were built from, so nil is returned for releases which are not
+// built from a git repository.
+func GetCommitRange(rel *models.Release, prevTag, tag string) *CommitRange {
+	if rel == nil || rel.GitActionConfig == nil || rel.GitActionConfig.GitRepo == "" {
+		return nil
+	}
+
+	if tag == "" || tag == "latest" {
+		return nil
+	}
+
+	res := &CommitRange{
+		Repository: rel.GitActionConfig.GitRepo,
+		Commit:     tag,
+	}
+
+	if prevTag != tag && prevTag != "latest" {
+		res.PreviousCommit = prevTag
+	}
+
+	return res
+}
+
+// GetImageTag returns the image tag which is set in the values of a release
+func GetImageTag(values map[string]interface{}) string {
+	imageVals, ok := values["image"].(map[string]interface{})
+
+	if !ok || imageVals["tag"] == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", imageVals["tag"])
+}
+
+// DeploymentNotifier creates a Sentry release and marks it as deployed whenever a release is
+// upgraded. Releases which are built from a git repository are named after the deployed commit,
+// and other releases are named after their Helm revision.
+type DeploymentNotifier struct {
+	commits    *CommitRange
+	sentryInts []*integrations.SentryIntegration
+}
+
+func NewDeploymentNotifier(commits *CommitRange, sentryInts ...*integrations.SentryIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{commits, sentryInts}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if len(d.sentryInts) == 0 || opts.Status != notifier.StatusHelmDeployed {
+		return nil
+	}
+
+	version := fmt.Sprintf("%s@%d", opts.Name, opts.Version)
+
+	var refs []*ReleaseRef
+
+	if d.commits != nil {
+		version = d.commits.Commit
+
+		refs = []*ReleaseRef{
+			{
+				Repository:     d.commits.Repository,
+				Commit:         d.commits.Commit,
+				PreviousCommit: d.commits.PreviousCommit,
+			},
+		}
+	}
+
+	// the URL of a self-hosted Sentry instance is set by users
+	client := netguard.NewHTTPClient(time.Second * 5)
+
+	var notifyErr error
+
+	for _, sentryInt := range d.sentryInts {
+		err := notifySentry(client, sentryInt, version, refs, opts)
+
+		if err != nil && notifyErr == nil {
+			notifyErr = err
+		}
+	}
+
+	return notifyErr
+}
+
+func notifySentry(
+	client *http.Client,
+	sentryInt *integrations.SentryIntegration,
+	version string,
+	refs []*ReleaseRef,
+	opts *notifier.NotifyOpts,
+) error {
+	err := createRelease(client, sentryInt, &Release{
+		Version:  version,
+		Projects: sentryInt.GetSentryProjects(),
+		Refs:     refs,
+		URL:      opts.URL,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	environment := sentryInt.Environment
+
+	if environment == "" {
+		environment = opts.ClusterName
+	}
+
+	return createDeploy(client, sentryInt, version, &Deploy{
+		Environment: environment,
+		Name:        fmt.Sprintf("%s/%s version %d", opts.Namespace, opts.Name, opts.Version),
+		URL:         opts.URL,
+	})
+}
@@ -0,0 +1,138 @@
+package sentry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func TestDeploymentNotifier(t *testing.T) {
+	// the test server listens on the loopback address
+	t.Cleanup(netguard.AllowLocalTargets())
+
+	releases := make([]*Release, 0)
+	deploys := make([]*Deploy, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/0/organizations/porter/releases/":
+			release := &Release{}
+
+			if err := json.NewDecoder(r.Body).Decode(release); err != nil {
+				t.Fatalf("%v\n", err)
+			}
+
+			releases = append(releases, release)
+
+			// the repository is not connected to the organization
+			if len(release.Refs) != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+		case "/api/0/organizations/porter/releases/4f2a9c1/deploys/":
+			deploy := &Deploy{}
+
+			if err := json.NewDecoder(r.Body).Decode(deploy); err != nil {
+				t.Fatalf("%v\n", err)
+			}
+
+			deploys = append(deploys, deploy)
+
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	sentryInt := &integrations.SentryIntegration{
+		BaseURL:          server.URL,
+		OrganizationSlug: "porter",
+		SentryProjects:   "api,worker",
+		AuthToken:        []byte("token"),
+	}
+
+	rel := &models.Release{
+		GitActionConfig: &models.GitActionConfig{
+			GitRepo: "porter-dev/api",
+		},
+	}
+
+	commits := GetCommitRange(rel, "b81e0d7", "4f2a9c1")
+
+	err := NewDeploymentNotifier(commits, sentryInt).Notify(&notifier.NotifyOpts{
+		ClusterName: "production",
+		Name:        "web",
+		Namespace:   "default",
+		Status:      notifier.StatusHelmDeployed,
+		Version:     4,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the release is created with commits, and then without commits once Sentry rejects them
+	if len(releases) != 2 {
+		t.Fatalf("expected 2 release requests, got %d\n", len(releases))
+	}
+
+	if refs := releases[0].Refs; len(refs) != 1 || refs[0].Repository != "porter-dev/api" ||
+		refs[0].Commit != "4f2a9c1" || refs[0].PreviousCommit != "b81e0d7" {
+		t.Errorf("incorrect commit refs for release\n")
+	}
+
+	if releases[1].Version != "4f2a9c1" || len(releases[1].Projects) != 2 || len(releases[1].Refs) != 0 {
+		t.Errorf("incorrect release: expected version 4f2a9c1 in 2 projects without refs\n")
+	}
+
+	if len(deploys) != 1 || deploys[0].Environment != "production" {
+		t.Fatalf("expected 1 deploy to the production environment\n")
+	}
+
+	// failed upgrades do not create releases
+	err = NewDeploymentNotifier(commits, sentryInt).Notify(&notifier.NotifyOpts{
+		ClusterName: "production",
+		Name:        "web",
+		Namespace:   "default",
+		Status:      notifier.StatusHelmFailed,
+	})
+
+	if err != nil || len(releases) != 2 {
+		t.Errorf("expected no releases to be created for failed upgrades\n")
+	}
+}
+
+func TestGetCommitRange(t *testing.T) {
+	rel := &models.Release{
+		GitActionConfig: &models.GitActionConfig{
+			GitRepo: "porter-dev/api",
+		},
+	}
+
+	if commits := GetCommitRange(rel, "latest", "4f2a9c1"); commits == nil || commits.PreviousCommit != "" {
+		t.Errorf("expected commit range without previous commit\n")
+	}
+
+	if commits := GetCommitRange(&models.Release{}, "b81e0d7", "4f2a9c1"); commits != nil {
+		t.Errorf("expected no commit range for release which is not built from a git repository\n")
+	}
+
+	if commits := GetCommitRange(rel, "b81e0d7", "latest"); commits != nil {
+		t.Errorf("expected no commit range for latest tag\n")
+	}
+}
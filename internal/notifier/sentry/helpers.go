@@ -0,0 +1,181 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// DefaultBaseURL is the URL of Sentry's SaaS offering, which is used for integrations that do
+// not set the URL of a self-hosted Sentry instance
+const DefaultBaseURL = "https://sentry.io"
+
+type Release struct {
+	Version  string        `json:"version"`
+	Projects []string      `json:"projects"`
+	Refs     []*ReleaseRef `json:"refs,omitempty"`
+	URL      string        `json:"url,omitempty"`
+}
+
+// ReleaseRef associates a release with the commits of a repository, which must be connected to
+// the Sentry organization, since the previous release
+type ReleaseRef struct {
+	Repository     string `json:"repository"`
+	Commit         string `json:"commit"`
+	PreviousCommit string `json:"previousCommit,omitempty"`
+}
+
+type Deploy struct {
+	Environment string `json:"environment"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// ValidateAuthToken returns an error if the auth token cannot access the Sentry organization.
+// The base URL is set by users for self-hosted Sentry instances, so it is rejected if it
+// resolves to an address which is not public.
+func ValidateAuthToken(ctx context.Context, baseURL, orgSlug, authToken string) error {
+	if err := netguard.ValidateURL(ctx, getBaseURL(baseURL)); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/api/0/organizations/%s/", getBaseURL(baseURL), url.PathEscape(orgSlug)),
+		nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken))
+
+	client := netguard.NewHTTPClient(time.Second * 5)
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid auth token for organization %s", orgSlug)
+	} else if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("organization %s not found", orgSlug)
+	} else if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("sentry api returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// createRelease creates a release in the Sentry projects of an integration. Sentry rejects
+// commit refs of repositories which are not connected to the organization, in which case the
+// release is created without commits.
+func createRelease(client *http.Client, sentryInt *integrations.SentryIntegration, release *Release) error {
+	statusCode, err := postJSON(
+		client,
+		sentryInt,
+		fmt.Sprintf("/api/0/organizations/%s/releases/", url.PathEscape(sentryInt.OrganizationSlug)),
+		release,
+	)
+
+	if err == nil && statusCode == http.StatusBadRequest && len(release.Refs) != 0 {
+		withoutRefs := *release
+		withoutRefs.Refs = nil
+
+		statusCode, err = postJSON(
+			client,
+			sentryInt,
+			fmt.Sprintf("/api/0/organizations/%s/releases/", url.PathEscape(sentryInt.OrganizationSlug)),
+			&withoutRefs,
+		)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Sentry returns 208 if the release already exists, such as when the same commit is deployed
+	// to several applications
+	if statusCode != http.StatusCreated && statusCode != http.StatusOK && statusCode != http.StatusAlreadyReported {
+		return fmt.Errorf("sentry releases api returned status code %d", statusCode)
+	}
+
+	return nil
+}
+
+// createDeploy marks a release as deployed to an environment
+func createDeploy(client *http.Client, sentryInt *integrations.SentryIntegration, version string, deploy *Deploy) error {
+	statusCode, err := postJSON(
+		client,
+		sentryInt,
+		fmt.Sprintf(
+			"/api/0/organizations/%s/releases/%s/deploys/",
+			url.PathEscape(sentryInt.OrganizationSlug),
+			url.PathEscape(version),
+		),
+		deploy,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusCreated {
+		return fmt.Errorf("sentry deploys api returned status code %d", statusCode)
+	}
+
+	return nil
+}
+
+func postJSON(client *http.Client, sentryInt *integrations.SentryIntegration, path string, data interface{}) (int, error) {
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		getBaseURL(sentryInt.BaseURL)+path,
+		bytes.NewReader(payload),
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", string(sentryInt.AuthToken)))
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func getBaseURL(baseURL string) string {
+	if baseURL == "" {
+		return DefaultBaseURL
+	}
+
+	return strings.TrimSuffix(baseURL, "/")
+}
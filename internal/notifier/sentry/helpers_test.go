@@ -0,0 +1,32 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+func TestValidateAuthTokenRejectsNonPublicURLs(t *testing.T) {
+	var received bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+
+	defer server.Close()
+
+	// the test server listens on the loopback address
+	err := ValidateAuthToken(context.Background(), server.URL, "porter", "token")
+
+	if !errors.Is(err, netguard.ErrNonPublicTarget) {
+		t.Errorf("expected ErrNonPublicTarget, got %v\n", err)
+	}
+
+	if received {
+		t.Errorf("expected the request not to reach the server\n")
+	}
+}
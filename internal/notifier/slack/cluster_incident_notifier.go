@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// maxExcerptLength keeps the excerpt under Slack's limit of 3000 characters per text block
+const maxExcerptLength = 2800
+
+type ClusterIncidentNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewClusterIncidentNotifier(slackInts ...*integrations.SlackIntegration) *ClusterIncidentNotifier {
+	return &ClusterIncidentNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *ClusterIncidentNotifier) NotifyOpened(incident *types.ClusterIncident, excerpt string, url string) error {
+	res := []*SlackBlock{}
+
+	topSectionMarkdwn := fmt.Sprintf(
+		":warning: Your application %s is failing on Porter. <%s|View the application.>",
+		"`"+incident.ReleaseName+"`",
+		url,
+	)
+
+	if incident.Reason == types.ClusterIncidentReasonImagePull {
+		topSectionMarkdwn = fmt.Sprintf(
+			":warning: Your application %s cannot pull its image on Porter. <%s|View the application.>",
+			"`"+incident.ReleaseName+"`",
+			url,
+		)
+	} else if incident.Reason == types.ClusterIncidentReasonLogAlert {
+		topSectionMarkdwn = fmt.Sprintf(
+			":warning: Your application %s matched a log alert rule on Porter. <%s|View the application.>",
+			"`"+incident.ReleaseName+"`",
+			url,
+		)
+	}
+
+	res = append(
+		res,
+		getMarkdownBlock(topSectionMarkdwn),
+		getDividerBlock(),
+		getMarkdownBlock(fmt.Sprintf("*Namespace:* %s", "`"+incident.Namespace+"`")),
+		getMarkdownBlock(fmt.Sprintf("*Name:* %s", "`"+incident.ReleaseName+"`")),
+		getMarkdownBlock(fmt.Sprintf(
+			"*Started at:* <!date^%d^ {date_num} {time_secs}| %s>",
+			incident.StartedAt.Unix(),
+			incident.StartedAt.Format("2006-01-02 15:04:05 UTC"),
+		)),
+		getMarkdownBlock(fmt.Sprintf("```\n%s\n```", incident.Message)),
+	)
+
+	if excerpt != "" {
+		if len(excerpt) > maxExcerptLength {
+			excerpt = excerpt[len(excerpt)-maxExcerptLength:]
+		}
+
+		res = append(
+			res,
+			getMarkdownBlock("*Logs:*"),
+			getMarkdownBlock(fmt.Sprintf("```\n%s\n```", excerpt)),
+		)
+	}
+
+	slackPayload := &SlackPayload{
+		Blocks: res,
+	}
+
+	payload, err := json.Marshal(slackPayload)
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, slackInt := range s.slackInts {
+		_, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,12 +1,53 @@
 package slack
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
+	"github.com/porter-dev/porter/internal/models/integrations"
 	"github.com/porter-dev/porter/internal/notifier"
 )
 
+// postBlocks posts a message to the webhook of each Slack integration, and returns the first
+// error which is encountered after every integration has been posted to
+func postBlocks(slackInts []*integrations.SlackIntegration, blocks []*SlackBlock) error {
+	payload, err := json.Marshal(&SlackPayload{
+		Blocks: blocks,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	var postErr error
+
+	for _, slackInt := range slackInts {
+		resp, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+
+		if err == nil {
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("slack webhook returned status code %d", resp.StatusCode)
+			}
+		}
+
+		if err != nil && postErr == nil {
+			postErr = err
+		}
+	}
+
+	return postErr
+}
+
 func getSlackBlocks(opts *notifier.NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 	res := []*SlackBlock{}
 
@@ -140,3 +181,12 @@ func getFailedInfoMessage(opts *notifier.NotifyOpts) string {
 
 	return fmt.Sprintf("```\n%s\n```", info)
 }
+
+// getTruncatedCodeBlock formats text as a code block, truncated to the first 500 characters
+func getTruncatedCodeBlock(text string) string {
+	if len(text) > 500 {
+		text = text[0:500] + "..."
+	}
+
+	return fmt.Sprintf("```\n%s\n```", text)
+}
@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type PreviewDeploymentNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewPreviewDeploymentNotifier(slackInts ...*integrations.SlackIntegration) *PreviewDeploymentNotifier {
+	return &PreviewDeploymentNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *PreviewDeploymentNotifier) Notify(opts *notifier.PreviewDeploymentNotifyOpts) error {
+	if len(s.slackInts) == 0 {
+		return nil
+	}
+
+	pr := fmt.Sprintf("`%s/%s#%d`", opts.RepoOwner, opts.RepoName, opts.PRNumber)
+
+	var md string
+
+	switch opts.Status {
+	case types.DeploymentStatusCreating:
+		md = fmt.Sprintf(
+			":hammer_and_wrench: The preview deployment for %s is being created on Porter. <%s|View the deployment.>",
+			pr,
+			opts.URL,
+		)
+	case types.DeploymentStatusFailed:
+		md = fmt.Sprintf(
+			":x: The preview deployment for %s failed on Porter. <%s|View the deployment.>",
+			pr,
+			opts.URL,
+		)
+	case types.DeploymentStatusInactive:
+		md = fmt.Sprintf(
+			":wastebasket: The preview deployment for %s was deleted from Porter.",
+			pr,
+		)
+	default:
+		md = fmt.Sprintf(
+			":rocket: The preview deployment for %s is ready on Porter! <%s|View the deployment.>",
+			pr,
+			opts.URL,
+		)
+	}
+
+	res := []*SlackBlock{
+		getMarkdownBlock(md),
+		getDividerBlock(),
+	}
+
+	if opts.PRName != "" {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*Pull request:* %s", opts.PRName)))
+	}
+
+	res = append(res, getMarkdownBlock(fmt.Sprintf("*Namespace:* %s", "`"+opts.Namespace+"`")))
+
+	if opts.Subdomain != "" {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*URL:* <%s>", opts.Subdomain)))
+	}
+
+	if opts.Status == types.DeploymentStatusFailed && opts.Info != "" {
+		res = append(res, getMarkdownBlock(getTruncatedCodeBlock(opts.Info)))
+	}
+
+	return postBlocks(s.slackInts, res)
+}
@@ -0,0 +1,41 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type ProvisionNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewProvisionNotifier(slackInts ...*integrations.SlackIntegration) *ProvisionNotifier {
+	return &ProvisionNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *ProvisionNotifier) NotifyFailed(opts *notifier.ProvisionNotifyOpts) error {
+	if len(s.slackInts) == 0 {
+		return nil
+	}
+
+	res := []*SlackBlock{
+		getMarkdownBlock(fmt.Sprintf(
+			":x: Your %s infrastructure failed to %s on Porter. <%s|View the infrastructure.>",
+			"`"+opts.Kind+"`",
+			opts.Operation,
+			opts.URL,
+		)),
+		getDividerBlock(),
+		getMarkdownBlock(fmt.Sprintf("*Infrastructure ID:* %d", opts.InfraID)),
+	}
+
+	if opts.Info != "" {
+		res = append(res, getMarkdownBlock(getTruncatedCodeBlock(opts.Info)))
+	}
+
+	return postBlocks(s.slackInts, res)
+}
@@ -0,0 +1,63 @@
+package slack
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ListRoutedIntegrations lists the Slack integrations of a project which should be notified
+// of an event in the given cluster and namespace
+func ListRoutedIntegrations(
+	repo repository.SlackIntegrationRepository,
+	projectID uint,
+	event types.SlackEvent,
+	clusterID uint,
+	namespace string,
+) ([]*integrations.SlackIntegration, error) {
+	slackInts, err := repo.ListSlackIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := repo.ListSlackRoutingRules(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return RouteIntegrations(slackInts, rules, event, clusterID, namespace), nil
+}
+
+// RouteIntegrations filters Slack integrations by their routing rules. Integrations without
+// routing rules receive every event, while integrations with routing rules only receive the
+// events which match one of their rules.
+func RouteIntegrations(
+	slackInts []*integrations.SlackIntegration,
+	rules []*integrations.SlackRoutingRule,
+	event types.SlackEvent,
+	clusterID uint,
+	namespace string,
+) []*integrations.SlackIntegration {
+	hasRules := make(map[uint]bool)
+	matches := make(map[uint]bool)
+
+	for _, rule := range rules {
+		hasRules[rule.SlackIntegrationID] = true
+
+		if rule.Matches(event, clusterID, namespace) {
+			matches[rule.SlackIntegrationID] = true
+		}
+	}
+
+	res := make([]*integrations.SlackIntegration, 0)
+
+	for _, slackInt := range slackInts {
+		if !hasRules[slackInt.ID] || matches[slackInt.ID] {
+			res = append(res, slackInt)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,60 @@
+package slack_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"gorm.io/gorm"
+)
+
+func TestRouteIntegrations(t *testing.T) {
+	slackInts := []*integrations.SlackIntegration{
+		{Model: gorm.Model{ID: 1}, Channel: "everything"},
+		{Model: gorm.Model{ID: 2}, Channel: "previews"},
+		{Model: gorm.Model{ID: 3}, Channel: "production-incidents"},
+	}
+
+	rules := []*integrations.SlackRoutingRule{
+		{SlackIntegrationID: 2, Event: types.SlackEventPreviewDeployment},
+		{SlackIntegrationID: 3, Event: types.SlackEventIncident, ClusterID: 1, Namespace: "production"},
+	}
+
+	tests := []struct {
+		event     types.SlackEvent
+		clusterID uint
+		namespace string
+		expected  []uint
+	}{
+		{types.SlackEventReleaseUpgrade, 1, "production", []uint{1}},
+		{types.SlackEventPreviewDeployment, 2, "pr-1", []uint{1, 2}},
+		{types.SlackEventIncident, 1, "production", []uint{1, 3}},
+		{types.SlackEventIncident, 1, "staging", []uint{1}},
+		{types.SlackEventIncident, 2, "production", []uint{1}},
+	}
+
+	for _, test := range tests {
+		res := slack.RouteIntegrations(slackInts, rules, test.event, test.clusterID, test.namespace)
+
+		ids := make([]uint, 0)
+
+		for _, slackInt := range res {
+			ids = append(ids, slackInt.ID)
+		}
+
+		if len(ids) != len(test.expected) {
+			t.Errorf("%s in cluster %d, namespace %s: expected integrations %v, got %v\n",
+				test.event, test.clusterID, test.namespace, test.expected, ids)
+			continue
+		}
+
+		for i := range ids {
+			if ids[i] != test.expected[i] {
+				t.Errorf("%s in cluster %d, namespace %s: expected integrations %v, got %v\n",
+					test.event, test.clusterID, test.namespace, test.expected, ids)
+				break
+			}
+		}
+	}
+}
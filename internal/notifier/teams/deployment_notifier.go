@@ -0,0 +1,69 @@
+package teams
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type DeploymentNotifier struct {
+	teamsInts []*integrations.TeamsIntegration
+	Config    *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, teamsInts ...*integrations.TeamsIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		teamsInts: teamsInts,
+		Config:    conf,
+	}
+}
+
+func (t *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if len(t.teamsInts) == 0 {
+		return nil
+	}
+
+	if t.Config != nil {
+		if !t.Config.Enabled {
+			return nil
+		}
+		if opts.Status == notifier.StatusHelmDeployed && !t.Config.Success {
+			return nil
+		}
+		if (opts.Status == notifier.StatusPodCrashed || opts.Status == notifier.StatusHelmFailed) && !t.Config.Failure {
+			return nil
+		}
+	}
+
+	var card *AdaptiveCard
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		card = newCard(fmt.Sprintf("Your application %s was successfully updated on Porter", opts.Name), colorGood, opts.URL)
+	case notifier.StatusHelmFailed:
+		card = newCard(fmt.Sprintf("Your application %s failed to deploy on Porter", opts.Name), colorAttention, opts.URL)
+	case notifier.StatusPodCrashed:
+		card = newCard(fmt.Sprintf("Your application %s crashed on Porter", opts.Name), colorAttention, opts.URL)
+	default:
+		return nil
+	}
+
+	facts := getFactSet(
+		getFact("Name", opts.Name),
+		getFact("Namespace", opts.Namespace),
+	)
+
+	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed {
+		facts.Facts = append(facts.Facts, getFact("Version", fmt.Sprintf("%d", opts.Version)))
+	}
+
+	card.Body = append(card.Body, facts)
+
+	if opts.Status != notifier.StatusHelmDeployed && opts.Info != "" {
+		card.Body = append(card.Body, getCodeBlock(opts.Info))
+	}
+
+	return postCard(t.teamsInts, card)
+}
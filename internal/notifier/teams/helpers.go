@@ -0,0 +1,161 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// adaptive card text colors
+const (
+	colorGood      = "Good"
+	colorAttention = "Attention"
+	colorWarning   = "Warning"
+)
+
+// Teams limits the size of a message to roughly 28 KB, so long error messages are truncated
+const maxCodeBlockLength = 2000
+
+type TeamsMessage struct {
+	Type        string             `json:"type"`
+	Attachments []*TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string        `json:"contentType"`
+	Content     *AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []*AdaptiveCardBlock  `json:"body"`
+	Actions []*AdaptiveCardAction `json:"actions,omitempty"`
+}
+
+type AdaptiveCardBlock struct {
+	Type     string              `json:"type"`
+	Text     string              `json:"text,omitempty"`
+	Size     string              `json:"size,omitempty"`
+	Weight   string              `json:"weight,omitempty"`
+	Color    string              `json:"color,omitempty"`
+	FontType string              `json:"fontType,omitempty"`
+	Wrap     bool                `json:"wrap,omitempty"`
+	Facts    []*AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// postCard posts an adaptive card to the webhook of each Teams integration, and returns the first
+// error which is encountered after every integration has been posted to
+func postCard(teamsInts []*integrations.TeamsIntegration, card *AdaptiveCard) error {
+	payload, err := json.Marshal(&TeamsMessage{
+		Type: "message",
+		Attachments: []*TeamsAttachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content:     card,
+			},
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	var postErr error
+
+	for _, teamsInt := range teamsInts {
+		resp, err := client.Post(string(teamsInt.Webhook), "application/json", bytes.NewReader(payload))
+
+		if err == nil {
+			resp.Body.Close()
+
+			// Power Automate workflows respond with 202 Accepted, while connector webhooks respond with 200
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+				err = fmt.Errorf("teams webhook returned status code %d", resp.StatusCode)
+			}
+		}
+
+		if err != nil && postErr == nil {
+			postErr = err
+		}
+	}
+
+	return postErr
+}
+
+func newCard(title, color, url string) *AdaptiveCard {
+	card := &AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []*AdaptiveCardBlock{
+			{
+				Type:   "TextBlock",
+				Text:   title,
+				Size:   "Medium",
+				Weight: "Bolder",
+				Color:  color,
+				Wrap:   true,
+			},
+		},
+	}
+
+	if url != "" {
+		card.Actions = append(card.Actions, &AdaptiveCardAction{
+			Type:  "Action.OpenUrl",
+			Title: "View on Porter",
+			URL:   url,
+		})
+	}
+
+	return card
+}
+
+func getFactSet(facts ...*AdaptiveCardFact) *AdaptiveCardBlock {
+	return &AdaptiveCardBlock{
+		Type:  "FactSet",
+		Facts: facts,
+	}
+}
+
+func getFact(title, value string) *AdaptiveCardFact {
+	return &AdaptiveCardFact{
+		Title: title,
+		Value: value,
+	}
+}
+
+// getCodeBlock formats text as a monospace text block, keeping the beginning of the text if
+// it is too long
+func getCodeBlock(text string) *AdaptiveCardBlock {
+	if len(text) > maxCodeBlockLength {
+		text = text[0:maxCodeBlockLength] + "..."
+	}
+
+	return &AdaptiveCardBlock{
+		Type:     "TextBlock",
+		Text:     text,
+		FontType: "Monospace",
+		Wrap:     true,
+	}
+}
@@ -0,0 +1,60 @@
+package teams
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type PreviewDeploymentNotifier struct {
+	teamsInts []*integrations.TeamsIntegration
+}
+
+func NewPreviewDeploymentNotifier(teamsInts ...*integrations.TeamsIntegration) *PreviewDeploymentNotifier {
+	return &PreviewDeploymentNotifier{
+		teamsInts: teamsInts,
+	}
+}
+
+func (t *PreviewDeploymentNotifier) Notify(opts *notifier.PreviewDeploymentNotifyOpts) error {
+	if len(t.teamsInts) == 0 {
+		return nil
+	}
+
+	pr := fmt.Sprintf("%s/%s#%d", opts.RepoOwner, opts.RepoName, opts.PRNumber)
+
+	var card *AdaptiveCard
+
+	switch opts.Status {
+	case types.DeploymentStatusCreating:
+		card = newCard(fmt.Sprintf("The preview deployment for %s is being created on Porter", pr), colorWarning, opts.URL)
+	case types.DeploymentStatusFailed:
+		card = newCard(fmt.Sprintf("The preview deployment for %s failed on Porter", pr), colorAttention, opts.URL)
+	case types.DeploymentStatusInactive:
+		card = newCard(fmt.Sprintf("The preview deployment for %s was deleted from Porter", pr), colorWarning, "")
+	default:
+		card = newCard(fmt.Sprintf("The preview deployment for %s is ready on Porter", pr), colorGood, opts.URL)
+	}
+
+	facts := getFactSet()
+
+	if opts.PRName != "" {
+		facts.Facts = append(facts.Facts, getFact("Pull request", opts.PRName))
+	}
+
+	facts.Facts = append(facts.Facts, getFact("Namespace", opts.Namespace))
+
+	if opts.Subdomain != "" {
+		facts.Facts = append(facts.Facts, getFact("URL", opts.Subdomain))
+	}
+
+	card.Body = append(card.Body, facts)
+
+	if opts.Status == types.DeploymentStatusFailed && opts.Info != "" {
+		card.Body = append(card.Body, getCodeBlock(opts.Info))
+	}
+
+	return postCard(t.teamsInts, card)
+}
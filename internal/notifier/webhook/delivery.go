@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/jobqueue"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/netguard"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	// jobKindWebhookDelivery sends a delivery to the URL of its webhook subscription
+	jobKindWebhookDelivery = "webhook-delivery"
+
+	// deliveries are attempted for roughly a day with the backoff of the job queue
+	maxDeliveryAttempts = 12
+)
+
+// deliveryClient sends deliveries, and refuses to connect to addresses which are not public
+var deliveryClient = netguard.NewHTTPClient(10 * time.Second)
+
+const (
+	HeaderEvent     = "X-Porter-Event"
+	HeaderDelivery  = "X-Porter-Delivery"
+	HeaderTimestamp = "X-Porter-Timestamp"
+
+	// HeaderSignature is the hex-encoded HMAC-SHA256 of the timestamp header and the body,
+	// joined by a period, prefixed by "sha256="
+	HeaderSignature = "X-Porter-Signature-256"
+)
+
+type deliveryPayload struct {
+	ProjectID  uint `json:"project_id"`
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// Dispatch creates a delivery of an event for every webhook subscription of the project which
+// subscribes to the event. Deliveries are sent by the job queue, so Dispatch does not wait for
+// the subscribed URLs to respond.
+func Dispatch(repo repository.Repository, projectID uint, event types.WebhookEvent, data interface{}) error {
+	subs, err := repo.WebhookSubscription().ListWebhookSubscriptionsByProjectID(projectID)
+
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+
+	for _, sub := range subs {
+		if !sub.Subscribes(event) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(&types.WebhookPayload{
+				Event:     event,
+				ProjectID: projectID,
+				Timestamp: time.Now().UTC(),
+				Data:      data,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := repo.WebhookSubscription().CreateWebhookDeliveryWithJob(&models.WebhookDelivery{
+			ProjectID:             projectID,
+			WebhookSubscriptionID: sub.ID,
+			Event:                 event,
+			Status:                types.WebhookDeliveryPending,
+			Payload:               payload,
+		}, newDeliveryJob)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newDeliveryJob returns a job which sends a delivery. The job is created directly rather than
+// through the job queue, so that events can be dispatched by processes which do not run the
+// queue, like the provisioner and the workers.
+func newDeliveryJob(delivery *models.WebhookDelivery) (*models.BackgroundJob, error) {
+	data, err := json.Marshal(&deliveryPayload{
+		ProjectID:  delivery.ProjectID,
+		DeliveryID: delivery.ID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BackgroundJob{
+		ProjectID:   delivery.ProjectID,
+		Kind:        jobKindWebhookDelivery,
+		Payload:     data,
+		Status:      types.BackgroundJobQueued,
+		MaxAttempts: maxDeliveryAttempts,
+		RunAfter:    time.Now().UTC(),
+	}, nil
+}
+
+// RegisterJobHandlers registers the handler of the jobs which send webhook deliveries
+func RegisterJobHandlers(queue *jobqueue.Queue, repo repository.Repository) {
+	queue.Register(jobKindWebhookDelivery, func(ctx context.Context, job *models.BackgroundJob) error {
+		return runDeliveryJob(ctx, repo, job)
+	})
+}
+
+func runDeliveryJob(ctx context.Context, repo repository.Repository, job *models.BackgroundJob) error {
+	payload := &deliveryPayload{}
+
+	if err := jobqueue.DecodePayload(job, payload); err != nil {
+		return err
+	}
+
+	delivery, err := repo.WebhookSubscription().ReadWebhookDelivery(payload.ProjectID, payload.DeliveryID)
+
+	if err != nil {
+		return fmt.Errorf("error reading webhook delivery: %w", err)
+	}
+
+	sub, err := repo.WebhookSubscription().ReadWebhookSubscription(delivery.ProjectID, delivery.WebhookSubscriptionID)
+
+	if err != nil {
+		return fmt.Errorf("error reading webhook subscription: %w", err)
+	}
+
+	delivery.Attempts = job.Attempts
+	delivery.ResponseCode, delivery.LatencyMs, err = send(ctx, sub, delivery)
+
+	if err == nil {
+		delivery.Status = types.WebhookDeliverySucceeded
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+
+		if job.Attempts >= job.MaxAttempts {
+			delivery.Status = types.WebhookDeliveryFailed
+		}
+	}
+
+	if _, updateErr := repo.WebhookSubscription().UpdateWebhookDelivery(delivery); updateErr != nil && err == nil {
+		return fmt.Errorf("error updating webhook delivery: %w", updateErr)
+	}
+
+	return err
+}
+
+// send posts a delivery to the URL of its subscription, and returns the status code of the
+// response and the latency of the request in milliseconds. Response bodies are not read, since
+// they may contain data of the receiving service which shouldn't be shown to the project.
+// Responses which are not 2xx return an error, so that the delivery is retried.
+func send(ctx context.Context, sub *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, int64, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Porter-Webhook")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, fmt.Sprintf("%d", delivery.ID))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(sub.Secret, timestamp, delivery.Payload))
+
+	start := time.Now()
+
+	resp, err := deliveryClient.Do(req)
+
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		return 0, latency, err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, latency, fmt.Errorf("webhook returned status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, latency, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of a timestamp and a body, which receivers can
+// compute to verify that a request was sent by Porter. The timestamp is signed so that
+// receivers can reject requests which are replayed later.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
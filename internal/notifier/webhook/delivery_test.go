@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// allowLocalTargets lets deliveries be sent to the local test servers of a test
+func allowLocalTargets(t *testing.T) {
+	t.Cleanup(netguard.AllowLocalTargets())
+}
+
+func TestSend(t *testing.T) {
+	allowLocalTargets(t)
+
+	secret := []byte("secret")
+	payload := []byte(`{"event":"deploy"}`)
+
+	var statusCode int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		expected := "sha256=" + Sign(secret, r.Header.Get(HeaderTimestamp), body)
+
+		if got := r.Header.Get(HeaderSignature); got != expected {
+			t.Errorf("incorrect signature: expected %s, got %s\n", expected, got)
+		}
+
+		if got := r.Header.Get(HeaderDelivery); got != "7" {
+			t.Errorf("incorrect delivery header: expected 7, got %s\n", got)
+		}
+
+		w.WriteHeader(statusCode)
+		w.Write([]byte("received"))
+	}))
+
+	defer server.Close()
+
+	sub := &models.WebhookSubscription{
+		URL:    server.URL,
+		Secret: secret,
+	}
+
+	delivery := &models.WebhookDelivery{
+		Event:   types.WebhookEventDeploy,
+		Payload: payload,
+	}
+
+	delivery.ID = 7
+
+	statusCode = http.StatusOK
+
+	code, latency, err := send(context.Background(), sub, delivery)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if code != http.StatusOK {
+		t.Errorf("incorrect response code: expected 200, got %d\n", code)
+	}
+
+	if latency < 0 {
+		t.Errorf("incorrect latency: expected a non-negative latency, got %d\n", latency)
+	}
+
+	// responses which are not 2xx should fail the attempt, so that the delivery is retried
+	statusCode = http.StatusInternalServerError
+
+	code, _, err = send(context.Background(), sub, delivery)
+
+	if err == nil {
+		t.Errorf("expected error for status code %d\n", code)
+	}
+
+	if code != http.StatusInternalServerError {
+		t.Errorf("incorrect response code: expected 500, got %d\n", code)
+	}
+}
+
+func TestSendRejectsNonPublicTargets(t *testing.T) {
+	var received bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+
+	defer server.Close()
+
+	sub := &models.WebhookSubscription{
+		URL:    server.URL,
+		Secret: []byte("secret"),
+	}
+
+	delivery := &models.WebhookDelivery{
+		Event:   types.WebhookEventDeploy,
+		Payload: []byte(`{}`),
+	}
+
+	// the test server listens on the loopback address, which is checked when dialing even
+	// though the subscription was never validated
+	_, _, err := send(context.Background(), sub, delivery)
+
+	if !errors.Is(err, netguard.ErrNonPublicTarget) {
+		t.Errorf("expected ErrNonPublicTarget, got %v\n", err)
+	}
+
+	if received {
+		t.Errorf("expected the delivery not to reach the server\n")
+	}
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// DeploymentNotifier dispatches deploy events for the deployments of a project. Unlike the
+// chat notifiers, it does not respect the notification config of a release, since webhooks are
+// integrations rather than alerts.
+type DeploymentNotifier struct {
+	repo repository.Repository
+}
+
+func NewDeploymentNotifier(repo repository.Repository) *DeploymentNotifier {
+	return &DeploymentNotifier{repo}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	var status string
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		status = "succeeded"
+	case notifier.StatusHelmFailed:
+		status = "failed"
+	default:
+		// crashed pods are dispatched as incident events
+		return nil
+	}
+
+	return Dispatch(d.repo, opts.ProjectID, types.WebhookEventDeploy, &types.WebhookDeployData{
+		ClusterID:   opts.ClusterID,
+		ClusterName: opts.ClusterName,
+		Name:        opts.Name,
+		Namespace:   opts.Namespace,
+		Status:      status,
+		Version:     opts.Version,
+		Info:        opts.Info,
+		URL:         opts.URL,
+	})
+}
+
+// IncidentNotifier dispatches incident events for the incidents reported by the agent of a
+// cluster
+type IncidentNotifier struct {
+	repo      repository.Repository
+	projectID uint
+}
+
+func NewIncidentNotifier(repo repository.Repository, projectID uint) *IncidentNotifier {
+	return &IncidentNotifier{repo, projectID}
+}
+
+func (i *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	return Dispatch(i.repo, i.projectID, types.WebhookEventIncident, &types.WebhookIncidentData{
+		Action:   "opened",
+		URL:      url,
+		Incident: incident,
+	})
+}
+
+func (i *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	return Dispatch(i.repo, i.projectID, types.WebhookEventIncident, &types.WebhookIncidentData{
+		Action:   "resolved",
+		URL:      url,
+		Incident: incident,
+	})
+}
+
+// ClusterIncidentNotifier dispatches incident events for the incidents detected by Porter
+type ClusterIncidentNotifier struct {
+	repo repository.Repository
+}
+
+func NewClusterIncidentNotifier(repo repository.Repository) *ClusterIncidentNotifier {
+	return &ClusterIncidentNotifier{repo}
+}
+
+func (c *ClusterIncidentNotifier) NotifyOpened(incident *types.ClusterIncident, excerpt string, url string) error {
+	return Dispatch(c.repo, incident.ProjectID, types.WebhookEventIncident, &types.WebhookIncidentData{
+		Action:          "opened",
+		URL:             url,
+		ClusterIncident: incident,
+	})
+}
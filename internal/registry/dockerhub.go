@@ -0,0 +1,295 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	dockerHubAPIURL = "https://hub.docker.com/v2"
+
+	// dockerHubRegistryHost is the host that Docker Hub images are pulled from
+	dockerHubRegistryHost = "index.docker.io"
+
+	// dockerHubAuthConfigKey is the key that the docker CLI and the kubelet use to look up
+	// Docker Hub credentials in a docker config file
+	dockerHubAuthConfigKey = "https://index.docker.io/v1/"
+
+	// dockerHubMaxPages limits the number of pages fetched when listing repositories or
+	// tags, so that very large namespaces don't block requests
+	dockerHubMaxPages = 10
+)
+
+// NormalizeDockerHubURL converts a Docker Hub namespace or repository, optionally prefixed
+// with a docker.io host, to a registry URL of the form index.docker.io/<namespace>[/<repo>]
+func NormalizeDockerHubURL(regURL string) (string, error) {
+	path := regURL
+
+	if splStr := strings.Split(path, "://"); len(splStr) > 1 {
+		path = splStr[1]
+	}
+
+	for _, host := range []string{"index.docker.io/", "registry-1.docker.io/", "docker.io/", "hub.docker.com/r/", "hub.docker.com/u/"} {
+		path = strings.TrimPrefix(path, host)
+	}
+
+	path = strings.Trim(path, "/")
+
+	if path == "" || len(strings.Split(path, "/")) > 2 {
+		return "", fmt.Errorf("docker hub registries must be a namespace or a repository of the form <namespace>/<repository>")
+	}
+
+	return dockerHubRegistryHost + "/" + path, nil
+}
+
+func (r *Registry) isDockerHub() bool {
+	return r.Service == ptypes.DockerHub || (r.Service == "" && strings.Contains(r.URL, "docker.io"))
+}
+
+// dockerHubPath returns the namespace and, if the registry is linked to a single repository,
+// the repository of a Docker Hub registry
+func (r *Registry) dockerHubPath() (string, string) {
+	path := strings.Split(r.URL, "docker.io/")
+	parts := strings.SplitN(strings.Trim(path[len(path)-1], "/"), "/", 2)
+
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+type dockerHubLoginReq struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type dockerHubLoginResp struct {
+	Token string `json:"token"`
+}
+
+type dockerHubRepositoryResult struct {
+	Name        string    `json:"name"`
+	Namespace   string    `json:"namespace"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+type dockerHubRepositoryResp struct {
+	Next    string                      `json:"next"`
+	Results []dockerHubRepositoryResult `json:"results"`
+}
+
+type dockerHubImageResult struct {
+	Name        string     `json:"name"`
+	Digest      string     `json:"digest"`
+	LastUpdated *time.Time `json:"last_updated"`
+	FullSize    int64      `json:"full_size"`
+	Images      []struct {
+		Architecture string `json:"architecture"`
+	} `json:"images"`
+}
+
+type dockerHubImageResp struct {
+	Next    string                 `json:"next"`
+	Results []dockerHubImageResult `json:"results"`
+}
+
+// getDockerHubToken exchanges the credentials of the registry's basic integration for a
+// Docker Hub API token. Personal access tokens are accepted in place of a password.
+func (r *Registry) getDockerHubToken(repo repository.Repository) (string, error) {
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(&dockerHubLoginReq{
+		Username: string(basic.Username),
+		Password: string(basic.Password),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.Post(dockerHubAPIURL+"/users/login", "application/json", strings.NewReader(string(data)))
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not log in to Docker Hub: status code %d", resp.StatusCode)
+	}
+
+	tokenObj := dockerHubLoginResp{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&tokenObj); err != nil {
+		return "", fmt.Errorf("Could not decode Dockerhub token from response: %v", err)
+	}
+
+	return tokenObj.Token, nil
+}
+
+// getDockerHubPages follows the pagination links of a Docker Hub API list endpoint, decoding
+// each page with decodePage, which returns the link to the next page
+func getDockerHubPages(token, pageURL string, decodePage func(resp *http.Response) (string, error)) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	for i := 0; i < dockerHubMaxPages && pageURL != ""; i++ {
+		req, err := http.NewRequest("GET", pageURL, nil)
+
+		if err != nil {
+			return err
+		}
+
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		resp, err := client.Do(req)
+
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return fmt.Errorf("RepositoryNotFoundException: %s was not found on Docker Hub", pageURL)
+		} else if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return fmt.Errorf("error listing Docker Hub resources: status code %d", resp.StatusCode)
+		}
+
+		pageURL, err = decodePage(resp)
+		resp.Body.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) listDockerHubRepositories(repo repository.Repository) ([]*ptypes.RegistryRepository, error) {
+	namespace, repoName := r.dockerHubPath()
+
+	// registries linked to a single repository only list that repository
+	if repoName != "" {
+		return []*ptypes.RegistryRepository{
+			{
+				Name: namespace + "/" + repoName,
+				URI:  dockerHubRegistryHost + "/" + namespace + "/" + repoName,
+			},
+		}, nil
+	}
+
+	token, err := r.getDockerHubToken(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.RegistryRepository, 0)
+
+	err = getDockerHubPages(
+		token,
+		fmt.Sprintf("%s/repositories/%s/?page_size=100", dockerHubAPIURL, namespace),
+		func(resp *http.Response) (string, error) {
+			page := dockerHubRepositoryResp{}
+
+			if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+				return "", fmt.Errorf("Could not read Docker Hub repositories: %v", err)
+			}
+
+			for _, result := range page.Results {
+				res = append(res, &ptypes.RegistryRepository{
+					Name:      result.Namespace + "/" + result.Name,
+					CreatedAt: result.LastUpdated,
+					URI:       dockerHubRegistryHost + "/" + result.Namespace + "/" + result.Name,
+				})
+			}
+
+			return page.Next, nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Registry) listDockerHubImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	namespace, linkedRepo := r.dockerHubPath()
+
+	// repositories may be given with or without the namespace
+	if !strings.Contains(repoName, "/") {
+		if repoName == "" {
+			repoName = linkedRepo
+		}
+
+		repoName = namespace + "/" + repoName
+	}
+
+	token, err := r.getDockerHubToken(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.Image, 0)
+
+	err = getDockerHubPages(
+		token,
+		fmt.Sprintf("%s/repositories/%s/tags/?page_size=100", dockerHubAPIURL, repoName),
+		func(resp *http.Response) (string, error) {
+			page := dockerHubImageResp{}
+
+			if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+				return "", fmt.Errorf("Could not read Docker Hub tags: %v", err)
+			}
+
+			for _, result := range page.Results {
+				img := &ptypes.Image{
+					RepositoryName: repoName,
+					Tag:            result.Name,
+					Digest:         result.Digest,
+					PushedAt:       result.LastUpdated,
+				}
+
+				for _, platformImg := range result.Images {
+					img.Architectures = appendArchitecture(img.Architectures, platformImg.Architecture)
+				}
+
+				// the full size of multi-architecture images is the sum of all platforms
+				if len(result.Images) <= 1 {
+					img.Size = result.FullSize
+				}
+
+				res = append(res, img)
+			}
+
+			return page.Next, nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,33 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestNormalizeDockerHubURL(t *testing.T) {
+	tests := map[string]string{
+		"my-org":                              "index.docker.io/my-org",
+		"my-org/my-app":                       "index.docker.io/my-org/my-app",
+		"docker.io/my-org/my-app":             "index.docker.io/my-org/my-app",
+		"https://index.docker.io/my-org/":     "index.docker.io/my-org",
+		"https://hub.docker.com/r/my-org/app": "index.docker.io/my-org/app",
+	}
+
+	for input, expected := range tests {
+		res, err := registry.NormalizeDockerHubURL(input)
+
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", input, err)
+		} else if res != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, res)
+		}
+	}
+
+	for _, input := range []string{"", "docker.io/", "my-org/my-app/extra"} {
+		if _, err := registry.NormalizeDockerHubURL(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
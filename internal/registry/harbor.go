@@ -0,0 +1,343 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	harborPageSize = 100
+
+	// harborMaxPages limits the number of pages fetched when listing repositories or
+	// artifacts, so that very large projects don't block requests
+	harborMaxPages = 10
+)
+
+var ErrInvalidHarborURL = fmt.Errorf("harbor registries must be of the form <host>/<project>")
+
+// NormalizeHarborURL converts a Harbor project URL, optionally prefixed with a scheme, to a
+// registry URL of the form <host>/<project>
+func NormalizeHarborURL(regURL string) (string, error) {
+	if !strings.Contains(regURL, "://") {
+		regURL = "https://" + regURL
+	}
+
+	parsedURL, err := url.Parse(regURL)
+
+	if err != nil {
+		return "", ErrInvalidHarborURL
+	}
+
+	project := strings.Trim(parsedURL.Path, "/")
+
+	if parsedURL.Host == "" || project == "" || strings.Contains(project, "/") {
+		return "", ErrInvalidHarborURL
+	}
+
+	return parsedURL.Host + "/" + project, nil
+}
+
+func (r *Registry) isHarbor() bool {
+	return r.Service == ptypes.Harbor
+}
+
+// harborProject returns the API base URL and the project of a Harbor registry
+func (r *Registry) harborProject() (string, string, error) {
+	normalized, err := NormalizeHarborURL(r.URL)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	parts := strings.SplitN(normalized, "/", 2)
+
+	return fmt.Sprintf("https://%s/api/v2.0", parts[0]), parts[1], nil
+}
+
+type harborRepository struct {
+	Name         string    `json:"name"`
+	CreationTime time.Time `json:"creation_time"`
+}
+
+type harborTag struct {
+	Name string `json:"name"`
+}
+
+type harborScanOverview struct {
+	ScanStatus string     `json:"scan_status"`
+	EndTime    *time.Time `json:"end_time"`
+	Summary    *struct {
+		Summary map[string]int `json:"summary"`
+	} `json:"summary"`
+}
+
+type harborArtifact struct {
+	Digest       string                        `json:"digest"`
+	PushTime     *time.Time                    `json:"push_time"`
+	Size         int64                         `json:"size"`
+	Tags         []harborTag                   `json:"tags"`
+	ScanOverview map[string]harborScanOverview `json:"scan_overview"`
+	ExtraAttrs   struct {
+		Architecture string `json:"architecture"`
+	} `json:"extra_attrs"`
+	References []struct {
+		Platform struct {
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"references"`
+}
+
+// getHarborPages requests each page of a Harbor API list endpoint until a page with fewer
+// than harborPageSize results is returned. decodePage returns the number of results decoded.
+func (r *Registry) getHarborPages(
+	repo repository.Repository,
+	pageURL string,
+	decodePage func(resp *http.Response) (int, error),
+) error {
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	separator := "?"
+
+	if strings.Contains(pageURL, "?") {
+		separator = "&"
+	}
+
+	for page := 1; page <= harborMaxPages; page++ {
+		req, err := http.NewRequest(
+			"GET",
+			fmt.Sprintf("%s%spage=%d&page_size=%d", pageURL, separator, page, harborPageSize),
+			nil,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		req.SetBasicAuth(string(basic.Username), string(basic.Password))
+
+		resp, err := client.Do(req)
+
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return fmt.Errorf("RepositoryNotFoundException: %s was not found on Harbor", pageURL)
+		} else if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return fmt.Errorf("error listing Harbor resources: status code %d", resp.StatusCode)
+		}
+
+		count, err := decodePage(resp)
+		resp.Body.Close()
+
+		if err != nil {
+			return err
+		}
+
+		if count < harborPageSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) listHarborRepositories(repo repository.Repository) ([]*ptypes.RegistryRepository, error) {
+	apiURL, project, err := r.harborProject()
+
+	if err != nil {
+		return nil, err
+	}
+
+	host := strings.TrimSuffix(strings.TrimPrefix(apiURL, "https://"), "/api/v2.0")
+	res := make([]*ptypes.RegistryRepository, 0)
+
+	err = r.getHarborPages(
+		repo,
+		fmt.Sprintf("%s/projects/%s/repositories", apiURL, url.PathEscape(project)),
+		func(resp *http.Response) (int, error) {
+			page := make([]harborRepository, 0)
+
+			if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+				return 0, fmt.Errorf("Could not read Harbor repositories: %v", err)
+			}
+
+			for _, harborRepo := range page {
+				res = append(res, &ptypes.RegistryRepository{
+					Name:      harborRepo.Name,
+					CreatedAt: harborRepo.CreationTime,
+					URI:       host + "/" + harborRepo.Name,
+				})
+			}
+
+			return len(page), nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *Registry) listHarborImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	artifacts, err := r.listHarborArtifacts(repoName, repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.Image, 0)
+
+	for _, artifact := range artifacts {
+		vulns := toHarborVulnerabilitySummary(&artifact)
+
+		// image indexes reference an artifact per platform
+		archs := appendArchitecture(nil, artifact.ExtraAttrs.Architecture)
+		size := artifact.Size
+
+		for _, ref := range artifact.References {
+			archs = appendArchitecture(archs, ref.Platform.Architecture)
+			size = 0
+		}
+
+		for _, tag := range artifact.Tags {
+			res = append(res, &ptypes.Image{
+				RepositoryName:  repoName,
+				Tag:             tag.Name,
+				Digest:          artifact.Digest,
+				PushedAt:        artifact.PushTime,
+				Size:            size,
+				Architectures:   archs,
+				Vulnerabilities: vulns,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Registry) getHarborImageScanSummary(
+	repo repository.Repository,
+	repoName, tag string,
+) (*ptypes.ImageVulnerabilitySummary, error) {
+	artifacts, err := r.listHarborArtifacts(repoName, repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, artifact := range artifacts {
+		for _, artifactTag := range artifact.Tags {
+			if artifactTag.Name != tag {
+				continue
+			}
+
+			if summary := toHarborVulnerabilitySummary(&artifact); summary != nil {
+				return summary, nil
+			}
+
+			return nil, ErrNoScanFindings
+		}
+	}
+
+	return nil, ErrNoScanFindings
+}
+
+// listHarborArtifacts lists the tagged artifacts of a repository, along with their scan
+// overviews. The repository name may include the Harbor project.
+func (r *Registry) listHarborArtifacts(repoName string, repo repository.Repository) ([]harborArtifact, error) {
+	apiURL, project, err := r.harborProject()
+
+	if err != nil {
+		return nil, err
+	}
+
+	repoName = strings.TrimPrefix(repoName, project+"/")
+
+	// repository names containing slashes must be double-encoded in the Harbor API
+	escapedRepo := url.PathEscape(url.PathEscape(repoName))
+
+	res := make([]harborArtifact, 0)
+
+	err = r.getHarborPages(
+		repo,
+		fmt.Sprintf(
+			"%s/projects/%s/repositories/%s/artifacts?with_tag=true&with_scan_overview=true",
+			apiURL, url.PathEscape(project), escapedRepo,
+		),
+		func(resp *http.Response) (int, error) {
+			page := make([]harborArtifact, 0)
+
+			if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+				return 0, fmt.Errorf("Could not read Harbor artifacts: %v", err)
+			}
+
+			res = append(res, page...)
+
+			return len(page), nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// toHarborVulnerabilitySummary converts the scan overview of an artifact to a vulnerability
+// summary, returning nil if the artifact has not been scanned successfully
+func toHarborVulnerabilitySummary(artifact *harborArtifact) *ptypes.ImageVulnerabilitySummary {
+	// the scan overview is keyed by the mime type of the scan report
+	for _, overview := range artifact.ScanOverview {
+		if overview.ScanStatus != "Success" || overview.Summary == nil {
+			continue
+		}
+
+		res := &ptypes.ImageVulnerabilitySummary{
+			Scanner:   ptypes.VulnerabilityScannerHarbor,
+			ScannedAt: overview.EndTime,
+		}
+
+		for severity, count := range overview.Summary.Summary {
+			switch strings.ToUpper(severity) {
+			case "CRITICAL":
+				res.Critical += count
+			case "HIGH":
+				res.High += count
+			case "MEDIUM":
+				res.Medium += count
+			case "LOW":
+				res.Low += count
+			default:
+				res.Unknown += count
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestNormalizeHarborURL(t *testing.T) {
+	tests := map[string]string{
+		"harbor.example.com/my-project":          "harbor.example.com/my-project",
+		"https://harbor.example.com/my-project/": "harbor.example.com/my-project",
+		"harbor.example.com:8443/my-project":     "harbor.example.com:8443/my-project",
+	}
+
+	for input, expected := range tests {
+		res, err := registry.NormalizeHarborURL(input)
+
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", input, err)
+		} else if res != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, res)
+		}
+	}
+
+	for _, input := range []string{"", "harbor.example.com", "harbor.example.com/my-project/my-repo"} {
+		if _, err := registry.NormalizeHarborURL(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
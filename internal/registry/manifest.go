@@ -0,0 +1,302 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const manifestAcceptHeader = "application/vnd.docker.distribution.manifest.list.v2+json, " +
+	"application/vnd.oci.image.index.v1+json, " +
+	"application/vnd.docker.distribution.manifest.v2+json, " +
+	"application/vnd.oci.image.manifest.v1+json"
+
+// maxManifestRequests limits the number of concurrent manifest requests made against a
+// registry when populating image metadata
+const maxManifestRequests = 10
+
+type manifestDescriptor struct {
+	Digest   string `json:"digest"`
+	Size     int64  `json:"size"`
+	Platform *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+		Variant      string `json:"variant,omitempty"`
+	} `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// imageManifest is the subset of fields of image manifests and manifest lists (or OCI
+// indexes) used to read image metadata
+type imageManifest struct {
+	Config    *manifestDescriptor  `json:"config,omitempty"`
+	Layers    []manifestDescriptor `json:"layers,omitempty"`
+	Manifests []manifestDescriptor `json:"manifests,omitempty"`
+}
+
+type imageConfig struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// v2Client makes authenticated requests against the docker registry http api of a registry
+type v2Client struct {
+	client   *http.Client
+	baseURL  string
+	username string
+	password string
+
+	// a bearer token, set for registries which use token authentication
+	token string
+}
+
+// PaginateImages sorts images by when they were pushed, most recent first, and returns the
+// given page of images along with the next page, or 0 if this is the last page. Pages start
+// at 1, and a page size of 0 returns all images.
+func PaginateImages(imgs []*ptypes.Image, page, pageSize uint) ([]*ptypes.Image, uint) {
+	sortImagesByPushedAt(imgs)
+
+	if pageSize == 0 {
+		return imgs, 0
+	}
+
+	if page == 0 {
+		page = 1
+	}
+
+	start := (page - 1) * pageSize
+
+	if start >= uint(len(imgs)) {
+		return []*ptypes.Image{}, 0
+	}
+
+	end := start + pageSize
+
+	if end >= uint(len(imgs)) {
+		return imgs[start:], 0
+	}
+
+	return imgs[start:end], page + 1
+}
+
+// sortImagesByPushedAt sorts images by when they were pushed, most recent first. Images
+// without a push time are sorted last, by tag.
+func sortImagesByPushedAt(imgs []*ptypes.Image) {
+	sort.SliceStable(imgs, func(i, j int) bool {
+		if imgs[i].PushedAt == nil || imgs[j].PushedAt == nil {
+			if imgs[i].PushedAt != nil || imgs[j].PushedAt != nil {
+				return imgs[i].PushedAt != nil
+			}
+
+			return imgs[i].Tag < imgs[j].Tag
+		}
+
+		if imgs[i].PushedAt.Equal(*imgs[j].PushedAt) {
+			return imgs[i].Tag < imgs[j].Tag
+		}
+
+		return imgs[i].PushedAt.After(*imgs[j].PushedAt)
+	})
+}
+
+// PopulateImageMetadata reads the digest, size and architectures of images from their
+// manifests, for registries whose tag listing doesn't include this metadata. Since a
+// request is made for each image, this should only be called with a page of images.
+func (r *Registry) PopulateImageMetadata(repoName string, imgs []*ptypes.Image, repo repository.Repository) error {
+	client, repoPath, err := r.getV2Client(repoName, repo)
+
+	if err != nil || client == nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxManifestRequests)
+
+	for _, img := range imgs {
+		if img.Digest != "" && img.Size != 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(img *ptypes.Image) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// metadata is best-effort, so images whose manifests can't be read are skipped
+			client.populateImage(repoPath, img)
+		}(img)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// getV2Client returns a client for the docker registry http api of registries which only
+// list tags, along with the path of the repository. A nil client is returned for registries
+// which already list image metadata.
+func (r *Registry) getV2Client(repoName string, repo repository.Repository) (*v2Client, string, error) {
+	client := &v2Client{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	switch {
+	case r.AzureIntegrationID != 0:
+		az, err := repo.AzureIntegration().ReadAzureIntegration(r.ProjectID, r.AzureIntegrationID)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		client.baseURL = strings.TrimSuffix(r.URL, "/")
+		client.username = az.AzureClientID
+		client.password = string(az.ServicePrincipalSecret)
+
+		if !strings.Contains(client.baseURL, "://") {
+			client.baseURL = "https://" + client.baseURL
+		}
+
+		return client, repoName, nil
+	case r.GCPIntegrationID != 0 && !strings.Contains(r.URL, "pkg.dev"):
+		gcp, err := repo.GCPIntegration().ReadGCPIntegration(r.ProjectID, r.GCPIntegrationID)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		parsedURL, err := url.Parse("https://" + r.URL)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		client.baseURL = "https://" + parsedURL.Host
+		client.username = "_json_key"
+		client.password = string(gcp.GCPKeyData)
+
+		return client, strings.Trim(parsedURL.Path, "/") + "/" + repoName, nil
+	case r.BasicIntegrationID != 0 && !r.isDockerHub() && !r.isHarbor() && !r.isQuay():
+		basic, err := repo.BasicIntegration().ReadBasicIntegration(r.ProjectID, r.BasicIntegrationID)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		parsedURL, err := url.Parse(r.URL)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		client.baseURL = fmt.Sprintf("%s://%s", parsedURL.Scheme, parsedURL.Host)
+		client.username = string(basic.Username)
+		client.password = string(basic.Password)
+
+		return client, repoName, nil
+	}
+
+	return nil, "", nil
+}
+
+func (c *v2Client) get(path, accept string, v interface{}) (http.Header, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v2/%s", c.baseURL, path), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.setAuth(req)
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error reading %s: status code %d", path, resp.StatusCode)
+	}
+
+	return resp.Header, json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (c *v2Client) populateImage(repoPath string, img *ptypes.Image) error {
+	manifest := &imageManifest{}
+
+	header, err := c.get(fmt.Sprintf("%s/manifests/%s", repoPath, img.Tag), manifestAcceptHeader, manifest)
+
+	if err != nil {
+		return err
+	}
+
+	if digest := header.Get("Docker-Content-Digest"); digest != "" {
+		img.Digest = digest
+	}
+
+	// manifest lists reference a manifest per platform
+	if len(manifest.Manifests) > 0 {
+		for _, platformManifest := range manifest.Manifests {
+			if platformManifest.Platform != nil {
+				img.Architectures = appendArchitecture(img.Architectures, platformManifest.Platform.Architecture)
+			}
+		}
+
+		return nil
+	}
+
+	if manifest.Config == nil {
+		return nil
+	}
+
+	img.Size = manifest.Config.Size
+
+	for _, layer := range manifest.Layers {
+		img.Size += layer.Size
+	}
+
+	config := &imageConfig{}
+
+	if _, err := c.get(fmt.Sprintf("%s/blobs/%s", repoPath, manifest.Config.Digest), "", config); err != nil {
+		return err
+	}
+
+	img.Architectures = appendArchitecture(img.Architectures, config.Architecture)
+
+	return nil
+}
+
+// appendArchitecture adds an architecture to a list of architectures if it is not already
+// present. Attestation manifests are reported with an "unknown" architecture and are skipped.
+func appendArchitecture(archs []string, arch string) []string {
+	if arch == "" || arch == "unknown" {
+		return archs
+	}
+
+	for _, existing := range archs {
+		if existing == arch {
+			return archs
+		}
+	}
+
+	return append(archs, arch)
+}
@@ -0,0 +1,50 @@
+package registry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestPaginateImages(t *testing.T) {
+	now := time.Now()
+	older := now.Add(-time.Hour)
+
+	getImages := func() []*types.Image {
+		return []*types.Image{
+			{Tag: "untimed"},
+			{Tag: "older", PushedAt: &older},
+			{Tag: "latest", PushedAt: &now},
+		}
+	}
+
+	imgs, next := registry.PaginateImages(getImages(), 0, 0)
+
+	if len(imgs) != 3 || next != 0 {
+		t.Fatalf("expected all images and no next page, got %d images and next page %d", len(imgs), next)
+	}
+
+	if imgs[0].Tag != "latest" || imgs[1].Tag != "older" || imgs[2].Tag != "untimed" {
+		t.Errorf("expected images sorted by push time, got %s, %s, %s", imgs[0].Tag, imgs[1].Tag, imgs[2].Tag)
+	}
+
+	imgs, next = registry.PaginateImages(getImages(), 1, 2)
+
+	if len(imgs) != 2 || next != 2 {
+		t.Errorf("expected 2 images and next page 2, got %d images and next page %d", len(imgs), next)
+	}
+
+	imgs, next = registry.PaginateImages(getImages(), 2, 2)
+
+	if len(imgs) != 1 || imgs[0].Tag != "untimed" || next != 0 {
+		t.Errorf("expected the last image and no next page, got %d images and next page %d", len(imgs), next)
+	}
+
+	imgs, next = registry.PaginateImages(getImages(), 3, 2)
+
+	if len(imgs) != 0 || next != 0 {
+		t.Errorf("expected no images past the last page, got %d images and next page %d", len(imgs), next)
+	}
+}
@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+// GetImagePlatforms returns the platforms which an image supports, read from its manifest
+// list if it is a multi-architecture image, or from its config otherwise
+func (r *Registry) GetImagePlatforms(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	image string,
+) ([]*ptypes.ImagePlatform, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %s: %w", image, err)
+	}
+
+	ref := "latest"
+
+	if digested, ok := named.(reference.Digested); ok {
+		ref = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		ref = tagged.Tag()
+	}
+
+	client, repoPath, _, err := r.getAuthorizedV2Client(repo, doAuth, reference.Path(named), "pull")
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &imageManifest{}
+
+	if _, err := client.get(fmt.Sprintf("%s/manifests/%s", repoPath, ref), manifestAcceptHeader, manifest); err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.ImagePlatform, 0)
+
+	if len(manifest.Manifests) > 0 {
+		for _, platformManifest := range manifest.Manifests {
+			// attestation manifests are listed with an "unknown" platform
+			if platformManifest.Platform == nil || platformManifest.Platform.Architecture == "" ||
+				platformManifest.Platform.Architecture == "unknown" {
+				continue
+			}
+
+			res = append(res, &ptypes.ImagePlatform{
+				OS:           platformManifest.Platform.OS,
+				Architecture: platformManifest.Platform.Architecture,
+				Variant:      platformManifest.Platform.Variant,
+			})
+		}
+
+		return res, nil
+	}
+
+	if manifest.Config == nil {
+		return res, nil
+	}
+
+	config := &imageConfig{}
+
+	if _, err := client.get(fmt.Sprintf("%s/blobs/%s", repoPath, manifest.Config.Digest), "", config); err != nil {
+		return nil, err
+	}
+
+	if config.Architecture != "" {
+		res = append(res, &ptypes.ImagePlatform{
+			OS:           config.OS,
+			Architecture: config.Architecture,
+			Variant:      config.Variant,
+		})
+	}
+
+	return res, nil
+}
+
+// GetUnsupportedArchitectures returns the node architectures which none of an image's
+// platforms support. Only linux platforms are considered, since cluster nodes run linux.
+func GetUnsupportedArchitectures(platforms []*ptypes.ImagePlatform, nodeArchs []string) []string {
+	supported := make(map[string]bool)
+
+	for _, platform := range platforms {
+		if platform.OS == "" || platform.OS == "linux" {
+			supported[platform.Architecture] = true
+		}
+	}
+
+	res := make([]string, 0)
+
+	for _, arch := range nodeArchs {
+		if !supported[arch] {
+			res = append(res, arch)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// FormatPlatforms returns a readable list of platforms, such as "linux/amd64, linux/arm64/v8"
+func FormatPlatforms(platforms []*ptypes.ImagePlatform) string {
+	strs := make([]string, 0, len(platforms))
+
+	for _, platform := range platforms {
+		str := platform.OS + "/" + platform.Architecture
+
+		if platform.Variant != "" {
+			str += "/" + platform.Variant
+		}
+
+		strs = append(strs, str)
+	}
+
+	return strings.Join(strs, ", ")
+}
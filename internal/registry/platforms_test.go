@@ -0,0 +1,46 @@
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestGetUnsupportedArchitectures(t *testing.T) {
+	platforms := []*types.ImagePlatform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm", Variant: "v7"},
+		{OS: "windows", Architecture: "arm64"},
+	}
+
+	tests := []struct {
+		nodeArchs []string
+		expected  []string
+	}{
+		{[]string{"amd64"}, []string{}},
+		{[]string{"amd64", "arm"}, []string{}},
+		{[]string{"arm64", "amd64"}, []string{"arm64"}},
+		{[]string{"s390x", "arm64"}, []string{"arm64", "s390x"}},
+	}
+
+	for _, test := range tests {
+		res := registry.GetUnsupportedArchitectures(platforms, test.nodeArchs)
+
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("nodes %v: expected unsupported architectures %v, got %v", test.nodeArchs, test.expected, res)
+		}
+	}
+}
+
+func TestFormatPlatforms(t *testing.T) {
+	res := registry.FormatPlatforms([]*types.ImagePlatform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	})
+
+	if expected := "linux/amd64, linux/arm64/v8"; res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+}
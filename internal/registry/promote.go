@@ -0,0 +1,416 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+// promoteTimeout limits the duration of each request made while promoting an image. Blob
+// requests stream layers between registries, so this must allow for large layers.
+const promoteTimeout = 15 * time.Minute
+
+var ErrInvalidImageDigest = fmt.Errorf("image digests must be of the form sha256:<64 hex characters>")
+
+var imageDigestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+var challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// ValidateImageDigest checks that a digest is a sha256 image digest
+func ValidateImageDigest(digest string) error {
+	if !imageDigestRegex.MatchString(digest) {
+		return ErrInvalidImageDigest
+	}
+
+	return nil
+}
+
+// PromoteImage copies an image by digest from a repository of this registry to a repository
+// of the target registry, and tags it if a tag is given. Manifest lists are copied along with
+// the manifest of each platform. Blobs are streamed between the registries, so no docker
+// daemon or local storage is required. The reference of the promoted image is returned.
+func (r *Registry) PromoteImage(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	repoName, digest string,
+	target *Registry,
+	targetRepoName, tag string,
+) (string, error) {
+	if err := ValidateImageDigest(digest); err != nil {
+		return "", err
+	}
+
+	src, srcPath, _, err := r.getAuthorizedV2Client(repo, doAuth, repoName, "pull")
+
+	if err != nil {
+		return "", fmt.Errorf("error authenticating with source registry: %w", err)
+	}
+
+	dst, dstPath, dstHost, err := target.getAuthorizedV2Client(repo, doAuth, targetRepoName, "pull,push")
+
+	if err != nil {
+		return "", fmt.Errorf("error authenticating with target registry: %w", err)
+	}
+
+	manifest, contentType, err := copyManifest(src, dst, srcPath, dstPath, digest)
+
+	if err != nil {
+		return "", err
+	}
+
+	if tag == "" {
+		return fmt.Sprintf("%s/%s@%s", dstHost, dstPath, digest), nil
+	}
+
+	if err := dst.putManifest(dstPath, tag, contentType, manifest); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s:%s", dstHost, dstPath, tag), nil
+}
+
+// getAuthorizedV2Client returns a client for the docker registry http api of the registry which
+// is authorized for the given actions on a repository, along with the path and host of the
+// repository
+func (r *Registry) getAuthorizedV2Client(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	repoName, actions string,
+) (*v2Client, string, string, error) {
+	conf, err := r.getDockerConfigFile(repo, doAuth)
+
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if conf == nil || len(conf.AuthConfigs) == 0 {
+		return nil, "", "", fmt.Errorf("registry %s does not support docker registry api credentials", r.Name)
+	}
+
+	client := &v2Client{
+		client: &http.Client{
+			Timeout: promoteTimeout,
+		},
+	}
+
+	for _, authConf := range conf.AuthConfigs {
+		client.username = authConf.Username
+		client.password = authConf.Password
+
+		if client.username == "" && authConf.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(authConf.Auth)
+
+			if err == nil {
+				if parts := strings.SplitN(string(decoded), ":", 2); len(parts) == 2 {
+					client.username = parts[0]
+					client.password = parts[1]
+				}
+			}
+		}
+	}
+
+	regURL := r.URL
+
+	if splStr := strings.Split(regURL, "://"); len(splStr) > 1 {
+		regURL = splStr[1]
+	}
+
+	regURL = strings.Trim(regURL, "/")
+	host := strings.SplitN(regURL, "/", 2)[0]
+	regPath := strings.Trim(strings.TrimPrefix(regURL, host), "/")
+
+	repoPath := strings.Trim(strings.TrimPrefix(repoName, host+"/"), "/")
+
+	// repository names may or may not include the path of the registry, such as the project
+	// of a GCR registry
+	if regPath != "" && !strings.HasPrefix(repoPath, regPath+"/") {
+		repoPath = regPath + "/" + repoPath
+	}
+
+	client.baseURL = "https://" + host
+
+	if r.isDockerHub() {
+		client.baseURL = "https://registry-1.docker.io"
+	}
+
+	if err := client.authorize(repoPath, actions); err != nil {
+		return nil, "", "", err
+	}
+
+	return client, repoPath, host, nil
+}
+
+// authorize requests a bearer token for the given actions on a repository, for registries
+// which use token authentication. Registries which accept basic auth are left unchanged.
+func (c *v2Client) authorize(repoPath, actions string) error {
+	resp, err := c.client.Get(c.baseURL + "/v2/")
+
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+
+	if resp.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return nil
+	}
+
+	params := make(map[string]string)
+
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	if params["realm"] == "" {
+		return fmt.Errorf("registry returned a token challenge without a realm")
+	}
+
+	query := url.Values{}
+	query.Set("scope", fmt.Sprintf("repository:%s:%s", repoPath, actions))
+
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", params["realm"], query.Encode()), nil)
+
+	if err != nil {
+		return err
+	}
+
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	tokenResp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer tokenResp.Body.Close()
+
+	if tokenResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error requesting registry token: status code %d", tokenResp.StatusCode)
+	}
+
+	token := &struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+
+	if err := json.NewDecoder(tokenResp.Body).Decode(token); err != nil {
+		return err
+	}
+
+	c.token = token.Token
+
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+
+	return nil
+}
+
+func (c *v2Client) setAuth(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	} else if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+}
+
+// do sends a request to the docker registry http api, and returns an error if the response
+// status is not one of the expected statuses
+func (c *v2Client) do(req *http.Request, expected ...int) (*http.Response, error) {
+	c.setAuth(req)
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+
+	resp.Body.Close()
+
+	return nil, fmt.Errorf("%s %s: unexpected status code %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+// copyManifest copies a manifest, along with the blobs or manifests it references, and
+// returns the manifest and its content type
+func copyManifest(src, dst *v2Client, srcPath, dstPath, digest string) ([]byte, string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v2/%s/manifests/%s", src.baseURL, srcPath, digest), nil)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	req.Header.Set("Accept", manifestAcceptHeader)
+
+	resp, err := src.do(req, http.StatusOK)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading manifest %s: %w", digest, err)
+	}
+
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the manifest is pushed as-is, so its digest must match the requested digest
+	if fmt.Sprintf("sha256:%x", sha256.Sum256(raw)) != digest {
+		return nil, "", fmt.Errorf("manifest %s does not match its digest", digest)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	manifest := &imageManifest{}
+
+	if err := json.Unmarshal(raw, manifest); err != nil {
+		return nil, "", fmt.Errorf("error reading manifest %s: %w", digest, err)
+	}
+
+	if len(manifest.Manifests) > 0 {
+		for _, platformManifest := range manifest.Manifests {
+			if _, _, err := copyManifest(src, dst, srcPath, dstPath, platformManifest.Digest); err != nil {
+				return nil, "", err
+			}
+		}
+	} else {
+		blobs := manifest.Layers
+
+		if manifest.Config != nil {
+			blobs = append([]manifestDescriptor{*manifest.Config}, blobs...)
+		}
+
+		for _, blob := range blobs {
+			if err := copyBlob(src, dst, srcPath, dstPath, blob); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	if err := dst.putManifest(dstPath, digest, contentType, raw); err != nil {
+		return nil, "", err
+	}
+
+	return raw, contentType, nil
+}
+
+// copyBlob streams a blob from the source repository to the target repository, unless the
+// target repository already contains the blob
+func copyBlob(src, dst *v2Client, srcPath, dstPath string, blob manifestDescriptor) error {
+	headReq, err := http.NewRequest("HEAD", fmt.Sprintf("%s/v2/%s/blobs/%s", dst.baseURL, dstPath, blob.Digest), nil)
+
+	if err != nil {
+		return err
+	}
+
+	if resp, err := dst.do(headReq, http.StatusOK); err == nil {
+		resp.Body.Close()
+		return nil
+	}
+
+	getReq, err := http.NewRequest("GET", fmt.Sprintf("%s/v2/%s/blobs/%s", src.baseURL, srcPath, blob.Digest), nil)
+
+	if err != nil {
+		return err
+	}
+
+	blobResp, err := src.do(getReq, http.StatusOK)
+
+	if err != nil {
+		return fmt.Errorf("error reading blob %s: %w", blob.Digest, err)
+	}
+
+	defer blobResp.Body.Close()
+
+	uploadReq, err := http.NewRequest("POST", fmt.Sprintf("%s/v2/%s/blobs/uploads/", dst.baseURL, dstPath), nil)
+
+	if err != nil {
+		return err
+	}
+
+	uploadResp, err := dst.do(uploadReq, http.StatusAccepted)
+
+	if err != nil {
+		return fmt.Errorf("error starting upload of blob %s: %w", blob.Digest, err)
+	}
+
+	uploadResp.Body.Close()
+
+	location, err := uploadResp.Location()
+
+	if err != nil {
+		return fmt.Errorf("error starting upload of blob %s: %w", blob.Digest, err)
+	}
+
+	query := location.Query()
+	query.Set("digest", blob.Digest)
+	location.RawQuery = query.Encode()
+
+	putReq, err := http.NewRequest("PUT", location.String(), blobResp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	putReq.ContentLength = blobResp.ContentLength
+	putReq.Header.Set("Content-Type", "application/octet-stream")
+
+	putResp, err := dst.do(putReq, http.StatusCreated)
+
+	if err != nil {
+		return fmt.Errorf("error uploading blob %s: %w", blob.Digest, err)
+	}
+
+	putResp.Body.Close()
+
+	return nil
+}
+
+func (c *v2Client) putManifest(repoPath, ref, contentType string, manifest []byte) error {
+	req, err := http.NewRequest(
+		"PUT",
+		fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL, repoPath, ref),
+		bytes.NewReader(manifest),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.do(req, http.StatusCreated, http.StatusOK)
+
+	if err != nil {
+		return fmt.Errorf("error pushing manifest %s: %w", ref, err)
+	}
+
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package registry_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestValidateImageDigest(t *testing.T) {
+	valid := "sha256:" + strings.Repeat("a1", 32)
+
+	if err := registry.ValidateImageDigest(valid); err != nil {
+		t.Errorf("expected %s to be valid, got %v", valid, err)
+	}
+
+	for _, digest := range []string{
+		"",
+		"latest",
+		"sha256:abc",
+		"sha512:" + strings.Repeat("a1", 32),
+		"sha256:" + strings.Repeat("A1", 32),
+	} {
+		if err := registry.ValidateImageDigest(digest); err != registry.ErrInvalidImageDigest {
+			t.Errorf("expected %q to be invalid, got %v", digest, err)
+		}
+	}
+}
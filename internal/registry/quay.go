@@ -0,0 +1,340 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	quayDefaultHost = "quay.io"
+
+	// quayOAuthUsername is the username used with Quay OAuth tokens, both for docker logins
+	// and for the basic integrations of Quay registries
+	quayOAuthUsername = "$oauthtoken"
+
+	quayPageSize = 100
+
+	// quayMaxPages limits the number of pages fetched when listing repositories or tags, so
+	// that very large namespaces don't block requests
+	quayMaxPages = 10
+)
+
+var ErrInvalidQuayURL = fmt.Errorf("quay registries must be a namespace of the form [<host>/]<namespace>")
+
+var ErrBuildTriggersNotSupported = fmt.Errorf("build triggers are only supported for quay registries")
+
+// NormalizeQuayURL converts a Quay namespace, optionally prefixed with a scheme and the host
+// of a self-hosted Quay instance, to a registry URL of the form <host>/<namespace>.
+// Namespaces without a host are assumed to be on quay.io.
+func NormalizeQuayURL(regURL string) (string, error) {
+	path := regURL
+
+	if splStr := strings.Split(path, "://"); len(splStr) > 1 {
+		path = splStr[1]
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] != "" && !strings.Contains(parts[0], "."):
+		return quayDefaultHost + "/" + parts[0], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0] + "/" + parts[1], nil
+	}
+
+	return "", ErrInvalidQuayURL
+}
+
+func (r *Registry) isQuay() bool {
+	return r.Service == ptypes.Quay
+}
+
+// quayNamespace returns the API base URL, host and namespace of a Quay registry
+func (r *Registry) quayNamespace() (string, string, string, error) {
+	normalized, err := NormalizeQuayURL(r.URL)
+
+	if err != nil {
+		return "", "", "", err
+	}
+
+	parts := strings.SplitN(normalized, "/", 2)
+
+	return fmt.Sprintf("https://%s/api/v1", parts[0]), parts[0], parts[1], nil
+}
+
+type quayRepository struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	LastModified int64  `json:"last_modified"`
+}
+
+type quayRepositoryResp struct {
+	Repositories []quayRepository `json:"repositories"`
+	NextPage     string           `json:"next_page"`
+}
+
+type quayTag struct {
+	Name           string `json:"name"`
+	ManifestDigest string `json:"manifest_digest"`
+	StartTS        int64  `json:"start_ts"`
+	Size           *int64 `json:"size"`
+}
+
+type quayTagResp struct {
+	Tags          []quayTag `json:"tags"`
+	HasAdditional bool      `json:"has_additional"`
+}
+
+type quayTrigger struct {
+	ID            string `json:"id"`
+	Service       string `json:"service"`
+	IsActive      bool   `json:"is_active"`
+	BuildSource   string `json:"build_source"`
+	RepositoryURL string `json:"repository_url"`
+}
+
+type quayTriggerResp struct {
+	Triggers []quayTrigger `json:"triggers"`
+}
+
+type quayBuild struct {
+	Phase   string `json:"phase"`
+	Started string `json:"started"`
+	Trigger *struct {
+		ID string `json:"id"`
+	} `json:"trigger"`
+}
+
+type quayBuildResp struct {
+	Builds []quayBuild `json:"builds"`
+}
+
+// authorizeQuayRequest adds the credentials of a basic integration to a Quay API request.
+// OAuth tokens are sent as bearer tokens, while robot accounts use basic auth.
+func authorizeQuayRequest(req *http.Request, basic *integrations.BasicIntegration) {
+	if string(basic.Username) == quayOAuthUsername || len(basic.Username) == 0 {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", string(basic.Password)))
+	} else {
+		req.SetBasicAuth(string(basic.Username), string(basic.Password))
+	}
+}
+
+// getQuay requests a Quay API endpoint and decodes the response into v
+func (r *Registry) getQuay(repo repository.Repository, reqURL string, v interface{}) error {
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	req, err := http.NewRequest("GET", reqURL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	authorizeQuayRequest(req, basic)
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("RepositoryNotFoundException: %s was not found on Quay", reqURL)
+	} else if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error reading Quay resources: status code %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("Could not read Quay response: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Registry) listQuayRepositories(repo repository.Repository) ([]*ptypes.RegistryRepository, error) {
+	apiURL, host, namespace, err := r.quayNamespace()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.RegistryRepository, 0)
+	nextPage := ""
+
+	for i := 0; i < quayMaxPages; i++ {
+		query := url.Values{}
+		query.Set("namespace", namespace)
+		query.Set("last_modified", "true")
+
+		if nextPage != "" {
+			query.Set("next_page", nextPage)
+		}
+
+		page := &quayRepositoryResp{}
+
+		if err := r.getQuay(repo, fmt.Sprintf("%s/repository?%s", apiURL, query.Encode()), page); err != nil {
+			return nil, err
+		}
+
+		for _, quayRepo := range page.Repositories {
+			res = append(res, &ptypes.RegistryRepository{
+				Name:      quayRepo.Namespace + "/" + quayRepo.Name,
+				CreatedAt: time.Unix(quayRepo.LastModified, 0).UTC(),
+				URI:       host + "/" + quayRepo.Namespace + "/" + quayRepo.Name,
+			})
+		}
+
+		if page.NextPage == "" {
+			break
+		}
+
+		nextPage = page.NextPage
+	}
+
+	return res, nil
+}
+
+func (r *Registry) listQuayImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	apiURL, _, namespace, err := r.quayNamespace()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// repositories may be given with or without the namespace
+	if !strings.Contains(repoName, "/") {
+		repoName = namespace + "/" + repoName
+	}
+
+	res := make([]*ptypes.Image, 0)
+
+	for page := 1; page <= quayMaxPages; page++ {
+		tagResp := &quayTagResp{}
+
+		err := r.getQuay(
+			repo,
+			fmt.Sprintf(
+				"%s/repository/%s/tag/?onlyActiveTags=true&limit=%d&page=%d",
+				apiURL, repoName, quayPageSize, page,
+			),
+			tagResp,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tagResp.Tags {
+			pushedAt := time.Unix(tag.StartTS, 0).UTC()
+
+			img := &ptypes.Image{
+				RepositoryName: repoName,
+				Tag:            tag.Name,
+				Digest:         tag.ManifestDigest,
+				PushedAt:       &pushedAt,
+			}
+
+			// the size is not reported for manifest lists
+			if tag.Size != nil {
+				img.Size = *tag.Size
+			}
+
+			res = append(res, img)
+		}
+
+		if !tagResp.HasAdditional {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// ListBuildTriggers lists the build triggers configured for a repository, along with the
+// status of the most recent build of each trigger
+func (r *Registry) ListBuildTriggers(
+	repoName string,
+	repo repository.Repository,
+) ([]*ptypes.BuildTrigger, error) {
+	if r.BasicIntegrationID == 0 || !r.isQuay() {
+		return nil, ErrBuildTriggersNotSupported
+	}
+
+	apiURL, _, namespace, err := r.quayNamespace()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(repoName, "/") {
+		repoName = namespace + "/" + repoName
+	}
+
+	triggerResp := &quayTriggerResp{}
+
+	if err := r.getQuay(repo, fmt.Sprintf("%s/repository/%s/trigger/", apiURL, repoName), triggerResp); err != nil {
+		return nil, err
+	}
+
+	buildResp := &quayBuildResp{}
+
+	if err := r.getQuay(repo, fmt.Sprintf("%s/repository/%s/build/?limit=%d", apiURL, repoName, quayPageSize), buildResp); err != nil {
+		return nil, err
+	}
+
+	res := make([]*ptypes.BuildTrigger, 0)
+
+	for _, trigger := range triggerResp.Triggers {
+		buildSource := trigger.BuildSource
+
+		if buildSource == "" {
+			buildSource = trigger.RepositoryURL
+		}
+
+		bt := &ptypes.BuildTrigger{
+			ID:          trigger.ID,
+			Service:     trigger.Service,
+			BuildSource: buildSource,
+			Active:      trigger.IsActive,
+		}
+
+		// builds are returned with the most recent build first
+		for _, build := range buildResp.Builds {
+			if build.Trigger == nil || build.Trigger.ID != trigger.ID {
+				continue
+			}
+
+			bt.LastBuildPhase = build.Phase
+
+			if startedAt, err := time.Parse(time.RFC1123Z, build.Started); err == nil {
+				bt.LastBuildStartedAt = &startedAt
+			}
+
+			break
+		}
+
+		res = append(res, bt)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,33 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestNormalizeQuayURL(t *testing.T) {
+	tests := map[string]string{
+		"my-org":                            "quay.io/my-org",
+		"quay.io/my-org":                    "quay.io/my-org",
+		"https://quay.io/my-org/":           "quay.io/my-org",
+		"quay.example.com/my-org":           "quay.example.com/my-org",
+		"https://quay.example.com:8443/org": "quay.example.com:8443/org",
+	}
+
+	for input, expected := range tests {
+		res, err := registry.NormalizeQuayURL(input)
+
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", input, err)
+		} else if res != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, res)
+		}
+	}
+
+	for _, input := range []string{"", "quay.io", "quay.io/my-org/my-app"} {
+		if _, err := registry.NormalizeQuayURL(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
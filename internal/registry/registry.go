@@ -37,6 +37,28 @@ import (
 // Registry wraps the gorm Registry model
 type Registry models.Registry
 
+// GetRegistryForImageRepo returns the registry which an image repository belongs to, preferring
+// the registry with the most specific URL
+func GetRegistryForImageRepo(registries []*models.Registry, imageRepoURI string) *models.Registry {
+	var res *models.Registry
+	longestMatch := 0
+
+	for _, reg := range registries {
+		regURL := strings.TrimSuffix(reg.ToRegistryType().URL, "/")
+
+		if regURL == "" || !strings.HasPrefix(imageRepoURI, regURL+"/") {
+			continue
+		}
+
+		if len(regURL) > longestMatch {
+			res = reg
+			longestMatch = len(regURL)
+		}
+	}
+
+	return res
+}
+
 func GetECRRegistryURL(awsIntRepo repository.AWSIntegrationRepository, projectID, awsIntID uint) (string, error) {
 	ctx := context.Background()
 
@@ -621,17 +643,17 @@ func (r *Registry) listDOCRRepositories(
 func (r *Registry) listPrivateRegistryRepositories(
 	repo repository.Repository,
 ) ([]*ptypes.RegistryRepository, error) {
-	// handle dockerhub different, as it doesn't implement the docker registry http api
-	if strings.Contains(r.URL, "docker.io") {
-		// in this case, we just return the single dockerhub repository that's linked
-		res := make([]*ptypes.RegistryRepository, 0)
+	if r.isHarbor() {
+		return r.listHarborRepositories(repo)
+	}
 
-		res = append(res, &ptypes.RegistryRepository{
-			Name: strings.Split(r.URL, "docker.io/")[1],
-			URI:  r.URL,
-		})
+	if r.isQuay() {
+		return r.listQuayRepositories(repo)
+	}
 
-		return res, nil
+	// handle dockerhub different, as it doesn't implement the docker registry http api
+	if r.isDockerHub() {
+		return r.listDockerHubRepositories(repo)
 	}
 
 	basic, err := repo.BasicIntegration().ReadBasicIntegration(
@@ -975,6 +997,10 @@ func (r *Registry) GetECRPaginatedImages(
 				PushedAt:       img.ImagePushedAt,
 			}
 
+			if img.ImageSizeInBytes != nil {
+				newImage.Size = *img.ImageSizeInBytes
+			}
+
 			if _, ok := imageIDMap[tag]; ok {
 				if _, ok := imageInfoMap[tag]; !ok {
 					imageInfoMap[tag] = newImage
@@ -1096,6 +1122,10 @@ func (r *Registry) listECRImages(repoName string, repo repository.Repository) ([
 				PushedAt:       img.ImagePushedAt,
 			}
 
+			if img.ImageSizeInBytes != nil {
+				newImage.Size = *img.ImageSizeInBytes
+			}
+
 			if _, ok := imageInfoMap[tag]; !ok {
 				imageInfoMap[tag] = newImage
 			}
@@ -1284,6 +1314,7 @@ func (r *Registry) listGARImages(repoName string, repo repository.Repository) ([
 						Tag:            tag,
 						PushedAt:       &uploadTime,
 						Digest:         strings.Split(image.Uri, "@")[1],
+						Size:           image.ImageSizeBytes,
 					})
 				}
 			}
@@ -1359,9 +1390,14 @@ func (r *Registry) listDOCRImages(
 	res := make([]*ptypes.Image, 0)
 
 	for _, tag := range tags {
+		updatedAt := tag.UpdatedAt
+
 		res = append(res, &ptypes.Image{
 			RepositoryName: repoName,
 			Tag:            tag.Tag,
+			Digest:         tag.ManifestDigest,
+			PushedAt:       &updatedAt,
+			Size:           int64(tag.CompressedSizeBytes),
 		})
 	}
 
@@ -1369,8 +1405,16 @@ func (r *Registry) listDOCRImages(
 }
 
 func (r *Registry) listPrivateRegistryImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	if r.isHarbor() {
+		return r.listHarborImages(repoName, repo)
+	}
+
+	if r.isQuay() {
+		return r.listQuayImages(repoName, repo)
+	}
+
 	// handle dockerhub different, as it doesn't implement the docker registry http api
-	if strings.Contains(r.URL, "docker.io") {
+	if r.isDockerHub() {
 		return r.listDockerHubImages(repoName, repo)
 	}
 
@@ -1426,112 +1470,32 @@ func (r *Registry) listPrivateRegistryImages(repoName string, repo repository.Re
 	return res, nil
 }
 
-type dockerHubImageResult struct {
-	Name string `json:"name"`
-}
-
-type dockerHubImageResp struct {
-	Results []dockerHubImageResult `json:"results"`
-}
-
-type dockerHubLoginReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+// HasExpiringCredentials returns true if the docker credentials of the registry expire, so
+// that image pull secrets must be regenerated periodically. ECR tokens expire after 12 hours.
+func (r *Registry) HasExpiringCredentials() bool {
+	return r.AWSIntegrationID != 0
 }
 
-type dockerHubLoginResp struct {
-	Token string `json:"token"`
-}
-
-func (r *Registry) listDockerHubImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
-	basic, err := repo.BasicIntegration().ReadBasicIntegration(
-		r.ProjectID,
-		r.BasicIntegrationID,
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	client := &http.Client{}
-
-	// first, make a request for the access token
-
-	data, err := json.Marshal(&dockerHubLoginReq{
-		Username: string(basic.Username),
-		Password: string(basic.Password),
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest(
-		"POST",
-		"https://hub.docker.com/v2/users/login",
-		strings.NewReader(string(data)),
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
-
-	if err != nil {
-		return nil, err
-	}
-
-	tokenObj := dockerHubLoginResp{}
-
-	if err := json.NewDecoder(resp.Body).Decode(&tokenObj); err != nil {
-		return nil, fmt.Errorf("Could not decode Dockerhub token from response: %v", err)
-	}
-
-	req, err = http.NewRequest(
-		"GET",
-		fmt.Sprintf("https://hub.docker.com/v2/repositories/%s/tags", strings.Split(r.URL, "docker.io/")[1]),
-		nil,
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenObj.Token))
-
-	resp, err = client.Do(req)
+// GetDockerConfigJSON returns a dockerconfigjson file contents with "auths"
+// populated.
+func (r *Registry) GetDockerConfigJSON(
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) ([]byte, error) {
+	conf, err := r.getDockerConfigFile(repo, doAuth)
 
 	if err != nil {
 		return nil, err
 	}
 
-	imageResp := dockerHubImageResp{}
-
-	if err := json.NewDecoder(resp.Body).Decode(&imageResp); err != nil {
-		return nil, fmt.Errorf("Could not read private registry repositories: %v", err)
-	}
-
-	res := make([]*ptypes.Image, 0)
-
-	for _, result := range imageResp.Results {
-		res = append(res, &ptypes.Image{
-			RepositoryName: repoName,
-			Tag:            result.Name,
-		})
-	}
-
-	return res, nil
+	return json.Marshal(conf)
 }
 
-// GetDockerConfigJSON returns a dockerconfigjson file contents with "auths"
-// populated.
-func (r *Registry) GetDockerConfigJSON(
+// getDockerConfigFile returns a docker config file with the credentials of the registry
+func (r *Registry) getDockerConfigFile(
 	repo repository.Repository,
 	doAuth *oauth2.Config, // only required if using DOCR
-) ([]byte, error) {
+) (*configfile.ConfigFile, error) {
 	var conf *configfile.ConfigFile
 	var err error
 
@@ -1560,7 +1524,7 @@ func (r *Registry) GetDockerConfigJSON(
 		return nil, err
 	}
 
-	return json.Marshal(conf)
+	return conf, nil
 }
 
 func (r *Registry) getECRDockerConfigFile(
@@ -1708,8 +1672,8 @@ func (r *Registry) getPrivateRegistryDockerConfigFile(
 
 	authConfigKey := parsedURL.Host
 
-	if strings.Contains(r.URL, "index.docker.io") {
-		authConfigKey = "https://index.docker.io/v1/"
+	if r.isDockerHub() {
+		authConfigKey = dockerHubAuthConfigKey
 	}
 
 	return &configfile.ConfigFile{
@@ -0,0 +1,497 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrTypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+var ErrImageDeletionNotSupported = fmt.Errorf("deleting images is not supported for this registry")
+
+// ValidateRetentionPolicy checks that the protected tag pattern of a policy is a valid
+// regular expression
+func ValidateRetentionPolicy(policy *models.RegistryRetentionPolicy) error {
+	if policy.ProtectedTagPattern == "" {
+		return nil
+	}
+
+	if _, err := regexp.Compile(policy.ProtectedTagPattern); err != nil {
+		return fmt.Errorf("invalid protected tag pattern: %w", err)
+	}
+
+	return nil
+}
+
+// EvaluateRetention selects the images of a repository which should be deleted by a retention
+// policy, and returns the number of images which are kept. Tags beyond the most recent
+// KeepLastTags are deleted unless they are protected, and untagged images are deleted once
+// they are older than DeleteUntaggedAfterDays. Tags which share a digest with a kept tag are
+// never deleted, since deleting a manifest removes all of its tags in most registries.
+func EvaluateRetention(
+	policy *models.RegistryRetentionPolicy,
+	tagged, untagged []*ptypes.Image,
+	now time.Time,
+) ([]*ptypes.RetentionImageDeletion, int, error) {
+	var protected *regexp.Regexp
+
+	if policy.ProtectedTagPattern != "" {
+		var err error
+
+		protected, err = regexp.Compile(policy.ProtectedTagPattern)
+
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid protected tag pattern: %w", err)
+		}
+	}
+
+	sortImagesByPushedAt(tagged)
+
+	candidates := make([]*ptypes.Image, 0)
+	keptDigests := make(map[string]bool)
+	kept := 0
+	unprotected := uint(0)
+
+	for _, img := range tagged {
+		if protected != nil && protected.MatchString(img.Tag) {
+			keptDigests[img.Digest] = true
+			kept++
+			continue
+		}
+
+		unprotected++
+
+		if policy.KeepLastTags == 0 || unprotected <= policy.KeepLastTags {
+			keptDigests[img.Digest] = true
+			kept++
+			continue
+		}
+
+		candidates = append(candidates, img)
+	}
+
+	res := make([]*ptypes.RetentionImageDeletion, 0)
+
+	for _, img := range candidates {
+		if img.Digest != "" && keptDigests[img.Digest] {
+			kept++
+			continue
+		}
+
+		res = append(res, &ptypes.RetentionImageDeletion{
+			Tag:      img.Tag,
+			Digest:   img.Digest,
+			PushedAt: img.PushedAt,
+			Reason:   ptypes.RetentionDeletionReasonTagCount,
+		})
+	}
+
+	for _, img := range untagged {
+		if policy.DeleteUntaggedAfterDays == 0 || img.PushedAt == nil ||
+			now.Sub(*img.PushedAt) < time.Duration(policy.DeleteUntaggedAfterDays)*24*time.Hour {
+			kept++
+			continue
+		}
+
+		res = append(res, &ptypes.RetentionImageDeletion{
+			Digest:   img.Digest,
+			PushedAt: img.PushedAt,
+			Reason:   ptypes.RetentionDeletionReasonUntagged,
+		})
+	}
+
+	return res, kept, nil
+}
+
+// EnforceRetention applies a retention policy to each repository of the registry. If dryRun
+// is set, no images are deleted and the report lists the images which would be deleted.
+// Failures are recorded per repository, so that a single repository doesn't block the rest.
+func (r *Registry) EnforceRetention(
+	policy *models.RegistryRetentionPolicy,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	dryRun bool,
+	now time.Time,
+) (*ptypes.RegistryRetentionReport, error) {
+	if err := ValidateRetentionPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	if !dryRun && !r.supportsImageDeletion() {
+		return nil, ErrImageDeletionNotSupported
+	}
+
+	repos, err := r.ListRepositories(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ptypes.RegistryRetentionReport{
+		RegistryID:   r.ID,
+		DryRun:       dryRun,
+		GeneratedAt:  now,
+		Repositories: make([]*ptypes.RetentionRepositoryReport, 0),
+	}
+
+	for _, regRepo := range repos {
+		repoReport := &ptypes.RetentionRepositoryReport{
+			Repository: regRepo.Name,
+			Deletions:  make([]*ptypes.RetentionImageDeletion, 0),
+		}
+
+		report.Repositories = append(report.Repositories, repoReport)
+
+		deletions, kept, err := r.evaluateRepositoryRetention(policy, regRepo.Name, repo, doAuth, now)
+
+		if err != nil {
+			repoReport.Error = err.Error()
+			continue
+		}
+
+		repoReport.Deletions = deletions
+		repoReport.Kept = kept
+
+		if dryRun || len(deletions) == 0 {
+			continue
+		}
+
+		if err := r.DeleteImages(regRepo.Name, deletions, repo); err != nil {
+			repoReport.Error = err.Error()
+		}
+	}
+
+	return report, nil
+}
+
+func (r *Registry) evaluateRepositoryRetention(
+	policy *models.RegistryRetentionPolicy,
+	repoName string,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	now time.Time,
+) ([]*ptypes.RetentionImageDeletion, int, error) {
+	tagged, err := r.ListImages(repoName, repo, doAuth)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// digests are required to avoid deleting tags which share a manifest with a kept tag
+	if err := r.PopulateImageMetadata(repoName, tagged, repo); err != nil {
+		return nil, 0, err
+	}
+
+	untagged, err := r.listUntaggedImages(repoName, repo)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return EvaluateRetention(policy, tagged, untagged, now)
+}
+
+func (r *Registry) supportsImageDeletion() bool {
+	if r.AWSIntegrationID != 0 {
+		return true
+	}
+
+	return r.BasicIntegrationID != 0 && !r.isQuay()
+}
+
+// listUntaggedImages lists the untagged images of a repository. Only ECR and Harbor report
+// untagged images, so an empty list is returned for other registries.
+func (r *Registry) listUntaggedImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	if r.AWSIntegrationID != 0 {
+		return r.listECRUntaggedImages(repoName, repo)
+	}
+
+	if r.BasicIntegrationID != 0 && r.isHarbor() {
+		artifacts, err := r.listHarborArtifacts(repoName, repo)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]*ptypes.Image, 0)
+
+		for _, artifact := range artifacts {
+			if len(artifact.Tags) == 0 {
+				res = append(res, &ptypes.Image{
+					RepositoryName: repoName,
+					Digest:         artifact.Digest,
+					PushedAt:       artifact.PushTime,
+				})
+			}
+		}
+
+		return res, nil
+	}
+
+	return []*ptypes.Image{}, nil
+}
+
+func (r *Registry) listECRUntaggedImages(repoName string, repo repository.Repository) ([]*ptypes.Image, error) {
+	aws, err := repo.AWSIntegration().ReadAWSIntegration(
+		r.ProjectID,
+		r.AWSIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ecr.NewFromConfig(aws.Config())
+
+	paginator := ecr.NewDescribeImagesPaginator(svc, &ecr.DescribeImagesInput{
+		RepositoryName: &repoName,
+		Filter: &ecrTypes.DescribeImagesFilter{
+			TagStatus: ecrTypes.TagStatusUntagged,
+		},
+	})
+
+	res := make([]*ptypes.Image, 0)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, img := range page.ImageDetails {
+			res = append(res, &ptypes.Image{
+				RepositoryName: repoName,
+				Digest:         *img.ImageDigest,
+				PushedAt:       img.ImagePushedAt,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteImages deletes images selected by a retention policy from a repository. Tags are
+// removed individually where the registry supports it, while untagged images are deleted
+// by digest.
+func (r *Registry) DeleteImages(
+	repoName string,
+	deletions []*ptypes.RetentionImageDeletion,
+	repo repository.Repository,
+) error {
+	if len(deletions) == 0 {
+		return nil
+	}
+
+	if r.AWSIntegrationID != 0 {
+		return r.deleteECRImages(repoName, deletions, repo)
+	}
+
+	if r.BasicIntegrationID != 0 && r.isHarbor() {
+		return r.deleteHarborImages(repoName, deletions, repo)
+	}
+
+	if r.BasicIntegrationID != 0 && r.isDockerHub() {
+		return r.deleteDockerHubImages(repoName, deletions, repo)
+	}
+
+	client, repoPath, err := r.getV2Client(repoName, repo)
+
+	if err != nil {
+		return err
+	} else if client == nil || r.BasicIntegrationID == 0 {
+		return ErrImageDeletionNotSupported
+	}
+
+	for _, deletion := range deletions {
+		if deletion.Digest == "" {
+			continue
+		}
+
+		if err := client.delete(fmt.Sprintf("%s/manifests/%s", repoPath, deletion.Digest)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) deleteECRImages(
+	repoName string,
+	deletions []*ptypes.RetentionImageDeletion,
+	repo repository.Repository,
+) error {
+	aws, err := repo.AWSIntegration().ReadAWSIntegration(
+		r.ProjectID,
+		r.AWSIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	svc := ecr.NewFromConfig(aws.Config())
+
+	imageIDs := make([]ecrTypes.ImageIdentifier, 0)
+
+	for _, deletion := range deletions {
+		deletion := deletion
+
+		// deleting by tag only removes the tag, and ECR deletes the image once it has no tags
+		if deletion.Tag != "" {
+			imageIDs = append(imageIDs, ecrTypes.ImageIdentifier{ImageTag: &deletion.Tag})
+		} else {
+			imageIDs = append(imageIDs, ecrTypes.ImageIdentifier{ImageDigest: &deletion.Digest})
+		}
+	}
+
+	// AWS API expects the length of imageIDs to be at max 100 at a time
+	for start := 0; start < len(imageIDs); start += 100 {
+		end := start + 100
+
+		if end > len(imageIDs) {
+			end = len(imageIDs)
+		}
+
+		resp, err := svc.BatchDeleteImage(context.Background(), &ecr.BatchDeleteImageInput{
+			RepositoryName: &repoName,
+			ImageIds:       imageIDs[start:end],
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Failures) > 0 && resp.Failures[0].FailureReason != nil {
+			return fmt.Errorf("could not delete %d images: %s", len(resp.Failures), *resp.Failures[0].FailureReason)
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) deleteHarborImages(
+	repoName string,
+	deletions []*ptypes.RetentionImageDeletion,
+	repo repository.Repository,
+) error {
+	apiURL, project, err := r.harborProject()
+
+	if err != nil {
+		return err
+	}
+
+	basic, err := repo.BasicIntegration().ReadBasicIntegration(
+		r.ProjectID,
+		r.BasicIntegrationID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	artifactsURL := fmt.Sprintf(
+		"%s/projects/%s/repositories/%s/artifacts",
+		apiURL, url.PathEscape(project), url.PathEscape(url.PathEscape(strings.TrimPrefix(repoName, project+"/"))),
+	)
+
+	for _, deletion := range deletions {
+		reqURL := fmt.Sprintf("%s/%s", artifactsURL, deletion.Digest)
+
+		// removing a tag leaves an untagged artifact, which is deleted by the untagged rule
+		if deletion.Tag != "" {
+			reqURL = fmt.Sprintf("%s/tags/%s", reqURL, url.PathEscape(deletion.Tag))
+		}
+
+		err := doDeleteRequest(reqURL, func(req *http.Request) {
+			req.SetBasicAuth(string(basic.Username), string(basic.Password))
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) deleteDockerHubImages(
+	repoName string,
+	deletions []*ptypes.RetentionImageDeletion,
+	repo repository.Repository,
+) error {
+	namespace, _ := r.dockerHubPath()
+
+	if !strings.Contains(repoName, "/") {
+		repoName = namespace + "/" + repoName
+	}
+
+	token, err := r.getDockerHubToken(repo)
+
+	if err != nil {
+		return err
+	}
+
+	for _, deletion := range deletions {
+		if deletion.Tag == "" {
+			continue
+		}
+
+		err := doDeleteRequest(
+			fmt.Sprintf("%s/repositories/%s/tags/%s/", dockerHubAPIURL, repoName, url.PathEscape(deletion.Tag)),
+			func(req *http.Request) {
+				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *v2Client) delete(path string) error {
+	return doDeleteRequest(fmt.Sprintf("%s/v2/%s", c.baseURL, path), c.setAuth)
+}
+
+// doDeleteRequest sends a DELETE request, treating resources which were already deleted as
+// successfully deleted
+func doDeleteRequest(reqURL string, authorize func(req *http.Request)) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	req, err := http.NewRequest("DELETE", reqURL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	authorize(req)
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error deleting %s: status code %d", reqURL, resp.StatusCode)
+	}
+
+	return nil
+}
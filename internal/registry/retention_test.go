@@ -0,0 +1,82 @@
+package registry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestEvaluateRetention(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	daysAgo := func(days int) *time.Time {
+		res := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &res
+	}
+
+	tagged := []*types.Image{
+		{Tag: "v1.0.0", Digest: "sha256:a", PushedAt: daysAgo(30)},
+		{Tag: "abc123", Digest: "sha256:b", PushedAt: daysAgo(20)},
+		{Tag: "def456", Digest: "sha256:c", PushedAt: daysAgo(10)},
+		{Tag: "ghi789", Digest: "sha256:d", PushedAt: daysAgo(5)},
+		{Tag: "latest", Digest: "sha256:d", PushedAt: daysAgo(1)},
+		{Tag: "old-latest", Digest: "sha256:d", PushedAt: daysAgo(40)},
+	}
+
+	untagged := []*types.Image{
+		{Digest: "sha256:e", PushedAt: daysAgo(15)},
+		{Digest: "sha256:f", PushedAt: daysAgo(2)},
+	}
+
+	policy := &models.RegistryRetentionPolicy{
+		KeepLastTags:            2,
+		DeleteUntaggedAfterDays: 7,
+		ProtectedTagPattern:     `^v[0-9]+\.`,
+	}
+
+	deletions, kept, err := registry.EvaluateRetention(policy, tagged, untagged, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]types.RetentionDeletionReason{
+		"abc123":   types.RetentionDeletionReasonTagCount,
+		"def456":   types.RetentionDeletionReasonTagCount,
+		"sha256:e": types.RetentionDeletionReasonUntagged,
+	}
+
+	if len(deletions) != len(expected) {
+		t.Fatalf("expected %d deletions, got %d", len(expected), len(deletions))
+	}
+
+	for _, deletion := range deletions {
+		key := deletion.Tag
+
+		if key == "" {
+			key = deletion.Digest
+		}
+
+		if reason, ok := expected[key]; !ok || reason != deletion.Reason {
+			t.Errorf("unexpected deletion of %s with reason %s", key, deletion.Reason)
+		}
+	}
+
+	// v1.0.0, latest, ghi789 and old-latest (which shares a digest with latest), and sha256:f
+	if kept != 5 {
+		t.Errorf("expected 5 kept images, got %d", kept)
+	}
+}
+
+func TestEvaluateRetentionInvalidPattern(t *testing.T) {
+	policy := &models.RegistryRetentionPolicy{
+		ProtectedTagPattern: "(",
+	}
+
+	if _, _, err := registry.EvaluateRetention(policy, nil, nil, time.Now()); err == nil {
+		t.Errorf("expected error for invalid protected tag pattern")
+	}
+}
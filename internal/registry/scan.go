@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrTypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+var ErrNoScanFindings = fmt.Errorf("no completed vulnerability scan found for image")
+
+// GetImageScanSummary returns the vulnerability counts reported by the registry's image
+// scanner for an image. ECR and Harbor registries are supported.
+func (r *Registry) GetImageScanSummary(
+	repo repository.Repository,
+	repoName, tag string,
+) (*ptypes.ImageVulnerabilitySummary, error) {
+	if r.AWSIntegrationID != 0 {
+		return r.getECRImageScanSummary(repo, repoName, tag)
+	}
+
+	if r.BasicIntegrationID != 0 && r.isHarbor() {
+		return r.getHarborImageScanSummary(repo, repoName, tag)
+	}
+
+	return nil, fmt.Errorf("image scanning is not supported for this registry")
+}
+
+// SupportsImageScanning returns true if scan results can be read from the registry
+func (r *Registry) SupportsImageScanning() bool {
+	return r.AWSIntegrationID != 0 || (r.BasicIntegrationID != 0 && r.isHarbor())
+}
+
+func (r *Registry) getECRImageScanSummary(
+	repo repository.Repository,
+	repoName, tag string,
+) (*ptypes.ImageVulnerabilitySummary, error) {
+	ctx := context.Background()
+
+	aws, err := repo.AWSIntegration().ReadAWSIntegration(
+		r.ProjectID,
+		r.AWSIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ecr.NewFromConfig(aws.Config())
+
+	resp, err := svc.DescribeImageScanFindings(ctx, &ecr.DescribeImageScanFindingsInput{
+		RepositoryName: &repoName,
+		ImageId: &ecrTypes.ImageIdentifier{
+			ImageTag: &tag,
+		},
+	})
+
+	if err != nil {
+		var scanNotFound *ecrTypes.ScanNotFoundException
+		var imageNotFound *ecrTypes.ImageNotFoundException
+
+		if errors.As(err, &scanNotFound) || errors.As(err, &imageNotFound) {
+			return nil, ErrNoScanFindings
+		}
+
+		return nil, err
+	}
+
+	if resp.ImageScanStatus == nil || resp.ImageScanStatus.Status != ecrTypes.ScanStatusComplete ||
+		resp.ImageScanFindings == nil {
+		return nil, ErrNoScanFindings
+	}
+
+	res := &ptypes.ImageVulnerabilitySummary{
+		Scanner:   ptypes.VulnerabilityScannerECR,
+		ScannedAt: resp.ImageScanFindings.ImageScanCompletedAt,
+	}
+
+	for severity, count := range resp.ImageScanFindings.FindingSeverityCounts {
+		switch strings.ToUpper(severity) {
+		case "CRITICAL":
+			res.Critical += int(count)
+		case "HIGH":
+			res.High += int(count)
+		case "MEDIUM":
+			res.Medium += int(count)
+		case "LOW":
+			res.Low += int(count)
+		default:
+			res.Unknown += int(count)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,266 @@
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+// cosignSignatureAnnotation is the annotation on the layers of a cosign signature manifest
+// which holds the base64-encoded signature of the layer
+const cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// maxSignaturePayloadSize limits the size of signature payloads read from a registry
+const maxSignaturePayloadSize = 1 << 20
+
+var (
+	ErrImageNotSigned         = fmt.Errorf("image is not signed")
+	ErrInvalidImageSignature  = fmt.Errorf("image signature could not be verified with the public key")
+	ErrUnsupportedSigningKey  = fmt.Errorf("signing keys must be PEM-encoded ECDSA, RSA or Ed25519 public keys")
+	ErrSignatureDigestInvalid = fmt.Errorf("image signature does not reference the image digest")
+)
+
+// cosignPayload is the simple signing payload which cosign signs for an image
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// ParseSigningPublicKey parses a PEM-encoded public key used to verify cosign signatures,
+// such as the cosign.pub file created by "cosign generate-key-pair"
+func ParseSigningPublicKey(pemKey string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(pemKey)))
+
+	if block == nil {
+		return nil, ErrUnsupportedSigningKey
+	}
+
+	pubKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+
+	if err != nil {
+		return nil, ErrUnsupportedSigningKey
+	}
+
+	switch pubKey.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return pubKey, nil
+	}
+
+	return nil, ErrUnsupportedSigningKey
+}
+
+// VerifySignaturePayload verifies a base64-encoded cosign signature of a payload, and checks
+// that the payload references the given image digest
+func VerifySignaturePayload(pubKey crypto.PublicKey, payload []byte, signature, digest string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+
+	if err != nil {
+		return ErrInvalidImageSignature
+	}
+
+	hash := sha256.Sum256(payload)
+	verified := false
+
+	switch key := pubKey.(type) {
+	case *ecdsa.PublicKey:
+		verified = ecdsa.VerifyASN1(key, hash[:], sig)
+	case *rsa.PublicKey:
+		verified = rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig) == nil
+	case ed25519.PublicKey:
+		verified = ed25519.Verify(key, payload, sig)
+	default:
+		return ErrUnsupportedSigningKey
+	}
+
+	if !verified {
+		return ErrInvalidImageSignature
+	}
+
+	parsed := &cosignPayload{}
+
+	if err := json.Unmarshal(payload, parsed); err != nil {
+		return ErrSignatureDigestInvalid
+	}
+
+	if parsed.Critical.Image.DockerManifestDigest != digest {
+		return ErrSignatureDigestInvalid
+	}
+
+	return nil
+}
+
+// VerifyImageSignature checks that an image of this registry has a cosign signature which
+// is verified by the public key. Signatures are read from the "sha256-<digest>.sig" tag of
+// the image repository, where cosign stores them by default.
+func (r *Registry) VerifyImageSignature(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	image string,
+	pubKey crypto.PublicKey,
+) error {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return fmt.Errorf("invalid image reference %s: %w", image, err)
+	}
+
+	client, repoPath, _, err := r.getAuthorizedV2Client(repo, doAuth, reference.Path(named), "pull")
+
+	if err != nil {
+		return err
+	}
+
+	var digest string
+
+	if digested, ok := named.(reference.Digested); ok {
+		digest = digested.Digest().String()
+	} else {
+		tag := "latest"
+
+		if tagged, ok := named.(reference.Tagged); ok {
+			tag = tagged.Tag()
+		}
+
+		digest, err = client.resolveDigest(repoPath, tag)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateImageDigest(digest); err != nil {
+		return err
+	}
+
+	sigManifest := &imageManifest{}
+
+	req, err := http.NewRequest(
+		"GET",
+		fmt.Sprintf("%s/v2/%s/manifests/%s.sig", client.baseURL, repoPath, strings.Replace(digest, ":", "-", 1)),
+		nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", manifestAcceptHeader)
+
+	resp, err := client.do(req, http.StatusOK, http.StatusNotFound)
+
+	if err != nil {
+		return fmt.Errorf("error reading image signatures: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrImageNotSigned
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(sigManifest); err != nil {
+		return fmt.Errorf("error reading image signatures: %w", err)
+	}
+
+	verifyErr := ErrImageNotSigned
+
+	// an image may be signed several times, and is verified if any signature is valid
+	for _, layer := range sigManifest.Layers {
+		signature, ok := layer.Annotations[cosignSignatureAnnotation]
+
+		if !ok {
+			continue
+		}
+
+		payload, err := client.getBlob(repoPath, layer.Digest)
+
+		if err != nil {
+			return err
+		}
+
+		if verifyErr = VerifySignaturePayload(pubKey, payload, signature, digest); verifyErr == nil {
+			return nil
+		}
+	}
+
+	return verifyErr
+}
+
+// resolveDigest returns the digest of the manifest which a tag points to
+func (c *v2Client) resolveDigest(repoPath, tag string) (string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL, repoPath, tag), nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", manifestAcceptHeader)
+
+	resp, err := c.do(req, http.StatusOK)
+
+	if err != nil {
+		return "", fmt.Errorf("error reading manifest %s: %w", tag, err)
+	}
+
+	defer resp.Body.Close()
+
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	raw, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(raw)), nil
+}
+
+// getBlob reads a small blob, such as a signature payload, and checks it against its digest
+func (c *v2Client) getBlob(repoPath, digest string) ([]byte, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v2/%s/blobs/%s", c.baseURL, repoPath, digest), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req, http.StatusOK)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading blob %s: %w", digest, err)
+	}
+
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSignaturePayloadSize))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fmt.Sprintf("sha256:%x", sha256.Sum256(raw)) != digest {
+		return nil, fmt.Errorf("blob %s does not match its digest", digest)
+	}
+
+	return raw, nil
+}
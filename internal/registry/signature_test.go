@@ -0,0 +1,88 @@
+package registry_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestVerifySignaturePayload(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKey, err := registry.ParseSigningPublicKey(string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: pubKeyBytes,
+	})))
+
+	if err != nil {
+		t.Fatalf("expected public key to be parsed, got %v", err)
+	}
+
+	digest := "sha256:" + strings.Repeat("a1", 32)
+	payload := []byte(fmt.Sprintf(
+		`{"critical":{"identity":{"docker-reference":"example.com/app"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+		digest,
+	))
+
+	sign := func(payload []byte) string {
+		hash := sha256.Sum256(payload)
+		sig, err := ecdsa.SignASN1(rand.Reader, privKey, hash[:])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return base64.StdEncoding.EncodeToString(sig)
+	}
+
+	if err := registry.VerifySignaturePayload(pubKey, payload, sign(payload), digest); err != nil {
+		t.Errorf("expected signature to be valid, got %v", err)
+	}
+
+	otherDigest := "sha256:" + strings.Repeat("b2", 32)
+
+	if err := registry.VerifySignaturePayload(pubKey, payload, sign(payload), otherDigest); err != registry.ErrSignatureDigestInvalid {
+		t.Errorf("expected signature of another digest to be rejected, got %v", err)
+	}
+
+	tampered := []byte(strings.Replace(string(payload), "example.com", "evil.com", 1))
+
+	if err := registry.VerifySignaturePayload(pubKey, tampered, sign(payload), digest); err != registry.ErrInvalidImageSignature {
+		t.Errorf("expected tampered payload to be rejected, got %v", err)
+	}
+
+	if err := registry.VerifySignaturePayload(pubKey, payload, "not base64!", digest); err != registry.ErrInvalidImageSignature {
+		t.Errorf("expected malformed signature to be rejected, got %v", err)
+	}
+}
+
+func TestParseSigningPublicKeyInvalid(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"not a key",
+		"-----BEGIN PUBLIC KEY-----\nYWJj\n-----END PUBLIC KEY-----",
+	} {
+		if _, err := registry.ParseSigningPublicKey(key); err != registry.ErrUnsupportedSigningKey {
+			t.Errorf("expected %q to be rejected, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+// maxUsageRequests limits the number of repositories whose images are listed concurrently
+// when computing registry usage
+const maxUsageRequests = 5
+
+// GetUsage lists the images of each repository of the registry to compute the number of
+// images and, for registries which report image sizes, the storage used by each repository.
+// The largest repositories are returned, up to the given limit.
+func (r *Registry) GetUsage(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+	limit int,
+	now time.Time,
+) (*ptypes.RegistryUsage, error) {
+	repos, err := r.ListRepositories(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repoUsages := make([]*ptypes.RepositoryUsage, len(repos))
+	repoErrs := make([]error, len(repos))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxUsageRequests)
+
+	for i, regRepo := range repos {
+		wg.Add(1)
+
+		go func(i int, repoName string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			repoUsages[i], repoErrs[i] = r.getRepositoryUsage(repoName, repo, doAuth)
+		}(i, regRepo.Name)
+	}
+
+	wg.Wait()
+
+	usage := &ptypes.RegistryUsage{
+		RegistryID:          r.ID,
+		RepositoryCount:     len(repos),
+		LargestRepositories: make([]*ptypes.RepositoryUsage, 0),
+		GeneratedAt:         now,
+	}
+
+	for i, repoUsage := range repoUsages {
+		if repoErrs[i] != nil {
+			usage.FailedRepositories = append(usage.FailedRepositories, repos[i].Name)
+			continue
+		}
+
+		usage.ImageCount += repoUsage.ImageCount
+		usage.TotalSizeBytes += repoUsage.SizeBytes
+		usage.LargestRepositories = append(usage.LargestRepositories, repoUsage)
+
+		if repoUsage.SizeBytes > 0 {
+			usage.StorageReported = true
+		}
+	}
+
+	SortRepositoryUsages(usage.LargestRepositories)
+
+	if limit > 0 && len(usage.LargestRepositories) > limit {
+		usage.LargestRepositories = usage.LargestRepositories[:limit]
+	}
+
+	return usage, nil
+}
+
+func (r *Registry) getRepositoryUsage(
+	repoName string,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) (*ptypes.RepositoryUsage, error) {
+	imgs, err := r.ListImages(repoName, repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// untagged images still use storage, but are only listed by some registries
+	untagged, err := r.listUntaggedImages(repoName, repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return GetRepositoryUsage(repoName, append(imgs, untagged...)), nil
+}
+
+// GetRepositoryUsage counts the images of a repository and sums their sizes. Tags which
+// point to the same digest are counted as a single image.
+func GetRepositoryUsage(repoName string, imgs []*ptypes.Image) *ptypes.RepositoryUsage {
+	res := &ptypes.RepositoryUsage{
+		Name: repoName,
+	}
+
+	seen := make(map[string]bool)
+
+	for _, img := range imgs {
+		if img.Digest != "" {
+			if seen[img.Digest] {
+				continue
+			}
+
+			seen[img.Digest] = true
+		}
+
+		res.ImageCount++
+		res.SizeBytes += img.Size
+	}
+
+	return res
+}
+
+// SortRepositoryUsages sorts repositories by size, largest first, and then by image count
+// for repositories of the same size, such as for registries which don't report sizes
+func SortRepositoryUsages(usages []*ptypes.RepositoryUsage) {
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].SizeBytes != usages[j].SizeBytes {
+			return usages[i].SizeBytes > usages[j].SizeBytes
+		}
+
+		if usages[i].ImageCount != usages[j].ImageCount {
+			return usages[i].ImageCount > usages[j].ImageCount
+		}
+
+		return usages[i].Name < usages[j].Name
+	})
+}
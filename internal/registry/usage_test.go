@@ -0,0 +1,46 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestGetRepositoryUsage(t *testing.T) {
+	usage := registry.GetRepositoryUsage("app", []*types.Image{
+		{Tag: "v1", Digest: "sha256:a", Size: 100},
+		{Tag: "latest", Digest: "sha256:a", Size: 100},
+		{Tag: "v2", Digest: "sha256:b", Size: 150},
+		{Digest: "sha256:c", Size: 50},
+		{Tag: "v3", Size: 25},
+	})
+
+	if usage.ImageCount != 4 {
+		t.Errorf("expected 4 images, got %d", usage.ImageCount)
+	}
+
+	if usage.SizeBytes != 325 {
+		t.Errorf("expected 325 bytes, got %d", usage.SizeBytes)
+	}
+}
+
+func TestSortRepositoryUsages(t *testing.T) {
+	usages := []*types.RepositoryUsage{
+		{Name: "small", ImageCount: 10, SizeBytes: 10},
+		{Name: "b", ImageCount: 3},
+		{Name: "large", ImageCount: 1, SizeBytes: 1000},
+		{Name: "a", ImageCount: 3},
+		{Name: "c", ImageCount: 5},
+	}
+
+	registry.SortRepositoryUsages(usages)
+
+	expected := []string{"large", "small", "c", "a", "b"}
+
+	for i, name := range expected {
+		if usages[i].Name != name {
+			t.Errorf("expected repository %d to be %s, got %s", i, name, usages[i].Name)
+		}
+	}
+}
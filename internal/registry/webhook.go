@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+)
+
+var ErrUnknownWebhookFormat = fmt.Errorf("unrecognized registry webhook payload: expected an ECR EventBridge, Docker Hub or Harbor push event")
+
+// PushEvent is an image tag pushed to a registry, parsed from a registry webhook
+type PushEvent struct {
+	// The repository of the image, including the registry host
+	RepositoryURI string
+	Tag           string
+	Digest        string
+}
+
+type ecrPushEvent struct {
+	DetailType string `json:"detail-type"`
+	Source     string `json:"source"`
+	Account    string `json:"account"`
+	Region     string `json:"region"`
+	Detail     struct {
+		ActionType     string `json:"action-type"`
+		Result         string `json:"result"`
+		RepositoryName string `json:"repository-name"`
+		ImageDigest    string `json:"image-digest"`
+		ImageTag       string `json:"image-tag"`
+	} `json:"detail"`
+}
+
+type dockerHubPushEvent struct {
+	PushData *struct {
+		Tag string `json:"tag"`
+	} `json:"push_data"`
+	Repository struct {
+		RepoName string `json:"repo_name"`
+	} `json:"repository"`
+}
+
+type harborPushEvent struct {
+	Type      string `json:"type"`
+	EventData *struct {
+		Resources []struct {
+			Digest      string `json:"digest"`
+			Tag         string `json:"tag"`
+			ResourceURL string `json:"resource_url"`
+		} `json:"resources"`
+	} `json:"event_data"`
+}
+
+// ParsePushEvents parses the pushed tags from the body of a registry webhook. ECR image
+// actions forwarded by EventBridge, Docker Hub webhooks and Harbor webhooks are supported.
+// Events for failed pushes, untagged pushes and other actions result in an empty list.
+func ParsePushEvents(body []byte) ([]*PushEvent, error) {
+	ecrEvent := &ecrPushEvent{}
+
+	if err := json.Unmarshal(body, ecrEvent); err == nil && ecrEvent.Source == "aws.ecr" {
+		res := make([]*PushEvent, 0)
+
+		if ecrEvent.DetailType == "ECR Image Action" && ecrEvent.Detail.ActionType == "PUSH" &&
+			ecrEvent.Detail.Result == "SUCCESS" && ecrEvent.Detail.ImageTag != "" {
+			res = append(res, &PushEvent{
+				RepositoryURI: fmt.Sprintf(
+					"%s.dkr.ecr.%s.amazonaws.com/%s",
+					ecrEvent.Account, ecrEvent.Region, ecrEvent.Detail.RepositoryName,
+				),
+				Tag:    ecrEvent.Detail.ImageTag,
+				Digest: ecrEvent.Detail.ImageDigest,
+			})
+		}
+
+		return res, nil
+	}
+
+	harborEvent := &harborPushEvent{}
+
+	if err := json.Unmarshal(body, harborEvent); err == nil && harborEvent.EventData != nil && harborEvent.Type != "" {
+		res := make([]*PushEvent, 0)
+
+		if harborEvent.Type != "PUSH_ARTIFACT" {
+			return res, nil
+		}
+
+		for _, resource := range harborEvent.EventData.Resources {
+			if resource.Tag == "" {
+				continue
+			}
+
+			// the resource url is the full image reference, such as harbor.example.com/project/repo:tag
+			res = append(res, &PushEvent{
+				RepositoryURI: strings.TrimSuffix(resource.ResourceURL, ":"+resource.Tag),
+				Tag:           resource.Tag,
+				Digest:        resource.Digest,
+			})
+		}
+
+		return res, nil
+	}
+
+	dockerHubEvent := &dockerHubPushEvent{}
+
+	if err := json.Unmarshal(body, dockerHubEvent); err == nil && dockerHubEvent.PushData != nil {
+		res := make([]*PushEvent, 0)
+
+		if dockerHubEvent.PushData.Tag != "" && dockerHubEvent.Repository.RepoName != "" {
+			res = append(res, &PushEvent{
+				RepositoryURI: dockerHubRegistryHost + "/" + dockerHubEvent.Repository.RepoName,
+				Tag:           dockerHubEvent.PushData.Tag,
+			})
+		}
+
+		return res, nil
+	}
+
+	return nil, ErrUnknownWebhookFormat
+}
+
+// GetImageRepoURICandidates returns the forms in which an image repository may be stored as
+// the image repository of a release. Docker Hub repositories may be stored with or without
+// a docker.io host.
+func GetImageRepoURICandidates(repoURI string) []string {
+	res := []string{repoURI}
+
+	named, err := reference.ParseNormalizedNamed(repoURI)
+
+	if err != nil || reference.Domain(named) != "docker.io" {
+		return res
+	}
+
+	path := reference.Path(named)
+
+	for _, candidate := range []string{
+		path,
+		"docker.io/" + path,
+		dockerHubRegistryHost + "/" + path,
+		"registry-1.docker.io/" + path,
+	} {
+		if candidate != repoURI {
+			res = append(res, candidate)
+		}
+	}
+
+	return res
+}
+
+// ValidateTagPattern checks that a push deploy tag pattern is a valid regular expression
+func ValidateTagPattern(pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid tag pattern: %w", err)
+	}
+
+	return nil
+}
+
+// MatchesTagPattern returns true if a tag matches a push deploy tag pattern. All tags match
+// an empty pattern.
+func MatchesTagPattern(pattern, tag string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	re, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(tag)
+}
@@ -0,0 +1,130 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+func TestParsePushEvents(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected []registry.PushEvent
+	}{
+		"ecr push": {
+			body: `{
+				"detail-type": "ECR Image Action",
+				"source": "aws.ecr",
+				"account": "123456789012",
+				"region": "us-east-1",
+				"detail": {
+					"action-type": "PUSH",
+					"result": "SUCCESS",
+					"repository-name": "web",
+					"image-digest": "sha256:abc",
+					"image-tag": "v1.0.0"
+				}
+			}`,
+			expected: []registry.PushEvent{{
+				RepositoryURI: "123456789012.dkr.ecr.us-east-1.amazonaws.com/web",
+				Tag:           "v1.0.0",
+				Digest:        "sha256:abc",
+			}},
+		},
+		"ecr delete": {
+			body: `{
+				"detail-type": "ECR Image Action",
+				"source": "aws.ecr",
+				"detail": {"action-type": "DELETE", "result": "SUCCESS", "image-tag": "v1.0.0"}
+			}`,
+			expected: []registry.PushEvent{},
+		},
+		"docker hub push": {
+			body: `{
+				"push_data": {"tag": "latest"},
+				"repository": {"repo_name": "porter/web"}
+			}`,
+			expected: []registry.PushEvent{{
+				RepositoryURI: "index.docker.io/porter/web",
+				Tag:           "latest",
+			}},
+		},
+		"harbor push": {
+			body: `{
+				"type": "PUSH_ARTIFACT",
+				"event_data": {
+					"resources": [{
+						"digest": "sha256:def",
+						"tag": "v2",
+						"resource_url": "harbor.example.com/project/web:v2"
+					}]
+				}
+			}`,
+			expected: []registry.PushEvent{{
+				RepositoryURI: "harbor.example.com/project/web",
+				Tag:           "v2",
+				Digest:        "sha256:def",
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		events, err := registry.ParsePushEvents([]byte(test.body))
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if len(events) != len(test.expected) {
+			t.Fatalf("%s: expected %d events, got %d", name, len(test.expected), len(events))
+		}
+
+		for i, event := range events {
+			if *event != test.expected[i] {
+				t.Errorf("%s: expected %+v, got %+v", name, test.expected[i], *event)
+			}
+		}
+	}
+
+	if _, err := registry.ParsePushEvents([]byte(`{"hello": "world"}`)); err != registry.ErrUnknownWebhookFormat {
+		t.Errorf("expected unknown webhook format error, got %v", err)
+	}
+}
+
+func TestGetImageRepoURICandidates(t *testing.T) {
+	candidates := registry.GetImageRepoURICandidates("index.docker.io/porter/web")
+
+	for _, expected := range []string{"porter/web", "docker.io/porter/web", "index.docker.io/porter/web"} {
+		found := false
+
+		for _, candidate := range candidates {
+			if candidate == expected {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expected %s in candidates %v", expected, candidates)
+		}
+	}
+
+	ecrURI := "123456789012.dkr.ecr.us-east-1.amazonaws.com/web"
+
+	if candidates := registry.GetImageRepoURICandidates(ecrURI); len(candidates) != 1 || candidates[0] != ecrURI {
+		t.Errorf("expected only %s, got %v", ecrURI, candidates)
+	}
+}
+
+func TestMatchesTagPattern(t *testing.T) {
+	if !registry.MatchesTagPattern("", "anything") {
+		t.Errorf("expected empty pattern to match all tags")
+	}
+
+	if !registry.MatchesTagPattern(`^v\d+\.\d+\.\d+$`, "v1.2.3") {
+		t.Errorf("expected semver tag to match")
+	}
+
+	if registry.MatchesTagPattern(`^v\d+\.\d+\.\d+$`, "latest") {
+		t.Errorf("expected latest not to match")
+	}
+}
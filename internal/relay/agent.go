@@ -0,0 +1,363 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/incidents"
+	lr "github.com/porter-dev/porter/pkg/logger"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ReportPath is the path of the API endpoint which relay agents send their reports to
+const ReportPath = "/api/relay/report"
+
+const (
+	// DefaultReportInterval is the interval which agents send reports on if none is configured
+	DefaultReportInterval = 30 * time.Second
+
+	// the largest number of events which are buffered while the server can't be reached. The
+	// oldest events are dropped once the buffer is full.
+	maxBufferedEvents = 1000
+)
+
+// AgentOpts configures the relay agent of a cluster
+type AgentOpts struct {
+	// the url of the Porter server, such as https://dashboard.getporter.dev
+	ServerURL string
+
+	// the relay token of the cluster
+	Token string
+
+	// the version of the agent, which is sent with its reports
+	Version string
+
+	// the clientset of the cluster which the agent runs in
+	Clientset kubernetes.Interface
+
+	Interval time.Duration
+
+	Logger *lr.Logger
+}
+
+// RunAgent watches the workloads of the cluster, and sends a report of their incidents, events
+// and metrics to the Porter server on an interval. Events are buffered while the server can't
+// be reached. The log alert rules which the server returns are evaluated on the logs of their
+// releases, and reported as incidents when they match. It returns when the context is
+// cancelled.
+func RunAgent(ctx context.Context, opts *AgentOpts) error {
+	if !strings.HasPrefix(opts.ServerURL, "http://") && !strings.HasPrefix(opts.ServerURL, "https://") {
+		return fmt.Errorf("invalid server url %s: must start with http:// or https://", opts.ServerURL)
+	}
+
+	interval := opts.Interval
+
+	if interval == 0 {
+		interval = DefaultReportInterval
+	}
+
+	a := &agent{
+		opts:     opts,
+		url:      strings.TrimSuffix(opts.ServerURL, "/") + ReportPath,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		events:   newEventBuffer(maxBufferedEvents),
+		reported: make(map[string]bool),
+
+		logAlerts: newLogAlerts(ctx, opts.Clientset, opts.Logger),
+	}
+
+	a.watchEvents(ctx, time.Now())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.report(ctx); err != nil && ctx.Err() == nil {
+			opts.Logger.Error().Err(err).Msg("error sending relay report")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+type agent struct {
+	opts   *AgentOpts
+	url    string
+	client *http.Client
+	events *eventBuffer
+
+	// the keys of the incidents which were part of the last accepted report, whose log
+	// excerpts were already sent
+	reported map[string]bool
+
+	logAlerts *logAlerts
+}
+
+// watchEvents buffers the warning events of the cluster which occur after the agent started
+func (a *agent) watchEvents(ctx context.Context, startedAt time.Time) {
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		a.opts.Clientset,
+		0,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = "type=Warning"
+		}),
+	)
+
+	handle := func(obj interface{}) {
+		event, ok := obj.(*v1.Event)
+
+		if !ok || event.Type != v1.EventTypeWarning {
+			return
+		}
+
+		timestamp := getEventTime(event)
+
+		// the informer lists the existing events when it starts, which were either reported by
+		// a previous agent or happened while no agent was running
+		if timestamp.Before(startedAt) {
+			return
+		}
+
+		a.events.add(&types.RelayEvent{
+			Namespace:          event.InvolvedObject.Namespace,
+			InvolvedObjectKind: event.InvolvedObject.Kind,
+			InvolvedObjectName: event.InvolvedObject.Name,
+			Reason:             event.Reason,
+			Message:            event.Message,
+			Timestamp:          timestamp,
+		})
+	}
+
+	factory.Core().V1().Events().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handle,
+		UpdateFunc: func(_, obj interface{}) {
+			handle(obj)
+		},
+	})
+
+	factory.Start(ctx.Done())
+}
+
+func (a *agent) report(ctx context.Context) error {
+	report := &types.RelayReport{
+		AgentVersion: a.opts.Version,
+		CollectedAt:  time.Now().UTC(),
+	}
+
+	detectCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	detected, err := incidents.NewDetector(a.opts.Clientset).Detect(detectCtx)
+
+	if err != nil {
+		return fmt.Errorf("error detecting incidents: %w", err)
+	}
+
+	report.Incidents = a.getIncidents(detectCtx, detected)
+
+	for _, incident := range a.logAlerts.evaluate(time.Now()) {
+		if a.reported[incident.Key] {
+			incident.LogExcerpt = ""
+		}
+
+		report.Incidents = append(report.Incidents, incident)
+	}
+
+	// metrics are best-effort, since not every cluster runs the metrics API
+	report.PodMetrics, err = getPodMetrics(detectCtx, a.opts.Clientset)
+
+	if err != nil {
+		a.opts.Logger.Debug().Msgf("could not get pod metrics: %v", err)
+	}
+
+	report.Events = a.events.take()
+
+	res, err := a.send(ctx, report)
+
+	if err != nil {
+		a.events.requeue(report.Events)
+		return err
+	}
+
+	// servers which don't support log alerts don't return a response
+	if res != nil {
+		a.logAlerts.setRules(res.LogAlertRules, time.Now())
+	}
+
+	a.logAlerts.sync(ctx)
+
+	a.reported = make(map[string]bool)
+
+	for _, incident := range report.Incidents {
+		a.reported[incident.Key] = true
+	}
+
+	return nil
+}
+
+func (a *agent) getIncidents(ctx context.Context, detected []*incidents.DetectedIncident) []*types.RelayIncident {
+	res := make([]*types.RelayIncident, 0, len(detected))
+
+	for _, d := range detected {
+		incident := &types.RelayIncident{
+			Key:                d.Key,
+			Reason:             d.Reason,
+			Message:            d.Message,
+			Namespace:          d.Namespace,
+			ReleaseName:        d.ReleaseName,
+			InvolvedObjectKind: d.InvolvedObjectKind,
+			InvolvedObjectName: d.InvolvedObjectName,
+			Pods:               d.Pods,
+		}
+
+		if !a.reported[d.Key] {
+			excerpt, err := incidents.GetExcerpt(ctx, a.opts.Clientset, &types.ClusterIncident{
+				Reason:    d.Reason,
+				Namespace: d.Namespace,
+				Pods:      d.Pods,
+			}, incidents.DefaultExcerptLines)
+
+			if err != nil {
+				a.opts.Logger.Debug().Msgf("could not get log excerpt of incident %s: %v", d.Key, err)
+			}
+
+			incident.LogExcerpt = excerpt
+		}
+
+		res = append(res, incident)
+	}
+
+	return res
+}
+
+func (a *agent) send(ctx context.Context, report *types.RelayReport) (*types.RelayReportResponse, error) {
+	body, err := json.Marshal(report)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.opts.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("error sending report to %s: %w", a.url, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("error sending report to %s: %s", a.url, resp.Status)
+	}
+
+	res := &types.RelayReportResponse{}
+
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error decoding the response of %s: %w", a.url, err)
+	}
+
+	return res, nil
+}
+
+// podMetricsList is the subset of the PodMetricsList of the metrics API which is reported
+type podMetricsList struct {
+	Items []struct {
+		Metadata   metav1.ObjectMeta `json:"metadata"`
+		Containers []struct {
+			Usage map[string]resource.Quantity `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+func getPodMetrics(ctx context.Context, clientset kubernetes.Interface) ([]*types.RelayPodMetrics, error) {
+	restClient := clientset.Discovery().RESTClient()
+
+	if restClient == nil {
+		return nil, fmt.Errorf("the clientset does not have a rest client")
+	}
+
+	raw, err := restClient.Get().AbsPath("/apis/metrics.k8s.io/v1beta1/pods").DoRaw(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := &podMetricsList{}
+
+	if err := json.Unmarshal(raw, list); err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.RelayPodMetrics, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		metrics := &types.RelayPodMetrics{
+			Namespace:   item.Metadata.Namespace,
+			Name:        item.Metadata.Name,
+			ReleaseName: item.Metadata.Labels["app.kubernetes.io/instance"],
+		}
+
+		if metrics.ReleaseName == "" {
+			metrics.ReleaseName = item.Metadata.Labels["release"]
+		}
+
+		for _, container := range item.Containers {
+			if cpu, ok := container.Usage["cpu"]; ok {
+				metrics.CPUMillicores += cpu.MilliValue()
+			}
+
+			if memory, ok := container.Usage["memory"]; ok {
+				metrics.MemoryBytes += memory.Value()
+			}
+		}
+
+		res = append(res, metrics)
+	}
+
+	return res, nil
+}
+
+func getEventTime(event *v1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+
+	if event.Series != nil {
+		return event.Series.LastObservedTime.Time
+	}
+
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+
+	return event.CreationTimestamp.Time
+}
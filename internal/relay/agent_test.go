@@ -0,0 +1,244 @@
+package relay_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/relay"
+	lr "github.com/porter-dev/porter/pkg/logger"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAgentReports(t *testing.T) {
+	var mu sync.Mutex
+	reports := make([]*types.RelayReport, 0)
+	rejected := -1
+
+	// the first report with events is rejected, so that its events are sent again in the next
+	// report
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != relay.ReportPath || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		report := &types.RelayReport{}
+
+		if err := json.NewDecoder(r.Body).Decode(report); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		reports = append(reports, report)
+
+		if rejected == -1 && len(report.Events) > 0 {
+			rejected = len(reports) - 1
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	defer server.Close()
+
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/instance": "web"},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "web",
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				},
+			},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go relay.RunAgent(ctx, &relay.AgentOpts{
+		ServerURL: server.URL,
+		Token:     "token",
+		Version:   "test",
+		Clientset: clientset,
+		Interval:  100 * time.Millisecond,
+		Logger:    lr.NewConsole(false),
+	})
+
+	// events which occur after the agent started are reported
+	time.Sleep(50 * time.Millisecond)
+
+	_, err := clientset.CoreV1().Events("default").Create(ctx, &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web.1", Namespace: "default"},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "default"},
+		Type:           v1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		LastTimestamp:  metav1.NewTime(time.Now().Add(time.Second)),
+	}, metav1.CreateOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received []*types.RelayReport
+	var rejectedIndex int
+
+	for i := 0; i < 100; i++ {
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		received = append([]*types.RelayReport{}, reports...)
+		rejectedIndex = rejected
+		mu.Unlock()
+
+		if rejectedIndex != -1 && len(received) >= rejectedIndex+3 {
+			break
+		}
+	}
+
+	if rejectedIndex == -1 || len(received) < rejectedIndex+3 {
+		t.Fatalf("expected the event to be reported, got %d reports\n", len(received))
+	}
+
+	for _, report := range received {
+		if report.AgentVersion != "test" || findCrashLoop(report) == nil {
+			t.Fatalf("expected reports with the crash loop of the pod\n")
+		}
+	}
+
+	// the excerpt is only sent until a report with the incident is accepted
+	if findCrashLoop(received[0]).LogExcerpt == "" {
+		t.Errorf("expected log excerpt in the first report of the incident\n")
+	}
+
+	if excerpt := findCrashLoop(received[len(received)-1]).LogExcerpt; excerpt != "" {
+		t.Errorf("expected no log excerpt once the incident was reported, got %s\n", excerpt)
+	}
+
+	// the events of the rejected report are sent again in the next report
+	events := received[rejectedIndex+1].Events
+
+	if len(events) == 0 || events[0].Reason != "BackOff" || events[0].InvolvedObjectName != "web" {
+		t.Errorf("expected the rejected event to be reported again\n")
+	}
+
+	if len(received[rejectedIndex+2].Events) != 0 {
+		t.Errorf("expected the event to only be reported again once\n")
+	}
+}
+
+func findCrashLoop(report *types.RelayReport) *types.RelayIncident {
+	for _, incident := range report.Incidents {
+		if incident.Reason == types.ClusterIncidentReasonCrashLoop {
+			return incident
+		}
+	}
+
+	return nil
+}
+
+func TestAgentLogAlerts(t *testing.T) {
+	var mu sync.Mutex
+	reports := make([]*types.RelayReport, 0)
+
+	rules := []*types.LogAlertRule{
+		{ID: 1, Namespace: "default", ReleaseName: "web", Name: "errors", Pattern: "fake", Threshold: 2, WindowSeconds: 60},
+		{ID: 2, Namespace: "default", ReleaseName: "web", Name: "panics", Pattern: "^panic", Threshold: 1, WindowSeconds: 60},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := &types.RelayReport{}
+
+		if err := json.NewDecoder(r.Body).Decode(report); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		reports = append(reports, report)
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(&types.RelayReportResponse{LogAlertRules: rules})
+	}))
+
+	defer server.Close()
+
+	// the logs of the fake clientset are "fake logs", which are read again each time the
+	// stream of the container is restarted
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/instance": "web"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "web"}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go relay.RunAgent(ctx, &relay.AgentOpts{
+		ServerURL: server.URL,
+		Token:     "token",
+		Clientset: clientset,
+		Interval:  50 * time.Millisecond,
+		Logger:    lr.NewConsole(false),
+	})
+
+	var alerts []*types.RelayIncident
+
+	for i := 0; i < 100 && len(alerts) < 2; i++ {
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		alerts = make([]*types.RelayIncident, 0)
+
+		for _, report := range reports {
+			for _, incident := range report.Incidents {
+				if incident.Reason == types.ClusterIncidentReasonLogAlert {
+					alerts = append(alerts, incident)
+				}
+			}
+		}
+
+		mu.Unlock()
+	}
+
+	if len(alerts) < 2 {
+		t.Fatalf("expected the log alert rule to be reported, got %d alerts\n", len(alerts))
+	}
+
+	for _, alert := range alerts {
+		if alert.InvolvedObjectName != "web/errors" || alert.ReleaseName != "web" {
+			t.Fatalf("expected only the matching rule to be reported, got %s\n", alert.InvolvedObjectName)
+		}
+	}
+
+	if !strings.Contains(alerts[0].LogExcerpt, "[web-1] fake logs") {
+		t.Errorf("expected matching lines in the excerpt, got %q\n", alerts[0].LogExcerpt)
+	}
+
+	if alerts[1].LogExcerpt != "" {
+		t.Errorf("expected no excerpt once the alert was reported, got %q\n", alerts[1].LogExcerpt)
+	}
+}
@@ -0,0 +1,57 @@
+package relay
+
+import (
+	"sync"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// eventBuffer holds the events which weren't reported yet, up to a maximum number of events
+type eventBuffer struct {
+	mu     sync.Mutex
+	max    int
+	events []*types.RelayEvent
+}
+
+func newEventBuffer(max int) *eventBuffer {
+	return &eventBuffer{
+		max:    max,
+		events: make([]*types.RelayEvent, 0),
+	}
+}
+
+func (b *eventBuffer) add(event *types.RelayEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events = append(b.events, event)
+	b.truncate()
+}
+
+// take returns the buffered events and empties the buffer
+func (b *eventBuffer) take() []*types.RelayEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := b.events
+	b.events = make([]*types.RelayEvent, 0)
+
+	return res
+}
+
+// requeue adds events which could not be reported back to the buffer, before the events which
+// were added since they were taken
+func (b *eventBuffer) requeue(events []*types.RelayEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events = append(append(make([]*types.RelayEvent, 0, len(events)+len(b.events)), events...), b.events...)
+	b.truncate()
+}
+
+// truncate drops the oldest events once the buffer is full
+func (b *eventBuffer) truncate() {
+	if len(b.events) > b.max {
+		b.events = b.events[len(b.events)-b.max:]
+	}
+}
@@ -0,0 +1,142 @@
+package relay
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/incidents"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// eventGroupWindow is how long the events of an object are grouped into the same kube event
+const eventGroupWindow = time.Hour
+
+// Ingest stores a report of the relay of a cluster. The incidents and pod metrics of the
+// report replace those of the previous report, and its events are appended to the kube events
+// of the cluster.
+func Ingest(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	relay *models.ClusterRelay,
+	report *types.RelayReport,
+	now time.Time,
+) error {
+	previous, err := relay.GetIncidents()
+
+	if err != nil {
+		return err
+	}
+
+	// log excerpts are only sent in the first report of an incident, so they're carried over
+	// from the previous report for the notifications of the incident
+	excerpts := make(map[string]string)
+
+	for _, incident := range previous {
+		if incident.LogExcerpt != "" {
+			excerpts[incident.Key] = incident.LogExcerpt
+		}
+	}
+
+	for _, incident := range report.Incidents {
+		if incident.LogExcerpt == "" {
+			incident.LogExcerpt = excerpts[incident.Key]
+		}
+	}
+
+	rawIncidents, err := json.Marshal(report.Incidents)
+
+	if err != nil {
+		return err
+	}
+
+	rawMetrics, err := json.Marshal(report.PodMetrics)
+
+	if err != nil {
+		return err
+	}
+
+	for _, event := range report.Events {
+		if err := storeEvent(repo.KubeEvent(), cluster, event, now); err != nil {
+			return err
+		}
+	}
+
+	relay.AgentVersion = report.AgentVersion
+	relay.LastReportAt = &now
+	relay.Incidents = rawIncidents
+	relay.PodMetrics = rawMetrics
+
+	_, err = repo.ClusterRelay().UpdateClusterRelay(relay)
+
+	return err
+}
+
+func storeEvent(repo repository.KubeEventRepository, cluster *models.Cluster, event *types.RelayEvent, now time.Time) error {
+	subEvent := &models.KubeSubEvent{
+		Message:   event.Message,
+		Reason:    event.Reason,
+		Timestamp: event.Timestamp,
+		EventType: types.KubeEventTypeCritical,
+	}
+
+	kubeEvent, err := repo.ReadEventByGroup(cluster.ProjectID, cluster.ID, &types.GroupOptions{
+		ResourceType:  event.InvolvedObjectKind,
+		Name:          event.InvolvedObjectName,
+		Namespace:     event.Namespace,
+		ThresholdTime: now.Add(-eventGroupWindow),
+	})
+
+	if err == nil {
+		return repo.AppendSubEvent(kubeEvent, subEvent)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	_, err = repo.CreateEvent(&models.KubeEvent{
+		ProjectID:    cluster.ProjectID,
+		ClusterID:    cluster.ID,
+		Name:         event.InvolvedObjectName,
+		ResourceType: strings.ToLower(event.InvolvedObjectKind),
+		Namespace:    strings.ToLower(event.Namespace),
+		SubEvents:    []models.KubeSubEvent{*subEvent},
+	})
+
+	return err
+}
+
+// GetDetectedIncidents returns the incidents of the last report of a relay, and the log
+// excerpts of the incidents by their key
+func GetDetectedIncidents(relay *models.ClusterRelay) ([]*incidents.DetectedIncident, map[string]string, error) {
+	reported, err := relay.GetIncidents()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	detected := make([]*incidents.DetectedIncident, 0, len(reported))
+	excerpts := make(map[string]string)
+
+	for _, incident := range reported {
+		detected = append(detected, &incidents.DetectedIncident{
+			Key:                incident.Key,
+			Reason:             incident.Reason,
+			Message:            incident.Message,
+			Namespace:          incident.Namespace,
+			ReleaseName:        incident.ReleaseName,
+			InvolvedObjectKind: incident.InvolvedObjectKind,
+			InvolvedObjectName: incident.InvolvedObjectName,
+			Pods:               incident.Pods,
+		})
+
+		if incident.LogExcerpt != "" {
+			excerpts[incident.Key] = incident.LogExcerpt
+		}
+	}
+
+	return detected, excerpts, nil
+}
@@ -0,0 +1,346 @@
+package relay
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/incidents"
+	lr "github.com/porter-dev/porter/pkg/logger"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// the number of matching lines of a rule which are kept for the log excerpt of its incident
+	maxLogAlertSamples = 20
+
+	// matching lines are truncated to this length in log excerpts
+	maxLogAlertLineLength = 500
+
+	// the largest log line which is matched. Longer lines end the stream of a container, which
+	// is restarted after the line on the next report.
+	maxLogLineLength = 1024 * 1024
+)
+
+// logAlerts evaluates the log alert rules of the cluster by following the logs of the pods of
+// each rule's release, so that only the rules which match are reported to Porter
+type logAlerts struct {
+	// the context of the agent, which the log streams are stopped with
+	ctx context.Context
+
+	clientset kubernetes.Interface
+	logger    *lr.Logger
+
+	mu    sync.Mutex
+	rules map[uint]*logAlertRule
+}
+
+type logAlertRule struct {
+	rule    *types.LogAlertRule
+	pattern *regexp.Regexp
+
+	// streams are started from the time the agent received the rule, so that older logs don't
+	// match it
+	startedAt time.Time
+
+	// the times of the most recent matching lines, oldest first. At most threshold times are
+	// kept, since the rule matches if the oldest of them is within the window.
+	matches []time.Time
+
+	// the most recent matching lines, oldest first
+	samples []*logAlertSample
+
+	// the log streams of the containers of the release's pods, by pod and container
+	streams map[string]*logStream
+
+	// the times at which ended streams are restarted from, by pod and container
+	resumeAt map[string]time.Time
+}
+
+type logAlertSample struct {
+	pod  string
+	line string
+	time time.Time
+}
+
+type logStream struct {
+	cancel context.CancelFunc
+}
+
+func newLogAlerts(ctx context.Context, clientset kubernetes.Interface, logger *lr.Logger) *logAlerts {
+	return &logAlerts{
+		ctx:       ctx,
+		clientset: clientset,
+		logger:    logger,
+		rules:     make(map[uint]*logAlertRule),
+	}
+}
+
+// setRules replaces the rules which are evaluated. Rules whose release and pattern didn't
+// change keep their matches and streams.
+func (l *logAlerts) setRules(rules []*types.LogAlertRule, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := make(map[uint]*logAlertRule)
+
+	for _, rule := range rules {
+		if existing, ok := l.rules[rule.ID]; ok && existing.rule.Namespace == rule.Namespace &&
+			existing.rule.ReleaseName == rule.ReleaseName && existing.rule.Pattern == rule.Pattern {
+			existing.rule = rule
+			next[rule.ID] = existing
+			delete(l.rules, rule.ID)
+			continue
+		}
+
+		pattern, err := regexp.Compile(rule.Pattern)
+
+		if err != nil {
+			l.logger.Error().Err(err).Msgf("invalid pattern of log alert rule %s", rule.Name)
+			continue
+		}
+
+		next[rule.ID] = &logAlertRule{
+			rule:      rule,
+			pattern:   pattern,
+			startedAt: now,
+			streams:   make(map[string]*logStream),
+			resumeAt:  make(map[string]time.Time),
+		}
+	}
+
+	// the rules which were deleted or changed stop following the logs of their release
+	for _, state := range l.rules {
+		for _, stream := range state.streams {
+			stream.cancel()
+		}
+	}
+
+	l.rules = next
+}
+
+// sync starts following the logs of the containers of each rule's release which aren't
+// followed yet, and stops following the logs of pods which no longer exist
+func (l *logAlerts) sync(ctx context.Context) {
+	l.mu.Lock()
+
+	states := make([]*logAlertRule, 0, len(l.rules))
+
+	for _, state := range l.rules {
+		states = append(states, state)
+	}
+
+	l.mu.Unlock()
+
+	for _, state := range states {
+		pods, err := l.listReleasePods(ctx, state.rule.Namespace, state.rule.ReleaseName)
+
+		if err != nil {
+			l.logger.Debug().Msgf("could not list the pods of release %s: %v", state.rule.ReleaseName, err)
+			continue
+		}
+
+		l.mu.Lock()
+
+		// the rule was removed while the pods were listed
+		if l.rules[state.rule.ID] != state {
+			l.mu.Unlock()
+			continue
+		}
+
+		current := make(map[string]bool)
+
+		for _, pod := range pods {
+			if pod.Status.Phase != v1.PodRunning {
+				continue
+			}
+
+			for _, container := range pod.Spec.Containers {
+				key := pod.Name + "/" + container.Name
+				current[key] = true
+
+				if _, ok := state.streams[key]; ok {
+					continue
+				}
+
+				since, ok := state.resumeAt[key]
+
+				if !ok {
+					since = state.startedAt
+				}
+
+				streamCtx, cancel := context.WithCancel(l.ctx)
+				stream := &logStream{cancel}
+				state.streams[key] = stream
+
+				go l.follow(streamCtx, state, stream, key, pod.Namespace, pod.Name, container.Name, since)
+			}
+		}
+
+		for key, stream := range state.streams {
+			if !current[key] {
+				stream.cancel()
+				delete(state.streams, key)
+			}
+		}
+
+		for key := range state.resumeAt {
+			if !current[key] {
+				delete(state.resumeAt, key)
+			}
+		}
+
+		l.mu.Unlock()
+	}
+}
+
+func (l *logAlerts) listReleasePods(ctx context.Context, namespace, releaseName string) ([]v1.Pod, error) {
+	// Porter charts label their pods with app.kubernetes.io/instance, and older charts with
+	// release
+	for _, label := range []string{"app.kubernetes.io/instance", "release"} {
+		pods, err := l.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", label, releaseName),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pods.Items) > 0 {
+			return pods.Items, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// follow matches the logs of a container against the pattern of a rule until the stream ends
+// or is cancelled. Ended streams are restarted from where they ended on the next sync.
+func (l *logAlerts) follow(
+	ctx context.Context,
+	state *logAlertRule,
+	stream *logStream,
+	key, namespace, pod, container string,
+	since time.Time,
+) {
+	logs, err := l.clientset.CoreV1().Pods(namespace).GetLogs(pod, &v1.PodLogOptions{
+		Container: container,
+		Follow:    true,
+		SinceTime: &metav1.Time{Time: since},
+	}).Stream(ctx)
+
+	if err == nil {
+		scanner := bufio.NewScanner(logs)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineLength)
+
+		for scanner.Scan() {
+			if line := scanner.Text(); state.pattern.MatchString(line) {
+				l.record(state, pod, line, time.Now())
+			}
+		}
+
+		logs.Close()
+	} else if ctx.Err() == nil {
+		l.logger.Debug().Msgf("could not follow the logs of %s: %v", key, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state.streams[key] == stream {
+		delete(state.streams, key)
+		state.resumeAt[key] = time.Now()
+	}
+}
+
+func (l *logAlerts) record(state *logAlertRule, pod, line string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state.matches = append(state.matches, now)
+
+	if threshold := int(state.rule.Threshold); threshold > 0 && len(state.matches) > threshold {
+		state.matches = state.matches[len(state.matches)-threshold:]
+	}
+
+	if len(line) > maxLogAlertLineLength {
+		line = line[:maxLogAlertLineLength]
+	}
+
+	state.samples = append(state.samples, &logAlertSample{pod, line, now})
+
+	if len(state.samples) > maxLogAlertSamples {
+		state.samples = state.samples[len(state.samples)-maxLogAlertSamples:]
+	}
+}
+
+// evaluate returns an incident for each rule which matched at least its threshold number of
+// lines within its window, with the matching lines of the window as the log excerpt
+func (l *logAlerts) evaluate(now time.Time) []*types.RelayIncident {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := make([]*types.RelayIncident, 0)
+
+	for _, state := range l.rules {
+		window := time.Duration(state.rule.WindowSeconds) * time.Second
+		windowStart := now.Add(-window)
+
+		for len(state.samples) > 0 && state.samples[0].time.Before(windowStart) {
+			state.samples = state.samples[1:]
+		}
+
+		threshold := int(state.rule.Threshold)
+
+		if threshold == 0 || len(state.matches) < threshold ||
+			state.matches[len(state.matches)-threshold].Before(windowStart) {
+			continue
+		}
+
+		name := state.rule.ReleaseName + "/" + state.rule.Name
+
+		incident := &types.RelayIncident{
+			Key: incidents.Key(
+				state.rule.Namespace, types.LogAlertRuleKind, name, types.ClusterIncidentReasonLogAlert,
+			),
+			Reason: types.ClusterIncidentReasonLogAlert,
+			Message: fmt.Sprintf(
+				"log alert rule %s matched %s at least %d times in %s",
+				state.rule.Name, state.rule.Pattern, state.rule.Threshold, window,
+			),
+			Namespace:          state.rule.Namespace,
+			ReleaseName:        state.rule.ReleaseName,
+			InvolvedObjectKind: types.LogAlertRuleKind,
+			InvolvedObjectName: name,
+			Pods:               make([]string, 0),
+		}
+
+		lines := make([]string, 0, len(state.samples))
+		pods := make(map[string]bool)
+
+		for _, sample := range state.samples {
+			lines = append(lines, fmt.Sprintf("[%s] %s", sample.pod, sample.line))
+
+			if !pods[sample.pod] {
+				pods[sample.pod] = true
+				incident.Pods = append(incident.Pods, sample.pod)
+			}
+		}
+
+		sort.Strings(incident.Pods)
+
+		incident.LogExcerpt = strings.Join(lines, "\n")
+
+		res = append(res, incident)
+	}
+
+	return res
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ApprovalRepository represents the set of queries on the ApprovalPolicy and Approval models
+type ApprovalRepository interface {
+	CreateApprovalPolicy(policy *models.ApprovalPolicy) (*models.ApprovalPolicy, error)
+	ReadApprovalPolicy(projectID, id uint) (*models.ApprovalPolicy, error)
+	ListApprovalPolicies(projectID uint) ([]*models.ApprovalPolicy, error)
+	DeleteApprovalPolicy(policy *models.ApprovalPolicy) error
+
+	CreateApproval(approval *models.Approval) (*models.Approval, error)
+	ReadApproval(projectID, id uint) (*models.Approval, error)
+
+	// ListApprovals lists the approvals of a project, most recent first. If status is set,
+	// only the approvals with the status are listed.
+	ListApprovals(projectID uint, status types.ApprovalStatus) ([]*models.Approval, error)
+
+	// ListApprovalsByDateRange lists the approvals of a project which were requested or reviewed
+	// in [from, to), oldest first
+	ListApprovalsByDateRange(projectID uint, from, to time.Time) ([]*models.Approval, error)
+
+	// ListPendingApprovalsByRelease lists the approvals of a release which have not been reviewed
+	ListPendingApprovalsByRelease(clusterID uint, namespace, name string) ([]*models.Approval, error)
+	UpdateApproval(approval *models.Approval) (*models.Approval, error)
+}
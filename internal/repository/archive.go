@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ArchiveRepository represents the set of queries which move old rows out of the deployment
+// and kube event tables, and read them back
+type ArchiveRepository interface {
+	// ArchiveDeployments archives up to limit deployments of closed pull requests which were
+	// last updated before the given time, and returns the number of archived deployments.
+	// Deployments are closed when they are deleted or marked inactive.
+	ArchiveDeployments(updatedBefore time.Time, limit int) (int64, error)
+
+	// ArchiveKubeEvents archives up to limit kube events which were last updated before the
+	// given time, and returns the number of archived events
+	ArchiveKubeEvents(updatedBefore time.Time, limit int) (int64, error)
+
+	ListArchivedDeployments(projectID, clusterID uint, opts *ListOptions) ([]*models.ArchivedDeployment, error)
+	ListArchivedKubeEvents(projectID, clusterID uint, opts *ListOptions) ([]*models.ArchivedKubeEvent, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditLogRepository represents the set of queries on the AuditLog model
+type AuditLogRepository interface {
+	CreateAuditLog(auditLog *models.AuditLog) (*models.AuditLog, error)
+	ListAuditLogsByProjectID(projectID uint, opts *types.ListAuditLogsRequest) ([]*models.AuditLog, int64, error)
+
+	// ListAuditLogsByDateRange lists all audit logs of a project which were created in
+	// [from, to), oldest first
+	ListAuditLogsByDateRange(projectID uint, from, to time.Time) ([]*models.AuditLog, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// BackgroundJobRepository represents the set of queries on the BackgroundJob model
+type BackgroundJobRepository interface {
+	CreateBackgroundJob(job *models.BackgroundJob) (*models.BackgroundJob, error)
+	ReadBackgroundJob(projectID, id uint) (*models.BackgroundJob, error)
+	ListBackgroundJobs(projectID uint, opts *ListOptions) ([]*models.BackgroundJob, error)
+	UpdateBackgroundJob(job *models.BackgroundJob) (*models.BackgroundJob, error)
+
+	// ClaimBackgroundJob marks the next job which is ready to run as running, and returns it.
+	// Running jobs which were claimed before staleBefore are claimed again, since the worker
+	// which claimed them has stopped.
+	ClaimBackgroundJob(now, staleBefore time.Time) (*models.BackgroundJob, error)
+}
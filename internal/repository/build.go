@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// BuildRepository represents the set of queries on the Build model
+type BuildRepository interface {
+	CreateBuild(build *models.Build) (*models.Build, error)
+	ReadBuild(projectID, clusterID, buildID uint) (*models.Build, error)
+	ListBuildsByNamespace(projectID, clusterID uint, namespace string) ([]*models.Build, error)
+	UpdateBuild(build *models.Build) (*models.Build, error)
+}
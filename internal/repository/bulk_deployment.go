@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// BulkDeploymentOperationRepository represents the set of queries on the
+// BulkDeploymentOperation model
+type BulkDeploymentOperationRepository interface {
+	CreateBulkDeploymentOperation(op *models.BulkDeploymentOperation) (*models.BulkDeploymentOperation, error)
+	ReadBulkDeploymentOperation(projectID, clusterID, id uint) (*models.BulkDeploymentOperation, error)
+	ListBulkDeploymentOperations(projectID, clusterID uint) ([]*models.BulkDeploymentOperation, error)
+	UpdateBulkDeploymentOperation(op *models.BulkDeploymentOperation) (*models.BulkDeploymentOperation, error)
+	UpdateBulkDeploymentOperationItem(item *models.BulkDeploymentOperationItem) (*models.BulkDeploymentOperationItem, error)
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ClusterRepository caches clusters, which are read by every cluster-scoped request
+type ClusterRepository struct {
+	repository.ClusterRepository
+
+	codec *codec
+	ttl   time.Duration
+}
+
+func newClusterRepository(repo repository.ClusterRepository, c *codec, ttl time.Duration) repository.ClusterRepository {
+	return &ClusterRepository{repo, c, ttl}
+}
+
+func clusterKey(projectID, clusterID uint) string {
+	return fmt.Sprintf("cluster:%d:%d", projectID, clusterID)
+}
+
+func (repo *ClusterRepository) ReadCluster(projectID, clusterID uint) (*models.Cluster, error) {
+	cluster := &models.Cluster{}
+
+	if repo.codec.get(clusterKey(projectID, clusterID), cluster) {
+		return cluster, nil
+	}
+
+	cluster, err := repo.ClusterRepository.ReadCluster(projectID, clusterID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.codec.set(clusterKey(projectID, clusterID), cluster, repo.ttl)
+
+	return cluster, nil
+}
+
+func (repo *ClusterRepository) UpdateCluster(cluster *models.Cluster) (*models.Cluster, error) {
+	defer repo.codec.invalidate(clusterKey(cluster.ProjectID, cluster.ID))
+
+	return repo.ClusterRepository.UpdateCluster(cluster)
+}
+
+func (repo *ClusterRepository) UpdateClusterTokenCache(tokenCache *ints.ClusterTokenCache) (*models.Cluster, error) {
+	cluster, err := repo.ClusterRepository.UpdateClusterTokenCache(tokenCache)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.codec.invalidate(clusterKey(cluster.ProjectID, cluster.ID))
+
+	return cluster, nil
+}
+
+func (repo *ClusterRepository) DeleteCluster(cluster *models.Cluster) error {
+	defer repo.codec.invalidate(clusterKey(cluster.ProjectID, cluster.ID))
+
+	return repo.ClusterRepository.DeleteCluster(cluster)
+}
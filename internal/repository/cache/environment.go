@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// EnvironmentRepository caches environments and deployments, which are read by every
+// preview environment webhook and workflow run
+type EnvironmentRepository struct {
+	repository.EnvironmentRepository
+
+	codec *codec
+	ttl   time.Duration
+}
+
+func newEnvironmentRepository(repo repository.EnvironmentRepository, c *codec, ttl time.Duration) repository.EnvironmentRepository {
+	return &EnvironmentRepository{repo, c, ttl}
+}
+
+func environmentKey(projectID, clusterID, gitInstallationID uint, owner, name string) string {
+	return fmt.Sprintf("environment:%d:%d:%d:%s/%s", projectID, clusterID, gitInstallationID, owner, name)
+}
+
+func environmentIDKey(projectID, clusterID, envID uint) string {
+	return fmt.Sprintf("environment:%d:%d:%d", projectID, clusterID, envID)
+}
+
+func deploymentKey(environmentID uint, namespace string) string {
+	return fmt.Sprintf("deployment:%d:%s", environmentID, namespace)
+}
+
+// environmentKeys returns the keys which an environment may be cached under
+func environmentKeys(env *models.Environment) []string {
+	return []string{
+		environmentKey(env.ProjectID, env.ClusterID, env.GitInstallationID, env.GitRepoOwner, env.GitRepoName),
+		environmentIDKey(env.ProjectID, env.ClusterID, env.ID),
+	}
+}
+
+func (repo *EnvironmentRepository) ReadEnvironment(
+	projectID, clusterID, gitInstallationID uint,
+	gitRepoOwner, gitRepoName string,
+) (*models.Environment, error) {
+	key := environmentKey(projectID, clusterID, gitInstallationID, gitRepoOwner, gitRepoName)
+	env := &models.Environment{}
+
+	if repo.codec.get(key, env) {
+		return env, nil
+	}
+
+	env, err := repo.EnvironmentRepository.ReadEnvironment(projectID, clusterID, gitInstallationID, gitRepoOwner, gitRepoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// owners and names are matched case-insensitively, so only cache the environment under
+	// the key which writes invalidate
+	if gitRepoOwner == env.GitRepoOwner && gitRepoName == env.GitRepoName {
+		repo.codec.set(key, env, repo.ttl)
+	}
+
+	return env, nil
+}
+
+func (repo *EnvironmentRepository) ReadEnvironmentByID(projectID, clusterID, envID uint) (*models.Environment, error) {
+	key := environmentIDKey(projectID, clusterID, envID)
+	env := &models.Environment{}
+
+	if repo.codec.get(key, env) {
+		return env, nil
+	}
+
+	env, err := repo.EnvironmentRepository.ReadEnvironmentByID(projectID, clusterID, envID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.codec.set(key, env, repo.ttl)
+
+	return env, nil
+}
+
+func (repo *EnvironmentRepository) UpdateEnvironment(environment *models.Environment) (*models.Environment, error) {
+	keys := environmentKeys(environment)
+
+	// the environment may have been cached under its previous repository owner and name
+	if prev, err := repo.EnvironmentRepository.ReadEnvironmentByID(
+		environment.ProjectID, environment.ClusterID, environment.ID,
+	); err == nil {
+		keys = append(keys, environmentKeys(prev)...)
+	}
+
+	defer repo.codec.invalidate(keys...)
+
+	return repo.EnvironmentRepository.UpdateEnvironment(environment)
+}
+
+func (repo *EnvironmentRepository) DeleteEnvironment(env *models.Environment) (*models.Environment, error) {
+	keys := environmentKeys(env)
+
+	// deployments are cached by the id of their environment, so the deployments of the
+	// environment are invalidated along with it
+	if depls, err := repo.EnvironmentRepository.ListDeployments(env.ID, nil); err == nil {
+		for _, depl := range depls {
+			keys = append(keys, deploymentKey(env.ID, depl.Namespace))
+		}
+	}
+
+	defer repo.codec.invalidate(keys...)
+
+	return repo.EnvironmentRepository.DeleteEnvironment(env)
+}
+
+func (repo *EnvironmentRepository) ReadDeployment(environmentID uint, namespace string) (*models.Deployment, error) {
+	key := deploymentKey(environmentID, namespace)
+	deployment := &models.Deployment{}
+
+	if repo.codec.get(key, deployment) {
+		return deployment, nil
+	}
+
+	deployment, err := repo.EnvironmentRepository.ReadDeployment(environmentID, namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.codec.set(key, deployment, repo.ttl)
+
+	return deployment, nil
+}
+
+func (repo *EnvironmentRepository) UpdateDeployment(deployment *models.Deployment) (*models.Deployment, error) {
+	defer repo.codec.invalidate(deploymentKey(deployment.EnvironmentID, deployment.Namespace))
+
+	return repo.EnvironmentRepository.UpdateDeployment(deployment)
+}
+
+func (repo *EnvironmentRepository) UpdateDeploymentWithJobs(
+	deployment *models.Deployment,
+	jobs ...*models.BackgroundJob,
+) (*models.Deployment, error) {
+	defer repo.codec.invalidate(deploymentKey(deployment.EnvironmentID, deployment.Namespace))
+
+	return repo.EnvironmentRepository.UpdateDeploymentWithJobs(deployment, jobs...)
+}
+
+func (repo *EnvironmentRepository) UpdateDeploymentFields(
+	deployment *models.Deployment,
+	fields ...string,
+) (*models.Deployment, error) {
+	defer repo.codec.invalidate(deploymentKey(deployment.EnvironmentID, deployment.Namespace))
+
+	return repo.EnvironmentRepository.UpdateDeploymentFields(deployment, fields...)
+}
+
+func (repo *EnvironmentRepository) DeleteDeployment(deployment *models.Deployment) (*models.Deployment, error) {
+	defer repo.codec.invalidate(deploymentKey(deployment.EnvironmentID, deployment.Namespace))
+
+	return repo.EnvironmentRepository.DeleteDeployment(deployment)
+}
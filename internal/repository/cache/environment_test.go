@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// memoryEnvironmentRepository stores the deployments of a single environment
+type memoryEnvironmentRepository struct {
+	repository.EnvironmentRepository
+
+	depls map[string]*models.Deployment
+}
+
+func (repo *memoryEnvironmentRepository) ReadDeployment(environmentID uint, namespace string) (*models.Deployment, error) {
+	depl, ok := repo.depls[namespace]
+
+	if !ok || depl.EnvironmentID != environmentID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	res := *depl
+	return &res, nil
+}
+
+func (repo *memoryEnvironmentRepository) ListDeployments(environmentID uint, opts *repository.ListOptions) ([]*models.Deployment, error) {
+	res := make([]*models.Deployment, 0)
+
+	for _, depl := range repo.depls {
+		if depl.EnvironmentID == environmentID {
+			res = append(res, depl)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *memoryEnvironmentRepository) DeleteEnvironment(env *models.Environment) (*models.Environment, error) {
+	for namespace, depl := range repo.depls {
+		if depl.EnvironmentID == env.ID {
+			delete(repo.depls, namespace)
+		}
+	}
+
+	return env, nil
+}
+
+func TestDeleteEnvironmentInvalidatesDeployments(t *testing.T) {
+	store := &memoryStore{make(map[string][]byte)}
+	base := &memoryEnvironmentRepository{
+		depls: map[string]*models.Deployment{
+			"pr-1": {EnvironmentID: 1, Namespace: "pr-1"},
+			"pr-2": {EnvironmentID: 1, Namespace: "pr-2"},
+		},
+	}
+
+	repo := newEnvironmentRepository(base, &codec{store: store, key: &[32]byte{}}, time.Minute)
+
+	for _, namespace := range []string{"pr-1", "pr-2"} {
+		if _, err := repo.ReadDeployment(1, namespace); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	env := &models.Environment{ProjectID: 1, ClusterID: 1}
+	env.ID = 1
+
+	if _, err := repo.DeleteEnvironment(env); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	for _, namespace := range []string{"pr-1", "pr-2"} {
+		if _, ok := store.values[keyPrefix+deploymentKey(1, namespace)]; ok {
+			t.Errorf("expected the deployment in %s to be invalidated with its environment", namespace)
+		}
+
+		if _, err := repo.ReadDeployment(1, namespace); err != gorm.ErrRecordNotFound {
+			t.Errorf("expected the deployment in %s to be deleted, got %v", namespace, err)
+		}
+	}
+}
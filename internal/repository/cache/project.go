@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ProjectRepository caches projects, which are read by every project-scoped request
+type ProjectRepository struct {
+	repository.ProjectRepository
+
+	codec *codec
+	ttl   time.Duration
+}
+
+func newProjectRepository(repo repository.ProjectRepository, c *codec, ttl time.Duration) repository.ProjectRepository {
+	return &ProjectRepository{repo, c, ttl}
+}
+
+func projectKey(id uint) string {
+	return fmt.Sprintf("project:%d", id)
+}
+
+func (repo *ProjectRepository) ReadProject(id uint) (*models.Project, error) {
+	project := &models.Project{}
+
+	if repo.codec.get(projectKey(id), project) {
+		return project, nil
+	}
+
+	project, err := repo.ProjectRepository.ReadProject(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.codec.set(projectKey(id), project, repo.ttl)
+
+	return project, nil
+}
+
+func (repo *ProjectRepository) UpdateProject(project *models.Project) (*models.Project, error) {
+	defer repo.codec.invalidate(projectKey(project.ID))
+
+	return repo.ProjectRepository.UpdateProject(project)
+}
+
+func (repo *ProjectRepository) DeleteProject(project *models.Project) (*models.Project, error) {
+	defer repo.codec.invalidate(projectKey(project.ID))
+
+	return repo.ProjectRepository.DeleteProject(project)
+}
+
+func (repo *ProjectRepository) RestoreProject(id uint, deletedAfter time.Time) (*models.Project, error) {
+	defer repo.codec.invalidate(projectKey(id))
+
+	return repo.ProjectRepository.RestoreProject(id, deletedAfter)
+}
+
+// roles are read with their project, so role writes invalidate the project as well
+
+func (repo *ProjectRepository) CreateProjectRole(project *models.Project, role *models.Role) (*models.Role, error) {
+	defer repo.codec.invalidate(projectKey(project.ID))
+
+	return repo.ProjectRepository.CreateProjectRole(project, role)
+}
+
+func (repo *ProjectRepository) UpdateProjectRole(projID uint, role *models.Role) (*models.Role, error) {
+	defer repo.codec.invalidate(projectKey(projID))
+
+	return repo.ProjectRepository.UpdateProjectRole(projID, role)
+}
+
+func (repo *ProjectRepository) DeleteProjectRole(projID, userID uint) (*models.Role, error) {
+	defer repo.codec.invalidate(projectKey(projID))
+
+	return repo.ProjectRepository.DeleteProjectRole(projID, userID)
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type memoryStore struct {
+	values map[string][]byte
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if val, ok := s.values[key]; ok {
+		return val, nil
+	}
+
+	return nil, ErrCacheMiss
+}
+
+func (s *memoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.values[key] = value
+	return nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+
+	return nil
+}
+
+// countingProjectRepository counts the reads which reach the underlying repository
+type countingProjectRepository struct {
+	repository.ProjectRepository
+
+	project *models.Project
+	reads   int
+}
+
+func (repo *countingProjectRepository) ReadProject(id uint) (*models.Project, error) {
+	repo.reads++
+
+	res := *repo.project
+	return &res, nil
+}
+
+func (repo *countingProjectRepository) UpdateProject(project *models.Project) (*models.Project, error) {
+	repo.project = project
+	return project, nil
+}
+
+func TestProjectRepositoryReadThrough(t *testing.T) {
+	store := &memoryStore{make(map[string][]byte)}
+	base := &countingProjectRepository{
+		project: &models.Project{Name: "project-1", Roles: []models.Role{{Role: types.Role{ProjectID: 1}}}},
+	}
+	base.project.ID = 1
+
+	repo := newProjectRepository(base, &codec{store: store, key: &[32]byte{}}, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		project, err := repo.ReadProject(1)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if project.Name != "project-1" || len(project.Roles) != 1 {
+			t.Fatalf("unexpected cached project: %v", project)
+		}
+	}
+
+	if base.reads != 1 {
+		t.Errorf("expected 1 database read, got %d", base.reads)
+	}
+
+	if _, err := repo.UpdateProject(&models.Project{Model: base.project.Model, Name: "project-2"}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	project, err := repo.ReadProject(1)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if project.Name != "project-2" || base.reads != 2 {
+		t.Errorf("expected update to invalidate the cached project, got %s after %d reads", project.Name, base.reads)
+	}
+}
+
+func TestProjectRepositoryZeroTTL(t *testing.T) {
+	store := &memoryStore{make(map[string][]byte)}
+	base := &countingProjectRepository{project: &models.Project{Name: "project-1"}}
+
+	repo := newProjectRepository(base, &codec{store: store, key: &[32]byte{}}, 0)
+
+	repo.ReadProject(1)
+	repo.ReadProject(1)
+
+	if base.reads != 2 || len(store.values) != 0 {
+		t.Errorf("expected a TTL of 0 to disable caching, got %d reads and %d cached values", base.reads, len(store.values))
+	}
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// Conf configures the TTLs of cached models. A TTL of zero disables caching of a model.
+type Conf struct {
+	ProjectTTL     time.Duration
+	ClusterTTL     time.Duration
+	EnvironmentTTL time.Duration
+}
+
+// CachedRepository is a repository.Repository which serves hot reads from a read-through
+// cache, and invalidates cached models when they are written through the repository
+type CachedRepository struct {
+	repository.Repository
+
+	project     repository.ProjectRepository
+	cluster     repository.ClusterRepository
+	environment repository.EnvironmentRepository
+
+	codec *codec
+	conf  *Conf
+}
+
+// NewRepository wraps a repository with a read-through cache. The key is used to encrypt
+// cached models, and should be the key used to encrypt the database.
+func NewRepository(repo repository.Repository, store Store, key *[32]byte, conf *Conf) repository.Repository {
+	return newCachedRepository(repo, &codec{store: store, key: key}, conf)
+}
+
+func newCachedRepository(repo repository.Repository, c *codec, conf *Conf) *CachedRepository {
+	return &CachedRepository{
+		Repository:  repo,
+		codec:       c,
+		conf:        conf,
+		project:     newProjectRepository(repo.Project(), c, conf.ProjectTTL),
+		cluster:     newClusterRepository(repo.Cluster(), c, conf.ClusterTTL),
+		environment: newEnvironmentRepository(repo.Environment(), c, conf.EnvironmentTTL),
+	}
+}
+
+func (t *CachedRepository) Project() repository.ProjectRepository {
+	return t.project
+}
+
+func (t *CachedRepository) Cluster() repository.ClusterRepository {
+	return t.cluster
+}
+
+func (t *CachedRepository) Environment() repository.EnvironmentRepository {
+	return t.environment
+}
+
+// Transaction runs fn in a transaction of the wrapped repository. The repository passed to
+// fn bypasses the cache for reads, and collects the keys of the models written in the
+// transaction, which are invalidated once it is committed. Nothing is invalidated if the
+// transaction is rolled back.
+func (t *CachedRepository) Transaction(fn func(tx repository.Repository) error) error {
+	pending := &pendingKeys{}
+
+	err := t.Repository.Transaction(func(tx repository.Repository) error {
+		return fn(newCachedRepository(tx, &codec{
+			store:   t.codec.store,
+			key:     t.codec.key,
+			pending: pending,
+		}, t.conf))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// when t is itself the repository of a transaction, the keys are added to the keys of
+	// the outer transaction
+	t.codec.invalidate(pending.list()...)
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// transactionRepository runs transactions against the same projects, so that the writes of a
+// transaction are visible to its reads
+type transactionRepository struct {
+	repository.Repository
+
+	project *countingProjectRepository
+}
+
+func (repo *transactionRepository) Project() repository.ProjectRepository {
+	return repo.project
+}
+
+func (repo *transactionRepository) Cluster() repository.ClusterRepository {
+	return nil
+}
+
+func (repo *transactionRepository) Environment() repository.EnvironmentRepository {
+	return nil
+}
+
+func (repo *transactionRepository) Transaction(fn func(tx repository.Repository) error) error {
+	return fn(repo)
+}
+
+func TestCachedRepositoryTransaction(t *testing.T) {
+	store := &memoryStore{make(map[string][]byte)}
+	base := &countingProjectRepository{project: &models.Project{Name: "project-1"}}
+	base.project.ID = 1
+
+	repo := NewRepository(&transactionRepository{project: base}, store, &[32]byte{}, &Conf{ProjectTTL: time.Minute})
+
+	if _, err := repo.Project().ReadProject(1); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	err := repo.Transaction(func(tx repository.Repository) error {
+		if _, err := tx.Project().UpdateProject(&models.Project{Model: base.project.Model, Name: "project-2"}); err != nil {
+			return err
+		}
+
+		// the cached project is only invalidated once the transaction is committed
+		if _, ok := store.values[keyPrefix+projectKey(1)]; !ok {
+			t.Errorf("expected the project to be invalidated after the commit")
+		}
+
+		// reads in the transaction see its writes
+		project, err := tx.Project().ReadProject(1)
+
+		if err != nil {
+			return err
+		}
+
+		if project.Name != "project-2" {
+			t.Errorf("expected the transaction to read project-2, got %s", project.Name)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, ok := store.values[keyPrefix+projectKey(1)]; ok {
+		t.Errorf("expected the committed transaction to invalidate the project")
+	}
+
+	project, err := repo.Project().ReadProject(1)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if project.Name != "project-2" {
+		t.Errorf("expected project-2 after the commit, got %s", project.Name)
+	}
+}
+
+func TestCachedRepositoryTransactionRollback(t *testing.T) {
+	store := &memoryStore{make(map[string][]byte)}
+	base := &countingProjectRepository{project: &models.Project{Name: "project-1"}}
+	base.project.ID = 1
+
+	repo := NewRepository(&transactionRepository{project: base}, store, &[32]byte{}, &Conf{ProjectTTL: time.Minute})
+
+	if _, err := repo.Project().ReadProject(1); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	rollbackErr := errors.New("rollback")
+
+	err := repo.Transaction(func(tx repository.Repository) error {
+		if _, err := tx.Project().UpdateProject(&models.Project{Model: base.project.Model, Name: "project-2"}); err != nil {
+			return err
+		}
+
+		return rollbackErr
+	})
+
+	if !errors.Is(err, rollbackErr) {
+		t.Fatalf("expected the error of the transaction, got %v", err)
+	}
+
+	if _, ok := store.values[keyPrefix+projectKey(1)]; !ok {
+		t.Errorf("expected a rolled back transaction not to invalidate the project")
+	}
+}
+
+func TestCachedRepositoryNestedTransaction(t *testing.T) {
+	store := &memoryStore{make(map[string][]byte)}
+	base := &countingProjectRepository{project: &models.Project{Name: "project-1"}}
+	base.project.ID = 1
+
+	repo := NewRepository(&transactionRepository{project: base}, store, &[32]byte{}, &Conf{ProjectTTL: time.Minute})
+
+	if _, err := repo.Project().ReadProject(1); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	err := repo.Transaction(func(tx repository.Repository) error {
+		err := tx.Transaction(func(tx repository.Repository) error {
+			_, err := tx.Project().UpdateProject(&models.Project{Model: base.project.Model, Name: "project-2"})
+			return err
+		})
+
+		// the keys of a nested transaction wait for the outer transaction
+		if _, ok := store.values[keyPrefix+projectKey(1)]; !ok {
+			t.Errorf("expected the project to be invalidated after the outer commit")
+		}
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, ok := store.values[keyPrefix+projectKey(1)]; ok {
+		t.Errorf("expected the committed transaction to invalidate the project")
+	}
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"sync"
+	"time"
+
+	redis "github.com/go-redis/redis/v8"
+	"github.com/porter-dev/porter/internal/encryption"
+)
+
+// keyPrefix namespaces the keys of the repository cache, so that the redis instance can be
+// shared with other services
+const keyPrefix = "porter:repository:"
+
+// ErrCacheMiss is returned by a Store when a key is not cached
+var ErrCacheMiss = errors.New("cache miss")
+
+// Store stores the raw values of the repository cache
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// RedisStore is a Store backed by a redis instance
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := s.client.Get(ctx, key).Bytes()
+
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+
+	return res, err
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (s *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	return s.client.Del(ctx, keys...).Err()
+}
+
+// codec reads and writes models to a Store. Cached models may contain decrypted credentials,
+// such as the tokens of a cluster, so values are encrypted with the same key as the database.
+type codec struct {
+	store Store
+	key   *[32]byte
+
+	// pending is set for the codecs of transactions, and collects the keys which are
+	// invalidated in the transaction
+	pending *pendingKeys
+}
+
+// pendingKeys are the keys invalidated in a transaction. They are only invalidated once the
+// transaction is committed, since invalidating them earlier would let concurrent reads cache
+// the values from before the transaction again.
+type pendingKeys struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (p *pendingKeys) add(keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys = append(p.keys, keys...)
+}
+
+func (p *pendingKeys) list() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.keys
+}
+
+// get decodes a cached model into v, and returns false if the model is not cached or
+// cannot be read. Errors are not returned, since reads fall through to the database.
+// Reads in a transaction are never cached, so that they see the writes of the transaction.
+func (c *codec) get(key string, v interface{}) bool {
+	if c.pending != nil {
+		return false
+	}
+
+	ciphertext, err := c.store.Get(context.Background(), keyPrefix+key)
+
+	if err != nil {
+		return false
+	}
+
+	plaintext, err := encryption.Decrypt(ciphertext, c.key)
+
+	if err != nil {
+		return false
+	}
+
+	return gob.NewDecoder(bytes.NewReader(plaintext)).Decode(v) == nil
+}
+
+// set caches a model, ignoring any errors. Models read in a transaction are not cached,
+// since they may not be committed.
+func (c *codec) set(key string, v interface{}, ttl time.Duration) {
+	if ttl <= 0 || c.pending != nil {
+		return
+	}
+
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return
+	}
+
+	ciphertext, err := encryption.Encrypt(buf.Bytes(), c.key)
+
+	if err != nil {
+		return
+	}
+
+	c.store.Set(context.Background(), keyPrefix+key, ciphertext, ttl)
+}
+
+// invalidate removes cached models. Invalidation is best-effort, so that writes which have
+// been committed to the database are not reported as failed: stale values expire with
+// their TTL if the store is unavailable. Keys invalidated in a transaction are invalidated
+// when it is committed.
+func (c *codec) invalidate(keys ...string) {
+	if c.pending != nil {
+		c.pending.add(keys...)
+		return
+	}
+
+	prefixed := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		prefixed = append(prefixed, keyPrefix+key)
+	}
+
+	if len(prefixed) > 0 {
+		c.store.Delete(context.Background(), prefixed...)
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	ints "github.com/porter-dev/porter/internal/models/integrations"
 )
@@ -20,4 +22,7 @@ type ClusterRepository interface {
 	UpdateCluster(cluster *models.Cluster) (*models.Cluster, error)
 	UpdateClusterTokenCache(tokenCache *ints.ClusterTokenCache) (*models.Cluster, error)
 	DeleteCluster(cluster *models.Cluster) error
+	ListDeletedClustersByProjectID(projectID uint, deletedAfter time.Time) ([]*models.Cluster, error)
+	RestoreCluster(projectID, clusterID uint, deletedAfter time.Time) (*models.Cluster, error)
+	PurgeDeletedClusters(deletedBefore time.Time) (int64, error)
 }
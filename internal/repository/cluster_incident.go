@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ClusterIncidentRepository represents the set of queries on the ClusterIncident model
+type ClusterIncidentRepository interface {
+	CreateClusterIncident(incident *models.ClusterIncident) (*models.ClusterIncident, error)
+	ReadClusterIncident(projectID, clusterID, incidentID uint) (*models.ClusterIncident, error)
+	ListClusterIncidents(projectID, clusterID uint, opts *types.ListClusterIncidentsRequest) ([]*models.ClusterIncident, int64, error)
+	ListActiveClusterIncidents(projectID, clusterID uint) ([]*models.ClusterIncident, error)
+	ListClusterIncidentsUpdatedSince(projectID, clusterID uint, since time.Time) ([]*models.ClusterIncident, error)
+	ListReleaseIncidentsSince(projectID, clusterID uint, namespace, releaseName string, since time.Time) ([]*models.ClusterIncident, error)
+	UpdateClusterIncident(incident *models.ClusterIncident) (*models.ClusterIncident, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ClusterRelayRepository represents the set of queries on the ClusterRelay model
+type ClusterRelayRepository interface {
+	CreateClusterRelay(relay *models.ClusterRelay) (*models.ClusterRelay, error)
+	ReadClusterRelay(clusterID uint) (*models.ClusterRelay, error)
+	UpdateClusterRelay(relay *models.ClusterRelay) (*models.ClusterRelay, error)
+	DeleteClusterRelay(relay *models.ClusterRelay) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ClusterTunnelRepository represents the set of queries on the ClusterTunnel model
+type ClusterTunnelRepository interface {
+	CreateClusterTunnel(tunnel *models.ClusterTunnel) (*models.ClusterTunnel, error)
+	ReadClusterTunnel(clusterID uint) (*models.ClusterTunnel, error)
+	UpdateClusterTunnel(tunnel *models.ClusterTunnel) (*models.ClusterTunnel, error)
+	DeleteClusterTunnel(tunnel *models.ClusterTunnel) error
+}
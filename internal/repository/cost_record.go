@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CostRecordRepository represents the set of queries on the CostRecord model
+type CostRecordRepository interface {
+	// CreateCostRecords stores the costs of a cluster for an hour. Records which already exist
+	// for the hour are left unchanged, so an hour is only recorded once.
+	CreateCostRecords(records []*models.CostRecord) error
+
+	// ListCostRecords lists the costs of a cluster for the hours which start between from and to
+	ListCostRecords(clusterID uint, from, to time.Time) ([]*models.CostRecord, error)
+}
@@ -1,6 +1,13 @@
 package credentials
 
-import "github.com/porter-dev/porter/internal/models/integrations"
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// ErrNotFound is returned when a credential does not exist in the credential storage
+var ErrNotFound = errors.New("credential not found")
 
 type OAuthCredential struct {
 	// The ID issued to the client
@@ -62,6 +69,16 @@ type GitlabCredential struct {
 	AppClientSecret []byte `json:"app_client_secret"`
 }
 
+// EnvGroupSecretPath identifies the secret variables of a version of an env group in the
+// credential storage. A version of 0 refers to every version of the env group.
+type EnvGroupSecretPath struct {
+	ProjectID uint
+	ClusterID uint
+	Namespace string
+	Name      string
+	Version   uint
+}
+
 type CredentialStorage interface {
 	// OAuth
 	WriteOAuthCredential(oauthIntegration *integrations.OAuthIntegration, data *OAuthCredential) error
@@ -87,4 +104,9 @@ type CredentialStorage interface {
 	WriteGitlabCredential(giIntegration *integrations.GitlabIntegration, data *GitlabCredential) error
 	GetGitlabCredential(giIntegration *integrations.GitlabIntegration) (*GitlabCredential, error)
 	CreateGitlabToken(giIntegration *integrations.GitlabIntegration) (string, error)
+
+	// Env groups
+	WriteEnvGroupSecrets(path *EnvGroupSecretPath, data map[string]string) error
+	GetEnvGroupSecrets(path *EnvGroupSecretPath) (map[string]string, error)
+	DeleteEnvGroupSecrets(path *EnvGroupSecretPath) error
 }
@@ -0,0 +1,22 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// CustomDomainDNSRecordRepository represents the set of queries on the CustomDomainDNSRecord model
+type CustomDomainDNSRecordRepository interface {
+	// CreateCustomDomainDNSRecord creates the DNS record of a custom domain, replacing the
+	// previous record of the domain in the cluster
+	CreateCustomDomainDNSRecord(record *models.CustomDomainDNSRecord) (*models.CustomDomainDNSRecord, error)
+	ReadCustomDomainDNSRecord(clusterID uint, host string) (*models.CustomDomainDNSRecord, error)
+	ListCustomDomainDNSRecordsByRelease(clusterID uint, namespace, releaseName string) ([]*models.CustomDomainDNSRecord, error)
+
+	// SearchCustomDomains returns the custom domains of the projects whose host contains the
+	// query
+	SearchCustomDomains(projectIDs []uint, query string, limit int) ([]*models.CustomDomainDNSRecord, error)
+
+	// ListPendingCustomDomainDNSRecords lists the records of every cluster which have not
+	// propagated yet
+	ListPendingCustomDomainDNSRecords() ([]*models.CustomDomainDNSRecord, error)
+	UpdateCustomDomainDNSRecord(record *models.CustomDomainDNSRecord) (*models.CustomDomainDNSRecord, error)
+	DeleteCustomDomainDNSRecord(record *models.CustomDomainDNSRecord) error
+}
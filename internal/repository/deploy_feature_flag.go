@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// DeployFeatureFlagRepository represents the set of queries on the DeployFeatureFlag model
+type DeployFeatureFlagRepository interface {
+	CreateDeployFeatureFlag(flag *models.DeployFeatureFlag) (*models.DeployFeatureFlag, error)
+
+	// ListDeployFeatureFlagsByRevision lists the feature flags which were changed by the
+	// upgrade that created a revision of a release
+	ListDeployFeatureFlagsByRevision(clusterID uint, namespace, name string, revision int) ([]*models.DeployFeatureFlag, error)
+
+	// ListAppliedDeployFeatureFlags lists the applied feature flags of the revisions of a
+	// release after a revision, from the most to the least recently applied
+	ListAppliedDeployFeatureFlags(clusterID uint, namespace, name string, afterRevision int) ([]*models.DeployFeatureFlag, error)
+
+	UpdateDeployFeatureFlag(flag *models.DeployFeatureFlag) (*models.DeployFeatureFlag, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// EmailPreferenceRepository represents the set of queries on the email preferences of users
+// and the deployment records which are summarized in email digests
+type EmailPreferenceRepository interface {
+	CreateEmailPreference(pref *models.EmailPreference) (*models.EmailPreference, error)
+	ReadEmailPreference(userID uint) (*models.EmailPreference, error)
+	UpdateEmailPreference(pref *models.EmailPreference) (*models.EmailPreference, error)
+
+	// ListEmailPreferencesByUserIDs lists the stored preferences of the users. Users without a
+	// stored preference are omitted.
+	ListEmailPreferencesByUserIDs(userIDs []uint) ([]*models.EmailPreference, error)
+
+	// ListDueDigestPreferences lists the preferences of the users which have opted in to the
+	// daily digest, and have not been sent a digest since sentBefore
+	ListDueDigestPreferences(sentBefore time.Time) ([]*models.EmailPreference, error)
+
+	CreateDeploymentRecord(record *models.DeploymentRecord) (*models.DeploymentRecord, error)
+	ListDeploymentRecords(projectID uint, createdAfter time.Time) ([]*models.DeploymentRecord, error)
+
+	// DeleteDeploymentRecords deletes the records which were created before the given time, and
+	// returns the number of deleted records
+	DeleteDeploymentRecords(createdBefore time.Time) (int64, error)
+}
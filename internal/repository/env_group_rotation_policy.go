@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// EnvGroupRotationPolicyRepository represents the set of queries on the EnvGroupRotationPolicy model
+type EnvGroupRotationPolicyRepository interface {
+	CreateEnvGroupRotationPolicy(policy *models.EnvGroupRotationPolicy) (*models.EnvGroupRotationPolicy, error)
+	ReadEnvGroupRotationPolicy(clusterID uint, namespace, name, key string) (*models.EnvGroupRotationPolicy, error)
+	ReadEnvGroupRotationPolicyByID(id uint) (*models.EnvGroupRotationPolicy, error)
+	ListEnvGroupRotationPolicies(clusterID uint, namespace, name string) ([]*models.EnvGroupRotationPolicy, error)
+	UpdateEnvGroupRotationPolicy(policy *models.EnvGroupRotationPolicy) (*models.EnvGroupRotationPolicy, error)
+	DeleteEnvGroupRotationPolicy(policy *models.EnvGroupRotationPolicy) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// EnvGroupSourceRepository represents the set of queries on the EnvGroupSource model
+type EnvGroupSourceRepository interface {
+	CreateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error)
+	ReadEnvGroupSource(clusterID uint, namespace, name string) (*models.EnvGroupSource, error)
+	ReadEnvGroupSourceByID(id uint) (*models.EnvGroupSource, error)
+	ReadEnvGroupSourceByWebhookToken(token string) (*models.EnvGroupSource, error)
+	UpdateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error)
+	DeleteEnvGroupSource(source *models.EnvGroupSource) error
+}
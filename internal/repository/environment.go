@@ -1,6 +1,10 @@
 package repository
 
-import "github.com/porter-dev/porter/internal/models"
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
 
 type EnvironmentRepository interface {
 	CreateEnvironment(env *models.Environment) (*models.Environment, error)
@@ -8,15 +12,46 @@ type EnvironmentRepository interface {
 	ReadEnvironmentByID(projectID, clusterID, envID uint) (*models.Environment, error)
 	ReadEnvironmentByOwnerRepoName(projectID, clusterID uint, owner, repo string) (*models.Environment, error)
 	ReadEnvironmentByWebhookIDOwnerRepoName(webhookID, owner, repo string) (*models.Environment, error)
-	ListEnvironments(projectID, clusterID uint) ([]*models.Environment, error)
+	ListEnvironments(projectID, clusterID uint, opts *ListOptions) ([]*models.Environment, error)
 	UpdateEnvironment(environment *models.Environment) (*models.Environment, error)
 	DeleteEnvironment(env *models.Environment) (*models.Environment, error)
 	CreateDeployment(deployment *models.Deployment) (*models.Deployment, error)
 	ReadDeployment(environmentID uint, namespace string) (*models.Deployment, error)
 	ReadDeploymentByID(projectID, clusterID, id uint) (*models.Deployment, error)
 	ReadDeploymentByGitDetails(environmentID uint, owner, repo string, prNumber uint) (*models.Deployment, error)
-	ListDeploymentsByCluster(projectID, clusterID uint, states ...string) ([]*models.Deployment, error)
-	ListDeployments(environmentID uint, states ...string) ([]*models.Deployment, error)
+
+	// ReadEnvironmentAndDeployment reads the environment of a git repository along with one of
+	// its deployments in a single query. The deployment is matched by its pull request number
+	// if prNumber is set, and by its namespace otherwise. If the environment exists but the
+	// deployment does not, the environment is returned with gorm.ErrRecordNotFound.
+	ReadEnvironmentAndDeployment(
+		projectID, clusterID, gitInstallationID uint,
+		owner, repo string,
+		prNumber uint,
+		namespace string,
+	) (*models.Environment, *models.Deployment, error)
+
+	// ListDeploymentsByCluster and ListDeployments read from the primary, unless the list
+	// options set ReadReplica
+	ListDeploymentsByCluster(projectID, clusterID uint, opts *ListOptions) ([]*models.Deployment, error)
+	ListDeployments(environmentID uint, opts *ListOptions) ([]*models.Deployment, error)
 	UpdateDeployment(deployment *models.Deployment) (*models.Deployment, error)
+
+	// UpdateDeploymentFields updates only the given fields of a deployment, without writing
+	// back the rest of a possibly stale deployment
+	UpdateDeploymentFields(deployment *models.Deployment, fields ...string) (*models.Deployment, error)
+
+	// UpdateDeploymentWithJobs updates a deployment and enqueues background jobs in the same
+	// transaction, so that the jobs are enqueued if and only if the update succeeds
+	UpdateDeploymentWithJobs(deployment *models.Deployment, jobs ...*models.BackgroundJob) (*models.Deployment, error)
+
 	DeleteDeployment(deployment *models.Deployment) (*models.Deployment, error)
+
+	// SearchDeployments returns deployments of the projects whose namespace, repo name,
+	// commit SHA, pull request name or subdomain contains the query
+	SearchDeployments(projectIDs []uint, query string, limit int) ([]*DeploymentSearchResult, error)
+
+	ListDeletedEnvironments(projectID, clusterID uint, deletedAfter time.Time) ([]*models.Environment, error)
+	RestoreEnvironment(projectID, clusterID, envID uint, deletedAfter time.Time) (*models.Environment, error)
+	PurgeDeletedEnvironments(deletedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// ExternalSecretStoreRepository represents the set of queries on the
+// ExternalSecretStore model
+type ExternalSecretStoreRepository interface {
+	CreateOrUpdateExternalSecretStore(store *models.ExternalSecretStore) (*models.ExternalSecretStore, error)
+	ReadExternalSecretStore(projectID uint) (*models.ExternalSecretStore, error)
+	DeleteExternalSecretStore(store *models.ExternalSecretStore) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// FreezeWindowRepository represents the set of queries on the FreezeWindow model
+type FreezeWindowRepository interface {
+	CreateFreezeWindow(window *models.FreezeWindow) (*models.FreezeWindow, error)
+	ReadFreezeWindow(projectID, id uint) (*models.FreezeWindow, error)
+	ListFreezeWindows(projectID uint) ([]*models.FreezeWindow, error)
+	DeleteFreezeWindow(window *models.FreezeWindow) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// GitOpsExportRepository represents the set of queries on the GitOpsExport model
+type GitOpsExportRepository interface {
+	CreateGitOpsExport(export *models.GitOpsExport) (*models.GitOpsExport, error)
+	ReadGitOpsExportByStackID(stackID uint) (*models.GitOpsExport, error)
+	UpdateGitOpsExport(export *models.GitOpsExport) (*models.GitOpsExport, error)
+	DeleteGitOpsExport(export *models.GitOpsExport) error
+}
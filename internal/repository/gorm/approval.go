@@ -0,0 +1,124 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ApprovalRepository uses gorm.DB for querying the database
+type ApprovalRepository struct {
+	db *gorm.DB
+}
+
+// NewApprovalRepository returns an ApprovalRepository which uses gorm.DB for querying the
+// database
+func NewApprovalRepository(db *gorm.DB) repository.ApprovalRepository {
+	return &ApprovalRepository{db}
+}
+
+func (repo *ApprovalRepository) CreateApprovalPolicy(policy *models.ApprovalPolicy) (*models.ApprovalPolicy, error) {
+	if err := repo.db.Create(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *ApprovalRepository) ReadApprovalPolicy(projectID, id uint) (*models.ApprovalPolicy, error) {
+	policy := &models.ApprovalPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *ApprovalRepository) ListApprovalPolicies(projectID uint) ([]*models.ApprovalPolicy, error) {
+	policies := make([]*models.ApprovalPolicy, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (repo *ApprovalRepository) DeleteApprovalPolicy(policy *models.ApprovalPolicy) error {
+	return repo.db.Delete(policy).Error
+}
+
+func (repo *ApprovalRepository) CreateApproval(approval *models.Approval) (*models.Approval, error) {
+	if err := repo.db.Create(approval).Error; err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (repo *ApprovalRepository) ReadApproval(projectID, id uint) (*models.Approval, error) {
+	approval := &models.Approval{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(approval).Error; err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (repo *ApprovalRepository) ListApprovals(projectID uint, status types.ApprovalStatus) ([]*models.Approval, error) {
+	approvals := make([]*models.Approval, 0)
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("id desc").Find(&approvals).Error; err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+func (repo *ApprovalRepository) ListApprovalsByDateRange(projectID uint, from, to time.Time) ([]*models.Approval, error) {
+	approvals := make([]*models.Approval, 0)
+
+	if err := repo.db.Where(
+		"project_id = ? AND ((created_at >= ? AND created_at < ?) OR (reviewed_at >= ? AND reviewed_at < ?))",
+		projectID, from, to, from, to,
+	).Order("id asc").Find(&approvals).Error; err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+func (repo *ApprovalRepository) ListPendingApprovalsByRelease(
+	clusterID uint,
+	namespace, name string,
+) ([]*models.Approval, error) {
+	approvals := make([]*models.Approval, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND name = ? AND status = ?",
+		clusterID, namespace, name, types.ApprovalStatusPending,
+	).Find(&approvals).Error; err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+func (repo *ApprovalRepository) UpdateApproval(approval *models.Approval) (*models.Approval, error) {
+	if err := repo.db.Save(approval).Error; err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,235 @@
+package gorm
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ArchiveRepository uses gorm.DB for querying the database
+type ArchiveRepository struct {
+	db *gorm.DB
+}
+
+// NewArchiveRepository returns an ArchiveRepository which uses
+// gorm.DB for querying the database
+func NewArchiveRepository(db *gorm.DB) repository.ArchiveRepository {
+	return &ArchiveRepository{db}
+}
+
+// clusterDeployment is a deployment along with the project and cluster of its environment
+type clusterDeployment struct {
+	models.Deployment
+
+	ProjectID uint
+	ClusterID uint
+}
+
+func (repo *ArchiveRepository) ArchiveDeployments(updatedBefore time.Time, limit int) (int64, error) {
+	var count int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		depls := make([]*clusterDeployment, 0)
+
+		// deployments of deleted environments are archived as well, until the environments
+		// are purged
+		if err := tx.Unscoped().Model(&models.Deployment{}).
+			Select("deployments.*, environments.project_id, environments.cluster_id").
+			Joins("INNER JOIN environments ON environments.id = deployments.environment_id").
+			Where(
+				"(deployments.deleted_at IS NOT NULL OR deployments.status = ?) AND deployments.updated_at < ?",
+				types.DeploymentStatusInactive, updatedBefore,
+			).
+			Order("deployments.id asc").
+			Limit(limit).
+			Scan(&depls).Error; err != nil {
+			return err
+		}
+
+		if len(depls) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(depls))
+
+		for _, depl := range depls {
+			data, err := json.Marshal(depl.ToDeploymentType())
+
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Create(&models.ArchivedDeployment{
+				ProjectID:           depl.ProjectID,
+				ClusterID:           depl.ClusterID,
+				EnvironmentID:       depl.EnvironmentID,
+				DeploymentID:        depl.ID,
+				Namespace:           depl.Namespace,
+				RepoOwner:           depl.RepoOwner,
+				RepoName:            depl.RepoName,
+				PullRequestID:       depl.PullRequestID,
+				DeploymentCreatedAt: depl.CreatedAt,
+				Data:                data,
+			}).Error; err != nil {
+				return err
+			}
+
+			ids = append(ids, depl.ID)
+		}
+
+		res := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Deployment{})
+
+		count = res.RowsAffected
+
+		return res.Error
+	})
+
+	return count, err
+}
+
+func (repo *ArchiveRepository) ArchiveKubeEvents(updatedBefore time.Time, limit int) (int64, error) {
+	var count int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		events := make([]*models.KubeEvent, 0)
+
+		if err := tx.Preload("SubEvents").
+			Where("updated_at < ?", updatedBefore).
+			Order("id asc").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(events))
+
+		for _, event := range events {
+			data, err := json.Marshal(event.ToKubeEventType())
+
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Create(&models.ArchivedKubeEvent{
+				ProjectID:    event.ProjectID,
+				ClusterID:    event.ClusterID,
+				KubeEventID:  event.ID,
+				Name:         event.Name,
+				ResourceType: event.ResourceType,
+				Namespace:    event.Namespace,
+				Data:         data,
+			}).Error; err != nil {
+				return err
+			}
+
+			ids = append(ids, event.ID)
+		}
+
+		if err := tx.Unscoped().Where("kube_event_id IN ?", ids).Delete(&models.KubeSubEvent{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Where("id IN ?", ids).Delete(&models.KubeEvent{})
+
+		count = res.RowsAffected
+
+		return res.Error
+	})
+
+	return count, err
+}
+
+var archivedDeploymentListColumns = &listColumns{
+	table: "archived_deployments",
+	filters: map[string]string{
+		"environment_id":  "environment_id",
+		"repo_owner":      "repo_owner",
+		"repo_name":       "repo_name",
+		"pull_request_id": "pull_request_id",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortDesc,
+}
+
+// ListArchivedDeployments lists the archived deployments of a cluster, most recently
+// archived first
+func (repo *ArchiveRepository) ListArchivedDeployments(
+	projectID, clusterID uint,
+	opts *repository.ListOptions,
+) ([]*models.ArchivedDeployment, error) {
+	depls := make([]*models.ArchivedDeployment, 0)
+
+	query, err := applyListOptions(
+		readReplica(repo.db).Where("project_id = ? AND cluster_id = ?", projectID, clusterID),
+		opts, archivedDeploymentListColumns,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&depls).Error; err != nil {
+		return nil, err
+	}
+
+	depls = depls[:trimPage(opts, archivedDeploymentListColumns, len(depls), func(i int) gorm.Model {
+		return depls[i].Model
+	})]
+
+	return depls, nil
+}
+
+var archivedKubeEventListColumns = &listColumns{
+	table: "archived_kube_events",
+	filters: map[string]string{
+		"namespace":     "namespace",
+		"resource_type": "resource_type",
+		"name":          "name",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortDesc,
+}
+
+// ListArchivedKubeEvents lists the archived kube events of a cluster, most recently archived
+// first
+func (repo *ArchiveRepository) ListArchivedKubeEvents(
+	projectID, clusterID uint,
+	opts *repository.ListOptions,
+) ([]*models.ArchivedKubeEvent, error) {
+	events := make([]*models.ArchivedKubeEvent, 0)
+
+	query, err := applyListOptions(
+		readReplica(repo.db).Where("project_id = ? AND cluster_id = ?", projectID, clusterID),
+		opts, archivedKubeEventListColumns,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	events = events[:trimPage(opts, archivedKubeEventListColumns, len(events), func(i int) gorm.Model {
+		return events[i].Model
+	})]
+
+	return events, nil
+}
@@ -0,0 +1,175 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestArchiveDeployments(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_archive_deployments.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: tester.initProjects[0].ID,
+		ClusterID: 1,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	depls := make([]*models.Deployment, 0)
+
+	for i, status := range []types.DeploymentStatus{
+		types.DeploymentStatusCreated, types.DeploymentStatusInactive, types.DeploymentStatusCreated,
+	} {
+		depl, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+			EnvironmentID: env.ID,
+			Namespace:     "pr-" + string(rune('1'+i)),
+			PullRequestID: uint(i + 1),
+			Status:        status,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		depls = append(depls, depl)
+	}
+
+	// the third deployment is closed by deleting it
+	if _, err := tester.repo.Environment().DeleteDeployment(depls[2]); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	old := time.Now().AddDate(0, 0, -100)
+
+	if err := tester.db.Unscoped().Model(&models.Deployment{}).Where("1 = 1").UpdateColumn("updated_at", old).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	count, err := tester.repo.Archive().ArchiveDeployments(time.Now().AddDate(0, 0, -90), 100)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 {
+		t.Errorf("incorrect number of archived deployments: expected %d, got %d\n", 2, count)
+	}
+
+	// the active deployment is not archived
+	if _, err := tester.repo.Environment().ReadDeployment(env.ID, "pr-1"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	archived, err := tester.repo.Archive().ListArchivedDeployments(tester.initProjects[0].ID, 1, nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(archived) != 2 {
+		t.Fatalf("incorrect number of listed archived deployments: expected %d, got %d\n", 2, len(archived))
+	}
+
+	for _, a := range archived {
+		depl := a.ToArchivedDeploymentType().Deployment
+
+		if depl.ID != depls[1].ID && depl.ID != depls[2].ID {
+			t.Errorf("unexpected archived deployment %d\n", depl.ID)
+		}
+	}
+
+	// archiving again is a no-op
+	count, err = tester.repo.Archive().ArchiveDeployments(time.Now().AddDate(0, 0, -90), 100)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 0 {
+		t.Errorf("incorrect number of archived deployments on second run: expected %d, got %d\n", 0, count)
+	}
+}
+
+func TestArchiveKubeEvents(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_archive_kube_events.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	event, err := tester.repo.KubeEvent().CreateEvent(&models.KubeEvent{
+		ProjectID:    tester.initProjects[0].ID,
+		ClusterID:    1,
+		Name:         "web-7c9d",
+		ResourceType: "pod",
+		Namespace:    "default",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := tester.repo.KubeEvent().AppendSubEvent(event, &models.KubeSubEvent{
+		Message:   "Back-off restarting failed container",
+		Reason:    "BackOff",
+		Timestamp: time.Now(),
+		EventType: "critical",
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := tester.db.Model(&models.KubeEvent{}).Where("id = ?", event.ID).
+		UpdateColumn("updated_at", time.Now().AddDate(0, 0, -100)).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	count, err := tester.repo.Archive().ArchiveKubeEvents(time.Now().AddDate(0, 0, -90), 100)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Errorf("incorrect number of archived events: expected %d, got %d\n", 1, count)
+	}
+
+	archived, err := tester.repo.Archive().ListArchivedKubeEvents(tester.initProjects[0].ID, 1, nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(archived) != 1 {
+		t.Fatalf("incorrect number of listed archived events: expected %d, got %d\n", 1, len(archived))
+	}
+
+	archivedEvent := archived[0].ToArchivedKubeEventType().KubeEvent
+
+	if archivedEvent.Name != "web-7c9d" || len(archivedEvent.SubEvents) != 1 {
+		t.Errorf("incorrect archived event: got %+v\n", archivedEvent)
+	}
+
+	var subEventCount int64
+
+	if err := tester.db.Unscoped().Model(&models.KubeSubEvent{}).Count(&subEventCount).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if subEventCount != 0 {
+		t.Errorf("sub events were not removed: got %d\n", subEventCount)
+	}
+}
@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository uses gorm.DB for querying the database
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository returns an AuditLogRepository which uses
+// gorm.DB for querying the database
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &AuditLogRepository{db}
+}
+
+// CreateAuditLog creates a new audit log entry
+func (repo *AuditLogRepository) CreateAuditLog(auditLog *models.AuditLog) (*models.AuditLog, error) {
+	if err := repo.db.Create(auditLog).Error; err != nil {
+		return nil, err
+	}
+
+	return auditLog, nil
+}
+
+// ListAuditLogsByProjectID lists the audit logs for a project, most recent first, along with
+// the total number of audit logs matching the options
+func (repo *AuditLogRepository) ListAuditLogsByProjectID(
+	projectID uint,
+	opts *types.ListAuditLogsRequest,
+) ([]*models.AuditLog, int64, error) {
+	if opts.Limit == 0 {
+		opts.Limit = 50
+	}
+
+	query := readReplica(repo.db).Where("project_id = ?", projectID)
+
+	if opts.ClusterID != 0 {
+		query = query.Where("cluster_id = ?", opts.ClusterID)
+	}
+
+	if opts.UserID != 0 {
+		query = query.Where("user_id = ?", opts.UserID)
+	}
+
+	if opts.Action != "" {
+		query = query.Where("action = ?", opts.Action)
+	}
+
+	if opts.Namespace != "" {
+		query = query.Where("namespace = ?", opts.Namespace)
+	}
+
+	if opts.ResourceKind != "" {
+		query = query.Where("resource_kind = ?", opts.ResourceKind)
+	}
+
+	if opts.ResourceName != "" {
+		query = query.Where("resource_name = ?", opts.ResourceName)
+	}
+
+	var count int64
+
+	if err := query.Model(&models.AuditLog{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	auditLogs := make([]*models.AuditLog, 0)
+
+	if err := query.Order("id desc").Limit(opts.Limit).Offset(opts.Skip).Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, count, nil
+}
+
+// ListAuditLogsByDateRange lists all audit logs of a project which were created in [from, to),
+// oldest first
+func (repo *AuditLogRepository) ListAuditLogsByDateRange(projectID uint, from, to time.Time) ([]*models.AuditLog, error) {
+	auditLogs := make([]*models.AuditLog, 0)
+
+	if err := readReplica(repo.db).Where(
+		"project_id = ? AND created_at >= ? AND created_at < ?", projectID, from, to,
+	).Order("id asc").Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
@@ -0,0 +1,119 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListAuditLogsByProjectID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_audit_logs.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	for i := 0; i < 3; i++ {
+		action := types.AuditLogActionResourceApply
+
+		if i == 2 {
+			action = "other.action"
+		}
+
+		_, err := tester.repo.AuditLog().CreateAuditLog(&models.AuditLog{
+			ProjectID:    tester.initProjects[0].ID,
+			ClusterID:    1,
+			UserID:       1,
+			Action:       string(action),
+			ResourceKind: "Deployment",
+			ResourceName: "web",
+			Namespace:    "default",
+			Metadata:     []byte(`{"dry_run":false}`),
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	auditLogs, count, err := tester.repo.AuditLog().ListAuditLogsByProjectID(
+		tester.initProjects[0].ID,
+		&types.ListAuditLogsRequest{
+			Action: string(types.AuditLogActionResourceApply),
+			Limit:  1,
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 {
+		t.Errorf("incorrect count: expected %d, got %d\n", 2, count)
+	}
+
+	if len(auditLogs) != 1 {
+		t.Fatalf("incorrect number of audit logs: expected %d, got %d\n", 1, len(auditLogs))
+	}
+
+	// audit logs should be returned with the most recent first
+	if auditLogs[0].ID != 2 {
+		t.Errorf("incorrect audit log: expected id %d, got %d\n", 2, auditLogs[0].ID)
+	}
+
+	if auditLogs[0].ToAuditLogType().Metadata["dry_run"] != false {
+		t.Errorf("metadata was not decoded\n")
+	}
+}
+
+func TestListAuditLogsByResource(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_audit_logs_by_resource.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	for _, name := range []string{"web", "worker", "web"} {
+		_, err := tester.repo.AuditLog().CreateAuditLog(&models.AuditLog{
+			ProjectID:    tester.initProjects[0].ID,
+			ClusterID:    1,
+			UserID:       1,
+			Action:       string(types.AuditLogActionEnvGroupSecretsRead),
+			ResourceKind: "EnvGroup",
+			ResourceName: name,
+			Namespace:    "default",
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	auditLogs, count, err := tester.repo.AuditLog().ListAuditLogsByProjectID(
+		tester.initProjects[0].ID,
+		&types.ListAuditLogsRequest{
+			Namespace:    "default",
+			ResourceKind: "EnvGroup",
+			ResourceName: "web",
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 || len(auditLogs) != 2 {
+		t.Fatalf("incorrect number of audit logs: expected %d, got %d\n", 2, len(auditLogs))
+	}
+
+	for _, auditLog := range auditLogs {
+		if auditLog.ResourceName != "web" {
+			t.Errorf("incorrect resource name: expected %s, got %s\n", "web", auditLog.ResourceName)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// BackgroundJobRepository uses gorm.DB for querying the database
+type BackgroundJobRepository struct {
+	db *gorm.DB
+}
+
+// NewBackgroundJobRepository returns a BackgroundJobRepository which uses
+// gorm.DB for querying the database
+func NewBackgroundJobRepository(db *gorm.DB) repository.BackgroundJobRepository {
+	return &BackgroundJobRepository{db}
+}
+
+func (repo *BackgroundJobRepository) CreateBackgroundJob(job *models.BackgroundJob) (*models.BackgroundJob, error) {
+	if err := repo.db.Create(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (repo *BackgroundJobRepository) ReadBackgroundJob(projectID, id uint) (*models.BackgroundJob, error) {
+	job := &models.BackgroundJob{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+var backgroundJobListColumns = &listColumns{
+	table: "background_jobs",
+	filters: map[string]string{
+		"kind":   "kind",
+		"status": "status",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortDesc,
+}
+
+// ListBackgroundJobs lists the jobs of a project, most recent first
+func (repo *BackgroundJobRepository) ListBackgroundJobs(
+	projectID uint,
+	opts *repository.ListOptions,
+) ([]*models.BackgroundJob, error) {
+	jobs := make([]*models.BackgroundJob, 0)
+
+	query, err := applyListOptions(repo.db.Where("project_id = ?", projectID), opts, backgroundJobListColumns)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	jobs = jobs[:trimPage(opts, backgroundJobListColumns, len(jobs), func(i int) gorm.Model {
+		return jobs[i].Model
+	})]
+
+	return jobs, nil
+}
+
+func (repo *BackgroundJobRepository) UpdateBackgroundJob(job *models.BackgroundJob) (*models.BackgroundJob, error) {
+	if err := repo.db.Save(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ClaimBackgroundJob claims the job which has waited the longest to run. Each claim counts
+// as an attempt, so that jobs which crash their worker are not retried forever.
+func (repo *BackgroundJobRepository) ClaimBackgroundJob(now, staleBefore time.Time) (*models.BackgroundJob, error) {
+	for {
+		job := &models.BackgroundJob{}
+
+		if err := repo.db.Where(
+			"(status = ? AND run_after <= ?) OR (status = ? AND locked_at < ?)",
+			types.BackgroundJobQueued, now, types.BackgroundJobRunning, staleBefore,
+		).Order("run_after asc").Order("id asc").First(job).Error; err != nil {
+			return nil, err
+		}
+
+		// the job is only claimed if it has not changed since it was read, so a job which is
+		// claimed by another worker in the meantime is skipped
+		res := repo.db.Model(&models.BackgroundJob{}).
+			Where("id = ? AND updated_at = ?", job.ID, job.UpdatedAt).
+			Updates(map[string]interface{}{
+				"status":    types.BackgroundJobRunning,
+				"locked_at": now,
+				"attempts":  gorm.Expr("attempts + 1"),
+			})
+
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			continue
+		}
+
+		if err := repo.db.First(job, job.ID).Error; err != nil {
+			return nil, err
+		}
+
+		return job, nil
+	}
+}
@@ -0,0 +1,67 @@
+package gorm_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestClaimBackgroundJob(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_claim_background_job.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	now := time.Now().UTC()
+
+	// the second job is not ready to run yet
+	for _, runAfter := range []time.Time{now.Add(-time.Minute), now.Add(time.Hour)} {
+		_, err := tester.repo.BackgroundJob().CreateBackgroundJob(&models.BackgroundJob{
+			ProjectID:   tester.initProjects[0].ID,
+			Kind:        "test-job",
+			Status:      types.BackgroundJobQueued,
+			MaxAttempts: 3,
+			RunAfter:    runAfter,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	job, err := tester.repo.BackgroundJob().ClaimBackgroundJob(now, now.Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if job.ID != 1 || job.Status != types.BackgroundJobRunning || job.Attempts != 1 {
+		t.Errorf("incorrect claimed job: expected running job 1 with 1 attempt, got job %d (%s) with %d attempts\n",
+			job.ID, job.Status, job.Attempts)
+	}
+
+	_, err = tester.repo.BackgroundJob().ClaimBackgroundJob(now, now.Add(-time.Hour))
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected no job to be claimed, got %v\n", err)
+	}
+
+	// once the running job is stale, it should be claimed again
+	job, err = tester.repo.BackgroundJob().ClaimBackgroundJob(now.Add(2*time.Minute), now.Add(time.Minute))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if job.ID != 1 || job.Attempts != 2 {
+		t.Errorf("incorrect claimed job: expected job 1 with 2 attempts, got job %d with %d attempts\n",
+			job.ID, job.Attempts)
+	}
+}
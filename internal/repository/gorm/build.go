@@ -0,0 +1,60 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// BuildRepository uses gorm.DB for querying the database
+type BuildRepository struct {
+	db *gorm.DB
+}
+
+// NewBuildRepository returns a BuildRepository which uses gorm.DB for querying the database
+func NewBuildRepository(db *gorm.DB) repository.BuildRepository {
+	return &BuildRepository{db}
+}
+
+func (repo *BuildRepository) CreateBuild(build *models.Build) (*models.Build, error) {
+	if err := repo.db.Create(build).Error; err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (repo *BuildRepository) ReadBuild(projectID, clusterID, buildID uint) (*models.Build, error) {
+	build := &models.Build{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND id = ?", projectID, clusterID, buildID).First(build).Error; err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (repo *BuildRepository) ListBuildsByNamespace(
+	projectID, clusterID uint,
+	namespace string,
+) ([]*models.Build, error) {
+	builds := make([]*models.Build, 0)
+
+	if err := repo.db.
+		Where("project_id = ? AND cluster_id = ? AND namespace = ?", projectID, clusterID, namespace).
+		Order("created_at desc").
+		Limit(100).
+		Find(&builds).Error; err != nil {
+		return nil, err
+	}
+
+	return builds, nil
+}
+
+func (repo *BuildRepository) UpdateBuild(build *models.Build) (*models.Build, error) {
+	if err := repo.db.Save(build).Error; err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// BulkDeploymentOperationRepository uses gorm.DB for querying the database
+type BulkDeploymentOperationRepository struct {
+	db *gorm.DB
+}
+
+// NewBulkDeploymentOperationRepository returns a BulkDeploymentOperationRepository which uses
+// gorm.DB for querying the database
+func NewBulkDeploymentOperationRepository(db *gorm.DB) repository.BulkDeploymentOperationRepository {
+	return &BulkDeploymentOperationRepository{db}
+}
+
+// CreateBulkDeploymentOperation creates an operation along with its items
+func (repo *BulkDeploymentOperationRepository) CreateBulkDeploymentOperation(
+	op *models.BulkDeploymentOperation,
+) (*models.BulkDeploymentOperation, error) {
+	if err := repo.db.Create(op).Error; err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (repo *BulkDeploymentOperationRepository) ReadBulkDeploymentOperation(
+	projectID, clusterID, id uint,
+) (*models.BulkDeploymentOperation, error) {
+	op := &models.BulkDeploymentOperation{}
+
+	if err := repo.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("bulk_deployment_operation_items.id asc")
+	}).Where("project_id = ? AND cluster_id = ? AND id = ?", projectID, clusterID, id).First(op).Error; err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// ListBulkDeploymentOperations lists the operations of a cluster, most recent first
+func (repo *BulkDeploymentOperationRepository) ListBulkDeploymentOperations(
+	projectID, clusterID uint,
+) ([]*models.BulkDeploymentOperation, error) {
+	ops := make([]*models.BulkDeploymentOperation, 0)
+
+	if err := repo.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("bulk_deployment_operation_items.id asc")
+	}).Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Order("id desc").Find(&ops).Error; err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+// UpdateBulkDeploymentOperation updates an operation, but not its items
+func (repo *BulkDeploymentOperationRepository) UpdateBulkDeploymentOperation(
+	op *models.BulkDeploymentOperation,
+) (*models.BulkDeploymentOperation, error) {
+	if err := repo.db.Omit("Items").Save(op).Error; err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (repo *BulkDeploymentOperationRepository) UpdateBulkDeploymentOperationItem(
+	item *models.BulkDeploymentOperationItem,
+) (*models.BulkDeploymentOperationItem, error) {
+	if err := repo.db.Save(item).Error; err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/models"
@@ -335,16 +336,14 @@ func (repo *ClusterRepository) UpdateClusterTokenCache(
 func (repo *ClusterRepository) DeleteCluster(
 	cluster *models.Cluster,
 ) error {
-	// clear TokenCache association
-	if err := repo.db.Where("id = ?", cluster.TokenCacheID).Delete(&ints.ClusterTokenCache{}).Error; err != nil {
-		return err
-	}
-
-	if err := repo.db.Where("id = ?", cluster.ID).Delete(&models.Cluster{}).Error; err != nil {
-		return err
-	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		// clear TokenCache association
+		if err := tx.Where("id = ?", cluster.TokenCacheID).Delete(&ints.ClusterTokenCache{}).Error; err != nil {
+			return err
+		}
 
-	return nil
+		return tx.Where("id = ?", cluster.ID).Delete(&models.Cluster{}).Error
+	})
 }
 
 // EncryptClusterData will encrypt the user's service account data before writing
@@ -463,3 +462,78 @@ func (repo *ClusterRepository) DecryptClusterCandidateData(
 
 	return nil
 }
+
+// ListDeletedClustersByProjectID lists the clusters of a project deleted after a given time
+func (repo *ClusterRepository) ListDeletedClustersByProjectID(
+	projectID uint,
+	deletedAfter time.Time,
+) ([]*models.Cluster, error) {
+	clusters := []*models.Cluster{}
+
+	if err := repo.db.Unscoped().
+		Where("project_id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", projectID, deletedAfter).
+		Order("deleted_at desc").Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		repo.DecryptClusterData(cluster, repo.key)
+	}
+
+	return clusters, nil
+}
+
+// RestoreCluster restores a cluster which was deleted after a given time, along with
+// its token cache
+func (repo *ClusterRepository) RestoreCluster(
+	projectID, clusterID uint,
+	deletedAfter time.Time,
+) (*models.Cluster, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		cluster := &models.Cluster{}
+
+		if err := tx.Unscoped().
+			Where("project_id = ? AND id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", projectID, clusterID, deletedAfter).
+			First(cluster).Error; err != nil {
+			return err
+		}
+
+		if cluster.TokenCacheID != 0 {
+			if err := tx.Unscoped().Model(&ints.ClusterTokenCache{}).Where("id = ?", cluster.TokenCacheID).
+				Update("deleted_at", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Model(&models.Cluster{}).Where("id = ?", cluster.ID).Update("deleted_at", nil).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.ReadCluster(projectID, clusterID)
+}
+
+// PurgeDeletedClusters permanently deletes clusters, and their token caches, which were
+// deleted before a given time
+func (repo *ClusterRepository) PurgeDeletedClusters(deletedBefore time.Time) (int64, error) {
+	var count int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		subQuery := tx.Unscoped().Model(&models.Cluster{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).Select("token_cache_id")
+
+		if err := tx.Unscoped().Where("id IN (?)", subQuery).Delete(&ints.ClusterTokenCache{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).Delete(&models.Cluster{})
+
+		count = res.RowsAffected
+
+		return res.Error
+	})
+
+	return count, err
+}
@@ -0,0 +1,135 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ClusterIncidentRepository uses gorm.DB for querying the database
+type ClusterIncidentRepository struct {
+	db *gorm.DB
+}
+
+// NewClusterIncidentRepository returns a ClusterIncidentRepository which uses
+// gorm.DB for querying the database
+func NewClusterIncidentRepository(db *gorm.DB) repository.ClusterIncidentRepository {
+	return &ClusterIncidentRepository{db}
+}
+
+func (repo *ClusterIncidentRepository) CreateClusterIncident(incident *models.ClusterIncident) (*models.ClusterIncident, error) {
+	if err := repo.db.Create(incident).Error; err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+func (repo *ClusterIncidentRepository) ReadClusterIncident(projectID, clusterID, incidentID uint) (*models.ClusterIncident, error) {
+	incident := &models.ClusterIncident{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND id = ?", projectID, clusterID, incidentID).First(incident).Error; err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// ListClusterIncidents lists the incidents in a cluster, most recent first, along with the
+// total number of incidents matching the options
+func (repo *ClusterIncidentRepository) ListClusterIncidents(
+	projectID, clusterID uint,
+	opts *types.ListClusterIncidentsRequest,
+) ([]*models.ClusterIncident, int64, error) {
+	if opts.Limit == 0 {
+		opts.Limit = 50
+	}
+
+	query := repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID)
+
+	switch opts.Status {
+	case types.IncidentStatusActive:
+		query = query.Where("resolved_at IS NULL")
+	case types.IncidentStatusResolved:
+		query = query.Where("resolved_at IS NOT NULL")
+	}
+
+	if opts.Namespace != "" {
+		query = query.Where("namespace = ?", opts.Namespace)
+	}
+
+	if opts.ReleaseName != "" {
+		query = query.Where("release_name = ?", opts.ReleaseName)
+	}
+
+	var count int64
+
+	if err := query.Model(&models.ClusterIncident{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	incidents := make([]*models.ClusterIncident, 0)
+
+	if err := query.Order("started_at desc").Order("id desc").Limit(opts.Limit).Offset(opts.Skip).Find(&incidents).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return incidents, count, nil
+}
+
+func (repo *ClusterIncidentRepository) ListActiveClusterIncidents(projectID, clusterID uint) ([]*models.ClusterIncident, error) {
+	incidents := make([]*models.ClusterIncident, 0)
+
+	if err := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND resolved_at IS NULL", projectID, clusterID,
+	).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+func (repo *ClusterIncidentRepository) ListClusterIncidentsUpdatedSince(
+	projectID, clusterID uint,
+	since time.Time,
+) ([]*models.ClusterIncident, error) {
+	incidents := make([]*models.ClusterIncident, 0)
+
+	if err := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND updated_at > ?", projectID, clusterID, since,
+	).Order("updated_at asc").Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+// ListReleaseIncidentsSince lists the incidents of a release which were open at any time since
+// the given time, including incidents which are still open
+func (repo *ClusterIncidentRepository) ListReleaseIncidentsSince(
+	projectID, clusterID uint,
+	namespace, releaseName string,
+	since time.Time,
+) ([]*models.ClusterIncident, error) {
+	incidents := make([]*models.ClusterIncident, 0)
+
+	if err := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND release_name = ? AND (resolved_at IS NULL OR resolved_at > ?)",
+		projectID, clusterID, namespace, releaseName, since,
+	).Order("started_at asc").Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+func (repo *ClusterIncidentRepository) UpdateClusterIncident(incident *models.ClusterIncident) (*models.ClusterIncident, error) {
+	if err := repo.db.Save(incident).Error; err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
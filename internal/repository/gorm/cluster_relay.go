@@ -0,0 +1,50 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ClusterRelayRepository uses gorm.DB for querying the database
+type ClusterRelayRepository struct {
+	db *gorm.DB
+}
+
+// NewClusterRelayRepository returns a ClusterRelayRepository which uses gorm.DB for
+// querying the database
+func NewClusterRelayRepository(db *gorm.DB) repository.ClusterRelayRepository {
+	return &ClusterRelayRepository{db}
+}
+
+func (repo *ClusterRelayRepository) CreateClusterRelay(relay *models.ClusterRelay) (*models.ClusterRelay, error) {
+	if err := repo.db.Create(relay).Error; err != nil {
+		return nil, err
+	}
+
+	return relay, nil
+}
+
+func (repo *ClusterRelayRepository) ReadClusterRelay(clusterID uint) (*models.ClusterRelay, error) {
+	relay := &models.ClusterRelay{}
+
+	if err := repo.db.Where("cluster_id = ?", clusterID).First(relay).Error; err != nil {
+		return nil, err
+	}
+
+	return relay, nil
+}
+
+func (repo *ClusterRelayRepository) UpdateClusterRelay(relay *models.ClusterRelay) (*models.ClusterRelay, error) {
+	if err := repo.db.Save(relay).Error; err != nil {
+		return nil, err
+	}
+
+	return relay, nil
+}
+
+// DeleteClusterRelay hard-deletes the relay, so that a relay can be created for the
+// cluster again
+func (repo *ClusterRelayRepository) DeleteClusterRelay(relay *models.ClusterRelay) error {
+	return repo.db.Unscoped().Delete(relay).Error
+}
@@ -0,0 +1,50 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ClusterTunnelRepository uses gorm.DB for querying the database
+type ClusterTunnelRepository struct {
+	db *gorm.DB
+}
+
+// NewClusterTunnelRepository returns a ClusterTunnelRepository which uses gorm.DB for
+// querying the database
+func NewClusterTunnelRepository(db *gorm.DB) repository.ClusterTunnelRepository {
+	return &ClusterTunnelRepository{db}
+}
+
+func (repo *ClusterTunnelRepository) CreateClusterTunnel(tunnel *models.ClusterTunnel) (*models.ClusterTunnel, error) {
+	if err := repo.db.Create(tunnel).Error; err != nil {
+		return nil, err
+	}
+
+	return tunnel, nil
+}
+
+func (repo *ClusterTunnelRepository) ReadClusterTunnel(clusterID uint) (*models.ClusterTunnel, error) {
+	tunnel := &models.ClusterTunnel{}
+
+	if err := repo.db.Where("cluster_id = ?", clusterID).First(tunnel).Error; err != nil {
+		return nil, err
+	}
+
+	return tunnel, nil
+}
+
+func (repo *ClusterTunnelRepository) UpdateClusterTunnel(tunnel *models.ClusterTunnel) (*models.ClusterTunnel, error) {
+	if err := repo.db.Save(tunnel).Error; err != nil {
+		return nil, err
+	}
+
+	return tunnel, nil
+}
+
+// DeleteClusterTunnel hard-deletes the tunnel, so that a tunnel can be created for the
+// cluster again
+func (repo *ClusterTunnelRepository) DeleteClusterTunnel(tunnel *models.ClusterTunnel) error {
+	return repo.db.Unscoped().Delete(tunnel).Error
+}
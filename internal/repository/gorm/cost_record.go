@@ -0,0 +1,40 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CostRecordRepository uses gorm.DB for querying the database
+type CostRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewCostRecordRepository returns a CostRecordRepository which uses
+// gorm.DB for querying the database
+func NewCostRecordRepository(db *gorm.DB) repository.CostRecordRepository {
+	return &CostRecordRepository{db}
+}
+
+func (repo *CostRecordRepository) CreateCostRecords(records []*models.CostRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&records).Error
+}
+
+func (repo *CostRecordRepository) ListCostRecords(clusterID uint, from, to time.Time) ([]*models.CostRecord, error) {
+	records := make([]*models.CostRecord, 0)
+
+	if err := repo.db.Where("cluster_id = ? AND hour >= ? AND hour < ?", clusterID, from, to).
+		Order("hour asc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
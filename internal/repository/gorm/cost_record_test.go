@@ -0,0 +1,56 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCreateCostRecords(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_create_cost_records.db",
+	}
+
+	setupTestEnv(tester, t)
+	initCluster(tester, t)
+	defer cleanup(tester, t)
+
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	// an hour which was already recorded is left unchanged
+	for _, hourCost := range []float64{1, 5} {
+		err := tester.repo.CostRecord().CreateCostRecords([]*models.CostRecord{
+			{
+				ProjectID:   tester.initProjects[0].ID,
+				ClusterID:   tester.initClusters[0].ID,
+				Namespace:   "default",
+				ReleaseName: "web",
+				Hour:        hour,
+				Cost:        hourCost,
+			},
+			{
+				ProjectID:   tester.initProjects[0].ID,
+				ClusterID:   tester.initClusters[0].ID,
+				Namespace:   "default",
+				ReleaseName: "web",
+				Hour:        hour.Add(time.Hour),
+				Cost:        hourCost,
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	records, err := tester.repo.CostRecord().ListCostRecords(tester.initClusters[0].ID, hour, hour.Add(2*time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 2 || records[0].Cost != 1 || records[1].Cost != 1 || !records[0].Hour.Equal(hour) {
+		t.Fatalf("expected the first records of both hours costing 1, got %v\n", records)
+	}
+}
@@ -0,0 +1,113 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CustomDomainDNSRecordRepository uses gorm.DB for querying the database
+type CustomDomainDNSRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomDomainDNSRecordRepository returns a CustomDomainDNSRecordRepository which uses
+// gorm.DB for querying the database
+func NewCustomDomainDNSRecordRepository(db *gorm.DB) repository.CustomDomainDNSRecordRepository {
+	return &CustomDomainDNSRecordRepository{db}
+}
+
+func (repo *CustomDomainDNSRecordRepository) CreateCustomDomainDNSRecord(
+	record *models.CustomDomainDNSRecord,
+) (*models.CustomDomainDNSRecord, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cluster_id = ? AND host = ?", record.ClusterID, record.Host).
+			Delete(&models.CustomDomainDNSRecord{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(record).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) ReadCustomDomainDNSRecord(
+	clusterID uint,
+	host string,
+) (*models.CustomDomainDNSRecord, error) {
+	record := &models.CustomDomainDNSRecord{}
+
+	if err := repo.db.Where("cluster_id = ? AND host = ?", clusterID, host).First(record).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) ListCustomDomainDNSRecordsByRelease(
+	clusterID uint,
+	namespace, releaseName string,
+) ([]*models.CustomDomainDNSRecord, error) {
+	records := make([]*models.CustomDomainDNSRecord, 0)
+
+	if err := repo.db.Where("cluster_id = ? AND namespace = ? AND release_name = ?", clusterID, namespace, releaseName).
+		Order("id asc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) SearchCustomDomains(
+	projectIDs []uint,
+	query string,
+	limit int,
+) ([]*models.CustomDomainDNSRecord, error) {
+	records := make([]*models.CustomDomainDNSRecord, 0)
+
+	if len(projectIDs) == 0 {
+		return records, nil
+	}
+
+	cond, args := searchCondition(query, "host")
+
+	if err := repo.db.Where("project_id IN ?", projectIDs).
+		Where(cond, args...).
+		Order("updated_at desc").
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) ListPendingCustomDomainDNSRecords() ([]*models.CustomDomainDNSRecord, error) {
+	records := make([]*models.CustomDomainDNSRecord, 0)
+
+	if err := repo.db.Where("status = ?", types.CustomDomainDNSPending).Order("id asc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) UpdateCustomDomainDNSRecord(
+	record *models.CustomDomainDNSRecord,
+) (*models.CustomDomainDNSRecord, error) {
+	if err := repo.db.Save(record).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) DeleteCustomDomainDNSRecord(record *models.CustomDomainDNSRecord) error {
+	return repo.db.Delete(record).Error
+}
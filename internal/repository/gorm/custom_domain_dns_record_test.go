@@ -0,0 +1,64 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestSearchCustomDomains(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_search_custom_domains.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	for _, host := range []string{"shop.acme.com", "docs.acme.com"} {
+		_, err := tester.repo.CustomDomainDNSRecord().CreateCustomDomainDNSRecord(&models.CustomDomainDNSRecord{
+			ProjectID:   tester.initProjects[0].ID,
+			ClusterID:   1,
+			Namespace:   "default",
+			ReleaseName: "web",
+			Host:        host,
+			Status:      types.CustomDomainDNSPending,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	// the search should ignore case, and should not match domains of other projects
+	records, err := tester.repo.CustomDomainDNSRecord().SearchCustomDomains([]uint{tester.initProjects[0].ID}, "SHOP", 10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 1 || records[0].Host != "shop.acme.com" || records[0].ReleaseName != "web" {
+		t.Fatalf("incorrect search results: expected shop.acme.com, got %d results\n", len(records))
+	}
+
+	records, err = tester.repo.CustomDomainDNSRecord().SearchCustomDomains([]uint{tester.initProjects[0].ID + 1}, "acme", 10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 0 {
+		t.Errorf("incorrect search results: expected no results, got %d\n", len(records))
+	}
+
+	records, err = tester.repo.CustomDomainDNSRecord().SearchCustomDomains([]uint{tester.initProjects[0].ID}, "acme", 1)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 1 {
+		t.Errorf("incorrect search results: expected the limit of 1 result, got %d\n", len(records))
+	}
+}
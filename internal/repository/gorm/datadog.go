@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// DatadogIntegrationRepository uses gorm.DB for querying the database
+type DatadogIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDatadogIntegrationRepository returns a DatadogIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewDatadogIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.DatadogIntegrationRepository {
+	return &DatadogIntegrationRepository{db, key}
+}
+
+// CreateDatadogIntegration creates a new Datadog integration
+func (repo *DatadogIntegrationRepository) CreateDatadogIntegration(
+	ddInt *ints.DatadogIntegration,
+) (*ints.DatadogIntegration, error) {
+	apiKey := ddInt.APIKey
+
+	cipherData, err := encryption.Encrypt(apiKey, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ddInt.APIKey = cipherData
+
+	if err := repo.db.Create(ddInt).Error; err != nil {
+		return nil, err
+	}
+
+	ddInt.APIKey = apiKey
+
+	return ddInt, nil
+}
+
+// ReadDatadogIntegration finds a Datadog integration of a project by its ID
+func (repo *DatadogIntegrationRepository) ReadDatadogIntegration(
+	projectID, integrationID uint,
+) (*ints.DatadogIntegration, error) {
+	ddInt := &ints.DatadogIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(ddInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAPIKey(ddInt); err != nil {
+		return nil, err
+	}
+
+	return ddInt, nil
+}
+
+// ListDatadogIntegrationsByProjectID finds all Datadog integrations of a project
+func (repo *DatadogIntegrationRepository) ListDatadogIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DatadogIntegration, error) {
+	ddInts := []*ints.DatadogIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&ddInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, ddInt := range ddInts {
+		if err := repo.decryptAPIKey(ddInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return ddInts, nil
+}
+
+// DeleteDatadogIntegration deletes a Datadog integration
+func (repo *DatadogIntegrationRepository) DeleteDatadogIntegration(
+	ddInt *ints.DatadogIntegration,
+) error {
+	return repo.db.Delete(ddInt).Error
+}
+
+func (repo *DatadogIntegrationRepository) decryptAPIKey(ddInt *ints.DatadogIntegration) error {
+	if len(ddInt.APIKey) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(ddInt.APIKey, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	ddInt.APIKey = plaintext
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestDatadogIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_datadog.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	apiKey := "b3f1c0d2e4a5968778695a4b3c2d1e0f"
+
+	ddInt, err := tester.repo.DatadogIntegration().CreateDatadogIntegration(&ints.DatadogIntegration{
+		ProjectID: projectID,
+		Name:      "production",
+		Site:      "datadoghq.eu",
+		Tags:      "env:production,team:platform",
+		APIKey:    []byte(apiKey),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the API key should be encrypted at rest, and decrypted when read
+	stored := &ints.DatadogIntegration{}
+
+	if err := tester.db.First(stored, ddInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.APIKey) == apiKey {
+		t.Errorf("API key was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.DatadogIntegration().ReadDatadogIntegration(projectID, ddInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.APIKey) != apiKey {
+		t.Errorf("incorrect API key: expected %s, got %s\n", apiKey, readInt.APIKey)
+	}
+
+	if tags := readInt.GetTags(); len(tags) != 2 || tags[0] != "env:production" || tags[1] != "team:platform" {
+		t.Errorf("incorrect tags: expected [env:production team:platform], got %v\n", tags)
+	}
+
+	ddInts, err := tester.repo.DatadogIntegration().ListDatadogIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(ddInts) != 1 || string(ddInts[0].APIKey) != apiKey {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted API key\n")
+	}
+
+	if err := tester.repo.DatadogIntegration().DeleteDatadogIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.DatadogIntegration().ReadDatadogIntegration(projectID, ddInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
@@ -0,0 +1,69 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DeployFeatureFlagRepository uses gorm.DB for querying the database
+type DeployFeatureFlagRepository struct {
+	db *gorm.DB
+}
+
+// NewDeployFeatureFlagRepository returns a DeployFeatureFlagRepository which uses gorm.DB for
+// querying the database
+func NewDeployFeatureFlagRepository(db *gorm.DB) repository.DeployFeatureFlagRepository {
+	return &DeployFeatureFlagRepository{db}
+}
+
+func (repo *DeployFeatureFlagRepository) CreateDeployFeatureFlag(flag *models.DeployFeatureFlag) (*models.DeployFeatureFlag, error) {
+	if err := repo.db.Create(flag).Error; err != nil {
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+func (repo *DeployFeatureFlagRepository) ListDeployFeatureFlagsByRevision(
+	clusterID uint,
+	namespace, name string,
+	revision int,
+) ([]*models.DeployFeatureFlag, error) {
+	flags := make([]*models.DeployFeatureFlag, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND name = ? AND revision = ?",
+		clusterID, namespace, name, revision,
+	).Order("id asc").Find(&flags).Error; err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+func (repo *DeployFeatureFlagRepository) ListAppliedDeployFeatureFlags(
+	clusterID uint,
+	namespace, name string,
+	afterRevision int,
+) ([]*models.DeployFeatureFlag, error) {
+	flags := make([]*models.DeployFeatureFlag, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND name = ? AND revision > ? AND status = ?",
+		clusterID, namespace, name, afterRevision, types.DeployFeatureFlagStatusApplied,
+	).Order("id desc").Find(&flags).Error; err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+func (repo *DeployFeatureFlagRepository) UpdateDeployFeatureFlag(flag *models.DeployFeatureFlag) (*models.DeployFeatureFlag, error) {
+	if err := repo.db.Save(flag).Error; err != nil {
+		return nil, err
+	}
+
+	return flag, nil
+}
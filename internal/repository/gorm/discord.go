@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// DiscordIntegrationRepository uses gorm.DB for querying the database
+type DiscordIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDiscordIntegrationRepository returns a DiscordIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewDiscordIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.DiscordIntegrationRepository {
+	return &DiscordIntegrationRepository{db, key}
+}
+
+// CreateDiscordIntegration creates a new Discord integration
+func (repo *DiscordIntegrationRepository) CreateDiscordIntegration(
+	discordInt *ints.DiscordIntegration,
+) (*ints.DiscordIntegration, error) {
+	webhook := discordInt.Webhook
+
+	cipherData, err := encryption.Encrypt(webhook, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	discordInt.Webhook = cipherData
+
+	if err := repo.db.Create(discordInt).Error; err != nil {
+		return nil, err
+	}
+
+	discordInt.Webhook = webhook
+
+	return discordInt, nil
+}
+
+// ReadDiscordIntegration finds a Discord integration of a project by its ID
+func (repo *DiscordIntegrationRepository) ReadDiscordIntegration(
+	projectID, integrationID uint,
+) (*ints.DiscordIntegration, error) {
+	discordInt := &ints.DiscordIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(discordInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptWebhook(discordInt); err != nil {
+		return nil, err
+	}
+
+	return discordInt, nil
+}
+
+// ListDiscordIntegrationsByProjectID finds all Discord integrations of a project
+func (repo *DiscordIntegrationRepository) ListDiscordIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DiscordIntegration, error) {
+	discordInts := []*ints.DiscordIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&discordInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, discordInt := range discordInts {
+		if err := repo.decryptWebhook(discordInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return discordInts, nil
+}
+
+// DeleteDiscordIntegration deletes a Discord integration
+func (repo *DiscordIntegrationRepository) DeleteDiscordIntegration(
+	discordInt *ints.DiscordIntegration,
+) error {
+	return repo.db.Delete(discordInt).Error
+}
+
+func (repo *DiscordIntegrationRepository) decryptWebhook(discordInt *ints.DiscordIntegration) error {
+	if len(discordInt.Webhook) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(discordInt.Webhook, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	discordInt.Webhook = plaintext
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+)
+
+func TestDiscordIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_discord.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: projectID,
+		ClusterID: 1,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	projectInt, err := tester.repo.DiscordIntegration().CreateDiscordIntegration(&ints.DiscordIntegration{
+		ProjectID: projectID,
+		Name:      "deploys",
+		Webhook:   []byte("https://discord.com/api/webhooks/1/project"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	envInt, err := tester.repo.DiscordIntegration().CreateDiscordIntegration(&ints.DiscordIntegration{
+		ProjectID:     projectID,
+		Name:          "previews",
+		EnvironmentID: env.ID,
+		Webhook:       []byte("https://discord.com/api/webhooks/2/environment"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the webhook should be encrypted at rest, and decrypted when read
+	stored := &ints.DiscordIntegration{}
+
+	if err := tester.db.First(stored, projectInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.Webhook) == "https://discord.com/api/webhooks/1/project" {
+		t.Errorf("webhook was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.DiscordIntegration().ReadDiscordIntegration(projectID, projectInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.Webhook) != "https://discord.com/api/webhooks/1/project" {
+		t.Errorf("incorrect webhook: expected %s, got %s\n", "https://discord.com/api/webhooks/1/project", readInt.Webhook)
+	}
+
+	// integrations of the environment are only routed events in its deployments' namespaces
+	routed, err := discord.ListRoutedIntegrations(tester.repo, projectID, 1, "pr-1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(routed) != 2 {
+		t.Errorf("incorrect number of integrations routed in the environment: expected %d, got %d\n", 2, len(routed))
+	}
+
+	routed, err = discord.ListRoutedIntegrations(tester.repo, projectID, 1, "default")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(routed) != 1 || routed[0].ID != projectInt.ID {
+		t.Errorf("expected only the project integration to be routed outside of the environment\n")
+	}
+
+	if err := tester.repo.DiscordIntegration().DeleteDiscordIntegration(envInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	discordInts, err := tester.repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(discordInts) != 1 || discordInts[0].ID != projectInt.ID {
+		t.Errorf("incorrect integrations after delete: expected only integration %d\n", projectInt.ID)
+	}
+}
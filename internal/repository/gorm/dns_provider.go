@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// DNSProviderIntegrationRepository uses gorm.DB for querying the database
+type DNSProviderIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDNSProviderIntegrationRepository returns a DNSProviderIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewDNSProviderIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.DNSProviderIntegrationRepository {
+	return &DNSProviderIntegrationRepository{db, key}
+}
+
+// CreateDNSProviderIntegration creates a new DNS provider integration
+func (repo *DNSProviderIntegrationRepository) CreateDNSProviderIntegration(
+	dnsInt *ints.DNSProviderIntegration,
+) (*ints.DNSProviderIntegration, error) {
+	apiToken := dnsInt.CloudflareAPIToken
+
+	cipherData, err := encryption.Encrypt(apiToken, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dnsInt.CloudflareAPIToken = cipherData
+
+	if err := repo.db.Create(dnsInt).Error; err != nil {
+		return nil, err
+	}
+
+	dnsInt.CloudflareAPIToken = apiToken
+
+	return dnsInt, nil
+}
+
+// ReadDNSProviderIntegration finds a DNS provider integration of a project by its ID
+func (repo *DNSProviderIntegrationRepository) ReadDNSProviderIntegration(
+	projectID, integrationID uint,
+) (*ints.DNSProviderIntegration, error) {
+	dnsInt := &ints.DNSProviderIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(dnsInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptCloudflareAPIToken(dnsInt); err != nil {
+		return nil, err
+	}
+
+	return dnsInt, nil
+}
+
+// ListDNSProviderIntegrationsByProjectID finds all DNS provider integrations of a project
+func (repo *DNSProviderIntegrationRepository) ListDNSProviderIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DNSProviderIntegration, error) {
+	dnsInts := []*ints.DNSProviderIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&dnsInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, dnsInt := range dnsInts {
+		if err := repo.decryptCloudflareAPIToken(dnsInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return dnsInts, nil
+}
+
+// DeleteDNSProviderIntegration deletes a DNS provider integration
+func (repo *DNSProviderIntegrationRepository) DeleteDNSProviderIntegration(
+	dnsInt *ints.DNSProviderIntegration,
+) error {
+	return repo.db.Delete(dnsInt).Error
+}
+
+func (repo *DNSProviderIntegrationRepository) decryptCloudflareAPIToken(dnsInt *ints.DNSProviderIntegration) error {
+	if len(dnsInt.CloudflareAPIToken) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(dnsInt.CloudflareAPIToken, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	dnsInt.CloudflareAPIToken = plaintext
+
+	return nil
+}
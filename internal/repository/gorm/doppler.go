@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// DopplerIntegrationRepository uses gorm.DB for querying the database
+type DopplerIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDopplerIntegrationRepository returns a DopplerIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewDopplerIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.DopplerIntegrationRepository {
+	return &DopplerIntegrationRepository{db, key}
+}
+
+// CreateDopplerIntegration creates a new Doppler integration
+func (repo *DopplerIntegrationRepository) CreateDopplerIntegration(
+	dopplerInt *ints.DopplerIntegration,
+) (*ints.DopplerIntegration, error) {
+	apiToken := dopplerInt.APIToken
+
+	cipherData, err := encryption.Encrypt(apiToken, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dopplerInt.APIToken = cipherData
+
+	if err := repo.db.Create(dopplerInt).Error; err != nil {
+		return nil, err
+	}
+
+	dopplerInt.APIToken = apiToken
+
+	return dopplerInt, nil
+}
+
+// ReadDopplerIntegration finds a Doppler integration of a project by its ID
+func (repo *DopplerIntegrationRepository) ReadDopplerIntegration(
+	projectID, integrationID uint,
+) (*ints.DopplerIntegration, error) {
+	dopplerInt := &ints.DopplerIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(dopplerInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAPIToken(dopplerInt); err != nil {
+		return nil, err
+	}
+
+	return dopplerInt, nil
+}
+
+// ListDopplerIntegrationsByProjectID finds all Doppler integrations of a project
+func (repo *DopplerIntegrationRepository) ListDopplerIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DopplerIntegration, error) {
+	dopplerInts := []*ints.DopplerIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&dopplerInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, dopplerInt := range dopplerInts {
+		if err := repo.decryptAPIToken(dopplerInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return dopplerInts, nil
+}
+
+// DeleteDopplerIntegration deletes a Doppler integration
+func (repo *DopplerIntegrationRepository) DeleteDopplerIntegration(
+	dopplerInt *ints.DopplerIntegration,
+) error {
+	return repo.db.Delete(dopplerInt).Error
+}
+
+func (repo *DopplerIntegrationRepository) decryptAPIToken(dopplerInt *ints.DopplerIntegration) error {
+	if len(dopplerInt.APIToken) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(dopplerInt.APIToken, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	dopplerInt.APIToken = plaintext
+
+	return nil
+}
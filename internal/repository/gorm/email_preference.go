@@ -0,0 +1,101 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EmailPreferenceRepository uses gorm.DB for querying the database
+type EmailPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailPreferenceRepository returns an EmailPreferenceRepository which uses
+// gorm.DB for querying the database
+func NewEmailPreferenceRepository(db *gorm.DB) repository.EmailPreferenceRepository {
+	return &EmailPreferenceRepository{db}
+}
+
+func (repo *EmailPreferenceRepository) CreateEmailPreference(pref *models.EmailPreference) (*models.EmailPreference, error) {
+	if err := repo.db.Create(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (repo *EmailPreferenceRepository) ReadEmailPreference(userID uint) (*models.EmailPreference, error) {
+	pref := &models.EmailPreference{}
+
+	if err := repo.db.Where("user_id = ?", userID).First(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (repo *EmailPreferenceRepository) UpdateEmailPreference(pref *models.EmailPreference) (*models.EmailPreference, error) {
+	if err := repo.db.Save(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (repo *EmailPreferenceRepository) ListEmailPreferencesByUserIDs(userIDs []uint) ([]*models.EmailPreference, error) {
+	prefs := make([]*models.EmailPreference, 0)
+
+	if len(userIDs) == 0 {
+		return prefs, nil
+	}
+
+	if err := repo.db.Where("user_id IN (?)", userIDs).Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+func (repo *EmailPreferenceRepository) ListDueDigestPreferences(sentBefore time.Time) ([]*models.EmailPreference, error) {
+	prefs := make([]*models.EmailPreference, 0)
+
+	if err := repo.db.Where(
+		"daily_digest = ? AND (last_digest_at IS NULL OR last_digest_at < ?)", true, sentBefore,
+	).Order("id asc").Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+func (repo *EmailPreferenceRepository) CreateDeploymentRecord(record *models.DeploymentRecord) (*models.DeploymentRecord, error) {
+	if err := repo.db.Create(record).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (repo *EmailPreferenceRepository) ListDeploymentRecords(
+	projectID uint,
+	createdAfter time.Time,
+) ([]*models.DeploymentRecord, error) {
+	records := make([]*models.DeploymentRecord, 0)
+
+	if err := repo.db.Where(
+		"project_id = ? AND created_at > ?", projectID, createdAfter,
+	).Order("created_at asc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *EmailPreferenceRepository) DeleteDeploymentRecords(createdBefore time.Time) (int64, error) {
+	res := repo.db.Unscoped().Where("created_at < ?", createdBefore).Delete(&models.DeploymentRecord{})
+
+	return res.RowsAffected, res.Error
+}
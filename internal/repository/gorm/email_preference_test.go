@@ -0,0 +1,154 @@
+package gorm_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/email"
+)
+
+type fakeEmailSender struct {
+	sent []*email.Message
+}
+
+func (f *fakeEmailSender) Send(msg *email.Message) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func TestEmailPreferences(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_email_preferences.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	initProjectRole(tester, t)
+	defer cleanup(tester, t)
+
+	user := tester.initUsers[0]
+	projectID := tester.initProjects[0].ID
+	sender := &fakeEmailSender{}
+
+	// users without a stored preference should be sent failure alerts, and every deploy should
+	// be recorded for the digests
+	deplNotifier := email.NewDeploymentNotifier(nil, tester.repo, sender)
+
+	for _, status := range []notifier.DeploymentStatus{notifier.StatusHelmDeployed, notifier.StatusHelmFailed} {
+		err := deplNotifier.Notify(&notifier.NotifyOpts{
+			ProjectID: projectID,
+			ClusterID: 1,
+			Name:      "web",
+			Namespace: "default",
+			Status:    status,
+			Version:   2,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != user.Email {
+		t.Fatalf("expected 1 failure alert to %s, got %d emails\n", user.Email, len(sender.sent))
+	}
+
+	records, err := tester.repo.EmailPreference().ListDeploymentRecords(projectID, time.Now().Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 deployment records, got %d\n", len(records))
+	}
+
+	// users who have opted out of failure alerts should not be sent them
+	pref, err := tester.repo.EmailPreference().CreateEmailPreference(&models.EmailPreference{
+		UserID:      user.ID,
+		DailyDigest: true,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := deplNotifier.Notify(&notifier.NotifyOpts{
+		ProjectID: projectID,
+		Name:      "web",
+		Status:    notifier.StatusHelmFailed,
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Errorf("expected no failure alert after opting out, got %d emails\n", len(sender.sent))
+	}
+
+	// a user who has never been sent a digest is due
+	due, err := tester.repo.EmailPreference().ListDueDigestPreferences(time.Now())
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(due) != 1 || due[0].UserID != user.ID {
+		t.Fatalf("expected the user to be due a digest\n")
+	}
+
+	sent, err := email.SendDigest(tester.repo, sender, "https://porter.run", user, time.Now().Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !sent || len(sender.sent) != 2 {
+		t.Fatalf("expected a digest to be sent\n")
+	}
+
+	if digest := sender.sent[1].Text; !strings.Contains(digest, "Deployments: 1 succeeded, 2 failed") {
+		t.Errorf("digest does not summarize the deployments:\n%s", digest)
+	}
+
+	now := time.Now()
+	pref.LastDigestAt = &now
+
+	if _, err := tester.repo.EmailPreference().UpdateEmailPreference(pref); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	due, err = tester.repo.EmailPreference().ListDueDigestPreferences(now.Add(-time.Minute))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(due) != 0 {
+		t.Errorf("expected no users to be due a digest, got %d\n", len(due))
+	}
+
+	// there is nothing to summarize since the last digest
+	sent, err = email.SendDigest(tester.repo, sender, "https://porter.run", user, now)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if sent {
+		t.Errorf("expected no digest to be sent without deployments\n")
+	}
+
+	count, err := tester.repo.EmailPreference().DeleteDeploymentRecords(time.Now().Add(time.Minute))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 3 {
+		t.Errorf("expected 3 deleted deployment records, got %d\n", count)
+	}
+}
@@ -0,0 +1,86 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupRotationPolicyRepository uses gorm.DB for querying the database
+type EnvGroupRotationPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvGroupRotationPolicyRepository returns an EnvGroupRotationPolicyRepository which uses
+// gorm.DB for querying the database
+func NewEnvGroupRotationPolicyRepository(db *gorm.DB) repository.EnvGroupRotationPolicyRepository {
+	return &EnvGroupRotationPolicyRepository{db}
+}
+
+func (repo *EnvGroupRotationPolicyRepository) CreateEnvGroupRotationPolicy(
+	policy *models.EnvGroupRotationPolicy,
+) (*models.EnvGroupRotationPolicy, error) {
+	if err := repo.db.Create(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *EnvGroupRotationPolicyRepository) ReadEnvGroupRotationPolicy(
+	clusterID uint,
+	namespace, name, key string,
+) (*models.EnvGroupRotationPolicy, error) {
+	policy := &models.EnvGroupRotationPolicy{}
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND name = ? AND key = ?",
+		clusterID, namespace, name, key,
+	).First(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *EnvGroupRotationPolicyRepository) ReadEnvGroupRotationPolicyByID(id uint) (*models.EnvGroupRotationPolicy, error) {
+	policy := &models.EnvGroupRotationPolicy{}
+
+	if err := repo.db.Where("id = ?", id).First(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *EnvGroupRotationPolicyRepository) ListEnvGroupRotationPolicies(
+	clusterID uint,
+	namespace, name string,
+) ([]*models.EnvGroupRotationPolicy, error) {
+	policies := make([]*models.EnvGroupRotationPolicy, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND name = ?",
+		clusterID, namespace, name,
+	).Order("key asc").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (repo *EnvGroupRotationPolicyRepository) UpdateEnvGroupRotationPolicy(
+	policy *models.EnvGroupRotationPolicy,
+) (*models.EnvGroupRotationPolicy, error) {
+	if err := repo.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeleteEnvGroupRotationPolicy deletes a policy permanently, so that a policy for the same
+// variable can be created again
+func (repo *EnvGroupRotationPolicyRepository) DeleteEnvGroupRotationPolicy(policy *models.EnvGroupRotationPolicy) error {
+	return repo.db.Unscoped().Delete(policy).Error
+}
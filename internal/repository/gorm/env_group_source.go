@@ -0,0 +1,77 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSourceRepository uses gorm.DB for querying the database
+type EnvGroupSourceRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvGroupSourceRepository returns an EnvGroupSourceRepository which uses gorm.DB for
+// querying the database
+func NewEnvGroupSourceRepository(db *gorm.DB) repository.EnvGroupSourceRepository {
+	return &EnvGroupSourceRepository{db}
+}
+
+func (repo *EnvGroupSourceRepository) CreateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	if err := repo.db.Create(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func (repo *EnvGroupSourceRepository) ReadEnvGroupSource(
+	clusterID uint,
+	namespace, name string,
+) (*models.EnvGroupSource, error) {
+	source := &models.EnvGroupSource{}
+
+	if err := repo.db.Where("cluster_id = ? AND namespace = ? AND name = ?", clusterID, namespace, name).First(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func (repo *EnvGroupSourceRepository) ReadEnvGroupSourceByID(id uint) (*models.EnvGroupSource, error) {
+	source := &models.EnvGroupSource{}
+
+	if err := repo.db.Where("id = ?", id).First(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// ReadEnvGroupSourceByWebhookToken finds a source by the token of its webhook
+func (repo *EnvGroupSourceRepository) ReadEnvGroupSourceByWebhookToken(token string) (*models.EnvGroupSource, error) {
+	source := &models.EnvGroupSource{}
+
+	if token == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := repo.db.Where("webhook_token = ?", token).First(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func (repo *EnvGroupSourceRepository) UpdateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	if err := repo.db.Save(source).Error; err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// DeleteEnvGroupSource deletes a source permanently, so that the env group can be linked again
+func (repo *EnvGroupSourceRepository) DeleteEnvGroupSource(source *models.EnvGroupSource) error {
+	return repo.db.Unscoped().Delete(source).Error
+}
@@ -1,7 +1,9 @@
 package gorm
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -29,21 +31,11 @@ func (repo *EnvironmentRepository) CreateEnvironment(env *models.Environment) (*
 func (repo *EnvironmentRepository) ReadEnvironment(projectID, clusterID, gitInstallationID uint, gitRepoOwner, gitRepoName string) (*models.Environment, error) {
 	env := &models.Environment{}
 
-	switch repo.db.Dialector.Name() {
-	case "sqlite":
-		if err := repo.db.Order("id desc").Where(
-			"project_id = ? AND cluster_id = ? AND git_installation_id = ? AND git_repo_owner LIKE ? AND git_repo_name LIKE ?",
-			projectID, clusterID, gitInstallationID, gitRepoOwner, gitRepoName,
-		).First(&env).Error; err != nil {
-			return nil, err
-		}
-	case "postgres":
-		if err := repo.db.Order("id desc").Where(
-			"project_id = ? AND cluster_id = ? AND git_installation_id = ? AND git_repo_owner iLIKE ? AND git_repo_name iLIKE ?",
-			projectID, clusterID, gitInstallationID, gitRepoOwner, gitRepoName,
-		).First(&env).Error; err != nil {
-			return nil, err
-		}
+	if err := repo.db.Order("id desc").Where(
+		"project_id = ? AND cluster_id = ? AND git_installation_id = ? AND LOWER(git_repo_owner) = LOWER(?) AND LOWER(git_repo_name) = LOWER(?)",
+		projectID, clusterID, gitInstallationID, gitRepoOwner, gitRepoName,
+	).First(&env).Error; err != nil {
+		return nil, err
 	}
 
 	return env, nil
@@ -68,19 +60,11 @@ func (repo *EnvironmentRepository) ReadEnvironmentByOwnerRepoName(
 ) (*models.Environment, error) {
 	env := &models.Environment{}
 
-	switch repo.db.Dialector.Name() {
-	case "sqlite":
-		if err := repo.db.Order("id desc").Where("project_id = ? AND cluster_id = ? AND git_repo_owner LIKE ? AND git_repo_name LIKE ?",
-			projectID, clusterID, gitRepoOwner, gitRepoName,
-		).First(&env).Error; err != nil {
-			return nil, err
-		}
-	case "postgres":
-		if err := repo.db.Order("id desc").Where("project_id = ? AND cluster_id = ? AND git_repo_owner iLIKE ? AND git_repo_name iLIKE ?",
-			projectID, clusterID, gitRepoOwner, gitRepoName,
-		).First(&env).Error; err != nil {
-			return nil, err
-		}
+	if err := repo.db.Order("id desc").Where(
+		"project_id = ? AND cluster_id = ? AND LOWER(git_repo_owner) = LOWER(?) AND LOWER(git_repo_name) = LOWER(?)",
+		projectID, clusterID, gitRepoOwner, gitRepoName,
+	).First(&env).Error; err != nil {
+		return nil, err
 	}
 
 	return env, nil
@@ -91,32 +75,55 @@ func (repo *EnvironmentRepository) ReadEnvironmentByWebhookIDOwnerRepoName(
 ) (*models.Environment, error) {
 	env := &models.Environment{}
 
-	switch repo.db.Dialector.Name() {
-	case "sqlite":
-		if err := repo.db.Order("id desc").Where("webhook_id = ? AND git_repo_owner LIKE ? AND git_repo_name LIKE ?",
-			webhookID, gitRepoOwner, gitRepoName,
-		).First(&env).Error; err != nil {
-			return nil, err
-		}
-	case "postgres":
-		if err := repo.db.Order("id desc").Where("webhook_id = ? AND git_repo_owner iLIKE ? AND git_repo_name iLIKE ?",
-			webhookID, gitRepoOwner, gitRepoName,
-		).First(&env).Error; err != nil {
-			return nil, err
-		}
+	if err := repo.db.Order("id desc").Where(
+		"webhook_id = ? AND LOWER(git_repo_owner) = LOWER(?) AND LOWER(git_repo_name) = LOWER(?)",
+		webhookID, gitRepoOwner, gitRepoName,
+	).First(&env).Error; err != nil {
+		return nil, err
 	}
 
 	return env, nil
 }
 
-func (repo *EnvironmentRepository) ListEnvironments(projectID, clusterID uint) ([]*models.Environment, error) {
+var environmentListColumns = &listColumns{
+	table: "environments",
+	filters: map[string]string{
+		"git_repo_owner": "git_repo_owner",
+		"git_repo_name":  "git_repo_name",
+		"mode":           "mode",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortAsc,
+}
+
+func (repo *EnvironmentRepository) ListEnvironments(
+	projectID, clusterID uint,
+	opts *repository.ListOptions,
+) ([]*models.Environment, error) {
 	envs := make([]*models.Environment, 0)
 
-	if err := repo.db.Order("id asc").Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Find(&envs).Error; err != nil {
+	query, err := applyListOptions(
+		repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID),
+		opts, environmentListColumns,
+	)
+
+	if err != nil {
 		return nil, err
 	}
 
-	return envs, nil
+	if err := query.Find(&envs).Error; err != nil {
+		return nil, err
+	}
+
+	n := trimPage(opts, environmentListColumns, len(envs), func(i int) gorm.Model {
+		return envs[i].Model
+	})
+
+	return envs[:n], nil
 }
 
 func (repo *EnvironmentRepository) UpdateEnvironment(environment *models.Environment) (*models.Environment, error) {
@@ -149,6 +156,31 @@ func (repo *EnvironmentRepository) UpdateDeployment(deployment *models.Deploymen
 	return deployment, nil
 }
 
+func (repo *EnvironmentRepository) UpdateDeploymentWithJobs(
+	deployment *models.Deployment,
+	jobs ...*models.BackgroundJob,
+) (*models.Deployment, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(deployment).Error; err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			if err := tx.Create(job).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
 func (repo *EnvironmentRepository) ReadDeployment(environmentID uint, namespace string) (*models.Deployment, error) {
 	depl := &models.Deployment{}
 	if err := repo.db.Order("id desc").Where("environment_id = ? AND namespace = ?", environmentID, namespace).First(&depl).Error; err != nil {
@@ -175,66 +207,149 @@ func (repo *EnvironmentRepository) ReadDeploymentByGitDetails(
 ) (*models.Deployment, error) {
 	depl := &models.Deployment{}
 
-	switch repo.db.Dialector.Name() {
-	case "sqlite":
-		if err := repo.db.Order("id asc").
-			Where("environment_id = ? AND repo_owner LIKE ? AND repo_name LIKE ? AND pull_request_id = ?",
-				environmentID, gitRepoOwner, gitRepoName, prNumber).
-			First(&depl).Error; err != nil {
-			return nil, err
-		}
-	case "postgres":
-		if err := repo.db.Order("id asc").
-			Where("environment_id = ? AND repo_owner iLIKE ? AND repo_name iLIKE ? AND pull_request_id = ?",
-				environmentID, gitRepoOwner, gitRepoName, prNumber).
-			First(&depl).Error; err != nil {
-			return nil, err
-		}
+	if err := repo.db.Order("id asc").
+		Where("environment_id = ? AND pull_request_id = ? AND LOWER(repo_owner) = LOWER(?) AND LOWER(repo_name) = LOWER(?)",
+			environmentID, prNumber, gitRepoOwner, gitRepoName).
+		First(&depl).Error; err != nil {
+		return nil, err
 	}
 
 	return depl, nil
 }
 
-func (repo *EnvironmentRepository) ListDeploymentsByCluster(projectID, clusterID uint, states ...string) ([]*models.Deployment, error) {
-	query := repo.db.
-		Order("deployments.updated_at desc").
-		Joins("INNER JOIN environments ON environments.id = deployments.environment_id").
-		Where("environments.project_id = ? AND environments.cluster_id = ? AND environments.deleted_at IS NULL", projectID, clusterID)
+// environmentDeployment is the result of reading an environment along with one of its
+// deployments. The deployment columns are prefixed, since both tables share column names.
+type environmentDeployment struct {
+	models.Environment
+	Deployment models.Deployment `gorm:"embedded;embeddedPrefix:deployment_"`
+}
 
-	if len(states) > 0 {
-		queryArr := make([]string, len(states))
-		stateInterArr := make([]interface{}, len(states))
+// prefixedColumns selects every column of a model's table with the given prefix, so that the
+// columns can be scanned into a struct embedding the model with an embeddedPrefix tag
+func prefixedColumns(db *gorm.DB, model interface{}, prefix string) (string, error) {
+	stmt := &gorm.Statement{DB: db}
 
-		for i, state := range states {
-			queryArr[i] = "deployments.status = ?"
-			stateInterArr[i] = state
-		}
+	if err := stmt.Parse(model); err != nil {
+		return "", err
+	}
+
+	columns := make([]string, 0, len(stmt.Schema.DBNames))
 
-		query = query.Where(strings.Join(queryArr, " OR "), stateInterArr...)
+	for _, name := range stmt.Schema.DBNames {
+		columns = append(columns, fmt.Sprintf("%[1]s.%[2]s AS %[3]s%[2]s", stmt.Schema.Table, name, prefix))
 	}
 
-	depls := make([]*models.Deployment, 0)
+	return strings.Join(columns, ", "), nil
+}
 
-	if err := query.Find(&depls).Error; err != nil {
+func (repo *EnvironmentRepository) ReadEnvironmentAndDeployment(
+	projectID, clusterID, gitInstallationID uint,
+	gitRepoOwner, gitRepoName string,
+	prNumber uint,
+	namespace string,
+) (*models.Environment, *models.Deployment, error) {
+	deploymentColumns, err := prefixedColumns(repo.db, &models.Deployment{}, "deployment_")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the deployment is left joined, so that a missing deployment can be told apart from a
+	// missing environment
+	query := repo.db.Model(&models.Environment{}).
+		Select("environments.*, "+deploymentColumns).
+		Where(
+			"environments.project_id = ? AND environments.cluster_id = ? AND environments.git_installation_id = ? AND "+
+				"LOWER(environments.git_repo_owner) = LOWER(?) AND LOWER(environments.git_repo_name) = LOWER(?)",
+			projectID, clusterID, gitInstallationID, gitRepoOwner, gitRepoName,
+		)
+
+	if prNumber != 0 {
+		query = query.Joins(
+			"LEFT JOIN deployments ON deployments.environment_id = environments.id AND deployments.deleted_at IS NULL AND "+
+				"deployments.pull_request_id = ? AND LOWER(deployments.repo_owner) = LOWER(?) AND LOWER(deployments.repo_name) = LOWER(?)",
+			prNumber, gitRepoOwner, gitRepoName,
+		).Order("environments.id desc, deployments.id asc")
+	} else {
+		query = query.Joins(
+			"LEFT JOIN deployments ON deployments.environment_id = environments.id AND deployments.deleted_at IS NULL AND "+
+				"deployments.namespace = ?",
+			namespace,
+		).Order("environments.id desc, deployments.id desc")
+	}
+
+	res := &environmentDeployment{}
+
+	q := query.Limit(1).Scan(res)
+
+	if q.Error != nil {
+		return nil, nil, q.Error
+	}
+
+	if q.RowsAffected == 0 {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+
+	if res.Deployment.ID == 0 {
+		return &res.Environment, nil, gorm.ErrRecordNotFound
+	}
+
+	return &res.Environment, &res.Deployment, nil
+}
+
+func (repo *EnvironmentRepository) UpdateDeploymentFields(
+	deployment *models.Deployment,
+	fields ...string,
+) (*models.Deployment, error) {
+	if err := repo.db.Model(deployment).Select(fields).Updates(deployment).Error; err != nil {
 		return nil, err
 	}
 
-	return depls, nil
+	return deployment, nil
 }
 
-func (repo *EnvironmentRepository) ListDeployments(environmentID uint, states ...string) ([]*models.Deployment, error) {
-	query := repo.db.Debug().Order("deployments.updated_at desc").Where("environment_id = ?", environmentID)
+var deploymentListColumns = &listColumns{
+	table: "deployments",
+	filters: map[string]string{
+		"status":         "status",
+		"environment_id": "environment_id",
+		"repo_owner":     "repo_owner",
+		"repo_name":      "repo_name",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "updated_at",
+	defaultOrder: repository.SortDesc,
+}
 
-	if len(states) > 0 {
-		queryArr := make([]string, len(states))
-		stateInterArr := make([]interface{}, len(states))
+func (repo *EnvironmentRepository) ListDeploymentsByCluster(
+	projectID, clusterID uint,
+	opts *repository.ListOptions,
+) ([]*models.Deployment, error) {
+	query := listSession(repo.db, opts).
+		Joins("INNER JOIN environments ON environments.id = deployments.environment_id").
+		Where("environments.project_id = ? AND environments.cluster_id = ? AND environments.deleted_at IS NULL", projectID, clusterID)
 
-		for i, state := range states {
-			queryArr[i] = "deployments.status = ?"
-			stateInterArr[i] = state
-		}
+	return repo.listDeployments(query, opts)
+}
 
-		query = query.Where(strings.Join(queryArr, " OR "), stateInterArr...)
+func (repo *EnvironmentRepository) ListDeployments(
+	environmentID uint,
+	opts *repository.ListOptions,
+) ([]*models.Deployment, error) {
+	return repo.listDeployments(listSession(repo.db, opts).Where("deployments.environment_id = ?", environmentID), opts)
+}
+
+func (repo *EnvironmentRepository) listDeployments(
+	query *gorm.DB,
+	opts *repository.ListOptions,
+) ([]*models.Deployment, error) {
+	query, err := applyListOptions(query, opts, deploymentListColumns)
+
+	if err != nil {
+		return nil, err
 	}
 
 	depls := make([]*models.Deployment, 0)
@@ -243,7 +358,11 @@ func (repo *EnvironmentRepository) ListDeployments(environmentID uint, states ..
 		return nil, err
 	}
 
-	return depls, nil
+	n := trimPage(opts, deploymentListColumns, len(depls), func(i int) gorm.Model {
+		return depls[i].Model
+	})
+
+	return depls[:n], nil
 }
 
 func (repo *EnvironmentRepository) DeleteDeployment(deployment *models.Deployment) (*models.Deployment, error) {
@@ -252,3 +371,95 @@ func (repo *EnvironmentRepository) DeleteDeployment(deployment *models.Deploymen
 	}
 	return deployment, nil
 }
+
+// ListDeletedEnvironments lists the environments of a cluster deleted after a given time
+func (repo *EnvironmentRepository) ListDeletedEnvironments(
+	projectID, clusterID uint,
+	deletedAfter time.Time,
+) ([]*models.Environment, error) {
+	envs := make([]*models.Environment, 0)
+
+	if err := repo.db.Unscoped().Order("deleted_at desc").
+		Where("project_id = ? AND cluster_id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", projectID, clusterID, deletedAfter).
+		Find(&envs).Error; err != nil {
+		return nil, err
+	}
+
+	return envs, nil
+}
+
+// RestoreEnvironment restores an environment which was deleted after a given time. Its
+// deployments are not restored, since their namespaces are deleted with the environment.
+func (repo *EnvironmentRepository) RestoreEnvironment(
+	projectID, clusterID, envID uint,
+	deletedAfter time.Time,
+) (*models.Environment, error) {
+	res := repo.db.Unscoped().Model(&models.Environment{}).
+		Where("project_id = ? AND cluster_id = ? AND id = ? AND deleted_at IS NOT NULL AND deleted_at > ?",
+			projectID, clusterID, envID, deletedAfter).
+		Update("deleted_at", nil)
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.ReadEnvironmentByID(projectID, clusterID, envID)
+}
+
+func (repo *EnvironmentRepository) SearchDeployments(
+	projectIDs []uint,
+	query string,
+	limit int,
+) ([]*repository.DeploymentSearchResult, error) {
+	res := make([]*repository.DeploymentSearchResult, 0)
+
+	if len(projectIDs) == 0 {
+		return res, nil
+	}
+
+	cond, args := searchCondition(
+		query,
+		"deployments.namespace", "deployments.repo_name", "deployments.commit_sha",
+		"deployments.pr_name", "deployments.subdomain",
+	)
+
+	if err := repo.db.Model(&models.Deployment{}).
+		Select("deployments.*, environments.project_id, environments.cluster_id").
+		Joins("INNER JOIN environments ON environments.id = deployments.environment_id AND environments.deleted_at IS NULL").
+		Where("environments.project_id IN ?", projectIDs).
+		Where(cond, args...).
+		Order("deployments.updated_at desc").
+		Limit(limit).
+		Scan(&res).Error; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PurgeDeletedEnvironments permanently deletes environments, and their deployments, which
+// were deleted before a given time
+func (repo *EnvironmentRepository) PurgeDeletedEnvironments(deletedBefore time.Time) (int64, error) {
+	var count int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		subQuery := tx.Unscoped().Model(&models.Environment{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).Select("id")
+
+		if err := tx.Unscoped().Where("environment_id IN (?)", subQuery).Delete(&models.Deployment{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).Delete(&models.Environment{})
+
+		count = res.RowsAffected
+
+		return res.Error
+	})
+
+	return count, err
+}
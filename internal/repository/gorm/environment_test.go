@@ -0,0 +1,225 @@
+package gorm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+func TestUpdateDeploymentWithJobs(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_deployment_with_jobs.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	depl, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: 1,
+		Namespace:     "pr-1",
+		Status:        types.DeploymentStatusCreating,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	depl.Status = types.DeploymentStatusCreated
+
+	_, err = tester.repo.Environment().UpdateDeploymentWithJobs(depl, &models.BackgroundJob{
+		ProjectID: tester.initProjects[0].ID,
+		Kind:      "test-job",
+		Status:    types.BackgroundJobQueued,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	jobs, err := tester.repo.BackgroundJob().ListBackgroundJobs(tester.initProjects[0].ID, &repository.ListOptions{
+		Filters: []repository.Filter{{Field: "kind", Values: []string{"test-job"}}},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(jobs) != 1 {
+		t.Errorf("incorrect number of jobs: expected %d, got %d\n", 1, len(jobs))
+	}
+}
+
+func TestSearchDeployments(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_search_deployments.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: tester.initProjects[0].ID,
+		ClusterID: 1,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-42-porter",
+		CommitSHA:     "8f4c2e1",
+		RepoName:      "porter",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	res, err := tester.repo.Environment().SearchDeployments([]uint{tester.initProjects[0].ID}, "8F4C", 10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(res) != 1 {
+		t.Fatalf("incorrect number of search results: expected %d, got %d\n", 1, len(res))
+	}
+
+	if res[0].Namespace != "pr-42-porter" || res[0].ProjectID != tester.initProjects[0].ID || res[0].ClusterID != 1 {
+		t.Errorf("incorrect search result: %+v\n", res[0])
+	}
+}
+
+func TestReadEnvironmentAndDeployment(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_read_environment_and_deployment.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID:         tester.initProjects[0].ID,
+		ClusterID:         1,
+		GitInstallationID: 5,
+		GitRepoOwner:      "Porter-Dev",
+		GitRepoName:       "Porter",
+		Name:              "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	depl, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-7-porter",
+		PullRequestID: 7,
+		RepoOwner:     "Porter-Dev",
+		RepoName:      "Porter",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// repository owners and names are matched case insensitively
+	gotEnv, gotDepl, err := tester.repo.Environment().ReadEnvironmentAndDeployment(
+		tester.initProjects[0].ID, 1, 5, "porter-dev", "porter", 7, "",
+	)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if gotEnv.ID != env.ID || gotEnv.Name != "preview" {
+		t.Errorf("incorrect environment: expected %d, got %d\n", env.ID, gotEnv.ID)
+	}
+
+	if gotDepl.ID != depl.ID || gotDepl.Namespace != "pr-7-porter" || gotDepl.EnvironmentID != env.ID {
+		t.Errorf("incorrect deployment: expected %d, got %d\n", depl.ID, gotDepl.ID)
+	}
+
+	_, gotDepl, err = tester.repo.Environment().ReadEnvironmentAndDeployment(
+		tester.initProjects[0].ID, 1, 5, "Porter-Dev", "Porter", 0, "pr-7-porter",
+	)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if gotDepl.ID != depl.ID {
+		t.Errorf("incorrect deployment by namespace: expected %d, got %d\n", depl.ID, gotDepl.ID)
+	}
+
+	// a missing deployment returns the environment
+	gotEnv, gotDepl, err = tester.repo.Environment().ReadEnvironmentAndDeployment(
+		tester.initProjects[0].ID, 1, 5, "Porter-Dev", "Porter", 8, "",
+	)
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) || gotEnv == nil || gotEnv.ID != env.ID || gotDepl != nil {
+		t.Errorf("expected environment without deployment, got %v, %v, %v\n", gotEnv, gotDepl, err)
+	}
+
+	// a missing environment returns neither
+	gotEnv, _, err = tester.repo.Environment().ReadEnvironmentAndDeployment(
+		tester.initProjects[0].ID, 1, 5, "Porter-Dev", "other", 7, "",
+	)
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) || gotEnv != nil {
+		t.Errorf("expected no environment, got %v, %v\n", gotEnv, err)
+	}
+}
+
+func TestUpdateDeploymentFields(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_deployment_fields.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	depl, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: 1,
+		Namespace:     "pr-1",
+		Status:        types.DeploymentStatusCreating,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	depl.Status = types.DeploymentStatusFailed
+	depl.GHPRCommentID = 1234
+
+	_, err = tester.repo.Environment().UpdateDeploymentFields(depl, "GHPRCommentID")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	stored, err := tester.repo.Environment().ReadDeployment(1, "pr-1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if stored.GHPRCommentID != 1234 {
+		t.Errorf("incorrect comment id: expected %d, got %d\n", 1234, stored.GHPRCommentID)
+	}
+
+	if stored.Status != types.DeploymentStatusCreating {
+		t.Errorf("status should not have been updated: expected %s, got %s\n", types.DeploymentStatusCreating, stored.Status)
+	}
+}
@@ -0,0 +1,347 @@
+package gorm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ExportVersion is the version of the export format written by ExportDatabase
+const ExportVersion = 1
+
+// exportModels are the models included in an instance export, in the order they are imported
+var exportModels = []interface{}{
+	&models.User{},
+	&models.Project{},
+	&models.Role{},
+	&ints.KubeIntegration{},
+	&ints.BasicIntegration{},
+	&ints.OIDCIntegration{},
+	&ints.OAuthIntegration{},
+	&ints.GCPIntegration{},
+	&ints.AWSIntegration{},
+	&ints.AzureIntegration{},
+	&ints.GitlabIntegration{},
+	&ints.SlackIntegration{},
+	&ints.SlackRoutingRule{},
+	&ints.DiscordIntegration{},
+	&ints.TeamsIntegration{},
+	&ints.PagerDutyIntegration{},
+	&ints.DatadogIntegration{},
+	&ints.SentryIntegration{},
+	&ints.JiraIntegration{},
+	&ints.LinearIntegration{},
+	&ints.DopplerIntegration{},
+	&ints.GrafanaIntegration{},
+	&ints.DNSProviderIntegration{},
+	&ints.FeatureFlagIntegration{},
+	&models.WebhookSubscription{},
+	&models.EmailPreference{},
+	&models.NotificationPreference{},
+	&models.StatusPage{},
+	&models.StatusPageRelease{},
+	&ints.GithubAppInstallation{},
+	&ints.GithubAppOAuthIntegration{},
+	&models.Infra{},
+	&models.Cluster{},
+	&models.Registry{},
+	&models.HelmRepo{},
+	&models.GitRepo{},
+	&models.Environment{},
+	&models.Deployment{},
+	&models.EnvGroupSource{},
+	&models.EnvGroupRotationPolicy{},
+	&models.ExternalSecretStore{},
+	&models.CustomDomainDNSRecord{},
+	&models.WildcardCertificate{},
+	&models.ApprovalPolicy{},
+	&models.Approval{},
+	&models.FreezeWindow{},
+	&models.QueuedDeploy{},
+	&models.GitOpsExport{},
+	&models.WorkflowTemplate{},
+	&models.ClusterTunnel{},
+	&models.ClusterRelay{},
+	&models.DeployFeatureFlag{},
+	&models.LogAlertRule{},
+}
+
+var (
+	// ErrInvalidExport is returned when an export cannot be decrypted or decoded
+	ErrInvalidExport = errors.New("invalid export: the export is corrupted or the passphrase is incorrect")
+
+	// ErrImportConflict is returned when a table which is imported already contains rows
+	ErrImportConflict = errors.New("import conflict: tables must be empty before an export is imported")
+)
+
+// Export is a plaintext export of an instance, keyed by table. Encrypted values are
+// decrypted, so that they can be re-encrypted with the key of the instance which imports
+// the export. An export should only be stored or transferred after it has been encrypted
+// with EncodeExport.
+type Export struct {
+	Version   int                          `json:"version"`
+	CreatedAt time.Time                    `json:"created_at"`
+	Tables    map[string][]json.RawMessage `json:"tables"`
+}
+
+// ExportDatabase exports every project, user, integration, cluster, registry and environment
+// of the instance, including rows which are soft-deleted
+func ExportDatabase(db *gorm.DB, key *[32]byte) (*Export, error) {
+	res := &Export{
+		Version:   ExportVersion,
+		CreatedAt: time.Now(),
+		Tables:    make(map[string][]json.RawMessage),
+	}
+
+	for _, model := range exportModels {
+		s, encrypted, err := parseExportModel(db, model)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// tables which are not used by this installation are skipped
+		if !db.Migrator().HasTable(s.Table) {
+			continue
+		}
+
+		rows := reflect.New(reflect.SliceOf(s.ModelType))
+
+		if err := db.Unscoped().Model(model).Order("id asc").Find(rows.Interface()).Error; err != nil {
+			return nil, fmt.Errorf("could not read table %s: %w", s.Table, err)
+		}
+
+		exported := make([]json.RawMessage, 0, rows.Elem().Len())
+
+		for i := 0; i < rows.Elem().Len(); i++ {
+			row, err := exportRow(s, encrypted, rows.Elem().Index(i), key)
+
+			if err != nil {
+				return nil, fmt.Errorf("could not export table %s: %w", s.Table, err)
+			}
+
+			exported = append(exported, row)
+		}
+
+		res.Tables[s.Table] = exported
+	}
+
+	return res, nil
+}
+
+// ImportDatabase imports an export into the instance in a single transaction. Encrypted
+// values are encrypted with the given key, and rows keep their IDs, so every imported table
+// must be empty.
+//
+// The number of rows imported into each table is returned.
+func ImportDatabase(db *gorm.DB, key *[32]byte, export *Export) (map[string]int, error) {
+	if export.Version != ExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", export.Version)
+	}
+
+	res := make(map[string]int)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range exportModels {
+			s, encrypted, err := parseExportModel(tx, model)
+
+			if err != nil {
+				return err
+			}
+
+			rows := export.Tables[s.Table]
+
+			if len(rows) == 0 {
+				continue
+			}
+
+			if !tx.Migrator().HasTable(s.Table) {
+				return fmt.Errorf("table %s does not exist, migrations must be run before importing", s.Table)
+			}
+
+			var count int64
+
+			if err := tx.Unscoped().Model(model).Count(&count).Error; err != nil {
+				return err
+			}
+
+			if count > 0 {
+				return fmt.Errorf("%w: table %s has %d rows", ErrImportConflict, s.Table, count)
+			}
+
+			for _, raw := range rows {
+				row, err := importRow(s, encrypted, raw, key)
+
+				if err != nil {
+					return fmt.Errorf("could not import table %s: %w", s.Table, err)
+				}
+
+				if err := tx.Session(&gorm.Session{SkipHooks: true}).Omit(clause.Associations).Create(row).Error; err != nil {
+					return fmt.Errorf("could not import table %s: %w", s.Table, err)
+				}
+			}
+
+			// rows are created with their IDs, so the sequence of the table must be moved past them
+			if tx.Dialector.Name() == "postgres" {
+				if err := tx.Exec(fmt.Sprintf(
+					"SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), (SELECT MAX(id) FROM %[1]s))", s.Table,
+				)).Error; err != nil {
+					return err
+				}
+			}
+
+			res[s.Table] = len(rows)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// EncodeExport compresses an export and encrypts it with a key derived from the passphrase
+func EncodeExport(export *Export, passphrase string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+
+	if err := json.NewEncoder(zw).Encode(export); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return encryption.EncryptWithPassphrase(buf.Bytes(), passphrase)
+}
+
+// DecodeExport decrypts and decompresses an export encoded with EncodeExport
+func DecodeExport(data []byte, passphrase string) (*Export, error) {
+	plaintext, err := encryption.DecryptWithPassphrase(data, passphrase)
+
+	if err != nil {
+		return nil, ErrInvalidExport
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(plaintext))
+
+	if err != nil {
+		return nil, ErrInvalidExport
+	}
+
+	res := &Export{}
+
+	if err := json.NewDecoder(zr).Decode(res); err != nil {
+		return nil, ErrInvalidExport
+	}
+
+	return res, nil
+}
+
+// parseExportModel returns the schema of a model and its encrypted columns
+func parseExportModel(db *gorm.DB, model interface{}) (*schema.Schema, map[string]bool, error) {
+	stmt := &gorm.Statement{DB: db}
+
+	if err := stmt.Parse(model); err != nil {
+		return nil, nil, err
+	}
+
+	encrypted := make(map[string]bool)
+
+	for _, ef := range encryptedFields {
+		if reflect.TypeOf(ef.model) != reflect.TypeOf(model) {
+			continue
+		}
+
+		for _, name := range ef.fields {
+			field := stmt.Schema.LookUpField(name)
+
+			if field == nil {
+				return nil, nil, fmt.Errorf("field %s not found on table %s", name, stmt.Schema.Table)
+			}
+
+			encrypted[field.DBName] = true
+		}
+	}
+
+	return stmt.Schema, encrypted, nil
+}
+
+func exportRow(s *schema.Schema, encrypted map[string]bool, rv reflect.Value, key *[32]byte) (json.RawMessage, error) {
+	row := make(map[string]interface{})
+
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+
+		value := field.ReflectValueOf(rv).Interface()
+
+		if ciphertext, ok := value.([]byte); ok && encrypted[field.DBName] && len(ciphertext) > 0 {
+			plaintext, err := encryption.Decrypt(ciphertext, key)
+
+			if err != nil {
+				return nil, fmt.Errorf("could not decrypt column %s: %w", field.DBName, err)
+			}
+
+			value = plaintext
+		}
+
+		row[field.DBName] = value
+	}
+
+	return json.Marshal(row)
+}
+
+func importRow(s *schema.Schema, encrypted map[string]bool, raw json.RawMessage, key *[32]byte) (interface{}, error) {
+	columns := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(raw, &columns); err != nil {
+		return nil, err
+	}
+
+	row := reflect.New(s.ModelType)
+
+	for _, field := range s.Fields {
+		data, ok := columns[field.DBName]
+
+		if field.DBName == "" || !ok {
+			continue
+		}
+
+		value := reflect.New(field.FieldType)
+
+		if err := json.Unmarshal(data, value.Interface()); err != nil {
+			return nil, fmt.Errorf("could not decode column %s: %w", field.DBName, err)
+		}
+
+		if plaintext, ok := value.Elem().Interface().([]byte); ok && encrypted[field.DBName] && len(plaintext) > 0 {
+			ciphertext, err := encryption.Encrypt(plaintext, key)
+
+			if err != nil {
+				return nil, err
+			}
+
+			value.Elem().SetBytes(ciphertext)
+		}
+
+		field.ReflectValueOf(row.Elem()).Set(value.Elem())
+	}
+
+	return row.Interface(), nil
+}
@@ -0,0 +1,105 @@
+package gorm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/gorm"
+)
+
+func TestExportImportDatabase(t *testing.T) {
+	source := &tester{
+		dbFileName: "./porter_export_source.db",
+	}
+
+	setupTestEnv(source, t)
+	initUser(source, t)
+	initProject(source, t)
+	initProjectRole(source, t)
+	initKubeIntegration(source, t)
+	defer cleanup(source, t)
+
+	_, err := source.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: source.initProjects[0].ID,
+		ClusterID: 1,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	export, err := gorm.ExportDatabase(source.db, source.key)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	data, err := gorm.EncodeExport(export, "correct horse battery staple")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := gorm.DecodeExport(data, "incorrect passphrase"); !errors.Is(err, gorm.ErrInvalidExport) {
+		t.Errorf("expected invalid export error with the wrong passphrase, got %v\n", err)
+	}
+
+	decoded, err := gorm.DecodeExport(data, "correct horse battery staple")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	target := &tester{
+		dbFileName: "./porter_export_target.db",
+	}
+
+	setupTestEnv(target, t)
+	defer cleanup(target, t)
+
+	var newKey [32]byte
+
+	for i, b := range []byte("__new_strong_encryption_key_____") {
+		newKey[i] = b
+	}
+
+	counts, err := gorm.ImportDatabase(target.db, &newKey, decoded)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if counts["projects"] != 1 || counts["users"] != 1 || counts["roles"] != 1 || counts["environments"] != 1 {
+		t.Errorf("incorrect import counts: got %v\n", counts)
+	}
+
+	repo := gorm.NewRepository(target.db, &newKey, nil)
+
+	project, err := repo.Project().ReadProject(source.initProjects[0].ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if project.Name != source.initProjects[0].Name {
+		t.Errorf("incorrect project name: expected %s, got %s\n", source.initProjects[0].Name, project.Name)
+	}
+
+	// integrations are re-encrypted with the key of the importing instance
+	ki, err := repo.KubeIntegration().ReadKubeIntegration(source.initProjects[0].ID, source.initKIs[0].ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(ki.Kubeconfig) != "current-context: testing\n" {
+		t.Errorf("incorrect kubeconfig: expected %s, got %s\n", "current-context: testing\n", ki.Kubeconfig)
+	}
+
+	// importing into tables which already have rows fails
+	if _, err := gorm.ImportDatabase(target.db, &newKey, decoded); !errors.Is(err, gorm.ErrImportConflict) {
+		t.Errorf("expected import conflict, got %v\n", err)
+	}
+}
@@ -0,0 +1,44 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ExternalSecretStoreRepository uses gorm.DB for querying the database
+type ExternalSecretStoreRepository struct {
+	db *gorm.DB
+}
+
+// NewExternalSecretStoreRepository returns an ExternalSecretStoreRepository which uses
+// gorm.DB for querying the database
+func NewExternalSecretStoreRepository(db *gorm.DB) repository.ExternalSecretStoreRepository {
+	return &ExternalSecretStoreRepository{db}
+}
+
+func (repo *ExternalSecretStoreRepository) CreateOrUpdateExternalSecretStore(
+	store *models.ExternalSecretStore,
+) (*models.ExternalSecretStore, error) {
+	if err := repo.db.Save(store).Error; err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (repo *ExternalSecretStoreRepository) ReadExternalSecretStore(projectID uint) (*models.ExternalSecretStore, error) {
+	store := &models.ExternalSecretStore{}
+
+	if err := repo.db.Where("project_id = ?", projectID).First(store).Error; err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// DeleteExternalSecretStore deletes the store permanently, since the unique index on the project
+// would otherwise prevent configuring a store again
+func (repo *ExternalSecretStoreRepository) DeleteExternalSecretStore(store *models.ExternalSecretStore) error {
+	return repo.db.Unscoped().Delete(store).Error
+}
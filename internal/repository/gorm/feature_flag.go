@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// FeatureFlagIntegrationRepository uses gorm.DB for querying the database
+type FeatureFlagIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewFeatureFlagIntegrationRepository returns a FeatureFlagIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewFeatureFlagIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.FeatureFlagIntegrationRepository {
+	return &FeatureFlagIntegrationRepository{db, key}
+}
+
+// CreateFeatureFlagIntegration creates a new feature flag integration
+func (repo *FeatureFlagIntegrationRepository) CreateFeatureFlagIntegration(
+	ffInt *ints.FeatureFlagIntegration,
+) (*ints.FeatureFlagIntegration, error) {
+	apiToken := ffInt.APIToken
+
+	cipherData, err := encryption.Encrypt(apiToken, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ffInt.APIToken = cipherData
+
+	if err := repo.db.Create(ffInt).Error; err != nil {
+		return nil, err
+	}
+
+	ffInt.APIToken = apiToken
+
+	return ffInt, nil
+}
+
+// ReadFeatureFlagIntegration finds a feature flag integration of a project by its ID
+func (repo *FeatureFlagIntegrationRepository) ReadFeatureFlagIntegration(
+	projectID, integrationID uint,
+) (*ints.FeatureFlagIntegration, error) {
+	ffInt := &ints.FeatureFlagIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(ffInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAPIToken(ffInt); err != nil {
+		return nil, err
+	}
+
+	return ffInt, nil
+}
+
+// ListFeatureFlagIntegrationsByProjectID finds all feature flag integrations of a project
+func (repo *FeatureFlagIntegrationRepository) ListFeatureFlagIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.FeatureFlagIntegration, error) {
+	ffInts := []*ints.FeatureFlagIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&ffInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, ffInt := range ffInts {
+		if err := repo.decryptAPIToken(ffInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return ffInts, nil
+}
+
+// DeleteFeatureFlagIntegration deletes a feature flag integration
+func (repo *FeatureFlagIntegrationRepository) DeleteFeatureFlagIntegration(
+	ffInt *ints.FeatureFlagIntegration,
+) error {
+	return repo.db.Delete(ffInt).Error
+}
+
+func (repo *FeatureFlagIntegrationRepository) decryptAPIToken(ffInt *ints.FeatureFlagIntegration) error {
+	if len(ffInt.APIToken) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(ffInt.APIToken, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	ffInt.APIToken = plaintext
+
+	return nil
+}
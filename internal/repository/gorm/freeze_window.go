@@ -0,0 +1,50 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// FreezeWindowRepository uses gorm.DB for querying the database
+type FreezeWindowRepository struct {
+	db *gorm.DB
+}
+
+// NewFreezeWindowRepository returns a FreezeWindowRepository which uses gorm.DB for querying
+// the database
+func NewFreezeWindowRepository(db *gorm.DB) repository.FreezeWindowRepository {
+	return &FreezeWindowRepository{db}
+}
+
+func (repo *FreezeWindowRepository) CreateFreezeWindow(window *models.FreezeWindow) (*models.FreezeWindow, error) {
+	if err := repo.db.Create(window).Error; err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (repo *FreezeWindowRepository) ReadFreezeWindow(projectID, id uint) (*models.FreezeWindow, error) {
+	window := &models.FreezeWindow{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(window).Error; err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (repo *FreezeWindowRepository) ListFreezeWindows(projectID uint) ([]*models.FreezeWindow, error) {
+	windows := make([]*models.FreezeWindow, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&windows).Error; err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func (repo *FreezeWindowRepository) DeleteFreezeWindow(window *models.FreezeWindow) error {
+	return repo.db.Delete(window).Error
+}
@@ -0,0 +1,49 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// GitOpsExportRepository uses gorm.DB for querying the database
+type GitOpsExportRepository struct {
+	db *gorm.DB
+}
+
+// NewGitOpsExportRepository returns a GitOpsExportRepository which uses gorm.DB for querying
+// the database
+func NewGitOpsExportRepository(db *gorm.DB) repository.GitOpsExportRepository {
+	return &GitOpsExportRepository{db}
+}
+
+func (repo *GitOpsExportRepository) CreateGitOpsExport(export *models.GitOpsExport) (*models.GitOpsExport, error) {
+	if err := repo.db.Create(export).Error; err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func (repo *GitOpsExportRepository) ReadGitOpsExportByStackID(stackID uint) (*models.GitOpsExport, error) {
+	export := &models.GitOpsExport{}
+
+	if err := repo.db.Where("stack_id = ?", stackID).First(export).Error; err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func (repo *GitOpsExportRepository) UpdateGitOpsExport(export *models.GitOpsExport) (*models.GitOpsExport, error) {
+	if err := repo.db.Save(export).Error; err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// DeleteGitOpsExport hard-deletes the export, so that the stack can be exported again
+func (repo *GitOpsExportRepository) DeleteGitOpsExport(export *models.GitOpsExport) error {
+	return repo.db.Unscoped().Delete(export).Error
+}
@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// GrafanaIntegrationRepository uses gorm.DB for querying the database
+type GrafanaIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewGrafanaIntegrationRepository returns a GrafanaIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewGrafanaIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.GrafanaIntegrationRepository {
+	return &GrafanaIntegrationRepository{db, key}
+}
+
+// CreateGrafanaIntegration creates a new Grafana integration
+func (repo *GrafanaIntegrationRepository) CreateGrafanaIntegration(
+	grafanaInt *ints.GrafanaIntegration,
+) (*ints.GrafanaIntegration, error) {
+	apiKey := grafanaInt.APIKey
+
+	cipherData, err := encryption.Encrypt(apiKey, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	grafanaInt.APIKey = cipherData
+
+	if err := repo.db.Create(grafanaInt).Error; err != nil {
+		return nil, err
+	}
+
+	grafanaInt.APIKey = apiKey
+
+	return grafanaInt, nil
+}
+
+// ReadGrafanaIntegration finds a Grafana integration of a project by its ID
+func (repo *GrafanaIntegrationRepository) ReadGrafanaIntegration(
+	projectID, integrationID uint,
+) (*ints.GrafanaIntegration, error) {
+	grafanaInt := &ints.GrafanaIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(grafanaInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAPIKey(grafanaInt); err != nil {
+		return nil, err
+	}
+
+	return grafanaInt, nil
+}
+
+// ListGrafanaIntegrationsByProjectID finds all Grafana integrations of a project
+func (repo *GrafanaIntegrationRepository) ListGrafanaIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.GrafanaIntegration, error) {
+	grafanaInts := []*ints.GrafanaIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&grafanaInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, grafanaInt := range grafanaInts {
+		if err := repo.decryptAPIKey(grafanaInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return grafanaInts, nil
+}
+
+// DeleteGrafanaIntegration deletes a Grafana integration
+func (repo *GrafanaIntegrationRepository) DeleteGrafanaIntegration(
+	grafanaInt *ints.GrafanaIntegration,
+) error {
+	return repo.db.Delete(grafanaInt).Error
+}
+
+func (repo *GrafanaIntegrationRepository) decryptAPIKey(grafanaInt *ints.GrafanaIntegration) error {
+	if len(grafanaInt.APIKey) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(grafanaInt.APIKey, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	grafanaInt.APIKey = plaintext
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestGrafanaIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_grafana.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	apiKey := "glsa_Xb2Kd9Fq4Lm7Np1Rt6Vw3Yz8Ac5Eg0Hj_4f1e9a2c"
+
+	grafanaInt, err := tester.repo.GrafanaIntegration().CreateGrafanaIntegration(&ints.GrafanaIntegration{
+		ProjectID: projectID,
+		Name:      "acme",
+		URL:       "https://acme.grafana.net",
+		APIKey:    []byte(apiKey),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the api key should be encrypted at rest, and decrypted when read
+	stored := &ints.GrafanaIntegration{}
+
+	if err := tester.db.First(stored, grafanaInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.APIKey) == apiKey {
+		t.Errorf("api key was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.GrafanaIntegration().ReadGrafanaIntegration(projectID, grafanaInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.APIKey) != apiKey {
+		t.Errorf("incorrect api key: expected %s, got %s\n", apiKey, readInt.APIKey)
+	}
+
+	grafanaInts, err := tester.repo.GrafanaIntegration().ListGrafanaIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(grafanaInts) != 1 || string(grafanaInts[0].APIKey) != apiKey {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted api key\n")
+	}
+
+	if err := tester.repo.GrafanaIntegration().DeleteGrafanaIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.GrafanaIntegration().ReadGrafanaIntegration(projectID, grafanaInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
@@ -79,6 +79,10 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.Onboarding{},
 		&models.Allowlist{},
 		&models.Tag{},
+		&models.AuditLog{},
+		&models.BackgroundJob{},
+		&models.ArchivedDeployment{},
+		&models.ArchivedKubeEvent{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -89,6 +93,50 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&ints.RegTokenCache{},
 		&ints.HelmRepoTokenCache{},
 		&ints.GithubAppInstallation{},
+		&ints.SlackIntegration{},
+		&ints.SlackRoutingRule{},
+		&ints.DiscordIntegration{},
+		&ints.TeamsIntegration{},
+		&ints.PagerDutyIntegration{},
+		&ints.DatadogIntegration{},
+		&ints.SentryIntegration{},
+		&ints.JiraIntegration{},
+		&ints.LinearIntegration{},
+		&ints.DopplerIntegration{},
+		&ints.GrafanaIntegration{},
+		&ints.DNSProviderIntegration{},
+		&ints.FeatureFlagIntegration{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.EmailPreference{},
+		&models.NotificationPreference{},
+		&models.ClusterIncident{},
+		&models.StatusPage{},
+		&models.StatusPageRelease{},
+		&models.EnvGroupSource{},
+		&models.EnvGroupRotationPolicy{},
+		&models.ExternalSecretStore{},
+		&models.DeploymentRecord{},
+		&models.Build{},
+		&models.ProjectUsageRecord{},
+		&models.CostRecord{},
+		&models.MultiClusterRelease{},
+		&models.MultiClusterReleaseTarget{},
+		&models.MultiClusterRollout{},
+		&models.MultiClusterRolloutTarget{},
+		&models.CustomDomainDNSRecord{},
+		&models.WildcardCertificate{},
+		&models.ApprovalPolicy{},
+		&models.Approval{},
+		&models.FreezeWindow{},
+		&models.QueuedDeploy{},
+		&models.GitOpsExport{},
+		&models.WorkflowTemplate{},
+		&models.ClusterTunnel{},
+		&models.ClusterRelay{},
+		&models.DeployFeatureFlag{},
+		&models.LogAlertRule{},
+		&models.LeaderLease{},
 	)
 
 	if err != nil {
@@ -0,0 +1,38 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ImageSignaturePolicyRepository uses gorm.DB for querying the database
+type ImageSignaturePolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewImageSignaturePolicyRepository returns an ImageSignaturePolicyRepository which uses
+// gorm.DB for querying the database
+func NewImageSignaturePolicyRepository(db *gorm.DB) repository.ImageSignaturePolicyRepository {
+	return &ImageSignaturePolicyRepository{db}
+}
+
+func (repo *ImageSignaturePolicyRepository) CreateOrUpdateImageSignaturePolicy(
+	policy *models.ImageSignaturePolicy,
+) (*models.ImageSignaturePolicy, error) {
+	if err := repo.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *ImageSignaturePolicyRepository) ReadImageSignaturePolicy(projectID uint) (*models.ImageSignaturePolicy, error) {
+	policy := &models.ImageSignaturePolicy{}
+
+	if err := repo.db.Where("project_id = ?", projectID).First(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
@@ -72,25 +72,50 @@ func (repo *InfraRepository) ReadInfra(projectID, infraID uint) (*models.Infra,
 	return infra, nil
 }
 
+var infraListColumns = &listColumns{
+	table: "infras",
+	filters: map[string]string{
+		"kind":   "kind",
+		"status": "status",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "updated_at",
+	defaultOrder: repository.SortDesc,
+}
+
 // ListInfrasByProjectID finds all aws infras
 // for a given project id
 func (repo *InfraRepository) ListInfrasByProjectID(
 	projectID uint,
 	apiVersion string,
+	opts *repository.ListOptions,
 ) ([]*models.Infra, error) {
 
 	infras := []*models.Infra{}
 
-	query := repo.db.Where("project_id = ?", projectID).Order("updated_at desc")
+	query := repo.db.Where("project_id = ?", projectID)
 
 	if apiVersion != "" {
 		query = query.Where("api_version = ?", apiVersion)
 	}
 
+	query, err := applyListOptions(query, opts, infraListColumns)
+
+	if err != nil {
+		return nil, err
+	}
+
 	if err := query.Find(&infras).Error; err != nil {
 		return nil, err
 	}
 
+	infras = infras[:trimPage(opts, infraListColumns, len(infras), func(i int) gorm.Model {
+		return infras[i].Model
+	})]
+
 	infraIDs := make([]uint, 0)
 
 	for _, infra := range infras {
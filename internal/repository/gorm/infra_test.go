@@ -1,6 +1,7 @@
 package gorm_test
 
 import (
+	"errors"
 	"testing"
 
 	"gorm.io/gorm"
@@ -8,6 +9,7 @@ import (
 	"github.com/go-test/deep"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
 func TestCreateInfra(t *testing.T) {
@@ -64,6 +66,7 @@ func TestListInfrasByProjectID(t *testing.T) {
 	infras, err := tester.repo.Infra().ListInfrasByProjectID(
 		tester.initProjects[0].Model.ID,
 		"",
+		nil,
 	)
 
 	if err != nil {
@@ -91,3 +94,92 @@ func TestListInfrasByProjectID(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestListInfrasByProjectIDWithListOptions(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_aws_infras_options.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	for i := 0; i < 5; i++ {
+		kind := types.InfraECR
+
+		if i%2 == 1 {
+			kind = types.InfraEKS
+		}
+
+		_, err := tester.repo.Infra().CreateInfra(&models.Infra{
+			Kind:      kind,
+			ProjectID: tester.initProjects[0].Model.ID,
+			Status:    types.StatusCreated,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	// read every page in ascending order of creation
+	opts := &repository.ListOptions{
+		SortBy: "created_at",
+		Order:  repository.SortAsc,
+		Limit:  2,
+	}
+
+	ids := make([]uint, 0)
+	pages := 0
+
+	for {
+		infras, err := tester.repo.Infra().ListInfrasByProjectID(tester.initProjects[0].Model.ID, "", opts)
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		pages++
+
+		for _, infra := range infras {
+			ids = append(ids, infra.ID)
+		}
+
+		if opts.NextCursor == "" {
+			break
+		}
+
+		opts.Cursor = opts.NextCursor
+	}
+
+	if diff := deep.Equal(ids, []uint{1, 2, 3, 4, 5}); diff != nil {
+		t.Errorf("incorrect infra ids")
+		t.Error(diff)
+	}
+
+	if pages != 3 {
+		t.Errorf("incorrect number of pages: expected %d, got %d\n", 3, pages)
+	}
+
+	// filter by kind
+	infras, err := tester.repo.Infra().ListInfrasByProjectID(tester.initProjects[0].Model.ID, "", &repository.ListOptions{
+		Filters: []repository.Filter{{Field: "kind", Values: []string{string(types.InfraEKS)}}},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(infras) != 2 {
+		t.Errorf("incorrect number of eks infras: expected %d, got %d\n", 2, len(infras))
+	}
+
+	// an invalid cursor should be rejected
+	_, err = tester.repo.Infra().ListInfrasByProjectID(tester.initProjects[0].Model.ID, "", &repository.ListOptions{
+		Cursor: "not-a-cursor",
+	})
+
+	if !errors.Is(err, repository.ErrInvalidListOptions) {
+		t.Errorf("expected invalid list options error, got %v\n", err)
+	}
+}
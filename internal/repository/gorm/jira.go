@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// JiraIntegrationRepository uses gorm.DB for querying the database
+type JiraIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewJiraIntegrationRepository returns a JiraIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewJiraIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.JiraIntegrationRepository {
+	return &JiraIntegrationRepository{db, key}
+}
+
+// CreateJiraIntegration creates a new Jira integration
+func (repo *JiraIntegrationRepository) CreateJiraIntegration(
+	jiraInt *ints.JiraIntegration,
+) (*ints.JiraIntegration, error) {
+	authToken := jiraInt.APIToken
+
+	cipherData, err := encryption.Encrypt(authToken, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	jiraInt.APIToken = cipherData
+
+	if err := repo.db.Create(jiraInt).Error; err != nil {
+		return nil, err
+	}
+
+	jiraInt.APIToken = authToken
+
+	return jiraInt, nil
+}
+
+// ReadJiraIntegration finds a Jira integration of a project by its ID
+func (repo *JiraIntegrationRepository) ReadJiraIntegration(
+	projectID, integrationID uint,
+) (*ints.JiraIntegration, error) {
+	jiraInt := &ints.JiraIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(jiraInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAPIToken(jiraInt); err != nil {
+		return nil, err
+	}
+
+	return jiraInt, nil
+}
+
+// ListJiraIntegrationsByProjectID finds all Jira integrations of a project
+func (repo *JiraIntegrationRepository) ListJiraIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.JiraIntegration, error) {
+	jiraInts := []*ints.JiraIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&jiraInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, jiraInt := range jiraInts {
+		if err := repo.decryptAPIToken(jiraInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return jiraInts, nil
+}
+
+// DeleteJiraIntegration deletes a Jira integration
+func (repo *JiraIntegrationRepository) DeleteJiraIntegration(
+	jiraInt *ints.JiraIntegration,
+) error {
+	return repo.db.Delete(jiraInt).Error
+}
+
+func (repo *JiraIntegrationRepository) decryptAPIToken(jiraInt *ints.JiraIntegration) error {
+	if len(jiraInt.APIToken) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(jiraInt.APIToken, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	jiraInt.APIToken = plaintext
+
+	return nil
+}
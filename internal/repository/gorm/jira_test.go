@@ -0,0 +1,76 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestJiraIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_jira.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	apiToken := "ATATT3xFfGF0a8d2c4f61e0b9735d2c4a8f61e0b9735"
+
+	jiraInt, err := tester.repo.JiraIntegration().CreateJiraIntegration(&ints.JiraIntegration{
+		ProjectID:       projectID,
+		Name:            "acme",
+		BaseURL:         "https://acme.atlassian.net",
+		Email:           "deploys@acme.com",
+		JiraProjectKeys: "WEB,API",
+		APIToken:        []byte(apiToken),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the api token should be encrypted at rest, and decrypted when read
+	stored := &ints.JiraIntegration{}
+
+	if err := tester.db.First(stored, jiraInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.APIToken) == apiToken {
+		t.Errorf("api token was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.JiraIntegration().ReadJiraIntegration(projectID, jiraInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.APIToken) != apiToken {
+		t.Errorf("incorrect api token: expected %s, got %s\n", apiToken, readInt.APIToken)
+	}
+
+	if keys := readInt.GetJiraProjectKeys(); len(keys) != 2 || keys[0] != "WEB" || keys[1] != "API" {
+		t.Errorf("incorrect jira project keys: expected [WEB API], got %v\n", keys)
+	}
+
+	jiraInts, err := tester.repo.JiraIntegration().ListJiraIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(jiraInts) != 1 || string(jiraInts[0].APIToken) != apiToken {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted api token\n")
+	}
+
+	if err := tester.repo.JiraIntegration().DeleteJiraIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.JiraIntegration().ReadJiraIntegration(projectID, jiraInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
@@ -0,0 +1,71 @@
+package gorm
+
+import (
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaderLeaseRepository uses gorm.DB for querying the database
+type LeaderLeaseRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaderLeaseRepository returns a LeaderLeaseRepository which uses
+// gorm.DB for querying the database
+func NewLeaderLeaseRepository(db *gorm.DB) repository.LeaderLeaseRepository {
+	return &LeaderLeaseRepository{db}
+}
+
+// AcquireLeaderLease creates the lease if it doesn't exist yet, and then takes it over if it's
+// held by the holder or has expired. The update is conditional, so only one of the holders
+// which acquire an expired lease at the same time acquires it.
+func (repo *LeaderLeaseRepository) AcquireLeaderLease(name, holder string, now, expiresAt time.Time) (bool, error) {
+	err := repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.LeaderLease{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: now,
+	}).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	res := repo.db.Model(&models.LeaderLease{}).
+		Where("name = ? AND (holder = ? OR expires_at <= ?)", name, holder, now).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": expiresAt,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (repo *LeaderLeaseRepository) ReleaseLeaderLease(name, holder string, now time.Time) error {
+	return repo.db.Model(&models.LeaderLease{}).
+		Where("name = ? AND holder = ? AND expires_at > ?", name, holder, now).
+		Update("expires_at", now).Error
+}
+
+func (repo *LeaderLeaseRepository) ListActiveLeaderLeases(prefix string, now time.Time) ([]*models.LeaderLease, error) {
+	leases := make([]*models.LeaderLease, 0)
+
+	// the prefix is escaped, since lease names can contain the wildcards of LIKE
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+
+	if err := repo.db.Where(
+		`name LIKE ? ESCAPE '\' AND expires_at > ?`, escaped+"%", now,
+	).Order("name asc").Find(&leases).Error; err != nil {
+		return nil, err
+	}
+
+	return leases, nil
+}
@@ -0,0 +1,83 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcquireLeaderLease(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_acquire_leader_lease.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	repo := tester.repo.LeaderLease()
+	now := time.Now().UTC()
+
+	held, err := repo.AcquireLeaderLease("leader/test", "replica-1", now, now.Add(time.Minute))
+
+	if err != nil || !held {
+		t.Fatalf("expected replica-1 to acquire the lease, got %t, %v\n", held, err)
+	}
+
+	// the lease is held by replica-1 until it expires
+	held, err = repo.AcquireLeaderLease("leader/test", "replica-2", now.Add(30*time.Second), now.Add(90*time.Second))
+
+	if err != nil || held {
+		t.Fatalf("expected replica-2 not to acquire the lease, got %t, %v\n", held, err)
+	}
+
+	held, err = repo.AcquireLeaderLease("leader/test", "replica-1", now.Add(30*time.Second), now.Add(90*time.Second))
+
+	if err != nil || !held {
+		t.Fatalf("expected replica-1 to renew the lease, got %t, %v\n", held, err)
+	}
+
+	held, err = repo.AcquireLeaderLease("leader/test", "replica-2", now.Add(2*time.Minute), now.Add(3*time.Minute))
+
+	if err != nil || !held {
+		t.Fatalf("expected replica-2 to acquire the expired lease, got %t, %v\n", held, err)
+	}
+
+	// a replica which doesn't hold the lease can't release it
+	if err := repo.ReleaseLeaderLease("leader/test", "replica-1", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leases, err := repo.ListActiveLeaderLeases("leader/", now.Add(2*time.Minute))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(leases) != 1 || leases[0].Holder != "replica-2" {
+		t.Fatalf("expected the lease to be held by replica-2, got %v\n", leases)
+	}
+
+	if err := repo.ReleaseLeaderLease("leader/test", "replica-2", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	held, err = repo.AcquireLeaderLease("leader/test", "replica-1", now.Add(2*time.Minute), now.Add(3*time.Minute))
+
+	if err != nil || !held {
+		t.Fatalf("expected replica-1 to acquire the released lease, got %t, %v\n", held, err)
+	}
+
+	// leases with another prefix are not listed
+	if _, err := repo.AcquireLeaderLease("member/replica-1", "replica-1", now, now.Add(time.Minute)); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leases, err = repo.ListActiveLeaderLeases("member/", now)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(leases) != 1 || leases[0].Name != "member/replica-1" {
+		t.Errorf("expected only the member lease to be listed, got %v\n", leases)
+	}
+}
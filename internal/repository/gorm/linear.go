@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// LinearIntegrationRepository uses gorm.DB for querying the database
+type LinearIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewLinearIntegrationRepository returns a LinearIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewLinearIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.LinearIntegrationRepository {
+	return &LinearIntegrationRepository{db, key}
+}
+
+// CreateLinearIntegration creates a new Linear integration
+func (repo *LinearIntegrationRepository) CreateLinearIntegration(
+	linearInt *ints.LinearIntegration,
+) (*ints.LinearIntegration, error) {
+	apiKey := linearInt.APIKey
+
+	cipherData, err := encryption.Encrypt(apiKey, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	linearInt.APIKey = cipherData
+
+	if err := repo.db.Create(linearInt).Error; err != nil {
+		return nil, err
+	}
+
+	linearInt.APIKey = apiKey
+
+	return linearInt, nil
+}
+
+// ReadLinearIntegration finds a Linear integration of a project by its ID
+func (repo *LinearIntegrationRepository) ReadLinearIntegration(
+	projectID, integrationID uint,
+) (*ints.LinearIntegration, error) {
+	linearInt := &ints.LinearIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(linearInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAPIKey(linearInt); err != nil {
+		return nil, err
+	}
+
+	return linearInt, nil
+}
+
+// ListLinearIntegrationsByProjectID finds all Linear integrations of a project
+func (repo *LinearIntegrationRepository) ListLinearIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.LinearIntegration, error) {
+	linearInts := []*ints.LinearIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&linearInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, linearInt := range linearInts {
+		if err := repo.decryptAPIKey(linearInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return linearInts, nil
+}
+
+// DeleteLinearIntegration deletes a Linear integration
+func (repo *LinearIntegrationRepository) DeleteLinearIntegration(
+	linearInt *ints.LinearIntegration,
+) error {
+	return repo.db.Delete(linearInt).Error
+}
+
+func (repo *LinearIntegrationRepository) decryptAPIKey(linearInt *ints.LinearIntegration) error {
+	if len(linearInt.APIKey) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(linearInt.APIKey, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	linearInt.APIKey = plaintext
+
+	return nil
+}
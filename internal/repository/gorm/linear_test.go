@@ -0,0 +1,69 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestLinearIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_linear.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	apiKey := "lin_api_a8d2c4f61e0b9735d2c4a8f61e0b9735d2c4a8f6"
+
+	linearInt, err := tester.repo.LinearIntegration().CreateLinearIntegration(&ints.LinearIntegration{
+		ProjectID: projectID,
+		Name:      "acme",
+		APIKey:    []byte(apiKey),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the api key should be encrypted at rest, and decrypted when read
+	stored := &ints.LinearIntegration{}
+
+	if err := tester.db.First(stored, linearInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.APIKey) == apiKey {
+		t.Errorf("api key was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.LinearIntegration().ReadLinearIntegration(projectID, linearInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.APIKey) != apiKey {
+		t.Errorf("incorrect api key: expected %s, got %s\n", apiKey, readInt.APIKey)
+	}
+
+	linearInts, err := tester.repo.LinearIntegration().ListLinearIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(linearInts) != 1 || string(linearInts[0].APIKey) != apiKey {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted api key\n")
+	}
+
+	if err := tester.repo.LinearIntegration().DeleteLinearIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.LinearIntegration().ReadLinearIntegration(projectID, linearInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
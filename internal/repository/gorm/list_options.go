@@ -0,0 +1,161 @@
+package gorm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// listColumns are the columns which a List* query can filter and sort on, keyed by the
+// field name used in repository.ListOptions
+type listColumns struct {
+	table string
+
+	filters map[string]string
+
+	// results are always sorted by a timestamp column, with the id as a tie-breaker, so that
+	// the cursor of a page can be encoded from its last result
+	sorts map[string]string
+
+	defaultSort  string
+	defaultOrder repository.SortOrder
+}
+
+// cursor is the position of the last result of a page
+type cursor struct {
+	SortValue time.Time `json:"v"`
+	ID        uint      `json:"id"`
+}
+
+// applyListOptions adds the filters, sort order and cursor of a set of list options to a query.
+// The query fetches one result more than the limit, which is used by trimPage to find if
+// there is a next page.
+func applyListOptions(query *gorm.DB, opts *repository.ListOptions, cols *listColumns) (*gorm.DB, error) {
+	if opts == nil {
+		opts = &repository.ListOptions{}
+	}
+
+	for _, filter := range opts.Filters {
+		column, ok := cols.filters[filter.Field]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot filter on field %s", repository.ErrInvalidListOptions, filter.Field)
+		}
+
+		if len(filter.Values) > 0 {
+			query = query.Where(fmt.Sprintf("%s.%s IN ?", cols.table, column), filter.Values)
+		}
+	}
+
+	sortColumn, order, err := getSort(opts, cols)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+
+		if err != nil {
+			return nil, err
+		}
+
+		op := ">"
+
+		if order == repository.SortDesc {
+			op = "<"
+		}
+
+		query = query.Where(
+			fmt.Sprintf("((%[1]s.%[2]s %[3]s ?) OR (%[1]s.%[2]s = ? AND %[1]s.id %[3]s ?))", cols.table, sortColumn, op),
+			c.SortValue, c.SortValue, c.ID,
+		)
+	}
+
+	query = query.Order(fmt.Sprintf("%s.%s %s", cols.table, sortColumn, order)).
+		Order(fmt.Sprintf("%s.id %s", cols.table, order))
+
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit + 1)
+	}
+
+	return query, nil
+}
+
+// trimPage returns the number of results which belong to the page, and sets the next cursor
+// of the list options from the last result of the page. The timestamps and id of a result
+// are read with getModel.
+func trimPage(opts *repository.ListOptions, cols *listColumns, count int, getModel func(i int) gorm.Model) int {
+	if opts == nil {
+		return count
+	}
+
+	opts.NextCursor = ""
+
+	if opts.Limit <= 0 || count <= opts.Limit {
+		return count
+	}
+
+	last := getModel(opts.Limit - 1)
+	sortValue := last.CreatedAt
+
+	if column, _, err := getSort(opts, cols); err == nil && column == "updated_at" {
+		sortValue = last.UpdatedAt
+	}
+
+	opts.NextCursor = encodeCursor(&cursor{sortValue, last.ID})
+
+	return opts.Limit
+}
+
+func getSort(opts *repository.ListOptions, cols *listColumns) (string, repository.SortOrder, error) {
+	sortBy := opts.SortBy
+
+	if sortBy == "" {
+		sortBy = cols.defaultSort
+	}
+
+	column, ok := cols.sorts[sortBy]
+
+	if !ok {
+		return "", "", fmt.Errorf("%w: cannot sort on field %s", repository.ErrInvalidListOptions, sortBy)
+	}
+
+	order := opts.Order
+
+	if order == "" {
+		order = cols.defaultOrder
+	}
+
+	if order != repository.SortAsc && order != repository.SortDesc {
+		return "", "", fmt.Errorf("%w: invalid sort order %s", repository.ErrInvalidListOptions, order)
+	}
+
+	return column, order, nil
+}
+
+func encodeCursor(c *cursor) string {
+	raw, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(encoded string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", repository.ErrInvalidListOptions)
+	}
+
+	c := &cursor{}
+
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", repository.ErrInvalidListOptions)
+	}
+
+	return c, nil
+}
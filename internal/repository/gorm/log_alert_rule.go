@@ -0,0 +1,65 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// LogAlertRuleRepository uses gorm.DB for querying the database
+type LogAlertRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewLogAlertRuleRepository returns a LogAlertRuleRepository which uses gorm.DB for querying
+// the database
+func NewLogAlertRuleRepository(db *gorm.DB) repository.LogAlertRuleRepository {
+	return &LogAlertRuleRepository{db}
+}
+
+func (repo *LogAlertRuleRepository) CreateLogAlertRule(rule *models.LogAlertRule) (*models.LogAlertRule, error) {
+	if err := repo.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (repo *LogAlertRuleRepository) ReadLogAlertRule(clusterID, id uint) (*models.LogAlertRule, error) {
+	rule := &models.LogAlertRule{}
+
+	if err := repo.db.Where("cluster_id = ? AND id = ?", clusterID, id).First(rule).Error; err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (repo *LogAlertRuleRepository) ListLogAlertRulesByRelease(
+	clusterID uint,
+	namespace, releaseName string,
+) ([]*models.LogAlertRule, error) {
+	rules := make([]*models.LogAlertRule, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND release_name = ?", clusterID, namespace, releaseName,
+	).Order("id asc").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (repo *LogAlertRuleRepository) ListLogAlertRulesByCluster(clusterID uint) ([]*models.LogAlertRule, error) {
+	rules := make([]*models.LogAlertRule, 0)
+
+	if err := repo.db.Where("cluster_id = ?", clusterID).Order("id asc").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (repo *LogAlertRuleRepository) DeleteLogAlertRule(rule *models.LogAlertRule) error {
+	return repo.db.Delete(rule).Error
+}
@@ -58,6 +58,13 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.StackEnvGroup{},
 		&models.DbMigration{},
 		&models.MonitorTestResult{},
+		&models.AuditLog{},
+		&models.ClusterIncident{},
+		&models.RegistryRetentionPolicy{},
+		&models.RegistryCredentialRefresh{},
+		&models.Build{},
+		&models.ImageSignaturePolicy{},
+		&models.PullThroughCacheRule{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
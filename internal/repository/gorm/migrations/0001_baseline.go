@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/repository/gorm"
+
+	pgorm "gorm.io/gorm"
+)
+
+// the baseline migration creates the schema which was previously created by gorm
+// auto-migration, so that existing databases are migrated to it without changes
+func init() {
+	register(&Migration{
+		Version: 1,
+		Name:    "baseline",
+		Up: func(tx *pgorm.DB) error {
+			return gorm.AutoMigrate(tx, false)
+		},
+		Down: func(tx *pgorm.DB) error {
+			return ErrIrreversible
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 2,
+		Name:    "bulk_deployment_operations",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.BulkDeploymentOperation{}, &models.BulkDeploymentOperationItem{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.BulkDeploymentOperationItem{}, &models.BulkDeploymentOperation{})
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"fmt"
+
+	pgorm "gorm.io/gorm"
+)
+
+// searchIndex is a lower-cased column which is matched by search queries
+type searchIndex struct {
+	table  string
+	column string
+}
+
+// searchIndexes are the search indexes of the tables which exist at this version
+var searchIndexes = []searchIndex{
+	{"releases", "name"},
+	{"releases", "namespace"},
+	{"git_action_configs", "git_repo"},
+	{"deployments", "namespace"},
+	{"deployments", "repo_name"},
+	{"deployments", "commit_sha"},
+	{"deployments", "pr_name"},
+	{"deployments", "subdomain"},
+}
+
+// createSearchIndexes creates trigram indexes on search columns. Search queries match anywhere
+// in a column, which can only use trigram indexes, so the indexes are only created on
+// postgres.
+func createSearchIndexes(tx *pgorm.DB, indexes []searchIndex) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	if err := tx.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return fmt.Errorf("could not create the pg_trgm extension: %w", err)
+	}
+
+	for _, idx := range indexes {
+		if err := tx.Exec(fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS idx_%[1]s_%[2]s_trgm ON %[1]s USING gin (lower(%[2]s) gin_trgm_ops)",
+			idx.table, idx.column,
+		)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropSearchIndexes(tx *pgorm.DB, indexes []searchIndex) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	for _, idx := range indexes {
+		if err := tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS idx_%s_%s_trgm", idx.table, idx.column)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	register(&Migration{
+		Version: 4,
+		Name:    "search_indexes",
+		Up: func(tx *pgorm.DB) error {
+			return createSearchIndexes(tx, searchIndexes)
+		},
+		Down: func(tx *pgorm.DB) error {
+			return dropSearchIndexes(tx, searchIndexes)
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"fmt"
+
+	pgorm "gorm.io/gorm"
+)
+
+// deploymentLookupIndexes cover the environment and deployment lookups made on every GitHub
+// webhook and deployment finalization. Repository owners and names are matched case
+// insensitively, so they are indexed lower-cased.
+var deploymentLookupIndexes = []struct {
+	name    string
+	table   string
+	columns string
+}{
+	{
+		"idx_environments_git_repo",
+		"environments",
+		"project_id, cluster_id, git_installation_id, lower(git_repo_owner), lower(git_repo_name)",
+	},
+	{
+		"idx_deployments_environment_namespace",
+		"deployments",
+		"environment_id, namespace",
+	},
+	{
+		"idx_deployments_environment_pull_request",
+		"deployments",
+		"environment_id, pull_request_id, lower(repo_owner), lower(repo_name)",
+	},
+}
+
+func init() {
+	register(&Migration{
+		Version: 5,
+		Name:    "deployment_lookup_indexes",
+		Up: func(tx *pgorm.DB) error {
+			for _, idx := range deploymentLookupIndexes {
+				if err := tx.Exec(fmt.Sprintf(
+					"CREATE INDEX IF NOT EXISTS %s ON %s (%s)", idx.name, idx.table, idx.columns,
+				)).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Down: func(tx *pgorm.DB) error {
+			for _, idx := range deploymentLookupIndexes {
+				if err := tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", idx.name)).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
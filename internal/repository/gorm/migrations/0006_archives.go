@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 6,
+		Name:    "archives",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.ArchivedDeployment{}, &models.ArchivedKubeEvent{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.ArchivedDeployment{}, &models.ArchivedKubeEvent{})
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 7,
+		Name:    "slack_routing_rules",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&ints.SlackRoutingRule{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&ints.SlackRoutingRule{})
+		},
+	})
+}
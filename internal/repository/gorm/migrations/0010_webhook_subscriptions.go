@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 10,
+		Name:    "webhook_subscriptions",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.WebhookSubscription{}, &models.WebhookDelivery{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.WebhookSubscription{}, &models.WebhookDelivery{})
+		},
+	})
+}
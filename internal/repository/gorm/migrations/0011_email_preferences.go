@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 11,
+		Name:    "email_preferences",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.EmailPreference{}, &models.DeploymentRecord{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.EmailPreference{}, &models.DeploymentRecord{})
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 12,
+		Name:    "pagerduty_integrations",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&ints.PagerDutyIntegration{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&ints.PagerDutyIntegration{})
+		},
+	})
+}
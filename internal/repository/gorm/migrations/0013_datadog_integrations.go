@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 13,
+		Name:    "datadog_integrations",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&ints.DatadogIntegration{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&ints.DatadogIntegration{})
+		},
+	})
+}
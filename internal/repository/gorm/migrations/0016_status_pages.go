@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 16,
+		Name:    "status_pages",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.StatusPage{}, &models.StatusPageRelease{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.StatusPageRelease{}, &models.StatusPage{})
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 17,
+		Name:    "jira_integrations",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&ints.JiraIntegration{}); err != nil {
+				return err
+			}
+
+			// the column already exists in databases created from the baseline migration
+			if tx.Migrator().HasColumn(&models.Deployment{}, "JiraIssueKeys") {
+				return nil
+			}
+
+			return tx.Migrator().AddColumn(&models.Deployment{}, "JiraIssueKeys")
+		},
+		Down: func(tx *pgorm.DB) error {
+			if err := tx.Migrator().DropColumn(&models.Deployment{}, "JiraIssueKeys"); err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropTable(&ints.JiraIntegration{})
+		},
+	})
+}
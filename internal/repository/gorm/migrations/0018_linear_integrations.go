@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 18,
+		Name:    "linear_integrations",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&ints.LinearIntegration{}); err != nil {
+				return err
+			}
+
+			// the column already exists in databases created from the baseline migration
+			if tx.Migrator().HasColumn(&models.Environment{}, "LinearIntegrationID") {
+				return nil
+			}
+
+			return tx.Migrator().AddColumn(&models.Environment{}, "LinearIntegrationID")
+		},
+		Down: func(tx *pgorm.DB) error {
+			if err := tx.Migrator().DropColumn(&models.Environment{}, "LinearIntegrationID"); err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropTable(&ints.LinearIntegration{})
+		},
+	})
+}
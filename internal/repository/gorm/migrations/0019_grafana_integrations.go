@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 19,
+		Name:    "grafana_integrations",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&ints.GrafanaIntegration{}); err != nil {
+				return err
+			}
+
+			// the column already exists in databases created from the baseline migration
+			if tx.Migrator().HasColumn(&models.Release{}, "GrafanaDashboardURL") {
+				return nil
+			}
+
+			return tx.Migrator().AddColumn(&models.Release{}, "GrafanaDashboardURL")
+		},
+		Down: func(tx *pgorm.DB) error {
+			if err := tx.Migrator().DropColumn(&models.Release{}, "GrafanaDashboardURL"); err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropTable(&ints.GrafanaIntegration{})
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 20,
+		Name:    "env_group_sources",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.EnvGroupSource{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.EnvGroupSource{})
+		},
+	})
+}
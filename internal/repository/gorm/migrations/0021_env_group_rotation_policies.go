@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 21,
+		Name:    "env_group_rotation_policies",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.EnvGroupRotationPolicy{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.EnvGroupRotationPolicy{})
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 22,
+		Name:    "external_secret_stores",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.ExternalSecretStore{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.ExternalSecretStore{})
+		},
+	})
+}
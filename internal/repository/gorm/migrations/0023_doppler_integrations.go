@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 23,
+		Name:    "doppler_integrations",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&ints.DopplerIntegration{}); err != nil {
+				return err
+			}
+
+			// adds the columns of sources which are synced from Doppler
+			return tx.AutoMigrate(&models.EnvGroupSource{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			for _, column := range []string{"WebhookToken", "DopplerIntegrationID"} {
+				if err := tx.Migrator().DropColumn(&models.EnvGroupSource{}, column); err != nil {
+					return err
+				}
+			}
+
+			return tx.Migrator().DropTable(&ints.DopplerIntegration{})
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+var stackSourceConfigBuildColumns = []string{
+	"BuildMethod",
+	"BuildFolderPath",
+	"BuildDockerfilePath",
+	"BuildBuilder",
+	"BuildBuildpacks",
+	"GitIntegrationKind",
+	"GitIntegrationID",
+	"GitRepo",
+	"GitBranch",
+}
+
+func init() {
+	register(&Migration{
+		Version: 24,
+		Name:    "stack_source_config_builds",
+		Up: func(tx *pgorm.DB) error {
+			for _, column := range stackSourceConfigBuildColumns {
+				if tx.Migrator().HasColumn(&models.StackSourceConfig{}, column) {
+					continue
+				}
+
+				if err := tx.Migrator().AddColumn(&models.StackSourceConfig{}, column); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Down: func(tx *pgorm.DB) error {
+			for _, column := range stackSourceConfigBuildColumns {
+				if err := tx.Migrator().DropColumn(&models.StackSourceConfig{}, column); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 25,
+		Name:    "project_usage_records",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&models.ProjectUsageRecord{}); err != nil {
+				return err
+			}
+
+			// adds the limits and cached usage of the metered resources
+			return tx.AutoMigrate(&models.ProjectUsage{}, &models.ProjectUsageCache{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			for _, column := range []string{"Apps", "BuildMinutes", "PreviewEnvironmentHours"} {
+				if err := tx.Migrator().DropColumn(&models.ProjectUsage{}, column); err != nil {
+					return err
+				}
+
+				if err := tx.Migrator().DropColumn(&models.ProjectUsageCache{}, column); err != nil {
+					return err
+				}
+			}
+
+			return tx.Migrator().DropTable(&models.ProjectUsageRecord{})
+		},
+	})
+}
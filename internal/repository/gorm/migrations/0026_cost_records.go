@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 26,
+		Name:    "cost_records",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.CostRecord{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.CostRecord{})
+		},
+	})
+}
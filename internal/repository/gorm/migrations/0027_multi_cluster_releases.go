@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 27,
+		Name:    "multi_cluster_releases",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(
+				&models.MultiClusterRelease{},
+				&models.MultiClusterReleaseTarget{},
+				&models.MultiClusterRollout{},
+				&models.MultiClusterRolloutTarget{},
+			)
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(
+				&models.MultiClusterRolloutTarget{},
+				&models.MultiClusterRollout{},
+				&models.MultiClusterReleaseTarget{},
+				&models.MultiClusterRelease{},
+			)
+		},
+	})
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 28,
+		Name:    "dns_provider_integrations",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(
+				&ints.DNSProviderIntegration{},
+				&models.CustomDomainDNSRecord{},
+			)
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(
+				&models.CustomDomainDNSRecord{},
+				&ints.DNSProviderIntegration{},
+			)
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 30,
+		Name:    "release_maintenance_mode",
+		Up: func(tx *pgorm.DB) error {
+			if tx.Migrator().HasColumn(&models.Release{}, "MaintenanceMode") {
+				return nil
+			}
+
+			return tx.Migrator().AddColumn(&models.Release{}, "MaintenanceMode")
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropColumn(&models.Release{}, "MaintenanceMode")
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 31,
+		Name:    "approvals",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.ApprovalPolicy{}, &models.Approval{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.Approval{}, &models.ApprovalPolicy{})
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 33,
+		Name:    "queued_deploys",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.QueuedDeploy{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.QueuedDeploy{})
+		},
+	})
+}
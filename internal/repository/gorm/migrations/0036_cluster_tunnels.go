@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 36,
+		Name:    "cluster_tunnels",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&models.ClusterTunnel{}); err != nil {
+				return err
+			}
+
+			if tx.Migrator().HasColumn(&models.Cluster{}, "TunnelEnabled") {
+				return nil
+			}
+
+			return tx.Migrator().AddColumn(&models.Cluster{}, "TunnelEnabled")
+		},
+		Down: func(tx *pgorm.DB) error {
+			if err := tx.Migrator().DropColumn(&models.Cluster{}, "TunnelEnabled"); err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropTable(&models.ClusterTunnel{})
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 38,
+		Name:    "deploy_feature_flags",
+		Up: func(tx *pgorm.DB) error {
+			if err := tx.AutoMigrate(&ints.FeatureFlagIntegration{}, &models.DeployFeatureFlag{}); err != nil {
+				return err
+			}
+
+			for _, model := range []interface{}{&models.QueuedDeploy{}, &models.Approval{}} {
+				if tx.Migrator().HasColumn(model, "FeatureFlags") {
+					continue
+				}
+
+				if err := tx.Migrator().AddColumn(model, "FeatureFlags"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Down: func(tx *pgorm.DB) error {
+			for _, model := range []interface{}{&models.QueuedDeploy{}, &models.Approval{}} {
+				if err := tx.Migrator().DropColumn(model, "FeatureFlags"); err != nil {
+					return err
+				}
+			}
+
+			return tx.Migrator().DropTable(&models.DeployFeatureFlag{}, &ints.FeatureFlagIntegration{})
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 39,
+		Name:    "log_alert_rules",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.LogAlertRule{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.LogAlertRule{})
+		},
+	})
+}
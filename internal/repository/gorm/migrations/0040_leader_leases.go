@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 40,
+		Name:    "leader_leases",
+		Up: func(tx *pgorm.DB) error {
+			return tx.AutoMigrate(&models.LeaderLease{})
+		},
+		Down: func(tx *pgorm.DB) error {
+			return tx.Migrator().DropTable(&models.LeaderLease{})
+		},
+	})
+}
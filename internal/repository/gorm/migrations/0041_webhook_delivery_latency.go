@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+
+	pgorm "gorm.io/gorm"
+)
+
+func init() {
+	register(&Migration{
+		Version: 41,
+		Name:    "webhook_delivery_latency",
+		Up: func(tx *pgorm.DB) error {
+			if !tx.Migrator().HasColumn(&models.WebhookDelivery{}, "LatencyMs") {
+				if err := tx.Migrator().AddColumn(&models.WebhookDelivery{}, "LatencyMs"); err != nil {
+					return err
+				}
+			}
+
+			// response bodies are no longer stored, so the bodies which were stored are dropped
+			if tx.Migrator().HasColumn(&models.WebhookDelivery{}, "response_body") {
+				return tx.Migrator().DropColumn(&models.WebhookDelivery{}, "response_body")
+			}
+
+			return nil
+		},
+		Down: func(tx *pgorm.DB) error {
+			if err := tx.Exec("ALTER TABLE webhook_deliveries ADD COLUMN response_body text").Error; err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropColumn(&models.WebhookDelivery{}, "LatencyMs")
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	pgorm "gorm.io/gorm"
+)
+
+// customDomainSearchIndexes index the hosts of custom domains, whose table is only created by
+// migration 28
+var customDomainSearchIndexes = []searchIndex{
+	{"custom_domain_dns_records", "host"},
+}
+
+func init() {
+	register(&Migration{
+		Version: 42,
+		Name:    "custom_domain_search_index",
+		Up: func(tx *pgorm.DB) error {
+			return createSearchIndexes(tx, customDomainSearchIndexes)
+		},
+		Down: func(tx *pgorm.DB) error {
+			return dropSearchIndexes(tx, customDomainSearchIndexes)
+		},
+	})
+}
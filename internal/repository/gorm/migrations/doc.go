@@ -0,0 +1,15 @@
+/*
+                        === Versioned Schema Migrations ===
+
+   This package contains the versioned migrations of the database schema. Each migration
+   has an Up function which applies it and a Down function which reverts it, and lives in
+   its own file named after its version, such as 0002_add_build_logs.go. Migrations are
+   registered in the init function of their file.
+
+   Applied migrations are recorded in the schema_migrations table, and are applied by the
+   migrate entrypoint ("migrate up", "migrate down <version>", "migrate status") before the
+   server starts. New models and schema changes must be added as a new migration, rather
+   than to the baseline migration.
+*/
+
+package migrations
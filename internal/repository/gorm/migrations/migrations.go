@@ -0,0 +1,339 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrIrreversible is returned by the Down function of migrations which cannot be reverted
+var ErrIrreversible = errors.New("migration cannot be reverted")
+
+// Migration is a single versioned change to the database schema
+type Migration struct {
+	Version uint
+	Name    string
+
+	Up   func(tx *gorm.DB) error
+	Down func(tx *gorm.DB) error
+}
+
+// SchemaMigration records a migration which has been applied to the database
+type SchemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrationStatus is the status of a migration in the database
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+var registered = make([]*Migration, 0)
+
+func register(migration *Migration) {
+	registered = append(registered, migration)
+}
+
+// All returns the registered migrations, ordered by version
+func All() []*Migration {
+	res := make([]*Migration, len(registered))
+	copy(res, registered)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+
+	return res
+}
+
+// LatestVersion returns the version of the latest registered migration
+func LatestVersion() uint {
+	all := All()
+
+	if len(all) == 0 {
+		return 0
+	}
+
+	return all[len(all)-1].Version
+}
+
+// Migrator applies and reverts migrations, recording them in the schema_migrations table
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// NewMigrator returns a Migrator for a set of migrations, which must have unique
+// versions greater than 0
+func NewMigrator(db *gorm.DB, migrations []*Migration) (*Migrator, error) {
+	sorted := make([]*Migration, len(migrations))
+	copy(sorted, migrations)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	for i, migration := range sorted {
+		if migration.Version == 0 {
+			return nil, fmt.Errorf("migration %s has no version", migration.Name)
+		}
+
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d",
+				sorted[i-1].Name, migration.Name, migration.Version)
+		}
+
+		if migration.Up == nil || migration.Down == nil {
+			return nil, fmt.Errorf("migration %d_%s must have up and down functions", migration.Version, migration.Name)
+		}
+	}
+
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("could not create schema_migrations table: %w", err)
+	}
+
+	return &Migrator{db, sorted}, nil
+}
+
+// CurrentVersion returns the version of the latest migration applied to the database, or
+// 0 if no migrations have been applied
+func (m *Migrator) CurrentVersion() (uint, error) {
+	var version uint
+
+	err := m.db.Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+
+	return version, err
+}
+
+// Status returns the status of every migration, as well as any applied migrations which are
+// not known to this version of Porter
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.applied()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*MigrationStatus, 0)
+
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+
+			delete(applied, migration.Version)
+		}
+
+		res = append(res, status)
+	}
+
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+
+		res = append(res, &MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+		})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+
+	return res, nil
+}
+
+// Pending returns the migrations which have not been applied to the database
+func (m *Migrator) Pending() ([]*Migration, error) {
+	applied, err := m.applied()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Migration, 0)
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			res = append(res, migration)
+		}
+	}
+
+	return res, nil
+}
+
+// CheckVersion returns an error if the database has pending migrations, or if it has been
+// migrated by a newer version of Porter
+func (m *Migrator) CheckVersion() error {
+	pending, err := m.Pending()
+
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("the database has %d pending migrations, starting with %d_%s: run \"migrate up\" to apply them",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+
+	current, err := m.CurrentVersion()
+
+	if err != nil {
+		return err
+	}
+
+	if latest := m.latestVersion(); current > latest {
+		return fmt.Errorf("the database is at version %d, which is newer than the latest known version %d: "+
+			"run \"migrate down %d\" with the newer version of Porter before downgrading", current, latest, latest)
+	}
+
+	return nil
+}
+
+// Up applies the pending migrations with a version up to and including the target version,
+// in order. A target of 0 applies all pending migrations.
+func (m *Migrator) Up(target uint) ([]*Migration, error) {
+	res := make([]*Migration, 0)
+
+	err := m.locked(func(tx *gorm.DB) error {
+		applied, err := appliedIn(tx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if target != 0 && migration.Version > target {
+				break
+			}
+
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			if err := migration.Up(tx); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+
+			if err := tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+
+			res = append(res, migration)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Down reverts the applied migrations with a version greater than the target version, in
+// reverse order. A target of 0 reverts every migration.
+func (m *Migrator) Down(target uint) ([]*Migration, error) {
+	res := make([]*Migration, 0)
+
+	err := m.locked(func(tx *gorm.DB) error {
+		applied, err := appliedIn(tx)
+
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+
+			if migration.Version <= target {
+				break
+			}
+
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if err := migration.Down(tx); err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+
+			if err := tx.Delete(&SchemaMigration{}, migration.Version).Error; err != nil {
+				return err
+			}
+
+			res = append(res, migration)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// locked runs a set of migrations in a single transaction, so that a failed migration
+// leaves the schema unchanged, while holding a lock so that concurrent migrate runs
+// do not apply the same migrations
+func (m *Migrator) locked(fn func(tx *gorm.DB) error) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("LOCK TABLE schema_migrations IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+				return fmt.Errorf("error acquiring lock on schema_migrations: %w", err)
+			}
+		}
+
+		return fn(tx)
+	})
+}
+
+func (m *Migrator) applied() (map[uint]*SchemaMigration, error) {
+	return appliedIn(m.db)
+}
+
+func (m *Migrator) latestVersion() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func appliedIn(db *gorm.DB) (map[uint]*SchemaMigration, error) {
+	records := make([]*SchemaMigration, 0)
+
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	res := make(map[uint]*SchemaMigration)
+
+	for _, record := range records {
+		res[record.Version] = record
+	}
+
+	return res, nil
+}
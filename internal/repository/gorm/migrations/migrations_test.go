@@ -0,0 +1,155 @@
+package migrations_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/repository/gorm/migrations"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+type gadget struct {
+	ID uint
+}
+
+var testMigrations = []*migrations.Migration{
+	{
+		Version: 2,
+		Name:    "create_gadgets",
+		Up:      func(tx *gorm.DB) error { return tx.Migrator().CreateTable(&gadget{}) },
+		Down:    func(tx *gorm.DB) error { return tx.Migrator().DropTable(&gadget{}) },
+	},
+	{
+		Version: 1,
+		Name:    "create_widgets",
+		Up:      func(tx *gorm.DB) error { return tx.Migrator().CreateTable(&widget{}) },
+		Down:    func(tx *gorm.DB) error { return tx.Migrator().DropTable(&widget{}) },
+	},
+}
+
+func setupMigrator(t *testing.T, ms []*migrations.Migration) (*gorm.DB, *migrations.Migrator) {
+	t.Helper()
+
+	db, err := adapter.New(&env.DBConf{
+		SQLLite:     true,
+		SQLLitePath: filepath.Join(t.TempDir(), "migrations.db"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(db, ms)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return db, migrator
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	db, migrator := setupMigrator(t, testMigrations)
+
+	if err := migrator.CheckVersion(); err == nil {
+		t.Errorf("expected pending migrations to fail the version check")
+	}
+
+	applied, err := migrator.Up(1)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(applied) != 1 || applied[0].Version != 1 || !db.Migrator().HasTable(&widget{}) || db.Migrator().HasTable(&gadget{}) {
+		t.Fatalf("expected only migration 1 to be applied")
+	}
+
+	if applied, err = migrator.Up(0); err != nil || len(applied) != 1 || applied[0].Version != 2 {
+		t.Fatalf("expected migration 2 to be applied, got %v, %v", applied, err)
+	}
+
+	if err := migrator.CheckVersion(); err != nil {
+		t.Errorf("expected version check to pass, got %v", err)
+	}
+
+	if version, err := migrator.CurrentVersion(); err != nil || version != 2 {
+		t.Errorf("expected current version 2, got %d, %v", version, err)
+	}
+
+	reverted, err := migrator.Down(0)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(reverted) != 2 || reverted[0].Version != 2 || reverted[1].Version != 1 {
+		t.Fatalf("expected migrations to be reverted in reverse order, got %v", reverted)
+	}
+
+	if db.Migrator().HasTable(&widget{}) || db.Migrator().HasTable(&gadget{}) {
+		t.Errorf("expected tables to be dropped")
+	}
+}
+
+func TestMigratorFailedMigration(t *testing.T) {
+	failing := append([]*migrations.Migration{}, testMigrations...)
+	failing = append(failing, &migrations.Migration{
+		Version: 3,
+		Name:    "fail",
+		Up:      func(tx *gorm.DB) error { return errors.New("failed") },
+		Down:    func(tx *gorm.DB) error { return nil },
+	})
+
+	_, migrator := setupMigrator(t, failing)
+
+	if _, err := migrator.Up(0); err == nil {
+		t.Fatalf("expected failed migration to return an error")
+	}
+
+	// the transaction is rolled back, so no migrations are recorded
+	if version, err := migrator.CurrentVersion(); err != nil || version != 0 {
+		t.Errorf("expected current version 0, got %d, %v", version, err)
+	}
+}
+
+func TestMigratorNewerDatabase(t *testing.T) {
+	db, migrator := setupMigrator(t, testMigrations)
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	older, err := migrations.NewMigrator(db, testMigrations[1:])
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := older.CheckVersion(); err == nil {
+		t.Errorf("expected a database migrated by a newer version to fail the version check")
+	}
+}
+
+func TestNewMigratorDuplicateVersions(t *testing.T) {
+	db, _ := setupMigrator(t, nil)
+
+	_, err := migrations.NewMigrator(db, append(testMigrations, &migrations.Migration{
+		Version: 1,
+		Name:    "duplicate",
+		Up:      func(tx *gorm.DB) error { return nil },
+		Down:    func(tx *gorm.DB) error { return nil },
+	}))
+
+	if err == nil {
+		t.Errorf("expected duplicate versions to return an error")
+	}
+}
@@ -0,0 +1,157 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// MultiClusterReleaseRepository uses gorm.DB for querying the database
+type MultiClusterReleaseRepository struct {
+	db *gorm.DB
+}
+
+// NewMultiClusterReleaseRepository returns a MultiClusterReleaseRepository which uses
+// gorm.DB for querying the database
+func NewMultiClusterReleaseRepository(db *gorm.DB) repository.MultiClusterReleaseRepository {
+	return &MultiClusterReleaseRepository{db}
+}
+
+func orderMultiClusterTargets(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc, id asc")
+}
+
+// CreateMultiClusterRelease creates a multi-cluster release along with its targets
+func (repo *MultiClusterReleaseRepository) CreateMultiClusterRelease(
+	release *models.MultiClusterRelease,
+) (*models.MultiClusterRelease, error) {
+	if err := repo.db.Create(release).Error; err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+func (repo *MultiClusterReleaseRepository) ReadMultiClusterRelease(
+	projectID, id uint,
+) (*models.MultiClusterRelease, error) {
+	release := &models.MultiClusterRelease{}
+
+	if err := repo.db.Preload("Targets", orderMultiClusterTargets).
+		Where("project_id = ? AND id = ?", projectID, id).First(release).Error; err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+func (repo *MultiClusterReleaseRepository) ListMultiClusterReleases(
+	projectID uint,
+) ([]*models.MultiClusterRelease, error) {
+	releases := make([]*models.MultiClusterRelease, 0)
+
+	if err := repo.db.Preload("Targets", orderMultiClusterTargets).
+		Where("project_id = ?", projectID).Order("name asc").Find(&releases).Error; err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+func (repo *MultiClusterReleaseRepository) UpdateMultiClusterRelease(
+	release *models.MultiClusterRelease,
+) (*models.MultiClusterRelease, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("multi_cluster_release_id = ?", release.ID).
+			Delete(&models.MultiClusterReleaseTarget{}).Error; err != nil {
+			return err
+		}
+
+		for i := range release.Targets {
+			release.Targets[i].ID = 0
+			release.Targets[i].MultiClusterReleaseID = release.ID
+		}
+
+		if len(release.Targets) > 0 {
+			if err := tx.Create(&release.Targets).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("Targets").Save(release).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+func (repo *MultiClusterReleaseRepository) DeleteMultiClusterRelease(release *models.MultiClusterRelease) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("multi_cluster_release_id = ?", release.ID).
+			Delete(&models.MultiClusterReleaseTarget{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(release).Error
+	})
+}
+
+// CreateMultiClusterRollout creates a rollout along with its targets
+func (repo *MultiClusterReleaseRepository) CreateMultiClusterRollout(
+	rollout *models.MultiClusterRollout,
+) (*models.MultiClusterRollout, error) {
+	if err := repo.db.Create(rollout).Error; err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+func (repo *MultiClusterReleaseRepository) ReadMultiClusterRollout(
+	releaseID, id uint,
+) (*models.MultiClusterRollout, error) {
+	rollout := &models.MultiClusterRollout{}
+
+	if err := repo.db.Preload("Targets", orderMultiClusterTargets).
+		Where("multi_cluster_release_id = ? AND id = ?", releaseID, id).First(rollout).Error; err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+func (repo *MultiClusterReleaseRepository) ListMultiClusterRollouts(
+	releaseID uint,
+) ([]*models.MultiClusterRollout, error) {
+	rollouts := make([]*models.MultiClusterRollout, 0)
+
+	if err := repo.db.Preload("Targets", orderMultiClusterTargets).
+		Where("multi_cluster_release_id = ?", releaseID).Order("id desc").Find(&rollouts).Error; err != nil {
+		return nil, err
+	}
+
+	return rollouts, nil
+}
+
+func (repo *MultiClusterReleaseRepository) UpdateMultiClusterRollout(
+	rollout *models.MultiClusterRollout,
+) (*models.MultiClusterRollout, error) {
+	if err := repo.db.Omit("Targets").Save(rollout).Error; err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+func (repo *MultiClusterReleaseRepository) UpdateMultiClusterRolloutTarget(
+	target *models.MultiClusterRolloutTarget,
+) (*models.MultiClusterRolloutTarget, error) {
+	if err := repo.db.Save(target).Error; err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
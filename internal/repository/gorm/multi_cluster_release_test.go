@@ -0,0 +1,54 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestUpdateMultiClusterRelease(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_multi_cluster_release.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	release, err := tester.repo.MultiClusterRelease().CreateMultiClusterRelease(&models.MultiClusterRelease{
+		ProjectID: tester.initProjects[0].ID,
+		Name:      "web",
+		Strategy:  types.MultiClusterStrategySequential,
+		Targets: []models.MultiClusterReleaseTarget{
+			{ClusterID: 1, Namespace: "default", Position: 0},
+			{ClusterID: 2, Namespace: "default", Position: 1},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the targets are replaced, in the order of their positions
+	release.Strategy = types.MultiClusterStrategyParallel
+	release.Targets = []models.MultiClusterReleaseTarget{
+		{ClusterID: 3, Namespace: "eu", Position: 1},
+		{ClusterID: 2, Namespace: "us", Position: 0},
+	}
+
+	if _, err := tester.repo.MultiClusterRelease().UpdateMultiClusterRelease(release); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	release, err = tester.repo.MultiClusterRelease().ReadMultiClusterRelease(tester.initProjects[0].ID, release.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if release.Strategy != types.MultiClusterStrategyParallel || len(release.Targets) != 2 ||
+		release.Targets[0].Namespace != "us" || release.Targets[1].Namespace != "eu" {
+		t.Fatalf("expected the parallel strategy with targets us and eu, got %s with %v\n", release.Strategy, release.Targets)
+	}
+}
@@ -0,0 +1,66 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NotificationPreferenceRepository uses gorm.DB for querying the database
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPreferenceRepository returns a NotificationPreferenceRepository which uses
+// gorm.DB for querying the database
+func NewNotificationPreferenceRepository(db *gorm.DB) repository.NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db}
+}
+
+func (repo *NotificationPreferenceRepository) CreateNotificationPreference(
+	pref *models.NotificationPreference,
+) (*models.NotificationPreference, error) {
+	if err := repo.db.Create(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (repo *NotificationPreferenceRepository) ReadNotificationPreference(
+	projectID, userID uint,
+	channel types.NotificationChannel,
+) (*models.NotificationPreference, error) {
+	pref := &models.NotificationPreference{}
+
+	if err := repo.db.Where(
+		"project_id = ? AND user_id = ? AND channel = ?", projectID, userID, channel,
+	).First(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (repo *NotificationPreferenceRepository) UpdateNotificationPreference(
+	pref *models.NotificationPreference,
+) (*models.NotificationPreference, error) {
+	if err := repo.db.Save(pref).Error; err != nil {
+		return nil, err
+	}
+
+	return pref, nil
+}
+
+func (repo *NotificationPreferenceRepository) ListNotificationPreferencesByProjectID(
+	projectID uint,
+) ([]*models.NotificationPreference, error) {
+	prefs := make([]*models.NotificationPreference, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
@@ -0,0 +1,152 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/email"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_preferences.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	initProjectRole(tester, t)
+	defer cleanup(tester, t)
+
+	user := tester.initUsers[0]
+	projectID := tester.initProjects[0].ID
+	sender := &fakeEmailSender{}
+
+	// the project only sends failed deploys to slack, and mutes warnings overnight
+	_, err := tester.repo.NotificationPreference().CreateNotificationPreference(&models.NotificationPreference{
+		ProjectID:          projectID,
+		Channel:            types.NotificationChannelSlack,
+		Events:             "deploy_failed,incident",
+		MinSeverity:        types.NotificationSeverityInfo,
+		QuietHoursStart:    "22:00",
+		QuietHoursEnd:      "07:00",
+		QuietHoursTimezone: "America/New_York",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	prefs, err := tester.repo.NotificationPreference().ListNotificationPreferencesByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	slackPref := notifier.GetNotificationPreference(prefs, projectID, 0, types.NotificationChannelSlack)
+
+	loc, _ := time.LoadLocation("America/New_York")
+	midnight := time.Date(2022, 11, 1, 0, 30, 0, 0, loc)
+	noon := time.Date(2022, 11, 1, 12, 0, 0, 0, loc)
+
+	if slackPref.Allows(types.NotificationEventDeploySucceeded, noon) {
+		t.Errorf("successful deploys should not be sent to slack\n")
+	}
+
+	if !slackPref.Allows(types.NotificationEventIncident, noon) || slackPref.Allows(types.NotificationEventIncident, midnight) {
+		t.Errorf("incidents should only be sent to slack outside of the quiet hours\n")
+	}
+
+	if !slackPref.Allows(types.NotificationEventDeployFailed, midnight) {
+		t.Errorf("critical events should be sent to slack during the quiet hours\n")
+	}
+
+	// channels without a stored preference send every event
+	if !notifier.GetNotificationPreference(prefs, projectID, 0, types.NotificationChannelWebhook).Allows(types.NotificationEventDeploySucceeded, midnight) {
+		t.Errorf("webhook channel without a preference should send every event\n")
+	}
+
+	// users who have raised their severity threshold are not emailed for incidents
+	_, err = tester.repo.NotificationPreference().CreateNotificationPreference(&models.NotificationPreference{
+		ProjectID:   projectID,
+		UserID:      user.ID,
+		Channel:     types.NotificationChannelEmail,
+		MinSeverity: types.NotificationSeverityCritical,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	prefs, err = tester.repo.NotificationPreference().ListNotificationPreferencesByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	users := notifier.FilterUsers(prefs, projectID, types.NotificationChannelEmail, types.NotificationEventIncident, []*models.User{user})
+
+	if len(users) != 0 {
+		t.Errorf("expected incident emails to be filtered for the user\n")
+	}
+
+	// failed deploys are critical, so they are still emailed to the user
+	if err := email.NewDeploymentNotifier(nil, tester.repo, sender).Notify(&notifier.NotifyOpts{
+		ProjectID: projectID,
+		Name:      "web",
+		Status:    notifier.StatusHelmFailed,
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected 1 failure alert, got %d emails\n", len(sender.sent))
+	}
+
+	// muting failed deploys for the project stops the alerts, but not the digest records
+	emailPref, err := tester.repo.NotificationPreference().CreateNotificationPreference(&models.NotificationPreference{
+		ProjectID:   projectID,
+		Channel:     types.NotificationChannelEmail,
+		Events:      "incident",
+		MinSeverity: types.NotificationSeverityInfo,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := email.NewDeploymentNotifier(nil, tester.repo, sender).Notify(&notifier.NotifyOpts{
+		ProjectID: projectID,
+		Name:      "web",
+		Status:    notifier.StatusHelmFailed,
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(sender.sent) != 1 {
+		t.Errorf("expected no failure alert after muting failed deploys, got %d emails\n", len(sender.sent))
+	}
+
+	records, err := tester.repo.EmailPreference().ListDeploymentRecords(projectID, time.Now().Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 2 {
+		t.Errorf("expected 2 deployment records, got %d\n", len(records))
+	}
+
+	readPref, err := tester.repo.NotificationPreference().ReadNotificationPreference(projectID, 0, types.NotificationChannelEmail)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if readPref.ID != emailPref.ID {
+		t.Errorf("read the preference of the wrong scope: expected id %d, got %d\n", emailPref.ID, readPref.ID)
+	}
+}
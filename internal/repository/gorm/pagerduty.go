@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// PagerDutyIntegrationRepository uses gorm.DB for querying the database
+type PagerDutyIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewPagerDutyIntegrationRepository returns a PagerDutyIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewPagerDutyIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.PagerDutyIntegrationRepository {
+	return &PagerDutyIntegrationRepository{db, key}
+}
+
+// CreatePagerDutyIntegration creates a new PagerDuty integration
+func (repo *PagerDutyIntegrationRepository) CreatePagerDutyIntegration(
+	pdInt *ints.PagerDutyIntegration,
+) (*ints.PagerDutyIntegration, error) {
+	routingKey := pdInt.RoutingKey
+
+	cipherData, err := encryption.Encrypt(routingKey, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pdInt.RoutingKey = cipherData
+
+	if err := repo.db.Create(pdInt).Error; err != nil {
+		return nil, err
+	}
+
+	pdInt.RoutingKey = routingKey
+
+	return pdInt, nil
+}
+
+// ReadPagerDutyIntegration finds a PagerDuty integration of a project by its ID
+func (repo *PagerDutyIntegrationRepository) ReadPagerDutyIntegration(
+	projectID, integrationID uint,
+) (*ints.PagerDutyIntegration, error) {
+	pdInt := &ints.PagerDutyIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(pdInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptRoutingKey(pdInt); err != nil {
+		return nil, err
+	}
+
+	return pdInt, nil
+}
+
+// ListPagerDutyIntegrationsByProjectID finds all PagerDuty integrations of a project
+func (repo *PagerDutyIntegrationRepository) ListPagerDutyIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.PagerDutyIntegration, error) {
+	pdInts := []*ints.PagerDutyIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&pdInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, pdInt := range pdInts {
+		if err := repo.decryptRoutingKey(pdInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return pdInts, nil
+}
+
+// DeletePagerDutyIntegration deletes a PagerDuty integration
+func (repo *PagerDutyIntegrationRepository) DeletePagerDutyIntegration(
+	pdInt *ints.PagerDutyIntegration,
+) error {
+	return repo.db.Delete(pdInt).Error
+}
+
+func (repo *PagerDutyIntegrationRepository) decryptRoutingKey(pdInt *ints.PagerDutyIntegration) error {
+	if len(pdInt.RoutingKey) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(pdInt.RoutingKey, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	pdInt.RoutingKey = plaintext
+
+	return nil
+}
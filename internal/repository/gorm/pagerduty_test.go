@@ -0,0 +1,75 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestPagerDutyIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_pagerduty.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	routingKey := "b3f1c0d2e4a5968778695a4b3c2d1e0f"
+
+	pdInt, err := tester.repo.PagerDutyIntegration().CreatePagerDutyIntegration(&ints.PagerDutyIntegration{
+		ProjectID:  projectID,
+		Name:       "production",
+		ClusterID:  1,
+		Namespace:  "default",
+		RoutingKey: []byte(routingKey),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the routing key should be encrypted at rest, and decrypted when read
+	stored := &ints.PagerDutyIntegration{}
+
+	if err := tester.db.First(stored, pdInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.RoutingKey) == routingKey {
+		t.Errorf("routing key was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.PagerDutyIntegration().ReadPagerDutyIntegration(projectID, pdInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.RoutingKey) != routingKey {
+		t.Errorf("incorrect routing key: expected %s, got %s\n", routingKey, readInt.RoutingKey)
+	}
+
+	if !readInt.Matches(1, "default") || readInt.Matches(2, "default") || readInt.Matches(1, "staging") {
+		t.Errorf("integration should only match releases in cluster 1 and namespace default\n")
+	}
+
+	pdInts, err := tester.repo.PagerDutyIntegration().ListPagerDutyIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(pdInts) != 1 || string(pdInts[0].RoutingKey) != routingKey {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted routing key\n")
+	}
+
+	if err := tester.repo.PagerDutyIntegration().DeletePagerDutyIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.PagerDutyIntegration().ReadPagerDutyIntegration(projectID, pdInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
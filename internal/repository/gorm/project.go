@@ -1,11 +1,126 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
 
+// projectChildModels are the models which belong to a project through their project_id, which are
+// purged along with the project
+var projectChildModels = []interface{}{
+	&models.Role{},
+	&models.Release{},
+	&models.Environment{},
+	&models.GitRepo{},
+	&models.Registry{},
+	&models.HelmRepo{},
+	&models.Cluster{},
+	&models.ClusterCandidate{},
+	&models.Database{},
+	&models.Infra{},
+	&models.Invite{},
+	&models.JobNotificationConfig{},
+	&models.KubeEvent{},
+	&models.ProjectUsage{},
+	&models.ProjectUsageCache{},
+	&models.ProjectUsageRecord{},
+	&models.Onboarding{},
+	&models.CredentialsExchangeToken{},
+	&models.APIToken{},
+	&models.Policy{},
+	&models.Tag{},
+	&models.Stack{},
+	&models.StackEnvGroup{},
+	&models.MonitorTestResult{},
+	&models.AuditLog{},
+	&models.ClusterIncident{},
+	&models.RegistryRetentionPolicy{},
+	&models.RegistryCredentialRefresh{},
+	&models.Build{},
+	&models.ImageSignaturePolicy{},
+	&models.PullThroughCacheRule{},
+	&models.GitOpsExport{},
+	&models.BulkDeploymentOperation{},
+	&models.EnvGroupSource{},
+	&models.EnvGroupRotationPolicy{},
+	&models.ExternalSecretStore{},
+	&models.ApprovalPolicy{},
+	&models.Approval{},
+	&models.FreezeWindow{},
+	&models.QueuedDeploy{},
+	&models.WorkflowTemplate{},
+	&models.ClusterTunnel{},
+	&models.ClusterRelay{},
+	&models.WebhookSubscription{},
+	&models.WebhookDelivery{},
+	&models.LogAlertRule{},
+	&models.DeploymentRecord{},
+	&models.CustomDomainDNSRecord{},
+	&models.MultiClusterRelease{},
+	&models.CostRecord{},
+	&models.ArchivedDeployment{},
+	&models.ArchivedKubeEvent{},
+	&models.BackgroundJob{},
+	&models.WildcardCertificate{},
+	&models.DeployFeatureFlag{},
+	&models.NotificationPreference{},
+	&models.StatusPage{},
+	&ints.KubeIntegration{},
+	&ints.BasicIntegration{},
+	&ints.OIDCIntegration{},
+	&ints.OAuthIntegration{},
+	&ints.GCPIntegration{},
+	&ints.AWSIntegration{},
+	&ints.AzureIntegration{},
+	&ints.GitlabIntegration{},
+	&ints.SlackIntegration{},
+	&ints.SlackRoutingRule{},
+	&ints.PagerDutyIntegration{},
+	&ints.FeatureFlagIntegration{},
+	&ints.DatadogIntegration{},
+	&ints.DNSProviderIntegration{},
+	&ints.DiscordIntegration{},
+	&ints.TeamsIntegration{},
+	&ints.JiraIntegration{},
+	&ints.LinearIntegration{},
+	&ints.DopplerIntegration{},
+	&ints.SentryIntegration{},
+	&ints.GrafanaIntegration{},
+}
+
+// projectGrandchildModels are the models which belong to a child of a project through a column
+// which references the child, which are purged before the child. Models which belong to another
+// grandchild are listed before it.
+var projectGrandchildModels = []struct {
+	model  interface{}
+	column string
+	parent interface{}
+	// the column of the parent which references the child of the project, if the parent is not
+	// a child of the project itself
+	parentColumn string
+	grandparent  interface{}
+}{
+	{&models.MultiClusterRolloutTarget{}, "multi_cluster_rollout_id", &models.MultiClusterRollout{}, "multi_cluster_release_id", &models.MultiClusterRelease{}},
+	{&models.MultiClusterRollout{}, "multi_cluster_release_id", &models.MultiClusterRelease{}, "", nil},
+	{&models.MultiClusterReleaseTarget{}, "multi_cluster_release_id", &models.MultiClusterRelease{}, "", nil},
+	{&models.StackResource{}, "stack_revision_id", &models.StackRevision{}, "stack_id", &models.Stack{}},
+	{&models.StackSourceConfig{}, "stack_revision_id", &models.StackRevision{}, "stack_id", &models.Stack{}},
+	{&models.StackRevision{}, "stack_id", &models.Stack{}, "", nil},
+	{&models.Deployment{}, "environment_id", &models.Environment{}, "", nil},
+	{&models.Operation{}, "infra_id", &models.Infra{}, "", nil},
+	{&models.KubeSubEvent{}, "kube_event_id", &models.KubeEvent{}, "", nil},
+	{&models.StatusPageRelease{}, "status_page_id", &models.StatusPage{}, "", nil},
+	{&models.ClusterResolver{}, "cluster_candidate_id", &models.ClusterCandidate{}, "", nil},
+	{&models.GitActionConfig{}, "release_id", &models.Release{}, "", nil},
+	{&ints.ClusterTokenCache{}, "cluster_id", &models.Cluster{}, "", nil},
+	{&ints.RegTokenCache{}, "registry_id", &models.Registry{}, "", nil},
+	{&ints.HelmRepoTokenCache{}, "helm_repo_id", &models.HelmRepo{}, "", nil},
+}
+
 // ProjectRepository uses gorm.DB for querying the database
 type ProjectRepository struct {
 	db *gorm.DB
@@ -135,3 +250,121 @@ func (repo *ProjectRepository) DeleteProjectRole(projID, userID uint) (*models.R
 
 	return role, nil
 }
+
+// ListDeletedProjectsByUserID lists the projects deleted after a given time where a user
+// has an associated role
+func (repo *ProjectRepository) ListDeletedProjectsByUserID(userID uint, deletedAfter time.Time) ([]*models.Project, error) {
+	projects := make([]*models.Project, 0)
+
+	subQuery := repo.db.Model(&models.Role{}).Where("user_id = ?", userID).Select("project_id")
+
+	if err := repo.db.Unscoped().Preload("Roles").Model(&models.Project{}).
+		Where("id IN (?) AND deleted_at IS NOT NULL AND deleted_at > ?", subQuery, deletedAfter).
+		Order("deleted_at desc").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// RestoreProject restores a project which was deleted after a given time
+func (repo *ProjectRepository) RestoreProject(id uint, deletedAfter time.Time) (*models.Project, error) {
+	project := &models.Project{}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Model(&models.Project{}).
+			Where("id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", id, deletedAfter).
+			Update("deleted_at", nil)
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Preload("Roles").Where("id = ?", id).First(project).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// PurgeDeletedProjects permanently deletes projects which were deleted before a given time, along
+// with every row which belongs to them, in a single transaction
+func (repo *ProjectRepository) PurgeDeletedProjects(deletedBefore time.Time) (int64, error) {
+	var count int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		projectIDs := make([]uint, 0)
+
+		if err := tx.Unscoped().Model(&models.Project{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			Pluck("id", &projectIDs).Error; err != nil {
+			return err
+		}
+
+		if len(projectIDs) == 0 {
+			return nil
+		}
+
+		for _, child := range projectGrandchildModels {
+			// tables which were never created have no rows to purge
+			if !tx.Migrator().HasTable(child.model) || !tx.Migrator().HasTable(child.parent) {
+				continue
+			}
+
+			parentIDs := tx.Unscoped().Model(child.parent).Select("id")
+
+			if child.grandparent != nil {
+				if !tx.Migrator().HasTable(child.grandparent) {
+					continue
+				}
+
+				parentIDs = parentIDs.Where(
+					child.parentColumn+" IN (?)",
+					tx.Unscoped().Model(child.grandparent).Where("project_id IN (?)", projectIDs).Select("id"),
+				)
+			} else {
+				parentIDs = parentIDs.Where("project_id IN (?)", projectIDs)
+			}
+
+			if err := tx.Unscoped().Where(child.column+" IN (?)", parentIDs).Delete(child.model).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, child := range projectChildModels {
+			if !tx.Migrator().HasTable(child) {
+				continue
+			}
+
+			if err := tx.Unscoped().Where("project_id IN (?)", projectIDs).Delete(child).Error; err != nil {
+				return err
+			}
+		}
+
+		res := tx.Unscoped().Where("id IN (?)", projectIDs).Delete(&models.Project{})
+
+		count = res.RowsAffected
+
+		return res.Error
+	})
+
+	return count, err
+}
+
+// ListProjectIDs lists the IDs of every project of the instance which is not deleted
+func (repo *ProjectRepository) ListProjectIDs() ([]uint, error) {
+	ids := make([]uint, 0)
+
+	if err := repo.db.Model(&models.Project{}).Order("id asc").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
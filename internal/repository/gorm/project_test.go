@@ -2,6 +2,7 @@ package gorm_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-test/deep"
 	"github.com/porter-dev/porter/api/types"
@@ -356,3 +357,170 @@ func TestDeleteProjectRole(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestRestoreProject(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_restore_proj.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	initProjectRole(tester, t)
+	defer cleanup(tester, t)
+
+	projID := tester.initProjects[0].Model.ID
+
+	if _, err := tester.repo.Project().DeleteProject(tester.initProjects[0]); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.Project().ReadProject(projID); err != orm.ErrRecordNotFound {
+		t.Fatalf("expected deleted project to not be found, got %v\n", err)
+	}
+
+	deleted, err := tester.repo.Project().ListDeletedProjectsByUserID(tester.initUsers[0].Model.ID, time.Now().Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(deleted) != 1 || deleted[0].Model.ID != projID {
+		t.Fatalf("expected deleted project to be listed, got %d projects\n", len(deleted))
+	}
+
+	// projects deleted before the retention window cannot be restored
+	if _, err := tester.repo.Project().RestoreProject(projID, time.Now().Add(time.Hour)); err != orm.ErrRecordNotFound {
+		t.Fatalf("expected project outside of the retention window to not be restored, got %v\n", err)
+	}
+
+	proj, err := tester.repo.Project().RestoreProject(projID, time.Now().Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if proj.Model.ID != projID || len(proj.Roles) != 1 {
+		t.Errorf("expected restored project with its role, got project %d with %d roles\n", proj.Model.ID, len(proj.Roles))
+	}
+
+	if _, err := tester.repo.Project().DeleteProject(proj); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	count, err := tester.repo.Project().PurgeDeletedProjects(time.Now().Add(time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 purged project, got %d\n", count)
+	}
+
+	if _, err := tester.repo.Project().RestoreProject(projID, time.Time{}); err != orm.ErrRecordNotFound {
+		t.Errorf("expected purged project to not be restored, got %v\n", err)
+	}
+}
+
+func TestPurgeDeletedProjectChildren(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_purge_proj_children.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	initProjectRole(tester, t)
+	initCluster(tester, t)
+	initRegistry(tester, t)
+	initRelease(tester, t)
+	defer cleanup(tester, t)
+
+	projID := tester.initProjects[0].Model.ID
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: projID,
+		ClusterID: tester.initClusters[0].ID,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-42-porter",
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the rows of other projects are kept
+	other, err := tester.repo.Project().CreateProject(&models.Project{Name: "project-other"})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.Registry().CreateRegistry(&models.Registry{
+		ProjectID: other.ID,
+		Name:      "registry-other",
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.Project().DeleteProject(tester.initProjects[0]); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	count, err := tester.repo.Project().PurgeDeletedProjects(time.Now().Add(time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("expected 1 purged project, got %d\n", count)
+	}
+
+	children := []interface{}{
+		&models.Role{},
+		&models.Cluster{},
+		&models.Registry{},
+		&models.Release{},
+		&models.Environment{},
+	}
+
+	for _, child := range children {
+		var childCount int64
+
+		if err := tester.db.Unscoped().Model(child).Where("project_id = ?", projID).Count(&childCount).Error; err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if childCount != 0 {
+			t.Errorf("expected the %T rows of the purged project to be deleted, got %d\n", child, childCount)
+		}
+	}
+
+	var deplCount int64
+
+	if err := tester.db.Unscoped().Model(&models.Deployment{}).Where("environment_id = ?", env.ID).Count(&deplCount).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if deplCount != 0 {
+		t.Errorf("expected the deployments of the purged project to be deleted, got %d\n", deplCount)
+	}
+
+	regs, err := tester.repo.Registry().ListRegistriesByProjectID(other.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(regs) != 1 {
+		t.Errorf("expected the registry of the other project to be kept, got %d registries\n", len(regs))
+	}
+}
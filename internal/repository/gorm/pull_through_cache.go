@@ -0,0 +1,61 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PullThroughCacheRuleRepository uses gorm.DB for querying the database
+type PullThroughCacheRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewPullThroughCacheRuleRepository returns a PullThroughCacheRuleRepository which uses
+// gorm.DB for querying the database
+func NewPullThroughCacheRuleRepository(db *gorm.DB) repository.PullThroughCacheRuleRepository {
+	return &PullThroughCacheRuleRepository{db}
+}
+
+func (repo *PullThroughCacheRuleRepository) ListPullThroughCacheRulesByClusterID(
+	projectID, clusterID uint,
+) ([]*models.PullThroughCacheRule, error) {
+	rules := make([]*models.PullThroughCacheRule, 0)
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Order("upstream_registry").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ReplacePullThroughCacheRules replaces the rules of a cluster in a single transaction
+func (repo *PullThroughCacheRuleRepository) ReplacePullThroughCacheRules(
+	projectID, clusterID uint,
+	rules []*models.PullThroughCacheRule,
+) ([]*models.PullThroughCacheRule, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		// rules are hard deleted, so that the unique index allows re-adding an upstream registry
+		if err := tx.Unscoped().Where("project_id = ? AND cluster_id = ?", projectID, clusterID).
+			Delete(&models.PullThroughCacheRule{}).Error; err != nil {
+			return err
+		}
+
+		for _, rule := range rules {
+			rule.ProjectID = projectID
+			rule.ClusterID = clusterID
+
+			if err := tx.Create(rule).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
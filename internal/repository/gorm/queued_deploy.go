@@ -0,0 +1,102 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// QueuedDeployRepository uses gorm.DB for querying the database
+type QueuedDeployRepository struct {
+	db *gorm.DB
+}
+
+// NewQueuedDeployRepository returns a QueuedDeployRepository which uses gorm.DB for querying
+// the database
+func NewQueuedDeployRepository(db *gorm.DB) repository.QueuedDeployRepository {
+	return &QueuedDeployRepository{db}
+}
+
+func (repo *QueuedDeployRepository) CreateQueuedDeploy(deploy *models.QueuedDeploy) (*models.QueuedDeploy, error) {
+	if err := repo.db.Create(deploy).Error; err != nil {
+		return nil, err
+	}
+
+	return deploy, nil
+}
+
+func (repo *QueuedDeployRepository) ReadQueuedDeploy(clusterID, id uint) (*models.QueuedDeploy, error) {
+	deploy := &models.QueuedDeploy{}
+
+	if err := repo.db.Where("cluster_id = ? AND id = ?", clusterID, id).First(deploy).Error; err != nil {
+		return nil, err
+	}
+
+	return deploy, nil
+}
+
+func (repo *QueuedDeployRepository) ListQueuedDeploys(
+	clusterID uint,
+	namespace, name string,
+) ([]*models.QueuedDeploy, error) {
+	deploys := make([]*models.QueuedDeploy, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND name = ? AND status IN ?",
+		clusterID, namespace, name, []types.DeployStatus{types.DeployStatusQueued, types.DeployStatusRunning},
+	).Order("id asc").Find(&deploys).Error; err != nil {
+		return nil, err
+	}
+
+	return deploys, nil
+}
+
+func (repo *QueuedDeployRepository) ClaimQueuedDeploy(deploy *models.QueuedDeploy, now time.Time) (bool, error) {
+	res := repo.db.Model(&models.QueuedDeploy{}).
+		Where("id = ? AND status = ?", deploy.ID, types.DeployStatusQueued).
+		Updates(map[string]interface{}{
+			"status":     types.DeployStatusRunning,
+			"started_at": now,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	return true, repo.db.First(deploy, deploy.ID).Error
+}
+
+func (repo *QueuedDeployRepository) CancelQueuedDeploy(deploy *models.QueuedDeploy, userID uint, now time.Time) (bool, error) {
+	res := repo.db.Model(&models.QueuedDeploy{}).
+		Where("id = ? AND status = ?", deploy.ID, types.DeployStatusQueued).
+		Updates(map[string]interface{}{
+			"status":               types.DeployStatusCancelled,
+			"cancelled_by_user_id": userID,
+			"finished_at":          now,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	return true, repo.db.First(deploy, deploy.ID).Error
+}
+
+func (repo *QueuedDeployRepository) UpdateQueuedDeploy(deploy *models.QueuedDeploy) (*models.QueuedDeploy, error) {
+	if err := repo.db.Save(deploy).Error; err != nil {
+		return nil, err
+	}
+
+	return deploy, nil
+}
@@ -0,0 +1,84 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestClaimAndCancelQueuedDeploy(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_claim_queued_deploy.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	now := time.Now().UTC()
+
+	deploys := make([]*models.QueuedDeploy, 0)
+
+	for _, name := range []string{"web", "web", "worker"} {
+		deploy, err := tester.repo.QueuedDeploy().CreateQueuedDeploy(&models.QueuedDeploy{
+			ProjectID: tester.initProjects[0].ID,
+			ClusterID: 1,
+			Namespace: "default",
+			Name:      name,
+			Source:    types.DeploySourceAPI,
+			Status:    types.DeployStatusQueued,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		deploys = append(deploys, deploy)
+	}
+
+	claimed, err := tester.repo.QueuedDeploy().ClaimQueuedDeploy(deploys[0], now)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !claimed || deploys[0].Status != types.DeployStatusRunning || deploys[0].StartedAt == nil {
+		t.Errorf("expected deploy 1 to be claimed and running, got %t (%s)\n", claimed, deploys[0].Status)
+	}
+
+	// a running deploy cannot be claimed again or cancelled
+	if claimed, err := tester.repo.QueuedDeploy().ClaimQueuedDeploy(deploys[0], now); err != nil || claimed {
+		t.Errorf("expected running deploy not to be claimed again, got %t, %v\n", claimed, err)
+	}
+
+	if cancelled, err := tester.repo.QueuedDeploy().CancelQueuedDeploy(deploys[0], 1, now); err != nil || cancelled {
+		t.Errorf("expected running deploy not to be cancelled, got %t, %v\n", cancelled, err)
+	}
+
+	cancelled, err := tester.repo.QueuedDeploy().CancelQueuedDeploy(deploys[1], 1, now)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !cancelled || deploys[1].Status != types.DeployStatusCancelled || deploys[1].CancelledByUserID != 1 {
+		t.Errorf("expected deploy 2 to be cancelled by user 1, got %t (%s)\n", cancelled, deploys[1].Status)
+	}
+
+	// a cancelled deploy cannot be claimed
+	if claimed, err := tester.repo.QueuedDeploy().ClaimQueuedDeploy(deploys[1], now); err != nil || claimed {
+		t.Errorf("expected cancelled deploy not to be claimed, got %t, %v\n", claimed, err)
+	}
+
+	queue, err := tester.repo.QueuedDeploy().ListQueuedDeploys(1, "default", "web")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(queue) != 1 || queue[0].ID != deploys[0].ID {
+		t.Errorf("expected the queue of web to only contain deploy 1, got %d deploys\n", len(queue))
+	}
+}
@@ -0,0 +1,25 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// readReplica routes the queries of a session to the read replica, if one is configured,
+// and to the primary otherwise. It should only be used by list queries which can tolerate
+// replication lag, and never for reads which are followed by a write.
+func readReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(adapter.ReadReplicaResolver))
+}
+
+// listSession returns the session of a list query, which reads from the read replica if the
+// list options allow it, and from the primary otherwise
+func listSession(db *gorm.DB, opts *repository.ListOptions) *gorm.DB {
+	if opts != nil && opts.ReadReplica {
+		return readReplica(db)
+	}
+
+	return db
+}
@@ -0,0 +1,235 @@
+package gorm_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// initDeployment creates an environment with a deployment in the primary database
+func initDeployment(tester *tester, t *testing.T) *models.Environment {
+	t.Helper()
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID:         tester.initProjects[0].ID,
+		ClusterID:         1,
+		GitInstallationID: 5,
+		GitRepoOwner:      "porter-dev",
+		GitRepoName:       "porter",
+		Name:              "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1-porter",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return env
+}
+
+// useEmptyReplica registers an empty read replica, so that tests can tell which database a
+// query was routed to
+func useEmptyReplica(tester *tester, t *testing.T, fileName string) {
+	t.Helper()
+
+	replica, err := gorm.Open(sqlite.Open(fileName), &gorm.Config{})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	t.Cleanup(func() {
+		os.Remove(fileName)
+	})
+
+	if err := replica.AutoMigrate(&models.Environment{}, &models.Deployment{}, &models.DeploymentRecord{}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	err = tester.db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Open(fileName)},
+	}, adapter.ReadReplicaResolver))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+}
+
+func TestListDeploymentsReadReplica(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_deployments_read_replica.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	env := initDeployment(tester, t)
+
+	useEmptyReplica(tester, t, "./porter_list_deployments_read_replica_replica.db")
+
+	// lists are read from the primary by default, since their results may be written back
+	depls, err := tester.repo.Environment().ListDeployments(env.ID, nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(depls) != 1 {
+		t.Errorf("expected 1 deployment from the primary, got %d\n", len(depls))
+	}
+
+	depls, err = tester.repo.Environment().ListDeploymentsByCluster(env.ProjectID, env.ClusterID, &repository.ListOptions{})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(depls) != 1 {
+		t.Errorf("expected 1 deployment of the cluster from the primary, got %d\n", len(depls))
+	}
+
+	// lists which allow it are read from the empty replica
+	opts := &repository.ListOptions{ReadReplica: true}
+
+	depls, err = tester.repo.Environment().ListDeployments(env.ID, opts)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(depls) != 0 {
+		t.Errorf("expected no deployments from the replica, got %d\n", len(depls))
+	}
+
+	depls, err = tester.repo.Environment().ListDeploymentsByCluster(env.ProjectID, env.ClusterID, opts)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(depls) != 0 {
+		t.Errorf("expected no deployments of the cluster from the replica, got %d\n", len(depls))
+	}
+}
+
+func TestListDeploymentsWithoutReadReplica(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_deployments_without_read_replica.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	env := initDeployment(tester, t)
+
+	// without a replica, lists which allow reading from the replica fall back to the primary
+	opts := &repository.ListOptions{ReadReplica: true}
+
+	depls, err := tester.repo.Environment().ListDeployments(env.ID, opts)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(depls) != 1 {
+		t.Errorf("expected 1 deployment from the primary, got %d\n", len(depls))
+	}
+
+	depls, err = tester.repo.Environment().ListDeploymentsByCluster(env.ProjectID, env.ClusterID, opts)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(depls) != 1 {
+		t.Errorf("expected 1 deployment of the cluster from the primary, got %d\n", len(depls))
+	}
+}
+
+func TestListReleaseHistoryReadReplica(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_release_history_read_replica.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	for version := 1; version <= 2; version++ {
+		_, err := tester.repo.EmailPreference().CreateDeploymentRecord(&models.DeploymentRecord{
+			ProjectID: tester.initProjects[0].ID,
+			ClusterID: 1,
+			Name:      "web",
+			Namespace: "default",
+			Status:    "succeeded",
+			Version:   version,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	// deploys of other releases are not listed
+	_, err := tester.repo.EmailPreference().CreateDeploymentRecord(&models.DeploymentRecord{
+		ProjectID: tester.initProjects[0].ID,
+		ClusterID: 1,
+		Name:      "worker",
+		Namespace: "default",
+		Status:    "succeeded",
+		Version:   1,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// without a replica, the history is read from the primary
+	records, err := tester.repo.Release().ListReleaseHistory(1, "default", "web", &repository.ListOptions{ReadReplica: true})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 2 || records[0].Version != 2 || records[1].Version != 1 {
+		t.Fatalf("expected versions 2 and 1 of web from the primary, got %d records\n", len(records))
+	}
+
+	useEmptyReplica(tester, t, "./porter_list_release_history_read_replica_replica.db")
+
+	records, err = tester.repo.Release().ListReleaseHistory(1, "default", "web", nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 2 {
+		t.Errorf("expected 2 records from the primary, got %d\n", len(records))
+	}
+
+	records, err = tester.repo.Release().ListReleaseHistory(1, "default", "web", &repository.ListOptions{ReadReplica: true})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 0 {
+		t.Errorf("expected no records from the replica, got %d\n", len(records))
+	}
+}
@@ -0,0 +1,204 @@
+package gorm
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+)
+
+type encryptedModel struct {
+	model  interface{}
+	fields []string
+}
+
+// encryptedFields are the fields of each model which are encrypted at rest
+var encryptedFields = []encryptedModel{
+	{&models.Cluster{}, []string{"CertificateAuthorityData"}},
+	{&models.ClusterCandidate{}, []string{"AWSClusterIDGuess", "Kubeconfig"}},
+	{&models.Infra{}, []string{"LastApplied"}},
+	{&models.Operation{}, []string{"LastApplied"}},
+	{&ints.ClusterTokenCache{}, []string{"Token"}},
+	{&ints.RegTokenCache{}, []string{"Token"}},
+	{&ints.HelmRepoTokenCache{}, []string{"Token"}},
+	{&ints.KubeIntegration{}, []string{"ClientCertificateData", "ClientKeyData", "Token", "Username", "Password", "Kubeconfig"}},
+	{&ints.BasicIntegration{}, []string{"Username", "Password"}},
+	{&ints.OIDCIntegration{}, []string{"IssuerURL", "ClientID", "ClientSecret", "CertificateAuthorityData", "IDToken", "RefreshToken"}},
+	{&ints.OAuthIntegration{}, []string{"ClientID", "AccessToken", "RefreshToken"}},
+	{&ints.GCPIntegration{}, []string{"GCPKeyData"}},
+	{&ints.AWSIntegration{}, []string{"AWSClusterID", "AWSAccessKeyID", "AWSSecretAccessKey", "AWSSessionToken"}},
+	{&ints.AzureIntegration{}, []string{"ServicePrincipalSecret", "ACRPassword1", "ACRPassword2", "AKSPassword"}},
+	{&ints.GitlabIntegration{}, []string{"AppClientID", "AppClientSecret"}},
+	{&ints.SlackIntegration{}, []string{"ClientID", "AccessToken", "RefreshToken", "Webhook"}},
+	{&ints.DiscordIntegration{}, []string{"Webhook"}},
+	{&ints.TeamsIntegration{}, []string{"Webhook"}},
+	{&ints.PagerDutyIntegration{}, []string{"RoutingKey"}},
+	{&ints.DatadogIntegration{}, []string{"APIKey"}},
+	{&ints.SentryIntegration{}, []string{"AuthToken"}},
+	{&ints.JiraIntegration{}, []string{"APIToken"}},
+	{&ints.LinearIntegration{}, []string{"APIKey"}},
+	{&ints.DopplerIntegration{}, []string{"APIToken"}},
+	{&ints.GrafanaIntegration{}, []string{"APIKey"}},
+	{&ints.DNSProviderIntegration{}, []string{"CloudflareAPIToken"}},
+	{&ints.FeatureFlagIntegration{}, []string{"APIToken"}},
+	{&models.WebhookSubscription{}, []string{"Secret"}},
+}
+
+// RegisterEncryptedFields adds fields of a model which are encrypted at rest to the fields
+// re-encrypted by ReEncrypt. It is used by models which are not part of this package, such
+// as the ee models, and should be called from an init function.
+func RegisterEncryptedFields(model interface{}, fields ...string) {
+	encryptedFields = append(encryptedFields, encryptedModel{model, fields})
+}
+
+// process 100 rows at a time
+const reEncryptStepSize = 100
+
+// ReEncryptResult counts the encrypted values processed by ReEncrypt
+type ReEncryptResult struct {
+	// ReEncrypted is the number of values which were re-encrypted with the current key
+	ReEncrypted int
+
+	// Current is the number of values which were already encrypted with the current key
+	Current int
+
+	// Failed is the number of values which could not be decrypted with any known key,
+	// which are left unchanged
+	Failed int
+}
+
+// ReEncrypt re-encrypts every encrypted value in the database with the given key. Values
+// encrypted with a previous key are decrypted with the keys registered through
+// encryption.AddDecryptionKeys.
+//
+// Values which are already encrypted with the key are skipped, so the re-encryption can be
+// resumed if it is interrupted. A value is only overwritten if it has not changed since it
+// was read, so the re-encryption can run while the server is writing to the database.
+func ReEncrypt(db *gorm.DB, key *[32]byte) (*ReEncryptResult, error) {
+	res := &ReEncryptResult{}
+
+	for _, ef := range encryptedFields {
+		stmt := &gorm.Statement{DB: db}
+
+		if err := stmt.Parse(ef.model); err != nil {
+			return nil, err
+		}
+
+		// tables which are not used by this installation are skipped
+		if !db.Migrator().HasTable(stmt.Schema.Table) {
+			continue
+		}
+
+		columns := make([]string, 0)
+
+		for _, name := range ef.fields {
+			field := stmt.Schema.LookUpField(name)
+
+			if field == nil {
+				return nil, fmt.Errorf("field %s not found on table %s", name, stmt.Schema.Table)
+			}
+
+			columns = append(columns, field.DBName)
+		}
+
+		if err := reEncryptTable(db, stmt.Schema.Table, columns, key, res); err != nil {
+			return nil, fmt.Errorf("could not re-encrypt table %s: %w", stmt.Schema.Table, err)
+		}
+	}
+
+	return res, nil
+}
+
+func reEncryptTable(db *gorm.DB, table string, columns []string, key *[32]byte, res *ReEncryptResult) error {
+	var lastID uint
+
+	for {
+		rows := make([]map[string]interface{}, 0)
+
+		// soft-deleted rows are re-encrypted as well, since they may be restored
+		if err := db.Table(table).
+			Select(append([]string{"id"}, columns...)).
+			Where("id > ?", lastID).
+			Order("id asc").
+			Limit(reEncryptStepSize).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			id, err := toUint(row["id"])
+
+			if err != nil {
+				return err
+			}
+
+			lastID = id
+
+			for _, column := range columns {
+				var ciphertext []byte
+
+				// some drivers return binary columns as strings
+				switch value := row[column].(type) {
+				case []byte:
+					ciphertext = value
+				case string:
+					ciphertext = []byte(value)
+				}
+
+				if len(ciphertext) == 0 {
+					continue
+				}
+
+				if encryption.HasKeyID(ciphertext, key) {
+					res.Current++
+					continue
+				}
+
+				plaintext, err := encryption.Decrypt(ciphertext, key)
+
+				if err != nil {
+					res.Failed++
+					continue
+				}
+
+				newCiphertext, err := encryption.Encrypt(plaintext, key)
+
+				if err != nil {
+					return err
+				}
+
+				if err := db.Table(table).
+					Where("id = ?", id).
+					Where(fmt.Sprintf("%s = ?", column), ciphertext).
+					UpdateColumn(column, newCiphertext).Error; err != nil {
+					return err
+				}
+
+				res.ReEncrypted++
+			}
+		}
+
+		if len(rows) < reEncryptStepSize {
+			return nil
+		}
+	}
+}
+
+func toUint(v interface{}) (uint, error) {
+	switch id := v.(type) {
+	case int64:
+		return uint(id), nil
+	case int32:
+		return uint(id), nil
+	case int:
+		return uint(id), nil
+	case uint:
+		return id, nil
+	case uint64:
+		return uint(id), nil
+	}
+
+	return 0, fmt.Errorf("unexpected id type %T", v)
+}
@@ -0,0 +1,71 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository/gorm"
+)
+
+func TestReEncrypt(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_reencrypt.db",
+	}
+
+	setupTestEnv(tester, t)
+	initKubeIntegration(tester, t)
+	defer cleanup(tester, t)
+
+	var newKey [32]byte
+
+	for i, b := range []byte("__new_strong_encryption_key_____") {
+		newKey[i] = b
+	}
+
+	// the kube integration was encrypted with the previous key
+	encryption.AddDecryptionKeys(tester.key)
+
+	res, err := gorm.ReEncrypt(tester.db, &newKey)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if res.ReEncrypted != 1 || res.Current != 0 || res.Failed != 0 {
+		t.Errorf("incorrect re-encryption result: expected 1 re-encrypted, got %+v\n", res)
+	}
+
+	stored := &ints.KubeIntegration{}
+
+	if err := tester.db.First(stored, tester.initKIs[0].ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !encryption.HasKeyID(stored.Kubeconfig, &newKey) {
+		t.Errorf("kubeconfig was not encrypted with the new key\n")
+	}
+
+	ki, err := gorm.NewRepository(tester.db, &newKey, nil).KubeIntegration().ReadKubeIntegration(
+		tester.initProjects[0].ID, tester.initKIs[0].ID,
+	)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(ki.Kubeconfig) != "current-context: testing\n" {
+		t.Errorf("incorrect kubeconfig: expected %s, got %s\n", "current-context: testing\n", ki.Kubeconfig)
+	}
+
+	// a second run should not re-encrypt anything
+	res, err = gorm.ReEncrypt(tester.db, &newKey)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if res.ReEncrypted != 0 || res.Current != 1 || res.Failed != 0 {
+		t.Errorf("incorrect re-encryption result: expected 1 current, got %+v\n", res)
+	}
+}
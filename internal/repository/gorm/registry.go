@@ -89,6 +89,23 @@ func (repo *RegistryRepository) ReadRegistryByInfraID(projectID, infraID uint) (
 	return reg, nil
 }
 
+// ReadRegistryByWebhookToken finds a registry by the token of its push webhook
+func (repo *RegistryRepository) ReadRegistryByWebhookToken(token string) (*models.Registry, error) {
+	reg := &models.Registry{}
+
+	if token == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := repo.db.Preload("TokenCache").Where("webhook_token = ?", token).First(&reg).Error; err != nil {
+		return nil, err
+	}
+
+	repo.DecryptRegistryData(reg, repo.key)
+
+	return reg, nil
+}
+
 // ListRegistriesByProjectID finds all registries
 // for a given project id
 func (repo *RegistryRepository) ListRegistriesByProjectID(
@@ -0,0 +1,52 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RegistryCredentialRefreshRepository uses gorm.DB for querying the database
+type RegistryCredentialRefreshRepository struct {
+	db *gorm.DB
+}
+
+// NewRegistryCredentialRefreshRepository returns a RegistryCredentialRefreshRepository which
+// uses gorm.DB for querying the database
+func NewRegistryCredentialRefreshRepository(db *gorm.DB) repository.RegistryCredentialRefreshRepository {
+	return &RegistryCredentialRefreshRepository{db}
+}
+
+func (repo *RegistryCredentialRefreshRepository) CreateOrUpdateRegistryCredentialRefresh(
+	refresh *models.RegistryCredentialRefresh,
+) (*models.RegistryCredentialRefresh, error) {
+	if err := repo.db.Save(refresh).Error; err != nil {
+		return nil, err
+	}
+
+	return refresh, nil
+}
+
+func (repo *RegistryCredentialRefreshRepository) ReadRegistryCredentialRefresh(
+	clusterID, registryID uint,
+) (*models.RegistryCredentialRefresh, error) {
+	refresh := &models.RegistryCredentialRefresh{}
+
+	if err := repo.db.Where("cluster_id = ? AND registry_id = ?", clusterID, registryID).First(refresh).Error; err != nil {
+		return nil, err
+	}
+
+	return refresh, nil
+}
+
+func (repo *RegistryCredentialRefreshRepository) ListRegistryCredentialRefreshesByClusterID(
+	projectID, clusterID uint,
+) ([]*models.RegistryCredentialRefresh, error) {
+	refreshes := make([]*models.RegistryCredentialRefresh, 0)
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Find(&refreshes).Error; err != nil {
+		return nil, err
+	}
+
+	return refreshes, nil
+}
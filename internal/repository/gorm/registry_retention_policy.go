@@ -0,0 +1,54 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionPolicyRepository uses gorm.DB for querying the database
+type RegistryRetentionPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewRegistryRetentionPolicyRepository returns a RegistryRetentionPolicyRepository which uses
+// gorm.DB for querying the database
+func NewRegistryRetentionPolicyRepository(db *gorm.DB) repository.RegistryRetentionPolicyRepository {
+	return &RegistryRetentionPolicyRepository{db}
+}
+
+func (repo *RegistryRetentionPolicyRepository) CreateOrUpdateRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) (*models.RegistryRetentionPolicy, error) {
+	if err := repo.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *RegistryRetentionPolicyRepository) ReadRegistryRetentionPolicy(
+	projectID, registryID uint,
+) (*models.RegistryRetentionPolicy, error) {
+	policy := &models.RegistryRetentionPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND registry_id = ?", projectID, registryID).First(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *RegistryRetentionPolicyRepository) ListEnabledRegistryRetentionPolicies() ([]*models.RegistryRetentionPolicy, error) {
+	policies := make([]*models.RegistryRetentionPolicy, 0)
+
+	if err := repo.db.Where("enabled = ?", true).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (repo *RegistryRetentionPolicyRepository) DeleteRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) error {
+	return repo.db.Delete(policy).Error
+}
@@ -34,19 +34,90 @@ func (repo *ReleaseRepository) ReadRelease(clusterID uint, name, namespace strin
 	return release, nil
 }
 
-// ReadRelease finds a single release based on their unique name and namespace pair.
-func (repo *ReleaseRepository) ListReleasesByImageRepoURI(clusterID uint, imageRepoURI string) ([]*models.Release, error) {
+var releaseListColumns = &listColumns{
+	table: "releases",
+	filters: map[string]string{
+		"namespace": "namespace",
+		"name":      "name",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortAsc,
+}
+
+// ListReleasesByImageRepoURI finds all releases in a cluster which use a given image repository
+func (repo *ReleaseRepository) ListReleasesByImageRepoURI(
+	clusterID uint,
+	imageRepoURI string,
+	opts *repository.ListOptions,
+) ([]*models.Release, error) {
 	releases := make([]*models.Release, 0)
 
 	if imageRepoURI == "" {
 		return releases, nil
 	}
 
-	if err := repo.db.Preload("GitActionConfig").Preload("Tags").Where("cluster_id = ?", clusterID).Where("image_repo_uri = ?", imageRepoURI).Find(&releases).Error; err != nil {
+	query, err := applyListOptions(
+		repo.db.Preload("GitActionConfig").Preload("Tags").Where("cluster_id = ?", clusterID).Where("image_repo_uri = ?", imageRepoURI),
+		opts, releaseListColumns,
+	)
+
+	if err != nil {
 		return nil, err
 	}
 
-	return releases, nil
+	if err := query.Find(&releases).Error; err != nil {
+		return nil, err
+	}
+
+	n := trimPage(opts, releaseListColumns, len(releases), func(i int) gorm.Model {
+		return releases[i].Model
+	})
+
+	return releases[:n], nil
+}
+
+var releaseHistoryListColumns = &listColumns{
+	table: "deployment_records",
+	filters: map[string]string{
+		"status": "status",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortDesc,
+}
+
+// ListReleaseHistory lists the deploys of a release which were recorded by Porter
+func (repo *ReleaseRepository) ListReleaseHistory(
+	clusterID uint,
+	namespace, name string,
+	opts *repository.ListOptions,
+) ([]*models.DeploymentRecord, error) {
+	query, err := applyListOptions(
+		listSession(repo.db, opts).Where("cluster_id = ? AND namespace = ? AND name = ?", clusterID, namespace, name),
+		opts, releaseHistoryListColumns,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*models.DeploymentRecord, 0)
+
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	n := trimPage(opts, releaseHistoryListColumns, len(records), func(i int) gorm.Model {
+		return records[i].Model
+	})
+
+	return records[:n], nil
 }
 
 // ReadReleaseByWebhookToken finds a single release based on their unique webhook token.
@@ -58,6 +129,28 @@ func (repo *ReleaseRepository) ReadReleaseByWebhookToken(token string) (*models.
 	return release, nil
 }
 
+func (repo *ReleaseRepository) SearchReleases(projectIDs []uint, query string, limit int) ([]*models.Release, error) {
+	releases := make([]*models.Release, 0)
+
+	if len(projectIDs) == 0 {
+		return releases, nil
+	}
+
+	cond, args := searchCondition(query, "releases.name", "releases.namespace", "git_action_configs.git_repo")
+
+	if err := repo.db.Preload("GitActionConfig").
+		Joins("LEFT JOIN git_action_configs ON git_action_configs.release_id = releases.id AND git_action_configs.deleted_at IS NULL").
+		Where("releases.project_id IN ?", projectIDs).
+		Where(cond, args...).
+		Order("releases.updated_at desc").
+		Limit(limit).
+		Find(&releases).Error; err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
 // UpdateRelease modifies an existing Release in the database
 func (repo *ReleaseRepository) UpdateRelease(release *models.Release) (*models.Release, error) {
 	if err := repo.db.Save(release).Error; err != nil {
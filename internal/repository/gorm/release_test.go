@@ -97,7 +97,7 @@ func TestListReleasesByImageRepoURI(t *testing.T) {
 		}
 	}
 
-	resReleases, err := tester.repo.Release().ListReleasesByImageRepoURI(1, "uri1")
+	resReleases, err := tester.repo.Release().ListReleasesByImageRepoURI(1, "uri1", nil)
 
 	if err != nil {
 		t.Fatalf("%v\n", err)
@@ -114,6 +114,48 @@ func TestListReleasesByImageRepoURI(t *testing.T) {
 	}
 }
 
+func TestSearchReleases(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_search_releases.db",
+	}
+
+	setupTestEnv(tester, t)
+	initRelease(tester, t)
+	defer cleanup(tester, t)
+
+	// the search should ignore case, and should not match releases of other projects
+	releases, err := tester.repo.Release().SearchReleases([]uint{1}, "MEISTER", 10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(releases) != 1 || releases[0].ID != tester.initReleases[0].ID {
+		t.Fatalf("incorrect search results: expected release %d, got %d results\n", tester.initReleases[0].ID, len(releases))
+	}
+
+	releases, err = tester.repo.Release().SearchReleases([]uint{2}, "meister", 10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(releases) != 0 {
+		t.Errorf("incorrect search results: expected no results, got %d\n", len(releases))
+	}
+
+	// wildcards in the query should be matched literally
+	releases, err = tester.repo.Release().SearchReleases([]uint{1}, "%", 10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(releases) != 0 {
+		t.Errorf("incorrect search results: expected no results, got %d\n", len(releases))
+	}
+}
+
 func TestDeleteRelease(t *testing.T) {
 	tester := &tester{
 		dbFileName: "./porter_delete_release.db",
@@ -33,6 +33,15 @@ type GormRepository struct {
 	githubAppInstallation     repository.GithubAppInstallationRepository
 	githubAppOAuthIntegration repository.GithubAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	discordIntegration        repository.DiscordIntegrationRepository
+	teamsIntegration          repository.TeamsIntegrationRepository
+	pagerDutyIntegration      repository.PagerDutyIntegrationRepository
+	datadogIntegration        repository.DatadogIntegrationRepository
+	sentryIntegration         repository.SentryIntegrationRepository
+	jiraIntegration           repository.JiraIntegrationRepository
+	linearIntegration         repository.LinearIntegrationRepository
+	dopplerIntegration        repository.DopplerIntegrationRepository
+	grafanaIntegration        repository.GrafanaIntegrationRepository
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
@@ -49,6 +58,43 @@ type GormRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	auditLog                  repository.AuditLogRepository
+	clusterIncident           repository.ClusterIncidentRepository
+	registryRetentionPolicy   repository.RegistryRetentionPolicyRepository
+	registryCredentialRefresh repository.RegistryCredentialRefreshRepository
+	build                     repository.BuildRepository
+	imageSignaturePolicy      repository.ImageSignaturePolicyRepository
+	pullThroughCacheRule      repository.PullThroughCacheRuleRepository
+	bulkDeploymentOperation   repository.BulkDeploymentOperationRepository
+	backgroundJob             repository.BackgroundJobRepository
+	archive                   repository.ArchiveRepository
+	webhookSubscription       repository.WebhookSubscriptionRepository
+	emailPreference           repository.EmailPreferenceRepository
+	notificationPreference    repository.NotificationPreferenceRepository
+	statusPage                repository.StatusPageRepository
+	envGroupSource            repository.EnvGroupSourceRepository
+	envGroupRotationPolicy    repository.EnvGroupRotationPolicyRepository
+	externalSecretStore       repository.ExternalSecretStoreRepository
+	costRecord                repository.CostRecordRepository
+	multiClusterRelease       repository.MultiClusterReleaseRepository
+	dnsProviderIntegration    repository.DNSProviderIntegrationRepository
+	customDomainDNSRecord     repository.CustomDomainDNSRecordRepository
+	wildcardCertificate       repository.WildcardCertificateRepository
+	approval                  repository.ApprovalRepository
+	freezeWindow              repository.FreezeWindowRepository
+	queuedDeploy              repository.QueuedDeployRepository
+	gitOpsExport              repository.GitOpsExportRepository
+	workflowTemplate          repository.WorkflowTemplateRepository
+	clusterTunnel             repository.ClusterTunnelRepository
+	clusterRelay              repository.ClusterRelayRepository
+	featureFlagIntegration    repository.FeatureFlagIntegrationRepository
+	deployFeatureFlag         repository.DeployFeatureFlagRepository
+	logAlertRule              repository.LogAlertRuleRepository
+	leaderLease               repository.LeaderLeaseRepository
+
+	db             *gorm.DB
+	key            *[32]byte
+	storageBackend credentials.CredentialStorage
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -155,6 +201,42 @@ func (t *GormRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *GormRepository) DiscordIntegration() repository.DiscordIntegrationRepository {
+	return t.discordIntegration
+}
+
+func (t *GormRepository) TeamsIntegration() repository.TeamsIntegrationRepository {
+	return t.teamsIntegration
+}
+
+func (t *GormRepository) PagerDutyIntegration() repository.PagerDutyIntegrationRepository {
+	return t.pagerDutyIntegration
+}
+
+func (t *GormRepository) DatadogIntegration() repository.DatadogIntegrationRepository {
+	return t.datadogIntegration
+}
+
+func (t *GormRepository) SentryIntegration() repository.SentryIntegrationRepository {
+	return t.sentryIntegration
+}
+
+func (t *GormRepository) JiraIntegration() repository.JiraIntegrationRepository {
+	return t.jiraIntegration
+}
+
+func (t *GormRepository) LinearIntegration() repository.LinearIntegrationRepository {
+	return t.linearIntegration
+}
+
+func (t *GormRepository) DopplerIntegration() repository.DopplerIntegrationRepository {
+	return t.dopplerIntegration
+}
+
+func (t *GormRepository) GrafanaIntegration() repository.GrafanaIntegrationRepository {
+	return t.grafanaIntegration
+}
+
 func (t *GormRepository) GitlabIntegration() repository.GitlabIntegrationRepository {
 	return t.gitlabIntegration
 }
@@ -219,10 +301,153 @@ func (t *GormRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *GormRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
+func (t *GormRepository) ClusterIncident() repository.ClusterIncidentRepository {
+	return t.clusterIncident
+}
+
+func (t *GormRepository) RegistryRetentionPolicy() repository.RegistryRetentionPolicyRepository {
+	return t.registryRetentionPolicy
+}
+
+func (t *GormRepository) RegistryCredentialRefresh() repository.RegistryCredentialRefreshRepository {
+	return t.registryCredentialRefresh
+}
+
+func (t *GormRepository) Build() repository.BuildRepository {
+	return t.build
+}
+
+func (t *GormRepository) ImageSignaturePolicy() repository.ImageSignaturePolicyRepository {
+	return t.imageSignaturePolicy
+}
+
+func (t *GormRepository) PullThroughCacheRule() repository.PullThroughCacheRuleRepository {
+	return t.pullThroughCacheRule
+}
+
+func (t *GormRepository) BulkDeploymentOperation() repository.BulkDeploymentOperationRepository {
+	return t.bulkDeploymentOperation
+}
+
+func (t *GormRepository) BackgroundJob() repository.BackgroundJobRepository {
+	return t.backgroundJob
+}
+
+func (t *GormRepository) Archive() repository.ArchiveRepository {
+	return t.archive
+}
+
+func (t *GormRepository) WebhookSubscription() repository.WebhookSubscriptionRepository {
+	return t.webhookSubscription
+}
+
+func (t *GormRepository) EmailPreference() repository.EmailPreferenceRepository {
+	return t.emailPreference
+}
+
+func (t *GormRepository) NotificationPreference() repository.NotificationPreferenceRepository {
+	return t.notificationPreference
+}
+
+func (t *GormRepository) StatusPage() repository.StatusPageRepository {
+	return t.statusPage
+}
+
+func (t *GormRepository) EnvGroupSource() repository.EnvGroupSourceRepository {
+	return t.envGroupSource
+}
+
+func (t *GormRepository) EnvGroupRotationPolicy() repository.EnvGroupRotationPolicyRepository {
+	return t.envGroupRotationPolicy
+}
+
+func (t *GormRepository) ExternalSecretStore() repository.ExternalSecretStoreRepository {
+	return t.externalSecretStore
+}
+
+func (t *GormRepository) CostRecord() repository.CostRecordRepository {
+	return t.costRecord
+}
+
+func (t *GormRepository) MultiClusterRelease() repository.MultiClusterReleaseRepository {
+	return t.multiClusterRelease
+}
+
+func (t *GormRepository) DNSProviderIntegration() repository.DNSProviderIntegrationRepository {
+	return t.dnsProviderIntegration
+}
+
+func (t *GormRepository) CustomDomainDNSRecord() repository.CustomDomainDNSRecordRepository {
+	return t.customDomainDNSRecord
+}
+
+func (t *GormRepository) WildcardCertificate() repository.WildcardCertificateRepository {
+	return t.wildcardCertificate
+}
+
+func (t *GormRepository) Approval() repository.ApprovalRepository {
+	return t.approval
+}
+
+func (t *GormRepository) FreezeWindow() repository.FreezeWindowRepository {
+	return t.freezeWindow
+}
+
+func (t *GormRepository) QueuedDeploy() repository.QueuedDeployRepository {
+	return t.queuedDeploy
+}
+
+func (t *GormRepository) GitOpsExport() repository.GitOpsExportRepository {
+	return t.gitOpsExport
+}
+
+func (t *GormRepository) WorkflowTemplate() repository.WorkflowTemplateRepository {
+	return t.workflowTemplate
+}
+
+func (t *GormRepository) ClusterTunnel() repository.ClusterTunnelRepository {
+	return t.clusterTunnel
+}
+
+func (t *GormRepository) ClusterRelay() repository.ClusterRelayRepository {
+	return t.clusterRelay
+}
+
+func (t *GormRepository) FeatureFlagIntegration() repository.FeatureFlagIntegrationRepository {
+	return t.featureFlagIntegration
+}
+
+func (t *GormRepository) DeployFeatureFlag() repository.DeployFeatureFlagRepository {
+	return t.deployFeatureFlag
+}
+
+func (t *GormRepository) LogAlertRule() repository.LogAlertRuleRepository {
+	return t.logAlertRule
+}
+
+func (t *GormRepository) LeaderLease() repository.LeaderLeaseRepository {
+	return t.leaderLease
+}
+
+// Transaction runs fn in a database transaction. Transactions which are started from the
+// repository passed to fn are nested with savepoints.
+func (t *GormRepository) Transaction(fn func(tx repository.Repository) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepository(tx, t.key, t.storageBackend))
+	})
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
 	return &GormRepository{
+		db:                        db,
+		key:                       key,
+		storageBackend:            storageBackend,
 		user:                      NewUserRepository(db),
 		session:                   NewSessionRepository(db),
 		project:                   NewProjectRepository(db),
@@ -249,6 +474,15 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		githubAppInstallation:     NewGithubAppInstallationRepository(db),
 		githubAppOAuthIntegration: NewGithubAppOAuthIntegrationRepository(db),
 		slackIntegration:          NewSlackIntegrationRepository(db, key),
+		discordIntegration:        NewDiscordIntegrationRepository(db, key),
+		teamsIntegration:          NewTeamsIntegrationRepository(db, key),
+		pagerDutyIntegration:      NewPagerDutyIntegrationRepository(db, key),
+		datadogIntegration:        NewDatadogIntegrationRepository(db, key),
+		sentryIntegration:         NewSentryIntegrationRepository(db, key),
+		jiraIntegration:           NewJiraIntegrationRepository(db, key),
+		linearIntegration:         NewLinearIntegrationRepository(db, key),
+		dopplerIntegration:        NewDopplerIntegrationRepository(db, key),
+		grafanaIntegration:        NewGrafanaIntegrationRepository(db, key),
 		gitlabIntegration:         NewGitlabIntegrationRepository(db, key, storageBackend),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(db, key, storageBackend),
 		notificationConfig:        NewNotificationConfigRepository(db),
@@ -265,5 +499,38 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		tag:                       NewTagRepository(db),
 		stack:                     NewStackRepository(db),
 		monitor:                   NewMonitorTestResultRepository(db),
+		auditLog:                  NewAuditLogRepository(db),
+		clusterIncident:           NewClusterIncidentRepository(db),
+		registryRetentionPolicy:   NewRegistryRetentionPolicyRepository(db),
+		registryCredentialRefresh: NewRegistryCredentialRefreshRepository(db),
+		build:                     NewBuildRepository(db),
+		imageSignaturePolicy:      NewImageSignaturePolicyRepository(db),
+		pullThroughCacheRule:      NewPullThroughCacheRuleRepository(db),
+		bulkDeploymentOperation:   NewBulkDeploymentOperationRepository(db),
+		backgroundJob:             NewBackgroundJobRepository(db),
+		archive:                   NewArchiveRepository(db),
+		webhookSubscription:       NewWebhookSubscriptionRepository(db, key),
+		emailPreference:           NewEmailPreferenceRepository(db),
+		notificationPreference:    NewNotificationPreferenceRepository(db),
+		statusPage:                NewStatusPageRepository(db),
+		envGroupSource:            NewEnvGroupSourceRepository(db),
+		envGroupRotationPolicy:    NewEnvGroupRotationPolicyRepository(db),
+		externalSecretStore:       NewExternalSecretStoreRepository(db),
+		costRecord:                NewCostRecordRepository(db),
+		multiClusterRelease:       NewMultiClusterReleaseRepository(db),
+		dnsProviderIntegration:    NewDNSProviderIntegrationRepository(db, key),
+		customDomainDNSRecord:     NewCustomDomainDNSRecordRepository(db),
+		wildcardCertificate:       NewWildcardCertificateRepository(db),
+		approval:                  NewApprovalRepository(db),
+		freezeWindow:              NewFreezeWindowRepository(db),
+		queuedDeploy:              NewQueuedDeployRepository(db),
+		gitOpsExport:              NewGitOpsExportRepository(db),
+		workflowTemplate:          NewWorkflowTemplateRepository(db),
+		clusterTunnel:             NewClusterTunnelRepository(db),
+		clusterRelay:              NewClusterRelayRepository(db),
+		featureFlagIntegration:    NewFeatureFlagIntegrationRepository(db, key),
+		deployFeatureFlag:         NewDeployFeatureFlagRepository(db),
+		logAlertRule:              NewLogAlertRuleRepository(db),
+		leaderLease:               NewLeaderLeaseRepository(db),
 	}
 }
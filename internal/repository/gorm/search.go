@@ -0,0 +1,24 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// searchCondition returns a condition which matches rows where any of the columns contains
+// the query, ignoring case. Columns are compared in lower case so that the trigram indexes
+// on the lower-cased columns can be used on postgres.
+func searchCondition(query string, columns ...string) (string, []interface{}) {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	pattern := "%" + replacer.Replace(strings.ToLower(query)) + "%"
+
+	conds := make([]string, 0, len(columns))
+	args := make([]interface{}, 0, len(columns))
+
+	for _, column := range columns {
+		conds = append(conds, fmt.Sprintf(`LOWER(%s) LIKE ? ESCAPE '\'`, column))
+		args = append(args, pattern)
+	}
+
+	return "(" + strings.Join(conds, " OR ") + ")", args
+}
@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// SentryIntegrationRepository uses gorm.DB for querying the database
+type SentryIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewSentryIntegrationRepository returns a SentryIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewSentryIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.SentryIntegrationRepository {
+	return &SentryIntegrationRepository{db, key}
+}
+
+// CreateSentryIntegration creates a new Sentry integration
+func (repo *SentryIntegrationRepository) CreateSentryIntegration(
+	sentryInt *ints.SentryIntegration,
+) (*ints.SentryIntegration, error) {
+	authToken := sentryInt.AuthToken
+
+	cipherData, err := encryption.Encrypt(authToken, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sentryInt.AuthToken = cipherData
+
+	if err := repo.db.Create(sentryInt).Error; err != nil {
+		return nil, err
+	}
+
+	sentryInt.AuthToken = authToken
+
+	return sentryInt, nil
+}
+
+// ReadSentryIntegration finds a Sentry integration of a project by its ID
+func (repo *SentryIntegrationRepository) ReadSentryIntegration(
+	projectID, integrationID uint,
+) (*ints.SentryIntegration, error) {
+	sentryInt := &ints.SentryIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(sentryInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptAuthToken(sentryInt); err != nil {
+		return nil, err
+	}
+
+	return sentryInt, nil
+}
+
+// ListSentryIntegrationsByProjectID finds all Sentry integrations of a project
+func (repo *SentryIntegrationRepository) ListSentryIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.SentryIntegration, error) {
+	sentryInts := []*ints.SentryIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&sentryInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, sentryInt := range sentryInts {
+		if err := repo.decryptAuthToken(sentryInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return sentryInts, nil
+}
+
+// DeleteSentryIntegration deletes a Sentry integration
+func (repo *SentryIntegrationRepository) DeleteSentryIntegration(
+	sentryInt *ints.SentryIntegration,
+) error {
+	return repo.db.Delete(sentryInt).Error
+}
+
+func (repo *SentryIntegrationRepository) decryptAuthToken(sentryInt *ints.SentryIntegration) error {
+	if len(sentryInt.AuthToken) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(sentryInt.AuthToken, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	sentryInt.AuthToken = plaintext
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestSentryIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_sentry.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	authToken := "sntrys_a8d2c4f61e0b9735d2c4a8f61e0b9735"
+
+	sentryInt, err := tester.repo.SentryIntegration().CreateSentryIntegration(&ints.SentryIntegration{
+		ProjectID:        projectID,
+		Name:             "production",
+		OrganizationSlug: "porter",
+		SentryProjects:   "api,worker",
+		AuthToken:        []byte(authToken),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the auth token should be encrypted at rest, and decrypted when read
+	stored := &ints.SentryIntegration{}
+
+	if err := tester.db.First(stored, sentryInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.AuthToken) == authToken {
+		t.Errorf("auth token was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.SentryIntegration().ReadSentryIntegration(projectID, sentryInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.AuthToken) != authToken {
+		t.Errorf("incorrect auth token: expected %s, got %s\n", authToken, readInt.AuthToken)
+	}
+
+	if projects := readInt.GetSentryProjects(); len(projects) != 2 || projects[0] != "api" || projects[1] != "worker" {
+		t.Errorf("incorrect sentry projects: expected [api worker], got %v\n", projects)
+	}
+
+	sentryInts, err := tester.repo.SentryIntegration().ListSentryIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(sentryInts) != 1 || string(sentryInts[0].AuthToken) != authToken {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted auth token\n")
+	}
+
+	if err := tester.repo.SentryIntegration().DeleteSentryIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.SentryIntegration().ReadSentryIntegration(projectID, sentryInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
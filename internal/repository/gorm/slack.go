@@ -59,15 +59,61 @@ func (repo *SlackIntegrationRepository) ListSlackIntegrationsByProjectID(
 	return slackInts, nil
 }
 
-// DeleteSlackIntegration deletes a slack integration by ID
+// DeleteSlackIntegration deletes a slack integration by ID, along with its routing rules
 func (repo *SlackIntegrationRepository) DeleteSlackIntegration(
 	integrationID uint,
 ) error {
-	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.SlackIntegration{}).Error; err != nil {
-		return err
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("slack_integration_id = ?", integrationID).Delete(&ints.SlackRoutingRule{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", integrationID).Delete(&ints.SlackIntegration{}).Error
+	})
+}
+
+// CreateSlackRoutingRule creates a rule which routes events to a Slack integration
+func (repo *SlackIntegrationRepository) CreateSlackRoutingRule(
+	rule *ints.SlackRoutingRule,
+) (*ints.SlackRoutingRule, error) {
+	if err := repo.db.Create(rule).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return rule, nil
+}
+
+// ReadSlackRoutingRule reads a routing rule of a project
+func (repo *SlackIntegrationRepository) ReadSlackRoutingRule(
+	projectID, ruleID uint,
+) (*ints.SlackRoutingRule, error) {
+	rule := &ints.SlackRoutingRule{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, ruleID).First(rule).Error; err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// ListSlackRoutingRules lists the routing rules of every Slack integration of a project
+func (repo *SlackIntegrationRepository) ListSlackRoutingRules(
+	projectID uint,
+) ([]*ints.SlackRoutingRule, error) {
+	rules := []*ints.SlackRoutingRule{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// DeleteSlackRoutingRule deletes a routing rule
+func (repo *SlackIntegrationRepository) DeleteSlackRoutingRule(
+	rule *ints.SlackRoutingRule,
+) error {
+	return repo.db.Delete(rule).Error
 }
 
 // EncryptSlackIntegrationData will encrypt the slack integration data before
@@ -0,0 +1,89 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// StatusPageRepository uses gorm.DB for querying the database
+type StatusPageRepository struct {
+	db *gorm.DB
+}
+
+// NewStatusPageRepository returns a StatusPageRepository which uses gorm.DB for querying
+// the database
+func NewStatusPageRepository(db *gorm.DB) repository.StatusPageRepository {
+	return &StatusPageRepository{db}
+}
+
+// CreateStatusPage creates a status page along with its releases
+func (repo *StatusPageRepository) CreateStatusPage(page *models.StatusPage) (*models.StatusPage, error) {
+	if err := repo.db.Create(page).Error; err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (repo *StatusPageRepository) ReadStatusPageByProjectID(projectID uint) (*models.StatusPage, error) {
+	return repo.readStatusPage(repo.db.Where("project_id = ?", projectID))
+}
+
+func (repo *StatusPageRepository) ReadStatusPageBySubdomain(subdomain string) (*models.StatusPage, error) {
+	return repo.readStatusPage(repo.db.Where("subdomain = ?", subdomain))
+}
+
+func (repo *StatusPageRepository) readStatusPage(query *gorm.DB) (*models.StatusPage, error) {
+	page := &models.StatusPage{}
+
+	if err := query.Preload("Releases", func(db *gorm.DB) *gorm.DB {
+		return db.Order("status_page_releases.id asc")
+	}).First(page).Error; err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// UpdateStatusPage updates a status page and replaces its releases
+func (repo *StatusPageRepository) UpdateStatusPage(page *models.StatusPage) (*models.StatusPage, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Releases").Save(page).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("status_page_id = ?", page.ID).Delete(&models.StatusPageRelease{}).Error; err != nil {
+			return err
+		}
+
+		for i := range page.Releases {
+			page.Releases[i].ID = 0
+			page.Releases[i].StatusPageID = page.ID
+		}
+
+		if len(page.Releases) == 0 {
+			return nil
+		}
+
+		return tx.Create(&page.Releases).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// DeleteStatusPage deletes a status page and its releases. The page is deleted permanently so
+// that its subdomain can be claimed again.
+func (repo *StatusPageRepository) DeleteStatusPage(page *models.StatusPage) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("status_page_id = ?", page.ID).Delete(&models.StatusPageRelease{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(page).Error
+	})
+}
@@ -0,0 +1,124 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/statuspage"
+)
+
+func TestStatusPages(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_status_pages.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+
+	page, err := tester.repo.StatusPage().CreateStatusPage(&models.StatusPage{
+		ProjectID: projectID,
+		Subdomain: "acme",
+		Title:     "Acme Status",
+		Releases: []models.StatusPageRelease{
+			{ClusterID: 1, Namespace: "default", ReleaseName: "web", DisplayName: "Website"},
+			{ClusterID: 1, Namespace: "default", ReleaseName: "api"},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	now := time.Now().UTC()
+	resolvedAt := now.Add(-time.Hour)
+
+	incidents := []*models.ClusterIncident{
+		// a resolved crash loop of the website which lasted an hour
+		{
+			ProjectID: projectID, ClusterID: 1, Namespace: "default", ReleaseName: "web", Reason: "crash_loop",
+			StartedAt: now.Add(-2 * time.Hour), LastSeenAt: resolvedAt, ResolvedAt: &resolvedAt,
+		},
+		// an ongoing probe failure of the api
+		{
+			ProjectID: projectID, ClusterID: 1, Namespace: "default", ReleaseName: "api", Reason: "probe_failed",
+			StartedAt: now.Add(-time.Hour), LastSeenAt: now,
+		},
+		// incidents of other releases are not shown
+		{
+			ProjectID: projectID, ClusterID: 1, Namespace: "default", ReleaseName: "worker", Reason: "crash_loop",
+			StartedAt: now.Add(-time.Hour), LastSeenAt: now,
+		},
+	}
+
+	for _, incident := range incidents {
+		if _, err := tester.repo.ClusterIncident().CreateClusterIncident(incident); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	page, err = tester.repo.StatusPage().ReadStatusPageBySubdomain("acme")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	public, err := statuspage.GetPublicStatusPage(tester.repo.ClusterIncident(), page, now)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if public.Status != types.StatusPageReleaseDegraded || len(public.Releases) != 2 {
+		t.Fatalf("expected a degraded page with 2 releases, got %s with %d releases\n", public.Status, len(public.Releases))
+	}
+
+	if web := public.Releases[0]; web.Name != "Website" || web.Status != types.StatusPageReleaseOperational || web.Uptime >= 100 {
+		t.Errorf("unexpected website status: %+v\n", web)
+	}
+
+	if api := public.Releases[1]; api.Name != "api" || api.Status != types.StatusPageReleaseDegraded || api.Uptime != 100 {
+		t.Errorf("unexpected api status: %+v\n", api)
+	}
+
+	// updating the page replaces its releases
+	page.Title = "Acme"
+	page.Releases = []models.StatusPageRelease{
+		{ClusterID: 1, Namespace: "default", ReleaseName: "worker"},
+	}
+
+	if _, err := tester.repo.StatusPage().UpdateStatusPage(page); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	page, err = tester.repo.StatusPage().ReadStatusPageByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if page.Title != "Acme" || len(page.Releases) != 1 || page.Releases[0].ReleaseName != "worker" {
+		t.Fatalf("status page was not updated: %+v\n", page)
+	}
+
+	// deleted subdomains can be claimed again
+	if err := tester.repo.StatusPage().DeleteStatusPage(page); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.StatusPage().ReadStatusPageBySubdomain("acme"); err == nil {
+		t.Fatalf("expected status page to be deleted\n")
+	}
+
+	if _, err := tester.repo.StatusPage().CreateStatusPage(&models.StatusPage{
+		ProjectID: projectID,
+		Subdomain: "acme",
+		Title:     "Acme Status",
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+}
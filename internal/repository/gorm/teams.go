@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// TeamsIntegrationRepository uses gorm.DB for querying the database
+type TeamsIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewTeamsIntegrationRepository returns a TeamsIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewTeamsIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.TeamsIntegrationRepository {
+	return &TeamsIntegrationRepository{db, key}
+}
+
+// CreateTeamsIntegration creates a new Teams integration
+func (repo *TeamsIntegrationRepository) CreateTeamsIntegration(
+	teamsInt *ints.TeamsIntegration,
+) (*ints.TeamsIntegration, error) {
+	webhook := teamsInt.Webhook
+
+	cipherData, err := encryption.Encrypt(webhook, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	teamsInt.Webhook = cipherData
+
+	if err := repo.db.Create(teamsInt).Error; err != nil {
+		return nil, err
+	}
+
+	teamsInt.Webhook = webhook
+
+	return teamsInt, nil
+}
+
+// ReadTeamsIntegration finds a Teams integration of a project by its ID
+func (repo *TeamsIntegrationRepository) ReadTeamsIntegration(
+	projectID, integrationID uint,
+) (*ints.TeamsIntegration, error) {
+	teamsInt := &ints.TeamsIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(teamsInt).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptWebhook(teamsInt); err != nil {
+		return nil, err
+	}
+
+	return teamsInt, nil
+}
+
+// ListTeamsIntegrationsByProjectID finds all Teams integrations of a project
+func (repo *TeamsIntegrationRepository) ListTeamsIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.TeamsIntegration, error) {
+	teamsInts := []*ints.TeamsIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&teamsInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, teamsInt := range teamsInts {
+		if err := repo.decryptWebhook(teamsInt); err != nil {
+			return nil, err
+		}
+	}
+
+	return teamsInts, nil
+}
+
+// DeleteTeamsIntegration deletes a Teams integration
+func (repo *TeamsIntegrationRepository) DeleteTeamsIntegration(
+	teamsInt *ints.TeamsIntegration,
+) error {
+	return repo.db.Delete(teamsInt).Error
+}
+
+func (repo *TeamsIntegrationRepository) decryptWebhook(teamsInt *ints.TeamsIntegration) error {
+	if len(teamsInt.Webhook) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(teamsInt.Webhook, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	teamsInt.Webhook = plaintext
+
+	return nil
+}
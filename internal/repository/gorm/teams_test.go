@@ -0,0 +1,69 @@
+package gorm_test
+
+import (
+	"testing"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestTeamsIntegrations(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_teams.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+	webhook := "https://porter.webhook.office.com/webhookb2/1"
+
+	teamsInt, err := tester.repo.TeamsIntegration().CreateTeamsIntegration(&ints.TeamsIntegration{
+		ProjectID: projectID,
+		Name:      "deploys",
+		Webhook:   []byte(webhook),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the webhook should be encrypted at rest, and decrypted when read
+	stored := &ints.TeamsIntegration{}
+
+	if err := tester.db.First(stored, teamsInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.Webhook) == webhook {
+		t.Errorf("webhook was stored in plaintext\n")
+	}
+
+	readInt, err := tester.repo.TeamsIntegration().ReadTeamsIntegration(projectID, teamsInt.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readInt.Webhook) != webhook {
+		t.Errorf("incorrect webhook: expected %s, got %s\n", webhook, readInt.Webhook)
+	}
+
+	teamsInts, err := tester.repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(teamsInts) != 1 || string(teamsInts[0].Webhook) != webhook {
+		t.Fatalf("incorrect integrations listed: expected 1 integration with decrypted webhook\n")
+	}
+
+	if err := tester.repo.TeamsIntegration().DeleteTeamsIntegration(readInt); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.TeamsIntegration().ReadTeamsIntegration(projectID, teamsInt.ID); err == nil {
+		t.Errorf("expected error reading deleted integration\n")
+	}
+}
@@ -0,0 +1,80 @@
+package gorm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+func TestTransaction(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_transaction.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID: tester.initProjects[0].ID,
+		ClusterID: 1,
+		Name:      "preview",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	depl, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// a failed transaction should roll back every write made in it
+	errFailed := errors.New("failed")
+
+	err = tester.repo.Transaction(func(tx repository.Repository) error {
+		if _, err := tx.Environment().DeleteDeployment(depl); err != nil {
+			return err
+		}
+
+		return errFailed
+	})
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("incorrect error: expected %v, got %v\n", errFailed, err)
+	}
+
+	if _, err := tester.repo.Environment().ReadDeployment(env.ID, "pr-1"); err != nil {
+		t.Fatalf("deployment was deleted by a rolled back transaction: %v\n", err)
+	}
+
+	// a successful transaction should commit every write made in it
+	err = tester.repo.Transaction(func(tx repository.Repository) error {
+		if _, err := tx.Environment().DeleteDeployment(depl); err != nil {
+			return err
+		}
+
+		_, err := tx.Environment().DeleteEnvironment(env)
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.Environment().ReadDeployment(env.ID, "pr-1"); err == nil {
+		t.Errorf("deployment was not deleted by a committed transaction\n")
+	}
+
+	if _, err := tester.repo.Environment().ReadEnvironmentByID(tester.initProjects[0].ID, 1, env.ID); err == nil {
+		t.Errorf("environment was not deleted by a committed transaction\n")
+	}
+}
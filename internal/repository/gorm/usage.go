@@ -1,9 +1,13 @@
 package gorm
 
 import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProjectUsageRepository implements repository.ProjectUsageRepository
@@ -85,3 +89,77 @@ func (repo *ProjectUsageRepository) UpdateProjectUsageCache(
 
 	return cache, nil
 }
+
+// MeterProjectUsage counts the deployed applications, clusters and active preview environments
+// of a project, and the seconds of its builds which finished between from and to
+func (repo *ProjectUsageRepository) MeterProjectUsage(
+	projID uint,
+	from, to time.Time,
+) (*models.ProjectUsageRecord, error) {
+	var apps, clusters, previews int64
+
+	if err := repo.db.Model(&models.Release{}).Where("project_id = ?", projID).Count(&apps).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Model(&models.Cluster{}).Where("project_id = ?", projID).Count(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Model(&models.Deployment{}).
+		Joins("JOIN environments ON environments.id = deployments.environment_id AND environments.deleted_at IS NULL").
+		Where("environments.project_id = ? AND deployments.status <> ?", projID, types.DeploymentStatusInactive).
+		Count(&previews).Error; err != nil {
+		return nil, err
+	}
+
+	builds := make([]*models.Build, 0)
+
+	if err := repo.db.Where("project_id = ? AND finished_at >= ? AND finished_at < ?", projID, from, to).
+		Find(&builds).Error; err != nil {
+		return nil, err
+	}
+
+	var buildSeconds uint
+
+	for _, build := range builds {
+		if duration := build.FinishedAt.Sub(build.CreatedAt); duration > 0 {
+			buildSeconds += uint(duration.Seconds())
+		}
+	}
+
+	return &models.ProjectUsageRecord{
+		ProjectID:               projID,
+		Apps:                    uint(apps),
+		Clusters:                uint(clusters),
+		BuildSeconds:            buildSeconds,
+		PreviewEnvironmentHours: uint(previews),
+	}, nil
+}
+
+// CreateProjectUsageRecord stores the usage of a project for an hour, unless the hour was
+// already metered
+func (repo *ProjectUsageRepository) CreateProjectUsageRecord(
+	record *models.ProjectUsageRecord,
+) (*models.ProjectUsageRecord, error) {
+	if err := repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ListProjectUsageRecords lists the metered hours of a project which start between from and to
+func (repo *ProjectUsageRepository) ListProjectUsageRecords(
+	projID uint,
+	from, to time.Time,
+) ([]*models.ProjectUsageRecord, error) {
+	records := make([]*models.ProjectUsageRecord, 0)
+
+	if err := repo.db.Where("project_id = ? AND hour >= ? AND hour < ?", projID, from, to).
+		Order("hour asc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,124 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestMeterProjectUsage(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_meter_project_usage.db",
+	}
+
+	setupTestEnv(tester, t)
+	initCluster(tester, t)
+	initRelease(tester, t)
+	defer cleanup(tester, t)
+
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	finished := hour.Add(30 * time.Minute)
+	nextHour := hour.Add(90 * time.Minute)
+
+	// only the first build finished during the metered hour, and the third hasn't finished
+	for _, finishedAt := range []*time.Time{&finished, &nextHour, nil} {
+		_, err := tester.repo.Build().CreateBuild(&models.Build{
+			Model:      gorm.Model{CreatedAt: hour.Add(20 * time.Minute)},
+			ProjectID:  tester.initProjects[0].ID,
+			ClusterID:  tester.initClusters[0].ID,
+			Status:     types.BuildStatusSucceeded,
+			FinishedAt: finishedAt,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	env, err := tester.repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID:    tester.initProjects[0].ID,
+		ClusterID:    tester.initClusters[0].ID,
+		GitRepoOwner: "porter-dev",
+		GitRepoName:  "porter",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// only the deployments which are not inactive are metered
+	for namespace, status := range map[string]types.DeploymentStatus{
+		"pr-1": types.DeploymentStatusCreated,
+		"pr-2": types.DeploymentStatusInactive,
+	} {
+		_, err := tester.repo.Environment().CreateDeployment(&models.Deployment{
+			EnvironmentID: env.ID,
+			Namespace:     namespace,
+			Status:        status,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	record, err := tester.repo.ProjectUsage().MeterProjectUsage(tester.initProjects[0].ID, hour, hour.Add(time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if record.Apps != 1 || record.Clusters != 1 || record.PreviewEnvironmentHours != 1 || record.BuildSeconds != 600 {
+		t.Errorf("incorrect metered usage: expected 1 app, 1 cluster, 1 preview hour and 600 build seconds, "+
+			"got %d apps, %d clusters, %d preview hours and %d build seconds\n",
+			record.Apps, record.Clusters, record.PreviewEnvironmentHours, record.BuildSeconds)
+	}
+}
+
+func TestCreateProjectUsageRecord(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_create_project_usage_record.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	// an hour which was already metered is left unchanged
+	for _, apps := range []uint{2, 5} {
+		_, err := tester.repo.ProjectUsage().CreateProjectUsageRecord(&models.ProjectUsageRecord{
+			ProjectID: tester.initProjects[0].ID,
+			Hour:      hour,
+			Apps:      apps,
+		})
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	_, err := tester.repo.ProjectUsage().CreateProjectUsageRecord(&models.ProjectUsageRecord{
+		ProjectID: tester.initProjects[0].ID,
+		Hour:      hour.Add(time.Hour),
+		Apps:      3,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	records, err := tester.repo.ProjectUsage().ListProjectUsageRecords(tester.initProjects[0].ID, hour, hour.Add(time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(records) != 1 || records[0].Apps != 2 {
+		t.Fatalf("expected the first record of the hour with 2 apps, got %v\n", records)
+	}
+}
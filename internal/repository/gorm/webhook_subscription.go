@@ -0,0 +1,199 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepository uses gorm.DB for querying the database
+type WebhookSubscriptionRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewWebhookSubscriptionRepository returns a WebhookSubscriptionRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewWebhookSubscriptionRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db, key}
+}
+
+// CreateWebhookSubscription creates a new webhook subscription
+func (repo *WebhookSubscriptionRepository) CreateWebhookSubscription(
+	sub *models.WebhookSubscription,
+) (*models.WebhookSubscription, error) {
+	secret := sub.Secret
+
+	cipherData, err := encryption.Encrypt(secret, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sub.Secret = cipherData
+
+	if err := repo.db.Create(sub).Error; err != nil {
+		return nil, err
+	}
+
+	sub.Secret = secret
+
+	return sub, nil
+}
+
+// ReadWebhookSubscription finds a webhook subscription of a project by its ID
+func (repo *WebhookSubscriptionRepository) ReadWebhookSubscription(
+	projectID, id uint,
+) (*models.WebhookSubscription, error) {
+	sub := &models.WebhookSubscription{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(sub).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptSecret(sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// ListWebhookSubscriptionsByProjectID finds all webhook subscriptions of a project
+func (repo *WebhookSubscriptionRepository) ListWebhookSubscriptionsByProjectID(
+	projectID uint,
+) ([]*models.WebhookSubscription, error) {
+	subs := []*models.WebhookSubscription{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, sub := range subs {
+		if err := repo.decryptSecret(sub); err != nil {
+			return nil, err
+		}
+	}
+
+	return subs, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription along with its deliveries
+func (repo *WebhookSubscriptionRepository) DeleteWebhookSubscription(
+	sub *models.WebhookSubscription,
+) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_subscription_id = ?", sub.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(sub).Error
+	})
+}
+
+func (repo *WebhookSubscriptionRepository) CreateWebhookDeliveryWithJob(
+	delivery *models.WebhookDelivery,
+	newJob func(delivery *models.WebhookDelivery) (*models.BackgroundJob, error),
+) (*models.WebhookDelivery, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(delivery).Error; err != nil {
+			return err
+		}
+
+		job, err := newJob(delivery)
+
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(job).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (repo *WebhookSubscriptionRepository) ReadWebhookDelivery(projectID, id uint) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+var webhookDeliveryListColumns = &listColumns{
+	table: "webhook_deliveries",
+	filters: map[string]string{
+		"event":  "event",
+		"status": "status",
+	},
+	sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	defaultSort:  "created_at",
+	defaultOrder: repository.SortDesc,
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook subscription, most recent first
+func (repo *WebhookSubscriptionRepository) ListWebhookDeliveries(
+	projectID, subscriptionID uint,
+	opts *repository.ListOptions,
+) ([]*models.WebhookDelivery, error) {
+	deliveries := make([]*models.WebhookDelivery, 0)
+
+	query, err := applyListOptions(
+		repo.db.Where("project_id = ? AND webhook_subscription_id = ?", projectID, subscriptionID),
+		opts,
+		webhookDeliveryListColumns,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries = deliveries[:trimPage(opts, webhookDeliveryListColumns, len(deliveries), func(i int) gorm.Model {
+		return deliveries[i].Model
+	})]
+
+	return deliveries, nil
+}
+
+func (repo *WebhookSubscriptionRepository) UpdateWebhookDelivery(
+	delivery *models.WebhookDelivery,
+) (*models.WebhookDelivery, error) {
+	if err := repo.db.Save(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (repo *WebhookSubscriptionRepository) decryptSecret(sub *models.WebhookSubscription) error {
+	if len(sub.Secret) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(sub.Secret, repo.key)
+
+	if err != nil {
+		return err
+	}
+
+	sub.Secret = plaintext
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+func TestWebhookSubscriptions(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_webhook_subscriptions.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].ID
+
+	deploySub := &models.WebhookSubscription{
+		ProjectID: projectID,
+		URL:       "https://example.com/deploys",
+		Secret:    []byte("deploy-secret"),
+	}
+
+	deploySub.SetEvents([]types.WebhookEvent{types.WebhookEventDeploy, types.WebhookEventRollback})
+
+	deploySub, err := tester.repo.WebhookSubscription().CreateWebhookSubscription(deploySub)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	allSub, err := tester.repo.WebhookSubscription().CreateWebhookSubscription(&models.WebhookSubscription{
+		ProjectID: projectID,
+		URL:       "https://example.com/all",
+		Secret:    []byte("all-secret"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the secret should be encrypted at rest, and decrypted when read
+	stored := &models.WebhookSubscription{}
+
+	if err := tester.db.First(stored, deploySub.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.Secret) == "deploy-secret" {
+		t.Errorf("secret was stored in plaintext\n")
+	}
+
+	readSub, err := tester.repo.WebhookSubscription().ReadWebhookSubscription(projectID, deploySub.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(readSub.Secret) != "deploy-secret" {
+		t.Errorf("incorrect secret: expected %s, got %s\n", "deploy-secret", readSub.Secret)
+	}
+
+	// an incident event should only be delivered to the subscription without events, and a
+	// deploy event to both subscriptions
+	if err := webhook.Dispatch(tester.repo, projectID, types.WebhookEventIncident, nil); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := webhook.Dispatch(tester.repo, projectID, types.WebhookEventDeploy, nil); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	deployDeliveries, err := tester.repo.WebhookSubscription().ListWebhookDeliveries(projectID, deploySub.ID, nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(deployDeliveries) != 1 || deployDeliveries[0].Event != types.WebhookEventDeploy {
+		t.Fatalf("expected 1 deploy delivery, got %d deliveries\n", len(deployDeliveries))
+	}
+
+	if deployDeliveries[0].Status != types.WebhookDeliveryPending {
+		t.Errorf("incorrect delivery status: expected %s, got %s\n", types.WebhookDeliveryPending, deployDeliveries[0].Status)
+	}
+
+	allDeliveries, err := tester.repo.WebhookSubscription().ListWebhookDeliveries(projectID, allSub.ID, nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(allDeliveries) != 2 {
+		t.Fatalf("expected 2 deliveries, got %d\n", len(allDeliveries))
+	}
+
+	// every delivery should be sent by a background job
+	jobs, err := tester.repo.BackgroundJob().ListBackgroundJobs(projectID, nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Errorf("expected 3 background jobs, got %d\n", len(jobs))
+	}
+
+	// deleting a subscription should delete its deliveries
+	if err := tester.repo.WebhookSubscription().DeleteWebhookSubscription(readSub); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.WebhookSubscription().ReadWebhookDelivery(projectID, deployDeliveries[0].ID); err == nil {
+		t.Errorf("expected error reading delivery of deleted subscription\n")
+	}
+}
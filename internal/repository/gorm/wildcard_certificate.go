@@ -0,0 +1,89 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// WildcardCertificateRepository uses gorm.DB for querying the database
+type WildcardCertificateRepository struct {
+	db *gorm.DB
+}
+
+// NewWildcardCertificateRepository returns a WildcardCertificateRepository which uses
+// gorm.DB for querying the database
+func NewWildcardCertificateRepository(db *gorm.DB) repository.WildcardCertificateRepository {
+	return &WildcardCertificateRepository{db}
+}
+
+func (repo *WildcardCertificateRepository) CreateWildcardCertificate(
+	cert *models.WildcardCertificate,
+) (*models.WildcardCertificate, error) {
+	if err := repo.db.Create(cert).Error; err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (repo *WildcardCertificateRepository) ReadWildcardCertificate(
+	clusterID, id uint,
+) (*models.WildcardCertificate, error) {
+	cert := &models.WildcardCertificate{}
+
+	if err := repo.db.Where("cluster_id = ? AND id = ?", clusterID, id).First(cert).Error; err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (repo *WildcardCertificateRepository) ReadWildcardCertificateByDomain(
+	clusterID uint,
+	domain string,
+) (*models.WildcardCertificate, error) {
+	cert := &models.WildcardCertificate{}
+
+	if err := repo.db.Where("cluster_id = ? AND domain = ?", clusterID, domain).First(cert).Error; err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (repo *WildcardCertificateRepository) ListWildcardCertificatesByClusterID(
+	clusterID uint,
+) ([]*models.WildcardCertificate, error) {
+	certs := make([]*models.WildcardCertificate, 0)
+
+	if err := repo.db.Where("cluster_id = ?", clusterID).Order("id asc").Find(&certs).Error; err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
+
+func (repo *WildcardCertificateRepository) ListWildcardCertificates() ([]*models.WildcardCertificate, error) {
+	certs := make([]*models.WildcardCertificate, 0)
+
+	if err := repo.db.Order("cluster_id asc, id asc").Find(&certs).Error; err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
+
+func (repo *WildcardCertificateRepository) UpdateWildcardCertificate(
+	cert *models.WildcardCertificate,
+) (*models.WildcardCertificate, error) {
+	if err := repo.db.Save(cert).Error; err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (repo *WildcardCertificateRepository) DeleteWildcardCertificate(cert *models.WildcardCertificate) error {
+	return repo.db.Delete(cert).Error
+}
@@ -0,0 +1,60 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// WorkflowTemplateRepository uses gorm.DB for querying the database
+type WorkflowTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkflowTemplateRepository returns a WorkflowTemplateRepository which uses gorm.DB for
+// querying the database
+func NewWorkflowTemplateRepository(db *gorm.DB) repository.WorkflowTemplateRepository {
+	return &WorkflowTemplateRepository{db}
+}
+
+func (repo *WorkflowTemplateRepository) CreateWorkflowTemplate(template *models.WorkflowTemplate) (*models.WorkflowTemplate, error) {
+	if err := repo.db.Create(template).Error; err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (repo *WorkflowTemplateRepository) ReadWorkflowTemplate(projectID uint, kind string) (*models.WorkflowTemplate, error) {
+	template := &models.WorkflowTemplate{}
+
+	if err := repo.db.Where("project_id = ? AND kind = ?", projectID, kind).First(template).Error; err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (repo *WorkflowTemplateRepository) ListWorkflowTemplates(projectID uint) ([]*models.WorkflowTemplate, error) {
+	templates := make([]*models.WorkflowTemplate, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("kind").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (repo *WorkflowTemplateRepository) UpdateWorkflowTemplate(template *models.WorkflowTemplate) (*models.WorkflowTemplate, error) {
+	if err := repo.db.Save(template).Error; err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteWorkflowTemplate hard-deletes the template, so that a template of the same kind can
+// be created again
+func (repo *WorkflowTemplateRepository) DeleteWorkflowTemplate(template *models.WorkflowTemplate) error {
+	return repo.db.Unscoped().Delete(template).Error
+}
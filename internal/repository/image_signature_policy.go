@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// ImageSignaturePolicyRepository represents the set of queries on the
+// ImageSignaturePolicy model
+type ImageSignaturePolicyRepository interface {
+	CreateOrUpdateImageSignaturePolicy(policy *models.ImageSignaturePolicy) (*models.ImageSignaturePolicy, error)
+	ReadImageSignaturePolicy(projectID uint) (*models.ImageSignaturePolicy, error)
+}
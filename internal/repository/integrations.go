@@ -51,6 +51,18 @@ type SlackIntegrationRepository interface {
 	CreateSlackIntegration(slackInt *ints.SlackIntegration) (*ints.SlackIntegration, error)
 	ListSlackIntegrationsByProjectID(projectID uint) ([]*ints.SlackIntegration, error)
 	DeleteSlackIntegration(integrationID uint) error
+
+	// CreateSlackRoutingRule creates a rule which routes events to a Slack integration
+	CreateSlackRoutingRule(rule *ints.SlackRoutingRule) (*ints.SlackRoutingRule, error)
+
+	// ReadSlackRoutingRule reads a routing rule of a project
+	ReadSlackRoutingRule(projectID, ruleID uint) (*ints.SlackRoutingRule, error)
+
+	// ListSlackRoutingRules lists the routing rules of every Slack integration of a project
+	ListSlackRoutingRules(projectID uint) ([]*ints.SlackRoutingRule, error)
+
+	// DeleteSlackRoutingRule deletes a routing rule
+	DeleteSlackRoutingRule(rule *ints.SlackRoutingRule) error
 }
 
 // AWSIntegrationRepository represents the set of queries on the AWS auth
@@ -100,3 +112,91 @@ type GitlabAppOAuthIntegrationRepository interface {
 	CreateGitlabAppOAuthIntegration(gi *ints.GitlabAppOAuthIntegration) (*ints.GitlabAppOAuthIntegration, error)
 	ReadGitlabAppOAuthIntegration(userID, projectID, integrationID uint) (*ints.GitlabAppOAuthIntegration, error)
 }
+
+// DiscordIntegrationRepository represents the set of queries on a Discord integration
+type DiscordIntegrationRepository interface {
+	CreateDiscordIntegration(discordInt *ints.DiscordIntegration) (*ints.DiscordIntegration, error)
+	ReadDiscordIntegration(projectID, integrationID uint) (*ints.DiscordIntegration, error)
+	ListDiscordIntegrationsByProjectID(projectID uint) ([]*ints.DiscordIntegration, error)
+	DeleteDiscordIntegration(discordInt *ints.DiscordIntegration) error
+}
+
+// TeamsIntegrationRepository represents the set of queries on a Microsoft Teams integration
+type TeamsIntegrationRepository interface {
+	CreateTeamsIntegration(teamsInt *ints.TeamsIntegration) (*ints.TeamsIntegration, error)
+	ReadTeamsIntegration(projectID, integrationID uint) (*ints.TeamsIntegration, error)
+	ListTeamsIntegrationsByProjectID(projectID uint) ([]*ints.TeamsIntegration, error)
+	DeleteTeamsIntegration(teamsInt *ints.TeamsIntegration) error
+}
+
+// PagerDutyIntegrationRepository represents the set of queries on a PagerDuty integration
+type PagerDutyIntegrationRepository interface {
+	CreatePagerDutyIntegration(pdInt *ints.PagerDutyIntegration) (*ints.PagerDutyIntegration, error)
+	ReadPagerDutyIntegration(projectID, integrationID uint) (*ints.PagerDutyIntegration, error)
+	ListPagerDutyIntegrationsByProjectID(projectID uint) ([]*ints.PagerDutyIntegration, error)
+	DeletePagerDutyIntegration(pdInt *ints.PagerDutyIntegration) error
+}
+
+// DatadogIntegrationRepository represents the set of queries on a Datadog integration
+type DatadogIntegrationRepository interface {
+	CreateDatadogIntegration(ddInt *ints.DatadogIntegration) (*ints.DatadogIntegration, error)
+	ReadDatadogIntegration(projectID, integrationID uint) (*ints.DatadogIntegration, error)
+	ListDatadogIntegrationsByProjectID(projectID uint) ([]*ints.DatadogIntegration, error)
+	DeleteDatadogIntegration(ddInt *ints.DatadogIntegration) error
+}
+
+// SentryIntegrationRepository represents the set of queries on a Sentry integration
+type SentryIntegrationRepository interface {
+	CreateSentryIntegration(sentryInt *ints.SentryIntegration) (*ints.SentryIntegration, error)
+	ReadSentryIntegration(projectID, integrationID uint) (*ints.SentryIntegration, error)
+	ListSentryIntegrationsByProjectID(projectID uint) ([]*ints.SentryIntegration, error)
+	DeleteSentryIntegration(sentryInt *ints.SentryIntegration) error
+}
+
+// JiraIntegrationRepository represents the set of queries on a Jira integration
+type JiraIntegrationRepository interface {
+	CreateJiraIntegration(jiraInt *ints.JiraIntegration) (*ints.JiraIntegration, error)
+	ReadJiraIntegration(projectID, integrationID uint) (*ints.JiraIntegration, error)
+	ListJiraIntegrationsByProjectID(projectID uint) ([]*ints.JiraIntegration, error)
+	DeleteJiraIntegration(jiraInt *ints.JiraIntegration) error
+}
+
+// LinearIntegrationRepository represents the set of queries on a Linear integration
+type LinearIntegrationRepository interface {
+	CreateLinearIntegration(linearInt *ints.LinearIntegration) (*ints.LinearIntegration, error)
+	ReadLinearIntegration(projectID, integrationID uint) (*ints.LinearIntegration, error)
+	ListLinearIntegrationsByProjectID(projectID uint) ([]*ints.LinearIntegration, error)
+	DeleteLinearIntegration(linearInt *ints.LinearIntegration) error
+}
+
+// DopplerIntegrationRepository represents the set of queries on a Doppler integration
+type DopplerIntegrationRepository interface {
+	CreateDopplerIntegration(dopplerInt *ints.DopplerIntegration) (*ints.DopplerIntegration, error)
+	ReadDopplerIntegration(projectID, integrationID uint) (*ints.DopplerIntegration, error)
+	ListDopplerIntegrationsByProjectID(projectID uint) ([]*ints.DopplerIntegration, error)
+	DeleteDopplerIntegration(dopplerInt *ints.DopplerIntegration) error
+}
+
+// GrafanaIntegrationRepository represents the set of queries on a Grafana integration
+type GrafanaIntegrationRepository interface {
+	CreateGrafanaIntegration(grafanaInt *ints.GrafanaIntegration) (*ints.GrafanaIntegration, error)
+	ReadGrafanaIntegration(projectID, integrationID uint) (*ints.GrafanaIntegration, error)
+	ListGrafanaIntegrationsByProjectID(projectID uint) ([]*ints.GrafanaIntegration, error)
+	DeleteGrafanaIntegration(grafanaInt *ints.GrafanaIntegration) error
+}
+
+// DNSProviderIntegrationRepository represents the set of queries on a DNS provider integration
+type DNSProviderIntegrationRepository interface {
+	CreateDNSProviderIntegration(dnsInt *ints.DNSProviderIntegration) (*ints.DNSProviderIntegration, error)
+	ReadDNSProviderIntegration(projectID, integrationID uint) (*ints.DNSProviderIntegration, error)
+	ListDNSProviderIntegrationsByProjectID(projectID uint) ([]*ints.DNSProviderIntegration, error)
+	DeleteDNSProviderIntegration(dnsInt *ints.DNSProviderIntegration) error
+}
+
+// FeatureFlagIntegrationRepository represents the set of queries on a feature flag integration
+type FeatureFlagIntegrationRepository interface {
+	CreateFeatureFlagIntegration(ffInt *ints.FeatureFlagIntegration) (*ints.FeatureFlagIntegration, error)
+	ReadFeatureFlagIntegration(projectID, integrationID uint) (*ints.FeatureFlagIntegration, error)
+	ListFeatureFlagIntegrationsByProjectID(projectID uint) ([]*ints.FeatureFlagIntegration, error)
+	DeleteFeatureFlagIntegration(ffInt *ints.FeatureFlagIntegration) error
+}
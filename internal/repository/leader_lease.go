@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// LeaderLeaseRepository represents the set of queries on the LeaderLease model
+type LeaderLeaseRepository interface {
+	// AcquireLeaderLease acquires or renews a lease for a holder until expiresAt, and returns
+	// true if the holder holds the lease. Leases held by another holder can only be acquired
+	// once they have expired.
+	AcquireLeaderLease(name, holder string, now, expiresAt time.Time) (bool, error)
+
+	// ReleaseLeaderLease expires a lease if it's held by the holder, so that another holder
+	// can acquire it without waiting for it to expire
+	ReleaseLeaderLease(name, holder string, now time.Time) error
+
+	// ListActiveLeaderLeases lists the leases whose name starts with a prefix which have not
+	// expired, by name
+	ListActiveLeaderLeases(prefix string, now time.Time) ([]*models.LeaderLease, error)
+}
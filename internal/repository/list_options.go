@@ -0,0 +1,50 @@
+package repository
+
+import "errors"
+
+// ErrInvalidListOptions is returned by List* queries when the list options contain
+// an unsupported filter or sort field, or an invalid cursor
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+// SortOrder is the order in which list results are sorted
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// Filter matches list results where a field is equal to any of the values
+type Filter struct {
+	Field  string
+	Values []string
+}
+
+// ListOptions filter, sort and paginate the results of List* queries. A nil ListOptions
+// returns every result in the default order of the query.
+//
+// Each query only supports filtering and sorting on a set of fields, and returns an error
+// for other fields. Results are paginated with a cursor rather than an offset, so that
+// pages stay consistent while results are created or deleted.
+type ListOptions struct {
+	Filters []Filter
+
+	// The field to sort by, which defaults to the default sort field of the query
+	SortBy string
+	Order  SortOrder
+
+	// The maximum number of results to return, where 0 returns every result
+	Limit int
+
+	// The cursor of the page to return, which is the NextCursor of the previous page
+	Cursor string
+
+	// NextCursor is set by List* queries to the cursor of the next page, or to an empty
+	// string if there are no more results
+	NextCursor string
+
+	// ReadReplica routes queries which support it to the read replica, if one is configured.
+	// Since replicas may lag behind the primary, it should only be set for lists which are
+	// returned to clients, and never for results which are written back.
+	ReadReplica bool
+}
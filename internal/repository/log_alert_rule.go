@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// LogAlertRuleRepository represents the set of queries on the LogAlertRule model
+type LogAlertRuleRepository interface {
+	CreateLogAlertRule(rule *models.LogAlertRule) (*models.LogAlertRule, error)
+	ReadLogAlertRule(clusterID, id uint) (*models.LogAlertRule, error)
+	ListLogAlertRulesByRelease(clusterID uint, namespace, releaseName string) ([]*models.LogAlertRule, error)
+	ListLogAlertRulesByCluster(clusterID uint) ([]*models.LogAlertRule, error)
+	DeleteLogAlertRule(rule *models.LogAlertRule) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// MultiClusterReleaseRepository represents the set of queries on the MultiClusterRelease
+// and MultiClusterRollout models
+type MultiClusterReleaseRepository interface {
+	CreateMultiClusterRelease(release *models.MultiClusterRelease) (*models.MultiClusterRelease, error)
+	ReadMultiClusterRelease(projectID, id uint) (*models.MultiClusterRelease, error)
+	ListMultiClusterReleases(projectID uint) ([]*models.MultiClusterRelease, error)
+
+	// UpdateMultiClusterRelease updates a multi-cluster release and replaces its targets
+	UpdateMultiClusterRelease(release *models.MultiClusterRelease) (*models.MultiClusterRelease, error)
+	DeleteMultiClusterRelease(release *models.MultiClusterRelease) error
+
+	CreateMultiClusterRollout(rollout *models.MultiClusterRollout) (*models.MultiClusterRollout, error)
+	ReadMultiClusterRollout(releaseID, id uint) (*models.MultiClusterRollout, error)
+
+	// ListMultiClusterRollouts lists the rollouts of a multi-cluster release, most recent first
+	ListMultiClusterRollouts(releaseID uint) ([]*models.MultiClusterRollout, error)
+
+	// UpdateMultiClusterRollout updates a rollout, but not its targets
+	UpdateMultiClusterRollout(rollout *models.MultiClusterRollout) (*models.MultiClusterRollout, error)
+	UpdateMultiClusterRolloutTarget(target *models.MultiClusterRolloutTarget) (*models.MultiClusterRolloutTarget, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NotificationPreferenceRepository represents the set of queries on the notification
+// preferences of projects and of the users of projects
+type NotificationPreferenceRepository interface {
+	CreateNotificationPreference(pref *models.NotificationPreference) (*models.NotificationPreference, error)
+
+	// ReadNotificationPreference reads the preference of a channel for a project if the user id
+	// is 0, or for a user of the project otherwise
+	ReadNotificationPreference(projectID, userID uint, channel types.NotificationChannel) (*models.NotificationPreference, error)
+	UpdateNotificationPreference(pref *models.NotificationPreference) (*models.NotificationPreference, error)
+
+	// ListNotificationPreferencesByProjectID lists the stored preferences of a project and of
+	// the users of the project
+	ListNotificationPreferencesByProjectID(projectID uint) ([]*models.NotificationPreference, error)
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -19,4 +21,10 @@ type ProjectRepository interface {
 	ListProjectsByUserID(userID uint) ([]*models.Project, error)
 	DeleteProject(project *models.Project) (*models.Project, error)
 	DeleteProjectRole(projID, userID uint) (*models.Role, error)
+	ListDeletedProjectsByUserID(userID uint, deletedAfter time.Time) ([]*models.Project, error)
+	RestoreProject(id uint, deletedAfter time.Time) (*models.Project, error)
+	PurgeDeletedProjects(deletedBefore time.Time) (int64, error)
+
+	// ListProjectIDs lists the IDs of every project of the instance which is not deleted
+	ListProjectIDs() ([]uint, error)
 }
@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// PullThroughCacheRuleRepository represents the set of queries on the
+// PullThroughCacheRule model
+type PullThroughCacheRuleRepository interface {
+	ListPullThroughCacheRulesByClusterID(projectID, clusterID uint) ([]*models.PullThroughCacheRule, error)
+	ReplacePullThroughCacheRules(projectID, clusterID uint, rules []*models.PullThroughCacheRule) ([]*models.PullThroughCacheRule, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// QueuedDeployRepository represents the set of queries on the QueuedDeploy model
+type QueuedDeployRepository interface {
+	CreateQueuedDeploy(deploy *models.QueuedDeploy) (*models.QueuedDeploy, error)
+	ReadQueuedDeploy(clusterID, id uint) (*models.QueuedDeploy, error)
+
+	// ListQueuedDeploys lists the running and queued deploys of a release, in the order
+	// they were requested
+	ListQueuedDeploys(clusterID uint, namespace, name string) ([]*models.QueuedDeploy, error)
+
+	// ClaimQueuedDeploy marks a queued deploy as running. It returns false if the deploy is no
+	// longer queued, because it was cancelled or claimed by another server.
+	ClaimQueuedDeploy(deploy *models.QueuedDeploy, now time.Time) (bool, error)
+
+	// CancelQueuedDeploy marks a queued deploy as cancelled. It returns false if the deploy
+	// is no longer queued, because it has started running.
+	CancelQueuedDeploy(deploy *models.QueuedDeploy, userID uint, now time.Time) (bool, error)
+
+	UpdateQueuedDeploy(deploy *models.QueuedDeploy) (*models.QueuedDeploy, error)
+}
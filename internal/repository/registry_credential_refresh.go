@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// RegistryCredentialRefreshRepository represents the set of queries on the
+// RegistryCredentialRefresh model
+type RegistryCredentialRefreshRepository interface {
+	CreateOrUpdateRegistryCredentialRefresh(refresh *models.RegistryCredentialRefresh) (*models.RegistryCredentialRefresh, error)
+	ReadRegistryCredentialRefresh(clusterID, registryID uint) (*models.RegistryCredentialRefresh, error)
+	ListRegistryCredentialRefreshesByClusterID(projectID, clusterID uint) ([]*models.RegistryCredentialRefresh, error)
+}
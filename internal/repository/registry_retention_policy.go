@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// RegistryRetentionPolicyRepository represents the set of queries on the
+// RegistryRetentionPolicy model
+type RegistryRetentionPolicyRepository interface {
+	CreateOrUpdateRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) (*models.RegistryRetentionPolicy, error)
+	ReadRegistryRetentionPolicy(projectID, registryID uint) (*models.RegistryRetentionPolicy, error)
+	ListEnabledRegistryRetentionPolicies() ([]*models.RegistryRetentionPolicy, error)
+	DeleteRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) error
+}
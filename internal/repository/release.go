@@ -12,7 +12,16 @@ type ReleaseRepository interface {
 	CreateRelease(release *models.Release) (*models.Release, error)
 	ReadRelease(clusterID uint, name, namespace string) (*models.Release, error)
 	ReadReleaseByWebhookToken(token string) (*models.Release, error)
-	ListReleasesByImageRepoURI(clusterID uint, imageRepoURI string) ([]*models.Release, error)
+	ListReleasesByImageRepoURI(clusterID uint, imageRepoURI string, opts *ListOptions) ([]*models.Release, error)
+
+	// SearchReleases returns releases of the projects whose name, namespace or git repo
+	// contains the query
+	SearchReleases(projectIDs []uint, query string, limit int) ([]*models.Release, error)
+
+	// ListReleaseHistory lists the deploys of a release which were recorded by Porter, newest
+	// first. It reads from the primary, unless the list options set ReadReplica.
+	ListReleaseHistory(clusterID uint, namespace, name string, opts *ListOptions) ([]*models.DeploymentRecord, error)
+
 	UpdateRelease(release *models.Release) (*models.Release, error)
 	DeleteRelease(release *models.Release) (*models.Release, error)
 }
@@ -27,6 +27,15 @@ type Repository interface {
 	GithubAppInstallation() GithubAppInstallationRepository
 	GithubAppOAuthIntegration() GithubAppOAuthIntegrationRepository
 	SlackIntegration() SlackIntegrationRepository
+	DiscordIntegration() DiscordIntegrationRepository
+	TeamsIntegration() TeamsIntegrationRepository
+	PagerDutyIntegration() PagerDutyIntegrationRepository
+	DatadogIntegration() DatadogIntegrationRepository
+	SentryIntegration() SentryIntegrationRepository
+	JiraIntegration() JiraIntegrationRepository
+	LinearIntegration() LinearIntegrationRepository
+	DopplerIntegration() DopplerIntegrationRepository
+	GrafanaIntegration() GrafanaIntegrationRepository
 	GitlabIntegration() GitlabIntegrationRepository
 	GitlabAppOAuthIntegration() GitlabAppOAuthIntegrationRepository
 	NotificationConfig() NotificationConfigRepository
@@ -43,4 +52,42 @@ type Repository interface {
 	Tag() TagRepository
 	Stack() StackRepository
 	MonitorTestResult() MonitorTestResultRepository
+	AuditLog() AuditLogRepository
+	ClusterIncident() ClusterIncidentRepository
+	RegistryRetentionPolicy() RegistryRetentionPolicyRepository
+	RegistryCredentialRefresh() RegistryCredentialRefreshRepository
+	Build() BuildRepository
+	ImageSignaturePolicy() ImageSignaturePolicyRepository
+	PullThroughCacheRule() PullThroughCacheRuleRepository
+	BulkDeploymentOperation() BulkDeploymentOperationRepository
+	BackgroundJob() BackgroundJobRepository
+	Archive() ArchiveRepository
+	WebhookSubscription() WebhookSubscriptionRepository
+	EmailPreference() EmailPreferenceRepository
+	NotificationPreference() NotificationPreferenceRepository
+	StatusPage() StatusPageRepository
+	EnvGroupSource() EnvGroupSourceRepository
+	EnvGroupRotationPolicy() EnvGroupRotationPolicyRepository
+	ExternalSecretStore() ExternalSecretStoreRepository
+	CostRecord() CostRecordRepository
+	MultiClusterRelease() MultiClusterReleaseRepository
+	DNSProviderIntegration() DNSProviderIntegrationRepository
+	CustomDomainDNSRecord() CustomDomainDNSRecordRepository
+	WildcardCertificate() WildcardCertificateRepository
+	Approval() ApprovalRepository
+	FreezeWindow() FreezeWindowRepository
+	QueuedDeploy() QueuedDeployRepository
+	GitOpsExport() GitOpsExportRepository
+	WorkflowTemplate() WorkflowTemplateRepository
+	ClusterTunnel() ClusterTunnelRepository
+	ClusterRelay() ClusterRelayRepository
+	FeatureFlagIntegration() FeatureFlagIntegrationRepository
+	DeployFeatureFlag() DeployFeatureFlagRepository
+	LogAlertRule() LogAlertRuleRepository
+	LeaderLease() LeaderLeaseRepository
+
+	// Transaction calls fn with a repository whose queries are made in a single transaction.
+	// The transaction is committed if fn returns nil, and rolled back if fn returns an error,
+	// so writes which touch several models cannot be partially applied.
+	Transaction(fn func(tx Repository) error) error
 }
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// DeploymentSearchResult is a deployment which matched a search query, along with the
+// project and cluster of its environment
+type DeploymentSearchResult struct {
+	models.Deployment
+
+	ProjectID uint
+	ClusterID uint
+}
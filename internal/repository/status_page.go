@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// StatusPageRepository represents the set of queries on the StatusPage model
+type StatusPageRepository interface {
+	CreateStatusPage(page *models.StatusPage) (*models.StatusPage, error)
+	ReadStatusPageByProjectID(projectID uint) (*models.StatusPage, error)
+	ReadStatusPageBySubdomain(subdomain string) (*models.StatusPage, error)
+	UpdateStatusPage(page *models.StatusPage) (*models.StatusPage, error)
+	DeleteStatusPage(page *models.StatusPage) error
+}
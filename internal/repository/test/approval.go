@@ -0,0 +1,173 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ApprovalRepository struct {
+	canQuery  bool
+	policies  []*models.ApprovalPolicy
+	approvals []*models.Approval
+}
+
+func NewApprovalRepository(canQuery bool) repository.ApprovalRepository {
+	return &ApprovalRepository{canQuery, []*models.ApprovalPolicy{}, []*models.Approval{}}
+}
+
+func (repo *ApprovalRepository) CreateApprovalPolicy(policy *models.ApprovalPolicy) (*models.ApprovalPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.policies = append(repo.policies, policy)
+	policy.ID = uint(len(repo.policies))
+
+	return policy, nil
+}
+
+func (repo *ApprovalRepository) ReadApprovalPolicy(projectID, id uint) (*models.ApprovalPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID && policy.ID == id {
+			return policy, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *ApprovalRepository) ListApprovalPolicies(projectID uint) ([]*models.ApprovalPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ApprovalPolicy, 0)
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID {
+			res = append(res, policy)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *ApprovalRepository) DeleteApprovalPolicy(policy *models.ApprovalPolicy) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if policy.ID == 0 || int(policy.ID) > len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = nil
+
+	return nil
+}
+
+func (repo *ApprovalRepository) CreateApproval(approval *models.Approval) (*models.Approval, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.approvals = append(repo.approvals, approval)
+	approval.ID = uint(len(repo.approvals))
+
+	return approval, nil
+}
+
+func (repo *ApprovalRepository) ReadApproval(projectID, id uint) (*models.Approval, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, approval := range repo.approvals {
+		if approval.ProjectID == projectID && approval.ID == id {
+			return approval, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *ApprovalRepository) ListApprovals(projectID uint, status types.ApprovalStatus) ([]*models.Approval, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Approval, 0)
+
+	for i := len(repo.approvals) - 1; i >= 0; i-- {
+		approval := repo.approvals[i]
+
+		if approval.ProjectID == projectID && (status == "" || approval.Status == status) {
+			res = append(res, approval)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *ApprovalRepository) ListApprovalsByDateRange(projectID uint, from, to time.Time) ([]*models.Approval, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	inRange := func(t *time.Time) bool {
+		return t != nil && !t.Before(from) && t.Before(to)
+	}
+
+	res := make([]*models.Approval, 0)
+
+	for _, approval := range repo.approvals {
+		if approval.ProjectID == projectID && (inRange(&approval.CreatedAt) || inRange(approval.ReviewedAt)) {
+			res = append(res, approval)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *ApprovalRepository) ListPendingApprovalsByRelease(
+	clusterID uint,
+	namespace, name string,
+) ([]*models.Approval, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Approval, 0)
+
+	for _, approval := range repo.approvals {
+		if approval.ClusterID == clusterID && approval.Namespace == namespace && approval.Name == name &&
+			approval.Status == types.ApprovalStatusPending {
+			res = append(res, approval)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *ApprovalRepository) UpdateApproval(approval *models.Approval) (*models.Approval, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if approval.ID == 0 || int(approval.ID) > len(repo.approvals) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.approvals[approval.ID-1] = approval
+
+	return approval, nil
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ArchiveRepository struct{}
+
+func NewArchiveRepository() repository.ArchiveRepository {
+	return &ArchiveRepository{}
+}
+
+func (repo *ArchiveRepository) ArchiveDeployments(updatedBefore time.Time, limit int) (int64, error) {
+	panic("not implemented")
+}
+
+func (repo *ArchiveRepository) ArchiveKubeEvents(updatedBefore time.Time, limit int) (int64, error) {
+	panic("not implemented")
+}
+
+func (repo *ArchiveRepository) ListArchivedDeployments(
+	projectID, clusterID uint,
+	opts *repository.ListOptions,
+) ([]*models.ArchivedDeployment, error) {
+	panic("not implemented")
+}
+
+func (repo *ArchiveRepository) ListArchivedKubeEvents(
+	projectID, clusterID uint,
+	opts *repository.ListOptions,
+) ([]*models.ArchivedKubeEvent, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type AuditLogRepository struct {
+	canQuery  bool
+	auditLogs []*models.AuditLog
+}
+
+func NewAuditLogRepository(canQuery bool) repository.AuditLogRepository {
+	return &AuditLogRepository{canQuery, []*models.AuditLog{}}
+}
+
+func (repo *AuditLogRepository) CreateAuditLog(auditLog *models.AuditLog) (*models.AuditLog, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if auditLog.CreatedAt.IsZero() {
+		auditLog.CreatedAt = time.Now()
+	}
+
+	repo.auditLogs = append(repo.auditLogs, auditLog)
+	auditLog.ID = uint(len(repo.auditLogs))
+
+	return auditLog, nil
+}
+
+func (repo *AuditLogRepository) ListAuditLogsByProjectID(
+	projectID uint,
+	opts *types.ListAuditLogsRequest,
+) ([]*models.AuditLog, int64, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *AuditLogRepository) ListAuditLogsByDateRange(projectID uint, from, to time.Time) ([]*models.AuditLog, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AuditLog, 0)
+
+	for _, auditLog := range repo.auditLogs {
+		if auditLog.ProjectID == projectID && !auditLog.CreatedAt.Before(from) && auditLog.CreatedAt.Before(to) {
+			res = append(res, auditLog)
+		}
+	}
+
+	return res, nil
+}
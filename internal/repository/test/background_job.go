@@ -0,0 +1,37 @@
+package test
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type BackgroundJobRepository struct{}
+
+func NewBackgroundJobRepository() repository.BackgroundJobRepository {
+	return &BackgroundJobRepository{}
+}
+
+func (repo *BackgroundJobRepository) CreateBackgroundJob(job *models.BackgroundJob) (*models.BackgroundJob, error) {
+	panic("not implemented")
+}
+
+func (repo *BackgroundJobRepository) ReadBackgroundJob(projectID, id uint) (*models.BackgroundJob, error) {
+	panic("not implemented")
+}
+
+func (repo *BackgroundJobRepository) ListBackgroundJobs(
+	projectID uint,
+	opts *repository.ListOptions,
+) ([]*models.BackgroundJob, error) {
+	panic("not implemented")
+}
+
+func (repo *BackgroundJobRepository) UpdateBackgroundJob(job *models.BackgroundJob) (*models.BackgroundJob, error) {
+	panic("not implemented")
+}
+
+func (repo *BackgroundJobRepository) ClaimBackgroundJob(now, staleBefore time.Time) (*models.BackgroundJob, error) {
+	panic("not implemented")
+}
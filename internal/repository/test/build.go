@@ -0,0 +1,31 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type BuildRepository struct{}
+
+func NewBuildRepository() repository.BuildRepository {
+	return &BuildRepository{}
+}
+
+func (repo *BuildRepository) CreateBuild(build *models.Build) (*models.Build, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *BuildRepository) ReadBuild(projectID, clusterID, buildID uint) (*models.Build, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *BuildRepository) ListBuildsByNamespace(
+	projectID, clusterID uint,
+	namespace string,
+) ([]*models.Build, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *BuildRepository) UpdateBuild(build *models.Build) (*models.Build, error) {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// BulkDeploymentOperationRepository will return errors on queries if canQuery is false, and
+// stores operations in-memory, indexed by their array index + 1. Items are indexed across
+// operations in the same way. Operations are copied when they are stored and read.
+type BulkDeploymentOperationRepository struct {
+	canQuery bool
+	ops      []*models.BulkDeploymentOperation
+	numItems uint
+}
+
+func NewBulkDeploymentOperationRepository(canQuery bool) repository.BulkDeploymentOperationRepository {
+	return &BulkDeploymentOperationRepository{canQuery: canQuery}
+}
+
+func (repo *BulkDeploymentOperationRepository) CreateBulkDeploymentOperation(
+	op *models.BulkDeploymentOperation,
+) (*models.BulkDeploymentOperation, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	op.ID = uint(len(repo.ops) + 1)
+
+	for i := range op.Items {
+		repo.numItems++
+
+		op.Items[i].ID = repo.numItems
+		op.Items[i].BulkDeploymentOperationID = op.ID
+	}
+
+	repo.ops = append(repo.ops, copyBulkDeploymentOperation(op))
+
+	return op, nil
+}
+
+func (repo *BulkDeploymentOperationRepository) ReadBulkDeploymentOperation(
+	projectID, clusterID, id uint,
+) (*models.BulkDeploymentOperation, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.ops) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	op := repo.ops[id-1]
+
+	if op.ProjectID != projectID || op.ClusterID != clusterID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return copyBulkDeploymentOperation(op), nil
+}
+
+func (repo *BulkDeploymentOperationRepository) ListBulkDeploymentOperations(
+	projectID, clusterID uint,
+) ([]*models.BulkDeploymentOperation, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.BulkDeploymentOperation, 0)
+
+	for i := len(repo.ops) - 1; i >= 0; i-- {
+		if repo.ops[i].ProjectID == projectID && repo.ops[i].ClusterID == clusterID {
+			res = append(res, copyBulkDeploymentOperation(repo.ops[i]))
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateBulkDeploymentOperation updates an operation, but not its items
+func (repo *BulkDeploymentOperationRepository) UpdateBulkDeploymentOperation(
+	op *models.BulkDeploymentOperation,
+) (*models.BulkDeploymentOperation, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(op.ID-1) >= len(repo.ops) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	stored := copyBulkDeploymentOperation(op)
+	stored.Items = repo.ops[op.ID-1].Items
+
+	repo.ops[op.ID-1] = stored
+
+	return op, nil
+}
+
+func (repo *BulkDeploymentOperationRepository) UpdateBulkDeploymentOperationItem(
+	item *models.BulkDeploymentOperationItem,
+) (*models.BulkDeploymentOperationItem, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(item.BulkDeploymentOperationID-1) >= len(repo.ops) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	items := repo.ops[item.BulkDeploymentOperationID-1].Items
+
+	for i := range items {
+		if items[i].ID == item.ID {
+			items[i] = *item
+			return item, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func copyBulkDeploymentOperation(op *models.BulkDeploymentOperation) *models.BulkDeploymentOperation {
+	copied := *op
+	copied.Items = append([]models.BulkDeploymentOperationItem{}, op.Items...)
+
+	return &copied
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -200,3 +201,15 @@ func (repo *ClusterRepository) DeleteCluster(
 
 	return nil
 }
+
+func (repo *ClusterRepository) ListDeletedClustersByProjectID(projectID uint, deletedAfter time.Time) ([]*models.Cluster, error) {
+	panic("unimplemented")
+}
+
+func (repo *ClusterRepository) RestoreCluster(projectID, clusterID uint, deletedAfter time.Time) (*models.Cluster, error) {
+	panic("unimplemented")
+}
+
+func (repo *ClusterRepository) PurgeDeletedClusters(deletedBefore time.Time) (int64, error) {
+	panic("unimplemented")
+}
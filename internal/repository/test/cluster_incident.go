@@ -0,0 +1,53 @@
+package test
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ClusterIncidentRepository struct{}
+
+func NewClusterIncidentRepository() repository.ClusterIncidentRepository {
+	return &ClusterIncidentRepository{}
+}
+
+func (repo *ClusterIncidentRepository) CreateClusterIncident(incident *models.ClusterIncident) (*models.ClusterIncident, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterIncidentRepository) ReadClusterIncident(projectID, clusterID, incidentID uint) (*models.ClusterIncident, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterIncidentRepository) ListClusterIncidents(
+	projectID, clusterID uint,
+	opts *types.ListClusterIncidentsRequest,
+) ([]*models.ClusterIncident, int64, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterIncidentRepository) ListActiveClusterIncidents(projectID, clusterID uint) ([]*models.ClusterIncident, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterIncidentRepository) ListClusterIncidentsUpdatedSince(
+	projectID, clusterID uint,
+	since time.Time,
+) ([]*models.ClusterIncident, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterIncidentRepository) ListReleaseIncidentsSince(
+	projectID, clusterID uint,
+	namespace, releaseName string,
+	since time.Time,
+) ([]*models.ClusterIncident, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterIncidentRepository) UpdateClusterIncident(incident *models.ClusterIncident) (*models.ClusterIncident, error) {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ClusterRelayRepository struct{}
+
+func NewClusterRelayRepository() repository.ClusterRelayRepository {
+	return &ClusterRelayRepository{}
+}
+
+func (repo *ClusterRelayRepository) CreateClusterRelay(relay *models.ClusterRelay) (*models.ClusterRelay, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterRelayRepository) ReadClusterRelay(clusterID uint) (*models.ClusterRelay, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterRelayRepository) UpdateClusterRelay(relay *models.ClusterRelay) (*models.ClusterRelay, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterRelayRepository) DeleteClusterRelay(relay *models.ClusterRelay) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ClusterTunnelRepository struct{}
+
+func NewClusterTunnelRepository() repository.ClusterTunnelRepository {
+	return &ClusterTunnelRepository{}
+}
+
+func (repo *ClusterTunnelRepository) CreateClusterTunnel(tunnel *models.ClusterTunnel) (*models.ClusterTunnel, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterTunnelRepository) ReadClusterTunnel(clusterID uint) (*models.ClusterTunnel, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterTunnelRepository) UpdateClusterTunnel(tunnel *models.ClusterTunnel) (*models.ClusterTunnel, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *ClusterTunnelRepository) DeleteClusterTunnel(tunnel *models.ClusterTunnel) error {
+	panic("not implemented") // TODO: Implement
+}
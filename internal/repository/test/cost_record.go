@@ -0,0 +1,22 @@
+package test
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type CostRecordRepository struct{}
+
+func NewCostRecordRepository() repository.CostRecordRepository {
+	return &CostRecordRepository{}
+}
+
+func (repo *CostRecordRepository) CreateCostRecords(records []*models.CostRecord) error {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *CostRecordRepository) ListCostRecords(clusterID uint, from, to time.Time) ([]*models.CostRecord, error) {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type CustomDomainDNSRecordRepository struct{}
+
+func NewCustomDomainDNSRecordRepository() repository.CustomDomainDNSRecordRepository {
+	return &CustomDomainDNSRecordRepository{}
+}
+
+func (repo *CustomDomainDNSRecordRepository) CreateCustomDomainDNSRecord(
+	record *models.CustomDomainDNSRecord,
+) (*models.CustomDomainDNSRecord, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *CustomDomainDNSRecordRepository) ReadCustomDomainDNSRecord(
+	clusterID uint,
+	host string,
+) (*models.CustomDomainDNSRecord, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *CustomDomainDNSRecordRepository) ListCustomDomainDNSRecordsByRelease(
+	clusterID uint,
+	namespace, releaseName string,
+) ([]*models.CustomDomainDNSRecord, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *CustomDomainDNSRecordRepository) SearchCustomDomains(
+	projectIDs []uint,
+	query string,
+	limit int,
+) ([]*models.CustomDomainDNSRecord, error) {
+	return []*models.CustomDomainDNSRecord{}, nil
+}
+
+func (repo *CustomDomainDNSRecordRepository) ListPendingCustomDomainDNSRecords() ([]*models.CustomDomainDNSRecord, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *CustomDomainDNSRecordRepository) UpdateCustomDomainDNSRecord(
+	record *models.CustomDomainDNSRecord,
+) (*models.CustomDomainDNSRecord, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *CustomDomainDNSRecordRepository) DeleteCustomDomainDNSRecord(record *models.CustomDomainDNSRecord) error {
+	panic("not implemented") // TODO: Implement
+}
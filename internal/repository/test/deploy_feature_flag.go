@@ -0,0 +1,28 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type DeployFeatureFlagRepository struct{}
+
+func NewDeployFeatureFlagRepository() repository.DeployFeatureFlagRepository {
+	return &DeployFeatureFlagRepository{}
+}
+
+func (repo *DeployFeatureFlagRepository) CreateDeployFeatureFlag(flag *models.DeployFeatureFlag) (*models.DeployFeatureFlag, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *DeployFeatureFlagRepository) ListDeployFeatureFlagsByRevision(clusterID uint, namespace, name string, revision int) ([]*models.DeployFeatureFlag, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *DeployFeatureFlagRepository) ListAppliedDeployFeatureFlags(clusterID uint, namespace, name string, afterRevision int) ([]*models.DeployFeatureFlag, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *DeployFeatureFlagRepository) UpdateDeployFeatureFlag(flag *models.DeployFeatureFlag) (*models.DeployFeatureFlag, error) {
+	panic("not implemented") // TODO: Implement
+}
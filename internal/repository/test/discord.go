@@ -0,0 +1,28 @@
+package test
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type DiscordIntegrationRepository struct{}
+
+func NewDiscordIntegrationRepository(canQuery bool) repository.DiscordIntegrationRepository {
+	return &DiscordIntegrationRepository{}
+}
+
+func (d *DiscordIntegrationRepository) CreateDiscordIntegration(discordInt *ints.DiscordIntegration) (*ints.DiscordIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (d *DiscordIntegrationRepository) ReadDiscordIntegration(projectID, integrationID uint) (*ints.DiscordIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (d *DiscordIntegrationRepository) ListDiscordIntegrationsByProjectID(projectID uint) ([]*ints.DiscordIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (d *DiscordIntegrationRepository) DeleteDiscordIntegration(discordInt *ints.DiscordIntegration) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type DNSProviderIntegrationRepository struct{}
+
+func NewDNSProviderIntegrationRepository(canQuery bool) repository.DNSProviderIntegrationRepository {
+	return &DNSProviderIntegrationRepository{}
+}
+
+func (t *DNSProviderIntegrationRepository) CreateDNSProviderIntegration(dnsInt *ints.DNSProviderIntegration) (*ints.DNSProviderIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *DNSProviderIntegrationRepository) ReadDNSProviderIntegration(projectID, integrationID uint) (*ints.DNSProviderIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *DNSProviderIntegrationRepository) ListDNSProviderIntegrationsByProjectID(projectID uint) ([]*ints.DNSProviderIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *DNSProviderIntegrationRepository) DeleteDNSProviderIntegration(dnsInt *ints.DNSProviderIntegration) error {
+	panic("not implemented") // TODO: Implement
+}
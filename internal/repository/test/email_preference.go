@@ -0,0 +1,49 @@
+package test
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type EmailPreferenceRepository struct{}
+
+func NewEmailPreferenceRepository() repository.EmailPreferenceRepository {
+	return &EmailPreferenceRepository{}
+}
+
+func (repo *EmailPreferenceRepository) CreateEmailPreference(pref *models.EmailPreference) (*models.EmailPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) ReadEmailPreference(userID uint) (*models.EmailPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) UpdateEmailPreference(pref *models.EmailPreference) (*models.EmailPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) ListEmailPreferencesByUserIDs(userIDs []uint) ([]*models.EmailPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) ListDueDigestPreferences(sentBefore time.Time) ([]*models.EmailPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) CreateDeploymentRecord(record *models.DeploymentRecord) (*models.DeploymentRecord, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) ListDeploymentRecords(
+	projectID uint,
+	createdAfter time.Time,
+) ([]*models.DeploymentRecord, error) {
+	panic("not implemented")
+}
+
+func (repo *EmailPreferenceRepository) DeleteDeploymentRecords(createdBefore time.Time) (int64, error) {
+	panic("not implemented")
+}
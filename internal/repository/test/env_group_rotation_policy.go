@@ -0,0 +1,46 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type EnvGroupRotationPolicyRepository struct{}
+
+func NewEnvGroupRotationPolicyRepository() repository.EnvGroupRotationPolicyRepository {
+	return &EnvGroupRotationPolicyRepository{}
+}
+
+func (repo *EnvGroupRotationPolicyRepository) CreateEnvGroupRotationPolicy(
+	policy *models.EnvGroupRotationPolicy,
+) (*models.EnvGroupRotationPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupRotationPolicyRepository) ReadEnvGroupRotationPolicy(
+	clusterID uint,
+	namespace, name, key string,
+) (*models.EnvGroupRotationPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupRotationPolicyRepository) ReadEnvGroupRotationPolicyByID(id uint) (*models.EnvGroupRotationPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupRotationPolicyRepository) ListEnvGroupRotationPolicies(
+	clusterID uint,
+	namespace, name string,
+) ([]*models.EnvGroupRotationPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupRotationPolicyRepository) UpdateEnvGroupRotationPolicy(
+	policy *models.EnvGroupRotationPolicy,
+) (*models.EnvGroupRotationPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupRotationPolicyRepository) DeleteEnvGroupRotationPolicy(policy *models.EnvGroupRotationPolicy) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type EnvGroupSourceRepository struct{}
+
+func NewEnvGroupSourceRepository() repository.EnvGroupSourceRepository {
+	return &EnvGroupSourceRepository{}
+}
+
+func (repo *EnvGroupSourceRepository) CreateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupSourceRepository) ReadEnvGroupSource(
+	clusterID uint,
+	namespace, name string,
+) (*models.EnvGroupSource, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupSourceRepository) ReadEnvGroupSourceByID(id uint) (*models.EnvGroupSource, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupSourceRepository) ReadEnvGroupSourceByWebhookToken(token string) (*models.EnvGroupSource, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupSourceRepository) UpdateEnvGroupSource(source *models.EnvGroupSource) (*models.EnvGroupSource, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *EnvGroupSourceRepository) DeleteEnvGroupSource(source *models.EnvGroupSource) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -1,22 +1,43 @@
 package test
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	UpdateDeploymentWithJobsMethod string = "update_deployment_with_jobs_0"
 )
 
-// EnvironmentRepository uses gorm.DB for querying the database
+// EnvironmentRepository will return errors on queries if canQuery is false, and stores
+// environments and deployments in-memory, indexed by their array index + 1. Deployments are
+// copied when they are stored and read, so that changes are only stored when they are written.
 type EnvironmentRepository struct {
+	canQuery       bool
+	failingMethods string
+	environments   []*models.Environment
+	deployments    []*models.Deployment
 }
 
-// NewEnvironmentRepository returns a DefaultEnvironmentRepository which uses
-// gorm.DB for querying the database
-func NewEnvironmentRepository() repository.EnvironmentRepository {
-	return &EnvironmentRepository{}
+// NewEnvironmentRepository will return errors if canQuery is false
+func NewEnvironmentRepository(canQuery bool, failingMethods ...string) repository.EnvironmentRepository {
+	return &EnvironmentRepository{canQuery: canQuery, failingMethods: strings.Join(failingMethods, ",")}
 }
 
 func (repo *EnvironmentRepository) CreateEnvironment(env *models.Environment) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.environments = append(repo.environments, env)
+	env.ID = uint(len(repo.environments))
+
+	return env, nil
 }
 
 func (repo *EnvironmentRepository) ReadEnvironment(projectID, clusterID, gitInstallationID uint, gitRepoOwner, gitRepoName string) (*models.Environment, error) {
@@ -24,7 +45,17 @@ func (repo *EnvironmentRepository) ReadEnvironment(projectID, clusterID, gitInst
 }
 
 func (repo *EnvironmentRepository) ReadEnvironmentByID(projectID, clusterID, envID uint) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, env := range repo.environments {
+		if env.ID == envID && env.ProjectID == projectID && env.ClusterID == clusterID && !env.DeletedAt.Valid {
+			return env, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *EnvironmentRepository) ReadEnvironmentByOwnerRepoName(
@@ -38,7 +69,7 @@ func (repo *EnvironmentRepository) ReadEnvironmentByWebhookIDOwnerRepoName(webho
 	panic("unimplemented")
 }
 
-func (repo *EnvironmentRepository) ListEnvironments(projectID, clusterID uint) ([]*models.Environment, error) {
+func (repo *EnvironmentRepository) ListEnvironments(projectID, clusterID uint, opts *repository.ListOptions) ([]*models.Environment, error) {
 	panic("unimplemented")
 }
 
@@ -46,38 +77,225 @@ func (repo *EnvironmentRepository) UpdateEnvironment(environment *models.Environ
 	panic("unimplemented")
 }
 
+// DeleteEnvironment soft-deletes an environment, and keeps its deployments
 func (repo *EnvironmentRepository) DeleteEnvironment(env *models.Environment) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	for _, e := range repo.environments {
+		if e.ID == env.ID && !e.DeletedAt.Valid {
+			e.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			return e, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *EnvironmentRepository) CreateDeployment(deployment *models.Deployment) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	deployment.ID = uint(len(repo.deployments) + 1)
+	repo.deployments = append(repo.deployments, copyDeployment(deployment))
+
+	return deployment, nil
 }
 
 func (repo *EnvironmentRepository) UpdateDeployment(deployment *models.Deployment) (*models.Deployment, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(deployment.ID-1) >= len(repo.deployments) || repo.deployments[deployment.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deployments[deployment.ID-1] = copyDeployment(deployment)
+
+	return deployment, nil
+}
+
+func (repo *EnvironmentRepository) UpdateDeploymentFields(
+	deployment *models.Deployment,
+	fields ...string,
+) (*models.Deployment, error) {
 	panic("unimplemented")
 }
 
+// UpdateDeploymentWithJobs updates a deployment. The jobs are not stored, since the test
+// repository does not run the job queue.
+func (repo *EnvironmentRepository) UpdateDeploymentWithJobs(
+	deployment *models.Deployment,
+	jobs ...*models.BackgroundJob,
+) (*models.Deployment, error) {
+	if !repo.canQuery || strings.Contains(repo.failingMethods, UpdateDeploymentWithJobsMethod) {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(deployment.ID-1) >= len(repo.deployments) || repo.deployments[deployment.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deployments[deployment.ID-1] = copyDeployment(deployment)
+
+	return deployment, nil
+}
+
+func (repo *EnvironmentRepository) SearchDeployments(
+	projectIDs []uint,
+	query string,
+	limit int,
+) ([]*repository.DeploymentSearchResult, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*repository.DeploymentSearchResult, 0)
+	query = strings.ToLower(query)
+
+	for _, depl := range repo.deployments {
+		if depl == nil || len(res) >= limit {
+			continue
+		}
+
+		matches := false
+
+		for _, field := range []string{depl.Namespace, depl.RepoName, depl.CommitSHA, depl.PRName, depl.Subdomain} {
+			if strings.Contains(strings.ToLower(field), query) {
+				matches = true
+			}
+		}
+
+		if !matches {
+			continue
+		}
+
+		for _, env := range repo.environments {
+			if env.ID != depl.EnvironmentID || env.DeletedAt.Valid {
+				continue
+			}
+
+			for _, projectID := range projectIDs {
+				if env.ProjectID == projectID {
+					res = append(res, &repository.DeploymentSearchResult{
+						Deployment: *copyDeployment(depl),
+						ProjectID:  env.ProjectID,
+						ClusterID:  env.ClusterID,
+					})
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
+
 func (repo *EnvironmentRepository) ReadDeployment(environmentID uint, namespace string) (*models.Deployment, error) {
 	panic("unimplemented")
 }
 
 func (repo *EnvironmentRepository) ReadDeploymentByID(projectID, clusterID, id uint) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.deployments) || repo.deployments[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	depl := repo.deployments[id-1]
+
+	// deployments are scoped to the project and cluster of their environment. Like the join of
+	// the database repository, deployments of deleted environments are still found.
+	for _, env := range repo.environments {
+		if env.ID == depl.EnvironmentID && env.ProjectID == projectID && env.ClusterID == clusterID {
+			return copyDeployment(depl), nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *EnvironmentRepository) ReadDeploymentByGitDetails(environmentID uint, owner, repoName string, prNumber uint) (*models.Deployment, error) {
 	panic("unimplemented")
 }
 
-func (repo *EnvironmentRepository) ListDeploymentsByCluster(projectID, clusterID uint, states ...string) ([]*models.Deployment, error) {
+func (repo *EnvironmentRepository) ReadEnvironmentAndDeployment(
+	projectID, clusterID, gitInstallationID uint,
+	owner, repoName string,
+	prNumber uint,
+	namespace string,
+) (*models.Environment, *models.Deployment, error) {
+	if !repo.canQuery {
+		return nil, nil, errors.New("Cannot read from database")
+	}
+
+	var env *models.Environment
+
+	for _, e := range repo.environments {
+		if !e.DeletedAt.Valid && e.ProjectID == projectID && e.ClusterID == clusterID && e.GitInstallationID == gitInstallationID &&
+			strings.EqualFold(e.GitRepoOwner, owner) && strings.EqualFold(e.GitRepoName, repoName) {
+			env = e
+			break
+		}
+	}
+
+	if env == nil {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+
+	for _, depl := range repo.deployments {
+		if depl == nil || depl.EnvironmentID != env.ID {
+			continue
+		}
+
+		if (prNumber != 0 && depl.PullRequestID == prNumber) || (prNumber == 0 && depl.Namespace == namespace) {
+			return env, copyDeployment(depl), nil
+		}
+	}
+
+	return env, nil, gorm.ErrRecordNotFound
+}
+
+func (repo *EnvironmentRepository) ListDeploymentsByCluster(projectID, clusterID uint, opts *repository.ListOptions) ([]*models.Deployment, error) {
 	panic("unimplemented")
 }
 
-func (repo *EnvironmentRepository) ListDeployments(environmentID uint, states ...string) ([]*models.Deployment, error) {
+func (repo *EnvironmentRepository) ListDeployments(environmentID uint, opts *repository.ListOptions) ([]*models.Deployment, error) {
 	panic("unimplemented")
 }
 
 func (repo *EnvironmentRepository) DeleteDeployment(deployment *models.Deployment) (*models.Deployment, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(deployment.ID-1) >= len(repo.deployments) || repo.deployments[deployment.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deployments[deployment.ID-1] = nil
+
+	return deployment, nil
+}
+
+func (repo *EnvironmentRepository) ListDeletedEnvironments(projectID, clusterID uint, deletedAfter time.Time) ([]*models.Environment, error) {
 	panic("unimplemented")
 }
+
+func (repo *EnvironmentRepository) RestoreEnvironment(projectID, clusterID, envID uint, deletedAfter time.Time) (*models.Environment, error) {
+	panic("unimplemented")
+}
+
+func (repo *EnvironmentRepository) PurgeDeletedEnvironments(deletedBefore time.Time) (int64, error) {
+	panic("unimplemented")
+}
+
+func copyDeployment(deployment *models.Deployment) *models.Deployment {
+	copied := *deployment
+
+	return &copied
+}
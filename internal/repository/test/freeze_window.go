@@ -0,0 +1,71 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type FreezeWindowRepository struct {
+	canQuery bool
+	windows  []*models.FreezeWindow
+}
+
+func NewFreezeWindowRepository(canQuery bool) repository.FreezeWindowRepository {
+	return &FreezeWindowRepository{canQuery, []*models.FreezeWindow{}}
+}
+
+func (repo *FreezeWindowRepository) CreateFreezeWindow(window *models.FreezeWindow) (*models.FreezeWindow, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.windows = append(repo.windows, window)
+	window.ID = uint(len(repo.windows))
+
+	return window, nil
+}
+
+func (repo *FreezeWindowRepository) ReadFreezeWindow(projectID, id uint) (*models.FreezeWindow, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.windows) || repo.windows[id-1] == nil || repo.windows[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.windows[id-1], nil
+}
+
+func (repo *FreezeWindowRepository) ListFreezeWindows(projectID uint) ([]*models.FreezeWindow, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.FreezeWindow, 0)
+
+	for _, window := range repo.windows {
+		if window != nil && window.ProjectID == projectID {
+			res = append(res, window)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *FreezeWindowRepository) DeleteFreezeWindow(window *models.FreezeWindow) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(window.ID-1) >= len(repo.windows) || repo.windows[window.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.windows[window.ID-1] = nil
+
+	return nil
+}
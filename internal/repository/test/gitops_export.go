@@ -0,0 +1,28 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type GitOpsExportRepository struct{}
+
+func NewGitOpsExportRepository() repository.GitOpsExportRepository {
+	return &GitOpsExportRepository{}
+}
+
+func (repo *GitOpsExportRepository) CreateGitOpsExport(export *models.GitOpsExport) (*models.GitOpsExport, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *GitOpsExportRepository) ReadGitOpsExportByStackID(stackID uint) (*models.GitOpsExport, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *GitOpsExportRepository) UpdateGitOpsExport(export *models.GitOpsExport) (*models.GitOpsExport, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *GitOpsExportRepository) DeleteGitOpsExport(export *models.GitOpsExport) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ImageSignaturePolicyRepository struct {
+	canQuery bool
+	policies map[uint]*models.ImageSignaturePolicy
+}
+
+func NewImageSignaturePolicyRepository(canQuery bool) repository.ImageSignaturePolicyRepository {
+	return &ImageSignaturePolicyRepository{canQuery, make(map[uint]*models.ImageSignaturePolicy)}
+}
+
+func (repo *ImageSignaturePolicyRepository) CreateOrUpdateImageSignaturePolicy(
+	policy *models.ImageSignaturePolicy,
+) (*models.ImageSignaturePolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if existing, ok := repo.policies[policy.ProjectID]; ok {
+		policy.ID = existing.ID
+	} else {
+		policy.ID = uint(len(repo.policies) + 1)
+	}
+
+	repo.policies[policy.ProjectID] = policy
+
+	return policy, nil
+}
+
+func (repo *ImageSignaturePolicyRepository) ReadImageSignaturePolicy(projectID uint) (*models.ImageSignaturePolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	policy, ok := repo.policies[projectID]
+
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return policy, nil
+}
@@ -58,6 +58,7 @@ func (repo *InfraRepository) ReadInfra(
 func (repo *InfraRepository) ListInfrasByProjectID(
 	projectID uint,
 	apiVersion string,
+	opts *repository.ListOptions,
 ) ([]*models.Infra, error) {
 	if !repo.canQuery {
 		return nil, errors.New("Cannot read from database")
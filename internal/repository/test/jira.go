@@ -0,0 +1,38 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type JiraIntegrationRepository struct {
+	canQuery bool
+}
+
+func NewJiraIntegrationRepository(canQuery bool) repository.JiraIntegrationRepository {
+	return &JiraIntegrationRepository{canQuery}
+}
+
+func (t *JiraIntegrationRepository) CreateJiraIntegration(jiraInt *ints.JiraIntegration) (*ints.JiraIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *JiraIntegrationRepository) ReadJiraIntegration(projectID, integrationID uint) (*ints.JiraIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+// ListJiraIntegrationsByProjectID lists no integrations, since the test repository does not
+// store Jira integrations
+func (t *JiraIntegrationRepository) ListJiraIntegrationsByProjectID(projectID uint) ([]*ints.JiraIntegration, error) {
+	if !t.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	return make([]*ints.JiraIntegration, 0), nil
+}
+
+func (t *JiraIntegrationRepository) DeleteJiraIntegration(jiraInt *ints.JiraIntegration) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,26 @@
+package test
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type LeaderLeaseRepository struct{}
+
+func NewLeaderLeaseRepository() repository.LeaderLeaseRepository {
+	return &LeaderLeaseRepository{}
+}
+
+func (repo *LeaderLeaseRepository) AcquireLeaderLease(name, holder string, now, expiresAt time.Time) (bool, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *LeaderLeaseRepository) ReleaseLeaderLease(name, holder string, now time.Time) error {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *LeaderLeaseRepository) ListActiveLeaderLeases(prefix string, now time.Time) ([]*models.LeaderLease, error) {
+	panic("not implemented") // TODO: Implement
+}
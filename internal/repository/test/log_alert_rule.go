@@ -0,0 +1,32 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type LogAlertRuleRepository struct{}
+
+func NewLogAlertRuleRepository() repository.LogAlertRuleRepository {
+	return &LogAlertRuleRepository{}
+}
+
+func (repo *LogAlertRuleRepository) CreateLogAlertRule(rule *models.LogAlertRule) (*models.LogAlertRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *LogAlertRuleRepository) ReadLogAlertRule(clusterID, id uint) (*models.LogAlertRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *LogAlertRuleRepository) ListLogAlertRulesByRelease(clusterID uint, namespace, releaseName string) ([]*models.LogAlertRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *LogAlertRuleRepository) ListLogAlertRulesByCluster(clusterID uint) ([]*models.LogAlertRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *LogAlertRuleRepository) DeleteLogAlertRule(rule *models.LogAlertRule) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type NotificationPreferenceRepository struct{}
+
+func NewNotificationPreferenceRepository() repository.NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{}
+}
+
+func (repo *NotificationPreferenceRepository) CreateNotificationPreference(pref *models.NotificationPreference) (*models.NotificationPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *NotificationPreferenceRepository) ReadNotificationPreference(projectID, userID uint, channel types.NotificationChannel) (*models.NotificationPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *NotificationPreferenceRepository) UpdateNotificationPreference(pref *models.NotificationPreference) (*models.NotificationPreference, error) {
+	panic("not implemented")
+}
+
+func (repo *NotificationPreferenceRepository) ListNotificationPreferencesByProjectID(projectID uint) ([]*models.NotificationPreference, error) {
+	panic("not implemented")
+}
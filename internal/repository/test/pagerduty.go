@@ -0,0 +1,28 @@
+package test
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type PagerDutyIntegrationRepository struct{}
+
+func NewPagerDutyIntegrationRepository(canQuery bool) repository.PagerDutyIntegrationRepository {
+	return &PagerDutyIntegrationRepository{}
+}
+
+func (t *PagerDutyIntegrationRepository) CreatePagerDutyIntegration(pdInt *ints.PagerDutyIntegration) (*ints.PagerDutyIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *PagerDutyIntegrationRepository) ReadPagerDutyIntegration(projectID, integrationID uint) (*ints.PagerDutyIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *PagerDutyIntegrationRepository) ListPagerDutyIntegrationsByProjectID(projectID uint) ([]*ints.PagerDutyIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *PagerDutyIntegrationRepository) DeletePagerDutyIntegration(pdInt *ints.PagerDutyIntegration) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -23,11 +24,14 @@ type ProjectRepository struct {
 	canQuery       bool
 	failingMethods string
 	projects       []*models.Project
+
+	// deleted projects, which are indexed by their id
+	deletedProjects map[uint]*models.Project
 }
 
 // NewProjectRepository will return errors if canQuery is false
 func NewProjectRepository(canQuery bool, failingMethods ...string) repository.ProjectRepository {
-	return &ProjectRepository{canQuery, strings.Join(failingMethods, ","), []*models.Project{}}
+	return &ProjectRepository{canQuery, strings.Join(failingMethods, ","), []*models.Project{}, make(map[uint]*models.Project)}
 }
 
 // CreateProject appends a new project to the in-memory projects array
@@ -105,14 +109,19 @@ func (repo *ProjectRepository) ReadProjectRole(userID, projID uint) (*models.Rol
 		return nil, errors.New("Cannot read from database")
 	}
 
-	if int(projID-1) >= len(repo.projects) || repo.projects[projID-1] == nil {
-		return nil, gorm.ErrRecordNotFound
+	project, ok := repo.deletedProjects[projID]
+
+	// the roles of deleted projects are kept until the projects are purged
+	if !ok {
+		if int(projID-1) >= len(repo.projects) || repo.projects[projID-1] == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+
+		project = repo.projects[projID-1]
 	}
 
 	// find role in project roles
-	index := int(projID - 1)
-
-	for _, role := range repo.projects[index].Roles {
+	for _, role := range project.Roles {
 		if role.UserID == userID {
 			return &role, nil
 		}
@@ -184,6 +193,9 @@ func (repo *ProjectRepository) DeleteProject(project *models.Project) (*models.P
 	index := int(project.ID - 1)
 	repo.projects[index] = nil
 
+	project.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	repo.deletedProjects[project.ID] = project
+
 	return project, nil
 }
 
@@ -222,3 +234,48 @@ func (repo *ProjectRepository) DeleteProjectRole(projID, userID uint) (*models.R
 
 	return &res, nil
 }
+
+func (repo *ProjectRepository) ListDeletedProjectsByUserID(userID uint, deletedAfter time.Time) ([]*models.Project, error) {
+	panic("unimplemented")
+}
+
+// RestoreProject restores a project which was deleted after a given time
+func (repo *ProjectRepository) RestoreProject(id uint, deletedAfter time.Time) (*models.Project, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	project, ok := repo.deletedProjects[id]
+
+	if !ok || !project.DeletedAt.Time.After(deletedAfter) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	delete(repo.deletedProjects, id)
+
+	project.DeletedAt = gorm.DeletedAt{}
+	repo.projects[id-1] = project
+
+	return project, nil
+}
+
+func (repo *ProjectRepository) PurgeDeletedProjects(deletedBefore time.Time) (int64, error) {
+	panic("unimplemented")
+}
+
+// ListProjectIDs lists the IDs of every project which is stored
+func (repo *ProjectRepository) ListProjectIDs() ([]uint, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	ids := make([]uint, 0)
+
+	for _, project := range repo.projects {
+		if project != nil {
+			ids = append(ids, project.ID)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,25 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type PullThroughCacheRuleRepository struct{}
+
+func NewPullThroughCacheRuleRepository() repository.PullThroughCacheRuleRepository {
+	return &PullThroughCacheRuleRepository{}
+}
+
+func (repo *PullThroughCacheRuleRepository) ListPullThroughCacheRulesByClusterID(
+	projectID, clusterID uint,
+) ([]*models.PullThroughCacheRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *PullThroughCacheRuleRepository) ReplacePullThroughCacheRules(
+	projectID, clusterID uint,
+	rules []*models.PullThroughCacheRule,
+) ([]*models.PullThroughCacheRule, error) {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type QueuedDeployRepository struct {
+	canQuery bool
+	deploys  []*models.QueuedDeploy
+}
+
+func NewQueuedDeployRepository(canQuery bool) repository.QueuedDeployRepository {
+	return &QueuedDeployRepository{canQuery, []*models.QueuedDeploy{}}
+}
+
+func (repo *QueuedDeployRepository) CreateQueuedDeploy(deploy *models.QueuedDeploy) (*models.QueuedDeploy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.deploys = append(repo.deploys, deploy)
+	deploy.ID = uint(len(repo.deploys))
+
+	return deploy, nil
+}
+
+func (repo *QueuedDeployRepository) ReadQueuedDeploy(clusterID, id uint) (*models.QueuedDeploy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id) > len(repo.deploys) || repo.deploys[id-1].ClusterID != clusterID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.deploys[id-1], nil
+}
+
+func (repo *QueuedDeployRepository) ListQueuedDeploys(clusterID uint, namespace, name string) ([]*models.QueuedDeploy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.QueuedDeploy, 0)
+
+	for _, deploy := range repo.deploys {
+		if deploy.ClusterID == clusterID && deploy.Namespace == namespace && deploy.Name == name &&
+			(deploy.Status == types.DeployStatusQueued || deploy.Status == types.DeployStatusRunning) {
+			res = append(res, deploy)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *QueuedDeployRepository) ClaimQueuedDeploy(deploy *models.QueuedDeploy, now time.Time) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	if deploy.Status != types.DeployStatusQueued {
+		return false, nil
+	}
+
+	deploy.Status = types.DeployStatusRunning
+	deploy.StartedAt = &now
+
+	return true, nil
+}
+
+func (repo *QueuedDeployRepository) CancelQueuedDeploy(deploy *models.QueuedDeploy, userID uint, now time.Time) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	if deploy.Status != types.DeployStatusQueued {
+		return false, nil
+	}
+
+	deploy.Status = types.DeployStatusCancelled
+	deploy.CancelledByUserID = userID
+	deploy.FinishedAt = &now
+
+	return true, nil
+}
+
+func (repo *QueuedDeployRepository) UpdateQueuedDeploy(deploy *models.QueuedDeploy) (*models.QueuedDeploy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if deploy.ID == 0 || int(deploy.ID) > len(repo.deploys) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deploys[deploy.ID-1] = deploy
+
+	return deploy, nil
+}
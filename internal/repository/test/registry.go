@@ -59,6 +59,20 @@ func (repo *RegistryRepository) ReadRegistryByInfraID(
 	panic("unimplemented")
 }
 
+func (repo *RegistryRepository) ReadRegistryByWebhookToken(token string) (*models.Registry, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, reg := range repo.registries {
+		if reg != nil && token != "" && reg.WebhookToken == token {
+			return reg, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
 // ListRegistriesByProjectID finds all registries
 // for a given project id
 func (repo *RegistryRepository) ListRegistriesByProjectID(
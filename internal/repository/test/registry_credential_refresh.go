@@ -0,0 +1,30 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type RegistryCredentialRefreshRepository struct{}
+
+func NewRegistryCredentialRefreshRepository() repository.RegistryCredentialRefreshRepository {
+	return &RegistryCredentialRefreshRepository{}
+}
+
+func (repo *RegistryCredentialRefreshRepository) CreateOrUpdateRegistryCredentialRefresh(
+	refresh *models.RegistryCredentialRefresh,
+) (*models.RegistryCredentialRefresh, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *RegistryCredentialRefreshRepository) ReadRegistryCredentialRefresh(
+	clusterID, registryID uint,
+) (*models.RegistryCredentialRefresh, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *RegistryCredentialRefreshRepository) ListRegistryCredentialRefreshesByClusterID(
+	projectID, clusterID uint,
+) ([]*models.RegistryCredentialRefresh, error) {
+	panic("not implemented") // TODO: Implement
+}
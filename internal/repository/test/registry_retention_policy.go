@@ -0,0 +1,32 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type RegistryRetentionPolicyRepository struct{}
+
+func NewRegistryRetentionPolicyRepository() repository.RegistryRetentionPolicyRepository {
+	return &RegistryRetentionPolicyRepository{}
+}
+
+func (repo *RegistryRetentionPolicyRepository) CreateOrUpdateRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) (*models.RegistryRetentionPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *RegistryRetentionPolicyRepository) ReadRegistryRetentionPolicy(
+	projectID, registryID uint,
+) (*models.RegistryRetentionPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *RegistryRetentionPolicyRepository) ListEnabledRegistryRetentionPolicies() ([]*models.RegistryRetentionPolicy, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *RegistryRetentionPolicyRepository) DeleteRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) error {
+	panic("not implemented") // TODO: Implement
+}
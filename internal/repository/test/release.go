@@ -2,6 +2,7 @@ package test
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -77,6 +78,7 @@ func (repo *ReleaseRepository) ReadRelease(
 // ListReleasesByProjectID finds all releases for a given project id
 func (repo *ReleaseRepository) ListReleasesByImageRepoURI(
 	clusterID uint, imageRepoURI string,
+	opts *repository.ListOptions,
 ) ([]*models.Release, error) {
 	if !repo.canQuery {
 		return nil, errors.New("Cannot read from database")
@@ -93,6 +95,47 @@ func (repo *ReleaseRepository) ListReleasesByImageRepoURI(
 	return res, nil
 }
 
+func (repo *ReleaseRepository) ListReleaseHistory(
+	clusterID uint, namespace, name string,
+	opts *repository.ListOptions,
+) ([]*models.DeploymentRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	return []*models.DeploymentRecord{}, nil
+}
+
+func (repo *ReleaseRepository) SearchReleases(projectIDs []uint, query string, limit int) ([]*models.Release, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Release, 0)
+	query = strings.ToLower(query)
+
+	for _, release := range repo.releases {
+		if release == nil || len(res) >= limit {
+			continue
+		}
+
+		inProject := false
+
+		for _, projectID := range projectIDs {
+			if release.ProjectID == projectID {
+				inProject = true
+			}
+		}
+
+		if inProject && (strings.Contains(strings.ToLower(release.Name), query) ||
+			strings.Contains(strings.ToLower(release.Namespace), query)) {
+			res = append(res, release)
+		}
+	}
+
+	return res, nil
+}
+
 // UpdateRelease modifies an existing Release in the database
 func (repo *ReleaseRepository) UpdateRelease(
 	release *models.Release,
@@ -32,6 +32,15 @@ type TestRepository struct {
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	discordIntegration        repository.DiscordIntegrationRepository
+	teamsIntegration          repository.TeamsIntegrationRepository
+	pagerDutyIntegration      repository.PagerDutyIntegrationRepository
+	datadogIntegration        repository.DatadogIntegrationRepository
+	sentryIntegration         repository.SentryIntegrationRepository
+	jiraIntegration           repository.JiraIntegrationRepository
+	linearIntegration         repository.LinearIntegrationRepository
+	dopplerIntegration        repository.DopplerIntegrationRepository
+	grafanaIntegration        repository.GrafanaIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
 	buildEvent                repository.BuildEventRepository
@@ -47,6 +56,39 @@ type TestRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	auditLog                  repository.AuditLogRepository
+	clusterIncident           repository.ClusterIncidentRepository
+	registryRetentionPolicy   repository.RegistryRetentionPolicyRepository
+	registryCredentialRefresh repository.RegistryCredentialRefreshRepository
+	build                     repository.BuildRepository
+	imageSignaturePolicy      repository.ImageSignaturePolicyRepository
+	pullThroughCacheRule      repository.PullThroughCacheRuleRepository
+	bulkDeploymentOperation   repository.BulkDeploymentOperationRepository
+	backgroundJob             repository.BackgroundJobRepository
+	archive                   repository.ArchiveRepository
+	webhookSubscription       repository.WebhookSubscriptionRepository
+	emailPreference           repository.EmailPreferenceRepository
+	notificationPreference    repository.NotificationPreferenceRepository
+	statusPage                repository.StatusPageRepository
+	envGroupSource            repository.EnvGroupSourceRepository
+	envGroupRotationPolicy    repository.EnvGroupRotationPolicyRepository
+	externalSecretStore       repository.ExternalSecretStoreRepository
+	costRecord                repository.CostRecordRepository
+	multiClusterRelease       repository.MultiClusterReleaseRepository
+	dnsProviderIntegration    repository.DNSProviderIntegrationRepository
+	customDomainDNSRecord     repository.CustomDomainDNSRecordRepository
+	wildcardCertificate       repository.WildcardCertificateRepository
+	approval                  repository.ApprovalRepository
+	freezeWindow              repository.FreezeWindowRepository
+	queuedDeploy              repository.QueuedDeployRepository
+	gitOpsExport              repository.GitOpsExportRepository
+	workflowTemplate          repository.WorkflowTemplateRepository
+	clusterTunnel             repository.ClusterTunnelRepository
+	clusterRelay              repository.ClusterRelayRepository
+	featureFlagIntegration    repository.FeatureFlagIntegrationRepository
+	deployFeatureFlag         repository.DeployFeatureFlagRepository
+	logAlertRule              repository.LogAlertRuleRepository
+	leaderLease               repository.LeaderLeaseRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -157,6 +199,42 @@ func (t *TestRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *TestRepository) DiscordIntegration() repository.DiscordIntegrationRepository {
+	return t.discordIntegration
+}
+
+func (t *TestRepository) TeamsIntegration() repository.TeamsIntegrationRepository {
+	return t.teamsIntegration
+}
+
+func (t *TestRepository) PagerDutyIntegration() repository.PagerDutyIntegrationRepository {
+	return t.pagerDutyIntegration
+}
+
+func (t *TestRepository) DatadogIntegration() repository.DatadogIntegrationRepository {
+	return t.datadogIntegration
+}
+
+func (t *TestRepository) SentryIntegration() repository.SentryIntegrationRepository {
+	return t.sentryIntegration
+}
+
+func (t *TestRepository) JiraIntegration() repository.JiraIntegrationRepository {
+	return t.jiraIntegration
+}
+
+func (t *TestRepository) LinearIntegration() repository.LinearIntegrationRepository {
+	return t.linearIntegration
+}
+
+func (t *TestRepository) DopplerIntegration() repository.DopplerIntegrationRepository {
+	return t.dopplerIntegration
+}
+
+func (t *TestRepository) GrafanaIntegration() repository.GrafanaIntegrationRepository {
+	return t.grafanaIntegration
+}
+
 func (t *TestRepository) NotificationConfig() repository.NotificationConfigRepository {
 	return t.notificationConfig
 }
@@ -217,6 +295,144 @@ func (t *TestRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *TestRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
+func (t *TestRepository) ClusterIncident() repository.ClusterIncidentRepository {
+	return t.clusterIncident
+}
+
+func (t *TestRepository) RegistryRetentionPolicy() repository.RegistryRetentionPolicyRepository {
+	return t.registryRetentionPolicy
+}
+
+func (t *TestRepository) RegistryCredentialRefresh() repository.RegistryCredentialRefreshRepository {
+	return t.registryCredentialRefresh
+}
+
+func (t *TestRepository) Build() repository.BuildRepository {
+	return t.build
+}
+
+func (t *TestRepository) ImageSignaturePolicy() repository.ImageSignaturePolicyRepository {
+	return t.imageSignaturePolicy
+}
+
+func (t *TestRepository) PullThroughCacheRule() repository.PullThroughCacheRuleRepository {
+	return t.pullThroughCacheRule
+}
+
+func (t *TestRepository) BulkDeploymentOperation() repository.BulkDeploymentOperationRepository {
+	return t.bulkDeploymentOperation
+}
+
+func (t *TestRepository) BackgroundJob() repository.BackgroundJobRepository {
+	return t.backgroundJob
+}
+
+func (t *TestRepository) Archive() repository.ArchiveRepository {
+	return t.archive
+}
+
+func (t *TestRepository) WebhookSubscription() repository.WebhookSubscriptionRepository {
+	return t.webhookSubscription
+}
+
+func (t *TestRepository) EmailPreference() repository.EmailPreferenceRepository {
+	return t.emailPreference
+}
+
+func (t *TestRepository) NotificationPreference() repository.NotificationPreferenceRepository {
+	return t.notificationPreference
+}
+
+func (t *TestRepository) StatusPage() repository.StatusPageRepository {
+	return t.statusPage
+}
+
+func (t *TestRepository) EnvGroupSource() repository.EnvGroupSourceRepository {
+	return t.envGroupSource
+}
+
+func (t *TestRepository) EnvGroupRotationPolicy() repository.EnvGroupRotationPolicyRepository {
+	return t.envGroupRotationPolicy
+}
+
+func (t *TestRepository) ExternalSecretStore() repository.ExternalSecretStoreRepository {
+	return t.externalSecretStore
+}
+
+func (t *TestRepository) CostRecord() repository.CostRecordRepository {
+	return t.costRecord
+}
+
+func (t *TestRepository) MultiClusterRelease() repository.MultiClusterReleaseRepository {
+	return t.multiClusterRelease
+}
+
+func (t *TestRepository) DNSProviderIntegration() repository.DNSProviderIntegrationRepository {
+	return t.dnsProviderIntegration
+}
+
+func (t *TestRepository) CustomDomainDNSRecord() repository.CustomDomainDNSRecordRepository {
+	return t.customDomainDNSRecord
+}
+
+func (t *TestRepository) WildcardCertificate() repository.WildcardCertificateRepository {
+	return t.wildcardCertificate
+}
+
+func (t *TestRepository) Approval() repository.ApprovalRepository {
+	return t.approval
+}
+
+func (t *TestRepository) FreezeWindow() repository.FreezeWindowRepository {
+	return t.freezeWindow
+}
+
+func (t *TestRepository) QueuedDeploy() repository.QueuedDeployRepository {
+	return t.queuedDeploy
+}
+
+func (t *TestRepository) GitOpsExport() repository.GitOpsExportRepository {
+	return t.gitOpsExport
+}
+
+func (t *TestRepository) WorkflowTemplate() repository.WorkflowTemplateRepository {
+	return t.workflowTemplate
+}
+
+func (t *TestRepository) ClusterTunnel() repository.ClusterTunnelRepository {
+	return t.clusterTunnel
+}
+
+func (t *TestRepository) ClusterRelay() repository.ClusterRelayRepository {
+	return t.clusterRelay
+}
+
+func (t *TestRepository) FeatureFlagIntegration() repository.FeatureFlagIntegrationRepository {
+	return t.featureFlagIntegration
+}
+
+func (t *TestRepository) DeployFeatureFlag() repository.DeployFeatureFlagRepository {
+	return t.deployFeatureFlag
+}
+
+func (t *TestRepository) LogAlertRule() repository.LogAlertRuleRepository {
+	return t.logAlertRule
+}
+
+func (t *TestRepository) LeaderLease() repository.LeaderLeaseRepository {
+	return t.leaderLease
+}
+
+// Transaction calls fn with the test repository. Writes made by fn are kept in memory
+// even if fn returns an error.
+func (t *TestRepository) Transaction(fn func(tx repository.Repository) error) error {
+	return fn(t)
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -231,7 +447,7 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitActionConfig:           NewGitActionConfigRepository(canQuery),
 		invite:                    NewInviteRepository(canQuery),
 		release:                   NewReleaseRepository(canQuery),
-		environment:               NewEnvironmentRepository(),
+		environment:               NewEnvironmentRepository(canQuery, failingMethods...),
 		authCode:                  NewAuthCodeRepository(canQuery),
 		dnsRecord:                 NewDNSRecordRepository(canQuery),
 		pwResetToken:              NewPWResetTokenRepository(canQuery),
@@ -248,6 +464,15 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitlabIntegration:         NewGitlabIntegrationRepository(canQuery),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(canQuery),
 		slackIntegration:          NewSlackIntegrationRepository(canQuery),
+		discordIntegration:        NewDiscordIntegrationRepository(canQuery),
+		teamsIntegration:          NewTeamsIntegrationRepository(canQuery),
+		pagerDutyIntegration:      NewPagerDutyIntegrationRepository(canQuery),
+		datadogIntegration:        NewDatadogIntegrationRepository(canQuery),
+		sentryIntegration:         NewSentryIntegrationRepository(canQuery),
+		jiraIntegration:           NewJiraIntegrationRepository(canQuery),
+		linearIntegration:         NewLinearIntegrationRepository(canQuery),
+		dopplerIntegration:        NewDopplerIntegrationRepository(canQuery),
+		grafanaIntegration:        NewGrafanaIntegrationRepository(canQuery),
 		notificationConfig:        NewNotificationConfigRepository(canQuery),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(canQuery),
 		buildEvent:                NewBuildEventRepository(canQuery),
@@ -263,5 +488,38 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		tag:                       NewTagRepository(),
 		stack:                     NewStackRepository(),
 		monitor:                   NewMonitorTestResultRepository(canQuery),
+		auditLog:                  NewAuditLogRepository(canQuery),
+		clusterIncident:           NewClusterIncidentRepository(),
+		registryRetentionPolicy:   NewRegistryRetentionPolicyRepository(),
+		registryCredentialRefresh: NewRegistryCredentialRefreshRepository(),
+		build:                     NewBuildRepository(),
+		imageSignaturePolicy:      NewImageSignaturePolicyRepository(canQuery),
+		pullThroughCacheRule:      NewPullThroughCacheRuleRepository(),
+		bulkDeploymentOperation:   NewBulkDeploymentOperationRepository(canQuery),
+		backgroundJob:             NewBackgroundJobRepository(),
+		archive:                   NewArchiveRepository(),
+		webhookSubscription:       NewWebhookSubscriptionRepository(canQuery),
+		emailPreference:           NewEmailPreferenceRepository(),
+		notificationPreference:    NewNotificationPreferenceRepository(),
+		statusPage:                NewStatusPageRepository(),
+		envGroupSource:            NewEnvGroupSourceRepository(),
+		envGroupRotationPolicy:    NewEnvGroupRotationPolicyRepository(),
+		externalSecretStore:       NewExternalSecretStoreRepository(),
+		costRecord:                NewCostRecordRepository(),
+		multiClusterRelease:       NewMultiClusterReleaseRepository(),
+		dnsProviderIntegration:    NewDNSProviderIntegrationRepository(canQuery),
+		customDomainDNSRecord:     NewCustomDomainDNSRecordRepository(),
+		wildcardCertificate:       NewWildcardCertificateRepository(),
+		approval:                  NewApprovalRepository(canQuery),
+		freezeWindow:              NewFreezeWindowRepository(canQuery),
+		queuedDeploy:              NewQueuedDeployRepository(canQuery),
+		gitOpsExport:              NewGitOpsExportRepository(),
+		workflowTemplate:          NewWorkflowTemplateRepository(),
+		clusterTunnel:             NewClusterTunnelRepository(),
+		clusterRelay:              NewClusterRelayRepository(),
+		featureFlagIntegration:    NewFeatureFlagIntegrationRepository(),
+		deployFeatureFlag:         NewDeployFeatureFlagRepository(),
+		logAlertRule:              NewLogAlertRuleRepository(),
+		leaderLease:               NewLeaderLeaseRepository(),
 	}
 }
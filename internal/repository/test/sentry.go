@@ -0,0 +1,28 @@
+package test
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type SentryIntegrationRepository struct{}
+
+func NewSentryIntegrationRepository(canQuery bool) repository.SentryIntegrationRepository {
+	return &SentryIntegrationRepository{}
+}
+
+func (t *SentryIntegrationRepository) CreateSentryIntegration(sentryInt *ints.SentryIntegration) (*ints.SentryIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *SentryIntegrationRepository) ReadSentryIntegration(projectID, integrationID uint) (*ints.SentryIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *SentryIntegrationRepository) ListSentryIntegrationsByProjectID(projectID uint) ([]*ints.SentryIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *SentryIntegrationRepository) DeleteSentryIntegration(sentryInt *ints.SentryIntegration) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -22,3 +22,19 @@ func (s *SlackIntegrationRepository) ListSlackIntegrationsByProjectID(projectID
 func (s *SlackIntegrationRepository) DeleteSlackIntegration(integrationID uint) error {
 	panic("not implemented") // TODO: Implement
 }
+
+func (s *SlackIntegrationRepository) CreateSlackRoutingRule(rule *ints.SlackRoutingRule) (*ints.SlackRoutingRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (s *SlackIntegrationRepository) ReadSlackRoutingRule(projectID, ruleID uint) (*ints.SlackRoutingRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (s *SlackIntegrationRepository) ListSlackRoutingRules(projectID uint) ([]*ints.SlackRoutingRule, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (s *SlackIntegrationRepository) DeleteSlackRoutingRule(rule *ints.SlackRoutingRule) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type StatusPageRepository struct{}
+
+func NewStatusPageRepository() repository.StatusPageRepository {
+	return &StatusPageRepository{}
+}
+
+func (repo *StatusPageRepository) CreateStatusPage(page *models.StatusPage) (*models.StatusPage, error) {
+	panic("not implemented")
+}
+
+func (repo *StatusPageRepository) ReadStatusPageByProjectID(projectID uint) (*models.StatusPage, error) {
+	panic("not implemented")
+}
+
+func (repo *StatusPageRepository) ReadStatusPageBySubdomain(subdomain string) (*models.StatusPage, error) {
+	panic("not implemented")
+}
+
+func (repo *StatusPageRepository) UpdateStatusPage(page *models.StatusPage) (*models.StatusPage, error) {
+	panic("not implemented")
+}
+
+func (repo *StatusPageRepository) DeleteStatusPage(page *models.StatusPage) error {
+	panic("not implemented")
+}
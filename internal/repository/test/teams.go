@@ -0,0 +1,28 @@
+package test
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type TeamsIntegrationRepository struct{}
+
+func NewTeamsIntegrationRepository(canQuery bool) repository.TeamsIntegrationRepository {
+	return &TeamsIntegrationRepository{}
+}
+
+func (t *TeamsIntegrationRepository) CreateTeamsIntegration(teamsInt *ints.TeamsIntegration) (*ints.TeamsIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *TeamsIntegrationRepository) ReadTeamsIntegration(projectID, integrationID uint) (*ints.TeamsIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *TeamsIntegrationRepository) ListTeamsIntegrationsByProjectID(projectID uint) ([]*ints.TeamsIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (t *TeamsIntegrationRepository) DeleteTeamsIntegration(teamsInt *ints.TeamsIntegration) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -13,6 +14,7 @@ type ProjectUsageRepository struct {
 	canQuery bool
 	usages   []*models.ProjectUsage
 	caches   []*models.ProjectUsageCache
+	records  []*models.ProjectUsageRecord
 }
 
 // NewProjectUsageRepository will return errors if canQuery is false
@@ -21,6 +23,7 @@ func NewProjectUsageRepository(canQuery bool) repository.ProjectUsageRepository
 		canQuery,
 		[]*models.ProjectUsage{},
 		[]*models.ProjectUsageCache{},
+		[]*models.ProjectUsageRecord{},
 	}
 }
 
@@ -127,3 +130,57 @@ func (repo *ProjectUsageRepository) UpdateProjectUsageCache(
 
 	return cache, nil
 }
+
+// MeterProjectUsage returns an empty record, since the test repository doesn't store the
+// resources which are metered
+func (repo *ProjectUsageRepository) MeterProjectUsage(
+	projID uint,
+	from, to time.Time,
+) (*models.ProjectUsageRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	return &models.ProjectUsageRecord{ProjectID: projID}, nil
+}
+
+// CreateProjectUsageRecord stores the usage of a project for an hour, unless the hour was
+// already metered
+func (repo *ProjectUsageRepository) CreateProjectUsageRecord(
+	record *models.ProjectUsageRecord,
+) (*models.ProjectUsageRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	for _, existing := range repo.records {
+		if existing.ProjectID == record.ProjectID && existing.Hour.Equal(record.Hour) {
+			return record, nil
+		}
+	}
+
+	record.ID = uint(len(repo.records) + 1)
+	repo.records = append(repo.records, record)
+
+	return record, nil
+}
+
+// ListProjectUsageRecords lists the metered hours of a project which start between from and to
+func (repo *ProjectUsageRepository) ListProjectUsageRecords(
+	projID uint,
+	from, to time.Time,
+) ([]*models.ProjectUsageRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ProjectUsageRecord, 0)
+
+	for _, record := range repo.records {
+		if record.ProjectID == projID && !record.Hour.Before(from) && record.Hour.Before(to) {
+			res = append(res, record)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type WebhookSubscriptionRepository struct{}
+
+func NewWebhookSubscriptionRepository(canQuery bool) repository.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{}
+}
+
+func (repo *WebhookSubscriptionRepository) CreateWebhookSubscription(
+	sub *models.WebhookSubscription,
+) (*models.WebhookSubscription, error) {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) ReadWebhookSubscription(
+	projectID, id uint,
+) (*models.WebhookSubscription, error) {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) ListWebhookSubscriptionsByProjectID(
+	projectID uint,
+) ([]*models.WebhookSubscription, error) {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) DeleteWebhookSubscription(sub *models.WebhookSubscription) error {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) CreateWebhookDeliveryWithJob(
+	delivery *models.WebhookDelivery,
+	newJob func(delivery *models.WebhookDelivery) (*models.BackgroundJob, error),
+) (*models.WebhookDelivery, error) {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) ReadWebhookDelivery(projectID, id uint) (*models.WebhookDelivery, error) {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) ListWebhookDeliveries(
+	projectID, subscriptionID uint,
+	opts *repository.ListOptions,
+) ([]*models.WebhookDelivery, error) {
+	panic("not implemented")
+}
+
+func (repo *WebhookSubscriptionRepository) UpdateWebhookDelivery(
+	delivery *models.WebhookDelivery,
+) (*models.WebhookDelivery, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type WildcardCertificateRepository struct{}
+
+func NewWildcardCertificateRepository() repository.WildcardCertificateRepository {
+	return &WildcardCertificateRepository{}
+}
+
+func (repo *WildcardCertificateRepository) CreateWildcardCertificate(
+	cert *models.WildcardCertificate,
+) (*models.WildcardCertificate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WildcardCertificateRepository) ReadWildcardCertificate(
+	clusterID, id uint,
+) (*models.WildcardCertificate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WildcardCertificateRepository) ReadWildcardCertificateByDomain(
+	clusterID uint,
+	domain string,
+) (*models.WildcardCertificate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WildcardCertificateRepository) ListWildcardCertificatesByClusterID(
+	clusterID uint,
+) ([]*models.WildcardCertificate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WildcardCertificateRepository) ListWildcardCertificates() ([]*models.WildcardCertificate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WildcardCertificateRepository) UpdateWildcardCertificate(
+	cert *models.WildcardCertificate,
+) (*models.WildcardCertificate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WildcardCertificateRepository) DeleteWildcardCertificate(cert *models.WildcardCertificate) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type WorkflowTemplateRepository struct{}
+
+func NewWorkflowTemplateRepository() repository.WorkflowTemplateRepository {
+	return &WorkflowTemplateRepository{}
+}
+
+func (repo *WorkflowTemplateRepository) CreateWorkflowTemplate(template *models.WorkflowTemplate) (*models.WorkflowTemplate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WorkflowTemplateRepository) ReadWorkflowTemplate(projectID uint, kind string) (*models.WorkflowTemplate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WorkflowTemplateRepository) ListWorkflowTemplates(projectID uint) ([]*models.WorkflowTemplate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WorkflowTemplateRepository) UpdateWorkflowTemplate(template *models.WorkflowTemplate) (*models.WorkflowTemplate, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (repo *WorkflowTemplateRepository) DeleteWorkflowTemplate(template *models.WorkflowTemplate) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -1,6 +1,10 @@
 package repository
 
-import "github.com/porter-dev/porter/internal/models"
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
 
 // ProjectUsageRepository represents the set of queries on the ProjectUsage model
 type ProjectUsageRepository interface {
@@ -10,4 +14,13 @@ type ProjectUsageRepository interface {
 	CreateProjectUsageCache(cache *models.ProjectUsageCache) (*models.ProjectUsageCache, error)
 	ReadProjectUsageCache(projID uint) (*models.ProjectUsageCache, error)
 	UpdateProjectUsageCache(cache *models.ProjectUsageCache) (*models.ProjectUsageCache, error)
+
+	// MeterProjectUsage counts the deployed applications, clusters and active preview
+	// environments of a project, and the seconds of its builds which finished between from and to
+	MeterProjectUsage(projID uint, from, to time.Time) (*models.ProjectUsageRecord, error)
+
+	// CreateProjectUsageRecord stores the usage of a project for an hour. A record which already
+	// exists for the hour is left unchanged, so an hour is only metered once.
+	CreateProjectUsageRecord(record *models.ProjectUsageRecord) (*models.ProjectUsageRecord, error)
+	ListProjectUsageRecords(projID uint, from, to time.Time) ([]*models.ProjectUsageRecord, error)
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WebhookSubscriptionRepository represents the set of queries on webhook subscriptions and
+// their deliveries
+type WebhookSubscriptionRepository interface {
+	CreateWebhookSubscription(sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	ReadWebhookSubscription(projectID, id uint) (*models.WebhookSubscription, error)
+	ListWebhookSubscriptionsByProjectID(projectID uint) ([]*models.WebhookSubscription, error)
+
+	// DeleteWebhookSubscription deletes a subscription along with its deliveries
+	DeleteWebhookSubscription(sub *models.WebhookSubscription) error
+
+	// CreateWebhookDeliveryWithJob creates a delivery along with the background job which
+	// sends it. The job is created after the delivery, so its payload can refer to the
+	// delivery id.
+	CreateWebhookDeliveryWithJob(
+		delivery *models.WebhookDelivery,
+		newJob func(delivery *models.WebhookDelivery) (*models.BackgroundJob, error),
+	) (*models.WebhookDelivery, error)
+
+	ReadWebhookDelivery(projectID, id uint) (*models.WebhookDelivery, error)
+	ListWebhookDeliveries(projectID, subscriptionID uint, opts *ListOptions) ([]*models.WebhookDelivery, error)
+	UpdateWebhookDelivery(delivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+}
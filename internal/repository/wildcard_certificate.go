@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// WildcardCertificateRepository represents the set of queries on the wildcard certificates of
+// a cluster
+type WildcardCertificateRepository interface {
+	CreateWildcardCertificate(cert *models.WildcardCertificate) (*models.WildcardCertificate, error)
+	ReadWildcardCertificate(clusterID, id uint) (*models.WildcardCertificate, error)
+	ReadWildcardCertificateByDomain(clusterID uint, domain string) (*models.WildcardCertificate, error)
+	ListWildcardCertificatesByClusterID(clusterID uint) ([]*models.WildcardCertificate, error)
+
+	// ListWildcardCertificates lists the wildcard certificates of every cluster, which are
+	// distributed in the background
+	ListWildcardCertificates() ([]*models.WildcardCertificate, error)
+	UpdateWildcardCertificate(cert *models.WildcardCertificate) (*models.WildcardCertificate, error)
+	DeleteWildcardCertificate(cert *models.WildcardCertificate) error
+}
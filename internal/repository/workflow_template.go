@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WorkflowTemplateRepository represents the set of queries on the WorkflowTemplate model
+type WorkflowTemplateRepository interface {
+	CreateWorkflowTemplate(template *models.WorkflowTemplate) (*models.WorkflowTemplate, error)
+	ReadWorkflowTemplate(projectID uint, kind string) (*models.WorkflowTemplate, error)
+	ListWorkflowTemplates(projectID uint) ([]*models.WorkflowTemplate, error)
+	UpdateWorkflowTemplate(template *models.WorkflowTemplate) (*models.WorkflowTemplate, error)
+	DeleteWorkflowTemplate(template *models.WorkflowTemplate) error
+}
@@ -0,0 +1,94 @@
+package secretrotation
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// DefaultLength is the length of generated values, unless the policy sets a length
+const DefaultLength = 32
+
+const (
+	alphanumericChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	hexChars          = "0123456789abcdef"
+)
+
+// Generator generates the new value of a secret variable which is rotated by a policy. Generators
+// which change the value in an external system, such as the password of a database, do so
+// before returning the value.
+type Generator interface {
+	Generate(ctx context.Context, policy *models.EnvGroupRotationPolicy) (string, error)
+}
+
+// GeneratorFactory returns a generator which can read the resources referenced by policies from
+// the repository
+type GeneratorFactory func(repo repository.Repository) Generator
+
+var generators = map[types.RotationGeneratorKind]GeneratorFactory{
+	types.RotationGeneratorRandom: func(repo repository.Repository) Generator {
+		return &RandomGenerator{}
+	},
+	types.RotationGeneratorRDSPassword: func(repo repository.Repository) Generator {
+		return &RDSPasswordGenerator{repo}
+	},
+}
+
+// RegisterGenerator sets the generator of a kind, replacing the existing generator of that kind
+func RegisterGenerator(kind types.RotationGeneratorKind, factory GeneratorFactory) {
+	generators[kind] = factory
+}
+
+// GetGenerator returns the generator of a kind
+func GetGenerator(repo repository.Repository, kind types.RotationGeneratorKind) (Generator, error) {
+	factory, ok := generators[kind]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown rotation generator %s", kind)
+	}
+
+	return factory(repo), nil
+}
+
+// RandomGenerator generates random strings of the length and charset of the policy
+type RandomGenerator struct{}
+
+func (g *RandomGenerator) Generate(ctx context.Context, policy *models.EnvGroupRotationPolicy) (string, error) {
+	chars := alphanumericChars
+
+	if policy.Charset == types.RotationCharsetHex {
+		chars = hexChars
+	}
+
+	return randomString(policyLength(policy), chars)
+}
+
+func policyLength(policy *models.EnvGroupRotationPolicy) int {
+	if policy.Length == 0 {
+		return DefaultLength
+	}
+
+	return int(policy.Length)
+}
+
+func randomString(length int, chars string) (string, error) {
+	res := make([]byte, length)
+	max := big.NewInt(int64(len(chars)))
+
+	for i := range res {
+		n, err := rand.Int(rand.Reader, max)
+
+		if err != nil {
+			return "", err
+		}
+
+		res[i] = chars[n.Int64()]
+	}
+
+	return string(res), nil
+}
@@ -0,0 +1,53 @@
+package secretrotation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestRandomGenerator(t *testing.T) {
+	tests := []struct {
+		policy *models.EnvGroupRotationPolicy
+		length int
+		chars  string
+	}{
+		{&models.EnvGroupRotationPolicy{}, DefaultLength, alphanumericChars},
+		{&models.EnvGroupRotationPolicy{Length: 64, Charset: types.RotationCharsetHex}, 64, hexChars},
+	}
+
+	for _, test := range tests {
+		val, err := (&RandomGenerator{}).Generate(context.Background(), test.policy)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(val) != test.length {
+			t.Errorf("expected length %d, got %d", test.length, len(val))
+		}
+
+		for _, c := range val {
+			if !strings.ContainsRune(test.chars, c) {
+				t.Errorf("unexpected character %q in %s", c, val)
+			}
+		}
+	}
+}
+
+func TestGetRDSRegion(t *testing.T) {
+	tests := map[string]string{
+		"mydb.abcdefghijkl.us-east-1.rds.amazonaws.com:5432": "us-east-1",
+		"mydb.abcdefghijkl.eu-west-2.rds.amazonaws.com":      "eu-west-2",
+		"localhost:5432": "",
+	}
+
+	for endpoint, expected := range tests {
+		if region := getRDSRegion(endpoint); region != expected {
+			t.Errorf("expected region %q for %s, got %q", expected, endpoint, region)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package secretrotation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// RDSPasswordGenerator generates a random password and sets it as the master password of an RDS
+// database which was provisioned by Porter. The password is also stored in the configuration of
+// the infra, so that updating the infra doesn't reset the password.
+type RDSPasswordGenerator struct {
+	repo repository.Repository
+}
+
+func (g *RDSPasswordGenerator) Generate(ctx context.Context, policy *models.EnvGroupRotationPolicy) (string, error) {
+	infra, err := g.repo.Infra().ReadInfra(policy.ProjectID, policy.InfraID)
+
+	if err != nil {
+		return "", fmt.Errorf("error reading infra %d: %w", policy.InfraID, err)
+	}
+
+	if infra.Kind != types.InfraRDS {
+		return "", fmt.Errorf("infra %d is not an RDS database", infra.ID)
+	}
+
+	database, err := g.repo.Database().ReadDatabaseByInfraID(policy.ProjectID, infra.ID)
+
+	if err != nil {
+		return "", fmt.Errorf("error reading database of infra %d: %w", infra.ID, err)
+	}
+
+	awsInt, err := g.repo.AWSIntegration().ReadAWSIntegration(policy.ProjectID, infra.AWSIntegrationID)
+
+	if err != nil {
+		return "", fmt.Errorf("error reading aws integration of infra %d: %w", infra.ID, err)
+	}
+
+	// RDS doesn't allow some symbols in master passwords, so only letters and digits are used
+	password, err := randomString(policyLength(policy), alphanumericChars)
+
+	if err != nil {
+		return "", err
+	}
+
+	region := getRDSRegion(database.InstanceEndpoint)
+
+	if region == "" {
+		region = awsInt.AWSRegion
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+		Credentials: credentials.NewStaticCredentials(
+			string(awsInt.AWSAccessKeyID),
+			string(awsInt.AWSSecretAccessKey),
+			string(awsInt.AWSSessionToken),
+		),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = rds.New(sess).ModifyDBInstanceWithContext(ctx, &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(database.InstanceID),
+		MasterUserPassword:   aws.String(password),
+		ApplyImmediately:     aws.Bool(true),
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error setting the master password of rds instance %s: %w", database.InstanceID, err)
+	}
+
+	if err := g.updateLastApplied(infra, password); err != nil {
+		return "", fmt.Errorf("the password of rds instance %s was rotated, but the infra was not updated: %w",
+			database.InstanceID, err)
+	}
+
+	return password, nil
+}
+
+// updateLastApplied stores the password in the values of the latest operation of the infra,
+// which are applied again when the infra is updated without new values
+func (g *RDSPasswordGenerator) updateLastApplied(infra *models.Infra, password string) error {
+	operation, err := g.repo.Infra().GetLatestOperation(infra)
+
+	if err != nil {
+		return err
+	}
+
+	lastApplied := make(map[string]interface{})
+
+	if err := json.Unmarshal(operation.LastApplied, &lastApplied); err != nil {
+		return err
+	}
+
+	lastApplied["db_passwd"] = password
+
+	operation.LastApplied, err = json.Marshal(lastApplied)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = g.repo.Infra().UpdateOperation(operation)
+
+	return err
+}
+
+// getRDSRegion returns the region of an RDS endpoint, such as
+// mydb.abcdefghijkl.us-east-1.rds.amazonaws.com:5432
+func getRDSRegion(endpoint string) string {
+	host := strings.Split(endpoint, ":")[0]
+	parts := strings.Split(host, ".")
+
+	if len(parts) < 5 || parts[len(parts)-3] != "rds" {
+		return ""
+	}
+
+	return parts[len(parts)-4]
+}
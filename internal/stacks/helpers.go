@@ -10,8 +10,6 @@ import (
 func CloneSourceConfigs(sourceConfigs []models.StackSourceConfig) ([]models.StackSourceConfig, error) {
 	res := make([]models.StackSourceConfig, 0)
 
-	// for now, only write source configs which are deployed as a docker image
-	// TODO: add parsing/writes for git-based sources
 	for _, sourceConfig := range sourceConfigs {
 		uid, err := encryption.GenerateRandomBytes(16)
 
@@ -19,13 +17,17 @@ func CloneSourceConfigs(sourceConfigs []models.StackSourceConfig) ([]models.Stac
 			return nil, err
 		}
 
-		res = append(res, models.StackSourceConfig{
+		clone := models.StackSourceConfig{
 			UID:          uid,
 			Name:         sourceConfig.Name,
 			DisplayName:  sourceConfig.DisplayName,
 			ImageRepoURI: sourceConfig.ImageRepoURI,
 			ImageTag:     sourceConfig.ImageTag,
-		})
+		}
+
+		clone.SetBuild(sourceConfig.ToStackSourceConfigBuildType())
+
+		res = append(res, clone)
 	}
 
 	return res, nil
@@ -38,8 +40,6 @@ func CloneAppResources(
 ) ([]models.StackResource, error) {
 	res := make([]models.StackResource, 0)
 
-	// for now, only write source configs which are deployed as a docker image
-	// TODO: add parsing/writes for git-based sources
 	for _, appResource := range appResources {
 		uid, err := encryption.GenerateRandomBytes(16)
 
@@ -72,6 +72,7 @@ func CloneAppResources(
 			TemplateName:         appResource.TemplateName,
 			TemplateVersion:      appResource.TemplateVersion,
 			HelmRevisionID:       appResource.HelmRevisionID,
+			Values:               appResource.Values,
 		})
 	}
 
@@ -0,0 +1,41 @@
+package statuspage
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+var pageTemplate = template.Must(template.New("status_page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="60">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; background: #f7f7f8; color: #1f2023; margin: 0; }
+main { max-width: 720px; margin: 48px auto; padding: 0 16px; }
+.banner { border-radius: 6px; padding: 16px; color: #fff; font-weight: 600; margin-bottom: 24px; }
+.release { display: flex; justify-content: space-between; background: #fff; border: 1px solid #e4e4e7; padding: 14px 16px; margin-top: -1px; }
+.operational { background: #38a169; } .degraded { background: #dd6b20; } .down { background: #e53e3e; }
+.status { font-size: 14px; } .status.operational { color: #38a169; background: none; } .status.degraded { color: #dd6b20; background: none; } .status.down { color: #e53e3e; background: none; }
+footer { color: #71717a; font-size: 12px; margin-top: 24px; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{ .Title }}</h1>
+<div class="banner {{ .Status }}">{{ if eq .Status "operational" }}All systems operational{{ else if eq .Status "degraded" }}Some systems are degraded{{ else }}Some systems are down{{ end }}</div>
+{{ range .Releases }}<div class="release"><span>{{ .Name }}</span><span class="status {{ .Status }}">{{ .Status }} &middot; {{ printf "%.2f" .Uptime }}% uptime</span></div>
+{{ end }}<footer>Uptime over the last {{ .UptimeDays }} days. Updated {{ .UpdatedAt.Format "2006-01-02 15:04 MST" }}.</footer>
+</main>
+</body>
+</html>
+`))
+
+// Render writes a status page as HTML
+func Render(w io.Writer, page *types.PublicStatusPage) error {
+	return pageTemplate.Execute(w, page)
+}
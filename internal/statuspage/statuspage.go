@@ -0,0 +1,210 @@
+package statuspage
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// UptimeDays is the number of days which the uptime of each release is computed over
+const UptimeDays = 30
+
+var subdomainRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidateSubdomain checks that a subdomain is a single lowercase DNS label
+func ValidateSubdomain(subdomain string) error {
+	if !subdomainRegex.MatchString(subdomain) {
+		return fmt.Errorf("subdomain must consist of lowercase letters, numbers and hyphens, and must start and end with a letter or number")
+	}
+
+	return nil
+}
+
+// GetSubdomain returns the status page subdomain of a request host, if the host is a direct
+// subdomain of the status page domain
+func GetSubdomain(host, domain string) (string, bool) {
+	if domain == "" {
+		return "", false
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.ToLower(host)
+	suffix := "." + strings.ToLower(domain)
+
+	if !strings.HasSuffix(host, suffix) {
+		return "", false
+	}
+
+	subdomain := strings.TrimSuffix(host, suffix)
+
+	if ValidateSubdomain(subdomain) != nil {
+		return "", false
+	}
+
+	return subdomain, true
+}
+
+// GetURL returns the public URL of a status page, using the scheme of the server URL. An
+// empty string is returned if no status page domain is configured.
+func GetURL(serverURL, domain, subdomain string) string {
+	if domain == "" {
+		return ""
+	}
+
+	scheme := "https"
+
+	if parsed, err := url.Parse(serverURL); err == nil && parsed.Scheme != "" {
+		scheme = parsed.Scheme
+	}
+
+	return fmt.Sprintf("%s://%s.%s", scheme, subdomain, domain)
+}
+
+// GetPublicStatusPage computes the current status and uptime of each release on a status page
+// from the incidents detected in their clusters
+func GetPublicStatusPage(
+	repo repository.ClusterIncidentRepository,
+	page *models.StatusPage,
+	now time.Time,
+) (*types.PublicStatusPage, error) {
+	since := now.Add(-UptimeDays * 24 * time.Hour)
+
+	res := &types.PublicStatusPage{
+		Title:      page.Title,
+		Status:     types.StatusPageReleaseOperational,
+		UptimeDays: UptimeDays,
+		Releases:   make([]*types.PublicStatusPageRelease, 0, len(page.Releases)),
+		UpdatedAt:  now,
+	}
+
+	for _, rel := range page.Releases {
+		incidents, err := repo.ListReleaseIncidentsSince(page.ProjectID, rel.ClusterID, rel.Namespace, rel.ReleaseName, since)
+
+		if err != nil {
+			return nil, err
+		}
+
+		status, uptime := GetReleaseStatus(incidents, since, now)
+
+		res.Releases = append(res.Releases, &types.PublicStatusPageRelease{
+			Name:   rel.GetDisplayName(),
+			Status: status,
+			Uptime: uptime,
+		})
+
+		if getStatusRank(status) > getStatusRank(res.Status) {
+			res.Status = status
+		}
+	}
+
+	return res, nil
+}
+
+// GetReleaseStatus returns the current status of a release from its incidents, along with the
+// percentage of the time between since and now in which the release was not down. Crash loops
+// and image pull failures mean that the release is down, while other incidents such as failed
+// probes only degrade the release and do not count against its uptime.
+func GetReleaseStatus(incidents []*models.ClusterIncident, since, now time.Time) (types.StatusPageReleaseStatus, float64) {
+	status := types.StatusPageReleaseOperational
+
+	type interval struct {
+		start, end time.Time
+	}
+
+	outages := make([]interval, 0)
+
+	for _, incident := range incidents {
+		isOutage := isOutageReason(incident.Reason)
+
+		if incident.ResolvedAt == nil {
+			if isOutage {
+				status = types.StatusPageReleaseDown
+			} else if status == types.StatusPageReleaseOperational {
+				status = types.StatusPageReleaseDegraded
+			}
+		}
+
+		if !isOutage {
+			continue
+		}
+
+		start, end := incident.StartedAt, now
+
+		if incident.ResolvedAt != nil {
+			end = *incident.ResolvedAt
+		}
+
+		if start.Before(since) {
+			start = since
+		}
+
+		if end.After(now) {
+			end = now
+		}
+
+		if end.After(start) {
+			outages = append(outages, interval{start, end})
+		}
+	}
+
+	// incidents are sorted by start time, so overlapping outages can be merged in a single pass
+	var downtime time.Duration
+	var curr *interval
+
+	for i := range outages {
+		if curr != nil && !outages[i].start.After(curr.end) {
+			if outages[i].end.After(curr.end) {
+				curr.end = outages[i].end
+			}
+
+			continue
+		}
+
+		if curr != nil {
+			downtime += curr.end.Sub(curr.start)
+		}
+
+		curr = &outages[i]
+	}
+
+	if curr != nil {
+		downtime += curr.end.Sub(curr.start)
+	}
+
+	window := now.Sub(since).Milliseconds()
+
+	if window <= 0 {
+		return status, 100
+	}
+
+	// uptime is rounded down to two decimal places, so that a release which was briefly down is
+	// not shown at 100%
+	uptime := float64((window-downtime.Milliseconds())*10000/window) / 100
+
+	return status, uptime
+}
+
+func isOutageReason(reason string) bool {
+	return reason == string(types.ClusterIncidentReasonCrashLoop) || reason == string(types.ClusterIncidentReasonImagePull)
+}
+
+func getStatusRank(status types.StatusPageReleaseStatus) int {
+	switch status {
+	case types.StatusPageReleaseDegraded:
+		return 1
+	case types.StatusPageReleaseDown:
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,114 @@
+package statuspage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/statuspage"
+)
+
+func TestGetReleaseStatus(t *testing.T) {
+	now := time.Date(2022, 11, 30, 0, 0, 0, 0, time.UTC)
+	since := now.Add(-100 * time.Hour)
+
+	resolvedAt := func(d time.Duration) *time.Time {
+		res := now.Add(-d)
+		return &res
+	}
+
+	tests := []struct {
+		name      string
+		incidents []*models.ClusterIncident
+		status    types.StatusPageReleaseStatus
+		uptime    float64
+	}{
+		{
+			name:   "no incidents",
+			status: types.StatusPageReleaseOperational,
+			uptime: 100,
+		},
+		{
+			name: "resolved crash loop",
+			incidents: []*models.ClusterIncident{
+				{Reason: "crash_loop", StartedAt: now.Add(-10 * time.Hour), ResolvedAt: resolvedAt(9 * time.Hour)},
+			},
+			status: types.StatusPageReleaseOperational,
+			uptime: 99,
+		},
+		{
+			name: "overlapping outages are only counted once",
+			incidents: []*models.ClusterIncident{
+				{Reason: "crash_loop", StartedAt: now.Add(-10 * time.Hour), ResolvedAt: resolvedAt(6 * time.Hour)},
+				{Reason: "image_pull", StartedAt: now.Add(-8 * time.Hour), ResolvedAt: resolvedAt(5 * time.Hour)},
+			},
+			status: types.StatusPageReleaseOperational,
+			uptime: 95,
+		},
+		{
+			name: "outages before the window are clipped",
+			incidents: []*models.ClusterIncident{
+				{Reason: "crash_loop", StartedAt: now.Add(-200 * time.Hour), ResolvedAt: resolvedAt(98 * time.Hour)},
+			},
+			status: types.StatusPageReleaseOperational,
+			uptime: 98,
+		},
+		{
+			name: "open probe failures degrade the release without affecting uptime",
+			incidents: []*models.ClusterIncident{
+				{Reason: "probe_failed", StartedAt: now.Add(-10 * time.Hour)},
+			},
+			status: types.StatusPageReleaseDegraded,
+			uptime: 100,
+		},
+		{
+			name: "open crash loop",
+			incidents: []*models.ClusterIncident{
+				{Reason: "probe_failed", StartedAt: now.Add(-20 * time.Hour)},
+				{Reason: "crash_loop", StartedAt: now.Add(-3 * time.Hour)},
+			},
+			status: types.StatusPageReleaseDown,
+			uptime: 97,
+		},
+	}
+
+	for _, test := range tests {
+		status, uptime := statuspage.GetReleaseStatus(test.incidents, since, now)
+
+		if status != test.status {
+			t.Errorf("%s: expected status %s, got %s\n", test.name, test.status, status)
+		}
+
+		if uptime != test.uptime {
+			t.Errorf("%s: expected uptime %.2f, got %.2f\n", test.name, test.uptime, uptime)
+		}
+	}
+}
+
+func TestGetSubdomain(t *testing.T) {
+	tests := []struct {
+		host      string
+		subdomain string
+		ok        bool
+	}{
+		{"acme.status.porter.run", "acme", true},
+		{"ACME.status.porter.run:443", "acme", true},
+		{"status.porter.run", "", false},
+		{"a.b.status.porter.run", "", false},
+		{"acme.porter.run", "", false},
+		{"-acme.status.porter.run", "", false},
+	}
+
+	for _, test := range tests {
+		subdomain, ok := statuspage.GetSubdomain(test.host, "status.porter.run")
+
+		if subdomain != test.subdomain || ok != test.ok {
+			t.Errorf("%s: expected (%q, %t), got (%q, %t)\n", test.host, test.subdomain, test.ok, subdomain, ok)
+		}
+	}
+
+	if _, ok := statuspage.GetSubdomain("acme.status.porter.run", ""); ok {
+		t.Errorf("status pages should not be served without a status page domain\n")
+	}
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey is the key which the span of a query is stored under in the gorm statement
+const gormSpanKey = "porter:tracing_span"
+
+// GormPlugin creates a span for every query run through a gorm database. Queries are children of
+// the span in the statement's context, so they are only part of a request's trace when they
+// are run with the request's context.
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "porter:tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	errs := []error{
+		cb.Create().Before("gorm:create").Register("porter:tracing_before_create", startQuerySpan("gorm.create")),
+		cb.Create().After("gorm:create").Register("porter:tracing_after_create", endQuerySpan),
+		cb.Query().Before("gorm:query").Register("porter:tracing_before_query", startQuerySpan("gorm.query")),
+		cb.Query().After("gorm:query").Register("porter:tracing_after_query", endQuerySpan),
+		cb.Update().Before("gorm:update").Register("porter:tracing_before_update", startQuerySpan("gorm.update")),
+		cb.Update().After("gorm:update").Register("porter:tracing_after_update", endQuerySpan),
+		cb.Delete().Before("gorm:delete").Register("porter:tracing_before_delete", startQuerySpan("gorm.delete")),
+		cb.Delete().After("gorm:delete").Register("porter:tracing_after_delete", endQuerySpan),
+		cb.Row().Before("gorm:row").Register("porter:tracing_before_row", startQuerySpan("gorm.row")),
+		cb.Row().After("gorm:row").Register("porter:tracing_after_row", endQuerySpan),
+		cb.Raw().Before("gorm:raw").Register("porter:tracing_before_raw", startQuerySpan("gorm.raw")),
+		cb.Raw().After("gorm:raw").Register("porter:tracing_after_raw", endQuerySpan),
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startQuerySpan(name string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil {
+			return
+		}
+
+		ctx, span := StartSpan(db.Statement.Context, name, semconv.DBSystemKey.String(db.Dialector.Name()))
+
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endQuerySpan(db *gorm.DB) {
+	val, ok := db.InstanceGet(gormSpanKey)
+
+	if !ok {
+		return
+	}
+
+	span, ok := val.(trace.Span)
+
+	if !ok {
+		return
+	}
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBSQLTableKey.String(db.Statement.Table))
+	}
+
+	span.SetAttributes(
+		semconv.DBStatementKey.String(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	err := db.Error
+
+	// a missing record is an expected result rather than a failed query
+	if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	EndSpan(span, err)
+}
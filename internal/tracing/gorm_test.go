@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type tracedModel struct {
+	gorm.Model
+
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prevProvider := otel.GetTracerProvider()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prevProvider)
+
+	dbFileName := "./porter_tracing.db"
+	defer os.Remove(dbFileName)
+
+	db, err := gorm.Open(sqlite.Open(dbFileName), &gorm.Config{})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := db.AutoMigrate(&tracedModel{}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := db.Use(NewGormPlugin()); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	ctx, parent := StartSpan(context.Background(), "request")
+
+	if err := db.WithContext(ctx).Create(&tracedModel{Name: "web"}).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := db.WithContext(ctx).First(&tracedModel{}, "name = ?", "worker").Error; err != gorm.ErrRecordNotFound {
+		t.Fatalf("expected record not found, got %v\n", err)
+	}
+
+	parent.End()
+
+	spans := recorder.Ended()
+
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d\n", len(spans))
+	}
+
+	if spans[0].Name() != "gorm.create" || spans[1].Name() != "gorm.query" {
+		t.Errorf("incorrect span names: expected gorm.create and gorm.query, got %s and %s\n",
+			spans[0].Name(), spans[1].Name())
+	}
+
+	for _, span := range spans[0:2] {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %s should be a child of the request span\n", span.Name())
+		}
+
+		// a missing record is not a failed query
+		if span.Status().Code != 0 {
+			t.Errorf("span %s should not have an error status\n", span.Name())
+		}
+	}
+}
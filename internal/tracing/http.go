@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// NewTransport wraps an HTTP transport so that a span is created for every outgoing request,
+// and the trace context is propagated to the server
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
+
+// NewHandler wraps an HTTP handler so that a span is created for every incoming request. The
+// trace context of the caller is extracted from the request headers.
+func NewHandler(handler http.Handler, operation string) http.Handler {
+	return otelhttp.NewHandler(handler, operation)
+}
@@ -0,0 +1,129 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	lr "github.com/porter-dev/porter/pkg/logger"
+)
+
+// ConnectPath is the path of the API endpoint which tunnel agents connect to
+const ConnectPath = "/api/tunnels/connect"
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// AgentOpts configures the tunnel agent of a cluster
+type AgentOpts struct {
+	// the url of the Porter server, such as https://dashboard.getporter.dev
+	ServerURL string
+
+	// the tunnel token of the cluster
+	Token string
+
+	// the addresses which the server can open streams to, such as the address of the
+	// Kubernetes API server of the cluster. Streams to other addresses are rejected, so the
+	// agent can't be used to reach arbitrary services in the cluster.
+	AllowedTargets []string
+
+	Logger *lr.Logger
+}
+
+// RunAgent connects the tunnel agent of a cluster to the Porter server, and reconnects when
+// the connection is lost. It returns when the context is cancelled.
+func RunAgent(ctx context.Context, opts *AgentOpts) error {
+	wsURL, err := getConnectURL(opts.ServerURL)
+
+	if err != nil {
+		return err
+	}
+
+	allowed := make(map[string]bool)
+
+	for _, target := range opts.AllowedTargets {
+		allowed[target] = true
+	}
+
+	dialer := &net.Dialer{}
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if !allowed[addr] {
+			return nil, fmt.Errorf("the tunnel agent does not allow connections to %s", addr)
+		}
+
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	delay := minReconnectDelay
+
+	for {
+		connectedAt := time.Now()
+
+		err := runSession(ctx, wsURL, opts.Token, dial)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		// sessions which were connected for a while reconnect immediately
+		if time.Since(connectedAt) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+
+		opts.Logger.Error().Err(err).Msgf("tunnel disconnected, reconnecting in %s", delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+func runSession(ctx context.Context, wsURL, token string, dial DialFunc) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, wsURL, header)
+
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("error connecting to %s: %s", wsURL, resp.Status)
+		}
+
+		return fmt.Errorf("error connecting to %s: %w", wsURL, err)
+	}
+
+	session := NewSession(conn, dial)
+
+	select {
+	case <-session.Done():
+		return session.Err()
+	case <-ctx.Done():
+		return session.Close()
+	}
+}
+
+func getConnectURL(serverURL string) (string, error) {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+
+	switch {
+	case strings.HasPrefix(serverURL, "https://"):
+		return "wss://" + strings.TrimPrefix(serverURL, "https://") + ConnectPath, nil
+	case strings.HasPrefix(serverURL, "http://"):
+		return "ws://" + strings.TrimPrefix(serverURL, "http://") + ConnectPath, nil
+	}
+
+	return "", fmt.Errorf("invalid server url %s: must start with http:// or https://", serverURL)
+}
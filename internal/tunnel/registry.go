@@ -0,0 +1,240 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/internal/encryption"
+)
+
+// ErrNotConnected is returned when a cluster's tunnel agent isn't connected to this server.
+// When Porter runs several replicas, the agent is connected to only one of them, so requests
+// which fail with ErrNotConnected should be retried after RetryAfter: they may be load
+// balanced to the replica which the agent is connected to, or the agent may have reconnected.
+var ErrNotConnected = errors.New("the tunnel agent of the cluster is not connected to this server, retry the request")
+
+// RetryAfter is the time after which requests which failed with ErrNotConnected should be
+// retried. Agents reconnect with a backoff which starts at a second.
+const RetryAfter = 5 * time.Second
+
+// DefaultRegistry is the registry of the tunnel sessions which are connected to this server
+var DefaultRegistry = NewRegistry()
+
+// Registry tracks the tunnel sessions of clusters, and routes connections to a cluster through
+// its session. Sessions are held in memory, so a cluster's traffic can only be routed by the
+// server replica which its agent is connected to. Connections are not forwarded between
+// replicas: other replicas fail with ErrNotConnected, which the API reports as a retryable
+// 503 error. Deployments which run several replicas should route the requests of tunneled
+// clusters to a single replica, for example with sticky sessions, to avoid retries.
+type Registry struct {
+	mu       sync.Mutex
+	sessions map[uint]*Session
+
+	proxyOnce   sync.Once
+	proxyAddr   string
+	proxySecret string
+	proxyErr    error
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[uint]*Session),
+	}
+}
+
+// Register registers the session of a cluster, and closes the previous session of the cluster
+func (r *Registry) Register(clusterID uint, session *Session) {
+	r.mu.Lock()
+	prev := r.sessions[clusterID]
+	r.sessions[clusterID] = session
+	r.mu.Unlock()
+
+	if prev != nil && prev != session {
+		prev.Close()
+	}
+}
+
+// Unregister removes the session of a cluster, if it's still the registered session
+func (r *Registry) Unregister(clusterID uint, session *Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sessions[clusterID] == session {
+		delete(r.sessions, clusterID)
+	}
+}
+
+// Disconnect closes the session of a cluster
+func (r *Registry) Disconnect(clusterID uint) {
+	r.mu.Lock()
+	session := r.sessions[clusterID]
+	delete(r.sessions, clusterID)
+	r.mu.Unlock()
+
+	if session != nil {
+		session.Close()
+	}
+}
+
+// IsConnected returns true if the tunnel agent of a cluster is connected to this server
+func (r *Registry) IsConnected(clusterID uint) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.sessions[clusterID]
+
+	return ok
+}
+
+// DialContext opens a connection to an address in a cluster through its tunnel
+func (r *Registry) DialContext(ctx context.Context, clusterID uint, addr string) (net.Conn, error) {
+	r.mu.Lock()
+	session := r.sessions[clusterID]
+	r.mu.Unlock()
+
+	if session == nil {
+		return nil, fmt.Errorf("cluster %d: %w", clusterID, ErrNotConnected)
+	}
+
+	return session.Open(ctx, addr)
+}
+
+// Proxy returns a proxy function for the http transports of a cluster, which routes their
+// connections through the cluster's tunnel. The connections are sent to a CONNECT proxy which
+// listens on the loopback interface, so that every client of the cluster is routed through
+// the tunnel, including the SPDY clients of exec and port forwarding which don't support
+// custom dialers.
+func (r *Registry) Proxy(clusterID uint) func(*http.Request) (*url.URL, error) {
+	return func(*http.Request) (*url.URL, error) {
+		if err := r.startProxy(); err != nil {
+			return nil, err
+		}
+
+		return &url.URL{
+			Scheme: "http",
+			User:   url.UserPassword(strconv.FormatUint(uint64(clusterID), 10), r.proxySecret),
+			Host:   r.proxyAddr,
+		}, nil
+	}
+}
+
+func (r *Registry) startProxy() error {
+	r.proxyOnce.Do(func() {
+		secret, err := encryption.GenerateRandomBytes(32)
+
+		if err != nil {
+			r.proxyErr = err
+			return
+		}
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+		if err != nil {
+			r.proxyErr = fmt.Errorf("error starting tunnel proxy: %w", err)
+			return
+		}
+
+		r.proxySecret = secret
+		r.proxyAddr = listener.Addr().String()
+
+		server := &http.Server{
+			Handler:           http.HandlerFunc(r.serveProxy),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go server.Serve(listener)
+	})
+
+	return r.proxyErr
+}
+
+// serveProxy handles a CONNECT request by opening a stream to its host through the tunnel of
+// the cluster in its credentials
+func (r *Registry) serveProxy(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodConnect {
+		http.Error(w, "the tunnel proxy only supports https cluster endpoints", http.StatusMethodNotAllowed)
+		return
+	}
+
+	clusterID, ok := r.authenticateProxy(req)
+
+	if !ok {
+		w.Header().Set("Proxy-Authenticate", `Basic realm="porter-tunnel"`)
+		http.Error(w, "invalid proxy credentials", http.StatusProxyAuthRequired)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	stream, err := r.DialContext(ctx, clusterID, req.Host)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+
+	if !ok {
+		stream.Close()
+		http.Error(w, "the tunnel proxy does not support hijacking", http.StatusInternalServerError)
+		return
+	}
+
+	conn, bufrw, err := hijacker.Hijack()
+
+	if err != nil {
+		stream.Close()
+		return
+	}
+
+	if _, err := conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		stream.Close()
+		conn.Close()
+		return
+	}
+
+	go func() {
+		// the client may have written data after the CONNECT request, which was buffered
+		if n := bufrw.Reader.Buffered(); n > 0 {
+			buffered, _ := bufrw.Reader.Peek(n)
+			stream.Write(buffered)
+		}
+
+		io.Copy(stream, conn)
+		stream.Close()
+	}()
+
+	io.Copy(conn, stream)
+	conn.Close()
+}
+
+func (r *Registry) authenticateProxy(req *http.Request) (uint, bool) {
+	// the proxy credentials are sent in the Proxy-Authorization header, which is parsed like
+	// an Authorization header
+	authReq := &http.Request{Header: http.Header{"Authorization": req.Header["Proxy-Authorization"]}}
+
+	user, pass, ok := authReq.BasicAuth()
+
+	if !ok || subtle.ConstantTimeCompare([]byte(pass), []byte(r.proxySecret)) != 1 {
+		return 0, false
+	}
+
+	clusterID, err := strconv.ParseUint(user, 10, 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return uint(clusterID), true
+}
@@ -0,0 +1,616 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// the types of the frames which are sent over a session. Each frame is a binary websocket
+// message which starts with its type and the id of its stream.
+const (
+	// frameOpen asks the peer to dial the address in the payload
+	frameOpen byte = iota + 1
+
+	// frameOpenResult answers a frameOpen, with an error message in the payload if the dial
+	// failed
+	frameOpenResult
+
+	// frameData carries bytes written to a stream
+	frameData
+
+	// frameWindow grants the peer more bytes of the send window of a stream
+	frameWindow
+
+	// frameClose closes a stream: the peer doesn't send or read more data
+	frameClose
+)
+
+const (
+	frameHeaderSize = 5
+
+	// the largest payload of a data frame
+	maxDataSize = 32 * 1024
+
+	// the number of bytes which can be sent on a stream before the peer reads them. This
+	// bounds the memory which a slow reader of a stream uses, without blocking the other
+	// streams of the session.
+	windowSize = 256 * 1024
+
+	pingPeriod = 20 * time.Second
+	pongWait   = 3 * pingPeriod
+	writeWait  = 10 * time.Second
+)
+
+var (
+	// ErrSessionClosed is returned by the streams of a session once the websocket connection
+	// of the session is closed
+	ErrSessionClosed = errors.New("tunnel session closed")
+
+	errStreamClosed = errors.New("tunnel stream closed")
+)
+
+// DialFunc dials an address which a peer opened a stream to
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Session multiplexes streams over the websocket connection between the Porter server and
+// the tunnel agent of a cluster. The server opens streams, which the agent accepts by
+// dialing their address in the cluster.
+type Session struct {
+	conn *websocket.Conn
+
+	// dial accepts the streams opened by the peer. Sessions without dial reject them.
+	dial DialFunc
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	streams map[uint32]*stream
+	nextID  uint32
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+// NewSession starts a session over a websocket connection. If dial is set, the streams
+// opened by the peer are accepted with it.
+func NewSession(conn *websocket.Conn, dial DialFunc) *Session {
+	s := &Session{
+		conn:    conn,
+		dial:    dial,
+		streams: make(map[uint32]*stream),
+		done:    make(chan struct{}),
+	}
+
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	go s.readLoop()
+	go s.pingLoop()
+
+	return s
+}
+
+// Done is closed when the session is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error which closed the session
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Close closes the session and all of its streams
+func (s *Session) Close() error {
+	s.closeWithError(ErrSessionClosed)
+	return nil
+}
+
+// Open opens a stream to an address, which the peer dials
+func (s *Session) Open(ctx context.Context, addr string) (net.Conn, error) {
+	s.mu.Lock()
+
+	if s.err != nil {
+		s.mu.Unlock()
+		return nil, s.err
+	}
+
+	s.nextID++
+	st := newStream(s, s.nextID)
+	s.streams[st.id] = st
+
+	s.mu.Unlock()
+
+	if err := s.writeFrame(frameOpen, st.id, []byte(addr)); err != nil {
+		s.removeStream(st.id)
+		return nil, err
+	}
+
+	select {
+	case err := <-st.opened:
+		if err != nil {
+			s.removeStream(st.id)
+			return nil, err
+		}
+
+		return st, nil
+	case <-ctx.Done():
+		st.Close()
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, s.Err()
+	}
+}
+
+func (s *Session) readLoop() {
+	for {
+		msgType, msg, err := s.conn.ReadMessage()
+
+		if err != nil {
+			s.closeWithError(fmt.Errorf("%w: %s", ErrSessionClosed, err.Error()))
+			return
+		}
+
+		s.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		if msgType != websocket.BinaryMessage || len(msg) < frameHeaderSize {
+			continue
+		}
+
+		if err := s.handleFrame(msg[0], binary.BigEndian.Uint32(msg[1:frameHeaderSize]), msg[frameHeaderSize:]); err != nil {
+			s.closeWithError(fmt.Errorf("%w: %s", ErrSessionClosed, err.Error()))
+			return
+		}
+	}
+}
+
+func (s *Session) handleFrame(frameType byte, id uint32, payload []byte) error {
+	if frameType == frameOpen {
+		if s.dial == nil {
+			return s.writeFrame(frameOpenResult, id, []byte("the peer does not accept streams"))
+		}
+
+		go s.accept(id, string(payload))
+
+		return nil
+	}
+
+	s.mu.Lock()
+	st, ok := s.streams[id]
+	s.mu.Unlock()
+
+	// frames can arrive for a stream after it's closed locally
+	if !ok {
+		return nil
+	}
+
+	switch frameType {
+	case frameOpenResult:
+		var err error
+
+		if len(payload) > 0 {
+			err = fmt.Errorf("error opening tunnel stream: %s", string(payload))
+		}
+
+		select {
+		case st.opened <- err:
+		default:
+		}
+	case frameData:
+		return st.receive(payload)
+	case frameWindow:
+		if len(payload) != 4 {
+			return fmt.Errorf("invalid window frame")
+		}
+
+		st.grant(int(binary.BigEndian.Uint32(payload)))
+	case frameClose:
+		st.remoteClose()
+	}
+
+	return nil
+}
+
+// accept dials the address of a stream which the peer opened, and copies data between the
+// stream and the dialed connection
+func (s *Session) accept(id uint32, addr string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	conn, err := s.dial(ctx, "tcp", addr)
+	cancel()
+
+	if err != nil {
+		s.writeFrame(frameOpenResult, id, []byte(err.Error()))
+		return
+	}
+
+	st := newStream(s, id)
+
+	s.mu.Lock()
+	s.streams[id] = st
+	s.mu.Unlock()
+
+	if err := s.writeFrame(frameOpenResult, id, nil); err != nil {
+		conn.Close()
+		s.removeStream(id)
+		return
+	}
+
+	go func() {
+		io.Copy(conn, st)
+
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			tcpConn.CloseWrite()
+		} else {
+			conn.Close()
+		}
+	}()
+
+	io.Copy(st, conn)
+	st.Close()
+	conn.Close()
+}
+
+func (s *Session) pingLoop() {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				s.closeWithError(fmt.Errorf("%w: %s", ErrSessionClosed, err.Error()))
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Session) writeFrame(frameType byte, id uint32, payload []byte) error {
+	msg := make([]byte, frameHeaderSize+len(payload))
+	msg[0] = frameType
+	binary.BigEndian.PutUint32(msg[1:frameHeaderSize], id)
+	copy(msg[frameHeaderSize:], payload)
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	select {
+	case <-s.done:
+		return s.Err()
+	default:
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+		go s.closeWithError(fmt.Errorf("%w: %s", ErrSessionClosed, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (s *Session) removeStream(id uint32) {
+	s.mu.Lock()
+	delete(s.streams, id)
+	s.mu.Unlock()
+}
+
+func (s *Session) closeWithError(err error) {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		s.err = err
+		streams := s.streams
+		s.streams = make(map[uint32]*stream)
+		s.mu.Unlock()
+
+		close(s.done)
+
+		s.conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			time.Now().Add(time.Second),
+		)
+
+		s.conn.Close()
+
+		for _, st := range streams {
+			st.notify()
+		}
+	})
+}
+
+// stream is a net.Conn which is multiplexed over a session
+type stream struct {
+	id      uint32
+	session *Session
+
+	// receives the result of opening the stream, for streams opened by this side
+	opened chan error
+
+	mu sync.Mutex
+
+	// data which was received and not yet read
+	buf bytes.Buffer
+
+	// the number of bytes which were read since the last window update was sent
+	consumed int
+
+	// the number of bytes which can be sent before the peer grants more
+	sendWindow int
+
+	closed       bool
+	remoteClosed bool
+
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	// signalled whenever the state of the stream changes, for blocked reads and writes
+	readReady  chan struct{}
+	writeReady chan struct{}
+}
+
+func newStream(session *Session, id uint32) *stream {
+	return &stream{
+		id:         id,
+		session:    session,
+		opened:     make(chan error, 1),
+		sendWindow: windowSize,
+		readReady:  make(chan struct{}, 1),
+		writeReady: make(chan struct{}, 1),
+	}
+}
+
+func (st *stream) Read(b []byte) (int, error) {
+	for {
+		st.mu.Lock()
+
+		if st.buf.Len() > 0 {
+			n, _ := st.buf.Read(b)
+			st.consumed += n
+
+			var grant int
+
+			if st.consumed >= windowSize/2 && !st.remoteClosed {
+				grant = st.consumed
+				st.consumed = 0
+			}
+
+			st.mu.Unlock()
+
+			if grant > 0 {
+				payload := make([]byte, 4)
+				binary.BigEndian.PutUint32(payload, uint32(grant))
+
+				st.session.writeFrame(frameWindow, st.id, payload)
+			}
+
+			return n, nil
+		}
+
+		if st.remoteClosed {
+			st.mu.Unlock()
+			return 0, io.EOF
+		}
+
+		if st.closed {
+			st.mu.Unlock()
+			return 0, errStreamClosed
+		}
+
+		deadline := st.readDeadline
+		st.mu.Unlock()
+
+		if err := st.wait(st.readReady, deadline); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (st *stream) Write(b []byte) (int, error) {
+	written := 0
+
+	for len(b) > 0 {
+		st.mu.Lock()
+
+		if st.closed || st.remoteClosed {
+			st.mu.Unlock()
+			return written, errStreamClosed
+		}
+
+		if st.sendWindow == 0 {
+			deadline := st.writeDeadline
+			st.mu.Unlock()
+
+			if err := st.wait(st.writeReady, deadline); err != nil {
+				return written, err
+			}
+
+			continue
+		}
+
+		n := len(b)
+
+		if n > st.sendWindow {
+			n = st.sendWindow
+		}
+
+		if n > maxDataSize {
+			n = maxDataSize
+		}
+
+		st.sendWindow -= n
+		st.mu.Unlock()
+
+		if err := st.session.writeFrame(frameData, st.id, b[:n]); err != nil {
+			return written, err
+		}
+
+		written += n
+		b = b[n:]
+	}
+
+	return written, nil
+}
+
+func (st *stream) Close() error {
+	st.mu.Lock()
+
+	if st.closed {
+		st.mu.Unlock()
+		return nil
+	}
+
+	st.closed = true
+	remoteClosed := st.remoteClosed
+	st.mu.Unlock()
+
+	st.notify()
+
+	if remoteClosed {
+		st.session.removeStream(st.id)
+		return nil
+	}
+
+	return st.session.writeFrame(frameClose, st.id, nil)
+}
+
+// wait blocks until the ready channel is signalled, the deadline passes or the session is
+// closed
+func (st *stream) wait(ready chan struct{}, deadline time.Time) error {
+	var timeout <-chan time.Time
+
+	if !deadline.IsZero() {
+		d := time.Until(deadline)
+
+		if d <= 0 {
+			return os.ErrDeadlineExceeded
+		}
+
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case <-ready:
+		return nil
+	case <-timeout:
+		return os.ErrDeadlineExceeded
+	case <-st.session.done:
+		return st.session.Err()
+	}
+}
+
+func (st *stream) notify() {
+	signal(st.readReady)
+	signal(st.writeReady)
+}
+
+func signal(ready chan struct{}) {
+	select {
+	case ready <- struct{}{}:
+	default:
+	}
+}
+
+func (st *stream) receive(data []byte) error {
+	st.mu.Lock()
+
+	if st.closed {
+		st.mu.Unlock()
+		return nil
+	}
+
+	if st.buf.Len()+len(data) > windowSize {
+		st.mu.Unlock()
+		return fmt.Errorf("stream %d exceeded its window", st.id)
+	}
+
+	st.buf.Write(data)
+	st.mu.Unlock()
+
+	signal(st.readReady)
+
+	return nil
+}
+
+func (st *stream) grant(n int) {
+	st.mu.Lock()
+	st.sendWindow += n
+	st.mu.Unlock()
+
+	signal(st.writeReady)
+}
+
+func (st *stream) remoteClose() {
+	st.mu.Lock()
+	st.remoteClosed = true
+	closed := st.closed
+	st.mu.Unlock()
+
+	st.notify()
+
+	if closed {
+		st.session.removeStream(st.id)
+	}
+}
+
+func (st *stream) LocalAddr() net.Addr {
+	return st.session.conn.LocalAddr()
+}
+
+func (st *stream) RemoteAddr() net.Addr {
+	return st.session.conn.RemoteAddr()
+}
+
+func (st *stream) SetDeadline(t time.Time) error {
+	st.mu.Lock()
+	st.readDeadline = t
+	st.writeDeadline = t
+	st.mu.Unlock()
+
+	st.notify()
+
+	return nil
+}
+
+func (st *stream) SetReadDeadline(t time.Time) error {
+	st.mu.Lock()
+	st.readDeadline = t
+	st.mu.Unlock()
+
+	st.notify()
+
+	return nil
+}
+
+func (st *stream) SetWriteDeadline(t time.Time) error {
+	st.mu.Lock()
+	st.writeDeadline = t
+	st.mu.Unlock()
+
+	st.notify()
+
+	return nil
+}
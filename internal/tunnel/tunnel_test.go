@@ -0,0 +1,191 @@
+package tunnel_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/internal/tunnel"
+	lr "github.com/porter-dev/porter/pkg/logger"
+)
+
+// connectAgent starts a server which registers the sessions of agents as cluster 1, and
+// connects an agent to it which allows connections to the targets
+func connectAgent(t *testing.T, registry *tunnel.Registry, targets ...string) {
+	upgrader := &websocket.Upgrader{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != tunnel.ConnectPath || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+
+		if err != nil {
+			return
+		}
+
+		session := tunnel.NewSession(conn, nil)
+		registry.Register(1, session)
+
+		<-session.Done()
+
+		registry.Unregister(1, session)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+
+	go tunnel.RunAgent(ctx, &tunnel.AgentOpts{
+		ServerURL:      server.URL,
+		Token:          "token",
+		AllowedTargets: targets,
+		Logger:         lr.NewConsole(false),
+	})
+
+	for i := 0; i < 100 && !registry.IsConnected(1); i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if !registry.IsConnected(1) {
+		t.Fatalf("expected agent to connect\n")
+	}
+}
+
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestDialThroughTunnel(t *testing.T) {
+	registry := tunnel.NewRegistry()
+	echoAddr := startEchoServer(t)
+
+	connectAgent(t, registry, echoAddr)
+
+	conn, err := registry.DialContext(context.Background(), 1, echoAddr)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	defer conn.Close()
+
+	// more data than the window of a stream, so that the writer waits for the reader
+	data := make([]byte, 2*1024*1024)
+	rand.Read(data)
+
+	go func() {
+		conn.Write(data)
+	}()
+
+	received := make([]byte, len(data))
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	if _, err := io.ReadFull(conn, received); err != nil {
+		t.Fatalf("unexpected error reading echoed data: %v\n", err)
+	}
+
+	if !bytes.Equal(data, received) {
+		t.Errorf("echoed data does not match written data\n")
+	}
+
+	// the agent rejects addresses which aren't allowed
+	_, err = registry.DialContext(context.Background(), 1, "127.0.0.1:1")
+
+	if err == nil || !strings.Contains(err.Error(), "does not allow") {
+		t.Errorf("expected disallowed target error, got %v\n", err)
+	}
+
+	_, err = registry.DialContext(context.Background(), 2, echoAddr)
+
+	if !errors.Is(err, tunnel.ErrNotConnected) {
+		t.Errorf("expected not connected error, got %v\n", err)
+	}
+}
+
+func TestProxyThroughTunnel(t *testing.T) {
+	registry := tunnel.NewRegistry()
+
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	defer apiServer.Close()
+
+	apiAddr := strings.TrimPrefix(apiServer.URL, "https://")
+
+	connectAgent(t, registry, apiAddr)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           registry.Proxy(1),
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		Timeout: 10 * time.Second,
+	}
+
+	resp, err := client.Get(apiServer.URL + "/version")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+		t.Errorf("expected ok response, got %d %s\n", resp.StatusCode, string(body))
+	}
+
+	// the proxy rejects clusters which aren't connected
+	client.Transport.(*http.Transport).Proxy = registry.Proxy(2)
+
+	if _, err := client.Get(apiServer.URL + "/version"); err == nil {
+		t.Errorf("expected error for cluster which is not connected\n")
+	}
+
+	registry.Disconnect(1)
+
+	if registry.IsConnected(1) {
+		t.Errorf("expected cluster to be disconnected\n")
+	}
+}
@@ -1,15 +1,29 @@
+//go:build !ee
 // +build !ee
 
 package usage
 
 import (
+	"errors"
+
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
+// GetLimit returns the limits which the instance admin configured for a project, or the basic
+// plan if the project has no configured limits
 func GetLimit(repo repository.Repository, proj *models.Project) (limit *types.ProjectUsage, err error) {
-	copyLimit := types.BasicPlan
+	limitModel, err := repo.ProjectUsage().ReadProjectUsage(proj.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		copyLimit := types.BasicPlan
+
+		return &copyLimit, nil
+	} else if err != nil {
+		return nil, err
+	}
 
-	return &copyLimit, nil
+	return limitModel.ToProjectUsageType(), nil
 }
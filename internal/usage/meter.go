@@ -0,0 +1,127 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// Meter records the usage of every project at the end of each hour
+type Meter struct {
+	repo   repository.Repository
+	logger *logger.Logger
+}
+
+func NewMeter(repo repository.Repository, logger *logger.Logger) *Meter {
+	return &Meter{repo, logger}
+}
+
+// Start meters each hour as it ends, until the context is cancelled. The last hour is metered on
+// start, in case it ended while the server was down.
+func (m *Meter) Start(ctx context.Context) {
+	go func() {
+		m.meterLastHour(time.Now())
+
+		for {
+			next := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+
+			m.meterLastHour(next)
+		}
+	}()
+}
+
+func (m *Meter) meterLastHour(now time.Time) {
+	hour := now.UTC().Truncate(time.Hour).Add(-time.Hour)
+
+	if err := MeterHour(m.repo, hour); err != nil {
+		m.logger.Error().Err(err).Msgf("error metering usage for %s", hour.Format(time.RFC3339))
+	}
+}
+
+// MeterHour records the usage of every project for the hour which starts at hour. Projects
+// which were already metered for the hour are left unchanged, so that the hour is only metered
+// once by servers which run concurrently.
+func MeterHour(repo repository.Repository, hour time.Time) error {
+	projIDs, err := repo.Project().ListProjectIDs()
+
+	if err != nil {
+		return fmt.Errorf("error listing projects: %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for _, projID := range projIDs {
+		record, err := repo.ProjectUsage().MeterProjectUsage(projID, hour, hour.Add(time.Hour))
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("project %d: %w", projID, err))
+			continue
+		}
+
+		record.Hour = hour
+
+		if _, err := repo.ProjectUsage().CreateProjectUsageRecord(record); err != nil {
+			errs = append(errs, fmt.Errorf("project %d: %w", projID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error metering %d of %d projects: %v", len(errs), len(projIDs), errs)
+	}
+
+	return nil
+}
+
+// GetUsageHistory returns the metered hours of a project between from and to, along with the
+// totals of the usage which accumulates over the hours
+func GetUsageHistory(
+	repo repository.Repository,
+	projID uint,
+	from, to time.Time,
+) (*types.GetProjectUsageHistoryResponse, error) {
+	records, err := repo.ProjectUsage().ListProjectUsageRecords(projID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.GetProjectUsageHistoryResponse{
+		Records: make([]*types.ProjectUsageRecord, 0, len(records)),
+	}
+
+	var buildSeconds uint
+
+	for _, record := range records {
+		res.Records = append(res.Records, record.ToProjectUsageRecordType())
+
+		buildSeconds += record.BuildSeconds
+		res.PreviewEnvironmentHours += record.PreviewEnvironmentHours
+	}
+
+	res.BuildMinutes = toBuildMinutes(buildSeconds)
+
+	return res, nil
+}
+
+// GetBillingPeriod returns the start of the current billing period, which is the start of the
+// current month in UTC
+func GetBillingPeriod(now time.Time) time.Time {
+	now = now.UTC()
+
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// toBuildMinutes converts the seconds of builds to minutes, rounding up
+func toBuildMinutes(seconds uint) uint {
+	return (seconds + 59) / 60
+}
@@ -0,0 +1,81 @@
+package usage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"github.com/porter-dev/porter/internal/usage"
+)
+
+func TestMeterHour(t *testing.T) {
+	repo := test.NewRepository(true)
+
+	for _, name := range []string{"project-1", "project-2"} {
+		if _, err := repo.Project().CreateProject(&models.Project{Name: name}); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	// metering an hour again, as a server which runs concurrently would, doesn't add records
+	for i := 0; i < 2; i++ {
+		if err := usage.MeterHour(repo, hour); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	for _, projID := range []uint{1, 2} {
+		records, err := repo.ProjectUsage().ListProjectUsageRecords(projID, hour, hour.Add(time.Hour))
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if len(records) != 1 || !records[0].Hour.Equal(hour) {
+			t.Errorf("expected a single record for project %d at %s, got %v\n", projID, hour, records)
+		}
+	}
+}
+
+func TestGetUsageHistory(t *testing.T) {
+	repo := test.NewRepository(true)
+
+	start := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	records := []*models.ProjectUsageRecord{
+		{ProjectID: 1, Hour: start, BuildSeconds: 90, PreviewEnvironmentHours: 2},
+		{ProjectID: 1, Hour: start.Add(time.Hour), BuildSeconds: 60, PreviewEnvironmentHours: 1},
+		{ProjectID: 1, Hour: start.AddDate(0, 1, 0), BuildSeconds: 600, PreviewEnvironmentHours: 3},
+		{ProjectID: 2, Hour: start, BuildSeconds: 600, PreviewEnvironmentHours: 3},
+	}
+
+	for _, record := range records {
+		if _, err := repo.ProjectUsage().CreateProjectUsageRecord(record); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	res, err := usage.GetUsageHistory(repo, 1, start, start.AddDate(0, 1, 0))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// 150 seconds of builds are rounded up to 3 minutes
+	if len(res.Records) != 2 || res.BuildMinutes != 3 || res.PreviewEnvironmentHours != 3 {
+		t.Errorf("expected 2 records, 3 build minutes and 3 preview environment hours, got %d records, "+
+			"%d build minutes and %d preview environment hours\n",
+			len(res.Records), res.BuildMinutes, res.PreviewEnvironmentHours)
+	}
+}
+
+func TestGetBillingPeriod(t *testing.T) {
+	now := time.Date(2022, 6, 15, 13, 30, 0, 0, time.FixedZone("PDT", -7*60*60))
+
+	if period := usage.GetBillingPeriod(now); !period.Equal(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the billing period to start on 2022-06-01 UTC, got %s\n", period)
+	}
+}
@@ -72,6 +72,28 @@ func GetUsage(opts *GetUsageOpts) (
 	usageCache.Clusters = uint(len(clusters))
 	usageCache.Users = uint(len(countedRoles))
 
+	// the build minutes and preview environment hours accumulate over the current billing
+	// period. Builds are counted as they finish, while preview environments are counted from
+	// the metered hours.
+	now := time.Now()
+	periodStart := GetBillingPeriod(now)
+
+	metered, err := opts.Repo.ProjectUsage().MeterProjectUsage(opts.Project.ID, periodStart, now)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	history, err := GetUsageHistory(opts.Repo, opts.Project.ID, periodStart, now)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	usageCache.Apps = metered.Apps
+	usageCache.BuildMinutes = toBuildMinutes(metered.BuildSeconds)
+	usageCache.PreviewEnvironmentHours = history.PreviewEnvironmentHours
+
 	// if the usage cache is 1 hour old, was not found, usage is currently over limit, or the clusters/users
 	// counts have changed, re-query for the usage
 	if !isCacheFound || usageCache.Is1HrOld() || isUsageExceeded(usageCache, limit) || isUsageChanged(&oldUsageCache, usageCache) {
@@ -106,10 +128,13 @@ func GetUsage(opts *GetUsageOpts) (
 	}
 
 	return &types.ProjectUsage{
-		ResourceCPU:    usageCache.ResourceCPU,
-		ResourceMemory: usageCache.ResourceMemory,
-		Clusters:       usageCache.Clusters,
-		Users:          usageCache.Users,
+		ResourceCPU:             usageCache.ResourceCPU,
+		ResourceMemory:          usageCache.ResourceMemory,
+		Clusters:                usageCache.Clusters,
+		Users:                   usageCache.Users,
+		Apps:                    usageCache.Apps,
+		BuildMinutes:            usageCache.BuildMinutes,
+		PreviewEnvironmentHours: usageCache.PreviewEnvironmentHours,
 	}, limit, usageCache, nil
 }
 
@@ -118,8 +143,13 @@ func isUsageExceeded(usageCache *models.ProjectUsageCache, limit *types.ProjectU
 	isMemExceeded := limit.ResourceMemory != 0 && usageCache.ResourceMemory > limit.ResourceMemory
 	isUsersExceeded := limit.Users != 0 && usageCache.Users > limit.Users
 	isClustersExceeded := limit.Clusters != 0 && usageCache.Clusters > limit.Clusters
+	isAppsExceeded := limit.Apps != 0 && usageCache.Apps > limit.Apps
+	isBuildMinutesExceeded := limit.BuildMinutes != 0 && usageCache.BuildMinutes > limit.BuildMinutes
+	isPreviewHoursExceeded := limit.PreviewEnvironmentHours != 0 &&
+		usageCache.PreviewEnvironmentHours > limit.PreviewEnvironmentHours
 
-	return isCPUExceeded || isMemExceeded || isUsersExceeded || isClustersExceeded
+	return isCPUExceeded || isMemExceeded || isUsersExceeded || isClustersExceeded ||
+		isAppsExceeded || isBuildMinutesExceeded || isPreviewHoursExceeded
 }
 
 func isUsageChanged(oldUsageCache, currUsageCache *models.ProjectUsageCache) bool {
@@ -127,7 +157,10 @@ func isUsageChanged(oldUsageCache, currUsageCache *models.ProjectUsageCache) boo
 		oldUsageCache.Clusters != currUsageCache.Clusters ||
 		oldUsageCache.Users != currUsageCache.Users ||
 		oldUsageCache.ResourceCPU != currUsageCache.ResourceCPU ||
-		oldUsageCache.ResourceMemory != currUsageCache.ResourceMemory
+		oldUsageCache.ResourceMemory != currUsageCache.ResourceMemory ||
+		oldUsageCache.Apps != currUsageCache.Apps ||
+		oldUsageCache.BuildMinutes != currUsageCache.BuildMinutes ||
+		oldUsageCache.PreviewEnvironmentHours != currUsageCache.PreviewEnvironmentHours
 }
 
 // gets the total resource usage across all nodes in all clusters
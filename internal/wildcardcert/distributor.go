@@ -0,0 +1,150 @@
+package wildcardcert
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/certificates"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+	"golang.org/x/oauth2"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// distributeInterval is how often the certificates are copied to their namespaces, which is how
+// long a renewed certificate or a new namespace can go without a copy
+const distributeInterval = 5 * time.Minute
+
+// Distributor copies the wildcard certificates of every cluster to their namespaces
+type Distributor struct {
+	repo   repository.Repository
+	doConf *oauth2.Config
+	logger *logger.Logger
+
+	// owns returns true for the clusters which this distributor copies the certificates of
+	owns func(clusterID uint) bool
+}
+
+func NewDistributor(repo repository.Repository, doConf *oauth2.Config, logger *logger.Logger) *Distributor {
+	return &Distributor{repo: repo, doConf: doConf, logger: logger}
+}
+
+// PartitionBy limits the distributor to the clusters which owns returns true for, so that the
+// clusters can be split across server replicas
+func (d *Distributor) PartitionBy(owns func(clusterID uint) bool) *Distributor {
+	d.owns = owns
+
+	return d
+}
+
+// Start copies the certificates every five minutes, until the context is cancelled
+func (d *Distributor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(distributeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := d.DistributeAll(); err != nil {
+					d.logger.Error().Err(err).Msg("error distributing wildcard certificates")
+				}
+			}
+		}
+	}()
+}
+
+// DistributeAll copies the wildcard certificates of every cluster which the distributor owns to
+// their namespaces
+func (d *Distributor) DistributeAll() error {
+	certs, err := d.repo.WildcardCertificate().ListWildcardCertificates()
+
+	if err != nil {
+		return fmt.Errorf("error listing wildcard certificates: %w", err)
+	}
+
+	byCluster := make(map[uint][]*models.WildcardCertificate)
+	clusterIDs := make([]uint, 0)
+
+	for _, cert := range certs {
+		if d.owns != nil && !d.owns(cert.ClusterID) {
+			continue
+		}
+
+		if _, ok := byCluster[cert.ClusterID]; !ok {
+			clusterIDs = append(clusterIDs, cert.ClusterID)
+		}
+
+		byCluster[cert.ClusterID] = append(byCluster[cert.ClusterID], cert)
+	}
+
+	errs := make([]error, 0)
+
+	for _, clusterID := range clusterIDs {
+		clusterCerts := byCluster[clusterID]
+
+		cluster, err := d.repo.Cluster().ReadCluster(clusterCerts[0].ProjectID, clusterID)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cluster %d: %w", clusterID, err))
+			continue
+		}
+
+		agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+			Cluster:           cluster,
+			Repo:              d.repo,
+			DigitalOceanOAuth: d.doConf,
+			Timeout:           30 * time.Second,
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cluster %d: %w", clusterID, err))
+			continue
+		}
+
+		for _, cert := range clusterCerts {
+			if err := Distribute(d.repo, agent.Clientset, cert); err != nil {
+				errs = append(errs, fmt.Errorf("certificate %d: %w", cert.ID, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error distributing %d wildcard certificates: %v", len(errs), errs)
+	}
+
+	return nil
+}
+
+// Distribute copies a wildcard certificate to its namespaces, and stores the result of the copy
+// with the certificate. Only errors which prevent the result from being stored are returned.
+func Distribute(repo repository.Repository, clientset k8s.Interface, cert *models.WildcardCertificate) error {
+	_, err := certificates.DistributeWildcardCertificate(
+		clientset,
+		cert.ResourceName(),
+		strconv.FormatUint(uint64(cert.ID), 10),
+		cert.SecretName(),
+		cert.GetNamespaces(),
+	)
+
+	// a certificate which has not been issued yet is copied once cert-manager has issued it, and
+	// its error is stored until then
+	if err != nil {
+		cert.DistributionError = err.Error()
+	} else {
+		now := time.Now()
+
+		cert.DistributedAt = &now
+		cert.DistributionError = ""
+	}
+
+	_, err = repo.WildcardCertificate().UpdateWildcardCertificate(cert)
+
+	return err
+}
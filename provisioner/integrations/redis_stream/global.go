@@ -180,6 +180,15 @@ func GlobalStreamListener(
 					})
 				}
 			}
+
+			switch status := fmt.Sprintf("%v", statusVal); status {
+			case "created", "error", "destroyed":
+				dispatchInfraChange(config, repo, infra, operation, status)
+
+				if status == "error" {
+					notifyProvisionFailed(config, repo, infra, operation)
+				}
+			}
 		}
 	}
 }
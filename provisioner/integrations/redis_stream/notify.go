@@ -0,0 +1,78 @@
+package redis_stream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/provisioner/server/config"
+)
+
+// notifyProvisionFailed posts a failed operation to the Slack and Discord integrations of the
+// project which are routed failed provisioning events
+func notifyProvisionFailed(config *config.Config, repo repository.Repository, infra *models.Infra, operation *models.Operation) {
+	opts := &notifier.ProvisionNotifyOpts{
+		ProjectID: infra.ProjectID,
+		InfraID:   infra.ID,
+		Kind:      string(infra.Kind),
+		Operation: strings.TrimPrefix(operation.Type, "retry_"),
+		Info:      operation.Error,
+		URL:       getInfraURL(config, infra),
+	}
+
+	slackInts, err := slack.ListRoutedIntegrations(
+		repo.SlackIntegration(), infra.ProjectID, types.SlackEventProvisionFailed, infra.ParentClusterID, "",
+	)
+
+	if err == nil {
+		err = slack.NewProvisionNotifier(slackInts...).NotifyFailed(opts)
+	}
+
+	if err != nil {
+		config.Logger.Debug().Msg(fmt.Sprintf("could not notify slack of failed operation %s: %s", operation.UID, err.Error()))
+	}
+
+	// infra does not belong to a namespace, so only the Discord integrations of the whole
+	// project are notified
+	discordInts, err := discord.ListRoutedIntegrations(repo, infra.ProjectID, infra.ParentClusterID, "")
+
+	if err == nil {
+		err = discord.NewProvisionNotifier(discordInts...).NotifyFailed(opts)
+	}
+
+	if err != nil {
+		config.Logger.Debug().Msg(fmt.Sprintf("could not notify discord of failed operation %s: %s", operation.UID, err.Error()))
+	}
+}
+
+// dispatchInfraChange sends an infra_change event to the webhook subscriptions of the project
+// when an operation has finished
+func dispatchInfraChange(config *config.Config, repo repository.Repository, infra *models.Infra, operation *models.Operation, status string) {
+	err := webhook.Dispatch(repo, infra.ProjectID, types.WebhookEventInfraChange, &types.WebhookInfraChangeData{
+		InfraID:   infra.ID,
+		Kind:      string(infra.Kind),
+		Operation: strings.TrimPrefix(operation.Type, "retry_"),
+		Status:    status,
+		Error:     operation.Error,
+		URL:       getInfraURL(config, infra),
+	})
+
+	if err != nil {
+		config.Logger.Debug().Msg(fmt.Sprintf("could not dispatch webhooks of operation %s: %s", operation.UID, err.Error()))
+	}
+}
+
+func getInfraURL(config *config.Config, infra *models.Infra) string {
+	return fmt.Sprintf(
+		"%s/infrastructure/%d?project_id=%d",
+		config.ProvisionerConf.ServerURL,
+		infra.ID,
+		infra.ProjectID,
+	)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/internal/adapter"
 	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	klocal "github.com/porter-dev/porter/internal/kubernetes/local"
 	"github.com/porter-dev/porter/internal/oauth"
@@ -55,6 +56,10 @@ type Config struct {
 	StorageManager storage.StorageManager
 	Repo           repository.Repository
 
+	// CredentialBackend is the backend for credential storage, if external cred storage (like Vault)
+	// is used
+	CredentialBackend credentials.CredentialStorage
+
 	// Logger for logging
 	Logger *logger.Logger
 
@@ -118,6 +123,9 @@ type ProvisionerConf struct {
 
 	// Client key for segment to report provisioning events
 	SegmentClientKey string `env:"SEGMENT_CLIENT_KEY"`
+
+	// ServerURL is the URL of the Porter server, which failed provisioning notifications link to
+	ServerURL string `env:"SERVER_URL,default=http://localhost:8080"`
 }
 
 type EnvConf struct {
@@ -159,7 +167,18 @@ func GetConfig(envConf *EnvConf) (*Config, error) {
 		key[i] = b
 	}
 
+	for _, prevKey := range envConf.DBConf.PreviousEncryptionKeys {
+		var decryptionKey [32]byte
+
+		for i, b := range []byte(prevKey) {
+			decryptionKey[i] = b
+		}
+
+		encryption.AddDecryptionKeys(&decryptionKey)
+	}
+
 	res.Repo = gorm.NewRepository(db, &key, InstanceCredentialBackend)
+	res.CredentialBackend = InstanceCredentialBackend
 
 	if envConf.ProvisionerConf.SentryDSN != "" {
 		res.Alerter, err = alerter.NewSentryAlerter(envConf.ProvisionerConf.SentryDSN, envConf.ProvisionerConf.SentryEnv)
@@ -3,6 +3,8 @@
 package config
 
 import (
+	"log"
+
 	"github.com/porter-dev/porter/ee/integrations/vault"
 )
 
@@ -16,10 +18,16 @@ func init() {
 	}
 
 	if InstanceEnvConf.DBConf.VaultAPIKey != "" && InstanceEnvConf.DBConf.VaultServerURL != "" && InstanceEnvConf.DBConf.VaultPrefix != "" {
-		InstanceCredentialBackend = vault.NewClient(
+		vaultClient := vault.NewClient(
 			InstanceEnvConf.DBConf.VaultServerURL,
 			InstanceEnvConf.DBConf.VaultAPIKey,
 			InstanceEnvConf.DBConf.VaultPrefix,
 		)
+
+		vaultClient.StartTokenRenewal(InstanceEnvConf.DBConf.VaultTokenRenewInterval, func(err error) {
+			log.Printf("error renewing vault token: %v", err)
+		})
+
+		InstanceCredentialBackend = vaultClient
 	}
 }
@@ -410,6 +410,8 @@ func createRDSEnvGroup(config *config.Config, infra *models.Infra, database *mod
 		return fmt.Errorf("failed to get agent: %s", err.Error())
 	}
 
+	storage := envgroup.NewSecretStorage(config.CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	// split the instance endpoint on the port
 	port := "5432"
 	host := database.InstanceEndpoint
@@ -419,16 +421,16 @@ func createRDSEnvGroup(config *config.Config, infra *models.Infra, database *mod
 		port = strArr[1]
 	}
 
-	_, err = envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+	_, err = envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
 		Name:      fmt.Sprintf("rds-credentials-%s", lastApplied["db_name"].(string)),
 		Namespace: "default",
 		Variables: map[string]string{},
-		SecretVariables: map[string]string{
+		SecretVariables: envgroup.EscapeTemplates(map[string]string{
 			"PGPORT":     port,
 			"PGHOST":     host,
 			"PGPASSWORD": lastApplied["db_passwd"].(string),
 			"PGUSER":     lastApplied["db_user"].(string),
-		},
+		}),
 	})
 
 	if err != nil {
@@ -457,7 +459,9 @@ func deleteRDSEnvGroup(config *config.Config, infra *models.Infra, lastApplied m
 		return fmt.Errorf("failed to get agent: %s", err.Error())
 	}
 
-	err = envgroup.DeleteEnvGroup(agent, fmt.Sprintf("rds-credentials-%s", lastApplied["db_name"].(string)), "default")
+	storage := envgroup.NewSecretStorage(config.CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	err = envgroup.DeleteStoredEnvGroup(agent, storage, fmt.Sprintf("rds-credentials-%s", lastApplied["db_name"].(string)), "default")
 
 	if err != nil {
 		return fmt.Errorf("failed to create RDS env group: %s", err.Error())
@@ -485,16 +489,18 @@ func createS3EnvGroup(config *config.Config, infra *models.Infra, lastApplied ma
 		return fmt.Errorf("failed to get agent: %s", err.Error())
 	}
 
+	storage := envgroup.NewSecretStorage(config.CredentialBackend, cluster.ProjectID, cluster.ID)
+
 	// split the instance endpoint on the port
-	_, err = envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
+	_, err = envgroup.CreateStoredEnvGroup(agent, storage, types.ConfigMapInput{
 		Name:      fmt.Sprintf("s3-credentials-%s", lastApplied["bucket_name"].(string)),
 		Namespace: "default",
 		Variables: map[string]string{},
-		SecretVariables: map[string]string{
+		SecretVariables: envgroup.EscapeTemplates(map[string]string{
 			"S3_AWS_ACCESS_KEY_ID": output["s3_aws_access_key_id"].(string),
 			"S3_AWS_SECRET_KEY":    output["s3_aws_secret_key"].(string),
 			"S3_BUCKET_NAME":       output["s3_bucket_name"].(string),
-		},
+		}),
 	})
 
 	if err != nil {
@@ -523,7 +529,9 @@ func deleteS3EnvGroup(config *config.Config, infra *models.Infra, lastApplied ma
 		return fmt.Errorf("failed to get agent: %s", err.Error())
 	}
 
-	err = envgroup.DeleteEnvGroup(agent, fmt.Sprintf("s3-credentials-%s", lastApplied["bucket_name"].(string)), "default")
+	storage := envgroup.NewSecretStorage(config.CredentialBackend, cluster.ProjectID, cluster.ID)
+
+	err = envgroup.DeleteStoredEnvGroup(agent, storage, fmt.Sprintf("s3-credentials-%s", lastApplied["bucket_name"].(string)), "default")
 
 	if err != nil {
 		return fmt.Errorf("failed to create RDS env group: %s", err.Error())
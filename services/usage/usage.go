@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/ee/integrations/vault"
 	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository"
@@ -63,6 +64,16 @@ func NewUsageTracker(opts *UsageTrackerOpts) (*UsageTracker, error) {
 		key[i] = b
 	}
 
+	for _, prevKey := range opts.DBConf.PreviousEncryptionKeys {
+		var decryptionKey [32]byte
+
+		for i, b := range []byte(prevKey) {
+			decryptionKey[i] = b
+		}
+
+		encryption.AddDecryptionKeys(&decryptionKey)
+	}
+
 	repo := rgorm.NewRepository(db, &key, credBackend)
 
 	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
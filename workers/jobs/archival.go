@@ -0,0 +1,119 @@
+//go:build ee
+
+/*
+
+                            === Archival Job ===
+
+This job moves old rows out of the deployments and kube events tables, so that the tables which
+are read on every request stay small. It is meant to be enqueued on a daily interval.
+
+  - Deployments of closed pull requests, which are deleted or inactive, are archived if they
+    were last updated before the archive retention window.
+  - Kube events, along with their sub events, are archived if they were last updated before
+    the archive retention window.
+  - Rows are archived in batches, each in its own transaction, so that the job does not hold
+    long-running locks on the tables.
+
+Archived rows can be listed through the archive endpoints of a cluster.
+
+*/
+
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/repository"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+// rows are archived 100 at a time
+const archiveStepSize = 100
+
+type archival struct {
+	enqueueTime   time.Time
+	repo          repository.Repository
+	retentionDays uint
+}
+
+// ArchivalOpts holds the options required to run this job
+type ArchivalOpts struct {
+	DBConf        *env.DBConf
+	RetentionDays uint
+}
+
+func NewArchival(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *ArchivalOpts,
+) (*archival, error) {
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	// archiving does not read credentials, so no credential backend is required
+	repo := rgorm.NewRepository(db, &key, nil)
+
+	return &archival{
+		enqueueTime, repo, opts.RetentionDays,
+	}, nil
+}
+
+func (a *archival) ID() string {
+	return "archival"
+}
+
+func (a *archival) EnqueueTime() time.Time {
+	return a.enqueueTime
+}
+
+func (a *archival) Run() error {
+	updatedBefore := a.enqueueTime.AddDate(0, 0, -int(a.retentionDays))
+
+	deplCount, err := archiveInSteps(func() (int64, error) {
+		return a.repo.Archive().ArchiveDeployments(updatedBefore, archiveStepSize)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	eventCount, err := archiveInSteps(func() (int64, error) {
+		return a.repo.Archive().ArchiveKubeEvents(updatedBefore, archiveStepSize)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("archived %d deployments and %d kube events last updated before %s",
+		deplCount, eventCount, updatedBefore.Format(time.RFC3339))
+
+	return nil
+}
+
+func (a *archival) SetData([]byte) {}
+
+// archiveInSteps runs an archive query until it archives less than a full step
+func archiveInSteps(archive func() (int64, error)) (int64, error) {
+	var total int64
+
+	for {
+		count, err := archive()
+
+		if err != nil {
+			return total, err
+		}
+
+		total += count
+
+		if count < archiveStepSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,148 @@
+//go:build ee
+
+/*
+
+                            === Email Digest Job ===
+
+This job sends the daily email digests of the users who have opted in to them. It is meant to be
+enqueued on a daily interval.
+
+  - A digest summarizes the deploys since the last digest of the user, along with the open
+    preview environments of every project the user belongs to.
+  - Users who have not been sent a digest in the last day are due. Users who have never been
+    sent a digest are sent the deploys of the last day.
+  - No email is sent if there is nothing to summarize, but the user is still marked as sent.
+  - Deploy records which are older than every digest are purged.
+
+*/
+
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/notifier/email"
+	"github.com/porter-dev/porter/internal/repository"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+const (
+	digestInterval = 24 * time.Hour
+
+	// digests which are a little early are still sent, so that the time at which a digest
+	// is sent does not drift later every day
+	digestIntervalTolerance = time.Hour
+
+	// deploy records are kept for a week, so that digests which are sent late still have the
+	// deploys since the last digest
+	deploymentRecordRetention = 7 * 24 * time.Hour
+)
+
+type emailDigest struct {
+	enqueueTime time.Time
+	repo        repository.Repository
+	sender      email.Sender
+	serverURL   string
+}
+
+// EmailDigestOpts holds the options required to run this job
+type EmailDigestOpts struct {
+	DBConf      *env.DBConf
+	ServerURL   string
+	EmailSender *email.SenderOpts
+}
+
+func NewEmailDigest(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EmailDigestOpts,
+) (*emailDigest, error) {
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	// digests do not read credentials, so no credential backend is required
+	repo := rgorm.NewRepository(db, &key, nil)
+
+	return &emailDigest{
+		enqueueTime: enqueueTime,
+		repo:        repo,
+		sender:      email.NewSender(opts.EmailSender),
+		serverURL:   opts.ServerURL,
+	}, nil
+}
+
+func (e *emailDigest) ID() string {
+	return "email-digest"
+}
+
+func (e *emailDigest) EnqueueTime() time.Time {
+	return e.enqueueTime
+}
+
+func (e *emailDigest) Run() error {
+	if e.sender == nil {
+		log.Println("no email provider is configured, skipping email digests")
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	prefs, err := e.repo.EmailPreference().ListDueDigestPreferences(now.Add(-digestInterval + digestIntervalTolerance))
+
+	if err != nil {
+		return err
+	}
+
+	sentCount := 0
+
+	for _, pref := range prefs {
+		user, err := e.repo.User().ReadUser(pref.UserID)
+
+		if err != nil {
+			log.Printf("error reading user ID %d: %v", pref.UserID, err)
+			continue
+		}
+
+		since := now.Add(-digestInterval)
+
+		if pref.LastDigestAt != nil {
+			since = *pref.LastDigestAt
+		}
+
+		sent, err := email.SendDigest(e.repo, e.sender, e.serverURL, user, since)
+
+		if err != nil {
+			// the user is not marked as sent, so that the digest is retried on the next run
+			log.Printf("error sending email digest to user ID %d: %v", user.ID, err)
+			continue
+		}
+
+		if sent {
+			sentCount++
+		}
+
+		pref.LastDigestAt = &now
+
+		if _, err := e.repo.EmailPreference().UpdateEmailPreference(pref); err != nil {
+			log.Printf("error updating email preference of user ID %d: %v", user.ID, err)
+		}
+	}
+
+	purgedCount, err := e.repo.EmailPreference().DeleteDeploymentRecords(now.Add(-deploymentRecordRetention))
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("sent %d email digests, purged %d deployment records", sentCount, purgedCount)
+
+	return nil
+}
+
+func (e *emailDigest) SetData([]byte) {}
@@ -0,0 +1,83 @@
+//go:build ee
+
+/*
+
+                            === Encryption Key Rotation Job ===
+
+This job re-encrypts every credential stored in the database with the current encryption key.
+It is meant to be enqueued once, after the encryption key has been rotated:
+
+  1. Set ENCRYPTION_KEY to the new key, and add the old key to PREVIOUS_ENCRYPTION_KEYS.
+     Credentials encrypted with either key can be read, and new credentials are encrypted
+     with the new key.
+  2. Run this job, and check that it reports no credentials which could not be decrypted.
+  3. Remove the old key from PREVIOUS_ENCRYPTION_KEYS.
+
+Credentials which cannot be decrypted with any known key are left unchanged.
+
+*/
+
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/encryption"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+
+	// registers the encrypted fields of the ee models
+	_ "github.com/porter-dev/porter/ee/repository/gorm"
+)
+
+type encryptionKeyRotation struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	key         *[32]byte
+}
+
+// EncryptionKeyRotationOpts holds the options required to run this job
+type EncryptionKeyRotationOpts struct {
+	DBConf *env.DBConf
+}
+
+func NewEncryptionKeyRotation(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EncryptionKeyRotationOpts,
+) (*encryptionKeyRotation, error) {
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	return &encryptionKeyRotation{
+		enqueueTime, db, &key,
+	}, nil
+}
+
+func (e *encryptionKeyRotation) ID() string {
+	return "encryption-key-rotation"
+}
+
+func (e *encryptionKeyRotation) EnqueueTime() time.Time {
+	return e.enqueueTime
+}
+
+func (e *encryptionKeyRotation) Run() error {
+	res, err := rgorm.ReEncrypt(e.db, e.key)
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("re-encrypted %d values with key %s: %d values were already up to date, %d could not be decrypted",
+		res.ReEncrypted, encryption.KeyID(e.key), res.Current, res.Failed)
+
+	return nil
+}
+
+func (e *encryptionKeyRotation) SetData([]byte) {}
@@ -0,0 +1,519 @@
+//go:build ee
+
+/*
+
+                            === Incident Detector Job ===
+
+This job detects incidents from the pod states and Kubernetes events of each cluster, and stores
+them in the database. It is meant to be enqueued on a short interval.
+
+  - Clusters with a relay which reported recently are not polled: the incidents detected by the
+    relay's in-cluster agent, and the log excerpts it attached to them, are used instead.
+  - Pods in the cluster are checked for crash loops, image pull failures and OOM kills.
+  - Recent "Unhealthy" events are checked for failed liveness and readiness probes.
+  - Failures are aggregated per workload, and new incidents are opened for failures which do not
+    already have an open incident.
+  - Open incidents which are no longer detected are resolved.
+  - Log alert rules of Porter releases are evaluated by the relay's agent, which reports the rules
+    that matched as incidents with the matching log lines as their excerpt.
+  - When a crash loop, image pull failure or log alert is opened for a Porter release, the project's Slack
+    integrations are notified with an excerpt of the container logs. If the release belongs to a
+    preview deployment, a comment is also added to the deployment's pull request.
+  - Crash loops of Porter releases which stay open for 10 minutes open an incident on the PagerDuty
+    services mapped to the release, which is resolved once the crash loop is resolved.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	gogithub "github.com/google/go-github/v41/github"
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/incidents"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+	"github.com/porter-dev/porter/internal/notifier/github"
+	"github.com/porter-dev/porter/internal/notifier/pagerduty"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/relay"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+type incidentDetector struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	repo        repository.Repository
+	doConf      *oauth2.Config
+	clusterID   uint
+	serverURL   string
+
+	githubAppID     string
+	githubAppSecret []byte
+}
+
+// IncidentDetectorOpts holds the options required to run this job
+type IncidentDetectorOpts struct {
+	DBConf         *env.DBConf
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+	ServerURL      string
+
+	// the Github app is used to comment on the pull requests of failing preview deployments
+	GithubAppID     string
+	GithubAppSecret []byte
+
+	Input map[string]interface{}
+}
+
+type incidentDetectorInput struct {
+	// if set, only this cluster is checked for incidents
+	ClusterID uint `mapstructure:"cluster_id"`
+}
+
+func NewIncidentDetector(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *IncidentDetectorOpts,
+) (*incidentDetector, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	parsedInput := &incidentDetectorInput{}
+
+	if err := mapstructure.Decode(opts.Input, parsedInput); err != nil {
+		return nil, err
+	}
+
+	return &incidentDetector{
+		enqueueTime, db, repo, doConf, parsedInput.ClusterID, opts.ServerURL,
+		opts.GithubAppID, opts.GithubAppSecret,
+	}, nil
+}
+
+func (i *incidentDetector) ID() string {
+	return "incident-detector"
+}
+
+func (i *incidentDetector) EnqueueTime() time.Time {
+	return i.enqueueTime
+}
+
+func (i *incidentDetector) Run() error {
+	clusters := make([]*models.Cluster, 0)
+
+	query := i.db
+
+	if i.clusterID != 0 {
+		query = query.Where("id = ?", i.clusterID)
+	}
+
+	if err := query.Find(&clusters).Error; err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		// clusters with an active relay report their incidents, so they aren't polled
+		detected, excerpts, err := i.getRelayIncidents(cluster)
+
+		if err != nil {
+			log.Printf("error reading relay incidents for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+			continue
+		}
+
+		var k8sAgent *kubernetes.Agent
+
+		if detected == nil {
+			k8sAgent, err = kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+				Cluster:                   cluster,
+				Repo:                      i.repo,
+				DigitalOceanOAuth:         i.doConf,
+				AllowInClusterConnections: false,
+				Timeout:                   5 * time.Second,
+			})
+
+			if err != nil {
+				log.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+				continue
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+
+		if k8sAgent != nil {
+			detected, err = incidents.NewDetector(k8sAgent.Clientset).Detect(ctx)
+
+			if err != nil {
+				cancel()
+				log.Printf("error detecting incidents for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+				continue
+			}
+		}
+
+		// the open incidents are read before they are reconciled, so that crash loops which have
+		// become sustained since the last run can be paged
+		previous, err := i.repo.ClusterIncident().ListActiveClusterIncidents(cluster.ProjectID, cluster.ID)
+
+		if err != nil {
+			cancel()
+			log.Printf("error listing open incidents for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+			continue
+		}
+
+		now := time.Now().UTC()
+
+		events, err := incidents.Reconcile(i.repo.ClusterIncident(), cluster, detected, now)
+
+		if err != nil {
+			cancel()
+			log.Printf("error storing incidents for cluster ID %d: %v", cluster.ID, err)
+			continue
+		}
+
+		log.Printf("incident detector: %d incident changes for cluster ID %d", len(events), cluster.ID)
+
+		for _, event := range events {
+			if !shouldNotifyIncident(cluster, event) {
+				continue
+			}
+
+			if err := i.notifyIncident(ctx, k8sAgent, excerpts, cluster, event.Incident); err != nil {
+				log.Printf("error sending notifications for incident ID %d in cluster ID %d: %v",
+					event.Incident.ID, cluster.ID, err)
+			}
+		}
+
+		if !cluster.NotificationsDisabled {
+			if err := i.notifyPagerDuty(cluster, previous, events, now); err != nil {
+				log.Printf("error sending pagerduty events for cluster ID %d: %v", cluster.ID, err)
+			}
+		}
+
+		cancel()
+	}
+
+	return nil
+}
+
+// getRelayIncidents returns the incidents and log excerpts of the last report of the relay of
+// a cluster. If the cluster doesn't have a relay which reported recently, nil is returned.
+func (i *incidentDetector) getRelayIncidents(cluster *models.Cluster) ([]*incidents.DetectedIncident, map[string]string, error) {
+	clusterRelay, err := i.repo.ClusterRelay().ReadClusterRelay(cluster.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, err
+	}
+
+	if !clusterRelay.IsActive(time.Now()) {
+		return nil, nil, nil
+	}
+
+	return relay.GetDetectedIncidents(clusterRelay)
+}
+
+// shouldNotifyIncident returns true for newly opened crash loops, image pull failures and log
+// alerts of Porter releases
+func shouldNotifyIncident(cluster *models.Cluster, event *types.ClusterIncidentEvent) bool {
+	if cluster.NotificationsDisabled || event.Type != types.ClusterIncidentEventOpened {
+		return false
+	}
+
+	incident := event.Incident
+
+	return incident.ReleaseName != "" && (incident.Reason == types.ClusterIncidentReasonCrashLoop ||
+		incident.Reason == types.ClusterIncidentReasonImagePull ||
+		incident.Reason == types.ClusterIncidentReasonLogAlert)
+}
+
+func (i *incidentDetector) notifyIncident(
+	ctx context.Context,
+	k8sAgent *kubernetes.Agent,
+	relayExcerpts map[string]string,
+	cluster *models.Cluster,
+	incident *types.ClusterIncident,
+) error {
+	rel, err := i.repo.Release().ReadRelease(cluster.ID, incident.ReleaseName, incident.Namespace)
+
+	// incidents are only sent for releases deployed through Porter
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var notifConf *types.NotificationConfig
+
+	if rel.NotificationConfig != 0 {
+		conf, err := i.repo.NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
+
+		if err != nil {
+			return err
+		}
+
+		notifConf = conf.ToNotificationConfigType()
+	}
+
+	var excerpt string
+
+	if k8sAgent != nil {
+		// the logs are best-effort, since the pod may have been replaced since detection
+		excerpt, err = incidents.GetExcerpt(ctx, k8sAgent.Clientset, incident, incidents.DefaultExcerptLines)
+
+		if err != nil {
+			log.Printf("error getting log excerpt for incident ID %d: %v", incident.ID, err)
+		}
+	} else {
+		excerpt = relayExcerpts[incidents.Key(
+			incident.Namespace, incident.InvolvedObjectKind, incident.InvolvedObjectName, incident.Reason,
+		)]
+	}
+
+	notifPrefs, err := i.repo.NotificationPreference().ListNotificationPreferencesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return err
+	}
+
+	notifiers := make([]notifier.ClusterIncidentNotifier, 0)
+
+	slackInts, err := slack.ListRoutedIntegrations(
+		i.repo.SlackIntegration(), cluster.ProjectID, types.SlackEventIncident, cluster.ID, incident.Namespace,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if len(slackInts) > 0 &&
+		notifier.ProjectAllows(notifPrefs, cluster.ProjectID, types.NotificationChannelSlack, types.NotificationEventIncident) {
+		notifiers = append(notifiers, slack.NewClusterIncidentNotifier(slackInts...))
+	}
+
+	discordInts, err := discord.ListRoutedIntegrations(i.repo, cluster.ProjectID, cluster.ID, incident.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	if len(discordInts) > 0 {
+		notifiers = append(notifiers, discord.NewClusterIncidentNotifier(discordInts...))
+	}
+
+	if notifier.ProjectAllows(notifPrefs, cluster.ProjectID, types.NotificationChannelWebhook, types.NotificationEventIncident) {
+		notifiers = append(notifiers, webhook.NewClusterIncidentNotifier(i.repo))
+	}
+
+	prNotifier, err := i.getPreviewDeploymentNotifier(cluster, incident.Namespace)
+
+	if err != nil {
+		log.Printf("error getting pull request for namespace %s in cluster ID %d: %v", incident.Namespace, cluster.ID, err)
+	} else if prNotifier != nil {
+		notifiers = append(notifiers, prNotifier)
+	}
+
+	appURL := i.getReleaseURL(cluster, incident.Namespace, incident.ReleaseName)
+
+	return notifier.NewMultiClusterIncidentNotifier(notifConf, notifiers...).NotifyOpened(incident, excerpt, appURL)
+}
+
+// notifyPagerDuty pages the PagerDuty services mapped to crash looping releases once the crash
+// loop has been open for pagerduty.SustainedCrashLoopDuration, and resolves the PagerDuty
+// incident once the crash loop is resolved. Ongoing incidents only produce events when they
+// change, so a crash loop is paged on the run where its duration crosses the threshold.
+func (i *incidentDetector) notifyPagerDuty(
+	cluster *models.Cluster,
+	previous []*models.ClusterIncident,
+	events []*types.ClusterIncidentEvent,
+	now time.Time,
+) error {
+	resolved := make(map[uint]bool)
+
+	for _, event := range events {
+		incident := event.Incident
+
+		if event.Type != types.ClusterIncidentEventResolved || incident.Reason != types.ClusterIncidentReasonCrashLoop ||
+			incident.ReleaseName == "" {
+			continue
+		}
+
+		resolved[incident.ID] = true
+
+		// only crash loops which were paged need to be resolved
+		if !pagerduty.IsSustained(incident.StartedAt, incident.LastSeenAt) {
+			continue
+		}
+
+		pdInts, err := pagerduty.ListRoutedIntegrations(
+			i.repo.PagerDutyIntegration(), cluster.ProjectID, cluster.ID, incident.Namespace,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if err := pagerduty.NewCrashLoopNotifier(pdInts...).NotifyResolved(incident); err != nil {
+			log.Printf("error resolving pagerduty incident for incident ID %d: %v", incident.ID, err)
+		}
+	}
+
+	for _, incident := range previous {
+		if incident.Reason != string(types.ClusterIncidentReasonCrashLoop) || incident.ReleaseName == "" ||
+			resolved[incident.ID] {
+			continue
+		}
+
+		if pagerduty.IsSustained(incident.StartedAt, incident.LastSeenAt) || !pagerduty.IsSustained(incident.StartedAt, now) {
+			continue
+		}
+
+		// incidents are only paged for releases deployed through Porter
+		if _, err := i.repo.Release().ReadRelease(cluster.ID, incident.ReleaseName, incident.Namespace); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		pdInts, err := pagerduty.ListRoutedIntegrations(
+			i.repo.PagerDutyIntegration(), cluster.ProjectID, cluster.ID, incident.Namespace,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		appURL := i.getReleaseURL(cluster, incident.Namespace, incident.ReleaseName)
+
+		err = pagerduty.NewCrashLoopNotifier(pdInts...).NotifySustained(
+			incident.ToClusterIncidentType(), cluster.Name, appURL,
+		)
+
+		if err != nil {
+			log.Printf("error paging pagerduty for incident ID %d: %v", incident.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (i *incidentDetector) getReleaseURL(cluster *models.Cluster, namespace, name string) string {
+	return fmt.Sprintf(
+		"%s/applications/%s/%s/%s?project_id=%d",
+		i.serverURL,
+		url.PathEscape(cluster.Name),
+		namespace,
+		name,
+		cluster.ProjectID,
+	)
+}
+
+// getPreviewDeploymentNotifier returns a notifier which comments on the pull request of the
+// preview deployment in a namespace. If the namespace does not belong to a preview deployment
+// opened from a pull request, nil is returned.
+func (i *incidentDetector) getPreviewDeploymentNotifier(
+	cluster *models.Cluster,
+	namespace string,
+) (notifier.ClusterIncidentNotifier, error) {
+	if i.githubAppID == "" || len(i.githubAppSecret) == 0 {
+		return nil, nil
+	}
+
+	depls, err := i.repo.Environment().ListDeploymentsByCluster(cluster.ProjectID, cluster.ID, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var depl *models.Deployment
+
+	for _, d := range depls {
+		if d.Namespace == namespace && d.PullRequestID != 0 {
+			depl = d
+			break
+		}
+	}
+
+	if depl == nil {
+		return nil, nil
+	}
+
+	env, err := i.repo.Environment().ReadEnvironmentByID(cluster.ProjectID, cluster.ID, depl.EnvironmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ghAppID, err := strconv.Atoi(i.githubAppID)
+
+	if err != nil {
+		return nil, fmt.Errorf("malformed GITHUB_APP_ID in worker configuration: %w", err)
+	}
+
+	itr, err := ghinstallation.New(
+		http.DefaultTransport,
+		int64(ghAppID),
+		int64(env.GitInstallationID),
+		i.githubAppSecret,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return github.NewClusterIncidentNotifier(
+		gogithub.NewClient(&http.Client{Transport: itr}),
+		depl.RepoOwner,
+		depl.RepoName,
+		int(depl.PullRequestID),
+	), nil
+}
+
+func (i *incidentDetector) SetData([]byte) {}
@@ -0,0 +1,212 @@
+//go:build ee
+
+/*
+
+                       === Registry Credential Refresh Job ===
+
+This job regenerates the image pull secrets of registries whose credentials expire, such as ECR
+registries whose tokens are valid for 12 hours. It is meant to be enqueued on an interval well
+below the credential lifetime, such as every 4 hours.
+
+  - Clusters are fetched, optionally filtered to a single cluster.
+  - For every registry of the cluster's project with expiring credentials, the pull secrets
+    named porter-<service>-<registry id> are regenerated in every namespace containing one.
+  - The result of each refresh is stored per cluster and registry, so that failures are
+    visible on the cluster's registry credential status.
+
+*/
+
+package jobs
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+type registryCredentialRefresh struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	repo        repository.Repository
+	doConf      *oauth2.Config
+	clusterID   uint
+}
+
+// RegistryCredentialRefreshOpts holds the options required to run this job
+type RegistryCredentialRefreshOpts struct {
+	DBConf         *env.DBConf
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+	ServerURL      string
+
+	Input map[string]interface{}
+}
+
+type registryCredentialRefreshInput struct {
+	// if set, only the pull secrets of this cluster are refreshed
+	ClusterID uint `mapstructure:"cluster_id"`
+}
+
+func NewRegistryCredentialRefresh(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *RegistryCredentialRefreshOpts,
+) (*registryCredentialRefresh, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	parsedInput := &registryCredentialRefreshInput{}
+
+	if err := mapstructure.Decode(opts.Input, parsedInput); err != nil {
+		return nil, err
+	}
+
+	return &registryCredentialRefresh{
+		enqueueTime, db, repo, doConf, parsedInput.ClusterID,
+	}, nil
+}
+
+func (r *registryCredentialRefresh) ID() string {
+	return "registry-credential-refresh"
+}
+
+func (r *registryCredentialRefresh) EnqueueTime() time.Time {
+	return r.enqueueTime
+}
+
+func (r *registryCredentialRefresh) Run() error {
+	clusters := make([]*models.Cluster, 0)
+
+	query := r.db
+
+	if r.clusterID != 0 {
+		query = query.Where("id = ?", r.clusterID)
+	}
+
+	if err := query.Find(&clusters).Error; err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		registries, err := r.repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+		if err != nil {
+			log.Printf("error listing registries for project ID %d: %v. skipping cluster ...", cluster.ProjectID, err)
+			continue
+		}
+
+		expiring := make([]*models.Registry, 0)
+
+		for _, reg := range registries {
+			_reg := registry.Registry(*reg)
+
+			if _reg.HasExpiringCredentials() {
+				expiring = append(expiring, reg)
+			}
+		}
+
+		if len(expiring) == 0 {
+			continue
+		}
+
+		k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+			Cluster:                   cluster,
+			Repo:                      r.repo,
+			DigitalOceanOAuth:         r.doConf,
+			AllowInClusterConnections: false,
+			Timeout:                   5 * time.Second,
+		})
+
+		if err != nil {
+			log.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+			continue
+		}
+
+		for _, reg := range expiring {
+			r.refreshRegistry(k8sAgent, cluster, reg)
+		}
+	}
+
+	return nil
+}
+
+// refreshRegistry regenerates the pull secrets of a registry in a cluster and stores the result
+func (r *registryCredentialRefresh) refreshRegistry(
+	k8sAgent *kubernetes.Agent,
+	cluster *models.Cluster,
+	reg *models.Registry,
+) {
+	status, err := r.repo.RegistryCredentialRefresh().ReadRegistryCredentialRefresh(cluster.ID, reg.ID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		status = &models.RegistryCredentialRefresh{
+			ProjectID:  cluster.ProjectID,
+			ClusterID:  cluster.ID,
+			RegistryID: reg.ID,
+		}
+	} else if err != nil {
+		log.Printf("error reading credential refresh status of registry ID %d in cluster ID %d: %v",
+			reg.ID, cluster.ID, err)
+		return
+	}
+
+	now := time.Now().UTC()
+
+	refreshed, err := k8sAgent.RefreshImagePullSecrets(r.repo, reg, r.doConf)
+
+	status.LastAttemptedAt = now
+	status.Namespaces = refreshed
+
+	if err != nil {
+		log.Printf("error refreshing pull secrets of registry ID %d in cluster ID %d: %v", reg.ID, cluster.ID, err)
+		status.Error = err.Error()
+	} else {
+		log.Printf("registry credential refresh: refreshed %d pull secrets of registry ID %d in cluster ID %d",
+			refreshed, reg.ID, cluster.ID)
+		status.Error = ""
+		status.LastRefreshedAt = &now
+	}
+
+	if _, err := r.repo.RegistryCredentialRefresh().CreateOrUpdateRegistryCredentialRefresh(status); err != nil {
+		log.Printf("error storing credential refresh status of registry ID %d in cluster ID %d: %v",
+			reg.ID, cluster.ID, err)
+	}
+}
+
+func (r *registryCredentialRefresh) SetData([]byte) {}
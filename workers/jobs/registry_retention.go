@@ -0,0 +1,170 @@
+//go:build ee
+
+/*
+
+                            === Registry Retention Job ===
+
+This job enforces the image retention policies of registries. It is meant to be enqueued on a
+daily interval.
+
+  - Enabled retention policies are fetched, optionally filtered to a single registry.
+  - For every repository of the policy's registry, tags beyond the most recent N tags are
+    deleted unless they match the protected tag pattern, and untagged images older than the
+    configured number of days are deleted.
+  - Tags which share a digest with a kept tag are never deleted.
+  - Policies of registries which no longer exist are deleted.
+
+*/
+
+package jobs
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+type registryRetention struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	repo        repository.Repository
+	doConf      *oauth2.Config
+	registryID  uint
+}
+
+// RegistryRetentionOpts holds the options required to run this job
+type RegistryRetentionOpts struct {
+	DBConf         *env.DBConf
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+	ServerURL      string
+
+	Input map[string]interface{}
+}
+
+type registryRetentionInput struct {
+	// if set, only the policy of this registry is enforced
+	RegistryID uint `mapstructure:"registry_id"`
+}
+
+func NewRegistryRetention(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *RegistryRetentionOpts,
+) (*registryRetention, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	parsedInput := &registryRetentionInput{}
+
+	if err := mapstructure.Decode(opts.Input, parsedInput); err != nil {
+		return nil, err
+	}
+
+	return &registryRetention{
+		enqueueTime, db, repo, doConf, parsedInput.RegistryID,
+	}, nil
+}
+
+func (r *registryRetention) ID() string {
+	return "registry-retention"
+}
+
+func (r *registryRetention) EnqueueTime() time.Time {
+	return r.enqueueTime
+}
+
+func (r *registryRetention) Run() error {
+	policies, err := r.repo.RegistryRetentionPolicy().ListEnabledRegistryRetentionPolicies()
+
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if r.registryID != 0 && policy.RegistryID != r.registryID {
+			continue
+		}
+
+		reg, err := r.repo.Registry().ReadRegistry(policy.ProjectID, policy.RegistryID)
+
+		if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := r.repo.RegistryRetentionPolicy().DeleteRegistryRetentionPolicy(policy); err != nil {
+				log.Printf("error deleting retention policy of deleted registry ID %d: %v", policy.RegistryID, err)
+			}
+
+			continue
+		} else if err != nil {
+			log.Printf("error reading registry ID %d: %v. skipping registry ...", policy.RegistryID, err)
+			continue
+		}
+
+		_reg := registry.Registry(*reg)
+
+		now := time.Now().UTC()
+
+		report, err := _reg.EnforceRetention(policy, r.repo, r.doConf, false, now)
+
+		if err != nil {
+			log.Printf("error enforcing retention policy of registry ID %d: %v. skipping registry ...", reg.ID, err)
+			continue
+		}
+
+		deleted := 0
+
+		for _, repoReport := range report.Repositories {
+			deleted += len(repoReport.Deletions)
+
+			if repoReport.Error != "" {
+				log.Printf("registry retention: error in repository %s of registry ID %d: %s",
+					repoReport.Repository, reg.ID, repoReport.Error)
+			}
+		}
+
+		log.Printf("registry retention: deleted %d images from registry ID %d", deleted, reg.ID)
+
+		policy.LastRunAt = &now
+
+		if _, err := r.repo.RegistryRetentionPolicy().CreateOrUpdateRegistryRetentionPolicy(policy); err != nil {
+			log.Printf("error updating retention policy of registry ID %d: %v", reg.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *registryRetention) SetData([]byte) {}
@@ -0,0 +1,94 @@
+//go:build ee
+
+/*
+
+                            === Soft Delete Purge Job ===
+
+This job permanently deletes projects, clusters and preview environments which were deleted
+before the soft delete retention window. It is meant to be enqueued on a daily interval.
+
+  - Projects are purged along with their roles.
+  - Clusters are purged along with their token caches.
+  - Environments are purged along with their deployments.
+
+*/
+
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/repository"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+type softDeletePurge struct {
+	enqueueTime   time.Time
+	repo          repository.Repository
+	retentionDays uint
+}
+
+// SoftDeletePurgeOpts holds the options required to run this job
+type SoftDeletePurgeOpts struct {
+	DBConf        *env.DBConf
+	RetentionDays uint
+}
+
+func NewSoftDeletePurge(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *SoftDeletePurgeOpts,
+) (*softDeletePurge, error) {
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	// purging does not read credentials, so no credential backend is required
+	repo := rgorm.NewRepository(db, &key, nil)
+
+	return &softDeletePurge{
+		enqueueTime, repo, opts.RetentionDays,
+	}, nil
+}
+
+func (s *softDeletePurge) ID() string {
+	return "soft-delete-purge"
+}
+
+func (s *softDeletePurge) EnqueueTime() time.Time {
+	return s.enqueueTime
+}
+
+func (s *softDeletePurge) Run() error {
+	deletedBefore := s.enqueueTime.AddDate(0, 0, -int(s.retentionDays))
+
+	envCount, err := s.repo.Environment().PurgeDeletedEnvironments(deletedBefore)
+
+	if err != nil {
+		return err
+	}
+
+	clusterCount, err := s.repo.Cluster().PurgeDeletedClusters(deletedBefore)
+
+	if err != nil {
+		return err
+	}
+
+	projectCount, err := s.repo.Project().PurgeDeletedProjects(deletedBefore)
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("purged %d projects, %d clusters and %d environments deleted before %s",
+		projectCount, clusterCount, envCount, deletedBefore.Format(time.RFC3339))
+
+	return nil
+}
+
+func (s *softDeletePurge) SetData([]byte) {}
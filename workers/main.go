@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/joeshaw/envdecode"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/notifier/email"
 	"github.com/porter-dev/porter/internal/opa"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/worker"
@@ -25,6 +27,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/encryption"
 	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
 	pgorm "github.com/porter-dev/porter/internal/repository/gorm"
 )
@@ -35,6 +38,8 @@ var (
 	dbConn      *gorm.DB
 	repo        repository.Repository
 	opaPolicies *opa.KubernetesPolicies
+
+	githubAppSecret []byte
 )
 
 // EnvConf holds the environment variables for this binary
@@ -53,8 +58,23 @@ type EnvConf struct {
 
 	OPAConfigFileDir string `env:"OPA_CONFIG_FILE_DIR,default=./internal/opa"`
 
+	GithubAppID         string `env:"GITHUB_APP_ID"`
+	GithubAppSecretPath string `env:"GITHUB_APP_SECRET_PATH"`
+
 	LegacyProjectIDs []uint `env:"LEGACY_PROJECT_IDS"`
 
+	SoftDeleteRetentionDays uint `env:"SOFT_DELETE_RETENTION_DAYS,default=30"`
+
+	ArchiveRetentionDays uint `env:"ARCHIVE_RETENTION_DAYS,default=90"`
+
+	SendgridAPIKey      string `env:"SENDGRID_API_KEY"`
+	SendgridSenderEmail string `env:"SENDGRID_SENDER_EMAIL"`
+	SMTPHost            string `env:"SMTP_HOST"`
+	SMTPPort            uint   `env:"SMTP_PORT,default=587"`
+	SMTPUsername        string `env:"SMTP_USERNAME"`
+	SMTPPassword        string `env:"SMTP_PASSWORD"`
+	SMTPSenderEmail     string `env:"SMTP_SENDER_EMAIL"`
+
 	Port uint `env:"PORT,default=3000"`
 }
 
@@ -92,6 +112,16 @@ func main() {
 		key[i] = b
 	}
 
+	for _, prevKey := range envDecoder.DBConf.PreviousEncryptionKeys {
+		var decryptionKey [32]byte
+
+		for i, b := range []byte(prevKey) {
+			decryptionKey[i] = b
+		}
+
+		encryption.AddDecryptionKeys(&decryptionKey)
+	}
+
 	repo = pgorm.NewRepository(db, &key, credBackend)
 
 	opaPolicies, err = opa.LoadPolicies(envDecoder.OPAConfigFileDir)
@@ -100,6 +130,14 @@ func main() {
 		log.Fatalln(err)
 	}
 
+	if envDecoder.GithubAppSecretPath != "" {
+		githubAppSecret, err = ioutil.ReadFile(envDecoder.GithubAppSecretPath)
+
+		if err != nil {
+			log.Fatalf("could not read github app secret: %v", err)
+		}
+	}
+
 	jobQueue = make(chan worker.Job, envDecoder.MaxQueue)
 	d := worker.NewDispatcher(int(envDecoder.MaxWorkers))
 
@@ -228,8 +266,124 @@ func getJob(id string, input map[string]interface{}) worker.Job {
 			return nil
 		}
 
+		return newJob
+	} else if id == "incident-detector" {
+		newJob, err := jobs.NewIncidentDetector(dbConn, time.Now().UTC(), &jobs.IncidentDetectorOpts{
+			DBConf:          &envDecoder.DBConf,
+			DOClientID:      envDecoder.DOClientID,
+			DOClientSecret:  envDecoder.DOClientSecret,
+			DOScopes:        []string{"read", "write"},
+			ServerURL:       envDecoder.ServerURL,
+			GithubAppID:     envDecoder.GithubAppID,
+			GithubAppSecret: githubAppSecret,
+			Input:           input,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: incident-detector. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "registry-retention" {
+		newJob, err := jobs.NewRegistryRetention(dbConn, time.Now().UTC(), &jobs.RegistryRetentionOpts{
+			DBConf:         &envDecoder.DBConf,
+			DOClientID:     envDecoder.DOClientID,
+			DOClientSecret: envDecoder.DOClientSecret,
+			DOScopes:       []string{"read", "write"},
+			ServerURL:      envDecoder.ServerURL,
+			Input:          input,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: registry-retention. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "registry-credential-refresh" {
+		newJob, err := jobs.NewRegistryCredentialRefresh(dbConn, time.Now().UTC(), &jobs.RegistryCredentialRefreshOpts{
+			DBConf:         &envDecoder.DBConf,
+			DOClientID:     envDecoder.DOClientID,
+			DOClientSecret: envDecoder.DOClientSecret,
+			DOScopes:       []string{"read", "write"},
+			ServerURL:      envDecoder.ServerURL,
+			Input:          input,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: registry-credential-refresh. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "soft-delete-purge" {
+		newJob, err := jobs.NewSoftDeletePurge(dbConn, time.Now().UTC(), &jobs.SoftDeletePurgeOpts{
+			DBConf:        &envDecoder.DBConf,
+			RetentionDays: envDecoder.SoftDeleteRetentionDays,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: soft-delete-purge. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "archival" {
+		newJob, err := jobs.NewArchival(dbConn, time.Now().UTC(), &jobs.ArchivalOpts{
+			DBConf:        &envDecoder.DBConf,
+			RetentionDays: envDecoder.ArchiveRetentionDays,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: archival. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "email-digest" {
+		newJob, err := jobs.NewEmailDigest(dbConn, time.Now().UTC(), &jobs.EmailDigestOpts{
+			DBConf:      &envDecoder.DBConf,
+			ServerURL:   envDecoder.ServerURL,
+			EmailSender: getEmailSenderOpts(),
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: email-digest. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "encryption-key-rotation" {
+		newJob, err := jobs.NewEncryptionKeyRotation(dbConn, time.Now().UTC(), &jobs.EncryptionKeyRotationOpts{
+			DBConf: &envDecoder.DBConf,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: encryption-key-rotation. Error: %v", err)
+			return nil
+		}
+
 		return newJob
 	}
 
 	return nil
 }
+
+// getEmailSenderOpts uses the SMTP server if it is configured, and SendGrid otherwise
+func getEmailSenderOpts() *email.SenderOpts {
+	if envDecoder.SMTPHost != "" {
+		return &email.SenderOpts{
+			SenderEmail:  envDecoder.SMTPSenderEmail,
+			SMTPHost:     envDecoder.SMTPHost,
+			SMTPPort:     envDecoder.SMTPPort,
+			SMTPUsername: envDecoder.SMTPUsername,
+			SMTPPassword: envDecoder.SMTPPassword,
+		}
+	}
+
+	return &email.SenderOpts{
+		SenderEmail:    envDecoder.SendgridSenderEmail,
+		SendgridAPIKey: envDecoder.SendgridAPIKey,
+	}
+}